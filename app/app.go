@@ -9,6 +9,7 @@ import (
 
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/badgerdb"
 	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/kaspanet/kaspad/infrastructure/os/execenv"
@@ -22,7 +23,9 @@ import (
 
 const (
 	leveldbCacheSizeMiB = 256
+	badgerCacheSizeMiB  = 256
 	defaultDataDirname  = "datadir2"
+	badgerDataDirname   = "badgerdatadir"
 )
 
 var desiredLimits = &limits.DesiredLimits{
@@ -39,10 +42,22 @@ var serviceDescription = &winservice.ServiceDescription{
 
 type kaspadApp struct {
 	cfg *config.Config
+
+	// networkCfgs are the configurations of the additional networks to run
+	// alongside the main one, see --networkconfigfile
+	networkCfgs []*config.Config
 }
 
 // StartApp starts the kaspad app, and blocks until it finishes running
 func StartApp() error {
+	if len(os.Args) > 1 && os.Args[1] == config.InitCommand {
+		err := config.RunInitCommand(os.Args[2:], os.Stdin, os.Stdout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return err
+	}
+
 	execenv.Initialize(desiredLimits)
 
 	// Load configuration and parse command line. This function also
@@ -55,12 +70,18 @@ func StartApp() error {
 	defer logger.BackendLog.Close()
 	defer panics.HandlePanic(log, "MAIN", nil)
 
-	app := &kaspadApp{cfg: cfg}
+	networkCfgs, err := loadNetworkConfigs(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
 
-	// Call serviceMain on Windows to handle running as a service. When
-	// the return isService flag is true, exit now since we ran as a
+	app := &kaspadApp{cfg: cfg, networkCfgs: networkCfgs}
+
+	// Call serviceMain on Windows and macOS to handle running as a service.
+	// When the return isService flag is true, exit now since we ran as a
 	// service. Otherwise, just fall through to normal operation.
-	if runtime.GOOS == "windows" {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
 		isService, err := winservice.WinServiceMain(app.main, serviceDescription, cfg)
 		if err != nil {
 			return err
@@ -94,8 +115,34 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 		return nil
 	}
 
-	if app.cfg.ResetDatabase {
-		err := removeDatabase(app.cfg)
+	if len(app.networkCfgs) > 0 {
+		supervisor := newNetworkSupervisor(append([]*config.Config{app.cfg}, app.networkCfgs...))
+		return supervisor.run(interrupt, startedChan)
+	}
+	return runNode(app.cfg, interrupt, interrupt, startedChan)
+}
+
+// runNode runs the node of a single network until the given interrupt channel
+// is closed. shutDownChan is closed by the node itself once a shutdown is
+// requested through one of its subsystems, such as the RPC server.
+func runNode(cfg *config.Config, interrupt <-chan struct{}, shutDownChan chan<- struct{},
+	startedChan chan<- struct{}) error {
+
+	err := runPreflightChecks(cfg)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	progress, err := newStartupProgress(cfg)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer progress.finish(false)
+
+	if cfg.ResetDatabase {
+		err := removeDatabase(cfg)
 		if err != nil {
 			log.Error(err)
 			return err
@@ -103,7 +150,8 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 	}
 
 	// Open the database
-	databaseContext, err := openDB(app.cfg)
+	progress.enterStage(startupStageOpeningDatabase)
+	databaseContext, err := openDB(cfg)
 	if err != nil {
 		log.Errorf("Loading database failed: %+v", err)
 		return err
@@ -122,8 +170,15 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 		return nil
 	}
 
+	if cfg.ExportBlocks != "" || cfg.ImportBlocks != "" {
+		return runBlockFileCommand(cfg, databaseContext)
+	}
+	if cfg.ExportUTXOSnapshot != "" || cfg.ImportUTXOSnapshot != "" {
+		return runUTXOSnapshotCommand(cfg, databaseContext)
+	}
+
 	// Create componentManager and start it.
-	componentManager, err := NewComponentManager(app.cfg, databaseContext, interrupt)
+	componentManager, err := newComponentManager(cfg, databaseContext, shutDownChan, DefaultComponentRegistry(), progress)
 	if err != nil {
 		log.Errorf("Unable to start kaspad: %+v", err)
 		return err
@@ -131,24 +186,13 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 
 	defer func() {
 		log.Infof("Gracefully shutting down kaspad...")
-
-		shutdownDone := make(chan struct{})
-		go func() {
-			componentManager.Stop()
-			shutdownDone <- struct{}{}
-		}()
-
-		const shutdownTimeout = 2 * time.Minute
-
-		select {
-		case <-shutdownDone:
-		case <-time.After(shutdownTimeout):
-			log.Criticalf("Graceful shutdown timed out %s. Terminating...", shutdownTimeout)
-		}
+		stopWithTimeout(componentManager.Stop, cfg.ShutdownTimeout)
 		log.Infof("Kaspad shutdown complete")
 	}()
 
+	progress.enterStage(startupStageStartingListeners)
 	componentManager.Start()
+	progress.finish(true)
 
 	if startedChan != nil {
 		startedChan <- struct{}{}
@@ -156,13 +200,55 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 
 	// Wait until the interrupt signal is received from an OS signal or
 	// shutdown is requested through one of the subsystems such as the RPC
-	// server.
-	<-interrupt
-	return nil
+	// server, reloading the configuration whenever SIGHUP is received.
+	reload := signal.ReloadListener()
+	for {
+		select {
+		case <-interrupt:
+			return nil
+		case <-reload:
+			_, _, err := componentManager.ReloadConfig()
+			if err != nil {
+				log.Errorf("Failed reloading the configuration: %s", err)
+			}
+		}
+	}
+}
+
+// exit flushes the log and terminates the process with the given status
+// code. It's a variable so that tests can observe the shutdown timeout
+// without exiting.
+var exit = func(code int) {
+	logger.BackendLog.Close()
+	os.Exit(code)
+}
+
+// stopWithTimeout calls stop, and terminates the process if it doesn't return
+// within the given timeout
+func stopWithTimeout(stop func(), timeout time.Duration) {
+	shutdownDone := make(chan struct{})
+	go func() {
+		stop()
+		close(shutdownDone)
+	}()
+
+	select {
+	case <-shutdownDone:
+	case <-time.After(timeout):
+		// Closing the database while components may still be using it is
+		// not safe, so exit right away to keep the shutdown bounded
+		log.Criticalf("Graceful shutdown timed out %s. Terminating...", timeout)
+		exit(1)
+	}
 }
 
 // dbPath returns the path to the block database given a database type.
 func databasePath(cfg *config.Config) string {
+	// Badger opens a leveldb directory as if it were an empty badger database,
+	// so each of them is kept in a directory of its own
+	if cfg.DbType == config.DbTypeBadger {
+		return filepath.Join(cfg.AppDir, badgerDataDirname)
+	}
 	return filepath.Join(cfg.AppDir, defaultDataDirname)
 }
 
@@ -172,6 +258,11 @@ func removeDatabase(cfg *config.Config) error {
 }
 
 func openDB(cfg *config.Config) (database.Database, error) {
+	if cfg.DbType == config.DbTypeMemory {
+		log.Warnf("Using an in-memory database. Its contents will be lost once kaspad shuts down")
+		return ldb.NewInMemoryLevelDB(leveldbCacheSizeMiB)
+	}
+
 	dbPath := databasePath(cfg)
 
 	err := checkDatabaseVersion(dbPath)
@@ -180,10 +271,24 @@ func openDB(cfg *config.Config) (database.Database, error) {
 	}
 
 	log.Infof("Loading database from '%s'", dbPath)
-	db, err := ldb.NewLevelDB(dbPath, leveldbCacheSizeMiB)
+	var db database.Database
+	if cfg.DbType == config.DbTypeBadger {
+		db, err = badgerdb.NewBadgerDB(dbPath, badgerCacheSizeMiB)
+	} else {
+		db, err = ldb.NewLevelDB(dbPath, leveldbCacheSizeMiB)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedDB, err := applyDatabaseEncryption(cfg, db)
 	if err != nil {
+		closeErr := db.Close()
+		if closeErr != nil {
+			log.Errorf("Failed to close the database: %s", closeErr)
+		}
 		return nil, err
 	}
 
-	return db, nil
+	return encryptedDB, nil
 }
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopWithTimeout(t *testing.T) {
+	originalExit := exit
+	defer func() { exit = originalExit }()
+	var exitCode int
+	var exitTime time.Time
+	exitCount := 0
+	exit = func(code int) {
+		exitCode = code
+		exitTime = time.Now()
+		exitCount++
+	}
+
+	// A shutdown that completes in time doesn't exit
+	stopWithTimeout(func() { time.Sleep(10 * time.Millisecond) }, time.Second)
+	if exitCount != 0 {
+		t.Fatalf("Expected no exit when the shutdown completes in time")
+	}
+
+	// A shutdown that hangs exits only once the timeout elapses
+	const timeout = 200 * time.Millisecond
+	unblock := make(chan struct{})
+	defer close(unblock)
+	start := time.Now()
+	stopWithTimeout(func() { <-unblock }, timeout)
+	if exitCount != 1 {
+		t.Fatalf("Expected a single exit when the shutdown times out, but got %d", exitCount)
+	}
+	if exitCode != 1 {
+		t.Fatalf("Expected exit code 1, but got %d", exitCode)
+	}
+	if elapsed := exitTime.Sub(start); elapsed < timeout {
+		t.Fatalf("Expected the exit to happen after the %s timeout, but it happened after %s", timeout, elapsed)
+	}
+}
@@ -5,6 +5,8 @@ import "time"
 type baseMessage struct {
 	messageNumber uint64
 	receivedAt    time.Time
+	correlationID string
+	warnings      []string
 }
 
 func (b *baseMessage) MessageNumber() uint64 {
@@ -22,3 +24,19 @@ func (b *baseMessage) ReceivedAt() time.Time {
 func (b *baseMessage) SetReceivedAt(receivedAt time.Time) {
 	b.receivedAt = receivedAt
 }
+
+func (b *baseMessage) CorrelationID() string {
+	return b.correlationID
+}
+
+func (b *baseMessage) SetCorrelationID(correlationID string) {
+	b.correlationID = correlationID
+}
+
+func (b *baseMessage) Warnings() []string {
+	return b.warnings
+}
+
+func (b *baseMessage) AddWarning(warning string) {
+	b.warnings = append(b.warnings, warning)
+}
@@ -253,6 +253,19 @@ func RPCUTXOEntryToUTXOEntry(entry *RPCUTXOEntry) (externalapi.UTXOEntry, error)
 	), nil
 }
 
+// UTXOEntryToRPCUTXOEntry converts UTXOEntry to RPCUTXOEntry
+func UTXOEntryToRPCUTXOEntry(entry externalapi.UTXOEntry) *RPCUTXOEntry {
+	return &RPCUTXOEntry{
+		Amount: entry.Amount(),
+		ScriptPublicKey: &RPCScriptPublicKey{
+			Script:  hex.EncodeToString(entry.ScriptPublicKey().Script),
+			Version: entry.ScriptPublicKey().Version,
+		},
+		BlockDAAScore: entry.BlockDAAScore(),
+		IsCoinbase:    entry.IsCoinbase(),
+	}
+}
+
 // DomainTransactionToRPCTransaction converts DomainTransactions to RPCTransactions
 func DomainTransactionToRPCTransaction(transaction *externalapi.DomainTransaction) *RPCTransaction {
 	inputs := make([]*RPCTransactionInput, len(transaction.Inputs))
@@ -376,6 +389,9 @@ func DomainBlockToRPCBlock(block *externalapi.DomainBlock) *RPCBlock {
 
 // RPCBlockToDomainBlock converts `block` into a DomainBlock
 func RPCBlockToDomainBlock(block *RPCBlock) (*externalapi.DomainBlock, error) {
+	if block.Header == nil {
+		return nil, errors.New("block header is missing")
+	}
 	parents := make([]externalapi.BlockLevelParents, len(block.Header.Parents))
 	for i, blockLevelParents := range block.Header.Parents {
 		parents[i] = make(externalapi.BlockLevelParents, len(blockLevelParents.ParentHashes))
@@ -33,8 +33,111 @@ func messageError(f string, desc string) *MessageError {
 	return &MessageError{Func: f, Description: desc}
 }
 
+// RPCErrorCode classifies an RPCError, so that clients can tell errors apart
+// without parsing their messages. Codes are never renumbered or reused.
+type RPCErrorCode uint32
+
+// These constants define the various supported RPC error codes
+const (
+	// RPCErrorCodeUnspecified is the code of errors that were not classified,
+	// such as errors returned by nodes that predate error codes
+	RPCErrorCodeUnspecified RPCErrorCode = 0
+	RPCErrorCodeInternal    RPCErrorCode = 1
+
+	// RPCErrorCodeInvalidParams means the request is malformed, or one of its
+	// fields can't be parsed or is out of range
+	RPCErrorCodeInvalidParams RPCErrorCode = 2
+	RPCErrorCodeNotFound      RPCErrorCode = 3
+	RPCErrorCodeNotSynced     RPCErrorCode = 4
+	RPCErrorCodeRateLimited   RPCErrorCode = 5
+
+	// RPCErrorCodeMethodNotAllowed means the method is forbidden on this
+	// endpoint, or while the node is in safe RPC mode
+	RPCErrorCodeMethodNotAllowed RPCErrorCode = 6
+
+	// RPCErrorCodeMethodUnavailable means the method requires an index or
+	// option the node was run without
+	RPCErrorCodeMethodUnavailable     RPCErrorCode = 7
+	RPCErrorCodeNotImplemented        RPCErrorCode = 8
+	RPCErrorCodeUnsupportedAPIVersion RPCErrorCode = 9
+	RPCErrorCodeIdempotencyKeyReused  RPCErrorCode = 10
+
+	// Block rejection subtypes
+	RPCErrorCodeBlockInvalid        RPCErrorCode = 100
+	RPCErrorCodeBlockDuplicate      RPCErrorCode = 101
+	RPCErrorCodeBlockMissingParents RPCErrorCode = 102
+
+	// RPCErrorCodeBlockTooOld means the block's DAA score is too far behind
+	// the virtual's, so it's rejected unless allowNonDAABlocks is set
+	RPCErrorCodeBlockTooOld RPCErrorCode = 103
+
+	// Transaction rejection subtypes
+	RPCErrorCodeTransactionInvalid RPCErrorCode = 200
+
+	// RPCErrorCodeTransactionDuplicate means the transaction is already in
+	// the mempool, or double spends a transaction that is
+	RPCErrorCodeTransactionDuplicate       RPCErrorCode = 201
+	RPCErrorCodeTransactionNonStandard     RPCErrorCode = 202
+	RPCErrorCodeTransactionInsufficientFee RPCErrorCode = 203
+
+	// RPCErrorCodeTransactionOrphan means the transaction spends unknown
+	// outputs, and either allowOrphan is not set or it can't be kept as an orphan
+	RPCErrorCodeTransactionOrphan        RPCErrorCode = 204
+	RPCErrorCodeTransactionImmatureSpend RPCErrorCode = 205
+	RPCErrorCodeTransactionNotFinal      RPCErrorCode = 206
+
+	// RPCErrorCodeTransactionNonCanonical means the transaction has data pushes
+	// or signatures that aren't canonically encoded, which the node requires
+	// on its network
+	RPCErrorCodeTransactionNonCanonical RPCErrorCode = 207
+)
+
+var rpcErrorCodeStrings = map[RPCErrorCode]string{
+	RPCErrorCodeUnspecified:                "Unspecified",
+	RPCErrorCodeInternal:                   "Internal",
+	RPCErrorCodeInvalidParams:              "InvalidParams",
+	RPCErrorCodeNotFound:                   "NotFound",
+	RPCErrorCodeNotSynced:                  "NotSynced",
+	RPCErrorCodeRateLimited:                "RateLimited",
+	RPCErrorCodeMethodNotAllowed:           "MethodNotAllowed",
+	RPCErrorCodeMethodUnavailable:          "MethodUnavailable",
+	RPCErrorCodeNotImplemented:             "NotImplemented",
+	RPCErrorCodeUnsupportedAPIVersion:      "UnsupportedAPIVersion",
+	RPCErrorCodeIdempotencyKeyReused:       "IdempotencyKeyReused",
+	RPCErrorCodeBlockInvalid:               "BlockInvalid",
+	RPCErrorCodeBlockDuplicate:             "BlockDuplicate",
+	RPCErrorCodeBlockMissingParents:        "BlockMissingParents",
+	RPCErrorCodeBlockTooOld:                "BlockTooOld",
+	RPCErrorCodeTransactionInvalid:         "TransactionInvalid",
+	RPCErrorCodeTransactionDuplicate:       "TransactionDuplicate",
+	RPCErrorCodeTransactionNonStandard:     "TransactionNonStandard",
+	RPCErrorCodeTransactionInsufficientFee: "TransactionInsufficientFee",
+	RPCErrorCodeTransactionOrphan:          "TransactionOrphan",
+	RPCErrorCodeTransactionImmatureSpend:   "TransactionImmatureSpend",
+	RPCErrorCodeTransactionNotFinal:        "TransactionNotFinal",
+	RPCErrorCodeTransactionNonCanonical:    "TransactionNonCanonical",
+}
+
+func (code RPCErrorCode) String() string {
+	if codeString, ok := rpcErrorCodeStrings[code]; ok {
+		return codeString
+	}
+	return fmt.Sprintf("Unknown RPCErrorCode (%d)", uint32(code))
+}
+
+// IsBlockRejection returns whether the code is one of the block rejection subtypes
+func (code RPCErrorCode) IsBlockRejection() bool {
+	return code >= RPCErrorCodeBlockInvalid && code < RPCErrorCodeTransactionInvalid
+}
+
+// IsTransactionRejection returns whether the code is one of the transaction rejection subtypes
+func (code RPCErrorCode) IsTransactionRejection() bool {
+	return code >= RPCErrorCodeTransactionInvalid && code < RPCErrorCodeTransactionInvalid+100
+}
+
 // RPCError represents an error arriving from the RPC
 type RPCError struct {
+	Code    RPCErrorCode
 	Message string
 }
 
@@ -43,9 +146,10 @@ func (err RPCError) Error() string {
 }
 
 // RPCErrorf formats according to a format specifier and returns the string
-// as an RPCError.
-func RPCErrorf(format string, args ...interface{}) *RPCError {
+// as an RPCError with the given code.
+func RPCErrorf(code RPCErrorCode, format string, args ...interface{}) *RPCError {
 	return &RPCError{
+		Code:    code,
 		Message: fmt.Sprintf(format, args...),
 	}
 }
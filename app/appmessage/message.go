@@ -73,6 +73,10 @@ const (
 	CmdRequestIBDChainBlockLocator
 	CmdIBDChainBlockLocator
 	CmdRequestAnticone
+	CmdRequestCompactBlock
+	CmdCompactBlock
+	CmdRequestBlockTransactions
+	CmdBlockTransactions
 
 	// rpc
 	CmdGetCurrentNetworkRequestMessage
@@ -163,6 +167,104 @@ const (
 	CmdGetMempoolEntriesByAddressesResponseMessage
 	CmdGetCoinSupplyRequestMessage
 	CmdGetCoinSupplyResponseMessage
+	CmdGetChainWorkStatusRequestMessage
+	CmdGetChainWorkStatusResponseMessage
+	CmdGetEffectiveConfigRequestMessage
+	CmdGetEffectiveConfigResponseMessage
+	CmdGetTransactionPropagationReportRequestMessage
+	CmdGetTransactionPropagationReportResponseMessage
+	CmdGetReorgedTransactionsStatsRequestMessage
+	CmdGetReorgedTransactionsStatsResponseMessage
+	CmdNotifyTransactionEvictedRequestMessage
+	CmdNotifyTransactionEvictedResponseMessage
+	CmdTransactionEvictedNotificationMessage
+	CmdGetImmatureCoinbaseOutputsRequestMessage
+	CmdGetImmatureCoinbaseOutputsResponseMessage
+	CmdNotifyBlueScoreReachedRequestMessage
+	CmdNotifyBlueScoreReachedResponseMessage
+	CmdBlueScoreReachedNotificationMessage
+	CmdGetChainChangedEventsFromBlockRequestMessage
+	CmdGetChainChangedEventsFromBlockResponseMessage
+	CmdGetOutpointSpendingTransactionRequestMessage
+	CmdGetOutpointSpendingTransactionResponseMessage
+	CmdGetScriptClassStatisticsRequestMessage
+	CmdGetScriptClassStatisticsResponseMessage
+	CmdGetFeeHistoryRequestMessage
+	CmdGetFeeHistoryResponseMessage
+	CmdGetCoinAgeAnalyticsRequestMessage
+	CmdGetCoinAgeAnalyticsResponseMessage
+	CmdGetIndexRetentionStatusRequestMessage
+	CmdGetIndexRetentionStatusResponseMessage
+	CmdNegotiateAPIVersionRequestMessage
+	CmdNegotiateAPIVersionResponseMessage
+	CmdGetRPCSessionsRequestMessage
+	CmdGetRPCSessionsResponseMessage
+	CmdDisconnectRPCSessionRequestMessage
+	CmdDisconnectRPCSessionResponseMessage
+	CmdBlockAddedBatchNotificationMessage
+	CmdDAGSnapshotNotificationMessage
+	CmdGetHeadersSelectedTipRequestMessage
+	CmdGetHeadersSelectedTipResponseMessage
+	CmdSetIndexEnabledRequestMessage
+	CmdSetIndexEnabledResponseMessage
+	CmdGetIndexStatusRequestMessage
+	CmdGetIndexStatusResponseMessage
+	CmdNotifyPeerEventsRequestMessage
+	CmdNotifyPeerEventsResponseMessage
+	CmdPeerEventNotificationMessage
+	CmdGetAddressManagerInfoRequestMessage
+	CmdGetAddressManagerInfoResponseMessage
+	CmdGetPeerFlowStatisticsRequestMessage
+	CmdGetPeerFlowStatisticsResponseMessage
+	CmdGetTransactionRequestMessage
+	CmdGetTransactionResponseMessage
+	CmdGetTransactionsByAddressRequestMessage
+	CmdGetTransactionsByAddressResponseMessage
+	CmdGetRelayPolicyRequestMessage
+	CmdGetRelayPolicyResponseMessage
+	CmdGetNetworkHealthRequestMessage
+	CmdGetNetworkHealthResponseMessage
+	CmdEstimateFeeRequestMessage
+	CmdEstimateFeeResponseMessage
+	CmdRefreshSeedsRequestMessage
+	CmdRefreshSeedsResponseMessage
+	CmdNotifyTransactionRemovedFromMempoolRequestMessage
+	CmdNotifyTransactionRemovedFromMempoolResponseMessage
+	CmdTransactionRemovedFromMempoolNotificationMessage
+	CmdNotifyTransactionConfirmedRequestMessage
+	CmdNotifyTransactionConfirmedResponseMessage
+	CmdTransactionConfirmedNotificationMessage
+	CmdGetUTXORequestMessage
+	CmdGetUTXOResponseMessage
+	CmdGetBlockHeadersRequestMessage
+	CmdGetBlockHeadersResponseMessage
+	CmdGetDAGTipsRequestMessage
+	CmdGetDAGTipsResponseMessage
+	CmdGetBannedPeersRequestMessage
+	CmdGetBannedPeersResponseMessage
+	CmdGetBandwidthInfoRequestMessage
+	CmdGetBandwidthInfoResponseMessage
+	CmdGetPayoutStatsRequestMessage
+	CmdGetPayoutStatsResponseMessage
+	CmdGetBlockRateStatusRequestMessage
+	CmdGetBlockRateStatusResponseMessage
+	CmdExportUTXOSnapshotRequestMessage
+	CmdExportUTXOSnapshotResponseMessage
+	CmdReloadConfigRequestMessage
+	CmdReloadConfigResponseMessage
+	CmdGetMempoolGraphRequestMessage
+	CmdGetMempoolGraphResponseMessage
+	CmdExportMempoolSnapshotRequestMessage
+	CmdExportMempoolSnapshotResponseMessage
+	CmdDeriveAddressFromPublicKeyRequestMessage
+	CmdDeriveAddressFromPublicKeyResponseMessage
+	CmdValidateAddressRequestMessage
+	CmdValidateAddressResponseMessage
+	CmdConvertAddressRequestMessage
+	CmdConvertAddressResponseMessage
+	CmdNotifyTransactionReplacedRequestMessage
+	CmdNotifyTransactionReplacedResponseMessage
+	CmdTransactionReplacedNotificationMessage
 )
 
 // ProtocolMessageCommandToString maps all MessageCommands to their string representation
@@ -210,6 +312,10 @@ var ProtocolMessageCommandToString = map[MessageCommand]string{
 	CmdRequestIBDChainBlockLocator:                 "RequestIBDChainBlockLocator",
 	CmdIBDChainBlockLocator:                        "IBDChainBlockLocator",
 	CmdRequestAnticone:                             "RequestAnticone",
+	CmdRequestCompactBlock:                         "RequestCompactBlock",
+	CmdCompactBlock:                                "CompactBlock",
+	CmdRequestBlockTransactions:                    "RequestBlockTransactions",
+	CmdBlockTransactions:                           "BlockTransactions",
 }
 
 // RPCMessageCommandToString maps all MessageCommands to their string representation
@@ -300,6 +406,104 @@ var RPCMessageCommandToString = map[MessageCommand]string{
 	CmdGetMempoolEntriesByAddressesResponseMessage:                "GetMempoolEntriesByAddressesResponse",
 	CmdGetCoinSupplyRequestMessage:                                "GetCoinSupplyRequest",
 	CmdGetCoinSupplyResponseMessage:                               "GetCoinSupplyResponse",
+	CmdGetChainWorkStatusRequestMessage:                           "GetChainWorkStatusRequest",
+	CmdGetChainWorkStatusResponseMessage:                          "GetChainWorkStatusResponse",
+	CmdGetEffectiveConfigRequestMessage:                           "GetEffectiveConfigRequest",
+	CmdGetEffectiveConfigResponseMessage:                          "GetEffectiveConfigResponse",
+	CmdGetTransactionPropagationReportRequestMessage:              "GetTransactionPropagationReportRequest",
+	CmdGetTransactionPropagationReportResponseMessage:             "GetTransactionPropagationReportResponse",
+	CmdGetReorgedTransactionsStatsRequestMessage:                  "GetReorgedTransactionsStatsRequest",
+	CmdGetReorgedTransactionsStatsResponseMessage:                 "GetReorgedTransactionsStatsResponse",
+	CmdNotifyTransactionEvictedRequestMessage:                     "NotifyTransactionEvictedRequest",
+	CmdNotifyTransactionEvictedResponseMessage:                    "NotifyTransactionEvictedResponse",
+	CmdTransactionEvictedNotificationMessage:                      "TransactionEvictedNotification",
+	CmdGetImmatureCoinbaseOutputsRequestMessage:                   "GetImmatureCoinbaseOutputsRequest",
+	CmdGetImmatureCoinbaseOutputsResponseMessage:                  "GetImmatureCoinbaseOutputsResponse",
+	CmdNotifyBlueScoreReachedRequestMessage:                       "NotifyBlueScoreReachedRequest",
+	CmdNotifyBlueScoreReachedResponseMessage:                      "NotifyBlueScoreReachedResponse",
+	CmdBlueScoreReachedNotificationMessage:                        "BlueScoreReachedNotification",
+	CmdGetChainChangedEventsFromBlockRequestMessage:               "GetChainChangedEventsFromBlockRequest",
+	CmdGetChainChangedEventsFromBlockResponseMessage:              "GetChainChangedEventsFromBlockResponse",
+	CmdGetOutpointSpendingTransactionRequestMessage:               "GetOutpointSpendingTransactionRequest",
+	CmdGetOutpointSpendingTransactionResponseMessage:              "GetOutpointSpendingTransactionResponse",
+	CmdGetScriptClassStatisticsRequestMessage:                     "GetScriptClassStatisticsRequest",
+	CmdGetScriptClassStatisticsResponseMessage:                    "GetScriptClassStatisticsResponse",
+	CmdGetFeeHistoryRequestMessage:                                "GetFeeHistoryRequest",
+	CmdGetFeeHistoryResponseMessage:                               "GetFeeHistoryResponse",
+	CmdGetCoinAgeAnalyticsRequestMessage:                          "GetCoinAgeAnalyticsRequest",
+	CmdGetCoinAgeAnalyticsResponseMessage:                         "GetCoinAgeAnalyticsResponse",
+	CmdGetIndexRetentionStatusRequestMessage:                      "GetIndexRetentionStatusRequest",
+	CmdGetIndexRetentionStatusResponseMessage:                     "GetIndexRetentionStatusResponse",
+	CmdNegotiateAPIVersionRequestMessage:                          "NegotiateAPIVersionRequest",
+	CmdNegotiateAPIVersionResponseMessage:                         "NegotiateAPIVersionResponse",
+	CmdGetRPCSessionsRequestMessage:                               "GetRPCSessionsRequest",
+	CmdGetRPCSessionsResponseMessage:                              "GetRPCSessionsResponse",
+	CmdDisconnectRPCSessionRequestMessage:                         "DisconnectRPCSessionRequest",
+	CmdDisconnectRPCSessionResponseMessage:                        "DisconnectRPCSessionResponse",
+	CmdBlockAddedBatchNotificationMessage:                         "BlockAddedBatchNotification",
+	CmdDAGSnapshotNotificationMessage:                             "DAGSnapshotNotification",
+	CmdGetHeadersSelectedTipRequestMessage:                        "GetHeadersSelectedTipRequest",
+	CmdGetHeadersSelectedTipResponseMessage:                       "GetHeadersSelectedTipResponse",
+	CmdSetIndexEnabledRequestMessage:                              "SetIndexEnabledRequest",
+	CmdSetIndexEnabledResponseMessage:                             "SetIndexEnabledResponse",
+	CmdGetIndexStatusRequestMessage:                               "GetIndexStatusRequest",
+	CmdGetIndexStatusResponseMessage:                              "GetIndexStatusResponse",
+	CmdNotifyPeerEventsRequestMessage:                             "NotifyPeerEventsRequest",
+	CmdNotifyPeerEventsResponseMessage:                            "NotifyPeerEventsResponse",
+	CmdPeerEventNotificationMessage:                               "PeerEventNotification",
+	CmdGetAddressManagerInfoRequestMessage:                        "GetAddressManagerInfoRequest",
+	CmdGetAddressManagerInfoResponseMessage:                       "GetAddressManagerInfoResponse",
+	CmdGetPeerFlowStatisticsRequestMessage:                        "GetPeerFlowStatisticsRequest",
+	CmdGetPeerFlowStatisticsResponseMessage:                       "GetPeerFlowStatisticsResponse",
+	CmdGetTransactionRequestMessage:                               "GetTransactionRequest",
+	CmdGetTransactionResponseMessage:                              "GetTransactionResponse",
+	CmdGetTransactionsByAddressRequestMessage:                     "GetTransactionsByAddressRequest",
+	CmdGetTransactionsByAddressResponseMessage:                    "GetTransactionsByAddressResponse",
+	CmdGetRelayPolicyRequestMessage:                               "GetRelayPolicyRequest",
+	CmdGetRelayPolicyResponseMessage:                              "GetRelayPolicyResponse",
+	CmdGetNetworkHealthRequestMessage:                             "GetNetworkHealthRequest",
+	CmdGetNetworkHealthResponseMessage:                            "GetNetworkHealthResponse",
+	CmdEstimateFeeRequestMessage:                                  "EstimateFeeRequest",
+	CmdEstimateFeeResponseMessage:                                 "EstimateFeeResponse",
+	CmdRefreshSeedsRequestMessage:                                 "RefreshSeedsRequest",
+	CmdRefreshSeedsResponseMessage:                                "RefreshSeedsResponse",
+	CmdNotifyTransactionRemovedFromMempoolRequestMessage:          "NotifyTransactionRemovedFromMempoolRequest",
+	CmdNotifyTransactionRemovedFromMempoolResponseMessage:         "NotifyTransactionRemovedFromMempoolResponse",
+	CmdTransactionRemovedFromMempoolNotificationMessage:           "TransactionRemovedFromMempoolNotification",
+	CmdNotifyTransactionConfirmedRequestMessage:                   "NotifyTransactionConfirmedRequest",
+	CmdNotifyTransactionConfirmedResponseMessage:                  "NotifyTransactionConfirmedResponse",
+	CmdTransactionConfirmedNotificationMessage:                    "TransactionConfirmedNotification",
+	CmdGetUTXORequestMessage:                                      "GetUTXORequest",
+	CmdGetUTXOResponseMessage:                                     "GetUTXOResponse",
+	CmdGetBlockHeadersRequestMessage:                              "GetBlockHeadersRequest",
+	CmdGetBlockHeadersResponseMessage:                             "GetBlockHeadersResponse",
+	CmdGetDAGTipsRequestMessage:                                   "GetDAGTipsRequest",
+	CmdGetDAGTipsResponseMessage:                                  "GetDAGTipsResponse",
+	CmdGetBannedPeersRequestMessage:                               "GetBannedPeersRequest",
+	CmdGetBannedPeersResponseMessage:                              "GetBannedPeersResponse",
+	CmdGetBandwidthInfoRequestMessage:                             "GetBandwidthInfoRequest",
+	CmdGetBandwidthInfoResponseMessage:                            "GetBandwidthInfoResponse",
+	CmdGetPayoutStatsRequestMessage:                               "GetPayoutStatsRequest",
+	CmdGetPayoutStatsResponseMessage:                              "GetPayoutStatsResponse",
+	CmdGetBlockRateStatusRequestMessage:                           "GetBlockRateStatusRequest",
+	CmdGetBlockRateStatusResponseMessage:                          "GetBlockRateStatusResponse",
+	CmdExportUTXOSnapshotRequestMessage:                           "ExportUTXOSnapshotRequest",
+	CmdExportUTXOSnapshotResponseMessage:                          "ExportUTXOSnapshotResponse",
+	CmdReloadConfigRequestMessage:                                 "ReloadConfigRequest",
+	CmdReloadConfigResponseMessage:                                "ReloadConfigResponse",
+	CmdGetMempoolGraphRequestMessage:                              "GetMempoolGraphRequest",
+	CmdGetMempoolGraphResponseMessage:                             "GetMempoolGraphResponse",
+	CmdExportMempoolSnapshotRequestMessage:                        "ExportMempoolSnapshotRequest",
+	CmdExportMempoolSnapshotResponseMessage:                       "ExportMempoolSnapshotResponse",
+	CmdDeriveAddressFromPublicKeyRequestMessage:                   "DeriveAddressFromPublicKeyRequest",
+	CmdDeriveAddressFromPublicKeyResponseMessage:                  "DeriveAddressFromPublicKeyResponse",
+	CmdValidateAddressRequestMessage:                              "ValidateAddressRequest",
+	CmdValidateAddressResponseMessage:                             "ValidateAddressResponse",
+	CmdConvertAddressRequestMessage:                               "ConvertAddressRequest",
+	CmdConvertAddressResponseMessage:                              "ConvertAddressResponse",
+	CmdNotifyTransactionReplacedRequestMessage:                    "NotifyTransactionReplacedRequest",
+	CmdNotifyTransactionReplacedResponseMessage:                   "NotifyTransactionReplacedResponse",
+	CmdTransactionReplacedNotificationMessage:                     "TransactionReplacedNotification",
 }
 
 // Message is an interface that describes a kaspa message. A type that
@@ -312,4 +516,14 @@ type Message interface {
 	SetMessageNumber(index uint64)
 	ReceivedAt() time.Time
 	SetReceivedAt(receivedAt time.Time)
+
+	// CorrelationID identifies an RPC request in the logs of the actions
+	// it triggered. It is never sent over the wire.
+	CorrelationID() string
+	SetCorrelationID(correlationID string)
+
+	// Warnings are sent along with an RPC response to notify the client of
+	// issues with its request that didn't fail it, such as a deprecated method
+	Warnings() []string
+	AddWarning(warning string)
 }
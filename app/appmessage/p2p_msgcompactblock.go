@@ -0,0 +1,41 @@
+package appmessage
+
+// PrefilledTransaction is a transaction that is sent in full as part of a
+// MsgCompactBlock, alongside its index within the block
+type PrefilledTransaction struct {
+	Index uint32
+	Tx    *MsgTx
+}
+
+// MsgCompactBlock implements the Message interface and represents a kaspa
+// CompactBlock message. It carries the header of a block and short IDs of
+// its transactions, out of which the receiver rebuilds the block from its
+// own mempool. Transactions that the receiver can't possibly have, such as
+// the coinbase, are sent in full as PrefilledTransactions.
+//
+// The short IDs are ordered by the block order of the transactions, skipping
+// the indexes of the prefilled transactions.
+type MsgCompactBlock struct {
+	baseMessage
+	Header                MsgBlockHeader
+	ShortIDs              []uint64
+	PrefilledTransactions []*PrefilledTransaction
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgCompactBlock) Command() MessageCommand {
+	return CmdCompactBlock
+}
+
+// NewMsgCompactBlock returns a new kaspa CompactBlock message that conforms to
+// the Message interface. See MsgCompactBlock for details.
+func NewMsgCompactBlock(header *MsgBlockHeader, shortIDs []uint64,
+	prefilledTransactions []*PrefilledTransaction) *MsgCompactBlock {
+
+	return &MsgCompactBlock{
+		Header:                *header,
+		ShortIDs:              shortIDs,
+		PrefilledTransactions: prefilledTransactions,
+	}
+}
@@ -58,6 +58,11 @@ type MsgVersion struct {
 
 	// The subnetwork of the generator of the version message. Should be nil in full nodes
 	SubnetworkID *externalapi.DomainSubnetworkID
+
+	// Address of the remote peer, as seen by the local peer. The remote peer
+	// uses it to discover its external address. It's nil if the local peer
+	// doesn't report it.
+	ReceiverAddress *NetAddress
 }
 
 // HasService returns whether the specified service is supported by the peer
@@ -22,6 +22,11 @@ type NetAddress struct {
 	// Port the peer is using. This is encoded in big endian on the appmessage
 	// which differs from most everything else.
 	Port uint16
+
+	// Services the address is advertised with. It's zero if they're
+	// unknown, in which case the services the peer reports in its version
+	// message apply.
+	Services ServiceFlag
 }
 
 // TCPAddress converts the NetAddress to *net.TCPAddr
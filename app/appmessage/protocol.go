@@ -41,16 +41,21 @@ const (
 	// SFNodeCF is a flag used to indicate a peer supports committed
 	// filters (CFs).
 	SFNodeCF
+
+	// SFNodeCompactBlocks is a flag used to indicate a peer is able to
+	// serve blocks as compact blocks.
+	SFNodeCompactBlocks
 )
 
 // Map of service flags back to their constant names for pretty printing.
 var sfStrings = map[ServiceFlag]string{
-	SFNodeNetwork: "SFNodeNetwork",
-	SFNodeGetUTXO: "SFNodeGetUTXO",
-	SFNodeBloom:   "SFNodeBloom",
-	SFNodeXthin:   "SFNodeXthin",
-	SFNodeBit5:    "SFNodeBit5",
-	SFNodeCF:      "SFNodeCF",
+	SFNodeNetwork:       "SFNodeNetwork",
+	SFNodeGetUTXO:       "SFNodeGetUTXO",
+	SFNodeBloom:         "SFNodeBloom",
+	SFNodeXthin:         "SFNodeXthin",
+	SFNodeBit5:          "SFNodeBit5",
+	SFNodeCF:            "SFNodeCF",
+	SFNodeCompactBlocks: "SFNodeCompactBlocks",
 }
 
 // orderedSFStrings is an ordered list of service flags from highest to
@@ -62,6 +67,7 @@ var orderedSFStrings = []ServiceFlag{
 	SFNodeXthin,
 	SFNodeBit5,
 	SFNodeCF,
+	SFNodeCompactBlocks,
 }
 
 // String returns the ServiceFlag in human-readable form.
@@ -89,6 +95,33 @@ func (f ServiceFlag) String() string {
 	return s
 }
 
+// ParseServiceFlags parses service flags in the form returned by
+// ServiceFlag.String, that is flag names separated by '|', any of which
+// may be given as a number instead
+func ParseServiceFlags(s string) (ServiceFlag, error) {
+	var flags ServiceFlag
+	for _, part := range strings.Split(s, "|") {
+		part = strings.TrimSpace(part)
+		found := false
+		for flag, name := range sfStrings {
+			if strings.EqualFold(part, name) {
+				flags |= flag
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		flag, err := strconv.ParseUint(part, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unknown service flag %q", part)
+		}
+		flags |= ServiceFlag(flag)
+	}
+	return flags, nil
+}
+
 // KaspaNet represents which kaspa network a message belongs to.
 type KaspaNet uint32
 
@@ -19,7 +19,8 @@ func TestServiceFlagStringer(t *testing.T) {
 		{SFNodeXthin, "SFNodeXthin"},
 		{SFNodeBit5, "SFNodeBit5"},
 		{SFNodeCF, "SFNodeCF"},
-		{0xffffffff, "SFNodeNetwork|SFNodeGetUTXO|SFNodeBloom|SFNodeXthin|SFNodeBit5|SFNodeCF|0xffffffc0"},
+		{SFNodeCompactBlocks, "SFNodeCompactBlocks"},
+		{0xffffffff, "SFNodeNetwork|SFNodeGetUTXO|SFNodeBloom|SFNodeXthin|SFNodeBit5|SFNodeCF|SFNodeCompactBlocks|0xffffff80"},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -33,6 +34,27 @@ func TestServiceFlagStringer(t *testing.T) {
 	}
 }
 
+// TestParseServiceFlags tests parsing service flags in their stringized form.
+func TestParseServiceFlags(t *testing.T) {
+	tests := []ServiceFlag{0, SFNodeNetwork, SFNodeNetwork | SFNodeCF, 0xffffffff}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		result, err := ParseServiceFlags(test.String())
+		if err != nil {
+			t.Errorf("ParseServiceFlags #%d: %s", i, err)
+			continue
+		}
+		if result != test {
+			t.Errorf("ParseServiceFlags #%d\n got: %s want: %s", i, result, test)
+		}
+	}
+
+	if _, err := ParseServiceFlags("SFNodeUnknown"); err == nil {
+		t.Errorf("ParseServiceFlags: expected an unknown flag to be rejected")
+	}
+}
+
 // TestKaspaNetStringer tests the stringized output for kaspa net types.
 func TestKaspaNetStringer(t *testing.T) {
 	tests := []struct {
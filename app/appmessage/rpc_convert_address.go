@@ -0,0 +1,49 @@
+package appmessage
+
+// ConvertAddressRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ConvertAddressRequestMessage struct {
+	baseMessage
+	Address         string
+	ScriptPublicKey *RPCScriptPublicKey
+}
+
+// Command returns the protocol command string for the message
+func (msg *ConvertAddressRequestMessage) Command() MessageCommand {
+	return CmdConvertAddressRequestMessage
+}
+
+// NewConvertAddressRequestMessage returns a instance of the message
+func NewConvertAddressRequestMessage(address string, scriptPublicKey *RPCScriptPublicKey) *ConvertAddressRequestMessage {
+	return &ConvertAddressRequestMessage{
+		Address:         address,
+		ScriptPublicKey: scriptPublicKey,
+	}
+}
+
+// ConvertAddressResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ConvertAddressResponseMessage struct {
+	baseMessage
+	Address         string
+	ScriptPublicKey *RPCScriptPublicKey
+	AddressType     string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ConvertAddressResponseMessage) Command() MessageCommand {
+	return CmdConvertAddressResponseMessage
+}
+
+// NewConvertAddressResponseMessage returns a instance of the message
+func NewConvertAddressResponseMessage(address string, scriptPublicKey *RPCScriptPublicKey,
+	addressType string) *ConvertAddressResponseMessage {
+
+	return &ConvertAddressResponseMessage{
+		Address:         address,
+		ScriptPublicKey: scriptPublicKey,
+		AddressType:     addressType,
+	}
+}
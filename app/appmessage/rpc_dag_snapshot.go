@@ -0,0 +1,28 @@
+package appmessage
+
+// DAGSnapshotNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type DAGSnapshotNotificationMessage struct {
+	baseMessage
+	TipHashes                      []string
+	VirtualSelectedParentHash      string
+	VirtualSelectedParentBlueScore uint64
+	VirtualDAAScore                uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *DAGSnapshotNotificationMessage) Command() MessageCommand {
+	return CmdDAGSnapshotNotificationMessage
+}
+
+// NewDAGSnapshotNotificationMessage returns a instance of the message
+func NewDAGSnapshotNotificationMessage(tipHashes []string, virtualSelectedParentHash string,
+	virtualSelectedParentBlueScore uint64, virtualDAAScore uint64) *DAGSnapshotNotificationMessage {
+
+	return &DAGSnapshotNotificationMessage{
+		TipHashes:                      tipHashes,
+		VirtualSelectedParentHash:      virtualSelectedParentHash,
+		VirtualSelectedParentBlueScore: virtualSelectedParentBlueScore,
+		VirtualDAAScore:                virtualDAAScore,
+	}
+}
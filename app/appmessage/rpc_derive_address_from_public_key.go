@@ -0,0 +1,43 @@
+package appmessage
+
+// DeriveAddressFromPublicKeyRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type DeriveAddressFromPublicKeyRequestMessage struct {
+	baseMessage
+	PublicKey string
+}
+
+// Command returns the protocol command string for the message
+func (msg *DeriveAddressFromPublicKeyRequestMessage) Command() MessageCommand {
+	return CmdDeriveAddressFromPublicKeyRequestMessage
+}
+
+// NewDeriveAddressFromPublicKeyRequestMessage returns a instance of the message
+func NewDeriveAddressFromPublicKeyRequestMessage(publicKey string) *DeriveAddressFromPublicKeyRequestMessage {
+	return &DeriveAddressFromPublicKeyRequestMessage{
+		PublicKey: publicKey,
+	}
+}
+
+// DeriveAddressFromPublicKeyResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type DeriveAddressFromPublicKeyResponseMessage struct {
+	baseMessage
+	Address     string
+	AddressType string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *DeriveAddressFromPublicKeyResponseMessage) Command() MessageCommand {
+	return CmdDeriveAddressFromPublicKeyResponseMessage
+}
+
+// NewDeriveAddressFromPublicKeyResponseMessage returns a instance of the message
+func NewDeriveAddressFromPublicKeyResponseMessage(address string, addressType string) *DeriveAddressFromPublicKeyResponseMessage {
+	return &DeriveAddressFromPublicKeyResponseMessage{
+		Address:     address,
+		AddressType: addressType,
+	}
+}
@@ -0,0 +1,38 @@
+package appmessage
+
+// DisconnectRPCSessionRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type DisconnectRPCSessionRequestMessage struct {
+	baseMessage
+	SessionID uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *DisconnectRPCSessionRequestMessage) Command() MessageCommand {
+	return CmdDisconnectRPCSessionRequestMessage
+}
+
+// NewDisconnectRPCSessionRequestMessage returns a instance of the message
+func NewDisconnectRPCSessionRequestMessage(sessionID uint64) *DisconnectRPCSessionRequestMessage {
+	return &DisconnectRPCSessionRequestMessage{
+		SessionID: sessionID,
+	}
+}
+
+// DisconnectRPCSessionResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type DisconnectRPCSessionResponseMessage struct {
+	baseMessage
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *DisconnectRPCSessionResponseMessage) Command() MessageCommand {
+	return CmdDisconnectRPCSessionResponseMessage
+}
+
+// NewDisconnectRPCSessionResponseMessage returns a instance of the message
+func NewDisconnectRPCSessionResponseMessage() *DisconnectRPCSessionResponseMessage {
+	return &DisconnectRPCSessionResponseMessage{}
+}
@@ -0,0 +1,123 @@
+package appmessage
+
+// rpcErrorResponses maps every RPC request to a constructor of its respective
+// response, carrying only an error
+var rpcErrorResponses = map[MessageCommand]func(rpcError *RPCError) Message{
+	CmdGetCurrentNetworkRequestMessage:    func(rpcError *RPCError) Message { return &GetCurrentNetworkResponseMessage{Error: rpcError} },
+	CmdSubmitBlockRequestMessage:          func(rpcError *RPCError) Message { return &SubmitBlockResponseMessage{Error: rpcError} },
+	CmdGetBlockTemplateRequestMessage:     func(rpcError *RPCError) Message { return &GetBlockTemplateResponseMessage{Error: rpcError} },
+	CmdNotifyBlockAddedRequestMessage:     func(rpcError *RPCError) Message { return &NotifyBlockAddedResponseMessage{Error: rpcError} },
+	CmdGetPeerAddressesRequestMessage:     func(rpcError *RPCError) Message { return &GetPeerAddressesResponseMessage{Error: rpcError} },
+	CmdGetSelectedTipHashRequestMessage:   func(rpcError *RPCError) Message { return &GetSelectedTipHashResponseMessage{Error: rpcError} },
+	CmdGetMempoolEntryRequestMessage:      func(rpcError *RPCError) Message { return &GetMempoolEntryResponseMessage{Error: rpcError} },
+	CmdGetConnectedPeerInfoRequestMessage: func(rpcError *RPCError) Message { return &GetConnectedPeerInfoResponseMessage{Error: rpcError} },
+	CmdAddPeerRequestMessage:              func(rpcError *RPCError) Message { return &AddPeerResponseMessage{Error: rpcError} },
+	CmdSubmitTransactionRequestMessage:    func(rpcError *RPCError) Message { return &SubmitTransactionResponseMessage{Error: rpcError} },
+	CmdNotifyVirtualSelectedParentChainChangedRequestMessage: func(rpcError *RPCError) Message {
+		return &NotifyVirtualSelectedParentChainChangedResponseMessage{Error: rpcError}
+	},
+	CmdGetBlockRequestMessage:      func(rpcError *RPCError) Message { return &GetBlockResponseMessage{Error: rpcError} },
+	CmdGetSubnetworkRequestMessage: func(rpcError *RPCError) Message { return &GetSubnetworkResponseMessage{Error: rpcError} },
+	CmdGetVirtualSelectedParentChainFromBlockRequestMessage: func(rpcError *RPCError) Message {
+		return &GetVirtualSelectedParentChainFromBlockResponseMessage{Error: rpcError}
+	},
+	CmdGetBlocksRequestMessage:                 func(rpcError *RPCError) Message { return &GetBlocksResponseMessage{Error: rpcError} },
+	CmdGetBlockCountRequestMessage:             func(rpcError *RPCError) Message { return &GetBlockCountResponseMessage{Error: rpcError} },
+	CmdGetBalanceByAddressRequestMessage:       func(rpcError *RPCError) Message { return &GetBalanceByAddressResponseMessage{Error: rpcError} },
+	CmdGetBlockDAGInfoRequestMessage:           func(rpcError *RPCError) Message { return &GetBlockDAGInfoResponseMessage{Error: rpcError} },
+	CmdResolveFinalityConflictRequestMessage:   func(rpcError *RPCError) Message { return &ResolveFinalityConflictResponseMessage{Error: rpcError} },
+	CmdNotifyFinalityConflictsRequestMessage:   func(rpcError *RPCError) Message { return &NotifyFinalityConflictsResponseMessage{Error: rpcError} },
+	CmdGetMempoolEntriesRequestMessage:         func(rpcError *RPCError) Message { return &GetMempoolEntriesResponseMessage{Error: rpcError} },
+	CmdShutDownRequestMessage:                  func(rpcError *RPCError) Message { return &ShutDownResponseMessage{Error: rpcError} },
+	CmdGetHeadersRequestMessage:                func(rpcError *RPCError) Message { return &GetHeadersResponseMessage{Error: rpcError} },
+	CmdNotifyUTXOsChangedRequestMessage:        func(rpcError *RPCError) Message { return &NotifyUTXOsChangedResponseMessage{Error: rpcError} },
+	CmdStopNotifyingUTXOsChangedRequestMessage: func(rpcError *RPCError) Message { return &StopNotifyingUTXOsChangedResponseMessage{Error: rpcError} },
+	CmdGetUTXOsByAddressesRequestMessage:       func(rpcError *RPCError) Message { return &GetUTXOsByAddressesResponseMessage{Error: rpcError} },
+	CmdGetBalancesByAddressesRequestMessage:    func(rpcError *RPCError) Message { return &GetBalancesByAddressesResponseMessage{Error: rpcError} },
+	CmdGetVirtualSelectedParentBlueScoreRequestMessage: func(rpcError *RPCError) Message {
+		return &GetVirtualSelectedParentBlueScoreResponseMessage{Error: rpcError}
+	},
+	CmdNotifyVirtualSelectedParentBlueScoreChangedRequestMessage: func(rpcError *RPCError) Message {
+		return &NotifyVirtualSelectedParentBlueScoreChangedResponseMessage{Error: rpcError}
+	},
+	CmdBanRequestMessage:     func(rpcError *RPCError) Message { return &BanResponseMessage{Error: rpcError} },
+	CmdUnbanRequestMessage:   func(rpcError *RPCError) Message { return &UnbanResponseMessage{Error: rpcError} },
+	CmdGetInfoRequestMessage: func(rpcError *RPCError) Message { return &GetInfoResponseMessage{Error: rpcError} },
+	CmdNotifyPruningPointUTXOSetOverrideRequestMessage: func(rpcError *RPCError) Message {
+		return &NotifyPruningPointUTXOSetOverrideResponseMessage{Error: rpcError}
+	},
+	CmdStopNotifyingPruningPointUTXOSetOverrideRequestMessage: func(rpcError *RPCError) Message {
+		return &StopNotifyingPruningPointUTXOSetOverrideResponseMessage{Error: rpcError}
+	},
+	CmdEstimateNetworkHashesPerSecondRequestMessage: func(rpcError *RPCError) Message {
+		return &EstimateNetworkHashesPerSecondResponseMessage{Error: rpcError}
+	},
+	CmdNotifyVirtualDaaScoreChangedRequestMessage: func(rpcError *RPCError) Message { return &NotifyVirtualDaaScoreChangedResponseMessage{Error: rpcError} },
+	CmdNotifyNewBlockTemplateRequestMessage:       func(rpcError *RPCError) Message { return &NotifyNewBlockTemplateResponseMessage{Error: rpcError} },
+	CmdGetCoinSupplyRequestMessage:                func(rpcError *RPCError) Message { return &GetCoinSupplyResponseMessage{Error: rpcError} },
+	CmdGetMempoolEntriesByAddressesRequestMessage: func(rpcError *RPCError) Message { return &GetMempoolEntriesByAddressesResponseMessage{Error: rpcError} },
+	CmdGetChainWorkStatusRequestMessage:           func(rpcError *RPCError) Message { return &GetChainWorkStatusResponseMessage{Error: rpcError} },
+	CmdGetEffectiveConfigRequestMessage:           func(rpcError *RPCError) Message { return &GetEffectiveConfigResponseMessage{Error: rpcError} },
+	CmdGetTransactionPropagationReportRequestMessage: func(rpcError *RPCError) Message {
+		return &GetTransactionPropagationReportResponseMessage{Error: rpcError}
+	},
+	CmdGetReorgedTransactionsStatsRequestMessage: func(rpcError *RPCError) Message { return &GetReorgedTransactionsStatsResponseMessage{Error: rpcError} },
+	CmdNotifyTransactionEvictedRequestMessage:    func(rpcError *RPCError) Message { return &NotifyTransactionEvictedResponseMessage{Error: rpcError} },
+	CmdGetImmatureCoinbaseOutputsRequestMessage:  func(rpcError *RPCError) Message { return &GetImmatureCoinbaseOutputsResponseMessage{Error: rpcError} },
+	CmdNotifyBlueScoreReachedRequestMessage:      func(rpcError *RPCError) Message { return &NotifyBlueScoreReachedResponseMessage{Error: rpcError} },
+	CmdGetChainChangedEventsFromBlockRequestMessage: func(rpcError *RPCError) Message {
+		return &GetChainChangedEventsFromBlockResponseMessage{Error: rpcError}
+	},
+	CmdGetOutpointSpendingTransactionRequestMessage: func(rpcError *RPCError) Message {
+		return &GetOutpointSpendingTransactionResponseMessage{Error: rpcError}
+	},
+	CmdGetScriptClassStatisticsRequestMessage: func(rpcError *RPCError) Message { return &GetScriptClassStatisticsResponseMessage{Error: rpcError} },
+	CmdGetFeeHistoryRequestMessage:            func(rpcError *RPCError) Message { return &GetFeeHistoryResponseMessage{Error: rpcError} },
+	CmdGetCoinAgeAnalyticsRequestMessage:      func(rpcError *RPCError) Message { return &GetCoinAgeAnalyticsResponseMessage{Error: rpcError} },
+	CmdGetIndexRetentionStatusRequestMessage:  func(rpcError *RPCError) Message { return &GetIndexRetentionStatusResponseMessage{Error: rpcError} },
+	CmdNegotiateAPIVersionRequestMessage:      func(rpcError *RPCError) Message { return &NegotiateAPIVersionResponseMessage{Error: rpcError} },
+	CmdGetRPCSessionsRequestMessage:           func(rpcError *RPCError) Message { return &GetRPCSessionsResponseMessage{Error: rpcError} },
+	CmdDisconnectRPCSessionRequestMessage:     func(rpcError *RPCError) Message { return &DisconnectRPCSessionResponseMessage{Error: rpcError} },
+	CmdGetHeadersSelectedTipRequestMessage:    func(rpcError *RPCError) Message { return &GetHeadersSelectedTipResponseMessage{Error: rpcError} },
+	CmdSetIndexEnabledRequestMessage:          func(rpcError *RPCError) Message { return &SetIndexEnabledResponseMessage{Error: rpcError} },
+	CmdGetIndexStatusRequestMessage:           func(rpcError *RPCError) Message { return &GetIndexStatusResponseMessage{Error: rpcError} },
+	CmdNotifyPeerEventsRequestMessage:         func(rpcError *RPCError) Message { return &NotifyPeerEventsResponseMessage{Error: rpcError} },
+	CmdGetAddressManagerInfoRequestMessage:    func(rpcError *RPCError) Message { return &GetAddressManagerInfoResponseMessage{Error: rpcError} },
+	CmdGetPeerFlowStatisticsRequestMessage:    func(rpcError *RPCError) Message { return &GetPeerFlowStatisticsResponseMessage{Error: rpcError} },
+	CmdGetTransactionRequestMessage:           func(rpcError *RPCError) Message { return &GetTransactionResponseMessage{Error: rpcError} },
+	CmdGetTransactionsByAddressRequestMessage: func(rpcError *RPCError) Message { return &GetTransactionsByAddressResponseMessage{Error: rpcError} },
+	CmdGetRelayPolicyRequestMessage:           func(rpcError *RPCError) Message { return &GetRelayPolicyResponseMessage{Error: rpcError} },
+	CmdGetNetworkHealthRequestMessage:         func(rpcError *RPCError) Message { return &GetNetworkHealthResponseMessage{Error: rpcError} },
+	CmdEstimateFeeRequestMessage:              func(rpcError *RPCError) Message { return &EstimateFeeResponseMessage{Error: rpcError} },
+	CmdRefreshSeedsRequestMessage:             func(rpcError *RPCError) Message { return &RefreshSeedsResponseMessage{Error: rpcError} },
+	CmdNotifyTransactionRemovedFromMempoolRequestMessage: func(rpcError *RPCError) Message {
+		return &NotifyTransactionRemovedFromMempoolResponseMessage{Error: rpcError}
+	},
+	CmdNotifyTransactionConfirmedRequestMessage: func(rpcError *RPCError) Message { return &NotifyTransactionConfirmedResponseMessage{Error: rpcError} },
+	CmdGetUTXORequestMessage:                    func(rpcError *RPCError) Message { return &GetUTXOResponseMessage{Error: rpcError} },
+	CmdGetBlockHeadersRequestMessage:            func(rpcError *RPCError) Message { return &GetBlockHeadersResponseMessage{Error: rpcError} },
+	CmdGetDAGTipsRequestMessage:                 func(rpcError *RPCError) Message { return &GetDAGTipsResponseMessage{Error: rpcError} },
+	CmdGetBannedPeersRequestMessage:             func(rpcError *RPCError) Message { return &GetBannedPeersResponseMessage{Error: rpcError} },
+	CmdGetBandwidthInfoRequestMessage:           func(rpcError *RPCError) Message { return &GetBandwidthInfoResponseMessage{Error: rpcError} },
+	CmdGetPayoutStatsRequestMessage:             func(rpcError *RPCError) Message { return &GetPayoutStatsResponseMessage{Error: rpcError} },
+	CmdGetBlockRateStatusRequestMessage:         func(rpcError *RPCError) Message { return &GetBlockRateStatusResponseMessage{Error: rpcError} },
+	CmdExportUTXOSnapshotRequestMessage:         func(rpcError *RPCError) Message { return &ExportUTXOSnapshotResponseMessage{Error: rpcError} },
+	CmdReloadConfigRequestMessage:               func(rpcError *RPCError) Message { return &ReloadConfigResponseMessage{Error: rpcError} },
+	CmdGetMempoolGraphRequestMessage:            func(rpcError *RPCError) Message { return &GetMempoolGraphResponseMessage{Error: rpcError} },
+	CmdExportMempoolSnapshotRequestMessage:      func(rpcError *RPCError) Message { return &ExportMempoolSnapshotResponseMessage{Error: rpcError} },
+	CmdDeriveAddressFromPublicKeyRequestMessage: func(rpcError *RPCError) Message { return &DeriveAddressFromPublicKeyResponseMessage{Error: rpcError} },
+	CmdValidateAddressRequestMessage:            func(rpcError *RPCError) Message { return &ValidateAddressResponseMessage{Error: rpcError} },
+	CmdConvertAddressRequestMessage:             func(rpcError *RPCError) Message { return &ConvertAddressResponseMessage{Error: rpcError} },
+	CmdNotifyTransactionReplacedRequestMessage:  func(rpcError *RPCError) Message { return &NotifyTransactionReplacedResponseMessage{Error: rpcError} },
+}
+
+// NewRPCErrorResponseMessage returns the response to the given RPC request
+// command, carrying only the given error. This is used to reject a request
+// without handling it. It returns false if the request command is unknown.
+func NewRPCErrorResponseMessage(requestCommand MessageCommand, rpcError *RPCError) (Message, bool) {
+	newErrorResponse, ok := rpcErrorResponses[requestCommand]
+	if !ok {
+		return nil, false
+	}
+	return newErrorResponse(rpcError), true
+}
@@ -0,0 +1,45 @@
+package appmessage
+
+// EstimateFeeRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type EstimateFeeRequestMessage struct {
+	baseMessage
+	TargetDAAScore uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *EstimateFeeRequestMessage) Command() MessageCommand {
+	return CmdEstimateFeeRequestMessage
+}
+
+// NewEstimateFeeRequestMessage returns a instance of the message
+func NewEstimateFeeRequestMessage(targetDAAScore uint64) *EstimateFeeRequestMessage {
+	return &EstimateFeeRequestMessage{
+		TargetDAAScore: targetDAAScore,
+	}
+}
+
+// EstimateFeeResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type EstimateFeeResponseMessage struct {
+	baseMessage
+	FeeRate            float64
+	ConfirmationWindow uint64
+	IsFallback         bool
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *EstimateFeeResponseMessage) Command() MessageCommand {
+	return CmdEstimateFeeResponseMessage
+}
+
+// NewEstimateFeeResponseMessage returns a instance of the message
+func NewEstimateFeeResponseMessage(feeRate float64, confirmationWindow uint64, isFallback bool) *EstimateFeeResponseMessage {
+	return &EstimateFeeResponseMessage{
+		FeeRate:            feeRate,
+		ConfirmationWindow: confirmationWindow,
+		IsFallback:         isFallback,
+	}
+}
@@ -0,0 +1,49 @@
+package appmessage
+
+// ExportMempoolSnapshotRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ExportMempoolSnapshotRequestMessage struct {
+	baseMessage
+	Path              string
+	IncludeOrphanPool bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *ExportMempoolSnapshotRequestMessage) Command() MessageCommand {
+	return CmdExportMempoolSnapshotRequestMessage
+}
+
+// NewExportMempoolSnapshotRequestMessage returns a instance of the message
+func NewExportMempoolSnapshotRequestMessage(path string, includeOrphanPool bool) *ExportMempoolSnapshotRequestMessage {
+	return &ExportMempoolSnapshotRequestMessage{
+		Path:              path,
+		IncludeOrphanPool: includeOrphanPool,
+	}
+}
+
+// ExportMempoolSnapshotResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ExportMempoolSnapshotResponseMessage struct {
+	baseMessage
+	TransactionCount uint64
+	OrphanCount      uint64
+	Timestamp        int64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ExportMempoolSnapshotResponseMessage) Command() MessageCommand {
+	return CmdExportMempoolSnapshotResponseMessage
+}
+
+// NewExportMempoolSnapshotResponseMessage returns a instance of the message
+func NewExportMempoolSnapshotResponseMessage(transactionCount uint64, orphanCount uint64,
+	timestamp int64) *ExportMempoolSnapshotResponseMessage {
+
+	return &ExportMempoolSnapshotResponseMessage{
+		TransactionCount: transactionCount,
+		OrphanCount:      orphanCount,
+		Timestamp:        timestamp,
+	}
+}
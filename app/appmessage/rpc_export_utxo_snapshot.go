@@ -0,0 +1,49 @@
+package appmessage
+
+// ExportUTXOSnapshotRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ExportUTXOSnapshotRequestMessage struct {
+	baseMessage
+	Path string
+}
+
+// Command returns the protocol command string for the message
+func (msg *ExportUTXOSnapshotRequestMessage) Command() MessageCommand {
+	return CmdExportUTXOSnapshotRequestMessage
+}
+
+// NewExportUTXOSnapshotRequestMessage returns a instance of the message
+func NewExportUTXOSnapshotRequestMessage(path string) *ExportUTXOSnapshotRequestMessage {
+	return &ExportUTXOSnapshotRequestMessage{
+		Path: path,
+	}
+}
+
+// ExportUTXOSnapshotResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ExportUTXOSnapshotResponseMessage struct {
+	baseMessage
+	PruningPointHash string
+	BlueScore        uint64
+	UTXOCommitment   string
+	UTXOCount        uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ExportUTXOSnapshotResponseMessage) Command() MessageCommand {
+	return CmdExportUTXOSnapshotResponseMessage
+}
+
+// NewExportUTXOSnapshotResponseMessage returns a instance of the message
+func NewExportUTXOSnapshotResponseMessage(pruningPointHash string, blueScore uint64, utxoCommitment string,
+	utxoCount uint64) *ExportUTXOSnapshotResponseMessage {
+
+	return &ExportUTXOSnapshotResponseMessage{
+		PruningPointHash: pruningPointHash,
+		BlueScore:        blueScore,
+		UTXOCommitment:   utxoCommitment,
+		UTXOCount:        utxoCount,
+	}
+}
@@ -0,0 +1,70 @@
+package appmessage
+
+// GetAddressManagerInfoRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetAddressManagerInfoRequestMessage struct {
+	baseMessage
+	SampleSize uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetAddressManagerInfoRequestMessage) Command() MessageCommand {
+	return CmdGetAddressManagerInfoRequestMessage
+}
+
+// NewGetAddressManagerInfoRequestMessage returns a instance of the message
+func NewGetAddressManagerInfoRequestMessage(sampleSize uint32) *GetAddressManagerInfoRequestMessage {
+	return &GetAddressManagerInfoRequestMessage{
+		SampleSize: sampleSize,
+	}
+}
+
+// GetAddressManagerInfoResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetAddressManagerInfoResponseMessage struct {
+	baseMessage
+	AddressCount                      uint64
+	BannedAddressCount                uint64
+	AddressCountsByConnectionFailures []uint64
+	AddressGroupCount                 uint64
+	SourceAddressCounts               []*AddressManagerSourceCount
+	SampleAddresses                   []*AddressManagerEntry
+
+	Error *RPCError
+}
+
+// AddressManagerSourceCount is the amount of addresses the address manager
+// learned from a single source
+type AddressManagerSourceCount struct {
+	Source       string
+	AddressCount uint64
+}
+
+// AddressManagerEntry describes a single address of the address manager
+type AddressManagerEntry struct {
+	Address               string
+	ConnectionFailedCount uint64
+	Source                string
+	Group                 string
+	Timestamp             int64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetAddressManagerInfoResponseMessage) Command() MessageCommand {
+	return CmdGetAddressManagerInfoResponseMessage
+}
+
+// NewGetAddressManagerInfoResponseMessage returns a instance of the message
+func NewGetAddressManagerInfoResponseMessage(addressCount uint64, bannedAddressCount uint64, addressCountsByConnectionFailures []uint64,
+	addressGroupCount uint64, sourceAddressCounts []*AddressManagerSourceCount,
+	sampleAddresses []*AddressManagerEntry) *GetAddressManagerInfoResponseMessage {
+
+	return &GetAddressManagerInfoResponseMessage{
+		AddressCount:                      addressCount,
+		BannedAddressCount:                bannedAddressCount,
+		AddressCountsByConnectionFailures: addressCountsByConnectionFailures,
+		AddressGroupCount:                 addressGroupCount,
+		SourceAddressCounts:               sourceAddressCounts,
+		SampleAddresses:                   sampleAddresses,
+	}
+}
@@ -0,0 +1,60 @@
+package appmessage
+
+// GetBandwidthInfoRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBandwidthInfoRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBandwidthInfoRequestMessage) Command() MessageCommand {
+	return CmdGetBandwidthInfoRequestMessage
+}
+
+// NewGetBandwidthInfoRequestMessage returns a instance of the message
+func NewGetBandwidthInfoRequestMessage() *GetBandwidthInfoRequestMessage {
+	return &GetBandwidthInfoRequestMessage{}
+}
+
+// GetBandwidthInfoResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBandwidthInfoResponseMessage struct {
+	baseMessage
+	TotalBytesSent     uint64
+	TotalBytesReceived uint64
+	UploadLimit        uint64
+	DownloadLimit      uint64
+	PeerUploadLimit    uint64
+	PeerDownloadLimit  uint64
+	Peers              []*PeerBandwidthInfo
+
+	Error *RPCError
+}
+
+// PeerBandwidthInfo holds the traffic counters of a connected peer
+type PeerBandwidthInfo struct {
+	ID            string
+	Address       string
+	BytesSent     uint64
+	BytesReceived uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBandwidthInfoResponseMessage) Command() MessageCommand {
+	return CmdGetBandwidthInfoResponseMessage
+}
+
+// NewGetBandwidthInfoResponseMessage returns a instance of the message
+func NewGetBandwidthInfoResponseMessage(totalBytesSent, totalBytesReceived, uploadLimit, downloadLimit,
+	peerUploadLimit, peerDownloadLimit uint64, peers []*PeerBandwidthInfo) *GetBandwidthInfoResponseMessage {
+
+	return &GetBandwidthInfoResponseMessage{
+		TotalBytesSent:     totalBytesSent,
+		TotalBytesReceived: totalBytesReceived,
+		UploadLimit:        uploadLimit,
+		DownloadLimit:      downloadLimit,
+		PeerUploadLimit:    peerUploadLimit,
+		PeerDownloadLimit:  peerDownloadLimit,
+		Peers:              peers,
+	}
+}
@@ -0,0 +1,46 @@
+package appmessage
+
+// GetBannedPeersRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBannedPeersRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBannedPeersRequestMessage) Command() MessageCommand {
+	return CmdGetBannedPeersRequestMessage
+}
+
+// NewGetBannedPeersRequestMessage returns a instance of the message
+func NewGetBannedPeersRequestMessage() *GetBannedPeersRequestMessage {
+	return &GetBannedPeersRequestMessage{}
+}
+
+// GetBannedPeersResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBannedPeersResponseMessage struct {
+	baseMessage
+	BannedPeers []*BannedPeer
+
+	Error *RPCError
+}
+
+// BannedPeer describes a banned IP
+type BannedPeer struct {
+	IP          string
+	BanTime     int64
+	BannedUntil int64
+	Reason      string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBannedPeersResponseMessage) Command() MessageCommand {
+	return CmdGetBannedPeersResponseMessage
+}
+
+// NewGetBannedPeersResponseMessage returns a instance of the message
+func NewGetBannedPeersResponseMessage(bannedPeers []*BannedPeer) *GetBannedPeersResponseMessage {
+	return &GetBannedPeersResponseMessage{
+		BannedPeers: bannedPeers,
+	}
+}
@@ -6,6 +6,7 @@ type GetBlockRequestMessage struct {
 	baseMessage
 	Hash                string
 	IncludeTransactions bool
+	Verbosity           RPCVerbosity
 }
 
 // Command returns the protocol command string for the message
@@ -0,0 +1,45 @@
+package appmessage
+
+// GetBlockHeadersRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBlockHeadersRequestMessage struct {
+	baseMessage
+	LowHash string
+	Count   uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBlockHeadersRequestMessage) Command() MessageCommand {
+	return CmdGetBlockHeadersRequestMessage
+}
+
+// NewGetBlockHeadersRequestMessage returns a instance of the message
+func NewGetBlockHeadersRequestMessage(lowHash string, count uint64) *GetBlockHeadersRequestMessage {
+	return &GetBlockHeadersRequestMessage{
+		LowHash: lowHash,
+		Count:   count,
+	}
+}
+
+// GetBlockHeadersResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBlockHeadersResponseMessage struct {
+	baseMessage
+	Headers  []string
+	HighHash string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBlockHeadersResponseMessage) Command() MessageCommand {
+	return CmdGetBlockHeadersResponseMessage
+}
+
+// NewGetBlockHeadersResponseMessage returns a instance of the message
+func NewGetBlockHeadersResponseMessage(headers []string, highHash string) *GetBlockHeadersResponseMessage {
+	return &GetBlockHeadersResponseMessage{
+		Headers:  headers,
+		HighHash: highHash,
+	}
+}
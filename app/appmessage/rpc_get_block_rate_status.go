@@ -0,0 +1,57 @@
+package appmessage
+
+// GetBlockRateStatusRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBlockRateStatusRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBlockRateStatusRequestMessage) Command() MessageCommand {
+	return CmdGetBlockRateStatusRequestMessage
+}
+
+// NewGetBlockRateStatusRequestMessage returns a instance of the message
+func NewGetBlockRateStatusRequestMessage() *GetBlockRateStatusRequestMessage {
+	return &GetBlockRateStatusRequestMessage{}
+}
+
+// GetBlockRateStatusResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBlockRateStatusResponseMessage struct {
+	baseMessage
+	TargetBlockRate   float64
+	ObservedBlockRate float64
+	IsWindowFull      bool
+	DifficultyChange  float64
+	TipTimestampDelay int64
+	Alerts            []*BlockRateAlert
+
+	Error *RPCError
+}
+
+// BlockRateAlert is an alert of the block rate monitor
+type BlockRateAlert struct {
+	Kind    string
+	Message string
+	Since   int64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBlockRateStatusResponseMessage) Command() MessageCommand {
+	return CmdGetBlockRateStatusResponseMessage
+}
+
+// NewGetBlockRateStatusResponseMessage returns a instance of the message
+func NewGetBlockRateStatusResponseMessage(targetBlockRate, observedBlockRate float64, isWindowFull bool,
+	difficultyChange float64, tipTimestampDelay int64, alerts []*BlockRateAlert) *GetBlockRateStatusResponseMessage {
+
+	return &GetBlockRateStatusResponseMessage{
+		TargetBlockRate:   targetBlockRate,
+		ObservedBlockRate: observedBlockRate,
+		IsWindowFull:      isWindowFull,
+		DifficultyChange:  difficultyChange,
+		TipTimestampDelay: tipTimestampDelay,
+		Alerts:            alerts,
+	}
+}
@@ -7,6 +7,8 @@ type GetBlocksRequestMessage struct {
 	LowHash             string
 	IncludeBlocks       bool
 	IncludeTransactions bool
+	Verbosity           RPCVerbosity
+	Limit               uint32
 }
 
 // Command returns the protocol command string for the message
@@ -30,6 +32,8 @@ type GetBlocksResponseMessage struct {
 	baseMessage
 	BlockHashes []string
 	Blocks      []*RPCBlock
+	NextLowHash string
+	HasMore     bool
 
 	Error *RPCError
 }
@@ -0,0 +1,59 @@
+package appmessage
+
+// GetChainChangedEventsFromBlockRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetChainChangedEventsFromBlockRequestMessage struct {
+	baseMessage
+	StartHash string
+	Limit     uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetChainChangedEventsFromBlockRequestMessage) Command() MessageCommand {
+	return CmdGetChainChangedEventsFromBlockRequestMessage
+}
+
+// NewGetChainChangedEventsFromBlockRequestMessage returns a instance of the message
+func NewGetChainChangedEventsFromBlockRequestMessage(startHash string, limit uint32) *GetChainChangedEventsFromBlockRequestMessage {
+	return &GetChainChangedEventsFromBlockRequestMessage{
+		StartHash: startHash,
+		Limit:     limit,
+	}
+}
+
+// GetChainChangedEventsFromBlockResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetChainChangedEventsFromBlockResponseMessage struct {
+	baseMessage
+	RemovedChainBlockHashes []string
+	AddedChainBlockHashes   []string
+	AcceptedTransactions    []*AcceptedTransactions
+	NextStartHash           string
+	HasMore                 bool
+
+	Error *RPCError
+}
+
+// AcceptedTransactions represents the transactions accepted by a chain block
+type AcceptedTransactions struct {
+	AcceptingBlockHash   string
+	AcceptedTransactions []*RPCTransaction
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetChainChangedEventsFromBlockResponseMessage) Command() MessageCommand {
+	return CmdGetChainChangedEventsFromBlockResponseMessage
+}
+
+// NewGetChainChangedEventsFromBlockResponseMessage returns a instance of the message
+func NewGetChainChangedEventsFromBlockResponseMessage(removedChainBlockHashes []string, addedChainBlockHashes []string,
+	acceptedTransactions []*AcceptedTransactions, nextStartHash string, hasMore bool) *GetChainChangedEventsFromBlockResponseMessage {
+
+	return &GetChainChangedEventsFromBlockResponseMessage{
+		RemovedChainBlockHashes: removedChainBlockHashes,
+		AddedChainBlockHashes:   addedChainBlockHashes,
+		AcceptedTransactions:    acceptedTransactions,
+		NextStartHash:           nextStartHash,
+		HasMore:                 hasMore,
+	}
+}
@@ -0,0 +1,52 @@
+package appmessage
+
+// GetChainWorkStatusRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetChainWorkStatusRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetChainWorkStatusRequestMessage) Command() MessageCommand {
+	return CmdGetChainWorkStatusRequestMessage
+}
+
+// NewGetChainWorkStatusRequestMessage returns a instance of the message
+func NewGetChainWorkStatusRequestMessage() *GetChainWorkStatusRequestMessage {
+	return &GetChainWorkStatusRequestMessage{}
+}
+
+// GetChainWorkStatusResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetChainWorkStatusResponseMessage struct {
+	baseMessage
+	VirtualSelectedParentHash     string
+	VirtualSelectedParentBlueWork string
+	HeaviestClaimedBlockHash      string
+	HeaviestClaimedBlueWork       string
+	HeaviestClaimedByPeer         string
+	LagInBlocks                   uint64
+	IsLagging                     bool
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetChainWorkStatusResponseMessage) Command() MessageCommand {
+	return CmdGetChainWorkStatusResponseMessage
+}
+
+// NewGetChainWorkStatusResponseMessage returns a instance of the message
+func NewGetChainWorkStatusResponseMessage(virtualSelectedParentHash string, virtualSelectedParentBlueWork string,
+	heaviestClaimedBlockHash string, heaviestClaimedBlueWork string,
+	heaviestClaimedByPeer string, lagInBlocks uint64, isLagging bool) *GetChainWorkStatusResponseMessage {
+	return &GetChainWorkStatusResponseMessage{
+		VirtualSelectedParentHash:     virtualSelectedParentHash,
+		VirtualSelectedParentBlueWork: virtualSelectedParentBlueWork,
+		HeaviestClaimedBlockHash:      heaviestClaimedBlockHash,
+		HeaviestClaimedBlueWork:       heaviestClaimedBlueWork,
+		HeaviestClaimedByPeer:         heaviestClaimedByPeer,
+		LagInBlocks:                   lagInBlocks,
+		IsLagging:                     isLagging,
+	}
+}
@@ -0,0 +1,63 @@
+package appmessage
+
+// GetCoinAgeAnalyticsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetCoinAgeAnalyticsRequestMessage struct {
+	baseMessage
+	Window uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetCoinAgeAnalyticsRequestMessage) Command() MessageCommand {
+	return CmdGetCoinAgeAnalyticsRequestMessage
+}
+
+// NewGetCoinAgeAnalyticsRequestMessage returns a instance of the message
+func NewGetCoinAgeAnalyticsRequestMessage(window uint32) *GetCoinAgeAnalyticsRequestMessage {
+	return &GetCoinAgeAnalyticsRequestMessage{
+		Window: window,
+	}
+}
+
+// GetCoinAgeAnalyticsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetCoinAgeAnalyticsResponseMessage struct {
+	baseMessage
+	DAAScore           uint64
+	DAAScoreWindowSize uint64
+	ChainBlocks        []*ChainBlockCoinDaysDestroyed
+	UTXOAgeWindows     []*UTXOAgeWindow
+
+	Error *RPCError
+}
+
+// ChainBlockCoinDaysDestroyed holds the coin-days destroyed by a single chain block
+type ChainBlockCoinDaysDestroyed struct {
+	BlockHash         string
+	DAAScore          uint64
+	SpentAmount       uint64
+	CoinDaysDestroyed float64
+}
+
+// UTXOAgeWindow holds the unspent outputs that were created within a single DAA score window
+type UTXOAgeWindow struct {
+	StartDAAScore uint64
+	UTXOCount     uint64
+	TotalAmount   uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetCoinAgeAnalyticsResponseMessage) Command() MessageCommand {
+	return CmdGetCoinAgeAnalyticsResponseMessage
+}
+
+// NewGetCoinAgeAnalyticsResponseMessage returns a instance of the message
+func NewGetCoinAgeAnalyticsResponseMessage(daaScore uint64, daaScoreWindowSize uint64, chainBlocks []*ChainBlockCoinDaysDestroyed,
+	utxoAgeWindows []*UTXOAgeWindow) *GetCoinAgeAnalyticsResponseMessage {
+	return &GetCoinAgeAnalyticsResponseMessage{
+		DAAScore:           daaScore,
+		DAAScoreWindowSize: daaScoreWindowSize,
+		ChainBlocks:        chainBlocks,
+		UTXOAgeWindows:     utxoAgeWindows,
+	}
+}
@@ -0,0 +1,48 @@
+package appmessage
+
+// GetDAGTipsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetDAGTipsRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetDAGTipsRequestMessage) Command() MessageCommand {
+	return CmdGetDAGTipsRequestMessage
+}
+
+// NewGetDAGTipsRequestMessage returns a instance of the message
+func NewGetDAGTipsRequestMessage() *GetDAGTipsRequestMessage {
+	return &GetDAGTipsRequestMessage{}
+}
+
+// GetDAGTipsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetDAGTipsResponseMessage struct {
+	baseMessage
+	Tips []*DAGTip
+
+	Error *RPCError
+}
+
+// DAGTip describes a block that's a tip of the DAG
+type DAGTip struct {
+	Hash                    string
+	BlueScore               uint64
+	IsVirtualParent         bool
+	IsVirtualSelectedParent bool
+	IsReceiveTimeKnown      bool
+	TimeSinceReceived       int64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetDAGTipsResponseMessage) Command() MessageCommand {
+	return CmdGetDAGTipsResponseMessage
+}
+
+// NewGetDAGTipsResponseMessage returns a instance of the message
+func NewGetDAGTipsResponseMessage(tips []*DAGTip) *GetDAGTipsResponseMessage {
+	return &GetDAGTipsResponseMessage{
+		Tips: tips,
+	}
+}
@@ -0,0 +1,45 @@
+package appmessage
+
+// GetEffectiveConfigRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetEffectiveConfigRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetEffectiveConfigRequestMessage) Command() MessageCommand {
+	return CmdGetEffectiveConfigRequestMessage
+}
+
+// NewGetEffectiveConfigRequestMessage returns a instance of the message
+func NewGetEffectiveConfigRequestMessage() *GetEffectiveConfigRequestMessage {
+	return &GetEffectiveConfigRequestMessage{}
+}
+
+// GetEffectiveConfigResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetEffectiveConfigResponseMessage struct {
+	baseMessage
+	Options []*EffectiveConfigOption
+
+	Error *RPCError
+}
+
+// EffectiveConfigOption is the resolved value of a single configuration option
+type EffectiveConfigOption struct {
+	Name   string
+	Value  string
+	Source string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetEffectiveConfigResponseMessage) Command() MessageCommand {
+	return CmdGetEffectiveConfigResponseMessage
+}
+
+// NewGetEffectiveConfigResponseMessage returns a instance of the message
+func NewGetEffectiveConfigResponseMessage(options []*EffectiveConfigOption) *GetEffectiveConfigResponseMessage {
+	return &GetEffectiveConfigResponseMessage{
+		Options: options,
+	}
+}
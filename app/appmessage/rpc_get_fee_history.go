@@ -0,0 +1,54 @@
+package appmessage
+
+// GetFeeHistoryRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetFeeHistoryRequestMessage struct {
+	baseMessage
+	Window uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetFeeHistoryRequestMessage) Command() MessageCommand {
+	return CmdGetFeeHistoryRequestMessage
+}
+
+// NewGetFeeHistoryRequestMessage returns a instance of the message
+func NewGetFeeHistoryRequestMessage(window uint32) *GetFeeHistoryRequestMessage {
+	return &GetFeeHistoryRequestMessage{
+		Window: window,
+	}
+}
+
+// GetFeeHistoryResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetFeeHistoryResponseMessage struct {
+	baseMessage
+	Percentiles []uint32
+	ChainBlocks []*ChainBlockFeeRates
+
+	Error *RPCError
+}
+
+// ChainBlockFeeRates holds the fee rate percentiles of the
+// transactions accepted by a single chain block
+type ChainBlockFeeRates struct {
+	BlockHash          string
+	BlueScore          uint64
+	DAAScore           uint64
+	Timestamp          int64
+	TransactionCount   uint64
+	FeeRatePercentiles []float64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetFeeHistoryResponseMessage) Command() MessageCommand {
+	return CmdGetFeeHistoryResponseMessage
+}
+
+// NewGetFeeHistoryResponseMessage returns a instance of the message
+func NewGetFeeHistoryResponseMessage(percentiles []uint32, chainBlocks []*ChainBlockFeeRates) *GetFeeHistoryResponseMessage {
+	return &GetFeeHistoryResponseMessage{
+		Percentiles: percentiles,
+		ChainBlocks: chainBlocks,
+	}
+}
@@ -0,0 +1,53 @@
+package appmessage
+
+// GetHeadersSelectedTipRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetHeadersSelectedTipRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetHeadersSelectedTipRequestMessage) Command() MessageCommand {
+	return CmdGetHeadersSelectedTipRequestMessage
+}
+
+// NewGetHeadersSelectedTipRequestMessage returns a instance of the message
+func NewGetHeadersSelectedTipRequestMessage() *GetHeadersSelectedTipRequestMessage {
+	return &GetHeadersSelectedTipRequestMessage{}
+}
+
+// GetHeadersSelectedTipResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetHeadersSelectedTipResponseMessage struct {
+	baseMessage
+	Hash        string
+	BlueScore   uint64
+	DAAScore    uint64
+	BlueWork    string
+	Bits        uint32
+	Difficulty  float64
+	Timestamp   int64
+	HeaderCount uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetHeadersSelectedTipResponseMessage) Command() MessageCommand {
+	return CmdGetHeadersSelectedTipResponseMessage
+}
+
+// NewGetHeadersSelectedTipResponseMessage returns a instance of the message
+func NewGetHeadersSelectedTipResponseMessage(hash string, blueScore uint64, daaScore uint64, blueWork string, bits uint32,
+	difficulty float64, timestamp int64, headerCount uint64) *GetHeadersSelectedTipResponseMessage {
+	return &GetHeadersSelectedTipResponseMessage{
+		Hash:        hash,
+		BlueScore:   blueScore,
+		DAAScore:    daaScore,
+		BlueWork:    blueWork,
+		Bits:        bits,
+		Difficulty:  difficulty,
+		Timestamp:   timestamp,
+		HeaderCount: headerCount,
+	}
+}
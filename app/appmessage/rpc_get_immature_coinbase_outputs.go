@@ -0,0 +1,59 @@
+package appmessage
+
+// GetImmatureCoinbaseOutputsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetImmatureCoinbaseOutputsRequestMessage struct {
+	baseMessage
+	Address string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetImmatureCoinbaseOutputsRequestMessage) Command() MessageCommand {
+	return CmdGetImmatureCoinbaseOutputsRequestMessage
+}
+
+// NewGetImmatureCoinbaseOutputsRequestMessage returns a instance of the message
+func NewGetImmatureCoinbaseOutputsRequestMessage(address string) *GetImmatureCoinbaseOutputsRequestMessage {
+	return &GetImmatureCoinbaseOutputsRequestMessage{
+		Address: address,
+	}
+}
+
+// GetImmatureCoinbaseOutputsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetImmatureCoinbaseOutputsResponseMessage struct {
+	baseMessage
+	VirtualDAAScore     uint64
+	CoinbaseMaturity    uint64
+	TotalImmatureAmount uint64
+	Outputs             []*ImmatureCoinbaseOutput
+
+	Error *RPCError
+}
+
+// ImmatureCoinbaseOutput is a coinbase output that can't be spent until the
+// virtual DAA score reaches SpendableAtDAAScore
+type ImmatureCoinbaseOutput struct {
+	Outpoint            *RPCOutpoint
+	Amount              uint64
+	BlockHash           string
+	DAAScore            uint64
+	SpendableAtDAAScore uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetImmatureCoinbaseOutputsResponseMessage) Command() MessageCommand {
+	return CmdGetImmatureCoinbaseOutputsResponseMessage
+}
+
+// NewGetImmatureCoinbaseOutputsResponseMessage returns a instance of the message
+func NewGetImmatureCoinbaseOutputsResponseMessage(virtualDAAScore uint64, coinbaseMaturity uint64,
+	totalImmatureAmount uint64, outputs []*ImmatureCoinbaseOutput) *GetImmatureCoinbaseOutputsResponseMessage {
+
+	return &GetImmatureCoinbaseOutputsResponseMessage{
+		VirtualDAAScore:     virtualDAAScore,
+		CoinbaseMaturity:    coinbaseMaturity,
+		TotalImmatureAmount: totalImmatureAmount,
+		Outputs:             outputs,
+	}
+}
@@ -0,0 +1,51 @@
+package appmessage
+
+// GetIndexRetentionStatusRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetIndexRetentionStatusRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetIndexRetentionStatusRequestMessage) Command() MessageCommand {
+	return CmdGetIndexRetentionStatusRequestMessage
+}
+
+// NewGetIndexRetentionStatusRequestMessage returns a instance of the message
+func NewGetIndexRetentionStatusRequestMessage() *GetIndexRetentionStatusRequestMessage {
+	return &GetIndexRetentionStatusRequestMessage{}
+}
+
+// GetIndexRetentionStatusResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetIndexRetentionStatusResponseMessage struct {
+	baseMessage
+	Indexes []*IndexRetentionStatus
+
+	Error *RPCError
+}
+
+// IndexRetentionStatus holds the retention limits of a single index, its
+// size as of the last time it was pruned, and the space pruning it reclaimed
+type IndexRetentionStatus struct {
+	IndexName             string
+	MaxAgeInSeconds       uint64
+	MaxSize               uint64
+	Size                  uint64
+	ChainBlockCount       uint64
+	PrunedChainBlockCount uint64
+	ReclaimedBytes        uint64
+	LastPruneTimestamp    int64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetIndexRetentionStatusResponseMessage) Command() MessageCommand {
+	return CmdGetIndexRetentionStatusResponseMessage
+}
+
+// NewGetIndexRetentionStatusResponseMessage returns a instance of the message
+func NewGetIndexRetentionStatusResponseMessage(indexes []*IndexRetentionStatus) *GetIndexRetentionStatusResponseMessage {
+	return &GetIndexRetentionStatusResponseMessage{
+		Indexes: indexes,
+	}
+}
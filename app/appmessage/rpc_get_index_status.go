@@ -0,0 +1,43 @@
+package appmessage
+
+// GetIndexStatusRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetIndexStatusRequestMessage struct {
+	baseMessage
+	IndexName string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetIndexStatusRequestMessage) Command() MessageCommand {
+	return CmdGetIndexStatusRequestMessage
+}
+
+// NewGetIndexStatusRequestMessage returns a instance of the message
+func NewGetIndexStatusRequestMessage(indexName string) *GetIndexStatusRequestMessage {
+	return &GetIndexStatusRequestMessage{
+		IndexName: indexName,
+	}
+}
+
+// GetIndexStatusResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetIndexStatusResponseMessage struct {
+	baseMessage
+	Status     string
+	BuildError string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetIndexStatusResponseMessage) Command() MessageCommand {
+	return CmdGetIndexStatusResponseMessage
+}
+
+// NewGetIndexStatusResponseMessage returns a instance of the message
+func NewGetIndexStatusResponseMessage(status string, buildError string) *GetIndexStatusResponseMessage {
+	return &GetIndexStatusResponseMessage{
+		Status:     status,
+		BuildError: buildError,
+	}
+}
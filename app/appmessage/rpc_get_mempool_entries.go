@@ -6,6 +6,7 @@ type GetMempoolEntriesRequestMessage struct {
 	baseMessage
 	IncludeOrphanPool     bool
 	FilterTransactionPool bool
+	Verbose               bool
 }
 
 // Command returns the protocol command string for the message
@@ -14,10 +15,13 @@ func (msg *GetMempoolEntriesRequestMessage) Command() MessageCommand {
 }
 
 // NewGetMempoolEntriesRequestMessage returns a instance of the message
-func NewGetMempoolEntriesRequestMessage(includeOrphanPool bool, filterTransactionPool bool) *GetMempoolEntriesRequestMessage {
+func NewGetMempoolEntriesRequestMessage(includeOrphanPool bool, filterTransactionPool bool,
+	verbose bool) *GetMempoolEntriesRequestMessage {
+
 	return &GetMempoolEntriesRequestMessage{
 		IncludeOrphanPool:     includeOrphanPool,
 		FilterTransactionPool: filterTransactionPool,
+		Verbose:               verbose,
 	}
 }
 
@@ -7,6 +7,7 @@ type GetMempoolEntryRequestMessage struct {
 	TxID                  string
 	IncludeOrphanPool     bool
 	FilterTransactionPool bool
+	Verbose               bool
 }
 
 // Command returns the protocol command string for the message
@@ -15,11 +16,14 @@ func (msg *GetMempoolEntryRequestMessage) Command() MessageCommand {
 }
 
 // NewGetMempoolEntryRequestMessage returns a instance of the message
-func NewGetMempoolEntryRequestMessage(txID string, includeOrphanPool bool, filterTransactionPool bool) *GetMempoolEntryRequestMessage {
+func NewGetMempoolEntryRequestMessage(txID string, includeOrphanPool bool, filterTransactionPool bool,
+	verbose bool) *GetMempoolEntryRequestMessage {
+
 	return &GetMempoolEntryRequestMessage{
 		TxID:                  txID,
 		IncludeOrphanPool:     includeOrphanPool,
 		FilterTransactionPool: filterTransactionPool,
+		Verbose:               verbose,
 	}
 }
 
@@ -37,6 +41,17 @@ type MempoolEntry struct {
 	Fee         uint64
 	Transaction *RPCTransaction
 	IsOrphan    bool
+	VerboseData *MempoolEntryVerboseData
+}
+
+// MempoolEntryVerboseData holds what the mempool knows about a transaction
+// beyond its fee. It's returned only on request.
+type MempoolEntryVerboseData struct {
+	Mass                  uint64
+	ArrivalTime           int64
+	ArrivalDAAScore       uint64
+	DependsTransactionIDs []string
+	SpentByTransactionIDs []string
 }
 
 // Command returns the protocol command string for the message
@@ -45,12 +60,15 @@ func (msg *GetMempoolEntryResponseMessage) Command() MessageCommand {
 }
 
 // NewGetMempoolEntryResponseMessage returns a instance of the message
-func NewGetMempoolEntryResponseMessage(fee uint64, transaction *RPCTransaction, isOrphan bool) *GetMempoolEntryResponseMessage {
+func NewGetMempoolEntryResponseMessage(fee uint64, transaction *RPCTransaction, isOrphan bool,
+	verboseData *MempoolEntryVerboseData) *GetMempoolEntryResponseMessage {
+
 	return &GetMempoolEntryResponseMessage{
 		Entry: &MempoolEntry{
 			Fee:         fee,
 			Transaction: transaction,
 			IsOrphan:    isOrphan,
+			VerboseData: verboseData,
 		},
 	}
 }
@@ -0,0 +1,66 @@
+package appmessage
+
+// GetMempoolGraphRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetMempoolGraphRequestMessage struct {
+	baseMessage
+	TransactionIDs    []string
+	IncludeOrphanPool bool
+	IncludeAncestry   bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetMempoolGraphRequestMessage) Command() MessageCommand {
+	return CmdGetMempoolGraphRequestMessage
+}
+
+// NewGetMempoolGraphRequestMessage returns a instance of the message
+func NewGetMempoolGraphRequestMessage(transactionIDs []string, includeOrphanPool bool,
+	includeAncestry bool) *GetMempoolGraphRequestMessage {
+
+	return &GetMempoolGraphRequestMessage{
+		TransactionIDs:    transactionIDs,
+		IncludeOrphanPool: includeOrphanPool,
+		IncludeAncestry:   includeAncestry,
+	}
+}
+
+// GetMempoolGraphResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetMempoolGraphResponseMessage struct {
+	baseMessage
+	Entries []*MempoolGraphEntry
+
+	Error *RPCError
+}
+
+// MempoolGraphEntry describes how a pending transaction relates to the other
+// pending transactions
+type MempoolGraphEntry struct {
+	TransactionID string
+	IsOrphan      bool
+	ParentIDs     []string
+	ChildIDs      []string
+	AncestorIDs   []string
+	DescendantIDs []string
+	Conflicts     []*MempoolConflict
+}
+
+// MempoolConflict is an outpoint a pending transaction spends along with the
+// other pending transactions that spend it
+type MempoolConflict struct {
+	Outpoint                  *RPCOutpoint
+	ConflictingTransactionIDs []string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetMempoolGraphResponseMessage) Command() MessageCommand {
+	return CmdGetMempoolGraphResponseMessage
+}
+
+// NewGetMempoolGraphResponseMessage returns a instance of the message
+func NewGetMempoolGraphResponseMessage(entries []*MempoolGraphEntry) *GetMempoolGraphResponseMessage {
+	return &GetMempoolGraphResponseMessage{
+		Entries: entries,
+	}
+}
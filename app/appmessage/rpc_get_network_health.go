@@ -0,0 +1,50 @@
+package appmessage
+
+// GetNetworkHealthRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetNetworkHealthRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetNetworkHealthRequestMessage) Command() MessageCommand {
+	return CmdGetNetworkHealthRequestMessage
+}
+
+// NewGetNetworkHealthRequestMessage returns a instance of the message
+func NewGetNetworkHealthRequestMessage() *GetNetworkHealthRequestMessage {
+	return &GetNetworkHealthRequestMessage{}
+}
+
+// GetNetworkHealthResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetNetworkHealthResponseMessage struct {
+	baseMessage
+	IsDegraded             bool
+	Reasons                []string
+	DegradedSinceTimestamp int64
+	PeerCount              uint32
+	MinPeers               uint32
+	LastBlockTimestamp     int64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetNetworkHealthResponseMessage) Command() MessageCommand {
+	return CmdGetNetworkHealthResponseMessage
+}
+
+// NewGetNetworkHealthResponseMessage returns a instance of the message
+func NewGetNetworkHealthResponseMessage(isDegraded bool, reasons []string, degradedSinceTimestamp int64,
+	peerCount uint32, minPeers uint32, lastBlockTimestamp int64) *GetNetworkHealthResponseMessage {
+
+	return &GetNetworkHealthResponseMessage{
+		IsDegraded:             isDegraded,
+		Reasons:                reasons,
+		DegradedSinceTimestamp: degradedSinceTimestamp,
+		PeerCount:              peerCount,
+		MinPeers:               minPeers,
+		LastBlockTimestamp:     lastBlockTimestamp,
+	}
+}
@@ -0,0 +1,45 @@
+package appmessage
+
+// GetOutpointSpendingTransactionRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetOutpointSpendingTransactionRequestMessage struct {
+	baseMessage
+	Outpoint *RPCOutpoint
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetOutpointSpendingTransactionRequestMessage) Command() MessageCommand {
+	return CmdGetOutpointSpendingTransactionRequestMessage
+}
+
+// NewGetOutpointSpendingTransactionRequestMessage returns a instance of the message
+func NewGetOutpointSpendingTransactionRequestMessage(outpoint *RPCOutpoint) *GetOutpointSpendingTransactionRequestMessage {
+	return &GetOutpointSpendingTransactionRequestMessage{
+		Outpoint: outpoint,
+	}
+}
+
+// GetOutpointSpendingTransactionResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetOutpointSpendingTransactionResponseMessage struct {
+	baseMessage
+	IsSpent               bool
+	SpendingTransactionID string
+	AcceptingBlockHash    string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetOutpointSpendingTransactionResponseMessage) Command() MessageCommand {
+	return CmdGetOutpointSpendingTransactionResponseMessage
+}
+
+// NewGetOutpointSpendingTransactionResponseMessage returns a instance of the message
+func NewGetOutpointSpendingTransactionResponseMessage(isSpent bool, spendingTransactionID string, acceptingBlockHash string) *GetOutpointSpendingTransactionResponseMessage {
+	return &GetOutpointSpendingTransactionResponseMessage{
+		IsSpent:               isSpent,
+		SpendingTransactionID: spendingTransactionID,
+		AcceptingBlockHash:    acceptingBlockHash,
+	}
+}
@@ -0,0 +1,67 @@
+package appmessage
+
+// GetPayoutStatsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetPayoutStatsRequestMessage struct {
+	baseMessage
+	RecentBlockCount uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetPayoutStatsRequestMessage) Command() MessageCommand {
+	return CmdGetPayoutStatsRequestMessage
+}
+
+// NewGetPayoutStatsRequestMessage returns a instance of the message
+func NewGetPayoutStatsRequestMessage(recentBlockCount uint32) *GetPayoutStatsRequestMessage {
+	return &GetPayoutStatsRequestMessage{
+		RecentBlockCount: recentBlockCount,
+	}
+}
+
+// GetPayoutStatsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetPayoutStatsResponseMessage struct {
+	baseMessage
+	Address           string
+	BlueBlockCount    uint64
+	RedBlockCount     uint64
+	PendingBlockCount uint64
+	OrphanBlockCount  uint64
+	TotalReward       uint64
+	MatureReward      uint64
+	RecentBlocks      []*PayoutBlock
+
+	Error *RPCError
+}
+
+// PayoutBlock is a block whose coinbase pays to the watched address
+type PayoutBlock struct {
+	BlockHash        string
+	Status           string
+	DAAScore         uint64
+	MergingBlockHash string
+	Reward           uint64
+	IsRewardMature   bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetPayoutStatsResponseMessage) Command() MessageCommand {
+	return CmdGetPayoutStatsResponseMessage
+}
+
+// NewGetPayoutStatsResponseMessage returns a instance of the message
+func NewGetPayoutStatsResponseMessage(address string, blueBlockCount, redBlockCount, pendingBlockCount,
+	orphanBlockCount, totalReward, matureReward uint64, recentBlocks []*PayoutBlock) *GetPayoutStatsResponseMessage {
+
+	return &GetPayoutStatsResponseMessage{
+		Address:           address,
+		BlueBlockCount:    blueBlockCount,
+		RedBlockCount:     redBlockCount,
+		PendingBlockCount: pendingBlockCount,
+		OrphanBlockCount:  orphanBlockCount,
+		TotalReward:       totalReward,
+		MatureReward:      matureReward,
+		RecentBlocks:      recentBlocks,
+	}
+}
@@ -0,0 +1,62 @@
+package appmessage
+
+// GetPeerFlowStatisticsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetPeerFlowStatisticsRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetPeerFlowStatisticsRequestMessage) Command() MessageCommand {
+	return CmdGetPeerFlowStatisticsRequestMessage
+}
+
+// NewGetPeerFlowStatisticsRequestMessage returns a instance of the message
+func NewGetPeerFlowStatisticsRequestMessage() *GetPeerFlowStatisticsRequestMessage {
+	return &GetPeerFlowStatisticsRequestMessage{}
+}
+
+// GetPeerFlowStatisticsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetPeerFlowStatisticsResponseMessage struct {
+	baseMessage
+	Peers []*PeerFlowStatistics
+
+	Error *RPCError
+}
+
+// PeerFlowStatistics holds the statistics of the protocol flows with a single
+// connected peer
+type PeerFlowStatistics struct {
+	ID                    string
+	Address               string
+	Flows                 []*FlowStatistics
+	RepeatedBlockRequests uint64
+	Anomalies             []*PeerFlowAnomaly
+}
+
+// FlowStatistics holds the statistics of a single group of protocol flows
+type FlowStatistics struct {
+	Flow            string
+	Count           uint64
+	AverageDuration int64
+	MaxDuration     int64
+}
+
+// PeerFlowAnomaly describes a statistical anomaly in the protocol flows with a peer
+type PeerFlowAnomaly struct {
+	Flow   string
+	Reason string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetPeerFlowStatisticsResponseMessage) Command() MessageCommand {
+	return CmdGetPeerFlowStatisticsResponseMessage
+}
+
+// NewGetPeerFlowStatisticsResponseMessage returns a instance of the message
+func NewGetPeerFlowStatisticsResponseMessage(peers []*PeerFlowStatistics) *GetPeerFlowStatisticsResponseMessage {
+	return &GetPeerFlowStatisticsResponseMessage{
+		Peers: peers,
+	}
+}
@@ -0,0 +1,59 @@
+package appmessage
+
+// GetRelayPolicyRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetRelayPolicyRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetRelayPolicyRequestMessage) Command() MessageCommand {
+	return CmdGetRelayPolicyRequestMessage
+}
+
+// NewGetRelayPolicyRequestMessage returns a instance of the message
+func NewGetRelayPolicyRequestMessage() *GetRelayPolicyRequestMessage {
+	return &GetRelayPolicyRequestMessage{}
+}
+
+// GetRelayPolicyResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetRelayPolicyResponseMessage struct {
+	baseMessage
+	Network                             string
+	RelayNonStandardTransactions        bool
+	RelayNonStandardTransactionsSource  string
+	MinimumRelayTransactionFee          uint64
+	MaximumOrphanTransactionCount       uint64
+	DustRelayTransactionFee             uint64
+	PayToPubKeyDustThreshold            uint64
+	MaximumStandardTransactionMass      uint64
+	EffectiveMinimumRelayTransactionFee uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetRelayPolicyResponseMessage) Command() MessageCommand {
+	return CmdGetRelayPolicyResponseMessage
+}
+
+// NewGetRelayPolicyResponseMessage returns a instance of the message
+func NewGetRelayPolicyResponseMessage(network string, relayNonStandardTransactions bool,
+	relayNonStandardTransactionsSource string, minimumRelayTransactionFee uint64,
+	maximumOrphanTransactionCount uint64, dustRelayTransactionFee uint64,
+	payToPubKeyDustThreshold uint64, maximumStandardTransactionMass uint64,
+	effectiveMinimumRelayTransactionFee uint64) *GetRelayPolicyResponseMessage {
+
+	return &GetRelayPolicyResponseMessage{
+		Network:                             network,
+		RelayNonStandardTransactions:        relayNonStandardTransactions,
+		RelayNonStandardTransactionsSource:  relayNonStandardTransactionsSource,
+		MinimumRelayTransactionFee:          minimumRelayTransactionFee,
+		MaximumOrphanTransactionCount:       maximumOrphanTransactionCount,
+		DustRelayTransactionFee:             dustRelayTransactionFee,
+		PayToPubKeyDustThreshold:            payToPubKeyDustThreshold,
+		MaximumStandardTransactionMass:      maximumStandardTransactionMass,
+		EffectiveMinimumRelayTransactionFee: effectiveMinimumRelayTransactionFee,
+	}
+}
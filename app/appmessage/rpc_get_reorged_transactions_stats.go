@@ -0,0 +1,47 @@
+package appmessage
+
+// GetReorgedTransactionsStatsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetReorgedTransactionsStatsRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetReorgedTransactionsStatsRequestMessage) Command() MessageCommand {
+	return CmdGetReorgedTransactionsStatsRequestMessage
+}
+
+// NewGetReorgedTransactionsStatsRequestMessage returns a instance of the message
+func NewGetReorgedTransactionsStatsRequestMessage() *GetReorgedTransactionsStatsRequestMessage {
+	return &GetReorgedTransactionsStatsRequestMessage{}
+}
+
+// GetReorgedTransactionsStatsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetReorgedTransactionsStatsResponseMessage struct {
+	baseMessage
+	ReorgCount                  uint64
+	ReorgedTransactionCount     uint64
+	ReinsertedTransactionCount  uint64
+	DiscardedTransactionCount   uint64
+	RebroadcastTransactionCount uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetReorgedTransactionsStatsResponseMessage) Command() MessageCommand {
+	return CmdGetReorgedTransactionsStatsResponseMessage
+}
+
+// NewGetReorgedTransactionsStatsResponseMessage returns a instance of the message
+func NewGetReorgedTransactionsStatsResponseMessage(reorgCount uint64, reorgedTransactionCount uint64, reinsertedTransactionCount uint64,
+	discardedTransactionCount uint64, rebroadcastTransactionCount uint64) *GetReorgedTransactionsStatsResponseMessage {
+	return &GetReorgedTransactionsStatsResponseMessage{
+		ReorgCount:                  reorgCount,
+		ReorgedTransactionCount:     reorgedTransactionCount,
+		ReinsertedTransactionCount:  reinsertedTransactionCount,
+		DiscardedTransactionCount:   discardedTransactionCount,
+		RebroadcastTransactionCount: rebroadcastTransactionCount,
+	}
+}
@@ -0,0 +1,53 @@
+package appmessage
+
+// GetRPCSessionsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetRPCSessionsRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetRPCSessionsRequestMessage) Command() MessageCommand {
+	return CmdGetRPCSessionsRequestMessage
+}
+
+// NewGetRPCSessionsRequestMessage returns a instance of the message
+func NewGetRPCSessionsRequestMessage() *GetRPCSessionsRequestMessage {
+	return &GetRPCSessionsRequestMessage{}
+}
+
+// GetRPCSessionsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetRPCSessionsResponseMessage struct {
+	baseMessage
+	Sessions []*RPCSession
+
+	Error *RPCError
+}
+
+// RPCSession describes an RPC client that's connected to the node
+type RPCSession struct {
+	ID                       uint64
+	Address                  string
+	EndpointName             string
+	IsAuthenticated          bool
+	ConnectedAt              int64
+	APIVersion               uint32
+	Subscriptions            []string
+	UTXOsChangedAddressCount uint64
+	ReceivedMessageCount     uint64
+	SentMessageCount         uint64
+	IsCurrentSession         bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetRPCSessionsResponseMessage) Command() MessageCommand {
+	return CmdGetRPCSessionsResponseMessage
+}
+
+// NewGetRPCSessionsResponseMessage returns a instance of the message
+func NewGetRPCSessionsResponseMessage(sessions []*RPCSession) *GetRPCSessionsResponseMessage {
+	return &GetRPCSessionsResponseMessage{
+		Sessions: sessions,
+	}
+}
@@ -0,0 +1,59 @@
+package appmessage
+
+// GetScriptClassStatisticsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetScriptClassStatisticsRequestMessage struct {
+	baseMessage
+	StartBlueScore uint64
+	EndBlueScore   uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetScriptClassStatisticsRequestMessage) Command() MessageCommand {
+	return CmdGetScriptClassStatisticsRequestMessage
+}
+
+// NewGetScriptClassStatisticsRequestMessage returns a instance of the message
+func NewGetScriptClassStatisticsRequestMessage(startBlueScore uint64, endBlueScore uint64) *GetScriptClassStatisticsRequestMessage {
+	return &GetScriptClassStatisticsRequestMessage{
+		StartBlueScore: startBlueScore,
+		EndBlueScore:   endBlueScore,
+	}
+}
+
+// GetScriptClassStatisticsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetScriptClassStatisticsResponseMessage struct {
+	baseMessage
+	WindowSize uint64
+	Windows    []*ScriptClassStatisticsWindow
+
+	Error *RPCError
+}
+
+// ScriptClassStatisticsWindow holds the statistics of the outputs accepted
+// by the chain blocks within a single blue score window
+type ScriptClassStatisticsWindow struct {
+	StartBlueScore uint64
+	ScriptClasses  []*ScriptClassStatistics
+}
+
+// ScriptClassStatistics holds the statistics of the outputs of a single script class
+type ScriptClassStatistics struct {
+	ScriptClass string
+	OutputCount uint64
+	TotalAmount uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetScriptClassStatisticsResponseMessage) Command() MessageCommand {
+	return CmdGetScriptClassStatisticsResponseMessage
+}
+
+// NewGetScriptClassStatisticsResponseMessage returns a instance of the message
+func NewGetScriptClassStatisticsResponseMessage(windowSize uint64, windows []*ScriptClassStatisticsWindow) *GetScriptClassStatisticsResponseMessage {
+	return &GetScriptClassStatisticsResponseMessage{
+		WindowSize: windowSize,
+		Windows:    windows,
+	}
+}
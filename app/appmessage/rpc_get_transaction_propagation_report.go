@@ -0,0 +1,61 @@
+package appmessage
+
+// GetTransactionPropagationReportRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionPropagationReportRequestMessage struct {
+	baseMessage
+	TransactionID string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionPropagationReportRequestMessage) Command() MessageCommand {
+	return CmdGetTransactionPropagationReportRequestMessage
+}
+
+// NewGetTransactionPropagationReportRequestMessage returns a instance of the message
+func NewGetTransactionPropagationReportRequestMessage(transactionID string) *GetTransactionPropagationReportRequestMessage {
+	return &GetTransactionPropagationReportRequestMessage{
+		TransactionID: transactionID,
+	}
+}
+
+// GetTransactionPropagationReportResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionPropagationReportResponseMessage struct {
+	baseMessage
+	TransactionID      string
+	SubmittedAt        int64
+	AnnouncedPeerCount uint32
+	RequestedPeerCount uint32
+	Announcements      []*TransactionPropagationEvent
+	Requests           []*TransactionPropagationEvent
+
+	Error *RPCError
+}
+
+// TransactionPropagationEvent is a single announcement of a transaction to
+// a peer, or a single request of it by a peer
+type TransactionPropagationEvent struct {
+	PeerAddress string
+	Timestamp   int64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionPropagationReportResponseMessage) Command() MessageCommand {
+	return CmdGetTransactionPropagationReportResponseMessage
+}
+
+// NewGetTransactionPropagationReportResponseMessage returns a instance of the message
+func NewGetTransactionPropagationReportResponseMessage(transactionID string, submittedAt int64,
+	announcedPeerCount uint32, requestedPeerCount uint32, announcements []*TransactionPropagationEvent,
+	requests []*TransactionPropagationEvent) *GetTransactionPropagationReportResponseMessage {
+
+	return &GetTransactionPropagationReportResponseMessage{
+		TransactionID:      transactionID,
+		SubmittedAt:        submittedAt,
+		AnnouncedPeerCount: announcedPeerCount,
+		RequestedPeerCount: requestedPeerCount,
+		Announcements:      announcements,
+		Requests:           requests,
+	}
+}
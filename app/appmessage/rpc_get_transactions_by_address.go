@@ -0,0 +1,56 @@
+package appmessage
+
+// GetTransactionsByAddressRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionsByAddressRequestMessage struct {
+	baseMessage
+	Address string
+	Offset  uint64
+	Limit   uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionsByAddressRequestMessage) Command() MessageCommand {
+	return CmdGetTransactionsByAddressRequestMessage
+}
+
+// NewGetTransactionsByAddressRequestMessage returns a instance of the message
+func NewGetTransactionsByAddressRequestMessage(address string, offset uint64, limit uint32) *GetTransactionsByAddressRequestMessage {
+	return &GetTransactionsByAddressRequestMessage{
+		Address: address,
+		Offset:  offset,
+		Limit:   limit,
+	}
+}
+
+// GetTransactionsByAddressResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionsByAddressResponseMessage struct {
+	baseMessage
+	Transactions []*AddressTransaction
+	HasMore      bool
+
+	Error *RPCError
+}
+
+// AddressTransaction represents an accepted transaction that paid to or spent from an address
+type AddressTransaction struct {
+	TransactionID           string
+	AcceptingBlockHash      string
+	AcceptingBlockBlueScore uint64
+	Received                uint64
+	Sent                    uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionsByAddressResponseMessage) Command() MessageCommand {
+	return CmdGetTransactionsByAddressResponseMessage
+}
+
+// NewGetTransactionsByAddressResponseMessage returns a instance of the message
+func NewGetTransactionsByAddressResponseMessage(transactions []*AddressTransaction, hasMore bool) *GetTransactionsByAddressResponseMessage {
+	return &GetTransactionsByAddressResponseMessage{
+		Transactions: transactions,
+		HasMore:      hasMore,
+	}
+}
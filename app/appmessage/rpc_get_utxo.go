@@ -0,0 +1,53 @@
+package appmessage
+
+// GetUTXORequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetUTXORequestMessage struct {
+	baseMessage
+	Outpoint       *RPCOutpoint
+	IncludeMempool bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetUTXORequestMessage) Command() MessageCommand {
+	return CmdGetUTXORequestMessage
+}
+
+// NewGetUTXORequestMessage returns a instance of the message
+func NewGetUTXORequestMessage(outpoint *RPCOutpoint, includeMempool bool) *GetUTXORequestMessage {
+	return &GetUTXORequestMessage{
+		Outpoint:       outpoint,
+		IncludeMempool: includeMempool,
+	}
+}
+
+// GetUTXOResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetUTXOResponseMessage struct {
+	baseMessage
+	IsUnspent                    bool
+	UTXOEntry                    *RPCUTXOEntry
+	IsInMempool                  bool
+	SpendingMempoolTransactionID string
+	AcceptingBlockHash           string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetUTXOResponseMessage) Command() MessageCommand {
+	return CmdGetUTXOResponseMessage
+}
+
+// NewGetUTXOResponseMessage returns a instance of the message
+func NewGetUTXOResponseMessage(isUnspent bool, utxoEntry *RPCUTXOEntry, isInMempool bool,
+	spendingMempoolTransactionID string, acceptingBlockHash string) *GetUTXOResponseMessage {
+
+	return &GetUTXOResponseMessage{
+		IsUnspent:                    isUnspent,
+		UTXOEntry:                    utxoEntry,
+		IsInMempool:                  isInMempool,
+		SpendingMempoolTransactionID: spendingMempoolTransactionID,
+		AcceptingBlockHash:           acceptingBlockHash,
+	}
+}
@@ -0,0 +1,63 @@
+package appmessage
+
+// The versions of the RPC API. A version may change the behavior of existing
+// methods, and connections keep the behavior of the version they negotiated.
+const (
+	// RPCAPIVersion1 is the RPC API as it was before versioning was introduced.
+	// Connections that never negotiate a version get it.
+	RPCAPIVersion1 uint32 = 1
+
+	// RPCAPIVersion2 answers a failed GetBalanceByAddressRequestMessage with a
+	// GetBalanceByAddressResponseMessage rather than a GetUTXOsByAddressesResponseMessage
+	RPCAPIVersion2 uint32 = 2
+
+	// MinRPCAPIVersion is the oldest RPC API version this kaspad supports
+	MinRPCAPIVersion = RPCAPIVersion1
+
+	// MaxRPCAPIVersion is the newest RPC API version this kaspad supports
+	MaxRPCAPIVersion = RPCAPIVersion2
+)
+
+// NegotiateAPIVersionRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NegotiateAPIVersionRequestMessage struct {
+	baseMessage
+	Version uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *NegotiateAPIVersionRequestMessage) Command() MessageCommand {
+	return CmdNegotiateAPIVersionRequestMessage
+}
+
+// NewNegotiateAPIVersionRequestMessage returns a instance of the message
+func NewNegotiateAPIVersionRequestMessage(version uint32) *NegotiateAPIVersionRequestMessage {
+	return &NegotiateAPIVersionRequestMessage{
+		Version: version,
+	}
+}
+
+// NegotiateAPIVersionResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NegotiateAPIVersionResponseMessage struct {
+	baseMessage
+	Version    uint32
+	MinVersion uint32
+	MaxVersion uint32
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NegotiateAPIVersionResponseMessage) Command() MessageCommand {
+	return CmdNegotiateAPIVersionResponseMessage
+}
+
+// NewNegotiateAPIVersionResponseMessage returns a instance of the message
+func NewNegotiateAPIVersionResponseMessage(version uint32, minVersion uint32, maxVersion uint32) *NegotiateAPIVersionResponseMessage {
+	return &NegotiateAPIVersionResponseMessage{
+		Version:    version,
+		MinVersion: minVersion,
+		MaxVersion: maxVersion,
+	}
+}
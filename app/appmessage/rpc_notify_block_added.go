@@ -4,6 +4,8 @@ package appmessage
 // its respective RPC message
 type NotifyBlockAddedRequestMessage struct {
 	baseMessage
+	IncludeSnapshot                bool
+	CoalesceIntervalInMilliseconds uint32
 }
 
 // Command returns the protocol command string for the message
@@ -12,8 +14,13 @@ func (msg *NotifyBlockAddedRequestMessage) Command() MessageCommand {
 }
 
 // NewNotifyBlockAddedRequestMessage returns a instance of the message
-func NewNotifyBlockAddedRequestMessage() *NotifyBlockAddedRequestMessage {
-	return &NotifyBlockAddedRequestMessage{}
+func NewNotifyBlockAddedRequestMessage(includeSnapshot bool,
+	coalesceIntervalInMilliseconds uint32) *NotifyBlockAddedRequestMessage {
+
+	return &NotifyBlockAddedRequestMessage{
+		IncludeSnapshot:                includeSnapshot,
+		CoalesceIntervalInMilliseconds: coalesceIntervalInMilliseconds,
+	}
 }
 
 // NotifyBlockAddedResponseMessage is an appmessage corresponding to
@@ -51,3 +58,22 @@ func NewBlockAddedNotificationMessage(block *RPCBlock) *BlockAddedNotificationMe
 		Block: block,
 	}
 }
+
+// BlockAddedBatchNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type BlockAddedBatchNotificationMessage struct {
+	baseMessage
+	Blocks []*RPCBlock
+}
+
+// Command returns the protocol command string for the message
+func (msg *BlockAddedBatchNotificationMessage) Command() MessageCommand {
+	return CmdBlockAddedBatchNotificationMessage
+}
+
+// NewBlockAddedBatchNotificationMessage returns a instance of the message
+func NewBlockAddedBatchNotificationMessage(blocks []*RPCBlock) *BlockAddedBatchNotificationMessage {
+	return &BlockAddedBatchNotificationMessage{
+		Blocks: blocks,
+	}
+}
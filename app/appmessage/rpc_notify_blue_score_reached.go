@@ -0,0 +1,76 @@
+package appmessage
+
+// NotifyBlueScoreReachedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyBlueScoreReachedRequestMessage struct {
+	baseMessage
+	ID            string
+	BlueScore     uint64
+	BlockHash     string
+	Confirmations uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyBlueScoreReachedRequestMessage) Command() MessageCommand {
+	return CmdNotifyBlueScoreReachedRequestMessage
+}
+
+// NewNotifyBlueScoreReachedRequestMessage returns a instance of the message
+func NewNotifyBlueScoreReachedRequestMessage(id string, blueScore uint64, blockHash string, confirmations uint64) *NotifyBlueScoreReachedRequestMessage {
+	return &NotifyBlueScoreReachedRequestMessage{
+		ID:            id,
+		BlueScore:     blueScore,
+		BlockHash:     blockHash,
+		Confirmations: confirmations,
+	}
+}
+
+// NotifyBlueScoreReachedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyBlueScoreReachedResponseMessage struct {
+	baseMessage
+	TargetBlueScore uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyBlueScoreReachedResponseMessage) Command() MessageCommand {
+	return CmdNotifyBlueScoreReachedResponseMessage
+}
+
+// NewNotifyBlueScoreReachedResponseMessage returns a instance of the message
+func NewNotifyBlueScoreReachedResponseMessage(targetBlueScore uint64) *NotifyBlueScoreReachedResponseMessage {
+	return &NotifyBlueScoreReachedResponseMessage{
+		TargetBlueScore: targetBlueScore,
+	}
+}
+
+// BlueScoreReachedNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type BlueScoreReachedNotificationMessage struct {
+	baseMessage
+	ID                             string
+	BlockHash                      string
+	Confirmations                  uint64
+	TargetBlueScore                uint64
+	VirtualSelectedParentBlueScore uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *BlueScoreReachedNotificationMessage) Command() MessageCommand {
+	return CmdBlueScoreReachedNotificationMessage
+}
+
+// NewBlueScoreReachedNotificationMessage returns a instance of the message
+func NewBlueScoreReachedNotificationMessage(id string, blockHash string, confirmations uint64,
+	targetBlueScore uint64, virtualSelectedParentBlueScore uint64) *BlueScoreReachedNotificationMessage {
+
+	return &BlueScoreReachedNotificationMessage{
+		ID:                             id,
+		BlockHash:                      blockHash,
+		Confirmations:                  confirmations,
+		TargetBlueScore:                targetBlueScore,
+		VirtualSelectedParentBlueScore: virtualSelectedParentBlueScore,
+	}
+}
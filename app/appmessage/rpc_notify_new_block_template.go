@@ -4,6 +4,11 @@ package appmessage
 // its respective RPC message
 type NotifyNewBlockTemplateRequestMessage struct {
 	baseMessage
+
+	// PayAddress is set if the notifications should carry the new
+	// templates, whose coinbase pays to it with ExtraData
+	PayAddress string
+	ExtraData  string
 }
 
 // Command returns the protocol command string for the message
@@ -16,6 +21,17 @@ func NewNotifyNewBlockTemplateRequestMessage() *NotifyNewBlockTemplateRequestMes
 	return &NotifyNewBlockTemplateRequestMessage{}
 }
 
+// NewNotifyNewBlockTemplateWithTemplatesRequestMessage returns an instance of
+// the message that registers for notifications that carry the new templates
+func NewNotifyNewBlockTemplateWithTemplatesRequestMessage(payAddress string,
+	extraData string) *NotifyNewBlockTemplateRequestMessage {
+
+	return &NotifyNewBlockTemplateRequestMessage{
+		PayAddress: payAddress,
+		ExtraData:  extraData,
+	}
+}
+
 // NotifyNewBlockTemplateResponseMessage is an appmessage corresponding to
 // its respective RPC message
 type NotifyNewBlockTemplateResponseMessage struct {
@@ -37,6 +53,11 @@ func NewNotifyNewBlockTemplateResponseMessage() *NotifyNewBlockTemplateResponseM
 // its respective RPC message
 type NewBlockTemplateNotificationMessage struct {
 	baseMessage
+
+	// Block and IsSynced are set only for listeners that registered with a
+	// pay address
+	Block    *RPCBlock
+	IsSynced bool
 }
 
 // Command returns the protocol command string for the message
@@ -48,3 +69,14 @@ func (msg *NewBlockTemplateNotificationMessage) Command() MessageCommand {
 func NewNewBlockTemplateNotificationMessage() *NewBlockTemplateNotificationMessage {
 	return &NewBlockTemplateNotificationMessage{}
 }
+
+// NewNewBlockTemplateNotificationMessageWithTemplate returns an instance of
+// the message that carries the given template
+func NewNewBlockTemplateNotificationMessageWithTemplate(block *RPCBlock,
+	isSynced bool) *NewBlockTemplateNotificationMessage {
+
+	return &NewBlockTemplateNotificationMessage{
+		Block:    block,
+		IsSynced: isSynced,
+	}
+}
@@ -0,0 +1,63 @@
+package appmessage
+
+// NotifyPeerEventsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyPeerEventsRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyPeerEventsRequestMessage) Command() MessageCommand {
+	return CmdNotifyPeerEventsRequestMessage
+}
+
+// NewNotifyPeerEventsRequestMessage returns a instance of the message
+func NewNotifyPeerEventsRequestMessage() *NotifyPeerEventsRequestMessage {
+	return &NotifyPeerEventsRequestMessage{}
+}
+
+// NotifyPeerEventsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyPeerEventsResponseMessage struct {
+	baseMessage
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyPeerEventsResponseMessage) Command() MessageCommand {
+	return CmdNotifyPeerEventsResponseMessage
+}
+
+// NewNotifyPeerEventsResponseMessage returns a instance of the message
+func NewNotifyPeerEventsResponseMessage() *NotifyPeerEventsResponseMessage {
+	return &NotifyPeerEventsResponseMessage{}
+}
+
+// Peer events, as reported by PeerEventNotificationMessage
+const (
+	PeerEventConnected          = "connected"
+	PeerEventHandshakeCompleted = "handshakeCompleted"
+	PeerEventDisconnected       = "disconnected"
+	PeerEventBanned             = "banned"
+)
+
+// PeerEventNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type PeerEventNotificationMessage struct {
+	baseMessage
+	Event                          string
+	Address                        string
+	IsOutbound                     bool
+	ID                             string
+	UserAgent                      string
+	ProtocolVersion                uint32
+	Reason                         string
+	ConnectionDurationMilliseconds int64
+	Timestamp                      int64
+}
+
+// Command returns the protocol command string for the message
+func (msg *PeerEventNotificationMessage) Command() MessageCommand {
+	return CmdPeerEventNotificationMessage
+}
@@ -0,0 +1,55 @@
+package appmessage
+
+// NotifyTransactionConfirmedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyTransactionConfirmedRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyTransactionConfirmedRequestMessage) Command() MessageCommand {
+	return CmdNotifyTransactionConfirmedRequestMessage
+}
+
+// NewNotifyTransactionConfirmedRequestMessage returns an instance of the message
+func NewNotifyTransactionConfirmedRequestMessage() *NotifyTransactionConfirmedRequestMessage {
+	return &NotifyTransactionConfirmedRequestMessage{}
+}
+
+// NotifyTransactionConfirmedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyTransactionConfirmedResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyTransactionConfirmedResponseMessage) Command() MessageCommand {
+	return CmdNotifyTransactionConfirmedResponseMessage
+}
+
+// NewNotifyTransactionConfirmedResponseMessage returns an instance of the message
+func NewNotifyTransactionConfirmedResponseMessage() *NotifyTransactionConfirmedResponseMessage {
+	return &NotifyTransactionConfirmedResponseMessage{}
+}
+
+// TransactionConfirmedNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type TransactionConfirmedNotificationMessage struct {
+	baseMessage
+	TransactionID string
+	BlockHash     string
+}
+
+// Command returns the protocol command string for the message
+func (msg *TransactionConfirmedNotificationMessage) Command() MessageCommand {
+	return CmdTransactionConfirmedNotificationMessage
+}
+
+// NewTransactionConfirmedNotificationMessage returns an instance of the message
+func NewTransactionConfirmedNotificationMessage(transactionID string, blockHash string) *TransactionConfirmedNotificationMessage {
+	return &TransactionConfirmedNotificationMessage{
+		TransactionID: transactionID,
+		BlockHash:     blockHash,
+	}
+}
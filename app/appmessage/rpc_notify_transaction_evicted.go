@@ -0,0 +1,55 @@
+package appmessage
+
+// NotifyTransactionEvictedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyTransactionEvictedRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyTransactionEvictedRequestMessage) Command() MessageCommand {
+	return CmdNotifyTransactionEvictedRequestMessage
+}
+
+// NewNotifyTransactionEvictedRequestMessage returns an instance of the message
+func NewNotifyTransactionEvictedRequestMessage() *NotifyTransactionEvictedRequestMessage {
+	return &NotifyTransactionEvictedRequestMessage{}
+}
+
+// NotifyTransactionEvictedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyTransactionEvictedResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyTransactionEvictedResponseMessage) Command() MessageCommand {
+	return CmdNotifyTransactionEvictedResponseMessage
+}
+
+// NewNotifyTransactionEvictedResponseMessage returns an instance of the message
+func NewNotifyTransactionEvictedResponseMessage() *NotifyTransactionEvictedResponseMessage {
+	return &NotifyTransactionEvictedResponseMessage{}
+}
+
+// TransactionEvictedNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type TransactionEvictedNotificationMessage struct {
+	baseMessage
+	TransactionID string
+	Reason        string
+}
+
+// Command returns the protocol command string for the message
+func (msg *TransactionEvictedNotificationMessage) Command() MessageCommand {
+	return CmdTransactionEvictedNotificationMessage
+}
+
+// NewTransactionEvictedNotificationMessage returns an instance of the message
+func NewTransactionEvictedNotificationMessage(transactionID string, reason string) *TransactionEvictedNotificationMessage {
+	return &TransactionEvictedNotificationMessage{
+		TransactionID: transactionID,
+		Reason:        reason,
+	}
+}
@@ -0,0 +1,55 @@
+package appmessage
+
+// NotifyTransactionRemovedFromMempoolRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyTransactionRemovedFromMempoolRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyTransactionRemovedFromMempoolRequestMessage) Command() MessageCommand {
+	return CmdNotifyTransactionRemovedFromMempoolRequestMessage
+}
+
+// NewNotifyTransactionRemovedFromMempoolRequestMessage returns an instance of the message
+func NewNotifyTransactionRemovedFromMempoolRequestMessage() *NotifyTransactionRemovedFromMempoolRequestMessage {
+	return &NotifyTransactionRemovedFromMempoolRequestMessage{}
+}
+
+// NotifyTransactionRemovedFromMempoolResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyTransactionRemovedFromMempoolResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyTransactionRemovedFromMempoolResponseMessage) Command() MessageCommand {
+	return CmdNotifyTransactionRemovedFromMempoolResponseMessage
+}
+
+// NewNotifyTransactionRemovedFromMempoolResponseMessage returns an instance of the message
+func NewNotifyTransactionRemovedFromMempoolResponseMessage() *NotifyTransactionRemovedFromMempoolResponseMessage {
+	return &NotifyTransactionRemovedFromMempoolResponseMessage{}
+}
+
+// TransactionRemovedFromMempoolNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type TransactionRemovedFromMempoolNotificationMessage struct {
+	baseMessage
+	TransactionID string
+	Reason        string
+}
+
+// Command returns the protocol command string for the message
+func (msg *TransactionRemovedFromMempoolNotificationMessage) Command() MessageCommand {
+	return CmdTransactionRemovedFromMempoolNotificationMessage
+}
+
+// NewTransactionRemovedFromMempoolNotificationMessage returns an instance of the message
+func NewTransactionRemovedFromMempoolNotificationMessage(transactionID string, reason string) *TransactionRemovedFromMempoolNotificationMessage {
+	return &TransactionRemovedFromMempoolNotificationMessage{
+		TransactionID: transactionID,
+		Reason:        reason,
+	}
+}
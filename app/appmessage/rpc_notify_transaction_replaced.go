@@ -0,0 +1,57 @@
+package appmessage
+
+// NotifyTransactionReplacedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyTransactionReplacedRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyTransactionReplacedRequestMessage) Command() MessageCommand {
+	return CmdNotifyTransactionReplacedRequestMessage
+}
+
+// NewNotifyTransactionReplacedRequestMessage returns an instance of the message
+func NewNotifyTransactionReplacedRequestMessage() *NotifyTransactionReplacedRequestMessage {
+	return &NotifyTransactionReplacedRequestMessage{}
+}
+
+// NotifyTransactionReplacedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyTransactionReplacedResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyTransactionReplacedResponseMessage) Command() MessageCommand {
+	return CmdNotifyTransactionReplacedResponseMessage
+}
+
+// NewNotifyTransactionReplacedResponseMessage returns an instance of the message
+func NewNotifyTransactionReplacedResponseMessage() *NotifyTransactionReplacedResponseMessage {
+	return &NotifyTransactionReplacedResponseMessage{}
+}
+
+// TransactionReplacedNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type TransactionReplacedNotificationMessage struct {
+	baseMessage
+	ReplacedTransactionID    string
+	ReplacementTransactionID string
+}
+
+// Command returns the protocol command string for the message
+func (msg *TransactionReplacedNotificationMessage) Command() MessageCommand {
+	return CmdTransactionReplacedNotificationMessage
+}
+
+// NewTransactionReplacedNotificationMessage returns an instance of the message
+func NewTransactionReplacedNotificationMessage(replacedTransactionID string,
+	replacementTransactionID string) *TransactionReplacedNotificationMessage {
+
+	return &TransactionReplacedNotificationMessage{
+		ReplacedTransactionID:    replacedTransactionID,
+		ReplacementTransactionID: replacementTransactionID,
+	}
+}
@@ -4,7 +4,9 @@ package appmessage
 // its respective RPC message
 type NotifyVirtualSelectedParentChainChangedRequestMessage struct {
 	baseMessage
-	IncludeAcceptedTransactionIDs bool
+	IncludeAcceptedTransactionIDs  bool
+	IncludeSnapshot                bool
+	CoalesceIntervalInMilliseconds uint32
 }
 
 // Command returns the protocol command string for the message
@@ -13,11 +15,13 @@ func (msg *NotifyVirtualSelectedParentChainChangedRequestMessage) Command() Mess
 }
 
 // NewNotifyVirtualSelectedParentChainChangedRequestMessage returns an instance of the message
-func NewNotifyVirtualSelectedParentChainChangedRequestMessage(
-	includeAcceptedTransactionIDs bool) *NotifyVirtualSelectedParentChainChangedRequestMessage {
+func NewNotifyVirtualSelectedParentChainChangedRequestMessage(includeAcceptedTransactionIDs bool,
+	includeSnapshot bool, coalesceIntervalInMilliseconds uint32) *NotifyVirtualSelectedParentChainChangedRequestMessage {
 
 	return &NotifyVirtualSelectedParentChainChangedRequestMessage{
-		IncludeAcceptedTransactionIDs: includeAcceptedTransactionIDs,
+		IncludeAcceptedTransactionIDs:  includeAcceptedTransactionIDs,
+		IncludeSnapshot:                includeSnapshot,
+		CoalesceIntervalInMilliseconds: coalesceIntervalInMilliseconds,
 	}
 }
 
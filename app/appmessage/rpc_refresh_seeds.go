@@ -0,0 +1,40 @@
+package appmessage
+
+// RefreshSeedsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type RefreshSeedsRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *RefreshSeedsRequestMessage) Command() MessageCommand {
+	return CmdRefreshSeedsRequestMessage
+}
+
+// NewRefreshSeedsRequestMessage returns a instance of the message
+func NewRefreshSeedsRequestMessage() *RefreshSeedsRequestMessage {
+	return &RefreshSeedsRequestMessage{}
+}
+
+// RefreshSeedsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type RefreshSeedsResponseMessage struct {
+	baseMessage
+	NewAddressCount uint32
+	IsComplete      bool
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *RefreshSeedsResponseMessage) Command() MessageCommand {
+	return CmdRefreshSeedsResponseMessage
+}
+
+// NewRefreshSeedsResponseMessage returns a instance of the message
+func NewRefreshSeedsResponseMessage(newAddressCount uint32, isComplete bool) *RefreshSeedsResponseMessage {
+	return &RefreshSeedsResponseMessage{
+		NewAddressCount: newAddressCount,
+		IsComplete:      isComplete,
+	}
+}
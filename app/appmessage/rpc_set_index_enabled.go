@@ -0,0 +1,43 @@
+package appmessage
+
+// SetIndexEnabledRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type SetIndexEnabledRequestMessage struct {
+	baseMessage
+	IndexName string
+	Enabled   bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *SetIndexEnabledRequestMessage) Command() MessageCommand {
+	return CmdSetIndexEnabledRequestMessage
+}
+
+// NewSetIndexEnabledRequestMessage returns a instance of the message
+func NewSetIndexEnabledRequestMessage(indexName string, enabled bool) *SetIndexEnabledRequestMessage {
+	return &SetIndexEnabledRequestMessage{
+		IndexName: indexName,
+		Enabled:   enabled,
+	}
+}
+
+// SetIndexEnabledResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type SetIndexEnabledResponseMessage struct {
+	baseMessage
+	Status string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *SetIndexEnabledResponseMessage) Command() MessageCommand {
+	return CmdSetIndexEnabledResponseMessage
+}
+
+// NewSetIndexEnabledResponseMessage returns a instance of the message
+func NewSetIndexEnabledResponseMessage(status string) *SetIndexEnabledResponseMessage {
+	return &SetIndexEnabledResponseMessage{
+		Status: status,
+	}
+}
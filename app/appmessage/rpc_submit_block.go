@@ -6,6 +6,7 @@ type SubmitBlockRequestMessage struct {
 	baseMessage
 	Block             *RPCBlock
 	AllowNonDAABlocks bool
+	IdempotencyKey    string
 }
 
 // Command returns the protocol command string for the message
@@ -102,4 +103,7 @@ type RPCBlockVerboseData struct {
 	MergeSetBluesHashes []string
 	MergeSetRedsHashes  []string
 	IsChainBlock        bool
+	TotalMass           uint64
+	TotalFees           uint64
+	AreFeesKnown        bool
 }
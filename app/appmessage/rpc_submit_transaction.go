@@ -4,8 +4,9 @@ package appmessage
 // its respective RPC message
 type SubmitTransactionRequestMessage struct {
 	baseMessage
-	Transaction *RPCTransaction
-	AllowOrphan bool
+	Transaction    *RPCTransaction
+	AllowOrphan    bool
+	IdempotencyKey string
 }
 
 // Command returns the protocol command string for the message
@@ -102,14 +103,18 @@ type RPCTransactionVerboseData struct {
 	Mass          uint64
 	BlockHash     string
 	BlockTime     uint64
+	Fee           uint64
+	IsFeeKnown    bool
 }
 
 // RPCTransactionInputVerboseData holds data about a transaction input
 type RPCTransactionInputVerboseData struct {
+	SignatureScriptAsm string
 }
 
 // RPCTransactionOutputVerboseData holds data about a transaction output
 type RPCTransactionOutputVerboseData struct {
 	ScriptPublicKeyType    string
 	ScriptPublicKeyAddress string
+	ScriptPublicKeyAsm     string
 }
@@ -0,0 +1,49 @@
+package appmessage
+
+// ValidateAddressRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ValidateAddressRequestMessage struct {
+	baseMessage
+	Address string
+}
+
+// Command returns the protocol command string for the message
+func (msg *ValidateAddressRequestMessage) Command() MessageCommand {
+	return CmdValidateAddressRequestMessage
+}
+
+// NewValidateAddressRequestMessage returns a instance of the message
+func NewValidateAddressRequestMessage(address string) *ValidateAddressRequestMessage {
+	return &ValidateAddressRequestMessage{
+		Address: address,
+	}
+}
+
+// ValidateAddressResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ValidateAddressResponseMessage struct {
+	baseMessage
+	IsValid       bool
+	InvalidReason string
+	Prefix        string
+	AddressType   string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ValidateAddressResponseMessage) Command() MessageCommand {
+	return CmdValidateAddressResponseMessage
+}
+
+// NewValidateAddressResponseMessage returns a instance of the message
+func NewValidateAddressResponseMessage(isValid bool, invalidReason string, prefix string,
+	addressType string) *ValidateAddressResponseMessage {
+
+	return &ValidateAddressResponseMessage{
+		IsValid:       isValid,
+		InvalidReason: invalidReason,
+		Prefix:        prefix,
+		AddressType:   addressType,
+	}
+}
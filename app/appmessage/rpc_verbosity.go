@@ -0,0 +1,29 @@
+package appmessage
+
+// RPCVerbosity selects how much of the blocks and transactions that block
+// and transaction RPCs return
+type RPCVerbosity uint32
+
+const (
+	// RPCVerbosityDefault returns the fields selected by the other flags
+	// of the request
+	RPCVerbosityDefault RPCVerbosity = iota
+
+	// RPCVerbosityHashesOnly returns only the hashes of blocks and the IDs
+	// and hashes of transactions
+	RPCVerbosityHashesOnly
+
+	// RPCVerbosityHeadersOnly returns block headers with their verbose
+	// data, without transactions, and transactions without their inputs
+	// and outputs
+	RPCVerbosityHeadersOnly
+
+	// RPCVerbosityFull returns blocks with all their transactions, and
+	// transactions with their scripts disassembled
+	RPCVerbosityFull
+)
+
+// IsValid returns whether the verbosity is one of the known verbosity levels
+func (v RPCVerbosity) IsValid() bool {
+	return v <= RPCVerbosityFull
+}
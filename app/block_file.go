@@ -0,0 +1,87 @@
+package app
+
+import (
+	"os"
+
+	"github.com/kaspanet/kaspad/app/blockfile"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// runBlockFileCommand runs --export-blocks or --import-blocks over the given
+// database, without starting any of the other components
+func runBlockFileCommand(cfg *config.Config, db database.Database) error {
+	domain, err := newDomain(cfg, db)
+	if err != nil {
+		log.Errorf("Unable to load the DAG: %+v", err)
+		return err
+	}
+
+	// Nothing listens to the consensus events, but consensus fails once
+	// their channel fills up
+	consensusEventsChannel := domain.ConsensusEventsChannel()
+	go func() {
+		for range consensusEventsChannel {
+		}
+	}()
+	defer close(consensusEventsChannel)
+
+	if cfg.ExportBlocks != "" {
+		err = exportBlocks(cfg, domain.Consensus())
+	} else {
+		err = importBlocks(cfg, domain.Consensus())
+	}
+	if err != nil {
+		log.Errorf("%+v", err)
+	}
+	return err
+}
+
+func exportBlocks(cfg *config.Config, consensus externalapi.Consensus) error {
+	file, err := os.OpenFile(cfg.ExportBlocks, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed creating the block file")
+	}
+
+	log.Infof("Exporting blocks to %s", cfg.ExportBlocks)
+	exportedBlockCount, err := blockfile.Export(consensus, cfg.ActiveNetParams, file,
+		func(processedBlockCount uint64, progressPercent int) {
+			log.Infof("Exported %d blocks (%d%%)", processedBlockCount, progressPercent)
+		})
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return errors.Wrapf(err, "failed closing the block file")
+	}
+	log.Infof("Exported %d blocks to %s", exportedBlockCount, cfg.ExportBlocks)
+	return nil
+}
+
+func importBlocks(cfg *config.Config, consensus externalapi.Consensus) error {
+	file, err := os.Open(cfg.ImportBlocks)
+	if err != nil {
+		return errors.Wrapf(err, "failed opening the block file")
+	}
+	defer file.Close()
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	log.Infof("Importing blocks from %s", cfg.ImportBlocks)
+	insertedBlockCount, skippedBlockCount, err := blockfile.Import(consensus, cfg.ActiveNetParams, file,
+		uint64(fileInfo.Size()), func(processedBlockCount uint64, progressPercent int) {
+			log.Infof("Processed %d blocks (%d%%)", processedBlockCount, progressPercent)
+		})
+	if err != nil {
+		return err
+	}
+	log.Infof("Imported %d blocks from %s. %d blocks already existed and were skipped",
+		insertedBlockCount, cfg.ImportBlocks, skippedBlockCount)
+	return nil
+}
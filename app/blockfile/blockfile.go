@@ -0,0 +1,180 @@
+package blockfile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+var magic = [8]byte{'K', 'A', 'S', 'B', 'L', 'O', 'C', 'K'}
+
+const (
+	// Version is the version of the block file format that's written
+	Version = 1
+
+	maxNetworkNameSize = 256
+	maxBlockSize       = 64 * 1024 * 1024
+)
+
+// Writer writes blocks into a block file
+type Writer struct {
+	writer *bufio.Writer
+}
+
+// NewWriter writes the file header for the given network, and returns a Writer
+// for the blocks that follow it
+func NewWriter(writer io.Writer, network string) (*Writer, error) {
+	bufferedWriter := bufio.NewWriter(writer)
+	_, err := bufferedWriter.Write(magic[:])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = binary.Write(bufferedWriter, binary.LittleEndian, uint32(Version))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = writeSizePrefixed(bufferedWriter, []byte(network))
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{writer: bufferedWriter}, nil
+}
+
+// WriteBlock writes the given block as the next block record
+func (w *Writer) WriteBlock(block *externalapi.DomainBlock) error {
+	message, err := protowire.FromAppMessage(appmessage.DomainBlockToMsgBlock(block))
+	if err != nil {
+		return err
+	}
+	serializedBlock, err := proto.Marshal(message)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return writeSizePrefixed(w.writer, serializedBlock)
+}
+
+// Flush writes any buffered blocks to the underlying writer
+func (w *Writer) Flush() error {
+	return errors.WithStack(w.writer.Flush())
+}
+
+func writeSizePrefixed(writer io.Writer, data []byte) error {
+	err := binary.Write(writer, binary.LittleEndian, uint32(len(data)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = writer.Write(data)
+	return errors.WithStack(err)
+}
+
+// Reader reads blocks from a block file
+type Reader struct {
+	reader *bufio.Reader
+	// bytesRead is the amount of bytes read from the file so far,
+	// which is used to report the progress of reading it
+	bytesRead uint64
+}
+
+// NewReader reads and validates the file header, and returns a Reader for the
+// blocks that follow it, along with the network the blocks belong to
+func NewReader(reader io.Reader) (blockReader *Reader, network string, err error) {
+	blockReader = &Reader{reader: bufio.NewReader(reader)}
+
+	var fileMagic [len(magic)]byte
+	err = blockReader.readFull(fileMagic[:])
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed reading the block file header")
+	}
+	if fileMagic != magic {
+		return nil, "", errors.New("not a block file")
+	}
+	var version uint32
+	err = blockReader.readUint32(&version)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed reading the block file header")
+	}
+	if version != Version {
+		return nil, "", errors.Errorf("unsupported block file version %d. Expected version: %d", version, Version)
+	}
+	networkName, err := blockReader.readSizePrefixed(maxNetworkNameSize)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed reading the block file header")
+	}
+	return blockReader, string(networkName), nil
+}
+
+// ReadBlock reads the next block record. It returns io.EOF once there are no more blocks.
+func (r *Reader) ReadBlock() (*externalapi.DomainBlock, error) {
+	// A file that's cut between block records is complete, while a file that's
+	// cut in the middle of one is truncated
+	_, err := r.reader.Peek(1)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+
+	serializedBlock, err := r.readSizePrefixed(maxBlockSize)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading a block record")
+	}
+	message := &protowire.KaspadMessage{}
+	err = proto.Unmarshal(serializedBlock, message)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decoding a block record")
+	}
+	appMessage, err := message.ToAppMessage()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decoding a block record")
+	}
+	msgBlock, ok := appMessage.(*appmessage.MsgBlock)
+	if !ok {
+		return nil, errors.Errorf("expected a block record, but got a %s message", appMessage.Command())
+	}
+	return appmessage.MsgBlockToDomainBlock(msgBlock), nil
+}
+
+// BytesRead returns the amount of bytes read from the file so far
+func (r *Reader) BytesRead() uint64 {
+	return r.bytesRead
+}
+
+func (r *Reader) readFull(data []byte) error {
+	n, err := io.ReadFull(r.reader, data)
+	r.bytesRead += uint64(n)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return errors.WithStack(err)
+}
+
+func (r *Reader) readUint32(value *uint32) error {
+	var data [4]byte
+	err := r.readFull(data[:])
+	if err != nil {
+		return err
+	}
+	*value = binary.LittleEndian.Uint32(data[:])
+	return nil
+}
+
+func (r *Reader) readSizePrefixed(maxSize uint32) ([]byte, error) {
+	var size uint32
+	err := r.readUint32(&size)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxSize {
+		return nil, errors.Errorf("record size %d is above the maximum of %d", size, maxSize)
+	}
+	data := make([]byte, size)
+	err = r.readFull(data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
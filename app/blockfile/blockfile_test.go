@@ -0,0 +1,146 @@
+package blockfile
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/hashes"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestExportAndImport(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+	factory := consensus.NewFactory()
+	exportingConsensus, teardown, err := factory.NewTestConsensus(consensusConfig, "TestExportAndImport_export")
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardown(false)
+
+	// Build a chain with a side branch, so that the DAG has blocks off
+	// the selected chain, and tips in the anticone of the selected tip
+	tipHash := consensusConfig.GenesisHash
+	for i := 0; i < 10; i++ {
+		tipHash, _, err = exportingConsensus.AddBlock([]*externalapi.DomainHash{tipHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+	}
+	sideHash, _, err := exportingConsensus.AddBlock([]*externalapi.DomainHash{consensusConfig.GenesisHash}, nil, nil)
+	if err != nil {
+		t.Fatalf("AddBlock: %+v", err)
+	}
+	_, _, err = exportingConsensus.AddBlock([]*externalapi.DomainHash{tipHash, sideHash}, nil, nil)
+	if err != nil {
+		t.Fatalf("AddBlock: %+v", err)
+	}
+	_, _, err = exportingConsensus.AddBlock([]*externalapi.DomainHash{sideHash}, nil, nil)
+	if err != nil {
+		t.Fatalf("AddBlock: %+v", err)
+	}
+
+	blockFile := &bytes.Buffer{}
+	exportedBlockCount, err := Export(exportingConsensus, &consensusConfig.Params, blockFile, func(uint64, int) {})
+	if err != nil {
+		t.Fatalf("Export: %+v", err)
+	}
+	const expectedBlockCount = 13
+	if exportedBlockCount != expectedBlockCount {
+		t.Fatalf("Expected %d blocks to be exported, but got %d", expectedBlockCount, exportedBlockCount)
+	}
+
+	importingConsensus, teardown, err := factory.NewTestConsensus(consensusConfig, "TestExportAndImport_import")
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardown(false)
+
+	fileContent := blockFile.Bytes()
+	insertedBlockCount, skippedBlockCount, err := Import(importingConsensus, &consensusConfig.Params,
+		bytes.NewReader(fileContent), uint64(len(fileContent)), func(uint64, int) {})
+	if err != nil {
+		t.Fatalf("Import: %+v", err)
+	}
+	if insertedBlockCount != expectedBlockCount || skippedBlockCount != 0 {
+		t.Fatalf("Unexpected import: %d blocks inserted and %d skipped", insertedBlockCount, skippedBlockCount)
+	}
+
+	exportedTips, err := exportingConsensus.Tips()
+	if err != nil {
+		t.Fatalf("Tips: %+v", err)
+	}
+	importedTips, err := importingConsensus.Tips()
+	if err != nil {
+		t.Fatalf("Tips: %+v", err)
+	}
+	if !reflect.DeepEqual(hashesSet(exportedTips), hashesSet(importedTips)) {
+		t.Fatalf("Expected tips %s, but got %s", exportedTips, importedTips)
+	}
+	exportedVirtualSelectedParent, err := exportingConsensus.GetVirtualSelectedParent()
+	if err != nil {
+		t.Fatalf("GetVirtualSelectedParent: %+v", err)
+	}
+	importedVirtualSelectedParent, err := importingConsensus.GetVirtualSelectedParent()
+	if err != nil {
+		t.Fatalf("GetVirtualSelectedParent: %+v", err)
+	}
+	if !exportedVirtualSelectedParent.Equal(importedVirtualSelectedParent) {
+		t.Fatalf("Expected virtual selected parent %s, but got %s",
+			exportedVirtualSelectedParent, importedVirtualSelectedParent)
+	}
+
+	// Importing the same file again skips all of its blocks
+	insertedBlockCount, skippedBlockCount, err = Import(importingConsensus, &consensusConfig.Params,
+		bytes.NewReader(fileContent), uint64(len(fileContent)), func(uint64, int) {})
+	if err != nil {
+		t.Fatalf("Import: %+v", err)
+	}
+	if insertedBlockCount != 0 || skippedBlockCount != expectedBlockCount {
+		t.Fatalf("Unexpected import: %d blocks inserted and %d skipped", insertedBlockCount, skippedBlockCount)
+	}
+
+	// A file of another network is rejected
+	_, _, err = Import(importingConsensus, &dagconfig.TestnetParams, bytes.NewReader(fileContent),
+		uint64(len(fileContent)), func(uint64, int) {})
+	if err == nil || !strings.Contains(err.Error(), "belongs to") {
+		t.Fatalf("Expected importing a file of another network to fail, but got %+v", err)
+	}
+
+	// A truncated file is rejected
+	_, _, err = Import(importingConsensus, &consensusConfig.Params, bytes.NewReader(fileContent[:len(fileContent)-1]),
+		uint64(len(fileContent)-1), func(uint64, int) {})
+	if err == nil {
+		t.Fatalf("Expected importing a truncated file to fail")
+	}
+}
+
+func hashesSet(blockHashes []*externalapi.DomainHash) map[string]struct{} {
+	set := make(map[string]struct{}, len(blockHashes))
+	for _, blockHashString := range hashes.ToStrings(blockHashes) {
+		set[blockHashString] = struct{}{}
+	}
+	return set
+}
+
+func TestReaderRejectsInvalidHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "empty", content: nil},
+		{name: "wrong magic", content: []byte("NOTBLOCK\x01\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "unsupported version", content: []byte("KASBLOCK\x02\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "network name too long", content: []byte("KASBLOCK\x01\x00\x00\x00\xff\xff\x00\x00")},
+	}
+	for _, test := range tests {
+		_, _, err := NewReader(bytes.NewReader(test.content))
+		if err == nil {
+			t.Errorf("%s: expected NewReader to fail", test.name)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Package blockfile implements the block file format that's used by
+kaspad --export-blocks and kaspad --import-blocks, to bootstrap a node from a
+local file rather than over P2P.
+
+File format
+
+A block file is sequential, and consists of a file header followed by any
+number of block records, up to the end of the file. All integers are little
+endian.
+
+The file header is:
+
+	magic         8 bytes   "KASBLOCK"
+	version       uint32    currently 1
+	network size  uint32    the size of the network name
+	network       bytes     the name of the network the blocks belong to, e.g. kaspa-mainnet
+
+Each block record is:
+
+	size          uint32    the size of the block, at most 64 MiB
+	block         bytes     a KaspadMessage whose payload is a block, encoded
+	                        with protobuf as defined in messages.proto
+
+Blocks are written in topological order, so that the parents of every block
+appear before it. The genesis isn't included, since every node already has it.
+*/
+package blockfile
@@ -0,0 +1,128 @@
+package blockfile
+
+import (
+	"io"
+	"sort"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// ProgressFunc is called as blocks are exported or imported, with the amount
+// of blocks processed so far and the estimated progress in percent
+type ProgressFunc func(processedBlockCount uint64, progressPercent int)
+
+// Export writes all the blocks of the DAG, except for the genesis, to the
+// given writer in topological order, and returns the amount of blocks written.
+// It requires all the blocks since the genesis to have their bodies, which is
+// not the case for nodes that synced from a pruning point.
+func Export(consensus externalapi.Consensus, params *dagconfig.Params, writer io.Writer,
+	onProgress ProgressFunc) (uint64, error) {
+
+	blockWriter, err := NewWriter(writer, params.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	virtualSelectedParent, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return 0, err
+	}
+	virtualSelectedParentInfo, err := consensus.GetBlockInfo(virtualSelectedParent)
+	if err != nil {
+		return 0, err
+	}
+
+	exportedBlockCount := uint64(0)
+	writeBlocks := func(blockHashes []*externalapi.DomainHash) error {
+		for _, blockHash := range blockHashes {
+			if blockHash.Equal(params.GenesisHash) {
+				continue
+			}
+			block, found, err := consensus.GetBlock(blockHash)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return errors.Errorf("block %s has no body. Only nodes that have all the blocks since "+
+					"the genesis can export them", blockHash)
+			}
+			err = blockWriter.WriteBlock(block)
+			if err != nil {
+				return err
+			}
+			exportedBlockCount++
+		}
+		return nil
+	}
+
+	// maxBlocks must be > MergeSetSizeLimit, so that every call makes progress
+	maxBlocks := params.MergeSetSizeLimit + 1
+	lowHash := params.GenesisHash
+	lastReportedProgressPercent := 0
+	for {
+		blockHashes, highHash, err := consensus.GetHashesBetween(lowHash, virtualSelectedParent, maxBlocks)
+		if err != nil {
+			return 0, err
+		}
+		// lowHash was already written by the previous iteration
+		if len(blockHashes) > 0 && blockHashes[0].Equal(lowHash) {
+			blockHashes = blockHashes[1:]
+		}
+		err = writeBlocks(blockHashes)
+		if err != nil {
+			return 0, err
+		}
+		if highHash.Equal(virtualSelectedParent) {
+			break
+		}
+
+		highBlockInfo, err := consensus.GetBlockInfo(highHash)
+		if err != nil {
+			return 0, err
+		}
+		progressPercent := int(float64(highBlockInfo.BlueScore) / float64(virtualSelectedParentInfo.BlueScore+1) * 100)
+		if progressPercent > lastReportedProgressPercent {
+			onProgress(exportedBlockCount, progressPercent)
+			lastReportedProgressPercent = progressPercent
+		}
+		lowHash = highHash
+	}
+
+	// GetHashesBetween only returns the past of the virtual selected parent,
+	// so the blocks in its anticone are written separately. A block has more
+	// blue work than all of its parents, so sorting by blue work keeps them
+	// in topological order.
+	virtualSelectedParentAnticone, err := consensus.Anticone(virtualSelectedParent)
+	if err != nil {
+		return 0, err
+	}
+	blueWorks := make(map[externalapi.DomainHash]*externalapi.BlockInfo, len(virtualSelectedParentAnticone))
+	for _, blockHash := range virtualSelectedParentAnticone {
+		blockInfo, err := consensus.GetBlockInfo(blockHash)
+		if err != nil {
+			return 0, err
+		}
+		blueWorks[*blockHash] = blockInfo
+	}
+	sort.Slice(virtualSelectedParentAnticone, func(i, j int) bool {
+		blueWorkI := blueWorks[*virtualSelectedParentAnticone[i]].BlueWork
+		blueWorkJ := blueWorks[*virtualSelectedParentAnticone[j]].BlueWork
+		if blueWorkI.Cmp(blueWorkJ) != 0 {
+			return blueWorkI.Cmp(blueWorkJ) < 0
+		}
+		return virtualSelectedParentAnticone[i].Less(virtualSelectedParentAnticone[j])
+	})
+	err = writeBlocks(virtualSelectedParentAnticone)
+	if err != nil {
+		return 0, err
+	}
+
+	err = blockWriter.Flush()
+	if err != nil {
+		return 0, err
+	}
+	onProgress(exportedBlockCount, 100)
+	return exportedBlockCount, nil
+}
@@ -0,0 +1,67 @@
+package blockfile
+
+import (
+	"io"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// Import validates and inserts all the blocks in the given block file, whose
+// size is used to estimate the progress. Blocks that already exist are skipped,
+// so an import that was interrupted can be resumed. It returns the amount of
+// blocks that were inserted and skipped.
+func Import(consensus externalapi.Consensus, params *dagconfig.Params, reader io.Reader, fileSize uint64,
+	onProgress ProgressFunc) (insertedBlockCount uint64, skippedBlockCount uint64, err error) {
+
+	blockReader, network, err := NewReader(reader)
+	if err != nil {
+		return 0, 0, err
+	}
+	if network != params.Name {
+		return 0, 0, errors.Errorf("the block file belongs to %s, while the node is on %s", network, params.Name)
+	}
+
+	lastReportedProgressPercent := 0
+	for {
+		block, err := blockReader.ReadBlock()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "failed reading block %d of the block file",
+				insertedBlockCount+skippedBlockCount+1)
+		}
+
+		// The virtual is resolved once all the blocks are inserted, since
+		// resolving it after every block is much slower
+		err = consensus.ValidateAndInsertBlock(block, false)
+		if err != nil {
+			if !errors.Is(err, ruleerrors.ErrDuplicateBlock) {
+				return 0, 0, errors.Wrapf(err, "invalid block %s", consensushashing.BlockHash(block))
+			}
+			skippedBlockCount++
+		} else {
+			insertedBlockCount++
+		}
+
+		progressPercent := 99
+		if fileSize > 0 && blockReader.BytesRead() < fileSize {
+			progressPercent = int(float64(blockReader.BytesRead()) / float64(fileSize) * 100)
+		}
+		if progressPercent > lastReportedProgressPercent {
+			onProgress(insertedBlockCount+skippedBlockCount, progressPercent)
+			lastReportedProgressPercent = progressPercent
+		}
+	}
+
+	err = consensus.ResolveVirtual(nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	onProgress(insertedBlockCount+skippedBlockCount, 100)
+	return insertedBlockCount, skippedBlockCount, nil
+}
@@ -2,35 +2,79 @@ package app
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
-
-	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"time"
 
 	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
 
 	"github.com/kaspanet/kaspad/app/protocol"
-	"github.com/kaspanet/kaspad/app/rpc"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/app/templatepush"
+
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/addrindex"
+	"github.com/kaspanet/kaspad/domain/blockratemonitor"
+	"github.com/kaspanet/kaspad/domain/coinageindex"
 	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/feehistoryindex"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/domain/kafkasink"
+	"github.com/kaspanet/kaspad/domain/mining/stratum"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/domain/payoutwatcher"
+	"github.com/kaspanet/kaspad/domain/scriptclassindex"
+	"github.com/kaspanet/kaspad/domain/sqlmirror"
+	"github.com/kaspanet/kaspad/domain/stxoindex"
+	"github.com/kaspanet/kaspad/domain/txindex"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	infrastructuredatabase "github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
-	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/id"
+	"github.com/kaspanet/kaspad/util"
 	"github.com/kaspanet/kaspad/util/panics"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
 )
 
 // ComponentManager is a wrapper for all the kaspad services
 type ComponentManager struct {
 	cfg               *config.Config
+	domain            domain.Domain
 	addressManager    *addressmanager.AddressManager
-	protocolManager   *protocol.Manager
-	rpcManager        *rpc.Manager
-	connectionManager *connmanager.ConnectionManager
+	protocolManager   ProtocolManager
+	rpcManager        RPCManager
+	connectionManager ConnectionManager
 	netAdapter        *netadapter.NetAdapter
 
+	// indexRetentionManager is nil if no index has a retention limit
+	indexRetentionManager *indexretention.Manager
+
+	// sqlMirror is nil unless --sqlmirror is set
+	sqlMirror *sqlmirror.Mirror
+
+	// kafkaSink is nil unless --kafkabroker is set
+	kafkaSink *kafkasink.Sink
+
+	// templatePusher is nil unless --templatepush is set
+	templatePusher *templatepush.Pusher
+
+	// stratumServer is nil unless --stratumlisten is set
+	stratumServer *stratum.Server
+
+	// blockRateMonitor is nil unless --blockratemonitor is set
+	blockRateMonitor *blockratemonitor.Monitor
+
+	// appliedCfg is the configuration the last reload applied, or cfg if
+	// the configuration wasn't reloaded yet
+	appliedCfg       *config.Config
+	reloadConfigLock sync.Mutex
+
 	started, shutdown int32
 }
 
@@ -49,6 +93,48 @@ func (a *ComponentManager) Start() {
 	}
 
 	a.connectionManager.Start()
+	a.rpcManager.Start()
+
+	if a.indexRetentionManager != nil {
+		a.indexRetentionManager.Start()
+	}
+
+	if a.sqlMirror != nil {
+		a.sqlMirror.Start()
+	}
+
+	if a.kafkaSink != nil {
+		a.kafkaSink.Start()
+	}
+
+	if a.templatePusher != nil {
+		a.templatePusher.Start()
+	}
+
+	if a.stratumServer != nil {
+		a.stratumServer.Start()
+	}
+
+	if a.blockRateMonitor != nil {
+		a.blockRateMonitor.Start()
+	}
+
+	if a.cfg.PrewarmUTXOCache {
+		go a.prewarmUTXOCache()
+	}
+}
+
+// prewarmUTXOCache loads the UTXOs that are the most likely to be spent soon
+// into the UTXO cache
+func (a *ComponentManager) prewarmUTXOCache() {
+	log.Infof("Pre-warming the UTXO cache in the background")
+	start := time.Now()
+	loaded, err := a.domain.Consensus().WarmUpVirtualUTXOSetCache()
+	if err != nil {
+		log.Warnf("Error pre-warming the UTXO cache: %s", err)
+		return
+	}
+	log.Infof("Pre-warmed the UTXO cache with %d UTXOs in %s", loaded, time.Since(start).Round(time.Millisecond))
 }
 
 // Stop gracefully shuts down all the kaspad services.
@@ -62,6 +148,31 @@ func (a *ComponentManager) Stop() {
 	log.Warnf("Kaspad shutting down")
 
 	a.connectionManager.Stop()
+	a.rpcManager.Stop()
+
+	if a.indexRetentionManager != nil {
+		a.indexRetentionManager.Stop()
+	}
+
+	if a.sqlMirror != nil {
+		a.sqlMirror.Stop()
+	}
+
+	if a.kafkaSink != nil {
+		a.kafkaSink.Stop()
+	}
+
+	if a.templatePusher != nil {
+		a.templatePusher.Stop()
+	}
+
+	if a.stratumServer != nil {
+		a.stratumServer.Stop()
+	}
+
+	if a.blockRateMonitor != nil {
+		a.blockRateMonitor.Stop()
+	}
 
 	err := a.netAdapter.Stop()
 	if err != nil {
@@ -69,16 +180,12 @@ func (a *ComponentManager) Stop() {
 	}
 
 	a.protocolManager.Close()
-	close(a.protocolManager.Context().Domain().ConsensusEventsChannel())
+	close(a.domain.ConsensusEventsChannel())
 
 	return
 }
 
-// NewComponentManager returns a new ComponentManager instance.
-// Use Start() to begin all services within this ComponentManager
-func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database, interrupt chan<- struct{}) (
-	*ComponentManager, error) {
-
+func newDomain(cfg *config.Config, db infrastructuredatabase.Database) (domain.Domain, error) {
 	consensusConfig := consensus.Config{
 		Params:                          *cfg.ActiveNetParams,
 		IsArchival:                      cfg.IsArchivalNode,
@@ -87,8 +194,44 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 	mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
 	mempoolConfig.MaximumOrphanTransactionCount = cfg.MaxOrphanTxs
 	mempoolConfig.MinimumRelayTransactionFee = cfg.MinRelayTxFee
+	mempoolConfig.DustRelayTransactionFee = cfg.DustRelayTxFee
+	mempoolConfig.MaximumStandardTransactionMass = cfg.MaxTxMass
+	mempoolConfig.MaximumTransactionPoolSize = cfg.MaxMempool * 1_000_000
+	mempoolConfig.AcceptNonStandard = cfg.RelayNonStd
+	mempoolConfig.AcceptReplacements = cfg.MempoolReplacement
+
+	return domain.New(&consensusConfig, mempoolConfig, db)
+}
 
-	domain, err := domain.New(&consensusConfig, mempoolConfig, db)
+// NewComponentManager returns a new ComponentManager instance.
+// Use Start() to begin all services within this ComponentManager
+func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database, interrupt chan<- struct{}) (
+	*ComponentManager, error) {
+
+	return NewComponentManagerWithRegistry(cfg, db, interrupt, DefaultComponentRegistry())
+}
+
+// NewComponentManagerWithRegistry returns a new ComponentManager instance whose
+// connection, protocol and RPC managers are built by the given registry.
+// Use Start() to begin all services within this ComponentManager
+func NewComponentManagerWithRegistry(cfg *config.Config, db infrastructuredatabase.Database,
+	interrupt chan<- struct{}, registry *ComponentRegistry) (*ComponentManager, error) {
+
+	return newComponentManager(cfg, db, interrupt, registry, nil)
+}
+
+// newComponentManager builds a ComponentManager, and reports the startup
+// stages it goes through to the given startupProgress, which may be nil
+func newComponentManager(cfg *config.Config, db infrastructuredatabase.Database, interrupt chan<- struct{},
+	registry *ComponentRegistry, progress *startupProgress) (*ComponentManager, error) {
+
+	progress.enterStage(startupStageLoadingDAG)
+	domain, err := newDomain(cfg, db)
+	if err != nil {
+		return nil, err
+	}
+
+	err = warnIfContradictingCheckpoints(cfg, domain)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +246,7 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 		return nil, err
 	}
 
+	progress.enterStage(startupStageLoadingIndexes)
 	var utxoIndex *utxoindex.UTXOIndex
 	if cfg.UTXOIndex {
 		utxoIndex, err = utxoindex.New(domain, db)
@@ -113,54 +257,422 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 		log.Infof("UTXO index started")
 	}
 
-	connectionManager, err := connmanager.New(cfg, netAdapter, addressManager)
+	// The indexes that follow the virtual selected parent chain are only
+	// loaded once they're first used
+	stxoIndex := rpccontext.NewOptionalIndex("stxoindex", cfg.STXOIndex, func() (rpccontext.ChainIndex, error) {
+		return stxoindex.New(domain, db)
+	})
+	scriptClassIndex := rpccontext.NewOptionalIndex("scriptclassindex", cfg.ScriptClassIndex, func() (rpccontext.ChainIndex, error) {
+		return scriptclassindex.New(domain, db)
+	})
+	feeHistoryIndex := rpccontext.NewOptionalIndex("feehistoryindex", cfg.FeeHistoryIndex, func() (rpccontext.ChainIndex, error) {
+		return feehistoryindex.New(domain, db)
+	})
+	coinAgeIndex := rpccontext.NewOptionalIndex("coinageindex", cfg.CoinAgeIndex, func() (rpccontext.ChainIndex, error) {
+		return coinageindex.New(domain, db)
+	})
+	txIndex := rpccontext.NewOptionalIndex("txindex", cfg.TXIndex, func() (rpccontext.ChainIndex, error) {
+		return txindex.New(domain, db)
+	})
+	addrIndex := rpccontext.NewOptionalIndex("addrindex", cfg.AddrIndex, func() (rpccontext.ChainIndex, error) {
+		return addrindex.New(domain, db)
+	})
+
+	indexRetentionManager := setupIndexRetention(cfg, db, stxoIndex, scriptClassIndex, feeHistoryIndex, coinAgeIndex, txIndex, addrIndex)
+
+	payoutWatcher, err := setupPayoutWatcher(cfg, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies := &ComponentDependencies{
+		Config:                cfg,
+		Domain:                domain,
+		NetAdapter:            netAdapter,
+		AddressManager:        addressManager,
+		UTXOIndex:             utxoIndex,
+		STXOIndex:             stxoIndex,
+		ScriptClassIndex:      scriptClassIndex,
+		FeeHistoryIndex:       feeHistoryIndex,
+		CoinAgeIndex:          coinAgeIndex,
+		TXIndex:               txIndex,
+		AddrIndex:             addrIndex,
+		IndexRetentionManager: indexRetentionManager,
+		PayoutWatcher:         payoutWatcher,
+		ShutDownChan:          interrupt,
+	}
+	// The RPC manager is built before the component manager, which the RPC
+	// reloads the configuration through, so it's only referenced once built
+	var componentManager *ComponentManager
+	dependencies.ReloadConfig = func() (reloadedOptions []string, restartRequiredOptions []string, err error) {
+		return componentManager.ReloadConfig()
+	}
+	dependencies.ConnectionManager, err = registry.NewConnectionManager(dependencies)
 	if err != nil {
 		return nil, err
 	}
-	protocolManager, err := protocol.NewManager(cfg, domain, netAdapter, addressManager, connectionManager)
+	dependencies.ProtocolManager, err = registry.NewProtocolManager(dependencies)
 	if err != nil {
 		return nil, err
 	}
-	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex, domain.ConsensusEventsChannel(), interrupt)
+	dependencies.BlockRateMonitor, err = setupBlockRateMonitor(cfg, domain, dependencies.ProtocolManager)
+	if err != nil {
+		return nil, err
+	}
+	rpcManager, err := registry.NewRPCManager(dependencies)
+	if err != nil {
+		return nil, err
+	}
+	wireComponents(dependencies.ProtocolManager, rpcManager)
+
+	sqlMirror, err := setupSQLMirror(cfg, domain)
+	if err != nil {
+		return nil, err
+	}
+	kafkaSink, err := setupKafkaSink(cfg, domain, db)
+	if err != nil {
+		return nil, err
+	}
+	wireChainChangedHandler(dependencies.ProtocolManager, sqlMirror, kafkaSink, payoutWatcher)
+	wireBlockAddedHandler(dependencies.ProtocolManager, kafkaSink, payoutWatcher)
+	wireKafkaSink(domain, dependencies.ProtocolManager, rpcManager, kafkaSink)
+	templatePusher, err := setupTemplatePusher(cfg, domain, dependencies.ProtocolManager)
+	if err != nil {
+		return nil, err
+	}
+	stratumServer, err := setupStratumServer(cfg, domain, dependencies.ProtocolManager)
+	if err != nil {
+		return nil, err
+	}
+	wireNewBlockTemplateHandler(dependencies.ProtocolManager, rpcManager, templatePusher, stratumServer)
 
-	return &ComponentManager{
-		cfg:               cfg,
-		protocolManager:   protocolManager,
-		rpcManager:        rpcManager,
-		connectionManager: connectionManager,
-		netAdapter:        netAdapter,
-		addressManager:    addressManager,
-	}, nil
+	componentManager = &ComponentManager{
+		cfg:                   cfg,
+		appliedCfg:            cfg,
+		domain:                domain,
+		indexRetentionManager: indexRetentionManager,
+		sqlMirror:             sqlMirror,
+		kafkaSink:             kafkaSink,
+		templatePusher:        templatePusher,
+		stratumServer:         stratumServer,
+		blockRateMonitor:      dependencies.BlockRateMonitor,
+		protocolManager:       dependencies.ProtocolManager,
+		rpcManager:            rpcManager,
+		connectionManager:     dependencies.ConnectionManager,
+		netAdapter:            netAdapter,
+		addressManager:        addressManager,
+	}
+	return componentManager, nil
+}
 
+// warnIfContradictingCheckpoints warns if the pruning points stored in the
+// database contradict the checkpoints of the network
+func warnIfContradictingCheckpoints(cfg *config.Config, domain domain.Domain) error {
+	if len(cfg.ActiveNetParams.Checkpoints) == 0 {
+		return nil
+	}
+	pruningPointHeaders, err := domain.Consensus().PruningPointHeaders()
+	if err != nil {
+		return err
+	}
+	err = cfg.ActiveNetParams.VerifyCheckpoints(pruningPointHeaders)
+	if errors.Is(err, dagconfig.ErrCheckpointContradiction) {
+		log.Criticalf("%s. The node is likely following a fake chain. Consider restarting it with --reset-db", err)
+		return nil
+	}
+	return err
 }
 
-func setupRPC(
+// setupIndexRetention returns a manager that prunes the enabled indexes according
+// to their retention limits, or nil if none of them has any
+func setupIndexRetention(
 	cfg *config.Config,
-	domain domain.Domain,
-	netAdapter *netadapter.NetAdapter,
-	protocolManager *protocol.Manager,
-	connectionManager *connmanager.ConnectionManager,
-	addressManager *addressmanager.AddressManager,
-	utxoIndex *utxoindex.UTXOIndex,
-	consensusEventsChan chan externalapi.ConsensusEvent,
-	shutDownChan chan<- struct{},
-) *rpc.Manager {
-
-	rpcManager := rpc.NewManager(
-		cfg,
-		domain,
-		netAdapter,
-		protocolManager,
-		connectionManager,
-		addressManager,
-		utxoIndex,
-		consensusEventsChan,
-		shutDownChan,
-	)
-	protocolManager.SetOnNewBlockTemplateHandler(rpcManager.NotifyNewBlockTemplate)
-	protocolManager.SetOnPruningPointUTXOSetOverrideHandler(rpcManager.NotifyPruningPointUTXOSetOverride)
-
-	return rpcManager
+	db infrastructuredatabase.Database,
+	stxoIndex *rpccontext.OptionalIndex,
+	scriptClassIndex *rpccontext.OptionalIndex,
+	feeHistoryIndex *rpccontext.OptionalIndex,
+	coinAgeIndex *rpccontext.OptionalIndex,
+	txIndex *rpccontext.OptionalIndex,
+	addrIndex *rpccontext.OptionalIndex,
+) *indexretention.Manager {
+
+	var policies []*indexretention.Policy
+	addPolicy := func(indexName string, index indexretention.Index, maxAge time.Duration, maxSize uint64) {
+		if maxAge == 0 && maxSize == 0 {
+			return
+		}
+		policies = append(policies, &indexretention.Policy{
+			IndexName: indexName,
+			Index:     index,
+			MaxAge:    maxAge,
+			MaxSize:   maxSize,
+		})
+	}
+	if cfg.STXOIndex {
+		addPolicy("STXO", stxoIndex, cfg.STXOIndexMaxAge, cfg.STXOIndexMaxSize)
+	}
+	if cfg.ScriptClassIndex {
+		addPolicy("script class", scriptClassIndex, cfg.ScriptClassIndexMaxAge, cfg.ScriptClassIndexMaxSize)
+	}
+	if cfg.FeeHistoryIndex {
+		addPolicy("fee history", feeHistoryIndex, cfg.FeeHistoryIndexMaxAge, cfg.FeeHistoryIndexMaxSize)
+	}
+	if cfg.CoinAgeIndex {
+		addPolicy("coin age", coinAgeIndex, cfg.CoinAgeIndexMaxAge, cfg.CoinAgeIndexMaxSize)
+	}
+	if cfg.TXIndex {
+		addPolicy("TX", txIndex, cfg.TXIndexMaxAge, cfg.TXIndexMaxSize)
+	}
+	if cfg.AddrIndex {
+		addPolicy("address", addrIndex, cfg.AddrIndexMaxAge, cfg.AddrIndexMaxSize)
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	log.Infof("Index retention enabled for %d indexes", len(policies))
+	return indexretention.New(db, cfg.ActiveNetParams.TargetTimePerBlock, policies)
+}
+
+// setupSQLMirror returns a mirror of the virtual selected parent chain, or
+// nil if --sqlmirror isn't set
+func setupSQLMirror(cfg *config.Config, domain domain.Domain) (*sqlmirror.Mirror, error) {
+	if cfg.SQLMirror == "" {
+		return nil, nil
+	}
+	mirror, err := sqlmirror.New(domain, cfg.ActiveNetParams, cfg.SQLMirror, cfg.SQLMirrorSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("SQL mirror enabled, mirroring into the %s schema", cfg.SQLMirrorSchema)
+	return mirror, nil
+}
+
+// setupKafkaSink returns a sink that publishes events to Kafka, or nil if
+// --kafkabroker isn't set
+func setupKafkaSink(cfg *config.Config, domain domain.Domain, db infrastructuredatabase.Database) (*kafkasink.Sink, error) {
+	if len(cfg.KafkaBrokers) == 0 {
+		return nil, nil
+	}
+	sink, err := kafkasink.New(domain, db, cfg.KafkaBrokers, cfg.KafkaTopicPrefix, cfg.ActiveNetParams.Name,
+		cfg.KafkaMaxQueuedEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Kafka event sink enabled, publishing to the %s.* topics", cfg.KafkaTopicPrefix)
+	return sink, nil
+}
+
+// setupPayoutWatcher returns a watcher of the blocks that pay to the address
+// of --payoutwatchaddress, or nil if it isn't set
+func setupPayoutWatcher(cfg *config.Config, domain domain.Domain) (*payoutwatcher.Watcher, error) {
+	if cfg.PayoutWatchAddress == "" {
+		return nil, nil
+	}
+	address, err := util.DecodeAddress(cfg.PayoutWatchAddress, cfg.NetParams().Prefix)
+	if err != nil {
+		return nil, err
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Payout watcher enabled, watching the blocks that pay to %s", cfg.PayoutWatchAddress)
+	return payoutwatcher.New(domain, cfg.NetParams(), cfg.PayoutWatchAddress, scriptPublicKey), nil
+}
+
+// setupBlockRateMonitor returns a monitor that alerts once the block rate or
+// the difficulty deviate from the targets of the network, or nil if
+// --blockratemonitor isn't set
+func setupBlockRateMonitor(cfg *config.Config, domain domain.Domain, protocolManager ProtocolManager) (
+	*blockratemonitor.Monitor, error) {
+
+	if !cfg.BlockRateMonitor {
+		return nil, nil
+	}
+	defaultProtocolManager, ok := protocolManager.(*protocol.Manager)
+	if !ok {
+		return nil, errors.Errorf("--blockratemonitor requires the default protocol manager, but got %T", protocolManager)
+	}
+	monitor := blockratemonitor.New(domain, cfg.NetParams().Name, cfg.NetParams().TargetTimePerBlock,
+		cfg.BlockRateWindow, cfg.BlockRateThreshold, cfg.BlockRateWebhook, defaultProtocolManager.Context().IsIBDRunning)
+
+	log.Infof("Block rate monitor enabled, measuring the block rate over %s", cfg.BlockRateWindow)
+	return monitor, nil
+}
+
+// wireChainChangedHandler lets the protocol manager notify the components
+// that follow the virtual selected parent chain of its every change. Any of
+// them may be nil.
+func wireChainChangedHandler(protocolManager ProtocolManager, sqlMirror *sqlmirror.Mirror,
+	kafkaSink *kafkasink.Sink, payoutWatcher *payoutwatcher.Watcher) {
+
+	if sqlMirror == nil && kafkaSink == nil && payoutWatcher == nil {
+		return
+	}
+	protocolManager.SetOnVirtualSelectedParentChainChangedHandler(
+		func(selectedParentChainChanges *externalapi.SelectedChainPath) error {
+			if sqlMirror != nil {
+				sqlMirror.Notify()
+			}
+			if kafkaSink != nil {
+				kafkaSink.NotifyVirtualSelectedParentChainChanged(selectedParentChainChanges)
+			}
+			if payoutWatcher != nil {
+				payoutWatcher.NotifyVirtualSelectedParentChainChanged(selectedParentChainChanges)
+			}
+			return nil
+		})
+}
+
+// wireBlockAddedHandler lets the protocol manager notify the components that
+// follow the blocks added to the DAG of every new block. Either of them may
+// be nil.
+func wireBlockAddedHandler(protocolManager ProtocolManager, kafkaSink *kafkasink.Sink,
+	payoutWatcher *payoutwatcher.Watcher) {
+
+	if kafkaSink == nil && payoutWatcher == nil {
+		return
+	}
+	protocolManager.SetOnBlockAddedHandler(func(block *externalapi.DomainBlock) error {
+		if kafkaSink != nil {
+			kafkaSink.NotifyBlockAdded(block)
+		}
+		if payoutWatcher != nil {
+			payoutWatcher.NotifyBlockAdded(block)
+		}
+		return nil
+	})
+}
+
+// wireKafkaSink lets the protocol manager and the mempool notify the Kafka
+// sink, if there's one, of the mempool events. They're passed on to the RPC
+// manager as well, as wireComponents does without a sink.
+func wireKafkaSink(domain domain.Domain, protocolManager ProtocolManager, rpcManager RPCManager,
+	kafkaSink *kafkasink.Sink) {
+
+	if kafkaSink == nil {
+		return
+	}
+	protocolManager.SetOnTransactionsEvictedHandler(
+		func(evictedTransactions []*miningmanagermodel.EvictedTransaction) error {
+			kafkaSink.NotifyTransactionsEvicted(evictedTransactions)
+			return rpcManager.NotifyTransactionsEvicted(evictedTransactions)
+		})
+	protocolManager.SetOnTransactionsRemovedFromMempoolHandler(
+		func(removedTransactions []*miningmanagermodel.RemovedTransaction) error {
+			kafkaSink.NotifyTransactionsRemovedFromMempool(removedTransactions)
+			return rpcManager.NotifyTransactionsRemovedFromMempool(removedTransactions)
+		})
+	protocolManager.SetOnTransactionsConfirmedHandler(
+		func(blockHash *externalapi.DomainHash, transactions []*externalapi.DomainTransaction) error {
+			kafkaSink.NotifyTransactionsConfirmed(blockHash, transactions)
+			return rpcManager.NotifyTransactionsConfirmed(blockHash, transactions)
+		})
+	domain.MiningManager().SetOnTransactionsAddedHandler(kafkaSink.NotifyTransactionsAddedToMempool)
+}
+
+// setupTemplatePusher returns a pusher that pushes every new block template to
+// the work distributor, or nil if --templatepush isn't set
+func setupTemplatePusher(cfg *config.Config, domain domain.Domain, protocolManager ProtocolManager) (
+	*templatepush.Pusher, error) {
+
+	if cfg.TemplatePush == "" {
+		return nil, nil
+	}
+	defaultProtocolManager, ok := protocolManager.(*protocol.Manager)
+	if !ok {
+		return nil, errors.Errorf("--templatepush requires the default protocol manager, but got %T", protocolManager)
+	}
+	payAddress, err := util.DecodeAddress(cfg.TemplatePushPayAddress, cfg.NetParams().Prefix)
+	if err != nil {
+		return nil, err
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(payAddress)
+	if err != nil {
+		return nil, err
+	}
+	coinbaseData := &externalapi.DomainCoinbaseData{
+		ScriptPublicKey: scriptPublicKey,
+		ExtraData:       []byte(version.Version() + "/" + cfg.TemplatePushExtraData),
+	}
+	pusher := templatepush.New(cfg.TemplatePush, domain, coinbaseData, cfg.NetParams().MaxCoinbasePayloadLength,
+		defaultProtocolManager.Context().HasPeers)
+
+	log.Infof("Template push enabled, pushing block templates to %s", cfg.TemplatePush)
+	return pusher, nil
+}
+
+// setupStratumServer returns a stratum server that serves jobs to miners, or
+// nil if --stratumlisten isn't set. Found blocks are submitted as the
+// SubmitBlock RPC submits them, and new jobs are served only while the node
+// is synced, unless blocks may be submitted when it's not.
+func setupStratumServer(cfg *config.Config, domain domain.Domain, protocolManager ProtocolManager) (
+	*stratum.Server, error) {
+
+	if cfg.StratumListen == "" {
+		return nil, nil
+	}
+	defaultProtocolManager, ok := protocolManager.(*protocol.Manager)
+	if !ok {
+		return nil, errors.Errorf("--stratumlisten requires the default protocol manager, but got %T", protocolManager)
+	}
+	payAddress, err := util.DecodeAddress(cfg.StratumPayAddress, cfg.NetParams().Prefix)
+	if err != nil {
+		return nil, err
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(payAddress)
+	if err != nil {
+		return nil, err
+	}
+	coinbaseData := &externalapi.DomainCoinbaseData{
+		ScriptPublicKey: scriptPublicKey,
+		ExtraData:       []byte(version.Version() + "/stratum"),
+	}
+	isSynced := func() (bool, error) {
+		if cfg.AllowSubmitBlockWhenNotSynced {
+			return true, nil
+		}
+		if !defaultProtocolManager.Context().HasPeers() {
+			return false, nil
+		}
+		return defaultProtocolManager.Context().IsNearlySynced()
+	}
+	submitBlock := func(block *externalapi.DomainBlock) error {
+		return defaultProtocolManager.AddBlock(block, "stratum")
+	}
+	server, err := stratum.New(cfg.StratumListen, domain, coinbaseData, cfg.NetParams().MaxCoinbasePayloadLength,
+		cfg.StratumShareDifficulty, isSynced, submitBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Stratum server listening on %s, paying to %s", server.Address(), cfg.StratumPayAddress)
+	return server, nil
+}
+
+// wireNewBlockTemplateHandler lets the protocol manager notify the template
+// pusher and the stratum server of every new block template, as well as the
+// RPC manager, as wireComponents does without them. Either of them may be
+// nil.
+func wireNewBlockTemplateHandler(protocolManager ProtocolManager, rpcManager RPCManager,
+	templatePusher *templatepush.Pusher, stratumServer *stratum.Server) {
+
+	if templatePusher == nil && stratumServer == nil {
+		return
+	}
+	protocolManager.SetOnNewBlockTemplateHandler(func() error {
+		if templatePusher != nil {
+			templatePusher.Notify()
+		}
+		if stratumServer != nil {
+			stratumServer.Notify()
+		}
+		return rpcManager.NotifyNewBlockTemplate()
+	})
 }
 
 // P2PNodeID returns the network ID associated with this ComponentManager
@@ -0,0 +1,195 @@
+package app
+
+import (
+	"github.com/kaspanet/kaspad/app/protocol"
+	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
+	"github.com/kaspanet/kaspad/app/rpc"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/blockratemonitor"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/domain/payoutwatcher"
+	"github.com/kaspanet/kaspad/domain/utxoindex"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+	"github.com/pkg/errors"
+)
+
+// ConnectionManager is the component that maintains the outgoing P2P connections
+type ConnectionManager interface {
+	Start()
+	Stop()
+}
+
+// ProtocolManager is the component that runs the P2P protocol flows
+type ProtocolManager interface {
+	Close()
+	SetOnNewBlockTemplateHandler(onNewBlockTemplateHandler flowcontext.OnNewBlockTemplateHandler)
+	SetOnPruningPointUTXOSetOverrideHandler(onPruningPointUTXOSetOverrideHandler flowcontext.OnPruningPointUTXOSetOverrideHandler)
+	SetOnTransactionsEvictedHandler(onTransactionsEvictedHandler flowcontext.OnTransactionsEvictedHandler)
+	SetOnTransactionsRemovedFromMempoolHandler(
+		onTransactionsRemovedFromMempoolHandler flowcontext.OnTransactionsRemovedFromMempoolHandler)
+	SetOnTransactionsConfirmedHandler(onTransactionsConfirmedHandler flowcontext.OnTransactionsConfirmedHandler)
+	SetOnPeerEventHandler(onPeerEventHandler flowcontext.OnPeerEventHandler)
+	SetOnVirtualSelectedParentChainChangedHandler(
+		onVirtualSelectedParentChainChangedHandler flowcontext.OnVirtualSelectedParentChainChangedHandler)
+	SetOnBlockAddedHandler(onBlockAddedHandler flowcontext.OnBlockAddedHandler)
+}
+
+// RPCManager is the component that serves the RPC requests and notifications
+type RPCManager interface {
+	Start()
+	Stop()
+	NotifyNewBlockTemplate() error
+	NotifyPruningPointUTXOSetOverride() error
+	NotifyTransactionsEvicted(evictedTransactions []*miningmanagermodel.EvictedTransaction) error
+	NotifyTransactionsRemovedFromMempool(removedTransactions []*miningmanagermodel.RemovedTransaction) error
+	NotifyTransactionsConfirmed(blockHash *externalapi.DomainHash, transactions []*externalapi.DomainTransaction) error
+	NotifyPeerEvent(event *flowcontext.PeerEvent) error
+}
+
+// PeerLimitsSetter is implemented by the connection managers whose peer
+// limits can be changed while they run, see ComponentManager.ReloadConfig
+type PeerLimitsSetter interface {
+	SetPeerLimits(targetOutgoing int, maxIncoming int)
+}
+
+// BanningPolicySetter is implemented by the protocol managers whose banning
+// policy can be changed while they run, see ComponentManager.ReloadConfig
+type BanningPolicySetter interface {
+	SetBanningPolicy(isBanningEnabled bool, banThreshold uint32)
+}
+
+var (
+	_ ConnectionManager   = (*connmanager.ConnectionManager)(nil)
+	_ ProtocolManager     = (*protocol.Manager)(nil)
+	_ RPCManager          = (*rpc.Manager)(nil)
+	_ PeerLimitsSetter    = (*connmanager.ConnectionManager)(nil)
+	_ BanningPolicySetter = (*protocol.Manager)(nil)
+)
+
+// ComponentDependencies holds everything a component factory may build its
+// component from. The components are built in the order connection manager,
+// protocol manager, RPC manager, so a factory only finds the components that
+// were built before its own.
+type ComponentDependencies struct {
+	Config         *config.Config
+	Domain         domain.Domain
+	NetAdapter     *netadapter.NetAdapter
+	AddressManager *addressmanager.AddressManager
+
+	// UTXOIndex is nil if it's disabled
+	UTXOIndex        *utxoindex.UTXOIndex
+	STXOIndex        *rpccontext.OptionalIndex
+	ScriptClassIndex *rpccontext.OptionalIndex
+	FeeHistoryIndex  *rpccontext.OptionalIndex
+	CoinAgeIndex     *rpccontext.OptionalIndex
+	TXIndex          *rpccontext.OptionalIndex
+	AddrIndex        *rpccontext.OptionalIndex
+
+	// IndexRetentionManager is nil if no index has a retention limit
+	IndexRetentionManager *indexretention.Manager
+
+	// PayoutWatcher is nil unless --payoutwatchaddress is set
+	PayoutWatcher *payoutwatcher.Watcher
+
+	// BlockRateMonitor is nil unless --blockratemonitor is set. It's built
+	// right after the protocol manager, whose IBD state it follows.
+	BlockRateMonitor *blockratemonitor.Monitor
+
+	// ReloadConfig reloads the configuration of the node, see
+	// ComponentManager.ReloadConfig
+	ReloadConfig rpccontext.ReloadConfigFunc
+
+	ConnectionManager ConnectionManager
+	ProtocolManager   ProtocolManager
+
+	// ShutDownChan is closed to request the node to shut down
+	ShutDownChan chan<- struct{}
+}
+
+// ComponentRegistry holds the factories the ComponentManager builds its
+// components with. Any of them may be replaced, for example by a mock in a
+// test or by a third-party implementation. Note that the default factories
+// build the concrete kaspad managers, which work only on top of each other, so
+// replacing the connection manager also requires replacing the protocol and
+// RPC managers, and replacing the protocol manager requires replacing the RPC
+// manager.
+type ComponentRegistry struct {
+	NewConnectionManager func(dependencies *ComponentDependencies) (ConnectionManager, error)
+	NewProtocolManager   func(dependencies *ComponentDependencies) (ProtocolManager, error)
+	NewRPCManager        func(dependencies *ComponentDependencies) (RPCManager, error)
+}
+
+// DefaultComponentRegistry returns a ComponentRegistry that builds the
+// regular kaspad components
+func DefaultComponentRegistry() *ComponentRegistry {
+	return &ComponentRegistry{
+		NewConnectionManager: newDefaultConnectionManager,
+		NewProtocolManager:   newDefaultProtocolManager,
+		NewRPCManager:        newDefaultRPCManager,
+	}
+}
+
+func newDefaultConnectionManager(dependencies *ComponentDependencies) (ConnectionManager, error) {
+	return connmanager.New(dependencies.Config, dependencies.NetAdapter, dependencies.AddressManager)
+}
+
+func newDefaultProtocolManager(dependencies *ComponentDependencies) (ProtocolManager, error) {
+	connectionManager, ok := dependencies.ConnectionManager.(*connmanager.ConnectionManager)
+	if !ok {
+		return nil, errors.Errorf("the default protocol manager requires the default connection manager, "+
+			"but got %T", dependencies.ConnectionManager)
+	}
+	return protocol.NewManager(dependencies.Config, dependencies.Domain, dependencies.NetAdapter,
+		dependencies.AddressManager, connectionManager)
+}
+
+func newDefaultRPCManager(dependencies *ComponentDependencies) (RPCManager, error) {
+	connectionManager, ok := dependencies.ConnectionManager.(*connmanager.ConnectionManager)
+	if !ok {
+		return nil, errors.Errorf("the default RPC manager requires the default connection manager, "+
+			"but got %T", dependencies.ConnectionManager)
+	}
+	protocolManager, ok := dependencies.ProtocolManager.(*protocol.Manager)
+	if !ok {
+		return nil, errors.Errorf("the default RPC manager requires the default protocol manager, "+
+			"but got %T", dependencies.ProtocolManager)
+	}
+	return rpc.NewManager(
+		dependencies.Config,
+		dependencies.Domain,
+		dependencies.NetAdapter,
+		protocolManager,
+		connectionManager,
+		dependencies.AddressManager,
+		dependencies.UTXOIndex,
+		dependencies.STXOIndex,
+		dependencies.ScriptClassIndex,
+		dependencies.FeeHistoryIndex,
+		dependencies.CoinAgeIndex,
+		dependencies.TXIndex,
+		dependencies.AddrIndex,
+		dependencies.IndexRetentionManager,
+		dependencies.PayoutWatcher,
+		dependencies.BlockRateMonitor,
+		dependencies.ReloadConfig,
+		dependencies.Domain.ConsensusEventsChannel(),
+		dependencies.ShutDownChan,
+	), nil
+}
+
+// wireComponents lets the protocol manager notify the RPC manager of the
+// events RPC clients may subscribe to
+func wireComponents(protocolManager ProtocolManager, rpcManager RPCManager) {
+	protocolManager.SetOnNewBlockTemplateHandler(rpcManager.NotifyNewBlockTemplate)
+	protocolManager.SetOnPruningPointUTXOSetOverrideHandler(rpcManager.NotifyPruningPointUTXOSetOverride)
+	protocolManager.SetOnTransactionsEvictedHandler(rpcManager.NotifyTransactionsEvicted)
+	protocolManager.SetOnTransactionsRemovedFromMempoolHandler(rpcManager.NotifyTransactionsRemovedFromMempool)
+	protocolManager.SetOnTransactionsConfirmedHandler(rpcManager.NotifyTransactionsConfirmed)
+	protocolManager.SetOnPeerEventHandler(rpcManager.NotifyPeerEvent)
+}
@@ -0,0 +1,218 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+type fakeConnectionManager struct {
+	started, stopped bool
+}
+
+func (f *fakeConnectionManager) Start() { f.started = true }
+func (f *fakeConnectionManager) Stop()  { f.stopped = true }
+
+type fakeProtocolManager struct {
+	closed                                     bool
+	onNewBlockTemplateHandler                  flowcontext.OnNewBlockTemplateHandler
+	onPruningPointUTXOSetOverrideHandler       flowcontext.OnPruningPointUTXOSetOverrideHandler
+	onTransactionsEvictedHandler               flowcontext.OnTransactionsEvictedHandler
+	onTransactionsRemovedFromMempoolHandler    flowcontext.OnTransactionsRemovedFromMempoolHandler
+	onTransactionsConfirmedHandler             flowcontext.OnTransactionsConfirmedHandler
+	onPeerEventHandler                         flowcontext.OnPeerEventHandler
+	onVirtualSelectedParentChainChangedHandler flowcontext.OnVirtualSelectedParentChainChangedHandler
+	onBlockAddedHandler                        flowcontext.OnBlockAddedHandler
+}
+
+func (f *fakeProtocolManager) Close() { f.closed = true }
+
+func (f *fakeProtocolManager) SetOnNewBlockTemplateHandler(handler flowcontext.OnNewBlockTemplateHandler) {
+	f.onNewBlockTemplateHandler = handler
+}
+
+func (f *fakeProtocolManager) SetOnPruningPointUTXOSetOverrideHandler(
+	handler flowcontext.OnPruningPointUTXOSetOverrideHandler) {
+
+	f.onPruningPointUTXOSetOverrideHandler = handler
+}
+
+func (f *fakeProtocolManager) SetOnTransactionsEvictedHandler(handler flowcontext.OnTransactionsEvictedHandler) {
+	f.onTransactionsEvictedHandler = handler
+}
+
+func (f *fakeProtocolManager) SetOnTransactionsRemovedFromMempoolHandler(
+	handler flowcontext.OnTransactionsRemovedFromMempoolHandler) {
+
+	f.onTransactionsRemovedFromMempoolHandler = handler
+}
+
+func (f *fakeProtocolManager) SetOnTransactionsConfirmedHandler(handler flowcontext.OnTransactionsConfirmedHandler) {
+	f.onTransactionsConfirmedHandler = handler
+}
+
+func (f *fakeProtocolManager) SetOnPeerEventHandler(handler flowcontext.OnPeerEventHandler) {
+	f.onPeerEventHandler = handler
+}
+
+func (f *fakeProtocolManager) SetOnVirtualSelectedParentChainChangedHandler(
+	handler flowcontext.OnVirtualSelectedParentChainChangedHandler) {
+
+	f.onVirtualSelectedParentChainChangedHandler = handler
+}
+
+func (f *fakeProtocolManager) SetOnBlockAddedHandler(handler flowcontext.OnBlockAddedHandler) {
+	f.onBlockAddedHandler = handler
+}
+
+type fakeRPCManager struct {
+	stopped       bool
+	notifications []string
+}
+
+func (f *fakeRPCManager) Start() {}
+func (f *fakeRPCManager) Stop()  { f.stopped = true }
+
+func (f *fakeRPCManager) NotifyNewBlockTemplate() error {
+	f.notifications = append(f.notifications, "NewBlockTemplate")
+	return nil
+}
+
+func (f *fakeRPCManager) NotifyPruningPointUTXOSetOverride() error {
+	f.notifications = append(f.notifications, "PruningPointUTXOSetOverride")
+	return nil
+}
+
+func (f *fakeRPCManager) NotifyTransactionsEvicted([]*miningmanagermodel.EvictedTransaction) error {
+	f.notifications = append(f.notifications, "TransactionsEvicted")
+	return nil
+}
+
+func (f *fakeRPCManager) NotifyTransactionsRemovedFromMempool([]*miningmanagermodel.RemovedTransaction) error {
+	f.notifications = append(f.notifications, "TransactionsRemovedFromMempool")
+	return nil
+}
+
+func (f *fakeRPCManager) NotifyTransactionsConfirmed(*externalapi.DomainHash, []*externalapi.DomainTransaction) error {
+	f.notifications = append(f.notifications, "TransactionsConfirmed")
+	return nil
+}
+
+func (f *fakeRPCManager) NotifyPeerEvent(*flowcontext.PeerEvent) error {
+	f.notifications = append(f.notifications, "PeerEvent")
+	return nil
+}
+
+func newTestComponentConfig(t *testing.T) *config.Config {
+	cfg := config.DefaultConfig()
+	*cfg.ActiveNetParams = dagconfig.SimnetParams
+	cfg.AppDir = t.TempDir()
+	cfg.Listeners = []string{"127.0.0.1:0"}
+	cfg.RPCListeners = []string{"127.0.0.1:0"}
+	return cfg
+}
+
+func TestComponentRegistry(t *testing.T) {
+	db, err := ldb.NewInMemoryLevelDB(8)
+	if err != nil {
+		t.Fatalf("NewInMemoryLevelDB: %+v", err)
+	}
+	defer db.Close()
+
+	connectionManager := &fakeConnectionManager{}
+	protocolManager := &fakeProtocolManager{}
+	rpcManager := &fakeRPCManager{}
+	registry := &ComponentRegistry{
+		NewConnectionManager: func(*ComponentDependencies) (ConnectionManager, error) {
+			return connectionManager, nil
+		},
+		NewProtocolManager: func(dependencies *ComponentDependencies) (ProtocolManager, error) {
+			if dependencies.ConnectionManager != connectionManager {
+				t.Errorf("the protocol manager factory didn't get the connection manager")
+			}
+			return protocolManager, nil
+		},
+		NewRPCManager: func(dependencies *ComponentDependencies) (RPCManager, error) {
+			if dependencies.ProtocolManager != protocolManager {
+				t.Errorf("the RPC manager factory didn't get the protocol manager")
+			}
+			return rpcManager, nil
+		},
+	}
+
+	componentManager, err := NewComponentManagerWithRegistry(newTestComponentConfig(t), db, make(chan struct{}), registry)
+	if err != nil {
+		t.Fatalf("NewComponentManagerWithRegistry: %+v", err)
+	}
+
+	err = protocolManager.onNewBlockTemplateHandler()
+	if err != nil {
+		t.Fatalf("onNewBlockTemplateHandler: %+v", err)
+	}
+	err = protocolManager.onPruningPointUTXOSetOverrideHandler()
+	if err != nil {
+		t.Fatalf("onPruningPointUTXOSetOverrideHandler: %+v", err)
+	}
+	err = protocolManager.onTransactionsEvictedHandler(nil)
+	if err != nil {
+		t.Fatalf("onTransactionsEvictedHandler: %+v", err)
+	}
+	err = protocolManager.onTransactionsRemovedFromMempoolHandler(nil)
+	if err != nil {
+		t.Fatalf("onTransactionsRemovedFromMempoolHandler: %+v", err)
+	}
+	err = protocolManager.onTransactionsConfirmedHandler(&externalapi.DomainHash{}, nil)
+	if err != nil {
+		t.Fatalf("onTransactionsConfirmedHandler: %+v", err)
+	}
+	err = protocolManager.onPeerEventHandler(&flowcontext.PeerEvent{})
+	if err != nil {
+		t.Fatalf("onPeerEventHandler: %+v", err)
+	}
+	if len(rpcManager.notifications) != 6 {
+		t.Fatalf("expected the protocol manager to notify the RPC manager of 6 events, but got %v",
+			rpcManager.notifications)
+	}
+
+	componentManager.Stop()
+	if !connectionManager.stopped || !rpcManager.stopped || !protocolManager.closed {
+		t.Fatalf("not all the components were stopped")
+	}
+	if connectionManager.started {
+		t.Fatalf("the connection manager was started although the component manager wasn't")
+	}
+}
+
+func TestDefaultComponentRegistryRequiresDefaultDependencies(t *testing.T) {
+	db, err := ldb.NewInMemoryLevelDB(8)
+	if err != nil {
+		t.Fatalf("NewInMemoryLevelDB: %+v", err)
+	}
+	defer db.Close()
+
+	registry := DefaultComponentRegistry()
+	registry.NewConnectionManager = func(*ComponentDependencies) (ConnectionManager, error) {
+		return &fakeConnectionManager{}, nil
+	}
+
+	_, err = NewComponentManagerWithRegistry(newTestComponentConfig(t), db, make(chan struct{}), registry)
+	if err == nil {
+		t.Fatalf("expected the default protocol manager to reject a substitute connection manager")
+	}
+
+	errFactory := errors.New("factory error")
+	registry = DefaultComponentRegistry()
+	registry.NewRPCManager = func(*ComponentDependencies) (RPCManager, error) {
+		return nil, errFactory
+	}
+	_, err = NewComponentManagerWithRegistry(newTestComponentConfig(t), db, make(chan struct{}), registry)
+	if !errors.Is(err, errFactory) {
+		t.Fatalf("expected the error of the RPC manager factory, but got %+v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+// ReloadConfig loads the configuration again, see config.Config.Reload, and
+// applies the changes of the reloadable options to the running components
+// without restarting them. It returns the names of the options whose changes
+// were applied, and of the ones whose changes take effect only after a restart.
+// The outcome is logged as well. The configuration is left as is if it fails to
+// load or its log level is invalid.
+func (a *ComponentManager) ReloadConfig() (reloadedOptions []string, restartRequiredOptions []string, err error) {
+	a.reloadConfigLock.Lock()
+	defer a.reloadConfigLock.Unlock()
+
+	newCfg, err := a.appliedCfg.Reload()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Changes are compared with the last applied configuration, so that each
+	// one is applied once, but the options that require a restart are compared
+	// with the configuration the node was started with, so that they're
+	// reported until it's restarted
+	changedOptions, _ := a.appliedCfg.ChangedOptions(newCfg)
+	_, restartRequiredOptions = a.cfg.ChangedOptions(newCfg)
+
+	isChanged := make(map[string]bool, len(changedOptions))
+	for _, name := range changedOptions {
+		isChanged[name] = true
+	}
+
+	// The log level is the only option that may be invalid at this point, so
+	// it's applied first, so that nothing is applied if it's invalid
+	if isChanged["loglevel"] {
+		err := logger.ParseAndSetLogLevels(newCfg.LogLevel)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid loglevel %s", newCfg.LogLevel)
+		}
+		reloadedOptions = append(reloadedOptions, "loglevel")
+	}
+
+	if isChanged["outpeers"] || isChanged["maxinpeers"] {
+		peerLimitsOptions := changedOf(isChanged, "maxinpeers", "outpeers")
+		if peerLimitsSetter, ok := a.connectionManager.(PeerLimitsSetter); ok {
+			peerLimitsSetter.SetPeerLimits(newCfg.TargetOutboundPeers, newCfg.MaxInboundPeers)
+			reloadedOptions = append(reloadedOptions, peerLimitsOptions...)
+		} else {
+			log.Warnf("The connection manager doesn't support changing its peer limits")
+			restartRequiredOptions = append(restartRequiredOptions, peerLimitsOptions...)
+		}
+	}
+
+	if isChanged["enablebanning"] || isChanged["banthreshold"] {
+		banningPolicyOptions := changedOf(isChanged, "banthreshold", "enablebanning")
+		if banningPolicySetter, ok := a.protocolManager.(BanningPolicySetter); ok {
+			banningPolicySetter.SetBanningPolicy(newCfg.EnableBanning, newCfg.BanThreshold)
+			reloadedOptions = append(reloadedOptions, banningPolicyOptions...)
+		} else {
+			log.Warnf("The protocol manager doesn't support changing its banning policy")
+			restartRequiredOptions = append(restartRequiredOptions, banningPolicyOptions...)
+		}
+	}
+
+	if isChanged["banduration"] {
+		a.addressManager.SetBanDuration(newCfg.BanDuration)
+		reloadedOptions = append(reloadedOptions, "banduration")
+	}
+
+	if isChanged["minrelaytxfee"] {
+		a.domain.MiningManager().SetMinimumRelayTransactionFee(newCfg.MinRelayTxFee)
+		reloadedOptions = append(reloadedOptions, "minrelaytxfee")
+	}
+
+	// The dust relay fee defaults to the minimum relay fee, so it's applied
+	// again when either changes
+	if isChanged["minrelaytxfee"] || isChanged["dustrelayfee"] {
+		a.domain.MiningManager().SetDustRelayTransactionFee(newCfg.DustRelayTxFee)
+		if isChanged["dustrelayfee"] {
+			reloadedOptions = append(reloadedOptions, "dustrelayfee")
+		}
+	}
+
+	a.appliedCfg = newCfg
+
+	sort.Strings(reloadedOptions)
+	sort.Strings(restartRequiredOptions)
+	if len(reloadedOptions) == 0 {
+		log.Infof("Reloaded the configuration, with no changes to apply")
+	} else {
+		log.Infof("Reloaded the configuration, applying the changes to %s", strings.Join(reloadedOptions, ", "))
+	}
+	if len(restartRequiredOptions) > 0 {
+		log.Warnf("The changes to %s take effect only after kaspad is restarted",
+			strings.Join(restartRequiredOptions, ", "))
+	}
+	return reloadedOptions, restartRequiredOptions, nil
+}
+
+// changedOf returns the names out of the given ones that are set in isChanged
+func changedOf(isChanged map[string]bool, names ...string) []string {
+	var changed []string
+	for _, name := range names {
+		if isChanged[name] {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/protocol"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/util"
+)
+
+func TestReloadConfig(t *testing.T) {
+	db, err := ldb.NewInMemoryLevelDB(8)
+	if err != nil {
+		t.Fatalf("NewInMemoryLevelDB: %+v", err)
+	}
+	defer db.Close()
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "simnet.conf")
+	writeConfigFile := func(content string) {
+		err := os.WriteFile(configFile, []byte("simnet=1\nappdir="+tmpDir+"\nlisten=127.0.0.1:0\n"+
+			"rpclisten=127.0.0.1:0\n"+content), 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+	}
+	writeConfigFile("")
+	cfg, err := config.LoadNetworkConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadNetworkConfig: %+v", err)
+	}
+
+	componentManager, err := NewComponentManagerWithRegistry(cfg, db, make(chan struct{}), DefaultComponentRegistry())
+	if err != nil {
+		t.Fatalf("NewComponentManagerWithRegistry: %+v", err)
+	}
+	banManager := componentManager.protocolManager.(*protocol.Manager).Context().BanManager()
+	if banManager.IsBanningEnabled() {
+		t.Fatalf("Expected banning to be disabled by default")
+	}
+
+	writeConfigFile("enablebanning=1\nminrelaytxfee=0.0001\noutpeers=2\nmaxorphantx=10\n")
+	reloadedOptions, restartRequiredOptions, err := componentManager.ReloadConfig()
+	if err != nil {
+		t.Fatalf("ReloadConfig: %+v", err)
+	}
+	expectedReloadedOptions := []string{"enablebanning", "minrelaytxfee", "outpeers"}
+	if !reflect.DeepEqual(reloadedOptions, expectedReloadedOptions) {
+		t.Fatalf("Expected the reloaded options %v, but got %v", expectedReloadedOptions, reloadedOptions)
+	}
+	expectedRestartRequiredOptions := []string{"maxorphantx"}
+	if !reflect.DeepEqual(restartRequiredOptions, expectedRestartRequiredOptions) {
+		t.Fatalf("Expected the options that require a restart %v, but got %v",
+			expectedRestartRequiredOptions, restartRequiredOptions)
+	}
+	if !banManager.IsBanningEnabled() {
+		t.Fatalf("Expected banning to be enabled once the configuration was reloaded")
+	}
+	minimumRelayTransactionFee := componentManager.domain.MiningManager().MinimumRelayTransactionFee()
+	if minimumRelayTransactionFee != util.Amount(10000) {
+		t.Fatalf("Expected a minimum relay fee of 10000 sompi, but got %d", minimumRelayTransactionFee)
+	}
+	// The dust relay fee isn't set, so it follows the minimum relay fee
+	dustRelayTransactionFee := componentManager.domain.MiningManager().DustRelayTransactionFee()
+	if dustRelayTransactionFee != util.Amount(10000) {
+		t.Fatalf("Expected a dust relay fee of 10000 sompi, but got %d", dustRelayTransactionFee)
+	}
+
+	// Reloading the same configuration again has nothing to apply, but the
+	// options that require a restart are still reported
+	reloadedOptions, restartRequiredOptions, err = componentManager.ReloadConfig()
+	if err != nil {
+		t.Fatalf("ReloadConfig: %+v", err)
+	}
+	if len(reloadedOptions) != 0 {
+		t.Fatalf("Expected no options to be reloaded, but got %v", reloadedOptions)
+	}
+	if !reflect.DeepEqual(restartRequiredOptions, expectedRestartRequiredOptions) {
+		t.Fatalf("Expected the options that require a restart %v, but got %v",
+			expectedRestartRequiredOptions, restartRequiredOptions)
+	}
+
+	// An invalid configuration is not applied at all
+	writeConfigFile("minrelaytxfee=0.001\ndbtype=other\n")
+	_, _, err = componentManager.ReloadConfig()
+	if err == nil {
+		t.Fatalf("Expected reloading an invalid configuration to fail")
+	}
+	minimumRelayTransactionFee = componentManager.domain.MiningManager().MinimumRelayTransactionFee()
+	if minimumRelayTransactionFee != util.Amount(10000) {
+		t.Fatalf("Expected the minimum relay fee to stay 10000 sompi, but got %d", minimumRelayTransactionFee)
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/encrypteddb"
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+)
+
+// applyDatabaseEncryption wraps the given database with encryption if a
+// passphrase was configured. Otherwise, it makes sure the database isn't
+// encrypted, so that the node doesn't fail later on when reading from it.
+func applyDatabaseEncryption(cfg *config.Config, db database.Database) (database.Database, error) {
+	passphrase, isEncryptionEnabled, err := databaseEncryptionPassphrase(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !isEncryptionEnabled {
+		isEncrypted, err := encrypteddb.IsEncrypted(db)
+		if err != nil {
+			return nil, err
+		}
+		if isEncrypted {
+			return nil, errors.New("the database is encrypted. Supply its passphrase with " +
+				"--dbencryptionkeyfile, --dbencryptionkeyenv or --dbencryptionkeyprompt")
+		}
+		return db, nil
+	}
+
+	log.Infof("Database encryption is enabled")
+	return encrypteddb.New(db, passphrase)
+}
+
+// databaseEncryptionPassphrase returns the database encryption passphrase
+// from the source that's configured, if any
+func databaseEncryptionPassphrase(cfg *config.Config) (passphrase []byte, isEncryptionEnabled bool, err error) {
+	switch {
+	case cfg.DbEncryptionKeyFile != "":
+		fileContent, err := os.ReadFile(cfg.DbEncryptionKeyFile)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed reading the database encryption key file")
+		}
+		passphrase = bytes.TrimRight(fileContent, "\r\n")
+	case cfg.DbEncryptionKeyEnv != "":
+		passphrase = []byte(os.Getenv(cfg.DbEncryptionKeyEnv))
+	case cfg.DbEncryptionKeyPrompt:
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return nil, false, errors.New("--dbencryptionkeyprompt requires kaspad to be run from a terminal")
+		}
+		fmt.Fprint(os.Stderr, "Database encryption passphrase: ")
+		passphrase, err = term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed reading the database encryption passphrase")
+		}
+	default:
+		return nil, false, nil
+	}
+
+	if len(passphrase) == 0 {
+		return nil, false, errors.New("the database encryption passphrase is empty")
+	}
+	return passphrase, true, nil
+}
@@ -0,0 +1,40 @@
+/*
+Package mempoolsnapshot implements the mempool snapshot format that's written
+by the ExportMempoolSnapshot RPC, to analyze the pending transactions of a node
+offline, e.g. during a spam attack, without writing a client that streams them.
+
+File format
+
+A snapshot file is a single JSON object:
+
+	{
+	  "version": 1,
+	  "network": "kaspa-mainnet",
+	  "timestamp": 1700000000000,
+	  "virtualDAAScore": 12345678,
+	  "transactions": [
+	    {
+	      "transactionId": "...",
+	      "rawTransaction": "...",
+	      "fee": 2036,
+	      "mass": 2036,
+	      "isOrphan": false,
+	      "isHighPriority": false,
+	      "addedAtDAAScore": 12345670
+	    }
+	  ]
+	}
+
+The timestamp is the time the snapshot was taken, in milliseconds since the
+epoch, and the virtual DAA score is the one of the node at that time, to compare
+addedAtDAAScore with. The transactions are ordered by the DAA score they were
+added to the mempool at, and then by their IDs.
+
+rawTransaction is a KaspadMessage with a Transaction payload, encoded with
+protobuf as defined in messages.proto and then in hex. That's the message a
+transaction is relayed in over P2P, and it doesn't include the fee and mass,
+which the mempool computes as it validates a transaction. The fee and mass of
+an orphan are 0, since the mempool can't validate it before its missing parents
+arrive.
+*/
+package mempoolsnapshot
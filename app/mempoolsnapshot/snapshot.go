@@ -0,0 +1,159 @@
+package mempoolsnapshot
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// Version is the version of the snapshot file format that's written
+const Version = 1
+
+// Snapshot is the content of a snapshot file
+type Snapshot struct {
+	Version         int            `json:"version"`
+	Network         string         `json:"network"`
+	Timestamp       int64          `json:"timestamp"`
+	VirtualDAAScore uint64         `json:"virtualDAAScore"`
+	Transactions    []*Transaction `json:"transactions"`
+}
+
+// Transaction is a pending transaction in a snapshot
+type Transaction struct {
+	TransactionID   string `json:"transactionId"`
+	RawTransaction  string `json:"rawTransaction"`
+	Fee             uint64 `json:"fee"`
+	Mass            uint64 `json:"mass"`
+	IsOrphan        bool   `json:"isOrphan"`
+	IsHighPriority  bool   `json:"isHighPriority"`
+	AddedAtDAAScore uint64 `json:"addedAtDAAScore"`
+}
+
+// New returns a snapshot of the given mempool entries, taken at the given time
+// and virtual DAA score
+func New(entries []*miningmanagermodel.MempoolSnapshotEntry, network string, timestamp mstime.Time,
+	virtualDAAScore uint64) (*Snapshot, error) {
+
+	transactions := make([]*Transaction, len(entries))
+	for i, entry := range entries {
+		rawTransaction, err := encodeTransaction(entry.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = &Transaction{
+			TransactionID:   consensushashing.TransactionID(entry.Transaction).String(),
+			RawTransaction:  rawTransaction,
+			Fee:             entry.Transaction.Fee,
+			Mass:            entry.Transaction.Mass,
+			IsOrphan:        entry.IsOrphan,
+			IsHighPriority:  entry.IsHighPriority,
+			AddedAtDAAScore: entry.AddedAtDAAScore,
+		}
+	}
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].AddedAtDAAScore != transactions[j].AddedAtDAAScore {
+			return transactions[i].AddedAtDAAScore < transactions[j].AddedAtDAAScore
+		}
+		return transactions[i].TransactionID < transactions[j].TransactionID
+	})
+
+	return &Snapshot{
+		Version:         Version,
+		Network:         network,
+		Timestamp:       timestamp.UnixMilliseconds(),
+		VirtualDAAScore: virtualDAAScore,
+		Transactions:    transactions,
+	}, nil
+}
+
+// WriteToFile writes the snapshot into a new file at the given path, which
+// must not exist. The file is removed if the write fails.
+func (s *Snapshot) WriteToFile(path string) (err error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed creating the snapshot file")
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(path)
+		}
+	}()
+
+	err = json.NewEncoder(file).Encode(s)
+	if err != nil {
+		return errors.Wrapf(err, "failed writing the snapshot file")
+	}
+	err = file.Close()
+	if err != nil {
+		return errors.Wrapf(err, "failed closing the snapshot file")
+	}
+	return nil
+}
+
+// ReadFile reads the snapshot in the file at the given path
+func ReadFile(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed opening the snapshot file")
+	}
+	defer file.Close()
+
+	snapshot := &Snapshot{}
+	err = json.NewDecoder(file).Decode(snapshot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decoding the snapshot file")
+	}
+	if snapshot.Version != Version {
+		return nil, errors.Errorf("unsupported snapshot version %d. Only version %d is supported",
+			snapshot.Version, Version)
+	}
+	return snapshot, nil
+}
+
+// DomainTransaction decodes the raw transaction, along with its fee and mass
+func (t *Transaction) DomainTransaction() (*externalapi.DomainTransaction, error) {
+	serializedMessage, err := hex.DecodeString(t.RawTransaction)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decoding the raw transaction of %s", t.TransactionID)
+	}
+	protoMessage := &protowire.KaspadMessage{}
+	err = proto.Unmarshal(serializedMessage, protoMessage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decoding the raw transaction of %s", t.TransactionID)
+	}
+	message, err := protoMessage.ToAppMessage()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decoding the raw transaction of %s", t.TransactionID)
+	}
+	msgTx, ok := message.(*appmessage.MsgTx)
+	if !ok {
+		return nil, errors.Errorf("the raw transaction of %s is a %s message", t.TransactionID, message.Command())
+	}
+	transaction := appmessage.MsgTxToDomainTransaction(msgTx)
+	transaction.Fee = t.Fee
+	transaction.Mass = t.Mass
+	return transaction, nil
+}
+
+func encodeTransaction(transaction *externalapi.DomainTransaction) (string, error) {
+	protoMessage, err := protowire.FromAppMessage(appmessage.DomainTransactionToMsgTx(transaction))
+	if err != nil {
+		return "", err
+	}
+	serializedMessage, err := proto.Marshal(protoMessage)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return hex.EncodeToString(serializedMessage), nil
+}
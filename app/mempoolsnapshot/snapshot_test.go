@@ -0,0 +1,103 @@
+package mempoolsnapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	newTransaction := func(lockTime uint64, fee uint64, mass uint64) *externalapi.DomainTransaction {
+		return &externalapi.DomainTransaction{
+			Version: 0,
+			Inputs: []*externalapi.DomainTransactionInput{{
+				PreviousOutpoint: externalapi.DomainOutpoint{
+					TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+					Index:         2,
+				},
+				SignatureScript: []byte{3, 4},
+				Sequence:        5,
+				SigOpCount:      1,
+			}},
+			Outputs: []*externalapi.DomainTransactionOutput{{
+				Value:           6,
+				ScriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{7, 8}, Version: 0},
+			}},
+			LockTime:     lockTime,
+			SubnetworkID: subnetworks.SubnetworkIDNative,
+			Payload:      []byte{},
+			Fee:          fee,
+			Mass:         mass,
+		}
+	}
+	entries := []*miningmanagermodel.MempoolSnapshotEntry{
+		{Transaction: newTransaction(1, 1000, 2000), AddedAtDAAScore: 20},
+		{Transaction: newTransaction(2, 0, 0), IsOrphan: true, AddedAtDAAScore: 10},
+		{Transaction: newTransaction(3, 3000, 4000), IsHighPriority: true, AddedAtDAAScore: 15},
+	}
+
+	timestamp := mstime.Now()
+	snapshot, err := New(entries, "kaspa-simnet", timestamp, 30)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+
+	dataDir, err := ioutil.TempDir("", "TestWriteAndRead")
+	if err != nil {
+		t.Fatalf("TempDir: %+v", err)
+	}
+	defer os.RemoveAll(dataDir)
+	path := filepath.Join(dataDir, "mempool.json")
+
+	err = snapshot.WriteToFile(path)
+	if err != nil {
+		t.Fatalf("WriteToFile: %+v", err)
+	}
+	// An existing file is never overwritten
+	err = snapshot.WriteToFile(path)
+	if err == nil {
+		t.Fatalf("Expected writing over an existing file to fail")
+	}
+
+	readSnapshot, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %+v", err)
+	}
+	if readSnapshot.Network != "kaspa-simnet" || readSnapshot.Timestamp != timestamp.UnixMilliseconds() ||
+		readSnapshot.VirtualDAAScore != 30 {
+		t.Fatalf("Unexpected snapshot header %+v", readSnapshot)
+	}
+
+	// The transactions are ordered by the DAA score they were added at
+	expectedEntries := []*miningmanagermodel.MempoolSnapshotEntry{entries[1], entries[2], entries[0]}
+	if len(readSnapshot.Transactions) != len(expectedEntries) {
+		t.Fatalf("Expected %d transactions, but got %d", len(expectedEntries), len(readSnapshot.Transactions))
+	}
+	for i, expectedEntry := range expectedEntries {
+		snapshotTransaction := readSnapshot.Transactions[i]
+		if snapshotTransaction.IsOrphan != expectedEntry.IsOrphan ||
+			snapshotTransaction.IsHighPriority != expectedEntry.IsHighPriority ||
+			snapshotTransaction.AddedAtDAAScore != expectedEntry.AddedAtDAAScore {
+			t.Fatalf("Transaction %d: unexpected metadata %+v", i, snapshotTransaction)
+		}
+		transaction, err := snapshotTransaction.DomainTransaction()
+		if err != nil {
+			t.Fatalf("DomainTransaction: %+v", err)
+		}
+		if !transaction.Equal(expectedEntry.Transaction) {
+			t.Fatalf("Transaction %d: expected %+v, but got %+v", i, expectedEntry.Transaction, transaction)
+		}
+		expectedTransactionID := consensushashing.TransactionID(expectedEntry.Transaction).String()
+		if snapshotTransaction.TransactionID != expectedTransactionID {
+			t.Fatalf("Transaction %d: expected ID %s, but got %s", i, expectedTransactionID,
+				snapshotTransaction.TransactionID)
+		}
+	}
+}
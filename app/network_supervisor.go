@@ -0,0 +1,160 @@
+package app
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+// loadNetworkConfigs loads the configurations of the networks to run alongside
+// the main one, and makes sure that the nodes of all the networks are isolated
+// from each other
+func loadNetworkConfigs(mainCfg *config.Config) ([]*config.Config, error) {
+	if len(mainCfg.NetworkConfigFiles) == 0 {
+		return nil, nil
+	}
+
+	networkCfgs := make([]*config.Config, 0, len(mainCfg.NetworkConfigFiles))
+	for _, networkConfigFile := range mainCfg.NetworkConfigFiles {
+		networkCfg, err := config.LoadNetworkConfig(networkConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		if networkCfg.ExportBlocks != "" || networkCfg.ImportBlocks != "" {
+			return nil, errors.Errorf("%s: export-blocks and import-blocks can't be used in the "+
+				"configuration of an additional network", networkConfigFile)
+		}
+		if networkCfg.ExportUTXOSnapshot != "" || networkCfg.ImportUTXOSnapshot != "" {
+			return nil, errors.Errorf("%s: export-utxo-snapshot and import-utxo-snapshot can't be used in the "+
+				"configuration of an additional network", networkConfigFile)
+		}
+		networkCfgs = append(networkCfgs, networkCfg)
+	}
+
+	err := checkNetworksAreIsolated(append([]*config.Config{mainCfg}, networkCfgs...))
+	if err != nil {
+		return nil, err
+	}
+	return networkCfgs, nil
+}
+
+// checkNetworksAreIsolated makes sure that no two of the given nodes share a
+// data directory, a listen address or a Unix socket
+func checkNetworksAreIsolated(cfgs []*config.Config) error {
+	appDirs := make(map[string]int)
+	addresses := make(map[string]int)
+	for i, cfg := range cfgs {
+		if other, ok := appDirs[cfg.AppDir]; ok {
+			return errors.Errorf("the %s and %s nodes can't share the data directory %s. "+
+				"Choose a different one with --appdir",
+				cfgs[other].NetParams().Name, cfg.NetParams().Name, cfg.AppDir)
+		}
+		appDirs[cfg.AppDir] = i
+
+		nodeAddresses := listenAddresses(cfg)
+		if cfg.RPCUnixSocket != "" && !cfg.DisableRPC {
+			nodeAddresses = append(nodeAddresses, "unix:"+cfg.RPCUnixSocket)
+		}
+		if cfg.StartupStatusSocket != "" {
+			nodeAddresses = append(nodeAddresses, "unix:"+cfg.StartupStatusSocket)
+		}
+		for _, address := range nodeAddresses {
+			if other, ok := addresses[address]; ok && other != i {
+				return errors.Errorf("the %s and %s nodes can't both listen on %s",
+					cfgs[other].NetParams().Name, cfg.NetParams().Name, address)
+			}
+			addresses[address] = i
+		}
+	}
+	return nil
+}
+
+// networkSupervisor runs the nodes of several networks in the same process.
+// Every node has its own data directory, listeners and RPC server. The
+// supervisor only ties their lifetimes together: once the process is
+// interrupted, or any of the nodes stops, for example because it failed to
+// start or was shut down via RPC, all the nodes are shut down.
+type networkSupervisor struct {
+	cfgs []*config.Config
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newNetworkSupervisor(cfgs []*config.Config) *networkSupervisor {
+	return &networkSupervisor{
+		cfgs: cfgs,
+		stop: make(chan struct{}),
+	}
+}
+
+func (s *networkSupervisor) stopAll() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+}
+
+// run runs all the nodes, and blocks until all of them have shut down. It
+// returns the first error any of the nodes failed with.
+func (s *networkSupervisor) run(interrupt <-chan struct{}, startedChan chan<- struct{}) error {
+	go func() {
+		select {
+		case <-interrupt:
+			s.stopAll()
+		case <-s.stop:
+		}
+	}()
+
+	errChan := make(chan error, len(s.cfgs))
+	nodeStartedChan := make(chan struct{}, len(s.cfgs))
+	for _, cfg := range s.cfgs {
+		cfg := cfg
+		networkName := cfg.NetParams().Name
+		shutDownChan := make(chan struct{})
+
+		go func() {
+			select {
+			case <-shutDownChan:
+				log.Infof("Shutdown of the %s node requested. Shutting down the nodes of all networks...",
+					networkName)
+				s.stopAll()
+			case <-s.stop:
+			}
+		}()
+
+		go func() {
+			log.Infof("Starting the %s node with data directory %s", networkName, cfg.AppDir)
+			err := runNode(cfg, s.stop, shutDownChan, nodeStartedChan)
+			if err != nil {
+				err = errors.Wrapf(err, "the %s node failed", networkName)
+			}
+			log.Infof("The %s node stopped", networkName)
+			s.stopAll()
+			errChan <- err
+		}()
+	}
+
+	go func() {
+		for range s.cfgs {
+			select {
+			case <-nodeStartedChan:
+			case <-s.stop:
+				return
+			}
+		}
+		log.Infof("The nodes of all %d networks have started", len(s.cfgs))
+		if startedChan != nil {
+			startedChan <- struct{}{}
+		}
+	}()
+
+	var firstErr error
+	for range s.cfgs {
+		err := <-errChan
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
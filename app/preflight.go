@@ -0,0 +1,219 @@
+package app
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/os/limits"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// reservedFileDescriptors is the amount of file descriptors kaspad needs regardless
+// of its configuration, e.g. for log files, DNS lookups and the profiling server
+const reservedFileDescriptors = 64
+
+// preflightCheck validates a single aspect of the configuration or of the
+// environment kaspad is about to run in. A failed check returns an error
+// that explains how to resolve it.
+type preflightCheck struct {
+	name  string
+	check func(cfg *config.Config) error
+}
+
+var preflightChecks = []preflightCheck{
+	{"configuration", checkConfigCombinations},
+	{"data directory", checkAppDirIsWritable},
+	{"listen addresses", checkListenAddressesAreBindable},
+	{"system clock", checkClock},
+	{"open file limit", checkFileLimit},
+}
+
+// runPreflightChecks runs all the preflight checks before any component is
+// started, so that misconfigurations are reported up front rather than
+// failing mid-operation. All failures are reported together.
+func runPreflightChecks(cfg *config.Config) error {
+	var failures []string
+	for _, preflightCheck := range preflightChecks {
+		err := preflightCheck.check(cfg)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", preflightCheck.name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.Errorf("preflight checks failed:\n\t%s", strings.Join(failures, "\n\t"))
+	}
+	return nil
+}
+
+func checkConfigCombinations(cfg *config.Config) error {
+	if cfg.DisableRPC && len(cfg.RPCListeners) > 0 {
+		return errors.New("--rpclisten has no effect when RPC is disabled. " +
+			"Remove either --norpc or --rpclisten")
+	}
+	if cfg.DisableRPC && len(cfg.RPCEndpoints) > 0 {
+		return errors.New("--rpcendpoint has no effect when RPC is disabled. " +
+			"Remove either --norpc or --rpcendpoint")
+	}
+	if cfg.ExportBlocks != "" && cfg.ImportBlocks != "" {
+		return errors.New("--export-blocks and --import-blocks can't be used together")
+	}
+	if len(cfg.NetworkConfigFiles) > 0 && (cfg.ExportBlocks != "" || cfg.ImportBlocks != "") {
+		return errors.New("--export-blocks and --import-blocks can't be used with --networkconfigfile. " +
+			"Export or import the blocks of every network separately")
+	}
+	if cfg.ExportUTXOSnapshot != "" && cfg.ImportUTXOSnapshot != "" {
+		return errors.New("--export-utxo-snapshot and --import-utxo-snapshot can't be used together")
+	}
+	if (cfg.ExportUTXOSnapshot != "" || cfg.ImportUTXOSnapshot != "") && (cfg.ExportBlocks != "" || cfg.ImportBlocks != "") {
+		return errors.New("--export-utxo-snapshot and --import-utxo-snapshot can't be used with " +
+			"--export-blocks or --import-blocks")
+	}
+	if len(cfg.NetworkConfigFiles) > 0 && (cfg.ExportUTXOSnapshot != "" || cfg.ImportUTXOSnapshot != "") {
+		return errors.New("--export-utxo-snapshot and --import-utxo-snapshot can't be used with --networkconfigfile. " +
+			"Export or import the snapshot of every network separately")
+	}
+	if cfg.DbType == config.DbTypeMemory &&
+		(cfg.DbEncryptionKeyFile != "" || cfg.DbEncryptionKeyEnv != "" || cfg.DbEncryptionKeyPrompt) {
+
+		return errors.New("database encryption has no effect with an in-memory database. " +
+			"Remove either --dbtype=memory or the --dbencryptionkey* options")
+	}
+	if cfg.HeadersOnly {
+		err := checkHeadersOnlyCombinations(cfg)
+		if err != nil {
+			return err
+		}
+	}
+	if cfg.DisableListen && cfg.Upnp {
+		return errors.New("--upnp has no effect when listening is disabled. " +
+			"Remove either --nolisten or --upnp. Note that --connect and --proxy disable listening " +
+			"unless --listen is specified")
+	}
+	if cfg.DisableListen && len(cfg.ExternalIPs) > 0 {
+		return errors.New("--externalip has no effect when listening is disabled. " +
+			"Remove either --nolisten or --externalip. Note that --connect and --proxy disable listening " +
+			"unless --listen is specified")
+	}
+	return nil
+}
+
+// checkHeadersOnlyCombinations rejects the options that rely on block bodies
+// or on the UTXO set, neither of which a headers-only node has
+func checkHeadersOnlyCombinations(cfg *config.Config) error {
+	incompatibleOptions := []struct {
+		name    string
+		enabled bool
+	}{
+		{"--utxoindex", cfg.UTXOIndex},
+		{"--stxoindex", cfg.STXOIndex},
+		{"--scriptclassindex", cfg.ScriptClassIndex},
+		{"--feehistoryindex", cfg.FeeHistoryIndex},
+		{"--coinageindex", cfg.CoinAgeIndex},
+		{"--txindex", cfg.TXIndex},
+		{"--addrindex", cfg.AddrIndex},
+		{"--archival", cfg.IsArchivalNode},
+		{"--prewarmutxocache", cfg.PrewarmUTXOCache},
+		{"--sqlmirror", cfg.SQLMirror != ""},
+		{"--kafkabroker", len(cfg.KafkaBrokers) > 0},
+		{"--templatepush", cfg.TemplatePush != ""},
+		{"--stratumlisten", cfg.StratumListen != ""},
+		{"--payoutwatchaddress", cfg.PayoutWatchAddress != ""},
+		{"--blockratemonitor", cfg.BlockRateMonitor},
+		{"--export-blocks", cfg.ExportBlocks != ""},
+		{"--import-blocks", cfg.ImportBlocks != ""},
+		{"--export-utxo-snapshot", cfg.ExportUTXOSnapshot != ""},
+		{"--import-utxo-snapshot", cfg.ImportUTXOSnapshot != ""},
+	}
+	for _, option := range incompatibleOptions {
+		if option.enabled {
+			return errors.Errorf("%s relies on block bodies or on the UTXO set, which a headers-only node doesn't have. "+
+				"Remove either --headersonly or %s", option.name, option.name)
+		}
+	}
+	return nil
+}
+
+func checkAppDirIsWritable(cfg *config.Config) error {
+	err := os.MkdirAll(cfg.AppDir, 0700)
+	if err != nil {
+		return errors.Errorf("cannot create %s: %s. Choose a different directory with --appdir",
+			cfg.AppDir, err)
+	}
+	probe, err := os.CreateTemp(cfg.AppDir, ".preflight")
+	if err != nil {
+		message := fmt.Sprintf("%s is not writable: %s. Make sure it is owned by the user running kaspad, "+
+			"or choose a different directory with --appdir", cfg.AppDir, err)
+		// Mounted container volumes are commonly owned by a different user
+		// than the one the container runs as
+		if uid := os.Getuid(); uid >= 0 {
+			message += fmt.Sprintf(". kaspad is running as uid %d, so if this is a mounted volume, "+
+				"run `chown -R %d:%d` on its source directory", uid, uid, os.Getgid())
+		}
+		return errors.New(message)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// listenAddresses returns the TCP addresses the P2P and RPC servers of the
+// node are going to listen on
+func listenAddresses(cfg *config.Config) []string {
+	var addresses []string
+	if !cfg.DisableListen {
+		addresses = append(addresses, cfg.Listeners...)
+	}
+	if !cfg.DisableRPC {
+		addresses = append(addresses, cfg.RPCListeners...)
+		for _, endpoint := range cfg.RPCEndpoints {
+			addresses = append(addresses, endpoint.Listeners...)
+		}
+	}
+	return addresses
+}
+
+func checkListenAddressesAreBindable(cfg *config.Config) error {
+	for _, address := range listenAddresses(cfg) {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return errors.Errorf("cannot listen on %s: %s. Make sure no other kaspad instance is running "+
+				"with the same ports, or choose different ones with --listen and --rpclisten", address, err)
+		}
+		listener.Close()
+	}
+	return nil
+}
+
+func checkClock(cfg *config.Config) error {
+	genesisTime := time.UnixMilli(cfg.NetParams().GenesisBlock.Header.TimeInMilliseconds())
+	if time.Now().Before(genesisTime) {
+		return errors.Errorf("the system clock (%s) is set to before the %s genesis block was created (%s). "+
+			"Blocks will not be validated correctly until the clock is fixed. Consider enabling NTP",
+			time.Now().Format(time.RFC3339), cfg.NetParams().Name, genesisTime.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func checkFileLimit(cfg *config.Config) error {
+	fileLimit, err := limits.FileLimit()
+	if err != nil {
+		return err
+	}
+	rpcMaxClients := cfg.RPCMaxClients
+	for _, endpoint := range cfg.RPCEndpoints {
+		rpcMaxClients += endpoint.MaxClients
+	}
+	requiredFileDescriptors := uint64(opt.DefaultOpenFilesCacheCapacity + reservedFileDescriptors +
+		cfg.TargetOutboundPeers + cfg.MaxInboundPeers + rpcMaxClients)
+	if fileLimit < requiredFileDescriptors {
+		return errors.Errorf("the open file limit is %d, while the configured peer and RPC client limits "+
+			"may require up to %d. Raise it (e.g. with `ulimit -n %d`), or lower --maxinpeers, --rpcmaxclients "+
+			"and the maxclients of RPC endpoints",
+			fileLimit, requiredFileDescriptors, requiredFileDescriptors)
+	}
+	return nil
+}
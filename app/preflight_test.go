@@ -0,0 +1,81 @@
+package app
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/os/limits"
+)
+
+func TestRunPreflightChecks(t *testing.T) {
+	cfg := newTestComponentConfig(t)
+	err := runPreflightChecks(cfg)
+	if err != nil {
+		t.Fatalf("Expected the preflight checks to pass, but got: %s", err)
+	}
+	if entries, err := os.ReadDir(cfg.AppDir); err != nil || len(entries) != 0 {
+		t.Fatalf("Expected the writability probe to be removed, but got %v (%v)", entries, err)
+	}
+
+	// Every failure is reported together
+	occupiedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %s", err)
+	}
+	defer occupiedListener.Close()
+	notADirectory := filepath.Join(t.TempDir(), "file")
+	err = os.WriteFile(notADirectory, nil, 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+
+	cfg = newTestComponentConfig(t)
+	cfg.ExportBlocks = "blocks"
+	cfg.ImportBlocks = "blocks"
+	cfg.AppDir = notADirectory
+	cfg.Listeners = []string{occupiedListener.Addr().String()}
+	expectedFailures := []string{
+		"configuration: --export-blocks and --import-blocks can't be used together",
+		"data directory: cannot create " + notADirectory,
+		"listen addresses: cannot listen on " + occupiedListener.Addr().String(),
+	}
+	fileLimit, err := limits.FileLimit()
+	if err != nil {
+		t.Fatalf("FileLimit: %s", err)
+	}
+	if fileLimit < 1<<30 {
+		cfg.MaxInboundPeers = int(fileLimit)
+		expectedFailures = append(expectedFailures, "open file limit: the open file limit is")
+	}
+
+	err = runPreflightChecks(cfg)
+	if err == nil {
+		t.Fatalf("Expected the preflight checks to fail")
+	}
+	for _, expectedFailure := range expectedFailures {
+		if !strings.Contains(err.Error(), expectedFailure) {
+			t.Errorf("Expected the error to contain %q, but got: %s", expectedFailure, err)
+		}
+	}
+	if strings.Contains(err.Error(), "system clock") {
+		t.Errorf("Expected the system clock check to pass, but got: %s", err)
+	}
+}
+
+func TestCheckHeadersOnlyCombinations(t *testing.T) {
+	cfg := newTestComponentConfig(t)
+	cfg.HeadersOnly = true
+	err := checkConfigCombinations(cfg)
+	if err != nil {
+		t.Fatalf("Expected a plain headers-only configuration to pass, but got: %s", err)
+	}
+
+	cfg.UTXOIndex = true
+	err = checkConfigCombinations(cfg)
+	if err == nil || !strings.Contains(err.Error(), "Remove either --headersonly or --utxoindex") {
+		t.Fatalf("Expected --utxoindex to be rejected on a headers-only node, but got: %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package banmanager
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+	"github.com/pkg/errors"
+)
+
+// Misbehavior is a kind of protocol violation that peers are scored for
+type Misbehavior uint8
+
+const (
+	// MisbehaviorInvalidBlock is sending a block that violates the
+	// consensus rules
+	MisbehaviorInvalidBlock Misbehavior = iota
+
+	// MisbehaviorMalformedMessage is sending a message that violates the
+	// protocol, such as an invalid transaction or an unexpected response
+	MisbehaviorMalformedMessage
+)
+
+// misbehaviorScores are the scores of the misbehaviors. Invalid blocks and
+// malformed messages can't be sent by an honest peer, so with the default ban
+// threshold they get it banned at once.
+var misbehaviorScores = map[Misbehavior]float64{
+	MisbehaviorInvalidBlock:     100,
+	MisbehaviorMalformedMessage: 100,
+}
+
+var misbehaviorStrings = map[Misbehavior]string{
+	MisbehaviorInvalidBlock:     "invalid block",
+	MisbehaviorMalformedMessage: "malformed message",
+}
+
+func (m Misbehavior) String() string {
+	if s, ok := misbehaviorStrings[m]; ok {
+		return s
+	}
+	return fmt.Sprintf("Unknown Misbehavior (%d)", uint8(m))
+}
+
+const (
+	// scoreHalfLife is the time it takes a ban score to decay to half
+	// its value
+	scoreHalfLife = time.Minute
+
+	// maxTrackedIPs is the amount of IPs above which the scores that
+	// decayed to nothing are pruned
+	maxTrackedIPs = 10000
+)
+
+type banScore struct {
+	score      float64
+	lastUpdate time.Time
+}
+
+func (bs *banScore) decayedScore(now time.Time) float64 {
+	halfLives := float64(now.Sub(bs.lastUpdate)) / float64(scoreHalfLife)
+	return bs.score * math.Pow(0.5, halfLives)
+}
+
+// BanManager scores peers for the protocol violations they commit, and bans
+// their IPs once their score reaches the ban threshold. Scores decay over
+// time, so that only repeated misbehavior leads to a ban, and are kept in
+// memory only. The bans themselves are persisted by the address manager.
+type BanManager struct {
+	isBanningEnabled  bool
+	threshold         float64
+	connectionManager *connmanager.ConnectionManager
+
+	scores map[string]*banScore
+	mutex  sync.Mutex
+}
+
+// New returns a new BanManager
+func New(cfg *config.Config, connectionManager *connmanager.ConnectionManager) *BanManager {
+	return &BanManager{
+		isBanningEnabled:  cfg.EnableBanning,
+		threshold:         float64(cfg.BanThreshold),
+		connectionManager: connectionManager,
+		scores:            make(map[string]*banScore),
+	}
+}
+
+// AddBanScore increases the ban score of the IP of the given connection by
+// the score of the given misbehavior, and bans the IP once its score
+// reaches the ban threshold. It returns whether the IP was banned.
+func (bm *BanManager) AddBanScore(netConnection *netadapter.NetConnection,
+	misbehavior Misbehavior, reason string) (bool, error) {
+
+	if !bm.IsBanningEnabled() {
+		return false, nil
+	}
+
+	ip := netConnection.NetAddress().IP.String()
+	score, reachedThreshold := bm.increaseScore(ip, misbehaviorScores[misbehavior], time.Now())
+	log.Debugf("Increased the ban score of %s to %.2f (%s: %s)", netConnection, score, misbehavior, reason)
+	if !reachedThreshold {
+		return false, nil
+	}
+
+	err := bm.connectionManager.Ban(netConnection, fmt.Sprintf("%s: %s", misbehavior, reason))
+	if err != nil {
+		if errors.Is(err, connmanager.ErrCannotBanPermanent) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// IsBanningEnabled returns whether misbehaving peers are banned
+func (bm *BanManager) IsBanningEnabled() bool {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+
+	return bm.isBanningEnabled
+}
+
+// SetBanningPolicy changes whether misbehaving peers are banned, and the ban
+// score at which they are. The current scores are kept, so lowering the
+// threshold bans an IP the next time its score increases.
+func (bm *BanManager) SetBanningPolicy(isBanningEnabled bool, threshold uint32) {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+
+	bm.isBanningEnabled = isBanningEnabled
+	bm.threshold = float64(threshold)
+}
+
+// BanScore returns the current ban score of the given IP
+func (bm *BanManager) BanScore(ip string) float64 {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+
+	score, ok := bm.scores[ip]
+	if !ok {
+		return 0
+	}
+	return score.decayedScore(time.Now())
+}
+
+// ResetBanScore resets the ban score of the given IP
+func (bm *BanManager) ResetBanScore(ip string) {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+
+	delete(bm.scores, ip)
+}
+
+// increaseScore adds the given score to the decayed score of the given IP,
+// and returns the new score and whether it reached the ban threshold. Once
+// the threshold is reached the score is reset, so that an IP that's unbanned
+// starts over.
+func (bm *BanManager) increaseScore(ip string, score float64, now time.Time) (float64, bool) {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+
+	ipScore, ok := bm.scores[ip]
+	if !ok {
+		bm.pruneDecayedScores(now)
+		ipScore = &banScore{}
+		bm.scores[ip] = ipScore
+	}
+	ipScore.score = ipScore.decayedScore(now) + score
+	ipScore.lastUpdate = now
+
+	if ipScore.score < bm.threshold {
+		return ipScore.score, false
+	}
+	delete(bm.scores, ip)
+	return ipScore.score, true
+}
+
+func (bm *BanManager) pruneDecayedScores(now time.Time) {
+	if len(bm.scores) < maxTrackedIPs {
+		return
+	}
+	for ip, score := range bm.scores {
+		if score.decayedScore(now) < 1 {
+			delete(bm.scores, ip)
+		}
+	}
+}
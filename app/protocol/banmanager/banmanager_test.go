@@ -0,0 +1,71 @@
+package banmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+func TestIncreaseScore(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.EnableBanning = true
+	cfg.BanThreshold = 100
+	banManager := New(cfg, nil)
+
+	const ip = "1.2.3.4"
+	now := time.Now()
+
+	// An invalid block reaches the threshold at once
+	score, reachedThreshold := banManager.increaseScore(ip, misbehaviorScores[MisbehaviorInvalidBlock], now)
+	if !reachedThreshold || score != 100 {
+		t.Fatalf("Expected an invalid block to reach the ban threshold, but got a score of %f", score)
+	}
+	if banManager.BanScore(ip) != 0 {
+		t.Fatalf("Expected the score to reset once the threshold was reached")
+	}
+
+	// Smaller scores have to add up for the threshold to be reached
+	const smallScore = 1
+	for i := 1; i < 100; i++ {
+		score, reachedThreshold = banManager.increaseScore(ip, smallScore, now)
+		if reachedThreshold || score != float64(i) {
+			t.Fatalf("Unexpected score after %d small scores: %f, reached threshold: %t", i, score, reachedThreshold)
+		}
+	}
+
+	// Scores decay to half their value every scoreHalfLife, so that
+	// misbehavior spread over time doesn't lead to a ban
+	score, reachedThreshold = banManager.increaseScore(ip, smallScore,
+		now.Add(scoreHalfLife))
+	if reachedThreshold || score != 50.5 {
+		t.Fatalf("Unexpected score after a half life: %f, reached threshold: %t", score, reachedThreshold)
+	}
+
+	// Scores are tracked per IP
+	score, _ = banManager.increaseScore("5.6.7.8", smallScore, now)
+	if score != 1 {
+		t.Fatalf("Unexpected score for a different IP: %f", score)
+	}
+
+	banManager.ResetBanScore(ip)
+	if banManager.BanScore(ip) != 0 {
+		t.Fatalf("Expected the score to be 0 after a reset")
+	}
+
+	// Lowering the threshold keeps the scores, so the next misbehavior reaches it
+	banManager.SetBanningPolicy(true, 3)
+	score, reachedThreshold = banManager.increaseScore("5.6.7.8", smallScore, now)
+	if reachedThreshold || score != 2 {
+		t.Fatalf("Unexpected score below the lowered threshold: %f, reached threshold: %t", score, reachedThreshold)
+	}
+	score, reachedThreshold = banManager.increaseScore("5.6.7.8", smallScore, now)
+	if !reachedThreshold || score != 3 {
+		t.Fatalf("Expected the lowered threshold to be reached, but got a score of %f", score)
+	}
+
+	banManager.SetBanningPolicy(false, 3)
+	if banManager.IsBanningEnabled() {
+		t.Fatalf("Expected banning to be disabled")
+	}
+}
@@ -0,0 +1,35 @@
+package flowcontext
+
+import (
+	"github.com/kaspanet/kaspad/app/protocol/banmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+)
+
+// BanManager returns the manager of the ban scores of the peers
+func (f *FlowContext) BanManager() *banmanager.BanManager {
+	return f.banManager
+}
+
+// AddBanScore scores the given connection for the given misbehavior, and
+// notifies that it was banned if its score reached the ban threshold. It
+// returns whether the connection was banned. Note that the connection is
+// not disconnected by AddBanScore.
+func (f *FlowContext) AddBanScore(netConnection *netadapter.NetConnection,
+	misbehavior banmanager.Misbehavior, reason string) (bool, error) {
+
+	isBanned, err := f.banManager.AddBanScore(netConnection, misbehavior, reason)
+	if err != nil {
+		return false, err
+	}
+	if !isBanned {
+		return false, nil
+	}
+
+	log.Warnf("Banning %s (%s: %s)", netConnection, misbehavior, reason)
+	f.NotifyPeerEvent(&PeerEvent{
+		Type:       PeerEventBanned,
+		Connection: netConnection,
+		Reason:     reason,
+	})
+	return true, nil
+}
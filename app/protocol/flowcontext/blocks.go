@@ -28,14 +28,22 @@ func (f *FlowContext) OnNewBlock(block *externalapi.DomainBlock) error {
 	}
 
 	log.Debugf("OnNewBlock: block %s unorphaned %d blocks", hash, len(unorphanedBlocks))
+	f.MarkBlockReceived()
 
 	newBlocks := []*externalapi.DomainBlock{block}
 	newBlocks = append(newBlocks, unorphanedBlocks...)
 
 	allAcceptedTransactions := make([]*externalapi.DomainTransaction, 0)
 	for _, newBlock := range newBlocks {
+		if f.onBlockAddedHandler != nil {
+			err := f.onBlockAddedHandler(newBlock)
+			if err != nil {
+				return err
+			}
+		}
+
 		log.Debugf("OnNewBlock: passing block %s transactions to mining manager", hash)
-		acceptedTransactions, err := f.Domain().MiningManager().HandleNewBlockTransactions(newBlock.Transactions)
+		acceptedTransactions, err := f.handleNewBlockTransactions(consensushashing.BlockHash(newBlock), newBlock.Transactions)
 		if err != nil {
 			return err
 		}
@@ -45,6 +53,14 @@ func (f *FlowContext) OnNewBlock(block *externalapi.DomainBlock) error {
 	return f.broadcastTransactionsAfterBlockAdded(newBlocks, allAcceptedTransactions)
 }
 
+// MarkBlockReceived records that a new block arrived, so that the node isn't
+// considered partitioned from the network
+func (f *FlowContext) MarkBlockReceived() {
+	if f.connectionManager != nil {
+		f.connectionManager.MarkBlockReceived()
+	}
+}
+
 // OnNewBlockTemplate calls the handler function whenever a new block template is available for miners.
 func (f *FlowContext) OnNewBlockTemplate() error {
 	// Clear current template cache. Note we call this even if the handler is nil, in order to keep the
@@ -102,18 +118,22 @@ func (f *FlowContext) SharedRequestedBlocks() *SharedRequestedBlocks {
 }
 
 // AddBlock adds the given block to the DAG and propagates it.
-func (f *FlowContext) AddBlock(block *externalapi.DomainBlock) error {
+// correlationID identifies the RPC request that submitted the block in the
+// logs.
+func (f *FlowContext) AddBlock(block *externalapi.DomainBlock, correlationID string) error {
 	if len(block.Transactions) == 0 {
 		return protocolerrors.Errorf(false, "cannot add header only block")
 	}
 
+	blockHash := consensushashing.BlockHash(block)
 	err := f.Domain().Consensus().ValidateAndInsertBlock(block, true)
 	if err != nil {
 		if errors.As(err, &ruleerrors.RuleError{}) {
-			log.Warnf("Validation failed for block %s: %s", consensushashing.BlockHash(block), err)
+			log.Warnf("[%s] Validation failed for block %s: %s", correlationID, blockHash, err)
 		}
 		return err
 	}
+	log.Debugf("[%s] Block %s was inserted into the DAG", correlationID, blockHash)
 	err = f.OnNewBlockTemplate()
 	if err != nil {
 		return err
@@ -122,7 +142,8 @@ func (f *FlowContext) AddBlock(block *externalapi.DomainBlock) error {
 	if err != nil {
 		return err
 	}
-	return f.Broadcast(appmessage.NewMsgInvBlock(consensushashing.BlockHash(block)))
+	log.Debugf("[%s] Broadcasting block %s to %d peers", correlationID, blockHash, len(f.readyPeerConnections()))
+	return f.Broadcast(appmessage.NewMsgInvBlock(blockHash))
 }
 
 // IsIBDRunning returns true if IBD is currently marked as running
@@ -148,16 +169,18 @@ func (f *FlowContext) TrySetIBDRunning(ibdPeer *peerpkg.Peer) bool {
 	return true
 }
 
-// UnsetIBDRunning unsets isInIBD
+// UnsetIBDRunning unsets isInIBD, and revalidates the mempool since
+// revalidation is skipped while in IBD
 func (f *FlowContext) UnsetIBDRunning() {
 	f.ibdPeerMutex.Lock()
-	defer f.ibdPeerMutex.Unlock()
-
 	if f.ibdPeer == nil {
+		f.ibdPeerMutex.Unlock()
 		panic("attempted to unset isInIBD when it was not set to begin with")
 	}
-
 	f.ibdPeer = nil
+	f.ibdPeerMutex.Unlock()
+
+	f.scheduleMempoolRevalidation()
 }
 
 // IBDPeer returns the current IBD peer or null if the node is not
@@ -168,3 +191,31 @@ func (f *FlowContext) IBDPeer() *peerpkg.Peer {
 
 	return f.ibdPeer
 }
+
+// IBDHelperPeers returns the peers, other than the given IBD peer, that are
+// expected to be able to serve the blocks up to highHash, since the last block
+// they announced is a known block at least as heavy as it. They're used to
+// download block bodies in parallel to the IBD peer.
+func (f *FlowContext) IBDHelperPeers(ibdPeer *peerpkg.Peer, highHash *externalapi.DomainHash) ([]*peerpkg.Peer, error) {
+	highBlockInfo, err := f.Domain().Consensus().GetBlockInfo(highHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var helpers []*peerpkg.Peer
+	for _, peer := range f.Peers() {
+		lastAnnouncedBlockHash := peer.LastAnnouncedBlockHash()
+		if peer == ibdPeer || lastAnnouncedBlockHash == nil {
+			continue
+		}
+		blockInfo, err := f.Domain().Consensus().GetBlockInfo(lastAnnouncedBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		if !blockInfo.HasHeader() || blockInfo.BlueWork.Cmp(highBlockInfo.BlueWork) < 0 {
+			continue
+		}
+		helpers = append(helpers, peer)
+	}
+	return helpers, nil
+}
@@ -0,0 +1,323 @@
+package flowcontext
+
+import (
+	"math/big"
+	"time"
+
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+	"github.com/kaspanet/kaspad/util/difficulty"
+)
+
+const (
+	// chainWorkLagDuration is the amount of time worth of blocks the virtual selected
+	// parent is allowed to fall behind the heaviest tip claimed by a peer before the
+	// node is considered to be lagging
+	chainWorkLagDuration = 10 * time.Minute
+
+	// chainWorkLagGracePeriod is the amount of time the node has to be lagging before
+	// a warning is logged. This gives ordinary syncing a chance to catch up.
+	chainWorkLagGracePeriod = time.Minute
+
+	// chainWorkLagWarningInterval is the minimal amount of time between two
+	// consecutive lag warnings
+	chainWorkLagWarningInterval = 10 * time.Minute
+
+	// maxAdvertisedHeadersPerPeer is the maximum amount of headers unknown to
+	// consensus that are kept for every peer to compute the blue work of the
+	// headers it advertises on top of them
+	maxAdvertisedHeadersPerPeer = 10_000
+
+	// maxTargetIncreasePerHeader is the maximum factor by which the target of an
+	// advertised header may exceed the target of its selected parent. The DAA
+	// averages the difficulty over a window of thousands of blocks, so honest
+	// headers never come close to it.
+	maxTargetIncreasePerHeader = 2
+)
+
+// ClaimedTip is the heaviest block a peer has advertised to us, regardless of
+// whether its body, or even its header, is available locally
+type ClaimedTip struct {
+	Peer      *peerpkg.Peer
+	BlockHash *externalapi.DomainHash
+	BlueWork  *big.Int
+}
+
+// ChainWorkStatus compares the blue work of the virtual selected parent with the
+// heaviest tip claimed by any of the connected peers. On headers-only nodes, whose
+// virtual never moves, the headers selected tip takes the place of the virtual
+// selected parent.
+type ChainWorkStatus struct {
+	VirtualSelectedParentHash     *externalapi.DomainHash
+	VirtualSelectedParentBlueWork *big.Int
+
+	// HeaviestClaimedTip is nil if no peer has claimed a tip yet
+	HeaviestClaimedTip *ClaimedTip
+
+	// LagInBlocks is the approximate amount of blocks, at the current difficulty,
+	// by which the virtual selected parent falls behind HeaviestClaimedTip
+	LagInBlocks uint64
+	IsLagging   bool
+}
+
+// advertisedHeader is what's kept of a header that a peer advertised, that
+// isn't known to consensus, and that passed checkAdvertisedHeader
+type advertisedHeader struct {
+	blueWork *big.Int
+	bits     uint32
+	daaScore uint64
+}
+
+// peerClaims are the headers a peer advertised and the heaviest of them
+type peerClaims struct {
+	tip               *ClaimedTip
+	advertisedHeaders map[externalapi.DomainHash]*advertisedHeader
+}
+
+// UpdatePeerClaimedTip records the block of the given header as claimed by the
+// given peer, if it's heavier than anything the peer has claimed before.
+//
+// The blue work of a header known to consensus is the one consensus calculated.
+// Otherwise, the header has to build on a header known to consensus or
+// advertised by the same peer before, and its blue work is the one of its
+// heaviest such parent plus the work of its own difficulty. The header has to
+// carry valid proof of work, and a DAA score and difficulty that are plausible
+// for that parent. The blue work the header states is never trusted, so that a
+// peer can't fake a heavy chain with a single cheap header, but a peer that
+// advertises a heavier chain while withholding its bodies is still noticed.
+func (f *FlowContext) UpdatePeerClaimedTip(peer *peerpkg.Peer, header externalapi.BlockHeader) error {
+	blockHash := consensushashing.HeaderHash(header)
+	blueWork, isKnownToConsensus, err := f.advertisedBlueWork(peer, blockHash, header)
+	if err != nil {
+		return err
+	}
+	if blueWork == nil {
+		return nil
+	}
+
+	f.peerClaimedTipsMutex.Lock()
+	claims, ok := f.peerClaimedTips[peer]
+	if !ok {
+		claims = &peerClaims{advertisedHeaders: make(map[externalapi.DomainHash]*advertisedHeader)}
+		f.peerClaimedTips[peer] = claims
+	}
+	if !isKnownToConsensus {
+		f.addAdvertisedHeader(claims, blockHash, &advertisedHeader{
+			blueWork: blueWork,
+			bits:     header.Bits(),
+			daaScore: header.DAAScore(),
+		})
+	}
+	if claims.tip == nil || blueWork.Cmp(claims.tip.BlueWork) > 0 {
+		claims.tip = &ClaimedTip{
+			Peer:      peer,
+			BlockHash: blockHash,
+			BlueWork:  blueWork,
+		}
+	}
+	f.peerClaimedTipsMutex.Unlock()
+
+	return f.warnIfChainWorkIsLagging()
+}
+
+// advertisedBlueWork returns the blue work of the given header as described in
+// UpdatePeerClaimedTip, or nil if the header can't be counted
+func (f *FlowContext) advertisedBlueWork(peer *peerpkg.Peer, blockHash *externalapi.DomainHash,
+	header externalapi.BlockHeader) (blueWork *big.Int, isKnownToConsensus bool, err error) {
+
+	blockInfo, err := f.Domain().Consensus().GetBlockInfo(blockHash)
+	if err != nil {
+		return nil, false, err
+	}
+	if blockInfo.HasHeader() {
+		return blockInfo.BlueWork, true, nil
+	}
+
+	var selectedParent *advertisedHeader
+	for _, parentHash := range header.DirectParents() {
+		parent, err := f.knownHeader(peer, parentHash)
+		if err != nil {
+			return nil, false, err
+		}
+		if parent != nil && (selectedParent == nil || parent.blueWork.Cmp(selectedParent.blueWork) > 0) {
+			selectedParent = parent
+		}
+	}
+	if selectedParent == nil {
+		log.Debugf("Ignoring the tip %s claimed by %s because none of its parents are known", blockHash, peer)
+		return nil, false, nil
+	}
+	if !checkAdvertisedHeader(header, selectedParent) {
+		log.Debugf("Ignoring the tip %s claimed by %s because its header is invalid", blockHash, peer)
+		return nil, false, nil
+	}
+	return new(big.Int).Add(selectedParent.blueWork, difficulty.CalcWork(header.Bits())), false, nil
+}
+
+// knownHeader returns the given header if it's known to consensus or was
+// advertised by the given peer before, and nil otherwise
+func (f *FlowContext) knownHeader(peer *peerpkg.Peer, blockHash *externalapi.DomainHash) (*advertisedHeader, error) {
+	blockInfo, err := f.Domain().Consensus().GetBlockInfo(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if blockInfo.HasHeader() {
+		header, err := f.Domain().Consensus().GetBlockHeader(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		return &advertisedHeader{blueWork: blockInfo.BlueWork, bits: header.Bits(), daaScore: header.DAAScore()}, nil
+	}
+
+	f.peerClaimedTipsMutex.RLock()
+	defer f.peerClaimedTipsMutex.RUnlock()
+
+	claims, ok := f.peerClaimedTips[peer]
+	if !ok {
+		return nil, nil
+	}
+	return claims.advertisedHeaders[*blockHash], nil
+}
+
+// checkAdvertisedHeader returns whether the given header carries valid proof
+// of work, and a DAA score and difficulty that are plausible for a header
+// built on the given selected parent
+func checkAdvertisedHeader(header externalapi.BlockHeader, selectedParent *advertisedHeader) bool {
+	if header.DAAScore() < selectedParent.daaScore {
+		return false
+	}
+	maxTarget := difficulty.CompactToBig(selectedParent.bits)
+	maxTarget.Mul(maxTarget, big.NewInt(maxTargetIncreasePerHeader))
+	if difficulty.CompactToBig(header.Bits()).Cmp(maxTarget) > 0 {
+		return false
+	}
+	return pow.CheckProofOfWorkByBits(header.ToMutable())
+}
+
+// addAdvertisedHeader keeps the given header so that the headers built on it
+// can be counted. Once there are too many headers, the ones that consensus
+// inserted meanwhile are dropped, and if that's not enough the header isn't
+// kept. This must be called while holding peerClaimedTipsMutex.
+func (f *FlowContext) addAdvertisedHeader(claims *peerClaims, blockHash *externalapi.DomainHash,
+	header *advertisedHeader) {
+
+	if len(claims.advertisedHeaders) >= maxAdvertisedHeadersPerPeer {
+		for advertisedHash := range claims.advertisedHeaders {
+			blockInfo, err := f.Domain().Consensus().GetBlockInfo(&advertisedHash)
+			if err == nil && blockInfo.HasHeader() {
+				delete(claims.advertisedHeaders, advertisedHash)
+			}
+		}
+		if len(claims.advertisedHeaders) >= maxAdvertisedHeadersPerPeer {
+			return
+		}
+	}
+	claims.advertisedHeaders[*blockHash] = header
+}
+
+// RemovePeerClaimedTip forgets the tip and the headers claimed by the given peer
+func (f *FlowContext) RemovePeerClaimedTip(peer *peerpkg.Peer) {
+	f.peerClaimedTipsMutex.Lock()
+	defer f.peerClaimedTipsMutex.Unlock()
+
+	delete(f.peerClaimedTips, peer)
+}
+
+func (f *FlowContext) heaviestClaimedTip() *ClaimedTip {
+	f.peerClaimedTipsMutex.RLock()
+	defer f.peerClaimedTipsMutex.RUnlock()
+
+	var heaviest *ClaimedTip
+	for _, claims := range f.peerClaimedTips {
+		if claims.tip != nil && (heaviest == nil || claims.tip.BlueWork.Cmp(heaviest.BlueWork) > 0) {
+			heaviest = claims.tip
+		}
+	}
+	return heaviest
+}
+
+// ChainWorkStatus returns how far the virtual selected parent falls behind the
+// heaviest tip claimed by the connected peers
+func (f *FlowContext) ChainWorkStatus() (*ChainWorkStatus, error) {
+	var virtualSelectedParent *externalapi.DomainHash
+	var err error
+	if f.Config().HeadersOnly {
+		virtualSelectedParent, err = f.Domain().Consensus().GetHeadersSelectedTip()
+	} else {
+		virtualSelectedParent, err = f.Domain().Consensus().GetVirtualSelectedParent()
+	}
+	if err != nil {
+		return nil, err
+	}
+	virtualSelectedParentHeader, err := f.Domain().Consensus().GetBlockHeader(virtualSelectedParent)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ChainWorkStatus{
+		VirtualSelectedParentHash:     virtualSelectedParent,
+		VirtualSelectedParentBlueWork: virtualSelectedParentHeader.BlueWork(),
+		HeaviestClaimedTip:            f.heaviestClaimedTip(),
+	}
+	if status.HeaviestClaimedTip == nil {
+		return status, nil
+	}
+
+	missingWork := new(big.Int).Sub(status.HeaviestClaimedTip.BlueWork, status.VirtualSelectedParentBlueWork)
+	if missingWork.Sign() <= 0 {
+		return status, nil
+	}
+	lagInBlocks := missingWork.Div(missingWork, difficulty.CalcWork(virtualSelectedParentHeader.Bits()))
+	if !lagInBlocks.IsUint64() {
+		status.LagInBlocks = ^uint64(0)
+	} else {
+		status.LagInBlocks = lagInBlocks.Uint64()
+	}
+
+	maxLagInBlocks := uint64(chainWorkLagDuration / f.Config().NetParams().TargetTimePerBlock)
+	status.IsLagging = status.LagInBlocks > maxLagInBlocks
+	return status, nil
+}
+
+// warnIfChainWorkIsLagging logs a warning if the virtual selected parent has been
+// falling significantly behind the heaviest tip claimed by our peers for a while.
+// This may indicate that the node is being eclipsed, or that the blocks building
+// the heavier chain are being withheld from it.
+func (f *FlowContext) warnIfChainWorkIsLagging() error {
+	// The virtual is expected to lag behind while IBD is running
+	if f.IsIBDRunning() {
+		return nil
+	}
+
+	status, err := f.ChainWorkStatus()
+	if err != nil {
+		return err
+	}
+
+	f.chainWorkLagMutex.Lock()
+	defer f.chainWorkLagMutex.Unlock()
+
+	if !status.IsLagging {
+		f.chainWorkLaggingSince = time.Time{}
+		return nil
+	}
+
+	now := time.Now()
+	if f.chainWorkLaggingSince.IsZero() {
+		f.chainWorkLaggingSince = now
+	}
+	if now.Sub(f.chainWorkLaggingSince) < chainWorkLagGracePeriod ||
+		now.Sub(f.lastChainWorkLagWarningTime) < chainWorkLagWarningInterval {
+		return nil
+	}
+	f.lastChainWorkLagWarningTime = now
+
+	log.Warnf("The virtual selected parent %s has been lagging approximately %d blocks behind the tip %s "+
+		"claimed by %s since %s. This node may be eclipsed, or the blocks of the heavier chain may be "+
+		"withheld from it. Consider connecting to additional trusted peers.",
+		status.VirtualSelectedParentHash, status.LagInBlocks, status.HeaviestClaimedTip.BlockHash,
+		status.HeaviestClaimedTip.Peer, f.chainWorkLaggingSince.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,167 @@
+package flowcontext
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/util/difficulty"
+)
+
+// testDomain is a domain whose consensus is a test consensus
+type testDomain struct {
+	domain.Domain
+	consensus externalapi.Consensus
+}
+
+func (td *testDomain) Consensus() externalapi.Consensus {
+	return td.consensus
+}
+
+// withHeaderFields returns a copy of the given header with the given bits,
+// DAA score and blue work, solved for its bits if solve is set
+func withHeaderFields(header externalapi.BlockHeader, bits uint32, daaScore uint64, blueWork *big.Int,
+	solve bool) externalapi.BlockHeader {
+
+	modifiedHeader := blockheader.NewImmutableBlockHeader(header.Version(), header.Parents(), header.HashMerkleRoot(),
+		header.AcceptedIDMerkleRoot(), header.UTXOCommitment(), header.TimeInMilliseconds(), bits, header.Nonce(),
+		daaScore, header.BlueScore(), blueWork, header.PruningPoint())
+	if !solve {
+		return modifiedHeader
+	}
+	block := &externalapi.DomainBlock{Header: modifiedHeader}
+	mining.SolveBlock(block, rand.New(rand.NewSource(0)))
+	return block.Header
+}
+
+func TestChainWorkStatus(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	factory := consensus.NewFactory()
+	peerConsensus, teardownPeerConsensus, err := factory.NewTestConsensus(consensusConfig, "TestChainWorkStatusPeer")
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardownPeerConsensus(false)
+	localConsensus, teardownLocalConsensus, err := factory.NewTestConsensus(consensusConfig, "TestChainWorkStatusLocal")
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardownLocalConsensus(false)
+
+	// The peer has a chain that the local node doesn't have at all
+	const chainLength = 6
+	rd := rand.New(rand.NewSource(0))
+	headers := make([]externalapi.BlockHeader, chainLength)
+	tip := consensusConfig.GenesisHash
+	for i := range headers {
+		block, _, err := peerConsensus.BuildBlockWithParents([]*externalapi.DomainHash{tip}, nil, nil)
+		if err != nil {
+			t.Fatalf("BuildBlockWithParents: %+v", err)
+		}
+		mining.SolveBlock(block, rd)
+		err = peerConsensus.ValidateAndInsertBlock(block, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+		headers[i] = block.Header
+		tip = consensushashing.BlockHash(block)
+	}
+	tipInfo, err := peerConsensus.GetBlockInfo(tip)
+	if err != nil {
+		t.Fatalf("GetBlockInfo: %+v", err)
+	}
+
+	// The local node gets only the headers of the first blocks, as if
+	// their bodies were withheld
+	for _, header := range headers[:2] {
+		err := localConsensus.ValidateAndInsertBlock(&externalapi.DomainBlock{Header: header}, false)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+	}
+
+	// A lag of more than two blocks is considered lagging
+	params := dagconfig.SimnetParams
+	params.TargetTimePerBlock = chainWorkLagDuration / 2
+	cfg := &config.Config{Flags: &config.Flags{NetworkFlags: config.NetworkFlags{ActiveNetParams: &params}}}
+	flowContext := New(cfg, &testDomain{consensus: localConsensus}, nil, nil, nil)
+
+	checkStatus := func(name string, expectedPeer *peerpkg.Peer, expectedBlueWork *big.Int, expectedIsLagging bool) {
+		status, err := flowContext.ChainWorkStatus()
+		if err != nil {
+			t.Fatalf("%s: ChainWorkStatus: %+v", name, err)
+		}
+		if status.HeaviestClaimedTip == nil {
+			t.Fatalf("%s: expected a claimed tip", name)
+		}
+		if status.HeaviestClaimedTip.Peer != expectedPeer {
+			t.Fatalf("%s: the heaviest tip was claimed by an unexpected peer", name)
+		}
+		if status.HeaviestClaimedTip.BlueWork.Cmp(expectedBlueWork) != 0 {
+			t.Fatalf("%s: expected blue work %d, got %d", name, expectedBlueWork, status.HeaviestClaimedTip.BlueWork)
+		}
+		if status.IsLagging != expectedIsLagging {
+			t.Fatalf("%s: expected IsLagging to be %t, got %t", name, expectedIsLagging, status.IsLagging)
+		}
+	}
+
+	honestPeer := peerpkg.New(nil)
+	for _, header := range headers {
+		err := flowContext.UpdatePeerClaimedTip(honestPeer, header)
+		if err != nil {
+			t.Fatalf("UpdatePeerClaimedTip: %+v", err)
+		}
+	}
+	checkStatus("heavier header-only chain", honestPeer, tipInfo.BlueWork, true)
+
+	// Cheating peers advertise headers that claim a lot of blue work, but
+	// don't carry it
+	hugeBlueWork := new(big.Int).Lsh(tipInfo.BlueWork, 64)
+	genesisChild := headers[0]
+	harderTarget := new(big.Int).Rsh(params.PowMax, 32)
+	easierTarget := new(big.Int).Lsh(difficulty.CompactToBig(headers[2].Bits()), 2)
+	invalidHeaders := map[string]externalapi.BlockHeader{
+		"invalid proof of work": withHeaderFields(headers[2], difficulty.BigToCompact(harderTarget),
+			headers[2].DAAScore(), hugeBlueWork, false),
+		"sharp difficulty drop": withHeaderFields(headers[2], difficulty.BigToCompact(easierTarget),
+			headers[2].DAAScore(), hugeBlueWork, true),
+		"decreasing DAA score": withHeaderFields(headers[2], headers[2].Bits(),
+			headers[1].DAAScore()-1, hugeBlueWork, true),
+	}
+	for name, header := range invalidHeaders {
+		peer := peerpkg.New(nil)
+		err := flowContext.UpdatePeerClaimedTip(peer, header)
+		if err != nil {
+			t.Fatalf("UpdatePeerClaimedTip: %+v", err)
+		}
+		if claims, ok := flowContext.peerClaimedTips[peer]; ok && claims.tip != nil {
+			t.Fatalf("%s: the header was counted", name)
+		}
+	}
+
+	// The blue work a valid header states is ignored
+	cheatingPeer := peerpkg.New(nil)
+	err = flowContext.UpdatePeerClaimedTip(cheatingPeer,
+		withHeaderFields(genesisChild, genesisChild.Bits(), genesisChild.DAAScore(), hugeBlueWork, true))
+	if err != nil {
+		t.Fatalf("UpdatePeerClaimedTip: %+v", err)
+	}
+	checkStatus("cheap fake header", honestPeer, tipInfo.BlueWork, true)
+
+	genesisInfo, err := localConsensus.GetBlockInfo(consensusConfig.GenesisHash)
+	if err != nil {
+		t.Fatalf("GetBlockInfo: %+v", err)
+	}
+	fakeHeaderBlueWork := new(big.Int).Add(genesisInfo.BlueWork, difficulty.CalcWork(genesisChild.Bits()))
+	flowContext.RemovePeerClaimedTip(honestPeer)
+	checkStatus("removed peer", cheatingPeer, fakeHeaderBlueWork, false)
+}
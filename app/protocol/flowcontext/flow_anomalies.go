@@ -0,0 +1,109 @@
+package flowcontext
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+)
+
+const (
+	// minPeersForRateAnomalies is the minimum amount of peers whose flow rates
+	// are compared. With fewer peers there's no telling what the normal rate is.
+	minPeersForRateAnomalies = 4
+
+	// minTimeConnectedForRateAnomalies is how long a peer has to be connected
+	// before its flow rates are compared to the ones of other peers
+	minTimeConnectedForRateAnomalies = 5 * time.Minute
+
+	// rateAnomalyFactor is how many times the median rate of a flow a peer
+	// has to run it to be considered anomalous
+	rateAnomalyFactor = 10
+
+	// minAnomalousRatePerMinute is the minimum rate of a flow that's
+	// considered anomalous, so that peers of a quiet network aren't flagged
+	// for running a flow a handful of times
+	minAnomalousRatePerMinute = 10
+)
+
+// rateAnomalyFlows are the groups of flows that are driven by the requests of
+// the peer, and as such whose rate is up to the peer
+var rateAnomalyFlows = []peerpkg.Flow{peerpkg.FlowBlockRelay, peerpkg.FlowTransactionRelay, peerpkg.FlowAddressExchange}
+
+// PeerFlowAnomaly is a statistical anomaly in the protocol flows with a peer,
+// which makes the peer a candidate for banning
+type PeerFlowAnomaly struct {
+	Flow   peerpkg.Flow
+	Reason string
+}
+
+// PeerFlowAnomalies returns the anomalies in the protocol flows of the
+// connected peers, for the peers that have any
+func (f *FlowContext) PeerFlowAnomalies() map[*peerpkg.Peer][]*PeerFlowAnomaly {
+	peers := f.Peers()
+	anomalies := make(map[*peerpkg.Peer][]*PeerFlowAnomaly)
+
+	for _, peer := range peers {
+		repeatedBlockRequests, isAnomalous := peer.RepeatedBlockRequests()
+		if isAnomalous {
+			anomalies[peer] = append(anomalies[peer], &PeerFlowAnomaly{
+				Flow: peerpkg.FlowBlockRelay,
+				Reason: fmt.Sprintf("requested blocks it had already requested %d times in %s",
+					repeatedBlockRequests, peerpkg.BlockRequestWindow),
+			})
+		}
+	}
+
+	for _, flow := range rateAnomalyFlows {
+		rates := make(map[*peerpkg.Peer]float64)
+		for _, peer := range peers {
+			timeConnected := peer.TimeConnected()
+			if timeConnected < minTimeConnectedForRateAnomalies {
+				continue
+			}
+			rates[peer] = float64(peer.FlowStatistics()[flow].Count) / timeConnected.Minutes()
+		}
+
+		for peer, medianRate := range findRateAnomalies(rates) {
+			anomalies[peer] = append(anomalies[peer], &PeerFlowAnomaly{
+				Flow: flow,
+				Reason: fmt.Sprintf("ran %s %.1f times per minute while the median peer ran it %.1f times per minute",
+					flow, rates[peer], medianRate),
+			})
+		}
+	}
+
+	return anomalies
+}
+
+// findRateAnomalies returns the peers whose rate is anomalous compared to the
+// rates of the rest of the given peers, mapped to the median rate
+func findRateAnomalies(rates map[*peerpkg.Peer]float64) map[*peerpkg.Peer]float64 {
+	if len(rates) < minPeersForRateAnomalies {
+		return nil
+	}
+
+	sortedRates := make([]float64, 0, len(rates))
+	for _, rate := range rates {
+		sortedRates = append(sortedRates, rate)
+	}
+	sort.Float64s(sortedRates)
+	medianRate := sortedRates[len(sortedRates)/2]
+	if len(sortedRates)%2 == 0 {
+		medianRate = (sortedRates[len(sortedRates)/2-1] + sortedRates[len(sortedRates)/2]) / 2
+	}
+
+	threshold := medianRate * rateAnomalyFactor
+	if threshold < minAnomalousRatePerMinute {
+		threshold = minAnomalousRatePerMinute
+	}
+
+	anomalies := make(map[*peerpkg.Peer]float64)
+	for peer, rate := range rates {
+		if rate >= threshold {
+			anomalies[peer] = medianRate
+		}
+	}
+	return anomalies
+}
@@ -0,0 +1,86 @@
+package flowcontext
+
+import (
+	"testing"
+
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+func TestFindRateAnomalies(t *testing.T) {
+	peers := make([]*peerpkg.Peer, 5)
+	for i := range peers {
+		peers[i] = peerpkg.New(nil)
+	}
+
+	tests := []struct {
+		name              string
+		rates             []float64
+		expectedAnomalies []int
+	}{
+		{
+			name:              "too few peers",
+			rates:             []float64{1, 1000},
+			expectedAnomalies: nil,
+		},
+		{
+			name:              "no outliers",
+			rates:             []float64{5, 6, 7, 8, 9},
+			expectedAnomalies: nil,
+		},
+		{
+			name:              "a single outlier",
+			rates:             []float64{5, 6, 7, 8, 100},
+			expectedAnomalies: []int{4},
+		},
+		{
+			name:              "an outlier below the minimal anomalous rate",
+			rates:             []float64{0, 0, 0.1, 0.1, 5},
+			expectedAnomalies: nil,
+		},
+	}
+
+	for _, test := range tests {
+		rates := make(map[*peerpkg.Peer]float64)
+		for i, rate := range test.rates {
+			rates[peers[i]] = rate
+		}
+
+		anomalies := findRateAnomalies(rates)
+		if len(anomalies) != len(test.expectedAnomalies) {
+			t.Fatalf("%s: expected %d anomalies but got %d", test.name, len(test.expectedAnomalies), len(anomalies))
+		}
+		for _, expectedAnomaly := range test.expectedAnomalies {
+			if _, ok := anomalies[peers[expectedAnomaly]]; !ok {
+				t.Fatalf("%s: expected peer %d to be anomalous", test.name, expectedAnomaly)
+			}
+		}
+	}
+}
+
+func TestRepeatedBlockRequests(t *testing.T) {
+	peer := peerpkg.New(nil)
+	hash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	otherHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2})
+
+	if peer.RecordBlockRequest(hash) || peer.RecordBlockRequest(otherHash) {
+		t.Fatalf("RecordBlockRequest: first requests are not anomalous")
+	}
+
+	becameAnomalousCount := 0
+	for i := 0; i < 30; i++ {
+		if peer.RecordBlockRequest(hash) {
+			becameAnomalousCount++
+		}
+	}
+	if becameAnomalousCount != 1 {
+		t.Fatalf("RecordBlockRequest: expected the requests to become anomalous once, but "+
+			"they became anomalous %d times", becameAnomalousCount)
+	}
+
+	repeatedBlockRequests, isAnomalous := peer.RepeatedBlockRequests()
+	if repeatedBlockRequests != 30 || !isAnomalous {
+		t.Fatalf("RepeatedBlockRequests: expected 30 anomalous repeated requests, but got %d "+
+			"(anomalous: %t)", repeatedBlockRequests, isAnomalous)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/kaspanet/kaspad/domain"
 
+	"github.com/kaspanet/kaspad/app/protocol/banmanager"
 	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
@@ -29,6 +30,10 @@ type OnPruningPointUTXOSetOverrideHandler func() error
 // when a transaction is added to the mempool
 type OnTransactionAddedToMempoolHandler func()
 
+// OnBlockAddedHandler is a handler function that's triggered when a block,
+// whether relayed, submitted or downloaded during IBD, is added to the DAG
+type OnBlockAddedHandler func(block *externalapi.DomainBlock) error
+
 // FlowContext holds state that is relevant to more than one flow or one peer, and allows communication between
 // different flows that can be associated to different peers.
 type FlowContext struct {
@@ -37,15 +42,21 @@ type FlowContext struct {
 	domain            domain.Domain
 	addressManager    *addressmanager.AddressManager
 	connectionManager *connmanager.ConnectionManager
+	banManager        *banmanager.BanManager
 
 	timeStarted int64
 
-	onNewBlockTemplateHandler            OnNewBlockTemplateHandler
-	onPruningPointUTXOSetOverrideHandler OnPruningPointUTXOSetOverrideHandler
-	onTransactionAddedToMempoolHandler   OnTransactionAddedToMempoolHandler
+	onNewBlockTemplateHandler                  OnNewBlockTemplateHandler
+	onBlockAddedHandler                        OnBlockAddedHandler
+	onPruningPointUTXOSetOverrideHandler       OnPruningPointUTXOSetOverrideHandler
+	onTransactionAddedToMempoolHandler         OnTransactionAddedToMempoolHandler
+	onTransactionsEvictedHandler               OnTransactionsEvictedHandler
+	onTransactionsConfirmedHandler             OnTransactionsConfirmedHandler
+	onPeerEventHandler                         OnPeerEventHandler
+	onVirtualSelectedParentChainChangedHandler OnVirtualSelectedParentChainChangedHandler
 
 	lastRebroadcastTime         time.Time
-	sharedRequestedTransactions *SharedRequestedTransactions
+	transactionRequestScheduler *TransactionRequestScheduler
 
 	sharedRequestedBlocks *SharedRequestedBlocks
 
@@ -55,13 +66,30 @@ type FlowContext struct {
 	peers      map[id.ID]*peerpkg.Peer
 	peersMutex sync.RWMutex
 
+	peerClaimedTips      map[*peerpkg.Peer]*peerClaims
+	peerClaimedTipsMutex sync.RWMutex
+
+	chainWorkLaggingSince       time.Time
+	lastChainWorkLagWarningTime time.Time
+	chainWorkLagMutex           sync.Mutex
+
 	orphans      map[externalapi.DomainHash]*externalapi.DomainBlock
 	orphansMutex sync.RWMutex
 
 	transactionIDsToPropagate        []*externalapi.DomainTransactionID
+	transactionCorrelationIDs        map[externalapi.DomainTransactionID]string
 	lastTransactionIDPropagationTime time.Time
 	transactionIDPropagationLock     sync.Mutex
 
+	transactionPropagationReports     map[externalapi.DomainTransactionID]*TransactionPropagationReport
+	transactionPropagationReportsLock sync.Mutex
+
+	reorgedTransactionsStats ReorgedTransactionsStats
+
+	isMempoolRevalidationRunning bool
+	isMempoolRevalidationPending bool
+	mempoolRevalidationLock      sync.Mutex
+
 	shutdownChan chan struct{}
 }
 
@@ -75,13 +103,17 @@ func New(cfg *config.Config, domain domain.Domain, addressManager *addressmanage
 		domain:                           domain,
 		addressManager:                   addressManager,
 		connectionManager:                connectionManager,
-		sharedRequestedTransactions:      NewSharedRequestedTransactions(),
+		banManager:                       banmanager.New(cfg, connectionManager),
+		transactionRequestScheduler:      NewTransactionRequestScheduler(),
 		sharedRequestedBlocks:            NewSharedRequestedBlocks(),
 		peers:                            make(map[id.ID]*peerpkg.Peer),
+		peerClaimedTips:                  make(map[*peerpkg.Peer]*peerClaims),
 		orphans:                          make(map[externalapi.DomainHash]*externalapi.DomainBlock),
 		timeStarted:                      mstime.Now().UnixMilliseconds(),
 		transactionIDsToPropagate:        []*externalapi.DomainTransactionID{},
+		transactionCorrelationIDs:        make(map[externalapi.DomainTransactionID]string),
 		lastTransactionIDPropagationTime: time.Now(),
+		transactionPropagationReports:    make(map[externalapi.DomainTransactionID]*TransactionPropagationReport),
 		shutdownChan:                     make(chan struct{}),
 	}
 }
@@ -107,6 +139,11 @@ func (f *FlowContext) SetOnNewBlockTemplateHandler(onNewBlockTemplateHandler OnN
 	f.onNewBlockTemplateHandler = onNewBlockTemplateHandler
 }
 
+// SetOnBlockAddedHandler sets the onBlockAdded handler
+func (f *FlowContext) SetOnBlockAddedHandler(onBlockAddedHandler OnBlockAddedHandler) {
+	f.onBlockAddedHandler = onBlockAddedHandler
+}
+
 // SetOnPruningPointUTXOSetOverrideHandler sets the onPruningPointUTXOSetOverrideHandler handler
 func (f *FlowContext) SetOnPruningPointUTXOSetOverrideHandler(onPruningPointUTXOSetOverrideHandler OnPruningPointUTXOSetOverrideHandler) {
 	f.onPruningPointUTXOSetOverrideHandler = onPruningPointUTXOSetOverrideHandler
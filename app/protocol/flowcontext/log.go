@@ -2,6 +2,9 @@ package flowcontext
 
 import (
 	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
 )
 
 var log = logger.RegisterSubSystem("PROT")
+
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,103 @@
+package flowcontext
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+)
+
+// OnTransactionsRemovedFromMempoolHandler is a handler function that's triggered
+// when transactions are removed from the mempool for any reason other than their
+// inclusion in a block
+type OnTransactionsRemovedFromMempoolHandler func(removedTransactions []*miningmanagermodel.RemovedTransaction) error
+
+// OnTransactionsConfirmedHandler is a handler function that's triggered when
+// mempool transactions are included in a block that was added to the DAG
+type OnTransactionsConfirmedHandler func(blockHash *externalapi.DomainHash,
+	transactions []*externalapi.DomainTransaction) error
+
+// SetOnTransactionsRemovedFromMempoolHandler sets the onTransactionsRemovedFromMempool handler
+func (f *FlowContext) SetOnTransactionsRemovedFromMempoolHandler(
+	onTransactionsRemovedFromMempoolHandler OnTransactionsRemovedFromMempoolHandler) {
+
+	f.Domain().MiningManager().SetOnTransactionsRemovedHandler(
+		func(removedTransactions []*miningmanagermodel.RemovedTransaction) {
+			err := onTransactionsRemovedFromMempoolHandler(removedTransactions)
+			if err != nil {
+				panic(err)
+			}
+		})
+}
+
+// SetOnTransactionsConfirmedHandler sets the onTransactionsConfirmed handler
+func (f *FlowContext) SetOnTransactionsConfirmedHandler(onTransactionsConfirmedHandler OnTransactionsConfirmedHandler) {
+	f.onTransactionsConfirmedHandler = onTransactionsConfirmedHandler
+}
+
+// handleNewBlockTransactions removes the transactions of the block with the
+// given hash from the mempool, reports the ones that were in it as confirmed,
+// and returns the orphans that got accepted to the mempool as a result
+func (f *FlowContext) handleNewBlockTransactions(blockHash *externalapi.DomainHash,
+	transactions []*externalapi.DomainTransaction) ([]*externalapi.DomainTransaction, error) {
+
+	acceptedOrphans, includedTransactions, err := f.Domain().MiningManager().HandleNewBlockTransactions(transactions)
+	if err != nil {
+		return nil, err
+	}
+	if len(includedTransactions) > 0 && f.onTransactionsConfirmedHandler != nil {
+		err := f.onTransactionsConfirmedHandler(blockHash, includedTransactions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return acceptedOrphans, nil
+}
+
+// handleChainBlocksTransactions removes from the mempool the transactions of
+// the added chain blocks, and of the blocks they merge. The virtual changes as
+// soon as a block is inserted, before the block is passed to OnNewBlock, so
+// this has to be done before the mempool is revalidated against the new UTXO
+// set for these transactions to be reported as confirmed rather than evicted.
+func (f *FlowContext) handleChainBlocksTransactions(selectedParentChainChanges *externalapi.SelectedChainPath) error {
+	if len(selectedParentChainChanges.Added) == 0 || f.Domain().MiningManager().TransactionCount(true, false) == 0 {
+		return nil
+	}
+
+	addedAcceptanceData, err := f.Domain().Consensus().GetBlocksAcceptanceData(selectedParentChainChanges.Added)
+	if err != nil {
+		return err
+	}
+	var acceptedOrphans []*externalapi.DomainTransaction
+	for i, chainBlockHash := range selectedParentChainChanges.Added {
+		for _, blockAcceptanceData := range addedAcceptanceData[i] {
+			transactions := make([]*externalapi.DomainTransaction, len(blockAcceptanceData.TransactionAcceptanceData))
+			for j, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+				transactions[j] = transactionAcceptanceData.Transaction
+			}
+			blockAcceptedOrphans, err := f.handleNewBlockTransactions(blockAcceptanceData.BlockHash, transactions)
+			if err != nil {
+				return err
+			}
+			acceptedOrphans = append(acceptedOrphans, blockAcceptedOrphans...)
+		}
+
+		chainBlock, found, err := f.Domain().Consensus().GetBlock(chainBlockHash)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		chainBlockAcceptedOrphans, err := f.handleNewBlockTransactions(chainBlockHash, chainBlock.Transactions)
+		if err != nil {
+			return err
+		}
+		acceptedOrphans = append(acceptedOrphans, chainBlockAcceptedOrphans...)
+	}
+
+	// Don't relay transactions when in IBD.
+	if len(acceptedOrphans) == 0 || f.IsIBDRunning() {
+		return nil
+	}
+	return f.EnqueueTransactionIDsForPropagation(consensushashing.TransactionIDs(acceptedOrphans))
+}
@@ -0,0 +1,58 @@
+package flowcontext
+
+import (
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+)
+
+// OnTransactionsEvictedHandler is a handler function that's triggered when
+// transactions are evicted from the mempool because they became invalid
+type OnTransactionsEvictedHandler func(evictedTransactions []*miningmanagermodel.EvictedTransaction) error
+
+// SetOnTransactionsEvictedHandler sets the onTransactionsEvicted handler
+func (f *FlowContext) SetOnTransactionsEvictedHandler(onTransactionsEvictedHandler OnTransactionsEvictedHandler) {
+	f.onTransactionsEvictedHandler = onTransactionsEvictedHandler
+}
+
+// scheduleMempoolRevalidation revalidates all the mempool transactions against
+// the virtual UTXO set in the background, evicting the ones that are no longer
+// valid. If a revalidation is already running, another one runs once it's
+// done, so that changes that arrive in bursts are coalesced into a single
+// additional pass.
+func (f *FlowContext) scheduleMempoolRevalidation() {
+	f.mempoolRevalidationLock.Lock()
+	defer f.mempoolRevalidationLock.Unlock()
+
+	if f.isMempoolRevalidationRunning {
+		f.isMempoolRevalidationPending = true
+		return
+	}
+	f.isMempoolRevalidationRunning = true
+	spawn("FlowContext.revalidateMempool", f.revalidateMempool)
+}
+
+func (f *FlowContext) revalidateMempool() {
+	for {
+		evictedTransactions, err := f.Domain().MiningManager().RevalidateTransactions()
+		if err != nil {
+			panic(err)
+		}
+		if len(evictedTransactions) > 0 {
+			log.Infof("Evicted %d transactions from the mempool that became invalid", len(evictedTransactions))
+			if f.onTransactionsEvictedHandler != nil {
+				err := f.onTransactionsEvictedHandler(evictedTransactions)
+				if err != nil {
+					panic(err)
+				}
+			}
+		}
+
+		f.mempoolRevalidationLock.Lock()
+		if !f.isMempoolRevalidationPending {
+			f.isMempoolRevalidationRunning = false
+			f.mempoolRevalidationLock.Unlock()
+			return
+		}
+		f.isMempoolRevalidationPending = false
+		f.mempoolRevalidationLock.Unlock()
+	}
+}
@@ -39,6 +39,7 @@ func (f *FlowContext) RemoveFromPeers(peer *peerpkg.Peer) {
 	defer f.peersMutex.Unlock()
 
 	delete(f.peers, *peer.ID())
+	f.RemovePeerClaimedTip(peer)
 }
 
 // readyPeerConnections returns the NetConnections of all the ready peers.
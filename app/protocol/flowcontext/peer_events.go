@@ -0,0 +1,59 @@
+package flowcontext
+
+import (
+	"time"
+
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+)
+
+// PeerEventType is a step in the lifecycle of a P2P connection
+type PeerEventType int
+
+// The steps in the lifecycle of a P2P connection. A banned peer is
+// disconnected as well, so PeerEventBanned is followed by PeerEventDisconnected.
+const (
+	PeerEventConnected PeerEventType = iota
+	PeerEventHandshakeCompleted
+	PeerEventDisconnected
+	PeerEventBanned
+)
+
+// PeerEvent describes a P2P connection going through a step of its lifecycle
+type PeerEvent struct {
+	Type       PeerEventType
+	Connection *netadapter.NetConnection
+
+	// Peer is nil if the handshake with the peer didn't complete
+	Peer *peerpkg.Peer
+
+	// Reason is why the peer was disconnected or banned
+	Reason string
+
+	// ConnectionDuration is how long the connection had been established,
+	// for PeerEventDisconnected
+	ConnectionDuration time.Duration
+
+	Time time.Time
+}
+
+// OnPeerEventHandler is a handler function that's triggered whenever a P2P
+// connection goes through a step of its lifecycle
+type OnPeerEventHandler func(event *PeerEvent) error
+
+// SetOnPeerEventHandler sets the onPeerEvent handler
+func (f *FlowContext) SetOnPeerEventHandler(onPeerEventHandler OnPeerEventHandler) {
+	f.onPeerEventHandler = onPeerEventHandler
+}
+
+// NotifyPeerEvent notifies the onPeerEvent handler of the given event, if there is one
+func (f *FlowContext) NotifyPeerEvent(event *PeerEvent) {
+	if f.onPeerEventHandler == nil {
+		return
+	}
+	event.Time = time.Now()
+	err := f.onPeerEventHandler(event)
+	if err != nil {
+		panic(err)
+	}
+}
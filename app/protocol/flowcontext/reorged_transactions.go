@@ -0,0 +1,214 @@
+package flowcontext
+
+import (
+	"sync/atomic"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/pkg/errors"
+)
+
+// maxReorgDepthForTransactionReinsertion is the maximum amount of removed
+// chain blocks for which the transactions are re-inserted into the mempool.
+// Reorgs this deep are practically only seen during IBD, where fetching the
+// acceptance data of every removed chain block would be too costly.
+const maxReorgDepthForTransactionReinsertion = 1000
+
+// OnVirtualSelectedParentChainChangedHandler is a handler function that's
+// triggered after the mempool was updated for a change of the virtual selected
+// parent chain
+type OnVirtualSelectedParentChainChangedHandler func(selectedParentChainChanges *externalapi.SelectedChainPath) error
+
+// SetOnVirtualSelectedParentChainChangedHandler sets the onVirtualSelectedParentChainChanged handler
+func (f *FlowContext) SetOnVirtualSelectedParentChainChangedHandler(
+	onVirtualSelectedParentChainChangedHandler OnVirtualSelectedParentChainChangedHandler) {
+
+	f.onVirtualSelectedParentChainChangedHandler = onVirtualSelectedParentChainChangedHandler
+}
+
+// ReorgedTransactionsStats are counters of the transactions that lost their
+// acceptance due to reorgs since the node started
+type ReorgedTransactionsStats struct {
+	// ReorgCount is the amount of virtual selected parent chain changes that
+	// removed chain blocks
+	ReorgCount uint64
+
+	// ReorgedTransactionCount is the amount of transactions that were accepted
+	// by a removed chain block and not by any of the added ones
+	ReorgedTransactionCount uint64
+
+	// ReinsertedTransactionCount is the amount of reorged transactions that
+	// were re-inserted into the mempool
+	ReinsertedTransactionCount uint64
+
+	// DiscardedTransactionCount is the amount of reorged transactions that the
+	// mempool rejected, usually because the virtual still accepts them or
+	// because they conflict with the new chain
+	DiscardedTransactionCount uint64
+
+	// RebroadcastTransactionCount is the amount of re-inserted transactions
+	// that were announced to peers again
+	RebroadcastTransactionCount uint64
+}
+
+// ReorgedTransactionsStats returns the counters of the transactions that lost
+// their acceptance due to reorgs
+func (f *FlowContext) ReorgedTransactionsStats() *ReorgedTransactionsStats {
+	return &ReorgedTransactionsStats{
+		ReorgCount:                  atomic.LoadUint64(&f.reorgedTransactionsStats.ReorgCount),
+		ReorgedTransactionCount:     atomic.LoadUint64(&f.reorgedTransactionsStats.ReorgedTransactionCount),
+		ReinsertedTransactionCount:  atomic.LoadUint64(&f.reorgedTransactionsStats.ReinsertedTransactionCount),
+		DiscardedTransactionCount:   atomic.LoadUint64(&f.reorgedTransactionsStats.DiscardedTransactionCount),
+		RebroadcastTransactionCount: atomic.LoadUint64(&f.reorgedTransactionsStats.RebroadcastTransactionCount),
+	}
+}
+
+// OnVirtualSelectedParentChainChanged updates the mempool after a change of the
+// virtual selected parent chain. It removes the transactions of the added chain
+// blocks and of the blocks they merge, re-inserts the transactions that lost their acceptance due to a reorg,
+// and then revalidates the mempool transactions against the new UTXO set in the
+// background. Finally, it notifies the onVirtualSelectedParentChainChanged
+// handler, if there is one.
+func (f *FlowContext) OnVirtualSelectedParentChainChanged(selectedParentChainChanges *externalapi.SelectedChainPath) error {
+	err := f.handleChainBlocksTransactions(selectedParentChainChanges)
+	if err != nil {
+		return err
+	}
+
+	err = f.reinsertReorgedTransactions(selectedParentChainChanges)
+	if err != nil {
+		return err
+	}
+
+	if f.onVirtualSelectedParentChainChangedHandler != nil {
+		err = f.onVirtualSelectedParentChainChangedHandler(selectedParentChainChanges)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Don't revalidate the mempool when in IBD, since the virtual changes
+	// constantly. It's revalidated once IBD is done.
+	if !f.IsIBDRunning() {
+		f.scheduleMempoolRevalidation()
+	}
+	return nil
+}
+
+// reinsertReorgedTransactions re-inserts into the mempool the transactions that
+// were accepted by the removed chain blocks but not by the added ones, so that
+// they don't silently disappear after a reorg, and rebroadcasts the ones the
+// mempool accepted
+func (f *FlowContext) reinsertReorgedTransactions(selectedParentChainChanges *externalapi.SelectedChainPath) error {
+	if len(selectedParentChainChanges.Removed) == 0 {
+		return nil
+	}
+	atomic.AddUint64(&f.reorgedTransactionsStats.ReorgCount, 1)
+	if len(selectedParentChainChanges.Removed) > maxReorgDepthForTransactionReinsertion {
+		log.Infof("Not re-inserting the transactions of %d removed chain blocks into the mempool: "+
+			"the reorg is deeper than %d blocks", len(selectedParentChainChanges.Removed),
+			maxReorgDepthForTransactionReinsertion)
+		return nil
+	}
+
+	reorgedTransactions, err := f.reorgedTransactions(selectedParentChainChanges)
+	if err != nil {
+		return err
+	}
+	if len(reorgedTransactions) == 0 {
+		return nil
+	}
+	atomic.AddUint64(&f.reorgedTransactionsStats.ReorgedTransactionCount, uint64(len(reorgedTransactions)))
+
+	var transactionIDsToRebroadcast []*externalapi.DomainTransactionID
+	discardedCount := 0
+	for _, transaction := range reorgedTransactions {
+		transactionID := consensushashing.TransactionID(transaction)
+		acceptedTransactions, err := f.Domain().MiningManager().ValidateAndInsertTransaction(transaction, false, false)
+		if err != nil {
+			if !errors.As(err, &mempool.RuleError{}) {
+				return err
+			}
+			log.Debugf("Reorged transaction %s was not re-inserted into the mempool: %s", transactionID, err)
+			discardedCount++
+			continue
+		}
+		transactionIDsToRebroadcast = append(transactionIDsToRebroadcast, consensushashing.TransactionIDs(acceptedTransactions)...)
+	}
+	reinsertedCount := len(reorgedTransactions) - discardedCount
+	atomic.AddUint64(&f.reorgedTransactionsStats.ReinsertedTransactionCount, uint64(reinsertedCount))
+	atomic.AddUint64(&f.reorgedTransactionsStats.DiscardedTransactionCount, uint64(discardedCount))
+	log.Infof("A reorg removed %d chain blocks: re-inserted %d of their %d transactions into the mempool",
+		len(selectedParentChainChanges.Removed), reinsertedCount, len(reorgedTransactions))
+
+	if reinsertedCount == 0 {
+		return nil
+	}
+	f.OnTransactionAddedToMempool()
+
+	// Don't relay transactions when in IBD.
+	if f.IsIBDRunning() {
+		return nil
+	}
+	rebroadcastCount, err := f.enqueueTransactionIDsForRebroadcast(transactionIDsToRebroadcast)
+	if err != nil {
+		return err
+	}
+	atomic.AddUint64(&f.reorgedTransactionsStats.RebroadcastTransactionCount, uint64(rebroadcastCount))
+	return nil
+}
+
+// reorgedTransactions returns the non-coinbase transactions that were accepted by
+// the removed chain blocks but not by the added ones, ordered from the lowest
+// removed chain block up, so that transactions come after the ones they spend
+func (f *FlowContext) reorgedTransactions(selectedParentChainChanges *externalapi.SelectedChainPath) (
+	[]*externalapi.DomainTransaction, error) {
+
+	addedAcceptanceData, err := f.Domain().Consensus().GetBlocksAcceptanceData(selectedParentChainChanges.Added)
+	if err != nil {
+		return nil, err
+	}
+	acceptedByAddedChainBlocks := make(map[externalapi.DomainTransactionID]struct{})
+	for _, acceptanceData := range addedAcceptanceData {
+		for _, blockAcceptanceData := range acceptanceData {
+			for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+				if transactionAcceptanceData.IsAccepted {
+					transactionID := consensushashing.TransactionID(transactionAcceptanceData.Transaction)
+					acceptedByAddedChainBlocks[*transactionID] = struct{}{}
+				}
+			}
+		}
+	}
+
+	removedAcceptanceData, err := f.Domain().Consensus().GetBlocksAcceptanceData(selectedParentChainChanges.Removed)
+	if err != nil {
+		return nil, err
+	}
+	var reorgedTransactions []*externalapi.DomainTransaction
+	// Removed chain blocks are ordered from the highest down
+	for i := len(removedAcceptanceData) - 1; i >= 0; i-- {
+		for _, blockAcceptanceData := range removedAcceptanceData[i] {
+			for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+				transaction := transactionAcceptanceData.Transaction
+				if !transactionAcceptanceData.IsAccepted || transactionhelper.IsCoinBase(transaction) {
+					continue
+				}
+				transactionID := consensushashing.TransactionID(transaction)
+				if _, ok := acceptedByAddedChainBlocks[*transactionID]; ok {
+					continue
+				}
+				// The UTXO entries of the inputs are those of the removed chain,
+				// so they're cleared in order for the mempool to validate the
+				// transaction against the UTXO set of the new one
+				reorgedTransaction := transaction.Clone()
+				for _, input := range reorgedTransaction.Inputs {
+					input.UTXOEntry = nil
+				}
+				reorgedTransactions = append(reorgedTransactions, reorgedTransaction)
+			}
+		}
+	}
+	return reorgedTransactions, nil
+}
@@ -3,16 +3,25 @@ package flowcontext
 import (
 	"sync"
 
+	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 )
 
 // SharedRequestedBlocks is a data structure that is shared between peers that
 // holds the hashes of all the requested blocks to prevent redundant requests.
+// Every block is requested from a single peer, and the routes of the rest of
+// the peers that announced it are kept so that it could be requested from one
+// of them if that request fails.
 type SharedRequestedBlocks struct {
-	blocks map[externalapi.DomainHash]struct{}
+	blocks map[externalapi.DomainHash]*requestedBlock
 	sync.Mutex
 }
 
+type requestedBlock struct {
+	fallbackRoutes []*router.Route
+}
+
 // Remove removes a block from the set.
 func (s *SharedRequestedBlocks) Remove(hash *externalapi.DomainHash) {
 	s.Lock()
@@ -29,21 +38,51 @@ func (s *SharedRequestedBlocks) RemoveSet(blockHashes map[externalapi.DomainHash
 	}
 }
 
-// AddIfNotExists adds a block to the set if it doesn't exist yet.
-func (s *SharedRequestedBlocks) AddIfNotExists(hash *externalapi.DomainHash) (exists bool) {
+// RemoveAndFallBack removes a block whose request failed from the set, and
+// re-announces it through the route of the next peer that announced it, so
+// that it would be requested from that peer instead.
+func (s *SharedRequestedBlocks) RemoveAndFallBack(hash *externalapi.DomainHash) {
+	s.Lock()
+	defer s.Unlock()
+
+	block, ok := s.blocks[*hash]
+	if !ok {
+		return
+	}
+	delete(s.blocks, *hash)
+
+	for _, route := range block.fallbackRoutes {
+		err := route.Enqueue(appmessage.NewMsgInvBlock(hash))
+		if err == nil {
+			log.Debugf("Falling back to requesting block %s from another peer that announced it", hash)
+			return
+		}
+	}
+}
+
+// AddIfNotExists adds a block to the set if it doesn't exist yet. If it does,
+// the given route, through which the block was announced, is kept as a
+// fallback for when the pending request fails.
+func (s *SharedRequestedBlocks) AddIfNotExists(hash *externalapi.DomainHash, announcedThrough *router.Route) (exists bool) {
 	s.Lock()
 	defer s.Unlock()
-	_, ok := s.blocks[*hash]
+	block, ok := s.blocks[*hash]
 	if ok {
+		for _, route := range block.fallbackRoutes {
+			if route == announcedThrough {
+				return true
+			}
+		}
+		block.fallbackRoutes = append(block.fallbackRoutes, announcedThrough)
 		return true
 	}
-	s.blocks[*hash] = struct{}{}
+	s.blocks[*hash] = &requestedBlock{}
 	return false
 }
 
 // NewSharedRequestedBlocks returns a new instance of SharedRequestedBlocks.
 func NewSharedRequestedBlocks() *SharedRequestedBlocks {
 	return &SharedRequestedBlocks{
-		blocks: make(map[externalapi.DomainHash]struct{}),
+		blocks: make(map[externalapi.DomainHash]*requestedBlock),
 	}
 }
@@ -0,0 +1,52 @@
+package flowcontext
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+func TestSharedRequestedBlocksFallBack(t *testing.T) {
+	sharedRequestedBlocks := NewSharedRequestedBlocks()
+	hash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+
+	requestingRoute := router.NewRoute("requesting")
+	closedRoute := router.NewRoute("closed")
+	fallbackRoute := router.NewRoute("fallback")
+	closedRoute.Close()
+
+	if sharedRequestedBlocks.AddIfNotExists(hash, requestingRoute) {
+		t.Fatalf("AddIfNotExists: the first announcement of a block must not exist")
+	}
+	if !sharedRequestedBlocks.AddIfNotExists(hash, closedRoute) ||
+		!sharedRequestedBlocks.AddIfNotExists(hash, fallbackRoute) ||
+		!sharedRequestedBlocks.AddIfNotExists(hash, fallbackRoute) {
+		t.Fatalf("AddIfNotExists: a block that's being requested must exist")
+	}
+
+	sharedRequestedBlocks.RemoveAndFallBack(hash)
+
+	message, err := fallbackRoute.DequeueWithTimeout(time.Second)
+	if err != nil {
+		t.Fatalf("DequeueWithTimeout: %s", err)
+	}
+	inv, ok := message.(*appmessage.MsgInvRelayBlock)
+	if !ok || !inv.Hash.Equal(hash) {
+		t.Fatalf("Expected the block to be re-announced through the fallback route, but got %s", message.Command())
+	}
+	if _, err := fallbackRoute.DequeueWithTimeout(10 * time.Millisecond); err == nil {
+		t.Fatalf("Expected the block to be re-announced only once")
+	}
+
+	if sharedRequestedBlocks.AddIfNotExists(hash, fallbackRoute) {
+		t.Fatalf("AddIfNotExists: a block whose request failed must not exist")
+	}
+
+	sharedRequestedBlocks.Remove(hash)
+	if sharedRequestedBlocks.AddIfNotExists(hash, requestingRoute) {
+		t.Fatalf("AddIfNotExists: a removed block must not exist")
+	}
+}
@@ -0,0 +1,156 @@
+package flowcontext
+
+import (
+	"time"
+
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+const (
+	// transactionPropagationReportTTL is how long the propagation report of a
+	// transaction submitted over RPC is kept
+	transactionPropagationReportTTL = time.Hour
+
+	// maxTransactionPropagationReports is the maximum amount of propagation
+	// reports kept at once. Beyond it, the oldest reports are evicted first.
+	maxTransactionPropagationReports = 10_000
+)
+
+// TransactionPropagationEvent is a single announcement of a transaction to a
+// peer, or a single request of it by a peer
+type TransactionPropagationEvent struct {
+	PeerAddress string
+	Timestamp   mstime.Time
+}
+
+// TransactionPropagationReport is the evidence of how a transaction that was
+// submitted over RPC propagated to the node's peers
+type TransactionPropagationReport struct {
+	SubmittedAt   mstime.Time
+	Announcements []*TransactionPropagationEvent
+	Requests      []*TransactionPropagationEvent
+}
+
+// AnnouncedPeerCount returns the amount of distinct peers the transaction was
+// announced to
+func (r *TransactionPropagationReport) AnnouncedPeerCount() int {
+	return distinctPeerCount(r.Announcements)
+}
+
+// RequestedPeerCount returns the amount of distinct peers that requested the
+// transaction
+func (r *TransactionPropagationReport) RequestedPeerCount() int {
+	return distinctPeerCount(r.Requests)
+}
+
+func distinctPeerCount(events []*TransactionPropagationEvent) int {
+	peerAddresses := make(map[string]struct{}, len(events))
+	for _, event := range events {
+		peerAddresses[event.PeerAddress] = struct{}{}
+	}
+	return len(peerAddresses)
+}
+
+func (r *TransactionPropagationReport) clone() *TransactionPropagationReport {
+	return &TransactionPropagationReport{
+		SubmittedAt:   r.SubmittedAt,
+		Announcements: append([]*TransactionPropagationEvent{}, r.Announcements...),
+		Requests:      append([]*TransactionPropagationEvent{}, r.Requests...),
+	}
+}
+
+// TransactionPropagationReport returns the propagation report of the given
+// transaction, if it was submitted over RPC within the last hour
+func (f *FlowContext) TransactionPropagationReport(
+	transactionID *externalapi.DomainTransactionID) (*TransactionPropagationReport, bool) {
+
+	f.transactionPropagationReportsLock.Lock()
+	defer f.transactionPropagationReportsLock.Unlock()
+
+	report, ok := f.transactionPropagationReports[*transactionID]
+	if !ok || mstime.Now().Sub(report.SubmittedAt) > transactionPropagationReportTTL {
+		return nil, false
+	}
+	return report.clone(), true
+}
+
+// OnTransactionRequested records that the given peer requested the given
+// transaction, if it was submitted over RPC
+func (f *FlowContext) OnTransactionRequested(transactionID *externalapi.DomainTransactionID, peer *peerpkg.Peer) {
+	f.transactionPropagationReportsLock.Lock()
+	defer f.transactionPropagationReportsLock.Unlock()
+
+	report, ok := f.transactionPropagationReports[*transactionID]
+	if !ok {
+		return
+	}
+	report.Requests = append(report.Requests, &TransactionPropagationEvent{
+		PeerAddress: peer.Address(),
+		Timestamp:   mstime.Now(),
+	})
+}
+
+// trackTransactionPropagation starts keeping propagation reports for the
+// given transactions
+func (f *FlowContext) trackTransactionPropagation(transactionIDs []*externalapi.DomainTransactionID) {
+	f.transactionPropagationReportsLock.Lock()
+	defer f.transactionPropagationReportsLock.Unlock()
+
+	now := mstime.Now()
+	for _, transactionID := range transactionIDs {
+		f.evictTransactionPropagationReportsIfRequired()
+		f.transactionPropagationReports[*transactionID] = &TransactionPropagationReport{SubmittedAt: now}
+	}
+}
+
+// recordTransactionAnnouncements records that the given transactions were
+// announced to the given peers, for those of them that were submitted over RPC
+func (f *FlowContext) recordTransactionAnnouncements(transactionIDs []*externalapi.DomainTransactionID,
+	peerConnections []*netadapter.NetConnection) {
+
+	f.transactionPropagationReportsLock.Lock()
+	defer f.transactionPropagationReportsLock.Unlock()
+
+	if len(f.transactionPropagationReports) == 0 {
+		return
+	}
+	now := mstime.Now()
+	for _, transactionID := range transactionIDs {
+		report, ok := f.transactionPropagationReports[*transactionID]
+		if !ok {
+			continue
+		}
+		for _, peerConnection := range peerConnections {
+			report.Announcements = append(report.Announcements, &TransactionPropagationEvent{
+				PeerAddress: peerConnection.Address(),
+				Timestamp:   now,
+			})
+		}
+	}
+}
+
+// evictTransactionPropagationReportsIfRequired removes expired reports and,
+// if there are still too many of them, the oldest one. This must be called
+// while holding transactionPropagationReportsLock.
+func (f *FlowContext) evictTransactionPropagationReportsIfRequired() {
+	if len(f.transactionPropagationReports) < maxTransactionPropagationReports {
+		return
+	}
+	var oldestTransactionID externalapi.DomainTransactionID
+	var oldestReport *TransactionPropagationReport
+	for transactionID, report := range f.transactionPropagationReports {
+		if mstime.Now().Sub(report.SubmittedAt) > transactionPropagationReportTTL {
+			delete(f.transactionPropagationReports, transactionID)
+			continue
+		}
+		if oldestReport == nil || report.SubmittedAt.Before(oldestReport.SubmittedAt) {
+			oldestTransactionID, oldestReport = transactionID, report
+		}
+	}
+	if len(f.transactionPropagationReports) >= maxTransactionPropagationReports && oldestReport != nil {
+		delete(f.transactionPropagationReports, oldestTransactionID)
+	}
+}
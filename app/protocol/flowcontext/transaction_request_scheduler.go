@@ -0,0 +1,297 @@
+package flowcontext
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+const (
+	// inboundPeerTransactionRequestDelay is how long requesting a transaction
+	// announced by an inbound peer is delayed, so that it's requested from an
+	// outbound peer that announced it too. Inbound peers are cheap to come by,
+	// so an attacker is more likely to be one of them.
+	inboundPeerTransactionRequestDelay = 2 * time.Second
+
+	// notFoundTransactionRequestDelay is how much longer requesting a
+	// transaction from a peer is delayed for every transaction it recently
+	// announced and then failed to deliver
+	notFoundTransactionRequestDelay = 500 * time.Millisecond
+
+	// maxNotFoundTransactionRequestDelay caps the delay of the transaction
+	// requests of peers that fail to deliver the transactions they announce
+	maxNotFoundTransactionRequestDelay = 10 * time.Second
+
+	// maxTransactionAnnouncementsPerPeer is the maximum amount of transactions
+	// a peer may have announced and not delivered yet. Further announcements
+	// are ignored, so that a peer can't exhaust the memory with announcements.
+	maxTransactionAnnouncementsPerPeer = 5000
+
+	// transactionRequestBucketCapacity is the maximum amount of transactions
+	// requested from a peer at once
+	transactionRequestBucketCapacity = 10_000
+
+	// transactionRequestsPerSecond is the rate in which the bucket of every
+	// peer refills, which caps the rate of transactions requested from it
+	transactionRequestsPerSecond = 1000
+
+	// inFlightTransactionRecheckInterval is how often a peer checks whether a
+	// transaction it announced, but which is requested from another peer,
+	// should be requested from it instead
+	inFlightTransactionRecheckInterval = time.Second
+)
+
+// TransactionRequestScheduler is a data structure that is shared between peers
+// that schedules the requests of the transactions they announce. Every
+// transaction is requested from a single peer at a time, preferring outbound
+// peers over inbound ones and penalizing peers that fail to deliver the
+// transactions they announce. If a request fails, the transaction is requested
+// from the next peer that announced it. The rate of requests to every peer is
+// limited with a token bucket, so that a peer can't flood this node with
+// announcements of transactions it then has to request.
+type TransactionRequestScheduler struct {
+	transactions             map[externalapi.DomainTransactionID]*scheduledTransaction
+	peers                    map[*peerpkg.Peer]*transactionRequestPeer
+	nextAnnouncementSequence uint64
+	sync.Mutex
+}
+
+type scheduledTransaction struct {
+	announcements map[*peerpkg.Peer]*transactionAnnouncement
+
+	// requestedFrom is nil if the transaction isn't requested from any peer
+	requestedFrom *peerpkg.Peer
+}
+
+type transactionAnnouncement struct {
+	// requestTime is the earliest time the transaction may be requested
+	requestTime time.Time
+	sequence    uint64
+}
+
+type transactionRequestPeer struct {
+	announcedTransactionIDs map[externalapi.DomainTransactionID]struct{}
+	tokens                  float64
+	lastRefillTime          time.Time
+	notFoundCount           int
+}
+
+// NewTransactionRequestScheduler returns a new instance of TransactionRequestScheduler.
+func NewTransactionRequestScheduler() *TransactionRequestScheduler {
+	return &TransactionRequestScheduler{
+		transactions: make(map[externalapi.DomainTransactionID]*scheduledTransaction),
+		peers:        make(map[*peerpkg.Peer]*transactionRequestPeer),
+	}
+}
+
+func (s *TransactionRequestScheduler) peer(peer *peerpkg.Peer, now time.Time) *transactionRequestPeer {
+	requestPeer, ok := s.peers[peer]
+	if !ok {
+		requestPeer = &transactionRequestPeer{
+			announcedTransactionIDs: make(map[externalapi.DomainTransactionID]struct{}),
+			tokens:                  transactionRequestBucketCapacity,
+			lastRefillTime:          now,
+		}
+		s.peers[peer] = requestPeer
+	}
+	return requestPeer
+}
+
+// Announce records that the given peer announced the given transactions
+func (s *TransactionRequestScheduler) Announce(peer *peerpkg.Peer, isOutbound bool,
+	transactionIDs []*externalapi.DomainTransactionID) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	now := time.Now()
+	requestPeer := s.peer(peer, now)
+	requestTime := now
+	if !isOutbound {
+		requestTime = requestTime.Add(inboundPeerTransactionRequestDelay)
+	}
+	notFoundDelay := time.Duration(requestPeer.notFoundCount) * notFoundTransactionRequestDelay
+	if notFoundDelay > maxNotFoundTransactionRequestDelay {
+		notFoundDelay = maxNotFoundTransactionRequestDelay
+	}
+	requestTime = requestTime.Add(notFoundDelay)
+
+	for _, transactionID := range transactionIDs {
+		if _, ok := requestPeer.announcedTransactionIDs[*transactionID]; ok {
+			continue
+		}
+		if len(requestPeer.announcedTransactionIDs) >= maxTransactionAnnouncementsPerPeer {
+			log.Debugf("Ignoring transaction announcements of peer %s, which has %d pending ones",
+				peer, len(requestPeer.announcedTransactionIDs))
+			return
+		}
+
+		transaction, ok := s.transactions[*transactionID]
+		if !ok {
+			transaction = &scheduledTransaction{
+				announcements: make(map[*peerpkg.Peer]*transactionAnnouncement),
+			}
+			s.transactions[*transactionID] = transaction
+		}
+		transaction.announcements[peer] = &transactionAnnouncement{
+			requestTime: requestTime,
+			sequence:    s.nextAnnouncementSequence,
+		}
+		s.nextAnnouncementSequence++
+		requestPeer.announcedTransactionIDs[*transactionID] = struct{}{}
+	}
+}
+
+// ScheduleRequests returns the transactions that should be requested from the
+// given peer now, in the order they were announced, and marks them as
+// requested from it. nextScheduleTime is when ScheduleRequests should be
+// called again, or the zero time if the peer has no pending announcements.
+func (s *TransactionRequestScheduler) ScheduleRequests(peer *peerpkg.Peer) (
+	transactionIDs []*externalapi.DomainTransactionID, nextScheduleTime time.Time) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	now := time.Now()
+	requestPeer, ok := s.peers[peer]
+	if !ok {
+		return nil, time.Time{}
+	}
+	requestPeer.refill(now)
+
+	type readyTransaction struct {
+		transactionID externalapi.DomainTransactionID
+		sequence      uint64
+	}
+	var readyTransactions []readyTransaction
+	updateNextScheduleTime := func(scheduleTime time.Time) {
+		if nextScheduleTime.IsZero() || scheduleTime.Before(nextScheduleTime) {
+			nextScheduleTime = scheduleTime
+		}
+	}
+	for transactionID := range requestPeer.announcedTransactionIDs {
+		transaction := s.transactions[transactionID]
+		if transaction.requestedFrom != nil {
+			updateNextScheduleTime(now.Add(inFlightTransactionRecheckInterval))
+			continue
+		}
+		announcement := transaction.announcements[peer]
+		if announcement.requestTime.After(now) {
+			updateNextScheduleTime(announcement.requestTime)
+			continue
+		}
+		readyTransactions = append(readyTransactions, readyTransaction{
+			transactionID: transactionID,
+			sequence:      announcement.sequence,
+		})
+	}
+	sort.Slice(readyTransactions, func(i, j int) bool {
+		return readyTransactions[i].sequence < readyTransactions[j].sequence
+	})
+
+	for _, readyTransaction := range readyTransactions {
+		if requestPeer.tokens < 1 {
+			tokenTime := time.Duration(float64(time.Second) * (1 - requestPeer.tokens) / transactionRequestsPerSecond)
+			updateNextScheduleTime(now.Add(tokenTime))
+			break
+		}
+		requestPeer.tokens--
+		transactionID := readyTransaction.transactionID
+		s.transactions[transactionID].requestedFrom = peer
+		transactionIDs = append(transactionIDs, &transactionID)
+	}
+	return transactionIDs, nextScheduleTime
+}
+
+func (p *transactionRequestPeer) refill(now time.Time) {
+	p.tokens += now.Sub(p.lastRefillTime).Seconds() * transactionRequestsPerSecond
+	if p.tokens > transactionRequestBucketCapacity {
+		p.tokens = transactionRequestBucketCapacity
+	}
+	p.lastRefillTime = now
+}
+
+// Received records that the given peer delivered the given transaction, which
+// is then no longer requested from any peer
+func (s *TransactionRequestScheduler) Received(peer *peerpkg.Peer, transactionID *externalapi.DomainTransactionID) {
+	s.Lock()
+	defer s.Unlock()
+
+	requestPeer, ok := s.peers[peer]
+	if ok && requestPeer.notFoundCount > 0 {
+		requestPeer.notFoundCount--
+	}
+	s.remove(transactionID)
+}
+
+// Remove removes a transaction from the scheduler, for example because it
+// was received in another way
+func (s *TransactionRequestScheduler) Remove(transactionID *externalapi.DomainTransactionID) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.remove(transactionID)
+}
+
+func (s *TransactionRequestScheduler) remove(transactionID *externalapi.DomainTransactionID) {
+	transaction, ok := s.transactions[*transactionID]
+	if !ok {
+		return
+	}
+	for announcingPeer := range transaction.announcements {
+		delete(s.peers[announcingPeer].announcedTransactionIDs, *transactionID)
+	}
+	delete(s.transactions, *transactionID)
+}
+
+// NotFound records that the given peer failed to deliver the given
+// transaction, so that it would be requested from the next peer that
+// announced it, and delays the following requests from the given peer
+func (s *TransactionRequestScheduler) NotFound(peer *peerpkg.Peer, transactionID *externalapi.DomainTransactionID) {
+	s.Lock()
+	defer s.Unlock()
+
+	requestPeer, ok := s.peers[peer]
+	if !ok {
+		return
+	}
+	requestPeer.notFoundCount++
+	s.removeAnnouncement(peer, requestPeer, *transactionID)
+}
+
+// RemovePeer removes all the announcements of the given peer once it
+// disconnects. The transactions that were requested from it are requested
+// from the next peers that announced them.
+func (s *TransactionRequestScheduler) RemovePeer(peer *peerpkg.Peer) {
+	s.Lock()
+	defer s.Unlock()
+
+	requestPeer, ok := s.peers[peer]
+	if !ok {
+		return
+	}
+	for transactionID := range requestPeer.announcedTransactionIDs {
+		s.removeAnnouncement(peer, requestPeer, transactionID)
+	}
+	delete(s.peers, peer)
+}
+
+func (s *TransactionRequestScheduler) removeAnnouncement(peer *peerpkg.Peer, requestPeer *transactionRequestPeer,
+	transactionID externalapi.DomainTransactionID) {
+
+	delete(requestPeer.announcedTransactionIDs, transactionID)
+	transaction, ok := s.transactions[transactionID]
+	if !ok {
+		return
+	}
+	delete(transaction.announcements, peer)
+	if transaction.requestedFrom == peer {
+		transaction.requestedFrom = nil
+	}
+	if len(transaction.announcements) == 0 {
+		delete(s.transactions, transactionID)
+	}
+}
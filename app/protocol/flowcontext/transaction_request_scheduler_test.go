@@ -0,0 +1,170 @@
+package flowcontext
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+func TestTransactionRequestScheduler(t *testing.T) {
+	scheduler := NewTransactionRequestScheduler()
+	transactionID := externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	transactionIDs := []*externalapi.DomainTransactionID{transactionID}
+
+	inboundPeer := peerpkg.New(nil)
+	outboundPeer := peerpkg.New(nil)
+	otherOutboundPeer := peerpkg.New(nil)
+
+	// The inbound peer announced the transaction first, but it's requested from the outbound peer
+	scheduler.Announce(inboundPeer, false, transactionIDs)
+	scheduler.Announce(outboundPeer, true, transactionIDs)
+	scheduler.Announce(otherOutboundPeer, true, transactionIDs)
+	scheduler.Announce(otherOutboundPeer, true, transactionIDs)
+
+	scheduled, nextScheduleTime := scheduler.ScheduleRequests(inboundPeer)
+	if len(scheduled) != 0 {
+		t.Fatalf("ScheduleRequests: the request from an inbound peer must be delayed")
+	}
+	if time.Until(nextScheduleTime) < inboundPeerTransactionRequestDelay/2 {
+		t.Fatalf("ScheduleRequests: expected the request from an inbound peer to be delayed by %s, "+
+			"but it's scheduled in %s", inboundPeerTransactionRequestDelay, time.Until(nextScheduleTime))
+	}
+	scheduled, _ = scheduler.ScheduleRequests(outboundPeer)
+	if len(scheduled) != 1 || !scheduled[0].Equal(transactionID) {
+		t.Fatalf("ScheduleRequests: expected the transaction to be requested from the outbound peer")
+	}
+	scheduled, _ = scheduler.ScheduleRequests(otherOutboundPeer)
+	if len(scheduled) != 0 {
+		t.Fatalf("ScheduleRequests: a transaction must not be requested from two peers at once")
+	}
+
+	// Once the outbound peer doesn't deliver the transaction, it's requested from the next outbound peer
+	scheduler.NotFound(outboundPeer, transactionID)
+	scheduled, _ = scheduler.ScheduleRequests(otherOutboundPeer)
+	if len(scheduled) != 1 || !scheduled[0].Equal(transactionID) {
+		t.Fatalf("ScheduleRequests: expected the transaction to be requested from the next peer that announced it")
+	}
+
+	// The outbound peer that didn't deliver the transaction is penalized
+	otherTransactionIDs := []*externalapi.DomainTransactionID{
+		externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+	}
+	scheduler.Announce(outboundPeer, true, otherTransactionIDs)
+	scheduled, nextScheduleTime = scheduler.ScheduleRequests(outboundPeer)
+	if len(scheduled) != 0 || time.Until(nextScheduleTime) < notFoundTransactionRequestDelay/2 {
+		t.Fatalf("ScheduleRequests: expected the requests from a peer that didn't deliver a transaction to be delayed")
+	}
+
+	// Once the transaction is received, it isn't requested from anyone else
+	scheduler.Received(otherOutboundPeer, transactionID)
+	scheduler.RemovePeer(outboundPeer)
+	scheduled, nextScheduleTime = scheduler.ScheduleRequests(inboundPeer)
+	if len(scheduled) != 0 || !nextScheduleTime.IsZero() {
+		t.Fatalf("ScheduleRequests: a received transaction must not be requested again")
+	}
+	if len(scheduler.transactions) != 0 {
+		t.Fatalf("Expected the scheduler not to hold any transactions, but it holds %d", len(scheduler.transactions))
+	}
+}
+
+func TestTransactionRequestSchedulerLimits(t *testing.T) {
+	scheduler := NewTransactionRequestScheduler()
+	peer := peerpkg.New(nil)
+
+	// Announcements beyond the limit are ignored
+	transactionIDs := make([]*externalapi.DomainTransactionID, maxTransactionAnnouncementsPerPeer+100)
+	for i := range transactionIDs {
+		transactionIDs[i] = externalapi.NewDomainTransactionIDFromByteArray(
+			&[externalapi.DomainHashSize]byte{byte(i), byte(i >> 8)})
+	}
+	scheduler.Announce(peer, true, transactionIDs)
+	if len(scheduler.transactions) != maxTransactionAnnouncementsPerPeer {
+		t.Fatalf("Expected %d announcements to be recorded, but got %d",
+			maxTransactionAnnouncementsPerPeer, len(scheduler.transactions))
+	}
+
+	// Requests beyond the capacity of the token bucket are delayed
+	scheduler.peers[peer].tokens = 10
+	scheduler.peers[peer].lastRefillTime = time.Now()
+	scheduled, nextScheduleTime := scheduler.ScheduleRequests(peer)
+	// The bucket may slightly refill meanwhile
+	if len(scheduled) < 10 || len(scheduled) >= 100 {
+		t.Fatalf("Expected about 10 transactions to be requested, but got %d", len(scheduled))
+	}
+	if nextScheduleTime.IsZero() || time.Until(nextScheduleTime) > time.Second {
+		t.Fatalf("Expected the rest of the transactions to be requested once the bucket refills")
+	}
+}
+
+// lineWriter sends every log line written to it to its channel
+type lineWriter chan string
+
+func (w lineWriter) Write(line []byte) (int, error) {
+	w <- string(line)
+	return len(line), nil
+}
+
+func (w lineWriter) Close() error {
+	return nil
+}
+
+func TestLogCorrelatedTransactionBroadcasts(t *testing.T) {
+	lines := make(lineWriter, 10)
+	backend := logger.NewBackend()
+	err := backend.AddLogWriter(lines, logger.LevelDebug)
+	if err != nil {
+		t.Fatalf("AddLogWriter: %s", err)
+	}
+	err = backend.Run()
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+	defer backend.Close()
+	originalLog := log
+	defer func() { log = originalLog }()
+	log = backend.Logger("PROT")
+	log.SetLevel(logger.LevelDebug)
+
+	params := dagconfig.SimnetParams
+	cfg := &config.Config{Flags: &config.Flags{NetworkFlags: config.NetworkFlags{ActiveNetParams: &params}}}
+	flowContext := New(cfg, nil, nil, nil, nil)
+
+	submittedTransactionID := externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	relayedTransactionID := externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{2})
+	flowContext.transactionCorrelationIDs[*submittedTransactionID] = "rpc-0000002a"
+
+	flowContext.logCorrelatedTransactionBroadcasts(
+		[]*externalapi.DomainTransactionID{submittedTransactionID, relayedTransactionID}, 3)
+	select {
+	case line := <-lines:
+		expected := "[rpc-0000002a] Broadcasting transaction " + submittedTransactionID.String() + " to 3 peers"
+		if !strings.Contains(line, expected) {
+			t.Fatalf("expected the broadcast to be logged as %q, but got %q", expected, line)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("the broadcast of the submitted transaction wasn't logged")
+	}
+	if _, ok := flowContext.transactionCorrelationIDs[*submittedTransactionID]; ok {
+		t.Fatalf("the correlation ID of a broadcast transaction was kept")
+	}
+
+	// Transactions that weren't submitted over RPC, and transactions whose
+	// broadcast was already logged, aren't logged
+	flowContext.logCorrelatedTransactionBroadcasts(
+		[]*externalapi.DomainTransactionID{submittedTransactionID, relayedTransactionID}, 3)
+	log.Debugf("done")
+	select {
+	case line := <-lines:
+		if !strings.Contains(line, "done") {
+			t.Fatalf("expected no more broadcasts to be logged, but got %q", line)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("the log wasn't written")
+	}
+}
@@ -12,14 +12,27 @@ import (
 const TransactionIDPropagationInterval = 500 * time.Millisecond
 
 // AddTransaction adds transaction to the mempool and propagates it.
-func (f *FlowContext) AddTransaction(tx *externalapi.DomainTransaction, allowOrphan bool) error {
+// correlationID identifies the RPC request that submitted the transaction
+// in the logs, along with the transactions it unorphaned.
+func (f *FlowContext) AddTransaction(tx *externalapi.DomainTransaction, allowOrphan bool, correlationID string) error {
 	acceptedTransactions, err := f.Domain().MiningManager().ValidateAndInsertTransaction(tx, true, allowOrphan)
 	if err != nil {
 		return err
 	}
 
+	transactionID := consensushashing.TransactionID(tx)
 	acceptedTransactionIDs := consensushashing.TransactionIDs(acceptedTransactions)
-	return f.EnqueueTransactionIDsForPropagation(acceptedTransactionIDs)
+	log.Debugf("[%s] Transaction %s was added to the mempool along with %d unorphaned transactions",
+		correlationID, transactionID, len(acceptedTransactionIDs)-1)
+	f.trackTransactionPropagation([]*externalapi.DomainTransactionID{transactionID})
+
+	f.transactionIDPropagationLock.Lock()
+	defer f.transactionIDPropagationLock.Unlock()
+
+	for _, transactionID := range acceptedTransactionIDs {
+		f.transactionCorrelationIDs[*transactionID] = correlationID
+	}
+	return f.enqueueTransactionIDsForPropagation(acceptedTransactionIDs)
 }
 
 func (f *FlowContext) shouldRebroadcastTransactions() bool {
@@ -27,10 +40,10 @@ func (f *FlowContext) shouldRebroadcastTransactions() bool {
 	return time.Since(f.lastRebroadcastTime) > rebroadcastInterval
 }
 
-// SharedRequestedTransactions returns a *transactionrelay.SharedRequestedTransactions for sharing
-// data about requested transactions between different peers.
-func (f *FlowContext) SharedRequestedTransactions() *SharedRequestedTransactions {
-	return f.sharedRequestedTransactions
+// TransactionRequestScheduler returns a *TransactionRequestScheduler for scheduling
+// the requests of the transactions announced by different peers.
+func (f *FlowContext) TransactionRequestScheduler() *TransactionRequestScheduler {
+	return f.transactionRequestScheduler
 }
 
 // OnTransactionAddedToMempool notifies the handler function that a transaction
@@ -48,11 +61,39 @@ func (f *FlowContext) EnqueueTransactionIDsForPropagation(transactionIDs []*exte
 	f.transactionIDPropagationLock.Lock()
 	defer f.transactionIDPropagationLock.Unlock()
 
+	return f.enqueueTransactionIDsForPropagation(transactionIDs)
+}
+
+// enqueueTransactionIDsForPropagation is the lock-free version of EnqueueTransactionIDsForPropagation
+func (f *FlowContext) enqueueTransactionIDsForPropagation(transactionIDs []*externalapi.DomainTransactionID) error {
 	f.transactionIDsToPropagate = append(f.transactionIDsToPropagate, transactionIDs...)
 
 	return f.maybePropagateTransactions()
 }
 
+// enqueueTransactionIDsForRebroadcast enqueues the given transaction IDs for
+// propagation, skipping those that are already enqueued, so that a transaction
+// that is rebroadcast for several reasons at once is only announced once. It
+// returns the amount of transaction IDs that were enqueued.
+func (f *FlowContext) enqueueTransactionIDsForRebroadcast(transactionIDs []*externalapi.DomainTransactionID) (int, error) {
+	f.transactionIDPropagationLock.Lock()
+	defer f.transactionIDPropagationLock.Unlock()
+
+	enqueued := make(map[externalapi.DomainTransactionID]struct{}, len(f.transactionIDsToPropagate))
+	for _, transactionID := range f.transactionIDsToPropagate {
+		enqueued[*transactionID] = struct{}{}
+	}
+	transactionIDsToEnqueue := make([]*externalapi.DomainTransactionID, 0, len(transactionIDs))
+	for _, transactionID := range transactionIDs {
+		if _, ok := enqueued[*transactionID]; ok {
+			continue
+		}
+		enqueued[*transactionID] = struct{}{}
+		transactionIDsToEnqueue = append(transactionIDsToEnqueue, transactionID)
+	}
+	return len(transactionIDsToEnqueue), f.enqueueTransactionIDsForPropagation(transactionIDsToEnqueue)
+}
+
 func (f *FlowContext) maybePropagateTransactions() error {
 	if time.Since(f.lastTransactionIDPropagationTime) < TransactionIDPropagationInterval &&
 		len(f.transactionIDsToPropagate) < appmessage.MaxInvPerTxInvMsg {
@@ -65,12 +106,15 @@ func (f *FlowContext) maybePropagateTransactions() error {
 			transactionIDsToBroadcast = f.transactionIDsToPropagate[:len(transactionIDsToBroadcast)]
 		}
 		log.Debugf("Transaction propagation: broadcasting %d transactions", len(transactionIDsToBroadcast))
+		peerConnections := f.readyPeerConnections()
+		f.logCorrelatedTransactionBroadcasts(transactionIDsToBroadcast, len(peerConnections))
 
 		inv := appmessage.NewMsgInvTransaction(transactionIDsToBroadcast)
-		err := f.Broadcast(inv)
+		err := f.netAdapter.P2PBroadcast(peerConnections, inv)
 		if err != nil {
 			return err
 		}
+		f.recordTransactionAnnouncements(transactionIDsToBroadcast, peerConnections)
 
 		f.transactionIDsToPropagate = f.transactionIDsToPropagate[len(transactionIDsToBroadcast):]
 	}
@@ -79,3 +123,20 @@ func (f *FlowContext) maybePropagateTransactions() error {
 
 	return nil
 }
+
+// logCorrelatedTransactionBroadcasts logs the broadcast of every one of the
+// given transactions that was submitted over RPC, along with the correlation
+// ID of the request that submitted it
+func (f *FlowContext) logCorrelatedTransactionBroadcasts(transactionIDs []*externalapi.DomainTransactionID, peerCount int) {
+	if len(f.transactionCorrelationIDs) == 0 {
+		return
+	}
+	for _, transactionID := range transactionIDs {
+		correlationID, ok := f.transactionCorrelationIDs[*transactionID]
+		if !ok {
+			continue
+		}
+		log.Debugf("[%s] Broadcasting transaction %s to %d peers", correlationID, transactionID, peerCount)
+		delete(f.transactionCorrelationIDs, *transactionID)
+	}
+}
@@ -89,7 +89,7 @@ func HandleHandshake(context HandleHandshakeContext, netConnection *netadapter.N
 	}
 
 	if peerAddress != nil {
-		err := context.AddressManager().AddAddresses(peerAddress)
+		_, err := context.AddressManager().AddAddressesFromSource(addressmanager.PeerAddressSource(peer.Address()), peerAddress)
 		if err != nil {
 			return nil, err
 		}
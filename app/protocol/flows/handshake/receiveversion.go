@@ -113,5 +113,11 @@ func (flow *receiveVersionFlow) start() (*appmessage.NetAddress, error) {
 
 	flow.peer.Connection().SetID(msgVersion.ID)
 
+	// Only outbound peers are trusted to report the external address of this
+	// node, since they're chosen by it rather than by an attacker
+	if isOutbound && msgVersion.ReceiverAddress != nil {
+		flow.AddressManager().AddExternalAddressVote(flow.peer.Connection().NetAddress(), msgVersion.ReceiverAddress)
+	}
+
 	return msgVersion.Address, nil
 }
@@ -66,6 +66,9 @@ func (flow *sendVersionFlow) start() error {
 
 	// Advertise the services flag
 	msg.Services = defaultServices
+	if !flow.Config().NoCompactBlocks && !flow.Config().HeadersOnly {
+		msg.Services |= appmessage.SFNodeCompactBlocks
+	}
 
 	// Advertise our max supported protocol version.
 	msg.ProtocolVersion = flow.Config().ProtocolVersion
@@ -73,6 +76,9 @@ func (flow *sendVersionFlow) start() error {
 	// Advertise if inv messages for transactions are desired.
 	msg.DisableRelayTx = flow.Config().BlocksOnly
 
+	// Let the peer know the address it's seen at, so that it can discover its external address
+	msg.ReceiverAddress = flow.peer.Connection().NetAddress()
+
 	err := flow.outgoingRoute.Enqueue(msg)
 	if err != nil {
 		return err
@@ -1,6 +1,8 @@
 package addressexchange
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/protocol/common"
 	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
@@ -18,6 +20,7 @@ type ReceiveAddressesContext interface {
 func ReceiveAddresses(context ReceiveAddressesContext, incomingRoute *router.Route, outgoingRoute *router.Route,
 	peer *peerpkg.Peer) error {
 
+	exchangeStart := time.Now()
 	subnetworkID := peer.SubnetworkID()
 	msgGetAddresses := appmessage.NewMsgRequestAddresses(false, subnetworkID)
 	err := outgoingRoute.Enqueue(msgGetAddresses)
@@ -35,5 +38,11 @@ func ReceiveAddresses(context ReceiveAddressesContext, incomingRoute *router.Rou
 		return protocolerrors.Errorf(true, "address count exceeded %d", addressmanager.GetAddressesMax)
 	}
 
-	return context.AddressManager().AddAddresses(msgAddresses.AddressList...)
+	_, err = context.AddressManager().AddAddressesFromSource(addressmanager.PeerAddressSource(peer.Address()),
+		msgAddresses.AddressList...)
+	if err != nil {
+		return err
+	}
+	peer.RecordFlow(peerpkg.FlowAddressExchange, time.Since(exchangeStart))
+	return nil
 }
@@ -2,8 +2,10 @@ package addressexchange
 
 import (
 	"math/rand"
+	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 )
@@ -14,12 +16,15 @@ type SendAddressesContext interface {
 }
 
 // SendAddresses sends addresses to a peer that requests it.
-func SendAddresses(context SendAddressesContext, incomingRoute *router.Route, outgoingRoute *router.Route) error {
+func SendAddresses(context SendAddressesContext, incomingRoute *router.Route, outgoingRoute *router.Route,
+	peer *peerpkg.Peer) error {
+
 	for {
 		_, err := incomingRoute.Dequeue()
 		if err != nil {
 			return err
 		}
+		requestStart := time.Now()
 
 		addresses := context.AddressManager().Addresses()
 		msgAddresses := appmessage.NewMsgAddresses(shuffleAddresses(addresses))
@@ -28,6 +33,7 @@ func SendAddresses(context SendAddressesContext, incomingRoute *router.Route, ou
 		if err != nil {
 			return err
 		}
+		peer.RecordFlow(peerpkg.FlowAddressExchange, time.Since(requestStart))
 	}
 }
 
@@ -0,0 +1,118 @@
+package blockrelay
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// shortTransactionIDLength is the amount of bytes out of the keyed hash of a
+// transaction ID that make its short transaction ID
+const shortTransactionIDLength = 6
+
+// shortTransactionID returns the short ID of the given transaction within the
+// given block. The transaction ID is hashed with the block hash as the key, so
+// that finding transactions whose short IDs collide requires grinding through
+// proof of work, rather than just through transactions.
+func shortTransactionID(blockHash *externalapi.DomainHash, transactionID *externalapi.DomainTransactionID) uint64 {
+	hasher, err := blake2b.New256(blockHash.ByteSlice())
+	if err != nil {
+		panic(errors.Wrapf(err, "this should never happen. A block hash is less than 64 bytes"))
+	}
+	hasher.Write(transactionID.ByteSlice())
+
+	var shortID [8]byte
+	copy(shortID[:shortTransactionIDLength], hasher.Sum(nil))
+	return binary.LittleEndian.Uint64(shortID[:])
+}
+
+// domainBlockToMsgCompactBlock converts the given block to a compact block.
+// The coinbase transaction, which never appears in a mempool, is prefilled.
+func domainBlockToMsgCompactBlock(block *externalapi.DomainBlock) *appmessage.MsgCompactBlock {
+	blockHash := consensushashing.BlockHash(block)
+
+	var prefilledTransactions []*appmessage.PrefilledTransaction
+	var shortIDs []uint64
+	if len(block.Transactions) > 0 {
+		prefilledTransactions = []*appmessage.PrefilledTransaction{{
+			Index: 0,
+			Tx:    appmessage.DomainTransactionToMsgTx(block.Transactions[0]),
+		}}
+		shortIDs = make([]uint64, 0, len(block.Transactions)-1)
+		for _, transaction := range block.Transactions[1:] {
+			shortIDs = append(shortIDs, shortTransactionID(blockHash, consensushashing.TransactionID(transaction)))
+		}
+	}
+
+	return appmessage.NewMsgCompactBlock(appmessage.DomainBlockHeaderToBlockHeader(block.Header),
+		shortIDs, prefilledTransactions)
+}
+
+// reconstructBlockTransactions fills the transactions of the given compact block
+// out of its prefilled transactions and the given mempool transactions. It
+// returns the indexes of the transactions that could not be found in the mempool,
+// whose slots in the returned transactions are left nil.
+//
+// Mempool transactions whose short IDs collide are treated as missing, since
+// there's no telling which of them the block contains.
+func reconstructBlockTransactions(blockHash *externalapi.DomainHash, compactBlock *appmessage.MsgCompactBlock,
+	mempoolTransactions []*externalapi.DomainTransaction) (
+	transactions []*externalapi.DomainTransaction, missingIndexes []uint32, err error) {
+
+	transactionCount := len(compactBlock.ShortIDs) + len(compactBlock.PrefilledTransactions)
+	transactions = make([]*externalapi.DomainTransaction, transactionCount)
+	for _, prefilledTransaction := range compactBlock.PrefilledTransactions {
+		index := prefilledTransaction.Index
+		if int(index) >= transactionCount {
+			return nil, nil, errors.Errorf("prefilled transaction index %d is out of range of "+
+				"a block with %d transactions", index, transactionCount)
+		}
+		if transactions[index] != nil {
+			return nil, nil, errors.Errorf("transaction %d is prefilled more than once", index)
+		}
+		transactions[index] = appmessage.MsgTxToDomainTransaction(prefilledTransaction.Tx)
+	}
+
+	mempoolTransactionsByShortID := make(map[uint64]*externalapi.DomainTransaction, len(mempoolTransactions))
+	collidingShortIDs := make(map[uint64]struct{})
+	for _, transaction := range mempoolTransactions {
+		shortID := shortTransactionID(blockHash, consensushashing.TransactionID(transaction))
+		if _, ok := mempoolTransactionsByShortID[shortID]; ok {
+			collidingShortIDs[shortID] = struct{}{}
+			continue
+		}
+		mempoolTransactionsByShortID[shortID] = transaction
+	}
+	for shortID := range collidingShortIDs {
+		delete(mempoolTransactionsByShortID, shortID)
+	}
+
+	shortIDIndex := 0
+	for i := range transactions {
+		if transactions[i] != nil {
+			continue
+		}
+		shortID := compactBlock.ShortIDs[shortIDIndex]
+		shortIDIndex++
+
+		transaction, ok := mempoolTransactionsByShortID[shortID]
+		if !ok {
+			missingIndexes = append(missingIndexes, uint32(i))
+			continue
+		}
+		// The transaction is cloned since consensus populates the transactions
+		// of the blocks it validates, and the mempool's copy should stay as is.
+		// The UTXO entries the mempool populated its inputs with are dropped,
+		// since consensus rejects blocks whose inputs are prefilled.
+		transactions[i] = transaction.Clone()
+		for _, input := range transactions[i].Inputs {
+			input.UTXOEntry = nil
+		}
+	}
+
+	return transactions, missingIndexes, nil
+}
@@ -0,0 +1,90 @@
+package blockrelay
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+)
+
+func TestReconstructBlockTransactions(t *testing.T) {
+	newTransaction := func(subnetworkID externalapi.DomainSubnetworkID, payload byte) *externalapi.DomainTransaction {
+		return &externalapi.DomainTransaction{
+			Inputs: []*externalapi.DomainTransactionInput{},
+			Outputs: []*externalapi.DomainTransactionOutput{{
+				Value:           uint64(payload),
+				ScriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{}, Version: 0},
+			}},
+			SubnetworkID: subnetworkID,
+			Payload:      []byte{payload},
+		}
+	}
+	transactions := []*externalapi.DomainTransaction{
+		newTransaction(subnetworks.SubnetworkIDCoinbase, 0),
+		newTransaction(subnetworks.SubnetworkIDNative, 1),
+		newTransaction(subnetworks.SubnetworkIDNative, 2),
+		newTransaction(subnetworks.SubnetworkIDNative, 3),
+	}
+	header := blockheader.NewImmutableBlockHeader(0, nil, merkle.CalculateHashMerkleRoot(transactions),
+		&externalapi.DomainHash{}, &externalapi.DomainHash{}, 0, 0, 0, 0, 0, big.NewInt(0), &externalapi.DomainHash{})
+	block := &externalapi.DomainBlock{Header: header, Transactions: transactions}
+	blockHash := consensushashing.BlockHash(block)
+
+	compactBlock := domainBlockToMsgCompactBlock(block)
+	if len(compactBlock.PrefilledTransactions) != 1 || compactBlock.PrefilledTransactions[0].Index != 0 {
+		t.Fatalf("Expected only the coinbase to be prefilled, but got %d prefilled transactions",
+			len(compactBlock.PrefilledTransactions))
+	}
+	if len(compactBlock.ShortIDs) != len(transactions)-1 {
+		t.Fatalf("Unexpected amount of short IDs. Want: %d, got: %d", len(transactions)-1, len(compactBlock.ShortIDs))
+	}
+
+	// Transaction 2 is missing from the mempool, which also holds an unrelated transaction
+	mempoolTransactions := []*externalapi.DomainTransaction{
+		transactions[3], newTransaction(subnetworks.SubnetworkIDNative, 4), transactions[1],
+	}
+	reconstructed, missingIndexes, err := reconstructBlockTransactions(blockHash, compactBlock, mempoolTransactions)
+	if err != nil {
+		t.Fatalf("reconstructBlockTransactions: %s", err)
+	}
+	if len(missingIndexes) != 1 || missingIndexes[0] != 2 {
+		t.Fatalf("Expected transaction 2 to be missing, but got missing indexes %v", missingIndexes)
+	}
+	reconstructed[2] = transactions[2]
+	if !merkle.CalculateHashMerkleRoot(reconstructed).Equal(header.HashMerkleRoot()) {
+		t.Fatalf("The reconstructed transactions don't match the block's merkle root")
+	}
+	if reconstructed[1] == transactions[1] {
+		t.Fatalf("Expected the mempool transactions to be cloned")
+	}
+
+	// Mempool transactions whose short IDs collide can't be told apart
+	duplicate := transactions[1].Clone()
+	_, missingIndexes, err = reconstructBlockTransactions(blockHash, compactBlock,
+		[]*externalapi.DomainTransaction{transactions[1], duplicate, transactions[2], transactions[3]})
+	if err != nil {
+		t.Fatalf("reconstructBlockTransactions: %s", err)
+	}
+	if len(missingIndexes) != 1 || missingIndexes[0] != 1 {
+		t.Fatalf("Expected transaction 1 to be missing, but got missing indexes %v", missingIndexes)
+	}
+
+	outOfRange := appmessage.NewMsgCompactBlock(&compactBlock.Header, compactBlock.ShortIDs,
+		[]*appmessage.PrefilledTransaction{{Index: uint32(len(transactions)), Tx: compactBlock.PrefilledTransactions[0].Tx}})
+	_, _, err = reconstructBlockTransactions(blockHash, outOfRange, nil)
+	if err == nil {
+		t.Fatalf("Expected an out of range prefilled transaction to be rejected")
+	}
+
+	prefilledTwice := appmessage.NewMsgCompactBlock(&compactBlock.Header, compactBlock.ShortIDs[1:],
+		[]*appmessage.PrefilledTransaction{compactBlock.PrefilledTransactions[0], compactBlock.PrefilledTransactions[0]})
+	_, _, err = reconstructBlockTransactions(blockHash, prefilledTwice, nil)
+	if err == nil {
+		t.Fatalf("Expected a transaction that is prefilled twice to be rejected")
+	}
+}
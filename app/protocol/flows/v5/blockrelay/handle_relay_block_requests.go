@@ -1,10 +1,13 @@
 package blockrelay
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
 	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
 )
@@ -15,7 +18,8 @@ type RelayBlockRequestsContext interface {
 }
 
 // HandleRelayBlockRequests listens to appmessage.MsgRequestRelayBlocks messages and sends
-// their corresponding blocks to the requesting peer.
+// their corresponding blocks to the requesting peer. It also serves the appmessage.MsgRequestCompactBlock
+// and appmessage.MsgRequestBlockTransactions messages of the compact block relay.
 func HandleRelayBlockRequests(context RelayBlockRequestsContext, incomingRoute *router.Route,
 	outgoingRoute *router.Route, peer *peerpkg.Peer) error {
 
@@ -24,26 +28,107 @@ func HandleRelayBlockRequests(context RelayBlockRequestsContext, incomingRoute *
 		if err != nil {
 			return err
 		}
-		getRelayBlocksMessage := message.(*appmessage.MsgRequestRelayBlocks)
-		log.Debugf("Got request for relay blocks with hashes %s", getRelayBlocksMessage.Hashes)
-		for _, hash := range getRelayBlocksMessage.Hashes {
-			// Fetch the block from the database.
-			block, found, err := context.Domain().Consensus().GetBlock(hash)
-			if err != nil {
-				return errors.Wrapf(err, "unable to fetch requested block hash %s", hash)
-			}
-
-			if !found {
-				return protocolerrors.Errorf(false, "Relay block %s not found", hash)
-			}
-
-			// TODO (Partial nodes): Convert block to partial block if needed
-
-			err = outgoingRoute.Enqueue(appmessage.DomainBlockToMsgBlock(block))
-			if err != nil {
-				return err
-			}
-			log.Debugf("Relayed block with hash %s", hash)
+		switch message := message.(type) {
+		case *appmessage.MsgRequestRelayBlocks:
+			err = sendRelayBlocks(context, outgoingRoute, peer, message)
+		case *appmessage.MsgRequestCompactBlock:
+			err = sendCompactBlock(context, outgoingRoute, peer, message)
+		case *appmessage.MsgRequestBlockTransactions:
+			err = sendBlockTransactions(context, outgoingRoute, message)
+		default:
+			err = protocolerrors.Errorf(true, "unexpected %s message in the block relay "+
+				"HandleRelayBlockRequests flow", message.Command())
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func sendRelayBlocks(context RelayBlockRequestsContext, outgoingRoute *router.Route, peer *peerpkg.Peer,
+	getRelayBlocksMessage *appmessage.MsgRequestRelayBlocks) error {
+
+	log.Debugf("Got request for relay blocks with hashes %s", getRelayBlocksMessage.Hashes)
+	for _, hash := range getRelayBlocksMessage.Hashes {
+		requestStart := time.Now()
+		block, err := getRequestedRelayBlock(context, peer, hash)
+		if err != nil {
+			return err
+		}
+
+		// TODO (Partial nodes): Convert block to partial block if needed
+
+		err = outgoingRoute.Enqueue(appmessage.DomainBlockToMsgBlock(block))
+		if err != nil {
+			return err
 		}
+		peer.RecordFlow(peerpkg.FlowBlockRelay, time.Since(requestStart))
+		log.Debugf("Relayed block with hash %s", hash)
+	}
+	return nil
+}
+
+func sendCompactBlock(context RelayBlockRequestsContext, outgoingRoute *router.Route, peer *peerpkg.Peer,
+	requestCompactBlockMessage *appmessage.MsgRequestCompactBlock) error {
+
+	requestStart := time.Now()
+	hash := requestCompactBlockMessage.Hash
+	log.Debugf("Got request for compact block %s", hash)
+	block, err := getRequestedRelayBlock(context, peer, hash)
+	if err != nil {
+		return err
+	}
+
+	err = outgoingRoute.Enqueue(domainBlockToMsgCompactBlock(block))
+	if err != nil {
+		return err
+	}
+	peer.RecordFlow(peerpkg.FlowBlockRelay, time.Since(requestStart))
+	log.Debugf("Relayed compact block with hash %s", hash)
+	return nil
+}
+
+func sendBlockTransactions(context RelayBlockRequestsContext, outgoingRoute *router.Route,
+	requestBlockTransactionsMessage *appmessage.MsgRequestBlockTransactions) error {
+
+	hash := requestBlockTransactionsMessage.BlockHash
+	log.Debugf("Got request for %d transactions of block %s", len(requestBlockTransactionsMessage.Indexes), hash)
+	block, found, err := context.Domain().Consensus().GetBlock(hash)
+	if err != nil {
+		return errors.Wrapf(err, "unable to fetch requested block hash %s", hash)
+	}
+	if !found {
+		return protocolerrors.Errorf(false, "Relay block %s not found", hash)
+	}
+
+	transactions := make([]*appmessage.MsgTx, len(requestBlockTransactionsMessage.Indexes))
+	for i, index := range requestBlockTransactionsMessage.Indexes {
+		if int(index) >= len(block.Transactions) {
+			return protocolerrors.Errorf(true, "requested transaction %d of block %s, which has "+
+				"only %d transactions", index, hash, len(block.Transactions))
+		}
+		transactions[i] = appmessage.DomainTransactionToMsgTx(block.Transactions[index])
+	}
+
+	return outgoingRoute.Enqueue(appmessage.NewMsgBlockTransactions(hash, transactions))
+}
+
+func getRequestedRelayBlock(context RelayBlockRequestsContext, peer *peerpkg.Peer,
+	hash *externalapi.DomainHash) (*externalapi.DomainBlock, error) {
+
+	if peer.RecordBlockRequest(hash) {
+		log.Warnf("Peer %s keeps requesting blocks it already requested, which makes it a "+
+			"candidate for banning", peer)
+	}
+
+	// Fetch the block from the database.
+	block, found, err := context.Domain().Consensus().GetBlock(hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch requested block hash %s", hash)
+	}
+
+	if !found {
+		return nil, protocolerrors.Errorf(false, "Relay block %s not found", hash)
 	}
+	return block, nil
 }
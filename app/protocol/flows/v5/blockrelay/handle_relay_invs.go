@@ -12,6 +12,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/hashset"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
@@ -27,6 +28,7 @@ type RelayInvsContext interface {
 	Domain() domain.Domain
 	Config() *config.Config
 	OnNewBlock(block *externalapi.DomainBlock) error
+	MarkBlockReceived()
 	OnNewBlockTemplate() error
 	OnPruningPointUTXOSetOverride() error
 	SharedRequestedBlocks() *flowcontext.SharedRequestedBlocks
@@ -37,6 +39,7 @@ type RelayInvsContext interface {
 	IsIBDRunning() bool
 	IsRecoverableError(err error) bool
 	IsNearlySynced() (bool, error)
+	UpdatePeerClaimedTip(peer *peerpkg.Peer, header externalapi.BlockHeader) error
 }
 
 type invRelayBlock struct {
@@ -78,6 +81,9 @@ func (flow *handleRelayInvsFlow) start() error {
 		}
 
 		log.Debugf("Got relay inv for block %s", inv.Hash)
+		if !inv.IsOrphanRoot {
+			flow.peer.SetLastAnnouncedBlockHash(inv.Hash)
+		}
 
 		blockInfo, err := flow.Domain().Consensus().GetBlockInfo(inv.Hash)
 		if err != nil {
@@ -130,7 +136,7 @@ func (flow *handleRelayInvsFlow) start() error {
 			return err
 		}
 		if exists {
-			log.Debugf("Aborting requesting block %s because it already exists", inv.Hash)
+			log.Debugf("Aborting requesting block %s because it is already being requested from another peer", inv.Hash)
 			continue
 		}
 
@@ -139,6 +145,13 @@ func (flow *handleRelayInvsFlow) start() error {
 			return err
 		}
 
+		// The block is claimed by the peer even if it turns out to be an
+		// orphan whose ancestors the peer withholds
+		err = flow.UpdatePeerClaimedTip(flow.peer, block.Header)
+		if err != nil {
+			return err
+		}
+
 		if flow.Config().NetParams().DisallowDirectBlocksOnTopOfGenesis && !flow.Config().AllowSubmitBlockWhenNotSynced && !flow.Config().Devnet && flow.isChildOfGenesis(block) {
 			log.Infof("Cannot process %s because it's a direct child of genesis.", consensushashing.BlockHash(block))
 			continue
@@ -186,6 +199,7 @@ func (flow *handleRelayInvsFlow) start() error {
 			}
 			return err
 		}
+
 		if len(missingParents) > 0 {
 			log.Debugf("Block %s is orphan and has missing parents: %s", inv.Hash, missingParents)
 			err := flow.processOrphan(block)
@@ -273,53 +287,185 @@ func (flow *handleRelayInvsFlow) readInv() (invRelayBlock, error) {
 }
 
 func (flow *handleRelayInvsFlow) requestBlock(requestHash *externalapi.DomainHash) (*externalapi.DomainBlock, bool, error) {
-	exists := flow.SharedRequestedBlocks().AddIfNotExists(requestHash)
+	exists := flow.SharedRequestedBlocks().AddIfNotExists(requestHash, flow.incomingRoute)
 	if exists {
 		return nil, true, nil
 	}
 
 	// In case the function returns earlier than expected, we want to make sure flow.SharedRequestedBlocks() is
-	// clean from any pending blocks.
-	defer flow.SharedRequestedBlocks().Remove(requestHash)
+	// clean from any pending blocks, and that the block is requested from another peer that announced it.
+	isReceived := false
+	defer func() {
+		if isReceived {
+			flow.SharedRequestedBlocks().Remove(requestHash)
+			return
+		}
+		flow.SharedRequestedBlocks().RemoveAndFallBack(requestHash)
+	}()
+
+	var block *externalapi.DomainBlock
+	var err error
+	if flow.shouldRequestCompactBlock() {
+		block, err = flow.requestCompactBlock(requestHash)
+	} else {
+		block, err = flow.requestFullBlock(requestHash)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	isReceived = true
+	return block, false, nil
+}
+
+// shouldRequestCompactBlock returns whether relay blocks should be requested
+// from the peer as compact blocks
+func (flow *handleRelayInvsFlow) shouldRequestCompactBlock() bool {
+	return !flow.Config().NoCompactBlocks && flow.peer.Services()&appmessage.SFNodeCompactBlocks != 0
+}
 
+func (flow *handleRelayInvsFlow) requestFullBlock(requestHash *externalapi.DomainHash) (*externalapi.DomainBlock, error) {
 	getRelayBlocksMsg := appmessage.NewMsgRequestRelayBlocks([]*externalapi.DomainHash{requestHash})
 	err := flow.outgoingRoute.Enqueue(getRelayBlocksMsg)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
 	msgBlock, err := flow.readMsgBlock()
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
 	block := appmessage.MsgBlockToDomainBlock(msgBlock)
 	blockHash := consensushashing.BlockHash(block)
 	if !blockHash.Equal(requestHash) {
-		return nil, false, protocolerrors.Errorf(true, "got unrequested block %s", blockHash)
+		return nil, protocolerrors.Errorf(true, "got unrequested block %s", blockHash)
 	}
+	return block, nil
+}
 
-	return block, false, nil
+// requestCompactBlock requests the block as a compact block, and rebuilds it
+// out of the mempool. Transactions missing from the mempool are requested from
+// the peer, and if the rebuilt block still doesn't match its header, the block
+// is requested in full.
+func (flow *handleRelayInvsFlow) requestCompactBlock(requestHash *externalapi.DomainHash) (*externalapi.DomainBlock, error) {
+	err := flow.outgoingRoute.Enqueue(appmessage.NewMsgRequestCompactBlock(requestHash))
+	if err != nil {
+		return nil, err
+	}
+
+	msgCompactBlock, err := flow.readMsgCompactBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	header := appmessage.BlockHeaderToDomainBlockHeader(&msgCompactBlock.Header)
+	blockHash := consensushashing.HeaderHash(header)
+	if !blockHash.Equal(requestHash) {
+		return nil, protocolerrors.Errorf(true, "got unrequested compact block %s", blockHash)
+	}
+
+	mempoolTransactions, _ := flow.Domain().MiningManager().AllTransactions(true, false)
+	transactions, missingIndexes, err := reconstructBlockTransactions(blockHash, msgCompactBlock, mempoolTransactions)
+	if err != nil {
+		return nil, protocolerrors.Wrapf(true, err, "got malformed compact block %s", blockHash)
+	}
+	log.Debugf("Rebuilt compact block %s out of the mempool with %d out of %d transactions missing",
+		blockHash, len(missingIndexes), len(transactions))
+
+	if len(missingIndexes) > 0 {
+		err := flow.outgoingRoute.Enqueue(appmessage.NewMsgRequestBlockTransactions(blockHash, missingIndexes))
+		if err != nil {
+			return nil, err
+		}
+
+		msgBlockTransactions, err := flow.readMsgBlockTransactions()
+		if err != nil {
+			return nil, err
+		}
+		if !msgBlockTransactions.BlockHash.Equal(blockHash) {
+			return nil, protocolerrors.Errorf(true, "got transactions of unrequested block %s",
+				msgBlockTransactions.BlockHash)
+		}
+		if len(msgBlockTransactions.Transactions) != len(missingIndexes) {
+			return nil, protocolerrors.Errorf(true, "requested %d transactions of block %s but got %d",
+				len(missingIndexes), blockHash, len(msgBlockTransactions.Transactions))
+		}
+		for i, index := range missingIndexes {
+			transactions[index] = appmessage.MsgTxToDomainTransaction(msgBlockTransactions.Transactions[i])
+		}
+	}
+
+	// A mempool transaction may share its ID, and therefore its short ID, with a
+	// block transaction while having a different signature script. In that case the
+	// rebuilt block won't match its header's merkle root
+	if !merkle.CalculateHashMerkleRoot(transactions).Equal(header.HashMerkleRoot()) {
+		log.Debugf("Compact block %s doesn't match its merkle root once rebuilt. "+
+			"Requesting the full block instead", blockHash)
+		return flow.requestFullBlock(requestHash)
+	}
+
+	return &externalapi.DomainBlock{
+		Header:       header,
+		Transactions: transactions,
+	}, nil
 }
 
 // readMsgBlock returns the next msgBlock in msgChan, and populates invsQueue with any inv messages that meanwhile arrive.
-//
-// Note: this function assumes msgChan can contain only appmessage.MsgInvRelayBlock and appmessage.MsgBlock messages.
-func (flow *handleRelayInvsFlow) readMsgBlock() (msgBlock *appmessage.MsgBlock, err error) {
+func (flow *handleRelayInvsFlow) readMsgBlock() (*appmessage.MsgBlock, error) {
+	message, err := flow.readNonInvMessage()
+	if err != nil {
+		return nil, err
+	}
+	msgBlock, ok := message.(*appmessage.MsgBlock)
+	if !ok {
+		return nil, errors.Errorf("unexpected message %s", message.Command())
+	}
+	return msgBlock, nil
+}
+
+// readMsgCompactBlock returns the next msgCompactBlock in msgChan, and populates invsQueue with any inv messages
+// that meanwhile arrive.
+func (flow *handleRelayInvsFlow) readMsgCompactBlock() (*appmessage.MsgCompactBlock, error) {
+	message, err := flow.readNonInvMessage()
+	if err != nil {
+		return nil, err
+	}
+	msgCompactBlock, ok := message.(*appmessage.MsgCompactBlock)
+	if !ok {
+		return nil, errors.Errorf("unexpected message %s", message.Command())
+	}
+	return msgCompactBlock, nil
+}
+
+// readMsgBlockTransactions returns the next msgBlockTransactions in msgChan, and populates invsQueue with any
+// inv messages that meanwhile arrive.
+func (flow *handleRelayInvsFlow) readMsgBlockTransactions() (*appmessage.MsgBlockTransactions, error) {
+	message, err := flow.readNonInvMessage()
+	if err != nil {
+		return nil, err
+	}
+	msgBlockTransactions, ok := message.(*appmessage.MsgBlockTransactions)
+	if !ok {
+		return nil, errors.Errorf("unexpected message %s", message.Command())
+	}
+	return msgBlockTransactions, nil
+}
+
+// readNonInvMessage returns the next message in msgChan that isn't an inv message, and populates invsQueue with
+// any inv messages that meanwhile arrive.
+func (flow *handleRelayInvsFlow) readNonInvMessage() (appmessage.Message, error) {
 	for {
 		message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
 		if err != nil {
 			return nil, err
 		}
 
-		switch message := message.(type) {
-		case *appmessage.MsgInvRelayBlock:
-			flow.invsQueue = append(flow.invsQueue, invRelayBlock{Hash: message.Hash, IsOrphanRoot: false})
-		case *appmessage.MsgBlock:
+		msgInv, ok := message.(*appmessage.MsgInvRelayBlock)
+		if !ok {
 			return message, nil
-		default:
-			return nil, errors.Errorf("unexpected message %s", message.Command())
 		}
+		flow.invsQueue = append(flow.invsQueue, invRelayBlock{Hash: msgInv.Hash, IsOrphanRoot: false})
 	}
 }
 
@@ -0,0 +1,134 @@
+package blockrelay
+
+import (
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// HandleRelayInvsHeadersOnly is the counterpart of HandleRelayInvs for headers-only nodes.
+// It listens to appmessage.MsgInvRelayBlock messages, requests their corresponding blocks
+// if their headers are missing, and adds only the headers to the DAG. Blocks whose past
+// is missing are synced via IBD rather than via the orphan pool. Since a headers-only node
+// has no block bodies, it never propagates blocks to the rest of the network.
+func HandleRelayInvsHeadersOnly(context RelayInvsContext, incomingRoute *router.Route, outgoingRoute *router.Route,
+	peer *peerpkg.Peer) error {
+
+	flow := &handleRelayInvsFlow{
+		RelayInvsContext: context,
+		incomingRoute:    incomingRoute,
+		outgoingRoute:    outgoingRoute,
+		peer:             peer,
+		invsQueue:        make([]invRelayBlock, 0),
+	}
+	err := flow.startHeadersOnly()
+	// This flow replaces HandleRelayInvs, which is otherwise the only place where IBD
+	// is triggered, so the channel can be closed now
+	close(peer.IBDRequestChannel())
+	return err
+}
+
+func (flow *handleRelayInvsFlow) startHeadersOnly() error {
+	for {
+		log.Debugf("Waiting for inv")
+		inv, err := flow.readInv()
+		if err != nil {
+			return err
+		}
+
+		log.Debugf("Got relay inv for block %s", inv.Hash)
+
+		blockInfo, err := flow.Domain().Consensus().GetBlockInfo(inv.Hash)
+		if err != nil {
+			return err
+		}
+		if blockInfo.Exists {
+			if blockInfo.BlockStatus == externalapi.StatusInvalid {
+				return protocolerrors.Errorf(true, "sent inv of an invalid block %s",
+					inv.Hash)
+			}
+			log.Debugf("Header of block %s already exists. continuing...", inv.Hash)
+			continue
+		}
+
+		// The headers relayed meanwhile are expected to be synced by the IBD
+		if flow.IsIBDRunning() {
+			log.Debugf("Got block %s while in IBD. Continuing...", inv.Hash)
+			continue
+		}
+
+		log.Debugf("Requesting block %s", inv.Hash)
+		block, exists, err := flow.requestBlock(inv.Hash)
+		if err != nil {
+			return err
+		}
+		if exists {
+			log.Debugf("Aborting requesting block %s because it already exists", inv.Hash)
+			continue
+		}
+
+		if flow.Config().NetParams().DisallowDirectBlocksOnTopOfGenesis && !flow.Config().AllowSubmitBlockWhenNotSynced && !flow.Config().Devnet && flow.isChildOfGenesis(block) {
+			log.Infof("Cannot process %s because it's a direct child of genesis.", consensushashing.BlockHash(block))
+			continue
+		}
+
+		err = flow.UpdatePeerClaimedTip(flow.peer, block.Header)
+		if err != nil {
+			return err
+		}
+
+		log.Debugf("Processing header %s", inv.Hash)
+		headerOnlyBlock := &externalapi.DomainBlock{Header: block.Header}
+		hasMissingParents, err := flow.processHeaderOnlyBlock(headerOnlyBlock)
+		if err != nil {
+			if errors.Is(err, ruleerrors.ErrPrunedBlock) {
+				log.Infof("Ignoring pruned block %s", inv.Hash)
+				continue
+			}
+
+			if errors.Is(err, ruleerrors.ErrDuplicateBlock) {
+				log.Infof("Ignoring duplicate block %s", inv.Hash)
+				continue
+			}
+			return err
+		}
+
+		if hasMissingParents {
+			log.Debugf("Block %s has missing parents. Attempting to start IBD against it.", inv.Hash)
+
+			// Note that this is a non-blocking send, since if IBD is already running, there is no need to trigger it
+			select {
+			case flow.peer.IBDRequestChannel() <- headerOnlyBlock:
+			default:
+			}
+			continue
+		}
+
+		flow.MarkBlockReceived()
+		log.Infof("Accepted header %s via relay", inv.Hash)
+	}
+}
+
+func (flow *handleRelayInvsFlow) processHeaderOnlyBlock(block *externalapi.DomainBlock) (hasMissingParents bool, err error) {
+	blockHash := consensushashing.BlockHash(block)
+	err = flow.Domain().Consensus().ValidateAndInsertBlock(block, false)
+	if err != nil {
+		if !errors.As(err, &ruleerrors.RuleError{}) {
+			return false, errors.Wrapf(err, "failed to process header %s", blockHash)
+		}
+
+		if errors.As(err, &ruleerrors.ErrMissingParents{}) {
+			return true, nil
+		}
+		// A duplicate block should not appear to the user as a warning and is already reported in the calling function
+		if !errors.Is(err, ruleerrors.ErrDuplicateBlock) {
+			log.Warnf("Rejected header %s from %s: %s", blockHash, flow.peer, err)
+		}
+		return false, protocolerrors.Wrapf(true, err, "got invalid header %s from relay", blockHash)
+	}
+	return false, nil
+}
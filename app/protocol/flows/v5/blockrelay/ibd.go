@@ -10,6 +10,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
@@ -27,7 +28,9 @@ type IBDContext interface {
 	IsIBDRunning() bool
 	TrySetIBDRunning(ibdPeer *peerpkg.Peer) bool
 	UnsetIBDRunning()
+	IBDHelperPeers(ibdPeer *peerpkg.Peer, highHash *externalapi.DomainHash) ([]*peerpkg.Peer, error)
 	IsRecoverableError(err error) bool
+	UpdatePeerClaimedTip(peer *peerpkg.Peer, header externalapi.BlockHeader) error
 }
 
 type handleIBDFlow struct {
@@ -51,14 +54,22 @@ func HandleIBD(context IBDContext, incomingRoute *router.Route, outgoingRoute *r
 
 func (flow *handleIBDFlow) start() error {
 	for {
-		// Wait for IBD requests triggered by other flows
-		block, ok := <-flow.peer.IBDRequestChannel()
-		if !ok {
-			return nil
-		}
-		err := flow.runIBDIfNotRunning(block)
-		if err != nil {
-			return err
+		// Wait for IBD requests triggered by other flows, or for requests
+		// to download blocks on behalf of the IBD that runs with another peer
+		select {
+		case block, ok := <-flow.peer.IBDRequestChannel():
+			if !ok {
+				return nil
+			}
+			err := flow.runIBDIfNotRunning(block)
+			if err != nil {
+				return err
+			}
+		case request := <-flow.peer.IBDBlocksRequestChannel():
+			err := flow.handleIBDBlocksRequest(request)
+			if err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -70,9 +81,11 @@ func (flow *handleIBDFlow) runIBDIfNotRunning(block *externalapi.DomainBlock) er
 		return nil
 	}
 
+	ibdStart := time.Now()
 	isFinishedSuccessfully := false
 	var err error
 	defer func() {
+		flow.peer.RecordFlow(peerpkg.FlowIBD, time.Since(ibdStart))
 		flow.UnsetIBDRunning()
 		flow.logIBDFinished(isFinishedSuccessfully, err)
 	}()
@@ -106,12 +119,12 @@ func (flow *handleIBDFlow) runIBDIfNotRunning(block *externalapi.DomainBlock) er
 		}
 	} else {
 		if flow.Config().NetParams().DisallowDirectBlocksOnTopOfGenesis && !flow.Config().AllowSubmitBlockWhenNotSynced {
-			isGenesisVirtualSelectedParent, err := flow.isGenesisVirtualSelectedParent()
+			isGenesisSelectedTip, err := flow.isGenesisSelectedTip()
 			if err != nil {
 				return err
 			}
 
-			if isGenesisVirtualSelectedParent {
+			if isGenesisSelectedTip {
 				log.Infof("Cannot IBD to %s because it won't change the pruning point. The node needs to IBD "+
 					"to the recent pruning point before normal operation can resume.", relayBlockHash)
 				return nil
@@ -126,8 +139,33 @@ func (flow *handleIBDFlow) runIBDIfNotRunning(block *externalapi.DomainBlock) er
 		}
 	}
 
+	// The headers up to the relay block were validated by now, so it's
+	// claimed by the peer even if the peer goes on to withhold the bodies
+	err = flow.UpdatePeerClaimedTip(flow.peer, block.Header)
+	if err != nil {
+		return err
+	}
+
+	if !flow.Config().HeadersOnly {
+		err = flow.syncMissingBodies(syncerHeaderSelectedTipHash, relayBlockHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = flow.warnIfContradictingCheckpoints()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Finished syncing blocks up to %s", relayBlockHash)
+	isFinishedSuccessfully = true
+	return nil
+}
+
+func (flow *handleIBDFlow) syncMissingBodies(syncerHeaderSelectedTipHash, relayBlockHash *externalapi.DomainHash) error {
 	// We start by syncing missing bodies over the syncer selected chain
-	err = flow.syncMissingBlockBodies(syncerHeaderSelectedTipHash)
+	err := flow.syncMissingBlockBodies(syncerHeaderSelectedTipHash)
 	if err != nil {
 		return err
 	}
@@ -140,17 +178,32 @@ func (flow *handleIBDFlow) runIBDIfNotRunning(block *externalapi.DomainBlock) er
 	// Note: this operation can be slightly optimized to avoid the full chain search since relay block
 	// is in syncer virtual mergeset which has bounded size.
 	if relayBlockInfo.BlockStatus == externalapi.StatusHeaderOnly {
-		err = flow.syncMissingBlockBodies(relayBlockHash)
-		if err != nil {
-			return err
-		}
+		return flow.syncMissingBlockBodies(relayBlockHash)
 	}
-
-	log.Debugf("Finished syncing blocks up to %s", relayBlockHash)
-	isFinishedSuccessfully = true
 	return nil
 }
 
+// warnIfContradictingCheckpoints warns if the pruning points the node has
+// synced contradict the checkpoints of the network. Peers whose pruning points
+// contradict the checkpoints are refused during IBD with headers proof, so this
+// can happen only if the node has synced its chain before the checkpoints
+// were embedded.
+func (flow *handleIBDFlow) warnIfContradictingCheckpoints() error {
+	if len(flow.Config().NetParams().Checkpoints) == 0 {
+		return nil
+	}
+	pruningPointHeaders, err := flow.Domain().Consensus().PruningPointHeaders()
+	if err != nil {
+		return err
+	}
+	err = flow.Config().NetParams().VerifyCheckpoints(pruningPointHeaders)
+	if errors.Is(err, dagconfig.ErrCheckpointContradiction) {
+		log.Criticalf("%s. The node is likely following a fake chain, and should be resynced from scratch", err)
+		return nil
+	}
+	return err
+}
+
 func (flow *handleIBDFlow) negotiateMissingSyncerChainSegment() (*externalapi.DomainHash, *externalapi.DomainHash, error) {
 	/*
 		Algorithm:
@@ -291,13 +344,22 @@ func (flow *handleIBDFlow) negotiateMissingSyncerChainSegment() (*externalapi.Do
 	return syncerHeaderSelectedTipHash, highestKnownSyncerChainHash, nil
 }
 
-func (flow *handleIBDFlow) isGenesisVirtualSelectedParent() (bool, error) {
-	virtualSelectedParent, err := flow.Domain().Consensus().GetVirtualSelectedParent()
+// selectedTip returns the virtual selected parent. Headers-only nodes
+// never move their virtual, so their headers selected tip is returned instead.
+func (flow *handleIBDFlow) selectedTip() (*externalapi.DomainHash, error) {
+	if flow.Config().HeadersOnly {
+		return flow.Domain().Consensus().GetHeadersSelectedTip()
+	}
+	return flow.Domain().Consensus().GetVirtualSelectedParent()
+}
+
+func (flow *handleIBDFlow) isGenesisSelectedTip() (bool, error) {
+	selectedTip, err := flow.selectedTip()
 	if err != nil {
 		return false, err
 	}
 
-	return virtualSelectedParent.Equal(flow.Config().NetParams().GenesisHash), nil
+	return selectedTip.Equal(flow.Config().NetParams().GenesisHash), nil
 }
 
 func (flow *handleIBDFlow) logIBDFinished(isFinishedSuccessfully bool, err error) {
@@ -652,42 +714,21 @@ func (flow *handleIBDFlow) syncMissingBlockBodies(highHash *externalapi.DomainHa
 		return err
 	}
 
-	for offset := 0; offset < len(hashes); offset += ibdBatchSize {
-		var hashesToRequest []*externalapi.DomainHash
-		if offset+ibdBatchSize < len(hashes) {
-			hashesToRequest = hashes[offset : offset+ibdBatchSize]
-		} else {
-			hashesToRequest = hashes[offset:]
-		}
+	downloader, err := flow.newIBDBlocksDownloader(hashes)
+	if err != nil {
+		return err
+	}
 
-		err := flow.outgoingRoute.Enqueue(appmessage.NewMsgRequestIBDBlocks(hashesToRequest))
+	for batchIndex := range downloader.batches {
+		// The blocks are downloaded in parallel, but are inserted in the order of the batches,
+		// which is topological
+		blocks, err := downloader.blocks(batchIndex)
 		if err != nil {
 			return err
 		}
 
-		for _, expectedHash := range hashesToRequest {
-			message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
-			if err != nil {
-				return err
-			}
-
-			msgIBDBlock, ok := message.(*appmessage.MsgIBDBlock)
-			if !ok {
-				return protocolerrors.Errorf(true, "received unexpected message type. "+
-					"expected: %s, got: %s", appmessage.CmdIBDBlock, message.Command())
-			}
-
-			block := appmessage.MsgBlockToDomainBlock(msgIBDBlock.MsgBlock)
+		for _, block := range blocks {
 			blockHash := consensushashing.BlockHash(block)
-			if !expectedHash.Equal(blockHash) {
-				return protocolerrors.Errorf(true, "expected block %s but got %s", expectedHash, blockHash)
-			}
-
-			err = flow.banIfBlockIsHeaderOnly(block)
-			if err != nil {
-				return err
-			}
-
 			err = flow.Domain().Consensus().ValidateAndInsertBlock(block, updateVirtual)
 			if err != nil {
 				if errors.Is(err, ruleerrors.ErrDuplicateBlock) {
@@ -704,7 +745,7 @@ func (flow *handleIBDFlow) syncMissingBlockBodies(highHash *externalapi.DomainHa
 			highestProcessedDAAScore = block.Header.DAAScore()
 		}
 
-		progressReporter.reportProgress(len(hashesToRequest), highestProcessedDAAScore)
+		progressReporter.reportProgress(len(blocks), highestProcessedDAAScore)
 	}
 
 	// We need to resolve virtual only if it wasn't updated while syncing block bodies
@@ -0,0 +1,221 @@
+package blockrelay
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/protocol/common"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+)
+
+const (
+	// maxIBDHelperPeers is the maximum number of peers that download block
+	// bodies in parallel to the IBD peer
+	maxIBDHelperPeers = 8
+
+	// maxIBDBatchesAhead is the maximum number of batches, beyond the one
+	// that's being inserted, that may be downloaded in advance. It bounds the
+	// amount of downloaded blocks that are held in memory.
+	maxIBDBatchesAhead = 2 * maxIBDHelperPeers
+
+	// ibdHelperBatchTimeout is the amount of time a helper peer has to
+	// download a batch. Once it's exceeded, the batch is downloaded from the
+	// IBD peer instead, so that a slow helper can't stall the IBD.
+	ibdHelperBatchTimeout = common.DefaultTimeout
+)
+
+// ibdBlocksDownloader downloads the bodies of the blocks missing during IBD,
+// split into batches. The IBD peer downloads the batch that's about to be
+// inserted, while idle helper peers download the batches that follow it. A
+// batch that a helper failed to download in time is downloaded from the IBD
+// peer.
+type ibdBlocksDownloader struct {
+	flow    *handleIBDFlow
+	batches [][]*externalapi.DomainHash
+
+	idleHelpers     []*peerpkg.Peer
+	responses       chan *peerpkg.IBDBlocksResponse
+	pendingRequests map[*peerpkg.IBDBlocksRequest]int
+	downloaded      map[int][]*externalapi.DomainBlock
+
+	// pendingBatches maps the batches requested from helpers to their deadlines
+	pendingBatches map[int]time.Time
+
+	// nextBatchToDispatch is the index of the first batch that was neither
+	// requested from a helper nor left to the IBD peer
+	nextBatchToDispatch int
+}
+
+func (flow *handleIBDFlow) newIBDBlocksDownloader(hashes []*externalapi.DomainHash) (*ibdBlocksDownloader, error) {
+	var batches [][]*externalapi.DomainHash
+	for offset := 0; offset < len(hashes); offset += ibdBatchSize {
+		if offset+ibdBatchSize < len(hashes) {
+			batches = append(batches, hashes[offset:offset+ibdBatchSize])
+		} else {
+			batches = append(batches, hashes[offset:])
+		}
+	}
+
+	var helpers []*peerpkg.Peer
+	if len(batches) > 1 {
+		var err error
+		helpers, err = flow.IBDHelperPeers(flow.peer, hashes[len(hashes)-1])
+		if err != nil {
+			return nil, err
+		}
+		if len(helpers) > maxIBDHelperPeers {
+			helpers = helpers[:maxIBDHelperPeers]
+		}
+	}
+	if len(helpers) > 0 {
+		log.Infof("Downloading the bodies of %d blocks from %s and %d more peers",
+			len(hashes), flow.peer, len(helpers))
+	}
+
+	return &ibdBlocksDownloader{
+		flow:        flow,
+		batches:     batches,
+		idleHelpers: helpers,
+		// Every helper has at most one pending request, so sending a
+		// response never blocks, even once the downloader is gone
+		responses:       make(chan *peerpkg.IBDBlocksResponse, len(helpers)),
+		pendingRequests: make(map[*peerpkg.IBDBlocksRequest]int),
+		pendingBatches:  make(map[int]time.Time),
+		downloaded:      make(map[int][]*externalapi.DomainBlock),
+	}, nil
+}
+
+// blocks returns the blocks of the batch with the given index. It must be
+// called for every batch, in order.
+func (d *ibdBlocksDownloader) blocks(batchIndex int) ([]*externalapi.DomainBlock, error) {
+	// The IBD peer is idle while the batch is awaited, so the batch is
+	// downloaded from it, unless it was already requested from a helper
+	if d.nextBatchToDispatch == batchIndex {
+		d.nextBatchToDispatch++
+	}
+	d.dispatchToIdleHelpers(batchIndex)
+
+	for {
+		if blocks, ok := d.downloaded[batchIndex]; ok {
+			delete(d.downloaded, batchIndex)
+			return blocks, nil
+		}
+		deadline, ok := d.pendingBatches[batchIndex]
+		if !ok {
+			return d.flow.downloadIBDBlocks(d.batches[batchIndex])
+		}
+
+		select {
+		case response := <-d.responses:
+			d.handleResponse(response)
+		case <-time.After(time.Until(deadline)):
+			// The helper isn't given any further batches, and its response
+			// is dropped if it ever arrives
+			log.Infof("A helper peer didn't download IBD batch %d in time, so it's downloaded from %s instead",
+				batchIndex, d.flow.peer)
+			delete(d.pendingBatches, batchIndex)
+		}
+		d.dispatchToIdleHelpers(batchIndex)
+	}
+}
+
+func (d *ibdBlocksDownloader) dispatchToIdleHelpers(currentBatchIndex int) {
+	for len(d.idleHelpers) > 0 && d.nextBatchToDispatch < len(d.batches) &&
+		d.nextBatchToDispatch <= currentBatchIndex+maxIBDBatchesAhead {
+
+		helper := d.idleHelpers[len(d.idleHelpers)-1]
+		d.idleHelpers = d.idleHelpers[:len(d.idleHelpers)-1]
+
+		request := &peerpkg.IBDBlocksRequest{
+			Hashes:          d.batches[d.nextBatchToDispatch],
+			ResponseChannel: d.responses,
+		}
+		select {
+		case helper.IBDBlocksRequestChannel() <- request:
+			d.pendingRequests[request] = d.nextBatchToDispatch
+			d.pendingBatches[d.nextBatchToDispatch] = time.Now().Add(ibdHelperBatchTimeout)
+			d.nextBatchToDispatch++
+		default:
+			// The IBD flow of the helper is either busy or gone, so it's not used anymore
+			log.Debugf("Not downloading IBD blocks from %s since it's not available", helper)
+		}
+	}
+}
+
+func (d *ibdBlocksDownloader) handleResponse(response *peerpkg.IBDBlocksResponse) {
+	batchIndex := d.pendingRequests[response.Request]
+	delete(d.pendingRequests, response.Request)
+	if _, ok := d.pendingBatches[batchIndex]; !ok {
+		log.Debugf("Dropping the IBD blocks that %s downloaded after the deadline", response.Peer)
+		return
+	}
+	delete(d.pendingBatches, batchIndex)
+
+	if response.Err != nil {
+		log.Infof("Couldn't download IBD blocks from %s, so they're downloaded from %s instead: %s",
+			response.Peer, d.flow.peer, response.Err)
+		return
+	}
+	d.downloaded[batchIndex] = response.Blocks
+	d.idleHelpers = append(d.idleHelpers, response.Peer)
+}
+
+// handleIBDBlocksRequest downloads blocks from the peer of this flow on behalf
+// of the IBD that runs with another peer
+func (flow *handleIBDFlow) handleIBDBlocksRequest(request *peerpkg.IBDBlocksRequest) error {
+	blocks, err := flow.downloadIBDBlocks(request.Hashes)
+	request.ResponseChannel <- &peerpkg.IBDBlocksResponse{
+		Request: request,
+		Peer:    flow.peer,
+		Blocks:  blocks,
+		Err:     err,
+	}
+	return err
+}
+
+// downloadIBDBlocks requests the given blocks from the peer of this flow. The
+// received blocks are checked against their hashes, so that the peer they
+// were downloaded from, rather than the IBD peer, is blamed for a mismatch.
+func (flow *handleIBDFlow) downloadIBDBlocks(hashes []*externalapi.DomainHash) ([]*externalapi.DomainBlock, error) {
+	err := flow.outgoingRoute.Enqueue(appmessage.NewMsgRequestIBDBlocks(hashes))
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*externalapi.DomainBlock, 0, len(hashes))
+	for _, expectedHash := range hashes {
+		message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		msgIBDBlock, ok := message.(*appmessage.MsgIBDBlock)
+		if !ok {
+			return nil, protocolerrors.Errorf(true, "received unexpected message type. "+
+				"expected: %s, got: %s", appmessage.CmdIBDBlock, message.Command())
+		}
+
+		block := appmessage.MsgBlockToDomainBlock(msgIBDBlock.MsgBlock)
+		blockHash := consensushashing.BlockHash(block)
+		if !expectedHash.Equal(blockHash) {
+			return nil, protocolerrors.Errorf(true, "expected block %s but got %s", expectedHash, blockHash)
+		}
+
+		err = flow.banIfBlockIsHeaderOnly(block)
+		if err != nil {
+			return nil, err
+		}
+
+		if !merkle.CalculateHashMerkleRoot(block.Transactions).Equal(block.Header.HashMerkleRoot()) {
+			return nil, protocolerrors.Errorf(true, "the transactions of block %s don't match its "+
+				"merkle root", blockHash)
+		}
+
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
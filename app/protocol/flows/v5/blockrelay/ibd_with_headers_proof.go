@@ -60,7 +60,8 @@ func (flow *handleIBDFlow) shouldSyncAndShouldDownloadHeadersProof(
 			return false, false, err
 		}
 
-		highestSharedBlockFound = blockInfo.HasBody()
+		// Headers-only nodes have no bodies, so sharing the header is enough
+		highestSharedBlockFound = blockInfo.HasBody() || (flow.Config().HeadersOnly && blockInfo.Exists)
 		pruningPoint, err := flow.Domain().Consensus().PruningPoint()
 		if err != nil {
 			return false, false, err
@@ -97,12 +98,12 @@ func (flow *handleIBDFlow) shouldSyncAndShouldDownloadHeadersProof(
 }
 
 func (flow *handleIBDFlow) checkIfHighHashHasMoreBlueWorkThanSelectedTipAndPruningDepthMoreBlueScore(relayBlock *externalapi.DomainBlock) (bool, error) {
-	virtualSelectedParent, err := flow.Domain().Consensus().GetVirtualSelectedParent()
+	selectedTip, err := flow.selectedTip()
 	if err != nil {
 		return false, err
 	}
 
-	virtualSelectedTipInfo, err := flow.Domain().Consensus().GetBlockInfo(virtualSelectedParent)
+	virtualSelectedTipInfo, err := flow.Domain().Consensus().GetBlockInfo(selectedTip)
 	if err != nil {
 		return false, err
 	}
@@ -188,6 +189,9 @@ func (flow *handleIBDFlow) downloadHeadersAndPruningUTXOSet(
 		return err
 	}
 
+	// Note that headers-only nodes sync the pruning point UTXO set as well, since
+	// importing it is what anchors the new pruning point in consensus. It's never
+	// updated afterwards.
 	log.Debugf("Syncing the current pruning point UTXO set")
 	syncedPruningPointUTXOSetSuccessfully, err := flow.syncPruningPointUTXOSet(flow.Domain().StagingConsensus(), proofPruningPoint)
 	if err != nil {
@@ -357,6 +361,11 @@ func (flow *handleIBDFlow) validateAndInsertPruningPoints(proofPruningPoint *ext
 		headers[i] = appmessage.BlockHeaderToDomainBlockHeader(header)
 	}
 
+	err = flow.Config().NetParams().VerifyCheckpoints(headers)
+	if err != nil {
+		return protocolerrors.Wrapf(true, err, "the pruning points of peer %s contradict the checkpoints", flow.peer)
+	}
+
 	arePruningPointsViolatingFinality, err := flow.Domain().Consensus().ArePruningPointsViolatingFinality(headers)
 	if err != nil {
 		return err
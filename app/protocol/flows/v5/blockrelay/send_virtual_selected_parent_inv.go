@@ -18,6 +18,12 @@ type SendVirtualSelectedParentInvContext interface {
 func SendVirtualSelectedParentInv(context SendVirtualSelectedParentInvContext,
 	outgoingRoute *router.Route, peer *peerpkg.Peer) error {
 
+	// A headers-only node can't serve the bodies the peer would request in return
+	if context.Config().HeadersOnly {
+		log.Debugf("Skipping sending the virtual selected parent hash to peer %s because the node is headers-only", peer)
+		return nil
+	}
+
 	virtualSelectedParent, err := context.Domain().Consensus().GetVirtualSelectedParent()
 	if err != nil {
 		return err
@@ -39,14 +39,23 @@ func SendPings(context SendPingsContext, incomingRoute *router.Route, outgoingRo
 
 func (flow *sendPingsFlow) start() error {
 	const pingInterval = 2 * time.Minute
-	ticker := time.NewTicker(pingInterval)
-	defer ticker.Stop()
 
 	for {
+		// Wait for the next ping on the incoming route rather than on a ticker,
+		// so that the flow ends as soon as the peer disconnects, and the peer is
+		// removed from the peer list right away
+		unexpectedMessage, err := flow.incomingRoute.DequeueWithTimeout(pingInterval)
+		if err == nil {
+			return protocolerrors.Errorf(true, "unexpected %s message while no ping is pending",
+				unexpectedMessage.Command())
+		}
+		if !errors.Is(err, router.ErrTimeout) {
+			return err
+		}
 		select {
 		case <-flow.ShutdownChan():
 			return nil
-		case <-ticker.C:
+		default:
 		}
 
 		nonce, err := random.Uint64()
@@ -64,7 +73,7 @@ func (flow *sendPingsFlow) start() error {
 		message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
 		if err != nil {
 			if errors.Is(err, router.ErrTimeout) {
-				return errors.Wrapf(flowcontext.ErrPingTimeout, err.Error())
+				return errors.Wrap(flowcontext.ErrPingTimeout, err.Error())
 			}
 			return err
 		}
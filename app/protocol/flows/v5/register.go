@@ -41,7 +41,7 @@ func registerAddressFlows(m protocolManager, router *routerpkg.Router, isStoppin
 	return []*common.Flow{
 		m.RegisterFlow("SendAddresses", router, []appmessage.MessageCommand{appmessage.CmdRequestAddresses}, isStopping, errChan,
 			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
-				return addressexchange.SendAddresses(m.Context(), incomingRoute, outgoingRoute)
+				return addressexchange.SendAddresses(m.Context(), incomingRoute, outgoingRoute, peer)
 			},
 		),
 
@@ -56,6 +56,11 @@ func registerAddressFlows(m protocolManager, router *routerpkg.Router, isStoppin
 func registerBlockRelayFlows(m protocolManager, router *routerpkg.Router, isStopping *uint32, errChan chan error) []*common.Flow {
 	outgoingRoute := router.OutgoingRoute()
 
+	handleRelayInvs := blockrelay.HandleRelayInvs
+	if m.Context().Config().HeadersOnly {
+		handleRelayInvs = blockrelay.HandleRelayInvsHeadersOnly
+	}
+
 	return []*common.Flow{
 		m.RegisterOneTimeFlow("SendVirtualSelectedParentInv", router, []appmessage.MessageCommand{},
 			isStopping, errChan, func(route *routerpkg.Route, peer *peerpkg.Peer) error {
@@ -64,9 +69,10 @@ func registerBlockRelayFlows(m protocolManager, router *routerpkg.Router, isStop
 
 		m.RegisterFlow("HandleRelayInvs", router, []appmessage.MessageCommand{
 			appmessage.CmdInvRelayBlock, appmessage.CmdBlock, appmessage.CmdBlockLocator,
+			appmessage.CmdCompactBlock, appmessage.CmdBlockTransactions,
 		},
 			isStopping, errChan, func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
-				return blockrelay.HandleRelayInvs(m.Context(), incomingRoute,
+				return handleRelayInvs(m.Context(), incomingRoute,
 					outgoingRoute, peer)
 			},
 		),
@@ -86,7 +92,9 @@ func registerBlockRelayFlows(m protocolManager, router *routerpkg.Router, isStop
 			},
 		),
 
-		m.RegisterFlow("HandleRelayBlockRequests", router, []appmessage.MessageCommand{appmessage.CmdRequestRelayBlocks}, isStopping, errChan,
+		m.RegisterFlow("HandleRelayBlockRequests", router, []appmessage.MessageCommand{
+			appmessage.CmdRequestRelayBlocks, appmessage.CmdRequestCompactBlock, appmessage.CmdRequestBlockTransactions,
+		}, isStopping, errChan,
 			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
 				return blockrelay.HandleRelayBlockRequests(m.Context(), incomingRoute, outgoingRoute, peer)
 			},
@@ -183,13 +191,13 @@ func registerTransactionRelayFlow(m protocolManager, router *routerpkg.Router, i
 		m.RegisterFlowWithCapacity("HandleRelayedTransactions", 10_000, router,
 			[]appmessage.MessageCommand{appmessage.CmdInvTransaction, appmessage.CmdTx, appmessage.CmdTransactionNotFound}, isStopping, errChan,
 			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
-				return transactionrelay.HandleRelayedTransactions(m.Context(), incomingRoute, outgoingRoute)
+				return transactionrelay.HandleRelayedTransactions(m.Context(), incomingRoute, outgoingRoute, peer, peer.IsOutbound())
 			},
 		),
 		m.RegisterFlow("HandleRequestTransactions", router,
 			[]appmessage.MessageCommand{appmessage.CmdRequestTransactions}, isStopping, errChan,
 			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
-				return transactionrelay.HandleRequestedTransactions(m.Context(), incomingRoute, outgoingRoute)
+				return transactionrelay.HandleRequestedTransactions(m.Context(), incomingRoute, outgoingRoute, peer)
 			},
 		),
 	}
@@ -1,9 +1,12 @@
 package transactionrelay
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/protocol/common"
 	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
 	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
 	"github.com/kaspanet/kaspad/domain"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
@@ -19,8 +22,9 @@ import (
 type TransactionsRelayContext interface {
 	NetAdapter() *netadapter.NetAdapter
 	Domain() domain.Domain
-	SharedRequestedTransactions() *flowcontext.SharedRequestedTransactions
+	TransactionRequestScheduler() *flowcontext.TransactionRequestScheduler
 	OnTransactionAddedToMempool()
+	OnTransactionRequested(transactionID *externalapi.DomainTransactionID, peer *peerpkg.Peer)
 	EnqueueTransactionIDsForPropagation(transactionIDs []*externalapi.DomainTransactionID) error
 	IsNearlySynced() (bool, error)
 }
@@ -28,75 +32,105 @@ type TransactionsRelayContext interface {
 type handleRelayedTransactionsFlow struct {
 	TransactionsRelayContext
 	incomingRoute, outgoingRoute *router.Route
+	peer                         *peerpkg.Peer
+	isOutbound                   bool
 	invsQueue                    []*appmessage.MsgInvTransaction
 }
 
 // HandleRelayedTransactions listens to appmessage.MsgInvTransaction messages, requests their corresponding transactions if they
 // are missing, adds them to the mempool and propagates them to the rest of the network.
-func HandleRelayedTransactions(context TransactionsRelayContext, incomingRoute *router.Route, outgoingRoute *router.Route) error {
+// The requests are scheduled with the rest of the peers that announce the same transactions, see
+// flowcontext.TransactionRequestScheduler. isOutbound is whether the connection to the peer is outbound.
+func HandleRelayedTransactions(context TransactionsRelayContext, incomingRoute *router.Route, outgoingRoute *router.Route,
+	peer *peerpkg.Peer, isOutbound bool) error {
+
 	flow := &handleRelayedTransactionsFlow{
 		TransactionsRelayContext: context,
 		incomingRoute:            incomingRoute,
 		outgoingRoute:            outgoingRoute,
+		peer:                     peer,
+		isOutbound:               isOutbound,
 		invsQueue:                make([]*appmessage.MsgInvTransaction, 0),
 	}
 	return flow.start()
 }
 
 func (flow *handleRelayedTransactionsFlow) start() error {
+	// The transactions that were requested from this peer and not received
+	// yet are requested from the next peers that announced them
+	defer flow.TransactionRequestScheduler().RemovePeer(flow.peer)
+
 	for {
-		inv, err := flow.readInv()
+		requestedIDs, nextScheduleTime, err := flow.requestScheduledTransactions()
 		if err != nil {
 			return err
 		}
+		if len(requestedIDs) > 0 {
+			err = flow.receiveTransactions(requestedIDs)
+			if err != nil {
+				return err
+			}
+			continue
+		}
 
-		isNearlySynced, err := flow.IsNearlySynced()
+		inv, err := flow.readInv(nextScheduleTime)
 		if err != nil {
 			return err
 		}
-		// Transaction relay is disabled if the node is out of sync and thus not mining
-		if !isNearlySynced {
+		if inv == nil {
 			continue
 		}
 
-		requestedIDs, err := flow.requestInvTransactions(inv)
+		isNearlySynced, err := flow.IsNearlySynced()
 		if err != nil {
 			return err
 		}
-
-		err = flow.receiveTransactions(requestedIDs)
-		if err != nil {
-			return err
+		// Transaction relay is disabled if the node is out of sync and thus not mining
+		if !isNearlySynced {
+			continue
 		}
+
+		flow.announceInvTransactions(inv)
 	}
 }
 
-func (flow *handleRelayedTransactionsFlow) requestInvTransactions(
-	inv *appmessage.MsgInvTransaction) (requestedIDs []*externalapi.DomainTransactionID, err error) {
-
-	idsToRequest := make([]*externalapi.DomainTransactionID, 0, len(inv.TxIDs))
+func (flow *handleRelayedTransactionsFlow) announceInvTransactions(inv *appmessage.MsgInvTransaction) {
+	idsToAnnounce := make([]*externalapi.DomainTransactionID, 0, len(inv.TxIDs))
 	for _, txID := range inv.TxIDs {
 		if flow.isKnownTransaction(txID) {
 			continue
 		}
-		exists := flow.SharedRequestedTransactions().AddIfNotExists(txID)
-		if exists {
+		idsToAnnounce = append(idsToAnnounce, txID)
+	}
+	flow.TransactionRequestScheduler().Announce(flow.peer, flow.isOutbound, idsToAnnounce)
+}
+
+// requestScheduledTransactions requests the transactions that are scheduled to be requested
+// from this peer now, and returns when the next ones are scheduled
+func (flow *handleRelayedTransactionsFlow) requestScheduledTransactions() (
+	requestedIDs []*externalapi.DomainTransactionID, nextScheduleTime time.Time, err error) {
+
+	scheduledIDs, nextScheduleTime := flow.TransactionRequestScheduler().ScheduleRequests(flow.peer)
+	idsToRequest := make([]*externalapi.DomainTransactionID, 0, len(scheduledIDs))
+	for _, txID := range scheduledIDs {
+		// The transaction could have been received in another way since it was announced
+		if flow.isKnownTransaction(txID) {
+			flow.TransactionRequestScheduler().Remove(txID)
 			continue
 		}
 		idsToRequest = append(idsToRequest, txID)
 	}
 
 	if len(idsToRequest) == 0 {
-		return idsToRequest, nil
+		return idsToRequest, nextScheduleTime, nil
 	}
 
 	msgGetTransactions := appmessage.NewMsgRequestTransactions(idsToRequest)
 	err = flow.outgoingRoute.Enqueue(msgGetTransactions)
 	if err != nil {
-		flow.SharedRequestedTransactions().RemoveMany(idsToRequest)
-		return nil, err
+		return nil, time.Time{}, err
 	}
-	return idsToRequest, nil
+	return idsToRequest, nextScheduleTime, nil
 }
 
 func (flow *handleRelayedTransactionsFlow) isKnownTransaction(txID *externalapi.DomainTransactionID) bool {
@@ -109,14 +143,29 @@ func (flow *handleRelayedTransactionsFlow) isKnownTransaction(txID *externalapi.
 	return false
 }
 
-func (flow *handleRelayedTransactionsFlow) readInv() (*appmessage.MsgInvTransaction, error) {
+// readInv returns the next inv message, or nil if there was none by nextScheduleTime.
+// If nextScheduleTime is the zero time, it waits for the next inv message indefinitely.
+func (flow *handleRelayedTransactionsFlow) readInv(nextScheduleTime time.Time) (*appmessage.MsgInvTransaction, error) {
 	if len(flow.invsQueue) > 0 {
 		var inv *appmessage.MsgInvTransaction
 		inv, flow.invsQueue = flow.invsQueue[0], flow.invsQueue[1:]
 		return inv, nil
 	}
 
-	msg, err := flow.incomingRoute.Dequeue()
+	var msg appmessage.Message
+	var err error
+	if nextScheduleTime.IsZero() {
+		msg, err = flow.incomingRoute.Dequeue()
+	} else {
+		timeout := time.Until(nextScheduleTime)
+		if timeout <= 0 {
+			return nil, nil
+		}
+		msg, err = flow.incomingRoute.DequeueWithTimeout(timeout)
+		if errors.Is(err, router.ErrTimeout) {
+			return nil, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -160,9 +209,6 @@ func (flow *handleRelayedTransactionsFlow) readMsgTxOrNotFound() (
 }
 
 func (flow *handleRelayedTransactionsFlow) receiveTransactions(requestedTransactions []*externalapi.DomainTransactionID) error {
-	// In case the function returns earlier than expected, we want to make sure sharedRequestedTransactions is
-	// clean from any pending transactions.
-	defer flow.SharedRequestedTransactions().RemoveMany(requestedTransactions)
 	for _, expectedID := range requestedTransactions {
 		msgTx, msgTxNotFound, err := flow.readMsgTxOrNotFound()
 		if err != nil {
@@ -174,6 +220,7 @@ func (flow *handleRelayedTransactionsFlow) receiveTransactions(requestedTransact
 					expectedID, msgTxNotFound.ID)
 			}
 
+			flow.TransactionRequestScheduler().NotFound(flow.peer, expectedID)
 			continue
 		}
 		tx := appmessage.MsgTxToDomainTransaction(msgTx)
@@ -182,6 +229,7 @@ func (flow *handleRelayedTransactionsFlow) receiveTransactions(requestedTransact
 			return protocolerrors.Errorf(true, "expected transaction %s, but got %s",
 				expectedID, txID)
 		}
+		flow.TransactionRequestScheduler().Received(flow.peer, txID)
 
 		acceptedTransactions, err :=
 			flow.Domain().MiningManager().ValidateAndInsertTransaction(tx, false, true)
@@ -191,9 +239,13 @@ func (flow *handleRelayedTransactionsFlow) receiveTransactions(requestedTransact
 				return errors.Wrapf(err, "failed to process transaction %s", txID)
 			}
 
+			// Only transactions that can't be valid are the peer's fault. Fee and
+			// dust rejections depend on this node's own policy, so they're
+			// dropped without scoring the peer.
 			shouldBan := false
 			if txRuleErr := (&mempool.TxRuleError{}); errors.As(ruleErr.Err, txRuleErr) {
-				if txRuleErr.RejectCode == mempool.RejectInvalid {
+				switch txRuleErr.RejectCode {
+				case mempool.RejectInvalid, mempool.RejectMalformed:
 					shouldBan = true
 				}
 			}
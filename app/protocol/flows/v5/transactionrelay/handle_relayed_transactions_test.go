@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
 	"github.com/kaspanet/kaspad/app/protocol/flows/v5/transactionrelay"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
 	"strings"
 	"testing"
 
@@ -25,7 +26,7 @@ import (
 type mocTransactionsRelayContext struct {
 	netAdapter                  *netadapter.NetAdapter
 	domain                      domain.Domain
-	sharedRequestedTransactions *flowcontext.SharedRequestedTransactions
+	transactionRequestScheduler *flowcontext.TransactionRequestScheduler
 }
 
 func (m *mocTransactionsRelayContext) NetAdapter() *netadapter.NetAdapter {
@@ -36,8 +37,8 @@ func (m *mocTransactionsRelayContext) Domain() domain.Domain {
 	return m.domain
 }
 
-func (m *mocTransactionsRelayContext) SharedRequestedTransactions() *flowcontext.SharedRequestedTransactions {
-	return m.sharedRequestedTransactions
+func (m *mocTransactionsRelayContext) TransactionRequestScheduler() *flowcontext.TransactionRequestScheduler {
+	return m.transactionRequestScheduler
 }
 
 func (m *mocTransactionsRelayContext) EnqueueTransactionIDsForPropagation(transactionIDs []*externalapi.DomainTransactionID) error {
@@ -47,6 +48,9 @@ func (m *mocTransactionsRelayContext) EnqueueTransactionIDsForPropagation(transa
 func (m *mocTransactionsRelayContext) OnTransactionAddedToMempool() {
 }
 
+func (m *mocTransactionsRelayContext) OnTransactionRequested(_ *externalapi.DomainTransactionID, _ *peerpkg.Peer) {
+}
+
 func (m *mocTransactionsRelayContext) IsNearlySynced() (bool, error) {
 	return true, nil
 }
@@ -65,7 +69,7 @@ func TestHandleRelayedTransactionsNotFound(t *testing.T) {
 		}
 		defer teardown(false)
 
-		sharedRequestedTransactions := flowcontext.NewSharedRequestedTransactions()
+		transactionRequestScheduler := flowcontext.NewTransactionRequestScheduler()
 		adapter, err := netadapter.NewNetAdapter(config.DefaultConfig())
 		if err != nil {
 			t.Fatalf("Failed to create a NetAdapter: %v", err)
@@ -77,7 +81,7 @@ func TestHandleRelayedTransactionsNotFound(t *testing.T) {
 		context := &mocTransactionsRelayContext{
 			netAdapter:                  adapter,
 			domain:                      domainInstance,
-			sharedRequestedTransactions: sharedRequestedTransactions,
+			transactionRequestScheduler: transactionRequestScheduler,
 		}
 		incomingRoute := router.NewRoute("incoming")
 		defer incomingRoute.Close()
@@ -128,7 +132,7 @@ func TestHandleRelayedTransactionsNotFound(t *testing.T) {
 			}
 		})
 
-		err = transactionrelay.HandleRelayedTransactions(context, incomingRoute, peerIncomingRoute)
+		err = transactionrelay.HandleRelayedTransactions(context, incomingRoute, peerIncomingRoute, peerpkg.New(nil), true)
 		// Since we inserted an unexpected message type to stop the infinity loop,
 		// we expect the error will be infected from this specific message and also the
 		// error will count as a protocol message.
@@ -158,7 +162,7 @@ func TestOnClosedIncomingRoute(t *testing.T) {
 		}
 		defer teardown(false)
 
-		sharedRequestedTransactions := flowcontext.NewSharedRequestedTransactions()
+		transactionRequestScheduler := flowcontext.NewTransactionRequestScheduler()
 		adapter, err := netadapter.NewNetAdapter(config.DefaultConfig())
 		if err != nil {
 			t.Fatalf("Failed to creat a NetAdapter : %v", err)
@@ -170,7 +174,7 @@ func TestOnClosedIncomingRoute(t *testing.T) {
 		context := &mocTransactionsRelayContext{
 			netAdapter:                  adapter,
 			domain:                      domainInstance,
-			sharedRequestedTransactions: sharedRequestedTransactions,
+			transactionRequestScheduler: transactionRequestScheduler,
 		}
 		incomingRoute := router.NewRoute("incoming")
 		outgoingRoute := router.NewRoute("outgoing")
@@ -188,7 +192,7 @@ func TestOnClosedIncomingRoute(t *testing.T) {
 			t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
 		}
 		incomingRoute.Close()
-		err = transactionrelay.HandleRelayedTransactions(context, incomingRoute, outgoingRoute)
+		err = transactionrelay.HandleRelayedTransactions(context, incomingRoute, outgoingRoute, peerpkg.New(nil), true)
 		if err == nil || !errors.Is(err, router.ErrRouteClosed) {
 			t.Fatalf("Unexpected error: expected: %v, got : %v", router.ErrRouteClosed, err)
 		}
@@ -1,23 +1,30 @@
 package transactionrelay
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 )
 
 type handleRequestedTransactionsFlow struct {
 	TransactionsRelayContext
 	incomingRoute, outgoingRoute *router.Route
+	peer                         *peerpkg.Peer
 }
 
 // HandleRequestedTransactions listens to appmessage.MsgRequestTransactions messages, responding with the requested
 // transactions if those are in the mempool.
 // Missing transactions would be ignored
-func HandleRequestedTransactions(context TransactionsRelayContext, incomingRoute *router.Route, outgoingRoute *router.Route) error {
+func HandleRequestedTransactions(context TransactionsRelayContext, incomingRoute *router.Route,
+	outgoingRoute *router.Route, peer *peerpkg.Peer) error {
+
 	flow := &handleRequestedTransactionsFlow{
 		TransactionsRelayContext: context,
 		incomingRoute:            incomingRoute,
 		outgoingRoute:            outgoingRoute,
+		peer:                     peer,
 	}
 	return flow.start()
 }
@@ -30,6 +37,7 @@ func (flow *handleRequestedTransactionsFlow) start() error {
 		}
 
 		for _, transactionID := range msgRequestTransactions.IDs {
+			requestStart := time.Now()
 			tx, _, ok := flow.Domain().MiningManager().GetTransaction(transactionID, true, false)
 
 			if !ok {
@@ -38,12 +46,15 @@ func (flow *handleRequestedTransactionsFlow) start() error {
 				if err != nil {
 					return err
 				}
+				flow.peer.RecordFlow(peerpkg.FlowTransactionRelay, time.Since(requestStart))
 				continue
 			}
 			err := flow.outgoingRoute.Enqueue(appmessage.DomainTransactionToMsgTx(tx))
 			if err != nil {
 				return err
 			}
+			flow.OnTransactionRequested(transactionID, flow.peer)
+			flow.peer.RecordFlow(peerpkg.FlowTransactionRelay, time.Since(requestStart))
 		}
 	}
 }
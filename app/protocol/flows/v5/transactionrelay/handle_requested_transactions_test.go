@@ -3,6 +3,7 @@ package transactionrelay_test
 import (
 	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
 	"github.com/kaspanet/kaspad/app/protocol/flows/v5/transactionrelay"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
 	"testing"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
@@ -32,7 +33,7 @@ func TestHandleRequestedTransactionsNotFound(t *testing.T) {
 		}
 		defer teardown(false)
 
-		sharedRequestedTransactions := flowcontext.NewSharedRequestedTransactions()
+		transactionRequestScheduler := flowcontext.NewTransactionRequestScheduler()
 		adapter, err := netadapter.NewNetAdapter(config.DefaultConfig())
 		if err != nil {
 			t.Fatalf("Failed to create a NetAdapter: %v", err)
@@ -44,7 +45,7 @@ func TestHandleRequestedTransactionsNotFound(t *testing.T) {
 		context := &mocTransactionsRelayContext{
 			netAdapter:                  adapter,
 			domain:                      domainInstance,
-			sharedRequestedTransactions: sharedRequestedTransactions,
+			transactionRequestScheduler: transactionRequestScheduler,
 		}
 		incomingRoute := router.NewRoute("incoming")
 		outgoingRoute := router.NewRoute("outgoing")
@@ -82,7 +83,7 @@ func TestHandleRequestedTransactionsNotFound(t *testing.T) {
 			incomingRoute.Close()
 		})
 
-		err = transactionrelay.HandleRequestedTransactions(context, incomingRoute, outgoingRoute)
+		err = transactionrelay.HandleRequestedTransactions(context, incomingRoute, outgoingRoute, peerpkg.New(nil))
 		// Make sure the error is due to the closed route.
 		if err == nil || !errors.Is(err, router.ErrRouteClosed) {
 			t.Fatalf("Unexpected error: expected: %v, got : %v", router.ErrRouteClosed, err)
@@ -57,6 +57,12 @@ func (m *Manager) Peers() []*peerpkg.Peer {
 	return m.context.Peers()
 }
 
+// PeerFlowAnomalies returns the anomalies in the protocol flows of the
+// connected peers, for the peers that have any
+func (m *Manager) PeerFlowAnomalies() map[*peerpkg.Peer][]*flowcontext.PeerFlowAnomaly {
+	return m.context.PeerFlowAnomalies()
+}
+
 // IBDPeer returns the current IBD peer or null if the node is not
 // in IBD
 func (m *Manager) IBDPeer() *peerpkg.Peer {
@@ -64,13 +70,39 @@ func (m *Manager) IBDPeer() *peerpkg.Peer {
 }
 
 // AddTransaction adds transaction to the mempool and propagates it.
-func (m *Manager) AddTransaction(tx *externalapi.DomainTransaction, allowOrphan bool) error {
-	return m.context.AddTransaction(tx, allowOrphan)
+// correlationID identifies the RPC request that submitted the transaction
+// in the logs.
+func (m *Manager) AddTransaction(tx *externalapi.DomainTransaction, allowOrphan bool, correlationID string) error {
+	return m.context.AddTransaction(tx, allowOrphan, correlationID)
 }
 
 // AddBlock adds the given block to the DAG and propagates it.
-func (m *Manager) AddBlock(block *externalapi.DomainBlock) error {
-	return m.context.AddBlock(block)
+// correlationID identifies the RPC request that submitted the block in the
+// logs.
+func (m *Manager) AddBlock(block *externalapi.DomainBlock, correlationID string) error {
+	return m.context.AddBlock(block, correlationID)
+}
+
+// TransactionPropagationReport returns the propagation report of the given
+// transaction, if it was submitted over RPC within the last hour
+func (m *Manager) TransactionPropagationReport(
+	transactionID *externalapi.DomainTransactionID) (*flowcontext.TransactionPropagationReport, bool) {
+
+	return m.context.TransactionPropagationReport(transactionID)
+}
+
+// OnVirtualSelectedParentChainChanged updates the mempool after the given
+// virtual selected parent chain changes: it re-inserts and rebroadcasts the
+// transactions that lost their acceptance, and evicts the ones that became
+// invalid
+func (m *Manager) OnVirtualSelectedParentChainChanged(selectedParentChainChanges *externalapi.SelectedChainPath) error {
+	return m.context.OnVirtualSelectedParentChainChanged(selectedParentChainChanges)
+}
+
+// ReorgedTransactionsStats returns the counters of the transactions that lost
+// their acceptance due to reorgs
+func (m *Manager) ReorgedTransactionsStats() *flowcontext.ReorgedTransactionsStats {
+	return m.context.ReorgedTransactionsStats()
 }
 
 // Context returns the manager's flow context
@@ -91,6 +123,12 @@ func (m *Manager) runFlows(flows []*common.Flow, peer *peerpkg.Peer, errChan <-c
 	return <-errChan
 }
 
+// SetBanningPolicy changes whether misbehaving peers are banned, and the ban
+// score at which they are
+func (m *Manager) SetBanningPolicy(isBanningEnabled bool, banThreshold uint32) {
+	m.context.BanManager().SetBanningPolicy(isBanningEnabled, banThreshold)
+}
+
 // SetOnNewBlockTemplateHandler sets the onNewBlockTemplate handler
 func (m *Manager) SetOnNewBlockTemplateHandler(onNewBlockTemplateHandler flowcontext.OnNewBlockTemplateHandler) {
 	m.context.SetOnNewBlockTemplateHandler(onNewBlockTemplateHandler)
@@ -106,6 +144,40 @@ func (m *Manager) SetOnTransactionAddedToMempoolHandler(onTransactionAddedToMemp
 	m.context.SetOnTransactionAddedToMempoolHandler(onTransactionAddedToMempoolHandler)
 }
 
+// SetOnTransactionsEvictedHandler sets the onTransactionsEvicted handler
+func (m *Manager) SetOnTransactionsEvictedHandler(onTransactionsEvictedHandler flowcontext.OnTransactionsEvictedHandler) {
+	m.context.SetOnTransactionsEvictedHandler(onTransactionsEvictedHandler)
+}
+
+// SetOnTransactionsRemovedFromMempoolHandler sets the onTransactionsRemovedFromMempool handler
+func (m *Manager) SetOnTransactionsRemovedFromMempoolHandler(
+	onTransactionsRemovedFromMempoolHandler flowcontext.OnTransactionsRemovedFromMempoolHandler) {
+
+	m.context.SetOnTransactionsRemovedFromMempoolHandler(onTransactionsRemovedFromMempoolHandler)
+}
+
+// SetOnTransactionsConfirmedHandler sets the onTransactionsConfirmed handler
+func (m *Manager) SetOnTransactionsConfirmedHandler(onTransactionsConfirmedHandler flowcontext.OnTransactionsConfirmedHandler) {
+	m.context.SetOnTransactionsConfirmedHandler(onTransactionsConfirmedHandler)
+}
+
+// SetOnPeerEventHandler sets the onPeerEvent handler
+func (m *Manager) SetOnPeerEventHandler(onPeerEventHandler flowcontext.OnPeerEventHandler) {
+	m.context.SetOnPeerEventHandler(onPeerEventHandler)
+}
+
+// SetOnBlockAddedHandler sets the onBlockAdded handler
+func (m *Manager) SetOnBlockAddedHandler(onBlockAddedHandler flowcontext.OnBlockAddedHandler) {
+	m.context.SetOnBlockAddedHandler(onBlockAddedHandler)
+}
+
+// SetOnVirtualSelectedParentChainChangedHandler sets the onVirtualSelectedParentChainChanged handler
+func (m *Manager) SetOnVirtualSelectedParentChainChangedHandler(
+	onVirtualSelectedParentChainChangedHandler flowcontext.OnVirtualSelectedParentChainChangedHandler) {
+
+	m.context.SetOnVirtualSelectedParentChainChangedHandler(onVirtualSelectedParentChainChangedHandler)
+}
+
 // IsIBDRunning returns true if IBD is currently marked as running
 func (m *Manager) IsIBDRunning() bool {
 	return m.context.IsIBDRunning()
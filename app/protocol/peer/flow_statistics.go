@@ -0,0 +1,123 @@
+package peer
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// Flow is a group of protocol flows whose statistics are tracked per peer
+type Flow string
+
+// The groups of protocol flows whose statistics are tracked per peer
+const (
+	// FlowHandshake is the handshake with the peer
+	FlowHandshake Flow = "handshake"
+
+	// FlowIBD is IBD with the peer as the syncer
+	FlowIBD Flow = "ibd"
+
+	// FlowBlockRelay is serving blocks the peer requested after they were
+	// relayed to it
+	FlowBlockRelay Flow = "blockRelay"
+
+	// FlowTransactionRelay is serving transactions the peer requested after
+	// they were relayed to it
+	FlowTransactionRelay Flow = "transactionRelay"
+
+	// FlowAddressExchange is serving the peer's requests for addresses and
+	// handling the addresses it sends
+	FlowAddressExchange Flow = "addressExchange"
+)
+
+// Flows are all the groups of protocol flows whose statistics are tracked
+var Flows = []Flow{FlowHandshake, FlowIBD, FlowBlockRelay, FlowTransactionRelay, FlowAddressExchange}
+
+// FlowStatistics holds the statistics of a group of protocol flows with a single peer
+type FlowStatistics struct {
+	Count         uint64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+// AverageDuration returns the average duration of a flow, or 0 if it never ran
+func (s FlowStatistics) AverageDuration() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Count)
+}
+
+const (
+	// BlockRequestWindow is how long the hashes of the blocks a peer requested
+	// are kept to find repeated requests
+	BlockRequestWindow = 10 * time.Minute
+
+	// maxRepeatedBlockRequests is the amount of repeated block requests within
+	// BlockRequestWindow that's considered anomalous
+	maxRepeatedBlockRequests = 20
+)
+
+// RecordFlow records that a flow of the given group ran with this peer for
+// the given duration
+func (p *Peer) RecordFlow(flow Flow, duration time.Duration) {
+	p.flowStatisticsLock.Lock()
+	defer p.flowStatisticsLock.Unlock()
+
+	statistics := p.flowStatistics[flow]
+	statistics.Count++
+	statistics.TotalDuration += duration
+	if duration > statistics.MaxDuration {
+		statistics.MaxDuration = duration
+	}
+	p.flowStatistics[flow] = statistics
+}
+
+// FlowStatistics returns the statistics of every group of protocol flows
+// with this peer
+func (p *Peer) FlowStatistics() map[Flow]FlowStatistics {
+	p.flowStatisticsLock.Lock()
+	defer p.flowStatisticsLock.Unlock()
+
+	flowStatistics := make(map[Flow]FlowStatistics, len(Flows))
+	for _, flow := range Flows {
+		flowStatistics[flow] = p.flowStatistics[flow]
+	}
+	return flowStatistics
+}
+
+// RecordBlockRequest records that the peer requested the block with the given
+// hash. It returns true the first time the amount of repeated requests in the
+// current window becomes anomalous.
+func (p *Peer) RecordBlockRequest(hash *externalapi.DomainHash) bool {
+	p.flowStatisticsLock.Lock()
+	defer p.flowStatisticsLock.Unlock()
+
+	now := time.Now()
+	if now.Sub(p.blockRequestWindowStart) > BlockRequestWindow {
+		p.blockRequestWindowStart = now
+		p.requestedBlockHashes = make(map[externalapi.DomainHash]struct{})
+		p.windowRepeatedBlockRequests = 0
+	}
+
+	if _, ok := p.requestedBlockHashes[*hash]; !ok {
+		p.requestedBlockHashes[*hash] = struct{}{}
+		return false
+	}
+
+	p.windowRepeatedBlockRequests++
+	return p.windowRepeatedBlockRequests == maxRepeatedBlockRequests
+}
+
+// RepeatedBlockRequests returns the amount of times the peer requested a block
+// it had already requested in the current BlockRequestWindow, and whether that
+// amount is anomalous
+func (p *Peer) RepeatedBlockRequests() (repeatedBlockRequests uint64, isAnomalous bool) {
+	p.flowStatisticsLock.Lock()
+	defer p.flowStatisticsLock.Unlock()
+
+	if time.Since(p.blockRequestWindowStart) > BlockRequestWindow {
+		return 0, false
+	}
+	return p.windowRepeatedBlockRequests, p.windowRepeatedBlockRequests >= maxRepeatedBlockRequests
+}
@@ -32,15 +32,27 @@ type Peer struct {
 	lastPingTime     time.Time     // Time we sent last ping
 	lastPingDuration time.Duration // Time for last ping to return
 
-	ibdRequestChannel chan *externalapi.DomainBlock // A channel used to communicate IBD requests between flows
+	ibdRequestChannel       chan *externalapi.DomainBlock // A channel used to communicate IBD requests between flows
+	ibdBlocksRequestChannel chan *IBDBlocksRequest        // A channel used to download IBD blocks on behalf of another peer
+
+	lastAnnouncedBlockHash     *externalapi.DomainHash
+	lastAnnouncedBlockHashLock sync.RWMutex
+
+	flowStatisticsLock          sync.Mutex
+	flowStatistics              map[Flow]FlowStatistics
+	requestedBlockHashes        map[externalapi.DomainHash]struct{}
+	blockRequestWindowStart     time.Time
+	windowRepeatedBlockRequests uint64
 }
 
 // New returns a new Peer
 func New(connection *netadapter.NetConnection) *Peer {
 	return &Peer{
-		connection:        connection,
-		connectionStarted: time.Now(),
-		ibdRequestChannel: make(chan *externalapi.DomainBlock),
+		connection:              connection,
+		connectionStarted:       time.Now(),
+		ibdRequestChannel:       make(chan *externalapi.DomainBlock),
+		ibdBlocksRequestChannel: make(chan *IBDBlocksRequest),
+		flowStatistics:          make(map[Flow]FlowStatistics),
 	}
 }
 
@@ -70,6 +82,11 @@ func (p *Peer) UserAgent() string {
 	return p.userAgent
 }
 
+// Services returns the services the peer advertised in its version message.
+func (p *Peer) Services() appmessage.ServiceFlag {
+	return p.services
+}
+
 // AdvertisedProtocolVersion returns the peer's advertised protocol version.
 func (p *Peer) AdvertisedProtocolVersion() uint32 {
 	return p.advertisedProtocolVerion
@@ -151,3 +168,46 @@ func (p *Peer) LastPingDuration() time.Duration {
 func (p *Peer) IBDRequestChannel() chan *externalapi.DomainBlock {
 	return p.ibdRequestChannel
 }
+
+// SetLastAnnouncedBlockHash records the hash of the last block the peer announced
+func (p *Peer) SetLastAnnouncedBlockHash(blockHash *externalapi.DomainHash) {
+	p.lastAnnouncedBlockHashLock.Lock()
+	defer p.lastAnnouncedBlockHashLock.Unlock()
+
+	p.lastAnnouncedBlockHash = blockHash
+}
+
+// LastAnnouncedBlockHash returns the hash of the last block the peer announced,
+// or nil if it hasn't announced any
+func (p *Peer) LastAnnouncedBlockHash() *externalapi.DomainHash {
+	p.lastAnnouncedBlockHashLock.RLock()
+	defer p.lastAnnouncedBlockHashLock.RUnlock()
+
+	return p.lastAnnouncedBlockHash
+}
+
+// IBDBlocksRequest asks the IBD flow of a peer to download the given blocks
+// on behalf of the IBD that runs with another peer
+type IBDBlocksRequest struct {
+	Hashes []*externalapi.DomainHash
+
+	// ResponseChannel receives the response once the blocks are downloaded,
+	// or once downloading them fails
+	ResponseChannel chan<- *IBDBlocksResponse
+}
+
+// IBDBlocksResponse is the response to an IBDBlocksRequest. Blocks is nil if
+// Err isn't.
+type IBDBlocksResponse struct {
+	Request *IBDBlocksRequest
+	Peer    *Peer
+	Blocks  []*externalapi.DomainBlock
+	Err     error
+}
+
+// IBDBlocksRequestChannel returns the channel used in order to ask the IBD flow
+// of this peer to download blocks on behalf of the IBD that runs with another peer.
+// The channel is unbuffered, so a request is only accepted while the flow is idle.
+func (p *Peer) IBDBlocksRequestChannel() chan *IBDBlocksRequest {
+	return p.ibdBlocksRequestChannel
+}
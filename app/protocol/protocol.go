@@ -1,18 +1,21 @@
 package protocol
 
 import (
+	"github.com/kaspanet/kaspad/app/protocol/banmanager"
 	"github.com/kaspanet/kaspad/app/protocol/common"
+	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
 	"github.com/kaspanet/kaspad/app/protocol/flows/ready"
 	"github.com/kaspanet/kaspad/app/protocol/flows/v5"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/protocol/flows/handshake"
 	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
 	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
-	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
@@ -37,12 +40,30 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 			panic(errors.Errorf("tried to initialize router when the protocol manager is closed"))
 		}
 
+		connectedTime := time.Now()
+		var peer *peerpkg.Peer
+		disconnectReason := "the connection was closed"
+		m.context.NotifyPeerEvent(&flowcontext.PeerEvent{
+			Type:       flowcontext.PeerEventConnected,
+			Connection: netConnection,
+		})
+		defer func() {
+			m.context.NotifyPeerEvent(&flowcontext.PeerEvent{
+				Type:               flowcontext.PeerEventDisconnected,
+				Connection:         netConnection,
+				Peer:               peer,
+				Reason:             disconnectReason,
+				ConnectionDuration: time.Since(connectedTime),
+			})
+		}()
+
 		isBanned, err := m.context.ConnectionManager().IsBanned(netConnection)
 		if err != nil && !errors.Is(err, addressmanager.ErrAddressNotFound) {
 			panic(err)
 		}
 		if isBanned {
 			log.Infof("Peer %s is banned. Disconnecting...", netConnection)
+			disconnectReason = "the peer is banned"
 			netConnection.Disconnect()
 			return
 		}
@@ -53,7 +74,8 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 			}
 		})
 
-		peer, err := handshake.HandleHandshake(m.context, netConnection, receiveVersionRoute,
+		handshakeStart := time.Now()
+		peer, err = handshake.HandleHandshake(m.context, netConnection, receiveVersionRoute,
 			sendVersionRoute, router.OutgoingRoute())
 
 		if err != nil {
@@ -61,17 +83,23 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 			select {
 			case innerError := <-errChan:
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
-					m.handleError(innerError, netConnection, router.OutgoingRoute())
+					disconnectReason = m.handleError(innerError, netConnection, router.OutgoingRoute())
 				} else {
 					log.Errorf("Peer %s sent invalid message: %s", netConnection, innerError)
-					m.handleError(err, netConnection, router.OutgoingRoute())
+					disconnectReason = m.handleError(err, netConnection, router.OutgoingRoute())
 				}
 			default:
-				m.handleError(err, netConnection, router.OutgoingRoute())
+				disconnectReason = m.handleError(err, netConnection, router.OutgoingRoute())
 			}
 			return
 		}
 		defer m.context.RemoveFromPeers(peer)
+		peer.RecordFlow(peerpkg.FlowHandshake, time.Since(handshakeStart))
+		m.context.NotifyPeerEvent(&flowcontext.PeerEvent{
+			Type:       flowcontext.PeerEventHandshakeCompleted,
+			Connection: netConnection,
+			Peer:       peer,
+		})
 
 		var flows []*common.Flow
 		log.Infof("Registering p2p flows for peer %s for protocol version %d", peer, peer.ProtocolVersion())
@@ -84,7 +112,7 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 
 		err = ready.HandleReady(receiveReadyRoute, router.OutgoingRoute(), peer)
 		if err != nil {
-			m.handleError(err, netConnection, router.OutgoingRoute())
+			disconnectReason = m.handleError(err, netConnection, router.OutgoingRoute())
 			return
 		}
 
@@ -93,7 +121,7 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 		flowsWaitGroup := &sync.WaitGroup{}
 		err = m.runFlows(flows, peer, errChan, flowsWaitGroup)
 		if err != nil {
-			m.handleError(err, netConnection, router.OutgoingRoute())
+			disconnectReason = m.handleError(err, netConnection, router.OutgoingRoute())
 			// We call `flowsWaitGroup.Wait()` in two places instead of deferring, because
 			// we already defer `m.routersWaitGroup.Done()`, so we try to avoid error prone
 			// and confusing use of multiple dependent defers.
@@ -104,13 +132,18 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 	})
 }
 
-func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection, outgoingRoute *routerpkg.Route) {
+// handleError disconnects from the peer the given error occurred with, scoring
+// it for misbehavior if the error calls for it. It returns the reason for the disconnection.
+func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection, outgoingRoute *routerpkg.Route) string {
 	if protocolErr := (protocolerrors.ProtocolError{}); errors.As(err, &protocolErr) {
-		if m.context.Config().EnableBanning && protocolErr.ShouldBan {
-			log.Warnf("Banning %s (reason: %s)", netConnection, protocolErr.Cause)
-
-			err := m.context.ConnectionManager().Ban(netConnection)
-			if err != nil && !errors.Is(err, connmanager.ErrCannotBanPermanent) {
+		reason := protocolErr.Cause.Error()
+		if m.context.BanManager().IsBanningEnabled() && protocolErr.ShouldBan {
+			misbehavior := banmanager.MisbehaviorMalformedMessage
+			if errors.As(err, &ruleerrors.RuleError{}) {
+				misbehavior = banmanager.MisbehaviorInvalidBlock
+			}
+			_, err := m.context.AddBanScore(netConnection, misbehavior, reason)
+			if err != nil {
 				panic(err)
 			}
 
@@ -121,15 +154,15 @@ func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection
 		}
 		log.Infof("Disconnecting from %s (reason: %s)", netConnection, protocolErr.Cause)
 		netConnection.Disconnect()
-		return
+		return reason
 	}
 	if errors.Is(err, routerpkg.ErrTimeout) {
 		log.Warnf("Got timeout from %s. Disconnecting...", netConnection)
 		netConnection.Disconnect()
-		return
+		return "timed out"
 	}
 	if errors.Is(err, routerpkg.ErrRouteClosed) {
-		return
+		return "the connection was closed"
 	}
 	panic(err)
 }
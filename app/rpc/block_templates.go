@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// blockTemplateRefreshInterval is the interval in which the templates of the
+// listeners are rebuilt between new blocks, so that mempool changes reach
+// them at most this late, and no more often than this
+const blockTemplateRefreshInterval = time.Second
+
+// blockTemplateCache holds the last template that was sent to the listeners
+// of every block template request, so that a new one is sent only when it's
+// different work: when new blocks change its parents, or when mempool
+// changes change its transactions. Templates that differ only in their
+// timestamp are not worth interrupting miners for.
+type blockTemplateCache struct {
+	// lock is held while templates are built and sent, so that the
+	// templates of a request are sent in the order they're built
+	lock      sync.Mutex
+	templates map[rpccontext.BlockTemplateRequest]*cachedBlockTemplate
+}
+
+type cachedBlockTemplate struct {
+	template *externalapi.DomainBlock
+	isSynced bool
+}
+
+func newBlockTemplateCache() *blockTemplateCache {
+	return &blockTemplateCache{
+		templates: make(map[rpccontext.BlockTemplateRequest]*cachedBlockTemplate),
+	}
+}
+
+// isSameAsCached returns whether the given template is the same work as the
+// cached template of the given request
+func (c *blockTemplateCache) isSameAsCached(request rpccontext.BlockTemplateRequest,
+	template *externalapi.DomainBlock, isSynced bool) bool {
+
+	cached, ok := c.templates[request]
+	if !ok {
+		return false
+	}
+	return cached.isSynced == isSynced &&
+		externalapi.HashesEqual(cached.template.Header.DirectParents(), template.Header.DirectParents()) &&
+		cached.template.Header.HashMerkleRoot().Equal(template.Header.HashMerkleRoot())
+}
+
+// pushBlockTemplates sends every listener whose new block template
+// notifications carry the templates the template of its request, if it's
+// different work than the last one it got
+func (m *Manager) pushBlockTemplates() error {
+	m.blockTemplateCache.lock.Lock()
+	defer m.blockTemplateCache.lock.Unlock()
+
+	requests := m.context.NotificationManager.NewBlockTemplateRequests()
+	templates := make(map[rpccontext.BlockTemplateRequest]*cachedBlockTemplate, len(requests))
+	for _, request := range requests {
+		template, isSynced, err := m.context.BuildBlockTemplate(request)
+		if err != nil {
+			if errors.Is(err, rpccontext.ErrInvalidBlockTemplateRequest) {
+				log.Warnf("Not sending the block template of pay address %s: %s", request.PayAddress, err)
+				continue
+			}
+			return err
+		}
+
+		if m.blockTemplateCache.isSameAsCached(request, template, isSynced) {
+			templates[request] = m.blockTemplateCache.templates[request]
+			continue
+		}
+		notification := appmessage.NewNewBlockTemplateNotificationMessageWithTemplate(
+			appmessage.DomainBlockToRPCBlock(template), isSynced)
+		err = m.context.NotificationManager.NotifyNewBlockTemplateWithTemplate(request, notification)
+		if err != nil {
+			return err
+		}
+		templates[request] = &cachedBlockTemplate{template: template, isSynced: isSynced}
+	}
+
+	// The templates of requests that no listener has anymore are dropped
+	m.blockTemplateCache.templates = templates
+	return nil
+}
+
+func (m *Manager) blockTemplateRefreshLoop() {
+	defer close(m.blockTemplatesDoneChan)
+
+	ticker := time.NewTicker(blockTemplateRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+		}
+
+		err := m.pushBlockTemplates()
+		if err != nil {
+			log.Errorf("Error pushing block templates: %+v", err)
+		}
+	}
+}
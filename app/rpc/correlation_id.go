@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+var correlationIDCounter uint64
+
+// newCorrelationID returns a new ID for an incoming RPC request. Handlers pass
+// it on to the actions the request triggers, such as block or transaction
+// relay, which prefix their logs with it, so that all the logs caused by a
+// request can be found by searching for its ID.
+func newCorrelationID() string {
+	return fmt.Sprintf("rpc-%08x", atomic.AddUint64(&correlationIDCounter, 1))
+}
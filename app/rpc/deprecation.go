@@ -0,0 +1,26 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// deprecatedMethods maps the requests of deprecated RPC methods to the
+// requests of the methods that replace them. Deprecated methods keep working,
+// but their responses carry a warning, so that clients notice before the
+// methods are removed.
+var deprecatedMethods = map[appmessage.MessageCommand]appmessage.MessageCommand{
+	appmessage.CmdGetBalanceByAddressRequestMessage: appmessage.CmdGetBalancesByAddressesRequestMessage,
+}
+
+// deprecationWarning returns the warning to add to the response to the given
+// request, or an empty string if its method isn't deprecated
+func deprecationWarning(requestCommand appmessage.MessageCommand) string {
+	replacement, ok := deprecatedMethods[requestCommand]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s is deprecated and will be removed in a future release, use %s instead",
+		requestCommand, replacement)
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"math"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+// endpointRejection returns the error to reject the given request with, if
+// the RPC endpoint it was made through doesn't allow it. It returns nil if
+// the request may be handled.
+func endpointRejection(rpcEndpoint *config.RPCEndpoint, limiter *rateLimiter,
+	request appmessage.Message) *appmessage.RPCError {
+
+	if rpcEndpoint == nil {
+		return nil
+	}
+	if !rpcEndpoint.IsMethodAllowed(request.Command()) {
+		return appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed, "%s is not allowed on RPC endpoint %s",
+			request.Command(), rpcEndpoint.Name)
+	}
+	if limiter != nil && !limiter.allow(time.Now()) {
+		return appmessage.RPCErrorf(appmessage.RPCErrorCodeRateLimited,
+			"rate limit of RPC endpoint %s exceeded: at most %g requests per second are allowed",
+			rpcEndpoint.Name, rpcEndpoint.RateLimit)
+	}
+	return nil
+}
+
+// rateLimiter is a token bucket that allows bursts of up to one second's
+// worth of requests. It is owned by a single connection's message loop, and
+// is therefore not safe for concurrent use.
+type rateLimiter struct {
+	requestsPerSecond float64
+	burst             float64
+	tokens            float64
+	lastRefill        time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	burst := math.Max(1, math.Ceil(requestsPerSecond))
+	return &rateLimiter{
+		requestsPerSecond: requestsPerSecond,
+		burst:             burst,
+		tokens:            burst,
+		lastRefill:        time.Now(),
+	}
+}
+
+func (limiter *rateLimiter) allow(now time.Time) bool {
+	elapsed := now.Sub(limiter.lastRefill).Seconds()
+	limiter.lastRefill = now
+	limiter.tokens = math.Min(limiter.burst, limiter.tokens+elapsed*limiter.requestsPerSecond)
+	if limiter.tokens < 1 {
+		return false
+	}
+	limiter.tokens--
+	return true
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+func TestEveryHandlerHasAnErrorResponse(t *testing.T) {
+	for command := range handlers {
+		_, ok := appmessage.NewRPCErrorResponseMessage(command,
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeInternal, "error"))
+		if !ok {
+			t.Errorf("no error response is defined for %s", command)
+		}
+	}
+}
+
+func TestEndpointRejection(t *testing.T) {
+	request := appmessage.NewShutDownRequestMessage()
+	if endpointRejection(nil, nil, request) != nil {
+		t.Fatalf("the default RPC server rejected a request")
+	}
+
+	endpoint := &config.RPCEndpoint{
+		Name:           "public",
+		AllowedMethods: map[appmessage.MessageCommand]struct{}{appmessage.CmdGetInfoRequestMessage: {}},
+	}
+	if endpointRejection(endpoint, nil, request) == nil {
+		t.Fatalf("a disallowed method was not rejected")
+	}
+	if endpointRejection(endpoint, nil, appmessage.NewGetInfoRequestMessage()) != nil {
+		t.Fatalf("an allowed method was rejected")
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(2)
+	now := limiter.lastRefill
+	for i := 0; i < 2; i++ {
+		if !limiter.allow(now) {
+			t.Fatalf("request %d within the burst was not allowed", i)
+		}
+	}
+	if limiter.allow(now) {
+		t.Fatalf("request beyond the burst was allowed")
+	}
+	if !limiter.allow(now.Add(500 * time.Millisecond)) {
+		t.Fatalf("request was not allowed after the bucket was refilled")
+	}
+	if limiter.allow(now.Add(500 * time.Millisecond)) {
+		t.Fatalf("request was allowed before the bucket was refilled")
+	}
+}
+
+func TestNewCorrelationID(t *testing.T) {
+	const goroutines = 8
+	const idsPerGoroutine = 1000
+	idFormat := regexp.MustCompile(`^rpc-[0-9a-f]{8,}$`)
+
+	// Requests are handled concurrently by the routers of all the clients
+	ids := make(chan string, goroutines*idsPerGoroutine)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for j := 0; j < idsPerGoroutine; j++ {
+				ids <- newCorrelationID()
+			}
+		}()
+	}
+	waitGroup.Wait()
+	close(ids)
+
+	seen := make(map[string]struct{}, goroutines*idsPerGoroutine)
+	for id := range ids {
+		if !idFormat.MatchString(id) {
+			t.Fatalf("correlation ID %q is malformed", id)
+		}
+		if _, ok := seen[id]; ok {
+			t.Fatalf("correlation ID %s was given to two requests", id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	request := appmessage.NewGetInfoRequestMessage()
+	correlationID := newCorrelationID()
+	request.SetCorrelationID(correlationID)
+	if request.CorrelationID() != correlationID {
+		t.Fatalf("expected the request to carry correlation ID %s, but got %q", correlationID, request.CorrelationID())
+	}
+}
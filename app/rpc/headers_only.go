@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+// headersOnlyMethods are the RPC methods that headers-only nodes serve. The
+// rest rely on block bodies, on the UTXO set or on the virtual, none of which
+// a headers-only node maintains.
+var headersOnlyMethods = map[appmessage.MessageCommand]struct{}{
+	appmessage.CmdGetCurrentNetworkRequestMessage:              {},
+	appmessage.CmdGetPeerAddressesRequestMessage:               {},
+	appmessage.CmdGetConnectedPeerInfoRequestMessage:           {},
+	appmessage.CmdGetAddressManagerInfoRequestMessage:          {},
+	appmessage.CmdGetPeerFlowStatisticsRequestMessage:          {},
+	appmessage.CmdGetNetworkHealthRequestMessage:               {},
+	appmessage.CmdAddPeerRequestMessage:                        {},
+	appmessage.CmdBanRequestMessage:                            {},
+	appmessage.CmdUnbanRequestMessage:                          {},
+	appmessage.CmdGetBannedPeersRequestMessage:                 {},
+	appmessage.CmdGetBandwidthInfoRequestMessage:               {},
+	appmessage.CmdNotifyPeerEventsRequestMessage:               {},
+	appmessage.CmdGetBlockRequestMessage:                       {},
+	appmessage.CmdGetBlockCountRequestMessage:                  {},
+	appmessage.CmdGetHeadersSelectedTipRequestMessage:          {},
+	appmessage.CmdGetBlockHeadersRequestMessage:                {},
+	appmessage.CmdEstimateNetworkHashesPerSecondRequestMessage: {},
+	appmessage.CmdGetChainWorkStatusRequestMessage:             {},
+	appmessage.CmdGetInfoRequestMessage:                        {},
+	appmessage.CmdGetEffectiveConfigRequestMessage:             {},
+	appmessage.CmdNegotiateAPIVersionRequestMessage:            {},
+	appmessage.CmdGetRPCSessionsRequestMessage:                 {},
+	appmessage.CmdDisconnectRPCSessionRequestMessage:           {},
+	appmessage.CmdShutDownRequestMessage:                       {},
+}
+
+// headersOnlyRejection returns the error to reject the given request with, if
+// the node is headers-only and can't serve it. It returns nil if the request
+// may be handled.
+func headersOnlyRejection(cfg *config.Config, request appmessage.Message) *appmessage.RPCError {
+	if !cfg.HeadersOnly {
+		return nil
+	}
+	if _, ok := headersOnlyMethods[request.Command()]; !ok {
+		return appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable,
+			"%s is unavailable since the node is running with --headersonly", request.Command())
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+func TestHeadersOnlyRejection(t *testing.T) {
+	for command := range headersOnlyMethods {
+		if _, ok := handlers[command]; !ok {
+			t.Errorf("%s is served by headers-only nodes but has no handler", command)
+		}
+	}
+
+	cfg := &config.Config{Flags: &config.Flags{}}
+	request := appmessage.NewGetBlockTemplateRequestMessage("", "")
+	if headersOnlyRejection(cfg, request) != nil {
+		t.Fatalf("a regular node rejected a request")
+	}
+
+	cfg.HeadersOnly = true
+	rejection := headersOnlyRejection(cfg, request)
+	if rejection == nil || rejection.Code != appmessage.RPCErrorCodeMethodUnavailable {
+		t.Fatalf("expected %s to be rejected as unavailable, but got %v", request.Command(), rejection)
+	}
+	if headersOnlyRejection(cfg, appmessage.NewGetHeadersSelectedTipRequestMessage()) != nil {
+		t.Fatalf("a headers-only node rejected a request it can serve")
+	}
+}
@@ -0,0 +1,46 @@
+package rpc
+
+import "time"
+
+// healthStatusUpdateInterval is how often the RPC servers are told whether
+// the node is synced and whether its connection to the network is degraded.
+// Both depend on the time as well, so they're polled rather than updated on
+// consensus and connection events.
+const healthStatusUpdateInterval = time.Second
+
+// Start begins updating the health status the RPC servers report, and
+// refreshing the block templates of the listeners
+func (m *Manager) Start() {
+	spawn("rpc.Manager.healthStatusLoop", m.healthStatusLoop)
+	spawn("rpc.Manager.blockTemplateRefreshLoop", m.blockTemplateRefreshLoop)
+}
+
+// Stop stops updating the health status the RPC servers report, and
+// refreshing the block templates of the listeners
+func (m *Manager) Stop() {
+	close(m.stopChan)
+	<-m.doneChan
+	<-m.blockTemplatesDoneChan
+}
+
+func (m *Manager) healthStatusLoop() {
+	defer close(m.doneChan)
+
+	ticker := time.NewTicker(healthStatusUpdateInterval)
+	defer ticker.Stop()
+	for {
+		isSynced, err := m.context.Domain.Consensus().IsNearlySynced()
+		if err != nil {
+			log.Errorf("Error checking whether the node is synced: %+v", err)
+			isSynced = false
+		}
+		m.context.NetAdapter.SetRPCIsSynced(isSynced)
+		m.context.NetAdapter.SetRPCIsNetworkDegraded(m.context.ConnectionManager.NetworkHealth().IsDegraded)
+
+		select {
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,9 +3,15 @@ package rpc
 import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/protocol"
+	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/blockratemonitor"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/domain/payoutwatcher"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
@@ -17,7 +23,12 @@ import (
 
 // Manager is an RPC manager
 type Manager struct {
-	context *rpccontext.Context
+	context            *rpccontext.Context
+	blockTemplateCache *blockTemplateCache
+
+	stopChan               chan struct{}
+	doneChan               chan struct{}
+	blockTemplatesDoneChan chan struct{}
 }
 
 // NewManager creates a new RPC Manager
@@ -29,6 +40,16 @@ func NewManager(
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	stxoIndex *rpccontext.OptionalIndex,
+	scriptClassIndex *rpccontext.OptionalIndex,
+	feeHistoryIndex *rpccontext.OptionalIndex,
+	coinAgeIndex *rpccontext.OptionalIndex,
+	txIndex *rpccontext.OptionalIndex,
+	addrIndex *rpccontext.OptionalIndex,
+	indexRetentionManager *indexretention.Manager,
+	payoutWatcher *payoutwatcher.Watcher,
+	blockRateMonitor *blockratemonitor.Monitor,
+	reloadConfig rpccontext.ReloadConfigFunc,
 	consensusEventsChan chan externalapi.ConsensusEvent,
 	shutDownChan chan<- struct{}) *Manager {
 
@@ -41,8 +62,22 @@ func NewManager(
 			connectionManager,
 			addressManager,
 			utxoIndex,
+			stxoIndex,
+			scriptClassIndex,
+			feeHistoryIndex,
+			coinAgeIndex,
+			txIndex,
+			addrIndex,
+			indexRetentionManager,
+			payoutWatcher,
+			blockRateMonitor,
+			reloadConfig,
 			shutDownChan,
 		),
+		blockTemplateCache:     newBlockTemplateCache(),
+		stopChan:               make(chan struct{}),
+		doneChan:               make(chan struct{}),
+		blockTemplatesDoneChan: make(chan struct{}),
 	}
 	netAdapter.SetRPCRouterInitializer(manager.routerInitializer)
 
@@ -81,6 +116,8 @@ func (m *Manager) notifyBlockAddedToDAG(block *externalapi.DomainBlock) error {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyBlockAddedToDAG")
 	defer onEnd()
 
+	m.context.TipReceiveTimes.OnBlockAdded(block)
+
 	// Before converting the block and populating it, we check if any listeners are interested.
 	// This is done since most nodes do not use this event.
 	if !m.context.NotificationManager.HasBlockAddedListeners() {
@@ -123,6 +160,15 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 		return err
 	}
 
+	if virtualChangeSet.VirtualSelectedParentChainChanges != nil {
+		for _, index := range m.context.OptionalIndexes() {
+			err := index.Update(virtualChangeSet.VirtualSelectedParentChainChanges)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	if virtualChangeSet.VirtualSelectedParentChainChanges == nil ||
 		(len(virtualChangeSet.VirtualSelectedParentChainChanges.Added) == 0 &&
 			len(virtualChangeSet.VirtualSelectedParentChainChanges.Removed) == 0) {
@@ -130,6 +176,11 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 		return nil
 	}
 
+	err = m.context.ProtocolManager.OnVirtualSelectedParentChainChanged(virtualChangeSet.VirtualSelectedParentChainChanges)
+	if err != nil {
+		return err
+	}
+
 	err = m.notifyVirtualSelectedParentChainChanged(virtualChangeSet)
 	if err != nil {
 		return err
@@ -142,11 +193,100 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 // block template is available for miners
 func (m *Manager) NotifyNewBlockTemplate() error {
 	notification := appmessage.NewNewBlockTemplateNotificationMessage()
-	return m.context.NotificationManager.NotifyNewBlockTemplate(notification)
+	err := m.context.NotificationManager.NotifyNewBlockTemplate(notification)
+	if err != nil {
+		return err
+	}
+	return m.pushBlockTemplates()
+}
+
+// NotifyTransactionsEvicted notifies the manager that the given transactions
+// were evicted from the mempool
+func (m *Manager) NotifyTransactionsEvicted(evictedTransactions []*miningmanagermodel.EvictedTransaction) error {
+	for _, evictedTransaction := range evictedTransactions {
+		transactionID := consensushashing.TransactionID(evictedTransaction.Transaction)
+		notification := appmessage.NewTransactionEvictedNotificationMessage(transactionID.String(), evictedTransaction.Reason)
+		err := m.context.NotificationManager.NotifyTransactionEvicted(notification)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyTransactionsRemovedFromMempool notifies the manager that the given
+// transactions were removed from the mempool
+func (m *Manager) NotifyTransactionsRemovedFromMempool(removedTransactions []*miningmanagermodel.RemovedTransaction) error {
+	for _, removedTransaction := range removedTransactions {
+		transactionID := consensushashing.TransactionID(removedTransaction.Transaction)
+		notification := appmessage.NewTransactionRemovedFromMempoolNotificationMessage(
+			transactionID.String(), removedTransaction.Reason)
+		err := m.context.NotificationManager.NotifyTransactionRemovedFromMempool(notification)
+		if err != nil {
+			return err
+		}
+
+		if removedTransaction.ReplacementTransactionID != nil {
+			replacedNotification := appmessage.NewTransactionReplacedNotificationMessage(
+				transactionID.String(), removedTransaction.ReplacementTransactionID.String())
+			err := m.context.NotificationManager.NotifyTransactionReplaced(replacedNotification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NotifyTransactionsConfirmed notifies the manager that the given mempool
+// transactions were included in the block with the given hash
+func (m *Manager) NotifyTransactionsConfirmed(blockHash *externalapi.DomainHash,
+	transactions []*externalapi.DomainTransaction) error {
+
+	for _, transaction := range transactions {
+		transactionID := consensushashing.TransactionID(transaction)
+		notification := appmessage.NewTransactionConfirmedNotificationMessage(transactionID.String(), blockHash.String())
+		err := m.context.NotificationManager.NotifyTransactionConfirmed(notification)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyPeerEvent notifies the manager that a P2P connection went through a
+// step of its lifecycle
+func (m *Manager) NotifyPeerEvent(event *flowcontext.PeerEvent) error {
+	notification := &appmessage.PeerEventNotificationMessage{
+		Event:      peerEventNames[event.Type],
+		Address:    event.Connection.Address(),
+		IsOutbound: event.Connection.IsOutbound(),
+		Reason:     event.Reason,
+		Timestamp:  event.Time.UnixMilli(),
+	}
+	if event.Connection.ID() != nil {
+		notification.ID = event.Connection.ID().String()
+	}
+	if event.Peer != nil {
+		notification.UserAgent = event.Peer.UserAgent()
+		notification.ProtocolVersion = event.Peer.ProtocolVersion()
+	}
+	if event.Type == flowcontext.PeerEventDisconnected {
+		notification.ConnectionDurationMilliseconds = event.ConnectionDuration.Milliseconds()
+	}
+	return m.context.NotificationManager.NotifyPeerEvent(notification)
+}
+
+var peerEventNames = map[flowcontext.PeerEventType]string{
+	flowcontext.PeerEventConnected:          appmessage.PeerEventConnected,
+	flowcontext.PeerEventHandshakeCompleted: appmessage.PeerEventHandshakeCompleted,
+	flowcontext.PeerEventDisconnected:       appmessage.PeerEventDisconnected,
+	flowcontext.PeerEventBanned:             appmessage.PeerEventBanned,
 }
 
 // NotifyPruningPointUTXOSetOverride notifies the manager whenever the UTXO index
-// resets due to pruning point change via IBD.
+// resets due to pruning point change via IBD. The indexes that follow the virtual
+// selected parent chain are resynced from the new pruning point as well.
 func (m *Manager) NotifyPruningPointUTXOSetOverride() error {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.NotifyPruningPointUTXOSetOverride")
 	defer onEnd()
@@ -158,6 +298,13 @@ func (m *Manager) NotifyPruningPointUTXOSetOverride() error {
 		}
 	}
 
+	for _, index := range m.context.OptionalIndexes() {
+		err := index.Reset()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -208,7 +355,11 @@ func (m *Manager) notifyVirtualSelectedParentBlueScoreChanged(virtualSelectedPar
 	defer onEnd()
 
 	notification := appmessage.NewVirtualSelectedParentBlueScoreChangedNotificationMessage(virtualSelectedParentBlueScore)
-	return m.context.NotificationManager.NotifyVirtualSelectedParentBlueScoreChanged(notification)
+	err := m.context.NotificationManager.NotifyVirtualSelectedParentBlueScoreChanged(notification)
+	if err != nil {
+		return err
+	}
+	return m.context.NotificationManager.NotifyBlueScoreReached(virtualSelectedParentBlueScore)
 }
 
 func (m *Manager) notifyVirtualDaaScoreChanged(virtualDAAScore uint64) error {
@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+)
+
+// adminMethods are the RPC methods that only clients with the admin
+// permission may call. They either control the node itself, its peers or its
+// indexes, submit data to it or write files on its host, while the rest only
+// read its state.
+var adminMethods = map[appmessage.MessageCommand]struct{}{
+	appmessage.CmdShutDownRequestMessage:                {},
+	appmessage.CmdAddPeerRequestMessage:                 {},
+	appmessage.CmdBanRequestMessage:                     {},
+	appmessage.CmdUnbanRequestMessage:                   {},
+	appmessage.CmdRefreshSeedsRequestMessage:            {},
+	appmessage.CmdSetIndexEnabledRequestMessage:         {},
+	appmessage.CmdDisconnectRPCSessionRequestMessage:    {},
+	appmessage.CmdResolveFinalityConflictRequestMessage: {},
+	appmessage.CmdSubmitBlockRequestMessage:             {},
+	appmessage.CmdSubmitTransactionRequestMessage:       {},
+	appmessage.CmdExportUTXOSnapshotRequestMessage:      {},
+	appmessage.CmdReloadConfigRequestMessage:            {},
+	appmessage.CmdExportMempoolSnapshotRequestMessage:   {},
+}
+
+// permissionRejection returns the error to reject the given request with, if
+// the client that made it authenticated with a read-only credential and the
+// method requires the admin permission. It returns nil if the request may be
+// handled.
+func permissionRejection(netConnection *netadapter.NetConnection, request appmessage.Message) *appmessage.RPCError {
+	if !netConnection.IsReadOnly() {
+		return nil
+	}
+	if _, ok := adminMethods[request.Command()]; ok {
+		return appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+			"%s requires the admin permission, but the client authenticated as read-only", request.Command())
+	}
+	return nil
+}
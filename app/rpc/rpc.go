@@ -7,6 +7,7 @@ import (
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
+	"time"
 )
 
 type handler func(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error)
@@ -51,6 +52,51 @@ var handlers = map[appmessage.MessageCommand]handler{
 	appmessage.CmdNotifyNewBlockTemplateRequestMessage:                      rpchandlers.HandleNotifyNewBlockTemplate,
 	appmessage.CmdGetCoinSupplyRequestMessage:                               rpchandlers.HandleGetCoinSupply,
 	appmessage.CmdGetMempoolEntriesByAddressesRequestMessage:                rpchandlers.HandleGetMempoolEntriesByAddresses,
+	appmessage.CmdGetChainWorkStatusRequestMessage:                          rpchandlers.HandleGetChainWorkStatus,
+	appmessage.CmdGetEffectiveConfigRequestMessage:                          rpchandlers.HandleGetEffectiveConfig,
+	appmessage.CmdGetTransactionPropagationReportRequestMessage:             rpchandlers.HandleGetTransactionPropagationReport,
+	appmessage.CmdGetReorgedTransactionsStatsRequestMessage:                 rpchandlers.HandleGetReorgedTransactionsStats,
+	appmessage.CmdNotifyTransactionEvictedRequestMessage:                    rpchandlers.HandleNotifyTransactionEvicted,
+	appmessage.CmdGetImmatureCoinbaseOutputsRequestMessage:                  rpchandlers.HandleGetImmatureCoinbaseOutputs,
+	appmessage.CmdNotifyBlueScoreReachedRequestMessage:                      rpchandlers.HandleNotifyBlueScoreReached,
+	appmessage.CmdGetChainChangedEventsFromBlockRequestMessage:              rpchandlers.HandleGetChainChangedEventsFromBlock,
+	appmessage.CmdGetOutpointSpendingTransactionRequestMessage:              rpchandlers.HandleGetOutpointSpendingTransaction,
+	appmessage.CmdGetScriptClassStatisticsRequestMessage:                    rpchandlers.HandleGetScriptClassStatistics,
+	appmessage.CmdGetFeeHistoryRequestMessage:                               rpchandlers.HandleGetFeeHistory,
+	appmessage.CmdGetCoinAgeAnalyticsRequestMessage:                         rpchandlers.HandleGetCoinAgeAnalytics,
+	appmessage.CmdGetIndexRetentionStatusRequestMessage:                     rpchandlers.HandleGetIndexRetentionStatus,
+	appmessage.CmdNegotiateAPIVersionRequestMessage:                         rpchandlers.HandleNegotiateAPIVersion,
+	appmessage.CmdGetRPCSessionsRequestMessage:                              rpchandlers.HandleGetRPCSessions,
+	appmessage.CmdDisconnectRPCSessionRequestMessage:                        rpchandlers.HandleDisconnectRPCSession,
+	appmessage.CmdGetHeadersSelectedTipRequestMessage:                       rpchandlers.HandleGetHeadersSelectedTip,
+	appmessage.CmdSetIndexEnabledRequestMessage:                             rpchandlers.HandleSetIndexEnabled,
+	appmessage.CmdGetIndexStatusRequestMessage:                              rpchandlers.HandleGetIndexStatus,
+	appmessage.CmdNotifyPeerEventsRequestMessage:                            rpchandlers.HandleNotifyPeerEvents,
+	appmessage.CmdGetAddressManagerInfoRequestMessage:                       rpchandlers.HandleGetAddressManagerInfo,
+	appmessage.CmdGetPeerFlowStatisticsRequestMessage:                       rpchandlers.HandleGetPeerFlowStatistics,
+	appmessage.CmdGetTransactionRequestMessage:                              rpchandlers.HandleGetTransaction,
+	appmessage.CmdGetTransactionsByAddressRequestMessage:                    rpchandlers.HandleGetTransactionsByAddress,
+	appmessage.CmdGetRelayPolicyRequestMessage:                              rpchandlers.HandleGetRelayPolicy,
+	appmessage.CmdGetNetworkHealthRequestMessage:                            rpchandlers.HandleGetNetworkHealth,
+	appmessage.CmdEstimateFeeRequestMessage:                                 rpchandlers.HandleEstimateFee,
+	appmessage.CmdRefreshSeedsRequestMessage:                                rpchandlers.HandleRefreshSeeds,
+	appmessage.CmdNotifyTransactionRemovedFromMempoolRequestMessage:         rpchandlers.HandleNotifyTransactionRemovedFromMempool,
+	appmessage.CmdNotifyTransactionConfirmedRequestMessage:                  rpchandlers.HandleNotifyTransactionConfirmed,
+	appmessage.CmdGetUTXORequestMessage:                                     rpchandlers.HandleGetUTXO,
+	appmessage.CmdGetBlockHeadersRequestMessage:                             rpchandlers.HandleGetBlockHeaders,
+	appmessage.CmdGetDAGTipsRequestMessage:                                  rpchandlers.HandleGetDAGTips,
+	appmessage.CmdGetBannedPeersRequestMessage:                              rpchandlers.HandleGetBannedPeers,
+	appmessage.CmdGetBandwidthInfoRequestMessage:                            rpchandlers.HandleGetBandwidthInfo,
+	appmessage.CmdGetPayoutStatsRequestMessage:                              rpchandlers.HandleGetPayoutStats,
+	appmessage.CmdGetBlockRateStatusRequestMessage:                          rpchandlers.HandleGetBlockRateStatus,
+	appmessage.CmdExportUTXOSnapshotRequestMessage:                          rpchandlers.HandleExportUTXOSnapshot,
+	appmessage.CmdReloadConfigRequestMessage:                                rpchandlers.HandleReloadConfig,
+	appmessage.CmdGetMempoolGraphRequestMessage:                             rpchandlers.HandleGetMempoolGraph,
+	appmessage.CmdExportMempoolSnapshotRequestMessage:                       rpchandlers.HandleExportMempoolSnapshot,
+	appmessage.CmdDeriveAddressFromPublicKeyRequestMessage:                  rpchandlers.HandleDeriveAddressFromPublicKey,
+	appmessage.CmdValidateAddressRequestMessage:                             rpchandlers.HandleValidateAddress,
+	appmessage.CmdConvertAddressRequestMessage:                              rpchandlers.HandleConvertAddress,
+	appmessage.CmdNotifyTransactionReplacedRequestMessage:                   rpchandlers.HandleNotifyTransactionReplaced,
 }
 
 func (m *Manager) routerInitializer(router *router.Router, netConnection *netadapter.NetConnection) {
@@ -63,17 +109,27 @@ func (m *Manager) routerInitializer(router *router.Router, netConnection *netada
 		panic(err)
 	}
 	m.context.NotificationManager.AddListener(router)
+	m.context.RPCSessions.Add(router, netConnection)
 
 	spawn("routerInitializer-handleIncomingMessages", func() {
 		defer m.context.NotificationManager.RemoveListener(router)
+		defer m.context.APIVersions.Remove(router)
+		defer m.context.RPCSessions.Remove(router)
 
-		err := m.handleIncomingMessages(router, incomingRoute)
+		err := m.handleIncomingMessages(router, incomingRoute, netConnection)
 		m.handleError(err, netConnection)
 	})
 }
 
-func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *router.Route) error {
+func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *router.Route,
+	netConnection *netadapter.NetConnection) error {
+
+	rpcEndpoint := netConnection.RPCEndpoint()
 	outgoingRoute := router.OutgoingRoute()
+	var limiter *rateLimiter
+	if rpcEndpoint != nil && rpcEndpoint.RateLimit > 0 {
+		limiter = newRateLimiter(rpcEndpoint.RateLimit)
+	}
 	for {
 		request, err := incomingRoute.Dequeue()
 		if err != nil {
@@ -83,10 +139,33 @@ func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *r
 		if !ok {
 			return err
 		}
-		response, err := handler(m.context, router, request)
-		if err != nil {
-			return err
+		request.SetCorrelationID(newCorrelationID())
+		log.Debugf("[%s] Handling %s from %s", request.CorrelationID(), request.Command(), netConnection)
+		start := time.Now()
+
+		var response appmessage.Message
+		rejection := endpointRejection(rpcEndpoint, limiter, request)
+		if rejection == nil {
+			rejection = permissionRejection(netConnection, request)
+		}
+		if rejection == nil {
+			rejection = headersOnlyRejection(m.context.Config, request)
+		}
+		if rejection != nil {
+			response, ok = appmessage.NewRPCErrorResponseMessage(request.Command(), rejection)
+			if !ok {
+				return errors.Errorf("no error response is defined for %s", request.Command())
+			}
+		} else {
+			response, err = handler(m.context, router, request)
+			if err != nil {
+				return errors.Wrapf(err, "[%s] error handling %s", request.CorrelationID(), request.Command())
+			}
+		}
+		if warning := deprecationWarning(request.Command()); warning != "" {
+			response.AddWarning(warning)
 		}
+		log.Debugf("[%s] Handled %s in %s", request.CorrelationID(), request.Command(), time.Since(start))
 		err = outgoingRoute.Enqueue(response)
 		if err != nil {
 			return err
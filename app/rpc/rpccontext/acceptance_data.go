@@ -0,0 +1,92 @@
+package rpccontext
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// maxMergingBlockSearchSize is the maximum number of blocks that are visited
+// while searching for the chain block that merged a block. The merging block
+// is usually a child of the block or close to it, so the search gives up on
+// blocks that are far from being merged rather than traverse large parts of
+// the DAG.
+const maxMergingBlockSearchSize = 1000
+
+// blockAcceptanceData returns the acceptance data of the transactions of the
+// given block, as determined by the chain block that merged it. found is false
+// if no chain block merged it yet.
+func (ctx *Context) blockAcceptanceData(blockHash *externalapi.DomainHash) (
+	blockAcceptanceData *externalapi.BlockAcceptanceData, found bool, err error) {
+
+	mergingBlockHash, found, err := ctx.mergingBlock(blockHash)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	acceptanceData, err := ctx.Domain.Consensus().GetBlockAcceptanceData(mergingBlockHash)
+	if err != nil {
+		// The acceptance data of blocks below the pruning point is pruned
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	for _, blockAcceptanceData := range acceptanceData {
+		if blockAcceptanceData.BlockHash.Equal(blockHash) {
+			return blockAcceptanceData, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// mergingBlock returns the chain block that merged the given block, which is
+// the earliest chain block in its future. Chain blocks in the future of the
+// block can't precede it on any path from the block, so the search doesn't
+// continue past them.
+func (ctx *Context) mergingBlock(blockHash *externalapi.DomainHash) (
+	mergingBlockHash *externalapi.DomainHash, found bool, err error) {
+
+	var mergingBlockBlueScore uint64
+	visited := map[externalapi.DomainHash]struct{}{*blockHash: {}}
+	queue := []*externalapi.DomainHash{blockHash}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		_, childrenHashes, err := ctx.Domain.Consensus().GetBlockRelations(current)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, childHash := range childrenHashes {
+			// The virtual is a child of the tips, but it's not a block
+			if childHash.Equal(model.VirtualBlockHash) {
+				continue
+			}
+			if _, ok := visited[*childHash]; ok {
+				continue
+			}
+			if len(visited) == maxMergingBlockSearchSize {
+				return nil, false, nil
+			}
+			visited[*childHash] = struct{}{}
+
+			isChainBlock, err := ctx.Domain.Consensus().IsChainBlock(childHash)
+			if err != nil {
+				return nil, false, err
+			}
+			if !isChainBlock {
+				queue = append(queue, childHash)
+				continue
+			}
+			childInfo, err := ctx.Domain.Consensus().GetBlockInfo(childHash)
+			if err != nil {
+				return nil, false, err
+			}
+			if mergingBlockHash == nil || childInfo.BlueScore < mergingBlockBlueScore {
+				mergingBlockHash = childHash
+				mergingBlockBlueScore = childInfo.BlueScore
+			}
+		}
+	}
+	return mergingBlockHash, mergingBlockHash != nil, nil
+}
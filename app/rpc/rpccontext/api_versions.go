@@ -0,0 +1,51 @@
+package rpccontext
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// APIVersions keeps the RPC API version every RPC connection negotiated
+type APIVersions struct {
+	versions map[*routerpkg.Router]uint32
+	lock     sync.RWMutex
+}
+
+// NewAPIVersions creates a new APIVersions
+func NewAPIVersions() *APIVersions {
+	return &APIVersions{
+		versions: make(map[*routerpkg.Router]uint32),
+	}
+}
+
+// Version returns the RPC API version of the connection with the given router.
+// Connections that never negotiated a version get appmessage.RPCAPIVersion1.
+func (v *APIVersions) Version(router *routerpkg.Router) uint32 {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	version, ok := v.versions[router]
+	if !ok {
+		return appmessage.RPCAPIVersion1
+	}
+	return version
+}
+
+// SetVersion sets the RPC API version of the connection with the given router
+func (v *APIVersions) SetVersion(router *routerpkg.Router, version uint32) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.versions[router] = version
+}
+
+// Remove forgets the RPC API version of the connection with the given router.
+// It's called once the connection closes.
+func (v *APIVersions) Remove(router *routerpkg.Router) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	delete(v.versions, router)
+}
@@ -0,0 +1,45 @@
+package rpccontext
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidBlockTemplateRequest is returned by BuildBlockTemplate when the
+// pay address or the extra data of the request are invalid
+var ErrInvalidBlockTemplateRequest = errors.New("invalid block template request")
+
+// BuildBlockTemplate builds the template of the given request the way
+// GetBlockTemplate does, and returns it with whether the node is synced
+func (ctx *Context) BuildBlockTemplate(request BlockTemplateRequest) (
+	template *externalapi.DomainBlock, isSynced bool, err error) {
+
+	payAddress, err := util.DecodeAddress(request.PayAddress, ctx.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		return nil, false, errors.Wrapf(ErrInvalidBlockTemplateRequest, "could not decode address: %s", err)
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(payAddress)
+	if err != nil {
+		return nil, false, err
+	}
+	coinbaseData := &externalapi.DomainCoinbaseData{
+		ScriptPublicKey: scriptPublicKey,
+		ExtraData:       []byte(version.Version() + "/" + request.ExtraData),
+	}
+
+	template, isNearlySynced, err := ctx.Domain.MiningManager().GetBlockTemplate(coinbaseData)
+	if err != nil {
+		return nil, false, err
+	}
+	maxCoinbasePayloadLength := ctx.Config.NetParams().MaxCoinbasePayloadLength
+	if uint64(len(template.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload)) > maxCoinbasePayloadLength {
+		return nil, false, errors.Wrapf(ErrInvalidBlockTemplateRequest,
+			"coinbase payload is above max length (%d). Try to shorten the extra data", maxCoinbasePayloadLength)
+	}
+
+	return template, ctx.ProtocolManager.Context().HasPeers() && isNearlySynced, nil
+}
@@ -77,3 +77,74 @@ func (ctx *Context) getAndConvertAcceptedTransactionIDs(selectedParentChainChang
 
 	return acceptedTransactionIDs, nil
 }
+
+// GetAndConvertAcceptedTransactions returns the transactions accepted by each
+// of the given chain blocks
+func (ctx *Context) GetAndConvertAcceptedTransactions(chainBlocks []*externalapi.DomainHash) (
+	[]*appmessage.AcceptedTransactions, error) {
+
+	chainBlocksAcceptanceData, err := ctx.Domain.Consensus().GetBlocksAcceptanceData(chainBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptedTransactions := make([]*appmessage.AcceptedTransactions, len(chainBlocks))
+	for i, chainBlock := range chainBlocks {
+		acceptedTransactions[i] = &appmessage.AcceptedTransactions{
+			AcceptingBlockHash:   chainBlock.String(),
+			AcceptedTransactions: nil,
+		}
+		for _, blockAcceptanceData := range chainBlocksAcceptanceData[i] {
+			for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+				if transactionAcceptanceData.IsAccepted {
+					acceptedTransactions[i].AcceptedTransactions = append(acceptedTransactions[i].AcceptedTransactions,
+						appmessage.DomainTransactionToRPCTransaction(transactionAcceptanceData.Transaction))
+				}
+			}
+		}
+	}
+	return acceptedTransactions, nil
+}
+
+// mergeVirtualSelectedParentChainChangedNotifications merges two consecutive
+// chain changes into a single one, as if they happened at once. Chain blocks
+// that the earlier change added and the later one removed appear in neither.
+func mergeVirtualSelectedParentChainChangedNotifications(
+	earlier, later *appmessage.VirtualSelectedParentChainChangedNotificationMessage) *appmessage.VirtualSelectedParentChainChangedNotificationMessage {
+
+	// The later change removes chain blocks from the tip, which are the
+	// last chain blocks the earlier change added, in reverse order
+	cancelledCount := 0
+	for cancelledCount < len(later.RemovedChainBlockHashes) && cancelledCount < len(earlier.AddedChainBlockHashes) {
+		lastAddedIndex := len(earlier.AddedChainBlockHashes) - 1 - cancelledCount
+		if later.RemovedChainBlockHashes[cancelledCount] != earlier.AddedChainBlockHashes[lastAddedIndex] {
+			break
+		}
+		cancelledCount++
+	}
+	keptAddedCount := len(earlier.AddedChainBlockHashes) - cancelledCount
+
+	removedChainBlockHashes := make([]string, 0,
+		len(earlier.RemovedChainBlockHashes)+len(later.RemovedChainBlockHashes)-cancelledCount)
+	removedChainBlockHashes = append(removedChainBlockHashes, earlier.RemovedChainBlockHashes...)
+	removedChainBlockHashes = append(removedChainBlockHashes, later.RemovedChainBlockHashes[cancelledCount:]...)
+
+	addedChainBlockHashes := make([]string, 0, keptAddedCount+len(later.AddedChainBlockHashes))
+	addedChainBlockHashes = append(addedChainBlockHashes, earlier.AddedChainBlockHashes[:keptAddedCount]...)
+	addedChainBlockHashes = append(addedChainBlockHashes, later.AddedChainBlockHashes...)
+
+	// Accepted transaction IDs are only included if the listener asked for
+	// them, in which case there are as many as there are added chain blocks
+	var acceptedTransactionIDs []*appmessage.AcceptedTransactionIDs
+	if len(earlier.AcceptedTransactionIDs) == len(earlier.AddedChainBlockHashes) &&
+		len(later.AcceptedTransactionIDs) == len(later.AddedChainBlockHashes) &&
+		len(earlier.AcceptedTransactionIDs)+len(later.AcceptedTransactionIDs) > 0 {
+
+		acceptedTransactionIDs = make([]*appmessage.AcceptedTransactionIDs, 0, len(addedChainBlockHashes))
+		acceptedTransactionIDs = append(acceptedTransactionIDs, earlier.AcceptedTransactionIDs[:keptAddedCount]...)
+		acceptedTransactionIDs = append(acceptedTransactionIDs, later.AcceptedTransactionIDs...)
+	}
+
+	return appmessage.NewVirtualSelectedParentChainChangedNotificationMessage(
+		removedChainBlockHashes, addedChainBlockHashes, acceptedTransactionIDs)
+}
@@ -0,0 +1,76 @@
+package rpccontext
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func acceptedTransactionIDsForTest(chainBlockHashes ...string) []*appmessage.AcceptedTransactionIDs {
+	acceptedTransactionIDs := make([]*appmessage.AcceptedTransactionIDs, len(chainBlockHashes))
+	for i, chainBlockHash := range chainBlockHashes {
+		acceptedTransactionIDs[i] = &appmessage.AcceptedTransactionIDs{
+			AcceptingBlockHash:     chainBlockHash,
+			AcceptedTransactionIDs: []string{"tx-" + chainBlockHash},
+		}
+	}
+	return acceptedTransactionIDs
+}
+
+func TestMergeVirtualSelectedParentChainChangedNotifications(t *testing.T) {
+	tests := []struct {
+		name            string
+		earlier         *appmessage.VirtualSelectedParentChainChangedNotificationMessage
+		later           *appmessage.VirtualSelectedParentChainChangedNotificationMessage
+		expectedRemoved []string
+		expectedAdded   []string
+	}{
+		{
+			name:          "consecutive additions",
+			earlier:       appmessage.NewVirtualSelectedParentChainChangedNotificationMessage(nil, []string{"a"}, nil),
+			later:         appmessage.NewVirtualSelectedParentChainChangedNotificationMessage(nil, []string{"b", "c"}, nil),
+			expectedAdded: []string{"a", "b", "c"},
+		},
+		{
+			name:    "later change removes some of the added chain blocks",
+			earlier: appmessage.NewVirtualSelectedParentChainChangedNotificationMessage([]string{"x"}, []string{"a", "b", "c"}, nil),
+			later: appmessage.NewVirtualSelectedParentChainChangedNotificationMessage([]string{"c", "b"},
+				[]string{"d"}, nil),
+			expectedRemoved: []string{"x"},
+			expectedAdded:   []string{"a", "d"},
+		},
+		{
+			name:    "later change removes all the added chain blocks and more",
+			earlier: appmessage.NewVirtualSelectedParentChainChangedNotificationMessage([]string{"x"}, []string{"a", "b"}, nil),
+			later: appmessage.NewVirtualSelectedParentChainChangedNotificationMessage([]string{"b", "a", "y"},
+				[]string{"d"}, nil),
+			expectedRemoved: []string{"x", "y"},
+			expectedAdded:   []string{"d"},
+		},
+	}
+	for _, test := range tests {
+		merged := mergeVirtualSelectedParentChainChangedNotifications(test.earlier, test.later)
+		if !reflect.DeepEqual(merged.RemovedChainBlockHashes, test.expectedRemoved) &&
+			!(len(merged.RemovedChainBlockHashes) == 0 && len(test.expectedRemoved) == 0) {
+
+			t.Errorf("%s: expected removed %v, but got %v", test.name, test.expectedRemoved, merged.RemovedChainBlockHashes)
+		}
+		if !reflect.DeepEqual(merged.AddedChainBlockHashes, test.expectedAdded) {
+			t.Errorf("%s: expected added %v, but got %v", test.name, test.expectedAdded, merged.AddedChainBlockHashes)
+		}
+		if merged.AcceptedTransactionIDs != nil {
+			t.Errorf("%s: expected no accepted transaction IDs, but got %v", test.name, merged.AcceptedTransactionIDs)
+		}
+	}
+
+	// The accepted transaction IDs of the removed chain blocks are dropped
+	earlier := appmessage.NewVirtualSelectedParentChainChangedNotificationMessage(nil, []string{"a", "b"},
+		acceptedTransactionIDsForTest("a", "b"))
+	later := appmessage.NewVirtualSelectedParentChainChangedNotificationMessage([]string{"b"}, []string{"c"},
+		acceptedTransactionIDsForTest("c"))
+	merged := mergeVirtualSelectedParentChainChangedNotifications(earlier, later)
+	if !reflect.DeepEqual(merged.AcceptedTransactionIDs, acceptedTransactionIDsForTest("a", "c")) {
+		t.Fatalf("Unexpected accepted transaction IDs %v", merged.AcceptedTransactionIDs)
+	}
+}
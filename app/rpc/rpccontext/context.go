@@ -3,6 +3,9 @@ package rpccontext
 import (
 	"github.com/kaspanet/kaspad/app/protocol"
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/blockratemonitor"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/domain/payoutwatcher"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
@@ -12,18 +15,37 @@ import (
 
 // Context represents the RPC context
 type Context struct {
-	Config            *config.Config
-	NetAdapter        *netadapter.NetAdapter
-	Domain            domain.Domain
-	ProtocolManager   *protocol.Manager
-	ConnectionManager *connmanager.ConnectionManager
-	AddressManager    *addressmanager.AddressManager
-	UTXOIndex         *utxoindex.UTXOIndex
-	ShutDownChan      chan<- struct{}
+	Config                *config.Config
+	NetAdapter            *netadapter.NetAdapter
+	Domain                domain.Domain
+	ProtocolManager       *protocol.Manager
+	ConnectionManager     *connmanager.ConnectionManager
+	AddressManager        *addressmanager.AddressManager
+	UTXOIndex             *utxoindex.UTXOIndex
+	STXOIndex             *OptionalIndex
+	ScriptClassIndex      *OptionalIndex
+	FeeHistoryIndex       *OptionalIndex
+	CoinAgeIndex          *OptionalIndex
+	TXIndex               *OptionalIndex
+	AddrIndex             *OptionalIndex
+	IndexRetentionManager *indexretention.Manager
+	PayoutWatcher         *payoutwatcher.Watcher
+	BlockRateMonitor      *blockratemonitor.Monitor
+	ReloadConfig          ReloadConfigFunc
+	ShutDownChan          chan<- struct{}
 
 	NotificationManager *NotificationManager
+	IdempotencyCache    *IdempotencyCache
+	APIVersions         *APIVersions
+	RPCSessions         *RPCSessions
+	TipReceiveTimes     *TipReceiveTimes
 }
 
+// ReloadConfigFunc reloads the configuration of the node and applies it to the
+// running components. It returns the names of the options whose changes were
+// applied, and of the ones whose changes take effect only after a restart.
+type ReloadConfigFunc func() (reloadedOptions []string, restartRequiredOptions []string, err error)
+
 // NewContext creates a new RPC context
 func NewContext(cfg *config.Config,
 	domain domain.Domain,
@@ -32,19 +54,43 @@ func NewContext(cfg *config.Config,
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	stxoIndex *OptionalIndex,
+	scriptClassIndex *OptionalIndex,
+	feeHistoryIndex *OptionalIndex,
+	coinAgeIndex *OptionalIndex,
+	txIndex *OptionalIndex,
+	addrIndex *OptionalIndex,
+	indexRetentionManager *indexretention.Manager,
+	payoutWatcher *payoutwatcher.Watcher,
+	blockRateMonitor *blockratemonitor.Monitor,
+	reloadConfig ReloadConfigFunc,
 	shutDownChan chan<- struct{}) *Context {
 
 	context := &Context{
-		Config:            cfg,
-		NetAdapter:        netAdapter,
-		Domain:            domain,
-		ProtocolManager:   protocolManager,
-		ConnectionManager: connectionManager,
-		AddressManager:    addressManager,
-		UTXOIndex:         utxoIndex,
-		ShutDownChan:      shutDownChan,
+		Config:                cfg,
+		NetAdapter:            netAdapter,
+		Domain:                domain,
+		ProtocolManager:       protocolManager,
+		ConnectionManager:     connectionManager,
+		AddressManager:        addressManager,
+		UTXOIndex:             utxoIndex,
+		STXOIndex:             stxoIndex,
+		ScriptClassIndex:      scriptClassIndex,
+		FeeHistoryIndex:       feeHistoryIndex,
+		CoinAgeIndex:          coinAgeIndex,
+		TXIndex:               txIndex,
+		AddrIndex:             addrIndex,
+		IndexRetentionManager: indexRetentionManager,
+		PayoutWatcher:         payoutWatcher,
+		BlockRateMonitor:      blockRateMonitor,
+		ReloadConfig:          reloadConfig,
+		ShutDownChan:          shutDownChan,
 	}
 	context.NotificationManager = NewNotificationManager(cfg.ActiveNetParams)
+	context.IdempotencyCache = NewIdempotencyCache()
+	context.APIVersions = NewAPIVersions()
+	context.RPCSessions = NewRPCSessions()
+	context.TipReceiveTimes = NewTipReceiveTimes()
 
 	return context
 }
@@ -0,0 +1,31 @@
+package rpccontext
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/hashes"
+)
+
+// DAGSnapshot returns the current tips and virtual selected parent of the DAG
+func (ctx *Context) DAGSnapshot() (*appmessage.DAGSnapshotNotificationMessage, error) {
+	consensus := ctx.Domain.Consensus()
+
+	tipHashes, err := consensus.Tips()
+	if err != nil {
+		return nil, err
+	}
+	virtualSelectedParent, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	virtualSelectedParentInfo, err := consensus.GetBlockInfo(virtualSelectedParent)
+	if err != nil {
+		return nil, err
+	}
+	virtualInfo, err := consensus.GetVirtualInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	return appmessage.NewDAGSnapshotNotificationMessage(hashes.ToStrings(tipHashes), virtualSelectedParent.String(),
+		virtualSelectedParentInfo.BlueScore, virtualInfo.DAAScore), nil
+}
@@ -0,0 +1,151 @@
+package rpccontext
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+const (
+	// idempotencyKeyTTL is how long the response to a request is kept for
+	// requests that repeat its idempotency key
+	idempotencyKeyTTL = 10 * time.Minute
+
+	// maxIdempotencyKeys is the maximum amount of responses kept at once.
+	// Beyond it, the oldest responses are evicted first.
+	maxIdempotencyKeys = 10_000
+)
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is repeated with
+// a request for a different block or transaction than the original request
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+// IdempotencyScope is the set of RPC clients that share idempotency keys:
+// the clients that connected through the same RPC endpoint and authenticated
+// with the same credential. Keys of one scope can neither collide with nor
+// reveal the responses of another. Clients that didn't have to authenticate
+// can't be told apart, so they share the scope of their endpoint.
+type IdempotencyScope struct {
+	RPCEndpoint  string
+	CredentialID string
+}
+
+// IdempotencyScope returns the idempotency scope of the RPC client of the
+// connection with the given router
+func (ctx *Context) IdempotencyScope(router *routerpkg.Router) IdempotencyScope {
+	session, ok := ctx.RPCSessions.ByRouter(router)
+	if !ok {
+		return IdempotencyScope{}
+	}
+	scope := IdempotencyScope{CredentialID: session.NetConnection.CredentialID()}
+	if rpcEndpoint := session.NetConnection.RPCEndpoint(); rpcEndpoint != nil {
+		scope.RPCEndpoint = rpcEndpoint.Name
+	}
+	return scope
+}
+
+type idempotencyCacheKey struct {
+	scope          IdempotencyScope
+	command        appmessage.MessageCommand
+	idempotencyKey string
+}
+
+type idempotentResponse struct {
+	fingerprint string
+	response    appmessage.Message
+	createdAt   time.Time
+
+	// done is closed once response is set
+	done chan struct{}
+}
+
+// IdempotencyCache keeps the responses to requests that carried idempotency
+// keys, so that requests repeating a key get the original response rather
+// than being handled again
+type IdempotencyCache struct {
+	responses map[idempotencyCacheKey]*idempotentResponse
+	lock      sync.Mutex
+}
+
+// NewIdempotencyCache creates a new IdempotencyCache
+func NewIdempotencyCache() *IdempotencyCache {
+	return &IdempotencyCache{
+		responses: make(map[idempotencyCacheKey]*idempotentResponse),
+	}
+}
+
+// Do returns the response to an earlier request with the same scope, command
+// and idempotency key if there is one. Otherwise, it handles the request with the
+// given handle function, and keeps the response if handle deems it final.
+// fingerprint identifies the content of the request, such as a block hash, and
+// must match the one of the earlier request, or else ErrIdempotencyKeyReused is
+// returned. A request that repeats the key of a request that is still being
+// handled waits for its response.
+func (c *IdempotencyCache) Do(scope IdempotencyScope, command appmessage.MessageCommand, idempotencyKey string,
+	fingerprint string, handle func() (response appmessage.Message, isFinal bool, err error)) (appmessage.Message, error) {
+
+	key := idempotencyCacheKey{scope: scope, command: command, idempotencyKey: idempotencyKey}
+
+	now := time.Now()
+	c.lock.Lock()
+	entry, ok := c.responses[key]
+	if ok && entry.response != nil && now.Sub(entry.createdAt) > idempotencyKeyTTL {
+		ok = false
+	}
+	if !ok {
+		entry = &idempotentResponse{fingerprint: fingerprint, createdAt: now, done: make(chan struct{})}
+		c.evictIfRequired(now)
+		c.responses[key] = entry
+	}
+	c.lock.Unlock()
+
+	if ok {
+		if entry.fingerprint != fingerprint {
+			return nil, ErrIdempotencyKeyReused
+		}
+		<-entry.done
+		if entry.response != nil {
+			return entry.response, nil
+		}
+		// The original request failed without a final response, so this
+		// one is handled from scratch
+		return c.Do(scope, command, idempotencyKey, fingerprint, handle)
+	}
+
+	response, isFinal, err := handle()
+	c.lock.Lock()
+	if err == nil && isFinal {
+		entry.response = response
+	} else if c.responses[key] == entry {
+		delete(c.responses, key)
+	}
+	c.lock.Unlock()
+	close(entry.done)
+
+	return response, err
+}
+
+// evictIfRequired removes expired responses and, if there are still too many
+// of them, the oldest ones. This must be called while holding the lock.
+func (c *IdempotencyCache) evictIfRequired(now time.Time) {
+	if len(c.responses) < maxIdempotencyKeys {
+		return
+	}
+	var oldestKey idempotencyCacheKey
+	var oldestEntry *idempotentResponse
+	for key, entry := range c.responses {
+		if now.Sub(entry.createdAt) > idempotencyKeyTTL {
+			delete(c.responses, key)
+			continue
+		}
+		if oldestEntry == nil || entry.createdAt.Before(oldestEntry.createdAt) {
+			oldestKey, oldestEntry = key, entry
+		}
+	}
+	if len(c.responses) >= maxIdempotencyKeys && oldestEntry != nil {
+		delete(c.responses, oldestKey)
+	}
+}
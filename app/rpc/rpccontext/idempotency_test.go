@@ -0,0 +1,140 @@
+package rpccontext
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	cache := NewIdempotencyCache()
+	command := appmessage.CmdSubmitTransactionRequestMessage
+	handleCount := 0
+	handle := func() (appmessage.Message, bool, error) {
+		handleCount++
+		return appmessage.NewSubmitTransactionResponseMessage("a"), true, nil
+	}
+
+	first, err := cache.Do(IdempotencyScope{}, command, "key", "a", handle)
+	if err != nil {
+		t.Fatalf("Do: %s", err)
+	}
+	repeated, err := cache.Do(IdempotencyScope{}, command, "key", "a", handle)
+	if err != nil {
+		t.Fatalf("Do: %s", err)
+	}
+	if repeated != first || handleCount != 1 {
+		t.Fatalf("a repeated key was handled again")
+	}
+
+	_, err = cache.Do(IdempotencyScope{}, command, "key", "b", handle)
+	if !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Fatalf("expected ErrIdempotencyKeyReused, got: %v", err)
+	}
+
+	// The same key of a different command is unrelated
+	_, err = cache.Do(IdempotencyScope{}, appmessage.CmdSubmitBlockRequestMessage, "key", "b", handle)
+	if err != nil || handleCount != 2 {
+		t.Fatalf("the key of a different command was not handled: %v", err)
+	}
+}
+
+func TestIdempotencyCacheScopes(t *testing.T) {
+	cache := NewIdempotencyCache()
+	command := appmessage.CmdSubmitTransactionRequestMessage
+	handleCount := 0
+	handle := func() (appmessage.Message, bool, error) {
+		handleCount++
+		return appmessage.NewSubmitTransactionResponseMessage("a"), true, nil
+	}
+
+	scopes := []IdempotencyScope{
+		{},
+		{CredentialID: "user:alice"},
+		{CredentialID: "user:bob"},
+		{RPCEndpoint: "tenant", CredentialID: "user:alice"},
+	}
+	for i, scope := range scopes {
+		// Each scope uses the same key for a different transaction,
+		// which would be rejected as a reused key within a single scope
+		fingerprint := string(rune('a' + i))
+		_, err := cache.Do(scope, command, "key", fingerprint, handle)
+		if err != nil {
+			t.Fatalf("the key of scope %+v collided with another scope: %s", scope, err)
+		}
+	}
+	if handleCount != len(scopes) {
+		t.Fatalf("expected every scope to be handled, but %d out of %d were", handleCount, len(scopes))
+	}
+}
+
+func TestIdempotencyCacheNonFinalResponses(t *testing.T) {
+	cache := NewIdempotencyCache()
+	command := appmessage.CmdSubmitBlockRequestMessage
+	handleCount := 0
+	handle := func() (appmessage.Message, bool, error) {
+		handleCount++
+		return appmessage.NewSubmitBlockResponseMessage(), false, nil
+	}
+	for i := 0; i < 2; i++ {
+		_, err := cache.Do(IdempotencyScope{}, command, "key", "a", handle)
+		if err != nil {
+			t.Fatalf("Do: %s", err)
+		}
+	}
+	if handleCount != 2 {
+		t.Fatalf("a non-final response was kept")
+	}
+
+	failingHandle := func() (appmessage.Message, bool, error) {
+		return nil, true, errors.New("failed")
+	}
+	_, err := cache.Do(IdempotencyScope{}, command, "failing", "a", failingHandle)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	_, err = cache.Do(IdempotencyScope{}, command, "failing", "a", handle)
+	if err != nil {
+		t.Fatalf("a request that failed blocked its key: %s", err)
+	}
+}
+
+func TestIdempotencyCacheConcurrentRequests(t *testing.T) {
+	cache := NewIdempotencyCache()
+	command := appmessage.CmdSubmitTransactionRequestMessage
+	release := make(chan struct{})
+	handleCount := 0
+	handle := func() (appmessage.Message, bool, error) {
+		handleCount++
+		<-release
+		return appmessage.NewSubmitTransactionResponseMessage("a"), true, nil
+	}
+
+	const requestCount = 10
+	responses := make([]appmessage.Message, requestCount)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < requestCount; i++ {
+		waitGroup.Add(1)
+		go func(i int) {
+			defer waitGroup.Done()
+			response, err := cache.Do(IdempotencyScope{}, command, "key", "a", handle)
+			if err != nil {
+				t.Errorf("Do: %s", err)
+			}
+			responses[i] = response
+		}(i)
+	}
+	close(release)
+	waitGroup.Wait()
+
+	if handleCount != 1 {
+		t.Fatalf("concurrent requests with the same key were handled %d times", handleCount)
+	}
+	for _, response := range responses {
+		if response != responses[0] {
+			t.Fatalf("concurrent requests with the same key got different responses")
+		}
+	}
+}
@@ -2,6 +2,7 @@ package rpccontext
 
 import (
 	"sync"
+	"time"
 
 	"github.com/kaspanet/kaspad/domain/dagconfig"
 
@@ -14,11 +15,30 @@ import (
 	"github.com/pkg/errors"
 )
 
+// maxPendingBlueScoreReachedNotifications is the maximum amount of
+// BlueScoreReached notifications a single listener may have scheduled at once
+const maxPendingBlueScoreReachedNotifications = 1000
+
+// ErrTooManyPendingBlueScoreReachedNotifications indicates that a listener
+// attempted to schedule more than maxPendingBlueScoreReachedNotifications
+// BlueScoreReached notifications
+var ErrTooManyPendingBlueScoreReachedNotifications = errors.Errorf(
+	"a listener may not have more than %d pending BlueScoreReached notifications",
+	maxPendingBlueScoreReachedNotifications)
+
+// MaxNotificationCoalesceInterval is the longest a listener may ask for
+// its notifications to be held back in order to coalesce them
+const MaxNotificationCoalesceInterval = 10 * time.Second
+
 // NotificationManager manages notifications for the RPC
 type NotificationManager struct {
 	sync.RWMutex
 	listeners map[*routerpkg.Router]*NotificationListener
 	params    *dagconfig.Params
+
+	// virtualSelectedParentBlueScore is the virtual selected parent blue
+	// score as of the latest NotifyBlueScoreReached
+	virtualSelectedParentBlueScore uint64
 }
 
 // UTXOsChangedNotificationAddress represents a kaspad address.
@@ -28,6 +48,13 @@ type UTXOsChangedNotificationAddress struct {
 	ScriptPublicKeyString utxoindex.ScriptPublicKeyString
 }
 
+// BlockTemplateRequest is the pay address and coinbase extra data of the
+// templates a listener gets with its new block template notifications
+type BlockTemplateRequest struct {
+	PayAddress string
+	ExtraData  string
+}
+
 // NotificationListener represents a registered RPC notification listener
 type NotificationListener struct {
 	params *dagconfig.Params
@@ -41,9 +68,28 @@ type NotificationListener struct {
 	propagateVirtualDaaScoreChangedNotifications                bool
 	propagatePruningPointUTXOSetOverrideNotifications           bool
 	propagateNewBlockTemplateNotifications                      bool
+	propagateTransactionEvictedNotifications                    bool
+	propagateTransactionRemovedFromMempoolNotifications         bool
+	propagateTransactionConfirmedNotifications                  bool
+	propagateTransactionReplacedNotifications                   bool
+	propagatePeerEventNotifications                             bool
 
 	propagateUTXOsChangedNotificationAddresses                                    map[utxoindex.ScriptPublicKeyString]*UTXOsChangedNotificationAddress
 	includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications bool
+
+	// newBlockTemplateRequest is nil unless the new block template
+	// notifications carry the templates
+	newBlockTemplateRequest *BlockTemplateRequest
+
+	pendingBlueScoreReachedNotifications []*appmessage.BlueScoreReachedNotificationMessage
+
+	// The notifications that are held back until the coalesce interval
+	// that started with the first of them passes. A zero interval sends
+	// the notifications right away.
+	blockAddedCoalesceInterval                           time.Duration
+	pendingAddedBlocks                                   []*appmessage.RPCBlock
+	virtualSelectedParentChainChangedCoalesceInterval    time.Duration
+	pendingVirtualSelectedParentChainChangedNotification *appmessage.VirtualSelectedParentChainChangedNotificationMessage
 }
 
 // NewNotificationManager creates a new NotificationManager
@@ -83,6 +129,72 @@ func (nm *NotificationManager) Listener(router *routerpkg.Router) (*Notification
 	return listener, nil
 }
 
+// Subscribe calls subscribe with the listener registered with the given
+// router. If getSnapshot isn't nil, the snapshot it returns is sent to the
+// listener before any of the notifications it subscribed to, and reflects
+// all the notifications that were sent before it.
+func (nm *NotificationManager) Subscribe(router *routerpkg.Router, subscribe func(listener *NotificationListener),
+	getSnapshot func() (*appmessage.DAGSnapshotNotificationMessage, error)) error {
+
+	// Apply a write-lock so that no notification is sent between
+	// subscribing and taking the snapshot
+	nm.Lock()
+	defer nm.Unlock()
+
+	listener, ok := nm.listeners[router]
+	if !ok {
+		return errors.Errorf("listener not found")
+	}
+	subscribe(listener)
+	if getSnapshot == nil {
+		return nil
+	}
+	snapshot, err := getSnapshot()
+	if err != nil {
+		return err
+	}
+	return router.OutgoingRoute().Enqueue(snapshot)
+}
+
+// Subscriptions returns the notifications the listener registered with the
+// given router subscribed to, named after their notification messages, and
+// the amount of addresses it gets UTXOsChanged notifications for
+func (nm *NotificationManager) Subscriptions(router *routerpkg.Router) (subscriptions []string, utxosChangedAddressCount int) {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	listener, ok := nm.listeners[router]
+	if !ok {
+		return nil, 0
+	}
+	subscriptions = make([]string, 0)
+	for _, subscription := range []struct {
+		name         string
+		isSubscribed bool
+	}{
+		{"blockAdded", listener.propagateBlockAddedNotifications},
+		{"virtualSelectedParentChainChanged", listener.propagateVirtualSelectedParentChainChangedNotifications},
+		{"finalityConflict", listener.propagateFinalityConflictNotifications},
+		{"finalityConflictResolved", listener.propagateFinalityConflictResolvedNotifications},
+		{"utxosChanged", listener.propagateUTXOsChangedNotifications},
+		{"virtualSelectedParentBlueScoreChanged", listener.propagateVirtualSelectedParentBlueScoreChangedNotifications},
+		{"pruningPointUTXOSetOverride", listener.propagatePruningPointUTXOSetOverrideNotifications},
+		{"virtualDaaScoreChanged", listener.propagateVirtualDaaScoreChangedNotifications},
+		{"newBlockTemplate", listener.propagateNewBlockTemplateNotifications},
+		{"transactionEvicted", listener.propagateTransactionEvictedNotifications},
+		{"transactionRemovedFromMempool", listener.propagateTransactionRemovedFromMempoolNotifications},
+		{"transactionConfirmed", listener.propagateTransactionConfirmedNotifications},
+		{"transactionReplaced", listener.propagateTransactionReplacedNotifications},
+		{"peerEvent", listener.propagatePeerEventNotifications},
+		{"blueScoreReached", len(listener.pendingBlueScoreReachedNotifications) > 0},
+	} {
+		if subscription.isSubscribed {
+			subscriptions = append(subscriptions, subscription.name)
+		}
+	}
+	return subscriptions, len(listener.propagateUTXOsChangedNotificationAddresses)
+}
+
 // HasBlockAddedListeners indicates if the notification manager has any listeners for `BlockAdded` events
 func (nm *NotificationManager) HasBlockAddedListeners() bool {
 	nm.RLock()
@@ -98,11 +210,19 @@ func (nm *NotificationManager) HasBlockAddedListeners() bool {
 
 // NotifyBlockAdded notifies the notification manager that a block has been added to the DAG
 func (nm *NotificationManager) NotifyBlockAdded(notification *appmessage.BlockAddedNotificationMessage) error {
-	nm.RLock()
-	defer nm.RUnlock()
+	// Apply a write-lock since the pending notifications of the listeners are modified
+	nm.Lock()
+	defer nm.Unlock()
 
 	for router, listener := range nm.listeners {
 		if listener.propagateBlockAddedNotifications {
+			if listener.blockAddedCoalesceInterval > 0 {
+				if len(listener.pendingAddedBlocks) == 0 {
+					nm.flushAfter(listener.blockAddedCoalesceInterval, nm.flushBlockAddedNotifications(router, listener))
+				}
+				listener.pendingAddedBlocks = append(listener.pendingAddedBlocks, notification.Block)
+				continue
+			}
 			err := router.OutgoingRoute().MaybeEnqueue(notification)
 			if err != nil {
 				return err
@@ -116,8 +236,9 @@ func (nm *NotificationManager) NotifyBlockAdded(notification *appmessage.BlockAd
 func (nm *NotificationManager) NotifyVirtualSelectedParentChainChanged(
 	notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage) error {
 
-	nm.RLock()
-	defer nm.RUnlock()
+	// Apply a write-lock since the pending notifications of the listeners are modified
+	nm.Lock()
+	defer nm.Unlock()
 
 	notificationWithoutAcceptedTransactionIDs := &appmessage.VirtualSelectedParentChainChangedNotificationMessage{
 		RemovedChainBlockHashes: notification.RemovedChainBlockHashes,
@@ -126,14 +247,25 @@ func (nm *NotificationManager) NotifyVirtualSelectedParentChainChanged(
 
 	for router, listener := range nm.listeners {
 		if listener.propagateVirtualSelectedParentChainChangedNotifications {
-			var err error
-
+			listenerNotification := notificationWithoutAcceptedTransactionIDs
 			if listener.includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications {
-				err = router.OutgoingRoute().MaybeEnqueue(notification)
-			} else {
-				err = router.OutgoingRoute().MaybeEnqueue(notificationWithoutAcceptedTransactionIDs)
+				listenerNotification = notification
+			}
+
+			if listener.virtualSelectedParentChainChangedCoalesceInterval > 0 {
+				pendingNotification := listener.pendingVirtualSelectedParentChainChangedNotification
+				if pendingNotification == nil {
+					nm.flushAfter(listener.virtualSelectedParentChainChangedCoalesceInterval,
+						nm.flushVirtualSelectedParentChainChangedNotification(router, listener))
+					listener.pendingVirtualSelectedParentChainChangedNotification = listenerNotification
+				} else {
+					listener.pendingVirtualSelectedParentChainChangedNotification =
+						mergeVirtualSelectedParentChainChangedNotifications(pendingNotification, listenerNotification)
+				}
+				continue
 			}
 
+			err := router.OutgoingRoute().MaybeEnqueue(listenerNotification)
 			if err != nil {
 				return err
 			}
@@ -142,6 +274,52 @@ func (nm *NotificationManager) NotifyVirtualSelectedParentChainChanged(
 	return nil
 }
 
+// flushAfter calls flush once the given interval passes
+func (nm *NotificationManager) flushAfter(interval time.Duration, flush func() error) {
+	spawn("NotificationManager.flushAfter", func() {
+		time.Sleep(interval)
+		err := flush()
+		if err != nil {
+			panic(err)
+		}
+	})
+}
+
+// flushBlockAddedNotifications returns a function that sends the given
+// listener the blocks that were added since its coalesce interval started
+func (nm *NotificationManager) flushBlockAddedNotifications(router *routerpkg.Router, listener *NotificationListener) func() error {
+	return func() error {
+		nm.Lock()
+		defer nm.Unlock()
+
+		blocks := listener.pendingAddedBlocks
+		listener.pendingAddedBlocks = nil
+		if _, ok := nm.listeners[router]; !ok || len(blocks) == 0 {
+			return nil
+		}
+		return router.OutgoingRoute().MaybeEnqueue(appmessage.NewBlockAddedBatchNotificationMessage(blocks))
+	}
+}
+
+// flushVirtualSelectedParentChainChangedNotification returns a function that
+// sends the given listener the chain changes that happened since its coalesce
+// interval started, merged into a single notification
+func (nm *NotificationManager) flushVirtualSelectedParentChainChangedNotification(router *routerpkg.Router,
+	listener *NotificationListener) func() error {
+
+	return func() error {
+		nm.Lock()
+		defer nm.Unlock()
+
+		notification := listener.pendingVirtualSelectedParentChainChangedNotification
+		listener.pendingVirtualSelectedParentChainChangedNotification = nil
+		if _, ok := nm.listeners[router]; !ok || notification == nil {
+			return nil
+		}
+		return router.OutgoingRoute().MaybeEnqueue(notification)
+	}
+}
+
 // HasListenersThatPropagateVirtualSelectedParentChainChanged returns whether there's any listener that is
 // subscribed to VirtualSelectedParentChainChanged notifications as well as checks if any such listener requested
 // to include AcceptedTransactionIDs.
@@ -246,6 +424,70 @@ func (nm *NotificationManager) NotifyVirtualSelectedParentBlueScoreChanged(
 	return nil
 }
 
+// NotifyBlueScoreReached notifies the notification manager that the DAG's
+// virtual selected parent blue score has changed, and sends the scheduled
+// BlueScoreReached notifications whose target was reached
+func (nm *NotificationManager) NotifyBlueScoreReached(virtualSelectedParentBlueScore uint64) error {
+	// Apply a write-lock since the pending notifications of the listeners are modified
+	nm.Lock()
+	defer nm.Unlock()
+
+	nm.virtualSelectedParentBlueScore = virtualSelectedParentBlueScore
+	for router, listener := range nm.listeners {
+		if len(listener.pendingBlueScoreReachedNotifications) == 0 {
+			continue
+		}
+		remainingNotifications := listener.pendingBlueScoreReachedNotifications[:0]
+		for _, notification := range listener.pendingBlueScoreReachedNotifications {
+			if notification.TargetBlueScore > virtualSelectedParentBlueScore {
+				remainingNotifications = append(remainingNotifications, notification)
+				continue
+			}
+			notification.VirtualSelectedParentBlueScore = virtualSelectedParentBlueScore
+			err := router.OutgoingRoute().Enqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+		listener.pendingBlueScoreReachedNotifications = remainingNotifications
+	}
+	return nil
+}
+
+// ScheduleBlueScoreReachedNotification schedules the given notification to be
+// sent to the listener registered with the given router once the virtual
+// selected parent blue score reaches notification.TargetBlueScore. If it
+// already did, the notification is sent immediately.
+func (nm *NotificationManager) ScheduleBlueScoreReachedNotification(router *routerpkg.Router,
+	notification *appmessage.BlueScoreReachedNotificationMessage, virtualSelectedParentBlueScore uint64) error {
+
+	// Apply a write-lock since the pending notifications of the listener are modified
+	nm.Lock()
+	defer nm.Unlock()
+
+	listener, ok := nm.listeners[router]
+	if !ok {
+		return errors.Errorf("listener not found")
+	}
+
+	// The virtual might have changed since the caller got its selected
+	// parent blue score, in which case the scheduled notification would
+	// have been missed by NotifyBlueScoreReached
+	if nm.virtualSelectedParentBlueScore > virtualSelectedParentBlueScore {
+		virtualSelectedParentBlueScore = nm.virtualSelectedParentBlueScore
+	}
+	if notification.TargetBlueScore <= virtualSelectedParentBlueScore {
+		notification.VirtualSelectedParentBlueScore = virtualSelectedParentBlueScore
+		return router.OutgoingRoute().Enqueue(notification)
+	}
+
+	if len(listener.pendingBlueScoreReachedNotifications) >= maxPendingBlueScoreReachedNotifications {
+		return errors.WithStack(ErrTooManyPendingBlueScoreReachedNotifications)
+	}
+	listener.pendingBlueScoreReachedNotifications = append(listener.pendingBlueScoreReachedNotifications, notification)
+	return nil
+}
+
 // NotifyVirtualDaaScoreChanged notifies the notification manager that the DAG's
 // virtual DAA score has changed
 func (nm *NotificationManager) NotifyVirtualDaaScoreChanged(
@@ -266,7 +508,8 @@ func (nm *NotificationManager) NotifyVirtualDaaScoreChanged(
 }
 
 // NotifyNewBlockTemplate notifies the notification manager that a new
-// block template is available for miners
+// block template is available for miners. The notification is sent only to
+// the listeners whose notifications don't carry the templates.
 func (nm *NotificationManager) NotifyNewBlockTemplate(
 	notification *appmessage.NewBlockTemplateNotificationMessage) error {
 
@@ -274,7 +517,143 @@ func (nm *NotificationManager) NotifyNewBlockTemplate(
 	defer nm.RUnlock()
 
 	for router, listener := range nm.listeners {
-		if listener.propagateNewBlockTemplateNotifications {
+		if listener.propagateNewBlockTemplateNotifications && listener.newBlockTemplateRequest == nil {
+			err := router.OutgoingRoute().Enqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NewBlockTemplateRequests returns the distinct block template requests of
+// the listeners whose new block template notifications carry the templates
+func (nm *NotificationManager) NewBlockTemplateRequests() []BlockTemplateRequest {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	requestSet := make(map[BlockTemplateRequest]struct{})
+	var requests []BlockTemplateRequest
+	for _, listener := range nm.listeners {
+		if !listener.propagateNewBlockTemplateNotifications || listener.newBlockTemplateRequest == nil {
+			continue
+		}
+		request := *listener.newBlockTemplateRequest
+		if _, ok := requestSet[request]; ok {
+			continue
+		}
+		requestSet[request] = struct{}{}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// NotifyNewBlockTemplateWithTemplate sends the given notification, which
+// carries a template, to the listeners of the given block template request
+func (nm *NotificationManager) NotifyNewBlockTemplateWithTemplate(request BlockTemplateRequest,
+	notification *appmessage.NewBlockTemplateNotificationMessage) error {
+
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for router, listener := range nm.listeners {
+		if listener.propagateNewBlockTemplateNotifications && listener.newBlockTemplateRequest != nil &&
+			*listener.newBlockTemplateRequest == request {
+
+			err := router.OutgoingRoute().Enqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NotifyTransactionEvicted notifies the notification manager that a transaction
+// was evicted from the mempool
+func (nm *NotificationManager) NotifyTransactionEvicted(
+	notification *appmessage.TransactionEvictedNotificationMessage) error {
+
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for router, listener := range nm.listeners {
+		if listener.propagateTransactionEvictedNotifications {
+			err := router.OutgoingRoute().Enqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NotifyTransactionRemovedFromMempool notifies the notification manager that a
+// transaction was removed from the mempool
+func (nm *NotificationManager) NotifyTransactionRemovedFromMempool(
+	notification *appmessage.TransactionRemovedFromMempoolNotificationMessage) error {
+
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for router, listener := range nm.listeners {
+		if listener.propagateTransactionRemovedFromMempoolNotifications {
+			err := router.OutgoingRoute().Enqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NotifyTransactionConfirmed notifies the notification manager that a mempool
+// transaction was included in a block
+func (nm *NotificationManager) NotifyTransactionConfirmed(
+	notification *appmessage.TransactionConfirmedNotificationMessage) error {
+
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for router, listener := range nm.listeners {
+		if listener.propagateTransactionConfirmedNotifications {
+			err := router.OutgoingRoute().Enqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NotifyTransactionReplaced notifies the notification manager that a mempool
+// transaction was replaced by a transaction paying a higher fee
+func (nm *NotificationManager) NotifyTransactionReplaced(
+	notification *appmessage.TransactionReplacedNotificationMessage) error {
+
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for router, listener := range nm.listeners {
+		if listener.propagateTransactionReplacedNotifications {
+			err := router.OutgoingRoute().Enqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NotifyPeerEvent notifies the notification manager that a P2P connection
+// went through a step of its lifecycle
+func (nm *NotificationManager) NotifyPeerEvent(notification *appmessage.PeerEventNotificationMessage) error {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for router, listener := range nm.listeners {
+		if listener.propagatePeerEventNotifications {
 			err := router.OutgoingRoute().Enqueue(notification)
 			if err != nil {
 				return err
@@ -312,6 +691,11 @@ func newNotificationListener(params *dagconfig.Params) *NotificationListener {
 		propagateUTXOsChangedNotifications:                          false,
 		propagateVirtualSelectedParentBlueScoreChangedNotifications: false,
 		propagateNewBlockTemplateNotifications:                      false,
+		propagateTransactionEvictedNotifications:                    false,
+		propagateTransactionRemovedFromMempoolNotifications:         false,
+		propagateTransactionConfirmedNotifications:                  false,
+		propagateTransactionReplacedNotifications:                   false,
+		propagatePeerEventNotifications:                             false,
 		propagatePruningPointUTXOSetOverrideNotifications:           false,
 	}
 }
@@ -323,16 +707,21 @@ func (nl *NotificationListener) IncludeAcceptedTransactionIDsInVirtualSelectedPa
 }
 
 // PropagateBlockAddedNotifications instructs the listener to send block added notifications
-// to the remote listener
-func (nl *NotificationListener) PropagateBlockAddedNotifications() {
+// to the remote listener. If coalesceInterval isn't zero, the blocks that are added within
+// it are sent together.
+func (nl *NotificationListener) PropagateBlockAddedNotifications(coalesceInterval time.Duration) {
 	nl.propagateBlockAddedNotifications = true
+	nl.blockAddedCoalesceInterval = coalesceInterval
 }
 
 // PropagateVirtualSelectedParentChainChangedNotifications instructs the listener to send chain changed notifications
-// to the remote listener
-func (nl *NotificationListener) PropagateVirtualSelectedParentChainChangedNotifications(includeAcceptedTransactionIDs bool) {
+// to the remote listener. If coalesceInterval isn't zero, the chain changes that happen within it are merged.
+func (nl *NotificationListener) PropagateVirtualSelectedParentChainChangedNotifications(includeAcceptedTransactionIDs bool,
+	coalesceInterval time.Duration) {
+
 	nl.propagateVirtualSelectedParentChainChangedNotifications = true
 	nl.includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications = includeAcceptedTransactionIDs
+	nl.virtualSelectedParentChainChangedCoalesceInterval = coalesceInterval
 }
 
 // PropagateFinalityConflictNotifications instructs the listener to send finality conflict notifications
@@ -476,6 +865,45 @@ func (nl *NotificationListener) PropagateVirtualDaaScoreChangedNotifications() {
 // new block template notifications to the remote listener
 func (nl *NotificationListener) PropagateNewBlockTemplateNotifications() {
 	nl.propagateNewBlockTemplateNotifications = true
+	nl.newBlockTemplateRequest = nil
+}
+
+// PropagateNewBlockTemplateNotificationsWithTemplates instructs the listener
+// to send new block template notifications that carry the templates of the
+// given request to the remote listener
+func (nl *NotificationListener) PropagateNewBlockTemplateNotificationsWithTemplates(request BlockTemplateRequest) {
+	nl.propagateNewBlockTemplateNotifications = true
+	nl.newBlockTemplateRequest = &request
+}
+
+// PropagateTransactionEvictedNotifications instructs the listener to send
+// transaction evicted notifications to the remote listener
+func (nl *NotificationListener) PropagateTransactionEvictedNotifications() {
+	nl.propagateTransactionEvictedNotifications = true
+}
+
+// PropagateTransactionRemovedFromMempoolNotifications instructs the listener to
+// send transaction removed from mempool notifications to the remote listener
+func (nl *NotificationListener) PropagateTransactionRemovedFromMempoolNotifications() {
+	nl.propagateTransactionRemovedFromMempoolNotifications = true
+}
+
+// PropagateTransactionConfirmedNotifications instructs the listener to send
+// transaction confirmed notifications to the remote listener
+func (nl *NotificationListener) PropagateTransactionConfirmedNotifications() {
+	nl.propagateTransactionConfirmedNotifications = true
+}
+
+// PropagateTransactionReplacedNotifications instructs the listener to send
+// transaction replaced notifications to the remote listener
+func (nl *NotificationListener) PropagateTransactionReplacedNotifications() {
+	nl.propagateTransactionReplacedNotifications = true
+}
+
+// PropagatePeerEventNotifications instructs the listener to send peer event
+// notifications to the remote listener
+func (nl *NotificationListener) PropagatePeerEventNotifications() {
+	nl.propagatePeerEventNotifications = true
 }
 
 // PropagatePruningPointUTXOSetOverrideNotifications instructs the listener to send pruning point UTXO set override notifications
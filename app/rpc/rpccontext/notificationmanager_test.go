@@ -0,0 +1,125 @@
+package rpccontext
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+func TestNotificationSnapshotAndCoalescing(t *testing.T) {
+	notificationManager := NewNotificationManager(&dagconfig.SimnetParams)
+	router := routerpkg.NewRouter("test")
+	notificationManager.AddListener(router)
+
+	snapshot := appmessage.NewDAGSnapshotNotificationMessage([]string{"tip"}, "tip", 1, 2)
+	err := notificationManager.Subscribe(router, func(listener *NotificationListener) {
+		listener.PropagateBlockAddedNotifications(50 * time.Millisecond)
+	}, func() (*appmessage.DAGSnapshotNotificationMessage, error) {
+		return snapshot, nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %+v", err)
+	}
+
+	const blockCount = 3
+	for i := 0; i < blockCount; i++ {
+		err := notificationManager.NotifyBlockAdded(appmessage.NewBlockAddedNotificationMessage(&appmessage.RPCBlock{}))
+		if err != nil {
+			t.Fatalf("NotifyBlockAdded: %+v", err)
+		}
+	}
+
+	message, err := router.OutgoingRoute().DequeueWithTimeout(time.Second)
+	if err != nil {
+		t.Fatalf("DequeueWithTimeout: %+v", err)
+	}
+	if message != snapshot {
+		t.Fatalf("Expected the snapshot to be sent first, but got %s", message.Command())
+	}
+
+	message, err = router.OutgoingRoute().DequeueWithTimeout(time.Second)
+	if err != nil {
+		t.Fatalf("DequeueWithTimeout: %+v", err)
+	}
+	batch, ok := message.(*appmessage.BlockAddedBatchNotificationMessage)
+	if !ok || len(batch.Blocks) != blockCount {
+		t.Fatalf("Expected a batch of %d blocks, but got %+v", blockCount, message)
+	}
+
+	// Nothing is sent once the batch is flushed
+	_, err = router.OutgoingRoute().DequeueWithTimeout(100 * time.Millisecond)
+	if err == nil {
+		t.Fatalf("Expected no more notifications")
+	}
+}
+
+func TestNewBlockTemplateNotificationsWithTemplates(t *testing.T) {
+	notificationManager := NewNotificationManager(&dagconfig.SimnetParams)
+	plainRouter := routerpkg.NewRouter("plain")
+	templateRouter := routerpkg.NewRouter("template")
+	otherTemplateRouter := routerpkg.NewRouter("other template")
+	for _, router := range []*routerpkg.Router{plainRouter, templateRouter, otherTemplateRouter} {
+		notificationManager.AddListener(router)
+	}
+
+	request := BlockTemplateRequest{PayAddress: "address", ExtraData: "extra data"}
+	otherRequest := BlockTemplateRequest{PayAddress: "other address"}
+	for router, subscribe := range map[*routerpkg.Router]func(listener *NotificationListener){
+		plainRouter: func(listener *NotificationListener) { listener.PropagateNewBlockTemplateNotifications() },
+		templateRouter: func(listener *NotificationListener) {
+			listener.PropagateNewBlockTemplateNotificationsWithTemplates(request)
+		},
+		otherTemplateRouter: func(listener *NotificationListener) {
+			listener.PropagateNewBlockTemplateNotificationsWithTemplates(otherRequest)
+		},
+	} {
+		err := notificationManager.Subscribe(router, subscribe, nil)
+		if err != nil {
+			t.Fatalf("Subscribe: %+v", err)
+		}
+	}
+
+	requests := notificationManager.NewBlockTemplateRequests()
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 block template requests, but got %d", len(requests))
+	}
+
+	plainNotification := appmessage.NewNewBlockTemplateNotificationMessage()
+	err := notificationManager.NotifyNewBlockTemplate(plainNotification)
+	if err != nil {
+		t.Fatalf("NotifyNewBlockTemplate: %+v", err)
+	}
+	templateNotification := appmessage.NewNewBlockTemplateNotificationMessageWithTemplate(&appmessage.RPCBlock{}, true)
+	err = notificationManager.NotifyNewBlockTemplateWithTemplate(request, templateNotification)
+	if err != nil {
+		t.Fatalf("NotifyNewBlockTemplateWithTemplate: %+v", err)
+	}
+
+	// Every listener gets only the notifications of its own kind
+	for router, expectedNotification := range map[*routerpkg.Router]appmessage.Message{
+		plainRouter:         plainNotification,
+		templateRouter:      templateNotification,
+		otherTemplateRouter: nil,
+	} {
+		message, err := router.OutgoingRoute().DequeueWithTimeout(100 * time.Millisecond)
+		if expectedNotification == nil {
+			if err == nil {
+				t.Fatalf("Expected no notification, but got %s", message.Command())
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("DequeueWithTimeout: %+v", err)
+		}
+		if message != expectedNotification {
+			t.Fatalf("Got an unexpected notification %+v", message)
+		}
+		_, err = router.OutgoingRoute().DequeueWithTimeout(100 * time.Millisecond)
+		if err == nil {
+			t.Fatalf("Expected no more notifications")
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package rpccontext
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+)
+
+// Index statuses, as reported by the GetIndexStatus and SetIndexEnabled commands
+const (
+	IndexStatusDisabled = "disabled"
+	IndexStatusUnloaded = "unloaded"
+	IndexStatusBuilding = "building"
+	IndexStatusReady    = "ready"
+	IndexStatusFailed   = "failed"
+)
+
+// ChainIndex is an optional index that follows the virtual selected parent chain
+type ChainIndex interface {
+	indexretention.Index
+	Update(chainChanges *externalapi.SelectedChainPath) error
+	Reset() error
+}
+
+// OptionalIndex is a ChainIndex that isn't loaded before it's first used,
+// so that a node with many optional indexes starts quickly. It may also be
+// enabled and disabled at runtime, in which case it's built in the background.
+//
+// Disabling an index keeps its data, so that once it's enabled again it only
+// has to catch up with the chain changes it had missed meanwhile.
+type OptionalIndex struct {
+	name string
+	load func() (ChainIndex, error)
+
+	lock      sync.Mutex
+	enabled   bool
+	index     ChainIndex
+	buildDone chan struct{}
+	buildErr  error
+}
+
+// NewOptionalIndex returns a new OptionalIndex that is built by the given
+// function once it's needed. name is the name of the option that enables the
+// index, e.g. stxoindex.
+func NewOptionalIndex(name string, enabled bool, load func() (ChainIndex, error)) *OptionalIndex {
+	return &OptionalIndex{
+		name:    name,
+		enabled: enabled,
+		load:    load,
+	}
+}
+
+// Name returns the name of the index
+func (oi *OptionalIndex) Name() string {
+	return oi.name
+}
+
+// IsEnabled returns whether the index is enabled
+func (oi *OptionalIndex) IsEnabled() bool {
+	oi.lock.Lock()
+	defer oi.lock.Unlock()
+
+	return oi.enabled
+}
+
+// Status returns the status of the index, and the error the last attempt to
+// build it failed with, if its status is IndexStatusFailed
+func (oi *OptionalIndex) Status() (status string, buildErr error) {
+	oi.lock.Lock()
+	defer oi.lock.Unlock()
+
+	switch {
+	case !oi.enabled:
+		return IndexStatusDisabled, nil
+	case oi.index != nil:
+		return IndexStatusReady, nil
+	case oi.buildDone != nil:
+		return IndexStatusBuilding, nil
+	case oi.buildErr != nil:
+		return IndexStatusFailed, oi.buildErr
+	default:
+		return IndexStatusUnloaded, nil
+	}
+}
+
+// Get returns the index, loading it first if it isn't loaded yet. It returns
+// an RPC error if the index is disabled, is being built in the background
+// after it was enabled at runtime, or failed to load.
+func (oi *OptionalIndex) Get() (ChainIndex, *appmessage.RPCError) {
+	oi.lock.Lock()
+	if !oi.enabled {
+		oi.lock.Unlock()
+		return nil, appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable,
+			"Method unavailable when the %[1]s is disabled. Enable it with --%[1]s or with SetIndexEnabled", oi.name)
+	}
+	if oi.index != nil {
+		defer oi.lock.Unlock()
+		return oi.index, nil
+	}
+	if oi.buildDone != nil {
+		oi.lock.Unlock()
+		return nil, appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable,
+			"Method unavailable while the %s is being built", oi.name)
+	}
+	buildDone := oi.startBuildingNoLock()
+	oi.lock.Unlock()
+
+	<-buildDone
+
+	oi.lock.Lock()
+	defer oi.lock.Unlock()
+	if oi.index == nil {
+		if oi.buildErr != nil {
+			return nil, appmessage.RPCErrorf(appmessage.RPCErrorCodeInternal,
+				"Failed to load the %s: %s", oi.name, oi.buildErr)
+		}
+		return nil, appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable,
+			"Method unavailable since the %s was disabled while it was loading", oi.name)
+	}
+	return oi.index, nil
+}
+
+// Loaded returns the index if it's loaded, or nil otherwise
+func (oi *OptionalIndex) Loaded() ChainIndex {
+	oi.lock.Lock()
+	defer oi.lock.Unlock()
+
+	return oi.index
+}
+
+// SetEnabled enables or disables the index. An index that gets enabled is
+// built in the background.
+func (oi *OptionalIndex) SetEnabled(enabled bool) {
+	oi.lock.Lock()
+	defer oi.lock.Unlock()
+
+	if oi.enabled == enabled {
+		return
+	}
+	oi.enabled = enabled
+	if !enabled {
+		oi.index = nil
+		log.Infof("Disabled the %s", oi.name)
+		return
+	}
+	oi.buildErr = nil
+	if oi.buildDone == nil {
+		oi.startBuildingNoLock()
+	}
+}
+
+// startBuildingNoLock starts loading the index in the background, and returns
+// a channel that is closed once it's done
+func (oi *OptionalIndex) startBuildingNoLock() <-chan struct{} {
+	buildDone := make(chan struct{})
+	oi.buildDone = buildDone
+	log.Infof("Loading the %s", oi.name)
+
+	spawn("OptionalIndex.build", func() {
+		index, err := oi.load()
+
+		oi.lock.Lock()
+		defer oi.lock.Unlock()
+		oi.buildDone = nil
+		close(buildDone)
+		if err != nil {
+			log.Errorf("Error loading the %s: %+v", oi.name, err)
+			oi.buildErr = err
+			return
+		}
+		if !oi.enabled {
+			return
+		}
+		oi.index = index
+		log.Infof("The %s is ready", oi.name)
+	})
+	return buildDone
+}
+
+// Update updates the index with the given chain changes, if it's loaded.
+// An index that isn't loaded catches up with them once it's loaded.
+func (oi *OptionalIndex) Update(chainChanges *externalapi.SelectedChainPath) error {
+	index := oi.Loaded()
+	if index == nil {
+		return nil
+	}
+	return index.Update(chainChanges)
+}
+
+// Reset resets the index, if it's loaded. An index that isn't loaded is
+// reset once it's loaded if it can't be brought in sync otherwise.
+func (oi *OptionalIndex) Reset() error {
+	index := oi.Loaded()
+	if index == nil {
+		return nil
+	}
+	return index.Reset()
+}
+
+// ChainBlockTracker implements indexretention.Index. It loads the index if it
+// isn't loaded yet, since its retention limits are kept regardless of whether
+// it's used. It returns nil if the index is disabled or unavailable, in which
+// case it's not pruned.
+func (oi *OptionalIndex) ChainBlockTracker() *indexretention.ChainBlockTracker {
+	index, rpcError := oi.Get()
+	if rpcError != nil {
+		log.Debugf("Not pruning the %s: %s", oi.name, rpcError.Message)
+		return nil
+	}
+	return index.ChainBlockTracker()
+}
+
+// VirtualSelectedParentBlueScore implements indexretention.Index
+func (oi *OptionalIndex) VirtualSelectedParentBlueScore() (uint64, error) {
+	index := oi.Loaded()
+	if index == nil {
+		return 0, nil
+	}
+	return index.VirtualSelectedParentBlueScore()
+}
+
+// PruneChainBlocks implements indexretention.Index
+func (oi *OptionalIndex) PruneChainBlocks(chainBlocks []*indexretention.TrackedChainBlock) error {
+	index := oi.Loaded()
+	if index == nil {
+		return nil
+	}
+	return index.PruneChainBlocks(chainBlocks)
+}
+
+// OptionalIndexes returns all the optional indexes
+func (ctx *Context) OptionalIndexes() []*OptionalIndex {
+	return []*OptionalIndex{ctx.STXOIndex, ctx.ScriptClassIndex, ctx.FeeHistoryIndex, ctx.CoinAgeIndex, ctx.TXIndex, ctx.AddrIndex}
+}
+
+// OptionalIndexByName returns the optional index with the given name, or nil
+// if there's none
+func (ctx *Context) OptionalIndexByName(name string) *OptionalIndex {
+	for _, index := range ctx.OptionalIndexes() {
+		if index.Name() == name {
+			return index
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package rpccontext
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/pkg/errors"
+)
+
+type fakeChainIndex struct {
+	updates int
+}
+
+func (f *fakeChainIndex) Update(*externalapi.SelectedChainPath) error {
+	f.updates++
+	return nil
+}
+
+func (f *fakeChainIndex) Reset() error { return nil }
+
+func (f *fakeChainIndex) ChainBlockTracker() *indexretention.ChainBlockTracker { return nil }
+
+func (f *fakeChainIndex) VirtualSelectedParentBlueScore() (uint64, error) { return 0, nil }
+
+func (f *fakeChainIndex) PruneChainBlocks([]*indexretention.TrackedChainBlock) error { return nil }
+
+func waitForIndexStatus(t *testing.T, index *OptionalIndex, expectedStatus string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		status, _ := index.Status()
+		if status == expectedStatus {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the index to become %s, but it's %s", expectedStatus, status)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestOptionalIndexLoadsOnFirstUse(t *testing.T) {
+	loads := 0
+	chainIndex := &fakeChainIndex{}
+	index := NewOptionalIndex("testindex", true, func() (ChainIndex, error) {
+		loads++
+		return chainIndex, nil
+	})
+
+	status, _ := index.Status()
+	if status != IndexStatusUnloaded {
+		t.Fatalf("expected the index to start %s, but it's %s", IndexStatusUnloaded, status)
+	}
+
+	// Chain changes that arrive before the index is loaded are left for it to catch up with
+	err := index.Update(&externalapi.SelectedChainPath{})
+	if err != nil {
+		t.Fatalf("Update: %+v", err)
+	}
+	if loads != 0 {
+		t.Fatalf("expected Update not to load the index")
+	}
+
+	for i := 0; i < 2; i++ {
+		loaded, rpcError := index.Get()
+		if rpcError != nil {
+			t.Fatalf("Get: %s", rpcError)
+		}
+		if loaded != chainIndex {
+			t.Fatalf("Get returned an unexpected index")
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("expected the index to be loaded once, but it was loaded %d times", loads)
+	}
+	status, _ = index.Status()
+	if status != IndexStatusReady {
+		t.Fatalf("expected the index to be %s, but it's %s", IndexStatusReady, status)
+	}
+
+	err = index.Update(&externalapi.SelectedChainPath{})
+	if err != nil {
+		t.Fatalf("Update: %+v", err)
+	}
+	if chainIndex.updates != 1 {
+		t.Fatalf("expected the loaded index to be updated once, but it was updated %d times", chainIndex.updates)
+	}
+}
+
+func TestOptionalIndexSetEnabled(t *testing.T) {
+	release := make(chan struct{})
+	index := NewOptionalIndex("testindex", false, func() (ChainIndex, error) {
+		<-release
+		return &fakeChainIndex{}, nil
+	})
+
+	_, rpcError := index.Get()
+	if rpcError == nil || rpcError.Code != appmessage.RPCErrorCodeMethodUnavailable {
+		t.Fatalf("expected a disabled index to be unavailable, but got %v", rpcError)
+	}
+
+	index.SetEnabled(true)
+	status, _ := index.Status()
+	if status != IndexStatusBuilding {
+		t.Fatalf("expected an enabled index to be %s, but it's %s", IndexStatusBuilding, status)
+	}
+	_, rpcError = index.Get()
+	if rpcError == nil || rpcError.Code != appmessage.RPCErrorCodeMethodUnavailable {
+		t.Fatalf("expected an index that is being built to be unavailable, but got %v", rpcError)
+	}
+
+	close(release)
+	waitForIndexStatus(t, index, IndexStatusReady)
+
+	index.SetEnabled(false)
+	if index.Loaded() != nil {
+		t.Fatalf("expected a disabled index to be dropped")
+	}
+	status, _ = index.Status()
+	if status != IndexStatusDisabled {
+		t.Fatalf("expected the index to be %s, but it's %s", IndexStatusDisabled, status)
+	}
+}
+
+func TestOptionalIndexLoadFailure(t *testing.T) {
+	errLoad := errors.New("load error")
+	index := NewOptionalIndex("testindex", true, func() (ChainIndex, error) {
+		return nil, errLoad
+	})
+
+	_, rpcError := index.Get()
+	if rpcError == nil || rpcError.Code != appmessage.RPCErrorCodeInternal {
+		t.Fatalf("expected an internal error, but got %v", rpcError)
+	}
+	status, buildErr := index.Status()
+	if status != IndexStatusFailed || !errors.Is(buildErr, errLoad) {
+		t.Fatalf("expected the index to be %s with the load error, but it's %s with %v",
+			IndexStatusFailed, status, buildErr)
+	}
+	if index.ChainBlockTracker() != nil {
+		t.Fatalf("expected an index that failed to load to have no chain block tracker")
+	}
+}
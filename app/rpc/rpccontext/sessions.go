@@ -0,0 +1,98 @@
+package rpccontext
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// RPCSession is an RPC client that's connected to the node
+type RPCSession struct {
+	ID            uint64
+	Router        *routerpkg.Router
+	NetConnection *netadapter.NetConnection
+	ConnectedAt   time.Time
+}
+
+// RPCSessions keeps the RPC connections that are currently open
+type RPCSessions struct {
+	sessions map[*routerpkg.Router]*RPCSession
+	nextID   uint64
+	lock     sync.RWMutex
+}
+
+// NewRPCSessions creates a new RPCSessions
+func NewRPCSessions() *RPCSessions {
+	return &RPCSessions{
+		sessions: make(map[*routerpkg.Router]*RPCSession),
+		nextID:   1,
+	}
+}
+
+// Add starts a session for the connection with the given router. Session IDs
+// are never reused, so that disconnecting a session by ID doesn't disconnect
+// a client that connected after it.
+func (s *RPCSessions) Add(router *routerpkg.Router, netConnection *netadapter.NetConnection) *RPCSession {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	session := &RPCSession{
+		ID:            s.nextID,
+		Router:        router,
+		NetConnection: netConnection,
+		ConnectedAt:   time.Now(),
+	}
+	s.nextID++
+	s.sessions[router] = session
+	return session
+}
+
+// Remove ends the session of the connection with the given router.
+// It's called once the connection closes.
+func (s *RPCSessions) Remove(router *routerpkg.Router) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.sessions, router)
+}
+
+// ByID returns the session with the given ID, if it's still open
+func (s *RPCSessions) ByID(id uint64) (*RPCSession, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, session := range s.sessions {
+		if session.ID == id {
+			return session, true
+		}
+	}
+	return nil, false
+}
+
+// ByRouter returns the session of the connection with the given router, if
+// it's still open
+func (s *RPCSessions) ByRouter(router *routerpkg.Router) (*RPCSession, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	session, ok := s.sessions[router]
+	return session, ok
+}
+
+// All returns all open sessions, ordered by the time they started
+func (s *RPCSessions) All() []*RPCSession {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	sessions := make([]*RPCSession, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions
+}
@@ -0,0 +1,64 @@
+package rpccontext
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+// maxTipReceiveTimes is the maximum amount of receive times kept at once.
+// Beyond it, the oldest ones are evicted first.
+const maxTipReceiveTimes = 10_000
+
+// TipReceiveTimes keeps the times at which the blocks that are currently tips
+// of the DAG were added to it. Only blocks added since the node started are
+// known.
+type TipReceiveTimes struct {
+	receiveTimes map[externalapi.DomainHash]time.Time
+	lock         sync.Mutex
+}
+
+// NewTipReceiveTimes creates a new TipReceiveTimes
+func NewTipReceiveTimes() *TipReceiveTimes {
+	return &TipReceiveTimes{
+		receiveTimes: make(map[externalapi.DomainHash]time.Time),
+	}
+}
+
+// OnBlockAdded records that the given block was just added to the DAG. Its
+// parents are no longer tips, so they're forgotten.
+func (t *TipReceiveTimes) OnBlockAdded(block *externalapi.DomainBlock) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, parent := range block.Header.DirectParents() {
+		delete(t.receiveTimes, *parent)
+	}
+	if len(t.receiveTimes) >= maxTipReceiveTimes {
+		t.evictOldest()
+	}
+	t.receiveTimes[*consensushashing.BlockHash(block)] = time.Now()
+}
+
+func (t *TipReceiveTimes) evictOldest() {
+	var oldestHash externalapi.DomainHash
+	var oldestTime time.Time
+	for hash, receiveTime := range t.receiveTimes {
+		if oldestTime.IsZero() || receiveTime.Before(oldestTime) {
+			oldestHash, oldestTime = hash, receiveTime
+		}
+	}
+	delete(t.receiveTimes, oldestHash)
+}
+
+// ReceiveTime returns the time the given tip was added to the DAG, if it's
+// known
+func (t *TipReceiveTimes) ReceiveTime(tipHash *externalapi.DomainHash) (receiveTime time.Time, ok bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	receiveTime, ok = t.receiveTimes[*tipHash]
+	return receiveTime, ok
+}
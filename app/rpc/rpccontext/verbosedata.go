@@ -96,15 +96,34 @@ func (ctx *Context) PopulateBlockWithVerboseData(block *appmessage.RPCBlock, dom
 	transactionIDs := make([]string, len(domainBlock.Transactions))
 	for i, transaction := range domainBlock.Transactions {
 		transactionIDs[i] = consensushashing.TransactionID(transaction).String()
+		ctx.Domain.Consensus().PopulateMass(transaction)
+		block.VerboseData.TotalMass += transaction.Mass
 	}
 	block.VerboseData.TransactionIDs = transactionIDs
 
+	blockAcceptanceData, areFeesKnown, err := ctx.blockAcceptanceData(blockHash)
+	if err != nil {
+		return err
+	}
+	block.VerboseData.AreFeesKnown = areFeesKnown
+	if areFeesKnown {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if transactionAcceptanceData.IsAccepted {
+				block.VerboseData.TotalFees += transactionAcceptanceData.Fee
+			}
+		}
+	}
+
 	if includeTransactionVerboseData {
-		for _, transaction := range block.Transactions {
+		for i, transaction := range block.Transactions {
 			err := ctx.PopulateTransactionWithVerboseData(transaction, domainBlockHeader)
 			if err != nil {
 				return err
 			}
+			if areFeesKnown && blockAcceptanceData.TransactionAcceptanceData[i].IsAccepted {
+				transaction.VerboseData.Fee = blockAcceptanceData.TransactionAcceptanceData[i].Fee
+				transaction.VerboseData.IsFeeKnown = true
+			}
 		}
 	}
 
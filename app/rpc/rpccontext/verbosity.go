@@ -0,0 +1,82 @@
+package rpccontext
+
+import (
+	"encoding/hex"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+)
+
+// HashesOnlyBlock returns the given block as it's returned with
+// RPCVerbosityHashesOnly: only its hash and the IDs of its transactions
+func HashesOnlyBlock(block *externalapi.DomainBlock) *appmessage.RPCBlock {
+	transactionIDs := make([]string, len(block.Transactions))
+	for i, transaction := range block.Transactions {
+		transactionIDs[i] = consensushashing.TransactionID(transaction).String()
+	}
+	return &appmessage.RPCBlock{
+		VerboseData: &appmessage.RPCBlockVerboseData{
+			Hash:           consensushashing.BlockHash(block).String(),
+			TransactionIDs: transactionIDs,
+		},
+	}
+}
+
+// ApplyTransactionVerbosity returns the given transaction, whose verbose data
+// is populated, with the fields that the given verbosity selects
+func (ctx *Context) ApplyTransactionVerbosity(transaction *appmessage.RPCTransaction,
+	verbosity appmessage.RPCVerbosity) (*appmessage.RPCTransaction, error) {
+
+	switch verbosity {
+	case appmessage.RPCVerbosityHashesOnly:
+		return &appmessage.RPCTransaction{
+			VerboseData: &appmessage.RPCTransactionVerboseData{
+				TransactionID: transaction.VerboseData.TransactionID,
+				Hash:          transaction.VerboseData.Hash,
+			},
+		}, nil
+	case appmessage.RPCVerbosityHeadersOnly:
+		headerOnlyTransaction := *transaction
+		headerOnlyTransaction.Inputs = nil
+		headerOnlyTransaction.Outputs = nil
+		return &headerOnlyTransaction, nil
+	case appmessage.RPCVerbosityFull:
+		err := ctx.PopulateTransactionWithDecodedScripts(transaction)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return transaction, nil
+}
+
+// PopulateTransactionWithDecodedScripts adds the disassembly of the scripts
+// of the given transaction to the verbose data of its inputs and outputs,
+// which must already be populated
+func (ctx *Context) PopulateTransactionWithDecodedScripts(transaction *appmessage.RPCTransaction) error {
+	for _, input := range transaction.Inputs {
+		signatureScript, err := hex.DecodeString(input.SignatureScript)
+		if err != nil {
+			return err
+		}
+		input.VerboseData.SignatureScriptAsm = disassembleScript(constants.MaxScriptPublicKeyVersion, signatureScript)
+	}
+	for _, output := range transaction.Outputs {
+		scriptPublicKey, err := hex.DecodeString(output.ScriptPublicKey.Script)
+		if err != nil {
+			return err
+		}
+		output.VerboseData.ScriptPublicKeyAsm = disassembleScript(output.ScriptPublicKey.Version, scriptPublicKey)
+	}
+	return nil
+}
+
+// disassembleScript returns the disassembly of the given script. Scripts that
+// fail to parse are disassembled up to the failure, followed by "[error]".
+func disassembleScript(version uint16, script []byte) string {
+	// Ignore the error since it's already reflected in the disassembly
+	disassembly, _ := txscript.DisasmString(version, script)
+	return disassembly
+}
@@ -13,7 +13,8 @@ func HandleAddPeer(context *rpccontext.Context, _ *router.Router, request appmes
 		log.Warn("AddPeer RPC command called while node in safe RPC mode -- ignoring.")
 		response := appmessage.NewAddPeerResponseMessage()
 		response.Error =
-			appmessage.RPCErrorf("AddPeer RPC command called while node in safe RPC mode")
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"AddPeer RPC command called while node in safe RPC mode")
 		return response, nil
 	}
 
@@ -21,7 +22,7 @@ func HandleAddPeer(context *rpccontext.Context, _ *router.Router, request appmes
 	address, err := network.NormalizeAddress(AddPeerRequest.Address, context.Config.ActiveNetParams.DefaultPort)
 	if err != nil {
 		errorMessage := &appmessage.AddPeerResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Could not parse address: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not parse address: %s", err)
 		return errorMessage, nil
 	}
 
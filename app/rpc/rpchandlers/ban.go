@@ -13,7 +13,8 @@ func HandleBan(context *rpccontext.Context, _ *router.Router, request appmessage
 		log.Warn("Ban RPC command called while node in safe RPC mode -- ignoring.")
 		response := appmessage.NewBanResponseMessage()
 		response.Error =
-			appmessage.RPCErrorf("Ban RPC command called while node in safe RPC mode")
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"Ban RPC command called while node in safe RPC mode")
 		return response, nil
 	}
 
@@ -25,14 +26,15 @@ func HandleBan(context *rpccontext.Context, _ *router.Router, request appmessage
 			hint = " (try to remove “[” and “]” symbols)"
 		}
 		errorMessage := &appmessage.BanResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Could not parse IP%s: %s", hint, banRequest.IP)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not parse IP%s: %s",
+			hint, banRequest.IP)
 		return errorMessage, nil
 	}
 
-	err := context.ConnectionManager.BanByIP(ip)
+	err := context.ConnectionManager.BanByIP(ip, "banned over RPC")
 	if err != nil {
 		errorMessage := &appmessage.BanResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Could not ban IP: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not ban IP: %s", err)
 		return errorMessage, nil
 	}
 	response := appmessage.NewBanResponseMessage()
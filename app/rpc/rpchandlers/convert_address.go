@@ -0,0 +1,66 @@
+package rpchandlers
+
+import (
+	"encoding/hex"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// HandleConvertAddress handles the respectively named RPC command
+func HandleConvertAddress(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	convertAddressRequest := request.(*appmessage.ConvertAddressRequestMessage)
+	if (convertAddressRequest.Address == "") == (convertAddressRequest.ScriptPublicKey == nil) {
+		errorMessage := &appmessage.ConvertAddressResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Exactly one of address and scriptPublicKey must be set")
+		return errorMessage, nil
+	}
+
+	var address util.Address
+	var scriptPublicKey *externalapi.ScriptPublicKey
+	if convertAddressRequest.Address != "" {
+		var err error
+		address, err = util.DecodeAddress(convertAddressRequest.Address, context.Config.ActiveNetParams.Prefix)
+		if err != nil {
+			errorMessage := &appmessage.ConvertAddressResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+				"Couldn't decode address '%s': %s", convertAddressRequest.Address, err)
+			return errorMessage, nil
+		}
+		scriptPublicKey, err = txscript.PayToAddrScript(address)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		script, err := hex.DecodeString(convertAddressRequest.ScriptPublicKey.Script)
+		if err != nil {
+			errorMessage := &appmessage.ConvertAddressResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+				"Couldn't decode the scriptPublicKey: %s", err)
+			return errorMessage, nil
+		}
+		scriptPublicKey = &externalapi.ScriptPublicKey{
+			Script:  script,
+			Version: convertAddressRequest.ScriptPublicKey.Version,
+		}
+		_, address, err = txscript.ExtractScriptPubKeyAddress(scriptPublicKey, context.Config.ActiveNetParams)
+		if err != nil || address == nil {
+			errorMessage := &appmessage.ConvertAddressResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+				"The scriptPublicKey isn't of a standard form that has an address")
+			return errorMessage, nil
+		}
+	}
+
+	rpcScriptPublicKey := &appmessage.RPCScriptPublicKey{
+		Version: scriptPublicKey.Version,
+		Script:  hex.EncodeToString(scriptPublicKey.Script),
+	}
+	addressType := txscript.GetScriptClass(scriptPublicKey.Script).String()
+	return appmessage.NewConvertAddressResponseMessage(address.EncodeAddress(), rpcScriptPublicKey, addressType), nil
+}
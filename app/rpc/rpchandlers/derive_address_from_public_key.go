@@ -0,0 +1,59 @@
+package rpchandlers
+
+import (
+	"encoding/hex"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// HandleDeriveAddressFromPublicKey handles the respectively named RPC command
+func HandleDeriveAddressFromPublicKey(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	deriveAddressFromPublicKeyRequest := request.(*appmessage.DeriveAddressFromPublicKeyRequestMessage)
+
+	publicKey, err := hex.DecodeString(deriveAddressFromPublicKeyRequest.PublicKey)
+	if err != nil {
+		errorMessage := &appmessage.DeriveAddressFromPublicKeyResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Couldn't decode the public key: %s", err)
+		return errorMessage, nil
+	}
+
+	// The public key is parsed as well, so that a key that isn't a point
+	// on the curve doesn't get an address no one can ever spend from
+	prefix := context.Config.ActiveNetParams.Prefix
+	var address util.Address
+	switch len(publicKey) {
+	case util.PublicKeySize:
+		_, err = secp256k1.DeserializeSchnorrPubKey(publicKey)
+		if err == nil {
+			address, err = util.NewAddressPublicKey(publicKey, prefix)
+		}
+	case util.PublicKeySizeECDSA:
+		_, err = secp256k1.DeserializeECDSAPubKey(publicKey)
+		if err == nil {
+			address, err = util.NewAddressPublicKeyECDSA(publicKey, prefix)
+		}
+	default:
+		errorMessage := &appmessage.DeriveAddressFromPublicKeyResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"The public key must be %d bytes long for Schnorr or %d bytes long for ECDSA, but it's %d bytes long",
+			util.PublicKeySize, util.PublicKeySizeECDSA, len(publicKey))
+		return errorMessage, nil
+	}
+	if err != nil {
+		errorMessage := &appmessage.DeriveAddressFromPublicKeyResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Invalid public key: %s", err)
+		return errorMessage, nil
+	}
+
+	addressType, err := addressScriptClass(address)
+	if err != nil {
+		return nil, err
+	}
+	return appmessage.NewDeriveAddressFromPublicKeyResponseMessage(address.EncodeAddress(), addressType), nil
+}
@@ -0,0 +1,35 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleDisconnectRPCSession handles the respectively named RPC command
+func HandleDisconnectRPCSession(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("DisconnectRPCSession RPC command called while node in safe RPC mode -- ignoring.")
+		response := appmessage.NewDisconnectRPCSessionResponseMessage()
+		response.Error =
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"DisconnectRPCSession RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	disconnectRPCSessionRequest := request.(*appmessage.DisconnectRPCSessionRequestMessage)
+	session, ok := context.RPCSessions.ByID(disconnectRPCSessionRequest.SessionID)
+	if !ok {
+		errorMessage := &appmessage.DisconnectRPCSessionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound,
+			"RPC session %d not found", disconnectRPCSessionRequest.SessionID)
+		return errorMessage, nil
+	}
+
+	log.Infof("Disconnecting RPC session %d (%s) per RPC request", session.ID, session.NetConnection)
+	session.NetConnection.Disconnect()
+
+	// If the session is the one that made the request, the response is
+	// dropped along with the rest of its messages
+	return appmessage.NewDisconnectRPCSessionResponseMessage(), nil
+}
@@ -0,0 +1,15 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleEstimateFee handles the respectively named RPC command
+func HandleEstimateFee(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	estimateFeeRequest := request.(*appmessage.EstimateFeeRequestMessage)
+
+	estimate := context.Domain.MiningManager().FeeEstimator().EstimateFeeRate(estimateFeeRequest.TargetDAAScore)
+	return appmessage.NewEstimateFeeResponseMessage(estimate.FeeRate, estimate.ConfirmationWindow, estimate.IsFallback), nil
+}
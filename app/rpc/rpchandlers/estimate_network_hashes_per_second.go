@@ -16,13 +16,21 @@ func HandleEstimateNetworkHashesPerSecond(
 
 	windowSize := int(estimateNetworkHashesPerSecondRequest.WindowSize)
 	startHash := model.VirtualBlockHash
+	if context.Config.HeadersOnly {
+		// The virtual of a headers-only node never moves
+		var err error
+		startHash, err = context.Domain.Consensus().GetHeadersSelectedTip()
+		if err != nil {
+			return nil, err
+		}
+	}
 	if estimateNetworkHashesPerSecondRequest.StartHash != "" {
 		var err error
 		startHash, err = externalapi.NewDomainHashFromString(estimateNetworkHashesPerSecondRequest.StartHash)
 		if err != nil {
 			response := &appmessage.EstimateNetworkHashesPerSecondResponseMessage{}
-			response.Error = appmessage.RPCErrorf("StartHash '%s' is not a valid block hash",
-				estimateNetworkHashesPerSecondRequest.StartHash)
+			response.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+				"StartHash '%s' is not a valid block hash", estimateNetworkHashesPerSecondRequest.StartHash)
 			return response, nil
 		}
 	}
@@ -32,7 +40,7 @@ func HandleEstimateNetworkHashesPerSecond(
 		if windowSize > windowSizeLimit {
 			response := &appmessage.EstimateNetworkHashesPerSecondResponseMessage{}
 			response.Error =
-				appmessage.RPCErrorf(
+				appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
 					"Requested window size %d is larger than max allowed in RPC safe mode (%d)",
 					windowSize, windowSizeLimit)
 			return response, nil
@@ -42,7 +50,7 @@ func HandleEstimateNetworkHashesPerSecond(
 	if uint64(windowSize) > context.Config.ActiveNetParams.PruningDepth() {
 		response := &appmessage.EstimateNetworkHashesPerSecondResponseMessage{}
 		response.Error =
-			appmessage.RPCErrorf(
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
 				"Requested window size %d is larger than pruning point depth %d",
 				windowSize, context.Config.ActiveNetParams.PruningDepth())
 		return response, nil
@@ -51,8 +59,9 @@ func HandleEstimateNetworkHashesPerSecond(
 	networkHashesPerSecond, err := context.Domain.Consensus().EstimateNetworkHashesPerSecond(startHash, windowSize)
 	if err != nil {
 		response := &appmessage.EstimateNetworkHashesPerSecondResponseMessage{}
-		response.Error = appmessage.RPCErrorf("could not resolve network hashes per "+
-			"second for startHash %s and window size %d: %s", startHash, windowSize, err)
+		response.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"could not resolve network hashes per second for startHash %s and window size %d: %s",
+			startHash, windowSize, err)
 		return response, nil
 	}
 
@@ -0,0 +1,63 @@
+package rpchandlers
+
+import (
+	"path/filepath"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/mempoolsnapshot"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+// HandleExportMempoolSnapshot handles the respectively named RPC command
+func HandleExportMempoolSnapshot(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("ExportMempoolSnapshot RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.ExportMempoolSnapshotResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"ExportMempoolSnapshot RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	exportMempoolSnapshotRequest := request.(*appmessage.ExportMempoolSnapshotRequestMessage)
+	if !filepath.IsAbs(exportMempoolSnapshotRequest.Path) {
+		errorMessage := &appmessage.ExportMempoolSnapshotResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"The path of the snapshot file must be absolute, but got %s", exportMempoolSnapshotRequest.Path)
+		return errorMessage, nil
+	}
+
+	entries := context.Domain.MiningManager().MempoolSnapshot(exportMempoolSnapshotRequest.IncludeOrphanPool)
+	timestamp := mstime.Now()
+	// The virtual DAA score is read after the mempool, so that it's never lower
+	// than the DAA scores the transactions were added at
+	virtualDAAScore, err := context.Domain.Consensus().GetVirtualDAAScore()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := mempoolsnapshot.New(entries, context.Config.NetParams().Name, timestamp, virtualDAAScore)
+	if err != nil {
+		return nil, err
+	}
+	err = snapshot.WriteToFile(exportMempoolSnapshotRequest.Path)
+	if err != nil {
+		errorMessage := &appmessage.ExportMempoolSnapshotResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInternal,
+			"Could not export the mempool snapshot: %s", err)
+		return errorMessage, nil
+	}
+
+	orphanCount := uint64(0)
+	for _, entry := range entries {
+		if entry.IsOrphan {
+			orphanCount++
+		}
+	}
+	log.Infof("Exported a mempool snapshot of %d transactions to %s", len(entries), exportMempoolSnapshotRequest.Path)
+
+	return appmessage.NewExportMempoolSnapshotResponseMessage(uint64(len(entries)), orphanCount,
+		timestamp.UnixMilliseconds()), nil
+}
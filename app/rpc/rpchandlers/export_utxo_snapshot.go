@@ -0,0 +1,60 @@
+package rpchandlers
+
+import (
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/app/utxosnapshot"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// isExportingUTXOSnapshot is set while a snapshot is exported, since exporting
+// a few snapshots at once only slows all of them down
+var isExportingUTXOSnapshot uint32
+
+// HandleExportUTXOSnapshot handles the respectively named RPC command
+func HandleExportUTXOSnapshot(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("ExportUTXOSnapshot RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.ExportUTXOSnapshotResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"ExportUTXOSnapshot RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	exportUTXOSnapshotRequest := request.(*appmessage.ExportUTXOSnapshotRequestMessage)
+	if !filepath.IsAbs(exportUTXOSnapshotRequest.Path) {
+		errorMessage := &appmessage.ExportUTXOSnapshotResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"The path of the snapshot file must be absolute, but got %s", exportUTXOSnapshotRequest.Path)
+		return errorMessage, nil
+	}
+
+	if !atomic.CompareAndSwapUint32(&isExportingUTXOSnapshot, 0, 1) {
+		errorMessage := &appmessage.ExportUTXOSnapshotResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeRateLimited,
+			"Another UTXO snapshot is being exported")
+		return errorMessage, nil
+	}
+	defer atomic.StoreUint32(&isExportingUTXOSnapshot, 0)
+
+	log.Infof("Exporting a UTXO snapshot to %s", exportUTXOSnapshotRequest.Path)
+	header, utxoCount, err := utxosnapshot.ExportToFile(context.Domain.Consensus(), context.Config.ActiveNetParams,
+		exportUTXOSnapshotRequest.Path, func(processedHeaderCount uint64, processedUTXOCount uint64) {
+			log.Infof("Exported %d headers and %d UTXOs", processedHeaderCount, processedUTXOCount)
+		})
+	if err != nil {
+		errorMessage := &appmessage.ExportUTXOSnapshotResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInternal,
+			"Could not export the UTXO snapshot: %s", err)
+		return errorMessage, nil
+	}
+	log.Infof("Exported %d UTXOs of pruning point %s to %s", utxoCount, header.PruningPoint,
+		exportUTXOSnapshotRequest.Path)
+
+	return appmessage.NewExportUTXOSnapshotResponseMessage(header.PruningPoint.String(), header.BlueScore,
+		header.UTXOCommitment.String(), utxoCount), nil
+}
@@ -0,0 +1,62 @@
+package rpchandlers
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+const (
+	defaultAddressManagerInfoSampleSize = 10
+	maxAddressManagerInfoSampleSize     = 1000
+)
+
+// HandleGetAddressManagerInfo handles the respectively named RPC command
+func HandleGetAddressManagerInfo(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getAddressManagerInfoRequest := request.(*appmessage.GetAddressManagerInfoRequestMessage)
+
+	sampleSize := getAddressManagerInfoRequest.SampleSize
+	if sampleSize == 0 {
+		sampleSize = defaultAddressManagerInfoSampleSize
+	}
+	if sampleSize > maxAddressManagerInfoSampleSize {
+		errorMessage := &appmessage.GetAddressManagerInfoResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"sampleSize may not be greater than %d", maxAddressManagerInfoSampleSize)
+		return errorMessage, nil
+	}
+
+	tableInfo := context.AddressManager.TableInfo(int(sampleSize))
+
+	addressCountsByConnectionFailures := make([]uint64, len(tableInfo.AddressCountsByConnectionFailures))
+	for i, addressCount := range tableInfo.AddressCountsByConnectionFailures {
+		addressCountsByConnectionFailures[i] = uint64(addressCount)
+	}
+
+	sourceAddressCounts := make([]*appmessage.AddressManagerSourceCount, len(tableInfo.SourceAddressCounts))
+	for i, sourceAddressCount := range tableInfo.SourceAddressCounts {
+		sourceAddressCounts[i] = &appmessage.AddressManagerSourceCount{
+			Source:       sourceAddressCount.Source,
+			AddressCount: uint64(sourceAddressCount.AddressCount),
+		}
+	}
+
+	sampleAddresses := make([]*appmessage.AddressManagerEntry, len(tableInfo.SampleAddresses))
+	for i, sampleAddress := range tableInfo.SampleAddresses {
+		netAddress := sampleAddress.NetAddress
+		sampleAddresses[i] = &appmessage.AddressManagerEntry{
+			Address:               net.JoinHostPort(netAddress.IP.String(), strconv.FormatUint(uint64(netAddress.Port), 10)),
+			ConnectionFailedCount: sampleAddress.ConnectionFailedCount,
+			Source:                sampleAddress.Source,
+			Group:                 sampleAddress.Group,
+			Timestamp:             netAddress.Timestamp.UnixMilliseconds(),
+		}
+	}
+
+	return appmessage.NewGetAddressManagerInfoResponseMessage(uint64(tableInfo.AddressCount),
+		uint64(tableInfo.BannedAddressCount), addressCountsByConnectionFailures, uint64(tableInfo.AddressGroupCount),
+		sourceAddressCounts, sampleAddresses), nil
+}
@@ -10,11 +10,12 @@ import (
 )
 
 // HandleGetBalanceByAddress handles the respectively named RPC command
-func HandleGetBalanceByAddress(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+func HandleGetBalanceByAddress(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	apiVersion := context.APIVersions.Version(router)
 	if !context.Config.UTXOIndex {
-		errorMessage := &appmessage.GetUTXOsByAddressesResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when kaspad is run without --utxoindex")
-		return errorMessage, nil
+		return newGetBalanceByAddressErrorResponse(apiVersion,
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable,
+				"Method unavailable when kaspad is run without --utxoindex")), nil
 	}
 
 	getBalanceByAddressRequest := request.(*appmessage.GetBalanceByAddressRequestMessage)
@@ -25,24 +26,32 @@ func HandleGetBalanceByAddress(context *rpccontext.Context, _ *router.Router, re
 		if !errors.As(err, &rpcError) {
 			return nil, err
 		}
-		errorMessage := &appmessage.GetUTXOsByAddressesResponseMessage{}
-		errorMessage.Error = rpcError
-		return errorMessage, nil
+		return newGetBalanceByAddressErrorResponse(apiVersion, rpcError), nil
 	}
 
 	response := appmessage.NewGetBalanceByAddressResponse(balance)
 	return response, nil
 }
 
+func newGetBalanceByAddressErrorResponse(apiVersion uint32, rpcError *appmessage.RPCError) appmessage.Message {
+	// Before RPC API version 2, errors were mistakenly sent in a GetUTXOsByAddresses response
+	if apiVersion < appmessage.RPCAPIVersion2 {
+		return &appmessage.GetUTXOsByAddressesResponseMessage{Error: rpcError}
+	}
+	return &appmessage.GetBalanceByAddressResponseMessage{Error: rpcError}
+}
+
 func getBalanceByAddress(context *rpccontext.Context, addressString string) (uint64, error) {
 	address, err := util.DecodeAddress(addressString, context.Config.ActiveNetParams.Prefix)
 	if err != nil {
-		return 0, appmessage.RPCErrorf("Couldn't decode address '%s': %s", addressString, err)
+		return 0, appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Couldn't decode address '%s': %s",
+			addressString, err)
 	}
 
 	scriptPublicKey, err := txscript.PayToAddrScript(address)
 	if err != nil {
-		return 0, appmessage.RPCErrorf("Could not create a scriptPublicKey for address '%s': %s", addressString, err)
+		return 0, appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Could not create a scriptPublicKey for address '%s': %s", addressString, err)
 	}
 	utxoOutpointEntryPairs, err := context.UTXOIndex.UTXOs(scriptPublicKey)
 	if err != nil {
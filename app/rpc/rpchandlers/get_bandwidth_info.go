@@ -0,0 +1,28 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetBandwidthInfo handles the respectively named RPC command
+func HandleGetBandwidthInfo(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	peers := context.ProtocolManager.Peers()
+	peerInfos := make([]*appmessage.PeerBandwidthInfo, len(peers))
+	for i, peer := range peers {
+		peerInfos[i] = &appmessage.PeerBandwidthInfo{
+			ID:            peer.ID().String(),
+			Address:       peer.Address(),
+			BytesSent:     peer.Connection().BytesSent(),
+			BytesReceived: peer.Connection().BytesReceived(),
+		}
+	}
+
+	bandwidthManager := context.NetAdapter.BandwidthManager()
+	return appmessage.NewGetBandwidthInfoResponseMessage(
+		bandwidthManager.TotalBytesSent(), bandwidthManager.TotalBytesReceived(),
+		bandwidthManager.UploadLimit(), bandwidthManager.DownloadLimit(),
+		bandwidthManager.PeerUploadLimit(), bandwidthManager.PeerDownloadLimit(),
+		peerInfos), nil
+}
@@ -0,0 +1,32 @@
+package rpchandlers
+
+import (
+	"sort"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetBannedPeers handles the respectively named RPC command
+func HandleGetBannedPeers(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	entries, err := context.AddressManager.BannedAddressEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	bannedPeers := make([]*appmessage.BannedPeer, len(entries))
+	for i, entry := range entries {
+		bannedPeers[i] = &appmessage.BannedPeer{
+			IP:          entry.Address.IP.String(),
+			BanTime:     entry.BanTime.UnixMilliseconds(),
+			BannedUntil: entry.BannedUntil.UnixMilliseconds(),
+			Reason:      entry.Reason,
+		}
+	}
+	sort.Slice(bannedPeers, func(i, j int) bool {
+		return bannedPeers[i].BanTime < bannedPeers[j].BanTime
+	})
+
+	return appmessage.NewGetBannedPeersResponseMessage(bannedPeers), nil
+}
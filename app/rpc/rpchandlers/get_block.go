@@ -11,39 +11,65 @@ import (
 // HandleGetBlock handles the respectively named RPC command
 func HandleGetBlock(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	getBlockRequest := request.(*appmessage.GetBlockRequestMessage)
+	if !getBlockRequest.Verbosity.IsValid() {
+		errorMessage := &appmessage.GetBlockResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Unknown verbosity %d", getBlockRequest.Verbosity)
+		return errorMessage, nil
+	}
 
 	// Load the raw block bytes from the database.
 	hash, err := externalapi.NewDomainHashFromString(getBlockRequest.Hash)
 	if err != nil {
 		errorMessage := &appmessage.GetBlockResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Hash could not be parsed: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Hash could not be parsed: %s", err)
 		return errorMessage, nil
 	}
 
 	block, err := context.Domain.Consensus().GetBlockEvenIfHeaderOnly(hash)
 	if err != nil {
 		errorMessage := &appmessage.GetBlockResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Block %s not found", hash)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound, "Block %s not found", hash)
 		return errorMessage, nil
 	}
 
 	response := appmessage.NewGetBlockResponseMessage()
 
-	if getBlockRequest.IncludeTransactions {
+	includeTransactions := getBlockRequest.IncludeTransactions
+	switch getBlockRequest.Verbosity {
+	case appmessage.RPCVerbosityHashesOnly:
+		response.Block = rpccontext.HashesOnlyBlock(block)
+		return response, nil
+	case appmessage.RPCVerbosityHeadersOnly:
+		includeTransactions = false
+	case appmessage.RPCVerbosityFull:
+		includeTransactions = true
+	}
+
+	if includeTransactions {
 		response.Block = appmessage.DomainBlockToRPCBlock(block)
 	} else {
 		response.Block = appmessage.DomainBlockToRPCBlock(&externalapi.DomainBlock{Header: block.Header})
 	}
 
-	err = context.PopulateBlockWithVerboseData(response.Block, block.Header, block, getBlockRequest.IncludeTransactions)
+	err = context.PopulateBlockWithVerboseData(response.Block, block.Header, block, includeTransactions)
 	if err != nil {
 		if errors.Is(err, rpccontext.ErrBuildBlockVerboseDataInvalidBlock) {
 			errorMessage := &appmessage.GetBlockResponseMessage{}
-			errorMessage.Error = appmessage.RPCErrorf("Block %s is invalid", hash)
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeBlockInvalid, "Block %s is invalid", hash)
 			return errorMessage, nil
 		}
 		return nil, err
 	}
 
+	if getBlockRequest.Verbosity == appmessage.RPCVerbosityFull {
+		for _, transaction := range response.Block.Transactions {
+			err := context.PopulateTransactionWithDecodedScripts(transaction)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return response, nil
 }
@@ -0,0 +1,84 @@
+package rpchandlers
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// maxBlockHeadersCount is the maximum amount of headers returned by a single
+// GetBlockHeaders request, unless the merge set size limit is higher
+const maxBlockHeadersCount = 1 << 12
+
+// HandleGetBlockHeaders handles the respectively named RPC command
+func HandleGetBlockHeaders(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getBlockHeadersRequest := request.(*appmessage.GetBlockHeadersRequestMessage)
+
+	// Decode lowHash
+	// If lowHash is empty - use genesis instead.
+	lowHash := context.Config.ActiveNetParams.GenesisHash
+	if getBlockHeadersRequest.LowHash != "" {
+		var err error
+		lowHash, err = externalapi.NewDomainHashFromString(getBlockHeadersRequest.LowHash)
+		if err != nil {
+			return &appmessage.GetBlockHeadersResponseMessage{
+				Error: appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not decode lowHash %s: %s",
+					getBlockHeadersRequest.LowHash, err),
+			}, nil
+		}
+	}
+	blockInfo, err := context.Domain.Consensus().GetBlockInfo(lowHash)
+	if err != nil {
+		return nil, err
+	}
+	if !blockInfo.HasHeader() {
+		return &appmessage.GetBlockHeadersResponseMessage{
+			Error: appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound, "Could not find lowHash %s", lowHash),
+		}, nil
+	}
+
+	// A merge set can't be split, so count can't be lower than the
+	// merge set size limit + 1
+	count := getBlockHeadersRequest.Count
+	if count == 0 || count > maxBlockHeadersCount {
+		count = maxBlockHeadersCount
+	}
+	minCount := context.Config.NetParams().MergeSetSizeLimit + 1
+	if count < minCount {
+		count = minCount
+	}
+
+	headersSelectedTip, err := context.Domain.Consensus().GetHeadersSelectedTip()
+	if err != nil {
+		return nil, err
+	}
+	blockHashes, _, err := context.Domain.Consensus().GetHashesBetween(lowHash, headersSelectedTip, count)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]string, len(blockHashes))
+	for i, blockHash := range blockHashes {
+		header, err := context.Domain.Consensus().GetBlockHeader(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		var serializedHeader bytes.Buffer
+		err = consensushashing.SerializeHeader(&serializedHeader, header)
+		if err != nil {
+			return nil, err
+		}
+		headers[i] = hex.EncodeToString(serializedHeader.Bytes())
+	}
+
+	highHash := ""
+	if len(blockHashes) > 0 {
+		highHash = blockHashes[len(blockHashes)-1].String()
+	}
+	return appmessage.NewGetBlockHeadersResponseMessage(headers, highHash), nil
+}
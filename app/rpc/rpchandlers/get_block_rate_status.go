@@ -0,0 +1,29 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetBlockRateStatus handles the respectively named RPC command
+func HandleGetBlockRateStatus(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if context.BlockRateMonitor == nil {
+		errorMessage := &appmessage.GetBlockRateStatusResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable,
+			"Method unavailable when kaspad is run without --blockratemonitor")
+		return errorMessage, nil
+	}
+
+	status := context.BlockRateMonitor.Status()
+	alerts := make([]*appmessage.BlockRateAlert, len(status.Alerts))
+	for i, alert := range status.Alerts {
+		alerts[i] = &appmessage.BlockRateAlert{
+			Kind:    alert.Kind,
+			Message: alert.Message,
+			Since:   alert.Since.UnixMilli(),
+		}
+	}
+	return appmessage.NewGetBlockRateStatusResponseMessage(status.TargetBlockRate, status.ObservedBlockRate,
+		status.IsWindowFull, status.DifficultyChange, status.TipTimestampDelay.Milliseconds(), alerts), nil
+}
@@ -18,7 +18,7 @@ func HandleGetBlockTemplate(context *rpccontext.Context, _ *router.Router, reque
 	payAddress, err := util.DecodeAddress(getBlockTemplateRequest.PayAddress, context.Config.ActiveNetParams.Prefix)
 	if err != nil {
 		errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Could not decode address: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not decode address: %s", err)
 		return errorMessage, nil
 	}
 
@@ -36,7 +36,9 @@ func HandleGetBlockTemplate(context *rpccontext.Context, _ *router.Router, reque
 
 	if uint64(len(templateBlock.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload)) > context.Config.NetParams().MaxCoinbasePayloadLength {
 		errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Coinbase payload is above max length (%d). Try to shorten the extra data.", context.Config.NetParams().MaxCoinbasePayloadLength)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Coinbase payload is above max length (%d). Try to shorten the extra data.",
+			context.Config.NetParams().MaxCoinbasePayloadLength)
 		return errorMessage, nil
 	}
 
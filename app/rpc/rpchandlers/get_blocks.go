@@ -12,11 +12,36 @@ import (
 func HandleGetBlocks(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	getBlocksRequest := request.(*appmessage.GetBlocksRequestMessage)
 
-	// Validate that user didn't set IncludeTransactions without setting IncludeBlocks
-	if !getBlocksRequest.IncludeBlocks && getBlocksRequest.IncludeTransactions {
+	// The verbosity, if set, overrides IncludeBlocks and IncludeTransactions
+	includeBlocks := getBlocksRequest.IncludeBlocks
+	includeTransactions := getBlocksRequest.IncludeTransactions
+	switch getBlocksRequest.Verbosity {
+	case appmessage.RPCVerbosityDefault:
+		// Validate that user didn't set IncludeTransactions without setting IncludeBlocks
+		if !includeBlocks && includeTransactions {
+			return &appmessage.GetBlocksResponseMessage{
+				Error: appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+					"If includeTransactions is set, then includeBlockVerboseData must be set as well"),
+			}, nil
+		}
+	case appmessage.RPCVerbosityHashesOnly:
+		includeBlocks, includeTransactions = false, false
+	case appmessage.RPCVerbosityHeadersOnly:
+		includeBlocks, includeTransactions = true, false
+	case appmessage.RPCVerbosityFull:
+		includeBlocks, includeTransactions = true, true
+	default:
 		return &appmessage.GetBlocksResponseMessage{
-			Error: appmessage.RPCErrorf(
-				"If includeTransactions is set, then includeBlockVerboseData must be set as well"),
+			Error: appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+				"Unknown verbosity %d", getBlocksRequest.Verbosity),
+		}, nil
+	}
+
+	// lowHash is always returned, so a page of a single block would never
+	// advance past it
+	if getBlocksRequest.Limit == 1 {
+		return &appmessage.GetBlocksResponseMessage{
+			Error: appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "limit must be at least 2"),
 		}, nil
 	}
 
@@ -28,7 +53,8 @@ func HandleGetBlocks(context *rpccontext.Context, _ *router.Router, request appm
 		lowHash, err = externalapi.NewDomainHashFromString(getBlocksRequest.LowHash)
 		if err != nil {
 			return &appmessage.GetBlocksResponseMessage{
-				Error: appmessage.RPCErrorf("Could not decode lowHash %s: %s", getBlocksRequest.LowHash, err),
+				Error: appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not decode lowHash %s: %s",
+					getBlocksRequest.LowHash, err),
 			}, nil
 		}
 
@@ -39,7 +65,8 @@ func HandleGetBlocks(context *rpccontext.Context, _ *router.Router, request appm
 
 		if !blockInfo.HasHeader() {
 			return &appmessage.GetBlocksResponseMessage{
-				Error: appmessage.RPCErrorf("Could not find lowHash %s", getBlocksRequest.LowHash),
+				Error: appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound, "Could not find lowHash %s",
+					getBlocksRequest.LowHash),
 			}, nil
 		}
 	}
@@ -61,21 +88,42 @@ func HandleGetBlocks(context *rpccontext.Context, _ *router.Router, request appm
 	// prepend low hash to make it inclusive
 	blockHashes = append([]*externalapi.DomainHash{lowHash}, blockHashes...)
 
+	// The blocks are ordered such that every block follows its ancestors, so
+	// the next page may start at the last block of this one without skipping
+	// any of the blocks in between. Once there are no more pages, the last
+	// block is the virtualSelectedParent, from which the blocks that are added
+	// later are requested.
+	limit := int(getBlocksRequest.Limit)
+	hasMore := !highHash.Equal(virtualSelectedParent)
+	if limit > 0 && len(blockHashes) > limit {
+		blockHashes = blockHashes[:limit]
+		hasMore = true
+	}
+	nextLowHash := blockHashes[len(blockHashes)-1]
+
 	// If the high hash is equal to virtualSelectedParent it means GetHashesBetween didn't skip any hashes, and
 	// there's space to add the virtualSelectedParent's anticone, otherwise you can't add the anticone because
 	// there's no guarantee that all of the anticone root ancestors will be present.
-	if highHash.Equal(virtualSelectedParent) {
+	if !hasMore {
 		virtualSelectedParentAnticone, err := context.Domain.Consensus().Anticone(virtualSelectedParent)
 		if err != nil {
 			return nil, err
 		}
+		// The anticone blocks that don't fit in the page aren't lost, since
+		// they're returned once they're merged into the past of a later
+		// virtualSelectedParent
+		if limit > 0 && len(blockHashes)+len(virtualSelectedParentAnticone) > limit {
+			virtualSelectedParentAnticone = virtualSelectedParentAnticone[:limit-len(blockHashes)]
+		}
 		blockHashes = append(blockHashes, virtualSelectedParentAnticone...)
 	}
 
 	// Prepare the response
 	response := appmessage.NewGetBlocksResponseMessage()
 	response.BlockHashes = hashes.ToStrings(blockHashes)
-	if getBlocksRequest.IncludeBlocks {
+	response.NextLowHash = nextLowHash.String()
+	response.HasMore = hasMore
+	if includeBlocks {
 		rpcBlocks := make([]*appmessage.RPCBlock, len(blockHashes))
 		for i, blockHash := range blockHashes {
 			block, err := context.Domain.Consensus().GetBlockEvenIfHeaderOnly(blockHash)
@@ -83,15 +131,23 @@ func HandleGetBlocks(context *rpccontext.Context, _ *router.Router, request appm
 				return nil, err
 			}
 
-			if getBlocksRequest.IncludeTransactions {
+			if includeTransactions {
 				rpcBlocks[i] = appmessage.DomainBlockToRPCBlock(block)
 			} else {
 				rpcBlocks[i] = appmessage.DomainBlockToRPCBlock(&externalapi.DomainBlock{Header: block.Header})
 			}
-			err = context.PopulateBlockWithVerboseData(rpcBlocks[i], block.Header, nil, getBlocksRequest.IncludeTransactions)
+			err = context.PopulateBlockWithVerboseData(rpcBlocks[i], block.Header, nil, includeTransactions)
 			if err != nil {
 				return nil, err
 			}
+			if getBlocksRequest.Verbosity == appmessage.RPCVerbosityFull {
+				for _, transaction := range rpcBlocks[i].Transactions {
+					err := context.PopulateTransactionWithDecodedScripts(transaction)
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
 		}
 		response.Blocks = rpcBlocks
 	}
@@ -0,0 +1,62 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// maxChainChangedEventsBlocks is the maximum amount of added chain blocks
+// returned by a single GetChainChangedEventsFromBlock request
+const maxChainChangedEventsBlocks = 1000
+
+// HandleGetChainChangedEventsFromBlock handles the respectively named RPC command
+func HandleGetChainChangedEventsFromBlock(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getChainChangedEventsFromBlockRequest := request.(*appmessage.GetChainChangedEventsFromBlockRequestMessage)
+
+	startHash, err := externalapi.NewDomainHashFromString(getChainChangedEventsFromBlockRequest.StartHash)
+	if err != nil {
+		errorMessage := &appmessage.GetChainChangedEventsFromBlockResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not parse startHash: %s", err)
+		return errorMessage, nil
+	}
+
+	virtualSelectedParentChain, err := context.Domain.Consensus().GetVirtualSelectedParentChainFromBlock(startHash)
+	if err != nil {
+		response := &appmessage.GetChainChangedEventsFromBlockResponseMessage{}
+		response.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not build virtual "+
+			"selected parent chain from %s: %s", getChainChangedEventsFromBlockRequest.StartHash, err)
+		return response, nil
+	}
+
+	limit := int(getChainChangedEventsFromBlockRequest.Limit)
+	if limit == 0 || limit > maxChainChangedEventsBlocks {
+		limit = maxChainChangedEventsBlocks
+	}
+	added := virtualSelectedParentChain.Added
+	hasMore := len(added) > limit
+	if hasMore {
+		added = added[:limit]
+	}
+
+	removedChainBlockHashes := make([]string, len(virtualSelectedParentChain.Removed))
+	for i, removed := range virtualSelectedParentChain.Removed {
+		removedChainBlockHashes[i] = removed.String()
+	}
+	addedChainBlockHashes := make([]string, len(added))
+	for i, addedChainBlock := range added {
+		addedChainBlockHashes[i] = addedChainBlock.String()
+	}
+	acceptedTransactions, err := context.GetAndConvertAcceptedTransactions(added)
+	if err != nil {
+		return nil, err
+	}
+
+	nextStartHash := startHash
+	if len(added) > 0 {
+		nextStartHash = added[len(added)-1]
+	}
+	return appmessage.NewGetChainChangedEventsFromBlockResponseMessage(removedChainBlockHashes, addedChainBlockHashes,
+		acceptedTransactions, nextStartHash.String(), hasMore), nil
+}
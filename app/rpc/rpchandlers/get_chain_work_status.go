@@ -0,0 +1,28 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetChainWorkStatus handles the respectively named RPC command
+func HandleGetChainWorkStatus(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	status, err := context.ProtocolManager.Context().ChainWorkStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &appmessage.GetChainWorkStatusResponseMessage{
+		VirtualSelectedParentHash:     status.VirtualSelectedParentHash.String(),
+		VirtualSelectedParentBlueWork: status.VirtualSelectedParentBlueWork.Text(16),
+		LagInBlocks:                   status.LagInBlocks,
+		IsLagging:                     status.IsLagging,
+	}
+	if status.HeaviestClaimedTip != nil {
+		response.HeaviestClaimedBlockHash = status.HeaviestClaimedTip.BlockHash.String()
+		response.HeaviestClaimedBlueWork = status.HeaviestClaimedTip.BlueWork.Text(16)
+		response.HeaviestClaimedByPeer = status.HeaviestClaimedTip.Peer.Address()
+	}
+	return response, nil
+}
@@ -0,0 +1,58 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/coinageindex"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// maxCoinAgeAnalyticsWindow is the maximum amount of chain blocks
+// returned in a single GetCoinAgeAnalytics call
+const maxCoinAgeAnalyticsWindow = 1000
+
+// HandleGetCoinAgeAnalytics handles the respectively named RPC command
+func HandleGetCoinAgeAnalytics(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	index, rpcError := context.CoinAgeIndex.Get()
+	if rpcError != nil {
+		errorMessage := &appmessage.GetCoinAgeAnalyticsResponseMessage{}
+		errorMessage.Error = rpcError
+		return errorMessage, nil
+	}
+	coinAgeIndex := index.(*coinageindex.CoinAgeIndex)
+
+	getCoinAgeAnalyticsRequest := request.(*appmessage.GetCoinAgeAnalyticsRequestMessage)
+	window := int(getCoinAgeAnalyticsRequest.Window)
+	if window == 0 || window > maxCoinAgeAnalyticsWindow {
+		window = maxCoinAgeAnalyticsWindow
+	}
+
+	coinDaysDestroyed, err := coinAgeIndex.CoinDaysDestroyed(window)
+	if err != nil {
+		return nil, err
+	}
+	daaScore, ageWindows, err := coinAgeIndex.UTXOAgeDistribution()
+	if err != nil {
+		return nil, err
+	}
+
+	chainBlocks := make([]*appmessage.ChainBlockCoinDaysDestroyed, len(coinDaysDestroyed))
+	for i, chainBlock := range coinDaysDestroyed {
+		chainBlocks[i] = &appmessage.ChainBlockCoinDaysDestroyed{
+			BlockHash:         chainBlock.BlockHash.String(),
+			DAAScore:          chainBlock.DAAScore,
+			SpentAmount:       chainBlock.SpentAmount,
+			CoinDaysDestroyed: chainBlock.CoinDaysDestroyed,
+		}
+	}
+	utxoAgeWindows := make([]*appmessage.UTXOAgeWindow, len(ageWindows))
+	for i, ageWindow := range ageWindows {
+		utxoAgeWindows[i] = &appmessage.UTXOAgeWindow{
+			StartDAAScore: ageWindow.StartDAAScore,
+			UTXOCount:     ageWindow.UTXOCount,
+			TotalAmount:   ageWindow.TotalAmount,
+		}
+	}
+	return appmessage.NewGetCoinAgeAnalyticsResponseMessage(daaScore, coinageindex.DAAScoreWindowSize,
+		chainBlocks, utxoAgeWindows), nil
+}
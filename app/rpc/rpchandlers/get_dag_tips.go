@@ -0,0 +1,59 @@
+package rpchandlers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetDAGTips handles the respectively named RPC command
+func HandleGetDAGTips(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	consensus := context.Domain.Consensus()
+
+	tipHashes, err := consensus.Tips()
+	if err != nil {
+		return nil, err
+	}
+	virtualInfo, err := consensus.GetVirtualInfo()
+	if err != nil {
+		return nil, err
+	}
+	virtualParents := make(map[externalapi.DomainHash]struct{}, len(virtualInfo.ParentHashes))
+	for _, parentHash := range virtualInfo.ParentHashes {
+		virtualParents[*parentHash] = struct{}{}
+	}
+	virtualSelectedParent, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tips := make([]*appmessage.DAGTip, len(tipHashes))
+	for i, tipHash := range tipHashes {
+		header, err := consensus.GetBlockHeader(tipHash)
+		if err != nil {
+			return nil, err
+		}
+		_, isVirtualParent := virtualParents[*tipHash]
+		tip := &appmessage.DAGTip{
+			Hash:                    tipHash.String(),
+			BlueScore:               header.BlueScore(),
+			IsVirtualParent:         isVirtualParent,
+			IsVirtualSelectedParent: tipHash.Equal(virtualSelectedParent),
+		}
+		if receiveTime, ok := context.TipReceiveTimes.ReceiveTime(tipHash); ok {
+			tip.IsReceiveTimeKnown = true
+			tip.TimeSinceReceived = now.Sub(receiveTime).Milliseconds()
+		}
+		tips[i] = tip
+	}
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].BlueScore > tips[j].BlueScore
+	})
+
+	return appmessage.NewGetDAGTipsResponseMessage(tips), nil
+}
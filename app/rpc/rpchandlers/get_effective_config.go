@@ -0,0 +1,21 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetEffectiveConfig handles the respectively named RPC command
+func HandleGetEffectiveConfig(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	effectiveOptions := context.Config.EffectiveOptions()
+	options := make([]*appmessage.EffectiveConfigOption, len(effectiveOptions))
+	for i, effectiveOption := range effectiveOptions {
+		options[i] = &appmessage.EffectiveConfigOption{
+			Name:   effectiveOption.Name,
+			Value:  effectiveOption.Value,
+			Source: string(effectiveOption.Source),
+		}
+	}
+	return appmessage.NewGetEffectiveConfigResponseMessage(options), nil
+}
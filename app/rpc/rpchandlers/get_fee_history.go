@@ -0,0 +1,47 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/feehistoryindex"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// maxFeeHistoryWindow is the maximum amount of chain blocks
+// returned in a single GetFeeHistory call
+const maxFeeHistoryWindow = 1000
+
+// HandleGetFeeHistory handles the respectively named RPC command
+func HandleGetFeeHistory(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	index, rpcError := context.FeeHistoryIndex.Get()
+	if rpcError != nil {
+		errorMessage := &appmessage.GetFeeHistoryResponseMessage{}
+		errorMessage.Error = rpcError
+		return errorMessage, nil
+	}
+	feeHistoryIndex := index.(*feehistoryindex.FeeHistoryIndex)
+
+	getFeeHistoryRequest := request.(*appmessage.GetFeeHistoryRequestMessage)
+	window := int(getFeeHistoryRequest.Window)
+	if window == 0 || window > maxFeeHistoryWindow {
+		window = maxFeeHistoryWindow
+	}
+
+	history, err := feeHistoryIndex.FeeHistory(window)
+	if err != nil {
+		return nil, err
+	}
+
+	chainBlocks := make([]*appmessage.ChainBlockFeeRates, len(history))
+	for i, feeRates := range history {
+		chainBlocks[i] = &appmessage.ChainBlockFeeRates{
+			BlockHash:          feeRates.BlockHash.String(),
+			BlueScore:          feeRates.BlueScore,
+			DAAScore:           feeRates.DAAScore,
+			Timestamp:          feeRates.TimeInMilliseconds,
+			TransactionCount:   feeRates.TransactionCount,
+			FeeRatePercentiles: feeRates.FeeRatePercentiles,
+		}
+	}
+	return appmessage.NewGetFeeHistoryResponseMessage(feehistoryindex.Percentiles, chainBlocks), nil
+}
@@ -9,6 +9,6 @@ import (
 // HandleGetHeaders handles the respectively named RPC command
 func HandleGetHeaders(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	response := &appmessage.GetHeadersResponseMessage{}
-	response.Error = appmessage.RPCErrorf("not implemented")
+	response.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotImplemented, "not implemented")
 	return response, nil
 }
@@ -0,0 +1,34 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetHeadersSelectedTip handles the respectively named RPC command
+func HandleGetHeadersSelectedTip(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	headersSelectedTip, err := context.Domain.Consensus().GetHeadersSelectedTip()
+	if err != nil {
+		return nil, err
+	}
+	header, err := context.Domain.Consensus().GetBlockHeader(headersSelectedTip)
+	if err != nil {
+		return nil, err
+	}
+	syncInfo, err := context.Domain.Consensus().GetSyncInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	return appmessage.NewGetHeadersSelectedTipResponseMessage(
+		headersSelectedTip.String(),
+		header.BlueScore(),
+		header.DAAScore(),
+		header.BlueWork().Text(16),
+		header.Bits(),
+		context.GetDifficultyRatio(header.Bits(), context.Config.ActiveNetParams),
+		header.TimeInMilliseconds(),
+		syncInfo.HeaderCount,
+	), nil
+}
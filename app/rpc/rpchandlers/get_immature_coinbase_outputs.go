@@ -0,0 +1,107 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// HandleGetImmatureCoinbaseOutputs handles the respectively named RPC command
+func HandleGetImmatureCoinbaseOutputs(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getImmatureCoinbaseOutputsRequest := request.(*appmessage.GetImmatureCoinbaseOutputsRequestMessage)
+
+	address, err := util.DecodeAddress(getImmatureCoinbaseOutputsRequest.Address, context.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		errorMessage := &appmessage.GetImmatureCoinbaseOutputsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not decode address '%s': %s",
+			getImmatureCoinbaseOutputsRequest.Address, err)
+		return errorMessage, nil
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		errorMessage := &appmessage.GetImmatureCoinbaseOutputsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Could not create a scriptPublicKey for address '%s': %s", getImmatureCoinbaseOutputsRequest.Address, err)
+		return errorMessage, nil
+	}
+
+	virtualInfo, err := context.Domain.Consensus().GetVirtualInfo()
+	if err != nil {
+		return nil, err
+	}
+	coinbaseMaturity := context.Config.ActiveNetParams.BlockCoinbaseMaturity
+	outputs, err := immatureCoinbaseOutputs(context, scriptPublicKey, virtualInfo.DAAScore, coinbaseMaturity)
+	if err != nil {
+		return nil, err
+	}
+
+	totalImmatureAmount := uint64(0)
+	for _, output := range outputs {
+		totalImmatureAmount += output.Amount
+	}
+	return appmessage.NewGetImmatureCoinbaseOutputsResponseMessage(virtualInfo.DAAScore, coinbaseMaturity,
+		totalImmatureAmount, outputs), nil
+}
+
+// immatureCoinbaseOutputs walks down the virtual selected parent chain and
+// collects the coinbase outputs paying scriptPublicKey that are not yet mature.
+// The coinbase transaction of a chain block is accepted by its selected child,
+// or by the virtual for the virtual selected parent, and its outputs mature once
+// the virtual DAA score reaches the DAA score of the accepting block plus the
+// coinbase maturity. Since DAA scores only decrease along the walk, it stops at
+// the first chain block whose coinbase outputs are mature.
+func immatureCoinbaseOutputs(context *rpccontext.Context, scriptPublicKey *externalapi.ScriptPublicKey,
+	virtualDAAScore uint64, coinbaseMaturity uint64) ([]*appmessage.ImmatureCoinbaseOutput, error) {
+
+	outputs := make([]*appmessage.ImmatureCoinbaseOutput, 0)
+	blockHash, err := context.Domain.Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	acceptingBlockDAAScore := virtualDAAScore
+	for acceptingBlockDAAScore+coinbaseMaturity > virtualDAAScore {
+		block, found, err := context.Domain.Consensus().GetBlock(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		// The remaining chain blocks were pruned, and their coinbase
+		// outputs have long matured
+		if !found {
+			break
+		}
+
+		coinbaseTransaction := block.Transactions[transactionhelper.CoinbaseTransactionIndex]
+		coinbaseTransactionID := consensushashing.TransactionID(coinbaseTransaction)
+		for i, output := range coinbaseTransaction.Outputs {
+			if !output.ScriptPublicKey.Equal(scriptPublicKey) {
+				continue
+			}
+			outputs = append(outputs, &appmessage.ImmatureCoinbaseOutput{
+				Outpoint: &appmessage.RPCOutpoint{
+					TransactionID: coinbaseTransactionID.String(),
+					Index:         uint32(i),
+				},
+				Amount:              output.Value,
+				BlockHash:           blockHash.String(),
+				DAAScore:            acceptingBlockDAAScore,
+				SpendableAtDAAScore: acceptingBlockDAAScore + coinbaseMaturity,
+			})
+		}
+
+		if blockHash.Equal(context.Config.ActiveNetParams.GenesisHash) {
+			break
+		}
+		blockInfo, err := context.Domain.Consensus().GetBlockInfo(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		acceptingBlockDAAScore = block.Header.DAAScore()
+		blockHash = blockInfo.SelectedParent
+	}
+	return outputs, nil
+}
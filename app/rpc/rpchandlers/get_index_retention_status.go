@@ -0,0 +1,33 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetIndexRetentionStatus handles the respectively named RPC command
+func HandleGetIndexRetentionStatus(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	indexes := make([]*appmessage.IndexRetentionStatus, 0)
+	// The retention manager only exists if any index has a retention limit
+	if context.IndexRetentionManager != nil {
+		for _, status := range context.IndexRetentionManager.Statuses() {
+			lastPruneTimestamp := int64(0)
+			if !status.LastPruneTime.IsZero() {
+				lastPruneTimestamp = status.LastPruneTime.UnixMilli()
+			}
+			indexes = append(indexes, &appmessage.IndexRetentionStatus{
+				IndexName:             status.IndexName,
+				MaxAgeInSeconds:       uint64(status.MaxAge.Seconds()),
+				MaxSize:               status.MaxSize,
+				Size:                  status.Size,
+				ChainBlockCount:       status.ChainBlockCount,
+				PrunedChainBlockCount: status.PrunedChainBlockCount,
+				ReclaimedBytes:        status.ReclaimedBytes,
+				LastPruneTimestamp:    lastPruneTimestamp,
+			})
+		}
+	}
+
+	return appmessage.NewGetIndexRetentionStatusResponseMessage(indexes), nil
+}
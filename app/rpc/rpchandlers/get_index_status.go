@@ -0,0 +1,26 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetIndexStatus handles the respectively named RPC command
+func HandleGetIndexStatus(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getIndexStatusRequest := request.(*appmessage.GetIndexStatusRequestMessage)
+	index := context.OptionalIndexByName(getIndexStatusRequest.IndexName)
+	if index == nil {
+		errorMessage := &appmessage.GetIndexStatusResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Unknown index %s", getIndexStatusRequest.IndexName)
+		return errorMessage, nil
+	}
+
+	status, buildErr := index.Status()
+	buildError := ""
+	if buildErr != nil {
+		buildError = buildErr.Error()
+	}
+	return appmessage.NewGetIndexStatusResponseMessage(status, buildError), nil
+}
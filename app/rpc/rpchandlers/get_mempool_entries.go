@@ -12,6 +12,27 @@ func HandleGetMempoolEntries(context *rpccontext.Context, _ *router.Router, requ
 
 	entries := make([]*appmessage.MempoolEntry, 0)
 
+	if getMempoolEntriesRequest.Verbose {
+		// The details of all the entries are taken at once, so that their
+		// dependencies are consistent with each other
+		allEntryDetails := context.Domain.MiningManager().MempoolEntryDetails(nil,
+			!getMempoolEntriesRequest.FilterTransactionPool, getMempoolEntriesRequest.IncludeOrphanPool)
+		for _, entryDetails := range allEntryDetails {
+			rpcTransaction := appmessage.DomainTransactionToRPCTransaction(entryDetails.Transaction)
+			err := context.PopulateTransactionWithVerboseData(rpcTransaction, nil)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, &appmessage.MempoolEntry{
+				Fee:         entryDetails.Transaction.Fee,
+				Transaction: rpcTransaction,
+				IsOrphan:    entryDetails.IsOrphan,
+				VerboseData: mempoolEntryVerboseData(entryDetails),
+			})
+		}
+		return appmessage.NewGetMempoolEntriesResponseMessage(entries), nil
+	}
+
 	transactionPoolTransactions, orphanPoolTransactions := context.Domain.MiningManager().AllTransactions(!getMempoolEntriesRequest.FilterTransactionPool, getMempoolEntriesRequest.IncludeOrphanPool)
 
 	if !getMempoolEntriesRequest.FilterTransactionPool {
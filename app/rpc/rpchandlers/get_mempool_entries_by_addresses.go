@@ -26,7 +26,8 @@ func HandleGetMempoolEntriesByAddresses(context *rpccontext.Context, _ *router.R
 		address, err := util.DecodeAddress(addressString, context.Config.NetParams().Prefix)
 		if err != nil {
 			errorMessage := &appmessage.GetMempoolEntriesByAddressesResponseMessage{}
-			errorMessage.Error = appmessage.RPCErrorf("Could not decode address '%s': %s", addressString, err)
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+				"Could not decode address '%s': %s", addressString, err)
 			return errorMessage, nil
 		}
 
@@ -36,7 +37,8 @@ func HandleGetMempoolEntriesByAddresses(context *rpccontext.Context, _ *router.R
 		scriptPublicKey, err := txscript.PayToAddrScript(address)
 		if err != nil {
 			errorMessage := &appmessage.GetMempoolEntriesByAddressesResponseMessage{}
-			errorMessage.Error = appmessage.RPCErrorf("Could not extract scriptPublicKey from address '%s': %s", addressString, err)
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+				"Could not extract scriptPublicKey from address '%s': %s", addressString, err)
 			return errorMessage, nil
 		}
 
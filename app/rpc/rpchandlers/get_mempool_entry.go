@@ -5,30 +5,47 @@ import (
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionid"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 )
 
 // HandleGetMempoolEntry handles the respectively named RPC command
 func HandleGetMempoolEntry(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
 
-	transaction := &externalapi.DomainTransaction{}
+	var mempoolTransaction *externalapi.DomainTransaction
 	var found bool
 	var isOrphan bool
+	var verboseData *appmessage.MempoolEntryVerboseData
 
 	getMempoolEntryRequest := request.(*appmessage.GetMempoolEntryRequestMessage)
 
 	transactionID, err := transactionid.FromString(getMempoolEntryRequest.TxID)
 	if err != nil {
 		errorMessage := &appmessage.GetMempoolEntryResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Transaction ID could not be parsed: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Transaction ID could not be parsed: %s", err)
 		return errorMessage, nil
 	}
 
-	mempoolTransaction, isOrphan, found := context.Domain.MiningManager().GetTransaction(transactionID, !getMempoolEntryRequest.FilterTransactionPool, getMempoolEntryRequest.IncludeOrphanPool)
+	if getMempoolEntryRequest.Verbose {
+		entryDetails := context.Domain.MiningManager().MempoolEntryDetails(
+			[]*externalapi.DomainTransactionID{transactionID},
+			!getMempoolEntryRequest.FilterTransactionPool, getMempoolEntryRequest.IncludeOrphanPool)
+		found = len(entryDetails) > 0
+		if found {
+			mempoolTransaction = entryDetails[0].Transaction
+			isOrphan = entryDetails[0].IsOrphan
+			verboseData = mempoolEntryVerboseData(entryDetails[0])
+		}
+	} else {
+		mempoolTransaction, isOrphan, found = context.Domain.MiningManager().GetTransaction(transactionID,
+			!getMempoolEntryRequest.FilterTransactionPool, getMempoolEntryRequest.IncludeOrphanPool)
+	}
 
 	if !found {
 		errorMessage := &appmessage.GetMempoolEntryResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Transaction %s was not found", transactionID)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound, "Transaction %s was not found",
+			transactionID)
 		return errorMessage, nil
 	}
 
@@ -37,5 +54,15 @@ func HandleGetMempoolEntry(context *rpccontext.Context, _ *router.Router, reques
 	if err != nil {
 		return nil, err
 	}
-	return appmessage.NewGetMempoolEntryResponseMessage(transaction.Fee, rpcTransaction, isOrphan), nil
+	return appmessage.NewGetMempoolEntryResponseMessage(mempoolTransaction.Fee, rpcTransaction, isOrphan, verboseData), nil
+}
+
+func mempoolEntryVerboseData(entryDetails *miningmanagermodel.MempoolEntryDetails) *appmessage.MempoolEntryVerboseData {
+	return &appmessage.MempoolEntryVerboseData{
+		Mass:                  entryDetails.Transaction.Mass,
+		ArrivalTime:           entryDetails.AddedAt.UnixMilli(),
+		ArrivalDAAScore:       entryDetails.AddedAtDAAScore,
+		DependsTransactionIDs: transactionIDStrings(entryDetails.DependsIDs),
+		SpentByTransactionIDs: transactionIDStrings(entryDetails.SpentByIDs),
+	}
 }
@@ -0,0 +1,75 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionid"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetMempoolGraph handles the respectively named RPC command
+func HandleGetMempoolGraph(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getMempoolGraphRequest := request.(*appmessage.GetMempoolGraphRequestMessage)
+
+	transactionIDs := make([]*externalapi.DomainTransactionID, len(getMempoolGraphRequest.TransactionIDs))
+	for i, transactionIDString := range getMempoolGraphRequest.TransactionIDs {
+		transactionID, err := transactionid.FromString(transactionIDString)
+		if err != nil {
+			errorMessage := &appmessage.GetMempoolGraphResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+				"Transaction ID %s could not be parsed: %s", transactionIDString, err)
+			return errorMessage, nil
+		}
+		transactionIDs[i] = transactionID
+	}
+
+	graphEntries := context.Domain.MiningManager().TransactionGraph(transactionIDs,
+		getMempoolGraphRequest.IncludeOrphanPool, getMempoolGraphRequest.IncludeAncestry)
+
+	// The mempool skips the transactions it doesn't have, and returns the
+	// rest in the requested order
+	if len(graphEntries) < len(transactionIDs) {
+		for i, transactionID := range transactionIDs {
+			if i < len(graphEntries) && graphEntries[i].TransactionID.Equal(transactionID) {
+				continue
+			}
+			errorMessage := &appmessage.GetMempoolGraphResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound,
+				"Transaction %s was not found", transactionID)
+			return errorMessage, nil
+		}
+	}
+
+	entries := make([]*appmessage.MempoolGraphEntry, len(graphEntries))
+	for i, graphEntry := range graphEntries {
+		conflicts := make([]*appmessage.MempoolConflict, len(graphEntry.Conflicts))
+		for j, conflict := range graphEntry.Conflicts {
+			conflicts[j] = &appmessage.MempoolConflict{
+				Outpoint: &appmessage.RPCOutpoint{
+					TransactionID: conflict.Outpoint.TransactionID.String(),
+					Index:         conflict.Outpoint.Index,
+				},
+				ConflictingTransactionIDs: transactionIDStrings(conflict.ConflictingTransactionIDs),
+			}
+		}
+		entries[i] = &appmessage.MempoolGraphEntry{
+			TransactionID: graphEntry.TransactionID.String(),
+			IsOrphan:      graphEntry.IsOrphan,
+			ParentIDs:     transactionIDStrings(graphEntry.ParentIDs),
+			ChildIDs:      transactionIDStrings(graphEntry.ChildIDs),
+			AncestorIDs:   transactionIDStrings(graphEntry.AncestorIDs),
+			DescendantIDs: transactionIDStrings(graphEntry.DescendantIDs),
+			Conflicts:     conflicts,
+		}
+	}
+	return appmessage.NewGetMempoolGraphResponseMessage(entries), nil
+}
+
+func transactionIDStrings(transactionIDs []*externalapi.DomainTransactionID) []string {
+	transactionIDStrings := make([]string, len(transactionIDs))
+	for i, transactionID := range transactionIDs {
+		transactionIDStrings[i] = transactionID.String()
+	}
+	return transactionIDStrings
+}
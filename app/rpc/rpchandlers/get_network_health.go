@@ -0,0 +1,18 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetNetworkHealth handles the respectively named RPC command
+func HandleGetNetworkHealth(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	health := context.ConnectionManager.NetworkHealth()
+	var degradedSinceTimestamp int64
+	if !health.DegradedSince.IsZero() {
+		degradedSinceTimestamp = health.DegradedSince.UnixMilli()
+	}
+	return appmessage.NewGetNetworkHealthResponseMessage(health.IsDegraded, health.Reasons, degradedSinceTimestamp,
+		uint32(health.PeerCount), uint32(health.MinPeers), health.LastBlockTime.UnixMilli()), nil
+}
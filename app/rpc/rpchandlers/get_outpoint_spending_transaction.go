@@ -0,0 +1,51 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionid"
+	"github.com/kaspanet/kaspad/domain/stxoindex"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetOutpointSpendingTransaction handles the respectively named RPC command
+func HandleGetOutpointSpendingTransaction(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	index, rpcError := context.STXOIndex.Get()
+	if rpcError != nil {
+		errorMessage := &appmessage.GetOutpointSpendingTransactionResponseMessage{}
+		errorMessage.Error = rpcError
+		return errorMessage, nil
+	}
+	stxoIndex := index.(*stxoindex.STXOIndex)
+
+	getOutpointSpendingTransactionRequest := request.(*appmessage.GetOutpointSpendingTransactionRequestMessage)
+	if getOutpointSpendingTransactionRequest.Outpoint == nil {
+		errorMessage := &appmessage.GetOutpointSpendingTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Outpoint is required")
+		return errorMessage, nil
+	}
+
+	transactionID, err := transactionid.FromString(getOutpointSpendingTransactionRequest.Outpoint.TransactionID)
+	if err != nil {
+		errorMessage := &appmessage.GetOutpointSpendingTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Transaction ID could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+	outpoint := &externalapi.DomainOutpoint{
+		TransactionID: *transactionID,
+		Index:         getOutpointSpendingTransactionRequest.Outpoint.Index,
+	}
+
+	spendingTransaction, found, err := stxoIndex.SpendingTransaction(outpoint)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return appmessage.NewGetOutpointSpendingTransactionResponseMessage(false, "", ""), nil
+	}
+
+	return appmessage.NewGetOutpointSpendingTransactionResponseMessage(true,
+		spendingTransaction.TransactionID.String(), spendingTransaction.AcceptingBlockHash.String()), nil
+}
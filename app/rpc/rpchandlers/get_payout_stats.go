@@ -0,0 +1,39 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetPayoutStats handles the respectively named RPC command
+func HandleGetPayoutStats(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.PayoutWatcher == nil {
+		errorMessage := &appmessage.GetPayoutStatsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable,
+			"Method unavailable when kaspad is run without --payoutwatchaddress")
+		return errorMessage, nil
+	}
+
+	getPayoutStatsRequest := request.(*appmessage.GetPayoutStatsRequestMessage)
+	stats, err := context.PayoutWatcher.Stats(int(getPayoutStatsRequest.RecentBlockCount))
+	if err != nil {
+		return nil, err
+	}
+
+	recentBlocks := make([]*appmessage.PayoutBlock, len(stats.RecentBlocks))
+	for i, block := range stats.RecentBlocks {
+		recentBlocks[i] = &appmessage.PayoutBlock{
+			BlockHash:      block.Hash.String(),
+			Status:         block.Status,
+			DAAScore:       block.DAAScore,
+			Reward:         block.Reward,
+			IsRewardMature: block.IsRewardMature,
+		}
+		if block.MergingBlockHash != nil {
+			recentBlocks[i].MergingBlockHash = block.MergingBlockHash.String()
+		}
+	}
+	return appmessage.NewGetPayoutStatsResponseMessage(stats.Address, stats.BlueBlockCount, stats.RedBlockCount,
+		stats.PendingBlockCount, stats.OrphanBlockCount, stats.TotalReward, stats.MatureReward, recentBlocks), nil
+}
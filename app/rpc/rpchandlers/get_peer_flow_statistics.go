@@ -0,0 +1,48 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetPeerFlowStatistics handles the respectively named RPC command
+func HandleGetPeerFlowStatistics(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	peers := context.ProtocolManager.Peers()
+	anomalies := context.ProtocolManager.PeerFlowAnomalies()
+
+	peerFlowStatistics := make([]*appmessage.PeerFlowStatistics, 0, len(peers))
+	for _, peer := range peers {
+		flowStatistics := peer.FlowStatistics()
+		flows := make([]*appmessage.FlowStatistics, 0, len(peerpkg.Flows))
+		for _, flow := range peerpkg.Flows {
+			statistics := flowStatistics[flow]
+			flows = append(flows, &appmessage.FlowStatistics{
+				Flow:            string(flow),
+				Count:           statistics.Count,
+				AverageDuration: statistics.AverageDuration().Milliseconds(),
+				MaxDuration:     statistics.MaxDuration.Milliseconds(),
+			})
+		}
+
+		peerAnomalies := make([]*appmessage.PeerFlowAnomaly, len(anomalies[peer]))
+		for i, anomaly := range anomalies[peer] {
+			peerAnomalies[i] = &appmessage.PeerFlowAnomaly{
+				Flow:   string(anomaly.Flow),
+				Reason: anomaly.Reason,
+			}
+		}
+
+		repeatedBlockRequests, _ := peer.RepeatedBlockRequests()
+		peerFlowStatistics = append(peerFlowStatistics, &appmessage.PeerFlowStatistics{
+			ID:                    peer.ID().String(),
+			Address:               peer.Address(),
+			Flows:                 flows,
+			RepeatedBlockRequests: repeatedBlockRequests,
+			Anomalies:             peerAnomalies,
+		})
+	}
+
+	return appmessage.NewGetPeerFlowStatisticsResponseMessage(peerFlowStatistics), nil
+}
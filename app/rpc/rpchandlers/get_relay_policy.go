@@ -0,0 +1,31 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// payToPubKeyScriptPublicKey is a pay-to-pubkey scriptPublicKey, used to
+// report the dust threshold of the most common outputs. Only its size matters.
+var payToPubKeyScriptPublicKey = &externalapi.ScriptPublicKey{
+	Script: append(append([]byte{txscript.OpData32}, make([]byte, 32)...), txscript.OpCheckSig),
+}
+
+// HandleGetRelayPolicy handles the respectively named RPC command
+func HandleGetRelayPolicy(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	miningManager := context.Domain.MiningManager()
+	return appmessage.NewGetRelayPolicyResponseMessage(
+		context.Config.NetParams().Name,
+		context.Config.RelayNonStd,
+		string(context.Config.RelayNonStdSource()),
+		uint64(miningManager.MinimumRelayTransactionFee()),
+		context.Config.MaxOrphanTxs,
+		uint64(miningManager.DustRelayTransactionFee()),
+		miningManager.DustThreshold(payToPubKeyScriptPublicKey),
+		context.Config.MaxTxMass,
+		uint64(miningManager.EffectiveMinimumRelayTransactionFee()),
+	), nil
+}
@@ -0,0 +1,14 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetReorgedTransactionsStats handles the respectively named RPC command
+func HandleGetReorgedTransactionsStats(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	stats := context.ProtocolManager.ReorgedTransactionsStats()
+	return appmessage.NewGetReorgedTransactionsStatsResponseMessage(stats.ReorgCount, stats.ReorgedTransactionCount,
+		stats.ReinsertedTransactionCount, stats.DiscardedTransactionCount, stats.RebroadcastTransactionCount), nil
+}
@@ -0,0 +1,39 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetRPCSessions handles the respectively named RPC command
+func HandleGetRPCSessions(context *rpccontext.Context, router *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	rpcSessions := context.RPCSessions.All()
+	sessions := make([]*appmessage.RPCSession, len(rpcSessions))
+	for i, rpcSession := range rpcSessions {
+		netConnection := rpcSession.NetConnection
+		endpointName := ""
+		isAuthenticated := false
+		if rpcEndpoint := netConnection.RPCEndpoint(); rpcEndpoint != nil {
+			endpointName = rpcEndpoint.Name
+			isAuthenticated = rpcEndpoint.AuthToken != ""
+		}
+		subscriptions, utxosChangedAddressCount := context.NotificationManager.Subscriptions(rpcSession.Router)
+
+		sessions[i] = &appmessage.RPCSession{
+			ID:                       rpcSession.ID,
+			Address:                  netConnection.Address(),
+			EndpointName:             endpointName,
+			IsAuthenticated:          isAuthenticated,
+			ConnectedAt:              rpcSession.ConnectedAt.UnixMilli(),
+			APIVersion:               context.APIVersions.Version(rpcSession.Router),
+			Subscriptions:            subscriptions,
+			UTXOsChangedAddressCount: uint64(utxosChangedAddressCount),
+			ReceivedMessageCount:     netConnection.ReceivedMessageCount(),
+			SentMessageCount:         netConnection.SentMessageCount(),
+			IsCurrentSession:         rpcSession.Router == router,
+		}
+	}
+
+	return appmessage.NewGetRPCSessionsResponseMessage(sessions), nil
+}
@@ -0,0 +1,75 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/scriptclassindex"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// maxScriptClassStatisticsWindows is the maximum amount of windows
+// that may be requested in a single GetScriptClassStatistics call
+const maxScriptClassStatisticsWindows = 1000
+
+// HandleGetScriptClassStatistics handles the respectively named RPC command
+func HandleGetScriptClassStatistics(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	index, rpcError := context.ScriptClassIndex.Get()
+	if rpcError != nil {
+		errorMessage := &appmessage.GetScriptClassStatisticsResponseMessage{}
+		errorMessage.Error = rpcError
+		return errorMessage, nil
+	}
+	scriptClassIndex := index.(*scriptclassindex.ScriptClassIndex)
+
+	getScriptClassStatisticsRequest := request.(*appmessage.GetScriptClassStatisticsRequestMessage)
+	startBlueScore := getScriptClassStatisticsRequest.StartBlueScore
+	endBlueScore := getScriptClassStatisticsRequest.EndBlueScore
+	if endBlueScore == 0 {
+		virtualSelectedParent, err := context.Domain.Consensus().GetVirtualSelectedParent()
+		if err != nil {
+			return nil, err
+		}
+		virtualSelectedParentInfo, err := context.Domain.Consensus().GetBlockInfo(virtualSelectedParent)
+		if err != nil {
+			return nil, err
+		}
+		endBlueScore = virtualSelectedParentInfo.BlueScore
+	}
+	if startBlueScore > endBlueScore {
+		errorMessage := &appmessage.GetScriptClassStatisticsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"startBlueScore %d is greater than endBlueScore %d", startBlueScore, endBlueScore)
+		return errorMessage, nil
+	}
+	windowCount := endBlueScore/scriptclassindex.WindowSize - startBlueScore/scriptclassindex.WindowSize + 1
+	if windowCount > maxScriptClassStatisticsWindows {
+		errorMessage := &appmessage.GetScriptClassStatisticsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"The requested range spans %d windows, while at most %d windows may be requested at once",
+			windowCount, maxScriptClassStatisticsWindows)
+		return errorMessage, nil
+	}
+
+	windows, err := scriptClassIndex.Windows(startBlueScore, endBlueScore)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcWindows := make([]*appmessage.ScriptClassStatisticsWindow, len(windows))
+	for i, window := range windows {
+		scriptClasses := make([]*appmessage.ScriptClassStatistics, len(window.Statistics))
+		for scriptClass, statistics := range window.Statistics {
+			scriptClasses[scriptClass] = &appmessage.ScriptClassStatistics{
+				ScriptClass: txscript.ScriptClass(scriptClass).String(),
+				OutputCount: statistics.OutputCount,
+				TotalAmount: statistics.TotalAmount,
+			}
+		}
+		rpcWindows[i] = &appmessage.ScriptClassStatisticsWindow{
+			StartBlueScore: window.StartBlueScore,
+			ScriptClasses:  scriptClasses,
+		}
+	}
+	return appmessage.NewGetScriptClassStatisticsResponseMessage(scriptclassindex.WindowSize, rpcWindows), nil
+}
@@ -9,6 +9,6 @@ import (
 // HandleGetSubnetwork handles the respectively named RPC command
 func HandleGetSubnetwork(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	response := &appmessage.GetSubnetworkResponseMessage{}
-	response.Error = appmessage.RPCErrorf("not implemented")
+	response.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotImplemented, "not implemented")
 	return response, nil
 }
@@ -0,0 +1,140 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionid"
+	"github.com/kaspanet/kaspad/domain/txindex"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetTransaction handles the respectively named RPC command
+func HandleGetTransaction(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	index, rpcError := context.TXIndex.Get()
+	if rpcError != nil {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = rpcError
+		return errorMessage, nil
+	}
+	txIndex := index.(*txindex.TXIndex)
+
+	getTransactionRequest := request.(*appmessage.GetTransactionRequestMessage)
+	if !getTransactionRequest.Verbosity.IsValid() {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Unknown verbosity %d", getTransactionRequest.Verbosity)
+		return errorMessage, nil
+	}
+	transactionID, err := transactionid.FromString(getTransactionRequest.TransactionID)
+	if err != nil {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Transaction ID could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	transactionBlocks, found, err := txIndex.TransactionBlocks(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		mempoolTransaction, _, found := context.Domain.MiningManager().GetTransaction(transactionID, true, false)
+		if !found {
+			errorMessage := &appmessage.GetTransactionResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound,
+				"Transaction %s was not found", transactionID)
+			return errorMessage, nil
+		}
+		rpcTransaction := appmessage.DomainTransactionToRPCTransaction(mempoolTransaction)
+		err := context.PopulateTransactionWithVerboseData(rpcTransaction, nil)
+		if err != nil {
+			return nil, err
+		}
+		rpcTransaction, err = context.ApplyTransactionVerbosity(rpcTransaction, getTransactionRequest.Verbosity)
+		if err != nil {
+			return nil, err
+		}
+		return appmessage.NewGetTransactionResponseMessage(rpcTransaction, []string{}, "", 0, true), nil
+	}
+
+	rpcTransaction, found, err := transactionFromIncludingBlocks(context, transactionID, transactionBlocks.IncludingBlockHashes)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound,
+			"All the blocks that include transaction %s were pruned", transactionID)
+		return errorMessage, nil
+	}
+	rpcTransaction, err = context.ApplyTransactionVerbosity(rpcTransaction, getTransactionRequest.Verbosity)
+	if err != nil {
+		return nil, err
+	}
+
+	includingBlockHashes := make([]string, len(transactionBlocks.IncludingBlockHashes))
+	for i, blockHash := range transactionBlocks.IncludingBlockHashes {
+		includingBlockHashes[i] = blockHash.String()
+	}
+
+	if transactionBlocks.AcceptingBlockHash == nil {
+		return appmessage.NewGetTransactionResponseMessage(rpcTransaction, includingBlockHashes, "", 0, false), nil
+	}
+	confirmations, err := confirmationsOf(context, transactionBlocks.AcceptingBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	return appmessage.NewGetTransactionResponseMessage(rpcTransaction, includingBlockHashes,
+		transactionBlocks.AcceptingBlockHash.String(), confirmations, false), nil
+}
+
+// transactionFromIncludingBlocks returns the transaction with the given ID, with its verbose
+// data, from the first of the given blocks whose body wasn't pruned
+func transactionFromIncludingBlocks(context *rpccontext.Context, transactionID *externalapi.DomainTransactionID,
+	includingBlockHashes []*externalapi.DomainHash) (*appmessage.RPCTransaction, bool, error) {
+
+	for _, blockHash := range includingBlockHashes {
+		block, found, err := context.Domain.Consensus().GetBlock(blockHash)
+		if err != nil {
+			return nil, false, err
+		}
+		if !found {
+			continue
+		}
+		for _, transaction := range block.Transactions {
+			if !consensushashing.TransactionID(transaction).Equal(transactionID) {
+				continue
+			}
+			rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction)
+			err := context.PopulateTransactionWithVerboseData(rpcTransaction, block.Header)
+			if err != nil {
+				return nil, false, err
+			}
+			return rpcTransaction, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// confirmationsOf returns the blue score of the virtual selected parent
+// minus the blue score of the given block
+func confirmationsOf(context *rpccontext.Context, blockHash *externalapi.DomainHash) (uint64, error) {
+	virtualSelectedParent, err := context.Domain.Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		return 0, err
+	}
+	virtualSelectedParentInfo, err := context.Domain.Consensus().GetBlockInfo(virtualSelectedParent)
+	if err != nil {
+		return 0, err
+	}
+	blockInfo, err := context.Domain.Consensus().GetBlockInfo(blockHash)
+	if err != nil {
+		return 0, err
+	}
+	if blockInfo.BlueScore > virtualSelectedParentInfo.BlueScore {
+		return 0, nil
+	}
+	return virtualSelectedParentInfo.BlueScore - blockInfo.BlueScore, nil
+}
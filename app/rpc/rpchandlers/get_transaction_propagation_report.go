@@ -0,0 +1,51 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionid"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetTransactionPropagationReport handles the respectively named RPC command
+func HandleGetTransactionPropagationReport(context *rpccontext.Context, _ *router.Router,
+	request appmessage.Message) (appmessage.Message, error) {
+
+	getTransactionPropagationReportRequest := request.(*appmessage.GetTransactionPropagationReportRequestMessage)
+
+	transactionID, err := transactionid.FromString(getTransactionPropagationReportRequest.TransactionID)
+	if err != nil {
+		errorMessage := &appmessage.GetTransactionPropagationReportResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Transaction ID could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	report, ok := context.ProtocolManager.TransactionPropagationReport(transactionID)
+	if !ok {
+		errorMessage := &appmessage.GetTransactionPropagationReportResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound,
+			"No propagation report was found for transaction %s. Reports are only kept for transactions "+
+				"submitted to this node within the last hour", transactionID)
+		return errorMessage, nil
+	}
+
+	return appmessage.NewGetTransactionPropagationReportResponseMessage(transactionID.String(),
+		report.SubmittedAt.UnixMilliseconds(), uint32(report.AnnouncedPeerCount()), uint32(report.RequestedPeerCount()),
+		transactionPropagationEventsToAppMessage(report.Announcements),
+		transactionPropagationEventsToAppMessage(report.Requests)), nil
+}
+
+func transactionPropagationEventsToAppMessage(
+	events []*flowcontext.TransactionPropagationEvent) []*appmessage.TransactionPropagationEvent {
+
+	appEvents := make([]*appmessage.TransactionPropagationEvent, len(events))
+	for i, event := range events {
+		appEvents[i] = &appmessage.TransactionPropagationEvent{
+			PeerAddress: event.PeerAddress,
+			Timestamp:   event.Timestamp.UnixMilliseconds(),
+		}
+	}
+	return appEvents
+}
@@ -0,0 +1,63 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/addrindex"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// maxTransactionsByAddress is the maximum amount of transactions
+// returned by a single GetTransactionsByAddress request
+const maxTransactionsByAddress = 1000
+
+// HandleGetTransactionsByAddress handles the respectively named RPC command
+func HandleGetTransactionsByAddress(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	index, rpcError := context.AddrIndex.Get()
+	if rpcError != nil {
+		errorMessage := &appmessage.GetTransactionsByAddressResponseMessage{}
+		errorMessage.Error = rpcError
+		return errorMessage, nil
+	}
+	addrIndex := index.(*addrindex.AddrIndex)
+
+	getTransactionsByAddressRequest := request.(*appmessage.GetTransactionsByAddressRequestMessage)
+	address, err := util.DecodeAddress(getTransactionsByAddressRequest.Address, context.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		errorMessage := &appmessage.GetTransactionsByAddressResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Could not decode address '%s': %s", getTransactionsByAddressRequest.Address, err)
+		return errorMessage, nil
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		errorMessage := &appmessage.GetTransactionsByAddressResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Could not create a scriptPublicKey for address '%s': %s", getTransactionsByAddressRequest.Address, err)
+		return errorMessage, nil
+	}
+
+	limit := getTransactionsByAddressRequest.Limit
+	if limit == 0 || limit > maxTransactionsByAddress {
+		limit = maxTransactionsByAddress
+	}
+	addressTransactions, hasMore, err := addrIndex.AddressTransactions(scriptPublicKey,
+		getTransactionsByAddressRequest.Offset, uint64(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	rpcTransactions := make([]*appmessage.AddressTransaction, len(addressTransactions))
+	for i, addressTransaction := range addressTransactions {
+		rpcTransactions[i] = &appmessage.AddressTransaction{
+			TransactionID:           addressTransaction.TransactionID.String(),
+			AcceptingBlockHash:      addressTransaction.AcceptingBlockHash.String(),
+			AcceptingBlockBlueScore: addressTransaction.AcceptingBlockBlueScore,
+			Received:                addressTransaction.Received,
+			Sent:                    addressTransaction.Sent,
+		}
+	}
+	return appmessage.NewGetTransactionsByAddressResponseMessage(rpcTransactions, hasMore), nil
+}
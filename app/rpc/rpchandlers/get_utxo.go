@@ -0,0 +1,62 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/txindex"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetUTXO handles the respectively named RPC command
+func HandleGetUTXO(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getUTXORequest := request.(*appmessage.GetUTXORequestMessage)
+	if getUTXORequest.Outpoint == nil {
+		errorMessage := &appmessage.GetUTXOResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Outpoint is required")
+		return errorMessage, nil
+	}
+	outpoint, err := appmessage.RPCOutpointToDomainOutpoint(getUTXORequest.Outpoint)
+	if err != nil {
+		errorMessage := &appmessage.GetUTXOResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Transaction ID could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	if getUTXORequest.IncludeMempool {
+		miningManager := context.Domain.MiningManager()
+		spender, found := miningManager.GetOutpointSpender(outpoint)
+		if found {
+			return appmessage.NewGetUTXOResponseMessage(false, nil, false,
+				consensushashing.TransactionID(spender).String(), ""), nil
+		}
+		entry, found := miningManager.GetUTXOEntry(outpoint)
+		if found {
+			return appmessage.NewGetUTXOResponseMessage(true, appmessage.UTXOEntryToRPCUTXOEntry(entry), true,
+				"", ""), nil
+		}
+	}
+
+	entry, found, err := context.Domain.Consensus().GetVirtualUTXO(outpoint)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return appmessage.NewGetUTXOResponseMessage(false, nil, false, "", ""), nil
+	}
+
+	acceptingBlockHash := ""
+	if index, rpcError := context.TXIndex.Get(); rpcError == nil {
+		transactionBlocks, found, err := index.(*txindex.TXIndex).TransactionBlocks(&outpoint.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		if found && transactionBlocks.AcceptingBlockHash != nil {
+			acceptingBlockHash = transactionBlocks.AcceptingBlockHash.String()
+		}
+	}
+
+	return appmessage.NewGetUTXOResponseMessage(true, appmessage.UTXOEntryToRPCUTXOEntry(entry), false,
+		"", acceptingBlockHash), nil
+}
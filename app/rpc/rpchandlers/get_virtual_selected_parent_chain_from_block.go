@@ -14,14 +14,14 @@ func HandleGetVirtualSelectedParentChainFromBlock(context *rpccontext.Context, _
 	startHash, err := externalapi.NewDomainHashFromString(getVirtualSelectedParentChainFromBlockRequest.StartHash)
 	if err != nil {
 		errorMessage := &appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Could not parse startHash: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not parse startHash: %s", err)
 		return errorMessage, nil
 	}
 
 	virtualSelectedParentChain, err := context.Domain.Consensus().GetVirtualSelectedParentChainFromBlock(startHash)
 	if err != nil {
 		response := &appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage{}
-		response.Error = appmessage.RPCErrorf("Could not build virtual "+
+		response.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not build virtual "+
 			"selected parent chain from %s: %s", getVirtualSelectedParentChainFromBlockRequest.StartHash, err)
 		return response, nil
 	}
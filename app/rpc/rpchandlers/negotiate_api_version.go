@@ -0,0 +1,31 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleNegotiateAPIVersion handles the respectively named RPC command
+func HandleNegotiateAPIVersion(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	negotiateAPIVersionRequest := request.(*appmessage.NegotiateAPIVersionRequestMessage)
+
+	if negotiateAPIVersionRequest.Version < appmessage.MinRPCAPIVersion {
+		errorMessage := &appmessage.NegotiateAPIVersionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeUnsupportedAPIVersion,
+			"RPC API version %d is no longer supported. The supported versions are %d to %d",
+			negotiateAPIVersionRequest.Version, appmessage.MinRPCAPIVersion, appmessage.MaxRPCAPIVersion)
+		return errorMessage, nil
+	}
+
+	// Clients that are newer than this kaspad get the newest version it supports,
+	// and may decide for themselves whether they can work with it
+	version := negotiateAPIVersionRequest.Version
+	if version > appmessage.MaxRPCAPIVersion {
+		version = appmessage.MaxRPCAPIVersion
+	}
+	context.APIVersions.SetVersion(router, version)
+
+	return appmessage.NewNegotiateAPIVersionResponseMessage(version,
+		appmessage.MinRPCAPIVersion, appmessage.MaxRPCAPIVersion), nil
+}
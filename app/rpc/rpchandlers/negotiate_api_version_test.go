@@ -0,0 +1,90 @@
+package rpchandlers_test
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/app/rpc/rpchandlers"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+func TestHandleNegotiateAPIVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		requested       uint32
+		expectedVersion uint32
+		expectsError    bool
+	}{
+		{name: "oldest version", requested: appmessage.MinRPCAPIVersion, expectedVersion: appmessage.MinRPCAPIVersion},
+		{name: "newest version", requested: appmessage.MaxRPCAPIVersion, expectedVersion: appmessage.MaxRPCAPIVersion},
+		{name: "newer than supported", requested: appmessage.MaxRPCAPIVersion + 1, expectedVersion: appmessage.MaxRPCAPIVersion},
+		{name: "older than supported", requested: appmessage.MinRPCAPIVersion - 1, expectsError: true},
+	}
+	for _, test := range tests {
+		context := &rpccontext.Context{APIVersions: rpccontext.NewAPIVersions()}
+		rpcRouter := router.NewRouter("test")
+
+		response, err := rpchandlers.HandleNegotiateAPIVersion(context, rpcRouter,
+			appmessage.NewNegotiateAPIVersionRequestMessage(test.requested))
+		if err != nil {
+			t.Fatalf("%s: HandleNegotiateAPIVersion: %+v", test.name, err)
+		}
+		negotiateAPIVersionResponse := response.(*appmessage.NegotiateAPIVersionResponseMessage)
+		if test.expectsError {
+			if negotiateAPIVersionResponse.Error == nil ||
+				negotiateAPIVersionResponse.Error.Code != appmessage.RPCErrorCodeUnsupportedAPIVersion {
+
+				t.Fatalf("%s: expected an UnsupportedAPIVersion error, but got %+v",
+					test.name, negotiateAPIVersionResponse.Error)
+			}
+			if context.APIVersions.Version(rpcRouter) != appmessage.RPCAPIVersion1 {
+				t.Fatalf("%s: a failed negotiation changed the version", test.name)
+			}
+			continue
+		}
+		if negotiateAPIVersionResponse.Error != nil {
+			t.Fatalf("%s: unexpected error: %s", test.name, negotiateAPIVersionResponse.Error.Message)
+		}
+		if negotiateAPIVersionResponse.Version != test.expectedVersion ||
+			negotiateAPIVersionResponse.MinVersion != appmessage.MinRPCAPIVersion ||
+			negotiateAPIVersionResponse.MaxVersion != appmessage.MaxRPCAPIVersion {
+
+			t.Fatalf("%s: unexpected response %+v", test.name, negotiateAPIVersionResponse)
+		}
+		if context.APIVersions.Version(rpcRouter) != test.expectedVersion {
+			t.Fatalf("%s: expected the connection to have version %d, but got %d",
+				test.name, test.expectedVersion, context.APIVersions.Version(rpcRouter))
+		}
+	}
+}
+
+func TestHandleGetBalanceByAddressErrorPerAPIVersion(t *testing.T) {
+	context := &rpccontext.Context{
+		Config:      &config.Config{Flags: &config.Flags{UTXOIndex: false}},
+		APIVersions: rpccontext.NewAPIVersions(),
+	}
+	rpcRouter := router.NewRouter("test")
+	request := appmessage.NewGetBalanceByAddressRequest("kaspa:invalid")
+
+	// Connections that didn't negotiate a version keep getting errors in a GetUTXOsByAddresses response
+	response, err := rpchandlers.HandleGetBalanceByAddress(context, rpcRouter, request)
+	if err != nil {
+		t.Fatalf("HandleGetBalanceByAddress: %+v", err)
+	}
+	legacyResponse, ok := response.(*appmessage.GetUTXOsByAddressesResponseMessage)
+	if !ok || legacyResponse.Error == nil {
+		t.Fatalf("Expected an error in a GetUTXOsByAddresses response, but got %+v", response)
+	}
+
+	context.APIVersions.SetVersion(rpcRouter, appmessage.RPCAPIVersion2)
+	response, err = rpchandlers.HandleGetBalanceByAddress(context, rpcRouter, request)
+	if err != nil {
+		t.Fatalf("HandleGetBalanceByAddress: %+v", err)
+	}
+	balanceResponse, ok := response.(*appmessage.GetBalanceByAddressResponseMessage)
+	if !ok || balanceResponse.Error == nil {
+		t.Fatalf("Expected an error in a GetBalanceByAddress response, but got %+v", response)
+	}
+}
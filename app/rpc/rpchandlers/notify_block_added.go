@@ -1,19 +1,47 @@
 package rpchandlers
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 )
 
 // HandleNotifyBlockAdded handles the respectively named RPC command
-func HandleNotifyBlockAdded(context *rpccontext.Context, router *router.Router, _ appmessage.Message) (appmessage.Message, error) {
-	listener, err := context.NotificationManager.Listener(router)
+func HandleNotifyBlockAdded(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	notifyBlockAddedRequest := request.(*appmessage.NotifyBlockAddedRequestMessage)
+
+	coalesceInterval, rpcError := notificationCoalesceInterval(notifyBlockAddedRequest.CoalesceIntervalInMilliseconds)
+	if rpcError != nil {
+		errorMessage := appmessage.NewNotifyBlockAddedResponseMessage()
+		errorMessage.Error = rpcError
+		return errorMessage, nil
+	}
+	var getSnapshot func() (*appmessage.DAGSnapshotNotificationMessage, error)
+	if notifyBlockAddedRequest.IncludeSnapshot {
+		getSnapshot = context.DAGSnapshot
+	}
+
+	err := context.NotificationManager.Subscribe(router, func(listener *rpccontext.NotificationListener) {
+		listener.PropagateBlockAddedNotifications(coalesceInterval)
+	}, getSnapshot)
 	if err != nil {
 		return nil, err
 	}
-	listener.PropagateBlockAddedNotifications()
 
 	response := appmessage.NewNotifyBlockAddedResponseMessage()
 	return response, nil
 }
+
+// notificationCoalesceInterval converts the coalesce interval of a
+// notification request, or returns the error to reject the request with
+func notificationCoalesceInterval(coalesceIntervalInMilliseconds uint32) (time.Duration, *appmessage.RPCError) {
+	coalesceInterval := time.Duration(coalesceIntervalInMilliseconds) * time.Millisecond
+	if coalesceInterval > rpccontext.MaxNotificationCoalesceInterval {
+		return 0, appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Coalesce interval %s is longer than the maximum of %s",
+			coalesceInterval, rpccontext.MaxNotificationCoalesceInterval)
+	}
+	return coalesceInterval, nil
+}
@@ -0,0 +1,70 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// HandleNotifyBlueScoreReached handles the respectively named RPC command
+func HandleNotifyBlueScoreReached(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	notifyBlueScoreReachedRequest := request.(*appmessage.NotifyBlueScoreReachedRequestMessage)
+
+	targetBlueScore := notifyBlueScoreReachedRequest.BlueScore
+	if notifyBlueScoreReachedRequest.BlockHash != "" {
+		if notifyBlueScoreReachedRequest.BlueScore != 0 {
+			errorMessage := &appmessage.NotifyBlueScoreReachedResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+				"Only one of blueScore and blockHash may be set")
+			return errorMessage, nil
+		}
+		blockHash, err := externalapi.NewDomainHashFromString(notifyBlueScoreReachedRequest.BlockHash)
+		if err != nil {
+			errorMessage := &appmessage.NotifyBlueScoreReachedResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Hash could not be parsed: %s",
+				err)
+			return errorMessage, nil
+		}
+		blockInfo, err := context.Domain.Consensus().GetBlockInfo(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		if !blockInfo.HasHeader() {
+			errorMessage := &appmessage.NotifyBlueScoreReachedResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound, "Block %s not found", blockHash)
+			return errorMessage, nil
+		}
+		targetBlueScore = blockInfo.BlueScore + notifyBlueScoreReachedRequest.Confirmations
+	} else if notifyBlueScoreReachedRequest.Confirmations != 0 {
+		errorMessage := &appmessage.NotifyBlueScoreReachedResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Confirmations may only be set along with blockHash")
+		return errorMessage, nil
+	}
+
+	virtualSelectedParent, err := context.Domain.Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	virtualSelectedParentInfo, err := context.Domain.Consensus().GetBlockInfo(virtualSelectedParent)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := appmessage.NewBlueScoreReachedNotificationMessage(notifyBlueScoreReachedRequest.ID,
+		notifyBlueScoreReachedRequest.BlockHash, notifyBlueScoreReachedRequest.Confirmations, targetBlueScore, 0)
+	err = context.NotificationManager.ScheduleBlueScoreReachedNotification(router, notification,
+		virtualSelectedParentInfo.BlueScore)
+	if err != nil {
+		if errors.Is(err, rpccontext.ErrTooManyPendingBlueScoreReachedNotifications) {
+			errorMessage := &appmessage.NotifyBlueScoreReachedResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeRateLimited, "%s", err)
+			return errorMessage, nil
+		}
+		return nil, err
+	}
+
+	return appmessage.NewNotifyBlueScoreReachedResponseMessage(targetBlueScore), nil
+}
@@ -4,16 +4,39 @@ import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
 )
 
 // HandleNotifyNewBlockTemplate handles the respectively named RPC command
-func HandleNotifyNewBlockTemplate(context *rpccontext.Context, router *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+func HandleNotifyNewBlockTemplate(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	notifyNewBlockTemplateRequest := request.(*appmessage.NotifyNewBlockTemplateRequestMessage)
+
 	listener, err := context.NotificationManager.Listener(router)
 	if err != nil {
 		return nil, err
 	}
-	listener.PropagateNewBlockTemplateNotifications()
 
-	response := appmessage.NewNotifyNewBlockTemplateResponseMessage()
-	return response, nil
+	if notifyNewBlockTemplateRequest.PayAddress == "" {
+		listener.PropagateNewBlockTemplateNotifications()
+		return appmessage.NewNotifyNewBlockTemplateResponseMessage(), nil
+	}
+
+	// Build a template once, so that an invalid request fails now rather
+	// than with every template
+	blockTemplateRequest := rpccontext.BlockTemplateRequest{
+		PayAddress: notifyNewBlockTemplateRequest.PayAddress,
+		ExtraData:  notifyNewBlockTemplateRequest.ExtraData,
+	}
+	_, _, err = context.BuildBlockTemplate(blockTemplateRequest)
+	if err != nil {
+		if !errors.Is(err, rpccontext.ErrInvalidBlockTemplateRequest) {
+			return nil, err
+		}
+		errorMessage := appmessage.NewNotifyNewBlockTemplateResponseMessage()
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "%s", err)
+		return errorMessage, nil
+	}
+	listener.PropagateNewBlockTemplateNotificationsWithTemplates(blockTemplateRequest)
+
+	return appmessage.NewNotifyNewBlockTemplateResponseMessage(), nil
 }
@@ -10,7 +10,8 @@ import (
 func HandleNotifyUTXOsChanged(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	if !context.Config.UTXOIndex {
 		errorMessage := appmessage.NewNotifyUTXOsChangedResponseMessage()
-		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when kaspad is run without --utxoindex")
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable,
+			"Method unavailable when kaspad is run without --utxoindex")
 		return errorMessage, nil
 	}
 
@@ -18,7 +19,7 @@ func HandleNotifyUTXOsChanged(context *rpccontext.Context, router *router.Router
 	addresses, err := context.ConvertAddressStringsToUTXOsChangedNotificationAddresses(notifyUTXOsChangedRequest.Addresses)
 	if err != nil {
 		errorMessage := appmessage.NewNotifyUTXOsChangedResponseMessage()
-		errorMessage.Error = appmessage.RPCErrorf("Parsing error: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Parsing error: %s", err)
 		return errorMessage, nil
 	}
 
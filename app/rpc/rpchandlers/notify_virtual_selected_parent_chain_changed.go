@@ -12,12 +12,25 @@ func HandleNotifyVirtualSelectedParentChainChanged(context *rpccontext.Context,
 
 	notifyVirtualSelectedParentChainChangedRequest := request.(*appmessage.NotifyVirtualSelectedParentChainChangedRequestMessage)
 
-	listener, err := context.NotificationManager.Listener(router)
+	coalesceInterval, rpcError := notificationCoalesceInterval(
+		notifyVirtualSelectedParentChainChangedRequest.CoalesceIntervalInMilliseconds)
+	if rpcError != nil {
+		errorMessage := appmessage.NewNotifyVirtualSelectedParentChainChangedResponseMessage()
+		errorMessage.Error = rpcError
+		return errorMessage, nil
+	}
+	var getSnapshot func() (*appmessage.DAGSnapshotNotificationMessage, error)
+	if notifyVirtualSelectedParentChainChangedRequest.IncludeSnapshot {
+		getSnapshot = context.DAGSnapshot
+	}
+
+	err := context.NotificationManager.Subscribe(router, func(listener *rpccontext.NotificationListener) {
+		listener.PropagateVirtualSelectedParentChainChangedNotifications(
+			notifyVirtualSelectedParentChainChangedRequest.IncludeAcceptedTransactionIDs, coalesceInterval)
+	}, getSnapshot)
 	if err != nil {
 		return nil, err
 	}
-	listener.PropagateVirtualSelectedParentChainChangedNotifications(
-		notifyVirtualSelectedParentChainChangedRequest.IncludeAcceptedTransactionIDs)
 
 	response := appmessage.NewNotifyVirtualSelectedParentChainChangedResponseMessage()
 	return response, nil
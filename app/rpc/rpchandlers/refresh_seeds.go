@@ -0,0 +1,27 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleRefreshSeeds handles the respectively named RPC command
+func HandleRefreshSeeds(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("RefreshSeeds RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.RefreshSeedsResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"RefreshSeeds RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	newAddressCount, isComplete, err := context.ConnectionManager.RefreshSeeds()
+	if err != nil {
+		errorMessage := &appmessage.RefreshSeedsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable, "Could not refresh the seeds: %s", err)
+		return errorMessage, nil
+	}
+	return appmessage.NewRefreshSeedsResponseMessage(newAddressCount, isComplete), nil
+}
@@ -0,0 +1,85 @@
+package rpchandlers
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/pkg/errors"
+)
+
+func TestBlockRejectionErrorCode(t *testing.T) {
+	tests := []struct {
+		err          error
+		expectedCode appmessage.RPCErrorCode
+	}{
+		{err: errors.Wrap(ruleerrors.ErrDuplicateBlock, "block already exists"), expectedCode: appmessage.RPCErrorCodeBlockDuplicate},
+		{err: ruleerrors.NewErrMissingParents(nil), expectedCode: appmessage.RPCErrorCodeBlockMissingParents},
+		{err: errors.Wrap(ruleerrors.ErrBadMerkleRoot, "bad merkle root"), expectedCode: appmessage.RPCErrorCodeBlockInvalid},
+	}
+	for _, test := range tests {
+		code := blockRejectionErrorCode(test.err)
+		if code != test.expectedCode {
+			t.Errorf("%s: expected %s, but got %s", test.err, test.expectedCode, code)
+		}
+	}
+}
+
+func TestTransactionRejectionErrorCode(t *testing.T) {
+	tests := []struct {
+		rejectCode   mempool.RejectCode
+		expectedCode appmessage.RPCErrorCode
+	}{
+		{rejectCode: mempool.RejectDuplicate, expectedCode: appmessage.RPCErrorCodeTransactionDuplicate},
+		{rejectCode: mempool.RejectNonstandard, expectedCode: appmessage.RPCErrorCodeTransactionNonStandard},
+		{rejectCode: mempool.RejectDust, expectedCode: appmessage.RPCErrorCodeTransactionNonStandard},
+		{rejectCode: mempool.RejectInsufficientFee, expectedCode: appmessage.RPCErrorCodeTransactionInsufficientFee},
+		{rejectCode: mempool.RejectBadOrphan, expectedCode: appmessage.RPCErrorCodeTransactionOrphan},
+		{rejectCode: mempool.RejectImmatureSpend, expectedCode: appmessage.RPCErrorCodeTransactionImmatureSpend},
+		{rejectCode: mempool.RejectFinality, expectedCode: appmessage.RPCErrorCodeTransactionNotFinal},
+		{rejectCode: mempool.RejectNonCanonicalPush, expectedCode: appmessage.RPCErrorCodeTransactionNonCanonical},
+		{rejectCode: mempool.RejectNonCanonicalSignature, expectedCode: appmessage.RPCErrorCodeTransactionNonCanonical},
+		{rejectCode: mempool.RejectInvalid, expectedCode: appmessage.RPCErrorCodeTransactionInvalid},
+	}
+	for _, test := range tests {
+		err := mempool.RuleError{Err: mempool.TxRuleError{RejectCode: test.rejectCode}}
+		code := transactionRejectionErrorCode(errors.WithStack(err))
+		if code != test.expectedCode {
+			t.Errorf("%s: expected %s, but got %s", test.rejectCode, test.expectedCode, code)
+		}
+	}
+
+	// Consensus rule errors wrapped in a mempool rule error carry no reject code
+	err := mempool.RuleError{Err: ruleerrors.ErrImmatureSpend}
+	code := transactionRejectionErrorCode(err)
+	if code != appmessage.RPCErrorCodeTransactionInvalid {
+		t.Errorf("expected %s, but got %s", appmessage.RPCErrorCodeTransactionInvalid, code)
+	}
+}
+
+func TestIsTransactionRejectionFinal(t *testing.T) {
+	tests := []struct {
+		err     error
+		isFinal bool
+	}{
+		{err: mempool.RuleError{Err: mempool.TxRuleError{RejectCode: mempool.RejectInvalid}}, isFinal: true},
+		{err: mempool.RuleError{Err: mempool.TxRuleError{RejectCode: mempool.RejectNonstandard}}, isFinal: true},
+		{err: mempool.RuleError{Err: mempool.TxRuleError{RejectCode: mempool.RejectDust}}, isFinal: true},
+		{err: mempool.RuleError{Err: mempool.TxRuleError{RejectCode: mempool.RejectNonCanonicalSignature}}, isFinal: true},
+		{err: mempool.RuleError{Err: errors.Wrap(ruleerrors.ErrScriptValidation, "bad signature")}, isFinal: true},
+		{err: mempool.RuleError{Err: mempool.TxRuleError{RejectCode: mempool.RejectDuplicate}}, isFinal: false},
+		{err: mempool.RuleError{Err: mempool.TxRuleError{RejectCode: mempool.RejectInsufficientFee}}, isFinal: false},
+		{err: mempool.RuleError{Err: mempool.TxRuleError{RejectCode: mempool.RejectBadOrphan}}, isFinal: false},
+		{err: mempool.RuleError{Err: mempool.TxRuleError{RejectCode: mempool.RejectImmatureSpend}}, isFinal: false},
+		{err: mempool.RuleError{Err: mempool.TxRuleError{RejectCode: mempool.RejectFinality}}, isFinal: false},
+		{err: mempool.RuleError{Err: errors.Wrap(ruleerrors.ErrUnfinalizedTx, "lock time not reached")}, isFinal: false},
+		{err: mempool.RuleError{Err: ruleerrors.NewErrMissingTxOut(nil)}, isFinal: false},
+	}
+	for _, test := range tests {
+		isFinal := isTransactionRejectionFinal(errors.WithStack(test.err))
+		if isFinal != test.isFinal {
+			t.Errorf("%s: expected isFinal to be %t", test.err, test.isFinal)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleReloadConfig handles the respectively named RPC command
+func HandleReloadConfig(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("ReloadConfig RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.ReloadConfigResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"ReloadConfig RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	reloadedOptions, restartRequiredOptions, err := context.ReloadConfig()
+	if err != nil {
+		errorMessage := &appmessage.ReloadConfigResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInternal,
+			"Could not reload the configuration: %s", err)
+		return errorMessage, nil
+	}
+	return appmessage.NewReloadConfigResponseMessage(reloadedOptions, restartRequiredOptions), nil
+}
@@ -12,11 +12,12 @@ func HandleResolveFinalityConflict(context *rpccontext.Context, _ *router.Router
 		log.Warn("ResolveFinalityConflict RPC command called while node in safe RPC mode -- ignoring.")
 		response := &appmessage.ResolveFinalityConflictResponseMessage{}
 		response.Error =
-			appmessage.RPCErrorf("ResolveFinalityConflict RPC command called while node in safe RPC mode")
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"ResolveFinalityConflict RPC command called while node in safe RPC mode")
 		return response, nil
 	}
 
 	response := &appmessage.ResolveFinalityConflictResponseMessage{}
-	response.Error = appmessage.RPCErrorf("not implemented")
+	response.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotImplemented, "not implemented")
 	return response, nil
 }
@@ -0,0 +1,32 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleSetIndexEnabled handles the respectively named RPC command
+func HandleSetIndexEnabled(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("SetIndexEnabled RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.SetIndexEnabledResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"SetIndexEnabled RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	setIndexEnabledRequest := request.(*appmessage.SetIndexEnabledRequestMessage)
+	index := context.OptionalIndexByName(setIndexEnabledRequest.IndexName)
+	if index == nil {
+		errorMessage := &appmessage.SetIndexEnabledResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Unknown index %s", setIndexEnabledRequest.IndexName)
+		return errorMessage, nil
+	}
+
+	index.SetEnabled(setIndexEnabledRequest.Enabled)
+	status, _ := index.Status()
+	return appmessage.NewSetIndexEnabledResponseMessage(status), nil
+}
@@ -16,7 +16,8 @@ func HandleShutDown(context *rpccontext.Context, _ *router.Router, _ appmessage.
 		log.Warn("ShutDown RPC command called while node in safe RPC mode -- ignoring.")
 		response := appmessage.NewShutDownResponseMessage()
 		response.Error =
-			appmessage.RPCErrorf("ShutDown RPC command called while node in safe RPC mode")
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"ShutDown RPC command called while node in safe RPC mode")
 		return response, nil
 	}
 
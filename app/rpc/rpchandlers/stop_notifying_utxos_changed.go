@@ -10,7 +10,8 @@ import (
 func HandleStopNotifyingUTXOsChanged(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	if !context.Config.UTXOIndex {
 		errorMessage := appmessage.NewStopNotifyingUTXOsChangedResponseMessage()
-		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when kaspad is run without --utxoindex")
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodUnavailable,
+			"Method unavailable when kaspad is run without --utxoindex")
 		return errorMessage, nil
 	}
 
@@ -18,7 +19,7 @@ func HandleStopNotifyingUTXOsChanged(context *rpccontext.Context, router *router
 	addresses, err := context.ConvertAddressStringsToUTXOsChangedNotificationAddresses(stopNotifyingUTXOsChangedRequest.Addresses)
 	if err != nil {
 		errorMessage := appmessage.NewNotifyUTXOsChangedResponseMessage()
-		errorMessage.Error = appmessage.RPCErrorf("Parsing error: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Parsing error: %s", err)
 		return errorMessage, nil
 	}
 
@@ -5,6 +5,7 @@ import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
@@ -12,9 +13,46 @@ import (
 )
 
 // HandleSubmitBlock handles the respectively named RPC command
-func HandleSubmitBlock(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+func HandleSubmitBlock(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	submitBlockRequest := request.(*appmessage.SubmitBlockRequestMessage)
 
+	domainBlock, err := appmessage.RPCBlockToDomainBlock(submitBlockRequest.Block)
+	if err != nil {
+		return &appmessage.SubmitBlockResponseMessage{
+			Error:        appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not parse block: %s", err),
+			RejectReason: appmessage.RejectReasonBlockInvalid,
+		}, nil
+	}
+
+	if submitBlockRequest.IdempotencyKey == "" {
+		return submitBlock(context, submitBlockRequest, domainBlock)
+	}
+	blockHash := consensushashing.BlockHash(domainBlock)
+	response, err := context.IdempotencyCache.Do(context.IdempotencyScope(router), request.Command(),
+		submitBlockRequest.IdempotencyKey, blockHash.String(), func() (appmessage.Message, bool, error) {
+			response, err := submitBlock(context, submitBlockRequest, domainBlock)
+			if err != nil {
+				return nil, false, err
+			}
+			// Blocks rejected only because the node is not synced may be
+			// accepted once it is, so this response is not kept
+			isFinal := response.(*appmessage.SubmitBlockResponseMessage).RejectReason != appmessage.RejectReasonIsInIBD
+			return response, isFinal, nil
+		})
+	if errors.Is(err, rpccontext.ErrIdempotencyKeyReused) {
+		return &appmessage.SubmitBlockResponseMessage{
+			Error: appmessage.RPCErrorf(appmessage.RPCErrorCodeIdempotencyKeyReused,
+				"Block %s not submitted - idempotency key %s was already used for a different block",
+				blockHash, submitBlockRequest.IdempotencyKey),
+			RejectReason: appmessage.RejectReasonBlockInvalid,
+		}, nil
+	}
+	return response, err
+}
+
+func submitBlock(context *rpccontext.Context, submitBlockRequest *appmessage.SubmitBlockRequestMessage,
+	domainBlock *externalapi.DomainBlock) (appmessage.Message, error) {
+
 	var err error
 	isSynced := false
 	// The node is considered synced if it has peers and consensus state is nearly synced
@@ -27,19 +65,11 @@ func HandleSubmitBlock(context *rpccontext.Context, _ *router.Router, request ap
 
 	if !context.Config.AllowSubmitBlockWhenNotSynced && !isSynced {
 		return &appmessage.SubmitBlockResponseMessage{
-			Error:        appmessage.RPCErrorf("Block not submitted - node is not synced"),
+			Error:        appmessage.RPCErrorf(appmessage.RPCErrorCodeNotSynced, "Block not submitted - node is not synced"),
 			RejectReason: appmessage.RejectReasonIsInIBD,
 		}, nil
 	}
 
-	domainBlock, err := appmessage.RPCBlockToDomainBlock(submitBlockRequest.Block)
-	if err != nil {
-		return &appmessage.SubmitBlockResponseMessage{
-			Error:        appmessage.RPCErrorf("Could not parse block: %s", err),
-			RejectReason: appmessage.RejectReasonBlockInvalid,
-		}, nil
-	}
-
 	if !submitBlockRequest.AllowNonDAABlocks {
 		virtualDAAScore, err := context.Domain.Consensus().GetVirtualDAAScore()
 		if err != nil {
@@ -50,14 +80,15 @@ func HandleSubmitBlock(context *rpccontext.Context, _ *router.Router, request ap
 		daaWindowSize := uint64(context.Config.NetParams().DifficultyAdjustmentWindowSize)
 		if virtualDAAScore > daaWindowSize && domainBlock.Header.DAAScore() < virtualDAAScore-daaWindowSize {
 			return &appmessage.SubmitBlockResponseMessage{
-				Error: appmessage.RPCErrorf("Block rejected. Reason: block DAA score %d is too far "+
-					"behind virtual's DAA score %d", domainBlock.Header.DAAScore(), virtualDAAScore),
+				Error: appmessage.RPCErrorf(appmessage.RPCErrorCodeBlockTooOld,
+					"Block rejected. Reason: block DAA score %d is too far behind virtual's DAA score %d",
+					domainBlock.Header.DAAScore(), virtualDAAScore),
 				RejectReason: appmessage.RejectReasonBlockInvalid,
 			}, nil
 		}
 	}
 
-	err = context.ProtocolManager.AddBlock(domainBlock)
+	err = context.ProtocolManager.AddBlock(domainBlock, submitBlockRequest.CorrelationID())
 	if err != nil {
 		isProtocolOrRuleError := errors.As(err, &ruleerrors.RuleError{}) || errors.As(err, &protocolerrors.ProtocolError{})
 		if !isProtocolOrRuleError {
@@ -66,18 +97,30 @@ func HandleSubmitBlock(context *rpccontext.Context, _ *router.Router, request ap
 
 		jsonBytes, _ := json.MarshalIndent(submitBlockRequest.Block.Header, "", "    ")
 		if jsonBytes != nil {
-			log.Warnf("The RPC submitted block triggered a rule/protocol error (%s), printing "+
-				"the full header for debug purposes: \n%s", err, string(jsonBytes))
+			log.Warnf("[%s] The RPC submitted block triggered a rule/protocol error (%s), printing "+
+				"the full header for debug purposes: \n%s", submitBlockRequest.CorrelationID(), err, string(jsonBytes))
 		}
 
 		return &appmessage.SubmitBlockResponseMessage{
-			Error:        appmessage.RPCErrorf("Block rejected. Reason: %s", err),
+			Error:        appmessage.RPCErrorf(blockRejectionErrorCode(err), "Block rejected. Reason: %s", err),
 			RejectReason: appmessage.RejectReasonBlockInvalid,
 		}, nil
 	}
 
-	log.Infof("Accepted block %s via submitBlock", consensushashing.BlockHash(domainBlock))
+	log.Infof("[%s] Accepted block %s via submitBlock", submitBlockRequest.CorrelationID(), consensushashing.BlockHash(domainBlock))
 
 	response := appmessage.NewSubmitBlockResponseMessage()
 	return response, nil
 }
+
+// blockRejectionErrorCode returns the RPC error code that best describes why
+// the given rule or protocol error caused a block to be rejected
+func blockRejectionErrorCode(err error) appmessage.RPCErrorCode {
+	if errors.Is(err, ruleerrors.ErrDuplicateBlock) {
+		return appmessage.RPCErrorCodeBlockDuplicate
+	}
+	if errors.As(err, &ruleerrors.ErrMissingParents{}) {
+		return appmessage.RPCErrorCodeBlockMissingParents
+	}
+	return appmessage.RPCErrorCodeBlockInvalid
+}
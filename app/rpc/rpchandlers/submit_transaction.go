@@ -3,36 +3,160 @@ package rpchandlers
 import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
 	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
 	"github.com/pkg/errors"
 )
 
 // HandleSubmitTransaction handles the respectively named RPC command
-func HandleSubmitTransaction(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+func HandleSubmitTransaction(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	submitTransactionRequest := request.(*appmessage.SubmitTransactionRequestMessage)
 
 	domainTransaction, err := appmessage.RPCTransactionToDomainTransaction(submitTransactionRequest.Transaction)
 	if err != nil {
 		errorMessage := &appmessage.SubmitTransactionResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Could not parse transaction: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not parse transaction: %s",
+			err)
 		return errorMessage, nil
 	}
 
+	err = checkOutputAddresses(submitTransactionRequest.Transaction, domainTransaction,
+		context.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		errorMessage := &appmessage.SubmitTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams,
+			"Transaction not submitted: %s", err)
+		return errorMessage, nil
+	}
+
+	if submitTransactionRequest.IdempotencyKey == "" {
+		response, _, err := submitTransaction(context, submitTransactionRequest, domainTransaction)
+		return response, err
+	}
 	transactionID := consensushashing.TransactionID(domainTransaction)
-	err = context.ProtocolManager.AddTransaction(domainTransaction, submitTransactionRequest.AllowOrphan)
+	response, err := context.IdempotencyCache.Do(context.IdempotencyScope(router), request.Command(),
+		submitTransactionRequest.IdempotencyKey, transactionID.String(), func() (appmessage.Message, bool, error) {
+			return submitTransaction(context, submitTransactionRequest, domainTransaction)
+		})
+	if errors.Is(err, rpccontext.ErrIdempotencyKeyReused) {
+		errorMessage := &appmessage.SubmitTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeIdempotencyKeyReused,
+			"Transaction %s not submitted - idempotency key %s was already used for a different transaction",
+			transactionID, submitTransactionRequest.IdempotencyKey)
+		return errorMessage, nil
+	}
+	return response, err
+}
+
+// submitTransaction adds the given transaction to the mempool. It also returns
+// whether the response is final, that is, whether submitting the transaction
+// again later would get the same response.
+func submitTransaction(context *rpccontext.Context, submitTransactionRequest *appmessage.SubmitTransactionRequestMessage,
+	domainTransaction *externalapi.DomainTransaction) (response appmessage.Message, isFinal bool, err error) {
+
+	transactionID := consensushashing.TransactionID(domainTransaction)
+	err = context.ProtocolManager.AddTransaction(domainTransaction, submitTransactionRequest.AllowOrphan,
+		submitTransactionRequest.CorrelationID())
 	if err != nil {
 		if !errors.As(err, &mempool.RuleError{}) {
-			return nil, err
+			return nil, false, err
 		}
 
-		log.Debugf("Rejected transaction %s: %s", transactionID, err)
+		log.Debugf("[%s] Rejected transaction %s: %s", submitTransactionRequest.CorrelationID(), transactionID, err)
 		errorMessage := &appmessage.SubmitTransactionResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Rejected transaction %s: %s", transactionID, err)
-		return errorMessage, nil
+		errorMessage.Error = appmessage.RPCErrorf(transactionRejectionErrorCode(err),
+			"Rejected transaction %s: %s", transactionID, err)
+		return errorMessage, isTransactionRejectionFinal(err), nil
 	}
 
-	response := appmessage.NewSubmitTransactionResponseMessage(transactionID.String())
-	return response, nil
+	return appmessage.NewSubmitTransactionResponseMessage(transactionID.String()), true, nil
+}
+
+// isTransactionRejectionFinal returns whether a transaction that was rejected
+// with the given mempool rule error would be rejected again if it were
+// submitted later. Transactions that were rejected because of the current
+// state of the DAG or the mempool, such as missing or immature inputs, an
+// unreached lock time or a fee too low for a full mempool, may be accepted
+// later.
+func isTransactionRejectionFinal(err error) bool {
+	var txRuleError mempool.TxRuleError
+	if errors.As(err, &txRuleError) {
+		switch txRuleError.RejectCode {
+		case mempool.RejectMalformed, mempool.RejectInvalid, mempool.RejectNonstandard, mempool.RejectDust,
+			mempool.RejectNonCanonicalPush, mempool.RejectNonCanonicalSignature:
+			return true
+		default:
+			return false
+		}
+	}
+	// Otherwise, the transaction broke a consensus rule
+	return !errors.Is(err, ruleerrors.ErrUnfinalizedTx) && !errors.As(err, &ruleerrors.ErrMissingTxOut{})
+}
+
+// checkOutputAddresses makes sure that the addresses a submitted transaction
+// states for its outputs, if any, belong to the network kaspad runs on, and
+// match the scriptPublicKeys of the outputs. The scriptPublicKeys themselves
+// carry no network, so this is the only place where a transaction built from
+// an address of another network, such as a testnet address on mainnet, can
+// be caught before its coins are sent.
+func checkOutputAddresses(transaction *appmessage.RPCTransaction, domainTransaction *externalapi.DomainTransaction,
+	prefix util.Bech32Prefix) error {
+
+	for i, output := range transaction.Outputs {
+		if output.VerboseData == nil || output.VerboseData.ScriptPublicKeyAddress == "" {
+			continue
+		}
+		addressString := output.VerboseData.ScriptPublicKeyAddress
+
+		address, err := util.DecodeAddress(addressString, util.Bech32PrefixUnknown)
+		if err != nil {
+			return errors.Errorf("output %d has a malformed address %s: %s", i, addressString, err)
+		}
+		if !address.IsForPrefix(prefix) {
+			return errors.Errorf("output %d pays to %s, which is an address of %s, but kaspad runs on %s",
+				i, addressString, address.Prefix(), prefix)
+		}
+
+		scriptPublicKey, err := txscript.PayToAddrScript(address)
+		if err != nil {
+			return errors.Errorf("output %d has an address %s that can't be paid to: %s", i, addressString, err)
+		}
+		if !scriptPublicKey.Equal(domainTransaction.Outputs[i].ScriptPublicKey) {
+			return errors.Errorf("output %d states the address %s, but its scriptPublicKey doesn't pay to it",
+				i, addressString)
+		}
+	}
+	return nil
+}
+
+// transactionRejectionErrorCode returns the RPC error code that corresponds
+// to the reject code of the given mempool rule error
+func transactionRejectionErrorCode(err error) appmessage.RPCErrorCode {
+	var txRuleError mempool.TxRuleError
+	if !errors.As(err, &txRuleError) {
+		return appmessage.RPCErrorCodeTransactionInvalid
+	}
+	switch txRuleError.RejectCode {
+	case mempool.RejectDuplicate:
+		return appmessage.RPCErrorCodeTransactionDuplicate
+	case mempool.RejectNonstandard, mempool.RejectDust:
+		return appmessage.RPCErrorCodeTransactionNonStandard
+	case mempool.RejectInsufficientFee:
+		return appmessage.RPCErrorCodeTransactionInsufficientFee
+	case mempool.RejectBadOrphan:
+		return appmessage.RPCErrorCodeTransactionOrphan
+	case mempool.RejectImmatureSpend:
+		return appmessage.RPCErrorCodeTransactionImmatureSpend
+	case mempool.RejectFinality:
+		return appmessage.RPCErrorCodeTransactionNotFinal
+	case mempool.RejectNonCanonicalPush, mempool.RejectNonCanonicalSignature:
+		return appmessage.RPCErrorCodeTransactionNonCanonical
+	default:
+		return appmessage.RPCErrorCodeTransactionInvalid
+	}
 }
@@ -0,0 +1,65 @@
+package rpchandlers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/util"
+)
+
+func TestCheckOutputAddresses(t *testing.T) {
+	publicKey := bytes.Repeat([]byte{0x01}, util.PublicKeySize)
+	mainnetAddress, err := util.NewAddressPublicKey(publicKey, util.Bech32PrefixKaspa)
+	if err != nil {
+		t.Fatalf("NewAddressPublicKey: %+v", err)
+	}
+	testnetAddress, err := util.NewAddressPublicKey(publicKey, util.Bech32PrefixKaspaTest)
+	if err != nil {
+		t.Fatalf("NewAddressPublicKey: %+v", err)
+	}
+	otherAddress, err := util.NewAddressPublicKey(bytes.Repeat([]byte{0x02}, util.PublicKeySize), util.Bech32PrefixKaspa)
+	if err != nil {
+		t.Fatalf("NewAddressPublicKey: %+v", err)
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(mainnetAddress)
+	if err != nil {
+		t.Fatalf("PayToAddrScript: %+v", err)
+	}
+
+	tests := []struct {
+		name          string
+		address       string
+		expectedError string
+	}{
+		{name: "no address", address: ""},
+		{name: "address of the network", address: mainnetAddress.EncodeAddress()},
+		{name: "address of another network", address: testnetAddress.EncodeAddress(), expectedError: "kaspatest"},
+		{name: "malformed address", address: "kaspa:qq", expectedError: "malformed"},
+		{name: "mismatching address", address: otherAddress.EncodeAddress(), expectedError: "doesn't pay to it"},
+	}
+	for _, test := range tests {
+		transaction := &appmessage.RPCTransaction{
+			Outputs: []*appmessage.RPCTransactionOutput{{
+				VerboseData: &appmessage.RPCTransactionOutputVerboseData{ScriptPublicKeyAddress: test.address},
+			}},
+		}
+		domainTransaction := &externalapi.DomainTransaction{
+			Outputs: []*externalapi.DomainTransactionOutput{{ScriptPublicKey: scriptPublicKey}},
+		}
+
+		err := checkOutputAddresses(transaction, domainTransaction, util.Bech32PrefixKaspa)
+		if test.expectedError == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %+v", test.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+			t.Errorf("%s: expected an error containing %q, but got %v", test.name, test.expectedError, err)
+		}
+	}
+}
@@ -13,7 +13,8 @@ func HandleUnban(context *rpccontext.Context, _ *router.Router, request appmessa
 		log.Warn("Unban RPC command called while node in safe RPC mode -- ignoring.")
 		response := appmessage.NewUnbanResponseMessage()
 		response.Error =
-			appmessage.RPCErrorf("Unban RPC command called while node in safe RPC mode")
+			appmessage.RPCErrorf(appmessage.RPCErrorCodeMethodNotAllowed,
+				"Unban RPC command called while node in safe RPC mode")
 		return response, nil
 	}
 
@@ -25,15 +26,18 @@ func HandleUnban(context *rpccontext.Context, _ *router.Router, request appmessa
 			hint = " (try to remove “[” and “]” symbols)"
 		}
 		errorMessage := &appmessage.UnbanResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Could not parse IP%s: %s", hint, unbanRequest.IP)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeInvalidParams, "Could not parse IP%s: %s",
+			hint, unbanRequest.IP)
 		return errorMessage, nil
 	}
 	err := context.AddressManager.Unban(appmessage.NewNetAddressIPPort(ip, 0))
 	if err != nil {
 		errorMessage := &appmessage.UnbanResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Could not unban IP: %s", err)
+		errorMessage.Error = appmessage.RPCErrorf(appmessage.RPCErrorCodeNotFound, "Could not unban IP: %s", err)
 		return errorMessage, nil
 	}
+	context.ProtocolManager.Context().BanManager().ResetBanScore(ip.String())
+
 	response := appmessage.NewUnbanResponseMessage()
 	return response, nil
 }
@@ -0,0 +1,46 @@
+package rpchandlers
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// HandleValidateAddress handles the respectively named RPC command
+func HandleValidateAddress(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	validateAddressRequest := request.(*appmessage.ValidateAddressRequestMessage)
+
+	// The address is decoded regardless of its prefix first, so that an
+	// address of another network is reported as such
+	address, err := util.DecodeAddress(validateAddressRequest.Address, util.Bech32PrefixUnknown)
+	if err != nil {
+		return appmessage.NewValidateAddressResponseMessage(false, err.Error(), "", ""), nil
+	}
+
+	addressType, err := addressScriptClass(address)
+	if err != nil {
+		return nil, err
+	}
+	prefix := context.Config.ActiveNetParams.Prefix
+	if !address.IsForPrefix(prefix) {
+		invalidReason := fmt.Sprintf("the address belongs to %s, but kaspad runs on %s", address.Prefix(), prefix)
+		return appmessage.NewValidateAddressResponseMessage(false, invalidReason, address.Prefix().String(),
+			addressType), nil
+	}
+
+	return appmessage.NewValidateAddressResponseMessage(true, "", address.Prefix().String(), addressType), nil
+}
+
+// addressScriptClass returns the name of the class of the scriptPublicKey
+// paying to the given address
+func addressScriptClass(address util.Address) (string, error) {
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return "", err
+	}
+	return txscript.GetScriptClass(scriptPublicKey.Script).String(), nil
+}
@@ -0,0 +1,206 @@
+package app
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+type startupStage int
+
+const (
+	startupStageOpeningDatabase startupStage = iota
+	startupStageLoadingDAG
+	startupStageLoadingIndexes
+	startupStageStartingListeners
+	startupStageCount
+)
+
+var startupStageDescriptions = map[startupStage]string{
+	startupStageOpeningDatabase:   "opening the database",
+	startupStageLoadingDAG:        "loading the DAG",
+	startupStageLoadingIndexes:    "loading the indexes",
+	startupStageStartingListeners: "starting the listeners",
+}
+
+// startupStillRunningLogInterval is how often a stage that takes a long time
+// is logged again, so that the node doesn't look hung
+const startupStillRunningLogInterval = 30 * time.Second
+
+// startupStatus is what the startup status socket reports
+type startupStatus struct {
+	Network             string  `json:"network"`
+	Stage               string  `json:"stage"`
+	StageNumber         int     `json:"stageNumber"`
+	StageCount          int     `json:"stageCount"`
+	StageElapsedSeconds float64 `json:"stageElapsedSeconds"`
+	ElapsedSeconds      float64 `json:"elapsedSeconds"`
+}
+
+// startupProgress tracks the stages of the node startup, logs them, and
+// reports the current one on the startup status socket, if one is configured.
+// All its methods may be called on a nil startupProgress, and do nothing.
+type startupProgress struct {
+	network string
+
+	lock       sync.Mutex
+	stage      startupStage
+	start      time.Time
+	stageStart time.Time
+
+	listener net.Listener
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func newStartupProgress(cfg *config.Config) (*startupProgress, error) {
+	now := time.Now()
+	progress := &startupProgress{
+		network:    cfg.NetParams().Name,
+		stage:      -1,
+		start:      now,
+		stageStart: now,
+		done:       make(chan struct{}),
+	}
+
+	if cfg.StartupStatusSocket != "" {
+		err := removeStaleStartupStatusSocket(cfg.StartupStatusSocket)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listening on the startup status socket %s", cfg.StartupStatusSocket)
+		}
+		progress.listener, err = net.Listen("unix", cfg.StartupStatusSocket)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listening on the startup status socket %s", cfg.StartupStatusSocket)
+		}
+		go progress.serveStatus()
+	}
+	go progress.logWhileStageRuns()
+
+	return progress, nil
+}
+
+// enterStage marks the given stage as the current one
+func (p *startupProgress) enterStage(stage startupStage) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.logStageFinished()
+	p.stage = stage
+	p.stageStart = time.Now()
+	log.Infof("Startup stage %d/%d: %s", stage+1, startupStageCount, startupStageDescriptions[stage])
+}
+
+// finish marks the startup as completed, and stops serving the startup status
+// socket. It's also called if the startup fails.
+func (p *startupProgress) finish(succeeded bool) {
+	if p == nil {
+		return
+	}
+	p.doneOnce.Do(func() {
+		close(p.done)
+		if p.listener != nil {
+			p.listener.Close()
+		}
+
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		if succeeded {
+			p.logStageFinished()
+			log.Infof("Startup completed in %s", time.Since(p.start).Round(time.Millisecond))
+		}
+	})
+}
+
+// logStageFinished must be called with the lock held
+func (p *startupProgress) logStageFinished() {
+	if p.stage < 0 {
+		return
+	}
+	log.Debugf("Finished %s in %s", startupStageDescriptions[p.stage], time.Since(p.stageStart).Round(time.Millisecond))
+}
+
+func (p *startupProgress) status() *startupStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+	status := &startupStatus{
+		Network:             p.network,
+		Stage:               "starting",
+		StageCount:          int(startupStageCount),
+		StageElapsedSeconds: now.Sub(p.stageStart).Seconds(),
+		ElapsedSeconds:      now.Sub(p.start).Seconds(),
+	}
+	if p.stage >= 0 {
+		status.Stage = startupStageDescriptions[p.stage]
+		status.StageNumber = int(p.stage) + 1
+	}
+	return status
+}
+
+func (p *startupProgress) logWhileStageRuns() {
+	ticker := time.NewTicker(startupStillRunningLogInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			status := p.status()
+			elapsed := time.Duration(status.ElapsedSeconds * float64(time.Second))
+			log.Infof("Still %s (%s since the node started)", status.Stage, elapsed.Round(time.Second))
+		}
+	}
+}
+
+// serveStatus writes the current startup status as a single JSON line to
+// every connection to the startup status socket, and closes it
+func (p *startupProgress) serveStatus() {
+	for {
+		connection, err := p.listener.Accept()
+		if err != nil {
+			select {
+			case <-p.done:
+			default:
+				log.Warnf("Stopped serving the startup status socket: %s", err)
+			}
+			return
+		}
+
+		err = json.NewEncoder(connection).Encode(p.status())
+		if err != nil {
+			log.Debugf("Error writing the startup status: %s", err)
+		}
+		connection.Close()
+	}
+}
+
+// removeStaleStartupStatusSocket removes the socket left behind by a node
+// that didn't shut down cleanly. A socket that is still being served is not
+// removed.
+func removeStaleStartupStatusSocket(path string) error {
+	fileInfo, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fileInfo.Mode()&os.ModeSocket == 0 {
+		return errors.Errorf("%s already exists and is not a socket", path)
+	}
+	connection, err := net.Dial("unix", path)
+	if err == nil {
+		connection.Close()
+		return errors.Errorf("%s is already being served", path)
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+func TestStartupStatusSocket(t *testing.T) {
+	cfg := config.DefaultConfig()
+	*cfg.ActiveNetParams = dagconfig.SimnetParams
+	cfg.StartupStatusSocket = filepath.Join(t.TempDir(), "startup.sock")
+
+	progress, err := newStartupProgress(cfg)
+	if err != nil {
+		t.Fatalf("newStartupProgress: %+v", err)
+	}
+	defer progress.finish(false)
+
+	status := readStartupStatus(t, cfg.StartupStatusSocket)
+	if status.StageNumber != 0 || status.Network != dagconfig.SimnetParams.Name {
+		t.Fatalf("unexpected status before the first stage: %+v", status)
+	}
+
+	progress.enterStage(startupStageLoadingDAG)
+	status = readStartupStatus(t, cfg.StartupStatusSocket)
+	if status.Stage != startupStageDescriptions[startupStageLoadingDAG] ||
+		status.StageNumber != 2 || status.StageCount != int(startupStageCount) {
+		t.Fatalf("unexpected status while loading the DAG: %+v", status)
+	}
+
+	progress.finish(true)
+	_, err = os.Stat(cfg.StartupStatusSocket)
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected the startup status socket to be removed once the startup finished, but got %v", err)
+	}
+}
+
+func readStartupStatus(t *testing.T, path string) *startupStatus {
+	connection, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("error connecting to the startup status socket: %+v", err)
+	}
+	defer connection.Close()
+
+	status := &startupStatus{}
+	err = json.NewDecoder(connection).Decode(status)
+	if err != nil {
+		t.Fatalf("error reading the startup status: %+v", err)
+	}
+	return status
+}
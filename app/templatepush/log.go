@@ -0,0 +1,9 @@
+package templatepush
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("TPSH")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,180 @@
+// Package templatepush pushes block templates to an external work
+// distributor, such as a pool frontend, as soon as they change, so that it
+// doesn't have to poll the node for them.
+package templatepush
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient/grpcclient"
+	"github.com/pkg/errors"
+)
+
+// refreshInterval is the interval in which the template is rebuilt between
+// new blocks, so that the pushed template includes new mempool transactions
+const refreshInterval = time.Second
+
+// retryInterval is the time the pusher waits before reconnecting to the
+// work distributor after an error
+const retryInterval = 5 * time.Second
+
+// HasPeersFunc returns whether the node has any peers, without which its
+// templates are never considered synced
+type HasPeersFunc func() bool
+
+// Pusher keeps a gRPC stream open to the RPC service of a work distributor,
+// and sends a GetBlockTemplateResponse message over it whenever the block
+// template changes: once a new block changes the parents of the template,
+// and once the template includes different mempool transactions. The
+// messages are the same ones a GetBlockTemplate request gets, so the
+// distributor may handle them as it would handle polled templates.
+type Pusher struct {
+	address                  string
+	domain                   domain.Domain
+	coinbaseData             *externalapi.DomainCoinbaseData
+	maxCoinbasePayloadLength uint64
+	hasPeers                 HasPeersFunc
+
+	// client is nil while the pusher is disconnected
+	client *grpcclient.GRPCClient
+
+	// lastPushedTemplate is the last template that was pushed over the
+	// current connection, or nil if none was
+	lastPushedTemplate *externalapi.DomainBlock
+	lastPushedIsSynced bool
+
+	notifyChan chan struct{}
+	stopChan   chan struct{}
+	doneChan   chan struct{}
+}
+
+// New creates a new Pusher that pushes to the work distributor at the given
+// host:port address the templates of the given domain, whose coinbase pays
+// to the given coinbase data
+func New(address string, domain domain.Domain, coinbaseData *externalapi.DomainCoinbaseData,
+	maxCoinbasePayloadLength uint64, hasPeers HasPeersFunc) *Pusher {
+
+	return &Pusher{
+		address:                  address,
+		domain:                   domain,
+		coinbaseData:             coinbaseData,
+		maxCoinbasePayloadLength: maxCoinbasePayloadLength,
+		hasPeers:                 hasPeers,
+		notifyChan:               make(chan struct{}, 1),
+		stopChan:                 make(chan struct{}),
+		doneChan:                 make(chan struct{}),
+	}
+}
+
+// Start connects to the work distributor and begins pushing templates
+func (p *Pusher) Start() {
+	spawn("templatepush.Pusher.pushLoop", p.pushLoop)
+}
+
+// Stop stops pushing templates and disconnects from the work distributor
+func (p *Pusher) Stop() {
+	close(p.stopChan)
+	<-p.doneChan
+}
+
+// Notify lets the pusher know that a new block template is available. It
+// never blocks.
+func (p *Pusher) Notify() {
+	select {
+	case p.notifyChan <- struct{}{}:
+	default:
+	}
+}
+
+func (p *Pusher) pushLoop() {
+	defer close(p.doneChan)
+	defer p.disconnect()
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		err := p.push()
+		if err != nil {
+			log.Errorf("Error pushing the block template to %s, retrying in %s: %s", p.address, retryInterval, err)
+			p.disconnect()
+			select {
+			case <-p.stopChan:
+				return
+			case <-time.After(retryInterval):
+				continue
+			}
+		}
+
+		select {
+		case <-p.stopChan:
+			return
+		case <-p.notifyChan:
+		case <-ticker.C:
+		}
+	}
+}
+
+// push pushes the current template, unless it's the same as the last pushed
+// one
+func (p *Pusher) push() error {
+	if p.client == nil {
+		client, err := grpcclient.Connect(p.address)
+		if err != nil {
+			return err
+		}
+		p.client = client
+		log.Infof("Connected to the work distributor at %s", p.address)
+	}
+
+	template, isNearlySynced, err := p.domain.MiningManager().GetBlockTemplate(p.coinbaseData)
+	if err != nil {
+		return err
+	}
+	isSynced := p.hasPeers() && isNearlySynced
+	if p.lastPushedTemplate != nil && isSynced == p.lastPushedIsSynced &&
+		isSameWork(template, p.lastPushedTemplate) {
+
+		return nil
+	}
+
+	coinbasePayload := template.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload
+	if uint64(len(coinbasePayload)) > p.maxCoinbasePayloadLength {
+		return errors.Errorf("the coinbase payload is above the max length (%d). "+
+			"Try to shorten the extra data", p.maxCoinbasePayloadLength)
+	}
+
+	message := appmessage.NewGetBlockTemplateResponseMessage(appmessage.DomainBlockToRPCBlock(template), isSynced)
+	err = p.client.SendAppMessage(message)
+	if err != nil {
+		return errors.Wrapf(err, "error sending the block template")
+	}
+	p.lastPushedTemplate = template
+	p.lastPushedIsSynced = isSynced
+	log.Debugf("Pushed a block template with %d transactions to %s", len(template.Transactions), p.address)
+	return nil
+}
+
+func (p *Pusher) disconnect() {
+	if p.client == nil {
+		return
+	}
+	err := p.client.Close()
+	if err != nil {
+		log.Debugf("Error disconnecting from the work distributor at %s: %s", p.address, err)
+	}
+	p.client = nil
+	p.lastPushedTemplate = nil
+}
+
+// isSameWork returns whether the given templates have the same parents and
+// transactions, so that mining either of them is equally useful. Templates
+// that differ only in their timestamp are the same work.
+func isSameWork(template *externalapi.DomainBlock, otherTemplate *externalapi.DomainBlock) bool {
+	return externalapi.HashesEqual(template.Header.DirectParents(), otherTemplate.Header.DirectParents()) &&
+		template.Header.HashMerkleRoot().Equal(otherTemplate.Header.HashMerkleRoot())
+}
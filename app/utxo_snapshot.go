@@ -0,0 +1,74 @@
+package app
+
+import (
+	"os"
+
+	"github.com/kaspanet/kaspad/app/utxosnapshot"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// runUTXOSnapshotCommand runs --export-utxo-snapshot or --import-utxo-snapshot
+// over the given database, without starting any of the other components
+func runUTXOSnapshotCommand(cfg *config.Config, db database.Database) error {
+	domain, err := newDomain(cfg, db)
+	if err != nil {
+		log.Errorf("Unable to load the DAG: %+v", err)
+		return err
+	}
+
+	// Nothing listens to the consensus events, but consensus fails once
+	// their channel fills up
+	consensusEventsChannel := domain.ConsensusEventsChannel()
+	go func() {
+		for range consensusEventsChannel {
+		}
+	}()
+	defer close(consensusEventsChannel)
+
+	if cfg.ExportUTXOSnapshot != "" {
+		err = exportUTXOSnapshot(cfg, domain)
+	} else {
+		err = importUTXOSnapshot(cfg, domain)
+	}
+	if err != nil {
+		log.Errorf("%+v", err)
+	}
+	return err
+}
+
+func logUTXOSnapshotProgress(processedHeaderCount uint64, processedUTXOCount uint64) {
+	log.Infof("Processed %d headers and %d UTXOs", processedHeaderCount, processedUTXOCount)
+}
+
+func exportUTXOSnapshot(cfg *config.Config, domain domain.Domain) error {
+	log.Infof("Exporting a UTXO snapshot to %s", cfg.ExportUTXOSnapshot)
+	header, utxoCount, err := utxosnapshot.ExportToFile(domain.Consensus(), cfg.ActiveNetParams,
+		cfg.ExportUTXOSnapshot, logUTXOSnapshotProgress)
+	if err != nil {
+		return err
+	}
+	log.Infof("Exported %d UTXOs of pruning point %s, at blue score %d, to %s. UTXO commitment: %s",
+		utxoCount, header.PruningPoint, header.BlueScore, cfg.ExportUTXOSnapshot, header.UTXOCommitment)
+	return nil
+}
+
+func importUTXOSnapshot(cfg *config.Config, domain domain.Domain) error {
+	file, err := os.Open(cfg.ImportUTXOSnapshot)
+	if err != nil {
+		return errors.Wrapf(err, "failed opening the snapshot file")
+	}
+	defer file.Close()
+
+	log.Infof("Importing a UTXO snapshot from %s", cfg.ImportUTXOSnapshot)
+	header, err := utxosnapshot.Import(domain, cfg.ActiveNetParams, file, logUTXOSnapshotProgress)
+	if err != nil {
+		return err
+	}
+	log.Infof("Imported the UTXO snapshot of pruning point %s, at blue score %d, from %s. UTXO commitment: %s. "+
+		"The node syncs the blocks above the pruning point once it's started",
+		header.PruningPoint, header.BlueScore, cfg.ImportUTXOSnapshot, header.UTXOCommitment)
+	return nil
+}
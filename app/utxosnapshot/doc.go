@@ -0,0 +1,51 @@
+/*
+Package utxosnapshot implements the UTXO snapshot format that's used by
+kaspad --export-utxo-snapshot, kaspad --import-utxo-snapshot and the
+ExportUTXOSnapshot RPC, to bootstrap a node from a local file rather than
+syncing the pruning point and its UTXO set over P2P.
+
+A snapshot is always taken at the pruning point of the exporting node. That's
+the only block that consensus can import a UTXO set at, and the only one whose
+UTXO set a node keeps in full, along with the proof and the trusted data that
+are needed to anchor it. The UTXO commitment in the header of the pruning point
+commits to its UTXO set, so an imported snapshot is verified the same way the
+pruning point and its UTXO set are verified during IBD: the pruning point proof
+is validated against the current consensus, the pruning points against the
+checkpoints, and the UTXO set against the commitment. Once a snapshot is
+imported, the node syncs the block bodies above the pruning point over P2P.
+
+File format
+
+A snapshot file is sequential, and consists of a file header followed by
+records, up to the end of the file. All integers are little endian.
+
+The file header is:
+
+	magic            8 bytes   "KASUTXOS"
+	version          uint32    currently 1
+	network size     uint32    the size of the network name
+	network          bytes     the name of the network of the snapshot, e.g. kaspa-mainnet
+	pruning point    32 bytes  the hash of the pruning point the snapshot is taken at
+	blue score       uint64    the blue score of the pruning point
+	UTXO commitment  32 bytes  the UTXO commitment of the pruning point
+
+Each record is:
+
+	size             uint32    the size of the message, at most 1 GiB
+	message          bytes     a KaspadMessage, encoded with protobuf as
+	                           defined in messages.proto
+
+The records are the P2P messages that a syncer sends during IBD with headers
+proof, in this order:
+
+	1. A PruningPointProof message
+	2. A PruningPoints message
+	3. A TrustedData message
+	4. A BlockWithTrustedDataV4 message for the pruning point, and one for
+	   every block in its anticone, followed by a DoneBlocksWithTrustedData message
+	5. Any number of BlockHeaders messages with the headers above the pruning
+	   point in topological order, followed by a DoneHeaders message
+	6. Any number of PruningPointUTXOSetChunk messages, followed by a
+	   DonePruningPointUTXOSetChunks message
+*/
+package utxosnapshot
@@ -0,0 +1,305 @@
+package utxosnapshot
+
+import (
+	"io"
+	"os"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// utxoChunkSize is the amount of UTXOs in every UTXO set chunk, which is the
+// same as the one used in P2P
+const utxoChunkSize = 1000
+
+// progressInterval is the amount of headers and UTXOs between progress reports
+const progressInterval = 100_000
+
+// errPruningPointMoved is returned when the pruning point of the exporting
+// node moves while a snapshot is exported
+var errPruningPointMoved = errors.New("the pruning point moved while exporting the snapshot. Please try again")
+
+// ProgressFunc is called as a snapshot is exported or imported, with the
+// amount of headers and UTXOs processed so far
+type ProgressFunc func(processedHeaderCount uint64, processedUTXOCount uint64)
+
+// ExportToFile exports a snapshot into a new file at the given path, which must
+// not exist. The file is removed if the export fails.
+func ExportToFile(consensus externalapi.Consensus, params *dagconfig.Params, path string,
+	onProgress ProgressFunc) (header *Header, utxoCount uint64, err error) {
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed creating the snapshot file")
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(path)
+		}
+	}()
+
+	header, utxoCount, err = Export(consensus, params, file, onProgress)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = file.Close()
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed closing the snapshot file")
+	}
+	return header, utxoCount, nil
+}
+
+// Export writes a snapshot of the UTXO set of the pruning point to the given
+// writer, along with everything needed to import it, and returns its header
+// and the amount of UTXOs written. It may be called while consensus is
+// processing blocks, but it fails if the pruning point moves meanwhile.
+func Export(consensus externalapi.Consensus, params *dagconfig.Params, writer io.Writer,
+	onProgress ProgressFunc) (*Header, uint64, error) {
+
+	pruningPoint, err := consensus.PruningPoint()
+	if err != nil {
+		return nil, 0, err
+	}
+	if pruningPoint.Equal(params.GenesisHash) {
+		return nil, 0, errors.New("the pruning point is still the genesis, so there's no snapshot to export")
+	}
+	pruningPointHeader, err := consensus.GetBlockHeader(pruningPoint)
+	if err != nil {
+		return nil, 0, err
+	}
+	header := &Header{
+		Network:        params.Name,
+		PruningPoint:   pruningPoint,
+		BlueScore:      pruningPointHeader.BlueScore(),
+		UTXOCommitment: pruningPointHeader.UTXOCommitment(),
+	}
+	snapshotWriter, err := NewWriter(writer, header)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pruningPointProof, err := consensus.BuildPruningPointProof()
+	if err != nil {
+		return nil, 0, err
+	}
+	proofPruningPoint := consensushashing.HeaderHash(pruningPointProof.Headers[0][len(pruningPointProof.Headers[0])-1])
+	if !proofPruningPoint.Equal(pruningPoint) {
+		return nil, 0, errPruningPointMoved
+	}
+	err = snapshotWriter.WriteMessage(appmessage.DomainPruningPointProofToMsgPruningPointProof(pruningPointProof))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pruningPointHeaders, err := consensus.PruningPointHeaders()
+	if err != nil {
+		return nil, 0, err
+	}
+	if !consensushashing.HeaderHash(pruningPointHeaders[len(pruningPointHeaders)-1]).Equal(pruningPoint) {
+		return nil, 0, errPruningPointMoved
+	}
+	msgPruningPointHeaders := make([]*appmessage.MsgBlockHeader, len(pruningPointHeaders))
+	for i, pruningPointHeader := range pruningPointHeaders {
+		msgPruningPointHeaders[i] = appmessage.DomainBlockHeaderToBlockHeader(pruningPointHeader)
+	}
+	err = snapshotWriter.WriteMessage(appmessage.NewMsgPruningPoints(msgPruningPointHeaders))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = writePruningPointAndItsAnticone(consensus, params, snapshotWriter, pruningPoint)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	headerCount, err := writeHeaders(consensus, params, snapshotWriter, pruningPoint, onProgress)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	utxoCount, err := writeUTXOs(consensus, snapshotWriter, pruningPoint, headerCount, onProgress)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = snapshotWriter.Flush()
+	if err != nil {
+		return nil, 0, err
+	}
+	onProgress(headerCount, utxoCount)
+	return header, utxoCount, nil
+}
+
+// writePruningPointAndItsAnticone writes the trusted data of the pruning point
+// and its anticone, followed by their blocks. The DAA window and GHOSTDAG data
+// that the blocks share are written once, and referenced by index.
+func writePruningPointAndItsAnticone(consensus externalapi.Consensus, params *dagconfig.Params,
+	snapshotWriter *Writer, pruningPoint *externalapi.DomainHash) error {
+
+	pruningPointAndItsAnticone, err := consensus.PruningPointAndItsAnticone()
+	if err != nil {
+		return err
+	}
+	if !pruningPointAndItsAnticone[0].Equal(pruningPoint) {
+		return errPruningPointMoved
+	}
+
+	daaWindow := make([]*externalapi.TrustedDataDataDAAHeader, 0, params.DifficultyAdjustmentWindowSize)
+	daaWindowIndexes := make(map[externalapi.DomainHash]uint64, params.DifficultyAdjustmentWindowSize)
+	blockDAAWindowIndexes := make(map[externalapi.DomainHash][]uint64, len(pruningPointAndItsAnticone))
+	ghostdagData := make([]*externalapi.BlockGHOSTDAGDataHashPair, 0)
+	ghostdagDataIndexes := make(map[externalapi.DomainHash]uint64)
+	blockGHOSTDAGDataIndexes := make(map[externalapi.DomainHash][]uint64, len(pruningPointAndItsAnticone))
+	for _, blockHash := range pruningPointAndItsAnticone {
+		blockDAAWindowHashes, err := consensus.BlockDAAWindowHashes(blockHash)
+		if err != nil {
+			return err
+		}
+		for i, daaBlockHash := range blockDAAWindowHashes {
+			index, ok := daaWindowIndexes[*daaBlockHash]
+			if !ok {
+				daaHeader, err := consensus.TrustedDataDataDAAHeader(blockHash, daaBlockHash, uint64(i))
+				if err != nil {
+					return err
+				}
+				daaWindow = append(daaWindow, daaHeader)
+				index = uint64(len(daaWindow) - 1)
+				daaWindowIndexes[*daaBlockHash] = index
+			}
+			blockDAAWindowIndexes[*blockHash] = append(blockDAAWindowIndexes[*blockHash], index)
+		}
+
+		ghostdagDataBlockHashes, err := consensus.TrustedBlockAssociatedGHOSTDAGDataBlockHashes(blockHash)
+		if err != nil {
+			return err
+		}
+		for _, ghostdagDataBlockHash := range ghostdagDataBlockHashes {
+			index, ok := ghostdagDataIndexes[*ghostdagDataBlockHash]
+			if !ok {
+				data, err := consensus.TrustedGHOSTDAGData(ghostdagDataBlockHash)
+				if err != nil {
+					return err
+				}
+				ghostdagData = append(ghostdagData, &externalapi.BlockGHOSTDAGDataHashPair{
+					Hash:         ghostdagDataBlockHash,
+					GHOSTDAGData: data,
+				})
+				index = uint64(len(ghostdagData) - 1)
+				ghostdagDataIndexes[*ghostdagDataBlockHash] = index
+			}
+			blockGHOSTDAGDataIndexes[*blockHash] = append(blockGHOSTDAGDataIndexes[*blockHash], index)
+		}
+	}
+
+	err = snapshotWriter.WriteMessage(appmessage.DomainTrustedDataToTrustedData(daaWindow, ghostdagData))
+	if err != nil {
+		return err
+	}
+	for _, blockHash := range pruningPointAndItsAnticone {
+		block, found, err := consensus.GetBlock(blockHash)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.Errorf("pruning point anticone block %s has no body", blockHash)
+		}
+		err = snapshotWriter.WriteMessage(appmessage.DomainBlockWithTrustedDataToBlockWithTrustedDataV4(
+			block, blockDAAWindowIndexes[*blockHash], blockGHOSTDAGDataIndexes[*blockHash]))
+		if err != nil {
+			return err
+		}
+	}
+	return snapshotWriter.WriteMessage(appmessage.NewMsgDoneBlocksWithTrustedData())
+}
+
+// writeHeaders writes the headers between the pruning point and the virtual
+// selected parent, which the importing node needs in order to accept the
+// pruning point, and returns the amount of headers written
+func writeHeaders(consensus externalapi.Consensus, params *dagconfig.Params, snapshotWriter *Writer,
+	pruningPoint *externalapi.DomainHash, onProgress ProgressFunc) (uint64, error) {
+
+	virtualSelectedParent, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return 0, err
+	}
+
+	// maxBlocks must be > MergeSetSizeLimit, so that every call makes progress
+	maxBlocks := params.MergeSetSizeLimit + 1
+	lowHash := pruningPoint
+	headerCount := uint64(0)
+	lastReportedCount := uint64(0)
+	for !lowHash.Equal(virtualSelectedParent) {
+		blockHashes, highHash, err := consensus.GetHashesBetween(lowHash, virtualSelectedParent, maxBlocks)
+		if err != nil {
+			return 0, err
+		}
+		// lowHash was already written by the previous iteration, or is the
+		// pruning point
+		if len(blockHashes) > 0 && blockHashes[0].Equal(lowHash) {
+			blockHashes = blockHashes[1:]
+		}
+
+		headers := make([]*appmessage.MsgBlockHeader, len(blockHashes))
+		for i, blockHash := range blockHashes {
+			header, err := consensus.GetBlockHeader(blockHash)
+			if err != nil {
+				return 0, err
+			}
+			headers[i] = appmessage.DomainBlockHeaderToBlockHeader(header)
+		}
+		err = snapshotWriter.WriteMessage(appmessage.NewBlockHeadersMessage(headers))
+		if err != nil {
+			return 0, err
+		}
+
+		headerCount += uint64(len(headers))
+		if headerCount >= lastReportedCount+progressInterval {
+			onProgress(headerCount, 0)
+			lastReportedCount = headerCount
+		}
+		lowHash = highHash
+	}
+	return headerCount, snapshotWriter.WriteMessage(appmessage.NewMsgDoneHeaders())
+}
+
+// writeUTXOs writes the UTXO set of the pruning point in chunks, and returns
+// the amount of UTXOs written
+func writeUTXOs(consensus externalapi.Consensus, snapshotWriter *Writer, pruningPoint *externalapi.DomainHash,
+	headerCount uint64, onProgress ProgressFunc) (uint64, error) {
+
+	var fromOutpoint *externalapi.DomainOutpoint
+	utxoCount := uint64(0)
+	lastReportedCount := uint64(0)
+	for {
+		pruningPointUTXOs, err := consensus.GetPruningPointUTXOs(pruningPoint, fromOutpoint, utxoChunkSize)
+		if err != nil {
+			if errors.Is(err, ruleerrors.ErrWrongPruningPointHash) {
+				return 0, errPruningPointMoved
+			}
+			return 0, err
+		}
+		if len(pruningPointUTXOs) > 0 {
+			err = snapshotWriter.WriteMessage(appmessage.NewMsgPruningPointUTXOSetChunk(
+				appmessage.DomainOutpointAndUTXOEntryPairsToOutpointAndUTXOEntryPairs(pruningPointUTXOs)))
+			if err != nil {
+				return 0, err
+			}
+			fromOutpoint = pruningPointUTXOs[len(pruningPointUTXOs)-1].Outpoint
+		}
+
+		utxoCount += uint64(len(pruningPointUTXOs))
+		if utxoCount >= lastReportedCount+progressInterval {
+			onProgress(headerCount, utxoCount)
+			lastReportedCount = utxoCount
+		}
+		if len(pruningPointUTXOs) < utxoChunkSize {
+			break
+		}
+	}
+	return utxoCount, snapshotWriter.WriteMessage(appmessage.NewMsgDonePruningPointUTXOSetChunks())
+}
@@ -0,0 +1,336 @@
+package utxosnapshot
+
+import (
+	"io"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// Import validates the snapshot in the given reader and, if it's valid, makes
+// its pruning point the pruning point of the node. The snapshot is imported
+// into a staging consensus, which replaces the current consensus only once the
+// UTXO set matches the UTXO commitment of the pruning point, so the node is
+// left untouched if the import fails. It returns the header of the imported
+// snapshot.
+func Import(domain domain.Domain, params *dagconfig.Params, reader io.Reader,
+	onProgress ProgressFunc) (*Header, error) {
+
+	snapshotReader, header, err := NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	if header.Network != params.Name {
+		return nil, errors.Errorf("the snapshot belongs to %s, while the node is on %s", header.Network, params.Name)
+	}
+
+	virtualSelectedParent, err := domain.Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	virtualSelectedParentInfo, err := domain.Consensus().GetBlockInfo(virtualSelectedParent)
+	if err != nil {
+		return nil, err
+	}
+	if virtualSelectedParentInfo.BlueScore >= header.BlueScore {
+		return nil, errors.Errorf("the node is already synced beyond the snapshot, whose blue score is %d "+
+			"while the blue score of the node is %d", header.BlueScore, virtualSelectedParentInfo.BlueScore)
+	}
+
+	err = domain.InitStagingConsensusWithoutGenesis()
+	if err != nil {
+		return nil, err
+	}
+	err = importIntoStagingConsensus(domain, params, snapshotReader, header, onProgress)
+	if err != nil {
+		deleteStagingConsensusErr := domain.DeleteStagingConsensus()
+		if deleteStagingConsensusErr != nil {
+			return nil, deleteStagingConsensusErr
+		}
+		return nil, err
+	}
+	err = domain.CommitStagingConsensus()
+	if err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func importIntoStagingConsensus(domain domain.Domain, params *dagconfig.Params, snapshotReader *Reader,
+	header *Header, onProgress ProgressFunc) error {
+
+	err := importPruningPointProof(domain, snapshotReader, header)
+	if err != nil {
+		return err
+	}
+	err = importPruningPoints(domain, params, snapshotReader, header)
+	if err != nil {
+		return err
+	}
+	err = importPruningPointAndItsAnticone(domain.StagingConsensus(), snapshotReader, header)
+	if err != nil {
+		return err
+	}
+	headerCount, err := importHeaders(domain.StagingConsensus(), snapshotReader, onProgress)
+	if err != nil {
+		return err
+	}
+
+	isValidPruningPoint, err := domain.StagingConsensus().IsValidPruningPoint(header.PruningPoint)
+	if err != nil {
+		return err
+	}
+	if !isValidPruningPoint {
+		return errors.Errorf("pruning point %s of the snapshot isn't valid given the headers above it",
+			header.PruningPoint)
+	}
+
+	utxoCount, err := importUTXOs(domain.StagingConsensus(), snapshotReader, header, headerCount, onProgress)
+	if err != nil {
+		return err
+	}
+	onProgress(headerCount, utxoCount)
+	return nil
+}
+
+func importPruningPointProof(domain domain.Domain, snapshotReader *Reader, header *Header) error {
+	message, err := snapshotReader.ReadMessage()
+	if err != nil {
+		return err
+	}
+	msgPruningPointProof, ok := message.(*appmessage.MsgPruningPointProof)
+	if !ok {
+		return unexpectedMessageError(appmessage.CmdPruningPointProof, message)
+	}
+	pruningPointProof := appmessage.MsgPruningPointProofToDomainPruningPointProof(msgPruningPointProof)
+	if len(pruningPointProof.Headers) == 0 || len(pruningPointProof.Headers[0]) == 0 {
+		return errors.New("the pruning point proof of the snapshot is empty")
+	}
+	proofPruningPoint := consensushashing.HeaderHash(pruningPointProof.Headers[0][len(pruningPointProof.Headers[0])-1])
+	if !proofPruningPoint.Equal(header.PruningPoint) {
+		return errors.Errorf("the pruning point proof of the snapshot is for %s, while its pruning point is %s",
+			proofPruningPoint, header.PruningPoint)
+	}
+
+	err = domain.Consensus().ValidatePruningPointProof(pruningPointProof)
+	if err != nil {
+		return errors.Wrapf(err, "the pruning point proof of the snapshot is invalid")
+	}
+	return domain.StagingConsensus().ApplyPruningPointProof(pruningPointProof)
+}
+
+func importPruningPoints(domain domain.Domain, params *dagconfig.Params, snapshotReader *Reader, header *Header) error {
+	message, err := snapshotReader.ReadMessage()
+	if err != nil {
+		return err
+	}
+	msgPruningPoints, ok := message.(*appmessage.MsgPruningPoints)
+	if !ok {
+		return unexpectedMessageError(appmessage.CmdPruningPoints, message)
+	}
+	if len(msgPruningPoints.Headers) == 0 {
+		return errors.New("the snapshot has no pruning points")
+	}
+	pruningPointHeaders := make([]externalapi.BlockHeader, len(msgPruningPoints.Headers))
+	for i, pruningPointHeader := range msgPruningPoints.Headers {
+		pruningPointHeaders[i] = appmessage.BlockHeaderToDomainBlockHeader(pruningPointHeader)
+	}
+
+	err = params.VerifyCheckpoints(pruningPointHeaders)
+	if err != nil {
+		return errors.Wrapf(err, "the pruning points of the snapshot contradict the checkpoints")
+	}
+	arePruningPointsViolatingFinality, err := domain.Consensus().ArePruningPointsViolatingFinality(pruningPointHeaders)
+	if err != nil {
+		return err
+	}
+	if arePruningPointsViolatingFinality {
+		return errors.New("the pruning points of the snapshot violate the finality of the node")
+	}
+	lastPruningPoint := consensushashing.HeaderHash(pruningPointHeaders[len(pruningPointHeaders)-1])
+	if !lastPruningPoint.Equal(header.PruningPoint) {
+		return errors.Errorf("the last pruning point of the snapshot is %s, while its pruning point is %s",
+			lastPruningPoint, header.PruningPoint)
+	}
+	return domain.StagingConsensus().ImportPruningPoints(pruningPointHeaders)
+}
+
+func importPruningPointAndItsAnticone(consensus externalapi.Consensus, snapshotReader *Reader, header *Header) error {
+	message, err := snapshotReader.ReadMessage()
+	if err != nil {
+		return err
+	}
+	msgTrustedData, ok := message.(*appmessage.MsgTrustedData)
+	if !ok {
+		return unexpectedMessageError(appmessage.CmdTrustedData, message)
+	}
+
+	for i := 0; ; i++ {
+		message, err := snapshotReader.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if _, ok := message.(*appmessage.MsgDoneBlocksWithTrustedData); ok {
+			if i == 0 {
+				return errors.New("the snapshot doesn't have the block of its pruning point")
+			}
+			return nil
+		}
+		msgBlockWithTrustedData, ok := message.(*appmessage.MsgBlockWithTrustedDataV4)
+		if !ok {
+			return unexpectedMessageError(appmessage.CmdBlockWithTrustedDataV4, message)
+		}
+
+		blockWithTrustedData, err := toBlockWithTrustedData(msgBlockWithTrustedData, msgTrustedData)
+		if err != nil {
+			return err
+		}
+		blockHash := consensushashing.BlockHash(blockWithTrustedData.Block)
+		if i == 0 {
+			// The first block is the pruning point, whose header commits to the
+			// blue score and the UTXO set that the file header claims
+			if !blockHash.Equal(header.PruningPoint) {
+				return errors.Errorf("the first block of the snapshot is %s, while its pruning point is %s",
+					blockHash, header.PruningPoint)
+			}
+			blockHeader := blockWithTrustedData.Block.Header
+			if blockHeader.BlueScore() != header.BlueScore || !blockHeader.UTXOCommitment().Equal(header.UTXOCommitment) {
+				return errors.Errorf("the header of pruning point %s doesn't match the snapshot file header",
+					header.PruningPoint)
+			}
+		}
+		err = consensus.ValidateAndInsertBlockWithTrustedData(blockWithTrustedData, false)
+		if err != nil {
+			return errors.Wrapf(err, "invalid block with trusted data %s", blockHash)
+		}
+	}
+}
+
+func toBlockWithTrustedData(block *appmessage.MsgBlockWithTrustedDataV4,
+	data *appmessage.MsgTrustedData) (*externalapi.BlockWithTrustedData, error) {
+
+	blockWithTrustedData := &externalapi.BlockWithTrustedData{
+		Block:        appmessage.MsgBlockToDomainBlock(block.Block),
+		DAAWindow:    make([]*externalapi.TrustedDataDataDAAHeader, 0, len(block.DAAWindowIndices)),
+		GHOSTDAGData: make([]*externalapi.BlockGHOSTDAGDataHashPair, 0, len(block.GHOSTDAGDataIndices)),
+	}
+	for _, index := range block.DAAWindowIndices {
+		if index >= uint64(len(data.DAAWindow)) {
+			return nil, errors.Errorf("DAA window index %d is out of range", index)
+		}
+		blockWithTrustedData.DAAWindow = append(blockWithTrustedData.DAAWindow,
+			appmessage.TrustedDataDataDAABlockV4ToTrustedDataDataDAAHeader(data.DAAWindow[index]))
+	}
+	for _, index := range block.GHOSTDAGDataIndices {
+		if index >= uint64(len(data.GHOSTDAGData)) {
+			return nil, errors.Errorf("GHOSTDAG data index %d is out of range", index)
+		}
+		blockWithTrustedData.GHOSTDAGData = append(blockWithTrustedData.GHOSTDAGData,
+			appmessage.GHOSTDAGHashPairToDomainGHOSTDAGHashPair(data.GHOSTDAGData[index]))
+	}
+	return blockWithTrustedData, nil
+}
+
+// importHeaders inserts the headers above the pruning point, and returns the
+// amount of headers read
+func importHeaders(consensus externalapi.Consensus, snapshotReader *Reader, onProgress ProgressFunc) (uint64, error) {
+	headerCount := uint64(0)
+	lastReportedCount := uint64(0)
+	for {
+		message, err := snapshotReader.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := message.(*appmessage.MsgDoneHeaders); ok {
+			return headerCount, nil
+		}
+		blockHeadersMessage, ok := message.(*appmessage.BlockHeadersMessage)
+		if !ok {
+			return 0, unexpectedMessageError(appmessage.CmdBlockHeaders, message)
+		}
+
+		for _, msgBlockHeader := range blockHeadersMessage.BlockHeaders {
+			block := &externalapi.DomainBlock{Header: appmessage.BlockHeaderToDomainBlockHeader(msgBlockHeader)}
+			blockHash := consensushashing.BlockHash(block)
+			blockInfo, err := consensus.GetBlockInfo(blockHash)
+			if err != nil {
+				return 0, err
+			}
+			// The blocks in the anticone of the pruning point were already
+			// inserted with their trusted data
+			if blockInfo.Exists {
+				continue
+			}
+			err = consensus.ValidateAndInsertBlock(block, false)
+			if err != nil {
+				return 0, errors.Wrapf(err, "invalid header %s", blockHash)
+			}
+		}
+
+		headerCount += uint64(len(blockHeadersMessage.BlockHeaders))
+		if headerCount >= lastReportedCount+progressInterval {
+			onProgress(headerCount, 0)
+			lastReportedCount = headerCount
+		}
+	}
+}
+
+// importUTXOs imports the UTXO set of the pruning point, and validates it
+// against the UTXO commitment of the pruning point. It returns the amount of
+// UTXOs imported.
+func importUTXOs(consensus externalapi.Consensus, snapshotReader *Reader, header *Header,
+	headerCount uint64, onProgress ProgressFunc) (utxoCount uint64, err error) {
+
+	defer func() {
+		clearErr := consensus.ClearImportedPruningPointData()
+		if clearErr != nil && err == nil {
+			err = clearErr
+		}
+	}()
+
+	lastReportedCount := uint64(0)
+	for {
+		message, err := snapshotReader.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := message.(*appmessage.MsgDonePruningPointUTXOSetChunks); ok {
+			break
+		}
+		utxoSetChunk, ok := message.(*appmessage.MsgPruningPointUTXOSetChunk)
+		if !ok {
+			return 0, unexpectedMessageError(appmessage.CmdPruningPointUTXOSetChunk, message)
+		}
+
+		err = consensus.AppendImportedPruningPointUTXOs(
+			appmessage.OutpointAndUTXOEntryPairsToDomainOutpointAndUTXOEntryPairs(utxoSetChunk.OutpointAndUTXOEntryPairs))
+		if err != nil {
+			return 0, err
+		}
+
+		utxoCount += uint64(len(utxoSetChunk.OutpointAndUTXOEntryPairs))
+		if utxoCount >= lastReportedCount+progressInterval {
+			onProgress(headerCount, utxoCount)
+			lastReportedCount = utxoCount
+		}
+	}
+
+	err = consensus.ValidateAndInsertImportedPruningPoint(header.PruningPoint)
+	if err != nil {
+		if errors.Is(err, ruleerrors.ErrBadPruningPointUTXOSet) {
+			return 0, errors.Errorf("the UTXO set of the snapshot doesn't match the UTXO commitment %s",
+				header.UTXOCommitment)
+		}
+		return 0, err
+	}
+	return utxoCount, nil
+}
+
+func unexpectedMessageError(expected appmessage.MessageCommand, message appmessage.Message) error {
+	return errors.Errorf("expected a %s record, but got a %s record", expected, message.Command())
+}
@@ -0,0 +1,205 @@
+package utxosnapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+var magic = [8]byte{'K', 'A', 'S', 'U', 'T', 'X', 'O', 'S'}
+
+const (
+	// Version is the version of the snapshot file format that's written
+	Version = 1
+
+	maxNetworkNameSize = 256
+
+	// maxRecordSize is the same as the maximum size of a P2P message, since
+	// the records are P2P messages
+	maxRecordSize = 1024 * 1024 * 1024
+)
+
+// Header describes the snapshot in a snapshot file
+type Header struct {
+	Network        string
+	PruningPoint   *externalapi.DomainHash
+	BlueScore      uint64
+	UTXOCommitment *externalapi.DomainHash
+}
+
+// Writer writes records into a snapshot file
+type Writer struct {
+	writer *bufio.Writer
+}
+
+// NewWriter writes the given file header, and returns a Writer for the records
+// that follow it
+func NewWriter(writer io.Writer, header *Header) (*Writer, error) {
+	bufferedWriter := bufio.NewWriter(writer)
+	_, err := bufferedWriter.Write(magic[:])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = binary.Write(bufferedWriter, binary.LittleEndian, uint32(Version))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = writeSizePrefixed(bufferedWriter, []byte(header.Network))
+	if err != nil {
+		return nil, err
+	}
+	_, err = bufferedWriter.Write(header.PruningPoint.ByteSlice())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = binary.Write(bufferedWriter, binary.LittleEndian, header.BlueScore)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	_, err = bufferedWriter.Write(header.UTXOCommitment.ByteSlice())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &Writer{writer: bufferedWriter}, nil
+}
+
+// WriteMessage writes the given message as the next record
+func (w *Writer) WriteMessage(message appmessage.Message) error {
+	protoMessage, err := protowire.FromAppMessage(message)
+	if err != nil {
+		return err
+	}
+	serializedMessage, err := proto.Marshal(protoMessage)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return writeSizePrefixed(w.writer, serializedMessage)
+}
+
+// Flush writes any buffered records to the underlying writer
+func (w *Writer) Flush() error {
+	return errors.WithStack(w.writer.Flush())
+}
+
+func writeSizePrefixed(writer io.Writer, data []byte) error {
+	err := binary.Write(writer, binary.LittleEndian, uint32(len(data)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = writer.Write(data)
+	return errors.WithStack(err)
+}
+
+// Reader reads records from a snapshot file
+type Reader struct {
+	reader *bufio.Reader
+}
+
+// NewReader reads and validates the file header, and returns a Reader for the
+// records that follow it
+func NewReader(reader io.Reader) (*Reader, *Header, error) {
+	snapshotReader := &Reader{reader: bufio.NewReader(reader)}
+
+	var fileMagic [len(magic)]byte
+	err := snapshotReader.readFull(fileMagic[:])
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading the snapshot file header")
+	}
+	if fileMagic != magic {
+		return nil, nil, errors.New("not a UTXO snapshot file")
+	}
+	var version uint32
+	err = binary.Read(snapshotReader.reader, binary.LittleEndian, &version)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading the snapshot file header")
+	}
+	if version != Version {
+		return nil, nil, errors.Errorf("unsupported snapshot file version %d. Expected version: %d", version, Version)
+	}
+	network, err := snapshotReader.readSizePrefixed(maxNetworkNameSize)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading the snapshot file header")
+	}
+	pruningPoint, err := snapshotReader.readHash()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading the snapshot file header")
+	}
+	var blueScore uint64
+	err = binary.Read(snapshotReader.reader, binary.LittleEndian, &blueScore)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading the snapshot file header")
+	}
+	utxoCommitment, err := snapshotReader.readHash()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading the snapshot file header")
+	}
+
+	header := &Header{
+		Network:        string(network),
+		PruningPoint:   pruningPoint,
+		BlueScore:      blueScore,
+		UTXOCommitment: utxoCommitment,
+	}
+	return snapshotReader, header, nil
+}
+
+// ReadMessage reads the next record
+func (r *Reader) ReadMessage() (appmessage.Message, error) {
+	serializedMessage, err := r.readSizePrefixed(maxRecordSize)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading a record")
+	}
+	protoMessage := &protowire.KaspadMessage{}
+	err = proto.Unmarshal(serializedMessage, protoMessage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decoding a record")
+	}
+	message, err := protoMessage.ToAppMessage()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decoding a record")
+	}
+	return message, nil
+}
+
+func (r *Reader) readFull(data []byte) error {
+	_, err := io.ReadFull(r.reader, data)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return errors.WithStack(err)
+}
+
+func (r *Reader) readHash() (*externalapi.DomainHash, error) {
+	var hash [externalapi.DomainHashSize]byte
+	err := r.readFull(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return externalapi.NewDomainHashFromByteArray(&hash), nil
+}
+
+func (r *Reader) readSizePrefixed(maxSize uint32) ([]byte, error) {
+	var size uint32
+	err := binary.Read(r.reader, binary.LittleEndian, &size)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, errors.WithStack(err)
+	}
+	if size > maxSize {
+		return nil, errors.Errorf("record size %d is above the maximum of %d", size, maxSize)
+	}
+	data := make([]byte, size)
+	err = r.readFull(data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,153 @@
+package utxosnapshot
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+func TestExportAndImport(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+	// This is done to reduce the pruning depth to a few blocks
+	consensusConfig.FinalityDuration = 5 * consensusConfig.TargetTimePerBlock
+	consensusConfig.K = 0
+	consensusConfig.PruningProofM = 1
+
+	factory := consensus.NewFactory()
+	exportingConsensus, teardown, err := factory.NewTestConsensus(consensusConfig, "TestExportAndImport_export")
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardown(false)
+
+	// A snapshot can't be exported before the pruning point moves
+	_, _, err = Export(exportingConsensus, &consensusConfig.Params, &bytes.Buffer{}, func(uint64, uint64) {})
+	if err == nil || !strings.Contains(err.Error(), "genesis") {
+		t.Fatalf("Expected exporting at the genesis to fail, but got %+v", err)
+	}
+
+	tipHash := consensusConfig.GenesisHash
+	for i := 0; i < 100; i++ {
+		tipHash, _, err = exportingConsensus.AddBlock([]*externalapi.DomainHash{tipHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+	}
+	pruningPoint, err := exportingConsensus.PruningPoint()
+	if err != nil {
+		t.Fatalf("PruningPoint: %+v", err)
+	}
+
+	snapshotFile := &bytes.Buffer{}
+	header, utxoCount, err := Export(exportingConsensus, &consensusConfig.Params, snapshotFile, func(uint64, uint64) {})
+	if err != nil {
+		t.Fatalf("Export: %+v", err)
+	}
+	if !header.PruningPoint.Equal(pruningPoint) {
+		t.Fatalf("Expected a snapshot at %s, but got one at %s", pruningPoint, header.PruningPoint)
+	}
+	if utxoCount == 0 {
+		t.Fatalf("Expected the snapshot to have UTXOs")
+	}
+
+	importingDomain, teardownDomain := setupDomain(t, consensusConfig, "TestExportAndImport_import")
+	defer teardownDomain()
+
+	// A file of another network is rejected
+	fileContent := snapshotFile.Bytes()
+	_, err = Import(importingDomain, &dagconfig.TestnetParams, bytes.NewReader(fileContent), func(uint64, uint64) {})
+	if err == nil || !strings.Contains(err.Error(), "belongs to") {
+		t.Fatalf("Expected importing a file of another network to fail, but got %+v", err)
+	}
+
+	// A truncated file is rejected, and leaves the node untouched
+	_, err = Import(importingDomain, &consensusConfig.Params, bytes.NewReader(fileContent[:len(fileContent)-1]),
+		func(uint64, uint64) {})
+	if err == nil {
+		t.Fatalf("Expected importing a truncated file to fail")
+	}
+	importingPruningPoint, err := importingDomain.Consensus().PruningPoint()
+	if err != nil {
+		t.Fatalf("PruningPoint: %+v", err)
+	}
+	if !importingPruningPoint.Equal(consensusConfig.GenesisHash) {
+		t.Fatalf("A failed import changed the pruning point to %s", importingPruningPoint)
+	}
+
+	importedHeader, err := Import(importingDomain, &consensusConfig.Params, bytes.NewReader(fileContent),
+		func(uint64, uint64) {})
+	if err != nil {
+		t.Fatalf("Import: %+v", err)
+	}
+	if !importedHeader.UTXOCommitment.Equal(header.UTXOCommitment) || importedHeader.BlueScore != header.BlueScore {
+		t.Fatalf("The imported header doesn't match the exported one")
+	}
+	importingPruningPoint, err = importingDomain.Consensus().PruningPoint()
+	if err != nil {
+		t.Fatalf("PruningPoint: %+v", err)
+	}
+	if !importingPruningPoint.Equal(pruningPoint) {
+		t.Fatalf("Expected pruning point %s after the import, but got %s", pruningPoint, importingPruningPoint)
+	}
+	headersSelectedTip, err := importingDomain.Consensus().GetHeadersSelectedTip()
+	if err != nil {
+		t.Fatalf("GetHeadersSelectedTip: %+v", err)
+	}
+	if !headersSelectedTip.Equal(tipHash) {
+		t.Fatalf("Expected headers selected tip %s after the import, but got %s", tipHash, headersSelectedTip)
+	}
+
+	// The node is now synced up to the snapshot, so importing it again is rejected
+	_, err = Import(importingDomain, &consensusConfig.Params, bytes.NewReader(fileContent), func(uint64, uint64) {})
+	if err == nil || !strings.Contains(err.Error(), "already synced") {
+		t.Fatalf("Expected importing the snapshot again to fail, but got %+v", err)
+	}
+}
+
+func setupDomain(t *testing.T, consensusConfig *consensus.Config, name string) (domain.Domain, func()) {
+	dataDir, err := ioutil.TempDir("", name)
+	if err != nil {
+		t.Fatalf("TempDir: %+v", err)
+	}
+	db, err := ldb.NewLevelDB(dataDir, 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %+v", err)
+	}
+	domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	return domainInstance, func() {
+		db.Close()
+		os.RemoveAll(dataDir)
+	}
+}
+
+func TestReaderRejectsInvalidHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "empty", content: nil},
+		{name: "wrong magic", content: []byte("KASBLOCK\x01\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "unsupported version", content: []byte("KASUTXOS\x02\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "network name too long", content: []byte("KASUTXOS\x01\x00\x00\x00\xff\xff\x00\x00")},
+		{name: "truncated", content: []byte("KASUTXOS\x01\x00\x00\x00\x00\x00\x00\x00\x00")},
+	}
+	for _, test := range tests {
+		_, _, err := NewReader(bytes.NewReader(test.content))
+		if err == nil {
+			t.Errorf("%s: expected NewReader to fail", test.name)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	exportSubCmd = "export"
+	verifySubCmd = "verify"
+)
+
+type exportConfig struct {
+	Output                string `long:"output" short:"o" description:"The file to write the vectors to" required:"true"`
+	Seed                  int64  `long:"seed" short:"s" description:"Seed for the randomly generated GHOSTDAG and UTXO diff cases" default:"1"`
+	NumberOfGHOSTDAGCases int    `long:"ghostdag-cases" description:"Number of GHOSTDAG cases to generate" default:"20"`
+	NumberOfUTXODiffCases int    `long:"utxo-diff-cases" description:"Number of UTXO diff cases to generate" default:"200"`
+	config.NetworkFlags
+}
+
+type verifyConfig struct {
+	Input string `long:"input" short:"i" description:"The file to read the vectors from" required:"true"`
+	config.NetworkFlags
+}
+
+func parseCommandLine() (subCommand string, config interface{}) {
+	parser := flags.NewParser(nil, flags.PrintErrors|flags.HelpFlag)
+
+	exportConf := &exportConfig{}
+	parser.AddCommand(exportSubCmd, "Exports consensus test vectors",
+		"Generates consensus test vectors using kaspad's implementation and writes them as JSON", exportConf)
+
+	verifyConf := &verifyConfig{}
+	parser.AddCommand(verifySubCmd, "Verifies consensus test vectors",
+		"Runs the given consensus test vectors against kaspad's implementation and reports any mismatch", verifyConf)
+
+	_, err := parser.Parse()
+	if err != nil {
+		var flagsErr *flags.Error
+		if ok := errors.As(err, &flagsErr); ok && flagsErr.Type == flags.ErrHelp {
+			os.Exit(0)
+		} else {
+			os.Exit(1)
+		}
+		return "", nil
+	}
+
+	switch parser.Command.Active.Name {
+	case exportSubCmd:
+		err := exportConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = exportConf
+	case verifySubCmd:
+		err := verifyConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = verifyConf
+	}
+
+	return parser.Command.Active.Name, config
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testvectors"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	subCmd, config := parseCommandLine()
+
+	var err error
+	switch subCmd {
+	case exportSubCmd:
+		err = export(config.(*exportConfig))
+	case verifySubCmd:
+		err = verify(config.(*verifyConfig))
+	default:
+		err = errors.Errorf("Unknown sub-command '%s'\n", subCmd)
+	}
+
+	if err != nil {
+		printErrorAndExit(err)
+	}
+}
+
+func export(conf *exportConfig) error {
+	vectors, err := testvectors.Generate(conf.NetParams(), &testvectors.GenerateOptions{
+		Seed:                  conf.Seed,
+		NumberOfGHOSTDAGCases: conf.NumberOfGHOSTDAGCases,
+		NumberOfUTXODiffCases: conf.NumberOfUTXODiffCases,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = testvectors.WriteFile(conf.Output, vectors)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d header validation cases, %d GHOSTDAG cases and %d UTXO diff cases to %s\n",
+		len(vectors.HeaderValidation), len(vectors.GHOSTDAG), len(vectors.UTXODiff), conf.Output)
+	return nil
+}
+
+func verify(conf *verifyConfig) error {
+	vectors, err := testvectors.ReadFile(conf.Input)
+	if err != nil {
+		return err
+	}
+
+	mismatches, err := testvectors.Verify(conf.NetParams(), vectors)
+	if err != nil {
+		return err
+	}
+	for _, mismatch := range mismatches {
+		fmt.Printf("[%s] %s: %s\n", mismatch.Category, mismatch.Description, mismatch.Details)
+	}
+	if len(mismatches) > 0 {
+		return errors.Errorf("%d vectors did not match", len(mismatches))
+	}
+	fmt.Println("All vectors match")
+	return nil
+}
+
+func printErrorAndExit(err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient/grpcclient"
+	"github.com/pkg/errors"
+)
+
+// resolveConnectOptions returns the options of connecting to the RPC server:
+// the credentials to present to it, and the TLS config if --rpctls is set
+func resolveConnectOptions(cfg *configFlags, rpcAddress string) (*grpcclient.ConnectOptions, error) {
+	options := &grpcclient.ConnectOptions{}
+	if cfg.RPCUser != "" {
+		options.Authorization = grpcclient.BasicAuthorization(cfg.RPCUser, cfg.RPCPassword)
+	} else {
+		authToken, err := resolveAuthToken(cfg, rpcAddress)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading the RPC cookie")
+		}
+		if authToken != "" {
+			options.Authorization = grpcclient.AuthTokenAuthorization(authToken)
+		}
+	}
+
+	if cfg.RPCTLS {
+		options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.RPCCert != "" {
+			certificate, err := os.ReadFile(cfg.RPCCert)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error reading the RPC certificate")
+			}
+			rootCAs := x509.NewCertPool()
+			if !rootCAs.AppendCertsFromPEM(certificate) {
+				return nil, errors.Errorf("no certificate was found in %s", cfg.RPCCert)
+			}
+			options.TLSConfig.RootCAs = rootCAs
+		}
+	}
+	return options, nil
+}
+
+// resolveAuthToken returns the auth token to present to the RPC server. Unless
+// one is given with --authtoken, the RPC auth cookie of the node is presented
+// when connecting to a local node, if the node writes one.
+func resolveAuthToken(cfg *configFlags, rpcAddress string) (string, error) {
+	if cfg.AuthToken != "" || !isLocalRPCServer(rpcAddress) {
+		return cfg.AuthToken, nil
+	}
+
+	cookieFile := cfg.RPCCookieFile
+	if cookieFile == "" {
+		cookieFile = config.DefaultRPCCookieFile(cfg.NetParams().Name)
+	}
+	cookie, err := grpcserver.ReadRPCCookie(cookieFile)
+	if os.IsNotExist(err) && cfg.RPCCookieFile == "" {
+		// The node doesn't require a cookie
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return cookie, nil
+}
+
+// isLocalRPCServer returns whether the RPC server in the given address runs on
+// this machine, so that it may be presented with the RPC auth cookie
+func isLocalRPCServer(rpcAddress string) bool {
+	if strings.HasPrefix(rpcAddress, "unix:") {
+		return true
+	}
+	host, _, err := net.SplitHostPort(rpcAddress)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -12,6 +12,9 @@ var commandTypes = []reflect.Type{
 	reflect.TypeOf(protowire.KaspadMessage_AddPeerRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetConnectedPeerInfoRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetPeerAddressesRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetAddressManagerInfoRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_RefreshSeedsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetPeerFlowStatisticsRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetCurrentNetworkRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetInfoRequest{}),
 
@@ -20,6 +23,7 @@ var commandTypes = []reflect.Type{
 	reflect.TypeOf(protowire.KaspadMessage_GetHeadersRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetBlockCountRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetBlockDagInfoRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetDAGTipsRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetSelectedTipHashRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetVirtualSelectedParentBlueScoreRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetVirtualSelectedParentChainFromBlockRequest{}),
@@ -34,13 +38,48 @@ var commandTypes = []reflect.Type{
 	reflect.TypeOf(protowire.KaspadMessage_GetMempoolEntriesByAddressesRequest{}),
 
 	reflect.TypeOf(protowire.KaspadMessage_SubmitTransactionRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetTransactionPropagationReportRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetReorgedTransactionsStatsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetImmatureCoinbaseOutputsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetChainChangedEventsFromBlockRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetOutpointSpendingTransactionRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetUTXORequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetBlockHeadersRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetTransactionRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetTransactionsByAddressRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetScriptClassStatisticsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetFeeHistoryRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_EstimateFeeRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetCoinAgeAnalyticsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetIndexRetentionStatusRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_SetIndexEnabledRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetIndexStatusRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_NegotiateAPIVersionRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetRPCSessionsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_DisconnectRPCSessionRequest{}),
 
 	reflect.TypeOf(protowire.KaspadMessage_GetUtxosByAddressesRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetBalanceByAddressRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetCoinSupplyRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetChainWorkStatusRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetHeadersSelectedTipRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetEffectiveConfigRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetRelayPolicyRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetNetworkHealthRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetPayoutStatsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetBlockRateStatusRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ExportUTXOSnapshotRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ReloadConfigRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetMempoolGraphRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ExportMempoolSnapshotRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_DeriveAddressFromPublicKeyRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ValidateAddressRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ConvertAddressRequest{}),
 
 	reflect.TypeOf(protowire.KaspadMessage_BanRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_UnbanRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetBannedPeersRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetBandwidthInfoRequest{}),
 }
 
 type commandDescription struct {
@@ -13,6 +13,12 @@ var (
 
 type configFlags struct {
 	RPCServer                          string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	AuthToken                          string `long:"authtoken" description:"Auth token of the RPC endpoint to connect to, if it has one"`
+	RPCCookieFile                      string `long:"rpccookiefile" description:"RPC auth cookie file of the node, which is presented when connecting to a local node without --authtoken or --rpcuser (default: the cookie file of the network in the default appdir)"`
+	RPCUser                            string `long:"rpcuser" description:"RPC user to authenticate as, if the node requires it with --rpcauth"`
+	RPCPassword                        string `long:"rpcpassword" default-mask:"-" description:"Password of the RPC user"`
+	RPCTLS                             bool   `long:"rpctls" description:"Connect to the RPC server over TLS"`
+	RPCCert                            string `long:"rpccert" description:"Certificate to trust the RPC server by when connecting over TLS, such as the self-signed certificate that kaspad generates (default: the system's trusted certificates)"`
 	Timeout                            uint64 `short:"t" long:"timeout" description:"Timeout for the request (in seconds)"`
 	RequestJSON                        string `short:"j" long:"json" description:"The request in JSON format"`
 	ListCommands                       bool   `short:"l" long:"list-commands" description:"List all commands and exit"`
@@ -50,6 +56,12 @@ func parseConfig() (*configFlags, error) {
 
 		return nil, errors.New("Exactly one of --json or a command must be specified")
 	}
+	if cfg.RPCUser != "" && cfg.AuthToken != "" {
+		return nil, errors.New("--rpcuser and --authtoken can't be used together")
+	}
+	if cfg.RPCCert != "" && !cfg.RPCTLS {
+		return nil, errors.New("--rpccert requires --rpctls")
+	}
 
 	return cfg, nil
 }
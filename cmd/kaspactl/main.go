@@ -28,7 +28,11 @@ func main() {
 	if err != nil {
 		printErrorAndExit(fmt.Sprintf("error parsing RPC server address: %s", err))
 	}
-	client, err := grpcclient.Connect(rpcAddress)
+	connectOptions, err := resolveConnectOptions(cfg, rpcAddress)
+	if err != nil {
+		printErrorAndExit(err.Error())
+	}
+	client, err := grpcclient.ConnectWithOptions(rpcAddress, connectOptions)
 	if err != nil {
 		printErrorAndExit(fmt.Sprintf("error connecting to the RPC server: %s", err))
 	}
@@ -113,6 +117,6 @@ func prettifyResponse(response string) string {
 }
 
 func printErrorAndExit(message string) {
-	fmt.Fprintf(os.Stderr, fmt.Sprintf("%s\n", message))
+	fmt.Fprintf(os.Stderr, "%s\n", message)
 	os.Exit(1)
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	formatCSV     = "csv"
+	formatParquet = "parquet"
+
+	defaultRPCServer     = "localhost"
+	defaultOutputDir     = "kaspa-export"
+	defaultConfirmations = 100
+	defaultBatchSize     = 1000
+
+	// maxBatchSize is the most chain blocks that a single
+	// GetChainChangedEventsFromBlock request returns
+	maxBatchSize = 1000
+)
+
+type configFlags struct {
+	RPCServer     string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	AuthToken     string `long:"authtoken" description:"Auth token of the RPC endpoint to connect to, if it has one"`
+	OutputDir     string `short:"o" long:"output" description:"Directory to write the exported files to. An export that's interrupted is resumed by running the tool again with the same directory"`
+	Format        string `long:"format" description:"Format of the exported files: csv or parquet"`
+	StartHash     string `long:"start-hash" description:"Hash of the chain block to export from, exclusive (default: the pruning point of the node)"`
+	EndBlueScore  uint64 `long:"end-bluescore" description:"Blue score of the last chain block to export (default: the latest chain block with --confirmations confirmations)"`
+	Confirmations uint64 `long:"confirmations" description:"Export only chain blocks with at least this many confirmations, so that the exported data isn't reorged"`
+	BatchSize     uint32 `long:"batch-size" description:"Number of chain blocks, along with the blocks they merge, that are written to each set of files"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		RPCServer:     defaultRPCServer,
+		OutputDir:     defaultOutputDir,
+		Format:        formatCSV,
+		Confirmations: defaultConfirmations,
+		BatchSize:     defaultBatchSize,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Format != formatCSV && cfg.Format != formatParquet {
+		return nil, errors.Errorf("unknown format %s. Supported formats are %s and %s", cfg.Format, formatCSV, formatParquet)
+	}
+	if cfg.BatchSize == 0 || cfg.BatchSize > maxBatchSize {
+		return nil, errors.Errorf("--batch-size must be between 1 and %d", maxBatchSize)
+	}
+
+	return cfg, nil
+}
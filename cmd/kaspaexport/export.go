@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+const stateFileName = "export-state.json"
+
+// exportState is the progress of an export, which is saved after every batch
+// so that an interrupted export can be resumed
+type exportState struct {
+	Network       string `json:"network"`
+	Format        string `json:"format"`
+	NextStartHash string `json:"nextStartHash"`
+	NextPart      uint64 `json:"nextPart"`
+}
+
+func statePath(cfg *configFlags) string {
+	return filepath.Join(cfg.OutputDir, stateFileName)
+}
+
+// loadState returns the saved state of the export in the output directory, or
+// a new state if the export is just starting
+func loadState(cfg *configFlags, client *rpcclient.RPCClient) (*exportState, error) {
+	stateBytes, err := os.ReadFile(statePath(cfg))
+	if err == nil {
+		state := &exportState{}
+		err := json.Unmarshal(stateBytes, state)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", statePath(cfg))
+		}
+		if state.Network != cfg.NetParams().Name || state.Format != cfg.Format {
+			return nil, errors.Errorf("%s holds a %s export of %s, which can't be resumed as a %s export of %s",
+				cfg.OutputDir, state.Format, state.Network, cfg.Format, cfg.NetParams().Name)
+		}
+		if cfg.StartHash != "" {
+			return nil, errors.Errorf("%s holds an export that's resumed from %s, so --start-hash can't be set",
+				cfg.OutputDir, state.NextStartHash)
+		}
+		log.Infof("Resuming the export from %s", state.NextStartHash)
+		return state, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	startHash := cfg.StartHash
+	if startHash == "" {
+		blockDAGInfo, err := client.GetBlockDAGInfo()
+		if err != nil {
+			return nil, err
+		}
+		startHash = blockDAGInfo.PruningPointHash
+	}
+	err = os.MkdirAll(cfg.OutputDir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Starting the export from %s", startHash)
+	return &exportState{
+		Network:       cfg.NetParams().Name,
+		Format:        cfg.Format,
+		NextStartHash: startHash,
+		NextPart:      0,
+	}, nil
+}
+
+// save writes the state to the output directory. The state is first written
+// under a temporary name, so that it's replaced atomically.
+func (state *exportState) save(cfg *configFlags) error {
+	stateBytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	temporaryPath := statePath(cfg) + ".tmp"
+	err = os.WriteFile(temporaryPath, stateBytes, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporaryPath, statePath(cfg))
+}
+
+// export exports the selected parent chain, from the start hash up to the end
+// blue score, in batches of chain blocks. Every block is exported along with
+// the chain block that merges it, and every batch is written to its own set
+// of files before the state is saved, so that the export may be interrupted
+// at any point and resumed.
+func export(cfg *configFlags, client *rpcclient.RPCClient) error {
+	state, err := loadState(cfg, client)
+	if err != nil {
+		return err
+	}
+
+	for {
+		endBlueScore, err := exportEndBlueScore(cfg, client)
+		if err != nil {
+			return err
+		}
+
+		response, err := client.GetChainChangedEventsFromBlock(state.NextStartHash, cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(response.RemovedChainBlockHashes) > 0 {
+			return errors.Errorf("block %s, which the export continues from, is no longer in the selected "+
+				"parent chain. Start a new export with more --confirmations", state.NextStartHash)
+		}
+
+		blocks, transactions, acceptance := newTables()
+		exportedChainBlockCount := 0
+		reachedEnd := !response.HasMore
+		for i, chainBlockHash := range response.AddedChainBlockHashes {
+			isWithinRange, err := exportChainBlock(client, chainBlockHash, response.AcceptedTransactions[i],
+				endBlueScore, blocks, transactions, acceptance)
+			if err != nil {
+				return err
+			}
+			if !isWithinRange {
+				reachedEnd = true
+				break
+			}
+			exportedChainBlockCount++
+		}
+
+		if exportedChainBlockCount > 0 {
+			for _, table := range []*table{blocks, transactions, acceptance} {
+				err := table.write(cfg.OutputDir, state.NextPart, cfg.Format)
+				if err != nil {
+					return err
+				}
+			}
+			log.Infof("Exported %d chain blocks, merging %d blocks and accepting %d transactions, to part %d",
+				exportedChainBlockCount, len(blocks.rows), len(acceptance.rows), state.NextPart)
+
+			state.NextStartHash = response.AddedChainBlockHashes[exportedChainBlockCount-1]
+			state.NextPart++
+			err = state.save(cfg)
+			if err != nil {
+				return err
+			}
+		}
+
+		if reachedEnd {
+			log.Infof("The export is up to date. Run it again to export the chain blocks added since")
+			return nil
+		}
+	}
+}
+
+// exportEndBlueScore returns the blue score of the last chain block that's
+// exported, which is no higher than the virtual selected parent blue score
+// minus the required confirmations
+func exportEndBlueScore(cfg *configFlags, client *rpcclient.RPCClient) (uint64, error) {
+	response, err := client.GetVirtualSelectedParentBlueScore()
+	if err != nil {
+		return 0, err
+	}
+	if response.BlueScore < cfg.Confirmations {
+		return 0, nil
+	}
+	endBlueScore := response.BlueScore - cfg.Confirmations
+	if cfg.EndBlueScore != 0 && cfg.EndBlueScore < endBlueScore {
+		endBlueScore = cfg.EndBlueScore
+	}
+	return endBlueScore, nil
+}
+
+// exportChainBlock adds the rows of the given chain block, of the blocks it
+// merges and of the transactions it accepts to the tables. It returns false,
+// without adding anything, if the blue score of the chain block is above
+// endBlueScore.
+func exportChainBlock(client *rpcclient.RPCClient, chainBlockHash string,
+	acceptedTransactions *appmessage.AcceptedTransactions, endBlueScore uint64,
+	blocks, transactions, acceptance *table) (bool, error) {
+
+	chainBlock, err := getBlock(client, chainBlockHash)
+	if err != nil {
+		return false, err
+	}
+	if chainBlock.Header.BlueScore > endBlueScore {
+		return false, nil
+	}
+
+	mergedBlocks := []*appmessage.RPCBlock{chainBlock}
+	mergeSet := make([]string, 0, len(chainBlock.VerboseData.MergeSetBluesHashes)+len(chainBlock.VerboseData.MergeSetRedsHashes))
+	mergeSet = append(mergeSet, chainBlock.VerboseData.MergeSetBluesHashes...)
+	mergeSet = append(mergeSet, chainBlock.VerboseData.MergeSetRedsHashes...)
+	for _, blockHash := range mergeSet {
+		// The selected parent is merged by the previous chain block
+		if blockHash == chainBlock.VerboseData.SelectedParentHash {
+			continue
+		}
+		block, err := getBlock(client, blockHash)
+		if err != nil {
+			return false, err
+		}
+		mergedBlocks = append(mergedBlocks, block)
+	}
+
+	for _, block := range mergedBlocks {
+		addBlockRows(block, chainBlockHash, blocks, transactions)
+	}
+	for _, transaction := range acceptedTransactions.AcceptedTransactions {
+		domainTransaction, err := appmessage.RPCTransactionToDomainTransaction(transaction)
+		if err != nil {
+			return false, err
+		}
+		acceptance.addRow(chainBlockHash, int64(chainBlock.Header.BlueScore),
+			consensushashing.TransactionID(domainTransaction).String())
+	}
+	return true, nil
+}
+
+func getBlock(client *rpcclient.RPCClient, blockHash string) (*appmessage.RPCBlock, error) {
+	response, err := client.GetBlock(blockHash, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block %s", blockHash)
+	}
+	return response.Block, nil
+}
+
+func addBlockRows(block *appmessage.RPCBlock, acceptingBlockHash string, blocks, transactions *table) {
+	header := block.Header
+	verboseData := block.VerboseData
+	blocks.addRow(verboseData.Hash, acceptingBlockHash, verboseData.IsChainBlock, int64(header.Version),
+		verboseData.SelectedParentHash, header.Timestamp, int64(header.Bits), strconv.FormatUint(header.Nonce, 10),
+		int64(header.DAAScore), int64(header.BlueScore), header.BlueWork, int64(len(block.Transactions)),
+		int64(verboseData.TotalMass))
+
+	for i, transaction := range block.Transactions {
+		totalOutputAmount := uint64(0)
+		for _, output := range transaction.Outputs {
+			totalOutputAmount += output.Amount
+		}
+		transactionVerboseData := transaction.VerboseData
+		transactions.addRow(transactionVerboseData.TransactionID, transactionVerboseData.Hash, verboseData.Hash,
+			int64(i), int64(transactionVerboseData.BlockTime), int64(transaction.Version), transaction.SubnetworkID,
+			strconv.FormatUint(transaction.LockTime, 10), strconv.FormatUint(transaction.Gas, 10),
+			int64(transactionVerboseData.Mass), int64(len(transaction.Inputs)), int64(len(transaction.Outputs)),
+			int64(totalOutputAmount), transaction.Payload)
+	}
+}
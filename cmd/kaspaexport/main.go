@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+)
+
+const rpcTimeout = time.Minute
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		os.Exit(1)
+	}
+	initLog()
+	defer backendLog.Close()
+
+	err = run(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		backendLog.Close()
+		os.Exit(1)
+	}
+}
+
+func run(cfg *configFlags) error {
+	rpcAddress, err := cfg.NetParams().NormalizeRPCServerAddress(cfg.RPCServer)
+	if err != nil {
+		return err
+	}
+	client, err := rpcclient.NewRPCClientWithAuthToken(rpcAddress, cfg.AuthToken)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	client.SetTimeout(rpcTimeout)
+
+	return export(cfg, client)
+}
@@ -0,0 +1,121 @@
+// Package parquet writes tables as Apache Parquet files, using
+// github.com/parquet-go/parquet-go.
+//
+// Every column is required, and the columns are compressed with Snappy.
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	parquetgo "github.com/parquet-go/parquet-go"
+	"github.com/pkg/errors"
+)
+
+// ColumnType is the type of the values of a column
+type ColumnType int
+
+// ColumnType values. The values of String columns are Go strings, of Int64
+// columns int64s, and of Boolean columns bools.
+const (
+	String ColumnType = iota
+	Int64
+	Boolean
+)
+
+// Column describes a column of a table
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+const createdBy = "kaspaexport"
+
+func (columnType ColumnType) goType() (reflect.Type, error) {
+	switch columnType {
+	case String:
+		return reflect.TypeOf(""), nil
+	case Int64:
+		return reflect.TypeOf(int64(0)), nil
+	case Boolean:
+		return reflect.TypeOf(false), nil
+	}
+	return nil, errors.Errorf("unknown column type %d", columnType)
+}
+
+// schemaOf returns the schema of a table with the given columns. The schema
+// is derived from a struct type rather than built out of a parquet-go Group,
+// since a Group orders its fields by name and the columns should keep their
+// order.
+func schemaOf(columns []Column) (*parquetgo.Schema, error) {
+	fields := make([]reflect.StructField, len(columns))
+	for i, column := range columns {
+		goType, err := column.Type.goType()
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %s", column.Name)
+		}
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("Column%d", i),
+			Type: goType,
+			Tag:  reflect.StructTag(fmt.Sprintf(`parquet:"%s,snappy"`, column.Name)),
+		}
+	}
+	return parquetgo.SchemaOf(reflect.New(reflect.StructOf(fields)).Interface()), nil
+}
+
+// Write writes a Parquet file with the given columns and rows to w. Every row
+// must have a value of the matching type for every column.
+func Write(w io.Writer, columns []Column, rows [][]interface{}) error {
+	schema, err := schemaOf(columns)
+	if err != nil {
+		return err
+	}
+
+	parquetRows := make([]parquetgo.Row, len(rows))
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return errors.Errorf("row %d has %d values, but there are %d columns", i, len(row), len(columns))
+		}
+		parquetRow := make(parquetgo.Row, len(columns))
+		for j, column := range columns {
+			value, err := parquetValue(column, row[j])
+			if err != nil {
+				return errors.Wrapf(err, "row %d", i)
+			}
+			parquetRow[j] = value.Level(0, 0, j)
+		}
+		parquetRows[i] = parquetRow
+	}
+
+	writer := parquetgo.NewWriter(w, schema, parquetgo.CreatedBy(createdBy, "", ""))
+	_, err = writer.WriteRows(parquetRows)
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func parquetValue(column Column, value interface{}) (parquetgo.Value, error) {
+	switch column.Type {
+	case String:
+		stringValue, ok := value.(string)
+		if !ok {
+			return parquetgo.Value{}, errors.Errorf("value %v of column %s is not a string", value, column.Name)
+		}
+		return parquetgo.ByteArrayValue([]byte(stringValue)), nil
+	case Int64:
+		int64Value, ok := value.(int64)
+		if !ok {
+			return parquetgo.Value{}, errors.Errorf("value %v of column %s is not an int64", value, column.Name)
+		}
+		return parquetgo.Int64Value(int64Value), nil
+	case Boolean:
+		boolValue, ok := value.(bool)
+		if !ok {
+			return parquetgo.Value{}, errors.Errorf("value %v of column %s is not a bool", value, column.Name)
+		}
+		return parquetgo.BooleanValue(boolValue), nil
+	}
+	return parquetgo.Value{}, errors.Errorf("column %s has unknown type %d", column.Name, column.Type)
+}
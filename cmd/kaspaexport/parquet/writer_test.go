@@ -0,0 +1,78 @@
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	parquetgo "github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestWrite(t *testing.T) {
+	columns := []Column{
+		{Name: "hash", Type: String},
+		{Name: "blue_score", Type: Int64},
+		{Name: "is_chain_block", Type: Boolean},
+	}
+	var rows [][]interface{}
+	for i := 0; i < 20; i++ {
+		rows = append(rows, []interface{}{string(rune('a' + i)), int64(i * 1000), i%3 == 0})
+	}
+
+	var file bytes.Buffer
+	err := Write(&file, columns, rows)
+	if err != nil {
+		t.Fatalf("Write: %s", err)
+	}
+
+	parquetFile, err := parquetgo.OpenFile(bytes.NewReader(file.Bytes()), int64(file.Len()))
+	if err != nil {
+		t.Fatalf("OpenFile: %s", err)
+	}
+	if parquetFile.NumRows() != int64(len(rows)) {
+		t.Fatalf("Unexpected row count %d", parquetFile.NumRows())
+	}
+	fields := parquetFile.Schema().Fields()
+	if len(fields) != len(columns) {
+		t.Fatalf("Unexpected schema %s", parquetFile.Schema())
+	}
+	for i, column := range columns {
+		if fields[i].Name() != column.Name || !fields[i].Required() {
+			t.Fatalf("Unexpected schema field %s for column %s", fields[i].Name(), column.Name)
+		}
+	}
+	logicalType := fields[0].Type().LogicalType()
+	if logicalType == nil {
+		t.Fatalf("Expected the string column to be annotated as a string, but it has no logical type")
+	}
+	if _, isString := logicalType.Value.(*format.StringType); !isString {
+		t.Fatalf("Expected the string column to be annotated as a string, but its logical type is %v",
+			logicalType)
+	}
+
+	reader := parquetgo.NewReader(parquetFile)
+	defer reader.Close()
+	readRows := make([]parquetgo.Row, len(rows)+1)
+	n, err := reader.ReadRows(readRows)
+	if err != nil && err != io.EOF {
+		t.Fatalf("ReadRows: %s", err)
+	}
+	if n != len(rows) {
+		t.Fatalf("Read %d rows rather than %d", n, len(rows))
+	}
+	for i, row := range rows {
+		readRow := readRows[i]
+		values := []interface{}{readRow[0].String(), readRow[1].Int64(), readRow[2].Boolean()}
+		for j, value := range values {
+			if value != row[j] {
+				t.Fatalf("Unexpected value %v in row %d of column %s. Want: %v", value, i, columns[j].Name, row[j])
+			}
+		}
+	}
+
+	err = Write(&bytes.Buffer{}, columns, [][]interface{}{{"a", 1, true}})
+	if err == nil {
+		t.Fatalf("Expected a value of the wrong type to be rejected")
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/kaspanet/kaspad/cmd/kaspaexport/parquet"
+)
+
+// table is an exported table, whose rows are written to a separate set of
+// files per batch, named <name>-<part>.<format>
+type table struct {
+	name    string
+	columns []parquet.Column
+	rows    [][]interface{}
+}
+
+func newTables() (blocks, transactions, acceptance *table) {
+	blocks = &table{
+		name: "blocks",
+		columns: []parquet.Column{
+			{Name: "hash", Type: parquet.String},
+			{Name: "accepting_block_hash", Type: parquet.String},
+			{Name: "is_chain_block", Type: parquet.Boolean},
+			{Name: "version", Type: parquet.Int64},
+			{Name: "selected_parent_hash", Type: parquet.String},
+			{Name: "timestamp", Type: parquet.Int64},
+			{Name: "bits", Type: parquet.Int64},
+			{Name: "nonce", Type: parquet.String},
+			{Name: "daa_score", Type: parquet.Int64},
+			{Name: "blue_score", Type: parquet.Int64},
+			{Name: "blue_work", Type: parquet.String},
+			{Name: "transaction_count", Type: parquet.Int64},
+			{Name: "total_mass", Type: parquet.Int64},
+		},
+	}
+	transactions = &table{
+		name: "transactions",
+		columns: []parquet.Column{
+			{Name: "transaction_id", Type: parquet.String},
+			{Name: "hash", Type: parquet.String},
+			{Name: "block_hash", Type: parquet.String},
+			{Name: "index_in_block", Type: parquet.Int64},
+			{Name: "block_time", Type: parquet.Int64},
+			{Name: "version", Type: parquet.Int64},
+			{Name: "subnetwork_id", Type: parquet.String},
+			{Name: "lock_time", Type: parquet.String},
+			{Name: "gas", Type: parquet.String},
+			{Name: "mass", Type: parquet.Int64},
+			{Name: "input_count", Type: parquet.Int64},
+			{Name: "output_count", Type: parquet.Int64},
+			{Name: "total_output_amount", Type: parquet.Int64},
+			{Name: "payload", Type: parquet.String},
+		},
+	}
+	acceptance = &table{
+		name: "acceptance",
+		columns: []parquet.Column{
+			{Name: "accepting_block_hash", Type: parquet.String},
+			{Name: "accepting_block_blue_score", Type: parquet.Int64},
+			{Name: "transaction_id", Type: parquet.String},
+		},
+	}
+	return blocks, transactions, acceptance
+}
+
+func (t *table) addRow(values ...interface{}) {
+	t.rows = append(t.rows, values)
+}
+
+func (t *table) fileName(part uint64, format string) string {
+	return fmt.Sprintf("%s-%06d.%s", t.name, part, format)
+}
+
+// write writes the rows of the table to its file for the given part in
+// outputDir. The file is first written under a temporary name, so that a file
+// by its actual name is always complete.
+func (t *table) write(outputDir string, part uint64, format string) error {
+	path := filepath.Join(outputDir, t.fileName(part, format))
+	temporaryPath := path + ".tmp"
+	file, err := os.Create(temporaryPath)
+	if err != nil {
+		return err
+	}
+	if format == formatParquet {
+		err = parquet.Write(file, t.columns, t.rows)
+	} else {
+		err = t.writeCSV(file)
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporaryPath, path)
+}
+
+func (t *table) writeCSV(file *os.File) error {
+	writer := csv.NewWriter(file)
+	record := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		record[i] = column.Name
+	}
+	err := writer.Write(record)
+	if err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		for i, value := range row {
+			switch value := value.(type) {
+			case string:
+				record[i] = value
+			case int64:
+				record[i] = strconv.FormatInt(value, 10)
+			case bool:
+				record[i] = strconv.FormatBool(value)
+			}
+		}
+		err := writer.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
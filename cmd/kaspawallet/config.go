@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"time"
 
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/pkg/errors"
@@ -27,6 +28,13 @@ const (
 const (
 	defaultListen    = "localhost:8082"
 	defaultRPCServer = "localhost"
+
+	defaultConsolidateInterval        = time.Hour
+	defaultConsolidateMinUTXOs        = 100
+	defaultConsolidateMaxUTXOAmount   = 10
+	defaultConsolidateMaxInputs       = 80
+	defaultConsolidateMaxTransactions = 1
+	defaultConsolidateMaxFeeRate      = 1
 )
 
 type configFlags struct {
@@ -42,6 +50,9 @@ type createConfig struct {
 	NumPublicKeys     uint32 `long:"num-public-keys" short:"n" description:"Total number of keys" default:"1"`
 	ECDSA             bool   `long:"ecdsa" description:"Create an ECDSA wallet"`
 	Import            bool   `long:"import" short:"i" description:"Import private keys (as opposed to generating them)"`
+
+	WatchOnly          bool     `long:"watch-only" description:"Create a watch-only wallet, which has only extended public keys and no private keys. It tracks balances and creates unsigned transactions, to be signed by the wallet that holds the private keys"`
+	ExtendedPublicKeys []string `long:"xpub" description:"An extended public key of the wallet that isn't derived from its private keys. Use multiple times for multisig wallets. The keys that aren't given are prompted for"`
 	config.NetworkFlags
 }
 
@@ -117,6 +128,14 @@ type startDaemonConfig struct {
 	Listen    string `long:"listen" short:"l" description:"Address to listen on (default: 0.0.0.0:8082)"`
 	Timeout   uint32 `long:"wait-timeout" short:"w" description:"Waiting timeout for RPC calls, seconds (default: 30 s)"`
 	Profile   string `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+
+	Consolidate                bool          `long:"consolidate" description:"Automatically consolidate the small UTXOs of the wallet into a single one while fees are low, signing with the wallet password"`
+	ConsolidateInterval        time.Duration `long:"consolidate-interval" description:"How often to check whether to consolidate UTXOs (default: 1h)"`
+	ConsolidateMinUTXOs        uint32        `long:"consolidate-min-utxos" description:"Consolidate only once the wallet has at least this many small UTXOs (default: 100)"`
+	ConsolidateMaxUTXOAmount   float64       `long:"consolidate-max-utxo-amount" description:"The largest amount, in Kaspa, of a UTXO considered small (default: 10)"`
+	ConsolidateMaxInputs       uint32        `long:"consolidate-max-inputs" description:"The largest number of UTXOs consolidated by a single transaction (default: 80)"`
+	ConsolidateMaxTransactions uint32        `long:"consolidate-max-transactions" description:"The largest number of consolidation transactions sent per check (default: 1)"`
+	ConsolidateMaxFeeRate      float64       `long:"consolidate-max-fee-rate" description:"Consolidate only while the required fee rate is at most this many sompi per gram of mass (default: 1)"`
 	config.NetworkFlags
 }
 
@@ -181,6 +200,13 @@ func parseCommandLine() (subCommand string, config interface{}) {
 	startDaemonConf := &startDaemonConfig{
 		RPCServer: defaultRPCServer,
 		Listen:    defaultListen,
+
+		ConsolidateInterval:        defaultConsolidateInterval,
+		ConsolidateMinUTXOs:        defaultConsolidateMinUTXOs,
+		ConsolidateMaxUTXOAmount:   defaultConsolidateMaxUTXOAmount,
+		ConsolidateMaxInputs:       defaultConsolidateMaxInputs,
+		ConsolidateMaxTransactions: defaultConsolidateMaxTransactions,
+		ConsolidateMaxFeeRate:      defaultConsolidateMaxFeeRate,
 	}
 	parser.AddCommand(startDaemonSubCmd, "Start the wallet daemon", "Start the wallet daemon", startDaemonConf)
 
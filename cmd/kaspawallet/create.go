@@ -6,7 +6,6 @@ import (
 	"os"
 
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
-	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/bip32"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/utils"
 	"github.com/pkg/errors"
 
@@ -14,13 +13,40 @@ import (
 )
 
 func create(conf *createConfig) error {
+	if conf.WatchOnly {
+		if conf.Import {
+			return errors.New("--watch-only and --import cannot be used together")
+		}
+		// A watch-only wallet has no private keys, so all of its extended
+		// public keys are given
+		conf.NumPrivateKeys = 0
+		if conf.NumPublicKeys < uint32(len(conf.ExtendedPublicKeys)) {
+			conf.NumPublicKeys = uint32(len(conf.ExtendedPublicKeys))
+		}
+	}
+	if conf.NumPrivateKeys > conf.NumPublicKeys {
+		return errors.Errorf("the wallet can't have more private keys (%d) than keys in total (%d)",
+			conf.NumPrivateKeys, conf.NumPublicKeys)
+	}
+	if conf.MinimumSignatures > conf.NumPublicKeys {
+		return errors.Errorf("the wallet can't require more signatures (%d) than it has keys (%d)",
+			conf.MinimumSignatures, conf.NumPublicKeys)
+	}
+	if uint32(len(conf.ExtendedPublicKeys)) > conf.NumPublicKeys-conf.NumPrivateKeys {
+		return errors.Errorf("%d extended public keys were given, but only %d of the keys of the wallet "+
+			"aren't derived from its private keys", len(conf.ExtendedPublicKeys), conf.NumPublicKeys-conf.NumPrivateKeys)
+	}
+
 	var encryptedMnemonics []*keys.EncryptedMnemonic
 	var signerExtendedPublicKeys []string
 	var err error
 	isMultisig := conf.NumPublicKeys > 1
-	if !conf.Import {
+	switch {
+	case conf.WatchOnly:
+		// There are no private keys to encrypt, so no password is needed
+	case !conf.Import:
 		encryptedMnemonics, signerExtendedPublicKeys, err = keys.CreateMnemonics(conf.NetParams(), conf.NumPrivateKeys, conf.Password, isMultisig)
-	} else {
+	default:
 		encryptedMnemonics, signerExtendedPublicKeys, err = keys.ImportMnemonics(conf.NetParams(), conf.NumPrivateKeys, conf.Password, isMultisig)
 	}
 	if err != nil {
@@ -31,28 +57,36 @@ func create(conf *createConfig) error {
 		fmt.Printf("Extended public key of mnemonic #%d:\n%s\n\n", i+1, extendedPublicKey)
 	}
 
-	fmt.Printf("Notice the above is neither a secret key to your wallet " +
-		"(use \"kaspawallet dump-unencrypted-data\" to see a secret seed phrase) " +
-		"nor a wallet public address (use \"kaspawallet new-address\" to create and see one)\n\n")
+	if !conf.WatchOnly {
+		fmt.Printf("Notice the above is neither a secret key to your wallet " +
+			"(use \"kaspawallet dump-unencrypted-data\" to see a secret seed phrase) " +
+			"nor a wallet public address (use \"kaspawallet new-address\" to create and see one)\n\n")
+	}
 
 	extendedPublicKeys := make([]string, conf.NumPrivateKeys, conf.NumPublicKeys)
 	copy(extendedPublicKeys, signerExtendedPublicKeys)
 	reader := bufio.NewReader(os.Stdin)
 	for i := conf.NumPrivateKeys; i < conf.NumPublicKeys; i++ {
-		fmt.Printf("Enter public key #%d here:\n", i+1)
-		extendedPublicKey, err := utils.ReadLine(reader)
-		if err != nil {
-			return err
+		var extendedPublicKey string
+		if givenIndex := i - conf.NumPrivateKeys; givenIndex < uint32(len(conf.ExtendedPublicKeys)) {
+			extendedPublicKey = conf.ExtendedPublicKeys[givenIndex]
+		} else {
+			fmt.Printf("Enter public key #%d here:\n", i+1)
+			line, err := utils.ReadLine(reader)
+			if err != nil {
+				return err
+			}
+			extendedPublicKey = string(line)
+
+			fmt.Println()
 		}
 
-		_, err = bip32.DeserializeExtendedKey(string(extendedPublicKey))
+		err = libkaspawallet.ValidateExtendedPublicKey(conf.NetParams(), extendedPublicKey)
 		if err != nil {
-			return errors.Wrapf(err, "%s is invalid extended public key", string(extendedPublicKey))
+			return err
 		}
 
-		fmt.Println()
-
-		extendedPublicKeys = append(extendedPublicKeys, string(extendedPublicKey))
+		extendedPublicKeys = append(extendedPublicKeys, extendedPublicKey)
 	}
 
 	// For a read only wallet the cosigner index is 0
@@ -89,5 +123,9 @@ func create(conf *createConfig) error {
 	}
 
 	fmt.Printf("Wrote the keys into %s\n", file.Path())
+	if conf.WatchOnly {
+		fmt.Println("The wallet is watch-only: use \"kaspawallet create-unsigned-transaction\" to spend its funds, " +
+			"and sign the transactions with the wallet that holds the private keys")
+	}
 	return nil
 }
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"math"
+	"time"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+// ConsolidationConfig configures the automatic consolidation of the small
+// UTXOs of the wallet. Without it, a wallet that receives many small payments,
+// such as the wallet of a miner, ends up with a balance too fragmented to be
+// spent by transactions of a standard mass.
+type ConsolidationConfig struct {
+	// Password decrypts the keys that sign the consolidation transactions
+	Password string
+
+	// Interval is how often the wallet checks whether to consolidate
+	Interval time.Duration
+
+	// MinimumUTXOCount is the least number of small UTXOs worth consolidating
+	MinimumUTXOCount uint32
+
+	// MaximumUTXOAmount is the largest amount, in sompi, of a small UTXO
+	MaximumUTXOAmount uint64
+
+	// MaximumInputCount is the largest number of UTXOs a single
+	// consolidation transaction spends
+	MaximumInputCount uint32
+
+	// MaximumTransactionCount is the largest number of consolidation
+	// transactions sent per check
+	MaximumTransactionCount uint32
+
+	// MaximumFeeRate is the highest fee rate, in sompi per gram of mass,
+	// that consolidation transactions pay. No UTXOs are consolidated while
+	// the node requires a higher one.
+	MaximumFeeRate float64
+}
+
+// consolidationTargetDAAScore is the confirmation target the fee rate of
+// consolidation transactions is estimated for. They aren't urgent, so this is
+// the longest confirmation window the node tracks.
+const consolidationTargetDAAScore = 600
+
+func (s *server) consolidateUTXOsPeriodically(config *ConsolidationConfig) {
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := s.consolidateUTXOsWithLock(config)
+		if err != nil {
+			log.Warnf("Error consolidating UTXOs: %s", err)
+		}
+	}
+}
+
+func (s *server) consolidateUTXOsWithLock(config *ConsolidationConfig) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.consolidateUTXOs(config)
+}
+
+func (s *server) consolidateUTXOs(config *ConsolidationConfig) error {
+	if !s.isSynced() {
+		log.Debugf("Not consolidating UTXOs: the wallet daemon is not synced yet, %s", s.formatSyncStateReport())
+		return nil
+	}
+
+	feeRate, maximumMass, err := s.consolidationPolicy()
+	if err != nil {
+		return err
+	}
+	if feeRate > config.MaximumFeeRate {
+		log.Debugf("Not consolidating UTXOs: the fee rate is %f sompi/gram, more than the maximum of %f",
+			feeRate, config.MaximumFeeRate)
+		return nil
+	}
+
+	err = s.refreshUTXOs()
+	if err != nil {
+		return err
+	}
+	dagInfo, err := s.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < config.MaximumTransactionCount; i++ {
+		utxos := s.selectConsolidationUTXOs(config, dagInfo.VirtualDAAScore)
+		if uint32(len(utxos)) < config.MinimumUTXOCount {
+			log.Debugf("Not consolidating UTXOs: the wallet has %d small UTXOs, less than the minimum of %d",
+				len(utxos), config.MinimumUTXOCount)
+			return nil
+		}
+		if uint32(len(utxos)) > config.MaximumInputCount {
+			utxos = utxos[:config.MaximumInputCount]
+		}
+
+		err := s.sendConsolidationTransaction(config, utxos, feeRate, maximumMass)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// consolidationPolicy returns the fee rate, in sompi per gram of mass, that a
+// consolidation transaction has to pay, and the maximum mass it may have
+func (s *server) consolidationPolicy() (feeRate float64, maximumMass uint64, err error) {
+	feeEstimate, err := s.rpcClient.EstimateFee(consolidationTargetDAAScore)
+	if err != nil {
+		return 0, 0, err
+	}
+	relayPolicy, err := s.rpcClient.GetRelayPolicy()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The node requires a higher fee rate than its estimate while its mempool is full
+	feeRate = math.Max(feeEstimate.FeeRate, float64(relayPolicy.MinimumRelayTransactionFee)/1000)
+	feeRate = math.Max(feeRate, float64(relayPolicy.EffectiveMinimumRelayTransactionFee)/1000)
+
+	maximumMass = relayPolicy.MaximumStandardTransactionMass
+	if maximumMass == 0 {
+		maximumMass = mempool.MaximumStandardTransactionMass
+	}
+	return feeRate, maximumMass, nil
+}
+
+// selectConsolidationUTXOs returns the spendable UTXOs of the wallet whose
+// amount is at most config.MaximumUTXOAmount, ordered from the smallest
+func (s *server) selectConsolidationUTXOs(config *ConsolidationConfig, virtualDAAScore uint64) []*walletUTXO {
+	var utxos []*walletUTXO
+
+	// utxosSortedByAmount is sorted in descending order, so it's iterated
+	// from its end to start from the smallest UTXOs
+	for i := len(s.utxosSortedByAmount) - 1; i >= 0; i-- {
+		utxo := s.utxosSortedByAmount[i]
+		if utxo.UTXOEntry.Amount() > config.MaximumUTXOAmount {
+			break
+		}
+		if !isUTXOSpendable(utxo, virtualDAAScore, s.params.BlockCoinbaseMaturity) {
+			continue
+		}
+		if broadcastTime, ok := s.usedOutpoints[*utxo.Outpoint]; ok && time.Since(broadcastTime) <= time.Minute {
+			continue
+		}
+		utxos = append(utxos, utxo)
+	}
+	return utxos
+}
+
+func (s *server) sendConsolidationTransaction(config *ConsolidationConfig, utxos []*walletUTXO,
+	feeRate float64, maximumMass uint64) error {
+
+	changeAddress, _, err := s.changeAddress(false, nil)
+	if err != nil {
+		return err
+	}
+
+	// The mass of the transaction, and thus its fee, doesn't depend on the
+	// amount it pays, so it's first built paying the whole value of its inputs
+	var transaction *serialization.PartiallySignedTransaction
+	var mass uint64
+	for {
+		transaction, err = s.createConsolidationTransaction(changeAddress, utxos, 0)
+		if err != nil {
+			return err
+		}
+		mass, err = s.estimateMassAfterSignatures(transaction)
+		if err != nil {
+			return err
+		}
+		if mass <= maximumMass {
+			break
+		}
+		if len(utxos) == 1 {
+			return errors.Errorf("a consolidation transaction spending a single UTXO has a mass of %d, "+
+				"more than the maximum of %d", mass, maximumMass)
+		}
+		utxos = utxos[:reducedConsolidationInputCount(len(utxos), mass, maximumMass)]
+	}
+
+	fee := uint64(math.Ceil(float64(mass) * feeRate))
+	transaction, err = s.createConsolidationTransaction(changeAddress, utxos, fee)
+	if err != nil {
+		return err
+	}
+	transactionBytes, err := serialization.SerializePartiallySignedTransaction(transaction)
+	if err != nil {
+		return err
+	}
+	signedTransactions, err := s.signTransactions([][]byte{transactionBytes}, config.Password)
+	if err != nil {
+		return err
+	}
+	transactionIDs, err := s.broadcast(signedTransactions, false)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Consolidated %d UTXOs worth %f KAS into %s with transaction %s, paying a fee of %d sompi",
+		len(utxos), float64(transaction.Tx.Outputs[0].Value+fee)/constants.SompiPerKaspa, changeAddress,
+		transactionIDs[0], fee)
+	return nil
+}
+
+// reducedConsolidationInputCount returns how many of the inputs of a
+// consolidation transaction with the given mass to keep, so that it gets
+// closer to the maximum mass. The inputs are reduced proportionally, but at
+// least one is removed and at least one is kept, so that repeated reductions
+// always end with a transaction that is either standard or has a single input.
+func reducedConsolidationInputCount(inputCount int, mass uint64, maximumMass uint64) int {
+	reducedInputCount := int(uint64(inputCount) * maximumMass / mass)
+	if reducedInputCount >= inputCount {
+		reducedInputCount = inputCount - 1
+	}
+	if reducedInputCount < 1 {
+		reducedInputCount = 1
+	}
+	return reducedInputCount
+}
+
+// createConsolidationTransaction creates a transaction that spends the given
+// UTXOs, paying their whole value, minus the given fee, to the given address
+func (s *server) createConsolidationTransaction(address util.Address, utxos []*walletUTXO, fee uint64) (
+	*serialization.PartiallySignedTransaction, error) {
+
+	totalValue := uint64(0)
+	selectedUTXOs := make([]*libkaspawallet.UTXO, len(utxos))
+	for i, utxo := range utxos {
+		selectedUTXOs[i] = &libkaspawallet.UTXO{
+			Outpoint:       utxo.Outpoint,
+			UTXOEntry:      utxo.UTXOEntry,
+			DerivationPath: s.walletAddressPath(utxo.address),
+		}
+		totalValue += utxo.UTXOEntry.Amount()
+	}
+	if fee >= totalValue {
+		return nil, errors.Errorf("the %d UTXOs to consolidate are worth %d sompi, which doesn't cover "+
+			"the fee of %d sompi", len(utxos), totalValue, fee)
+	}
+
+	payments := []*libkaspawallet.Payment{{
+		Address: address,
+		Amount:  totalValue - fee,
+	}}
+	transactionBytes, err := libkaspawallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
+		s.keysFile.MinimumSignatures, payments, selectedUTXOs)
+	if err != nil {
+		return nil, err
+	}
+	return serialization.DeserializePartiallySignedTransaction(transactionBytes)
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestSelectConsolidationUTXOs(t *testing.T) {
+	params := dagconfig.SimnetParams
+	params.BlockCoinbaseMaturity = 100
+	const virtualDAAScore = 1000
+
+	scriptPublicKey, _ := testutils.OpTrueScript()
+	newUTXO := func(index uint32, amount uint64, isCoinbase bool, blockDAAScore uint64) *walletUTXO {
+		return &walletUTXO{
+			Outpoint:  &externalapi.DomainOutpoint{Index: index},
+			UTXOEntry: utxo.NewUTXOEntry(amount, scriptPublicKey, isCoinbase, blockDAAScore),
+		}
+	}
+	large := newUTXO(0, 5000, false, 0)
+	small := newUTXO(1, 300, false, 0)
+	immatureCoinbase := newUTXO(2, 200, true, virtualDAAScore-1)
+	matureCoinbase := newUTXO(3, 200, true, 0)
+	recentlySpent := newUTXO(4, 150, false, 0)
+	smallest := newUTXO(5, 100, false, 0)
+
+	serverInstance := &server{
+		params:              &params,
+		utxosSortedByAmount: []*walletUTXO{large, small, immatureCoinbase, matureCoinbase, recentlySpent, smallest},
+		usedOutpoints:       map[externalapi.DomainOutpoint]time.Time{*recentlySpent.Outpoint: time.Now()},
+	}
+
+	selectedUTXOs := serverInstance.selectConsolidationUTXOs(&ConsolidationConfig{MaximumUTXOAmount: 1000}, virtualDAAScore)
+	expectedUTXOs := []*walletUTXO{smallest, matureCoinbase, small}
+	if len(selectedUTXOs) != len(expectedUTXOs) {
+		t.Fatalf("Expected %d UTXOs to be selected, but got %d", len(expectedUTXOs), len(selectedUTXOs))
+	}
+	for i, expectedUTXO := range expectedUTXOs {
+		if selectedUTXOs[i] != expectedUTXO {
+			t.Errorf("Expected UTXO %d to be %s, but got %s", i, expectedUTXO.Outpoint, selectedUTXOs[i].Outpoint)
+		}
+	}
+}
+
+func TestReducedConsolidationInputCount(t *testing.T) {
+	tests := []struct {
+		inputCount  int
+		mass        uint64
+		maximumMass uint64
+		expected    int
+	}{
+		{inputCount: 100, mass: 200_000, maximumMass: 100_000, expected: 50},
+		{inputCount: 10, mass: 100_001, maximumMass: 100_000, expected: 9},
+		// A transaction so heavy that the proportional reduction would keep no inputs
+		{inputCount: 2, mass: 500_000, maximumMass: 100_000, expected: 1},
+		{inputCount: 1, mass: 500_000, maximumMass: 100_000, expected: 1},
+	}
+	for _, test := range tests {
+		inputCount := reducedConsolidationInputCount(test.inputCount, test.mass, test.maximumMass)
+		if inputCount != test.expected {
+			t.Errorf("Expected %d of %d inputs with a mass of %d to be kept, but got %d",
+				test.expected, test.inputCount, test.mass, inputCount)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
 
 	"github.com/kaspanet/kaspad/util/txmass"
 
@@ -48,8 +49,11 @@ type server struct {
 // Currently, set to 100MB
 const MaxDaemonSendMsgSize = 100_000_000
 
-// Start starts the kaspawalletd server
-func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath string, profile string, timeout uint32) error {
+// Start starts the kaspawalletd server. If consolidationConfig isn't nil, the
+// small UTXOs of the wallet are consolidated automatically.
+func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath string, profile string, timeout uint32,
+	consolidationConfig *ConsolidationConfig) error {
+
 	initLog(defaultLogFile, defaultErrLogFile)
 
 	defer panics.HandlePanic(log, "MAIN", nil)
@@ -82,6 +86,13 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		return err
 	}
 
+	if consolidationConfig != nil {
+		_, err = keysFile.DecryptMnemonics(consolidationConfig.Password)
+		if err != nil {
+			return errors.Wrap(err, "Error decrypting the keys file for UTXO consolidation")
+		}
+	}
+
 	serverInstance := &server{
 		rpcClient:                   rpcClient,
 		params:                      params,
@@ -97,6 +108,9 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		maxProcessedAddressesForLog: 0,
 	}
 
+	if keysFile.IsWatchOnly() {
+		log.Infof("The wallet is watch-only, so it creates unsigned transactions but doesn't sign them")
+	}
 	log.Infof("Read, syncing the wallet...")
 	spawn("serverInstance.sync", func() {
 		err := serverInstance.sync()
@@ -105,6 +119,14 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		}
 	})
 
+	if consolidationConfig != nil {
+		log.Infof("Consolidating UTXOs of up to %f KAS every %s",
+			float64(consolidationConfig.MaximumUTXOAmount)/constants.SompiPerKaspa, consolidationConfig.Interval)
+		spawn("serverInstance.consolidateUTXOsPeriodically", func() {
+			serverInstance.consolidateUTXOsPeriodically(consolidationConfig)
+		})
+	}
+
 	grpcServer := grpc.NewServer(grpc.MaxSendMsgSize(MaxDaemonSendMsgSize))
 	pb.RegisterKaspawalletdServer(grpcServer, serverInstance)
 
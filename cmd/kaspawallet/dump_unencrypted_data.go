@@ -24,6 +24,9 @@ func dumpUnencryptedData(conf *dumpUnencryptedDataConfig) error {
 	if err != nil {
 		return err
 	}
+	if keysFile.IsWatchOnly() {
+		return errors.WithStack(keys.ErrWatchOnly)
+	}
 
 	if len(conf.Password) == 0 {
 		conf.Password = keys.GetPassword("Password:")
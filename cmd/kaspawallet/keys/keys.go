@@ -28,6 +28,11 @@ var (
 // LastVersion is the most up to date file format version
 const LastVersion = 1
 
+// ErrWatchOnly is returned when the private keys of a watch-only wallet are requested
+var ErrWatchOnly = errors.New("the wallet is watch-only, so it has no private keys to sign with. " +
+	"Create unsigned transactions with it, sign them with the wallet that holds the private keys, " +
+	"and broadcast them")
+
 func defaultKeysFile(netParams *dagconfig.Params) string {
 	return filepath.Join(defaultAppDir, netParams.Name, "keys.json")
 }
@@ -205,9 +210,19 @@ func (d *File) LastUsedInternalIndex() uint32 {
 	return d.lastUsedInternalIndex
 }
 
+// IsWatchOnly returns whether the wallet has only extended public keys, and
+// no private keys to sign transactions with
+func (d *File) IsWatchOnly() bool {
+	return len(d.EncryptedMnemonics) == 0
+}
+
 // DecryptMnemonics asks the user to enter the password for the private keys and
 // returns the decrypted private keys.
 func (d *File) DecryptMnemonics(password string) ([]string, error) {
+	if d.IsWatchOnly() {
+		return nil, errors.WithStack(ErrWatchOnly)
+	}
+
 	passwordBytes := []byte(password)
 
 	var numThreads uint8
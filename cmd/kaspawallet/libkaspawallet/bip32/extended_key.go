@@ -53,7 +53,7 @@ func (extKey *ExtendedKey) Public() (*ExtendedKey, error) {
 		return nil, errors.Wrap(err, "error calculating publicKey")
 	}
 
-	version, err := toPublicVersion(extKey.Version)
+	version, err := ToPublicVersion(extKey.Version)
 	if err != nil {
 		return nil, err
 	}
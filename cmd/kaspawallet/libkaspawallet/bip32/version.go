@@ -102,7 +102,9 @@ var KaspaSimnetPublic = [4]byte{
 	0x7d,
 }
 
-func toPublicVersion(version [4]byte) ([4]byte, error) {
+// ToPublicVersion returns the version of the public extended keys that
+// correspond to the private extended keys of the given version
+func ToPublicVersion(version [4]byte) ([4]byte, error) {
 	switch version {
 	case BitcoinMainnetPrivate:
 		return BitcoinMainnetPublic, nil
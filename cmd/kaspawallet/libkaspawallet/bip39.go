@@ -80,3 +80,29 @@ func versionFromParams(params *dagconfig.Params) ([4]byte, error) {
 
 	return [4]byte{}, errors.Errorf("unknown network %s", params.Name)
 }
+
+// ValidateExtendedPublicKey returns an error if the given string isn't an
+// extended public key of the given network
+func ValidateExtendedPublicKey(params *dagconfig.Params, extendedPublicKey string) error {
+	extendedKey, err := bip32.DeserializeExtendedKey(extendedPublicKey)
+	if err != nil {
+		return errors.Wrapf(err, "%s is an invalid extended public key", extendedPublicKey)
+	}
+	// The key itself isn't included in the error, so that it doesn't leak
+	if extendedKey.IsPrivate() {
+		return errors.New("an extended private key was given instead of an extended public key")
+	}
+
+	privateVersion, err := versionFromParams(params)
+	if err != nil {
+		return err
+	}
+	publicVersion, err := bip32.ToPublicVersion(privateVersion)
+	if err != nil {
+		return err
+	}
+	if extendedKey.Version != publicVersion {
+		return errors.Errorf("%s is not an extended public key of %s", extendedPublicKey, params.Name)
+	}
+	return nil
+}
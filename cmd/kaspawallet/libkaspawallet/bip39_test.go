@@ -0,0 +1,46 @@
+package libkaspawallet_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/bip32"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestValidateExtendedPublicKey(t *testing.T) {
+	mnemonic, err := libkaspawallet.CreateMnemonic()
+	if err != nil {
+		t.Fatalf("CreateMnemonic: %+v", err)
+	}
+	extendedPublicKey, err := libkaspawallet.MasterPublicKeyFromMnemonic(&dagconfig.MainnetParams, mnemonic, false)
+	if err != nil {
+		t.Fatalf("MasterPublicKeyFromMnemonic: %+v", err)
+	}
+
+	err = libkaspawallet.ValidateExtendedPublicKey(&dagconfig.MainnetParams, extendedPublicKey)
+	if err != nil {
+		t.Fatalf("ValidateExtendedPublicKey: %+v", err)
+	}
+
+	err = libkaspawallet.ValidateExtendedPublicKey(&dagconfig.TestnetParams, extendedPublicKey)
+	if err == nil || !strings.Contains(err.Error(), "is not an extended public key of kaspa-testnet") {
+		t.Fatalf("Expected a mainnet key to be rejected on testnet, but got: %v", err)
+	}
+
+	err = libkaspawallet.ValidateExtendedPublicKey(&dagconfig.MainnetParams, "kpub-not-a-key")
+	if err == nil || !strings.Contains(err.Error(), "is an invalid extended public key") {
+		t.Fatalf("Expected an invalid key to be rejected, but got: %v", err)
+	}
+
+	extendedPrivateKey, err := bip32.NewMaster(bip39.NewSeed(mnemonic, ""), bip32.KaspaMainnetPrivate)
+	if err != nil {
+		t.Fatalf("NewMaster: %+v", err)
+	}
+	err = libkaspawallet.ValidateExtendedPublicKey(&dagconfig.MainnetParams, extendedPrivateKey.String())
+	if err == nil || strings.Contains(err.Error(), extendedPrivateKey.String()) {
+		t.Fatalf("Expected an extended private key to be rejected without being revealed, but got: %v", err)
+	}
+}
@@ -20,6 +20,10 @@ func send(conf *sendConfig) error {
 		return err
 	}
 
+	if keysFile.IsWatchOnly() {
+		return errors.Errorf("Cannot use 'send' command for a watch-only wallet. Use 'create-unsigned-transaction', " +
+			"sign the transaction with the wallet that holds the private keys and 'broadcast' it")
+	}
 	if len(keysFile.ExtendedPublicKeys) > len(keysFile.EncryptedMnemonics) {
 		return errors.Errorf("Cannot use 'send' command for multisig wallet without all of the keys")
 	}
@@ -23,6 +23,9 @@ func sign(conf *signConfig) error {
 	if err != nil {
 		return err
 	}
+	if keysFile.IsWatchOnly() {
+		return errors.WithStack(keys.ErrWatchOnly)
+	}
 
 	if len(conf.Password) == 0 {
 		conf.Password = keys.GetPassword("Password:")
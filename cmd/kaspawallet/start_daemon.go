@@ -1,7 +1,36 @@
 package main
 
-import "github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/server"
+import (
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/server"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/pkg/errors"
+)
 
 func startDaemon(conf *startDaemonConfig) error {
-	return server.Start(conf.NetParams(), conf.Listen, conf.RPCServer, conf.KeysFile, conf.Profile, conf.Timeout)
+	var consolidationConfig *server.ConsolidationConfig
+	if conf.Consolidate {
+		if conf.ConsolidateInterval <= 0 || conf.ConsolidateMinUTXOs == 0 || conf.ConsolidateMaxInputs == 0 ||
+			conf.ConsolidateMaxTransactions == 0 {
+
+			return errors.New("--consolidate-interval, --consolidate-min-utxos, --consolidate-max-inputs " +
+				"and --consolidate-max-transactions must be greater than 0")
+		}
+
+		if len(conf.Password) == 0 {
+			conf.Password = keys.GetPassword("Password:")
+		}
+		consolidationConfig = &server.ConsolidationConfig{
+			Password:                conf.Password,
+			Interval:                conf.ConsolidateInterval,
+			MinimumUTXOCount:        conf.ConsolidateMinUTXOs,
+			MaximumUTXOAmount:       uint64(conf.ConsolidateMaxUTXOAmount * constants.SompiPerKaspa),
+			MaximumInputCount:       conf.ConsolidateMaxInputs,
+			MaximumTransactionCount: conf.ConsolidateMaxTransactions,
+			MaximumFeeRate:          conf.ConsolidateMaxFeeRate,
+		}
+	}
+
+	return server.Start(conf.NetParams(), conf.Listen, conf.RPCServer, conf.KeysFile, conf.Profile, conf.Timeout,
+		consolidationConfig)
 }
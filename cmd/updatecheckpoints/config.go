@@ -0,0 +1,43 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+const (
+	defaultDataDirname = "datadir2"
+	defaultCount       = 10
+)
+
+type configFlags struct {
+	AppDir string `long:"appdir" short:"b" description:"The kaspad application directory to read the pruning points from"`
+	Count  int    `long:"count" short:"c" description:"The number of most recent pruning points to commit to"`
+	Output string `long:"output" short:"o" description:"The file to write the generated checkpoints to. Prints to stdout if not set"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		AppDir: config.DefaultAppDir,
+		Count:  defaultCount,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (cfg *configFlags) databasePath() string {
+	return filepath.Join(cfg.AppDir, cfg.NetParams().Name, defaultDataDirname)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"text/template"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+const leveldbCacheSizeMiB = 256
+
+// checkpointsVariableNames maps the networks that have checkpoints to the
+// names of the variables that hold them in package dagconfig
+var checkpointsVariableNames = map[string]string{
+	dagconfig.MainnetParams.Name: "mainnetCheckpoints",
+	dagconfig.TestnetParams.Name: "testnetCheckpoints",
+}
+
+var checkpointsTemplate = template.Must(template.New("checkpoints").Parse(`// Code generated by cmd/updatecheckpoints. DO NOT EDIT.
+
+package dagconfig
+
+var {{.VariableName}} = []Checkpoint{
+{{- range .Checkpoints}}
+	newCheckpoint({{.PruningPointIndex}}, "{{.Hash}}"),
+{{- end}}
+}
+`))
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		os.Exit(1)
+	}
+
+	err = updateCheckpoints(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+}
+
+func updateCheckpoints(cfg *configFlags) error {
+	variableName, ok := checkpointsVariableNames[cfg.NetParams().Name]
+	if !ok {
+		return errors.Errorf("network %s does not have checkpoints", cfg.NetParams().Name)
+	}
+
+	checkpoints, err := recentCheckpoints(cfg)
+	if err != nil {
+		return err
+	}
+
+	generated := &bytes.Buffer{}
+	err = checkpointsTemplate.Execute(generated, map[string]interface{}{
+		"VariableName": variableName,
+		"Checkpoints":  checkpoints,
+	})
+	if err != nil {
+		return err
+	}
+	formatted, err := format.Source(generated.Bytes())
+	if err != nil {
+		return err
+	}
+
+	if cfg.Output == "" {
+		_, err = os.Stdout.Write(formatted)
+		return err
+	}
+	err = os.WriteFile(cfg.Output, formatted, 0644)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d checkpoints to %s\n", len(checkpoints), cfg.Output)
+	return nil
+}
+
+// recentCheckpoints reads the pruning points of the node in the configured
+// application directory, and returns checkpoints for the most recent ones.
+// The node must not be running while this is done.
+func recentCheckpoints(cfg *configFlags) ([]dagconfig.Checkpoint, error) {
+	_, err := os.Stat(cfg.databasePath())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find a kaspad database")
+	}
+	db, err := ldb.NewLevelDB(cfg.databasePath(), leveldbCacheSizeMiB)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open the database at %s. Make sure kaspad is not running",
+			cfg.databasePath())
+	}
+	defer db.Close()
+
+	consensusConfig := &consensus.Config{Params: *cfg.NetParams()}
+	domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+	if err != nil {
+		return nil, err
+	}
+	pruningPointHeaders, err := domainInstance.Consensus().PruningPointHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	// The genesis is always the first pruning point, so there is no point in
+	// committing to it
+	firstIndex := 1
+	if len(pruningPointHeaders)-cfg.Count > firstIndex {
+		firstIndex = len(pruningPointHeaders) - cfg.Count
+	}
+	var checkpoints []dagconfig.Checkpoint
+	for i := firstIndex; i < len(pruningPointHeaders); i++ {
+		checkpoints = append(checkpoints, dagconfig.Checkpoint{
+			PruningPointIndex: uint64(i),
+			Hash:              consensushashing.HeaderHash(pruningPointHeaders[i]),
+		})
+	}
+	return checkpoints, nil
+}
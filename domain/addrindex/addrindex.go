@@ -0,0 +1,167 @@
+package addrindex
+
+import (
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/domain/indexsync"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// AddrIndex maintains an index between script public keys and the
+// accepted transactions that touched them
+type AddrIndex struct {
+	store  *addrIndexStore
+	syncer *indexsync.Syncer
+}
+
+// New creates a new address index.
+//
+// Blocks may be added to the consensus while this is called, since the index
+// catches up with the chain changes it misses meanwhile on the next Update.
+func New(domain domain.Domain, database database.Database) (*AddrIndex, error) {
+	addrIndex := &AddrIndex{
+		store: newAddrIndexStore(database),
+	}
+	addrIndex.syncer = indexsync.New("address index", domain, database, virtualSelectedParentKey, &indexsync.Callbacks{
+		Reset:            addrIndex.reset,
+		RemoveChainBlock: addrIndex.removeChainBlock,
+		AddChainBlock:    addrIndex.addChainBlock,
+	})
+
+	err := addrIndex.syncer.CatchUp()
+	if err != nil {
+		return nil, err
+	}
+	return addrIndex, nil
+}
+
+// Reset deletes the whole address index and resyncs it from the pruning point.
+// Transactions that were accepted before the pruning point are not indexed.
+func (ai *AddrIndex) Reset() error {
+	return ai.syncer.Reset()
+}
+
+// Update updates the address index with the given DAG selected parent chain changes
+func (ai *AddrIndex) Update(chainChanges *externalapi.SelectedChainPath) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "AddrIndex.Update")
+	defer onEnd()
+
+	return ai.syncer.Update(chainChanges)
+}
+
+func (ai *AddrIndex) reset(_ *externalapi.DomainHash) error {
+	return ai.store.deleteAll()
+}
+
+func (ai *AddrIndex) removeChainBlock(dbTransaction database.Transaction,
+	blockHash *externalapi.DomainHash, blueScore uint64) error {
+
+	log.Tracef("Removing the transactions accepted by chain block %s from the address index", blockHash)
+	return ai.store.removeAddressTransactions(dbTransaction, blockHash, blueScore)
+}
+
+func (ai *AddrIndex) addChainBlock(dbTransaction database.Transaction, blockHash *externalapi.DomainHash,
+	header externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error {
+
+	entries, addressTransactions := acceptedAddressTransactions(acceptanceData, blockHash, header.BlueScore())
+	log.Tracef("Adding %d address transactions accepted by chain block %s to the address index",
+		len(entries), blockHash)
+	return ai.store.addAddressTransactions(dbTransaction, blockHash, header.BlueScore(), entries, addressTransactions)
+}
+
+// acceptedAddressTransactions returns the transactions accepted by a chain block
+// with the given acceptance data, once for every script public key each of them
+// touched, along with the amounts it paid to and spent from that script public key
+func acceptedAddressTransactions(acceptanceData externalapi.AcceptanceData,
+	acceptingBlockHash *externalapi.DomainHash, acceptingBlockBlueScore uint64) (
+	entries []*addressTransactionEntry, addressTransactions []*AddressTransaction) {
+
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted {
+				continue
+			}
+			transaction := transactionAcceptanceData.Transaction
+			transactionID := consensushashing.TransactionID(transaction)
+
+			addressTransactionsByScriptPublicKey := make(map[string]*AddressTransaction)
+			addressTransactionOf := func(scriptPublicKey *externalapi.ScriptPublicKey) *AddressTransaction {
+				serializedScriptPublicKey := string(serializeScriptPublicKey(scriptPublicKey))
+				addressTransaction, ok := addressTransactionsByScriptPublicKey[serializedScriptPublicKey]
+				if !ok {
+					addressTransaction = &AddressTransaction{
+						TransactionID:           transactionID,
+						AcceptingBlockHash:      acceptingBlockHash,
+						AcceptingBlockBlueScore: acceptingBlockBlueScore,
+					}
+					addressTransactionsByScriptPublicKey[serializedScriptPublicKey] = addressTransaction
+					entries = append(entries, &addressTransactionEntry{
+						scriptPublicKey: scriptPublicKey,
+						transactionID:   transactionID,
+					})
+					addressTransactions = append(addressTransactions, addressTransaction)
+				}
+				return addressTransaction
+			}
+
+			for _, utxoEntry := range transactionAcceptanceData.TransactionInputUTXOEntries {
+				addressTransactionOf(utxoEntry.ScriptPublicKey()).Sent += utxoEntry.Amount()
+			}
+			for _, output := range transaction.Outputs {
+				addressTransactionOf(output.ScriptPublicKey).Received += output.Value
+			}
+		}
+	}
+	return entries, addressTransactions
+}
+
+// AddressTransactions returns up to limit of the accepted transactions that
+// touched the given script public key, in the order they were accepted,
+// skipping the first offset ones. hasMore is true if there are transactions
+// past the returned ones. Transactions that were accepted before the index
+// had started are not returned.
+func (ai *AddrIndex) AddressTransactions(scriptPublicKey *externalapi.ScriptPublicKey, offset uint64, limit uint64) (
+	addressTransactions []*AddressTransaction, hasMore bool, err error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "AddrIndex.AddressTransactions")
+	defer onEnd()
+
+	ai.syncer.Lock()
+	defer ai.syncer.Unlock()
+
+	return ai.store.getAddressTransactions(scriptPublicKey, offset, limit)
+}
+
+// ChainBlockTracker returns the tracker of the chain blocks the address index holds the accepted transactions of
+func (ai *AddrIndex) ChainBlockTracker() *indexretention.ChainBlockTracker {
+	return ai.store.chainBlockTracker
+}
+
+// VirtualSelectedParentBlueScore returns the blue score of the
+// virtual selected parent the address index is synced with
+func (ai *AddrIndex) VirtualSelectedParentBlueScore() (uint64, error) {
+	return ai.syncer.VirtualSelectedParentBlueScore()
+}
+
+// PruneChainBlocks removes the transactions accepted by the given chain blocks from the address index
+func (ai *AddrIndex) PruneChainBlocks(chainBlocks []*indexretention.TrackedChainBlock) error {
+	ai.syncer.Lock()
+	defer ai.syncer.Unlock()
+
+	dbTransaction, err := ai.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, chainBlock := range chainBlocks {
+		err := ai.store.removeAddressTransactions(dbTransaction, chainBlock.BlockHash, chainBlock.BlueScore)
+		if err != nil {
+			return err
+		}
+	}
+	return dbTransaction.Commit()
+}
@@ -0,0 +1,7 @@
+package addrindex
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("ADIN")
@@ -0,0 +1,26 @@
+package addrindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// AddressTransaction is an accepted transaction that touched an address,
+// either by paying to it or by spending from it
+type AddressTransaction struct {
+	TransactionID           *externalapi.DomainTransactionID
+	AcceptingBlockHash      *externalapi.DomainHash
+	AcceptingBlockBlueScore uint64
+
+	// Received is the amount the transaction paid to the address
+	Received uint64
+
+	// Sent is the amount the transaction spent from the address
+	Sent uint64
+}
+
+// addressTransactionEntry identifies the record of a transaction
+// that touched an address under the chain block that accepted it
+type addressTransactionEntry struct {
+	scriptPublicKey *externalapi.ScriptPublicKey
+	transactionID   *externalapi.DomainTransactionID
+}
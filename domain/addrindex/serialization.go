@@ -0,0 +1,141 @@
+package addrindex
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+const lengthSize = 8
+const scriptPublicKeyVersionSize = 2
+const blueScoreSize = 8
+const amountSize = 8
+const addressTransactionKeySize = blueScoreSize + externalapi.DomainHashSize
+const addressTransactionSize = externalapi.DomainHashSize + 2*amountSize
+
+// serializeScriptPublicKey serializes a script public key along with the
+// length of its script, so that no serialized script public key is a prefix
+// of another one, and the buckets named after them never overlap
+func serializeScriptPublicKey(scriptPublicKey *externalapi.ScriptPublicKey) []byte {
+	serializedScriptPublicKey := make([]byte, scriptPublicKeyVersionSize+lengthSize+len(scriptPublicKey.Script))
+	binary.LittleEndian.PutUint16(serializedScriptPublicKey[:scriptPublicKeyVersionSize], scriptPublicKey.Version)
+	binary.LittleEndian.PutUint64(serializedScriptPublicKey[scriptPublicKeyVersionSize:scriptPublicKeyVersionSize+lengthSize],
+		uint64(len(scriptPublicKey.Script)))
+	copy(serializedScriptPublicKey[scriptPublicKeyVersionSize+lengthSize:], scriptPublicKey.Script)
+	return serializedScriptPublicKey
+}
+
+// deserializeScriptPublicKey deserializes the script public key at the start
+// of the given bytes, and returns it along with the bytes that follow it
+func deserializeScriptPublicKey(serializedScriptPublicKey []byte) (*externalapi.ScriptPublicKey, []byte, error) {
+	if len(serializedScriptPublicKey) < scriptPublicKeyVersionSize+lengthSize {
+		return nil, nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing a script public key")
+	}
+	version := binary.LittleEndian.Uint16(serializedScriptPublicKey[:scriptPublicKeyVersionSize])
+	scriptLength := binary.LittleEndian.Uint64(
+		serializedScriptPublicKey[scriptPublicKeyVersionSize : scriptPublicKeyVersionSize+lengthSize])
+	rest := serializedScriptPublicKey[scriptPublicKeyVersionSize+lengthSize:]
+	if scriptLength > uint64(len(rest)) {
+		return nil, nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing a script public key")
+	}
+	script := make([]byte, scriptLength)
+	copy(script, rest[:scriptLength])
+	return &externalapi.ScriptPublicKey{Script: script, Version: version}, rest[scriptLength:], nil
+}
+
+// serializeAddressTransactionKey serializes the key of an address transaction.
+// The blue score is big-endian so that the transactions of an address are
+// iterated in the order they were accepted.
+func serializeAddressTransactionKey(acceptingBlockBlueScore uint64, transactionID *externalapi.DomainTransactionID) []byte {
+	serializedKey := make([]byte, addressTransactionKeySize)
+	binary.BigEndian.PutUint64(serializedKey[:blueScoreSize], acceptingBlockBlueScore)
+	copy(serializedKey[blueScoreSize:], transactionID.ByteSlice())
+	return serializedKey
+}
+
+func deserializeAddressTransactionKey(serializedKey []byte) (
+	acceptingBlockBlueScore uint64, transactionID *externalapi.DomainTransactionID, err error) {
+
+	if len(serializedKey) != addressTransactionKeySize {
+		return 0, nil, errors.Errorf("an address transaction key must be %d bytes long, but got %d bytes",
+			addressTransactionKeySize, len(serializedKey))
+	}
+	transactionID, err = externalapi.NewDomainTransactionIDFromByteSlice(serializedKey[blueScoreSize:])
+	if err != nil {
+		return 0, nil, err
+	}
+	return binary.BigEndian.Uint64(serializedKey[:blueScoreSize]), transactionID, nil
+}
+
+// serializeAddressTransaction serializes the parts of an address
+// transaction that aren't already serialized in its key
+func serializeAddressTransaction(addressTransaction *AddressTransaction) []byte {
+	serializedTransaction := make([]byte, addressTransactionSize)
+	copy(serializedTransaction[:externalapi.DomainHashSize], addressTransaction.AcceptingBlockHash.ByteSlice())
+	binary.LittleEndian.PutUint64(serializedTransaction[externalapi.DomainHashSize:externalapi.DomainHashSize+amountSize],
+		addressTransaction.Received)
+	binary.LittleEndian.PutUint64(serializedTransaction[externalapi.DomainHashSize+amountSize:], addressTransaction.Sent)
+	return serializedTransaction
+}
+
+func deserializeAddressTransaction(serializedKey []byte, serializedTransaction []byte) (*AddressTransaction, error) {
+	acceptingBlockBlueScore, transactionID, err := deserializeAddressTransactionKey(serializedKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(serializedTransaction) < addressTransactionSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing an address transaction")
+	}
+	acceptingBlockHash, err := externalapi.NewDomainHashFromByteSlice(serializedTransaction[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+	return &AddressTransaction{
+		TransactionID:           transactionID,
+		AcceptingBlockHash:      acceptingBlockHash,
+		AcceptingBlockBlueScore: acceptingBlockBlueScore,
+		Received: binary.LittleEndian.Uint64(
+			serializedTransaction[externalapi.DomainHashSize : externalapi.DomainHashSize+amountSize]),
+		Sent: binary.LittleEndian.Uint64(serializedTransaction[externalapi.DomainHashSize+amountSize:]),
+	}, nil
+}
+
+func serializeAddressTransactionEntries(entries []*addressTransactionEntry) []byte {
+	serializedEntries := make([]byte, lengthSize)
+	binary.LittleEndian.PutUint64(serializedEntries, uint64(len(entries)))
+	for _, entry := range entries {
+		serializedEntries = append(serializedEntries, entry.transactionID.ByteSlice()...)
+		serializedEntries = append(serializedEntries, serializeScriptPublicKey(entry.scriptPublicKey)...)
+	}
+	return serializedEntries
+}
+
+func deserializeAddressTransactionEntries(serializedEntries []byte) ([]*addressTransactionEntry, error) {
+	if len(serializedEntries) < lengthSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing address transaction entries")
+	}
+	length := binary.LittleEndian.Uint64(serializedEntries[:lengthSize])
+	rest := serializedEntries[lengthSize:]
+	entries := make([]*addressTransactionEntry, 0, length)
+	for i := uint64(0); i < length; i++ {
+		if len(rest) < externalapi.DomainHashSize {
+			return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing address transaction entries")
+		}
+		transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(rest[:externalapi.DomainHashSize])
+		if err != nil {
+			return nil, err
+		}
+		scriptPublicKey, afterScriptPublicKey, err := deserializeScriptPublicKey(rest[externalapi.DomainHashSize:])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &addressTransactionEntry{
+			scriptPublicKey: scriptPublicKey,
+			transactionID:   transactionID,
+		})
+		rest = afterScriptPublicKey
+	}
+	return entries, nil
+}
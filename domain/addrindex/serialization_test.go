@@ -0,0 +1,96 @@
+package addrindex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+func Test_serializeScriptPublicKeyNoPrefixes(t *testing.T) {
+	shortScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2}, Version: 0}
+	longScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2, '/', 3}, Version: 0}
+
+	shortBucketPrefix := append(serializeScriptPublicKey(shortScriptPublicKey), '/')
+	if bytes.HasPrefix(serializeScriptPublicKey(longScriptPublicKey), shortBucketPrefix) {
+		t.Fatalf("The bucket of a script public key must not be a prefix of the bucket of another one")
+	}
+}
+
+func Test_serializeAddressTransactionEntries(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+
+	for length := 0; length < 32; length++ {
+		entries := make([]*addressTransactionEntry, length)
+		for i := range entries {
+			var transactionIDBytes [externalapi.DomainHashSize]byte
+			r.Read(transactionIDBytes[:])
+			script := make([]byte, r.Intn(100))
+			r.Read(script)
+			entries[i] = &addressTransactionEntry{
+				scriptPublicKey: &externalapi.ScriptPublicKey{Script: script, Version: uint16(r.Intn(2))},
+				transactionID:   externalapi.NewDomainTransactionIDFromByteArray(&transactionIDBytes),
+			}
+		}
+		result, err := deserializeAddressTransactionEntries(serializeAddressTransactionEntries(entries))
+		if err != nil {
+			t.Fatalf("Failed deserializing address transaction entries: %v", err)
+		}
+		if len(result) != len(entries) {
+			t.Fatalf("Expected %d entries, got %d", len(entries), len(result))
+		}
+		for i := range entries {
+			if !entries[i].transactionID.Equal(result[i].transactionID) ||
+				!entries[i].scriptPublicKey.Equal(result[i].scriptPublicKey) {
+				t.Fatalf("Expected \n %+v \n==\n %+v\n", entries[i], result[i])
+			}
+		}
+	}
+}
+
+func Test_deserializeAddressTransactionEntriesFailure(t *testing.T) {
+	entries := []*addressTransactionEntry{
+		{
+			scriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{1, 2, 3}, Version: 0},
+			transactionID:   externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		},
+	}
+	serialized := serializeAddressTransactionEntries(entries)
+	binary.LittleEndian.PutUint64(serialized[:lengthSize], uint64(len(entries)+1))
+	_, err := deserializeAddressTransactionEntries(serialized)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected error to be EOF, instead got: %v", err)
+	}
+}
+
+func Test_serializeAddressTransaction(t *testing.T) {
+	addressTransaction := &AddressTransaction{
+		TransactionID:           externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		AcceptingBlockHash:      externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+		AcceptingBlockBlueScore: 1234,
+		Received:                5000,
+		Sent:                    7000,
+	}
+	serializedKey := serializeAddressTransactionKey(addressTransaction.AcceptingBlockBlueScore, addressTransaction.TransactionID)
+	result, err := deserializeAddressTransaction(serializedKey, serializeAddressTransaction(addressTransaction))
+	if err != nil {
+		t.Fatalf("Failed deserializing address transaction: %v", err)
+	}
+	if !reflect.DeepEqual(result, addressTransaction) {
+		t.Fatalf("Expected \n %+v \n==\n %+v\n", addressTransaction, result)
+	}
+}
+
+func Test_serializeAddressTransactionKeyOrder(t *testing.T) {
+	transactionID := externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0xff})
+	otherTransactionID := externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0})
+
+	if bytes.Compare(serializeAddressTransactionKey(255, transactionID), serializeAddressTransactionKey(256, otherTransactionID)) >= 0 {
+		t.Fatalf("Address transactions must be ordered by the blue score of their accepting block")
+	}
+}
@@ -0,0 +1,172 @@
+package addrindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+var addressTransactionsBucket = database.MakeBucket([]byte("addr-index-address-transactions"))
+var addressTransactionEntriesBucket = database.MakeBucket([]byte("addr-index-address-transaction-entries"))
+var chainBlockTrackerBucket = database.MakeBucket([]byte("addr-index-chain-block-tracker"))
+var virtualSelectedParentKey = database.MakeBucket([]byte("")).Key([]byte("addr-index-virtual-selected-parent"))
+
+type addrIndexStore struct {
+	database          database.Database
+	chainBlockTracker *indexretention.ChainBlockTracker
+}
+
+func newAddrIndexStore(database database.Database) *addrIndexStore {
+	return &addrIndexStore{
+		database:          database,
+		chainBlockTracker: indexretention.NewChainBlockTracker(chainBlockTrackerBucket),
+	}
+}
+
+func (ais *addrIndexStore) addressTransactionsBucket(scriptPublicKey *externalapi.ScriptPublicKey) *database.Bucket {
+	return addressTransactionsBucket.Bucket(serializeScriptPublicKey(scriptPublicKey))
+}
+
+func (ais *addrIndexStore) addressTransactionKey(scriptPublicKey *externalapi.ScriptPublicKey,
+	acceptingBlockBlueScore uint64, transactionID *externalapi.DomainTransactionID) *database.Key {
+
+	return ais.addressTransactionsBucket(scriptPublicKey).Key(serializeAddressTransactionKey(acceptingBlockBlueScore, transactionID))
+}
+
+func (ais *addrIndexStore) addressTransactionEntriesKey(acceptingBlockHash *externalapi.DomainHash) *database.Key {
+	return addressTransactionEntriesBucket.Key(acceptingBlockHash.ByteSlice())
+}
+
+// addAddressTransactions records the given transactions, which were accepted by
+// the given chain block, under the addresses they touched
+func (ais *addrIndexStore) addAddressTransactions(dataAccessor database.DataAccessor,
+	acceptingBlockHash *externalapi.DomainHash, acceptingBlockBlueScore uint64,
+	entries []*addressTransactionEntry, addressTransactions []*AddressTransaction) error {
+
+	size := uint64(0)
+	for i, entry := range entries {
+		key := ais.addressTransactionKey(entry.scriptPublicKey, acceptingBlockBlueScore, entry.transactionID)
+		serializedTransaction := serializeAddressTransaction(addressTransactions[i])
+		err := dataAccessor.Put(key, serializedTransaction)
+		if err != nil {
+			return err
+		}
+		size += indexretention.RecordSize(key, serializedTransaction)
+	}
+
+	key := ais.addressTransactionEntriesKey(acceptingBlockHash)
+	serializedEntries := serializeAddressTransactionEntries(entries)
+	err := dataAccessor.Put(key, serializedEntries)
+	if err != nil {
+		return err
+	}
+	size += indexretention.RecordSize(key, serializedEntries)
+
+	return ais.chainBlockTracker.Track(dataAccessor, acceptingBlockHash, acceptingBlockBlueScore, size)
+}
+
+// removeAddressTransactions removes the transactions accepted by the given chain
+// block, after it was removed from the virtual selected parent chain or pruned
+func (ais *addrIndexStore) removeAddressTransactions(dataAccessor database.DataAccessor,
+	acceptingBlockHash *externalapi.DomainHash, acceptingBlockBlueScore uint64) error {
+
+	err := ais.chainBlockTracker.Untrack(dataAccessor, acceptingBlockHash, acceptingBlockBlueScore)
+	if err != nil {
+		return err
+	}
+
+	key := ais.addressTransactionEntriesKey(acceptingBlockHash)
+	serializedEntries, err := dataAccessor.Get(key)
+	if err != nil {
+		// The block was accepted before the index was started
+		if database.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	entries, err := deserializeAddressTransactionEntries(serializedEntries)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err := dataAccessor.Delete(ais.addressTransactionKey(entry.scriptPublicKey, acceptingBlockBlueScore, entry.transactionID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return dataAccessor.Delete(key)
+}
+
+// getAddressTransactions returns up to limit of the transactions that touched
+// the given script public key, in the order they were accepted, skipping the
+// first offset ones
+func (ais *addrIndexStore) getAddressTransactions(scriptPublicKey *externalapi.ScriptPublicKey,
+	offset uint64, limit uint64) (addressTransactions []*AddressTransaction, hasMore bool, err error) {
+
+	cursor, err := ais.database.Cursor(ais.addressTransactionsBucket(scriptPublicKey))
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close()
+
+	for skipped := uint64(0); skipped < offset; skipped++ {
+		if !cursor.Next() {
+			return []*AddressTransaction{}, false, nil
+		}
+	}
+
+	addressTransactions = []*AddressTransaction{}
+	for cursor.Next() {
+		if uint64(len(addressTransactions)) == limit {
+			return addressTransactions, true, nil
+		}
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, false, err
+		}
+		serializedTransaction, err := cursor.Value()
+		if err != nil {
+			return nil, false, err
+		}
+		addressTransaction, err := deserializeAddressTransaction(key.Suffix(), serializedTransaction)
+		if err != nil {
+			return nil, false, err
+		}
+		addressTransactions = append(addressTransactions, addressTransaction)
+	}
+	return addressTransactions, false, nil
+}
+
+func (ais *addrIndexStore) deleteAll() error {
+	for _, bucket := range []*database.Bucket{addressTransactionsBucket, addressTransactionEntriesBucket, chainBlockTrackerBucket} {
+		err := ais.deleteBucket(bucket)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ais *addrIndexStore) deleteBucket(bucket *database.Bucket) error {
+	cursor, err := ais.database.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+
+		err = ais.database.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+package blockratemonitor
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("BRMN")
+var spawn = panics.GoroutineWrapperFunc(log)
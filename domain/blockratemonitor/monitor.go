@@ -0,0 +1,299 @@
+// Package blockratemonitor compares the rate at which the node sees blocks,
+// and the difficulty they're mined at, with the targets of the network, and
+// alerts once they deviate too much. A block rate far below the target may
+// mean that the hash rate collapsed or that the node is isolated from the
+// network, and a block rate far above it, or a sudden difficulty change, may
+// mean a timestamp attack.
+package blockratemonitor
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/util/difficulty"
+	"github.com/pkg/errors"
+)
+
+// sampleInterval is the interval in which the virtual is sampled
+const sampleInterval = 10 * time.Second
+
+// minWindow is the minimum window the block rate is measured over, which
+// has to span enough samples for the rate to be meaningful
+const minWindow = 10 * sampleInterval
+
+// The kinds of alerts
+const (
+	// AlertLowBlockRate is raised when the block rate is too far below the
+	// target block rate
+	AlertLowBlockRate = "lowBlockRate"
+
+	// AlertHighBlockRate is raised when the block rate is too far above the
+	// target block rate
+	AlertHighBlockRate = "highBlockRate"
+
+	// AlertDifficultyChange is raised when the difficulty changed too much
+	// within the window
+	AlertDifficultyChange = "difficultyChange"
+)
+
+// IsIBDRunningFunc returns whether the node is in IBD, while which the block
+// rate it sees says nothing about the network
+type IsIBDRunningFunc func() bool
+
+// Alert is a deviation from the targets of the network
+type Alert struct {
+	Kind    string
+	Message string
+	Since   time.Time
+}
+
+// Status is the block rate and the difficulty change the monitor measured
+// over the last window, along with the alerts they raised
+type Status struct {
+	// TargetBlockRate and ObservedBlockRate are in blocks per second
+	TargetBlockRate   float64
+	ObservedBlockRate float64
+
+	// IsWindowFull is whether a whole window was sampled since the node
+	// started or left IBD. ObservedBlockRate and DifficultyChange are 0
+	// until it was.
+	IsWindowFull bool
+
+	// DifficultyChange is the ratio of the current difficulty to the
+	// difficulty at the start of the window
+	DifficultyChange float64
+
+	// TipTimestampDelay is how far the timestamp of the selected tip is
+	// behind the clock
+	TipTimestampDelay time.Duration
+
+	Alerts []*Alert
+}
+
+// sample is the state of the virtual at some point in time
+type sample struct {
+	time     time.Time
+	daaScore uint64
+	work     *big.Int
+}
+
+// Monitor samples the virtual in a fixed interval, and measures the block
+// rate and the difficulty change over a sliding window of its samples. The
+// DAA score of the virtual grows by about one per block, so the block rate
+// is the growth of the DAA score over the window.
+type Monitor struct {
+	domain          domain.Domain
+	network         string
+	targetBlockRate float64
+	window          time.Duration
+	threshold       float64
+	webhook         *webhook
+	isIBDRunning    IsIBDRunningFunc
+
+	lock              sync.Mutex
+	samples           []*sample
+	tipTimestampDelay time.Duration
+	alerts            map[string]*Alert
+
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+// ValidateConfig returns an error if the given monitor options are invalid
+func ValidateConfig(window time.Duration, threshold float64, webhookURL string) error {
+	if window < minWindow {
+		return errors.Errorf("the window must be at least %s, but it's %s", minWindow, window)
+	}
+	if threshold <= 0 || threshold >= 1 {
+		return errors.Errorf("the threshold must be between 0 and 1, but it's %f", threshold)
+	}
+	if webhookURL != "" {
+		return validateWebhookURL(webhookURL)
+	}
+	return nil
+}
+
+// New creates a new Monitor that alerts once the block rate deviates from
+// the one of the given target time per block, or the difficulty changes, by
+// more than the given threshold, which is a fraction of the target rate or
+// of the difficulty, over the given window. Alerts are posted to the given
+// webhook URL, unless it's empty.
+func New(domain domain.Domain, network string, targetTimePerBlock time.Duration, window time.Duration,
+	threshold float64, webhookURL string, isIBDRunning IsIBDRunningFunc) *Monitor {
+
+	var alertWebhook *webhook
+	if webhookURL != "" {
+		alertWebhook = newWebhook(webhookURL)
+	}
+	return &Monitor{
+		domain:          domain,
+		network:         network,
+		targetBlockRate: float64(time.Second) / float64(targetTimePerBlock),
+		window:          window,
+		threshold:       threshold,
+		webhook:         alertWebhook,
+		isIBDRunning:    isIBDRunning,
+		alerts:          make(map[string]*Alert),
+		stopChan:        make(chan struct{}),
+		doneChan:        make(chan struct{}),
+	}
+}
+
+// Start begins sampling the virtual
+func (m *Monitor) Start() {
+	spawn("blockratemonitor.Monitor.sampleLoop", m.sampleLoop)
+}
+
+// Stop stops sampling the virtual
+func (m *Monitor) Stop() {
+	close(m.stopChan)
+	<-m.doneChan
+}
+
+func (m *Monitor) sampleLoop() {
+	defer close(m.doneChan)
+
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+	for {
+		err := m.sample()
+		if err != nil {
+			log.Errorf("Error sampling the virtual: %s", err)
+		}
+
+		select {
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample samples the virtual, and raises or resolves alerts according to
+// the window that ends with the new sample
+func (m *Monitor) sample() error {
+	now := time.Now()
+	if m.isIBDRunning() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.samples = nil
+		return nil
+	}
+
+	consensus := m.domain.Consensus()
+	virtualInfo, err := consensus.GetVirtualInfo()
+	if err != nil {
+		return err
+	}
+	selectedTip, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return err
+	}
+	selectedTipHeader, err := consensus.GetBlockHeader(selectedTip)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.tipTimestampDelay = now.Sub(time.UnixMilli(selectedTipHeader.TimeInMilliseconds()))
+	m.samples = append(m.samples, &sample{
+		time:     now,
+		daaScore: virtualInfo.DAAScore,
+		work:     difficulty.CalcWork(virtualInfo.Bits),
+	})
+
+	// Only the last sample at or before the start of the window is kept
+	windowStart := now.Add(-m.window)
+	for len(m.samples) > 1 && !m.samples[1].time.After(windowStart) {
+		m.samples = m.samples[1:]
+	}
+	if m.samples[0].time.After(windowStart) {
+		return nil
+	}
+
+	blockRate, difficultyChange := measure(m.samples[0], m.samples[len(m.samples)-1])
+	m.updateAlerts(now, m.deviations(blockRate, difficultyChange))
+	return nil
+}
+
+// updateAlerts raises an alert for every new deviation, and resolves the
+// alerts of the deviations that ended. The lock must be held.
+func (m *Monitor) updateAlerts(now time.Time, deviations map[string]string) {
+	for kind, message := range deviations {
+		if _, ok := m.alerts[kind]; ok {
+			continue
+		}
+		alert := &Alert{Kind: kind, Message: message, Since: now}
+		m.alerts[kind] = alert
+		log.Warnf("Block rate alert: %s", message)
+		m.postToWebhook(alert, false)
+	}
+	for kind, alert := range m.alerts {
+		if _, ok := deviations[kind]; ok {
+			continue
+		}
+		delete(m.alerts, kind)
+		log.Infof("Block rate alert resolved: %s", alert.Message)
+		m.postToWebhook(alert, true)
+	}
+}
+
+// measure returns the block rate, in blocks per second, and the difficulty
+// change between the given samples
+func measure(first *sample, last *sample) (blockRate float64, difficultyChange float64) {
+	elapsed := last.time.Sub(first.time).Seconds()
+	if elapsed > 0 && last.daaScore >= first.daaScore {
+		blockRate = float64(last.daaScore-first.daaScore) / elapsed
+	}
+	difficultyChange, _ = new(big.Float).Quo(new(big.Float).SetInt(last.work), new(big.Float).SetInt(first.work)).Float64()
+	return blockRate, difficultyChange
+}
+
+// deviations returns a message, by alert kind, for every way in which the
+// given block rate and difficulty change deviate from the targets by more
+// than the threshold
+func (m *Monitor) deviations(blockRate float64, difficultyChange float64) map[string]string {
+	deviations := make(map[string]string)
+	if blockRate < m.targetBlockRate*(1-m.threshold) {
+		deviations[AlertLowBlockRate] = fmt.Sprintf("%.2f blocks per second within the last %s, "+
+			"while the target is %.2f. The hash rate may have collapsed, or the node may be isolated from the network",
+			blockRate, m.window, m.targetBlockRate)
+	}
+	if blockRate > m.targetBlockRate*(1+m.threshold) {
+		deviations[AlertHighBlockRate] = fmt.Sprintf("%.2f blocks per second within the last %s, "+
+			"while the target is %.2f. The block timestamps may be manipulated",
+			blockRate, m.window, m.targetBlockRate)
+	}
+	if difficultyChange < 1-m.threshold || difficultyChange > 1+m.threshold {
+		deviations[AlertDifficultyChange] = fmt.Sprintf("The difficulty changed by a factor of %.2f "+
+			"within the last %s", difficultyChange, m.window)
+	}
+	return deviations
+}
+
+// Status returns the block rate and the difficulty change over the last
+// window, along with the current alerts
+func (m *Monitor) Status() *Status {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	status := &Status{
+		TargetBlockRate:   m.targetBlockRate,
+		TipTimestampDelay: m.tipTimestampDelay,
+	}
+	if len(m.samples) > 0 && !m.samples[0].time.After(m.samples[len(m.samples)-1].time.Add(-m.window)) {
+		status.IsWindowFull = true
+		status.ObservedBlockRate, status.DifficultyChange = measure(m.samples[0], m.samples[len(m.samples)-1])
+	}
+	for _, kind := range []string{AlertLowBlockRate, AlertHighBlockRate, AlertDifficultyChange} {
+		if alert, ok := m.alerts[kind]; ok {
+			status.Alerts = append(status.Alerts, alert)
+		}
+	}
+	return status
+}
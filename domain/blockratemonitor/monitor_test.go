@@ -0,0 +1,91 @@
+package blockratemonitor
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestMeasure(t *testing.T) {
+	start := time.Unix(1000, 0)
+	first := &sample{time: start, daaScore: 100, work: big.NewInt(1000)}
+	last := &sample{time: start.Add(100 * time.Second), daaScore: 150, work: big.NewInt(1500)}
+
+	blockRate, difficultyChange := measure(first, last)
+	if blockRate != 0.5 {
+		t.Fatalf("Expected a block rate of 0.5, but got %f", blockRate)
+	}
+	if difficultyChange != 1.5 {
+		t.Fatalf("Expected a difficulty change of 1.5, but got %f", difficultyChange)
+	}
+
+	// A DAA score that went down, as it may after a reorg, isn't a negative rate
+	last.daaScore = 90
+	blockRate, _ = measure(first, last)
+	if blockRate != 0 {
+		t.Fatalf("Expected a block rate of 0, but got %f", blockRate)
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	monitor := New(nil, "test", time.Second, minWindow, 0.5, "", func() bool { return false })
+
+	tests := []struct {
+		blockRate        float64
+		difficultyChange float64
+		expectedAlerts   []string
+	}{
+		{blockRate: 1, difficultyChange: 1, expectedAlerts: nil},
+		{blockRate: 0.6, difficultyChange: 1.4, expectedAlerts: nil},
+		{blockRate: 0.4, difficultyChange: 1, expectedAlerts: []string{AlertLowBlockRate}},
+		{blockRate: 0.4, difficultyChange: 0.4, expectedAlerts: []string{AlertLowBlockRate, AlertDifficultyChange}},
+		{blockRate: 1.6, difficultyChange: 1.6, expectedAlerts: []string{AlertHighBlockRate, AlertDifficultyChange}},
+		{blockRate: 1, difficultyChange: 1, expectedAlerts: nil},
+	}
+	now := time.Unix(1000, 0)
+	for i, test := range tests {
+		now = now.Add(sampleInterval)
+		monitor.updateAlerts(now, monitor.deviations(test.blockRate, test.difficultyChange))
+
+		alerts := monitor.Status().Alerts
+		if len(alerts) != len(test.expectedAlerts) {
+			t.Fatalf("Test %d: expected %d alerts, but got %d", i, len(test.expectedAlerts), len(alerts))
+		}
+		for j, alert := range alerts {
+			if alert.Kind != test.expectedAlerts[j] {
+				t.Fatalf("Test %d: expected alert %s, but got %s", i, test.expectedAlerts[j], alert.Kind)
+			}
+		}
+	}
+
+	// An alert that goes on isn't raised again
+	monitor.updateAlerts(now, monitor.deviations(0.1, 1))
+	monitor.updateAlerts(now.Add(sampleInterval), monitor.deviations(0.1, 1))
+	alerts := monitor.Status().Alerts
+	if len(alerts) != 1 || !alerts[0].Since.Equal(now) {
+		t.Fatalf("Expected the low block rate alert to be raised once, at %s", now)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		window      time.Duration
+		threshold   float64
+		webhookURL  string
+		expectError bool
+	}{
+		{window: 10 * time.Minute, threshold: 0.5, webhookURL: "", expectError: false},
+		{window: 10 * time.Minute, threshold: 0.5, webhookURL: "https://example.com/alerts", expectError: false},
+		{window: time.Second, threshold: 0.5, webhookURL: "", expectError: true},
+		{window: 10 * time.Minute, threshold: 0, webhookURL: "", expectError: true},
+		{window: 10 * time.Minute, threshold: 1, webhookURL: "", expectError: true},
+		{window: 10 * time.Minute, threshold: 0.5, webhookURL: "ftp://example.com", expectError: true},
+	}
+	for _, test := range tests {
+		err := ValidateConfig(test.window, test.threshold, test.webhookURL)
+		if (err != nil) != test.expectError {
+			t.Fatalf("ValidateConfig(%s, %f, %s): expected an error: %t, but got %v",
+				test.window, test.threshold, test.webhookURL, test.expectError, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package blockratemonitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// webhookTimeout is the time a webhook is given to accept an alert
+const webhookTimeout = 10 * time.Second
+
+// webhookPayload is the JSON body alerts are posted to the webhook with
+type webhookPayload struct {
+	Network    string `json:"network"`
+	Kind       string `json:"kind"`
+	Message    string `json:"message"`
+	IsResolved bool   `json:"isResolved"`
+
+	// Since is the time the alert was raised, in milliseconds since the
+	// epoch
+	Since int64 `json:"since"`
+}
+
+// webhook posts alerts to a URL
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+func validateWebhookURL(webhookURL string) error {
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid webhook URL %s", webhookURL)
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return errors.Errorf("the webhook URL %s must be an http or https URL", webhookURL)
+	}
+	return nil
+}
+
+func newWebhook(webhookURL string) *webhook {
+	return &webhook{
+		url:    webhookURL,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (w *webhook) post(payload *webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	response, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return errors.Errorf("the webhook responded with %s", response.Status)
+	}
+	return nil
+}
+
+// postToWebhook posts the given alert, or its resolution, to the webhook, if
+// there's one, in the background
+func (m *Monitor) postToWebhook(alert *Alert, isResolved bool) {
+	if m.webhook == nil {
+		return
+	}
+	payload := &webhookPayload{
+		Network:    m.network,
+		Kind:       alert.Kind,
+		Message:    alert.Message,
+		IsResolved: isResolved,
+		Since:      alert.Since.UnixMilli(),
+	}
+	spawn("blockratemonitor.Monitor.postToWebhook", func() {
+		err := m.webhook.post(payload)
+		if err != nil {
+			log.Warnf("Error posting the %s alert to the webhook: %s", alert.Kind, err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package blockratemonitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhook(t *testing.T) {
+	payloads := make(chan *webhookPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/failing" {
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		payload := &webhookPayload{}
+		err := json.NewDecoder(request.Body).Decode(payload)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		payloads <- payload
+	}))
+	defer server.Close()
+
+	expectedPayload := &webhookPayload{
+		Network:    "kaspa-simnet",
+		Kind:       AlertLowBlockRate,
+		Message:    "message",
+		IsResolved: true,
+		Since:      1234,
+	}
+	err := newWebhook(server.URL).post(expectedPayload)
+	if err != nil {
+		t.Fatalf("post: %s", err)
+	}
+	payload := <-payloads
+	if *payload != *expectedPayload {
+		t.Fatalf("Expected payload %+v, but got %+v", expectedPayload, payload)
+	}
+
+	err = newWebhook(server.URL + "/failing").post(expectedPayload)
+	if err == nil {
+		t.Fatalf("Expected posting to a failing webhook to fail")
+	}
+}
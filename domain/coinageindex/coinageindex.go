@@ -0,0 +1,320 @@
+package coinageindex
+
+import (
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/domain/indexsync"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// DAAScoreWindowSize is the size, in DAA score, of the windows the ages of
+// unspent outputs are aggregated by, and of a day when measuring coin-days.
+// At one block per second it's exactly a day.
+const DAAScoreWindowSize = 86_400
+
+// CoinAgeIndex maintains the coin-days destroyed by every block in the
+// virtual selected parent chain, along with the age distribution of the
+// unspent outputs of the virtual selected parent
+type CoinAgeIndex struct {
+	domain domain.Domain
+	store  *coinAgeIndexStore
+	syncer *indexsync.Syncer
+
+	// The deltas of the age windows in the current database transaction
+	ageWindowDeltas map[uint64]*ageWindowDelta
+}
+
+// New creates a new coin age index.
+//
+// Blocks may be added to the consensus while this is called, since the index
+// catches up with the chain changes it misses meanwhile on the next Update.
+func New(domain domain.Domain, database database.Database) (*CoinAgeIndex, error) {
+	coinAgeIndex := &CoinAgeIndex{
+		domain: domain,
+		store:  newCoinAgeIndexStore(database),
+	}
+	coinAgeIndex.syncer = indexsync.New("coin age index", domain, database, virtualSelectedParentKey, &indexsync.Callbacks{
+		Reset:              coinAgeIndex.reset,
+		RemoveChainBlock:   coinAgeIndex.removeChainBlock,
+		AddChainBlock:      coinAgeIndex.addChainBlock,
+		BeginChainChanges:  coinAgeIndex.beginChainChanges,
+		FinishChainChanges: coinAgeIndex.finishChainChanges,
+	})
+
+	err := coinAgeIndex.syncer.CatchUp()
+	if err != nil {
+		return nil, err
+	}
+	return coinAgeIndex, nil
+}
+
+// Reset deletes the whole coin age index, seeds the age distribution with the
+// pruning point UTXO set and resyncs the index from the pruning point.
+// Coin-days destroyed by chain blocks below the pruning point are not indexed.
+func (cai *CoinAgeIndex) Reset() error {
+	return cai.syncer.Reset()
+}
+
+// Update updates the coin age index with the given DAG selected parent chain changes
+func (cai *CoinAgeIndex) Update(chainChanges *externalapi.SelectedChainPath) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "CoinAgeIndex.Update")
+	defer onEnd()
+
+	return cai.syncer.Update(chainChanges)
+}
+
+// reset deletes the whole coin age index, and seeds the age distribution
+// with the UTXO set of the given pruning point
+func (cai *CoinAgeIndex) reset(pruningPoint *externalapi.DomainHash) error {
+	err := cai.store.deleteAll()
+	if err != nil {
+		return err
+	}
+	return cai.seedFromPruningPointUTXOSet(pruningPoint)
+}
+
+func (cai *CoinAgeIndex) seedFromPruningPointUTXOSet(pruningPoint *externalapi.DomainHash) error {
+	deltas := make(map[uint64]*ageWindowDelta)
+	var fromOutpoint *externalapi.DomainOutpoint
+	for {
+		const step = 1000
+		pruningPointUTXOs, err := cai.domain.Consensus().GetPruningPointUTXOs(pruningPoint, fromOutpoint, step)
+		if err != nil {
+			return err
+		}
+		for _, pruningPointUTXO := range pruningPointUTXOs {
+			addToAgeWindow(deltas, pruningPointUTXO.UTXOEntry.BlockDAAScore(), pruningPointUTXO.UTXOEntry.Amount())
+		}
+
+		if len(pruningPointUTXOs) < step {
+			break
+		}
+		fromOutpoint = pruningPointUTXOs[len(pruningPointUTXOs)-1].Outpoint
+	}
+
+	log.Infof("Seeding the coin age index with %d age windows of the pruning point UTXO set", len(deltas))
+	dbTransaction, err := cai.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	err = cai.store.applyAgeWindowDeltas(dbTransaction, deltas)
+	if err != nil {
+		return err
+	}
+	return dbTransaction.Commit()
+}
+
+// beginChainChanges starts accumulating the deltas per window, since
+// every window may be updated only once per database transaction
+func (cai *CoinAgeIndex) beginChainChanges() {
+	cai.ageWindowDeltas = make(map[uint64]*ageWindowDelta)
+}
+
+func (cai *CoinAgeIndex) removeChainBlock(dbTransaction database.Transaction,
+	blockHash *externalapi.DomainHash, blueScore uint64) error {
+
+	log.Tracef("Removing chain block %s from the coin age index", blockHash)
+	record, found, err := cai.store.removeChainBlockRecord(dbTransaction, blockHash, blueScore)
+	if err != nil {
+		return err
+	}
+	// The block was added to the chain before the index was started
+	if !found {
+		return nil
+	}
+	for _, delta := range record.ageWindowDeltas {
+		mergedDelta := ageWindowDeltaOf(cai.ageWindowDeltas, delta.window)
+		mergedDelta.countDelta -= delta.countDelta
+		mergedDelta.amountDelta -= delta.amountDelta
+	}
+	return nil
+}
+
+func (cai *CoinAgeIndex) addChainBlock(dbTransaction database.Transaction, blockHash *externalapi.DomainHash,
+	header externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error {
+
+	record := newChainBlockRecord(blockHash, header.DAAScore(), acceptanceData)
+	log.Tracef("Adding chain block %s, which destroyed %f coin-days, to the coin age index",
+		blockHash, record.coinDaysDestroyed.CoinDaysDestroyed)
+	err := cai.store.addChainBlockRecord(dbTransaction, record, header.BlueScore())
+	if err != nil {
+		return err
+	}
+	for _, delta := range record.ageWindowDeltas {
+		mergedDelta := ageWindowDeltaOf(cai.ageWindowDeltas, delta.window)
+		mergedDelta.countDelta += delta.countDelta
+		mergedDelta.amountDelta += delta.amountDelta
+	}
+	return nil
+}
+
+func (cai *CoinAgeIndex) finishChainChanges(dbTransaction database.Transaction) error {
+	return cai.store.applyAgeWindowDeltas(dbTransaction, cai.ageWindowDeltas)
+}
+
+// newChainBlockRecord builds the record of a chain block with the given DAA
+// score out of its acceptance data. The outputs of the transactions it accepted
+// are created at its DAA score, while the outputs they spend are destroyed.
+func newChainBlockRecord(blockHash *externalapi.DomainHash, daaScore uint64,
+	acceptanceData externalapi.AcceptanceData) *chainBlockRecord {
+
+	coinDaysDestroyed := &ChainBlockCoinDaysDestroyed{
+		BlockHash: blockHash,
+		DAAScore:  daaScore,
+	}
+	deltas := make(map[uint64]*ageWindowDelta)
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted {
+				continue
+			}
+			for _, utxoEntry := range transactionAcceptanceData.TransactionInputUTXOEntries {
+				age := daaScore - utxoEntry.BlockDAAScore()
+				coinDaysDestroyed.SpentAmount += utxoEntry.Amount()
+				coinDaysDestroyed.CoinDaysDestroyed += float64(utxoEntry.Amount()) / constants.SompiPerKaspa *
+					float64(age) / DAAScoreWindowSize
+				removeFromAgeWindow(deltas, utxoEntry.BlockDAAScore(), utxoEntry.Amount())
+			}
+			for _, output := range transactionAcceptanceData.Transaction.Outputs {
+				addToAgeWindow(deltas, daaScore, output.Value)
+			}
+		}
+	}
+
+	record := &chainBlockRecord{
+		coinDaysDestroyed: coinDaysDestroyed,
+		ageWindowDeltas:   make([]*ageWindowDelta, 0, len(deltas)),
+	}
+	for _, delta := range deltas {
+		if delta.countDelta == 0 && delta.amountDelta == 0 {
+			continue
+		}
+		record.ageWindowDeltas = append(record.ageWindowDeltas, delta)
+	}
+	return record
+}
+
+// ageWindowDeltaOf returns the delta of the given window in deltas,
+// adding an empty delta if there is none
+func ageWindowDeltaOf(deltas map[uint64]*ageWindowDelta, window uint64) *ageWindowDelta {
+	delta, ok := deltas[window]
+	if !ok {
+		delta = &ageWindowDelta{window: window}
+		deltas[window] = delta
+	}
+	return delta
+}
+
+func addToAgeWindow(deltas map[uint64]*ageWindowDelta, daaScore uint64, amount uint64) {
+	delta := ageWindowDeltaOf(deltas, daaScore/DAAScoreWindowSize)
+	delta.countDelta++
+	delta.amountDelta += int64(amount)
+}
+
+func removeFromAgeWindow(deltas map[uint64]*ageWindowDelta, daaScore uint64, amount uint64) {
+	delta := ageWindowDeltaOf(deltas, daaScore/DAAScoreWindowSize)
+	delta.countDelta--
+	delta.amountDelta -= int64(amount)
+}
+
+// CoinDaysDestroyed returns the coin-days destroyed by up to the last
+// windowSize indexed chain blocks, ordered from the oldest to the virtual
+// selected parent. Fewer chain blocks are returned if the index had started
+// after them.
+func (cai *CoinAgeIndex) CoinDaysDestroyed(windowSize int) ([]*ChainBlockCoinDaysDestroyed, error) {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "CoinAgeIndex.CoinDaysDestroyed")
+	defer onEnd()
+
+	cai.syncer.Lock()
+	defer cai.syncer.Unlock()
+
+	blockHash, err := cai.syncer.VirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*ChainBlockCoinDaysDestroyed, 0, windowSize)
+	for len(history) < windowSize {
+		record, found, err := cai.store.getChainBlockRecord(cai.store.database, blockHash)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			break
+		}
+		history = append(history, record.coinDaysDestroyed)
+
+		blockInfo, err := cai.domain.Consensus().GetBlockInfo(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		blockHash = blockInfo.SelectedParent
+	}
+
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+	return history, nil
+}
+
+// UTXOAgeDistribution returns the non-empty age windows of the UTXO set of
+// the virtual selected parent, ordered from the oldest, along with the DAA
+// score of the virtual selected parent the ages should be measured from
+func (cai *CoinAgeIndex) UTXOAgeDistribution() (daaScore uint64, ageWindows []*AgeWindow, err error) {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "CoinAgeIndex.UTXOAgeDistribution")
+	defer onEnd()
+
+	cai.syncer.Lock()
+	defer cai.syncer.Unlock()
+
+	virtualSelectedParent, err := cai.syncer.VirtualSelectedParent()
+	if err != nil {
+		return 0, nil, err
+	}
+	virtualSelectedParentHeader, err := cai.domain.Consensus().GetBlockHeader(virtualSelectedParent)
+	if err != nil {
+		return 0, nil, err
+	}
+	ageWindows, err = cai.store.getAgeWindows()
+	if err != nil {
+		return 0, nil, err
+	}
+	return virtualSelectedParentHeader.DAAScore(), ageWindows, nil
+}
+
+// ChainBlockTracker returns the tracker of the chain blocks the coin age index holds records of
+func (cai *CoinAgeIndex) ChainBlockTracker() *indexretention.ChainBlockTracker {
+	return cai.store.chainBlockTracker
+}
+
+// VirtualSelectedParentBlueScore returns the blue score of the
+// virtual selected parent the coin age index is synced with
+func (cai *CoinAgeIndex) VirtualSelectedParentBlueScore() (uint64, error) {
+	return cai.syncer.VirtualSelectedParentBlueScore()
+}
+
+// PruneChainBlocks removes the records of the given chain blocks, shortening
+// the coin-days destroyed history. The UTXO age distribution is kept intact,
+// so only the data needed to undo the blocks' acceptance on a reorg is lost.
+func (cai *CoinAgeIndex) PruneChainBlocks(chainBlocks []*indexretention.TrackedChainBlock) error {
+	cai.syncer.Lock()
+	defer cai.syncer.Unlock()
+
+	dbTransaction, err := cai.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, chainBlock := range chainBlocks {
+		_, _, err := cai.store.removeChainBlockRecord(dbTransaction, chainBlock.BlockHash, chainBlock.BlueScore)
+		if err != nil {
+			return err
+		}
+	}
+	return dbTransaction.Commit()
+}
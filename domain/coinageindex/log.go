@@ -0,0 +1,7 @@
+package coinageindex
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("CAIN")
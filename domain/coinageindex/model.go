@@ -0,0 +1,42 @@
+package coinageindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// ChainBlockCoinDaysDestroyed are the coin-age metrics of the
+// transactions accepted by a single chain block
+type ChainBlockCoinDaysDestroyed struct {
+	BlockHash *externalapi.DomainHash
+	DAAScore  uint64
+
+	// SpentAmount is the total amount of the outputs spent by the chain block
+	SpentAmount uint64
+
+	// CoinDaysDestroyed is the sum of the amounts, in kaspa, of the outputs
+	// spent by the chain block, each multiplied by its age in days
+	CoinDaysDestroyed float64
+}
+
+// AgeWindow holds the unspent outputs of the virtual selected parent
+// UTXO set that were created within a single DAA score window
+type AgeWindow struct {
+	StartDAAScore uint64
+	UTXOCount     uint64
+	TotalAmount   uint64
+}
+
+// ageWindowDelta is the change a single chain block makes to an age window
+type ageWindowDelta struct {
+	window      uint64
+	countDelta  int64
+	amountDelta int64
+}
+
+// chainBlockRecord is everything stored for a single chain block, which
+// allows both answering queries and undoing the chain block once it's removed
+// from the virtual selected parent chain
+type chainBlockRecord struct {
+	coinDaysDestroyed *ChainBlockCoinDaysDestroyed
+	ageWindowDeltas   []*ageWindowDelta
+}
@@ -0,0 +1,78 @@
+package coinageindex
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+const uint64Size = 8
+const ageWindowSize = 2 * uint64Size
+const ageWindowDeltaSize = 3 * uint64Size
+const chainBlockRecordHeaderSize = 4 * uint64Size
+
+func serializeAgeWindow(utxoCount uint64, totalAmount uint64) []byte {
+	serializedAgeWindow := make([]byte, ageWindowSize)
+	binary.LittleEndian.PutUint64(serializedAgeWindow[:uint64Size], utxoCount)
+	binary.LittleEndian.PutUint64(serializedAgeWindow[uint64Size:], totalAmount)
+	return serializedAgeWindow
+}
+
+func deserializeAgeWindow(serializedAgeWindow []byte) (utxoCount uint64, totalAmount uint64, err error) {
+	if len(serializedAgeWindow) != ageWindowSize {
+		return 0, 0, errors.Errorf("the given age window is %d bytes while it should be %d bytes",
+			len(serializedAgeWindow), ageWindowSize)
+	}
+	return binary.LittleEndian.Uint64(serializedAgeWindow[:uint64Size]),
+		binary.LittleEndian.Uint64(serializedAgeWindow[uint64Size:]), nil
+}
+
+// serializeChainBlockRecord serializes the given record. The block hash
+// is not serialized, since it's the key the record is stored by.
+func serializeChainBlockRecord(record *chainBlockRecord) []byte {
+	serializedRecord := make([]byte, chainBlockRecordHeaderSize+len(record.ageWindowDeltas)*ageWindowDeltaSize)
+	binary.LittleEndian.PutUint64(serializedRecord[0:], record.coinDaysDestroyed.DAAScore)
+	binary.LittleEndian.PutUint64(serializedRecord[uint64Size:], record.coinDaysDestroyed.SpentAmount)
+	binary.LittleEndian.PutUint64(serializedRecord[2*uint64Size:], math.Float64bits(record.coinDaysDestroyed.CoinDaysDestroyed))
+	binary.LittleEndian.PutUint64(serializedRecord[3*uint64Size:], uint64(len(record.ageWindowDeltas)))
+	for i, delta := range record.ageWindowDeltas {
+		start := chainBlockRecordHeaderSize + i*ageWindowDeltaSize
+		binary.LittleEndian.PutUint64(serializedRecord[start:], delta.window)
+		binary.LittleEndian.PutUint64(serializedRecord[start+uint64Size:], uint64(delta.countDelta))
+		binary.LittleEndian.PutUint64(serializedRecord[start+2*uint64Size:], uint64(delta.amountDelta))
+	}
+	return serializedRecord
+}
+
+func deserializeChainBlockRecord(serializedRecord []byte) (*chainBlockRecord, error) {
+	if len(serializedRecord) < chainBlockRecordHeaderSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "the given chain block record is %d bytes while "+
+			"it should be at least %d bytes", len(serializedRecord), chainBlockRecordHeaderSize)
+	}
+	deltaCount := binary.LittleEndian.Uint64(serializedRecord[3*uint64Size:])
+	expectedSize := uint64(chainBlockRecordHeaderSize) + deltaCount*ageWindowDeltaSize
+	if uint64(len(serializedRecord)) != expectedSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "the given chain block record is %d bytes while "+
+			"it should be %d bytes", len(serializedRecord), expectedSize)
+	}
+
+	record := &chainBlockRecord{
+		coinDaysDestroyed: &ChainBlockCoinDaysDestroyed{
+			DAAScore:          binary.LittleEndian.Uint64(serializedRecord[0:]),
+			SpentAmount:       binary.LittleEndian.Uint64(serializedRecord[uint64Size:]),
+			CoinDaysDestroyed: math.Float64frombits(binary.LittleEndian.Uint64(serializedRecord[2*uint64Size:])),
+		},
+		ageWindowDeltas: make([]*ageWindowDelta, deltaCount),
+	}
+	for i := range record.ageWindowDeltas {
+		start := chainBlockRecordHeaderSize + i*ageWindowDeltaSize
+		record.ageWindowDeltas[i] = &ageWindowDelta{
+			window:      binary.LittleEndian.Uint64(serializedRecord[start:]),
+			countDelta:  int64(binary.LittleEndian.Uint64(serializedRecord[start+uint64Size:])),
+			amountDelta: int64(binary.LittleEndian.Uint64(serializedRecord[start+2*uint64Size:])),
+		}
+	}
+	return record, nil
+}
@@ -0,0 +1,63 @@
+package coinageindex
+
+import (
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func Test_serializeChainBlockRecord(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+
+	for length := 0; length < 32; length++ {
+		record := &chainBlockRecord{
+			coinDaysDestroyed: &ChainBlockCoinDaysDestroyed{
+				DAAScore:          r.Uint64(),
+				SpentAmount:       r.Uint64(),
+				CoinDaysDestroyed: r.Float64() * 1000,
+			},
+			ageWindowDeltas: make([]*ageWindowDelta, length),
+		}
+		for i := range record.ageWindowDeltas {
+			record.ageWindowDeltas[i] = &ageWindowDelta{
+				window:      r.Uint64(),
+				countDelta:  r.Int63() - r.Int63(),
+				amountDelta: r.Int63() - r.Int63(),
+			}
+		}
+		result, err := deserializeChainBlockRecord(serializeChainBlockRecord(record))
+		if err != nil {
+			t.Fatalf("Failed deserializing chain block record: %v", err)
+		}
+		if !reflect.DeepEqual(result, record) {
+			t.Fatalf("Expected \n %+v \n==\n %+v\n", record, result)
+		}
+	}
+}
+
+func Test_deserializeChainBlockRecordFailure(t *testing.T) {
+	record := &chainBlockRecord{
+		coinDaysDestroyed: &ChainBlockCoinDaysDestroyed{},
+		ageWindowDeltas:   []*ageWindowDelta{{window: 1, countDelta: 1, amountDelta: 1}},
+	}
+	serialized := serializeChainBlockRecord(record)
+	binary.LittleEndian.PutUint64(serialized[3*uint64Size:], 2)
+	_, err := deserializeChainBlockRecord(serialized)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected error to be EOF, instead got: %v", err)
+	}
+}
+
+func Test_serializeAgeWindow(t *testing.T) {
+	utxoCount, totalAmount, err := deserializeAgeWindow(serializeAgeWindow(3, 1000))
+	if err != nil {
+		t.Fatalf("Failed deserializing age window: %v", err)
+	}
+	if utxoCount != 3 || totalAmount != 1000 {
+		t.Fatalf("Expected an age window of 3 UTXOs worth 1000, got %d UTXOs worth %d", utxoCount, totalAmount)
+	}
+}
@@ -0,0 +1,187 @@
+package coinageindex
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+var ageWindowsBucket = database.MakeBucket([]byte("coin-age-index-age-windows"))
+var chainBlockRecordsBucket = database.MakeBucket([]byte("coin-age-index-chain-blocks"))
+var chainBlockTrackerBucket = database.MakeBucket([]byte("coin-age-index-chain-block-tracker"))
+var virtualSelectedParentKey = database.MakeBucket([]byte("")).Key([]byte("coin-age-index-virtual-selected-parent"))
+
+type coinAgeIndexStore struct {
+	database          database.Database
+	chainBlockTracker *indexretention.ChainBlockTracker
+}
+
+func newCoinAgeIndexStore(database database.Database) *coinAgeIndexStore {
+	return &coinAgeIndexStore{
+		database:          database,
+		chainBlockTracker: indexretention.NewChainBlockTracker(chainBlockTrackerBucket),
+	}
+}
+
+func (cais *coinAgeIndexStore) ageWindowKey(window uint64) *database.Key {
+	var keyBytes [8]byte
+	binary.BigEndian.PutUint64(keyBytes[:], window)
+	return ageWindowsBucket.Key(keyBytes[:])
+}
+
+func (cais *coinAgeIndexStore) chainBlockRecordKey(blockHash *externalapi.DomainHash) *database.Key {
+	return chainBlockRecordsBucket.Key(blockHash.ByteSlice())
+}
+
+func (cais *coinAgeIndexStore) addChainBlockRecord(dataAccessor database.DataAccessor,
+	record *chainBlockRecord, blueScore uint64) error {
+
+	blockHash := record.coinDaysDestroyed.BlockHash
+	key := cais.chainBlockRecordKey(blockHash)
+	serializedRecord := serializeChainBlockRecord(record)
+	err := dataAccessor.Put(key, serializedRecord)
+	if err != nil {
+		return err
+	}
+	return cais.chainBlockTracker.Track(dataAccessor, blockHash, blueScore, indexretention.RecordSize(key, serializedRecord))
+}
+
+// removeChainBlockRecord deletes and returns the record of the given chain
+// block, after it was removed from the virtual selected parent chain or
+// pruned. found is false if the block was added to the chain before the
+// index was started, or if its record was pruned.
+func (cais *coinAgeIndexStore) removeChainBlockRecord(dataAccessor database.DataAccessor,
+	blockHash *externalapi.DomainHash, blueScore uint64) (record *chainBlockRecord, found bool, err error) {
+
+	err = cais.chainBlockTracker.Untrack(dataAccessor, blockHash, blueScore)
+	if err != nil {
+		return nil, false, err
+	}
+
+	record, found, err = cais.getChainBlockRecord(dataAccessor, blockHash)
+	if err != nil || !found {
+		return nil, found, err
+	}
+	err = dataAccessor.Delete(cais.chainBlockRecordKey(blockHash))
+	if err != nil {
+		return nil, false, err
+	}
+	return record, true, nil
+}
+
+func (cais *coinAgeIndexStore) getChainBlockRecord(dataAccessor database.DataAccessor,
+	blockHash *externalapi.DomainHash) (record *chainBlockRecord, found bool, err error) {
+
+	serializedRecord, err := dataAccessor.Get(cais.chainBlockRecordKey(blockHash))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	record, err = deserializeChainBlockRecord(serializedRecord)
+	if err != nil {
+		return nil, false, err
+	}
+	record.coinDaysDestroyed.BlockHash = blockHash
+	return record, true, nil
+}
+
+// applyAgeWindowDeltas applies the given deltas to the age windows. Since
+// reads don't observe writes that weren't committed yet, every window may
+// appear at most once in the deltas of a database transaction.
+func (cais *coinAgeIndexStore) applyAgeWindowDeltas(dataAccessor database.DataAccessor,
+	deltas map[uint64]*ageWindowDelta) error {
+
+	for window, delta := range deltas {
+		key := cais.ageWindowKey(window)
+		utxoCount, totalAmount := uint64(0), uint64(0)
+		serializedAgeWindow, err := dataAccessor.Get(key)
+		if err != nil && !database.IsNotFoundError(err) {
+			return err
+		}
+		if err == nil {
+			utxoCount, totalAmount, err = deserializeAgeWindow(serializedAgeWindow)
+			if err != nil {
+				return err
+			}
+		}
+
+		utxoCount = uint64(int64(utxoCount) + delta.countDelta)
+		totalAmount = uint64(int64(totalAmount) + delta.amountDelta)
+		if utxoCount == 0 {
+			err = dataAccessor.Delete(key)
+		} else {
+			err = dataAccessor.Put(key, serializeAgeWindow(utxoCount, totalAmount))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getAgeWindows returns all the non-empty age windows, ordered by their DAA score
+func (cais *coinAgeIndexStore) getAgeWindows() ([]*AgeWindow, error) {
+	cursor, err := cais.database.Cursor(ageWindowsBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	ageWindows := make([]*AgeWindow, 0)
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		serializedAgeWindow, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		utxoCount, totalAmount, err := deserializeAgeWindow(serializedAgeWindow)
+		if err != nil {
+			return nil, err
+		}
+		ageWindows = append(ageWindows, &AgeWindow{
+			StartDAAScore: binary.BigEndian.Uint64(key.Suffix()) * DAAScoreWindowSize,
+			UTXOCount:     utxoCount,
+			TotalAmount:   totalAmount,
+		})
+	}
+	return ageWindows, nil
+}
+
+func (cais *coinAgeIndexStore) deleteAll() error {
+	for _, bucket := range []*database.Bucket{ageWindowsBucket, chainBlockRecordsBucket, chainBlockTrackerBucket} {
+		err := cais.deleteBucket(bucket)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cais *coinAgeIndexStore) deleteBucket(bucket *database.Bucket) error {
+	cursor, err := cais.database.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+
+		err = cais.database.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -23,6 +23,7 @@ type consensus struct {
 	genesisHash  *externalapi.DomainHash
 
 	expectedDAAWindowDurationInMilliseconds int64
+	virtualUTXOSetCacheSize                 int
 
 	blockProcessor        model.BlockProcessor
 	blockBuilder          model.BlockBuilder
@@ -59,6 +60,7 @@ type consensus struct {
 	headersSelectedChainStore           model.HeadersSelectedChainStore
 	daaBlocksStore                      model.DAABlocksStore
 	blocksWithTrustedDataDAAWindowStore model.BlocksWithTrustedDataDAAWindowStore
+	virtualChangeJournalStore           model.VirtualChangeJournalStore
 
 	consensusEventsChan chan externalapi.ConsensusEvent
 	virtualNotUpdated   bool
@@ -614,6 +616,37 @@ func (s *consensus) GetVirtualUTXOs(expectedVirtualParents []*externalapi.Domain
 	return virtualUTXOs, nil
 }
 
+// GetVirtualUTXO returns the entry of the given outpoint in the virtual UTXO
+// set, if it's unspent
+func (s *consensus) GetVirtualUTXO(outpoint *externalapi.DomainOutpoint) (externalapi.UTXOEntry, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+
+	found, err := s.consensusStateStore.HasUTXOByOutpoint(s.databaseContext, stagingArea, outpoint)
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+	entry, err := s.consensusStateStore.UTXOByOutpoint(s.databaseContext, stagingArea, outpoint)
+	if err != nil {
+		return nil, false, err
+	}
+	return entry, true, nil
+}
+
+func (s *consensus) GetVirtualChangeJournalEntriesAfter(virtualParents []*externalapi.DomainHash) (
+	entries []*externalapi.VirtualChangeJournalEntry, found bool, err error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.virtualChangeJournalStore.EntriesAfter(s.databaseContext, virtualParents)
+}
+
 func (s *consensus) PruningPoint() (*externalapi.DomainHash, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -74,3 +74,64 @@ func TestConsensus_GetBlockInfo(t *testing.T) {
 
 	})
 }
+
+func TestConsensus_GetVirtualChangeJournalEntriesAfter(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		consensus, teardown, err := factory.NewTestConsensus(consensusConfig, "TestConsensus_GetVirtualChangeJournalEntriesAfter")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		const chainLength = 3
+		virtualChangeSets := make([]*externalapi.VirtualChangeSet, 0, chainLength)
+		tipHash := consensusConfig.GenesisHash
+		for i := 0; i < chainLength; i++ {
+			var virtualChangeSet *externalapi.VirtualChangeSet
+			tipHash, virtualChangeSet, err = consensus.AddBlock([]*externalapi.DomainHash{tipHash}, nil, nil)
+			if err != nil {
+				t.Fatalf("AddBlock: %+v", err)
+			}
+			virtualChangeSets = append(virtualChangeSets, virtualChangeSet)
+		}
+
+		entries, found, err := consensus.GetVirtualChangeJournalEntriesAfter(virtualChangeSets[0].VirtualParents)
+		if err != nil {
+			t.Fatalf("GetVirtualChangeJournalEntriesAfter: %+v", err)
+		}
+		if !found {
+			t.Fatalf("The virtual parents of the first virtual change are missing from the journal")
+		}
+		if len(entries) != chainLength-1 {
+			t.Fatalf("Unexpected amount of journal entries. Want: %d, got: %d", chainLength-1, len(entries))
+		}
+		for i, entry := range entries {
+			virtualChangeSet := virtualChangeSets[i+1]
+			if !externalapi.HashesEqual(entry.VirtualParents, virtualChangeSet.VirtualParents) {
+				t.Fatalf("Unexpected virtual parents in journal entry %d. Want: %s, got: %s",
+					i, virtualChangeSet.VirtualParents, entry.VirtualParents)
+			}
+			if entry.VirtualUTXODiff.ToAdd().Len() != virtualChangeSet.VirtualUTXODiff.ToAdd().Len() ||
+				entry.VirtualUTXODiff.ToRemove().Len() != virtualChangeSet.VirtualUTXODiff.ToRemove().Len() {
+				t.Fatalf("Unexpected virtual UTXO diff in journal entry %d", i)
+			}
+		}
+
+		entries, found, err = consensus.GetVirtualChangeJournalEntriesAfter([]*externalapi.DomainHash{tipHash})
+		if err != nil {
+			t.Fatalf("GetVirtualChangeJournalEntriesAfter: %+v", err)
+		}
+		if !found || len(entries) != 0 {
+			t.Fatalf("Expected no journal entries after the current virtual parents, got %d", len(entries))
+		}
+
+		_, found, err = consensus.GetVirtualChangeJournalEntriesAfter([]*externalapi.DomainHash{{}})
+		if err != nil {
+			t.Fatalf("GetVirtualChangeJournalEntriesAfter: %+v", err)
+		}
+		if found {
+			t.Fatalf("Unexpectedly found unknown virtual parents in the journal")
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package serialization
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// VirtualChangeJournalEntryToDBVirtualChangeJournalEntry converts VirtualChangeJournalEntry to DbVirtualChangeJournalEntry
+func VirtualChangeJournalEntryToDBVirtualChangeJournalEntry(
+	entry *externalapi.VirtualChangeJournalEntry) (*DbVirtualChangeJournalEntry, error) {
+
+	dbUTXODiff, err := UTXODiffToDBUTXODiff(entry.VirtualUTXODiff)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DbVirtualChangeJournalEntry{
+		VirtualParents:  DomainHashesToDbHashes(entry.VirtualParents),
+		VirtualUtxoDiff: dbUTXODiff,
+	}, nil
+}
+
+// DBVirtualChangeJournalEntryToVirtualChangeJournalEntry converts DbVirtualChangeJournalEntry to VirtualChangeJournalEntry
+func DBVirtualChangeJournalEntryToVirtualChangeJournalEntry(
+	dbEntry *DbVirtualChangeJournalEntry) (*externalapi.VirtualChangeJournalEntry, error) {
+
+	virtualParents, err := DbHashesToDomainHashes(dbEntry.VirtualParents)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualUTXODiff, err := DBUTXODiffToUTXODiff(dbEntry.VirtualUtxoDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalapi.VirtualChangeJournalEntry{
+		VirtualParents:  virtualParents,
+		VirtualUTXODiff: virtualUTXODiff,
+	}, nil
+}
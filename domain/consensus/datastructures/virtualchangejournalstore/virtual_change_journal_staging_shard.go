@@ -0,0 +1,54 @@
+package virtualchangejournalstore
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+type virtualChangeJournalStagingShard struct {
+	store    *virtualChangeJournalStore
+	newEntry *externalapi.VirtualChangeJournalEntry
+}
+
+func (vcjs *virtualChangeJournalStore) stagingShard(stagingArea *model.StagingArea) *virtualChangeJournalStagingShard {
+	return stagingArea.GetOrCreateShard(vcjs.shardID, func() model.StagingShard {
+		return &virtualChangeJournalStagingShard{
+			store:    vcjs,
+			newEntry: nil,
+		}
+	}).(*virtualChangeJournalStagingShard)
+}
+
+func (vcjss *virtualChangeJournalStagingShard) Commit(dbTx model.DBTransaction) error {
+	if vcjss.newEntry == nil {
+		return nil
+	}
+
+	index, err := vcjss.store.nextIndex(dbTx)
+	if err != nil {
+		return err
+	}
+
+	entryBytes, err := vcjss.store.serializeEntry(vcjss.newEntry)
+	if err != nil {
+		return err
+	}
+	err = dbTx.Put(vcjss.store.indexAsKey(index), entryBytes)
+	if err != nil {
+		return err
+	}
+
+	// Keep only the most recent maxEntries entries
+	if index >= vcjss.store.maxEntries {
+		err = dbTx.Delete(vcjss.store.indexAsKey(index - vcjss.store.maxEntries))
+		if err != nil {
+			return err
+		}
+	}
+
+	return dbTx.Put(vcjss.store.nextIndexKey, vcjss.store.serializeIndex(index+1))
+}
+
+func (vcjss *virtualChangeJournalStagingShard) isStaged() bool {
+	return vcjss.newEntry != nil
+}
@@ -0,0 +1,145 @@
+package virtualchangejournalstore
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kaspanet/kaspad/domain/consensus/database"
+	"github.com/kaspanet/kaspad/domain/consensus/database/binaryserialization"
+	"github.com/kaspanet/kaspad/domain/consensus/database/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/util/staging"
+)
+
+var bucketName = []byte("virtual-change-journal")
+var nextIndexKeyName = []byte("virtual-change-journal-next-index")
+
+type virtualChangeJournalStore struct {
+	shardID      model.StagingShardID
+	maxEntries   uint64
+	bucket       model.DBBucket
+	nextIndexKey model.DBKey
+}
+
+// New instantiates a new VirtualChangeJournalStore that keeps
+// the most recent maxEntries virtual changes
+func New(prefixBucket model.DBBucket, maxEntries uint64) model.VirtualChangeJournalStore {
+	return &virtualChangeJournalStore{
+		shardID:      staging.GenerateShardingID(),
+		maxEntries:   maxEntries,
+		bucket:       prefixBucket.Bucket(bucketName),
+		nextIndexKey: prefixBucket.Key(nextIndexKeyName),
+	}
+}
+
+// Stage stages a journal entry for the given virtual parents and the virtual UTXO diff that resulted in them
+func (vcjs *virtualChangeJournalStore) Stage(stagingArea *model.StagingArea,
+	virtualParents []*externalapi.DomainHash, virtualUTXODiff externalapi.UTXODiff) {
+
+	stagingShard := vcjs.stagingShard(stagingArea)
+	stagingShard.newEntry = &externalapi.VirtualChangeJournalEntry{
+		VirtualParents:  virtualParents,
+		VirtualUTXODiff: virtualUTXODiff,
+	}
+}
+
+func (vcjs *virtualChangeJournalStore) IsStaged(stagingArea *model.StagingArea) bool {
+	return vcjs.stagingShard(stagingArea).isStaged()
+}
+
+// EntriesAfter returns the journal entries that follow the most recent entry
+// that resulted in the given virtual parents. found is false if no such entry
+// is in the journal.
+func (vcjs *virtualChangeJournalStore) EntriesAfter(dbContext model.DBReader,
+	virtualParents []*externalapi.DomainHash) (entries []*externalapi.VirtualChangeJournalEntry, found bool, err error) {
+
+	cursor, err := dbContext.Cursor(vcjs.bucket)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close()
+
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		entryBytes, err := cursor.Value()
+		if err != nil {
+			return nil, false, err
+		}
+		entry, err := vcjs.deserializeEntry(entryBytes)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if externalapi.HashesEqual(entry.VirtualParents, virtualParents) {
+			found = true
+			entries = nil
+			continue
+		}
+		if found {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, found, nil
+}
+
+// Clear deletes all the entries from the journal
+func (vcjs *virtualChangeJournalStore) Clear(dbContext model.DBWriter) error {
+	cursor, err := dbContext.Cursor(vcjs.bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		err = dbContext.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (vcjs *virtualChangeJournalStore) nextIndex(dbContext model.DBReader) (uint64, error) {
+	indexBytes, err := dbContext.Get(vcjs.nextIndexKey)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return binaryserialization.DeserializeUint64(indexBytes)
+}
+
+func (vcjs *virtualChangeJournalStore) serializeIndex(index uint64) []byte {
+	return binaryserialization.SerializeUint64(index)
+}
+
+func (vcjs *virtualChangeJournalStore) indexAsKey(index uint64) model.DBKey {
+	var keyBytes [8]byte
+	binary.BigEndian.PutUint64(keyBytes[:], index)
+	return vcjs.bucket.Key(keyBytes[:])
+}
+
+func (vcjs *virtualChangeJournalStore) serializeEntry(entry *externalapi.VirtualChangeJournalEntry) ([]byte, error) {
+	dbEntry, err := serialization.VirtualChangeJournalEntryToDBVirtualChangeJournalEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(dbEntry)
+}
+
+func (vcjs *virtualChangeJournalStore) deserializeEntry(entryBytes []byte) (*externalapi.VirtualChangeJournalEntry, error) {
+	dbEntry := &serialization.DbVirtualChangeJournalEntry{}
+	err := proto.Unmarshal(entryBytes, dbEntry)
+	if err != nil {
+		return nil, err
+	}
+	return serialization.DBVirtualChangeJournalEntryToVirtualChangeJournalEntry(dbEntry)
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/datastructures/pruningstore"
 	"github.com/kaspanet/kaspad/domain/consensus/datastructures/reachabilitydatastore"
 	"github.com/kaspanet/kaspad/domain/consensus/datastructures/utxodiffstore"
+	"github.com/kaspanet/kaspad/domain/consensus/datastructures/virtualchangejournalstore"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
 	"github.com/kaspanet/kaspad/domain/consensus/processes/blockbuilder"
@@ -62,6 +63,13 @@ const (
 	defaultTestLeveldbCacheSizeMiB = 8
 	defaultPreallocateCaches       = true
 	defaultTestPreallocateCaches   = false
+
+	// virtualChangeJournalSize is the amount of recent virtual changes kept
+	// for indexes that fell behind consensus to catch up with
+	virtualChangeJournalSize = 1000
+
+	// virtualUTXOSetCacheSize is the amount of virtual UTXOs kept in memory
+	virtualUTXOSetCacheSize = 10_000
 )
 
 // Config is the full config required to run consensus
@@ -147,13 +155,14 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 	multisetStore := multisetstore.New(prefixBucket, 200, preallocateCaches)
 	pruningStore := pruningstore.New(prefixBucket, 2, preallocateCaches)
 	utxoDiffStore := utxodiffstore.New(prefixBucket, 200, preallocateCaches)
-	consensusStateStore := consensusstatestore.New(prefixBucket, 10_000, preallocateCaches)
+	consensusStateStore := consensusstatestore.New(prefixBucket, virtualUTXOSetCacheSize, preallocateCaches)
 
 	headersSelectedTipStore := headersselectedtipstore.New(prefixBucket)
 	finalityStore := finalitystore.New(prefixBucket, 200, preallocateCaches)
 	headersSelectedChainStore := headersselectedchainstore.New(prefixBucket, pruningWindowSizeForCaches, preallocateCaches)
 	daaBlocksStore := daablocksstore.New(prefixBucket, pruningWindowSizeForCaches, int(config.FinalityDepth()), preallocateCaches)
 	windowHeapSliceStore := blockwindowheapslicestore.New(2000, preallocateCaches)
+	virtualChangeJournalStore := virtualchangejournalstore.New(prefixBucket, virtualChangeJournalSize)
 
 	newReachabilityDataStore := reachabilitydatastore.New(prefixBucket, pruningWindowSizePlusFinalityDepthForCache*2, preallocateCaches)
 	blockRelationStores, reachabilityDataStores, ghostdagDataStores := dagStores(config, prefixBucket, pruningWindowSizePlusFinalityDepthForCache, pruningWindowSizeForCaches, preallocateCaches)
@@ -304,7 +313,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		blockHeaderStore,
 		headersSelectedTipStore,
 		pruningStore,
-		daaBlocksStore)
+		daaBlocksStore,
+		virtualChangeJournalStore)
 	if err != nil {
 		return nil, false, err
 	}
@@ -481,6 +491,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 
 		expectedDAAWindowDurationInMilliseconds: config.TargetTimePerBlock.Milliseconds() *
 			int64(config.DifficultyAdjustmentWindowSize),
+		virtualUTXOSetCacheSize: virtualUTXOSetCacheSize,
 
 		blockProcessor:        blockProcessor,
 		blockBuilder:          blockBuilder,
@@ -517,6 +528,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		headersSelectedChainStore:           headersSelectedChainStore,
 		daaBlocksStore:                      daaBlocksStore,
 		blocksWithTrustedDataDAAWindowStore: daaWindowStore,
+		virtualChangeJournalStore:           virtualChangeJournalStore,
 
 		consensusEventsChan: consensusEventsChan,
 		virtualNotUpdated:   true,
@@ -26,6 +26,9 @@ type Consensus interface {
 	GetMissingBlockBodyHashes(highHash *DomainHash) ([]*DomainHash, error)
 	GetPruningPointUTXOs(expectedPruningPointHash *DomainHash, fromOutpoint *DomainOutpoint, limit int) ([]*OutpointAndUTXOEntryPair, error)
 	GetVirtualUTXOs(expectedVirtualParents []*DomainHash, fromOutpoint *DomainOutpoint, limit int) ([]*OutpointAndUTXOEntryPair, error)
+	GetVirtualUTXO(outpoint *DomainOutpoint) (entry UTXOEntry, found bool, err error)
+	GetVirtualChangeJournalEntriesAfter(virtualParents []*DomainHash) (entries []*VirtualChangeJournalEntry, found bool, err error)
+	WarmUpVirtualUTXOSetCache() (int, error)
 	PruningPoint() (*DomainHash, error)
 	PruningPointHeaders() ([]BlockHeader, error)
 	PruningPointAndItsAnticone() ([]*DomainHash, error)
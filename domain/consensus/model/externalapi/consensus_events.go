@@ -28,3 +28,10 @@ type SelectedChainPath struct {
 	Added   []*DomainHash
 	Removed []*DomainHash
 }
+
+// VirtualChangeJournalEntry is a virtual UTXO diff along with the virtual
+// parents it resulted in, as recorded by consensus in its virtual change journal
+type VirtualChangeJournalEntry struct {
+	VirtualParents  []*DomainHash
+	VirtualUTXODiff UTXODiff
+}
@@ -0,0 +1,14 @@
+package model
+
+import "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+
+// VirtualChangeJournalStore represents a store of the most recent virtual UTXO
+// diffs, committed along with the virtual UTXO set itself
+type VirtualChangeJournalStore interface {
+	Store
+	Stage(stagingArea *StagingArea, virtualParents []*externalapi.DomainHash, virtualUTXODiff externalapi.UTXODiff)
+	IsStaged(stagingArea *StagingArea) bool
+	EntriesAfter(dbContext DBReader, virtualParents []*externalapi.DomainHash) (
+		entries []*externalapi.VirtualChangeJournalEntry, found bool, err error)
+	Clear(dbContext DBWriter) error
+}
@@ -122,5 +122,5 @@ func TestBuildSubsidyTable(t *testing.T) {
 		}
 	}
 	tableStr += "\n}"
-	t.Logf(tableStr)
+	t.Log(tableStr)
 }
@@ -22,18 +22,19 @@ type consensusStateManager struct {
 	finalityManager       model.FinalityManager
 	difficultyManager     model.DifficultyManager
 
-	headersSelectedTipStore model.HeaderSelectedTipStore
-	blockStatusStore        model.BlockStatusStore
-	ghostdagDataStore       model.GHOSTDAGDataStore
-	consensusStateStore     model.ConsensusStateStore
-	multisetStore           model.MultisetStore
-	blockStore              model.BlockStore
-	utxoDiffStore           model.UTXODiffStore
-	blockRelationStore      model.BlockRelationStore
-	acceptanceDataStore     model.AcceptanceDataStore
-	blockHeaderStore        model.BlockHeaderStore
-	pruningStore            model.PruningStore
-	daaBlocksStore          model.DAABlocksStore
+	headersSelectedTipStore   model.HeaderSelectedTipStore
+	blockStatusStore          model.BlockStatusStore
+	ghostdagDataStore         model.GHOSTDAGDataStore
+	consensusStateStore       model.ConsensusStateStore
+	multisetStore             model.MultisetStore
+	blockStore                model.BlockStore
+	utxoDiffStore             model.UTXODiffStore
+	blockRelationStore        model.BlockRelationStore
+	acceptanceDataStore       model.AcceptanceDataStore
+	blockHeaderStore          model.BlockHeaderStore
+	pruningStore              model.PruningStore
+	daaBlocksStore            model.DAABlocksStore
+	virtualChangeJournalStore model.VirtualChangeJournalStore
 
 	stores []model.Store
 }
@@ -66,7 +67,8 @@ func New(
 	blockHeaderStore model.BlockHeaderStore,
 	headersSelectedTipStore model.HeaderSelectedTipStore,
 	pruningStore model.PruningStore,
-	daaBlocksStore model.DAABlocksStore) (model.ConsensusStateManager, error) {
+	daaBlocksStore model.DAABlocksStore,
+	virtualChangeJournalStore model.VirtualChangeJournalStore) (model.ConsensusStateManager, error) {
 
 	csm := &consensusStateManager{
 		maxBlockParents:   maxBlockParents,
@@ -85,18 +87,19 @@ func New(
 		finalityManager:       finalityManager,
 		difficultyManager:     difficultyManager,
 
-		multisetStore:           multisetStore,
-		blockStore:              blockStore,
-		blockStatusStore:        blockStatusStore,
-		ghostdagDataStore:       ghostdagDataStore,
-		consensusStateStore:     consensusStateStore,
-		utxoDiffStore:           utxoDiffStore,
-		blockRelationStore:      blockRelationStore,
-		acceptanceDataStore:     acceptanceDataStore,
-		blockHeaderStore:        blockHeaderStore,
-		headersSelectedTipStore: headersSelectedTipStore,
-		pruningStore:            pruningStore,
-		daaBlocksStore:          daaBlocksStore,
+		multisetStore:             multisetStore,
+		blockStore:                blockStore,
+		blockStatusStore:          blockStatusStore,
+		ghostdagDataStore:         ghostdagDataStore,
+		consensusStateStore:       consensusStateStore,
+		utxoDiffStore:             utxoDiffStore,
+		blockRelationStore:        blockRelationStore,
+		acceptanceDataStore:       acceptanceDataStore,
+		blockHeaderStore:          blockHeaderStore,
+		headersSelectedTipStore:   headersSelectedTipStore,
+		pruningStore:              pruningStore,
+		daaBlocksStore:            daaBlocksStore,
+		virtualChangeJournalStore: virtualChangeJournalStore,
 
 		stores: []model.Store{
 			consensusStateStore,
@@ -111,6 +114,7 @@ func New(
 			blockHeaderStore,
 			headersSelectedTipStore,
 			pruningStore,
+			virtualChangeJournalStore,
 		},
 	}
 
@@ -161,6 +161,13 @@ func (csm *consensusStateManager) applyImportedPruningPointUTXOSet(stagingArea *
 		return err
 	}
 
+	// The journaled virtual changes don't lead to the imported virtual UTXO set
+	log.Debugf("Clearing the virtual change journal")
+	err = csm.virtualChangeJournalStore.Clear(dbTx)
+	if err != nil {
+		return err
+	}
+
 	log.Debugf("Committing all staged data for imported pruning point")
 	err = dbTx.Commit()
 	if err != nil {
@@ -95,6 +95,9 @@ func (csm *consensusStateManager) updateVirtualWithParents(
 	log.Debugf("Staging new UTXO diff for the virtual block")
 	csm.consensusStateStore.StageVirtualUTXODiff(stagingArea, virtualUTXODiff)
 
+	log.Debugf("Staging the virtual change in the virtual change journal")
+	csm.virtualChangeJournalStore.Stage(stagingArea, virtualParents, virtualUTXODiff)
+
 	log.Debugf("Updating the selected tip's utxo-diff")
 	err = csm.updateSelectedTipUTXODiff(stagingArea, virtualUTXODiff)
 	if err != nil {
@@ -68,10 +68,9 @@ func (csm *consensusStateManager) validateBlockTransactionsAgainstPastUTXO(stagi
 	}
 	log.Tracef("The past median time of %s is %d", blockHash, selectedParentMedianTime)
 
+	transactions := make([]*externalapi.DomainTransaction, 0, len(block.Transactions))
 	for i, transaction := range block.Transactions {
 		transactionID := consensushashing.TransactionID(transaction)
-		log.Tracef("Validating transaction %s in block %s against "+
-			"the block's past UTXO", transactionID, blockHash)
 		if i == transactionhelper.CoinbaseTransactionIndex {
 			log.Tracef("Skipping transaction %s because it is the coinbase", transactionID)
 			continue
@@ -82,16 +81,20 @@ func (csm *consensusStateManager) validateBlockTransactionsAgainstPastUTXO(stagi
 		if err != nil {
 			return err
 		}
+		transactions = append(transactions, transaction)
+	}
 
-		log.Tracef("Validating transaction %s and populating it with fee", transactionID)
-		err = csm.transactionValidator.ValidateTransactionInContextAndPopulateFee(
-			stagingArea, transaction, blockHash)
-		if err != nil {
-			return err
-		}
-		log.Tracef("Validation against the block's past UTXO "+
-			"passed for transaction %s in block %s", transactionID, blockHash)
+	// The transactions of a block can't spend each other's outputs, so they're
+	// validated together, which lets the scripts of all their inputs be
+	// executed in parallel
+	log.Tracef("Validating the transactions of block %s against the block's past UTXO "+
+		"and populating them with fee", blockHash)
+	err = csm.transactionValidator.ValidateTransactionsInContextAndPopulateFee(
+		stagingArea, transactions, blockHash)
+	if err != nil {
+		return err
 	}
+	log.Tracef("Validation against the block's past UTXO passed for the transactions of block %s", blockHash)
 	return nil
 }
 
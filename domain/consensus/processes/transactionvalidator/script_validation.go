@@ -0,0 +1,133 @@
+package transactionvalidator
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/pkg/errors"
+)
+
+// scriptValidationInput is an input whose script is executed by
+// validateTransactionsScripts
+type scriptValidationInput struct {
+	tx         *externalapi.DomainTransaction
+	inputIndex int
+}
+
+// validateTransactionsScripts executes the scripts of the inputs of the given
+// transactions. The inputs are dispatched to a pool of up to GOMAXPROCS
+// workers, and the error of the first failing input, by the order of the
+// transactions and of their inputs, is returned, regardless of the order in
+// which the workers got to them.
+func (v *transactionValidator) validateTransactionsScripts(txs []*externalapi.DomainTransaction) error {
+	var inputs []scriptValidationInput
+	var missingOutpoints []*externalapi.DomainOutpoint
+	for _, tx := range txs {
+		for i, input := range tx.Inputs {
+			if input.UTXOEntry == nil {
+				missingOutpoints = append(missingOutpoints, &input.PreviousOutpoint)
+				continue
+			}
+			inputs = append(inputs, scriptValidationInput{tx: tx, inputIndex: i})
+		}
+	}
+
+	inputErrors := make([]error, len(inputs))
+	var nextInput int64 = -1
+	var hasFailed int32
+	validateInputs := func() {
+		// SighashReusedValues is lazily populated, so each worker has its
+		// own, which it renews whenever it moves to the next transaction
+		var currentTx *externalapi.DomainTransaction
+		var sighashReusedValues *consensushashing.SighashReusedValues
+		for {
+			// Inputs are claimed in order, so once an input has failed, all
+			// the inputs claimed from then on come after it, and their
+			// errors would never be returned
+			if atomic.LoadInt32(&hasFailed) != 0 {
+				return
+			}
+			i := int(atomic.AddInt64(&nextInput, 1))
+			if i >= len(inputs) {
+				return
+			}
+
+			input := inputs[i]
+			if input.tx != currentTx {
+				currentTx = input.tx
+				sighashReusedValues = &consensushashing.SighashReusedValues{}
+			}
+			err := v.validateInputScript(input.tx, input.inputIndex, sighashReusedValues)
+			if err != nil {
+				inputErrors[i] = err
+				atomic.StoreInt32(&hasFailed, 1)
+			}
+		}
+	}
+
+	workerCount := runtime.GOMAXPROCS(0)
+	if workerCount > len(inputs) {
+		workerCount = len(inputs)
+	}
+	if workerCount <= 1 {
+		validateInputs()
+	} else {
+		var waitGroup sync.WaitGroup
+		waitGroup.Add(workerCount)
+		for i := 0; i < workerCount; i++ {
+			go func() {
+				defer waitGroup.Done()
+				validateInputs()
+			}()
+		}
+		waitGroup.Wait()
+	}
+
+	for _, err := range inputErrors {
+		if err != nil {
+			return err
+		}
+	}
+	if len(missingOutpoints) > 0 {
+		return ruleerrors.NewErrMissingTxOut(missingOutpoints)
+	}
+	return nil
+}
+
+// validateInputScript executes the script pair of the input at the given
+// index of the transaction
+func (v *transactionValidator) validateInputScript(tx *externalapi.DomainTransaction, inputIndex int,
+	sighashReusedValues *consensushashing.SighashReusedValues) error {
+
+	input := tx.Inputs[inputIndex]
+	sigScript := input.SignatureScript
+	scriptPubKey := input.UTXOEntry.ScriptPublicKey()
+
+	// Create a new script engine for the script pair.
+	vm, err := txscript.NewEngine(scriptPubKey, tx, inputIndex, txscript.ScriptNoFlags, v.sigCache, v.sigCacheECDSA,
+		sighashReusedValues)
+	if err != nil {
+		return errors.Wrapf(ruleerrors.ErrScriptMalformed, "failed to parse input "+
+			"%d which references output %s - "+
+			"%s (input script bytes %x, prev "+
+			"output script bytes %x)",
+			inputIndex,
+			input.PreviousOutpoint, err, sigScript, scriptPubKey)
+	}
+
+	// Execute the script pair.
+	if err := vm.Execute(); err != nil {
+		return errors.Wrapf(ruleerrors.ErrScriptValidation, "failed to validate input "+
+			"%d which references output %s - "+
+			"%s (input script bytes %x, prev output "+
+			"script bytes %x)",
+			inputIndex,
+			input.PreviousOutpoint, err, sigScript, scriptPubKey)
+	}
+	return nil
+}
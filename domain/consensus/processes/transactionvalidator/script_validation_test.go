@@ -0,0 +1,68 @@
+package transactionvalidator
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/pkg/errors"
+)
+
+func TestValidateTransactionsScripts(t *testing.T) {
+	// Make sure the inputs are spread over several workers even on a
+	// single-core machine
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+
+	validator := &transactionValidator{
+		sigCache:      txscript.NewSigCache(sigCacheSize),
+		sigCacheECDSA: txscript.NewSigCacheECDSA(sigCacheSize),
+	}
+
+	const transactionCount = 10
+	const inputCount = 20
+	newTransactions := func(failingInputs map[[2]int]bool) []*externalapi.DomainTransaction {
+		transactions := make([]*externalapi.DomainTransaction, transactionCount)
+		for i := range transactions {
+			inputs := make([]*externalapi.DomainTransactionInput, inputCount)
+			for j := range inputs {
+				script := []byte{txscript.OpTrue}
+				if failingInputs[[2]int{i, j}] {
+					script = []byte{txscript.OpFalse}
+				}
+				inputs[j] = &externalapi.DomainTransactionInput{
+					PreviousOutpoint: externalapi.DomainOutpoint{Index: uint32(i*inputCount + j)},
+					Sequence:         constants.MaxTxInSequenceNum,
+					UTXOEntry: utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: script},
+						false, 0),
+				}
+			}
+			transactions[i] = &externalapi.DomainTransaction{Inputs: inputs}
+		}
+		return transactions
+	}
+
+	err := validator.validateTransactionsScripts(newTransactions(nil))
+	if err != nil {
+		t.Fatalf("validateTransactionsScripts: %+v", err)
+	}
+
+	// The error of the first failing input is returned, no matter which of
+	// the workers got to it first
+	failingInputs := map[[2]int]bool{{3, 17}: true, {3, 5}: true, {7, 0}: true, {9, 19}: true}
+	for i := 0; i < 20; i++ {
+		err := validator.validateTransactionsScripts(newTransactions(failingInputs))
+		if !errors.Is(err, ruleerrors.ErrScriptValidation) {
+			t.Fatalf("Expected ErrScriptValidation, but got: %v", err)
+		}
+		expectedOutpoint := externalapi.DomainOutpoint{Index: 3*inputCount + 5}
+		if !strings.Contains(err.Error(), "failed to validate input 5 which references output "+
+			expectedOutpoint.String()) {
+			t.Fatalf("Expected the error of input 5 of transaction 3, but got: %v", err)
+		}
+	}
+}
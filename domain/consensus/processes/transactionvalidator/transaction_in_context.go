@@ -6,7 +6,6 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
-	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
@@ -68,39 +67,59 @@ func (v *transactionValidator) ValidateTransactionInContextIgnoringUTXO(stagingA
 func (v *transactionValidator) ValidateTransactionInContextAndPopulateFee(stagingArea *model.StagingArea,
 	tx *externalapi.DomainTransaction, povBlockHash *externalapi.DomainHash) error {
 
-	err := v.checkTransactionCoinbaseMaturity(stagingArea, povBlockHash, tx)
+	err := v.validateTransactionInContextIgnoringScriptsAndPopulateFee(stagingArea, tx, povBlockHash)
 	if err != nil {
 		return err
 	}
 
-	totalSompiIn, err := v.checkTransactionInputAmounts(tx)
-	if err != nil {
-		return err
+	return v.validateTransactionsScripts([]*externalapi.DomainTransaction{tx})
+}
+
+// ValidateTransactionsInContextAndPopulateFee validates the transactions against their referenced UTXOs, and
+// populates their fee fields. It's equivalent to calling ValidateTransactionInContextAndPopulateFee for each
+// of the transactions, except that the scripts of all their inputs are executed together, once all the other
+// checks passed, so that they're spread over all the script validation workers.
+//
+// Note: if the function fails, there's no guarantee that the transaction fee fields will remain unaffected.
+func (v *transactionValidator) ValidateTransactionsInContextAndPopulateFee(stagingArea *model.StagingArea,
+	txs []*externalapi.DomainTransaction, povBlockHash *externalapi.DomainHash) error {
+
+	for _, tx := range txs {
+		err := v.validateTransactionInContextIgnoringScriptsAndPopulateFee(stagingArea, tx, povBlockHash)
+		if err != nil {
+			return err
+		}
 	}
 
-	totalSompiOut, err := v.checkTransactionOutputAmounts(tx, totalSompiIn)
+	return v.validateTransactionsScripts(txs)
+}
+
+func (v *transactionValidator) validateTransactionInContextIgnoringScriptsAndPopulateFee(
+	stagingArea *model.StagingArea, tx *externalapi.DomainTransaction, povBlockHash *externalapi.DomainHash) error {
+
+	err := v.checkTransactionCoinbaseMaturity(stagingArea, povBlockHash, tx)
 	if err != nil {
 		return err
 	}
 
-	tx.Fee = totalSompiIn - totalSompiOut
-
-	err = v.checkTransactionSequenceLock(stagingArea, povBlockHash, tx)
+	totalSompiIn, err := v.checkTransactionInputAmounts(tx)
 	if err != nil {
 		return err
 	}
 
-	err = v.validateTransactionSigOpCounts(tx)
+	totalSompiOut, err := v.checkTransactionOutputAmounts(tx, totalSompiIn)
 	if err != nil {
 		return err
 	}
 
-	err = v.validateTransactionScripts(tx)
+	tx.Fee = totalSompiIn - totalSompiOut
+
+	err = v.checkTransactionSequenceLock(stagingArea, povBlockHash, tx)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return v.validateTransactionSigOpCounts(tx)
 }
 
 func (v *transactionValidator) checkTransactionCoinbaseMaturity(stagingArea *model.StagingArea,
@@ -225,46 +244,6 @@ func (v *transactionValidator) checkTransactionSequenceLock(stagingArea *model.S
 	return nil
 }
 
-func (v *transactionValidator) validateTransactionScripts(tx *externalapi.DomainTransaction) error {
-	var missingOutpoints []*externalapi.DomainOutpoint
-	sighashReusedValues := &consensushashing.SighashReusedValues{}
-
-	for i, input := range tx.Inputs {
-		// Create a new script engine for the script pair.
-		sigScript := input.SignatureScript
-		utxoEntry := input.UTXOEntry
-		if utxoEntry == nil {
-			missingOutpoints = append(missingOutpoints, &input.PreviousOutpoint)
-			continue
-		}
-
-		scriptPubKey := utxoEntry.ScriptPublicKey()
-		vm, err := txscript.NewEngine(scriptPubKey, tx, i, txscript.ScriptNoFlags, v.sigCache, v.sigCacheECDSA, sighashReusedValues)
-		if err != nil {
-			return errors.Wrapf(ruleerrors.ErrScriptMalformed, "failed to parse input "+
-				"%d which references output %s - "+
-				"%s (input script bytes %x, prev "+
-				"output script bytes %x)",
-				i,
-				input.PreviousOutpoint, err, sigScript, scriptPubKey)
-		}
-
-		// Execute the script pair.
-		if err := vm.Execute(); err != nil {
-			return errors.Wrapf(ruleerrors.ErrScriptValidation, "failed to validate input "+
-				"%d which references output %s - "+
-				"%s (input script bytes %x, prev output "+
-				"script bytes %x)",
-				i,
-				input.PreviousOutpoint, err, sigScript, scriptPubKey)
-		}
-	}
-	if len(missingOutpoints) > 0 {
-		return ruleerrors.NewErrMissingTxOut(missingOutpoints)
-	}
-	return nil
-}
-
 func (v *transactionValidator) calcTxSequenceLockFromReferencedUTXOEntries(stagingArea *model.StagingArea,
 	povBlockHash *externalapi.DomainHash, tx *externalapi.DomainTransaction) (*sequenceLock, error) {
 
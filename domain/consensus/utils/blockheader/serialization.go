@@ -0,0 +1,102 @@
+package blockheader
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/serialization"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxSerializedParents is the maximum amount of block levels, and of
+	// parents per block level, that a serialized header may declare. It
+	// only guards against huge allocations while deserializing.
+	maxSerializedParents = 1 << 10
+
+	// maxSerializedBlueWorkLength is the maximum length, in bytes, of the
+	// blue work of a serialized header
+	maxSerializedBlueWorkLength = 64
+)
+
+// DeserializeHeader reads a header that was written by
+// consensushashing.SerializeHeader from r
+func DeserializeHeader(r io.Reader) (externalapi.BlockHeader, error) {
+	var version uint16
+	var numParents uint64
+	err := serialization.ReadElements(r, &version, &numParents)
+	if err != nil {
+		return nil, err
+	}
+	if numParents > maxSerializedParents {
+		return nil, errors.Errorf("header has %d block levels, which is more than the maximum of %d",
+			numParents, maxSerializedParents)
+	}
+	parents := make([]externalapi.BlockLevelParents, numParents)
+	for i := range parents {
+		var numBlockLevelParents uint64
+		err := serialization.ReadElement(r, &numBlockLevelParents)
+		if err != nil {
+			return nil, err
+		}
+		if numBlockLevelParents > maxSerializedParents {
+			return nil, errors.Errorf("header has %d parents in block level %d, which is more than the maximum of %d",
+				numBlockLevelParents, i, maxSerializedParents)
+		}
+		parents[i] = make(externalapi.BlockLevelParents, numBlockLevelParents)
+		for j := range parents[i] {
+			parents[i][j], err = readHash(r)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	hashMerkleRoot, err := readHash(r)
+	if err != nil {
+		return nil, err
+	}
+	acceptedIDMerkleRoot, err := readHash(r)
+	if err != nil {
+		return nil, err
+	}
+	utxoCommitment, err := readHash(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeInMilliseconds int64
+	var bits uint32
+	var nonce, daaScore, blueScore, blueWorkLength uint64
+	err = serialization.ReadElements(r, &timeInMilliseconds, &bits, &nonce, &daaScore, &blueScore, &blueWorkLength)
+	if err != nil {
+		return nil, err
+	}
+	if blueWorkLength > maxSerializedBlueWorkLength {
+		return nil, errors.Errorf("header has a blue work of %d bytes, which is more than the maximum of %d",
+			blueWorkLength, maxSerializedBlueWorkLength)
+	}
+	blueWorkBytes := make([]byte, blueWorkLength)
+	_, err = io.ReadFull(r, blueWorkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pruningPoint, err := readHash(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewImmutableBlockHeader(version, parents, hashMerkleRoot, acceptedIDMerkleRoot, utxoCommitment,
+		timeInMilliseconds, bits, nonce, daaScore, blueScore, new(big.Int).SetBytes(blueWorkBytes), pruningPoint), nil
+}
+
+func readHash(r io.Reader) (*externalapi.DomainHash, error) {
+	var hashBytes [externalapi.DomainHashSize]byte
+	_, err := io.ReadFull(r, hashBytes[:])
+	if err != nil {
+		return nil, err
+	}
+	return externalapi.NewDomainHashFromByteArray(&hashBytes), nil
+}
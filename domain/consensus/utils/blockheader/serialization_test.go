@@ -0,0 +1,56 @@
+package blockheader
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestHeaderSerializationRoundTrip(t *testing.T) {
+	header := NewImmutableBlockHeader(
+		1,
+		[]externalapi.BlockLevelParents{
+			{
+				externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+				externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+			},
+			{externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{3})},
+		},
+		externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{4}),
+		externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{5}),
+		externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{6}),
+		7,
+		8,
+		9,
+		10,
+		11,
+		big.NewInt(12345678),
+		externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{13}),
+	)
+
+	var buffer bytes.Buffer
+	err := consensushashing.SerializeHeader(&buffer, header)
+	if err != nil {
+		t.Fatalf("SerializeHeader: %s", err)
+	}
+	serialized := buffer.Bytes()
+
+	deserialized, err := DeserializeHeader(bytes.NewReader(serialized))
+	if err != nil {
+		t.Fatalf("DeserializeHeader: %s", err)
+	}
+	if !deserialized.Equal(header) {
+		t.Fatalf("The deserialized header is different from the original one")
+	}
+	if !consensushashing.HeaderHash(deserialized).Equal(consensushashing.HeaderHash(header)) {
+		t.Fatalf("The deserialized header has a different hash than the original one")
+	}
+
+	_, err = DeserializeHeader(bytes.NewReader(serialized[:len(serialized)-1]))
+	if err == nil {
+		t.Fatalf("Expected a truncated header to fail deserialization")
+	}
+}
@@ -20,7 +20,7 @@ func HeaderHash(header externalapi.BaseBlockHeader) *externalapi.DomainHash {
 	// Encode the header and hash everything prior to the number of
 	// transactions.
 	writer := hashes.NewBlockHashWriter()
-	err := serializeHeader(writer, header)
+	err := SerializeHeader(writer, header)
 	if err != nil {
 		// It seems like this could only happen if the writer returned an error.
 		// and this writer should never return an error (no allocations or possible failures)
@@ -31,7 +31,9 @@ func HeaderHash(header externalapi.BaseBlockHeader) *externalapi.DomainHash {
 	return writer.Finalize()
 }
 
-func serializeHeader(w io.Writer, header externalapi.BaseBlockHeader) error {
+// SerializeHeader writes the given header to w in the format it's hashed in.
+// It can be read back with blockheader.DeserializeHeader.
+func SerializeHeader(w io.Writer, header externalapi.BaseBlockHeader) error {
 	timestamp := header.TimeInMilliseconds()
 	blueWork := header.BlueWork().Bytes()
 
@@ -0,0 +1,248 @@
+package testvectors
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/processes/ghostdagmanager"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/pkg/errors"
+)
+
+// GHOSTDAGCase describes a DAG and the GHOSTDAG coloring that is expected
+// for each of its blocks. Blocks are ordered topologically, and the first
+// block is the genesis.
+type GHOSTDAGCase struct {
+	Description string            `json:"description"`
+	K           externalapi.KType `json:"k"`
+	Blocks      []*GHOSTDAGBlock  `json:"blocks"`
+}
+
+// GHOSTDAGBlock is a single block within a GHOSTDAGCase
+type GHOSTDAGBlock struct {
+	Hash                   string   `json:"hash"`
+	Parents                []string `json:"parents"`
+	Bits                   uint32   `json:"bits"`
+	ExpectedBlueScore      uint64   `json:"expectedBlueScore"`
+	ExpectedBlueWork       string   `json:"expectedBlueWork"`
+	ExpectedSelectedParent string   `json:"expectedSelectedParent"`
+	ExpectedMergeSetBlues  []string `json:"expectedMergeSetBlues"`
+	ExpectedMergeSetReds   []string `json:"expectedMergeSetReds"`
+}
+
+type ghostdagRun struct {
+	manager           model.GHOSTDAGManager
+	dagTopology       *memoryDAGTopologyManager
+	ghostdagDataStore *memoryGHOSTDAGDataStore
+	headerStore       *memoryBlockHeaderStore
+}
+
+func newGHOSTDAGRun(k externalapi.KType, genesisHash *externalapi.DomainHash) *ghostdagRun {
+	run := &ghostdagRun{
+		dagTopology:       &memoryDAGTopologyManager{parents: make(map[externalapi.DomainHash][]*externalapi.DomainHash)},
+		ghostdagDataStore: &memoryGHOSTDAGDataStore{data: make(map[externalapi.DomainHash]*externalapi.BlockGHOSTDAGData)},
+		headerStore:       &memoryBlockHeaderStore{headers: make(map[externalapi.DomainHash]externalapi.BlockHeader)},
+	}
+	run.manager = ghostdagmanager.New(nil, run.dagTopology, run.ghostdagDataStore, run.headerStore, k, genesisHash)
+	return run
+}
+
+func (run *ghostdagRun) addBlock(blockHash *externalapi.DomainHash, parents []*externalapi.DomainHash, bits uint32) (
+	*externalapi.BlockGHOSTDAGData, error) {
+
+	run.dagTopology.parents[*blockHash] = parents
+	run.headerStore.headers[*blockHash] = blockheader.NewImmutableBlockHeader(
+		constants.BlockVersion,
+		[]externalapi.BlockLevelParents{parents},
+		&externalapi.DomainHash{},
+		&externalapi.DomainHash{},
+		&externalapi.DomainHash{},
+		0,
+		bits,
+		0,
+		0,
+		0,
+		big.NewInt(0),
+		&externalapi.DomainHash{},
+	)
+	err := run.manager.GHOSTDAG(nil, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return run.ghostdagDataStore.Get(nil, nil, blockHash, false)
+}
+
+// GenerateGHOSTDAGCases generates numberOfCases random DAGs using the given
+// seed, colors them with kaspad's GHOSTDAG implementation, and returns them as
+// test vectors
+func GenerateGHOSTDAGCases(seed int64, numberOfCases int, bits uint32) ([]*GHOSTDAGCase, error) {
+	random := rand.New(rand.NewSource(seed))
+	cases := make([]*GHOSTDAGCase, 0, numberOfCases)
+	for i := 0; i < numberOfCases; i++ {
+		k := externalapi.KType(1 + random.Intn(5))
+		numberOfBlocks := 20 + random.Intn(80)
+		ghostdagCase, err := generateGHOSTDAGCase(random, k, numberOfBlocks, bits)
+		if err != nil {
+			return nil, err
+		}
+		ghostdagCase.Description = fmt.Sprintf("random DAG #%d (seed %d) with k=%d and %d blocks",
+			i, seed, k, numberOfBlocks)
+		cases = append(cases, ghostdagCase)
+	}
+	return cases, nil
+}
+
+func generateGHOSTDAGCase(random *rand.Rand, k externalapi.KType, numberOfBlocks int, bits uint32) (*GHOSTDAGCase, error) {
+	genesisHash := randomHash(random)
+	run := newGHOSTDAGRun(k, genesisHash)
+	ghostdagCase := &GHOSTDAGCase{K: k}
+
+	addBlock := func(blockHash *externalapi.DomainHash, parents []*externalapi.DomainHash) error {
+		blockGHOSTDAGData, err := run.addBlock(blockHash, parents, bits)
+		if err != nil {
+			return err
+		}
+		ghostdagCase.Blocks = append(ghostdagCase.Blocks, &GHOSTDAGBlock{
+			Hash:                   blockHash.String(),
+			Parents:                hashesToStrings(parents),
+			Bits:                   bits,
+			ExpectedBlueScore:      blockGHOSTDAGData.BlueScore(),
+			ExpectedBlueWork:       blockGHOSTDAGData.BlueWork().Text(16),
+			ExpectedSelectedParent: hashToString(blockGHOSTDAGData.SelectedParent()),
+			ExpectedMergeSetBlues:  hashesToStrings(blockGHOSTDAGData.MergeSetBlues()),
+			ExpectedMergeSetReds:   hashesToStrings(blockGHOSTDAGData.MergeSetReds()),
+		})
+		return nil
+	}
+
+	err := addBlock(genesisHash, []*externalapi.DomainHash{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocks are added in rounds. All the blocks within a round only see the
+	// tips as they were at the beginning of the round, which simulates
+	// network delay and creates wide anticones.
+	tips := []*externalapi.DomainHash{genesisHash}
+	for len(ghostdagCase.Blocks) < numberOfBlocks {
+		roundSize := 1 + random.Intn(int(k)+3)
+		roundTips := tips
+		var newBlocks []*externalapi.DomainHash
+		referencedTips := make(map[externalapi.DomainHash]struct{})
+		for i := 0; i < roundSize && len(ghostdagCase.Blocks) < numberOfBlocks; i++ {
+			numberOfParents := 1 + random.Intn(len(roundTips))
+			parents := make([]*externalapi.DomainHash, 0, numberOfParents)
+			for _, index := range random.Perm(len(roundTips))[:numberOfParents] {
+				parents = append(parents, roundTips[index])
+				referencedTips[*roundTips[index]] = struct{}{}
+			}
+			blockHash := randomHash(random)
+			err := addBlock(blockHash, parents)
+			if err != nil {
+				return nil, err
+			}
+			newBlocks = append(newBlocks, blockHash)
+		}
+
+		tips = newBlocks
+		for _, tip := range roundTips {
+			if _, ok := referencedTips[*tip]; !ok {
+				tips = append(tips, tip)
+			}
+		}
+	}
+
+	return ghostdagCase, nil
+}
+
+func verifyGHOSTDAGCase(ghostdagCase *GHOSTDAGCase) ([]*Mismatch, error) {
+	if len(ghostdagCase.Blocks) == 0 {
+		return nil, errors.Errorf("GHOSTDAG case %q has no blocks", ghostdagCase.Description)
+	}
+	genesisHash, err := externalapi.NewDomainHashFromString(ghostdagCase.Blocks[0].Hash)
+	if err != nil {
+		return nil, err
+	}
+	run := newGHOSTDAGRun(ghostdagCase.K, genesisHash)
+
+	var mismatches []*Mismatch
+	for _, block := range ghostdagCase.Blocks {
+		blockHash, err := externalapi.NewDomainHashFromString(block.Hash)
+		if err != nil {
+			return nil, err
+		}
+		parents, err := stringsToHashes(block.Parents)
+		if err != nil {
+			return nil, err
+		}
+		blockGHOSTDAGData, err := run.addBlock(blockHash, parents, block.Bits)
+		if err != nil {
+			return nil, err
+		}
+
+		mismatch := func(format string, args ...interface{}) {
+			mismatches = append(mismatches, &Mismatch{
+				Category:    "ghostdag",
+				Description: ghostdagCase.Description,
+				Details:     fmt.Sprintf("block %s: %s", block.Hash, fmt.Sprintf(format, args...)),
+			})
+		}
+		if blockGHOSTDAGData.BlueScore() != block.ExpectedBlueScore {
+			mismatch("expected blue score %d but got %d", block.ExpectedBlueScore, blockGHOSTDAGData.BlueScore())
+		}
+		if blockGHOSTDAGData.BlueWork().Text(16) != block.ExpectedBlueWork {
+			mismatch("expected blue work %s but got %s", block.ExpectedBlueWork, blockGHOSTDAGData.BlueWork().Text(16))
+		}
+		if hashToString(blockGHOSTDAGData.SelectedParent()) != block.ExpectedSelectedParent {
+			mismatch("expected selected parent %s but got %s",
+				block.ExpectedSelectedParent, hashToString(blockGHOSTDAGData.SelectedParent()))
+		}
+		if !reflect.DeepEqual(hashesToStrings(blockGHOSTDAGData.MergeSetBlues()), block.ExpectedMergeSetBlues) {
+			mismatch("expected merge set blues %v but got %v",
+				block.ExpectedMergeSetBlues, hashesToStrings(blockGHOSTDAGData.MergeSetBlues()))
+		}
+		if !reflect.DeepEqual(hashesToStrings(blockGHOSTDAGData.MergeSetReds()), block.ExpectedMergeSetReds) {
+			mismatch("expected merge set reds %v but got %v",
+				block.ExpectedMergeSetReds, hashesToStrings(blockGHOSTDAGData.MergeSetReds()))
+		}
+	}
+	return mismatches, nil
+}
+
+func randomHash(random *rand.Rand) *externalapi.DomainHash {
+	var hashBytes [externalapi.DomainHashSize]byte
+	random.Read(hashBytes[:])
+	return externalapi.NewDomainHashFromByteArray(&hashBytes)
+}
+
+func hashToString(hash *externalapi.DomainHash) string {
+	if hash == nil {
+		return ""
+	}
+	return hash.String()
+}
+
+func hashesToStrings(hashes []*externalapi.DomainHash) []string {
+	strings := make([]string, len(hashes))
+	for i, hash := range hashes {
+		strings[i] = hash.String()
+	}
+	return strings
+}
+
+func stringsToHashes(strings []string) ([]*externalapi.DomainHash, error) {
+	hashes := make([]*externalapi.DomainHash, len(strings))
+	for i, hashString := range strings {
+		hash, err := externalapi.NewDomainHashFromString(hashString)
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hash
+	}
+	return hashes, nil
+}
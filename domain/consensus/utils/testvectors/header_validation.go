@@ -0,0 +1,221 @@
+package testvectors
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// HeaderValidationCase describes a header that is submitted directly on top
+// of the network's genesis, and the rule error it is expected to be rejected
+// with. An empty ExpectedRuleError means the header is expected to be valid.
+type HeaderValidationCase struct {
+	Description       string  `json:"description"`
+	Header            *Header `json:"header"`
+	ExpectedRuleError string  `json:"expectedRuleError"`
+}
+
+// Header is the test vector representation of a block header
+type Header struct {
+	Version              uint16     `json:"version"`
+	Parents              [][]string `json:"parents"`
+	HashMerkleRoot       string     `json:"hashMerkleRoot"`
+	AcceptedIDMerkleRoot string     `json:"acceptedIdMerkleRoot"`
+	UTXOCommitment       string     `json:"utxoCommitment"`
+	TimeInMilliseconds   int64      `json:"timeInMilliseconds"`
+	Bits                 uint32     `json:"bits"`
+	Nonce                uint64     `json:"nonce"`
+	DAAScore             uint64     `json:"daaScore"`
+	BlueScore            uint64     `json:"blueScore"`
+	BlueWork             string     `json:"blueWork"`
+	PruningPoint         string     `json:"pruningPoint"`
+}
+
+type headerMutation struct {
+	description string
+	mutate      func(header *Header)
+}
+
+var headerMutations = []headerMutation{
+	{"valid header", func(*Header) {}},
+	{"unknown version", func(header *Header) { header.Version++ }},
+	{"no parents", func(header *Header) { header.Parents = [][]string{} }},
+	{"unknown parent", func(header *Header) {
+		header.Parents[0] = append(header.Parents[0], externalapi.NewDomainHashFromByteArray(&[32]byte{1}).String())
+	}},
+	{"timestamp too far in the future", func(header *Header) {
+		header.TimeInMilliseconds += 100 * 365 * 24 * 60 * 60 * 1000
+	}},
+	{"timestamp too old", func(header *Header) { header.TimeInMilliseconds = 0 }},
+	{"unexpected difficulty", func(header *Header) { header.Bits-- }},
+	{"unexpected DAA score", func(header *Header) { header.DAAScore++ }},
+	{"unexpected blue score", func(header *Header) { header.BlueScore++ }},
+	{"unexpected blue work", func(header *Header) { header.BlueWork = "1" + header.BlueWork }},
+	{"unexpected pruning point", func(header *Header) {
+		header.PruningPoint = externalapi.NewDomainHashFromByteArray(&[32]byte{2}).String()
+	}},
+}
+
+// GenerateHeaderValidationCases builds a valid header on top of the genesis of
+// the given network, applies a set of mutations to it, and records the rule
+// error kaspad rejects each of the resulting headers with
+func GenerateHeaderValidationCases(params *dagconfig.Params) ([]*HeaderValidationCase, error) {
+	validHeader, err := buildValidHeader(params)
+	if err != nil {
+		return nil, err
+	}
+
+	cases := make([]*HeaderValidationCase, 0, len(headerMutations))
+	for _, mutation := range headerMutations {
+		header := headerToVector(validHeader)
+		mutation.mutate(header)
+		ruleErrorName, err := validateHeader(params, header)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, &HeaderValidationCase{
+			Description:       mutation.description,
+			Header:            header,
+			ExpectedRuleError: ruleErrorName,
+		})
+	}
+	return cases, nil
+}
+
+func buildValidHeader(params *dagconfig.Params) (externalapi.BlockHeader, error) {
+	testConsensus, teardown, err := newTestConsensus(params, "buildValidHeader")
+	if err != nil {
+		return nil, err
+	}
+	defer teardown(false)
+
+	return testConsensus.BuildHeaderWithParents([]*externalapi.DomainHash{params.GenesisHash})
+}
+
+func verifyHeaderValidationCase(params *dagconfig.Params, headerValidationCase *HeaderValidationCase) ([]*Mismatch, error) {
+	ruleErrorName, err := validateHeader(params, headerValidationCase.Header)
+	if err != nil {
+		return nil, err
+	}
+	if ruleErrorName != headerValidationCase.ExpectedRuleError {
+		return []*Mismatch{{
+			Category:    "headerValidation",
+			Description: headerValidationCase.Description,
+			Details: fmt.Sprintf("expected rule error %q but got %q",
+				headerValidationCase.ExpectedRuleError, ruleErrorName),
+		}}, nil
+	}
+	return nil, nil
+}
+
+// validateHeader submits the given header to a fresh consensus instance and
+// returns the name of the rule error it was rejected with, or an empty string
+// if it was accepted. Errors that are not rule errors are returned as is.
+func validateHeader(params *dagconfig.Params, header *Header) (string, error) {
+	blockHeader, err := headerFromVector(header)
+	if err != nil {
+		return "", err
+	}
+
+	testConsensus, teardown, err := newTestConsensus(params, "validateHeader")
+	if err != nil {
+		return "", err
+	}
+	defer teardown(false)
+
+	err = testConsensus.ValidateAndInsertBlock(&externalapi.DomainBlock{Header: blockHeader}, false)
+	if err == nil {
+		return "", nil
+	}
+	ruleError := ruleerrors.RuleError{}
+	if !errors.As(err, &ruleError) {
+		return "", err
+	}
+	return ruleErrorName(ruleError), nil
+}
+
+// ruleErrorName returns the name of the rule error, i.e. the message it was
+// created with, without the wrapped inner error
+func ruleErrorName(ruleError ruleerrors.RuleError) string {
+	return strings.SplitN(ruleError.Error(), ":", 2)[0]
+}
+
+func newTestConsensus(params *dagconfig.Params, testName string) (testapi.TestConsensus, func(keepDataDir bool), error) {
+	consensusConfig := &consensus.Config{Params: *params}
+	consensusConfig.SkipProofOfWork = true
+	return consensus.NewFactory().NewTestConsensus(consensusConfig, testName)
+}
+
+func headerToVector(header externalapi.BlockHeader) *Header {
+	parents := make([][]string, len(header.Parents()))
+	for i, blockLevelParents := range header.Parents() {
+		parents[i] = hashesToStrings(blockLevelParents)
+	}
+	return &Header{
+		Version:              header.Version(),
+		Parents:              parents,
+		HashMerkleRoot:       header.HashMerkleRoot().String(),
+		AcceptedIDMerkleRoot: header.AcceptedIDMerkleRoot().String(),
+		UTXOCommitment:       header.UTXOCommitment().String(),
+		TimeInMilliseconds:   header.TimeInMilliseconds(),
+		Bits:                 header.Bits(),
+		Nonce:                header.Nonce(),
+		DAAScore:             header.DAAScore(),
+		BlueScore:            header.BlueScore(),
+		BlueWork:             header.BlueWork().Text(16),
+		PruningPoint:         header.PruningPoint().String(),
+	}
+}
+
+func headerFromVector(header *Header) (externalapi.BlockHeader, error) {
+	parents := make([]externalapi.BlockLevelParents, len(header.Parents))
+	for i, blockLevelParents := range header.Parents {
+		hashes, err := stringsToHashes(blockLevelParents)
+		if err != nil {
+			return nil, err
+		}
+		parents[i] = hashes
+	}
+	hashMerkleRoot, err := externalapi.NewDomainHashFromString(header.HashMerkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	acceptedIDMerkleRoot, err := externalapi.NewDomainHashFromString(header.AcceptedIDMerkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	utxoCommitment, err := externalapi.NewDomainHashFromString(header.UTXOCommitment)
+	if err != nil {
+		return nil, err
+	}
+	blueWork, ok := new(big.Int).SetString(header.BlueWork, 16)
+	if !ok {
+		return nil, errors.Errorf("blue work %q is not a valid hexadecimal number", header.BlueWork)
+	}
+	pruningPoint, err := externalapi.NewDomainHashFromString(header.PruningPoint)
+	if err != nil {
+		return nil, err
+	}
+	return blockheader.NewImmutableBlockHeader(
+		header.Version,
+		parents,
+		hashMerkleRoot,
+		acceptedIDMerkleRoot,
+		utxoCommitment,
+		header.TimeInMilliseconds,
+		header.Bits,
+		header.Nonce,
+		header.DAAScore,
+		header.BlueScore,
+		blueWork,
+		pruningPoint,
+	), nil
+}
@@ -0,0 +1,159 @@
+package testvectors
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/database"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// The stores below implement just enough of their respective interfaces to
+// run GHOSTDAG over a DAG that is fully described by a test vector, without
+// requiring a database or a full consensus instance.
+
+type memoryGHOSTDAGDataStore struct {
+	data map[externalapi.DomainHash]*externalapi.BlockGHOSTDAGData
+}
+
+func (ds *memoryGHOSTDAGDataStore) Stage(_ *model.StagingArea, blockHash *externalapi.DomainHash,
+	blockGHOSTDAGData *externalapi.BlockGHOSTDAGData, _ bool) {
+
+	ds.data[*blockHash] = blockGHOSTDAGData
+}
+
+func (ds *memoryGHOSTDAGDataStore) IsStaged(*model.StagingArea) bool {
+	return false
+}
+
+func (ds *memoryGHOSTDAGDataStore) Get(_ model.DBReader, _ *model.StagingArea, blockHash *externalapi.DomainHash,
+	_ bool) (*externalapi.BlockGHOSTDAGData, error) {
+
+	blockGHOSTDAGData, ok := ds.data[*blockHash]
+	if !ok {
+		return nil, errors.Wrapf(database.ErrNotFound, "GHOSTDAG data for %s is missing", blockHash)
+	}
+	return blockGHOSTDAGData, nil
+}
+
+func (ds *memoryGHOSTDAGDataStore) UnstageAll(*model.StagingArea) {}
+
+type memoryDAGTopologyManager struct {
+	parents map[externalapi.DomainHash][]*externalapi.DomainHash
+}
+
+func (dt *memoryDAGTopologyManager) Parents(_ *model.StagingArea, blockHash *externalapi.DomainHash) (
+	[]*externalapi.DomainHash, error) {
+
+	parents, ok := dt.parents[*blockHash]
+	if !ok {
+		return nil, errors.Wrapf(database.ErrNotFound, "parents of %s are missing", blockHash)
+	}
+	return parents, nil
+}
+
+func (dt *memoryDAGTopologyManager) IsAncestorOf(stagingArea *model.StagingArea, blockHashA *externalapi.DomainHash,
+	blockHashB *externalapi.DomainHash) (bool, error) {
+
+	visited := make(map[externalapi.DomainHash]struct{})
+	queue := []*externalapi.DomainHash{blockHashB}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		parents, err := dt.Parents(stagingArea, current)
+		if err != nil {
+			return false, err
+		}
+		for _, parent := range parents {
+			if parent.Equal(blockHashA) {
+				return true, nil
+			}
+			if _, ok := visited[*parent]; ok {
+				continue
+			}
+			visited[*parent] = struct{}{}
+			queue = append(queue, parent)
+		}
+	}
+	return false, nil
+}
+
+func (dt *memoryDAGTopologyManager) Children(*model.StagingArea, *externalapi.DomainHash) ([]*externalapi.DomainHash, error) {
+	panic("unimplemented")
+}
+
+func (dt *memoryDAGTopologyManager) IsParentOf(*model.StagingArea, *externalapi.DomainHash, *externalapi.DomainHash) (bool, error) {
+	panic("unimplemented")
+}
+
+func (dt *memoryDAGTopologyManager) IsChildOf(*model.StagingArea, *externalapi.DomainHash, *externalapi.DomainHash) (bool, error) {
+	panic("unimplemented")
+}
+
+func (dt *memoryDAGTopologyManager) IsAncestorOfAny(*model.StagingArea, *externalapi.DomainHash, []*externalapi.DomainHash) (bool, error) {
+	panic("unimplemented")
+}
+
+func (dt *memoryDAGTopologyManager) IsAnyAncestorOf(*model.StagingArea, []*externalapi.DomainHash, *externalapi.DomainHash) (bool, error) {
+	panic("unimplemented")
+}
+
+func (dt *memoryDAGTopologyManager) IsInSelectedParentChainOf(*model.StagingArea, *externalapi.DomainHash, *externalapi.DomainHash) (bool, error) {
+	panic("unimplemented")
+}
+
+func (dt *memoryDAGTopologyManager) ChildInSelectedParentChainOf(*model.StagingArea, *externalapi.DomainHash, *externalapi.DomainHash) (*externalapi.DomainHash, error) {
+	panic("unimplemented")
+}
+
+func (dt *memoryDAGTopologyManager) SetParents(*model.StagingArea, *externalapi.DomainHash, []*externalapi.DomainHash) error {
+	panic("unimplemented")
+}
+
+type memoryBlockHeaderStore struct {
+	headers map[externalapi.DomainHash]externalapi.BlockHeader
+}
+
+func (hs *memoryBlockHeaderStore) Stage(_ *model.StagingArea, blockHash *externalapi.DomainHash, blockHeader externalapi.BlockHeader) {
+	hs.headers[*blockHash] = blockHeader
+}
+
+func (hs *memoryBlockHeaderStore) IsStaged(*model.StagingArea) bool {
+	return false
+}
+
+func (hs *memoryBlockHeaderStore) BlockHeader(_ model.DBReader, _ *model.StagingArea, blockHash *externalapi.DomainHash) (
+	externalapi.BlockHeader, error) {
+
+	header, ok := hs.headers[*blockHash]
+	if !ok {
+		return nil, errors.Wrapf(database.ErrNotFound, "header of %s is missing", blockHash)
+	}
+	return header, nil
+}
+
+func (hs *memoryBlockHeaderStore) HasBlockHeader(_ model.DBReader, _ *model.StagingArea, blockHash *externalapi.DomainHash) (bool, error) {
+	_, ok := hs.headers[*blockHash]
+	return ok, nil
+}
+
+func (hs *memoryBlockHeaderStore) BlockHeaders(dbContext model.DBReader, stagingArea *model.StagingArea,
+	blockHashes []*externalapi.DomainHash) ([]externalapi.BlockHeader, error) {
+
+	headers := make([]externalapi.BlockHeader, len(blockHashes))
+	for i, blockHash := range blockHashes {
+		header, err := hs.BlockHeader(dbContext, stagingArea, blockHash)
+		if err != nil {
+			return nil, err
+		}
+		headers[i] = header
+	}
+	return headers, nil
+}
+
+func (hs *memoryBlockHeaderStore) Delete(_ *model.StagingArea, blockHash *externalapi.DomainHash) {
+	delete(hs.headers, *blockHash)
+}
+
+func (hs *memoryBlockHeaderStore) Count(*model.StagingArea) uint64 {
+	return uint64(len(hs.headers))
+}
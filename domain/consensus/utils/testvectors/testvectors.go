@@ -0,0 +1,130 @@
+// Package testvectors exports consensus test vectors from kaspad's own
+// implementation into a JSON format, and verifies a set of vectors against it.
+//
+// The vectors are meant to be consumed by alternative node implementations that
+// wish to verify their consensus rules are compatible with kaspad.
+package testvectors
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// FormatVersion is the version of the vectors JSON format. It should be bumped
+// whenever a backwards-incompatible change is made to the format.
+const FormatVersion = 1
+
+// Vectors is the root object of a test vectors file
+type Vectors struct {
+	FormatVersion    uint32                  `json:"formatVersion"`
+	Network          string                  `json:"network"`
+	HeaderValidation []*HeaderValidationCase `json:"headerValidation"`
+	GHOSTDAG         []*GHOSTDAGCase         `json:"ghostdag"`
+	UTXODiff         []*UTXODiffCase         `json:"utxoDiff"`
+}
+
+// Mismatch describes a single vector whose expected result did not match the
+// result produced by kaspad
+type Mismatch struct {
+	Category    string
+	Description string
+	Details     string
+}
+
+// GenerateOptions controls the amount and shape of the generated vectors
+type GenerateOptions struct {
+	Seed                  int64
+	NumberOfGHOSTDAGCases int
+	NumberOfUTXODiffCases int
+}
+
+// Generate generates a full set of test vectors for the given network
+func Generate(params *dagconfig.Params, options *GenerateOptions) (*Vectors, error) {
+	headerValidationCases, err := GenerateHeaderValidationCases(params)
+	if err != nil {
+		return nil, err
+	}
+	ghostdagCases, err := GenerateGHOSTDAGCases(options.Seed, options.NumberOfGHOSTDAGCases, params.GenesisBlock.Header.Bits())
+	if err != nil {
+		return nil, err
+	}
+	utxoDiffCases, err := GenerateUTXODiffCases(options.Seed, options.NumberOfUTXODiffCases)
+	if err != nil {
+		return nil, err
+	}
+	return &Vectors{
+		FormatVersion:    FormatVersion,
+		Network:          params.Name,
+		HeaderValidation: headerValidationCases,
+		GHOSTDAG:         ghostdagCases,
+		UTXODiff:         utxoDiffCases,
+	}, nil
+}
+
+// Verify runs all the given vectors against kaspad's implementation and
+// returns every vector whose result differs from the expected one. An error
+// is returned only if the vectors themselves are malformed.
+func Verify(params *dagconfig.Params, vectors *Vectors) ([]*Mismatch, error) {
+	if vectors.Network != params.Name {
+		return nil, errors.Errorf("the vectors were generated for network %s, but verification was requested "+
+			"for network %s", vectors.Network, params.Name)
+	}
+
+	var mismatches []*Mismatch
+	for _, headerValidationCase := range vectors.HeaderValidation {
+		caseMismatches, err := verifyHeaderValidationCase(params, headerValidationCase)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed verifying header validation case %q", headerValidationCase.Description)
+		}
+		mismatches = append(mismatches, caseMismatches...)
+	}
+	for _, ghostdagCase := range vectors.GHOSTDAG {
+		caseMismatches, err := verifyGHOSTDAGCase(ghostdagCase)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed verifying GHOSTDAG case %q", ghostdagCase.Description)
+		}
+		mismatches = append(mismatches, caseMismatches...)
+	}
+	for _, utxoDiffCase := range vectors.UTXODiff {
+		caseMismatches, err := verifyUTXODiffCase(utxoDiffCase)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed verifying UTXO diff case %q", utxoDiffCase.Description)
+		}
+		mismatches = append(mismatches, caseMismatches...)
+	}
+	return mismatches, nil
+}
+
+// ReadFile reads and decodes the vectors in the given file
+func ReadFile(path string) (*Vectors, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	vectors := &Vectors{}
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(vectors)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decoding %s", path)
+	}
+	if vectors.FormatVersion != FormatVersion {
+		return nil, errors.Errorf("unsupported test vectors format version %d, expected %d",
+			vectors.FormatVersion, FormatVersion)
+	}
+	return vectors, nil
+}
+
+// WriteFile encodes the given vectors into the given file
+func WriteFile(path string, vectors *Vectors) error {
+	vectorsJSON, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, vectorsJSON, 0644)
+}
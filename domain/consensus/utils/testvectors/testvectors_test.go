@@ -0,0 +1,51 @@
+package testvectors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestGenerateAndVerify(t *testing.T) {
+	params := &dagconfig.SimnetParams
+	vectors, err := Generate(params, &GenerateOptions{
+		Seed:                  1,
+		NumberOfGHOSTDAGCases: 5,
+		NumberOfUTXODiffCases: 50,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %+v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "vectors.json")
+	err = WriteFile(path, vectors)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+	readVectors, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %+v", err)
+	}
+
+	mismatches, err := Verify(params, readVectors)
+	if err != nil {
+		t.Fatalf("Verify: %+v", err)
+	}
+	for _, mismatch := range mismatches {
+		t.Errorf("%s: %s: %s", mismatch.Category, mismatch.Description, mismatch.Details)
+	}
+
+	validHeaderFound := false
+	for _, headerValidationCase := range readVectors.HeaderValidation {
+		if headerValidationCase.ExpectedRuleError == "" {
+			if headerValidationCase.Description != "valid header" {
+				t.Errorf("header validation case %q was unexpectedly valid", headerValidationCase.Description)
+			}
+			validHeaderFound = true
+		}
+	}
+	if !validHeaderFound {
+		t.Errorf("the valid header case was unexpectedly rejected")
+	}
+}
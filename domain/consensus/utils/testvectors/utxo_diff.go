@@ -0,0 +1,264 @@
+package testvectors
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/pkg/errors"
+)
+
+const (
+	// UTXODiffOperationWithDiff denotes applying the other diff on top of the base diff
+	UTXODiffOperationWithDiff = "withDiff"
+
+	// UTXODiffOperationDiffFrom denotes calculating the diff between the base diff and the other diff
+	UTXODiffOperationDiffFrom = "diffFrom"
+)
+
+// UTXODiffCase describes the application of a UTXO diff operation between
+// two UTXO diffs and its expected result
+type UTXODiffCase struct {
+	Description   string    `json:"description"`
+	Operation     string    `json:"operation"`
+	Base          *UTXODiff `json:"base"`
+	Other         *UTXODiff `json:"other"`
+	ExpectedError bool      `json:"expectedError"`
+	Expected      *UTXODiff `json:"expected,omitempty"`
+}
+
+// UTXODiff is the test vector representation of a UTXO diff. Entries are
+// sorted by outpoint
+type UTXODiff struct {
+	ToAdd    []*UTXOEntry `json:"toAdd"`
+	ToRemove []*UTXOEntry `json:"toRemove"`
+}
+
+// UTXOEntry is the test vector representation of a UTXO entry and its outpoint
+type UTXOEntry struct {
+	TransactionID          string `json:"transactionId"`
+	Index                  uint32 `json:"index"`
+	Amount                 uint64 `json:"amount"`
+	ScriptPublicKey        string `json:"scriptPublicKey"`
+	ScriptPublicKeyVersion uint16 `json:"scriptPublicKeyVersion"`
+	IsCoinbase             bool   `json:"isCoinbase"`
+	BlockDAAScore          uint64 `json:"blockDaaScore"`
+}
+
+// GenerateUTXODiffCases generates numberOfCases random UTXO diff operations
+// using the given seed, and records the result kaspad produces for each
+func GenerateUTXODiffCases(seed int64, numberOfCases int) ([]*UTXODiffCase, error) {
+	random := rand.New(rand.NewSource(seed))
+
+	// A small pool of outpoints and DAA scores is used so that the generated
+	// diffs frequently intersect, which is where most of the diff algebra
+	// rules come into play
+	const outpointPoolSize = 4
+	outpoints := make([]*externalapi.DomainOutpoint, outpointPoolSize)
+	for i := range outpoints {
+		var transactionID [externalapi.DomainHashSize]byte
+		random.Read(transactionID[:])
+		outpoints[i] = &externalapi.DomainOutpoint{
+			TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&transactionID),
+			Index:         uint32(random.Intn(3)),
+		}
+	}
+
+	cases := make([]*UTXODiffCase, 0, numberOfCases)
+	for i := 0; i < numberOfCases; i++ {
+		base := randomUTXODiff(random, outpoints, nil)
+		other := randomUTXODiff(random, outpoints, base)
+		operation := UTXODiffOperationWithDiff
+		if random.Intn(2) == 0 {
+			operation = UTXODiffOperationDiffFrom
+		}
+
+		utxoDiffCase := &UTXODiffCase{
+			Description: fmt.Sprintf("random %s #%d (seed %d)", operation, i, seed),
+			Operation:   operation,
+			Base:        utxoDiffToVector(base),
+			Other:       utxoDiffToVector(other),
+		}
+		result, err := applyUTXODiffOperation(operation, base, other)
+		if err != nil {
+			utxoDiffCase.ExpectedError = true
+		} else {
+			utxoDiffCase.Expected = utxoDiffToVector(result)
+		}
+		cases = append(cases, utxoDiffCase)
+	}
+	return cases, nil
+}
+
+// randomUTXODiff generates a random valid UTXO diff over the given outpoints.
+// If a base diff is given, its entries are frequently reused, so that the
+// generated diff interacts with it rather than merely conflicting with it.
+func randomUTXODiff(random *rand.Rand, outpoints []*externalapi.DomainOutpoint,
+	base externalapi.UTXODiff) externalapi.UTXODiff {
+
+	entryFor := func(outpoint *externalapi.DomainOutpoint) externalapi.UTXOEntry {
+		if base != nil && random.Intn(2) == 0 {
+			if entry, ok := base.ToAdd().Get(outpoint); ok {
+				return entry
+			}
+			if entry, ok := base.ToRemove().Get(outpoint); ok {
+				return entry
+			}
+		}
+		return randomUTXOEntry(random)
+	}
+
+	for {
+		toAdd := make(map[externalapi.DomainOutpoint]externalapi.UTXOEntry)
+		toRemove := make(map[externalapi.DomainOutpoint]externalapi.UTXOEntry)
+		for _, outpoint := range outpoints {
+			switch random.Intn(16) {
+			case 0, 1, 2:
+				toAdd[*outpoint] = entryFor(outpoint)
+			case 3, 4, 5:
+				toRemove[*outpoint] = entryFor(outpoint)
+			case 6:
+				toAdd[*outpoint] = entryFor(outpoint)
+				toRemove[*outpoint] = entryFor(outpoint)
+			}
+		}
+		diff, err := utxo.NewUTXODiffFromCollections(utxo.NewUTXOCollection(toAdd), utxo.NewUTXOCollection(toRemove))
+		if err != nil {
+			// The randomly chosen collections do not form a valid diff. Try again.
+			continue
+		}
+		return diff
+	}
+}
+
+func randomUTXOEntry(random *rand.Rand) externalapi.UTXOEntry {
+	script := make([]byte, 1+random.Intn(4))
+	random.Read(script)
+	return utxo.NewUTXOEntry(
+		uint64(1+random.Intn(1000)),
+		&externalapi.ScriptPublicKey{Script: script, Version: 0},
+		random.Intn(5) == 0,
+		uint64(1+random.Intn(2)),
+	)
+}
+
+func applyUTXODiffOperation(operation string, base, other externalapi.UTXODiff) (externalapi.UTXODiff, error) {
+	switch operation {
+	case UTXODiffOperationWithDiff:
+		return base.WithDiff(other)
+	case UTXODiffOperationDiffFrom:
+		return base.DiffFrom(other)
+	default:
+		return nil, errors.Errorf("unknown UTXO diff operation %q", operation)
+	}
+}
+
+func verifyUTXODiffCase(utxoDiffCase *UTXODiffCase) ([]*Mismatch, error) {
+	base, err := utxoDiffFromVector(utxoDiffCase.Base)
+	if err != nil {
+		return nil, err
+	}
+	other, err := utxoDiffFromVector(utxoDiffCase.Other)
+	if err != nil {
+		return nil, err
+	}
+	if utxoDiffCase.Operation != UTXODiffOperationWithDiff && utxoDiffCase.Operation != UTXODiffOperationDiffFrom {
+		return nil, errors.Errorf("unknown UTXO diff operation %q", utxoDiffCase.Operation)
+	}
+
+	mismatch := func(format string, args ...interface{}) []*Mismatch {
+		return []*Mismatch{{
+			Category:    "utxoDiff",
+			Description: utxoDiffCase.Description,
+			Details:     fmt.Sprintf(format, args...),
+		}}
+	}
+	result, err := applyUTXODiffOperation(utxoDiffCase.Operation, base, other)
+	if err != nil {
+		if !utxoDiffCase.ExpectedError {
+			return mismatch("unexpected error: %s", err), nil
+		}
+		return nil, nil
+	}
+	if utxoDiffCase.ExpectedError {
+		return mismatch("expected an error but got none"), nil
+	}
+	resultVector := utxoDiffToVector(result)
+	if !reflect.DeepEqual(resultVector, utxoDiffCase.Expected) {
+		return mismatch("expected diff %+v but got %+v", utxoDiffCase.Expected, resultVector), nil
+	}
+	return nil, nil
+}
+
+func utxoDiffToVector(diff externalapi.UTXODiff) *UTXODiff {
+	return &UTXODiff{
+		ToAdd:    utxoCollectionToVector(diff.ToAdd()),
+		ToRemove: utxoCollectionToVector(diff.ToRemove()),
+	}
+}
+
+func utxoCollectionToVector(collection externalapi.UTXOCollection) []*UTXOEntry {
+	entries := make([]*UTXOEntry, 0, collection.Len())
+	iterator := collection.Iterator()
+	defer iterator.Close()
+	for ok := iterator.First(); ok; ok = iterator.Next() {
+		outpoint, entry, err := iterator.Get()
+		if err != nil {
+			panic(err)
+		}
+		entries = append(entries, &UTXOEntry{
+			TransactionID:          outpoint.TransactionID.String(),
+			Index:                  outpoint.Index,
+			Amount:                 entry.Amount(),
+			ScriptPublicKey:        hex.EncodeToString(entry.ScriptPublicKey().Script),
+			ScriptPublicKeyVersion: entry.ScriptPublicKey().Version,
+			IsCoinbase:             entry.IsCoinbase(),
+			BlockDAAScore:          entry.BlockDAAScore(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].TransactionID != entries[j].TransactionID {
+			return entries[i].TransactionID < entries[j].TransactionID
+		}
+		return entries[i].Index < entries[j].Index
+	})
+	return entries
+}
+
+func utxoDiffFromVector(diffVector *UTXODiff) (externalapi.UTXODiff, error) {
+	toAdd, err := utxoCollectionFromVector(diffVector.ToAdd)
+	if err != nil {
+		return nil, err
+	}
+	toRemove, err := utxoCollectionFromVector(diffVector.ToRemove)
+	if err != nil {
+		return nil, err
+	}
+	return utxo.NewUTXODiffFromCollections(toAdd, toRemove)
+}
+
+func utxoCollectionFromVector(entries []*UTXOEntry) (externalapi.UTXOCollection, error) {
+	utxoMap := make(map[externalapi.DomainOutpoint]externalapi.UTXOEntry, len(entries))
+	for _, entry := range entries {
+		transactionID, err := externalapi.NewDomainTransactionIDFromString(entry.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		script, err := hex.DecodeString(entry.ScriptPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		outpoint := externalapi.DomainOutpoint{TransactionID: *transactionID, Index: entry.Index}
+		utxoMap[outpoint] = utxo.NewUTXOEntry(
+			entry.Amount,
+			&externalapi.ScriptPublicKey{Script: script, Version: entry.ScriptPublicKeyVersion},
+			entry.IsCoinbase,
+			entry.BlockDAAScore,
+		)
+	}
+	return utxo.NewUTXOCollection(utxoMap), nil
+}
@@ -198,6 +198,27 @@ func DisasmString(version uint16, buf []byte) (string, error) {
 	return "", scriptError(ErrPubKeyFormat, "the version of the scriptPublicHash is higher then the known version")
 }
 
+// HasCanonicalPushes returns whether or not the passed script parses and only
+// contains data pushes that use the smallest possible encoding. Unlike the
+// script engine, which only requires minimal encoding of the pushes it
+// executes, this also covers the pushes in branches that are not executed.
+func HasCanonicalPushes(script []byte) bool {
+	pops, err := parseScript(script)
+	if err != nil {
+		return false
+	}
+
+	for _, pop := range pops {
+		if pop.opcode.value == Op0 || pop.opcode.value > OpPushData4 {
+			continue
+		}
+		if err := pop.checkMinimalDataPush(); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // canonicalPush returns true if the object is either not a push instruction
 // or the push instruction contained wherein is matches the canonical form
 // or using the smallest instruction to do the job. False otherwise.
@@ -3674,6 +3674,11 @@ func TestHasCanonicalPushes(t *testing.T) {
 
 	for i, test := range tests {
 		script := mustParseShortForm(test.script, 0)
+		if HasCanonicalPushes(script) != test.expected {
+			t.Errorf("HasCanonicalPushes: #%d (%s) wrong result"+
+				"\ngot: %v\nwant: %v", i, test.name,
+				!test.expected, test.expected)
+		}
 		pops, err := parseScript(script)
 		if err != nil {
 			if test.expected {
@@ -3690,6 +3695,16 @@ func TestHasCanonicalPushes(t *testing.T) {
 			}
 		}
 	}
+
+	// The pushes of branches that aren't executed must be canonical as well
+	unexecutedBranchScript := mustParseShortForm("0 IF PUSHDATA1 0x04 0x01020304 ENDIF 1", 0)
+	if HasCanonicalPushes(unexecutedBranchScript) {
+		t.Errorf("HasCanonicalPushes: expected a non-canonical push in an unexecuted branch to be detected")
+	}
+	canonicalScript := mustParseShortForm("0 IF DATA_4 0x01020304 ENDIF 16 DATA_1 0x11 -1", 0)
+	if !HasCanonicalPushes(canonicalScript) {
+		t.Errorf("HasCanonicalPushes: expected a script of canonical pushes to be canonical")
+	}
 }
 
 // TestIsPushOnly ensures the isPushOnly function returns the
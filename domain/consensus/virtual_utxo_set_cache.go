@@ -0,0 +1,99 @@
+package consensus
+
+import (
+	"container/heap"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// warmUpChunkSize is the amount of virtual UTXOs read while holding the
+// consensus lock when looking for the ones to warm up the cache with
+const warmUpChunkSize = 1000
+
+// WarmUpVirtualUTXOSetCache loads the virtual UTXOs that were created most
+// recently, by DAA score, into the virtual UTXO set cache, as many as it
+// fits. These are the UTXOs the transactions of the next blocks are the most
+// likely to spend. The virtual UTXO set is scanned in chunks so that blocks
+// may be processed meanwhile. It returns the amount of UTXOs loaded.
+func (s *consensus) WarmUpVirtualUTXOSetCache() (int, error) {
+	newestUTXOs := &newestUTXOsHeap{}
+	var fromOutpoint *externalapi.DomainOutpoint
+	for {
+		chunk, err := s.virtualUTXOsChunk(fromOutpoint)
+		if err != nil {
+			return 0, err
+		}
+		isLastChunk := len(chunk) < warmUpChunkSize
+
+		// The chunk starts from fromOutpoint itself, unless it was spent meanwhile
+		if fromOutpoint != nil && len(chunk) > 0 && chunk[0].Outpoint.Equal(fromOutpoint) {
+			chunk = chunk[1:]
+		}
+		for _, pair := range chunk {
+			if newestUTXOs.Len() < s.virtualUTXOSetCacheSize {
+				heap.Push(newestUTXOs, pair)
+				continue
+			}
+			if pair.UTXOEntry.BlockDAAScore() > (*newestUTXOs)[0].UTXOEntry.BlockDAAScore() {
+				(*newestUTXOs)[0] = pair
+				heap.Fix(newestUTXOs, 0)
+			}
+		}
+
+		if isLastChunk || len(chunk) == 0 {
+			break
+		}
+		fromOutpoint = chunk[len(chunk)-1].Outpoint
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+	loaded := 0
+	for _, pair := range *newestUTXOs {
+		// Reading a UTXO adds it to the cache. UTXOs that were spent since the
+		// scan are skipped.
+		_, err := s.consensusStateStore.UTXOByOutpoint(s.databaseContext, stagingArea, pair.Outpoint)
+		if database.IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		loaded++
+	}
+	return loaded, nil
+}
+
+func (s *consensus) virtualUTXOsChunk(fromOutpoint *externalapi.DomainOutpoint) (
+	[]*externalapi.OutpointAndUTXOEntryPair, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.consensusStateStore.VirtualUTXOs(s.databaseContext, fromOutpoint, warmUpChunkSize)
+}
+
+// newestUTXOsHeap is a min-heap of UTXOs by their DAA score, so that the
+// oldest of the newest UTXOs found so far is the one to replace
+type newestUTXOsHeap []*externalapi.OutpointAndUTXOEntryPair
+
+func (h newestUTXOsHeap) Len() int { return len(h) }
+func (h newestUTXOsHeap) Less(i, j int) bool {
+	return h[i].UTXOEntry.BlockDAAScore() < h[j].UTXOEntry.BlockDAAScore()
+}
+func (h newestUTXOsHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *newestUTXOsHeap) Push(x interface{}) {
+	*h = append(*h, x.(*externalapi.OutpointAndUTXOEntryPair))
+}
+
+func (h *newestUTXOsHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
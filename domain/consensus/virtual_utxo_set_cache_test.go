@@ -0,0 +1,49 @@
+package consensus_test
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+)
+
+func TestWarmUpVirtualUTXOSetCache(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestWarmUpVirtualUTXOSetCache")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		tipHash := consensusConfig.GenesisHash
+		for i := 0; i < 5; i++ {
+			tipHash, _, err = tc.AddBlock([]*externalapi.DomainHash{tipHash}, nil, nil)
+			if err != nil {
+				t.Fatalf("AddBlock: %+v", err)
+			}
+		}
+
+		virtualInfo, err := tc.GetVirtualInfo()
+		if err != nil {
+			t.Fatalf("GetVirtualInfo: %+v", err)
+		}
+		virtualUTXOs, err := tc.GetVirtualUTXOs(virtualInfo.ParentHashes, nil, 1000)
+		if err != nil {
+			t.Fatalf("GetVirtualUTXOs: %+v", err)
+		}
+		if len(virtualUTXOs) == 0 {
+			t.Fatalf("Expected the virtual UTXO set not to be empty")
+		}
+
+		loaded, err := tc.WarmUpVirtualUTXOSetCache()
+		if err != nil {
+			t.Fatalf("WarmUpVirtualUTXOSetCache: %+v", err)
+		}
+		if loaded != len(virtualUTXOs) {
+			t.Fatalf("Expected all the %d virtual UTXOs to fit in the cache, but %d were loaded",
+				len(virtualUTXOs), loaded)
+		}
+	})
+}
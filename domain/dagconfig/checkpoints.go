@@ -0,0 +1,53 @@
+package dagconfig
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+)
+
+// Checkpoint commits to the hash of the pruning point with the given index.
+// Pruning point indexes start from the genesis, which has index 0.
+//
+// Checkpoints are embedded in each release by the maintainers (see
+// cmd/updatecheckpoints), and protect nodes that sync from scratch from
+// being led onto long-range fake chains. No checkpoints were generated for
+// mainnet and testnet yet, so until they are, nodes on these networks aren't
+// protected by them.
+type Checkpoint struct {
+	PruningPointIndex uint64
+	Hash              *externalapi.DomainHash
+}
+
+// ErrCheckpointContradiction indicates that a list of pruning points
+// contradicts one of the network's checkpoints
+var ErrCheckpointContradiction = errors.New("checkpoint contradiction")
+
+// VerifyCheckpoints returns an ErrCheckpointContradiction if the given pruning
+// point headers, ordered by their index, contradict any of the network's
+// checkpoints. Checkpoints with an index beyond the given list are ignored.
+func (p *Params) VerifyCheckpoints(pruningPointHeaders []externalapi.BlockHeader) error {
+	for _, checkpoint := range p.Checkpoints {
+		if checkpoint.PruningPointIndex >= uint64(len(pruningPointHeaders)) {
+			continue
+		}
+		pruningPointHash := consensushashing.HeaderHash(pruningPointHeaders[checkpoint.PruningPointIndex])
+		if !pruningPointHash.Equal(checkpoint.Hash) {
+			return errors.Wrapf(ErrCheckpointContradiction, "pruning point #%d is %s, while the "+
+				"checkpoint of network %s commits to %s", checkpoint.PruningPointIndex, pruningPointHash,
+				p.Name, checkpoint.Hash)
+		}
+	}
+	return nil
+}
+
+func newCheckpoint(pruningPointIndex uint64, hashString string) Checkpoint {
+	hash, err := externalapi.NewDomainHashFromString(hashString)
+	if err != nil {
+		panic(err)
+	}
+	return Checkpoint{
+		PruningPointIndex: pruningPointIndex,
+		Hash:              hash,
+	}
+}
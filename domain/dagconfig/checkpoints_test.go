@@ -0,0 +1,61 @@
+package dagconfig
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+)
+
+func TestVerifyCheckpoints(t *testing.T) {
+	pruningPointHeaders := make([]externalapi.BlockHeader, 3)
+	for i := range pruningPointHeaders {
+		pruningPointHeaders[i] = blockheader.NewImmutableBlockHeader(0, nil, &externalapi.DomainHash{},
+			&externalapi.DomainHash{}, &externalapi.DomainHash{}, int64(i), 0, 0, 0, 0, big.NewInt(0), &externalapi.DomainHash{})
+	}
+	otherHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+
+	tests := []struct {
+		name          string
+		checkpoints   []Checkpoint
+		expectedError error
+	}{
+		{
+			name:        "no checkpoints",
+			checkpoints: nil,
+		},
+		{
+			name: "matching checkpoints",
+			checkpoints: []Checkpoint{
+				{PruningPointIndex: 1, Hash: consensushashing.HeaderHash(pruningPointHeaders[1])},
+				{PruningPointIndex: 2, Hash: consensushashing.HeaderHash(pruningPointHeaders[2])},
+			},
+		},
+		{
+			name: "checkpoint beyond the pruning points",
+			checkpoints: []Checkpoint{
+				{PruningPointIndex: 2, Hash: consensushashing.HeaderHash(pruningPointHeaders[2])},
+				{PruningPointIndex: 3, Hash: otherHash},
+			},
+		},
+		{
+			name: "contradicted checkpoint",
+			checkpoints: []Checkpoint{
+				{PruningPointIndex: 1, Hash: consensushashing.HeaderHash(pruningPointHeaders[1])},
+				{PruningPointIndex: 2, Hash: otherHash},
+			},
+			expectedError: ErrCheckpointContradiction,
+		},
+	}
+
+	for _, test := range tests {
+		params := &Params{Name: "test", Checkpoints: test.checkpoints}
+		err := params.VerifyCheckpoints(pruningPointHeaders)
+		if !errors.Is(err, test.expectedError) {
+			t.Errorf("%s: expected error %v but got %v", test.name, test.expectedError, err)
+		}
+	}
+}
@@ -63,6 +63,9 @@ type Params struct {
 	// RPCPort defines the rpc server port
 	RPCPort string
 
+	// RPCWebSocketPort defines the port of the WebSocket RPC server
+	RPCWebSocketPort string
+
 	// DefaultPort defines the default peer-to-peer port for the network.
 	DefaultPort string
 
@@ -129,6 +132,17 @@ type Params struct {
 	// Mempool parameters
 	RelayNonStdTxs bool
 
+	// RequireMinimalPushes specifies whether the mempool rejects transactions
+	// whose scripts push data with anything but the smallest possible encoding,
+	// including the pushes in branches of pay-to-script-hash scripts that are
+	// not executed
+	RequireMinimalPushes bool
+
+	// RequireCanonicalSignatures specifies whether the mempool rejects
+	// transactions spending pay-to-pubkey outputs with signatures that aren't
+	// canonically encoded
+	RequireCanonicalSignatures bool
+
 	// AcceptUnroutable specifies whether this network accepts unroutable
 	// IP addresses, such as 10.0.0.0/8
 	AcceptUnroutable bool
@@ -188,6 +202,11 @@ type Params struct {
 	MaxBlockLevel int
 
 	MergeDepth uint64
+
+	// Checkpoints are commitments to recent pruning points of the network.
+	// A node whose pruning points contradict them is on a different chain
+	// than the one the release was made with.
+	Checkpoints []Checkpoint
 }
 
 // NormalizeRPCServerAddress returns addr with the current network default
@@ -208,11 +227,12 @@ func (p *Params) PruningDepth() uint64 {
 
 // MainnetParams defines the network parameters for the main Kaspa network.
 var MainnetParams = Params{
-	K:           defaultGHOSTDAGK,
-	Name:        "kaspa-mainnet",
-	Net:         appmessage.Mainnet,
-	RPCPort:     "16110",
-	DefaultPort: "16111",
+	K:                defaultGHOSTDAGK,
+	Name:             "kaspa-mainnet",
+	Net:              appmessage.Mainnet,
+	RPCPort:          "16110",
+	RPCWebSocketPort: "18110",
+	DefaultPort:      "16111",
 	DNSSeeds: []string{
 		// This DNS seeder is run by Wolfie
 		"mainnet-dnsseed.kas.pa",
@@ -255,7 +275,9 @@ var MainnetParams = Params{
 	MinerConfirmationWindow:       2016, //
 
 	// Mempool parameters
-	RelayNonStdTxs: false,
+	RelayNonStdTxs:             false,
+	RequireMinimalPushes:       true,
+	RequireCanonicalSignatures: true,
 
 	// AcceptUnroutable specifies whether this network accepts unroutable
 	// IP addresses, such as 10.0.0.0/8
@@ -288,15 +310,18 @@ var MainnetParams = Params{
 	// This means that any block that has a level lower or equal to genesis will be level 0.
 	MaxBlockLevel: 225,
 	MergeDepth:    defaultMergeDepth,
+
+	Checkpoints: mainnetCheckpoints,
 }
 
 // TestnetParams defines the network parameters for the test Kaspa network.
 var TestnetParams = Params{
-	K:           defaultGHOSTDAGK,
-	Name:        "kaspa-testnet-10",
-	Net:         appmessage.Testnet,
-	RPCPort:     "16210",
-	DefaultPort: "16211",
+	K:                defaultGHOSTDAGK,
+	Name:             "kaspa-testnet-10",
+	Net:              appmessage.Testnet,
+	RPCPort:          "16210",
+	RPCWebSocketPort: "18210",
+	DefaultPort:      "16211",
 	DNSSeeds: []string{
 		"testnet-10-dnsseed.kas.pa",
 		// This DNS seeder is run by Tiram
@@ -324,7 +349,9 @@ var TestnetParams = Params{
 	MinerConfirmationWindow:       2016,
 
 	// Mempool parameters
-	RelayNonStdTxs: false,
+	RelayNonStdTxs:             false,
+	RequireMinimalPushes:       true,
+	RequireCanonicalSignatures: true,
 
 	// AcceptUnroutable specifies whether this network accepts unroutable
 	// IP addresses, such as 10.0.0.0/8
@@ -354,6 +381,8 @@ var TestnetParams = Params{
 
 	MaxBlockLevel: 250,
 	MergeDepth:    defaultMergeDepth,
+
+	Checkpoints: testnetCheckpoints,
 }
 
 // SimnetParams defines the network parameters for the simulation test Kaspa
@@ -364,12 +393,13 @@ var TestnetParams = Params{
 // following normal discovery rules. This is important as otherwise it would
 // just turn into another public testnet.
 var SimnetParams = Params{
-	K:           defaultGHOSTDAGK,
-	Name:        "kaspa-simnet",
-	Net:         appmessage.Simnet,
-	RPCPort:     "16510",
-	DefaultPort: "16511",
-	DNSSeeds:    []string{}, // NOTE: There must NOT be any seeds.
+	K:                defaultGHOSTDAGK,
+	Name:             "kaspa-simnet",
+	Net:              appmessage.Simnet,
+	RPCPort:          "16510",
+	RPCWebSocketPort: "18510",
+	DefaultPort:      "16511",
+	DNSSeeds:         []string{}, // NOTE: There must NOT be any seeds.
 
 	// DAG parameters
 	GenesisBlock:                    &simnetGenesisBlock,
@@ -392,7 +422,11 @@ var SimnetParams = Params{
 	MinerConfirmationWindow:       100,
 
 	// Mempool parameters
-	RelayNonStdTxs: false,
+	// Non-standard transactions are relayed by default so that
+	// protocol experiments don't require patched binaries
+	RelayNonStdTxs:             true,
+	RequireMinimalPushes:       false,
+	RequireCanonicalSignatures: false,
 
 	// AcceptUnroutable specifies whether this network accepts unroutable
 	// IP addresses, such as 10.0.0.0/8
@@ -424,12 +458,13 @@ var SimnetParams = Params{
 
 // DevnetParams defines the network parameters for the development Kaspa network.
 var DevnetParams = Params{
-	K:           defaultGHOSTDAGK,
-	Name:        "kaspa-devnet",
-	Net:         appmessage.Devnet,
-	RPCPort:     "16610",
-	DefaultPort: "16611",
-	DNSSeeds:    []string{}, // NOTE: There must NOT be any seeds.
+	K:                defaultGHOSTDAGK,
+	Name:             "kaspa-devnet",
+	Net:              appmessage.Devnet,
+	RPCPort:          "16610",
+	RPCWebSocketPort: "18610",
+	DefaultPort:      "16611",
+	DNSSeeds:         []string{}, // NOTE: There must NOT be any seeds.
 
 	// DAG parameters
 	GenesisBlock:                    &devnetGenesisBlock,
@@ -452,7 +487,11 @@ var DevnetParams = Params{
 	MinerConfirmationWindow:       2016,
 
 	// Mempool parameters
-	RelayNonStdTxs: false,
+	// Non-standard transactions are relayed by default so that
+	// protocol experiments don't require patched binaries
+	RelayNonStdTxs:             true,
+	RequireMinimalPushes:       false,
+	RequireCanonicalSignatures: false,
 
 	// AcceptUnroutable specifies whether this network accepts unroutable
 	// IP addresses, such as 10.0.0.0/8
@@ -0,0 +1,191 @@
+package feehistoryindex
+
+import (
+	"math"
+	"sort"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/domain/indexsync"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// FeeHistoryIndex maintains the fee rate percentiles of the transactions
+// accepted by every block in the virtual selected parent chain
+type FeeHistoryIndex struct {
+	domain domain.Domain
+	store  *feeHistoryIndexStore
+	syncer *indexsync.Syncer
+}
+
+// New creates a new fee history index.
+//
+// Blocks may be added to the consensus while this is called, since the index
+// catches up with the chain changes it misses meanwhile on the next Update.
+func New(domain domain.Domain, database database.Database) (*FeeHistoryIndex, error) {
+	feeHistoryIndex := &FeeHistoryIndex{
+		domain: domain,
+		store:  newFeeHistoryIndexStore(database),
+	}
+	feeHistoryIndex.syncer = indexsync.New("fee history index", domain, database, virtualSelectedParentKey, &indexsync.Callbacks{
+		Reset:            feeHistoryIndex.reset,
+		RemoveChainBlock: feeHistoryIndex.removeChainBlock,
+		AddChainBlock:    feeHistoryIndex.addChainBlock,
+	})
+
+	err := feeHistoryIndex.syncer.CatchUp()
+	if err != nil {
+		return nil, err
+	}
+	return feeHistoryIndex, nil
+}
+
+// Reset deletes the whole fee history index and resyncs it from the pruning
+// point. Chain blocks below the pruning point are not indexed.
+func (fhi *FeeHistoryIndex) Reset() error {
+	return fhi.syncer.Reset()
+}
+
+// Update updates the fee history index with the given DAG selected parent chain changes
+func (fhi *FeeHistoryIndex) Update(chainChanges *externalapi.SelectedChainPath) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "FeeHistoryIndex.Update")
+	defer onEnd()
+
+	return fhi.syncer.Update(chainChanges)
+}
+
+func (fhi *FeeHistoryIndex) reset(_ *externalapi.DomainHash) error {
+	return fhi.store.deleteAll()
+}
+
+func (fhi *FeeHistoryIndex) removeChainBlock(dbTransaction database.Transaction,
+	blockHash *externalapi.DomainHash, blueScore uint64) error {
+
+	log.Tracef("Removing the fee rates of chain block %s from the fee history index", blockHash)
+	return fhi.store.removeChainBlockFeeRates(dbTransaction, blockHash, blueScore)
+}
+
+func (fhi *FeeHistoryIndex) addChainBlock(dbTransaction database.Transaction, blockHash *externalapi.DomainHash,
+	header externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error {
+
+	feeRates := fhi.acceptedFeeRates(acceptanceData)
+	log.Tracef("Adding the fee rates of %d transactions accepted by chain block %s to the fee history index",
+		len(feeRates), blockHash)
+	return fhi.store.addChainBlockFeeRates(dbTransaction, &ChainBlockFeeRates{
+		BlockHash:          blockHash,
+		BlueScore:          header.BlueScore(),
+		DAAScore:           header.DAAScore(),
+		TimeInMilliseconds: header.TimeInMilliseconds(),
+		TransactionCount:   uint64(len(feeRates)),
+		FeeRatePercentiles: feeRatePercentiles(feeRates),
+	})
+}
+
+// acceptedFeeRates returns the fee rates of the non-coinbase
+// transactions accepted in the given acceptance data
+func (fhi *FeeHistoryIndex) acceptedFeeRates(acceptanceData externalapi.AcceptanceData) []float64 {
+	feeRates := make([]float64, 0)
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			transaction := transactionAcceptanceData.Transaction
+			if !transactionAcceptanceData.IsAccepted || transactionhelper.IsCoinBase(transaction) {
+				continue
+			}
+			// The mass of transactions isn't stored along with the blocks that contain them
+			fhi.domain.Consensus().PopulateMass(transaction)
+			feeRates = append(feeRates, float64(transactionAcceptanceData.Fee)/float64(transaction.Mass))
+		}
+	}
+	return feeRates
+}
+
+// feeRatePercentiles returns the fee rate at each of Percentiles using the
+// nearest-rank method, or all zeros if there are no fee rates
+func feeRatePercentiles(feeRates []float64) []float64 {
+	percentiles := make([]float64, len(Percentiles))
+	if len(feeRates) == 0 {
+		return percentiles
+	}
+
+	sort.Float64s(feeRates)
+	for i, percentile := range Percentiles {
+		rank := int(math.Ceil(float64(percentile) / 100 * float64(len(feeRates))))
+		if rank < 1 {
+			rank = 1
+		}
+		percentiles[i] = feeRates[rank-1]
+	}
+	return percentiles
+}
+
+// FeeHistory returns the fee rates of up to the last windowSize indexed
+// chain blocks, ordered from the oldest to the virtual selected parent. Fewer
+// chain blocks are returned if the index had started after them.
+func (fhi *FeeHistoryIndex) FeeHistory(windowSize int) ([]*ChainBlockFeeRates, error) {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "FeeHistoryIndex.FeeHistory")
+	defer onEnd()
+
+	fhi.syncer.Lock()
+	defer fhi.syncer.Unlock()
+
+	blockHash, err := fhi.syncer.VirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*ChainBlockFeeRates, 0, windowSize)
+	for len(history) < windowSize {
+		feeRates, found, err := fhi.store.getChainBlockFeeRates(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			break
+		}
+		history = append(history, feeRates)
+
+		blockInfo, err := fhi.domain.Consensus().GetBlockInfo(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		blockHash = blockInfo.SelectedParent
+	}
+
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+	return history, nil
+}
+
+// ChainBlockTracker returns the tracker of the chain blocks the fee history index holds fee rates of
+func (fhi *FeeHistoryIndex) ChainBlockTracker() *indexretention.ChainBlockTracker {
+	return fhi.store.chainBlockTracker
+}
+
+// VirtualSelectedParentBlueScore returns the blue score of the
+// virtual selected parent the fee history index is synced with
+func (fhi *FeeHistoryIndex) VirtualSelectedParentBlueScore() (uint64, error) {
+	return fhi.syncer.VirtualSelectedParentBlueScore()
+}
+
+// PruneChainBlocks removes the fee rates of the given chain blocks from the fee history index
+func (fhi *FeeHistoryIndex) PruneChainBlocks(chainBlocks []*indexretention.TrackedChainBlock) error {
+	fhi.syncer.Lock()
+	defer fhi.syncer.Unlock()
+
+	dbTransaction, err := fhi.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, chainBlock := range chainBlocks {
+		err := fhi.store.removeChainBlockFeeRates(dbTransaction, chainBlock.BlockHash, chainBlock.BlueScore)
+		if err != nil {
+			return err
+		}
+	}
+	return dbTransaction.Commit()
+}
@@ -0,0 +1,7 @@
+package feehistoryindex
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("FHIN")
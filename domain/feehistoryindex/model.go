@@ -0,0 +1,25 @@
+package feehistoryindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// Percentiles are the percentiles of the fee rates of the transactions
+// accepted by every chain block that are recorded in the index
+var Percentiles = []uint32{10, 25, 50, 75, 90}
+
+// ChainBlockFeeRates are the fee rates, in sompi per gram of mass, of the
+// transactions accepted by a single chain block
+type ChainBlockFeeRates struct {
+	BlockHash          *externalapi.DomainHash
+	BlueScore          uint64
+	DAAScore           uint64
+	TimeInMilliseconds int64
+
+	// TransactionCount is the amount of non-coinbase transactions accepted by
+	// the chain block. FeeRatePercentiles are all zero if it's zero.
+	TransactionCount uint64
+
+	// FeeRatePercentiles holds the fee rate at each of Percentiles
+	FeeRatePercentiles []float64
+}
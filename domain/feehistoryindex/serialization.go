@@ -0,0 +1,48 @@
+package feehistoryindex
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+const uint64Size = 8
+
+// chainBlockFeeRatesSize is the size of serialized chain block fee rates: the
+// blue score, DAA score, timestamp and transaction count followed by a fee
+// rate for every one of Percentiles
+var chainBlockFeeRatesSize = (4 + len(Percentiles)) * uint64Size
+
+// serializeChainBlockFeeRates serializes the given fee rates. The block hash
+// is not serialized, since it's the key the fee rates are stored by.
+func serializeChainBlockFeeRates(feeRates *ChainBlockFeeRates) []byte {
+	serializedFeeRates := make([]byte, chainBlockFeeRatesSize)
+	binary.LittleEndian.PutUint64(serializedFeeRates[0:], feeRates.BlueScore)
+	binary.LittleEndian.PutUint64(serializedFeeRates[uint64Size:], feeRates.DAAScore)
+	binary.LittleEndian.PutUint64(serializedFeeRates[2*uint64Size:], uint64(feeRates.TimeInMilliseconds))
+	binary.LittleEndian.PutUint64(serializedFeeRates[3*uint64Size:], feeRates.TransactionCount)
+	for i, feeRate := range feeRates.FeeRatePercentiles {
+		binary.LittleEndian.PutUint64(serializedFeeRates[(4+i)*uint64Size:], math.Float64bits(feeRate))
+	}
+	return serializedFeeRates
+}
+
+func deserializeChainBlockFeeRates(serializedFeeRates []byte) (*ChainBlockFeeRates, error) {
+	if len(serializedFeeRates) != chainBlockFeeRatesSize {
+		return nil, errors.Errorf("the given chain block fee rates are %d bytes while they should be %d bytes",
+			len(serializedFeeRates), chainBlockFeeRatesSize)
+	}
+	feeRates := &ChainBlockFeeRates{
+		BlueScore:          binary.LittleEndian.Uint64(serializedFeeRates[0:]),
+		DAAScore:           binary.LittleEndian.Uint64(serializedFeeRates[uint64Size:]),
+		TimeInMilliseconds: int64(binary.LittleEndian.Uint64(serializedFeeRates[2*uint64Size:])),
+		TransactionCount:   binary.LittleEndian.Uint64(serializedFeeRates[3*uint64Size:]),
+		FeeRatePercentiles: make([]float64, len(Percentiles)),
+	}
+	for i := range feeRates.FeeRatePercentiles {
+		feeRates.FeeRatePercentiles[i] = math.Float64frombits(
+			binary.LittleEndian.Uint64(serializedFeeRates[(4+i)*uint64Size:]))
+	}
+	return feeRates, nil
+}
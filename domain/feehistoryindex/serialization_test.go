@@ -0,0 +1,69 @@
+package feehistoryindex
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_serializeChainBlockFeeRates(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+
+	for i := 0; i < 32; i++ {
+		feeRates := &ChainBlockFeeRates{
+			BlueScore:          r.Uint64(),
+			DAAScore:           r.Uint64(),
+			TimeInMilliseconds: r.Int63(),
+			TransactionCount:   r.Uint64(),
+			FeeRatePercentiles: make([]float64, len(Percentiles)),
+		}
+		for j := range feeRates.FeeRatePercentiles {
+			feeRates.FeeRatePercentiles[j] = r.Float64() * 1000
+		}
+		result, err := deserializeChainBlockFeeRates(serializeChainBlockFeeRates(feeRates))
+		if err != nil {
+			t.Fatalf("Failed deserializing chain block fee rates: %v", err)
+		}
+		if !reflect.DeepEqual(result, feeRates) {
+			t.Fatalf("Expected \n %+v \n==\n %+v\n", feeRates, result)
+		}
+	}
+}
+
+func Test_deserializeChainBlockFeeRatesFailure(t *testing.T) {
+	serialized := serializeChainBlockFeeRates(&ChainBlockFeeRates{FeeRatePercentiles: make([]float64, len(Percentiles))})
+	_, err := deserializeChainBlockFeeRates(serialized[:len(serialized)-1])
+	if err == nil {
+		t.Fatalf("Expected an error when deserializing truncated chain block fee rates")
+	}
+}
+
+func Test_feeRatePercentiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		feeRates []float64
+		expected []float64
+	}{
+		{
+			name:     "no transactions",
+			feeRates: nil,
+			expected: []float64{0, 0, 0, 0, 0},
+		},
+		{
+			name:     "single transaction",
+			feeRates: []float64{3},
+			expected: []float64{3, 3, 3, 3, 3},
+		},
+		{
+			name:     "ten unordered transactions",
+			feeRates: []float64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+			expected: []float64{1, 3, 5, 8, 9},
+		},
+	}
+	for _, test := range tests {
+		result := feeRatePercentiles(test.feeRates)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package feehistoryindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+var chainBlockFeeRatesBucket = database.MakeBucket([]byte("fee-history-index-chain-block-fee-rates"))
+var chainBlockTrackerBucket = database.MakeBucket([]byte("fee-history-index-chain-block-tracker"))
+var virtualSelectedParentKey = database.MakeBucket([]byte("")).Key([]byte("fee-history-index-virtual-selected-parent"))
+
+type feeHistoryIndexStore struct {
+	database          database.Database
+	chainBlockTracker *indexretention.ChainBlockTracker
+}
+
+func newFeeHistoryIndexStore(database database.Database) *feeHistoryIndexStore {
+	return &feeHistoryIndexStore{
+		database:          database,
+		chainBlockTracker: indexretention.NewChainBlockTracker(chainBlockTrackerBucket),
+	}
+}
+
+func (fhis *feeHistoryIndexStore) chainBlockFeeRatesKey(blockHash *externalapi.DomainHash) *database.Key {
+	return chainBlockFeeRatesBucket.Key(blockHash.ByteSlice())
+}
+
+func (fhis *feeHistoryIndexStore) addChainBlockFeeRates(dataAccessor database.DataAccessor,
+	feeRates *ChainBlockFeeRates) error {
+
+	key := fhis.chainBlockFeeRatesKey(feeRates.BlockHash)
+	serializedFeeRates := serializeChainBlockFeeRates(feeRates)
+	err := dataAccessor.Put(key, serializedFeeRates)
+	if err != nil {
+		return err
+	}
+	return fhis.chainBlockTracker.Track(dataAccessor, feeRates.BlockHash, feeRates.BlueScore,
+		indexretention.RecordSize(key, serializedFeeRates))
+}
+
+func (fhis *feeHistoryIndexStore) removeChainBlockFeeRates(dataAccessor database.DataAccessor,
+	blockHash *externalapi.DomainHash, blueScore uint64) error {
+
+	err := fhis.chainBlockTracker.Untrack(dataAccessor, blockHash, blueScore)
+	if err != nil {
+		return err
+	}
+	return dataAccessor.Delete(fhis.chainBlockFeeRatesKey(blockHash))
+}
+
+// getChainBlockFeeRates returns the fee rates of the given chain block.
+// found is false if the block was added to the chain before the index was started.
+func (fhis *feeHistoryIndexStore) getChainBlockFeeRates(blockHash *externalapi.DomainHash) (
+	feeRates *ChainBlockFeeRates, found bool, err error) {
+
+	serializedFeeRates, err := fhis.database.Get(fhis.chainBlockFeeRatesKey(blockHash))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	feeRates, err = deserializeChainBlockFeeRates(serializedFeeRates)
+	if err != nil {
+		return nil, false, err
+	}
+	feeRates.BlockHash = blockHash
+	return feeRates, true, nil
+}
+
+func (fhis *feeHistoryIndexStore) deleteAll() error {
+	err := fhis.chainBlockTracker.DeleteAll(fhis.database)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := fhis.database.Cursor(chainBlockFeeRatesBucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+
+		err = fhis.database.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
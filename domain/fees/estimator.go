@@ -0,0 +1,275 @@
+package fees
+
+import (
+	"math"
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+const (
+	// minBucketFeeRate is the lower bound, in sompi per gram, of the
+	// lowest fee rate bucket but one. Lower fee rates all fall into the
+	// lowest bucket.
+	minBucketFeeRate = 1.0
+
+	// maxBucketFeeRate is the lower bound, in sompi per gram, of the
+	// highest fee rate bucket
+	maxBucketFeeRate = 1e6
+
+	// bucketSpacing is the ratio between the lower bounds of consecutive
+	// fee rate buckets
+	bucketSpacing = 1.25
+
+	// decayPerDAAScore is the factor the statistics decay by for every
+	// unit of DAA score, so that old observations gradually lose weight.
+	// It's a half-life of about 2000 DAA score.
+	decayPerDAAScore = 0.99965
+
+	// successThreshold is the minimal ratio of the transactions of a fee
+	// rate range that has to be included within a confirmation window for
+	// the range to be considered sufficient for that window
+	successThreshold = 0.85
+
+	// minObservations is the minimal (decayed) amount of transactions a fee
+	// rate range has to hold in order to be evaluated
+	minObservations = 10
+
+	// maxTrackedTransactions is the maximum amount of mempool transactions
+	// tracked at once. Transactions beyond it are ignored.
+	maxTrackedTransactions = 100_000
+)
+
+// ConfirmationWindows are the confirmation windows, in DAA score, that are
+// tracked by the estimator. An estimate for any other target is made for the
+// largest window that doesn't exceed it.
+var ConfirmationWindows = []uint64{1, 2, 5, 10, 30, 60, 120, 300, 600}
+
+// Estimate is a fee rate estimate for a target confirmation window
+type Estimate struct {
+	// FeeRate is in sompi per gram of mass
+	FeeRate float64
+
+	// ConfirmationWindow is the window the estimate was made for
+	ConfirmationWindow uint64
+
+	// IsFallback is true if there was not enough data to estimate a fee
+	// rate for ConfirmationWindow, in which case FeeRate is the minimum
+	// relay fee rate
+	IsFallback bool
+}
+
+type feeRateBucket struct {
+	lowerBound float64
+
+	// observed holds, for every confirmation window, the decayed amount of
+	// transactions for which it's known whether they were included in a
+	// block within the window, and confirmed the decayed amount of those
+	// that were
+	observed  []float64
+	confirmed []float64
+}
+
+type trackedTransaction struct {
+	entryDAAScore uint64
+	bucketIndex   int
+
+	// nextWindowIndex is the index of the first confirmation window the
+	// transaction hasn't been accounted for yet
+	nextWindowIndex int
+}
+
+// Estimator estimates the fee rate required for a transaction to be
+// included in a block within a target confirmation window. It tracks when
+// transactions enter the mempool, and whether they're included in a block
+// within each of the ConfirmationWindows, for ranges of fee rates.
+type Estimator struct {
+	minimumFeeRate float64
+
+	buckets              []*feeRateBucket
+	trackedTransactions  map[externalapi.DomainTransactionID]*trackedTransaction
+	lastDecayDAAScore    uint64
+	hasProcessedDAAScore bool
+
+	mutex sync.Mutex
+}
+
+// NewEstimator creates a new Estimator. minimumFeeRate, in sompi per gram, is
+// the lowest rate it ever estimates.
+func NewEstimator(minimumFeeRate float64) *Estimator {
+	buckets := []*feeRateBucket{newFeeRateBucket(0)}
+	for lowerBound := minBucketFeeRate; lowerBound <= maxBucketFeeRate; lowerBound *= bucketSpacing {
+		buckets = append(buckets, newFeeRateBucket(lowerBound))
+	}
+
+	return &Estimator{
+		minimumFeeRate:      minimumFeeRate,
+		buckets:             buckets,
+		trackedTransactions: make(map[externalapi.DomainTransactionID]*trackedTransaction),
+	}
+}
+
+// SetMinimumFeeRate changes the lowest rate, in sompi per gram, the estimator
+// ever estimates
+func (e *Estimator) SetMinimumFeeRate(minimumFeeRate float64) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.minimumFeeRate = minimumFeeRate
+}
+
+func newFeeRateBucket(lowerBound float64) *feeRateBucket {
+	return &feeRateBucket{
+		lowerBound: lowerBound,
+		observed:   make([]float64, len(ConfirmationWindows)),
+		confirmed:  make([]float64, len(ConfirmationWindows)),
+	}
+}
+
+func (e *Estimator) bucketIndex(feeRate float64) int {
+	// Buckets are few, so a linear search from the top is good enough
+	for i := len(e.buckets) - 1; i > 0; i-- {
+		if feeRate >= e.buckets[i].lowerBound {
+			return i
+		}
+	}
+	return 0
+}
+
+// ProcessMempoolTransactions starts tracking the given transactions, which
+// were just accepted to the mempool at the given virtual DAA score. Their
+// Fee and Mass must be populated.
+func (e *Estimator) ProcessMempoolTransactions(transactions []*externalapi.DomainTransaction, daaScore uint64) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for _, transaction := range transactions {
+		if len(e.trackedTransactions) >= maxTrackedTransactions {
+			return
+		}
+		if transaction.Mass == 0 {
+			continue
+		}
+		transactionID := consensushashing.TransactionID(transaction)
+		if _, ok := e.trackedTransactions[*transactionID]; ok {
+			continue
+		}
+		feeRate := float64(transaction.Fee) / float64(transaction.Mass)
+		e.trackedTransactions[*transactionID] = &trackedTransaction{
+			entryDAAScore: daaScore,
+			bucketIndex:   e.bucketIndex(feeRate),
+		}
+	}
+}
+
+// ProcessBlockTransactions accounts for the inclusion of the given
+// non-coinbase transactions in a block that was added at the given virtual DAA score, and
+// for the tracked transactions that weren't included within a confirmation
+// window they've outlived. Transactions that left the mempool for any other
+// reason are accounted for as not included, until they outlive the largest
+// confirmation window.
+func (e *Estimator) ProcessBlockTransactions(transactions []*externalapi.DomainTransaction, daaScore uint64) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.decay(daaScore)
+
+	for _, transaction := range transactions {
+		transactionID := consensushashing.TransactionID(transaction)
+		tracked, ok := e.trackedTransactions[*transactionID]
+		if !ok {
+			continue
+		}
+		delete(e.trackedTransactions, *transactionID)
+
+		e.resolveExpiredWindows(tracked, daaScore)
+		bucket := e.buckets[tracked.bucketIndex]
+		for i := tracked.nextWindowIndex; i < len(ConfirmationWindows); i++ {
+			bucket.observed[i]++
+			bucket.confirmed[i]++
+		}
+	}
+
+	for transactionID, tracked := range e.trackedTransactions {
+		e.resolveExpiredWindows(tracked, daaScore)
+		if tracked.nextWindowIndex == len(ConfirmationWindows) {
+			delete(e.trackedTransactions, transactionID)
+		}
+	}
+}
+
+// resolveExpiredWindows accounts for the given transaction not having been
+// included within the confirmation windows that ended before daaScore
+func (e *Estimator) resolveExpiredWindows(tracked *trackedTransaction, daaScore uint64) {
+	if daaScore < tracked.entryDAAScore {
+		return
+	}
+	age := daaScore - tracked.entryDAAScore
+	bucket := e.buckets[tracked.bucketIndex]
+	for tracked.nextWindowIndex < len(ConfirmationWindows) && age > ConfirmationWindows[tracked.nextWindowIndex] {
+		bucket.observed[tracked.nextWindowIndex]++
+		tracked.nextWindowIndex++
+	}
+}
+
+func (e *Estimator) decay(daaScore uint64) {
+	if !e.hasProcessedDAAScore {
+		e.hasProcessedDAAScore = true
+		e.lastDecayDAAScore = daaScore
+		return
+	}
+	if daaScore <= e.lastDecayDAAScore {
+		return
+	}
+	factor := math.Pow(decayPerDAAScore, float64(daaScore-e.lastDecayDAAScore))
+	e.lastDecayDAAScore = daaScore
+	for _, bucket := range e.buckets {
+		for i := range ConfirmationWindows {
+			bucket.observed[i] *= factor
+			bucket.confirmed[i] *= factor
+		}
+	}
+}
+
+// EstimateFeeRate estimates the fee rate required for a transaction to be
+// included in a block within targetDAAScore of entering the mempool.
+//
+// Fee rate buckets are grouped from the highest down into ranges holding
+// enough observations, and the estimate is the lowest fee rate of the lowest
+// range in which at least successThreshold of the transactions were
+// included in time, provided all the ranges above it qualify as well.
+func (e *Estimator) EstimateFeeRate(targetDAAScore uint64) *Estimate {
+	windowIndex := 0
+	for i, window := range ConfirmationWindows {
+		if window <= targetDAAScore {
+			windowIndex = i
+		}
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	estimate := &Estimate{
+		FeeRate:            e.minimumFeeRate,
+		ConfirmationWindow: ConfirmationWindows[windowIndex],
+		IsFallback:         true,
+	}
+
+	rangeObserved, rangeConfirmed := 0.0, 0.0
+	for i := len(e.buckets) - 1; i >= 0; i-- {
+		bucket := e.buckets[i]
+		rangeObserved += bucket.observed[windowIndex]
+		rangeConfirmed += bucket.confirmed[windowIndex]
+		if rangeObserved < minObservations {
+			continue
+		}
+		if rangeConfirmed/rangeObserved < successThreshold {
+			break
+		}
+		estimate.FeeRate = math.Max(bucket.lowerBound, e.minimumFeeRate)
+		estimate.IsFallback = false
+		rangeObserved, rangeConfirmed = 0, 0
+	}
+	return estimate
+}
@@ -0,0 +1,81 @@
+package fees
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+func newTestTransaction(index int, fee uint64) *externalapi.DomainTransaction {
+	return &externalapi.DomainTransaction{
+		Inputs: []*externalapi.DomainTransactionInput{{
+			PreviousOutpoint: externalapi.DomainOutpoint{
+				TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+				Index:         uint32(index),
+			},
+		}},
+		Fee:  fee,
+		Mass: 1000,
+	}
+}
+
+func TestEstimateFeeRate(t *testing.T) {
+	const minimumFeeRate = 1
+	estimator := NewEstimator(minimumFeeRate)
+
+	estimate := estimator.EstimateFeeRate(10)
+	if !estimate.IsFallback || estimate.FeeRate != minimumFeeRate || estimate.ConfirmationWindow != 10 {
+		t.Fatalf("Expected the minimum fee rate as a fallback without any data, but got %+v", estimate)
+	}
+
+	// Transactions paying 100 sompi per gram are included in the next block, while
+	// transactions paying 2 sompi per gram are included only after 20 DAA score
+	daaScore := uint64(1000)
+	index := 0
+	for round := 0; round < 50; round++ {
+		highFeeTransaction := newTestTransaction(index, 100_000)
+		lowFeeTransaction := newTestTransaction(index+1, 2_000)
+		index += 2
+		estimator.ProcessMempoolTransactions([]*externalapi.DomainTransaction{highFeeTransaction, lowFeeTransaction}, daaScore)
+		estimator.ProcessBlockTransactions([]*externalapi.DomainTransaction{highFeeTransaction}, daaScore+1)
+		estimator.ProcessBlockTransactions([]*externalapi.DomainTransaction{lowFeeTransaction}, daaScore+20)
+		daaScore += 20
+	}
+
+	estimate = estimator.EstimateFeeRate(1)
+	if estimate.IsFallback || estimate.FeeRate > 100 || estimate.FeeRate < 50 {
+		t.Fatalf("Expected an estimate close to 100 sompi per gram for inclusion in the next block, "+
+			"but got %+v", estimate)
+	}
+	estimate = estimator.EstimateFeeRate(45)
+	if estimate.IsFallback || estimate.ConfirmationWindow != 30 || estimate.FeeRate > 2 || estimate.FeeRate < 1 {
+		t.Fatalf("Expected an estimate close to 2 sompi per gram for inclusion within 30 DAA score, "+
+			"but got %+v", estimate)
+	}
+	if len(estimator.trackedTransactions) != 0 {
+		t.Fatalf("Expected no transactions to remain tracked, but %d do", len(estimator.trackedTransactions))
+	}
+}
+
+func TestEstimatorForgetsUnincludedTransactions(t *testing.T) {
+	estimator := NewEstimator(1)
+	transaction := newTestTransaction(0, 1_000)
+	estimator.ProcessMempoolTransactions([]*externalapi.DomainTransaction{transaction}, 0)
+
+	largestWindow := ConfirmationWindows[len(ConfirmationWindows)-1]
+	estimator.ProcessBlockTransactions(nil, largestWindow)
+	if len(estimator.trackedTransactions) != 1 {
+		t.Fatalf("Expected the transaction to be tracked until it outlives the largest confirmation window")
+	}
+	estimator.ProcessBlockTransactions(nil, largestWindow+1)
+	if len(estimator.trackedTransactions) != 0 {
+		t.Fatalf("Expected the transaction not to be tracked once it outlived the largest confirmation window")
+	}
+	bucket := estimator.buckets[estimator.bucketIndex(1)]
+	for i := range ConfirmationWindows {
+		if bucket.observed[i] == 0 || bucket.confirmed[i] != 0 {
+			t.Fatalf("Expected the transaction to be accounted for as not included within window %d",
+				ConfirmationWindows[i])
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package indexretention
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+const blueScoreSize = 8
+
+// TrackedChainBlock is a chain block an index holds records of, along with
+// the amount of bytes these records take
+type TrackedChainBlock struct {
+	BlockHash *externalapi.DomainHash
+	BlueScore uint64
+	Size      uint64
+}
+
+// ChainBlockTracker keeps the chain blocks an index holds records of ordered
+// by their blue score, so that the oldest ones can be found and pruned
+type ChainBlockTracker struct {
+	bucket *database.Bucket
+}
+
+// NewChainBlockTracker creates a new ChainBlockTracker that keeps its entries in the given bucket
+func NewChainBlockTracker(bucket *database.Bucket) *ChainBlockTracker {
+	return &ChainBlockTracker{
+		bucket: bucket,
+	}
+}
+
+func (cbt *ChainBlockTracker) key(blockHash *externalapi.DomainHash, blueScore uint64) *database.Key {
+	keyBytes := make([]byte, blueScoreSize+externalapi.DomainHashSize)
+	binary.BigEndian.PutUint64(keyBytes[:blueScoreSize], blueScore)
+	copy(keyBytes[blueScoreSize:], blockHash.ByteSlice())
+	return cbt.bucket.Key(keyBytes)
+}
+
+// Track starts tracking the given chain block, whose records take size bytes
+func (cbt *ChainBlockTracker) Track(dataAccessor database.DataAccessor,
+	blockHash *externalapi.DomainHash, blueScore uint64, size uint64) error {
+
+	var serializedSize [8]byte
+	binary.LittleEndian.PutUint64(serializedSize[:], size)
+	return dataAccessor.Put(cbt.key(blockHash, blueScore), serializedSize[:])
+}
+
+// Untrack stops tracking the given chain block, once its records are deleted
+func (cbt *ChainBlockTracker) Untrack(dataAccessor database.DataAccessor,
+	blockHash *externalapi.DomainHash, blueScore uint64) error {
+
+	return dataAccessor.Delete(cbt.key(blockHash, blueScore))
+}
+
+// ForEach calls the given function for every tracked chain block, from the
+// lowest blue score up, until it returns false or an error
+func (cbt *ChainBlockTracker) ForEach(database database.Database,
+	function func(chainBlock *TrackedChainBlock) (shouldContinue bool, err error)) error {
+
+	cursor, err := database.Cursor(cbt.bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		chainBlock, err := deserializeTrackedChainBlock(key.Suffix(), value)
+		if err != nil {
+			return err
+		}
+
+		shouldContinue, err := function(chainBlock)
+		if err != nil {
+			return err
+		}
+		if !shouldContinue {
+			return nil
+		}
+	}
+	return nil
+}
+
+func deserializeTrackedChainBlock(keySuffix []byte, value []byte) (*TrackedChainBlock, error) {
+	if len(keySuffix) != blueScoreSize+externalapi.DomainHashSize || len(value) != 8 {
+		return nil, errors.Errorf("the given tracked chain block is malformed: its key suffix is %d bytes "+
+			"and its value is %d bytes", len(keySuffix), len(value))
+	}
+	blockHash, err := externalapi.NewDomainHashFromByteSlice(keySuffix[blueScoreSize:])
+	if err != nil {
+		return nil, err
+	}
+	return &TrackedChainBlock{
+		BlockHash: blockHash,
+		BlueScore: binary.BigEndian.Uint64(keySuffix[:blueScoreSize]),
+		Size:      binary.LittleEndian.Uint64(value),
+	}, nil
+}
+
+// DeleteAll stops tracking all chain blocks
+func (cbt *ChainBlockTracker) DeleteAll(database database.Database) error {
+	cursor, err := database.Cursor(cbt.bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+
+		err = database.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordSize returns the amount of bytes a record with the given key and value takes
+func RecordSize(key *database.Key, value []byte) uint64 {
+	return uint64(len(key.Bytes()) + len(value))
+}
@@ -0,0 +1,9 @@
+package indexretention
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("IRET")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,237 @@
+package indexretention
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// pruneInterval is the interval between consecutive prunings of the indexes
+const pruneInterval = 10 * time.Minute
+
+// maxChainBlocksPerPrune is the maximum amount of chain blocks
+// whose records are pruned together in a single call to an index
+const maxChainBlocksPerPrune = 1000
+
+// Index is an index whose records of old chain blocks can be pruned
+type Index interface {
+	// ChainBlockTracker returns the tracker of the chain blocks the index holds records of,
+	// or nil if the index is unavailable and may not be pruned
+	ChainBlockTracker() *ChainBlockTracker
+
+	// VirtualSelectedParentBlueScore returns the blue score of the
+	// virtual selected parent the index is synced with
+	VirtualSelectedParentBlueScore() (uint64, error)
+
+	// PruneChainBlocks deletes the records of the given chain blocks and stops tracking them
+	PruneChainBlocks(chainBlocks []*TrackedChainBlock) error
+}
+
+// Policy is the retention policy of a single index
+type Policy struct {
+	IndexName string
+	Index     Index
+
+	// MaxAge is the maximum age of the chain blocks whose records are kept, or 0 if it's unlimited
+	MaxAge time.Duration
+
+	// MaxSize is the maximum amount of bytes the records of the index take, or 0 if it's unlimited
+	MaxSize uint64
+}
+
+// Status is the state of an index as of the last time it was pruned,
+// along with the space pruning it had reclaimed since kaspad started
+type Status struct {
+	IndexName string
+	MaxAge    time.Duration
+	MaxSize   uint64
+
+	Size            uint64
+	ChainBlockCount uint64
+
+	PrunedChainBlockCount uint64
+	ReclaimedBytes        uint64
+	LastPruneTime         time.Time
+}
+
+// Manager periodically prunes the records of old chain blocks from indexes
+// according to their retention policies
+type Manager struct {
+	database           database.Database
+	targetTimePerBlock time.Duration
+	policies           []*Policy
+
+	statuses     []*Status
+	statusesLock sync.Mutex
+
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+// New creates a new Manager that applies the given policies. The age of chain
+// blocks is measured in blue score, which grows by one every targetTimePerBlock.
+func New(database database.Database, targetTimePerBlock time.Duration, policies []*Policy) *Manager {
+	statuses := make([]*Status, len(policies))
+	for i, policy := range policies {
+		statuses[i] = &Status{
+			IndexName: policy.IndexName,
+			MaxAge:    policy.MaxAge,
+			MaxSize:   policy.MaxSize,
+		}
+	}
+	return &Manager{
+		database:           database,
+		targetTimePerBlock: targetTimePerBlock,
+		policies:           policies,
+		statuses:           statuses,
+		stopChan:           make(chan struct{}),
+		doneChan:           make(chan struct{}),
+	}
+}
+
+// Start begins pruning the indexes every pruneInterval
+func (m *Manager) Start() {
+	spawn("indexretention.Manager.pruneLoop", m.pruneLoop)
+}
+
+// Stop stops pruning the indexes, and waits for an ongoing pruning to finish
+func (m *Manager) Stop() {
+	close(m.stopChan)
+	<-m.doneChan
+}
+
+func (m *Manager) pruneLoop() {
+	defer close(m.doneChan)
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		err := m.Prune()
+		if err != nil {
+			log.Errorf("Error pruning the indexes: %+v", err)
+		}
+
+		select {
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune prunes all the indexes according to their policies
+func (m *Manager) Prune() error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "indexretention.Manager.Prune")
+	defer onEnd()
+
+	for i, policy := range m.policies {
+		err := m.prune(policy, m.statuses[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) prune(policy *Policy, status *Status) error {
+	tracker := policy.Index.ChainBlockTracker()
+	if tracker == nil {
+		return nil
+	}
+
+	minBlueScore := uint64(0)
+	if policy.MaxAge > 0 {
+		virtualSelectedParentBlueScore, err := policy.Index.VirtualSelectedParentBlueScore()
+		if err != nil {
+			return err
+		}
+		maxAgeInBlueScore := uint64(policy.MaxAge / m.targetTimePerBlock)
+		if virtualSelectedParentBlueScore > maxAgeInBlueScore {
+			minBlueScore = virtualSelectedParentBlueScore - maxAgeInBlueScore
+		}
+	}
+
+	size, chainBlockCount := uint64(0), uint64(0)
+	err := tracker.ForEach(m.database, func(chainBlock *TrackedChainBlock) (bool, error) {
+		size += chainBlock.Size
+		chainBlockCount++
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	prunedChainBlockCount, reclaimedBytes := uint64(0), uint64(0)
+	var chainBlocksToPrune []*TrackedChainBlock
+	pruneChainBlocks := func() error {
+		err := policy.Index.PruneChainBlocks(chainBlocksToPrune)
+		if err != nil {
+			return err
+		}
+		for _, chainBlock := range chainBlocksToPrune {
+			prunedChainBlockCount++
+			reclaimedBytes += chainBlock.Size
+		}
+		chainBlocksToPrune = nil
+		return nil
+	}
+	// The chain blocks are pruned from the oldest up, until the remaining
+	// ones are both young enough and fit within the maximum size
+	remainingSize := size
+	err = tracker.ForEach(m.database, func(chainBlock *TrackedChainBlock) (bool, error) {
+		isTooOld := chainBlock.BlueScore < minBlueScore
+		isOverMaxSize := policy.MaxSize > 0 && remainingSize > policy.MaxSize
+		if !isTooOld && !isOverMaxSize {
+			return false, nil
+		}
+
+		chainBlocksToPrune = append(chainBlocksToPrune, chainBlock)
+		remainingSize -= chainBlock.Size
+		if len(chainBlocksToPrune) == maxChainBlocksPerPrune {
+			err := pruneChainBlocks()
+			if err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(chainBlocksToPrune) > 0 {
+		err := pruneChainBlocks()
+		if err != nil {
+			return err
+		}
+	}
+
+	if prunedChainBlockCount > 0 {
+		log.Infof("Pruned %d chain blocks from the %s index, reclaiming %d bytes",
+			prunedChainBlockCount, policy.IndexName, reclaimedBytes)
+	}
+
+	m.statusesLock.Lock()
+	defer m.statusesLock.Unlock()
+
+	status.Size = size - reclaimedBytes
+	status.ChainBlockCount = chainBlockCount - prunedChainBlockCount
+	status.PrunedChainBlockCount += prunedChainBlockCount
+	status.ReclaimedBytes += reclaimedBytes
+	status.LastPruneTime = time.Now()
+	return nil
+}
+
+// Statuses returns the statuses of all the indexes, in the order of their policies
+func (m *Manager) Statuses() []*Status {
+	m.statusesLock.Lock()
+	defer m.statusesLock.Unlock()
+
+	statuses := make([]*Status, len(m.statuses))
+	for i, status := range m.statuses {
+		statusClone := *status
+		statuses[i] = &statusClone
+	}
+	return statuses
+}
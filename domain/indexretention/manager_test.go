@@ -0,0 +1,172 @@
+package indexretention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+type fakeIndex struct {
+	database                       database.Database
+	chainBlockTracker              *ChainBlockTracker
+	virtualSelectedParentBlueScore uint64
+	pruneCallSizes                 []int
+}
+
+func (fi *fakeIndex) ChainBlockTracker() *ChainBlockTracker {
+	return fi.chainBlockTracker
+}
+
+func (fi *fakeIndex) VirtualSelectedParentBlueScore() (uint64, error) {
+	return fi.virtualSelectedParentBlueScore, nil
+}
+
+func (fi *fakeIndex) PruneChainBlocks(chainBlocks []*TrackedChainBlock) error {
+	fi.pruneCallSizes = append(fi.pruneCallSizes, len(chainBlocks))
+	dbTransaction, err := fi.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, chainBlock := range chainBlocks {
+		err := fi.chainBlockTracker.Untrack(dbTransaction, chainBlock.BlockHash, chainBlock.BlueScore)
+		if err != nil {
+			return err
+		}
+	}
+	return dbTransaction.Commit()
+}
+
+// newFakeIndexForTest returns an index that tracks chainBlockCount chain
+// blocks of size chainBlockSize, with the blue scores 0 to chainBlockCount-1
+func newFakeIndexForTest(t *testing.T, chainBlockCount uint64, chainBlockSize uint64) (*fakeIndex, func()) {
+	db, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("Error creating the database: %+v", err)
+	}
+
+	index := &fakeIndex{
+		database:                       db,
+		chainBlockTracker:              NewChainBlockTracker(database.MakeBucket([]byte("fake-index-chain-block-tracker"))),
+		virtualSelectedParentBlueScore: chainBlockCount - 1,
+	}
+	for blueScore := uint64(0); blueScore < chainBlockCount; blueScore++ {
+		var hashBytes [externalapi.DomainHashSize]byte
+		hashBytes[0] = byte(blueScore)
+		hashBytes[1] = byte(blueScore >> 8)
+		err := index.chainBlockTracker.Track(db, externalapi.NewDomainHashFromByteArray(&hashBytes), blueScore, chainBlockSize)
+		if err != nil {
+			t.Fatalf("Track: %+v", err)
+		}
+	}
+
+	return index, func() {
+		db.Close()
+	}
+}
+
+func remainingBlueScores(t *testing.T, index *fakeIndex) []uint64 {
+	var blueScores []uint64
+	err := index.chainBlockTracker.ForEach(index.database, func(chainBlock *TrackedChainBlock) (bool, error) {
+		blueScores = append(blueScores, chainBlock.BlueScore)
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("ForEach: %+v", err)
+	}
+	return blueScores
+}
+
+func TestPrune(t *testing.T) {
+	const chainBlockSize = 100
+	tests := []struct {
+		name                   string
+		maxAge                 time.Duration
+		maxSize                uint64
+		expectedFirstBlueScore uint64
+	}{
+		{name: "unlimited", expectedFirstBlueScore: 0},
+		{name: "max age", maxAge: 20 * time.Second, expectedFirstBlueScore: 79},
+		{name: "max size", maxSize: 10 * chainBlockSize, expectedFirstBlueScore: 90},
+		{name: "max size not aligned to chain blocks", maxSize: 10*chainBlockSize + 50, expectedFirstBlueScore: 90},
+		{name: "max age stricter than max size", maxAge: 5 * time.Second, maxSize: 10 * chainBlockSize,
+			expectedFirstBlueScore: 94},
+		{name: "max size stricter than max age", maxAge: 50 * time.Second, maxSize: 10 * chainBlockSize,
+			expectedFirstBlueScore: 90},
+	}
+	for _, test := range tests {
+		index, teardown := newFakeIndexForTest(t, 100, chainBlockSize)
+
+		manager := New(index.database, time.Second, []*Policy{
+			{IndexName: "fake", Index: index, MaxAge: test.maxAge, MaxSize: test.maxSize},
+		})
+		err := manager.Prune()
+		if err != nil {
+			t.Fatalf("%s: Prune: %+v", test.name, err)
+		}
+
+		blueScores := remainingBlueScores(t, index)
+		expectedChainBlockCount := 100 - test.expectedFirstBlueScore
+		if uint64(len(blueScores)) != expectedChainBlockCount || blueScores[0] != test.expectedFirstBlueScore {
+			t.Fatalf("%s: expected the chain blocks with blue scores %d to 99 to remain, but got %v",
+				test.name, test.expectedFirstBlueScore, blueScores)
+		}
+
+		status := manager.Statuses()[0]
+		expectedPrunedChainBlockCount := test.expectedFirstBlueScore
+		if status.ChainBlockCount != expectedChainBlockCount ||
+			status.Size != expectedChainBlockCount*chainBlockSize ||
+			status.PrunedChainBlockCount != expectedPrunedChainBlockCount ||
+			status.ReclaimedBytes != expectedPrunedChainBlockCount*chainBlockSize ||
+			status.LastPruneTime.IsZero() {
+
+			t.Fatalf("%s: unexpected status %+v", test.name, status)
+		}
+
+		// Pruning again right away finds nothing left to prune
+		err = manager.Prune()
+		if err != nil {
+			t.Fatalf("%s: Prune: %+v", test.name, err)
+		}
+		secondStatus := manager.Statuses()[0]
+		if secondStatus.PrunedChainBlockCount != status.PrunedChainBlockCount ||
+			secondStatus.ReclaimedBytes != status.ReclaimedBytes {
+
+			t.Fatalf("%s: unexpected status after pruning again %+v", test.name, secondStatus)
+		}
+
+		teardown()
+	}
+}
+
+func TestPruneInBatches(t *testing.T) {
+	index, teardown := newFakeIndexForTest(t, 2500, 1)
+	defer teardown()
+
+	manager := New(index.database, time.Second, []*Policy{
+		{IndexName: "fake", Index: index, MaxSize: 1},
+	})
+	err := manager.Prune()
+	if err != nil {
+		t.Fatalf("Prune: %+v", err)
+	}
+
+	expectedPruneCallSizes := []int{maxChainBlocksPerPrune, maxChainBlocksPerPrune, 499}
+	if len(index.pruneCallSizes) != len(expectedPruneCallSizes) {
+		t.Fatalf("Expected %d calls to PruneChainBlocks, but got %v", len(expectedPruneCallSizes), index.pruneCallSizes)
+	}
+	for i, expectedPruneCallSize := range expectedPruneCallSizes {
+		if index.pruneCallSizes[i] != expectedPruneCallSize {
+			t.Fatalf("Expected %v calls to PruneChainBlocks, but got %v", expectedPruneCallSizes, index.pruneCallSizes)
+		}
+	}
+
+	blueScores := remainingBlueScores(t, index)
+	if len(blueScores) != 1 || blueScores[0] != 2499 {
+		t.Fatalf("Expected only the chain block with blue score 2499 to remain, but got %v", blueScores)
+	}
+}
@@ -0,0 +1,7 @@
+package indexsync
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("INSY")
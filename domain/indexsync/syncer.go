@@ -0,0 +1,347 @@
+// Package indexsync keeps indexes in sync with the virtual selected parent
+// chain.
+//
+// A Syncer tracks the virtual selected parent an index is synced with,
+// catches the index up with the chain changes it had missed, and resets it if
+// it's too far behind for that. The index itself only adds and removes the
+// records of single chain blocks.
+package indexsync
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// maxChainBlocksPerCommit is the maximum amount of chain blocks whose
+// records are committed together while syncing an index
+const maxChainBlocksPerCommit = 1000
+
+// Callbacks are the functions through which a Syncer changes the records of
+// an index. The Syncer calls them while the index is locked.
+type Callbacks struct {
+	// Reset deletes all the records of the index, before it's synced from the given pruning point
+	Reset func(pruningPoint *externalapi.DomainHash) error
+
+	// RemoveChainBlock removes the records of a chain block that was removed from the virtual selected parent chain
+	RemoveChainBlock func(dbTransaction database.Transaction, blockHash *externalapi.DomainHash, blueScore uint64) error
+
+	// AddChainBlock adds the records of a chain block that was added to the virtual selected parent chain
+	AddChainBlock func(dbTransaction database.Transaction, blockHash *externalapi.DomainHash,
+		header externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error
+
+	// BeginChainChanges and FinishChainChanges may be set by indexes that aggregate the records of the
+	// chain blocks removed and added in a database transaction, and write them once they're all applied.
+	// BeginChainChanges is called before the chain blocks are removed and added, and FinishChainChanges
+	// after they were, before the transaction is committed.
+	BeginChainChanges  func()
+	FinishChainChanges func(dbTransaction database.Transaction) error
+}
+
+// Syncer keeps an index in sync with the virtual selected parent chain
+type Syncer struct {
+	name                     string
+	domain                   domain.Domain
+	database                 database.Database
+	virtualSelectedParentKey *database.Key
+	callbacks                *Callbacks
+
+	mutex sync.Mutex
+}
+
+// New creates a new Syncer of the index whose records are changed through the
+// given callbacks. The Syncer keeps the virtual selected parent the index is
+// synced with in virtualSelectedParentKey. name is the name of the index in log
+// messages, e.g. "STXO index".
+func New(name string, domain domain.Domain, database database.Database,
+	virtualSelectedParentKey *database.Key, callbacks *Callbacks) *Syncer {
+
+	return &Syncer{
+		name:                     name,
+		domain:                   domain,
+		database:                 database,
+		virtualSelectedParentKey: virtualSelectedParentKey,
+		callbacks:                callbacks,
+	}
+}
+
+// Lock locks the index, so that it isn't changed until Unlock is called
+func (s *Syncer) Lock() {
+	s.mutex.Lock()
+}
+
+// Unlock unlocks the index
+func (s *Syncer) Unlock() {
+	s.mutex.Unlock()
+}
+
+// CatchUp brings the index in sync with the virtual selected parent chain by
+// applying the chain changes it had missed, or by resetting it if it's too far
+// behind for that
+func (s *Syncer) CatchUp() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.catchUp()
+}
+
+// catchUp is CatchUp for callers that already hold the lock, so that nothing
+// changes the index between checking how far behind it is and syncing it
+func (s *Syncer) catchUp() error {
+	isRecoverable, err := s.isRecoverable()
+	if err != nil {
+		return err
+	}
+	if !isRecoverable {
+		return s.reset()
+	}
+
+	virtualSelectedParent, err := s.VirtualSelectedParent()
+	if err != nil {
+		return err
+	}
+	return s.syncFrom(virtualSelectedParent)
+}
+
+// isRecoverable returns whether the index can be brought in sync with the
+// virtual selected parent chain by applying the chain changes it had missed,
+// which requires the acceptance data of the chain blocks it should add. The
+// index must be locked while this is called.
+func (s *Syncer) isRecoverable() (bool, error) {
+	virtualSelectedParent, err := s.VirtualSelectedParent()
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	virtualSelectedParentInfo, err := s.domain.Consensus().GetBlockInfo(virtualSelectedParent)
+	if err != nil {
+		return false, err
+	}
+	if !virtualSelectedParentInfo.Exists {
+		return false, nil
+	}
+
+	pruningPoint, err := s.domain.Consensus().PruningPoint()
+	if err != nil {
+		return false, err
+	}
+	pruningPointInfo, err := s.domain.Consensus().GetBlockInfo(pruningPoint)
+	if err != nil {
+		return false, err
+	}
+	return virtualSelectedParentInfo.BlueScore >= pruningPointInfo.BlueScore, nil
+}
+
+// Reset deletes the whole index and resyncs it from the pruning point
+func (s *Syncer) Reset() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.reset()
+}
+
+// reset is Reset for callers that already hold the lock
+func (s *Syncer) reset() error {
+	// First we delete the virtual selected parent, so if anything goes wrong,
+	// the index will be marked as "not synced" and will be reset.
+	err := s.database.Delete(s.virtualSelectedParentKey)
+	if err != nil {
+		return err
+	}
+
+	pruningPoint, err := s.domain.Consensus().PruningPoint()
+	if err != nil {
+		return err
+	}
+	err = s.callbacks.Reset(pruningPoint)
+	if err != nil {
+		return err
+	}
+	return s.syncFrom(pruningPoint)
+}
+
+// syncFrom applies all the virtual selected parent chain changes since the
+// given block, in chunks of maxChainBlocksPerCommit chain blocks
+func (s *Syncer) syncFrom(blockHash *externalapi.DomainHash) error {
+	chainChanges, err := s.domain.Consensus().GetVirtualSelectedParentChainFromBlock(blockHash)
+	if err != nil {
+		return err
+	}
+	if len(chainChanges.Removed) == 0 && len(chainChanges.Added) == 0 {
+		return s.database.Put(s.virtualSelectedParentKey, blockHash.ByteSlice())
+	}
+
+	log.Infof("Syncing the %s with %d removed and %d added chain blocks",
+		s.name, len(chainChanges.Removed), len(chainChanges.Added))
+	removed := chainChanges.Removed
+	added := chainChanges.Added
+	for {
+		chunkSize := len(added)
+		if chunkSize > maxChainBlocksPerCommit {
+			chunkSize = maxChainBlocksPerCommit
+		}
+		err := s.applyChainChanges(&externalapi.SelectedChainPath{
+			Removed: removed,
+			Added:   added[:chunkSize],
+		})
+		if err != nil {
+			return err
+		}
+
+		removed = nil
+		added = added[chunkSize:]
+		if len(added) == 0 {
+			return nil
+		}
+	}
+}
+
+// Update updates the index with the given DAG selected parent chain changes
+func (s *Syncer) Update(chainChanges *externalapi.SelectedChainPath) error {
+	if len(chainChanges.Removed) == 0 && len(chainChanges.Added) == 0 {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	continuesFromIndex, err := s.continuesFromVirtualSelectedParent(chainChanges)
+	if err != nil {
+		return err
+	}
+	if !continuesFromIndex {
+		// This happens when the index was loaded while blocks were being added,
+		// so it's either already synced with these changes or had missed some
+		log.Debugf("The chain changes don't start from the virtual selected parent of the %s. "+
+			"Catching up instead", s.name)
+		return s.catchUp()
+	}
+	return s.applyChainChanges(chainChanges)
+}
+
+// continuesFromVirtualSelectedParent returns whether the given chain changes
+// start from the virtual selected parent the index is synced with. The index
+// must be locked while this is called.
+func (s *Syncer) continuesFromVirtualSelectedParent(chainChanges *externalapi.SelectedChainPath) (bool, error) {
+	virtualSelectedParent, err := s.VirtualSelectedParent()
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// The removed chain blocks are ordered from the previous virtual selected parent down
+	if len(chainChanges.Removed) > 0 {
+		return chainChanges.Removed[0].Equal(virtualSelectedParent), nil
+	}
+	firstAddedBlockInfo, err := s.domain.Consensus().GetBlockInfo(chainChanges.Added[0])
+	if err != nil {
+		return false, err
+	}
+	return firstAddedBlockInfo.SelectedParent.Equal(virtualSelectedParent), nil
+}
+
+func (s *Syncer) applyChainChanges(chainChanges *externalapi.SelectedChainPath) error {
+	dbTransaction, err := s.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	if s.callbacks.BeginChainChanges != nil {
+		s.callbacks.BeginChainChanges()
+	}
+
+	for _, removedBlockHash := range chainChanges.Removed {
+		removedBlockInfo, err := s.domain.Consensus().GetBlockInfo(removedBlockHash)
+		if err != nil {
+			return err
+		}
+		err = s.callbacks.RemoveChainBlock(dbTransaction, removedBlockHash, removedBlockInfo.BlueScore)
+		if err != nil {
+			return err
+		}
+	}
+
+	acceptanceData, err := s.domain.Consensus().GetBlocksAcceptanceData(chainChanges.Added)
+	if err != nil {
+		return err
+	}
+	for i, addedBlockHash := range chainChanges.Added {
+		header, err := s.domain.Consensus().GetBlockHeader(addedBlockHash)
+		if err != nil {
+			return err
+		}
+		err = s.callbacks.AddChainBlock(dbTransaction, addedBlockHash, header, acceptanceData[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.callbacks.FinishChainChanges != nil {
+		err := s.callbacks.FinishChainChanges(dbTransaction)
+		if err != nil {
+			return err
+		}
+	}
+
+	virtualSelectedParent, err := s.virtualSelectedParentAfter(chainChanges)
+	if err != nil {
+		return err
+	}
+	err = dbTransaction.Put(s.virtualSelectedParentKey, virtualSelectedParent.ByteSlice())
+	if err != nil {
+		return err
+	}
+
+	return dbTransaction.Commit()
+}
+
+// virtualSelectedParentAfter returns the virtual selected parent once the
+// given chain changes are applied
+func (s *Syncer) virtualSelectedParentAfter(chainChanges *externalapi.SelectedChainPath) (*externalapi.DomainHash, error) {
+	if len(chainChanges.Added) > 0 {
+		return chainChanges.Added[len(chainChanges.Added)-1], nil
+	}
+
+	// The chain only retreated, so the virtual selected parent
+	// is the selected parent of the last removed chain block
+	lastRemovedBlockInfo, err := s.domain.Consensus().GetBlockInfo(chainChanges.Removed[len(chainChanges.Removed)-1])
+	if err != nil {
+		return nil, err
+	}
+	return lastRemovedBlockInfo.SelectedParent, nil
+}
+
+// VirtualSelectedParent returns the virtual selected parent the index is
+// synced with. The index must be locked while this is called.
+func (s *Syncer) VirtualSelectedParent() (*externalapi.DomainHash, error) {
+	virtualSelectedParentBytes, err := s.database.Get(s.virtualSelectedParentKey)
+	if err != nil {
+		return nil, err
+	}
+	return externalapi.NewDomainHashFromByteSlice(virtualSelectedParentBytes)
+}
+
+// VirtualSelectedParentBlueScore returns the blue score of the
+// virtual selected parent the index is synced with
+func (s *Syncer) VirtualSelectedParentBlueScore() (uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	virtualSelectedParent, err := s.VirtualSelectedParent()
+	if err != nil {
+		return 0, err
+	}
+	virtualSelectedParentInfo, err := s.domain.Consensus().GetBlockInfo(virtualSelectedParent)
+	if err != nil {
+		return 0, err
+	}
+	return virtualSelectedParentInfo.BlueScore, nil
+}
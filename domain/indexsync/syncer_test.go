@@ -0,0 +1,277 @@
+package indexsync
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+var testVirtualSelectedParentKey = database.MakeBucket([]byte("")).Key([]byte("test-index-virtual-selected-parent"))
+
+// testDomain is a domain whose consensus is a test consensus
+type testDomain struct {
+	domain.Domain
+	consensus externalapi.Consensus
+}
+
+func (td *testDomain) Consensus() externalapi.Consensus {
+	return td.consensus
+}
+
+// recordingIndex is an index that records the blue scores of the chain blocks it holds
+type recordingIndex struct {
+	t           *testing.T
+	blueScores  map[externalapi.DomainHash]uint64
+	resetCount  int
+	beginCount  int
+	finishCount int
+}
+
+func newRecordingIndex(t *testing.T) *recordingIndex {
+	return &recordingIndex{t: t, blueScores: make(map[externalapi.DomainHash]uint64)}
+}
+
+func (ri *recordingIndex) callbacks() *Callbacks {
+	return &Callbacks{
+		Reset: func(_ *externalapi.DomainHash) error {
+			ri.resetCount++
+			ri.blueScores = make(map[externalapi.DomainHash]uint64)
+			return nil
+		},
+		RemoveChainBlock: func(_ database.Transaction, blockHash *externalapi.DomainHash, blueScore uint64) error {
+			recordedBlueScore, ok := ri.blueScores[*blockHash]
+			if !ok || recordedBlueScore != blueScore {
+				ri.t.Fatalf("Removed chain block %s with blue score %d, which the index doesn't hold",
+					blockHash, blueScore)
+			}
+			delete(ri.blueScores, *blockHash)
+			return nil
+		},
+		AddChainBlock: func(_ database.Transaction, blockHash *externalapi.DomainHash,
+			header externalapi.BlockHeader, _ externalapi.AcceptanceData) error {
+
+			if _, ok := ri.blueScores[*blockHash]; ok {
+				ri.t.Fatalf("Added chain block %s, which the index already holds", blockHash)
+			}
+			ri.blueScores[*blockHash] = header.BlueScore()
+			return nil
+		},
+		BeginChainChanges: func() {
+			ri.beginCount++
+		},
+		FinishChainChanges: func(_ database.Transaction) error {
+			ri.finishCount++
+			return nil
+		},
+	}
+}
+
+func TestSyncer(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+	testConsensus, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig, "TestSyncer")
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardown(false)
+
+	db, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %+v", err)
+	}
+	defer db.Close()
+
+	testDomain := &testDomain{consensus: testConsensus}
+	index := newRecordingIndex(t)
+	syncer := New("test index", testDomain, db, testVirtualSelectedParentKey, index.callbacks())
+	err = syncer.CatchUp()
+	if err != nil {
+		t.Fatalf("CatchUp: %+v", err)
+	}
+	if index.resetCount != 1 {
+		t.Fatalf("Expected a new index to be reset once, but it was reset %d times", index.resetCount)
+	}
+
+	// addChain adds a chain of the given length on top of the given block,
+	// updating the index with the chain changes of every block unless skipUpdates is set
+	addChain := func(parent *externalapi.DomainHash, length int, skipUpdates bool) []*externalapi.DomainHash {
+		chain := make([]*externalapi.DomainHash, length)
+		for i := range chain {
+			blockHash, virtualChangeSet, err := testConsensus.AddBlock([]*externalapi.DomainHash{parent}, nil, nil)
+			if err != nil {
+				t.Fatalf("AddBlock: %+v", err)
+			}
+			if !skipUpdates {
+				err := syncer.Update(virtualChangeSet.VirtualSelectedParentChainChanges)
+				if err != nil {
+					t.Fatalf("Update: %+v", err)
+				}
+			}
+			chain[i] = blockHash
+			parent = blockHash
+		}
+		return chain
+	}
+	// checkIndex checks that the index holds exactly the given chain
+	checkIndex := func(chain []*externalapi.DomainHash) {
+		if len(index.blueScores) != len(chain) {
+			t.Fatalf("Expected the index to hold %d chain blocks, but it holds %d",
+				len(chain), len(index.blueScores))
+		}
+		for _, blockHash := range chain {
+			if _, ok := index.blueScores[*blockHash]; !ok {
+				t.Fatalf("Chain block %s is missing from the index", blockHash)
+			}
+		}
+		syncer.Lock()
+		virtualSelectedParent, err := syncer.VirtualSelectedParent()
+		syncer.Unlock()
+		if err != nil {
+			t.Fatalf("VirtualSelectedParent: %+v", err)
+		}
+		if !virtualSelectedParent.Equal(chain[len(chain)-1]) {
+			t.Fatalf("Expected the index to be synced with %s, but it's synced with %s",
+				chain[len(chain)-1], virtualSelectedParent)
+		}
+		if index.beginCount != index.finishCount {
+			t.Fatalf("BeginChainChanges was called %d times, but FinishChainChanges %d times",
+				index.beginCount, index.finishCount)
+		}
+	}
+
+	chainA := addChain(consensusConfig.GenesisHash, 3, false)
+	checkIndex(chainA)
+
+	// A longer chain from the genesis reorgs the index
+	chainB := addChain(consensusConfig.GenesisHash, 5, false)
+	checkIndex(chainB)
+
+	// Chain changes that don't continue from the index make it catch up
+	chainB = append(chainB, addChain(chainB[len(chainB)-1], 2, true)...)
+	chainB = append(chainB, addChain(chainB[len(chainB)-1], 1, false)...)
+	checkIndex(chainB)
+
+	// A syncer of an index that's already synced doesn't reset it
+	syncer = New("test index", testDomain, db, testVirtualSelectedParentKey, index.callbacks())
+	chainB = append(chainB, addChain(chainB[len(chainB)-1], 2, true)...)
+	err = syncer.CatchUp()
+	if err != nil {
+		t.Fatalf("CatchUp: %+v", err)
+	}
+	if index.resetCount != 1 {
+		t.Fatalf("Expected a synced index not to be reset, but it was reset %d times", index.resetCount)
+	}
+	checkIndex(chainB)
+
+	err = syncer.Reset()
+	if err != nil {
+		t.Fatalf("Reset: %+v", err)
+	}
+	if index.resetCount != 2 {
+		t.Fatalf("Expected the index to be reset twice, but it was reset %d times", index.resetCount)
+	}
+	checkIndex(chainB)
+
+	blueScore, err := syncer.VirtualSelectedParentBlueScore()
+	if err != nil {
+		t.Fatalf("VirtualSelectedParentBlueScore: %+v", err)
+	}
+	if blueScore != index.blueScores[*chainB[len(chainB)-1]] {
+		t.Fatalf("Unexpected virtual selected parent blue score %d", blueScore)
+	}
+}
+
+// interruptingConsensus calls interrupt whenever block info is read, which a
+// Syncer does between finding where the index stands and changing it
+type interruptingConsensus struct {
+	externalapi.Consensus
+	interrupt func()
+}
+
+func (ic *interruptingConsensus) GetBlockInfo(blockHash *externalapi.DomainHash) (*externalapi.BlockInfo, error) {
+	ic.interrupt()
+	return ic.Consensus.GetBlockInfo(blockHash)
+}
+
+func TestSyncerConcurrentCatchUp(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+	testConsensus, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig, "TestSyncerConcurrentCatchUp")
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardown(false)
+
+	db, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %+v", err)
+	}
+	defer db.Close()
+
+	index := newRecordingIndex(t)
+	interruptingConsensus := &interruptingConsensus{Consensus: testConsensus, interrupt: func() {}}
+	syncer := New("test index", &testDomain{consensus: interruptingConsensus}, db, testVirtualSelectedParentKey,
+		index.callbacks())
+	err = syncer.CatchUp()
+	if err != nil {
+		t.Fatalf("CatchUp: %+v", err)
+	}
+
+	chain := make([]*externalapi.DomainHash, 2)
+	chainChanges := make([]*externalapi.SelectedChainPath, len(chain))
+	parent := consensusConfig.GenesisHash
+	for i := range chain {
+		blockHash, virtualChangeSet, err := testConsensus.AddBlock([]*externalapi.DomainHash{parent}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		chain[i] = blockHash
+		chainChanges[i] = virtualChangeSet.VirtualSelectedParentChainChanges
+		parent = blockHash
+	}
+
+	// Once the first update starts, the index catches up concurrently. The
+	// update waits a while for the catch-up to finish, which it should only
+	// manage to do if it isn't kept out while the update is applied.
+	var isInterrupted uint32
+	catchUpDone := make(chan error, 1)
+	interruptingConsensus.interrupt = func() {
+		if !atomic.CompareAndSwapUint32(&isInterrupted, 0, 1) {
+			return
+		}
+		go func() {
+			catchUpDone <- syncer.CatchUp()
+		}()
+		select {
+		case err := <-catchUpDone:
+			catchUpDone <- err
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	for _, changes := range chainChanges {
+		err := syncer.Update(changes)
+		if err != nil {
+			t.Fatalf("Update: %+v", err)
+		}
+	}
+	err = <-catchUpDone
+	if err != nil {
+		t.Fatalf("CatchUp: %+v", err)
+	}
+
+	if len(index.blueScores) != len(chain) {
+		t.Fatalf("Expected the index to hold %d chain blocks, but it holds %d", len(chain), len(index.blueScores))
+	}
+	for _, blockHash := range chain {
+		if _, ok := index.blueScores[*blockHash]; !ok {
+			t.Fatalf("Chain block %s is missing from the index", blockHash)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package kafkasink
+
+import (
+	"encoding/json"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+// The types of the events, which is the type field of every event
+const (
+	eventTypeBlockAdded                        = "blockAdded"
+	eventTypeVirtualSelectedParentChainChanged = "virtualSelectedParentChainChanged"
+	eventTypeTransactionAddedToMempool         = "transactionAddedToMempool"
+	eventTypeTransactionRemovedFromMempool     = "transactionRemovedFromMempool"
+	eventTypeTransactionEvictedFromMempool     = "transactionEvictedFromMempool"
+	eventTypeTransactionConfirmed              = "transactionConfirmed"
+	eventTypeEventsDropped                     = "eventsDropped"
+)
+
+// event holds the fields that are common to all the events
+type event struct {
+	Type    string `json:"type"`
+	Network string `json:"network"`
+}
+
+type blockAddedEvent struct {
+	event
+	BlockHash      string   `json:"blockHash"`
+	Version        uint16   `json:"version"`
+	ParentHashes   []string `json:"parentHashes"`
+	Timestamp      int64    `json:"timestamp"`
+	Bits           uint32   `json:"bits"`
+	Nonce          uint64   `json:"nonce"`
+	DAAScore       uint64   `json:"daaScore"`
+	BlueScore      uint64   `json:"blueScore"`
+	BlueWork       string   `json:"blueWork"`
+	TransactionIDs []string `json:"transactionIds"`
+}
+
+type virtualSelectedParentChainChangedEvent struct {
+	event
+	RemovedChainBlockHashes []string `json:"removedChainBlockHashes"`
+	AddedChainBlockHashes   []string `json:"addedChainBlockHashes"`
+}
+
+// eventsDroppedEvent is published to a topic after some of its events were
+// dropped because the queue of the events that weren't published yet was full
+type eventsDroppedEvent struct {
+	event
+	DroppedEventCount uint64 `json:"droppedEventCount"`
+}
+
+type mempoolEvent struct {
+	event
+	TransactionID string `json:"transactionId"`
+
+	// Fee and Mass are set only for transactionAddedToMempool events
+	Fee  uint64 `json:"fee,omitempty"`
+	Mass uint64 `json:"mass,omitempty"`
+
+	// Reason is set only for transactionRemovedFromMempool and
+	// transactionEvictedFromMempool events
+	Reason string `json:"reason,omitempty"`
+
+	// BlockHash is set only for transactionConfirmed events
+	BlockHash string `json:"blockHash,omitempty"`
+}
+
+func newBlockAddedEvent(network string, block *externalapi.DomainBlock) *blockAddedEvent {
+	header := block.Header
+	return &blockAddedEvent{
+		event:          event{Type: eventTypeBlockAdded, Network: network},
+		BlockHash:      consensushashing.BlockHash(block).String(),
+		Version:        header.Version(),
+		ParentHashes:   hashStrings(header.DirectParents()),
+		Timestamp:      header.TimeInMilliseconds(),
+		Bits:           header.Bits(),
+		Nonce:          header.Nonce(),
+		DAAScore:       header.DAAScore(),
+		BlueScore:      header.BlueScore(),
+		BlueWork:       header.BlueWork().String(),
+		TransactionIDs: transactionIDStrings(block.Transactions),
+	}
+}
+
+func newVirtualSelectedParentChainChangedEvent(network string,
+	selectedParentChainChanges *externalapi.SelectedChainPath) *virtualSelectedParentChainChangedEvent {
+
+	return &virtualSelectedParentChainChangedEvent{
+		event:                   event{Type: eventTypeVirtualSelectedParentChainChanged, Network: network},
+		RemovedChainBlockHashes: hashStrings(selectedParentChainChanges.Removed),
+		AddedChainBlockHashes:   hashStrings(selectedParentChainChanges.Added),
+	}
+}
+
+func newMempoolEvent(eventType string, network string, transaction *externalapi.DomainTransaction) *mempoolEvent {
+	return &mempoolEvent{
+		event:         event{Type: eventType, Network: network},
+		TransactionID: consensushashing.TransactionID(transaction).String(),
+	}
+}
+
+// encodeEvent encodes an event as JSON. The events consist only of strings
+// and numbers, so they always encode successfully.
+func encodeEvent(event interface{}) []byte {
+	encodedEvent, err := json.Marshal(event)
+	if err != nil {
+		panic(err)
+	}
+	return encodedEvent
+}
+
+func hashStrings(hashes []*externalapi.DomainHash) []string {
+	strings := make([]string, len(hashes))
+	for i, hash := range hashes {
+		strings[i] = hash.String()
+	}
+	return strings
+}
+
+func transactionIDStrings(transactions []*externalapi.DomainTransaction) []string {
+	strings := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		strings[i] = consensushashing.TransactionID(transaction).String()
+	}
+	return strings
+}
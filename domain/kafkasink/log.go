@@ -0,0 +1,9 @@
+package kafkasink
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("KFKA")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,468 @@
+// Package kafkasink publishes the block, selected parent chain and mempool
+// events of the node to Kafka topics, as JSON, for stream processing
+// consumers.
+package kafkasink
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/network/kafka"
+)
+
+// The suffixes of the names of the topics the events are published to,
+// which follow the topic prefix
+const (
+	BlocksTopicSuffix  = ".blocks"
+	ChainTopicSuffix   = ".chain"
+	MempoolTopicSuffix = ".mempool"
+)
+
+// maxEventsPerRequest is the maximum amount of events that are published in
+// a single produce request
+const maxEventsPerRequest = 1000
+
+// requestTimeout is the time the brokers are given to acknowledge a produce
+// request
+const requestTimeout = 10 * time.Second
+
+// maxChainBlocksPerEvent is the maximum amount of added chain blocks in a
+// single chain event, which bounds the size of the events that catch up with
+// the chain changes missed while the queue was full
+const maxChainBlocksPerEvent = 1000
+
+// retryInterval is the time the sink waits before publishing again after an
+// error
+const retryInterval = 5 * time.Second
+
+// producer produces messages to Kafka, as kafka.Producer does
+type producer interface {
+	Produce(messages []*kafka.Message) error
+	Close()
+}
+
+// Sink publishes events to Kafka. Events are queued in the database as they
+// happen, and are removed from the queue only once all the in-sync replicas
+// of their partitions acknowledged them, so every queued event is delivered
+// at least once: the events of a failed produce request are published again,
+// even if the brokers got some of them, and the events that weren't
+// published before the node stopped are published once it starts again.
+//
+// The sink never holds up the processing of blocks and transactions. While
+// the queue is full, new events are dropped until it's half empty again. Then
+// an eventsDropped event, with the number of dropped events, is queued to
+// every topic that lost events, and the next chain event catches up with all
+// the chain changes since the last queued one. Events dropped by a node that
+// stops before its queue has room again aren't reported.
+//
+// Block and chain events are keyed by block hash, and mempool events by
+// transaction ID, so the events of the same block or transaction always go
+// to the same partition, in order.
+type Sink struct {
+	producer        producer
+	domain          domain.Domain
+	database        database.Database
+	network         string
+	blocksTopic     string
+	chainTopic      string
+	mempoolTopic    string
+	maxQueuedEvents int
+
+	lock               sync.Mutex
+	queue              []*queuedEvent
+	nextSequenceNumber uint64
+
+	// isDropping is set from the time the queue is full until it's half
+	// empty again. droppedEventCounts holds the number of events dropped
+	// meanwhile by topic, and droppedChainEventCount the number of chain
+	// events, which are caught up with rather than reported.
+	isDropping             bool
+	droppedEventCounts     map[string]uint64
+	droppedChainEventCount uint64
+
+	// lastQueuedChainBlock is the virtual selected parent of the last queued
+	// chain event, which the next chain event continues from. It's nil
+	// until the first chain event is queued.
+	lastQueuedChainBlock *externalapi.DomainHash
+
+	notifyChan chan struct{}
+	stopChan   chan struct{}
+	doneChan   chan struct{}
+}
+
+// New creates a new Sink that publishes the events of the given network to
+// the Kafka cluster of the given brokers, in topics whose names start with
+// topicPrefix. The events are queued in the given database until they're
+// published, and at most maxQueuedEvents of them are queued at once. The
+// chain changes are followed in the consensus of the given domain.
+func New(domain domain.Domain, db database.Database, brokers []string, topicPrefix string, network string,
+	maxQueuedEvents int) (*Sink, error) {
+
+	for _, topicSuffix := range []string{BlocksTopicSuffix, ChainTopicSuffix, MempoolTopicSuffix} {
+		err := kafka.ValidateTopicName(topicPrefix + topicSuffix)
+		if err != nil {
+			return nil, err
+		}
+	}
+	producer, err := kafka.NewProducer(brokers, "kaspad", requestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return newSink(domain, db, producer, topicPrefix, network, maxQueuedEvents)
+}
+
+func newSink(domain domain.Domain, db database.Database, producer producer, topicPrefix string, network string,
+	maxQueuedEvents int) (*Sink, error) {
+
+	queue, err := loadEvents(db)
+	if err != nil {
+		return nil, err
+	}
+	nextSequenceNumber := uint64(0)
+	if len(queue) > 0 {
+		nextSequenceNumber = queue[len(queue)-1].sequenceNumber + 1
+		log.Infof("Publishing %d events that weren't published to Kafka before the node stopped", len(queue))
+	}
+
+	sink := &Sink{
+		producer:           producer,
+		domain:             domain,
+		database:           db,
+		network:            network,
+		blocksTopic:        topicPrefix + BlocksTopicSuffix,
+		chainTopic:         topicPrefix + ChainTopicSuffix,
+		mempoolTopic:       topicPrefix + MempoolTopicSuffix,
+		maxQueuedEvents:    maxQueuedEvents,
+		queue:              queue,
+		nextSequenceNumber: nextSequenceNumber,
+		droppedEventCounts: make(map[string]uint64),
+		notifyChan:         make(chan struct{}, 1),
+		stopChan:           make(chan struct{}),
+		doneChan:           make(chan struct{}),
+	}
+	return sink, nil
+}
+
+// Start begins publishing the queued events
+func (s *Sink) Start() {
+	spawn("kafkasink.Sink.publishLoop", s.publishLoop)
+	s.wake()
+}
+
+// Stop stops publishing, after a last attempt to publish the queued events.
+// Events that happen later are still queued, and are published once the node
+// starts again.
+func (s *Sink) Stop() {
+	close(s.stopChan)
+	<-s.doneChan
+}
+
+// NotifyBlockAdded queues a blockAdded event for a block that was added to
+// the DAG
+func (s *Sink) NotifyBlockAdded(block *externalapi.DomainBlock) {
+	event := newBlockAddedEvent(s.network, block)
+	s.enqueue(&kafka.Message{Topic: s.blocksTopic, Key: []byte(event.BlockHash), Value: encodeEvent(event)})
+}
+
+// NotifyVirtualSelectedParentChainChanged queues
+// virtualSelectedParentChainChanged events, keyed by the new virtual
+// selected parent of each. The events hold the chain changes since the last
+// queued chain event, so that the chain events that were dropped while the
+// queue was full are caught up with, rather than the given changes alone.
+func (s *Sink) NotifyVirtualSelectedParentChainChanged(selectedParentChainChanges *externalapi.SelectedChainPath) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.hasRoomFor(1) {
+		s.droppedChainEventCount++
+		return
+	}
+
+	if s.lastQueuedChainBlock != nil {
+		chainChanges, err := s.domain.Consensus().GetVirtualSelectedParentChainFromBlock(s.lastQueuedChainBlock)
+		if err != nil {
+			log.Warnf("Error getting the chain changes since the last chain event queued for Kafka, "+
+				"publishing only the latest ones: %s", err)
+		} else {
+			selectedParentChainChanges = chainChanges
+			s.droppedChainEventCount = 0
+		}
+	}
+	// The dropped chain events that weren't caught up with are reported
+	// instead, before the given changes
+	if s.droppedChainEventCount > 0 {
+		s.queueEvents([]*kafka.Message{s.eventsDroppedMessage(s.chainTopic, s.droppedChainEventCount)})
+		s.droppedChainEventCount = 0
+	}
+
+	// The removed chain blocks are all in the first event
+	removed := selectedParentChainChanges.Removed
+	added := selectedParentChainChanges.Added
+	var messages []*kafka.Message
+	for len(added) > 0 {
+		eventAdded := added
+		if len(eventAdded) > maxChainBlocksPerEvent {
+			eventAdded = eventAdded[:maxChainBlocksPerEvent]
+		}
+		virtualSelectedParent := eventAdded[len(eventAdded)-1]
+		event := newVirtualSelectedParentChainChangedEvent(s.network, &externalapi.SelectedChainPath{
+			Removed: removed,
+			Added:   eventAdded,
+		})
+		messages = append(messages, &kafka.Message{
+			Topic: s.chainTopic,
+			Key:   []byte(virtualSelectedParent.String()),
+			Value: encodeEvent(event),
+		})
+		s.lastQueuedChainBlock = virtualSelectedParent
+
+		removed = nil
+		added = added[len(eventAdded):]
+	}
+	s.queueEvents(messages)
+}
+
+// NotifyTransactionsAddedToMempool queues a transactionAddedToMempool event
+// for every given transaction
+func (s *Sink) NotifyTransactionsAddedToMempool(transactions []*externalapi.DomainTransaction) {
+	messages := make([]*kafka.Message, len(transactions))
+	for i, transaction := range transactions {
+		event := newMempoolEvent(eventTypeTransactionAddedToMempool, s.network, transaction)
+		event.Fee = transaction.Fee
+		event.Mass = transaction.Mass
+		messages[i] = s.mempoolMessage(event)
+	}
+	s.enqueue(messages...)
+}
+
+// NotifyTransactionsRemovedFromMempool queues a transactionRemovedFromMempool
+// event for every given transaction
+func (s *Sink) NotifyTransactionsRemovedFromMempool(removedTransactions []*miningmanagermodel.RemovedTransaction) {
+	messages := make([]*kafka.Message, len(removedTransactions))
+	for i, removedTransaction := range removedTransactions {
+		event := newMempoolEvent(eventTypeTransactionRemovedFromMempool, s.network, removedTransaction.Transaction)
+		event.Reason = removedTransaction.Reason
+		messages[i] = s.mempoolMessage(event)
+	}
+	s.enqueue(messages...)
+}
+
+// NotifyTransactionsEvicted queues a transactionEvictedFromMempool event for
+// every given transaction
+func (s *Sink) NotifyTransactionsEvicted(evictedTransactions []*miningmanagermodel.EvictedTransaction) {
+	messages := make([]*kafka.Message, len(evictedTransactions))
+	for i, evictedTransaction := range evictedTransactions {
+		event := newMempoolEvent(eventTypeTransactionEvictedFromMempool, s.network, evictedTransaction.Transaction)
+		event.Reason = evictedTransaction.Reason
+		messages[i] = s.mempoolMessage(event)
+	}
+	s.enqueue(messages...)
+}
+
+// NotifyTransactionsConfirmed queues a transactionConfirmed event for every
+// given mempool transaction that the given block included
+func (s *Sink) NotifyTransactionsConfirmed(blockHash *externalapi.DomainHash,
+	transactions []*externalapi.DomainTransaction) {
+
+	messages := make([]*kafka.Message, len(transactions))
+	for i, transaction := range transactions {
+		event := newMempoolEvent(eventTypeTransactionConfirmed, s.network, transaction)
+		event.BlockHash = blockHash.String()
+		messages[i] = s.mempoolMessage(event)
+	}
+	s.enqueue(messages...)
+}
+
+func (s *Sink) mempoolMessage(event *mempoolEvent) *kafka.Message {
+	return &kafka.Message{Topic: s.mempoolTopic, Key: []byte(event.TransactionID), Value: encodeEvent(event)}
+}
+
+// enqueue queues the given messages, and wakes the publish loop up. It never
+// blocks: while the queue is full, the messages are dropped, and counted by
+// topic.
+func (s *Sink) enqueue(messages ...*kafka.Message) {
+	if len(messages) == 0 {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.hasRoomFor(len(messages)) {
+		for _, message := range messages {
+			s.droppedEventCounts[message.Topic]++
+		}
+		return
+	}
+	s.queueEvents(messages)
+}
+
+// hasRoomFor returns whether the given number of events may be queued. Once
+// the queue is full, events are dropped until it's half empty again, rather
+// than every time it's full, so that a slow Kafka cluster doesn't get an
+// eventsDropped event after every produce request. When events stop being
+// dropped, an eventsDropped event is queued to every topic that lost events.
+// The sink must be locked while this is called.
+func (s *Sink) hasRoomFor(eventCount int) bool {
+	if s.isDropping {
+		if len(s.queue) > s.maxQueuedEvents/2 {
+			return false
+		}
+		s.isDropping = false
+		s.queueEventsDropped()
+	}
+
+	// An empty queue takes any amount of events, so that more events than
+	// fit in the queue aren't always dropped
+	if len(s.queue) > 0 && len(s.queue)+eventCount > s.maxQueuedEvents {
+		log.Warnf("The queue of the events that weren't published to Kafka yet is full. " +
+			"New events are dropped until it's half empty again")
+		s.isDropping = true
+		return false
+	}
+	return true
+}
+
+// queueEventsDropped queues an eventsDropped event to every topic that lost
+// events since the queue was full. Chain events aren't reported, since the
+// next chain event catches up with them.
+func (s *Sink) queueEventsDropped() {
+	var messages []*kafka.Message
+	droppedEventCount := uint64(0)
+	for _, topic := range []string{s.blocksTopic, s.mempoolTopic} {
+		if s.droppedEventCounts[topic] == 0 {
+			continue
+		}
+		messages = append(messages, s.eventsDroppedMessage(topic, s.droppedEventCounts[topic]))
+		droppedEventCount += s.droppedEventCounts[topic]
+		delete(s.droppedEventCounts, topic)
+	}
+	log.Infof("The queue of the events that weren't published to Kafka yet has room again, "+
+		"after %d block and mempool events and %d chain events were dropped",
+		droppedEventCount, s.droppedChainEventCount)
+	s.queueEvents(messages)
+}
+
+// eventsDroppedMessage returns the message of an eventsDropped event of the
+// given topic. It's keyed by its event type, so all the eventsDropped events
+// of a topic go to the same partition.
+func (s *Sink) eventsDroppedMessage(topic string, droppedEventCount uint64) *kafka.Message {
+	event := &eventsDroppedEvent{
+		event:             event{Type: eventTypeEventsDropped, Network: s.network},
+		DroppedEventCount: droppedEventCount,
+	}
+	return &kafka.Message{Topic: topic, Key: []byte(eventTypeEventsDropped), Value: encodeEvent(event)}
+}
+
+// queueEvents stores the given messages in the database, adds them to the
+// queue, and wakes the publish loop up. The sink must be locked while this
+// is called.
+func (s *Sink) queueEvents(messages []*kafka.Message) {
+	if len(messages) == 0 {
+		return
+	}
+
+	events := make([]*queuedEvent, len(messages))
+	for i, message := range messages {
+		events[i] = &queuedEvent{sequenceNumber: s.nextSequenceNumber, message: message}
+		s.nextSequenceNumber++
+	}
+	err := storeEvents(s.database, events)
+	if err != nil {
+		// The events are still published, unless the node stops first
+		log.Errorf("Error storing %d events that weren't published to Kafka yet: %s", len(events), err)
+	}
+	s.queue = append(s.queue, events...)
+
+	s.wake()
+}
+
+func (s *Sink) wake() {
+	select {
+	case s.notifyChan <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Sink) publishLoop() {
+	defer close(s.doneChan)
+	defer s.producer.Close()
+
+	for {
+		select {
+		case <-s.stopChan:
+			s.publishBeforeStop()
+			return
+		case <-s.notifyChan:
+		}
+
+		err := s.publishQueue()
+		if err != nil {
+			log.Errorf("Error publishing events to Kafka, retrying in %s: %s", retryInterval, err)
+			select {
+			case <-s.stopChan:
+				s.publishBeforeStop()
+				return
+			case <-time.After(retryInterval):
+				s.wake()
+			}
+		}
+	}
+}
+
+// publishQueue publishes the queued events until the queue is empty. The
+// events are removed from the queue only after they're acknowledged, and
+// since events are only ever appended to the queue meanwhile, the
+// acknowledged ones are still at its head.
+func (s *Sink) publishQueue() error {
+	for {
+		s.lock.Lock()
+		events := s.queue
+		if len(events) > maxEventsPerRequest {
+			events = events[:maxEventsPerRequest]
+		}
+		s.lock.Unlock()
+		if len(events) == 0 {
+			return nil
+		}
+
+		messages := make([]*kafka.Message, len(events))
+		for i, event := range events {
+			messages[i] = event.message
+		}
+		err := s.producer.Produce(messages)
+		if err != nil {
+			return err
+		}
+		log.Debugf("Published %d events to Kafka", len(events))
+
+		err = deleteEvents(s.database, events)
+		if err != nil {
+			// The events are published again once the node starts again,
+			// which consumers have to expect anyway
+			log.Errorf("Error deleting %d published events from the database: %s", len(events), err)
+		}
+
+		s.lock.Lock()
+		s.queue = s.queue[len(events):]
+		if len(s.queue) == 0 {
+			s.queue = nil
+		}
+		s.lock.Unlock()
+	}
+}
+
+func (s *Sink) publishBeforeStop() {
+	err := s.publishQueue()
+	if err != nil {
+		s.lock.Lock()
+		unpublishedEventCount := len(s.queue)
+		s.lock.Unlock()
+		log.Warnf("%d events weren't published to Kafka before stopping, and are published "+
+			"once the node starts again: %s", unpublishedEventCount, err)
+	}
+}
@@ -0,0 +1,393 @@
+package kafkasink
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/network/kafka"
+	"github.com/pkg/errors"
+)
+
+// fakeProducer records the messages it's given, or fails while shouldFail
+// is set
+type fakeProducer struct {
+	lock       sync.Mutex
+	shouldFail bool
+	messages   []*kafka.Message
+}
+
+func (p *fakeProducer) Produce(messages []*kafka.Message) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.shouldFail {
+		return errors.New("failing")
+	}
+	p.messages = append(p.messages, messages...)
+	return nil
+}
+
+func (p *fakeProducer) Close() {}
+
+func (p *fakeProducer) setShouldFail(shouldFail bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.shouldFail = shouldFail
+}
+
+func (p *fakeProducer) producedMessages() []*kafka.Message {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.messages
+}
+
+// testDomain is a domain whose consensus is a test consensus
+type testDomain struct {
+	domain.Domain
+	consensus externalapi.Consensus
+}
+
+func (td *testDomain) Consensus() externalapi.Consensus {
+	return td.consensus
+}
+
+func newTestDatabase(t *testing.T) database.Database {
+	db, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSink(t *testing.T) {
+	producer := &fakeProducer{}
+	topicPrefix := "kaspad"
+	sink, err := newSink(nil, newTestDatabase(t), producer, topicPrefix, "kaspa-simnet", 100)
+	if err != nil {
+		t.Fatalf("newSink: %s", err)
+	}
+	sink.Start()
+
+	transaction := &externalapi.DomainTransaction{Fee: 1000, Mass: 2000}
+	transactionID := consensushashing.TransactionID(transaction).String()
+	blockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	sink.NotifyTransactionsAddedToMempool([]*externalapi.DomainTransaction{transaction})
+	sink.NotifyTransactionsConfirmed(blockHash, []*externalapi.DomainTransaction{transaction})
+	sink.NotifyVirtualSelectedParentChainChanged(&externalapi.SelectedChainPath{
+		Added: []*externalapi.DomainHash{blockHash},
+	})
+	sink.Stop()
+
+	messages := producer.producedMessages()
+	if len(messages) != 3 {
+		t.Fatalf("Expected 3 messages, but got %d", len(messages))
+	}
+
+	expectedMessages := []struct {
+		topic     string
+		key       string
+		eventType string
+	}{
+		{topic: topicPrefix + MempoolTopicSuffix, key: transactionID, eventType: eventTypeTransactionAddedToMempool},
+		{topic: topicPrefix + MempoolTopicSuffix, key: transactionID, eventType: eventTypeTransactionConfirmed},
+		{topic: topicPrefix + ChainTopicSuffix, key: blockHash.String(), eventType: eventTypeVirtualSelectedParentChainChanged},
+	}
+	for i, expectedMessage := range expectedMessages {
+		message := messages[i]
+		if message.Topic != expectedMessage.topic || string(message.Key) != expectedMessage.key {
+			t.Fatalf("Message %d: expected topic %s and key %s, but got topic %s and key %s",
+				i, expectedMessage.topic, expectedMessage.key, message.Topic, message.Key)
+		}
+		var event mempoolEvent
+		err := json.Unmarshal(message.Value, &event)
+		if err != nil {
+			t.Fatalf("Message %d: error decoding the event: %s", i, err)
+		}
+		if event.Type != expectedMessage.eventType || event.Network != "kaspa-simnet" {
+			t.Fatalf("Message %d: unexpected event %s", i, message.Value)
+		}
+	}
+
+	var addedEvent mempoolEvent
+	err = json.Unmarshal(messages[0].Value, &addedEvent)
+	if err != nil {
+		t.Fatalf("Error decoding the event: %s", err)
+	}
+	if addedEvent.Fee != transaction.Fee || addedEvent.Mass != transaction.Mass {
+		t.Fatalf("Unexpected transactionAddedToMempool event %s", messages[0].Value)
+	}
+}
+
+func TestSinkRetriesFailedEvents(t *testing.T) {
+	db := newTestDatabase(t)
+	producer := &fakeProducer{}
+	sink, err := newSink(nil, db, producer, "kaspad", "kaspa-simnet", 100)
+	if err != nil {
+		t.Fatalf("newSink: %s", err)
+	}
+
+	removedTransactions := make([]*miningmanagermodel.RemovedTransaction, 3)
+	for i := range removedTransactions {
+		removedTransactions[i] = &miningmanagermodel.RemovedTransaction{
+			Transaction: &externalapi.DomainTransaction{LockTime: uint64(i)},
+			Reason:      "test",
+		}
+	}
+
+	// The events stay queued until Kafka acknowledges them
+	producer.setShouldFail(true)
+	sink.NotifyTransactionsRemovedFromMempool(removedTransactions[:2])
+	err = sink.publishQueue()
+	if err == nil {
+		t.Fatalf("Expected publishing to fail")
+	}
+	if len(sink.queue) != 2 {
+		t.Fatalf("Expected the events to remain queued, but %d are", len(sink.queue))
+	}
+
+	// The queued events outlive the sink, and are published by the next one
+	// after the events it had before
+	restartedSink, err := newSink(nil, db, producer, "kaspad", "kaspa-simnet", 100)
+	if err != nil {
+		t.Fatalf("newSink: %s", err)
+	}
+	if len(restartedSink.queue) != 2 {
+		t.Fatalf("Expected 2 events to be loaded from the database, but %d were", len(restartedSink.queue))
+	}
+	restartedSink.NotifyTransactionsRemovedFromMempool(removedTransactions[2:])
+
+	producer.setShouldFail(false)
+	err = restartedSink.publishQueue()
+	if err != nil {
+		t.Fatalf("publishQueue: %s", err)
+	}
+	if len(restartedSink.queue) != 0 {
+		t.Fatalf("Expected the queue to be empty, but it has %d events", len(restartedSink.queue))
+	}
+	messages := producer.producedMessages()
+	if len(messages) != 3 {
+		t.Fatalf("Expected 3 messages, but got %d", len(messages))
+	}
+	for i, message := range messages {
+		expectedKey := consensushashing.TransactionID(removedTransactions[i].Transaction).String()
+		if string(message.Key) != expectedKey {
+			t.Fatalf("Message %d: expected key %s, but got %s", i, expectedKey, message.Key)
+		}
+	}
+
+	// The published events are deleted from the database
+	events, err := loadEvents(db)
+	if err != nil {
+		t.Fatalf("loadEvents: %s", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Expected the published events to be deleted, but %d are left", len(events))
+	}
+}
+
+// Make sure that events are dropped rather than waited for room while the
+// queue is full, and that the dropped events are reported once it's half
+// empty again
+func TestSinkDropsEventsWhileFull(t *testing.T) {
+	producer := &fakeProducer{shouldFail: true}
+	const maxQueuedEvents = 4
+	sink, err := newSink(nil, newTestDatabase(t), producer, "kaspad", "kaspa-simnet", maxQueuedEvents)
+	if err != nil {
+		t.Fatalf("newSink: %s", err)
+	}
+
+	evictedTransactions := make([]*miningmanagermodel.EvictedTransaction, 6)
+	for i := range evictedTransactions {
+		evictedTransactions[i] = &miningmanagermodel.EvictedTransaction{
+			Transaction: &externalapi.DomainTransaction{LockTime: uint64(i)},
+			Reason:      "test",
+		}
+	}
+	sink.NotifyTransactionsEvicted(evictedTransactions[:4])
+
+	notifiedChan := make(chan struct{})
+	go func() {
+		sink.NotifyTransactionsEvicted(evictedTransactions[4:5])
+		close(notifiedChan)
+	}()
+	select {
+	case <-notifiedChan:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the event to be dropped rather than wait for room in the queue")
+	}
+	if len(sink.queue) != 4 {
+		t.Fatalf("Expected 4 queued events, but got %d", len(sink.queue))
+	}
+
+	// Events are dropped until the queue is half empty, even if they'd fit
+	sink.lock.Lock()
+	sink.queue = sink.queue[1:]
+	sink.lock.Unlock()
+	sink.NotifyTransactionsEvicted(evictedTransactions[4:5])
+	if len(sink.queue) != 3 {
+		t.Fatalf("Expected the event to be dropped while the queue is more than half full, "+
+			"but %d events are queued", len(sink.queue))
+	}
+
+	producer.setShouldFail(false)
+	err = sink.publishQueue()
+	if err != nil {
+		t.Fatalf("publishQueue: %s", err)
+	}
+	sink.NotifyTransactionsEvicted(evictedTransactions[5:])
+	if len(sink.queue) != 2 {
+		t.Fatalf("Expected an eventsDropped event and the new event to be queued, but %d events are",
+			len(sink.queue))
+	}
+
+	var droppedEvent eventsDroppedEvent
+	err = json.Unmarshal(sink.queue[0].message.Value, &droppedEvent)
+	if err != nil {
+		t.Fatalf("Error decoding the event: %s", err)
+	}
+	if droppedEvent.Type != eventTypeEventsDropped || droppedEvent.DroppedEventCount != 2 ||
+		sink.queue[0].message.Topic != sink.mempoolTopic {
+
+		t.Fatalf("Unexpected eventsDropped event %s in topic %s", sink.queue[0].message.Value,
+			sink.queue[0].message.Topic)
+	}
+	expectedKey := consensushashing.TransactionID(evictedTransactions[5].Transaction).String()
+	if string(sink.queue[1].message.Key) != expectedKey {
+		t.Fatalf("Expected the new event to be queued after the eventsDropped event")
+	}
+}
+
+// Make sure that the chain events dropped while the queue is full are caught
+// up with by the next chain event, rather than reported as dropped
+func TestSinkCatchesUpWithChainChanges(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+	testConsensus, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig,
+		"TestSinkCatchesUpWithChainChanges")
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardown(false)
+
+	producer := &fakeProducer{shouldFail: true}
+	sink, err := newSink(&testDomain{consensus: testConsensus}, newTestDatabase(t), producer,
+		"kaspad", "kaspa-simnet", 2)
+	if err != nil {
+		t.Fatalf("newSink: %s", err)
+	}
+
+	var chain []*externalapi.DomainHash
+	addBlock := func() {
+		parent := consensusConfig.GenesisHash
+		if len(chain) > 0 {
+			parent = chain[len(chain)-1]
+		}
+		blockHash, virtualChangeSet, err := testConsensus.AddBlock([]*externalapi.DomainHash{parent}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		chain = append(chain, blockHash)
+		sink.NotifyVirtualSelectedParentChainChanged(virtualChangeSet.VirtualSelectedParentChainChanges)
+	}
+
+	// Two chain events fill the queue, and the next two are dropped
+	for i := 0; i < 4; i++ {
+		addBlock()
+	}
+	if len(sink.queue) != 2 {
+		t.Fatalf("Expected 2 queued events, but got %d", len(sink.queue))
+	}
+
+	producer.setShouldFail(false)
+	err = sink.publishQueue()
+	if err != nil {
+		t.Fatalf("publishQueue: %s", err)
+	}
+	addBlock()
+	err = sink.publishQueue()
+	if err != nil {
+		t.Fatalf("publishQueue: %s", err)
+	}
+
+	messages := producer.producedMessages()
+	if len(messages) != 3 {
+		t.Fatalf("Expected 3 chain events, but got %d", len(messages))
+	}
+	expectedAddedChainBlocks := [][]*externalapi.DomainHash{chain[:1], chain[1:2], chain[2:]}
+	for i, message := range messages {
+		var event virtualSelectedParentChainChangedEvent
+		err := json.Unmarshal(message.Value, &event)
+		if err != nil {
+			t.Fatalf("Message %d: error decoding the event: %s", i, err)
+		}
+		if event.Type != eventTypeVirtualSelectedParentChainChanged {
+			t.Fatalf("Message %d: unexpected event %s", i, message.Value)
+		}
+		expectedAdded := hashStrings(expectedAddedChainBlocks[i])
+		if len(event.RemovedChainBlockHashes) != 0 || len(event.AddedChainBlockHashes) != len(expectedAdded) {
+			t.Fatalf("Message %d: expected the added chain blocks %v, but got %s", i, expectedAdded, message.Value)
+		}
+		for j := range expectedAdded {
+			if event.AddedChainBlockHashes[j] != expectedAdded[j] {
+				t.Fatalf("Message %d: expected the added chain blocks %v, but got %s", i, expectedAdded, message.Value)
+			}
+		}
+		if string(message.Key) != expectedAdded[len(expectedAdded)-1] {
+			t.Fatalf("Message %d: expected the key %s, but got %s", i, expectedAdded[len(expectedAdded)-1], message.Key)
+		}
+	}
+}
+
+func TestNewValidatesTopicPrefix(t *testing.T) {
+	db := newTestDatabase(t)
+	_, err := New(nil, db, []string{"localhost:9092"}, "kaspad events", "kaspa-simnet", 100)
+	if err == nil {
+		t.Fatalf("Expected New to fail with an invalid topic prefix")
+	}
+	_, err = New(nil, db, nil, "kaspad", "kaspa-simnet", 100)
+	if err == nil {
+		t.Fatalf("Expected New to fail without brokers")
+	}
+}
+
+// Make sure that a stopped sink doesn't wait for the retry interval, and
+// that it keeps queueing events
+func TestSinkStopWhileFailing(t *testing.T) {
+	producer := &fakeProducer{shouldFail: true}
+	db := newTestDatabase(t)
+	sink, err := newSink(nil, db, producer, "kaspad", "kaspa-simnet", 100)
+	if err != nil {
+		t.Fatalf("newSink: %s", err)
+	}
+	sink.Start()
+	evictedTransactions := []*miningmanagermodel.EvictedTransaction{
+		{Transaction: &externalapi.DomainTransaction{}, Reason: "test"},
+		{Transaction: &externalapi.DomainTransaction{LockTime: 1}, Reason: "test"},
+	}
+	sink.NotifyTransactionsEvicted(evictedTransactions[:1])
+
+	start := time.Now()
+	sink.Stop()
+	if time.Since(start) >= retryInterval {
+		t.Fatalf("Stop waited for the retry interval")
+	}
+
+	sink.NotifyTransactionsEvicted(evictedTransactions[1:])
+	events, err := loadEvents(db)
+	if err != nil {
+		t.Fatalf("loadEvents: %s", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events to be kept for the next start, but %d are", len(events))
+	}
+}
@@ -0,0 +1,124 @@
+package kafkasink
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/network/kafka"
+	"github.com/pkg/errors"
+)
+
+// queueBucket holds the events that weren't published yet, keyed by their
+// big-endian sequence number so that a cursor goes over them in order
+var queueBucket = database.MakeBucket([]byte("kafka-sink-queue"))
+
+// queuedEvent is an event that's kept in the database until Kafka
+// acknowledges it
+type queuedEvent struct {
+	sequenceNumber uint64
+	message        *kafka.Message
+}
+
+func queuedEventKey(sequenceNumber uint64) *database.Key {
+	var keySuffix [8]byte
+	binary.BigEndian.PutUint64(keySuffix[:], sequenceNumber)
+	return queueBucket.Key(keySuffix[:])
+}
+
+// storeEvents adds the given events to the database, in a single transaction
+func storeEvents(db database.Database, events []*queuedEvent) error {
+	dbTx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTx.RollbackUnlessClosed()
+
+	for _, event := range events {
+		err := dbTx.Put(queuedEventKey(event.sequenceNumber), serializeMessage(event.message))
+		if err != nil {
+			return err
+		}
+	}
+	return dbTx.Commit()
+}
+
+// deleteEvents removes the given events from the database, in a single
+// transaction
+func deleteEvents(db database.Database, events []*queuedEvent) error {
+	dbTx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTx.RollbackUnlessClosed()
+
+	for _, event := range events {
+		err := dbTx.Delete(queuedEventKey(event.sequenceNumber))
+		if err != nil {
+			return err
+		}
+	}
+	return dbTx.Commit()
+}
+
+// loadEvents returns the events in the database, oldest first
+func loadEvents(db database.Database) ([]*queuedEvent, error) {
+	cursor, err := db.Cursor(queueBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var events []*queuedEvent
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		if len(key.Suffix()) != 8 {
+			return nil, errors.Errorf("the key of a queued Kafka event is %d bytes rather than 8",
+				len(key.Suffix()))
+		}
+		// The cursor reuses the memory of the value on the next call to Next
+		message, err := deserializeMessage(append([]byte(nil), value...))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &queuedEvent{
+			sequenceNumber: binary.BigEndian.Uint64(key.Suffix()),
+			message:        message,
+		})
+	}
+	return events, nil
+}
+
+// serializeMessage serializes a message as its topic, key and value, each
+// preceded by its length as a uvarint
+func serializeMessage(message *kafka.Message) []byte {
+	serializedMessage := make([]byte, 0,
+		3*binary.MaxVarintLen64+len(message.Topic)+len(message.Key)+len(message.Value))
+	for _, field := range [][]byte{[]byte(message.Topic), message.Key, message.Value} {
+		serializedMessage = binary.AppendUvarint(serializedMessage, uint64(len(field)))
+		serializedMessage = append(serializedMessage, field...)
+	}
+	return serializedMessage
+}
+
+func deserializeMessage(serializedMessage []byte) (*kafka.Message, error) {
+	var fields [3][]byte
+	for i := range fields {
+		length, lengthSize := binary.Uvarint(serializedMessage)
+		if lengthSize <= 0 || length > uint64(len(serializedMessage)-lengthSize) {
+			return nil, errors.New("a queued Kafka event is malformed")
+		}
+		fields[i] = serializedMessage[lengthSize : lengthSize+int(length)]
+		serializedMessage = serializedMessage[lengthSize+int(length):]
+	}
+	if len(serializedMessage) != 0 {
+		return nil, errors.New("a queued Kafka event has trailing bytes")
+	}
+	return &kafka.Message{Topic: string(fields[0]), Key: fields[1], Value: fields[2]}, nil
+}
@@ -0,0 +1,79 @@
+package stratum
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strconv"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+)
+
+// job is a block template that's served to miners. Miners search for a nonce
+// given the pre-PoW hash of the template, which is the hash of its header
+// with a zero timestamp and nonce, and its timestamp.
+type job struct {
+	id       uint64
+	template *externalapi.DomainBlock
+	powState *pow.State
+
+	// headerWords are the pre-PoW hash as four little-endian words, as
+	// mining.notify sends it
+	headerWords []uint64
+
+	// submittedNonces are the nonces of the shares that were submitted for
+	// this job, so that a share is never counted twice
+	submittedNonces map[uint64]struct{}
+}
+
+func newJob(id uint64, template *externalapi.DomainBlock) *job {
+	header := template.Header.ToMutable()
+	powState := pow.NewState(header)
+
+	header.SetTimeInMilliseconds(0)
+	header.SetNonce(0)
+	prePowHash := consensushashing.HeaderHash(header).ByteSlice()
+	headerWords := make([]uint64, len(prePowHash)/8)
+	for i := range headerWords {
+		headerWords[i] = binary.LittleEndian.Uint64(prePowHash[i*8:])
+	}
+
+	return &job{
+		id:              id,
+		template:        template,
+		powState:        powState,
+		headerWords:     headerWords,
+		submittedNonces: make(map[uint64]struct{}),
+	}
+}
+
+// notifyParams returns the params of the mining.notify call that serves this
+// job
+func (j *job) notifyParams() []interface{} {
+	return []interface{}{strconv.FormatUint(j.id, 10), j.headerWords, j.template.Header.TimeInMilliseconds()}
+}
+
+// proofOfWorkValue returns the proof of work value of the template with the
+// given nonce
+func (j *job) proofOfWorkValue(nonce uint64) *big.Int {
+	powState := *j.powState
+	powState.Nonce = nonce
+	return powState.CalculateProofOfWorkValue()
+}
+
+// blockTarget returns the highest proof of work value a block of this job
+// may have
+func (j *job) blockTarget() *big.Int {
+	return &j.powState.Target
+}
+
+// block returns the block of this job with the given nonce
+func (j *job) block(nonce uint64) *externalapi.DomainBlock {
+	header := j.template.Header.ToMutable()
+	header.SetNonce(nonce)
+	return &externalapi.DomainBlock{
+		Header:       header.ToImmutable(),
+		Transactions: j.template.Transactions,
+	}
+}
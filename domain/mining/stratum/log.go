@@ -0,0 +1,9 @@
+package stratum
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("STRM")
+var spawn = panics.GoroutineWrapperFunc(log)
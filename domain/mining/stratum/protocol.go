@@ -0,0 +1,135 @@
+package stratum
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The methods the server handles, which miners call
+const (
+	methodSubscribe           = "mining.subscribe"
+	methodExtranonceSubscribe = "mining.extranonce.subscribe"
+	methodAuthorize           = "mining.authorize"
+	methodSubmit              = "mining.submit"
+)
+
+// The methods the server calls, which miners handle
+const (
+	methodSetExtranonce = "mining.set_extranonce"
+	methodSetDifficulty = "mining.set_difficulty"
+	methodNotify        = "mining.notify"
+)
+
+// protocolVersion is the version of the protocol the server speaks, which it
+// answers mining.subscribe with
+const protocolVersion = "EthereumStratum/1.0.0"
+
+// The error codes of the errors the server answers with
+const (
+	errorCodeOther         = 20
+	errorCodeJobNotFound   = 21
+	errorCodeDuplicate     = 22
+	errorCodeLowDifficulty = 23
+	errorCodeUnauthorized  = 24
+)
+
+// request is a call of a method of the server by a miner
+type request struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// response is the answer of the server to a request. Error is nil if the
+// request succeeded, and otherwise an array of the error code, the error
+// message and a traceback, which is always null.
+type response struct {
+	ID     json.RawMessage `json:"id"`
+	Result interface{}     `json:"result"`
+	Error  []interface{}   `json:"error"`
+}
+
+// notification is a call of a method of a miner by the server
+type notification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// stratumError is an error that's sent to the miner with its code
+type stratumError struct {
+	code    int
+	message string
+}
+
+func (e *stratumError) Error() string {
+	return e.message
+}
+
+func newStratumError(code int, format string, args ...interface{}) *stratumError {
+	return &stratumError{code: code, message: errors.Errorf(format, args...).Error()}
+}
+
+// difficulty1Target is the share target of difficulty 1, which is the
+// difficulty 1 target of Bitcoin, as most mining software expects
+var difficulty1Target = new(big.Int).Lsh(big.NewInt(0xffff), 208)
+
+// ValidateShareDifficulty returns an error if the given share difficulty
+// can't be set to miners
+func ValidateShareDifficulty(shareDifficulty float64) error {
+	if shareDifficulty <= 0 || math.IsInf(shareDifficulty, 0) || math.IsNaN(shareDifficulty) {
+		return errors.Errorf("the share difficulty must be a positive number, but got %f", shareDifficulty)
+	}
+	return nil
+}
+
+// shareTarget returns the highest proof of work value a share of the given
+// difficulty may have
+func shareTarget(shareDifficulty float64) *big.Int {
+	target, _ := new(big.Float).Quo(new(big.Float).SetInt(difficulty1Target), big.NewFloat(shareDifficulty)).Int(nil)
+	return target
+}
+
+// parseNonce parses the nonce of a share, which is given in hex. A nonce that
+// is short enough to not include the extranonce of the session is prefixed
+// by it.
+func parseNonce(nonceString string, extranonce string) (uint64, error) {
+	nonceString = strings.TrimPrefix(nonceString, "0x")
+	const nonceHexLength = 16
+	extranonce2Length := nonceHexLength - len(extranonce)
+	if len(nonceString) <= extranonce2Length {
+		nonceString = extranonce + strings.Repeat("0", extranonce2Length-len(nonceString)) + nonceString
+	}
+	if len(nonceString) != nonceHexLength {
+		return 0, errors.Errorf("the nonce must be at most %d hex characters", nonceHexLength)
+	}
+	return strconv.ParseUint(nonceString, 16, 64)
+}
+
+// parseJobID parses a job ID, which miners may send either as a string or as
+// a number
+func parseJobID(rawJobID json.RawMessage) (uint64, error) {
+	var jobIDString string
+	err := json.Unmarshal(rawJobID, &jobIDString)
+	if err != nil {
+		jobIDString = string(rawJobID)
+	}
+	return strconv.ParseUint(jobIDString, 10, 64)
+}
+
+func parseStringParam(params []json.RawMessage, index int) (string, error) {
+	if index >= len(params) {
+		return "", errors.Errorf("missing param %d", index)
+	}
+	var param string
+	err := json.Unmarshal(params[index], &param)
+	if err != nil {
+		return "", errors.Errorf("param %d must be a string", index)
+	}
+	return param, nil
+}
@@ -0,0 +1,387 @@
+// Package stratum serves block templates to mining software over the stratum
+// protocol, so that solo miners can mine to the node directly, without a
+// bridge between its RPC service and their mining software.
+package stratum
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/pkg/errors"
+)
+
+// refreshInterval is the interval in which the template is rebuilt between
+// new blocks, so that the served jobs include new mempool transactions
+const refreshInterval = time.Second
+
+// statsLogInterval is the interval in which the shares of the workers are
+// logged
+const statsLogInterval = 10 * time.Minute
+
+// maxJobs is the amount of recent jobs whose shares are accepted. Shares of
+// older jobs are stale.
+const maxJobs = 16
+
+// IsSyncedFunc returns whether the node is synced, without which blocks it
+// mines are not accepted, so it serves no new jobs
+type IsSyncedFunc func() (bool, error)
+
+// SubmitBlockFunc adds a block that was found by a miner to the DAG, and
+// broadcasts it
+type SubmitBlockFunc func(block *externalapi.DomainBlock) error
+
+// WorkerStats are the counts of the shares a worker submitted
+type WorkerStats struct {
+	ValidShares   uint64
+	StaleShares   uint64
+	InvalidShares uint64
+	BlocksFound   uint64
+	LastShareTime time.Time
+}
+
+// Server is a stratum server. Every template of the mining manager that
+// changes the parents or the transactions of the previous one is served to
+// the authorized miners as a new job, and every share they submit is checked
+// against the share difficulty and the target of the block, and submitted as
+// a block if it meets the latter. The coinbase of all the jobs pays to the
+// same address, so workers authorize with any name, which is used only to
+// track their shares.
+type Server struct {
+	listener                 net.Listener
+	domain                   domain.Domain
+	coinbaseData             *externalapi.DomainCoinbaseData
+	maxCoinbasePayloadLength uint64
+	shareDifficulty          float64
+	shareTarget              *big.Int
+	isSynced                 IsSyncedFunc
+	submitBlock              SubmitBlockFunc
+
+	lock           sync.Mutex
+	jobs           map[uint64]*job
+	currentJob     *job
+	nextJobID      uint64
+	sessions       map[*session]struct{}
+	nextExtranonce uint16
+	workerStats    map[string]*WorkerStats
+
+	// isWaitingForSync is whether the last refresh found the node not
+	// synced, so that it's logged only once
+	isWaitingForSync bool
+
+	notifyChan chan struct{}
+	stopChan   chan struct{}
+	waitGroup  sync.WaitGroup
+}
+
+// New creates a new Server that listens on the given address, and serves
+// jobs of the templates of the given domain, whose coinbase pays to the given
+// coinbase data
+func New(listenAddress string, domain domain.Domain, coinbaseData *externalapi.DomainCoinbaseData,
+	maxCoinbasePayloadLength uint64, shareDifficulty float64, isSynced IsSyncedFunc,
+	submitBlock SubmitBlockFunc) (*Server, error) {
+
+	err := ValidateShareDifficulty(shareDifficulty)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listening for stratum connections on %s", listenAddress)
+	}
+	return &Server{
+		listener:                 listener,
+		domain:                   domain,
+		coinbaseData:             coinbaseData,
+		maxCoinbasePayloadLength: maxCoinbasePayloadLength,
+		shareDifficulty:          shareDifficulty,
+		shareTarget:              shareTarget(shareDifficulty),
+		isSynced:                 isSynced,
+		submitBlock:              submitBlock,
+		jobs:                     make(map[uint64]*job),
+		sessions:                 make(map[*session]struct{}),
+		workerStats:              make(map[string]*WorkerStats),
+		notifyChan:               make(chan struct{}, 1),
+		stopChan:                 make(chan struct{}),
+	}, nil
+}
+
+// Address returns the address the server listens on
+func (s *Server) Address() net.Addr {
+	return s.listener.Addr()
+}
+
+// Start begins accepting miners and serving them jobs
+func (s *Server) Start() {
+	s.waitGroup.Add(2)
+	spawn("stratum.Server.acceptLoop", s.acceptLoop)
+	spawn("stratum.Server.jobLoop", s.jobLoop)
+}
+
+// Stop stops listening and disconnects all the miners
+func (s *Server) Stop() {
+	close(s.stopChan)
+	err := s.listener.Close()
+	if err != nil {
+		log.Debugf("Error closing the stratum listener: %s", err)
+	}
+
+	s.lock.Lock()
+	for session := range s.sessions {
+		session.close()
+	}
+	s.lock.Unlock()
+
+	s.waitGroup.Wait()
+}
+
+// Notify lets the server know that a new block template is available. It
+// never blocks.
+func (s *Server) Notify() {
+	select {
+	case s.notifyChan <- struct{}{}:
+	default:
+	}
+}
+
+// WorkerStats returns the share counts of every worker that submitted a
+// share, by worker name
+func (s *Server) WorkerStats() map[string]WorkerStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	workerStats := make(map[string]WorkerStats, len(s.workerStats))
+	for workerName, stats := range s.workerStats {
+		workerStats[workerName] = *stats
+	}
+	return workerStats
+}
+
+func (s *Server) acceptLoop() {
+	defer s.waitGroup.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-s.stopChan:
+				return
+			default:
+			}
+			log.Errorf("Error accepting a stratum connection: %s", err)
+			continue
+		}
+
+		session, ok := s.addSession(conn)
+		if !ok {
+			continue
+		}
+		log.Debugf("Stratum connection from %s", conn.RemoteAddr())
+		s.waitGroup.Add(1)
+		spawn("stratum.session.handle", func() {
+			defer s.waitGroup.Done()
+			defer s.removeSession(session)
+			session.handle()
+		})
+	}
+}
+
+// addSession starts a session over the given connection, unless the server
+// is stopping
+func (s *Server) addSession(conn net.Conn) (*session, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	select {
+	case <-s.stopChan:
+		err := conn.Close()
+		if err != nil {
+			log.Debugf("Error closing the stratum connection from %s: %s", conn.RemoteAddr(), err)
+		}
+		return nil, false
+	default:
+	}
+
+	session := newSession(s, conn, fmt.Sprintf("%04x", s.nextExtranonce))
+	s.nextExtranonce++
+	s.sessions[session] = struct{}{}
+	return session, true
+}
+
+func (s *Server) removeSession(session *session) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.sessions, session)
+	log.Debugf("Stratum connection from %s closed", session.conn.RemoteAddr())
+}
+
+func (s *Server) jobLoop() {
+	defer s.waitGroup.Done()
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	statsTicker := time.NewTicker(statsLogInterval)
+	defer statsTicker.Stop()
+
+	for {
+		err := s.refreshJob()
+		if err != nil {
+			log.Errorf("Error refreshing the stratum job: %s", err)
+		}
+
+		select {
+		case <-s.stopChan:
+			return
+		case <-s.notifyChan:
+		case <-ticker.C:
+		case <-statsTicker.C:
+			s.logWorkerStats()
+		}
+	}
+}
+
+// refreshJob serves a new job to the miners, unless the current template is
+// the same work as the current job
+func (s *Server) refreshJob() error {
+	isSynced, err := s.isSynced()
+	if err != nil {
+		return err
+	}
+	if !isSynced {
+		s.lock.Lock()
+		if !s.isWaitingForSync {
+			log.Warnf("The node is not synced. No new stratum jobs are served until it is")
+			s.isWaitingForSync = true
+		}
+		s.lock.Unlock()
+		return nil
+	}
+
+	template, _, err := s.domain.MiningManager().GetBlockTemplate(s.coinbaseData)
+	if err != nil {
+		return err
+	}
+	coinbasePayload := template.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload
+	if uint64(len(coinbasePayload)) > s.maxCoinbasePayloadLength {
+		return errors.Errorf("the coinbase payload is above the max length (%d)", s.maxCoinbasePayloadLength)
+	}
+
+	s.lock.Lock()
+	s.isWaitingForSync = false
+	currentJob := s.currentJob
+	s.lock.Unlock()
+	if currentJob != nil && isSameWork(template, currentJob.template) {
+		return nil
+	}
+
+	// Only this loop creates jobs, so the job ID may be assigned after the
+	// job is built outside the lock
+	job := newJob(0, template)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	job.id = s.nextJobID
+	s.nextJobID++
+	s.jobs[job.id] = job
+	if job.id >= maxJobs {
+		delete(s.jobs, job.id-maxJobs)
+	}
+	s.currentJob = job
+	for session := range s.sessions {
+		session.sendJob(job)
+	}
+	log.Debugf("Serving stratum job %d with %d transactions", job.id, len(template.Transactions))
+	return nil
+}
+
+// submitShare checks a share of the given worker, and submits its block if it
+// meets the target of the block
+func (s *Server) submitShare(workerName string, jobID uint64, nonce uint64) *stratumError {
+	s.lock.Lock()
+	stats := s.workerStatsOf(workerName)
+	stats.LastShareTime = time.Now()
+	job, ok := s.jobs[jobID]
+	if !ok {
+		stats.StaleShares++
+		s.lock.Unlock()
+		return newStratumError(errorCodeJobNotFound, "job %d not found", jobID)
+	}
+	if _, ok := job.submittedNonces[nonce]; ok {
+		stats.InvalidShares++
+		s.lock.Unlock()
+		return newStratumError(errorCodeDuplicate, "duplicate share")
+	}
+	job.submittedNonces[nonce] = struct{}{}
+	s.lock.Unlock()
+
+	proofOfWorkValue := job.proofOfWorkValue(nonce)
+	if proofOfWorkValue.Cmp(s.shareTarget) > 0 {
+		s.lock.Lock()
+		stats.InvalidShares++
+		s.lock.Unlock()
+		return newStratumError(errorCodeLowDifficulty, "low difficulty share")
+	}
+
+	isBlockFound := false
+	if proofOfWorkValue.Cmp(job.blockTarget()) <= 0 {
+		block := job.block(nonce)
+		blockHash := consensushashing.BlockHash(block)
+		err := s.submitBlock(block)
+		if err != nil {
+			log.Warnf("Block %s found by worker %s was rejected: %s", blockHash, workerName, err)
+		} else {
+			log.Infof("Worker %s found block %s", workerName, blockHash)
+			isBlockFound = true
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stats.ValidShares++
+	if isBlockFound {
+		stats.BlocksFound++
+	}
+	return nil
+}
+
+// workerStatsOf returns the stats of the given worker. It must be called
+// with the lock held.
+func (s *Server) workerStatsOf(workerName string) *WorkerStats {
+	stats, ok := s.workerStats[workerName]
+	if !ok {
+		stats = &WorkerStats{}
+		s.workerStats[workerName] = stats
+	}
+	return stats
+}
+
+func (s *Server) logWorkerStats() {
+	workerStats := s.WorkerStats()
+	workerNames := make([]string, 0, len(workerStats))
+	for workerName := range workerStats {
+		workerNames = append(workerNames, workerName)
+	}
+	sort.Strings(workerNames)
+	for _, workerName := range workerNames {
+		stats := workerStats[workerName]
+		log.Infof("Worker %s: %d valid, %d stale and %d invalid shares, %d blocks found, last share at %s",
+			workerName, stats.ValidShares, stats.StaleShares, stats.InvalidShares, stats.BlocksFound,
+			stats.LastShareTime.Format(time.RFC3339))
+	}
+}
+
+// isSameWork returns whether the given templates have the same parents and
+// transactions, so that mining either of them is equally useful
+func isSameWork(template *externalapi.DomainBlock, otherTemplate *externalapi.DomainBlock) bool {
+	return externalapi.HashesEqual(template.Header.DirectParents(), otherTemplate.Header.DirectParents()) &&
+		template.Header.HashMerkleRoot().Equal(otherTemplate.Header.HashMerkleRoot())
+}
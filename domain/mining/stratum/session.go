@@ -0,0 +1,202 @@
+package stratum
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+)
+
+// maxRequestLength is the maximum length of a line a miner sends
+const maxRequestLength = 64 * 1024
+
+// writeTimeout is the time a miner is given to read a message before it's
+// disconnected
+const writeTimeout = 10 * time.Second
+
+// session is the connection of a miner. Its workers are guarded by the lock
+// of the server, so that jobs are sent in the order they're created.
+type session struct {
+	server     *Server
+	conn       net.Conn
+	extranonce string
+
+	writeLock sync.Mutex
+
+	// workers are the names of the workers that authorized over this
+	// session. Jobs are sent only once a worker authorized.
+	workers map[string]struct{}
+}
+
+func newSession(server *Server, conn net.Conn, extranonce string) *session {
+	return &session{
+		server:     server,
+		conn:       conn,
+		extranonce: extranonce,
+		workers:    make(map[string]struct{}),
+	}
+}
+
+// handle handles the requests of the miner until it disconnects
+func (s *session) handle() {
+	defer s.close()
+
+	scanner := bufio.NewScanner(s.conn)
+	scanner.Buffer(make([]byte, 4096), maxRequestLength)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var request request
+		err := json.Unmarshal(line, &request)
+		if err != nil {
+			log.Debugf("Malformed stratum request from %s: %s", s.conn.RemoteAddr(), err)
+			return
+		}
+		err = s.handleRequest(&request)
+		if err != nil {
+			log.Debugf("Error answering a stratum request of %s: %s", s.conn.RemoteAddr(), err)
+			return
+		}
+	}
+}
+
+func (s *session) handleRequest(request *request) error {
+	switch request.Method {
+	case methodSubscribe:
+		return s.respond(request, []interface{}{true, protocolVersion}, nil)
+	case methodExtranonceSubscribe:
+		return s.respond(request, true, nil)
+	case methodAuthorize:
+		return s.handleAuthorize(request)
+	case methodSubmit:
+		return s.respond(request, true, s.handleSubmit(request))
+	default:
+		return s.respond(request, nil, newStratumError(errorCodeOther, "unknown method %s", request.Method))
+	}
+}
+
+// handleAuthorize authorizes the worker whose name is the first param. The
+// first worker of the session is then sent the extranonce, the share
+// difficulty and the current job.
+func (s *session) handleAuthorize(request *request) error {
+	workerName, err := parseStringParam(request.Params, 0)
+	if err == nil && workerName == "" {
+		err = newStratumError(errorCodeOther, "the worker name may not be empty")
+	}
+	if err != nil {
+		return s.respond(request, nil, newStratumError(errorCodeOther, "invalid authorize params: %s", err))
+	}
+	err = s.respond(request, true, nil)
+	if err != nil {
+		return err
+	}
+
+	s.server.lock.Lock()
+	defer s.server.lock.Unlock()
+
+	isFirstWorker := len(s.workers) == 0
+	s.workers[workerName] = struct{}{}
+	log.Infof("Stratum worker %s authorized from %s", workerName, s.conn.RemoteAddr())
+	if !isFirstWorker {
+		return nil
+	}
+	err = s.send(&notification{
+		Method: methodSetExtranonce,
+		Params: []interface{}{s.extranonce, 8 - len(s.extranonce)/2},
+	})
+	if err != nil {
+		return err
+	}
+	err = s.send(&notification{Method: methodSetDifficulty, Params: []interface{}{s.server.shareDifficulty}})
+	if err != nil {
+		return err
+	}
+	if s.server.currentJob == nil {
+		return nil
+	}
+	return s.send(&notification{Method: methodNotify, Params: s.server.currentJob.notifyParams()})
+}
+
+// handleSubmit submits the share whose params are the worker name, the job
+// ID and the nonce
+func (s *session) handleSubmit(request *request) *stratumError {
+	workerName, err := parseStringParam(request.Params, 0)
+	if err != nil {
+		return newStratumError(errorCodeOther, "invalid submit params: %s", err)
+	}
+	if len(request.Params) < 3 {
+		return newStratumError(errorCodeOther, "invalid submit params: expected 3 params")
+	}
+	jobID, err := parseJobID(request.Params[1])
+	if err != nil {
+		return newStratumError(errorCodeOther, "invalid job ID: %s", err)
+	}
+	nonceString, err := parseStringParam(request.Params, 2)
+	if err != nil {
+		return newStratumError(errorCodeOther, "invalid submit params: %s", err)
+	}
+	nonce, err := parseNonce(nonceString, s.extranonce)
+	if err != nil {
+		return newStratumError(errorCodeOther, "invalid nonce: %s", err)
+	}
+
+	s.server.lock.Lock()
+	_, isAuthorized := s.workers[workerName]
+	s.server.lock.Unlock()
+	if !isAuthorized {
+		return newStratumError(errorCodeUnauthorized, "worker %s is not authorized", workerName)
+	}
+
+	return s.server.submitShare(workerName, jobID, nonce)
+}
+
+// sendJob sends the given job to the miner, if any of its workers
+// authorized. It must be called with the lock of the server held.
+func (s *session) sendJob(job *job) {
+	if len(s.workers) == 0 {
+		return
+	}
+	err := s.send(&notification{Method: methodNotify, Params: job.notifyParams()})
+	if err != nil {
+		log.Debugf("Error sending a stratum job to %s: %s", s.conn.RemoteAddr(), err)
+		s.close()
+	}
+}
+
+func (s *session) respond(request *request, result interface{}, stratumErr *stratumError) error {
+	response := &response{ID: request.ID}
+	if stratumErr != nil {
+		response.Error = []interface{}{stratumErr.code, stratumErr.message, nil}
+	} else {
+		response.Result = result
+	}
+	return s.send(response)
+}
+
+func (s *session) send(message interface{}) error {
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	messageBytes = append(messageBytes, '\n')
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	err = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err != nil {
+		return err
+	}
+	_, err = s.conn.Write(messageBytes)
+	return err
+}
+
+func (s *session) close() {
+	err := s.conn.Close()
+	if err != nil {
+		log.Tracef("Error closing the stratum connection from %s: %s", s.conn.RemoteAddr(), err)
+	}
+}
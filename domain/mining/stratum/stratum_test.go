@@ -0,0 +1,116 @@
+package stratum
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+	"github.com/kaspanet/kaspad/util/difficulty"
+)
+
+func TestJob(t *testing.T) {
+	// A target of 2^255 - 1, so that about every other nonce is a block
+	bits := difficulty.BigToCompact(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1)))
+	header := blockheader.NewImmutableBlockHeader(0,
+		[]externalapi.BlockLevelParents{{externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})}},
+		&externalapi.DomainHash{}, &externalapi.DomainHash{}, &externalapi.DomainHash{},
+		1234, bits, 0, 0, 0, big.NewInt(0), &externalapi.DomainHash{})
+	template := &externalapi.DomainBlock{Header: header}
+	job := newJob(7, template)
+
+	params := job.notifyParams()
+	if params[0] != "7" || params[2] != int64(1234) {
+		t.Fatalf("Unexpected notify params %v", params)
+	}
+	zeroedHeader := header.ToMutable()
+	zeroedHeader.SetTimeInMilliseconds(0)
+	prePowHash := consensushashing.HeaderHash(zeroedHeader).ByteSlice()
+	for i, word := range job.headerWords {
+		for j := 0; j < 8; j++ {
+			if byte(word>>(8*j)) != prePowHash[i*8+j] {
+				t.Fatalf("Header word %d doesn't match the pre-PoW hash", i)
+			}
+		}
+	}
+
+	foundBlocks := 0
+	for nonce := uint64(0); nonce < 20; nonce++ {
+		block := job.block(nonce)
+		if block.Header.Nonce() != nonce || block.Header.TimeInMilliseconds() != 1234 {
+			t.Fatalf("Unexpected header of the block of nonce %d", nonce)
+		}
+		isBlock := job.proofOfWorkValue(nonce).Cmp(job.blockTarget()) <= 0
+		if isBlock != pow.CheckProofOfWorkByBits(block.Header.ToMutable()) {
+			t.Fatalf("The proof of work of nonce %d doesn't match the one of its block", nonce)
+		}
+		if isBlock {
+			foundBlocks++
+		}
+	}
+	if foundBlocks == 0 {
+		t.Fatalf("Expected some of the nonces to be blocks")
+	}
+}
+
+func TestShareTarget(t *testing.T) {
+	if shareTarget(1).Cmp(difficulty1Target) != 0 {
+		t.Fatalf("The target of difficulty 1 is %x", shareTarget(1))
+	}
+	expectedTarget := new(big.Int).Div(difficulty1Target, big.NewInt(4))
+	if shareTarget(4).Cmp(expectedTarget) != 0 {
+		t.Fatalf("The target of difficulty 4 is %x, but expected %x", shareTarget(4), expectedTarget)
+	}
+	if shareTarget(0.5).Cmp(new(big.Int).Mul(difficulty1Target, big.NewInt(2))) != 0 {
+		t.Fatalf("The target of difficulty 0.5 is %x", shareTarget(0.5))
+	}
+
+	for _, invalidDifficulty := range []float64{0, -1} {
+		if ValidateShareDifficulty(invalidDifficulty) == nil {
+			t.Fatalf("Expected share difficulty %f to be invalid", invalidDifficulty)
+		}
+	}
+}
+
+func TestParseNonce(t *testing.T) {
+	tests := []struct {
+		nonce         string
+		extranonce    string
+		expectedNonce uint64
+		expectError   bool
+	}{
+		{nonce: "0123456789abcdef", extranonce: "", expectedNonce: 0x0123456789abcdef},
+		{nonce: "0x0123456789abcdef", extranonce: "", expectedNonce: 0x0123456789abcdef},
+		{nonce: "ff", extranonce: "", expectedNonce: 0xff},
+		{nonce: "456789abcdef", extranonce: "0123", expectedNonce: 0x0123456789abcdef},
+		{nonce: "ff", extranonce: "0123", expectedNonce: 0x01230000000000ff},
+		{nonce: "0123456789abcdef", extranonce: "0123", expectedNonce: 0x0123456789abcdef},
+		{nonce: "0123456789abcdef0", extranonce: "", expectError: true},
+		{nonce: "xyz", extranonce: "", expectError: true},
+	}
+	for _, test := range tests {
+		nonce, err := parseNonce(test.nonce, test.extranonce)
+		if test.expectError {
+			if err == nil {
+				t.Fatalf("Expected parsing nonce %s to fail", test.nonce)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parseNonce(%s, %s): %s", test.nonce, test.extranonce, err)
+		}
+		if nonce != test.expectedNonce {
+			t.Fatalf("parseNonce(%s, %s): expected %x, but got %x", test.nonce, test.extranonce, test.expectedNonce, nonce)
+		}
+	}
+
+	for _, rawJobID := range []string{`"12"`, `12`} {
+		jobID, err := parseJobID(json.RawMessage(rawJobID))
+		if err != nil || jobID != 12 {
+			t.Fatalf("parseJobID(%s): got %d, %v", rawJobID, jobID, err)
+		}
+	}
+}
@@ -120,15 +120,16 @@ func (btb *blockTemplateBuilder) BuildBlockTemplate(
 
 	mempoolTransactions := btb.mempool.BlockCandidateTransactions()
 	candidateTxs := make([]*candidateTx, 0, len(mempoolTransactions))
-	for _, tx := range mempoolTransactions {
+	for _, mempoolTransaction := range mempoolTransactions {
 		// Calculate the tx value
+		tx := mempoolTransaction.Transaction
 		gasLimit := uint64(0)
 		if !subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
 			panic("We currently don't support non native subnetworks")
 		}
 		candidateTxs = append(candidateTxs, &candidateTx{
 			DomainTransaction: tx,
-			txValue:           btb.calcTxValue(tx),
+			txValue:           btb.calcTxValue(mempoolTransaction),
 			gasLimit:          gasLimit,
 		})
 	}
@@ -151,7 +152,7 @@ func (btb *blockTemplateBuilder) BuildBlockTemplate(
 		for _, tx := range invalidTxsErr.InvalidTransactions {
 			invalidTxs = append(invalidTxs, tx.Transaction)
 		}
-		err = btb.mempool.RemoveTransactions(invalidTxs, true)
+		err = btb.mempool.RemoveTransactions(invalidTxs, true, "it's invalid in a new block template")
 		if err != nil {
 			// mempool.RemoveTransactions might return errors in situations that are perfectly fine in this context.
 			// TODO: Once the mempool invariants are clear, this should be converted back `return nil, err`:
@@ -208,11 +209,18 @@ func (btb *blockTemplateBuilder) ModifyBlockTemplate(newCoinbaseData *consensuse
 // calcTxValue calculates a value to be used in transaction selection.
 // The higher the number the more likely it is that the transaction will be
 // included in the block.
-func (btb *blockTemplateBuilder) calcTxValue(tx *consensusexternalapi.DomainTransaction) float64 {
+//
+// The value is derived from the fee rate of the best ancestor package the
+// transaction belongs to, rather than from its own, so that a high-fee child
+// pulls in its low-fee parent. Note that the child itself may only be
+// included in a later block, since a block may not contain chained
+// transactions.
+func (btb *blockTemplateBuilder) calcTxValue(candidate *miningmanagerapi.BlockCandidateTransaction) float64 {
 	massLimit := btb.policy.BlockMaxMass
 
-	mass := tx.Mass
-	fee := tx.Fee
+	tx := candidate.Transaction
+	mass := candidate.PackageMass
+	fee := candidate.PackageFee
 	if subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
 		return float64(fee) / (float64(mass) / float64(massLimit))
 	}
@@ -3,6 +3,7 @@ package miningmanager
 import (
 	"github.com/kaspanet/kaspad/domain/consensusreference"
 	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/fees"
 	"github.com/kaspanet/kaspad/domain/miningmanager/blocktemplatebuilder"
 	mempoolpkg "github.com/kaspanet/kaspad/domain/miningmanager/mempool"
 	"sync"
@@ -27,6 +28,7 @@ func (f *factory) NewMiningManager(consensusReference consensusreference.Consens
 		consensusReference:   consensusReference,
 		mempool:              mempool,
 		blockTemplateBuilder: blockTemplateBuilder,
+		feeEstimator:         fees.NewEstimator(float64(mempoolConfig.MinimumRelayTransactionFee) / 1000),
 		cachingTime:          time.Time{},
 		cacheLock:            &sync.Mutex{},
 	}
@@ -0,0 +1,87 @@
+package mempool
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+)
+
+// maxPackageAncestorCount is the maximum amount of mempool ancestors a
+// transaction may have for its ancestor package to be evaluated. It bounds the
+// work of evaluating the packages of long chains of mempool transactions.
+const maxPackageAncestorCount = 25
+
+// ancestorPackage is the total fee and mass of a mempool transaction along
+// with all of its ancestors in the mempool
+type ancestorPackage struct {
+	fee  uint64
+	mass uint64
+}
+
+func (p *ancestorPackage) feeRate() float64 {
+	if p.mass == 0 {
+		return 0
+	}
+	return float64(p.fee) / float64(p.mass)
+}
+
+// bestAncestorPackages returns, for every transaction without mempool
+// parents, the ancestor package with the highest fee rate that it belongs to,
+// if that fee rate is higher than the one of the transaction itself. Such a
+// transaction is worth more to a block than its own fee rate suggests, since
+// including it allows its descendant to be included in the next block: this is
+// how a high-fee child pays for its low-fee parent.
+func bestAncestorPackages(transactions model.IDToTransactionMap) map[externalapi.DomainTransactionID]*ancestorPackage {
+	// A nil entry means the transaction has more than maxPackageAncestorCount ancestors
+	ancestorsByTransactionID := make(map[externalapi.DomainTransactionID]model.IDToTransactionMap, len(transactions))
+	var ancestorsOf func(transaction *model.MempoolTransaction) model.IDToTransactionMap
+	ancestorsOf = func(transaction *model.MempoolTransaction) model.IDToTransactionMap {
+		transactionID := *transaction.TransactionID()
+		if ancestors, ok := ancestorsByTransactionID[transactionID]; ok {
+			return ancestors
+		}
+		ancestors := model.IDToTransactionMap{}
+		for parentID, parent := range transaction.ParentTransactionsInPool() {
+			parentAncestors := ancestorsOf(parent)
+			if parentAncestors == nil {
+				ancestors = nil
+				break
+			}
+			ancestors[parentID] = parent
+			for ancestorID, ancestor := range parentAncestors {
+				ancestors[ancestorID] = ancestor
+			}
+			if len(ancestors) > maxPackageAncestorCount {
+				ancestors = nil
+				break
+			}
+		}
+		ancestorsByTransactionID[transactionID] = ancestors
+		return ancestors
+	}
+
+	bestPackages := make(map[externalapi.DomainTransactionID]*ancestorPackage)
+	for _, transaction := range transactions {
+		ancestors := ancestorsOf(transaction)
+		if len(ancestors) == 0 {
+			continue
+		}
+		candidatePackage := &ancestorPackage{fee: transaction.Transaction().Fee, mass: transaction.Transaction().Mass}
+		for _, ancestor := range ancestors {
+			candidatePackage.fee += ancestor.Transaction().Fee
+			candidatePackage.mass += ancestor.Transaction().Mass
+		}
+		for ancestorID, ancestor := range ancestors {
+			if len(ancestor.ParentTransactionsInPool()) > 0 {
+				continue
+			}
+			bestPackage, ok := bestPackages[ancestorID]
+			if !ok {
+				bestPackage = &ancestorPackage{fee: ancestor.Transaction().Fee, mass: ancestor.Transaction().Mass}
+			}
+			if candidatePackage.feeRate() > bestPackage.feeRate() {
+				bestPackages[ancestorID] = candidatePackage
+			}
+		}
+	}
+	return bestPackages
+}
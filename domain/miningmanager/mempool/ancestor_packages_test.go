@@ -0,0 +1,90 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+)
+
+func TestBestAncestorPackages(t *testing.T) {
+	transactions := model.IDToTransactionMap{}
+	lockTime := uint64(0)
+	addTransaction := func(fee uint64, mass uint64, parents ...*model.MempoolTransaction) *model.MempoolTransaction {
+		// Every transaction gets a distinct lock time, so that it gets a distinct ID
+		lockTime++
+		parentsInPool := model.IDToTransactionMap{}
+		for _, parent := range parents {
+			parentsInPool[*parent.TransactionID()] = parent
+		}
+		transaction := model.NewMempoolTransaction(&externalapi.DomainTransaction{
+			LockTime: lockTime,
+			Fee:      fee,
+			Mass:     mass,
+		}, parentsInPool, false, 0, time.Time{})
+		transactions[*transaction.TransactionID()] = transaction
+		return transaction
+	}
+
+	// A low-fee parent with a high-fee child
+	lowFeeParent := addTransaction(100, 1000)
+	addTransaction(10_000, 1000, lowFeeParent)
+
+	// A high-fee parent with a low-fee child, which doesn't raise its fee rate
+	highFeeParent := addTransaction(5000, 1000)
+	addTransaction(0, 1000, highFeeParent)
+
+	// A chain whose last transaction pays for all of it
+	chainRoot := addTransaction(0, 1000)
+	chainMiddle := addTransaction(0, 1000, chainRoot)
+	addTransaction(30_000, 1000, chainMiddle)
+
+	// A child that pays for two parents
+	firstParent := addTransaction(0, 1000)
+	secondParent := addTransaction(1000, 1000)
+	addTransaction(20_000, 1000, firstParent, secondParent)
+
+	// A transaction with no mempool relatives
+	loneTransaction := addTransaction(1000, 1000)
+
+	// A chain that's too long to be evaluated
+	longChainRoot := addTransaction(0, 1000)
+	longChainTip := longChainRoot
+	for i := 0; i < maxPackageAncestorCount+1; i++ {
+		longChainTip = addTransaction(0, 1000, longChainTip)
+	}
+	addTransaction(1_000_000, 1000, longChainTip)
+
+	bestPackages := bestAncestorPackages(transactions)
+	tests := []struct {
+		name            string
+		transaction     *model.MempoolTransaction
+		expectedPackage *ancestorPackage
+	}{
+		{name: "low-fee parent", transaction: lowFeeParent, expectedPackage: &ancestorPackage{fee: 10_100, mass: 2000}},
+		{name: "high-fee parent", transaction: highFeeParent, expectedPackage: nil},
+		{name: "chain root", transaction: chainRoot, expectedPackage: &ancestorPackage{fee: 30_000, mass: 3000}},
+		{name: "first parent", transaction: firstParent, expectedPackage: &ancestorPackage{fee: 21_000, mass: 3000}},
+		{name: "second parent", transaction: secondParent, expectedPackage: &ancestorPackage{fee: 21_000, mass: 3000}},
+		{name: "lone transaction", transaction: loneTransaction, expectedPackage: nil},
+		{name: "long chain root", transaction: longChainRoot, expectedPackage: nil},
+	}
+	for _, test := range tests {
+		bestPackage, ok := bestPackages[*test.transaction.TransactionID()]
+		if test.expectedPackage == nil {
+			if ok {
+				t.Errorf("%s: expected no better package, but got %+v", test.name, bestPackage)
+			}
+			continue
+		}
+		if !ok || *bestPackage != *test.expectedPackage {
+			t.Errorf("%s: expected package %+v, but got %+v", test.name, test.expectedPackage, bestPackage)
+		}
+	}
+
+	// Only transactions without mempool parents are block candidates
+	if len(bestPackages) != 4 {
+		t.Errorf("Expected 4 improved packages, but got %d", len(bestPackages))
+	}
+}
@@ -0,0 +1,144 @@
+package mempool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/pkg/errors"
+)
+
+const (
+	// canonicalSignatureLength is the length of a signature push in a
+	// signature script: a 64 byte signature followed by its sighash type
+	canonicalSignatureLength = 65
+)
+
+var (
+	// secp256k1FieldPrime is the prime of the field the secp256k1 curve is
+	// defined over
+	secp256k1FieldPrime, _ = new(big.Int).SetString(
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+
+	// secp256k1Order is the order of the secp256k1 curve group
+	secp256k1Order, _ = new(big.Int).SetString(
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+	// secp256k1HalfOrder is used to tell low S values from high ones in ECDSA
+	// signatures
+	secp256k1HalfOrder = new(big.Int).Rsh(secp256k1Order, 1)
+)
+
+// checkTransactionCanonicalInIsolation makes sure that the signature scripts
+// and the scriptPublicKeys of the transaction push their data with the smallest
+// possible encoding, when the mempool is configured to require it.
+func (mp *mempool) checkTransactionCanonicalInIsolation(transaction *externalapi.DomainTransaction) error {
+	if !mp.config.RequireMinimalPushes {
+		return nil
+	}
+
+	for i, input := range transaction.Inputs {
+		if !txscript.HasCanonicalPushes(input.SignatureScript) {
+			str := fmt.Sprintf("transaction input %d: signature script has a non-canonical data push", i)
+			return transactionRuleError(RejectNonCanonicalPush, str)
+		}
+	}
+
+	for i, output := range transaction.Outputs {
+		if !txscript.HasCanonicalPushes(output.ScriptPublicKey.Script) {
+			str := fmt.Sprintf("transaction output %d: scriptPublicKey has a non-canonical data push", i)
+			return transactionRuleError(RejectNonCanonicalPush, str)
+		}
+	}
+
+	return nil
+}
+
+// checkTransactionCanonicalInContext makes sure that the pay-to-script-hash
+// scripts redeemed by the transaction push their data with the smallest
+// possible encoding, including in the branches that are not executed, and that
+// the signatures spending pay-to-pubkey outputs are canonically encoded, when
+// the mempool is configured to require either.
+//
+// Most of the signature checks are currently mirrored by the signature
+// verification in consensus. However, they're a policy of their own, so that
+// relaying nodes don't depend on the exact behavior of the underlying crypto
+// library to reject malleated signatures.
+func (mp *mempool) checkTransactionCanonicalInContext(transaction *externalapi.DomainTransaction) error {
+	for i, input := range transaction.Inputs {
+		// It is safe to elide existence and index checks here since
+		// they have already been checked prior to calling this
+		// function.
+		originScriptPubKey := input.UTXOEntry.ScriptPublicKey()
+		scriptClass := txscript.GetScriptClass(originScriptPubKey.Script)
+		switch scriptClass {
+		case txscript.ScriptHashTy:
+			if !mp.config.RequireMinimalPushes {
+				continue
+			}
+			pushes, err := txscript.PushedData(input.SignatureScript)
+			if err != nil || len(pushes) == 0 {
+				continue
+			}
+			redeemScript := pushes[len(pushes)-1]
+			if !txscript.HasCanonicalPushes(redeemScript) {
+				str := fmt.Sprintf("transaction input %d: pay-to-script-hash script has a "+
+					"non-canonical data push", i)
+				return transactionRuleError(RejectNonCanonicalPush, str)
+			}
+
+		case txscript.PubKeyTy, txscript.PubKeyECDSATy:
+			if !mp.config.RequireCanonicalSignatures {
+				continue
+			}
+			err := checkSignatureScriptEncoding(input.SignatureScript, scriptClass == txscript.PubKeyECDSATy)
+			if err != nil {
+				str := fmt.Sprintf("transaction input %d: %s", i, err)
+				return transactionRuleError(RejectNonCanonicalSignature, str)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSignatureScriptEncoding returns an error if the signature script of a
+// pay-to-pubkey spend isn't a single push of a canonically encoded signature
+func checkSignatureScriptEncoding(signatureScript []byte, isECDSA bool) error {
+	pushes, err := txscript.PushedData(signatureScript)
+	if err != nil || len(pushes) != 1 {
+		return errors.New("signature script isn't a single push of a signature")
+	}
+	signature := pushes[0]
+	if len(signature) != canonicalSignatureLength {
+		return errors.Errorf("signature is %d bytes long instead of %d", len(signature), canonicalSignatureLength)
+	}
+
+	hashType := consensushashing.SigHashType(signature[canonicalSignatureLength-1])
+	if !hashType.IsStandardSigHashType() {
+		return errors.Errorf("signature has a non-standard sighash type %d", hashType)
+	}
+
+	// Both kinds of signatures consist of a 32 byte big-endian R value
+	// followed by a 32 byte big-endian S value
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	if isECDSA {
+		if r.Sign() == 0 || r.Cmp(secp256k1Order) >= 0 {
+			return errors.New("ECDSA signature R value is out of range")
+		}
+		if s.Sign() == 0 || s.Cmp(secp256k1HalfOrder) > 0 {
+			return errors.New("ECDSA signature S value is not in the lower half of the order")
+		}
+		return nil
+	}
+	if r.Cmp(secp256k1FieldPrime) >= 0 {
+		return errors.New("Schnorr signature R value is not less than the field prime")
+	}
+	if s.Cmp(secp256k1Order) >= 0 {
+		return errors.New("Schnorr signature S value is not less than the curve order")
+	}
+	return nil
+}
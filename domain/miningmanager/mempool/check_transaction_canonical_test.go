@@ -0,0 +1,220 @@
+package mempool
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+func TestCheckTransactionCanonicalInIsolation(t *testing.T) {
+	canonicalScript := mustBuildScript(t, txscript.NewScriptBuilder().AddData(bytes.Repeat([]byte{0x01}, 32)).
+		AddOp(txscript.OpCheckSig))
+	// The same script, but pushing the public key with OP_PUSHDATA1
+	nonCanonicalScript := append([]byte{txscript.OpPushData1, 32}, canonicalScript[1:]...)
+
+	tests := []struct {
+		name            string
+		signatureScript []byte
+		scriptPublicKey []byte
+		isCanonical     bool
+	}{
+		{name: "canonical", signatureScript: canonicalScript, scriptPublicKey: canonicalScript, isCanonical: true},
+		{name: "non-canonical signature script", signatureScript: nonCanonicalScript, scriptPublicKey: canonicalScript},
+		{name: "non-canonical scriptPublicKey", signatureScript: canonicalScript, scriptPublicKey: nonCanonicalScript},
+	}
+
+	for _, test := range tests {
+		transaction := &externalapi.DomainTransaction{
+			Inputs:  []*externalapi.DomainTransactionInput{{SignatureScript: test.signatureScript}},
+			Outputs: []*externalapi.DomainTransactionOutput{{ScriptPublicKey: &externalapi.ScriptPublicKey{Script: test.scriptPublicKey}}},
+		}
+
+		mp := &mempool{config: &Config{RequireMinimalPushes: true}}
+		err := mp.checkTransactionCanonicalInIsolation(transaction)
+		expectRejectCode(t, test.name, err, test.isCanonical, RejectNonCanonicalPush)
+
+		// Nothing is rejected when the mempool doesn't require canonical encodings
+		mp = &mempool{config: &Config{}}
+		err = mp.checkTransactionCanonicalInIsolation(transaction)
+		if err != nil {
+			t.Errorf("%s: unexpected error when minimal pushes aren't required: %+v", test.name, err)
+		}
+	}
+}
+
+func TestCheckTransactionCanonicalInContext(t *testing.T) {
+	schnorrScriptPublicKey := mustBuildScript(t, txscript.NewScriptBuilder().AddData(bytes.Repeat([]byte{0x01}, 32)).
+		AddOp(txscript.OpCheckSig))
+	ecdsaScriptPublicKey := mustBuildScript(t, txscript.NewScriptBuilder().AddData(append([]byte{0x02}, bytes.Repeat([]byte{0x01}, 32)...)).
+		AddOp(txscript.OpCheckSigECDSA))
+
+	redeemScript := mustBuildScript(t, txscript.NewScriptBuilder().AddOp(txscript.OpFalse).AddOp(txscript.OpIf).
+		AddData([]byte{1, 2, 3, 4}).AddOp(txscript.OpEndIf).AddOp(txscript.OpTrue))
+	// The same script, but pushing the data of the branch that isn't executed with OP_PUSHDATA1
+	nonCanonicalRedeemScript := append(append([]byte{}, redeemScript[:2]...),
+		append([]byte{txscript.OpPushData1}, redeemScript[2:]...)...)
+	scriptHashScriptPublicKey := func(redeemScript []byte) []byte {
+		address, err := util.NewAddressScriptHash(redeemScript, util.Bech32PrefixKaspaSim)
+		if err != nil {
+			t.Fatalf("NewAddressScriptHash: %+v", err)
+		}
+		scriptPublicKey, err := txscript.PayToAddrScript(address)
+		if err != nil {
+			t.Fatalf("PayToAddrScript: %+v", err)
+		}
+		return scriptPublicKey.Script
+	}
+
+	signature := func(r []byte, s []byte, hashType consensushashing.SigHashType) []byte {
+		signature := make([]byte, 0, canonicalSignatureLength)
+		signature = append(signature, r...)
+		signature = append(signature, s...)
+		return append(signature, byte(hashType))
+	}
+	signatureScript := func(pushes ...[]byte) []byte {
+		builder := txscript.NewScriptBuilder()
+		for _, push := range pushes {
+			builder.AddData(push)
+		}
+		return mustBuildScript(t, builder)
+	}
+
+	low := bytes.Repeat([]byte{0x01}, 32)
+	secp256k1OrderBytes := secp256k1Order.Bytes()
+	secp256k1HalfOrderPlusOne := new(big.Int).Add(secp256k1HalfOrder, big.NewInt(1)).Bytes()
+	secp256k1FieldPrimeBytes := secp256k1FieldPrime.Bytes()
+
+	tests := []struct {
+		name                string
+		scriptPublicKey     []byte
+		signatureScript     []byte
+		expectedIsCanonical bool
+		expectedRejectCode  RejectCode
+	}{
+		{
+			name:                "canonical Schnorr signature",
+			scriptPublicKey:     schnorrScriptPublicKey,
+			signatureScript:     signatureScript(signature(low, low, consensushashing.SigHashAll)),
+			expectedIsCanonical: true,
+		},
+		{
+			name:                "canonical ECDSA signature",
+			scriptPublicKey:     ecdsaScriptPublicKey,
+			signatureScript:     signatureScript(signature(low, low, consensushashing.SigHashAll)),
+			expectedIsCanonical: true,
+		},
+		{
+			name:               "signature with a non-standard sighash type",
+			scriptPublicKey:    schnorrScriptPublicKey,
+			signatureScript:    signatureScript(signature(low, low, consensushashing.SigHashMask)),
+			expectedRejectCode: RejectNonCanonicalSignature,
+		},
+		{
+			name:               "short signature",
+			scriptPublicKey:    schnorrScriptPublicKey,
+			signatureScript:    signatureScript(signature(low, low, consensushashing.SigHashAll)[1:]),
+			expectedRejectCode: RejectNonCanonicalSignature,
+		},
+		{
+			name:            "two pushes",
+			scriptPublicKey: schnorrScriptPublicKey,
+			signatureScript: signatureScript(signature(low, low, consensushashing.SigHashAll),
+				signature(low, low, consensushashing.SigHashAll)),
+			expectedRejectCode: RejectNonCanonicalSignature,
+		},
+		{
+			name:               "Schnorr signature with R not less than the field prime",
+			scriptPublicKey:    schnorrScriptPublicKey,
+			signatureScript:    signatureScript(signature(secp256k1FieldPrimeBytes, low, consensushashing.SigHashAll)),
+			expectedRejectCode: RejectNonCanonicalSignature,
+		},
+		{
+			name:               "Schnorr signature with S not less than the order",
+			scriptPublicKey:    schnorrScriptPublicKey,
+			signatureScript:    signatureScript(signature(low, secp256k1OrderBytes, consensushashing.SigHashAll)),
+			expectedRejectCode: RejectNonCanonicalSignature,
+		},
+		{
+			name:               "ECDSA signature with a high S",
+			scriptPublicKey:    ecdsaScriptPublicKey,
+			signatureScript:    signatureScript(signature(low, secp256k1HalfOrderPlusOne, consensushashing.SigHashAll)),
+			expectedRejectCode: RejectNonCanonicalSignature,
+		},
+		{
+			name:               "ECDSA signature with R not less than the order",
+			scriptPublicKey:    ecdsaScriptPublicKey,
+			signatureScript:    signatureScript(signature(secp256k1OrderBytes, low, consensushashing.SigHashAll)),
+			expectedRejectCode: RejectNonCanonicalSignature,
+		},
+		{
+			name:                "canonical pay-to-script-hash script",
+			scriptPublicKey:     scriptHashScriptPublicKey(redeemScript),
+			signatureScript:     signatureScript(redeemScript),
+			expectedIsCanonical: true,
+		},
+		{
+			name:               "pay-to-script-hash script with a non-canonical push in a branch that isn't executed",
+			scriptPublicKey:    scriptHashScriptPublicKey(nonCanonicalRedeemScript),
+			signatureScript:    signatureScript(nonCanonicalRedeemScript),
+			expectedRejectCode: RejectNonCanonicalPush,
+		},
+	}
+
+	for _, test := range tests {
+		transaction := &externalapi.DomainTransaction{
+			Inputs: []*externalapi.DomainTransactionInput{{
+				SignatureScript: test.signatureScript,
+				UTXOEntry: utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: test.scriptPublicKey},
+					false, constants.UnacceptedDAAScore),
+			}},
+		}
+
+		mp := &mempool{config: &Config{RequireMinimalPushes: true, RequireCanonicalSignatures: true}}
+		err := mp.checkTransactionCanonicalInContext(transaction)
+		expectRejectCode(t, test.name, err, test.expectedIsCanonical, test.expectedRejectCode)
+
+		// Nothing is rejected when the mempool doesn't require canonical encodings
+		mp = &mempool{config: &Config{}}
+		err = mp.checkTransactionCanonicalInContext(transaction)
+		if err != nil {
+			t.Errorf("%s: unexpected error when canonical encodings aren't required: %+v", test.name, err)
+		}
+	}
+}
+
+func mustBuildScript(t *testing.T, builder *txscript.ScriptBuilder) []byte {
+	script, err := builder.Script()
+	if err != nil {
+		t.Fatalf("Script: %+v", err)
+	}
+	return script
+}
+
+func expectRejectCode(t *testing.T, name string, err error, isCanonical bool, expectedRejectCode RejectCode) {
+	if isCanonical {
+		if err != nil {
+			t.Errorf("%s: unexpected error: %+v", name, err)
+		}
+		return
+	}
+	if err == nil {
+		t.Errorf("%s: expected the transaction to be rejected", name)
+		return
+	}
+	var txRuleError TxRuleError
+	if !errors.As(err, &txRuleError) {
+		t.Errorf("%s: unexpected error type %T", name, err)
+		return
+	}
+	if txRuleError.RejectCode != expectedRejectCode {
+		t.Errorf("%s: expected reject code %s, but got %s", name, expectedRejectCode, txRuleError.RejectCode)
+	}
+}
@@ -2,6 +2,9 @@ package mempool
 
 import (
 	"fmt"
+	"math"
+	"math/bits"
+	"strings"
 
 	"github.com/kaspanet/kaspad/util/txmass"
 
@@ -36,8 +39,8 @@ const (
 	// (1 + 15*74 + 3) + (15*34 + 3) + 23 = 1650
 	maximumStandardSignatureScriptSize = 1650
 
-	// MaximumStandardTransactionMass is the maximum mass allowed for transactions that
-	// are considered standard and will therefore be relayed and considered for mining.
+	// MaximumStandardTransactionMass is the default maximum mass allowed for transactions
+	// that are considered standard and will therefore be relayed and considered for mining.
 	MaximumStandardTransactionMass = 100_000
 )
 
@@ -58,9 +61,17 @@ func (mp *mempool) checkTransactionStandardInIsolation(transaction *externalapi.
 	// almost as much to process as the sender fees, limit the maximum
 	// size of a transaction. This also helps mitigate CPU exhaustion
 	// attacks.
-	if transaction.Mass > MaximumStandardTransactionMass {
-		str := fmt.Sprintf("transaction mass of %d is larger than max allowed size of %d",
-			transaction.Mass, MaximumStandardTransactionMass)
+	// The rejection states both the mass and the limit, so that wallets building
+	// large consolidations know how many transactions to split them into.
+	maximumMass := mp.config.MaximumStandardTransactionMass
+	if transaction.Mass > maximumMass {
+		minimumTransactionCount := transaction.Mass / maximumMass
+		if transaction.Mass%maximumMass != 0 {
+			minimumTransactionCount++
+		}
+		str := fmt.Sprintf("transaction mass of %d is larger than the maximum standard transaction mass of %d "+
+			"-- consider splitting it into at least %d transactions, each spending fewer inputs",
+			transaction.Mass, maximumMass, minimumTransactionCount)
 		return transactionRuleError(RejectNonstandard, str)
 	}
 
@@ -77,6 +88,7 @@ func (mp *mempool) checkTransactionStandardInIsolation(transaction *externalapi.
 	}
 
 	// None of the output public key scripts can be a non-standard script or be "dust".
+	var dustOutputs []string
 	for i, output := range transaction.Outputs {
 		if output.ScriptPublicKey.Version > constants.MaxScriptPublicKeyVersion {
 			return transactionRuleError(RejectNonstandard, "The version of the scriptPublicKey is higher than the known version.")
@@ -88,20 +100,27 @@ func (mp *mempool) checkTransactionStandardInIsolation(transaction *externalapi.
 		}
 
 		if mp.IsTransactionOutputDust(output) {
-			str := fmt.Sprintf("transaction output %d: payment "+
-				"of %d is dust", i, output.Value)
-			return transactionRuleError(RejectDust, str)
+			dustOutputs = append(dustOutputs, fmt.Sprintf("output %d: payment of %d is below the dust "+
+				"threshold of %d", i, output.Value, mp.dustThreshold(output.ScriptPublicKey)))
 		}
 	}
 
+	// All the dust outputs are reported at once, so that the sender can fix
+	// them all before resubmitting
+	if len(dustOutputs) > 0 {
+		str := fmt.Sprintf("transaction has %d dust outputs (%s) -- consider aggregating them into "+
+			"fewer outputs, or adding their values to the fee", len(dustOutputs), strings.Join(dustOutputs, "; "))
+		return transactionRuleError(RejectDust, str)
+	}
+
 	return nil
 }
 
 // IsTransactionOutputDust returns whether or not the passed transaction output amount
-// is considered dust or not based on the configured minimum transaction relay fee.
-// Dust is defined in terms of the minimum transaction relay fee. In
-// particular, if the cost to the network to spend coins is more than 1/3 of the
-// minimum transaction relay fee, it is considered dust.
+// is considered dust or not based on the configured dust relay fee.
+// Dust is defined in terms of the dust relay fee. In particular, if the cost to
+// the network to spend coins is more than 1/3 of their value at the dust relay
+// fee, it is considered dust.
 //
 // It is exported for use by transaction generators and wallets
 func (mp *mempool) IsTransactionOutputDust(output *externalapi.DomainTransactionOutput) bool {
@@ -110,6 +129,44 @@ func (mp *mempool) IsTransactionOutputDust(output *externalapi.DomainTransaction
 		return true
 	}
 
+	totalSerializedSize := outputSpendEstimatedSerializedSize(output.ScriptPublicKey)
+
+	// The output is considered dust if the cost to the network to spend the
+	// coins is more than 1/3 of its value at the dust relay fee.
+	// mp.config.DustRelayTransactionFee is in sompi/KB, so multiply
+	// by 1000 to convert to bytes.
+	//
+	// Using the typical values for a pay-to-pubkey transaction from
+	// the breakdown in outputSpendEstimatedSerializedSize and the default
+	// dust relay fee of 1000, this equates to values less than 546 sompi
+	// being considered dust.
+	//
+	// The following is equivalent to (value/totalSerializedSize) * (1/3) * 1000
+	// without needing to do floating point math.
+	return output.Value*1000/(3*totalSerializedSize) < uint64(mp.config.DustRelayTransactionFee)
+}
+
+// dustThreshold returns the smallest value, in sompi, of an output paying to
+// the passed scriptPublicKey that isn't considered dust by
+// IsTransactionOutputDust. It's math.MaxUint64 if the dust relay fee is so high
+// that practically any value is dust.
+func (mp *mempool) dustThreshold(scriptPublicKey *externalapi.ScriptPublicKey) uint64 {
+	totalSerializedSize := outputSpendEstimatedSerializedSize(scriptPublicKey)
+
+	// This is the smallest value for which
+	// value*1000/(3*totalSerializedSize) >= dust relay fee, that is the cost
+	// of spending the output at the dust relay fee, times 3, rounded up
+	high, cost := bits.Mul64(3*totalSerializedSize, uint64(mp.config.DustRelayTransactionFee))
+	if high != 0 || cost > math.MaxUint64-999 {
+		return math.MaxUint64
+	}
+	return (cost + 999) / 1000
+}
+
+// outputSpendEstimatedSerializedSize returns the estimated serialized size of
+// an output paying to the passed scriptPublicKey together with the input that
+// would spend it.
+func outputSpendEstimatedSerializedSize(scriptPublicKey *externalapi.ScriptPublicKey) uint64 {
 	// The total serialized size consists of the output and the associated
 	// input script to redeem it. Since there is no input script
 	// to redeem it yet, use the minimum size of a typical input script.
@@ -127,21 +184,8 @@ func (mp *mempool) IsTransactionOutputDust(output *externalapi.DomainTransaction
 	// The most common scripts are pay-to-pubkey, and as per the above
 	// breakdown, the minimum size of a p2pk input script is 148 bytes. So
 	// that figure is used.
-	totalSerializedSize := txmass.TransactionOutputEstimatedSerializedSize(output) + 148
-
-	// The output is considered dust if the cost to the network to spend the
-	// coins is more than 1/3 of the minimum free transaction relay fee.
-	// mp.config.MinimumRelayTransactionFee is in sompi/KB, so multiply
-	// by 1000 to convert to bytes.
-	//
-	// Using the typical values for a pay-to-pubkey transaction from
-	// the breakdown above and the default minimum free transaction relay
-	// fee of 1000, this equates to values less than 546 sompi being
-	// considered dust.
-	//
-	// The following is equivalent to (value/totalSerializedSize) * (1/3) * 1000
-	// without needing to do floating point math.
-	return output.Value*1000/(3*totalSerializedSize) < uint64(mp.config.MinimumRelayTransactionFee)
+	output := &externalapi.DomainTransactionOutput{ScriptPublicKey: scriptPublicKey}
+	return txmass.TransactionOutputEstimatedSerializedSize(output) + 148
 }
 
 // checkTransactionStandardInContext performs a series of checks on a transaction's
@@ -6,7 +6,9 @@ package mempool
 
 import (
 	"bytes"
+	"fmt"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/kaspanet/kaspad/domain/consensusreference"
@@ -116,10 +118,10 @@ func TestIsTransactionOutputDust(t *testing.T) {
 			0xba, 0x5e}, 0}
 
 	tests := []struct {
-		name                       string // test description
-		txOut                      externalapi.DomainTransactionOutput
-		minimumRelayTransactionFee util.Amount // minimum relay transaction fee.
-		isDust                     bool
+		name                    string // test description
+		txOut                   externalapi.DomainTransactionOutput
+		dustRelayTransactionFee util.Amount // dust relay transaction fee.
+		isDust                  bool
 	}{
 		{
 			// Any value is allowed with a zero relay fee.
@@ -181,7 +183,7 @@ func TestIsTransactionOutputDust(t *testing.T) {
 
 		for _, test := range tests {
 			mempoolConfig := DefaultConfig(tc.DAGParams())
-			mempoolConfig.MinimumRelayTransactionFee = test.minimumRelayTransactionFee
+			mempoolConfig.DustRelayTransactionFee = test.dustRelayTransactionFee
 			tcAsConsensus := tc.(externalapi.Consensus)
 			tcAsConsensusPointer := &tcAsConsensus
 			mempool := New(mempoolConfig, consensusreference.NewConsensusReference(&tcAsConsensusPointer)).(*mempool)
@@ -195,6 +197,119 @@ func TestIsTransactionOutputDust(t *testing.T) {
 	})
 }
 
+func TestDustThreshold(t *testing.T) {
+	scriptPublicKey := &externalapi.ScriptPublicKey{Script: bytes.Repeat([]byte{0x01}, 36)}
+
+	tests := []struct {
+		dustRelayTransactionFee util.Amount
+		expectedThreshold       uint64
+	}{
+		{dustRelayTransactionFee: 0, expectedThreshold: 0},
+		{dustRelayTransactionFee: 1, expectedThreshold: 1},
+		// Matches the 605 and 606 sompi test cases in TestIsTransactionOutputDust
+		{dustRelayTransactionFee: 1000, expectedThreshold: 606},
+		{dustRelayTransactionFee: math.MaxUint64, expectedThreshold: math.MaxUint64},
+	}
+
+	for _, test := range tests {
+		mp := &mempool{config: &Config{DustRelayTransactionFee: test.dustRelayTransactionFee}}
+		threshold := mp.DustThreshold(scriptPublicKey)
+		if threshold != test.expectedThreshold {
+			t.Errorf("Expected a dust threshold of %d with a dust relay fee of %d, but got %d",
+				test.expectedThreshold, test.dustRelayTransactionFee, threshold)
+		}
+		if test.dustRelayTransactionFee == math.MaxUint64 {
+			continue
+		}
+		output := &externalapi.DomainTransactionOutput{Value: threshold, ScriptPublicKey: scriptPublicKey}
+		if mp.IsTransactionOutputDust(output) {
+			t.Errorf("Expected an output of the dust threshold %d not to be dust", threshold)
+		}
+		if threshold > 0 {
+			output.Value--
+			if !mp.IsTransactionOutputDust(output) {
+				t.Errorf("Expected an output of %d, below the dust threshold, to be dust", output.Value)
+			}
+		}
+	}
+}
+
+func TestCheckTransactionStandardInIsolationReportsAllDustOutputs(t *testing.T) {
+	address, err := util.NewAddressPublicKey(bytes.Repeat([]byte{0x01}, 32), util.Bech32PrefixKaspaTest)
+	if err != nil {
+		t.Fatalf("NewAddressPublicKey: %+v", err)
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatalf("PayToAddrScript: %+v", err)
+	}
+	transaction := &externalapi.DomainTransaction{
+		Outputs: []*externalapi.DomainTransactionOutput{
+			{Value: 1, ScriptPublicKey: scriptPublicKey},
+			{Value: 100000000, ScriptPublicKey: scriptPublicKey},
+			{Value: 2, ScriptPublicKey: scriptPublicKey},
+		},
+	}
+
+	mp := &mempool{config: &Config{DustRelayTransactionFee: 1000}}
+	err = mp.checkTransactionStandardInIsolation(transaction)
+	var txRuleError TxRuleError
+	if !errors.As(err, &txRuleError) || txRuleError.RejectCode != RejectDust {
+		t.Fatalf("Expected the transaction to be rejected as dust, but got: %v", err)
+	}
+	threshold := mp.DustThreshold(scriptPublicKey)
+	for _, expectedSubstring := range []string{
+		"2 dust outputs",
+		fmt.Sprintf("output 0: payment of 1 is below the dust threshold of %d", threshold),
+		fmt.Sprintf("output 2: payment of 2 is below the dust threshold of %d", threshold),
+	} {
+		if !strings.Contains(txRuleError.Description, expectedSubstring) {
+			t.Errorf("Expected the rejection %q to contain %q", txRuleError.Description, expectedSubstring)
+		}
+	}
+	if strings.Contains(txRuleError.Description, "output 1:") {
+		t.Errorf("Expected the rejection %q not to report output 1, which isn't dust", txRuleError.Description)
+	}
+}
+
+func TestCheckTransactionStandardInIsolationReportsMassAndLimit(t *testing.T) {
+	tests := []struct {
+		mass                            uint64
+		maximumStandardTransactionMass  uint64
+		expectedMinimumTransactionCount uint64
+	}{
+		{mass: 2500, maximumStandardTransactionMass: 1000, expectedMinimumTransactionCount: 3},
+		{mass: 3000, maximumStandardTransactionMass: 1000, expectedMinimumTransactionCount: 3},
+		{mass: 1001, maximumStandardTransactionMass: 1000, expectedMinimumTransactionCount: 2},
+	}
+
+	for _, test := range tests {
+		transaction := &externalapi.DomainTransaction{Mass: test.mass}
+		mp := &mempool{config: &Config{MaximumStandardTransactionMass: test.maximumStandardTransactionMass}}
+		err := mp.checkTransactionStandardInIsolation(transaction)
+		var txRuleError TxRuleError
+		if !errors.As(err, &txRuleError) || txRuleError.RejectCode != RejectNonstandard {
+			t.Fatalf("Expected a transaction of mass %d to be rejected as non-standard, but got: %v", test.mass, err)
+		}
+		for _, expectedSubstring := range []string{
+			fmt.Sprintf("transaction mass of %d", test.mass),
+			fmt.Sprintf("maximum standard transaction mass of %d", test.maximumStandardTransactionMass),
+			fmt.Sprintf("at least %d transactions", test.expectedMinimumTransactionCount),
+		} {
+			if !strings.Contains(txRuleError.Description, expectedSubstring) {
+				t.Errorf("Expected the rejection %q to contain %q", txRuleError.Description, expectedSubstring)
+			}
+		}
+	}
+
+	transaction := &externalapi.DomainTransaction{Mass: 1000}
+	mp := &mempool{config: &Config{MaximumStandardTransactionMass: 1000}}
+	err := mp.checkTransactionStandardInIsolation(transaction)
+	if err != nil {
+		t.Fatalf("Expected a transaction of the maximum standard mass to be standard, but got: %v", err)
+	}
+}
+
 func TestCheckTransactionStandardInIsolation(t *testing.T) {
 	// Create some dummy, but otherwise standard, data for transactions.
 	prevOutTxID := &externalapi.DomainTransactionID{}
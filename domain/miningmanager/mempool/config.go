@@ -13,6 +13,10 @@ import (
 const (
 	defaultMaximumTransactionCount = 1_000_000
 
+	// defaultMaximumTransactionPoolSize is the default maximum estimated
+	// serialized size, in bytes, of the transactions in the transaction pool
+	defaultMaximumTransactionPoolSize = 300_000_000
+
 	defaultTransactionExpireIntervalSeconds     uint64 = 60
 	defaultTransactionExpireScanIntervalSeconds uint64 = 10
 	defaultOrphanExpireIntervalSeconds          uint64 = 60
@@ -38,6 +42,7 @@ const (
 // Config represents a mempool configuration
 type Config struct {
 	MaximumTransactionCount               uint64
+	MaximumTransactionPoolSize            uint64
 	TransactionExpireIntervalDAAScore     uint64
 	TransactionExpireScanIntervalDAAScore uint64
 	TransactionExpireScanIntervalSeconds  uint64
@@ -46,8 +51,13 @@ type Config struct {
 	MaximumOrphanTransactionMass          uint64
 	MaximumOrphanTransactionCount         uint64
 	AcceptNonStandard                     bool
+	AcceptReplacements                    bool
+	RequireMinimalPushes                  bool
+	RequireCanonicalSignatures            bool
 	MaximumMassPerBlock                   uint64
+	MaximumStandardTransactionMass        uint64
 	MinimumRelayTransactionFee            util.Amount
+	DustRelayTransactionFee               util.Amount
 	MinimumStandardTransactionVersion     uint16
 	MaximumStandardTransactionVersion     uint16
 }
@@ -58,6 +68,7 @@ func DefaultConfig(dagParams *dagconfig.Params) *Config {
 
 	return &Config{
 		MaximumTransactionCount:               defaultMaximumTransactionCount,
+		MaximumTransactionPoolSize:            defaultMaximumTransactionPoolSize,
 		TransactionExpireIntervalDAAScore:     uint64(float64(defaultTransactionExpireIntervalSeconds) / targetBlocksPerSecond),
 		TransactionExpireScanIntervalDAAScore: uint64(float64(defaultTransactionExpireScanIntervalSeconds) / targetBlocksPerSecond),
 		TransactionExpireScanIntervalSeconds:  defaultTransactionExpireScanIntervalSeconds,
@@ -66,8 +77,12 @@ func DefaultConfig(dagParams *dagconfig.Params) *Config {
 		MaximumOrphanTransactionMass:          defaultMaximumOrphanTransactionMass,
 		MaximumOrphanTransactionCount:         defaultMaximumOrphanTransactionCount,
 		AcceptNonStandard:                     dagParams.RelayNonStdTxs,
+		RequireMinimalPushes:                  dagParams.RequireMinimalPushes,
+		RequireCanonicalSignatures:            dagParams.RequireCanonicalSignatures,
 		MaximumMassPerBlock:                   dagParams.MaxBlockMass,
+		MaximumStandardTransactionMass:        MaximumStandardTransactionMass,
 		MinimumRelayTransactionFee:            defaultMinimumRelayTransactionFee,
+		DustRelayTransactionFee:               defaultMinimumRelayTransactionFee,
 		MinimumStandardTransactionVersion:     defaultMinimumStandardTransactionVersion,
 		MaximumStandardTransactionVersion:     defaultMaximumStandardTransactionVersion,
 	}
@@ -0,0 +1,53 @@
+package mempool
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+)
+
+// EntryDetails returns the details of the given pending transactions, or of
+// all of them if transactionIDs is empty. The transactions that aren't found
+// are skipped, as are the ones in the transaction pool if
+// includeTransactionPool is not set.
+func (mp *mempool) EntryDetails(transactionIDs []*externalapi.DomainTransactionID, includeTransactionPool bool,
+	includeOrphanPool bool) []*miningmanagermodel.MempoolEntryDetails {
+
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	graph := mp.buildTransactionGraph(includeOrphanPool)
+
+	if len(transactionIDs) == 0 {
+		transactionIDs = make([]*externalapi.DomainTransactionID, 0, len(graph.transactions))
+		for transactionID := range graph.transactions {
+			transactionID := transactionID
+			transactionIDs = append(transactionIDs, &transactionID)
+		}
+		sortTransactionIDs(transactionIDs)
+	}
+
+	entries := make([]*miningmanagermodel.MempoolEntryDetails, 0, len(transactionIDs))
+	for _, transactionID := range transactionIDs {
+		entry := &miningmanagermodel.MempoolEntryDetails{
+			DependsIDs: sortedTransactionIDs(graph.parents[*transactionID]),
+			SpentByIDs: sortedTransactionIDs(graph.children[*transactionID]),
+		}
+		if mempoolTransaction, ok := mp.transactionsPool.allTransactions[*transactionID]; ok {
+			if !includeTransactionPool {
+				continue
+			}
+			entry.Transaction = mempoolTransaction.Transaction().Clone() //this pointer leaves the mempool, hence we clone.
+			entry.AddedAt = mempoolTransaction.AddedAt()
+			entry.AddedAtDAAScore = mempoolTransaction.AddedAtDAAScore()
+		} else if orphanTransaction, ok := mp.orphansPool.allOrphans[*transactionID]; ok && includeOrphanPool {
+			entry.Transaction = orphanTransaction.Transaction().Clone()
+			entry.IsOrphan = true
+			entry.AddedAt = orphanTransaction.AddedAt()
+			entry.AddedAtDAAScore = orphanTransaction.AddedAtDAAScore()
+		} else {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
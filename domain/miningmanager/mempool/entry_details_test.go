@@ -0,0 +1,78 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+)
+
+func TestEntryDetails(t *testing.T) {
+	mp := &mempool{
+		transactionsPool: &transactionsPool{allTransactions: model.IDToTransactionMap{}},
+		orphansPool:      &orphansPool{allOrphans: idToOrphanMap{}},
+	}
+	lockTime := uint64(0)
+	newTransaction := func(spentTransactionIDs ...*externalapi.DomainTransactionID) *externalapi.DomainTransaction {
+		// Every transaction gets a distinct lock time, so that it gets a distinct ID
+		lockTime++
+		transaction := &externalapi.DomainTransaction{LockTime: lockTime, Fee: 1000, Mass: 2000}
+		for _, transactionID := range spentTransactionIDs {
+			transaction.Inputs = append(transaction.Inputs, &externalapi.DomainTransactionInput{
+				PreviousOutpoint: externalapi.DomainOutpoint{TransactionID: *transactionID, Index: 0},
+			})
+		}
+		return transaction
+	}
+	addedAt := time.UnixMilli(1_600_000_000_000)
+
+	parent := model.NewMempoolTransaction(newTransaction(), nil, false, 10, addedAt)
+	mp.transactionsPool.allTransactions[*parent.TransactionID()] = parent
+	child := model.NewMempoolTransaction(newTransaction(parent.TransactionID()), nil, false, 11,
+		addedAt.Add(time.Second))
+	mp.transactionsPool.allTransactions[*child.TransactionID()] = child
+	orphan := model.NewOrphanTransaction(newTransaction(child.TransactionID()), false, 12,
+		addedAt.Add(2*time.Second))
+	mp.orphansPool.allOrphans[*orphan.TransactionID()] = orphan
+
+	entries := mp.EntryDetails([]*externalapi.DomainTransactionID{child.TransactionID()}, true, true)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, but got %d", len(entries))
+	}
+	childEntry := entries[0]
+	if !childEntry.Transaction.Equal(child.Transaction()) || childEntry.IsOrphan {
+		t.Fatalf("Expected the entry to be the child, but got %+v", childEntry)
+	}
+	if childEntry.Transaction == child.Transaction() {
+		t.Fatalf("Expected the transaction of the entry to be a copy")
+	}
+	if !childEntry.AddedAt.Equal(addedAt.Add(time.Second)) || childEntry.AddedAtDAAScore != 11 {
+		t.Fatalf("Unexpected arrival of the child: %s at DAA score %d", childEntry.AddedAt, childEntry.AddedAtDAAScore)
+	}
+	expectTransactionIDs(t, "child depends", childEntry.DependsIDs, parent.TransactionID())
+	expectTransactionIDs(t, "child spent by", childEntry.SpentByIDs, orphan.TransactionID())
+
+	// Without the orphan pool, nothing spends the child
+	entries = mp.EntryDetails([]*externalapi.DomainTransactionID{child.TransactionID()}, true, false)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, but got %d", len(entries))
+	}
+	expectTransactionIDs(t, "child spent by without orphans", entries[0].SpentByIDs)
+
+	// Filtering the transaction pool leaves only the orphan, which still
+	// depends on the child
+	entries = mp.EntryDetails(nil, false, true)
+	if len(entries) != 1 || !entries[0].IsOrphan {
+		t.Fatalf("Expected only the orphan, but got %+v", entries)
+	}
+	expectTransactionIDs(t, "orphan depends", entries[0].DependsIDs, child.TransactionID())
+	if entries[0].AddedAtDAAScore != 12 {
+		t.Fatalf("Expected the orphan to be added at DAA score 12, but got %d", entries[0].AddedAtDAAScore)
+	}
+
+	entries = mp.EntryDetails(nil, true, true)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, but got %d", len(entries))
+	}
+}
@@ -1,58 +1,68 @@
 package mempool
 
 import (
+	"fmt"
+
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
 )
 
+// handleNewBlockTransactions removes the transactions of a new block, and the
+// transactions that double spend them, from the mempool. It returns the orphans
+// that got accepted as a result, and the block transactions that were in the
+// transaction pool.
 func (mp *mempool) handleNewBlockTransactions(blockTransactions []*externalapi.DomainTransaction) (
-	[]*externalapi.DomainTransaction, error) {
+	acceptedOrphans []*externalapi.DomainTransaction, includedTransactions []*externalapi.DomainTransaction, err error) {
 
 	// Skip the coinbase transaction
 	blockTransactions = blockTransactions[transactionhelper.CoinbaseTransactionIndex+1:]
 
-	acceptedOrphans := []*externalapi.DomainTransaction{}
+	acceptedOrphans = []*externalapi.DomainTransaction{}
 	for _, transaction := range blockTransactions {
 		transactionID := consensushashing.TransactionID(transaction)
-		err := mp.removeTransaction(transactionID, false)
+		if _, ok := mp.transactionsPool.allTransactions[*transactionID]; ok {
+			includedTransactions = append(includedTransactions, transaction)
+		}
+		err := mp.removeTransaction(transactionID, false, "")
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		err = mp.removeDoubleSpends(transaction)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		err = mp.orphansPool.removeOrphan(transactionID, false)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		acceptedOrphansFromThisTransaction, err := mp.orphansPool.processOrphansAfterAcceptedTransaction(transaction)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		acceptedOrphans = append(acceptedOrphans, acceptedOrphansFromThisTransaction...)
 	}
-	err := mp.orphansPool.expireOrphanTransactions()
+	err = mp.orphansPool.expireOrphanTransactions()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	err = mp.transactionsPool.expireOldTransactions()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return acceptedOrphans, nil
+	return acceptedOrphans, includedTransactions, nil
 }
 
 func (mp *mempool) removeDoubleSpends(transaction *externalapi.DomainTransaction) error {
 	for _, input := range transaction.Inputs {
 		if redeemer, ok := mp.mempoolUTXOSet.transactionByPreviousOutpoint[input.PreviousOutpoint]; ok {
-			err := mp.removeTransaction(redeemer.TransactionID(), true)
+			err := mp.removeTransaction(redeemer.TransactionID(), true,
+				fmt.Sprintf("it double spends transaction %s of a new block", consensushashing.TransactionID(transaction)))
 			if err != nil {
 				return err
 			}
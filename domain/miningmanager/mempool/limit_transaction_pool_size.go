@@ -0,0 +1,141 @@
+package mempool
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+	"github.com/kaspanet/kaspad/util/txmass"
+)
+
+// rollingMinimumFeeRateHalfLife is the time it takes the minimum fee rate
+// raised by evictions to decay by half while the transaction pool is at least
+// half full. It decays twice as fast while the pool is less than half full, and
+// four times as fast while it's less than a quarter full.
+const rollingMinimumFeeRateHalfLife = 12 * time.Hour
+
+func transactionSize(transaction *model.MempoolTransaction) uint64 {
+	return txmass.TransactionEstimatedSerializedSize(transaction.Transaction())
+}
+
+// limitTransactionPoolSize evicts the transactions paying the lowest fee rates,
+// along with all the transactions that spend their outputs, until the estimated
+// serialized size of the transaction pool is within MaximumTransactionPoolSize.
+// Every eviction raises the rolling minimum fee rate above the fee rate of the
+// evicted package, so that transactions that would be evicted right away
+// aren't accepted in the first place.
+func (tp *transactionsPool) limitTransactionPoolSize() error {
+	maximumSize := tp.mempool.config.MaximumTransactionPoolSize
+	currentIndex := 0
+
+	for tp.totalSize > maximumSize {
+		if currentIndex >= len(tp.allTransactions) {
+			log.Warnf("The size of the high-priority transactions in mempool (%d bytes) is higher than "+
+				"maximum allowed (%d bytes)", tp.totalSize, maximumSize)
+			return nil
+		}
+		transactionToRemove := tp.transactionsOrderedByFeeRate.GetByIndex(currentIndex)
+		if transactionToRemove.IsHighPriority() {
+			currentIndex++
+			continue
+		}
+
+		evictedPackage := model.IDToTransactionMap{*transactionToRemove.TransactionID(): transactionToRemove}
+		for _, redeemer := range tp.getRedeemers(transactionToRemove) {
+			evictedPackage[*redeemer.TransactionID()] = redeemer
+		}
+		packageFee, packageMass := uint64(0), uint64(0)
+		for _, transaction := range evictedPackage {
+			packageFee += transaction.Transaction().Fee
+			packageMass += transaction.Transaction().Mass
+		}
+
+		log.Debugf("Removing transaction %s and its %d redeemers, because the mempool size (%d bytes) "+
+			"exceeded the limit (%d bytes)", transactionToRemove.TransactionID(), len(evictedPackage)-1,
+			tp.totalSize, maximumSize)
+		err := tp.mempool.removeTransaction(transactionToRemove.TransactionID(), true,
+			"the mempool is full and its fee rate is among the lowest")
+		if err != nil {
+			return err
+		}
+		tp.raiseRollingMinimumFeeRate(packageFee, packageMass)
+	}
+	return nil
+}
+
+// raiseRollingMinimumFeeRate raises the rolling minimum fee rate above the fee
+// rate of an evicted package by the minimum relay fee, so that a transaction
+// has to pay more than the evicted ones to take their place
+func (tp *transactionsPool) raiseRollingMinimumFeeRate(packageFee uint64, packageMass uint64) {
+	if packageMass == 0 {
+		return
+	}
+	tp.decayRollingMinimumFeeRate()
+
+	// Fee rates are in sompi per 1000 grams of mass, like the minimum relay fee
+	feeRate := uint64(math.Ceil(float64(packageFee)*1000/float64(packageMass))) +
+		uint64(tp.mempool.config.MinimumRelayTransactionFee)
+	if feeRate > tp.rollingMinimumFeeRate {
+		tp.rollingMinimumFeeRate = feeRate
+	}
+}
+
+// decayRollingMinimumFeeRate lowers the rolling minimum fee rate according to
+// the time passed since it was last updated, and drops it altogether once it's
+// below half the minimum relay fee
+func (tp *transactionsPool) decayRollingMinimumFeeRate() {
+	now := time.Now()
+	elapsed := now.Sub(tp.lastRollingMinimumFeeRateUpdate)
+	tp.lastRollingMinimumFeeRateUpdate = now
+	if tp.rollingMinimumFeeRate == 0 {
+		return
+	}
+
+	halfLife := rollingMinimumFeeRateHalfLife
+	maximumSize := tp.mempool.config.MaximumTransactionPoolSize
+	if tp.totalSize < maximumSize/4 {
+		halfLife /= 4
+	} else if tp.totalSize < maximumSize/2 {
+		halfLife /= 2
+	}
+	tp.rollingMinimumFeeRate = uint64(float64(tp.rollingMinimumFeeRate) / math.Pow(2, elapsed.Seconds()/halfLife.Seconds()))
+
+	if tp.rollingMinimumFeeRate < uint64(tp.mempool.config.MinimumRelayTransactionFee)/2 {
+		tp.rollingMinimumFeeRate = 0
+	}
+}
+
+// minimumFeeRate returns the minimum fee rate, in sompi per 1000 grams of mass,
+// of the transactions accepted to the transaction pool: the minimum relay fee,
+// or the rolling minimum fee rate if the pool recently had to evict
+// transactions and it's higher
+func (tp *transactionsPool) minimumFeeRate() uint64 {
+	tp.decayRollingMinimumFeeRate()
+
+	minimumRelayTransactionFee := uint64(tp.mempool.config.MinimumRelayTransactionFee)
+	if tp.rollingMinimumFeeRate > minimumRelayTransactionFee {
+		return tp.rollingMinimumFeeRate
+	}
+	return minimumRelayTransactionFee
+}
+
+// checkRollingMinimumFee rejects the given transaction if it pays less than the
+// rolling minimum fee rate, which is raised while the transaction pool is full
+func (tp *transactionsPool) checkRollingMinimumFee(transaction *externalapi.DomainTransaction) error {
+	tp.decayRollingMinimumFeeRate()
+	if tp.rollingMinimumFeeRate == 0 {
+		return nil
+	}
+
+	minimumFee := uint64(math.Ceil(float64(transaction.Mass) * float64(tp.rollingMinimumFeeRate) / 1000))
+	if transaction.Fee < minimumFee {
+		str := fmt.Sprintf("transaction %s has %d fees, but the mempool is full and requires a fee rate of "+
+			"%d sompi per 1000 grams of mass, which is %d fees", consensushashing.TransactionID(transaction),
+			transaction.Fee, tp.rollingMinimumFeeRate, minimumFee)
+		return transactionRuleError(RejectInsufficientFee, str)
+	}
+	return nil
+}
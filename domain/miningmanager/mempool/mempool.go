@@ -6,7 +6,9 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensusreference"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
 	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/util"
 )
 
 type mempool struct {
@@ -18,6 +20,18 @@ type mempool struct {
 	mempoolUTXOSet   *mempoolUTXOSet
 	transactionsPool *transactionsPool
 	orphansPool      *orphansPool
+
+	onTransactionsRemovedHandler miningmanagermodel.OnTransactionsRemovedHandler
+
+	// removedTransactions are the transactions removed since the
+	// onTransactionsRemoved handler was last called
+	removedTransactions []*miningmanagermodel.RemovedTransaction
+
+	onTransactionsAddedHandler miningmanagermodel.OnTransactionsAddedHandler
+
+	// addedTransactions are the transactions added since the
+	// onTransactionsAdded handler was last called
+	addedTransactions []*externalapi.DomainTransaction
 }
 
 // New constructs a new mempool
@@ -37,6 +51,8 @@ func New(config *Config, consensusReference consensusreference.ConsensusReferenc
 func (mp *mempool) ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
 	acceptedTransactions []*externalapi.DomainTransaction, err error) {
 
+	defer mp.notifyRemovedTransactions()
+	defer mp.notifyAddedTransactions()
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
 
@@ -68,6 +84,29 @@ func (mp *mempool) GetTransaction(transactionID *externalapi.DomainTransactionID
 	return transaction, isOrphan, transactionfound
 }
 
+// GetUTXOEntry returns the entry of the given outpoint if it's an output of a
+// transaction in the transaction pool
+func (mp *mempool) GetUTXOEntry(outpoint *externalapi.DomainOutpoint) (externalapi.UTXOEntry, bool) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	entry, ok := mp.mempoolUTXOSet.poolUnspentOutputs[*outpoint]
+	return entry, ok
+}
+
+// GetOutpointSpender returns the transaction in the transaction pool that
+// spends the given outpoint, if there's one
+func (mp *mempool) GetOutpointSpender(outpoint *externalapi.DomainOutpoint) (*externalapi.DomainTransaction, bool) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	spender, ok := mp.mempoolUTXOSet.transactionByPreviousOutpoint[*outpoint]
+	if !ok {
+		return nil, false
+	}
+	return spender.Transaction(), true
+}
+
 func (mp *mempool) GetTransactionsByAddresses(includeTransactionPool bool, includeOrphanPool bool) (
 	sendingInTransactionPool map[string]*externalapi.DomainTransaction,
 	receivingInTransactionPool map[string]*externalapi.DomainTransaction,
@@ -129,15 +168,17 @@ func (mp *mempool) TransactionCount(includeTransactionPool bool, includeOrphanPo
 }
 
 func (mp *mempool) HandleNewBlockTransactions(transactions []*externalapi.DomainTransaction) (
-	acceptedOrphans []*externalapi.DomainTransaction, err error) {
+	acceptedOrphans []*externalapi.DomainTransaction, includedTransactions []*externalapi.DomainTransaction, err error) {
 
+	defer mp.notifyRemovedTransactions()
+	defer mp.notifyAddedTransactions()
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
 
 	return mp.handleNewBlockTransactions(transactions)
 }
 
-func (mp *mempool) BlockCandidateTransactions() []*externalapi.DomainTransaction {
+func (mp *mempool) BlockCandidateTransactions() []*miningmanagermodel.BlockCandidateTransaction {
 	mp.mtx.RLock()
 	defer mp.mtx.RUnlock()
 
@@ -145,22 +186,152 @@ func (mp *mempool) BlockCandidateTransactions() []*externalapi.DomainTransaction
 }
 
 func (mp *mempool) RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error) {
+	defer mp.notifyRemovedTransactions()
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
 
 	return mp.revalidateHighPriorityTransactions()
 }
 
-func (mp *mempool) RemoveTransactions(transactions []*externalapi.DomainTransaction, removeRedeemers bool) error {
+// RevalidateTransactions takes the mempool lock for every batch of
+// transactions separately, so that it doesn't block other mempool operations
+// for the whole revalidation
+func (mp *mempool) RevalidateTransactions() (evictedTransactions []*miningmanagermodel.EvictedTransaction, err error) {
+	defer mp.notifyRemovedTransactions()
+	return mp.revalidateTransactions()
+}
+
+func (mp *mempool) RemoveTransactions(transactions []*externalapi.DomainTransaction, removeRedeemers bool,
+	reason string) error {
+
+	defer mp.notifyRemovedTransactions()
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return mp.removeTransactions(transactions, removeRedeemers, reason)
+}
+
+func (mp *mempool) RemoveTransaction(transactionID *externalapi.DomainTransactionID, removeRedeemers bool,
+	reason string) error {
+
+	defer mp.notifyRemovedTransactions()
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return mp.removeTransaction(transactionID, removeRedeemers, reason)
+}
+
+func (mp *mempool) SetOnTransactionsRemovedHandler(
+	onTransactionsRemovedHandler miningmanagermodel.OnTransactionsRemovedHandler) {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	mp.onTransactionsRemovedHandler = onTransactionsRemovedHandler
+}
+
+// notifyRemovedTransactions calls the onTransactionsRemoved handler with the
+// transactions that were removed since it was last called. It must be called
+// without holding the mempool lock, so that the handler may use the mempool.
+func (mp *mempool) notifyRemovedTransactions() {
+	mp.mtx.Lock()
+	onTransactionsRemovedHandler := mp.onTransactionsRemovedHandler
+	removedTransactions := mp.removedTransactions
+	mp.removedTransactions = nil
+	mp.mtx.Unlock()
+
+	if onTransactionsRemovedHandler != nil && len(removedTransactions) > 0 {
+		onTransactionsRemovedHandler(removedTransactions)
+	}
+}
+
+func (mp *mempool) SetOnTransactionsAddedHandler(
+	onTransactionsAddedHandler miningmanagermodel.OnTransactionsAddedHandler) {
+
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
 
-	return mp.removeTransactions(transactions, removeRedeemers)
+	mp.onTransactionsAddedHandler = onTransactionsAddedHandler
+}
+
+func (mp *mempool) recordAddedTransaction(mempoolTransaction *model.MempoolTransaction) {
+	// Nobody would be notified of the addition, so there's no point in
+	// cloning the transaction
+	if mp.onTransactionsAddedHandler == nil {
+		return
+	}
+	mp.addedTransactions = append(mp.addedTransactions, mempoolTransaction.Transaction().Clone())
 }
 
-func (mp *mempool) RemoveTransaction(transactionID *externalapi.DomainTransactionID, removeRedeemers bool) error {
+// notifyAddedTransactions calls the onTransactionsAdded handler with the
+// transactions that were added since it was last called. Like
+// notifyRemovedTransactions, it must be called without holding the mempool
+// lock.
+func (mp *mempool) notifyAddedTransactions() {
+	mp.mtx.Lock()
+	onTransactionsAddedHandler := mp.onTransactionsAddedHandler
+	addedTransactions := mp.addedTransactions
+	mp.addedTransactions = nil
+	mp.mtx.Unlock()
+
+	if onTransactionsAddedHandler != nil && len(addedTransactions) > 0 {
+		onTransactionsAddedHandler(addedTransactions)
+	}
+}
+
+// MinimumRelayTransactionFee returns the minimum fee, in sompi/KB, of the
+// transactions accepted to the mempool
+func (mp *mempool) MinimumRelayTransactionFee() util.Amount {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return mp.config.MinimumRelayTransactionFee
+}
+
+// SetMinimumRelayTransactionFee changes the minimum fee, in sompi/KB, of the
+// transactions accepted to the mempool. The transactions already in the mempool
+// are kept, even if they pay less.
+func (mp *mempool) SetMinimumRelayTransactionFee(minimumRelayTransactionFee util.Amount) {
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
 
-	return mp.removeTransaction(transactionID, removeRedeemers)
+	mp.config.MinimumRelayTransactionFee = minimumRelayTransactionFee
+}
+
+// EffectiveMinimumRelayTransactionFee returns the minimum fee, in sompi/KB, of
+// the transactions currently accepted to the mempool. It's higher than the
+// minimum relay fee while the mempool is full and evicts transactions.
+func (mp *mempool) EffectiveMinimumRelayTransactionFee() util.Amount {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return util.Amount(mp.transactionsPool.minimumFeeRate())
+}
+
+// DustRelayTransactionFee returns the fee rate, in sompi/KB, that the outputs
+// considered dust are defined in terms of
+func (mp *mempool) DustRelayTransactionFee() util.Amount {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return mp.config.DustRelayTransactionFee
+}
+
+// SetDustRelayTransactionFee changes the fee rate, in sompi/KB, that the
+// outputs considered dust are defined in terms of. The transactions already in
+// the mempool are kept, even if they have outputs that are now dust.
+func (mp *mempool) SetDustRelayTransactionFee(dustRelayTransactionFee util.Amount) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	mp.config.DustRelayTransactionFee = dustRelayTransactionFee
+}
+
+// DustThreshold returns the smallest value, in sompi, of an output paying to
+// the given scriptPublicKey that isn't considered dust
+func (mp *mempool) DustThreshold(scriptPublicKey *externalapi.ScriptPublicKey) uint64 {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return mp.dustThreshold(scriptPublicKey)
 }
@@ -5,8 +5,6 @@ import (
 
 	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
 
-	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
-
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
 	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
@@ -65,18 +63,28 @@ func (mpus *mempoolUTXOSet) removeTransaction(transaction *model.MempoolTransact
 	}
 }
 
-func (mpus *mempoolUTXOSet) checkDoubleSpends(transaction *externalapi.DomainTransaction) error {
-	outpoint := externalapi.DomainOutpoint{TransactionID: *consensushashing.TransactionID(transaction)}
-
-	for i, input := range transaction.Inputs {
-		outpoint.Index = uint32(i)
-
-		if existingTransaction, exists := mpus.transactionByPreviousOutpoint[input.PreviousOutpoint]; exists {
+// checkDoubleSpends returns the transactions in the transaction pool that spend
+// any of the outpoints the given transaction spends. Unless the mempool accepts
+// replacements, such a double spend is an error.
+func (mpus *mempoolUTXOSet) checkDoubleSpends(transaction *externalapi.DomainTransaction) (
+	conflictingTransactions []*model.MempoolTransaction, err error) {
+
+	isConflicting := make(map[externalapi.DomainTransactionID]bool)
+	for _, input := range transaction.Inputs {
+		existingTransaction, exists := mpus.transactionByPreviousOutpoint[input.PreviousOutpoint]
+		if !exists {
+			continue
+		}
+		if !mpus.mempool.config.AcceptReplacements {
 			str := fmt.Sprintf("output %s already spent by transaction %s in the memory pool",
 				input.PreviousOutpoint, existingTransaction.TransactionID())
-			return transactionRuleError(RejectDuplicate, str)
+			return nil, transactionRuleError(RejectDuplicate, str)
+		}
+		if !isConflicting[*existingTransaction.TransactionID()] {
+			isConflicting[*existingTransaction.TransactionID()] = true
+			conflictingTransactions = append(conflictingTransactions, existingTransaction)
 		}
 	}
 
-	return nil
+	return conflictingTransactions, nil
 }
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 )
@@ -11,6 +13,7 @@ type MempoolTransaction struct {
 	parentTransactionsInPool IDToTransactionMap
 	isHighPriority           bool
 	addedAtDAAScore          uint64
+	addedAt                  time.Time
 }
 
 // NewMempoolTransaction constructs a new MempoolTransaction
@@ -19,12 +22,14 @@ func NewMempoolTransaction(
 	parentTransactionsInPool IDToTransactionMap,
 	isHighPriority bool,
 	addedAtDAAScore uint64,
+	addedAt time.Time,
 ) *MempoolTransaction {
 	return &MempoolTransaction{
 		transaction:              transaction,
 		parentTransactionsInPool: parentTransactionsInPool,
 		isHighPriority:           isHighPriority,
 		addedAtDAAScore:          addedAtDAAScore,
+		addedAt:                  addedAt,
 	}
 }
 
@@ -57,3 +62,8 @@ func (mt *MempoolTransaction) IsHighPriority() bool {
 func (mt *MempoolTransaction) AddedAtDAAScore() uint64 {
 	return mt.addedAtDAAScore
 }
+
+// AddedAt returns the time at which this MempoolTransaction was added to the mempool
+func (mt *MempoolTransaction) AddedAt() time.Time {
+	return mt.addedAt
+}
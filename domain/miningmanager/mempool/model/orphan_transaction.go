@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 )
@@ -10,6 +12,7 @@ type OrphanTransaction struct {
 	transaction     *externalapi.DomainTransaction
 	isHighPriority  bool
 	addedAtDAAScore uint64
+	addedAt         time.Time
 }
 
 // NewOrphanTransaction constructs a new OrphanTransaction
@@ -17,11 +20,13 @@ func NewOrphanTransaction(
 	transaction *externalapi.DomainTransaction,
 	isHighPriority bool,
 	addedAtDAAScore uint64,
+	addedAt time.Time,
 ) *OrphanTransaction {
 	return &OrphanTransaction{
 		transaction:     transaction,
 		isHighPriority:  isHighPriority,
 		addedAtDAAScore: addedAtDAAScore,
+		addedAt:         addedAt,
 	}
 }
 
@@ -44,3 +49,8 @@ func (ot *OrphanTransaction) IsHighPriority() bool {
 func (ot *OrphanTransaction) AddedAtDAAScore() uint64 {
 	return ot.addedAtDAAScore
 }
+
+// AddedAt returns the time at which this OrphanTransaction was added to the mempool
+func (ot *OrphanTransaction) AddedAt() time.Time {
+	return ot.addedAt
+}
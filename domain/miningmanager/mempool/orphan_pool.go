@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
 
@@ -124,7 +125,7 @@ func (op *orphansPool) addOrphan(transaction *externalapi.DomainTransaction, isH
 	if err != nil {
 		return err
 	}
-	orphanTransaction := model.NewOrphanTransaction(transaction, isHighPriority, virtualDAAScore)
+	orphanTransaction := model.NewOrphanTransaction(transaction, isHighPriority, virtualDAAScore, time.Now())
 
 	op.allOrphans[*orphanTransaction.TransactionID()] = orphanTransaction
 	for _, input := range transaction.Inputs {
@@ -218,6 +219,7 @@ func (op *orphansPool) unorphanTransaction(transaction *model.OrphanTransaction)
 		op.mempool.transactionsPool.getParentTransactionsInPool(transaction.Transaction()),
 		false,
 		virtualDAAScore,
+		time.Now(),
 	)
 	err = op.mempool.transactionsPool.addMempoolTransaction(mempoolTransaction)
 	if err != nil {
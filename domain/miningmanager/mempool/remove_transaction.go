@@ -1,14 +1,19 @@
 package mempool
 
 import (
+	"fmt"
+
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
 )
 
-func (mp *mempool) removeTransactions(transactions []*externalapi.DomainTransaction, removeRedeemers bool) error {
+func (mp *mempool) removeTransactions(transactions []*externalapi.DomainTransaction, removeRedeemers bool,
+	reason string) error {
+
 	for _, transaction := range transactions {
-		err := mp.removeTransaction(consensushashing.TransactionID(transaction), removeRedeemers)
+		err := mp.removeTransaction(consensushashing.TransactionID(transaction), removeRedeemers, reason)
 		if err != nil {
 			return err
 		}
@@ -16,7 +21,23 @@ func (mp *mempool) removeTransactions(transactions []*externalapi.DomainTransact
 	return nil
 }
 
-func (mp *mempool) removeTransaction(transactionID *externalapi.DomainTransactionID, removeRedeemers bool) error {
+// removeTransaction removes the given transaction from the mempool, and reports
+// its removal to the onTransactionsRemoved handler along with the given reason.
+// An empty reason means that the transaction was included in a block, in which
+// case its removal isn't reported.
+func (mp *mempool) removeTransaction(transactionID *externalapi.DomainTransactionID, removeRedeemers bool,
+	reason string) error {
+
+	return mp.removeTransactionReplacedBy(transactionID, removeRedeemers, reason, nil)
+}
+
+// removeTransactionReplacedBy is removeTransaction for a transaction that's
+// replaced by the transaction with the given ID, which is reported along with
+// its removal. replacementTransactionID is nil if the transaction isn't
+// replaced.
+func (mp *mempool) removeTransactionReplacedBy(transactionID *externalapi.DomainTransactionID, removeRedeemers bool,
+	reason string, replacementTransactionID *externalapi.DomainTransactionID) error {
+
 	if _, ok := mp.orphansPool.allOrphans[*transactionID]; ok {
 		return mp.orphansPool.removeOrphan(transactionID, true)
 	}
@@ -36,6 +57,16 @@ func (mp *mempool) removeTransaction(transactionID *externalapi.DomainTransactio
 		}
 	}
 
+	if reason != "" {
+		mp.recordRemovedTransaction(mempoolTransaction, reason, replacementTransactionID)
+	}
+	if removeRedeemers {
+		for _, redeemer := range redeemers {
+			mp.recordRemovedTransaction(redeemer,
+				fmt.Sprintf("it spends an output of removed transaction %s", transactionID), nil)
+		}
+	}
+
 	for _, transactionToRemove := range transactionsToRemove {
 		err := mp.removeTransactionFromSets(transactionToRemove, removeRedeemers)
 		if err != nil {
@@ -68,3 +99,17 @@ func (mp *mempool) removeTransactionFromSets(mempoolTransaction *model.MempoolTr
 
 	return nil
 }
+
+func (mp *mempool) recordRemovedTransaction(mempoolTransaction *model.MempoolTransaction, reason string,
+	replacementTransactionID *externalapi.DomainTransactionID) {
+	// Nobody would be notified of the removal, so there's no point in
+	// cloning the transaction
+	if mp.onTransactionsRemovedHandler == nil {
+		return
+	}
+	mp.removedTransactions = append(mp.removedTransactions, &miningmanagermodel.RemovedTransaction{
+		Transaction:              mempoolTransaction.Transaction().Clone(),
+		Reason:                   reason,
+		ReplacementTransactionID: replacementTransactionID,
+	})
+}
@@ -0,0 +1,93 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+)
+
+// maximumReplacementEvictions is the maximum number of transactions a single
+// replacement may evict from the mempool, counting the transactions it
+// conflicts with and all the transactions that spend their outputs. It bounds
+// the work a replacement costs, and the amount of transactions that have to be
+// relayed again if it's replaced itself.
+const maximumReplacementEvictions = 100
+
+// replaceConflictingTransactions removes the given transactions, which spend
+// some of the outpoints the given transaction spends, along with all the
+// transactions that spend their outputs, so that the given transaction may be
+// added to the mempool instead. This is allowed only if:
+//   - The fee rate of the transaction is higher than the fee rate of each of the
+//     transactions it conflicts with
+//   - The transaction pays at least the fees of all the transactions it evicts,
+//     plus the minimum relay fee for its own mass, so that relaying it is paid for
+//   - The transaction doesn't evict more than maximumReplacementEvictions
+//     transactions
+//   - The transaction doesn't spend outputs of any of the transactions it evicts
+//
+// It's called after the transaction was fully validated, so that its fee is
+// known and the mempool isn't changed for a transaction that would be rejected
+// anyway.
+func (mp *mempool) replaceConflictingTransactions(transaction *externalapi.DomainTransaction,
+	conflictingTransactions []*model.MempoolTransaction, parentsInPool model.IDToTransactionMap) error {
+
+	transactionID := consensushashing.TransactionID(transaction)
+
+	evictedTransactions := make(model.IDToTransactionMap)
+	for _, conflictingTransaction := range conflictingTransactions {
+		evictedTransactions[*conflictingTransaction.TransactionID()] = conflictingTransaction
+		for _, redeemer := range mp.transactionsPool.getRedeemers(conflictingTransaction) {
+			evictedTransactions[*redeemer.TransactionID()] = redeemer
+		}
+	}
+	if len(evictedTransactions) > maximumReplacementEvictions {
+		str := fmt.Sprintf("transaction %s would replace %d transactions, more than the maximum of %d",
+			transactionID, len(evictedTransactions), maximumReplacementEvictions)
+		return transactionRuleError(RejectDuplicate, str)
+	}
+
+	for parentID := range parentsInPool {
+		if _, ok := evictedTransactions[parentID]; ok {
+			str := fmt.Sprintf("transaction %s spends an output of transaction %s, which it would replace",
+				transactionID, parentID)
+			return transactionRuleError(RejectDuplicate, str)
+		}
+	}
+
+	feeRate := float64(transaction.Fee) / float64(transaction.Mass)
+	for _, conflictingTransaction := range conflictingTransactions {
+		conflictingFeeRate := float64(conflictingTransaction.Transaction().Fee) /
+			float64(conflictingTransaction.Transaction().Mass)
+		if feeRate <= conflictingFeeRate {
+			str := fmt.Sprintf("transaction %s has a fee rate of %f sompi/gram, which isn't higher than "+
+				"the fee rate of %f sompi/gram of transaction %s, which it would replace",
+				transactionID, feeRate, conflictingFeeRate, conflictingTransaction.TransactionID())
+			return transactionRuleError(RejectInsufficientFee, str)
+		}
+	}
+
+	evictedFees := uint64(0)
+	for _, evictedTransaction := range evictedTransactions {
+		evictedFees += evictedTransaction.Transaction().Fee
+	}
+	minimumFee := evictedFees + mp.minimumRequiredTransactionRelayFee(transaction.Mass)
+	if transaction.Fee < minimumFee {
+		str := fmt.Sprintf("transaction %s has a fee of %d sompi, but replacing %d transactions that pay "+
+			"%d sompi requires a fee of at least %d sompi", transactionID, transaction.Fee,
+			len(evictedTransactions), evictedFees, minimumFee)
+		return transactionRuleError(RejectInsufficientFee, str)
+	}
+
+	for _, conflictingTransaction := range conflictingTransactions {
+		err := mp.removeTransactionReplacedBy(conflictingTransaction.TransactionID(), true,
+			fmt.Sprintf("it was replaced by transaction %s, which pays a higher fee", transactionID), transactionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Debugf("Transaction %s replaced %d transactions in the mempool", transactionID, len(evictedTransactions))
+	return nil
+}
@@ -0,0 +1,105 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+// revalidationBatchSize is the amount of transactions that are revalidated
+// under a single hold of the mempool lock
+const revalidationBatchSize = 100
+
+func (mp *mempool) revalidateTransactions() ([]*miningmanagermodel.EvictedTransaction, error) {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "revalidateTransactions")
+	defer onEnd()
+
+	mp.mtx.RLock()
+	transactionIDs := make([]externalapi.DomainTransactionID, 0, len(mp.transactionsPool.allTransactions))
+	for transactionID := range mp.transactionsPool.allTransactions {
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+	mp.mtx.RUnlock()
+
+	var evictedTransactions []*miningmanagermodel.EvictedTransaction
+	for start := 0; start < len(transactionIDs); start += revalidationBatchSize {
+		end := start + revalidationBatchSize
+		if end > len(transactionIDs) {
+			end = len(transactionIDs)
+		}
+		batchEvictedTransactions, err := mp.revalidateTransactionsBatch(transactionIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		evictedTransactions = append(evictedTransactions, batchEvictedTransactions...)
+	}
+	return evictedTransactions, nil
+}
+
+func (mp *mempool) revalidateTransactionsBatch(transactionIDs []externalapi.DomainTransactionID) (
+	[]*miningmanagermodel.EvictedTransaction, error) {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	var evictedTransactions []*miningmanagermodel.EvictedTransaction
+	for i := range transactionIDs {
+		// The transaction might have been removed since the revalidation
+		// started, either by other mempool operations or by being the
+		// redeemer of an evicted transaction
+		transaction, ok := mp.transactionsPool.allTransactions[transactionIDs[i]]
+		if !ok {
+			continue
+		}
+		transactionEvictedTransactions, err := mp.revalidateTransactionOrEvict(transaction)
+		if err != nil {
+			return nil, err
+		}
+		evictedTransactions = append(evictedTransactions, transactionEvictedTransactions...)
+	}
+	return evictedTransactions, nil
+}
+
+// revalidateTransactionOrEvict revalidates the given transaction against the
+// current virtual UTXO set, and if it's no longer valid removes it, along with
+// its redeemers, from the mempool
+func (mp *mempool) revalidateTransactionOrEvict(transaction *model.MempoolTransaction) (
+	[]*miningmanagermodel.EvictedTransaction, error) {
+
+	clearInputs(transaction)
+
+	var reason string
+	_, missingOutpoints, err := mp.fillInputsAndGetMissingParents(transaction.Transaction())
+	if err != nil {
+		if !errors.As(err, &RuleError{}) {
+			return nil, err
+		}
+		reason = err.Error()
+	} else if len(missingOutpoints) > 0 {
+		reason = fmt.Sprintf("input %s is spent or missing", missingOutpoints[0])
+	} else {
+		return nil, nil
+	}
+
+	log.Debugf("Evicting transaction %s, it failed revalidation: %s", transaction.TransactionID(), reason)
+	evictedTransactions := []*miningmanagermodel.EvictedTransaction{{
+		Transaction: transaction.Transaction().Clone(),
+		Reason:      reason,
+	}}
+	for _, redeemer := range mp.transactionsPool.getRedeemers(transaction) {
+		evictedTransactions = append(evictedTransactions, &miningmanagermodel.EvictedTransaction{
+			Transaction: redeemer.Transaction().Clone(),
+			Reason:      fmt.Sprintf("it spends an output of evicted transaction %s", transaction.TransactionID()),
+		})
+	}
+
+	err = mp.removeTransaction(transaction.TransactionID(), true, reason)
+	if err != nil {
+		return nil, err
+	}
+	return evictedTransactions, nil
+}
@@ -0,0 +1,37 @@
+package mempool
+
+import (
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+)
+
+// Snapshot returns all the pending transactions in the transaction pool, and
+// in the orphan pool if includeOrphanPool is set, as of a single point in time
+func (mp *mempool) Snapshot(includeOrphanPool bool) []*miningmanagermodel.MempoolSnapshotEntry {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	entryCount := len(mp.transactionsPool.allTransactions)
+	if includeOrphanPool {
+		entryCount += len(mp.orphansPool.allOrphans)
+	}
+	entries := make([]*miningmanagermodel.MempoolSnapshotEntry, 0, entryCount)
+	for _, mempoolTransaction := range mp.transactionsPool.allTransactions {
+		entries = append(entries, &miningmanagermodel.MempoolSnapshotEntry{
+			Transaction:     mempoolTransaction.Transaction().Clone(), //this pointer leaves the mempool, hence we clone.
+			IsOrphan:        false,
+			IsHighPriority:  mempoolTransaction.IsHighPriority(),
+			AddedAtDAAScore: mempoolTransaction.AddedAtDAAScore(),
+		})
+	}
+	if includeOrphanPool {
+		for _, orphanTransaction := range mp.orphansPool.allOrphans {
+			entries = append(entries, &miningmanagermodel.MempoolSnapshotEntry{
+				Transaction:     orphanTransaction.Transaction().Clone(),
+				IsOrphan:        true,
+				IsHighPriority:  orphanTransaction.IsHighPriority(),
+				AddedAtDAAScore: orphanTransaction.AddedAtDAAScore(),
+			})
+		}
+	}
+	return entries
+}
@@ -0,0 +1,164 @@
+package mempool
+
+import (
+	"sort"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+)
+
+// transactionGraph is the dependency graph of the pending transactions,
+// along with the outpoints each of them spends
+type transactionGraph struct {
+	transactions map[externalapi.DomainTransactionID]*externalapi.DomainTransaction
+	isOrphan     map[externalapi.DomainTransactionID]bool
+	parents      map[externalapi.DomainTransactionID]map[externalapi.DomainTransactionID]struct{}
+	children     map[externalapi.DomainTransactionID]map[externalapi.DomainTransactionID]struct{}
+	spenders     map[externalapi.DomainOutpoint][]*externalapi.DomainTransactionID
+}
+
+// TransactionGraph returns how the given pending transactions relate to the
+// other pending transactions: their parents and children in the mempool, and
+// the outpoints they spend that other pending transactions spend as well. Only
+// orphans may have such conflicts, since the transaction pool rejects double
+// spends, while an orphan is checked for double spending the transaction pool
+// only once its missing parents arrive.
+//
+// All the pending transactions are returned if transactionIDs is empty. The
+// transactions that aren't found are skipped. The ancestors and descendants of
+// the transactions are computed only if includeAncestry is set, since they're
+// quadratic in the length of the chains of pending transactions.
+func (mp *mempool) TransactionGraph(transactionIDs []*externalapi.DomainTransactionID, includeOrphanPool bool,
+	includeAncestry bool) []*miningmanagermodel.TransactionGraphEntry {
+
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	graph := mp.buildTransactionGraph(includeOrphanPool)
+
+	if len(transactionIDs) == 0 {
+		transactionIDs = make([]*externalapi.DomainTransactionID, 0, len(graph.transactions))
+		for transactionID := range graph.transactions {
+			transactionID := transactionID
+			transactionIDs = append(transactionIDs, &transactionID)
+		}
+		sortTransactionIDs(transactionIDs)
+	}
+
+	entries := make([]*miningmanagermodel.TransactionGraphEntry, 0, len(transactionIDs))
+	for _, transactionID := range transactionIDs {
+		transaction, ok := graph.transactions[*transactionID]
+		if !ok {
+			continue
+		}
+		entry := &miningmanagermodel.TransactionGraphEntry{
+			TransactionID: transactionID,
+			IsOrphan:      graph.isOrphan[*transactionID],
+			ParentIDs:     sortedTransactionIDs(graph.parents[*transactionID]),
+			ChildIDs:      sortedTransactionIDs(graph.children[*transactionID]),
+			Conflicts:     graph.conflicts(transactionID, transaction),
+		}
+		if includeAncestry {
+			entry.AncestorIDs = sortedTransactionIDs(graph.closure(transactionID, graph.parents))
+			entry.DescendantIDs = sortedTransactionIDs(graph.closure(transactionID, graph.children))
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (mp *mempool) buildTransactionGraph(includeOrphanPool bool) *transactionGraph {
+	graph := &transactionGraph{
+		transactions: make(map[externalapi.DomainTransactionID]*externalapi.DomainTransaction),
+		isOrphan:     make(map[externalapi.DomainTransactionID]bool),
+		parents:      make(map[externalapi.DomainTransactionID]map[externalapi.DomainTransactionID]struct{}),
+		children:     make(map[externalapi.DomainTransactionID]map[externalapi.DomainTransactionID]struct{}),
+		spenders:     make(map[externalapi.DomainOutpoint][]*externalapi.DomainTransactionID),
+	}
+	for transactionID, mempoolTransaction := range mp.transactionsPool.allTransactions {
+		graph.transactions[transactionID] = mempoolTransaction.Transaction()
+	}
+	if includeOrphanPool {
+		for transactionID, orphanTransaction := range mp.orphansPool.allOrphans {
+			graph.transactions[transactionID] = orphanTransaction.Transaction()
+			graph.isOrphan[transactionID] = true
+		}
+	}
+
+	for transactionID, transaction := range graph.transactions {
+		transactionID := transactionID
+		for _, input := range transaction.Inputs {
+			graph.spenders[input.PreviousOutpoint] = append(graph.spenders[input.PreviousOutpoint], &transactionID)
+
+			parentID := input.PreviousOutpoint.TransactionID
+			if _, ok := graph.transactions[parentID]; !ok {
+				continue
+			}
+			if graph.parents[transactionID] == nil {
+				graph.parents[transactionID] = make(map[externalapi.DomainTransactionID]struct{})
+			}
+			graph.parents[transactionID][parentID] = struct{}{}
+			if graph.children[parentID] == nil {
+				graph.children[parentID] = make(map[externalapi.DomainTransactionID]struct{})
+			}
+			graph.children[parentID][transactionID] = struct{}{}
+		}
+	}
+	return graph
+}
+
+func (graph *transactionGraph) conflicts(transactionID *externalapi.DomainTransactionID,
+	transaction *externalapi.DomainTransaction) []*miningmanagermodel.TransactionConflict {
+
+	var conflicts []*miningmanagermodel.TransactionConflict
+	for _, input := range transaction.Inputs {
+		spenders := graph.spenders[input.PreviousOutpoint]
+		if len(spenders) < 2 {
+			continue
+		}
+		conflict := &miningmanagermodel.TransactionConflict{Outpoint: input.PreviousOutpoint}
+		for _, spender := range spenders {
+			if !spender.Equal(transactionID) {
+				conflict.ConflictingTransactionIDs = append(conflict.ConflictingTransactionIDs, spender)
+			}
+		}
+		sortTransactionIDs(conflict.ConflictingTransactionIDs)
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// closure returns the transactions reachable from the given one through the
+// given edges, not including itself
+func (graph *transactionGraph) closure(transactionID *externalapi.DomainTransactionID,
+	edges map[externalapi.DomainTransactionID]map[externalapi.DomainTransactionID]struct{}) map[externalapi.DomainTransactionID]struct{} {
+
+	reached := make(map[externalapi.DomainTransactionID]struct{})
+	stack := []externalapi.DomainTransactionID{*transactionID}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for next := range edges[current] {
+			if _, ok := reached[next]; ok {
+				continue
+			}
+			reached[next] = struct{}{}
+			stack = append(stack, next)
+		}
+	}
+	return reached
+}
+
+func sortedTransactionIDs(transactionIDSet map[externalapi.DomainTransactionID]struct{}) []*externalapi.DomainTransactionID {
+	transactionIDs := make([]*externalapi.DomainTransactionID, 0, len(transactionIDSet))
+	for transactionID := range transactionIDSet {
+		transactionID := transactionID
+		transactionIDs = append(transactionIDs, &transactionID)
+	}
+	sortTransactionIDs(transactionIDs)
+	return transactionIDs
+}
+
+func sortTransactionIDs(transactionIDs []*externalapi.DomainTransactionID) {
+	sort.Slice(transactionIDs, func(i, j int) bool { return transactionIDs[i].Less(transactionIDs[j]) })
+}
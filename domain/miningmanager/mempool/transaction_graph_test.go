@@ -0,0 +1,117 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+)
+
+func TestTransactionGraph(t *testing.T) {
+	mp := &mempool{
+		transactionsPool: &transactionsPool{allTransactions: model.IDToTransactionMap{}},
+		orphansPool:      &orphansPool{allOrphans: idToOrphanMap{}},
+	}
+	lockTime := uint64(0)
+	newTransaction := func(spentOutpoints ...externalapi.DomainOutpoint) *externalapi.DomainTransaction {
+		// Every transaction gets a distinct lock time, so that it gets a distinct ID
+		lockTime++
+		transaction := &externalapi.DomainTransaction{LockTime: lockTime}
+		for _, outpoint := range spentOutpoints {
+			transaction.Inputs = append(transaction.Inputs, &externalapi.DomainTransactionInput{PreviousOutpoint: outpoint})
+		}
+		return transaction
+	}
+	addTransaction := func(spentOutpoints ...externalapi.DomainOutpoint) *externalapi.DomainTransactionID {
+		transaction := model.NewMempoolTransaction(newTransaction(spentOutpoints...), nil, false, 0, time.Time{})
+		mp.transactionsPool.allTransactions[*transaction.TransactionID()] = transaction
+		return transaction.TransactionID()
+	}
+	addOrphan := func(spentOutpoints ...externalapi.DomainOutpoint) *externalapi.DomainTransactionID {
+		orphan := model.NewOrphanTransaction(newTransaction(spentOutpoints...), false, 0, time.Time{})
+		mp.orphansPool.allOrphans[*orphan.TransactionID()] = orphan
+		return orphan.TransactionID()
+	}
+	outpoint := func(transactionID *externalapi.DomainTransactionID) externalapi.DomainOutpoint {
+		return externalapi.DomainOutpoint{TransactionID: *transactionID, Index: 0}
+	}
+
+	confirmedTransactionID := consensushashing.TransactionID(newTransaction())
+	missingTransactionID := consensushashing.TransactionID(newTransaction())
+
+	// A chain of three pool transactions, and an orphan that spends the same
+	// outpoint as the middle one of them
+	parent := addTransaction(outpoint(confirmedTransactionID))
+	child := addTransaction(outpoint(parent))
+	grandchild := addTransaction(outpoint(child))
+	orphan := addOrphan(outpoint(parent), outpoint(missingTransactionID))
+	lone := addTransaction()
+
+	entries := mp.TransactionGraph([]*externalapi.DomainTransactionID{child, missingTransactionID, orphan},
+		true, true)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, but got %d", len(entries))
+	}
+
+	childEntry := entries[0]
+	if !childEntry.TransactionID.Equal(child) || childEntry.IsOrphan {
+		t.Fatalf("Expected the first entry to be the child, but got %s", childEntry.TransactionID)
+	}
+	expectTransactionIDs(t, "child parents", childEntry.ParentIDs, parent)
+	expectTransactionIDs(t, "child children", childEntry.ChildIDs, grandchild)
+	expectTransactionIDs(t, "child ancestors", childEntry.AncestorIDs, parent)
+	expectTransactionIDs(t, "child descendants", childEntry.DescendantIDs, grandchild)
+	if len(childEntry.Conflicts) != 1 || childEntry.Conflicts[0].Outpoint != outpoint(parent) {
+		t.Fatalf("Expected the child to conflict over the output of its parent, but got %+v", childEntry.Conflicts)
+	}
+	expectTransactionIDs(t, "child conflicts", childEntry.Conflicts[0].ConflictingTransactionIDs, orphan)
+
+	orphanEntry := entries[1]
+	if !orphanEntry.TransactionID.Equal(orphan) || !orphanEntry.IsOrphan {
+		t.Fatalf("Expected the second entry to be the orphan, but got %s", orphanEntry.TransactionID)
+	}
+	expectTransactionIDs(t, "orphan parents", orphanEntry.ParentIDs, parent)
+	expectTransactionIDs(t, "orphan children", orphanEntry.ChildIDs)
+	if len(orphanEntry.Conflicts) != 1 {
+		t.Fatalf("Expected the orphan to have 1 conflict, but got %d", len(orphanEntry.Conflicts))
+	}
+	expectTransactionIDs(t, "orphan conflicts", orphanEntry.Conflicts[0].ConflictingTransactionIDs, child)
+
+	// The ancestry of the grandchild spans the whole chain, and is left out
+	// unless it's requested
+	entries = mp.TransactionGraph([]*externalapi.DomainTransactionID{grandchild}, true, false)
+	if len(entries) != 1 || entries[0].AncestorIDs != nil || entries[0].DescendantIDs != nil {
+		t.Fatalf("Expected the grandchild without its ancestry, but got %+v", entries)
+	}
+	entries = mp.TransactionGraph([]*externalapi.DomainTransactionID{grandchild}, true, true)
+	expectTransactionIDs(t, "grandchild ancestors", entries[0].AncestorIDs, parent, child)
+
+	// Without the orphan pool there are no orphans, and so no conflicts
+	entries = mp.TransactionGraph(nil, false, false)
+	expectedTransactionIDs := []*externalapi.DomainTransactionID{parent, child, grandchild, lone}
+	sortTransactionIDs(expectedTransactionIDs)
+	entryTransactionIDs := make([]*externalapi.DomainTransactionID, len(entries))
+	for i, entry := range entries {
+		entryTransactionIDs[i] = entry.TransactionID
+		if entry.IsOrphan || len(entry.Conflicts) != 0 {
+			t.Fatalf("Expected no orphans or conflicts without the orphan pool, but got %+v", entry)
+		}
+	}
+	expectTransactionIDs(t, "all transactions", entryTransactionIDs, expectedTransactionIDs...)
+}
+
+func expectTransactionIDs(t *testing.T, name string, transactionIDs []*externalapi.DomainTransactionID,
+	expectedTransactionIDs ...*externalapi.DomainTransactionID) {
+
+	sortTransactionIDs(expectedTransactionIDs)
+	if len(transactionIDs) != len(expectedTransactionIDs) {
+		t.Fatalf("%s: expected %s, but got %s", name, expectedTransactionIDs, transactionIDs)
+	}
+	for i := range transactionIDs {
+		if !transactionIDs[i].Equal(expectedTransactionIDs[i]) {
+			t.Fatalf("%s: expected %s, but got %s", name, expectedTransactionIDs, transactionIDs)
+		}
+	}
+}
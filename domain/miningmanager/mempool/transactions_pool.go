@@ -8,6 +8,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
 )
 
 type transactionsPool struct {
@@ -18,6 +19,16 @@ type transactionsPool struct {
 	transactionsOrderedByFeeRate  model.TransactionsOrderedByFeeRate
 	lastExpireScanDAAScore        uint64
 	lastExpireScanTime            time.Time
+
+	// totalSize is the estimated serialized size of all the transactions
+	// in the pool, in bytes
+	totalSize uint64
+
+	// rollingMinimumFeeRate is the minimum fee rate, in sompi per 1000
+	// grams of mass, raised by evicting transactions from a full pool. It
+	// decays over time, and is 0 when it doesn't apply.
+	rollingMinimumFeeRate           uint64
+	lastRollingMinimumFeeRateUpdate time.Time
 }
 
 func newTransactionsPool(mp *mempool) *transactionsPool {
@@ -29,6 +40,10 @@ func newTransactionsPool(mp *mempool) *transactionsPool {
 		transactionsOrderedByFeeRate:  model.TransactionsOrderedByFeeRate{},
 		lastExpireScanDAAScore:        0,
 		lastExpireScanTime:            time.Now(),
+
+		totalSize:                       0,
+		rollingMinimumFeeRate:           0,
+		lastRollingMinimumFeeRateUpdate: time.Now(),
 	}
 }
 
@@ -41,7 +56,7 @@ func (tp *transactionsPool) addTransaction(transaction *externalapi.DomainTransa
 	}
 
 	mempoolTransaction := model.NewMempoolTransaction(
-		transaction, parentTransactionsInPool, isHighPriority, virtualDAAScore)
+		transaction, parentTransactionsInPool, isHighPriority, virtualDAAScore, time.Now())
 
 	err = tp.addMempoolTransaction(mempoolTransaction)
 	if err != nil {
@@ -53,6 +68,7 @@ func (tp *transactionsPool) addTransaction(transaction *externalapi.DomainTransa
 
 func (tp *transactionsPool) addMempoolTransaction(transaction *model.MempoolTransaction) error {
 	tp.allTransactions[*transaction.TransactionID()] = transaction
+	tp.totalSize += transactionSize(transaction)
 
 	for _, parentTransactionInPool := range transaction.ParentTransactionsInPool() {
 		parentTransactionID := *parentTransactionInPool.TransactionID()
@@ -74,11 +90,14 @@ func (tp *transactionsPool) addMempoolTransaction(transaction *model.MempoolTran
 		tp.highPriorityTransactions[*transaction.TransactionID()] = transaction
 	}
 
+	tp.mempool.recordAddedTransaction(transaction)
+
 	return nil
 }
 
 func (tp *transactionsPool) removeTransaction(transaction *model.MempoolTransaction) error {
 	delete(tp.allTransactions, *transaction.TransactionID())
+	tp.totalSize -= transactionSize(transaction)
 
 	err := tp.transactionsOrderedByFeeRate.Remove(transaction)
 	if err != nil {
@@ -119,7 +138,7 @@ func (tp *transactionsPool) expireOldTransactions() error {
 		if daaScoreSinceAdded > tp.mempool.config.TransactionExpireIntervalDAAScore {
 			log.Debugf("Removing transaction %s, because it expired. DAAScore moved by %d, expire interval: %d",
 				mempoolTransaction.TransactionID(), daaScoreSinceAdded, tp.mempool.config.TransactionExpireIntervalDAAScore)
-			err = tp.mempool.removeTransaction(mempoolTransaction.TransactionID(), true)
+			err = tp.mempool.removeTransaction(mempoolTransaction.TransactionID(), true, "it expired")
 			if err != nil {
 				return err
 			}
@@ -131,12 +150,25 @@ func (tp *transactionsPool) expireOldTransactions() error {
 	return nil
 }
 
-func (tp *transactionsPool) allReadyTransactions() []*externalapi.DomainTransaction {
-	result := []*externalapi.DomainTransaction{}
+// allReadyTransactions returns the transactions that don't spend other
+// mempool transactions, along with the best ancestor packages they belong to
+func (tp *transactionsPool) allReadyTransactions() []*miningmanagermodel.BlockCandidateTransaction {
+	result := []*miningmanagermodel.BlockCandidateTransaction{}
 
+	bestPackages := bestAncestorPackages(tp.allTransactions)
 	for _, mempoolTransaction := range tp.allTransactions {
 		if len(mempoolTransaction.ParentTransactionsInPool()) == 0 {
-			result = append(result, mempoolTransaction.Transaction().Clone()) //this pointer leaves the mempool, and gets its utxo set to nil, hence we clone.
+			transaction := mempoolTransaction.Transaction()
+			candidate := &miningmanagermodel.BlockCandidateTransaction{
+				Transaction: transaction.Clone(), //this pointer leaves the mempool, and gets its utxo set to nil, hence we clone.
+				PackageFee:  transaction.Fee,
+				PackageMass: transaction.Mass,
+			}
+			if bestPackage, ok := bestPackages[*mempoolTransaction.TransactionID()]; ok {
+				candidate.PackageFee = bestPackage.fee
+				candidate.PackageMass = bestPackage.mass
+			}
+			result = append(result, candidate)
 		}
 	}
 
@@ -194,7 +226,8 @@ func (tp *transactionsPool) limitTransactionCount() error {
 
 		log.Debugf("Removing transaction %s, because mempoolTransaction count (%d) exceeded the limit (%d)",
 			transactionToRemove.TransactionID(), len(tp.allTransactions), tp.mempool.config.MaximumTransactionCount)
-		err := tp.mempool.removeTransaction(transactionToRemove.TransactionID(), true)
+		err := tp.mempool.removeTransaction(transactionToRemove.TransactionID(), true,
+			"the mempool is full and its fee rate is among the lowest")
 		if err != nil {
 			return err
 		}
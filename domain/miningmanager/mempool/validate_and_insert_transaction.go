@@ -19,7 +19,7 @@ func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainT
 	// Populate mass in the beginning, it will be used in multiple places throughout the validation and insertion.
 	mp.consensusReference.Consensus().PopulateMass(transaction)
 
-	err = mp.validateTransactionPreUTXOEntry(transaction)
+	conflictingTransactions, err := mp.validateTransactionPreUTXOEntry(transaction)
 	if err != nil {
 		return nil, err
 	}
@@ -30,6 +30,11 @@ func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainT
 	}
 
 	if len(missingOutpoints) > 0 {
+		if len(conflictingTransactions) > 0 {
+			str := fmt.Sprintf("Transaction %s is an orphan, and orphans can't replace transactions in the mempool",
+				consensushashing.TransactionID(transaction))
+			return nil, transactionRuleError(RejectDuplicate, str)
+		}
 		if !allowOrphan {
 			str := fmt.Sprintf("Transaction %s is an orphan, where allowOrphan = false",
 				consensushashing.TransactionID(transaction))
@@ -44,6 +49,18 @@ func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainT
 		return nil, err
 	}
 
+	err = mp.transactionsPool.checkRollingMinimumFee(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conflictingTransactions) > 0 {
+		err = mp.replaceConflictingTransactions(transaction, conflictingTransactions, parentsInPool)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	mempoolTransaction, err := mp.transactionsPool.addTransaction(transaction, parentsInPool, isHighPriority)
 	if err != nil {
 		return nil, err
@@ -61,5 +78,15 @@ func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainT
 		return nil, err
 	}
 
+	err = mp.transactionsPool.limitTransactionPoolSize()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := mp.transactionsPool.allTransactions[*mempoolTransaction.TransactionID()]; !ok {
+		str := fmt.Sprintf("Transaction %s was evicted right away, since the mempool is full and its fee rate "+
+			"is among the lowest", mempoolTransaction.TransactionID())
+		return nil, transactionRuleError(RejectInsufficientFee, str)
+	}
+
 	return acceptedTransactions, nil
 }
@@ -5,18 +5,18 @@ import (
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
 )
 
-func (mp *mempool) validateTransactionPreUTXOEntry(transaction *externalapi.DomainTransaction) error {
-	err := mp.validateTransactionInIsolation(transaction)
+func (mp *mempool) validateTransactionPreUTXOEntry(transaction *externalapi.DomainTransaction) (
+	conflictingTransactions []*model.MempoolTransaction, err error) {
+
+	err = mp.validateTransactionInIsolation(transaction)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := mp.mempoolUTXOSet.checkDoubleSpends(transaction); err != nil {
-		return err
-	}
-	return nil
+	return mp.mempoolUTXOSet.checkDoubleSpends(transaction)
 }
 
 func (mp *mempool) validateTransactionInIsolation(transaction *externalapi.DomainTransaction) error {
@@ -40,6 +40,12 @@ func (mp *mempool) validateTransactionInIsolation(transaction *externalapi.Domai
 		}
 	}
 
+	if err := mp.checkTransactionCanonicalInIsolation(transaction); err != nil {
+		rejectCode, _ := extractRejectCode(err)
+		str := fmt.Sprintf("transaction %s is not canonical: %s", transactionID, err)
+		return transactionRuleError(rejectCode, str)
+	}
+
 	return nil
 }
 
@@ -60,5 +66,12 @@ func (mp *mempool) validateTransactionInContext(transaction *externalapi.DomainT
 		}
 	}
 
+	if err := mp.checkTransactionCanonicalInContext(transaction); err != nil {
+		rejectCode, _ := extractRejectCode(err)
+		str := fmt.Sprintf("transaction inputs %s are not canonical: %s",
+			consensushashing.TransactionID(transaction), err)
+		return transactionRuleError(rejectCode, str)
+	}
+
 	return nil
 }
@@ -5,8 +5,11 @@ import (
 	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
 	"github.com/kaspanet/kaspad/domain/consensusreference"
+	"github.com/kaspanet/kaspad/domain/fees"
 	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/util"
 )
 
 // MiningManager creates block templates for mining as well as maintaining
@@ -19,6 +22,8 @@ type MiningManager interface {
 		transactionPoolTransaction *externalapi.DomainTransaction,
 		isOrphan bool,
 		found bool)
+	GetUTXOEntry(outpoint *externalapi.DomainOutpoint) (entry externalapi.UTXOEntry, found bool)
+	GetOutpointSpender(outpoint *externalapi.DomainOutpoint) (spender *externalapi.DomainTransaction, found bool)
 	GetTransactionsByAddresses(includeTransactionPool bool, includeOrphanPool bool) (
 		sendingInTransactionPool map[string]*externalapi.DomainTransaction,
 		receivingInTransactionPool map[string]*externalapi.DomainTransaction,
@@ -29,16 +34,33 @@ type MiningManager interface {
 		transactionPoolTransactions []*externalapi.DomainTransaction,
 		orphanPoolTransactions []*externalapi.DomainTransaction)
 	TransactionCount(includeTransactionPool bool, includeOrphanPool bool) int
-	HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) ([]*externalapi.DomainTransaction, error)
+	TransactionGraph(transactionIDs []*externalapi.DomainTransactionID, includeOrphanPool bool,
+		includeAncestry bool) []*miningmanagermodel.TransactionGraphEntry
+	MempoolSnapshot(includeOrphanPool bool) []*miningmanagermodel.MempoolSnapshotEntry
+	MempoolEntryDetails(transactionIDs []*externalapi.DomainTransactionID, includeTransactionPool bool,
+		includeOrphanPool bool) []*miningmanagermodel.MempoolEntryDetails
+	HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) (
+		acceptedOrphans []*externalapi.DomainTransaction, includedTransactions []*externalapi.DomainTransaction, err error)
 	ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
 		acceptedTransactions []*externalapi.DomainTransaction, err error)
 	RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error)
+	RevalidateTransactions() (evictedTransactions []*miningmanagermodel.EvictedTransaction, err error)
+	FeeEstimator() *fees.Estimator
+	MinimumRelayTransactionFee() util.Amount
+	SetMinimumRelayTransactionFee(minimumRelayTransactionFee util.Amount)
+	EffectiveMinimumRelayTransactionFee() util.Amount
+	DustRelayTransactionFee() util.Amount
+	SetDustRelayTransactionFee(dustRelayTransactionFee util.Amount)
+	DustThreshold(scriptPublicKey *externalapi.ScriptPublicKey) uint64
+	SetOnTransactionsRemovedHandler(onTransactionsRemovedHandler miningmanagermodel.OnTransactionsRemovedHandler)
+	SetOnTransactionsAddedHandler(onTransactionsAddedHandler miningmanagermodel.OnTransactionsAddedHandler)
 }
 
 type miningManager struct {
 	consensusReference   consensusreference.ConsensusReference
 	mempool              miningmanagermodel.Mempool
 	blockTemplateBuilder miningmanagermodel.BlockTemplateBuilder
+	feeEstimator         *fees.Estimator
 	cachedBlockTemplate  *externalapi.DomainBlockTemplate
 	cachingTime          time.Time
 	cacheLock            *sync.Mutex
@@ -103,9 +125,26 @@ func (mm *miningManager) GetBlockTemplateBuilder() miningmanagermodel.BlockTempl
 	return mm.blockTemplateBuilder
 }
 
-// HandleNewBlockTransactions handles the transactions for a new block that was just added to the DAG
-func (mm *miningManager) HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) ([]*externalapi.DomainTransaction, error) {
-	return mm.mempool.HandleNewBlockTransactions(txs)
+// HandleNewBlockTransactions handles the transactions for a new block that was just added to the DAG.
+// It returns the orphans that were accepted to the mempool as a result, and the block transactions
+// that were in the mempool.
+func (mm *miningManager) HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) (
+	acceptedOrphans []*externalapi.DomainTransaction, includedTransactions []*externalapi.DomainTransaction, err error) {
+
+	acceptedOrphans, includedTransactions, err = mm.mempool.HandleNewBlockTransactions(txs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	virtualDAAScore, err := mm.consensusReference.Consensus().GetVirtualDAAScore()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Skip the coinbase transaction
+	mm.feeEstimator.ProcessBlockTransactions(txs[transactionhelper.CoinbaseTransactionIndex+1:], virtualDAAScore)
+	mm.feeEstimator.ProcessMempoolTransactions(acceptedOrphans, virtualDAAScore)
+
+	return acceptedOrphans, includedTransactions, nil
 }
 
 // ValidateAndInsertTransaction validates the given transaction, and
@@ -114,7 +153,18 @@ func (mm *miningManager) HandleNewBlockTransactions(txs []*externalapi.DomainTra
 func (mm *miningManager) ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction,
 	isHighPriority bool, allowOrphan bool) (acceptedTransactions []*externalapi.DomainTransaction, err error) {
 
-	return mm.mempool.ValidateAndInsertTransaction(transaction, isHighPriority, allowOrphan)
+	acceptedTransactions, err = mm.mempool.ValidateAndInsertTransaction(transaction, isHighPriority, allowOrphan)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualDAAScore, err := mm.consensusReference.Consensus().GetVirtualDAAScore()
+	if err != nil {
+		return nil, err
+	}
+	mm.feeEstimator.ProcessMempoolTransactions(acceptedTransactions, virtualDAAScore)
+
+	return acceptedTransactions, nil
 }
 
 func (mm *miningManager) GetTransaction(
@@ -128,6 +178,16 @@ func (mm *miningManager) GetTransaction(
 	return mm.mempool.GetTransaction(transactionID, includeTransactionPool, includeOrphanPool)
 }
 
+func (mm *miningManager) GetUTXOEntry(outpoint *externalapi.DomainOutpoint) (externalapi.UTXOEntry, bool) {
+	return mm.mempool.GetUTXOEntry(outpoint)
+}
+
+func (mm *miningManager) GetOutpointSpender(outpoint *externalapi.DomainOutpoint) (
+	*externalapi.DomainTransaction, bool) {
+
+	return mm.mempool.GetOutpointSpender(outpoint)
+}
+
 func (mm *miningManager) AllTransactions(includeTransactionPool bool, includeOrphanPool bool) (
 	transactionPoolTransactions []*externalapi.DomainTransaction,
 	orphanPoolTransactions []*externalapi.DomainTransaction) {
@@ -149,8 +209,95 @@ func (mm *miningManager) TransactionCount(includeTransactionPool bool, includeOr
 	return mm.mempool.TransactionCount(includeTransactionPool, includeOrphanPool)
 }
 
+// TransactionGraph returns how the given pending transactions relate to the
+// other pending transactions, or how all of them do if transactionIDs is empty
+func (mm *miningManager) TransactionGraph(transactionIDs []*externalapi.DomainTransactionID, includeOrphanPool bool,
+	includeAncestry bool) []*miningmanagermodel.TransactionGraphEntry {
+
+	return mm.mempool.TransactionGraph(transactionIDs, includeOrphanPool, includeAncestry)
+}
+
+// MempoolSnapshot returns all the pending transactions, along with what the
+// mempool knows about them, as of a single point in time
+func (mm *miningManager) MempoolSnapshot(includeOrphanPool bool) []*miningmanagermodel.MempoolSnapshotEntry {
+	return mm.mempool.Snapshot(includeOrphanPool)
+}
+
+// MempoolEntryDetails returns the details of the given pending transactions,
+// or of all of them if transactionIDs is empty
+func (mm *miningManager) MempoolEntryDetails(transactionIDs []*externalapi.DomainTransactionID,
+	includeTransactionPool bool, includeOrphanPool bool) []*miningmanagermodel.MempoolEntryDetails {
+
+	return mm.mempool.EntryDetails(transactionIDs, includeTransactionPool, includeOrphanPool)
+}
+
 func (mm *miningManager) RevalidateHighPriorityTransactions() (
 	validTransactions []*externalapi.DomainTransaction, err error) {
 
 	return mm.mempool.RevalidateHighPriorityTransactions()
 }
+
+func (mm *miningManager) RevalidateTransactions() (evictedTransactions []*miningmanagermodel.EvictedTransaction, err error) {
+	return mm.mempool.RevalidateTransactions()
+}
+
+// FeeEstimator returns the estimator of the fee rates required for
+// transactions to be included in a block within a target window
+func (mm *miningManager) FeeEstimator() *fees.Estimator {
+	return mm.feeEstimator
+}
+
+// MinimumRelayTransactionFee returns the minimum fee, in sompi/KB, of the
+// transactions accepted to the mempool
+func (mm *miningManager) MinimumRelayTransactionFee() util.Amount {
+	return mm.mempool.MinimumRelayTransactionFee()
+}
+
+// SetMinimumRelayTransactionFee changes the minimum fee, in sompi/KB, of the
+// transactions accepted to the mempool, along with the lowest fee rate the fee
+// estimator estimates
+func (mm *miningManager) SetMinimumRelayTransactionFee(minimumRelayTransactionFee util.Amount) {
+	mm.mempool.SetMinimumRelayTransactionFee(minimumRelayTransactionFee)
+	mm.feeEstimator.SetMinimumFeeRate(float64(minimumRelayTransactionFee) / 1000)
+}
+
+// EffectiveMinimumRelayTransactionFee returns the minimum fee, in sompi/KB, of
+// the transactions currently accepted to the mempool, which is raised while the
+// mempool is full
+func (mm *miningManager) EffectiveMinimumRelayTransactionFee() util.Amount {
+	return mm.mempool.EffectiveMinimumRelayTransactionFee()
+}
+
+// DustRelayTransactionFee returns the fee rate, in sompi/KB, that the outputs
+// the mempool considers dust are defined in terms of
+func (mm *miningManager) DustRelayTransactionFee() util.Amount {
+	return mm.mempool.DustRelayTransactionFee()
+}
+
+// SetDustRelayTransactionFee changes the fee rate, in sompi/KB, that the
+// outputs the mempool considers dust are defined in terms of
+func (mm *miningManager) SetDustRelayTransactionFee(dustRelayTransactionFee util.Amount) {
+	mm.mempool.SetDustRelayTransactionFee(dustRelayTransactionFee)
+}
+
+// DustThreshold returns the smallest value, in sompi, of an output paying to
+// the given scriptPublicKey that the mempool doesn't consider dust
+func (mm *miningManager) DustThreshold(scriptPublicKey *externalapi.ScriptPublicKey) uint64 {
+	return mm.mempool.DustThreshold(scriptPublicKey)
+}
+
+// SetOnTransactionsRemovedHandler sets the handler that's called with the transactions
+// removed from the mempool for any reason other than their inclusion in a block
+func (mm *miningManager) SetOnTransactionsRemovedHandler(
+	onTransactionsRemovedHandler miningmanagermodel.OnTransactionsRemovedHandler) {
+
+	mm.mempool.SetOnTransactionsRemovedHandler(onTransactionsRemovedHandler)
+}
+
+// SetOnTransactionsAddedHandler sets the handler that's called with the
+// transactions added to the transaction pool
+func (mm *miningManager) SetOnTransactionsAddedHandler(
+	onTransactionsAddedHandler miningmanagermodel.OnTransactionsAddedHandler) {
+
+	mm.mempool.SetOnTransactionsAddedHandler(onTransactionsAddedHandler)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensusreference"
 	"github.com/kaspanet/kaspad/domain/miningmanager/model"
 	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/txmass"
 	"github.com/kaspanet/kaspad/version"
 	"reflect"
 	"strings"
@@ -172,6 +173,154 @@ func TestDoubleSpendInMempool(t *testing.T) {
 	})
 }
 
+// TestReplaceByFeeInMempool verifies that when replacements are accepted, a
+// transaction double-spending a mempool transaction replaces it only if it
+// pays enough of a higher fee, and that the replacement is reported.
+func TestReplaceByFeeInMempool(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestReplaceByFeeInMempool")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		mempoolConfig.AcceptReplacements = true
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+		var removedTransactions []*model.RemovedTransaction
+		miningManager.SetOnTransactionsRemovedHandler(func(transactions []*model.RemovedTransaction) {
+			removedTransactions = append(removedTransactions, transactions...)
+		})
+
+		transaction, err := createChildAndParentTxsAndAddParentToConsensus(tc)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+		_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+		transactionID := consensushashing.TransactionID(transaction)
+
+		lowFeeTransaction := transaction.Clone()
+		lowFeeTransaction.ID = nil
+		lowFeeTransaction.Outputs[0].Value-- // raise the fee by a single sompi
+		_, err = miningManager.ValidateAndInsertTransaction(lowFeeTransaction, false, true)
+		if err == nil || !strings.Contains(err.Error(), "requires a fee of at least") {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+		if _, _, ok := miningManager.GetTransaction(transactionID, true, false); !ok {
+			t.Fatalf("Transaction %s was removed from the mempool by a rejected replacement", transactionID)
+		}
+
+		replacementTransaction := transaction.Clone()
+		replacementTransaction.ID = nil
+		replacementTransaction.Outputs[0].Value -= 10000
+		_, err = miningManager.ValidateAndInsertTransaction(replacementTransaction, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+		replacementTransactionID := consensushashing.TransactionID(replacementTransaction)
+		if _, _, ok := miningManager.GetTransaction(transactionID, true, false); ok {
+			t.Fatalf("Transaction %s wasn't removed from the mempool by its replacement", transactionID)
+		}
+		if _, _, ok := miningManager.GetTransaction(replacementTransactionID, true, false); !ok {
+			t.Fatalf("Replacement transaction %s wasn't added to the mempool", replacementTransactionID)
+		}
+
+		if len(removedTransactions) != 1 {
+			t.Fatalf("Expected 1 removed transaction to be reported, but got %d", len(removedTransactions))
+		}
+		removedTransactionID := consensushashing.TransactionID(removedTransactions[0].Transaction)
+		if !removedTransactionID.Equal(transactionID) {
+			t.Fatalf("Expected the removal of %s to be reported, but got %s", transactionID, removedTransactionID)
+		}
+		if removedTransactions[0].ReplacementTransactionID == nil ||
+			!removedTransactions[0].ReplacementTransactionID.Equal(replacementTransactionID) {
+			t.Fatalf("Expected the removal to be reported as a replacement by %s, but got %v",
+				replacementTransactionID, removedTransactions[0].ReplacementTransactionID)
+		}
+	})
+}
+
+// TestLimitTransactionPoolSize verifies that once the mempool is full, the
+// transaction paying the lowest fee rate is evicted, and that the effective
+// minimum relay fee is then raised above its fee rate.
+func TestLimitTransactionPoolSize(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestLimitTransactionPoolSize")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		createTransactionWithFee := func(i int, fee uint64) *externalapi.DomainTransaction {
+			transaction := createTransactionWithUTXOEntry(t, i, 0)
+			transaction.Outputs[0].Value = transaction.Inputs[0].UTXOEntry.Amount() - fee
+			return transaction
+		}
+		transactionSize := txmass.TransactionEstimatedSerializedSize(createTransactionWithFee(0, 0))
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		mempoolConfig.MaximumTransactionPoolSize = 2*transactionSize + transactionSize/2
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+		var removedTransactions []*model.RemovedTransaction
+		miningManager.SetOnTransactionsRemovedHandler(func(transactions []*model.RemovedTransaction) {
+			removedTransactions = append(removedTransactions, transactions...)
+		})
+
+		transactions := []*externalapi.DomainTransaction{
+			createTransactionWithFee(0, 200_000),
+			createTransactionWithFee(1, 100_000),
+			createTransactionWithFee(2, 300_000),
+		}
+		for _, transaction := range transactions {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+		}
+
+		cheapestTransactionID := consensushashing.TransactionID(transactions[1])
+		if _, _, ok := miningManager.GetTransaction(cheapestTransactionID, true, false); ok {
+			t.Fatalf("Transaction %s, which pays the lowest fee rate, wasn't evicted", cheapestTransactionID)
+		}
+		if len(removedTransactions) != 1 {
+			t.Fatalf("Expected 1 removed transaction to be reported, but got %d", len(removedTransactions))
+		}
+		removedTransactionID := consensushashing.TransactionID(removedTransactions[0].Transaction)
+		if !removedTransactionID.Equal(cheapestTransactionID) {
+			t.Fatalf("Expected the removal of %s to be reported, but got %s", cheapestTransactionID, removedTransactionID)
+		}
+		if !strings.Contains(removedTransactions[0].Reason, "the mempool is full") {
+			t.Fatalf("Unexpected removal reason: %s", removedTransactions[0].Reason)
+		}
+
+		evictedFeeRate := uint64(100_000) * 1000 / removedTransactions[0].Transaction.Mass
+		if uint64(miningManager.EffectiveMinimumRelayTransactionFee()) <= evictedFeeRate {
+			t.Fatalf("Expected the effective minimum relay fee to be raised above %d, but it's %d",
+				evictedFeeRate, miningManager.EffectiveMinimumRelayTransactionFee())
+		}
+
+		_, err = miningManager.ValidateAndInsertTransaction(createTransactionWithFee(3, 100_000), false, true)
+		if err == nil || !strings.Contains(err.Error(), "the mempool is full") {
+			t.Fatalf("Expected a transaction paying the evicted fee rate to be rejected, but got: %v", err)
+		}
+	})
+}
+
 // TestHandleNewBlockTransactions verifies that all the transactions in the block were successfully removed from the mempool.
 func TestHandleNewBlockTransactions(t *testing.T) {
 	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
@@ -188,6 +337,14 @@ func TestHandleNewBlockTransactions(t *testing.T) {
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
 		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		var removedTransactions []*model.RemovedTransaction
+		miningManager.SetOnTransactionsRemovedHandler(func(transactions []*model.RemovedTransaction) {
+			removedTransactions = append(removedTransactions, transactions...)
+		})
+		var addedTransactions []*externalapi.DomainTransaction
+		miningManager.SetOnTransactionsAddedHandler(func(transactions []*externalapi.DomainTransaction) {
+			addedTransactions = append(addedTransactions, transactions...)
+		})
 		transactionsToInsert := make([]*externalapi.DomainTransaction, 10)
 		for i := range transactionsToInsert {
 			transaction := createTransactionWithUTXOEntry(t, i, 0)
@@ -196,15 +353,22 @@ func TestHandleNewBlockTransactions(t *testing.T) {
 			if err != nil {
 				t.Fatalf("ValidateAndInsertTransaction: %v", err)
 			}
+			if len(addedTransactions) != i+1 || !addedTransactions[i].Equal(transaction) {
+				t.Fatalf("Expected transaction %s to be reported as added", consensushashing.TransactionID(transaction))
+			}
 		}
 
 		const partialLength = 3
 		blockWithFirstPartOfTheTransactions := append([]*externalapi.DomainTransaction{nil}, transactionsToInsert[0:partialLength]...)
 		blockWithRestOfTheTransactions := append([]*externalapi.DomainTransaction{nil}, transactionsToInsert[partialLength:]...)
-		_, err = miningManager.HandleNewBlockTransactions(blockWithFirstPartOfTheTransactions)
+		_, includedTransactions, err := miningManager.HandleNewBlockTransactions(blockWithFirstPartOfTheTransactions)
 		if err != nil {
 			t.Fatalf("HandleNewBlockTransactions: %v", err)
 		}
+		if len(includedTransactions) != partialLength {
+			t.Fatalf("Expected %d transactions to be reported as included, but got %d",
+				partialLength, len(includedTransactions))
+		}
 		mempoolTransactions, _ := miningManager.AllTransactions(true, false)
 		for _, removedTransaction := range blockWithFirstPartOfTheTransactions {
 			if contains(removedTransaction, mempoolTransactions) {
@@ -221,7 +385,7 @@ func TestHandleNewBlockTransactions(t *testing.T) {
 			}
 		}
 		// Handle all the other transactions.
-		_, err = miningManager.HandleNewBlockTransactions(blockWithRestOfTheTransactions)
+		_, _, err = miningManager.HandleNewBlockTransactions(blockWithRestOfTheTransactions)
 		if err != nil {
 			t.Fatalf("HandleNewBlockTransactions: %v", err)
 		}
@@ -230,6 +394,10 @@ func TestHandleNewBlockTransactions(t *testing.T) {
 			blockIDs := domainBlocksToBlockIds(mempoolTransactions)
 			t.Fatalf("The mempool contains unexpected transactions: %s", blockIDs)
 		}
+		if len(removedTransactions) != 0 {
+			t.Fatalf("Expected the removal of included transactions not to be reported, but got %d reports",
+				len(removedTransactions))
+		}
 	})
 }
 
@@ -266,7 +434,7 @@ func TestDoubleSpendWithBlock(t *testing.T) {
 		doubleSpendTransactionInTheBlock := createTransactionWithUTXOEntry(t, 0, 0)
 		doubleSpendTransactionInTheBlock.Inputs[0].PreviousOutpoint = transactionInTheMempool.Inputs[0].PreviousOutpoint
 		blockTransactions := []*externalapi.DomainTransaction{nil, doubleSpendTransactionInTheBlock}
-		_, err = miningManager.HandleNewBlockTransactions(blockTransactions)
+		_, _, err = miningManager.HandleNewBlockTransactions(blockTransactions)
 		if err != nil {
 			t.Fatalf("HandleNewBlockTransactions: %v", err)
 		}
@@ -278,6 +446,57 @@ func TestDoubleSpendWithBlock(t *testing.T) {
 	})
 }
 
+// TestReportRemovedTransactions verifies that a transaction that's removed from the mempool since it double
+// spends a transaction of a new block is reported to the onTransactionsRemoved handler, along with the reason.
+func TestReportRemovedTransactions(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestReportRemovedTransactions")
+		if err != nil {
+			t.Fatalf("Failed setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		var removedTransactions []*model.RemovedTransaction
+		miningManager.SetOnTransactionsRemovedHandler(func(transactions []*model.RemovedTransaction) {
+			removedTransactions = append(removedTransactions, transactions...)
+		})
+
+		transactionInTheMempool := createTransactionWithUTXOEntry(t, 0, 0)
+		_, err = miningManager.ValidateAndInsertTransaction(transactionInTheMempool, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+		doubleSpendTransactionInTheBlock := createTransactionWithUTXOEntry(t, 0, 0)
+		doubleSpendTransactionInTheBlock.Outputs[0].Value++
+		blockTransactions := []*externalapi.DomainTransaction{nil, doubleSpendTransactionInTheBlock}
+		_, includedTransactions, err := miningManager.HandleNewBlockTransactions(blockTransactions)
+		if err != nil {
+			t.Fatalf("HandleNewBlockTransactions: %v", err)
+		}
+		if len(includedTransactions) != 0 {
+			t.Fatalf("Expected no transactions to be reported as included, but got %d", len(includedTransactions))
+		}
+		if len(removedTransactions) != 1 {
+			t.Fatalf("Expected 1 removed transaction to be reported, but got %d", len(removedTransactions))
+		}
+		removedTransactionID := consensushashing.TransactionID(removedTransactions[0].Transaction)
+		if !removedTransactionID.Equal(consensushashing.TransactionID(transactionInTheMempool)) {
+			t.Fatalf("Expected the removal of %s to be reported, but got %s",
+				consensushashing.TransactionID(transactionInTheMempool), removedTransactionID)
+		}
+		if !strings.Contains(removedTransactions[0].Reason, "double spends") {
+			t.Fatalf("Unexpected removal reason: %s", removedTransactions[0].Reason)
+		}
+	})
+}
+
 // TestOrphanTransactions verifies that a transaction could be a part of a new block template, only if it's not an orphan.
 func TestOrphanTransactions(t *testing.T) {
 	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
@@ -343,7 +562,7 @@ func TestOrphanTransactions(t *testing.T) {
 		if err != nil {
 			t.Fatalf("GetBlock: %v", err)
 		}
-		_, err = miningManager.HandleNewBlockTransactions(blockParentsTransactions.Transactions)
+		_, _, err = miningManager.HandleNewBlockTransactions(blockParentsTransactions.Transactions)
 		if err != nil {
 			t.Fatalf("HandleNewBlockTransactions: %+v", err)
 		}
@@ -577,6 +796,121 @@ func TestRevalidateHighPriorityTransactions(t *testing.T) {
 	})
 }
 
+// TestRevalidateTransactions verifies that transactions that become invalid
+// after a reorg are evicted from the mempool along with their redeemers
+func TestRevalidateTransactions(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestRevalidateTransactions")
+		if err != nil {
+			t.Fatalf("Failed setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+
+		// Create two valid transactions that double-spend each other (childTransaction1, childTransaction2)
+		parentTransaction, childTransaction1, err := createParentAndChildrenTransactions(tc)
+		if err != nil {
+			t.Fatalf("Error creating parentTransaction and childTransaction1: %+v", err)
+		}
+		tips, err := tc.Tips()
+		if err != nil {
+			t.Fatalf("Error getting tips: %+v", err)
+		}
+
+		fundingBlock, _, err := tc.AddBlock(tips, nil, []*externalapi.DomainTransaction{parentTransaction})
+		if err != nil {
+			t.Fatalf("Error getting function block: %+v", err)
+		}
+
+		childTransaction2 := childTransaction1.Clone()
+		childTransaction2.Outputs[0].Value-- // decrement value to change id
+
+		// Mine 1 block with confirming childTransaction1 and 2 blocks confirming childTransaction2, so that
+		// childTransaction2 is accepted
+		tip1, _, err := tc.AddBlock([]*externalapi.DomainHash{fundingBlock}, nil,
+			[]*externalapi.DomainTransaction{childTransaction1})
+		if err != nil {
+			t.Fatalf("Error adding tip1: %+v", err)
+		}
+		tip2, _, err := tc.AddBlock([]*externalapi.DomainHash{fundingBlock}, nil,
+			[]*externalapi.DomainTransaction{childTransaction2})
+		if err != nil {
+			t.Fatalf("Error adding tip2: %+v", err)
+		}
+		_, _, err = tc.AddBlock([]*externalapi.DomainHash{tip2}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error mining on top of tip2: %+v", err)
+		}
+
+		// Add to mempool a transaction that spends childTransaction2, and a
+		// transaction that spends it in turn
+		spendingTransaction, err := testutils.CreateTransaction(childTransaction2, 1000)
+		if err != nil {
+			t.Fatalf("Error creating spendingTransaction: %+v", err)
+		}
+		_, err = miningManager.ValidateAndInsertTransaction(spendingTransaction, false, false)
+		if err != nil {
+			t.Fatalf("Error inserting spendingTransaction: %+v", err)
+		}
+		redeemerTransaction, err := testutils.CreateTransaction(spendingTransaction, 1000)
+		if err != nil {
+			t.Fatalf("Error creating redeemerTransaction: %+v", err)
+		}
+		_, err = miningManager.ValidateAndInsertTransaction(redeemerTransaction, false, false)
+		if err != nil {
+			t.Fatalf("Error inserting redeemerTransaction: %+v", err)
+		}
+
+		// Revalidate, to make sure both transactions are still valid
+		evictedTransactions, err := miningManager.RevalidateTransactions()
+		if err != nil {
+			t.Fatalf("Error from first RevalidateTransactions: %+v", err)
+		}
+		if len(evictedTransactions) != 0 {
+			t.Fatalf("Expected no transaction to be evicted, but got %v instead", evictedTransactions)
+		}
+
+		// Mine 2 more blocks on top of tip1, to re-org out childTransaction2, thus making spendingTransaction invalid
+		for i := 0; i < 2; i++ {
+			tip1, _, err = tc.AddBlock([]*externalapi.DomainHash{tip1}, nil, nil)
+			if err != nil {
+				t.Fatalf("Error mining on top of tip1: %+v", err)
+			}
+		}
+
+		// Revalidate again, this time both transactions should be evicted
+		evictedTransactions, err = miningManager.RevalidateTransactions()
+		if err != nil {
+			t.Fatalf("Error from second RevalidateTransactions: %+v", err)
+		}
+		if len(evictedTransactions) != 2 ||
+			!evictedTransactions[0].Transaction.Equal(spendingTransaction) ||
+			!evictedTransactions[1].Transaction.Equal(redeemerTransaction) {
+
+			t.Fatalf("Expected spendingTransaction and redeemerTransaction to be evicted, but got %v instead",
+				evictedTransactions)
+		}
+		for _, evictedTransaction := range evictedTransactions {
+			if evictedTransaction.Reason == "" {
+				t.Fatalf("Transaction %s was evicted without a reason",
+					consensushashing.TransactionID(evictedTransaction.Transaction))
+			}
+		}
+		mempoolTransactions, _ := miningManager.AllTransactions(true, false)
+		if len(mempoolTransactions) != 0 {
+			t.Fatalf("Expected to have empty allTransactions, but got %v instead", mempoolTransactions)
+		}
+	})
+}
+
 // TestModifyBlockTemplate verifies that modifying a block template changes coinbase data correctly.
 func TestModifyBlockTemplate(t *testing.T) {
 	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
@@ -652,7 +986,7 @@ func TestModifyBlockTemplate(t *testing.T) {
 		if err != nil {
 			t.Fatalf("GetBlock: %v", err)
 		}
-		_, err = miningManager.HandleNewBlockTransactions(blockParentsTransactions.Transactions)
+		_, _, err = miningManager.HandleNewBlockTransactions(blockParentsTransactions.Transactions)
 		if err != nil {
 			t.Fatalf("HandleNewBlockTransactions: %+v", err)
 		}
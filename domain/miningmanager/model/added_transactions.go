@@ -0,0 +1,11 @@
+package model
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// OnTransactionsAddedHandler is a handler function that's called with the
+// transactions that were added to the transaction pool by a mempool
+// operation, including orphans that the operation unorphaned, once the
+// operation is done
+type OnTransactionsAddedHandler func(addedTransactions []*externalapi.DomainTransaction)
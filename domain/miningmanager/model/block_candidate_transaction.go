@@ -0,0 +1,21 @@
+package model
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// BlockCandidateTransaction is a mempool transaction that may be included in
+// the next block, along with the ancestor package with the highest fee rate
+// that it belongs to. An ancestor package is a mempool transaction along with
+// all of its ancestors in the mempool, so a transaction with a high-fee
+// descendant is worth including for the sake of the descendant, which may only
+// be included in a later block.
+type BlockCandidateTransaction struct {
+	Transaction *externalapi.DomainTransaction
+
+	// PackageFee and PackageMass are the total fee and mass of the package.
+	// They're the fee and mass of the transaction itself if none of its
+	// descendants raises its fee rate.
+	PackageFee  uint64
+	PackageMass uint64
+}
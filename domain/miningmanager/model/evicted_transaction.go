@@ -0,0 +1,12 @@
+package model
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// EvictedTransaction is a transaction that was removed from the mempool
+// because it became invalid, along with the reason it became invalid
+type EvictedTransaction struct {
+	Transaction *externalapi.DomainTransaction
+	Reason      string
+}
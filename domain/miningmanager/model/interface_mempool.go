@@ -2,16 +2,18 @@ package model
 
 import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/util"
 )
 
 // Mempool maintains a set of known transactions that
 // are intended to be mined into new blocks
 type Mempool interface {
-	HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) ([]*externalapi.DomainTransaction, error)
-	BlockCandidateTransactions() []*externalapi.DomainTransaction
+	HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) (
+		acceptedOrphans []*externalapi.DomainTransaction, includedTransactions []*externalapi.DomainTransaction, err error)
+	BlockCandidateTransactions() []*BlockCandidateTransaction
 	ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
 		acceptedTransactions []*externalapi.DomainTransaction, err error)
-	RemoveTransactions(txs []*externalapi.DomainTransaction, removeRedeemers bool) error
+	RemoveTransactions(txs []*externalapi.DomainTransaction, removeRedeemers bool, reason string) error
 	GetTransaction(
 		transactionID *externalapi.DomainTransactionID,
 		includeTransactionPool bool,
@@ -20,6 +22,8 @@ type Mempool interface {
 		transactionPoolTransaction *externalapi.DomainTransaction,
 		isOrphan bool,
 		found bool)
+	GetUTXOEntry(outpoint *externalapi.DomainOutpoint) (entry externalapi.UTXOEntry, found bool)
+	GetOutpointSpender(outpoint *externalapi.DomainOutpoint) (spender *externalapi.DomainTransaction, found bool)
 	GetTransactionsByAddresses(
 		includeTransactionPool bool,
 		includeOrphanPool bool) (
@@ -38,5 +42,19 @@ type Mempool interface {
 		includeTransactionPool bool,
 		includeOrphanPool bool) int
 	RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error)
+	RevalidateTransactions() (evictedTransactions []*EvictedTransaction, err error)
+	TransactionGraph(transactionIDs []*externalapi.DomainTransactionID, includeOrphanPool bool,
+		includeAncestry bool) []*TransactionGraphEntry
+	Snapshot(includeOrphanPool bool) []*MempoolSnapshotEntry
+	EntryDetails(transactionIDs []*externalapi.DomainTransactionID, includeTransactionPool bool,
+		includeOrphanPool bool) []*MempoolEntryDetails
 	IsTransactionOutputDust(output *externalapi.DomainTransactionOutput) bool
+	MinimumRelayTransactionFee() util.Amount
+	SetMinimumRelayTransactionFee(minimumRelayTransactionFee util.Amount)
+	EffectiveMinimumRelayTransactionFee() util.Amount
+	DustRelayTransactionFee() util.Amount
+	SetDustRelayTransactionFee(dustRelayTransactionFee util.Amount)
+	DustThreshold(scriptPublicKey *externalapi.ScriptPublicKey) uint64
+	SetOnTransactionsRemovedHandler(onTransactionsRemovedHandler OnTransactionsRemovedHandler)
+	SetOnTransactionsAddedHandler(onTransactionsAddedHandler OnTransactionsAddedHandler)
 }
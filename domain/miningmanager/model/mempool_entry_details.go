@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// MempoolEntryDetails is what the mempool knows about a pending transaction
+// that's useful for reasoning about chains of unconfirmed transactions
+type MempoolEntryDetails struct {
+	Transaction     *externalapi.DomainTransaction
+	IsOrphan        bool
+	AddedAt         time.Time
+	AddedAtDAAScore uint64
+
+	// DependsIDs are the pending transactions whose outputs the transaction
+	// spends, and SpentByIDs are the pending transactions that spend its outputs
+	DependsIDs []*externalapi.DomainTransactionID
+	SpentByIDs []*externalapi.DomainTransactionID
+}
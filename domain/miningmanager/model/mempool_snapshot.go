@@ -0,0 +1,14 @@
+package model
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// MempoolSnapshotEntry is a pending transaction along with what the mempool
+// knows about it
+type MempoolSnapshotEntry struct {
+	Transaction     *externalapi.DomainTransaction
+	IsOrphan        bool
+	IsHighPriority  bool
+	AddedAtDAAScore uint64
+}
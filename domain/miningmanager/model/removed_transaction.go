@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// RemovedTransaction is a transaction that was removed from the mempool for
+// any reason other than its inclusion in a block, along with that reason.
+// ReplacementTransactionID is set if the transaction was replaced by a
+// conflicting transaction that pays a higher fee.
+type RemovedTransaction struct {
+	Transaction              *externalapi.DomainTransaction
+	Reason                   string
+	ReplacementTransactionID *externalapi.DomainTransactionID
+}
+
+// OnTransactionsRemovedHandler is a handler function that's called with the
+// transactions that were removed from the mempool by a mempool operation, once
+// the operation is done
+type OnTransactionsRemovedHandler func(removedTransactions []*RemovedTransaction)
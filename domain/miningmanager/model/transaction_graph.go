@@ -0,0 +1,35 @@
+package model
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// TransactionGraphEntry describes how a pending transaction relates to the
+// other pending transactions in the mempool
+type TransactionGraphEntry struct {
+	TransactionID *externalapi.DomainTransactionID
+	IsOrphan      bool
+
+	// ParentIDs are the pending transactions whose outputs the transaction
+	// spends, and ChildIDs are the pending transactions that spend its outputs
+	ParentIDs []*externalapi.DomainTransactionID
+	ChildIDs  []*externalapi.DomainTransactionID
+
+	// AncestorIDs and DescendantIDs are the transitive closures of ParentIDs
+	// and ChildIDs. They're nil unless they were requested.
+	AncestorIDs   []*externalapi.DomainTransactionID
+	DescendantIDs []*externalapi.DomainTransactionID
+
+	// Conflicts are the outpoints the transaction spends that other pending
+	// transactions spend as well
+	Conflicts []*TransactionConflict
+}
+
+// TransactionConflict is an outpoint that a few pending transactions spend
+type TransactionConflict struct {
+	Outpoint externalapi.DomainOutpoint
+
+	// ConflictingTransactionIDs are the pending transactions that spend
+	// the outpoint, other than the transaction the conflict belongs to
+	ConflictingTransactionIDs []*externalapi.DomainTransactionID
+}
@@ -0,0 +1,7 @@
+package payoutwatcher
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("PAYW")
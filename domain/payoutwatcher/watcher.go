@@ -0,0 +1,418 @@
+// Package payoutwatcher tracks the blocks whose coinbase pays to a given
+// address, such as the blocks of a solo miner, and whether the selected
+// parent chain merged them blue and paid their rewards.
+package payoutwatcher
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/processes/coinbasemanager"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+// maxMinedBlocks is the maximum amount of blocks the watcher tracks. Once it
+// tracks more, the earliest ones are dropped, along with their statistics.
+const maxMinedBlocks = 10_000
+
+// The statuses of a mined block
+const (
+	// StatusPending is the status of a block no chain block merged yet
+	StatusPending = "pending"
+
+	// StatusBlue is the status of a block a chain block merged blue
+	StatusBlue = "blue"
+
+	// StatusRed is the status of a block a chain block merged red, which
+	// gets no reward
+	StatusRed = "red"
+
+	// StatusOrphan is the status of a pending block that's too deep in the
+	// past of the virtual to ever be merged
+	StatusOrphan = "orphan"
+)
+
+// MinedBlock is a block whose coinbase pays to the watched address
+type MinedBlock struct {
+	Hash   *externalapi.DomainHash
+	Status string
+
+	DAAScore uint64
+
+	// MergingBlockHash is the chain block that merged the block, or nil if
+	// it's pending or an orphan
+	MergingBlockHash *externalapi.DomainHash
+
+	// Reward is the reward the coinbase of the merging block pays for the
+	// block, in sompi, which is 0 unless the block is blue
+	Reward uint64
+
+	// IsRewardMature is whether the reward may be spent
+	IsRewardMature bool
+}
+
+// Stats are the statistics of the blocks whose coinbase pays to the watched
+// address, since the node started
+type Stats struct {
+	Address           string
+	BlueBlockCount    uint64
+	RedBlockCount     uint64
+	PendingBlockCount uint64
+	OrphanBlockCount  uint64
+
+	// TotalReward is the reward of all the blue blocks, in sompi, of which
+	// MatureReward may already be spent
+	TotalReward  uint64
+	MatureReward uint64
+
+	// RecentBlocks are the most recently found blocks, the latest first
+	RecentBlocks []*MinedBlock
+}
+
+// minedBlock is the state of a tracked block
+type minedBlock struct {
+	hash *externalapi.DomainHash
+
+	// sequence orders the blocks by the time they were first seen
+	sequence  uint64
+	daaScore  uint64
+	blueScore uint64
+
+	mergingBlockHash *externalapi.DomainHash
+	isBlue           bool
+	reward           uint64
+
+	// rewardDAAScore is the DAA score of the chain block that accepted the
+	// coinbase of the merging block, which is the DAA score of the reward
+	// UTXO. It's valid only if isRewardAccepted is set.
+	rewardDAAScore   uint64
+	isRewardAccepted bool
+}
+
+// Watcher tracks the blocks whose coinbase pays to a given address. It learns
+// about blocks as they're added to the DAG and as the selected parent chain
+// merges them, so it only knows about the blocks found since the node started.
+//
+// The reward of a blue block is paid by the coinbase of the chain block that
+// merges it, and it matures once the coinbase of that chain block is accepted
+// by the next one, and the virtual DAA score has passed the DAA score of the
+// latter by the coinbase maturity. A block that no chain block merged is
+// considered an orphan once the blue score of the virtual has passed its own
+// by the merge depth, since chain blocks don't merge blocks that deep.
+type Watcher struct {
+	domain                                  domain.Domain
+	address                                 string
+	scriptPublicKey                         *externalapi.ScriptPublicKey
+	coinbaseMaturity                        uint64
+	mergeDepth                              uint64
+	coinbasePayloadScriptPublicKeyMaxLength uint8
+
+	lock         sync.Mutex
+	minedBlocks  map[externalapi.DomainHash]*minedBlock
+	nextSequence uint64
+
+	// mergedBlocks are the tracked blocks that every chain block merged
+	mergedBlocks map[externalapi.DomainHash][]*minedBlock
+}
+
+// New creates a new Watcher that tracks the blocks whose coinbase pays to the
+// given script public key, which is the one of the given address
+func New(domain domain.Domain, params *dagconfig.Params, address string,
+	scriptPublicKey *externalapi.ScriptPublicKey) *Watcher {
+
+	return &Watcher{
+		domain:                                  domain,
+		address:                                 address,
+		scriptPublicKey:                         scriptPublicKey,
+		coinbaseMaturity:                        params.BlockCoinbaseMaturity,
+		mergeDepth:                              params.MergeDepth,
+		coinbasePayloadScriptPublicKeyMaxLength: params.CoinbasePayloadScriptPublicKeyMaxLength,
+		minedBlocks:                             make(map[externalapi.DomainHash]*minedBlock),
+		mergedBlocks:                            make(map[externalapi.DomainHash][]*minedBlock),
+	}
+}
+
+// NotifyBlockAdded starts tracking the given block if its coinbase pays to
+// the watched address
+func (w *Watcher) NotifyBlockAdded(block *externalapi.DomainBlock) {
+	if len(block.Transactions) == 0 ||
+		!w.paysToWatchedAddress(block.Transactions[transactionhelper.CoinbaseTransactionIndex]) {
+		return
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	blockHash := consensushashing.BlockHash(block)
+	if _, ok := w.minedBlocks[*blockHash]; ok {
+		return
+	}
+	minedBlock := w.track(blockHash)
+	minedBlock.daaScore = block.Header.DAAScore()
+	minedBlock.blueScore = block.Header.BlueScore()
+	log.Infof("Found block %s, paying to %s", blockHash, w.address)
+}
+
+// NotifyVirtualSelectedParentChainChanged updates the tracked blocks that the
+// removed chain blocks no longer merge, and the ones the added chain blocks
+// merge
+func (w *Watcher) NotifyVirtualSelectedParentChainChanged(selectedParentChainChanges *externalapi.SelectedChainPath) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for _, removedChainBlock := range selectedParentChainChanges.Removed {
+		err := w.handleRemovedChainBlock(removedChainBlock)
+		if err != nil {
+			log.Errorf("Error handling the removal of chain block %s: %s", removedChainBlock, err)
+		}
+	}
+	for _, addedChainBlock := range selectedParentChainChanges.Added {
+		err := w.handleAddedChainBlock(addedChainBlock)
+		if err != nil {
+			log.Errorf("Error handling the addition of chain block %s: %s", addedChainBlock, err)
+		}
+	}
+}
+
+func (w *Watcher) handleRemovedChainBlock(chainBlockHash *externalapi.DomainHash) error {
+	for _, minedBlock := range w.mergedBlocks[*chainBlockHash] {
+		log.Infof("Block %s is no longer merged, since chain block %s was removed", minedBlock.hash, chainBlockHash)
+		minedBlock.mergingBlockHash = nil
+		minedBlock.isBlue = false
+		minedBlock.reward = 0
+		minedBlock.isRewardAccepted = false
+	}
+	delete(w.mergedBlocks, *chainBlockHash)
+
+	// The removed chain block accepted the coinbase of its selected parent
+	blockInfo, err := w.domain.Consensus().GetBlockInfo(chainBlockHash)
+	if err != nil {
+		return err
+	}
+	for _, minedBlock := range w.mergedBlocks[*blockInfo.SelectedParent] {
+		minedBlock.isRewardAccepted = false
+	}
+	return nil
+}
+
+func (w *Watcher) handleAddedChainBlock(chainBlockHash *externalapi.DomainHash) error {
+	consensus := w.domain.Consensus()
+	blockInfo, err := consensus.GetBlockInfo(chainBlockHash)
+	if err != nil {
+		return err
+	}
+
+	// The added chain block accepts the coinbase of its selected parent
+	if mergedBlocks := w.mergedBlocks[*blockInfo.SelectedParent]; len(mergedBlocks) > 0 {
+		header, err := consensus.GetBlockHeader(chainBlockHash)
+		if err != nil {
+			return err
+		}
+		for _, minedBlock := range mergedBlocks {
+			minedBlock.rewardDAAScore = header.DAAScore()
+			minedBlock.isRewardAccepted = true
+		}
+	}
+
+	acceptanceData, err := consensus.GetBlockAcceptanceData(chainBlockHash)
+	if err != nil {
+		return err
+	}
+	var mergedBlockHashes []*externalapi.DomainHash
+	scriptPublicKeys := make(map[externalapi.DomainHash]*externalapi.ScriptPublicKey, len(acceptanceData))
+	for _, blockAcceptanceData := range acceptanceData {
+		coinbase := blockAcceptanceData.TransactionAcceptanceData[transactionhelper.CoinbaseTransactionIndex].Transaction
+		_, coinbaseData, _, err := coinbasemanager.ExtractCoinbaseDataBlueScoreAndSubsidy(
+			coinbase, w.coinbasePayloadScriptPublicKeyMaxLength)
+		if err != nil {
+			return err
+		}
+		scriptPublicKeys[*blockAcceptanceData.BlockHash] = coinbaseData.ScriptPublicKey
+		if coinbaseData.ScriptPublicKey.Equal(w.scriptPublicKey) {
+			mergedBlockHashes = append(mergedBlockHashes, blockAcceptanceData.BlockHash)
+		}
+	}
+	if len(mergedBlockHashes) == 0 {
+		return nil
+	}
+
+	chainBlock, _, err := consensus.GetBlock(chainBlockHash)
+	if err != nil {
+		return err
+	}
+	coinbaseOutputs := chainBlock.Transactions[transactionhelper.CoinbaseTransactionIndex].Outputs
+	rewards := blueRewards(blockInfo.MergeSetBlues, scriptPublicKeys, coinbaseOutputs)
+	for _, mergedBlockHash := range mergedBlockHashes {
+		minedBlock, ok := w.minedBlocks[*mergedBlockHash]
+		if !ok {
+			header, err := consensus.GetBlockHeader(mergedBlockHash)
+			if err != nil {
+				return err
+			}
+			minedBlock = w.track(mergedBlockHash)
+			minedBlock.daaScore = header.DAAScore()
+			minedBlock.blueScore = header.BlueScore()
+		}
+		minedBlock.mergingBlockHash = chainBlockHash
+		minedBlock.reward, minedBlock.isBlue = rewards[*mergedBlockHash]
+		w.mergedBlocks[*chainBlockHash] = append(w.mergedBlocks[*chainBlockHash], minedBlock)
+
+		if minedBlock.isBlue {
+			log.Infof("Block %s was merged blue by chain block %s, with a reward of %d sompi",
+				mergedBlockHash, chainBlockHash, minedBlock.reward)
+		} else {
+			log.Infof("Block %s was merged red by chain block %s", mergedBlockHash, chainBlockHash)
+		}
+	}
+	return nil
+}
+
+// blueRewards returns the rewards that the given coinbase outputs of a chain
+// block pay to the blue blocks it merges. The coinbase has an output for
+// every blue block that has a reward, in the order of the merge set blues, to
+// the script public key in the coinbase payload of that block, followed by
+// the reward of the red blocks. Blue blocks whose turn doesn't come with an
+// output to their script public key are taken to have no reward.
+func blueRewards(mergeSetBlues []*externalapi.DomainHash,
+	scriptPublicKeys map[externalapi.DomainHash]*externalapi.ScriptPublicKey,
+	coinbaseOutputs []*externalapi.DomainTransactionOutput) map[externalapi.DomainHash]uint64 {
+
+	rewards := make(map[externalapi.DomainHash]uint64, len(mergeSetBlues))
+	outputIndex := 0
+	for _, blueBlockHash := range mergeSetBlues {
+		rewards[*blueBlockHash] = 0
+		scriptPublicKey, ok := scriptPublicKeys[*blueBlockHash]
+		if !ok || outputIndex >= len(coinbaseOutputs) {
+			continue
+		}
+		output := coinbaseOutputs[outputIndex]
+		if !output.ScriptPublicKey.Equal(scriptPublicKey) {
+			continue
+		}
+		rewards[*blueBlockHash] = output.Value
+		outputIndex++
+	}
+	return rewards
+}
+
+// track starts tracking the given block, dropping the earliest tracked block
+// if there are too many. The lock must be held.
+func (w *Watcher) track(blockHash *externalapi.DomainHash) *minedBlock {
+	if len(w.minedBlocks) >= maxMinedBlocks {
+		var earliest *minedBlock
+		for _, minedBlock := range w.minedBlocks {
+			if earliest == nil || minedBlock.sequence < earliest.sequence {
+				earliest = minedBlock
+			}
+		}
+		w.untrack(earliest)
+	}
+
+	minedBlock := &minedBlock{hash: blockHash, sequence: w.nextSequence}
+	w.nextSequence++
+	w.minedBlocks[*blockHash] = minedBlock
+	return minedBlock
+}
+
+// untrack stops tracking the given block. The lock must be held.
+func (w *Watcher) untrack(minedBlock *minedBlock) {
+	delete(w.minedBlocks, *minedBlock.hash)
+	if minedBlock.mergingBlockHash == nil {
+		return
+	}
+	mergedBlocks := w.mergedBlocks[*minedBlock.mergingBlockHash]
+	for i, mergedBlock := range mergedBlocks {
+		if mergedBlock == minedBlock {
+			mergedBlocks = append(mergedBlocks[:i], mergedBlocks[i+1:]...)
+			break
+		}
+	}
+	if len(mergedBlocks) == 0 {
+		delete(w.mergedBlocks, *minedBlock.mergingBlockHash)
+		return
+	}
+	w.mergedBlocks[*minedBlock.mergingBlockHash] = mergedBlocks
+}
+
+func (w *Watcher) paysToWatchedAddress(coinbase *externalapi.DomainTransaction) bool {
+	_, coinbaseData, _, err := coinbasemanager.ExtractCoinbaseDataBlueScoreAndSubsidy(
+		coinbase, w.coinbasePayloadScriptPublicKeyMaxLength)
+	if err != nil {
+		return false
+	}
+	return coinbaseData.ScriptPublicKey.Equal(w.scriptPublicKey)
+}
+
+// Stats returns the statistics of the tracked blocks, along with up to
+// recentBlockCount of the most recently found ones
+func (w *Watcher) Stats(recentBlockCount int) (*Stats, error) {
+	virtualInfo, err := w.domain.Consensus().GetVirtualInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	stats := &Stats{Address: w.address}
+	minedBlocks := make([]*minedBlock, 0, len(w.minedBlocks))
+	for _, minedBlock := range w.minedBlocks {
+		minedBlocks = append(minedBlocks, minedBlock)
+
+		switch w.status(minedBlock, virtualInfo) {
+		case StatusBlue:
+			stats.BlueBlockCount++
+		case StatusRed:
+			stats.RedBlockCount++
+		case StatusPending:
+			stats.PendingBlockCount++
+		case StatusOrphan:
+			stats.OrphanBlockCount++
+		}
+		stats.TotalReward += minedBlock.reward
+		if w.isRewardMature(minedBlock, virtualInfo) {
+			stats.MatureReward += minedBlock.reward
+		}
+	}
+
+	sort.Slice(minedBlocks, func(i, j int) bool {
+		return minedBlocks[i].sequence > minedBlocks[j].sequence
+	})
+	if len(minedBlocks) > recentBlockCount {
+		minedBlocks = minedBlocks[:recentBlockCount]
+	}
+	stats.RecentBlocks = make([]*MinedBlock, len(minedBlocks))
+	for i, minedBlock := range minedBlocks {
+		stats.RecentBlocks[i] = &MinedBlock{
+			Hash:             minedBlock.hash,
+			Status:           w.status(minedBlock, virtualInfo),
+			DAAScore:         minedBlock.daaScore,
+			MergingBlockHash: minedBlock.mergingBlockHash,
+			Reward:           minedBlock.reward,
+			IsRewardMature:   w.isRewardMature(minedBlock, virtualInfo),
+		}
+	}
+	return stats, nil
+}
+
+func (w *Watcher) status(minedBlock *minedBlock, virtualInfo *externalapi.VirtualInfo) string {
+	if minedBlock.mergingBlockHash != nil {
+		if minedBlock.isBlue {
+			return StatusBlue
+		}
+		return StatusRed
+	}
+	if minedBlock.blueScore+w.mergeDepth < virtualInfo.BlueScore {
+		return StatusOrphan
+	}
+	return StatusPending
+}
+
+func (w *Watcher) isRewardMature(minedBlock *minedBlock, virtualInfo *externalapi.VirtualInfo) bool {
+	return minedBlock.reward > 0 && minedBlock.isRewardAccepted &&
+		virtualInfo.DAAScore >= minedBlock.rewardDAAScore+w.coinbaseMaturity
+}
@@ -0,0 +1,76 @@
+package payoutwatcher
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+func TestBlueRewards(t *testing.T) {
+	hash := func(b byte) *externalapi.DomainHash {
+		return externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{b})
+	}
+	minerScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1}, Version: 0}
+	otherScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{2}, Version: 0}
+
+	// Block 3 has no reward, so it has no output, and the last output is the
+	// reward of the red blocks
+	mergeSetBlues := []*externalapi.DomainHash{hash(1), hash(2), hash(3), hash(4)}
+	scriptPublicKeys := map[externalapi.DomainHash]*externalapi.ScriptPublicKey{
+		*hash(1): minerScriptPublicKey,
+		*hash(2): otherScriptPublicKey,
+		*hash(3): minerScriptPublicKey,
+		*hash(4): otherScriptPublicKey,
+		*hash(5): minerScriptPublicKey,
+	}
+	coinbaseOutputs := []*externalapi.DomainTransactionOutput{
+		{Value: 100, ScriptPublicKey: minerScriptPublicKey},
+		{Value: 200, ScriptPublicKey: otherScriptPublicKey},
+		{Value: 400, ScriptPublicKey: otherScriptPublicKey},
+		{Value: 500, ScriptPublicKey: minerScriptPublicKey},
+	}
+
+	rewards := blueRewards(mergeSetBlues, scriptPublicKeys, coinbaseOutputs)
+	expectedRewards := map[externalapi.DomainHash]uint64{*hash(1): 100, *hash(2): 200, *hash(3): 0, *hash(4): 400}
+	if len(rewards) != len(expectedRewards) {
+		t.Fatalf("Expected %d rewards, but got %d", len(expectedRewards), len(rewards))
+	}
+	for blockHash, expectedReward := range expectedRewards {
+		reward, ok := rewards[blockHash]
+		if !ok || reward != expectedReward {
+			t.Fatalf("Expected a reward of %d for block %s, but got %d", expectedReward, &blockHash, reward)
+		}
+	}
+	if _, ok := rewards[*hash(5)]; ok {
+		t.Fatalf("Got a reward for a block that isn't blue")
+	}
+}
+
+func TestTrackDropsEarliestBlocks(t *testing.T) {
+	watcher := &Watcher{
+		minedBlocks:  make(map[externalapi.DomainHash]*minedBlock),
+		mergedBlocks: make(map[externalapi.DomainHash][]*minedBlock),
+	}
+	mergingBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{0xff})
+	for i := 0; i < maxMinedBlocks+2; i++ {
+		var blockHashBytes [externalapi.DomainHashSize]byte
+		blockHashBytes[0], blockHashBytes[1] = byte(i), byte(i>>8)
+		minedBlock := watcher.track(externalapi.NewDomainHashFromByteArray(&blockHashBytes))
+		if i < 2 {
+			minedBlock.mergingBlockHash = mergingBlockHash
+			watcher.mergedBlocks[*mergingBlockHash] = append(watcher.mergedBlocks[*mergingBlockHash], minedBlock)
+		}
+	}
+
+	if len(watcher.minedBlocks) != maxMinedBlocks {
+		t.Fatalf("Expected %d tracked blocks, but got %d", maxMinedBlocks, len(watcher.minedBlocks))
+	}
+	for _, minedBlock := range watcher.minedBlocks {
+		if minedBlock.sequence < 2 {
+			t.Fatalf("Block %d wasn't dropped", minedBlock.sequence)
+		}
+	}
+	if _, ok := watcher.mergedBlocks[*mergingBlockHash]; ok {
+		t.Fatalf("The merged blocks of the dropped blocks weren't dropped")
+	}
+}
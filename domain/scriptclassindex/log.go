@@ -0,0 +1,7 @@
+package scriptclassindex
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("SCIN")
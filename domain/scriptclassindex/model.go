@@ -0,0 +1,48 @@
+package scriptclassindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+)
+
+// scriptClassCount is the amount of script classes known to txscript
+const scriptClassCount = int(txscript.ScriptHashTy) + 1
+
+// ScriptClassStatistics are the statistics of the outputs of a single script class
+type ScriptClassStatistics struct {
+	OutputCount uint64
+	TotalAmount uint64
+}
+
+// WindowStatistics are the statistics of the outputs accepted by the chain
+// blocks within a blue score window, indexed by txscript.ScriptClass
+type WindowStatistics [scriptClassCount]ScriptClassStatistics
+
+func (ws *WindowStatistics) add(other *WindowStatistics) {
+	for i := range ws {
+		ws[i].OutputCount += other[i].OutputCount
+		ws[i].TotalAmount += other[i].TotalAmount
+	}
+}
+
+func (ws *WindowStatistics) subtract(other *WindowStatistics) {
+	for i := range ws {
+		ws[i].OutputCount -= other[i].OutputCount
+		ws[i].TotalAmount -= other[i].TotalAmount
+	}
+}
+
+func (ws *WindowStatistics) isEmpty() bool {
+	for i := range ws {
+		if ws[i].OutputCount != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Window is a blue score window along with the statistics of the
+// outputs accepted by its chain blocks
+type Window struct {
+	StartBlueScore uint64
+	Statistics     *WindowStatistics
+}
@@ -0,0 +1,220 @@
+package scriptclassindex
+
+import (
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/domain/indexsync"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// WindowSize is the size, in blue score, of the windows the statistics are
+// aggregated by. At one block per second it's roughly a day.
+const WindowSize = 86_400
+
+// ScriptClassIndex maintains rolling statistics of the script classes of
+// the outputs accepted by the virtual selected parent chain
+type ScriptClassIndex struct {
+	store  *scriptClassIndexStore
+	syncer *indexsync.Syncer
+
+	// The changes of the statistics per window in the current database transaction
+	addedStatistics   map[uint64]*WindowStatistics
+	removedStatistics map[uint64]*WindowStatistics
+}
+
+// New creates a new script class index.
+//
+// Blocks may be added to the consensus while this is called, since the index
+// catches up with the chain changes it misses meanwhile on the next Update.
+func New(domain domain.Domain, database database.Database) (*ScriptClassIndex, error) {
+	scriptClassIndex := &ScriptClassIndex{
+		store: newScriptClassIndexStore(database),
+	}
+	scriptClassIndex.syncer = indexsync.New("script class index", domain, database, virtualSelectedParentKey, &indexsync.Callbacks{
+		Reset:              scriptClassIndex.reset,
+		RemoveChainBlock:   scriptClassIndex.removeChainBlock,
+		AddChainBlock:      scriptClassIndex.addChainBlock,
+		BeginChainChanges:  scriptClassIndex.beginChainChanges,
+		FinishChainChanges: scriptClassIndex.finishChainChanges,
+	})
+
+	err := scriptClassIndex.syncer.CatchUp()
+	if err != nil {
+		return nil, err
+	}
+	return scriptClassIndex, nil
+}
+
+// Reset deletes the whole script class index and resyncs it from the pruning
+// point. Outputs that were accepted before the pruning point are not counted.
+func (sci *ScriptClassIndex) Reset() error {
+	return sci.syncer.Reset()
+}
+
+// Update updates the script class index with the given DAG selected parent chain changes
+func (sci *ScriptClassIndex) Update(chainChanges *externalapi.SelectedChainPath) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "ScriptClassIndex.Update")
+	defer onEnd()
+
+	return sci.syncer.Update(chainChanges)
+}
+
+func (sci *ScriptClassIndex) reset(_ *externalapi.DomainHash) error {
+	return sci.store.deleteAll()
+}
+
+// beginChainChanges starts accumulating the changes of the statistics per
+// window, since every window may be updated only once per database transaction
+func (sci *ScriptClassIndex) beginChainChanges() {
+	sci.addedStatistics = make(map[uint64]*WindowStatistics)
+	sci.removedStatistics = make(map[uint64]*WindowStatistics)
+}
+
+func (sci *ScriptClassIndex) removeChainBlock(dbTransaction database.Transaction,
+	blockHash *externalapi.DomainHash, blueScore uint64) error {
+
+	log.Tracef("Removing the outputs accepted by chain block %s from the script class index", blockHash)
+	window, statistics, found, err := sci.store.removeChainBlockStatistics(dbTransaction, blockHash, blueScore)
+	if err != nil {
+		return err
+	}
+	// The block was added to the chain before the index was started
+	if !found {
+		return nil
+	}
+	windowStatisticsOf(sci.removedStatistics, window).add(statistics)
+	return nil
+}
+
+func (sci *ScriptClassIndex) addChainBlock(dbTransaction database.Transaction, blockHash *externalapi.DomainHash,
+	header externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error {
+
+	window := header.BlueScore() / WindowSize
+	statistics := acceptedOutputStatistics(acceptanceData)
+	log.Tracef("Adding the outputs accepted by chain block %s to window %d of the script class index",
+		blockHash, window)
+	err := sci.store.addChainBlockStatistics(dbTransaction, blockHash, header.BlueScore(), window, statistics)
+	if err != nil {
+		return err
+	}
+	windowStatisticsOf(sci.addedStatistics, window).add(statistics)
+	return nil
+}
+
+// finishChainChanges updates every window whose statistics were changed
+func (sci *ScriptClassIndex) finishChainChanges(dbTransaction database.Transaction) error {
+	// Make sure that windows that only had statistics added are updated as well
+	for window := range sci.addedStatistics {
+		windowStatisticsOf(sci.removedStatistics, window)
+	}
+	for window, removed := range sci.removedStatistics {
+		err := sci.store.updateWindowStatistics(dbTransaction, window, windowStatisticsOf(sci.addedStatistics, window), removed)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// windowStatisticsOf returns the statistics of the given window
+// in statisticsByWindow, adding empty statistics if there are none
+func windowStatisticsOf(statisticsByWindow map[uint64]*WindowStatistics, window uint64) *WindowStatistics {
+	statistics, ok := statisticsByWindow[window]
+	if !ok {
+		statistics = &WindowStatistics{}
+		statisticsByWindow[window] = statistics
+	}
+	return statistics
+}
+
+// acceptedOutputStatistics returns the statistics of the outputs of the
+// transactions accepted in the given acceptance data
+func acceptedOutputStatistics(acceptanceData externalapi.AcceptanceData) *WindowStatistics {
+	statistics := &WindowStatistics{}
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted {
+				continue
+			}
+			for _, output := range transactionAcceptanceData.Transaction.Outputs {
+				scriptClass := outputScriptClass(output.ScriptPublicKey)
+				statistics[scriptClass].OutputCount++
+				statistics[scriptClass].TotalAmount += output.Value
+			}
+		}
+	}
+	return statistics
+}
+
+// outputScriptClass returns the script class of the given scriptPublicKey.
+// Scripts of unknown versions are always non-standard.
+func outputScriptClass(scriptPublicKey *externalapi.ScriptPublicKey) txscript.ScriptClass {
+	if scriptPublicKey.Version > constants.MaxScriptPublicKeyVersion {
+		return txscript.NonStandardTy
+	}
+	return txscript.GetScriptClass(scriptPublicKey.Script)
+}
+
+// Windows returns the statistics of the windows containing the blue scores
+// in the given inclusive range. Windows in which no outputs were accepted
+// are omitted.
+func (sci *ScriptClassIndex) Windows(startBlueScore uint64, endBlueScore uint64) ([]*Window, error) {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "ScriptClassIndex.Windows")
+	defer onEnd()
+
+	sci.syncer.Lock()
+	defer sci.syncer.Unlock()
+
+	windows := make([]*Window, 0)
+	for window := startBlueScore / WindowSize; window <= endBlueScore/WindowSize; window++ {
+		statistics, err := sci.store.getWindowStatistics(sci.store.database, window)
+		if err != nil {
+			return nil, err
+		}
+		if statistics.isEmpty() {
+			continue
+		}
+		windows = append(windows, &Window{
+			StartBlueScore: window * WindowSize,
+			Statistics:     statistics,
+		})
+	}
+	return windows, nil
+}
+
+// ChainBlockTracker returns the tracker of the chain blocks the script class index holds statistics of
+func (sci *ScriptClassIndex) ChainBlockTracker() *indexretention.ChainBlockTracker {
+	return sci.store.chainBlockTracker
+}
+
+// VirtualSelectedParentBlueScore returns the blue score of the
+// virtual selected parent the script class index is synced with
+func (sci *ScriptClassIndex) VirtualSelectedParentBlueScore() (uint64, error) {
+	return sci.syncer.VirtualSelectedParentBlueScore()
+}
+
+// PruneChainBlocks removes the per chain block statistics of the given chain
+// blocks. Their outputs remain counted in the window statistics, so only the
+// data needed to undo the blocks' acceptance on a reorg is reclaimed.
+func (sci *ScriptClassIndex) PruneChainBlocks(chainBlocks []*indexretention.TrackedChainBlock) error {
+	sci.syncer.Lock()
+	defer sci.syncer.Unlock()
+
+	dbTransaction, err := sci.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, chainBlock := range chainBlocks {
+		_, _, _, err := sci.store.removeChainBlockStatistics(dbTransaction, chainBlock.BlockHash, chainBlock.BlueScore)
+		if err != nil {
+			return err
+		}
+	}
+	return dbTransaction.Commit()
+}
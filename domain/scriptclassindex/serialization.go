@@ -0,0 +1,57 @@
+package scriptclassindex
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const uint64Size = 8
+const windowStatisticsSize = scriptClassCount * 2 * uint64Size
+const chainBlockStatisticsSize = uint64Size + windowStatisticsSize
+
+func serializeWindowStatistics(statistics *WindowStatistics) []byte {
+	serializedStatistics := make([]byte, windowStatisticsSize)
+	for i, scriptClassStatistics := range statistics {
+		start := i * 2 * uint64Size
+		binary.LittleEndian.PutUint64(serializedStatistics[start:], scriptClassStatistics.OutputCount)
+		binary.LittleEndian.PutUint64(serializedStatistics[start+uint64Size:], scriptClassStatistics.TotalAmount)
+	}
+	return serializedStatistics
+}
+
+func deserializeWindowStatistics(serializedStatistics []byte) (*WindowStatistics, error) {
+	if len(serializedStatistics) != windowStatisticsSize {
+		return nil, errors.Errorf("the given window statistics are %d bytes while they should be %d bytes",
+			len(serializedStatistics), windowStatisticsSize)
+	}
+	statistics := &WindowStatistics{}
+	for i := range statistics {
+		start := i * 2 * uint64Size
+		statistics[i].OutputCount = binary.LittleEndian.Uint64(serializedStatistics[start:])
+		statistics[i].TotalAmount = binary.LittleEndian.Uint64(serializedStatistics[start+uint64Size:])
+	}
+	return statistics, nil
+}
+
+// serializeChainBlockStatistics serializes the statistics of a single chain
+// block along with the window they were added to
+func serializeChainBlockStatistics(window uint64, statistics *WindowStatistics) []byte {
+	serializedChainBlockStatistics := make([]byte, chainBlockStatisticsSize)
+	binary.LittleEndian.PutUint64(serializedChainBlockStatistics[:uint64Size], window)
+	copy(serializedChainBlockStatistics[uint64Size:], serializeWindowStatistics(statistics))
+	return serializedChainBlockStatistics
+}
+
+func deserializeChainBlockStatistics(serializedChainBlockStatistics []byte) (uint64, *WindowStatistics, error) {
+	if len(serializedChainBlockStatistics) != chainBlockStatisticsSize {
+		return 0, nil, errors.Errorf("the given chain block statistics are %d bytes while they should be %d bytes",
+			len(serializedChainBlockStatistics), chainBlockStatisticsSize)
+	}
+	window := binary.LittleEndian.Uint64(serializedChainBlockStatistics[:uint64Size])
+	statistics, err := deserializeWindowStatistics(serializedChainBlockStatistics[uint64Size:])
+	if err != nil {
+		return 0, nil, err
+	}
+	return window, statistics, nil
+}
@@ -0,0 +1,55 @@
+package scriptclassindex
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func Test_serializeChainBlockStatistics(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+
+	for i := 0; i < 32; i++ {
+		statistics := &WindowStatistics{}
+		for scriptClass := range statistics {
+			statistics[scriptClass] = ScriptClassStatistics{
+				OutputCount: r.Uint64(),
+				TotalAmount: r.Uint64(),
+			}
+		}
+		window := r.Uint64()
+		resultWindow, resultStatistics, err := deserializeChainBlockStatistics(
+			serializeChainBlockStatistics(window, statistics))
+		if err != nil {
+			t.Fatalf("Failed deserializing chain block statistics: %v", err)
+		}
+		if resultWindow != window {
+			t.Fatalf("Expected window %d, got %d", window, resultWindow)
+		}
+		if *resultStatistics != *statistics {
+			t.Fatalf("Expected \n %+v \n==\n %+v\n", statistics, resultStatistics)
+		}
+	}
+}
+
+func Test_deserializeWindowStatisticsFailure(t *testing.T) {
+	serialized := serializeWindowStatistics(&WindowStatistics{})
+	_, err := deserializeWindowStatistics(serialized[:len(serialized)-1])
+	if err == nil {
+		t.Fatalf("Expected an error when deserializing truncated window statistics")
+	}
+}
+
+func TestWindowStatistics_subtract(t *testing.T) {
+	statistics := &WindowStatistics{}
+	chainBlockStatistics := &WindowStatistics{}
+	chainBlockStatistics[1] = ScriptClassStatistics{OutputCount: 2, TotalAmount: 100}
+
+	statistics.add(chainBlockStatistics)
+	if statistics.isEmpty() {
+		t.Fatalf("Expected the statistics not to be empty after adding a chain block")
+	}
+	statistics.subtract(chainBlockStatistics)
+	if !statistics.isEmpty() {
+		t.Fatalf("Expected the statistics to be empty after subtracting the only chain block, got %+v", statistics)
+	}
+}
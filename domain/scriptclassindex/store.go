@@ -0,0 +1,155 @@
+package scriptclassindex
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+var windowStatisticsBucket = database.MakeBucket([]byte("script-class-index-window-statistics"))
+var chainBlockStatisticsBucket = database.MakeBucket([]byte("script-class-index-chain-block-statistics"))
+var chainBlockTrackerBucket = database.MakeBucket([]byte("script-class-index-chain-block-tracker"))
+var virtualSelectedParentKey = database.MakeBucket([]byte("")).Key([]byte("script-class-index-virtual-selected-parent"))
+
+type scriptClassIndexStore struct {
+	database          database.Database
+	chainBlockTracker *indexretention.ChainBlockTracker
+}
+
+func newScriptClassIndexStore(database database.Database) *scriptClassIndexStore {
+	return &scriptClassIndexStore{
+		database:          database,
+		chainBlockTracker: indexretention.NewChainBlockTracker(chainBlockTrackerBucket),
+	}
+}
+
+func (scis *scriptClassIndexStore) windowStatisticsKey(window uint64) *database.Key {
+	var keyBytes [8]byte
+	binary.BigEndian.PutUint64(keyBytes[:], window)
+	return windowStatisticsBucket.Key(keyBytes[:])
+}
+
+func (scis *scriptClassIndexStore) chainBlockStatisticsKey(blockHash *externalapi.DomainHash) *database.Key {
+	return chainBlockStatisticsBucket.Key(blockHash.ByteSlice())
+}
+
+// addChainBlockStatistics stores the statistics of the outputs accepted
+// by the given chain block, along with the window they were added to
+func (scis *scriptClassIndexStore) addChainBlockStatistics(dataAccessor database.DataAccessor,
+	blockHash *externalapi.DomainHash, blueScore uint64, window uint64, statistics *WindowStatistics) error {
+
+	key := scis.chainBlockStatisticsKey(blockHash)
+	serializedChainBlockStatistics := serializeChainBlockStatistics(window, statistics)
+	err := dataAccessor.Put(key, serializedChainBlockStatistics)
+	if err != nil {
+		return err
+	}
+	return scis.chainBlockTracker.Track(dataAccessor, blockHash, blueScore,
+		indexretention.RecordSize(key, serializedChainBlockStatistics))
+}
+
+// removeChainBlockStatistics deletes and returns the statistics of the outputs
+// accepted by the given chain block, after it was removed from the virtual
+// selected parent chain or pruned. found is false if the block was added to
+// the chain before the index was started, or if its statistics were pruned.
+func (scis *scriptClassIndexStore) removeChainBlockStatistics(dataAccessor database.DataAccessor,
+	blockHash *externalapi.DomainHash, blueScore uint64) (window uint64, statistics *WindowStatistics, found bool, err error) {
+
+	err = scis.chainBlockTracker.Untrack(dataAccessor, blockHash, blueScore)
+	if err != nil {
+		return 0, nil, false, err
+	}
+
+	key := scis.chainBlockStatisticsKey(blockHash)
+	serializedChainBlockStatistics, err := dataAccessor.Get(key)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return 0, nil, false, nil
+		}
+		return 0, nil, false, err
+	}
+	window, statistics, err = deserializeChainBlockStatistics(serializedChainBlockStatistics)
+	if err != nil {
+		return 0, nil, false, err
+	}
+
+	err = dataAccessor.Delete(key)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	return window, statistics, true, nil
+}
+
+// updateWindowStatistics adds and subtracts the given statistics from the
+// given window. Since reads don't observe writes that weren't committed yet,
+// every window may be updated at most once per database transaction.
+func (scis *scriptClassIndexStore) updateWindowStatistics(dataAccessor database.DataAccessor,
+	window uint64, added *WindowStatistics, removed *WindowStatistics) error {
+
+	windowStatistics, err := scis.getWindowStatistics(dataAccessor, window)
+	if err != nil {
+		return err
+	}
+	windowStatistics.add(added)
+	windowStatistics.subtract(removed)
+	return scis.putWindowStatistics(dataAccessor, window, windowStatistics)
+}
+
+// getWindowStatistics returns the statistics of the given window,
+// which are empty if no outputs were indexed in it
+func (scis *scriptClassIndexStore) getWindowStatistics(dataAccessor database.DataAccessor,
+	window uint64) (*WindowStatistics, error) {
+
+	serializedStatistics, err := dataAccessor.Get(scis.windowStatisticsKey(window))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return &WindowStatistics{}, nil
+		}
+		return nil, err
+	}
+	return deserializeWindowStatistics(serializedStatistics)
+}
+
+func (scis *scriptClassIndexStore) putWindowStatistics(dataAccessor database.DataAccessor,
+	window uint64, statistics *WindowStatistics) error {
+
+	key := scis.windowStatisticsKey(window)
+	if statistics.isEmpty() {
+		return dataAccessor.Delete(key)
+	}
+	return dataAccessor.Put(key, serializeWindowStatistics(statistics))
+}
+
+func (scis *scriptClassIndexStore) deleteAll() error {
+	for _, bucket := range []*database.Bucket{windowStatisticsBucket, chainBlockStatisticsBucket, chainBlockTrackerBucket} {
+		err := scis.deleteBucket(bucket)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (scis *scriptClassIndexStore) deleteBucket(bucket *database.Bucket) error {
+	cursor, err := scis.database.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+
+		err = scis.database.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package sqlmirror
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/db/postgres"
+)
+
+// batch accumulates the statements of a single PostgreSQL transaction
+type batch struct {
+	queries    *queries
+	params     *dagconfig.Params
+	statements []*postgres.Statement
+
+	// insertedBlocks are the blocks whose headers were already added to the
+	// batch, so that a chain block that's merged by the next one isn't
+	// inserted twice
+	insertedBlocks map[externalapi.DomainHash]struct{}
+}
+
+func (b *batch) add(query string, args ...interface{}) {
+	b.statements = append(b.statements, &postgres.Statement{Query: query, Args: args})
+}
+
+func (b *batch) addBlockHeader(blockHash *externalapi.DomainHash, header externalapi.BlockHeader) {
+	parentHashes := make([]string, len(header.DirectParents()))
+	for i, parentHash := range header.DirectParents() {
+		parentHashes[i] = parentHash.String()
+	}
+	b.add(b.queries.insertBlock, blockHash.String(), header.Version(), header.TimeInMilliseconds(), header.Bits(),
+		header.Nonce(), header.DAAScore(), header.BlueScore(), postgres.Numeric(header.BlueWork()), parentHashes)
+	b.insertedBlocks[*blockHash] = struct{}{}
+}
+
+// addMergedBlock adds the transactions of a block that the given chain block
+// merges, and marks the ones the chain block accepted
+func (b *batch) addMergedBlock(chainBlockHash *externalapi.DomainHash, blockAcceptanceData *externalapi.BlockAcceptanceData) {
+	blockHash := blockAcceptanceData.BlockHash.String()
+	b.add(b.queries.setAcceptingBlock, chainBlockHash.String(), blockHash)
+
+	// The transaction acceptance data is ordered as the transactions of the block
+	for i, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+		transaction := transactionAcceptanceData.Transaction
+		transactionID := consensushashing.TransactionID(transaction).String()
+		b.addTransaction(transactionID, transaction)
+		b.add(b.queries.insertBlockTx, blockHash, transactionID, i)
+		if transactionAcceptanceData.IsAccepted {
+			b.add(b.queries.insertAcceptedTx, transactionID, chainBlockHash.String(), blockHash,
+				transactionAcceptanceData.Fee)
+		}
+	}
+}
+
+func (b *batch) addTransaction(transactionID string, transaction *externalapi.DomainTransaction) {
+	b.add(b.queries.insertTransaction, transactionID, transaction.Version, transaction.LockTime,
+		transaction.SubnetworkID.String(), transaction.Gas, transaction.Payload)
+	for i, input := range transaction.Inputs {
+		b.add(b.queries.insertInput, transactionID, i, input.PreviousOutpoint.TransactionID.String(),
+			input.PreviousOutpoint.Index, input.SignatureScript, input.Sequence, uint16(input.SigOpCount))
+	}
+	for i, output := range transaction.Outputs {
+		var address interface{}
+		_, extractedAddress, err := txscript.ExtractScriptPubKeyAddress(output.ScriptPublicKey, b.params)
+		if err == nil && extractedAddress != nil {
+			address = extractedAddress.String()
+		}
+		b.add(b.queries.insertOutput, transactionID, i, output.Value, output.ScriptPublicKey.Script,
+			output.ScriptPublicKey.Version, address)
+	}
+}
+
+// removeChainBlock adds the statements that undo the acceptance of the
+// blocks and transactions a chain block that was removed from the selected
+// parent chain had accepted. The blocks and transactions themselves are kept,
+// since they're still in the DAG.
+func (b *batch) removeChainBlock(chainBlockHash *externalapi.DomainHash) {
+	b.add(b.queries.deleteAcceptedTxs, chainBlockHash.String())
+	b.add(b.queries.unsetAcceptingBlock, chainBlockHash.String())
+	b.add(b.queries.unsetChainBlock, chainBlockHash.String())
+}
@@ -0,0 +1,329 @@
+// Package sqlmirror mirrors the selected parent chain, and the blocks and
+// transactions it accepts, into a PostgreSQL schema, so that they may be
+// queried with SQL without running an external ETL against the RPC server.
+package sqlmirror
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/db/postgres"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+// maxChainBlocksPerBatch is the maximum amount of chain blocks that are
+// mirrored together in a single PostgreSQL transaction
+const maxChainBlocksPerBatch = 100
+
+// retryInterval is the time the mirror waits before reconnecting to
+// PostgreSQL after an error
+const retryInterval = 10 * time.Second
+
+// Mirror keeps a PostgreSQL schema in sync with the virtual selected parent
+// chain. The position of the mirror in the chain is stored along with the
+// data in the same PostgreSQL transactions, so the mirror resumes where it
+// stopped after a restart or a lost connection, and never holds a partially
+// mirrored chain block.
+type Mirror struct {
+	domain        domain.Domain
+	params        *dagconfig.Params
+	connectionURL string
+	queries       *queries
+
+	// conn is nil while the mirror is disconnected
+	conn *postgres.Conn
+
+	// chainBlockHash is the last chain block that was mirrored
+	chainBlockHash *externalapi.DomainHash
+
+	notifyChan chan struct{}
+	stopChan   chan struct{}
+	doneChan   chan struct{}
+}
+
+// New creates a new Mirror that mirrors the DAG of the given domain into the
+// given schema of the PostgreSQL database at connectionURL
+func New(domain domain.Domain, params *dagconfig.Params, connectionURL string, schemaName string) (*Mirror, error) {
+	err := postgres.ValidateConnectionURL(connectionURL)
+	if err != nil {
+		return nil, err
+	}
+	err = postgres.ValidateIdentifier(schemaName)
+	if err != nil {
+		return nil, err
+	}
+	return &Mirror{
+		domain:        domain,
+		params:        params,
+		connectionURL: connectionURL,
+		queries:       newQueries(schemaName),
+		notifyChan:    make(chan struct{}, 1),
+		stopChan:      make(chan struct{}),
+		doneChan:      make(chan struct{}),
+	}, nil
+}
+
+// Start connects to PostgreSQL and begins mirroring
+func (m *Mirror) Start() {
+	spawn("sqlmirror.Mirror.mirrorLoop", m.mirrorLoop)
+}
+
+// Stop stops mirroring, waits for the ongoing batch to be committed, and
+// disconnects from PostgreSQL
+func (m *Mirror) Stop() {
+	close(m.stopChan)
+	<-m.doneChan
+}
+
+// Notify lets the mirror know that the virtual selected parent chain changed.
+// It never blocks, since the mirror catches up with all the changes since its
+// last batch at once.
+func (m *Mirror) Notify() {
+	select {
+	case m.notifyChan <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Mirror) mirrorLoop() {
+	defer close(m.doneChan)
+	defer m.disconnect()
+
+	for {
+		err := m.mirror()
+		if err != nil {
+			log.Errorf("Error mirroring to PostgreSQL, retrying in %s: %s", retryInterval, err)
+			m.disconnect()
+			select {
+			case <-m.stopChan:
+				return
+			case <-time.After(retryInterval):
+				continue
+			}
+		}
+
+		select {
+		case <-m.stopChan:
+			return
+		case <-m.notifyChan:
+		}
+	}
+}
+
+func (m *Mirror) disconnect() {
+	if m.conn == nil {
+		return
+	}
+	err := m.conn.Close()
+	if err != nil {
+		log.Debugf("Error disconnecting from PostgreSQL: %s", err)
+	}
+	m.conn = nil
+}
+
+// mirror mirrors all the changes of the virtual selected parent chain since
+// the last mirrored chain block
+func (m *Mirror) mirror() error {
+	if m.conn == nil {
+		err := m.connect()
+		if err != nil {
+			return err
+		}
+	}
+
+	changes, err := m.domain.Consensus().GetVirtualSelectedParentChainFromBlock(m.chainBlockHash)
+	if err != nil {
+		return err
+	}
+	if len(changes.Removed) == 0 && len(changes.Added) == 0 {
+		return nil
+	}
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "sqlmirror.Mirror.mirror")
+	defer onEnd()
+
+	for len(changes.Removed) > 0 || len(changes.Added) > 0 {
+		select {
+		case <-m.stopChan:
+			return nil
+		default:
+		}
+
+		removed := changes.Removed
+		if len(removed) > maxChainBlocksPerBatch {
+			removed = removed[:maxChainBlocksPerBatch]
+		}
+		added := changes.Added
+		if len(added) > maxChainBlocksPerBatch-len(removed) {
+			added = added[:maxChainBlocksPerBatch-len(removed)]
+		}
+		err := m.mirrorBatch(removed, added)
+		if err != nil {
+			return err
+		}
+		changes.Removed = changes.Removed[len(removed):]
+		changes.Added = changes.Added[len(added):]
+	}
+	log.Debugf("Mirrored the selected parent chain up to %s", m.chainBlockHash)
+	return nil
+}
+
+// mirrorBatch unmirrors the given removed chain blocks, ordered from the
+// highest down, and then mirrors the given added ones, ordered from the lowest
+// up, in a single PostgreSQL transaction
+func (m *Mirror) mirrorBatch(removed []*externalapi.DomainHash, added []*externalapi.DomainHash) error {
+	batch := &batch{queries: m.queries, params: m.params, insertedBlocks: make(map[externalapi.DomainHash]struct{})}
+	chainBlockHash := m.chainBlockHash
+	for _, removedChainBlockHash := range removed {
+		batch.removeChainBlock(removedChainBlockHash)
+
+		blockInfo, err := m.domain.Consensus().GetBlockInfo(removedChainBlockHash)
+		if err != nil {
+			return err
+		}
+		chainBlockHash = blockInfo.SelectedParent
+	}
+	for _, addedChainBlockHash := range added {
+		err := m.addChainBlock(batch, addedChainBlockHash)
+		if err != nil {
+			return err
+		}
+		chainBlockHash = addedChainBlockHash
+	}
+
+	// The statements of a batch run in a single transaction
+	batch.add(m.queries.updateSyncState, chainBlockHash.String())
+	err := m.conn.ExecBatch(batch.statements)
+	if err != nil {
+		return err
+	}
+	m.chainBlockHash = chainBlockHash
+	return nil
+}
+
+// addChainBlock adds to the batch the given chain block, along with the
+// blocks it merges and their transactions
+func (m *Mirror) addChainBlock(batch *batch, chainBlockHash *externalapi.DomainHash) error {
+	consensus := m.domain.Consensus()
+	err := m.addBlockHeader(batch, chainBlockHash)
+	if err != nil {
+		return err
+	}
+	batch.add(m.queries.setChainBlock, chainBlockHash.String())
+
+	acceptanceData, err := consensus.GetBlockAcceptanceData(chainBlockHash)
+	if err != nil {
+		return err
+	}
+	for _, blockAcceptanceData := range acceptanceData {
+		err := m.addBlockHeader(batch, blockAcceptanceData.BlockHash)
+		if err != nil {
+			return err
+		}
+		batch.addMergedBlock(chainBlockHash, blockAcceptanceData)
+	}
+	return nil
+}
+
+func (m *Mirror) addBlockHeader(batch *batch, blockHash *externalapi.DomainHash) error {
+	if _, ok := batch.insertedBlocks[*blockHash]; ok {
+		return nil
+	}
+	header, err := m.domain.Consensus().GetBlockHeader(blockHash)
+	if err != nil {
+		return err
+	}
+	batch.addBlockHeader(blockHash, header)
+	return nil
+}
+
+// connect connects to PostgreSQL, creates the schema if it doesn't exist yet,
+// and loads the last mirrored chain block
+func (m *Mirror) connect() error {
+	conn, err := postgres.Connect(m.connectionURL)
+	if err != nil {
+		return err
+	}
+	m.conn = conn
+
+	err = conn.ExecScript(m.queries.schema)
+	if err != nil {
+		return errors.Wrapf(err, "error creating the schema")
+	}
+
+	var network, chainBlockHashString string
+	err = conn.QueryRow(m.queries.selectSyncState).Scan(&network, &chainBlockHashString)
+	if errors.Is(err, postgres.ErrNoRows) {
+		return m.startFromPruningPoint(true)
+	}
+	if err != nil {
+		return err
+	}
+	if network != m.params.Name {
+		return errors.Errorf("the schema mirrors %s rather than %s. Mirror every network into a schema of its own",
+			network, m.params.Name)
+	}
+	chainBlockHash, err := externalapi.NewDomainHashFromString(chainBlockHashString)
+	if err != nil {
+		return errors.Wrapf(err, "malformed sync state")
+	}
+
+	isInChain, err := m.isInPruningPointFuture(chainBlockHash)
+	if err != nil {
+		return err
+	}
+	if !isInChain {
+		log.Warnf("The last mirrored chain block %s is not in the future of the pruning point, "+
+			"so mirroring restarts from the pruning point. The chain blocks that were mirrored "+
+			"before may remain marked as such, so consider recreating the schema", chainBlockHash)
+		return m.startFromPruningPoint(false)
+	}
+	m.chainBlockHash = chainBlockHash
+	log.Infof("Connected to PostgreSQL, mirroring from chain block %s", chainBlockHash)
+	return nil
+}
+
+// isInPruningPointFuture returns whether the given block is known to the
+// node, and is the pruning point or in its selected chain future, which
+// is where the chain can be followed from
+func (m *Mirror) isInPruningPointFuture(blockHash *externalapi.DomainHash) (bool, error) {
+	consensus := m.domain.Consensus()
+	blockInfo, err := consensus.GetBlockInfo(blockHash)
+	if err != nil {
+		return false, err
+	}
+	if !blockInfo.HasBody() {
+		return false, nil
+	}
+	pruningPoint, err := consensus.PruningPoint()
+	if err != nil {
+		return false, err
+	}
+	if pruningPoint.Equal(blockHash) {
+		return true, nil
+	}
+	return consensus.IsInSelectedParentChainOf(pruningPoint, blockHash)
+}
+
+// startFromPruningPoint makes the mirror follow the chain from the pruning
+// point, whose past isn't mirrored since a pruned node doesn't hold it
+func (m *Mirror) startFromPruningPoint(isNewSchema bool) error {
+	pruningPoint, err := m.domain.Consensus().PruningPoint()
+	if err != nil {
+		return err
+	}
+	if isNewSchema {
+		err = m.conn.Exec(m.queries.insertSyncState, m.params.Name, pruningPoint.String())
+	} else {
+		err = m.conn.Exec(m.queries.updateSyncState, pruningPoint.String())
+	}
+	if err != nil {
+		return err
+	}
+	m.chainBlockHash = pruningPoint
+	log.Infof("Connected to PostgreSQL, mirroring from the pruning point %s", pruningPoint)
+	return nil
+}
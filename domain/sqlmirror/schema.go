@@ -0,0 +1,147 @@
+package sqlmirror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// schemaSQL creates the tables of the mirror, unless they already exist.
+//
+// blocks holds the headers of the mirrored blocks: the chain blocks, and the
+// blocks they merge. accepting_block_hash is the chain block that merged the
+// block, if any. A transaction appears once in transactions, and once in
+// block_transactions for every block that includes it. accepted_transactions
+// holds the transactions that the current selected parent chain accepted.
+const schemaSQL = `
+CREATE SCHEMA IF NOT EXISTS %[1]s;
+
+CREATE TABLE IF NOT EXISTS %[1]s.sync_state (
+	id               INTEGER PRIMARY KEY CHECK (id = 1),
+	network          TEXT NOT NULL,
+	chain_block_hash TEXT NOT NULL,
+	updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS %[1]s.blocks (
+	hash                 TEXT PRIMARY KEY,
+	version              INTEGER NOT NULL,
+	timestamp            BIGINT NOT NULL,
+	bits                 BIGINT NOT NULL,
+	nonce                NUMERIC(20) NOT NULL,
+	daa_score            BIGINT NOT NULL,
+	blue_score           BIGINT NOT NULL,
+	blue_work            NUMERIC NOT NULL,
+	parent_hashes        TEXT[] NOT NULL,
+	is_chain_block       BOOLEAN NOT NULL DEFAULT false,
+	accepting_block_hash TEXT
+);
+CREATE INDEX IF NOT EXISTS blocks_blue_score_idx ON %[1]s.blocks (blue_score);
+CREATE INDEX IF NOT EXISTS blocks_accepting_block_hash_idx ON %[1]s.blocks (accepting_block_hash);
+
+CREATE TABLE IF NOT EXISTS %[1]s.transactions (
+	transaction_id TEXT PRIMARY KEY,
+	version        INTEGER NOT NULL,
+	lock_time      NUMERIC(20) NOT NULL,
+	subnetwork_id  TEXT NOT NULL,
+	gas            NUMERIC(20) NOT NULL,
+	payload        BYTEA NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS %[1]s.block_transactions (
+	block_hash     TEXT NOT NULL,
+	transaction_id TEXT NOT NULL,
+	index_in_block INTEGER NOT NULL,
+	PRIMARY KEY (block_hash, transaction_id)
+);
+CREATE INDEX IF NOT EXISTS block_transactions_transaction_id_idx ON %[1]s.block_transactions (transaction_id);
+
+CREATE TABLE IF NOT EXISTS %[1]s.transaction_inputs (
+	transaction_id          TEXT NOT NULL,
+	input_index             INTEGER NOT NULL,
+	previous_transaction_id TEXT NOT NULL,
+	previous_index          BIGINT NOT NULL,
+	signature_script        BYTEA NOT NULL,
+	sequence                NUMERIC(20) NOT NULL,
+	sig_op_count            SMALLINT NOT NULL,
+	PRIMARY KEY (transaction_id, input_index)
+);
+CREATE INDEX IF NOT EXISTS transaction_inputs_previous_outpoint_idx
+	ON %[1]s.transaction_inputs (previous_transaction_id, previous_index);
+
+CREATE TABLE IF NOT EXISTS %[1]s.transaction_outputs (
+	transaction_id            TEXT NOT NULL,
+	output_index              INTEGER NOT NULL,
+	amount                    BIGINT NOT NULL,
+	script_public_key         BYTEA NOT NULL,
+	script_public_key_version INTEGER NOT NULL,
+	script_public_key_address TEXT,
+	PRIMARY KEY (transaction_id, output_index)
+);
+CREATE INDEX IF NOT EXISTS transaction_outputs_address_idx ON %[1]s.transaction_outputs (script_public_key_address);
+
+CREATE TABLE IF NOT EXISTS %[1]s.accepted_transactions (
+	transaction_id       TEXT PRIMARY KEY,
+	accepting_block_hash TEXT NOT NULL,
+	block_hash           TEXT NOT NULL,
+	fee                  BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS accepted_transactions_accepting_block_hash_idx
+	ON %[1]s.accepted_transactions (accepting_block_hash);
+`
+
+// queries are the statements the mirror runs, qualified with its schema
+type queries struct {
+	schema string
+
+	selectSyncState     string
+	insertSyncState     string
+	updateSyncState     string
+	insertBlock         string
+	setChainBlock       string
+	unsetChainBlock     string
+	setAcceptingBlock   string
+	unsetAcceptingBlock string
+	insertTransaction   string
+	insertBlockTx       string
+	insertInput         string
+	insertOutput        string
+	insertAcceptedTx    string
+	deleteAcceptedTxs   string
+}
+
+func newQueries(schemaName string) *queries {
+	schema := `"` + schemaName + `"`
+	qualify := func(query string) string {
+		return strings.TrimSpace(fmt.Sprintf(query, schema))
+	}
+	return &queries{
+		schema: qualify(schemaSQL),
+
+		selectSyncState: qualify(`SELECT network, chain_block_hash FROM %s.sync_state WHERE id = 1`),
+		insertSyncState: qualify(`INSERT INTO %s.sync_state (id, network, chain_block_hash) VALUES (1, $1, $2)`),
+		updateSyncState: qualify(`UPDATE %s.sync_state SET chain_block_hash = $1, updated_at = now() WHERE id = 1`),
+		insertBlock: qualify(`INSERT INTO %s.blocks
+			(hash, version, timestamp, bits, nonce, daa_score, blue_score, blue_work, parent_hashes)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (hash) DO NOTHING`),
+		setChainBlock:       qualify(`UPDATE %s.blocks SET is_chain_block = true WHERE hash = $1`),
+		unsetChainBlock:     qualify(`UPDATE %s.blocks SET is_chain_block = false WHERE hash = $1`),
+		setAcceptingBlock:   qualify(`UPDATE %s.blocks SET accepting_block_hash = $1 WHERE hash = $2`),
+		unsetAcceptingBlock: qualify(`UPDATE %s.blocks SET accepting_block_hash = NULL WHERE accepting_block_hash = $1`),
+		insertTransaction: qualify(`INSERT INTO %s.transactions
+			(transaction_id, version, lock_time, subnetwork_id, gas, payload)
+			VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (transaction_id) DO NOTHING`),
+		insertBlockTx: qualify(`INSERT INTO %s.block_transactions (block_hash, transaction_id, index_in_block)
+			VALUES ($1, $2, $3) ON CONFLICT (block_hash, transaction_id) DO NOTHING`),
+		insertInput: qualify(`INSERT INTO %s.transaction_inputs
+			(transaction_id, input_index, previous_transaction_id, previous_index, signature_script, sequence, sig_op_count)
+			VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (transaction_id, input_index) DO NOTHING`),
+		insertOutput: qualify(`INSERT INTO %s.transaction_outputs
+			(transaction_id, output_index, amount, script_public_key, script_public_key_version, script_public_key_address)
+			VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (transaction_id, output_index) DO NOTHING`),
+		insertAcceptedTx: qualify(`INSERT INTO %s.accepted_transactions
+			(transaction_id, accepting_block_hash, block_hash, fee) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (transaction_id) DO UPDATE SET accepting_block_hash = EXCLUDED.accepting_block_hash,
+			block_hash = EXCLUDED.block_hash, fee = EXCLUDED.fee`),
+		deleteAcceptedTxs: qualify(`DELETE FROM %s.accepted_transactions WHERE accepting_block_hash = $1`),
+	}
+}
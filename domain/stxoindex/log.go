@@ -0,0 +1,7 @@
+package stxoindex
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("STIN")
@@ -0,0 +1,12 @@
+package stxoindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// SpendingTransaction is the transaction that spent an outpoint,
+// along with the chain block that accepted it
+type SpendingTransaction struct {
+	TransactionID      *externalapi.DomainTransactionID
+	AcceptingBlockHash *externalapi.DomainHash
+}
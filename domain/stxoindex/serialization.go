@@ -0,0 +1,96 @@
+package stxoindex
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+const outpointIndexSize = 4
+const outpointSize = externalapi.DomainHashSize + outpointIndexSize
+const outpointsLengthSize = 8
+
+func serializeOutpoint(outpoint *externalapi.DomainOutpoint) []byte {
+	serializedOutpoint := make([]byte, outpointSize)
+	copy(serializedOutpoint[:externalapi.DomainHashSize], outpoint.TransactionID.ByteSlice())
+	binary.LittleEndian.PutUint32(serializedOutpoint[externalapi.DomainHashSize:], outpoint.Index)
+	return serializedOutpoint
+}
+
+func deserializeOutpoint(serializedOutpoint []byte) (*externalapi.DomainOutpoint, error) {
+	if len(serializedOutpoint) != outpointSize {
+		return nil, errors.Errorf("the given outpoint is %d bytes while an outpoint is %d bytes",
+			len(serializedOutpoint), outpointSize)
+	}
+	transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(serializedOutpoint[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+	return &externalapi.DomainOutpoint{
+		TransactionID: *transactionID,
+		Index:         binary.LittleEndian.Uint32(serializedOutpoint[externalapi.DomainHashSize:]),
+	}, nil
+}
+
+func serializeOutpoints(outpoints []*externalapi.DomainOutpoint) []byte {
+	serializedOutpoints := make([]byte, outpointsLengthSize+outpointSize*len(outpoints))
+	binary.LittleEndian.PutUint64(serializedOutpoints[:outpointsLengthSize], uint64(len(outpoints)))
+	for i, outpoint := range outpoints {
+		start := outpointsLengthSize + outpointSize*i
+		copy(serializedOutpoints[start:start+outpointSize], serializeOutpoint(outpoint))
+	}
+	return serializedOutpoints
+}
+
+func deserializeOutpoints(serializedOutpoints []byte) ([]*externalapi.DomainOutpoint, error) {
+	if len(serializedOutpoints) < outpointsLengthSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing outpoints")
+	}
+	length := binary.LittleEndian.Uint64(serializedOutpoints[:outpointsLengthSize])
+	outpoints := make([]*externalapi.DomainOutpoint, length)
+	for i := uint64(0); i < length; i++ {
+		start := outpointsLengthSize + outpointSize*i
+		end := start + outpointSize
+
+		if end > uint64(len(serializedOutpoints)) {
+			return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing outpoints")
+		}
+
+		var err error
+		outpoints[i], err = deserializeOutpoint(serializedOutpoints[start:end])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return outpoints, nil
+}
+
+func serializeSpendingTransaction(spendingTransaction *SpendingTransaction) []byte {
+	serializedSpendingTransaction := make([]byte, 2*externalapi.DomainHashSize)
+	copy(serializedSpendingTransaction[:externalapi.DomainHashSize], spendingTransaction.TransactionID.ByteSlice())
+	copy(serializedSpendingTransaction[externalapi.DomainHashSize:], spendingTransaction.AcceptingBlockHash.ByteSlice())
+	return serializedSpendingTransaction
+}
+
+func deserializeSpendingTransaction(serializedSpendingTransaction []byte) (*SpendingTransaction, error) {
+	if len(serializedSpendingTransaction) != 2*externalapi.DomainHashSize {
+		return nil, errors.Errorf("the given spending transaction is %d bytes while it should be %d bytes",
+			len(serializedSpendingTransaction), 2*externalapi.DomainHashSize)
+	}
+	transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(
+		serializedSpendingTransaction[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+	acceptingBlockHash, err := externalapi.NewDomainHashFromByteSlice(
+		serializedSpendingTransaction[externalapi.DomainHashSize:])
+	if err != nil {
+		return nil, err
+	}
+	return &SpendingTransaction{
+		TransactionID:      transactionID,
+		AcceptingBlockHash: acceptingBlockHash,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package stxoindex
+
+import (
+	"encoding/binary"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func Test_serializeOutpoints(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+
+	for length := 0; length < 32; length++ {
+		outpoints := make([]*externalapi.DomainOutpoint, length)
+		for i := range outpoints {
+			var transactionIDBytes [externalapi.DomainHashSize]byte
+			r.Read(transactionIDBytes[:])
+			outpoints[i] = &externalapi.DomainOutpoint{
+				TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&transactionIDBytes),
+				Index:         r.Uint32(),
+			}
+		}
+		result, err := deserializeOutpoints(serializeOutpoints(outpoints))
+		if err != nil {
+			t.Fatalf("Failed deserializing outpoints: %v", err)
+		}
+		if len(result) != len(outpoints) {
+			t.Fatalf("Expected %d outpoints, got %d", len(outpoints), len(result))
+		}
+		for i := range outpoints {
+			if !outpoints[i].Equal(result[i]) {
+				t.Fatalf("Expected \n %s \n==\n %s\n", outpoints[i], result[i])
+			}
+		}
+	}
+}
+
+func Test_deserializeOutpointsFailure(t *testing.T) {
+	outpoints := []*externalapi.DomainOutpoint{
+		{TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}), Index: 1},
+		{TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{2}), Index: 2},
+	}
+	serialized := serializeOutpoints(outpoints)
+	binary.LittleEndian.PutUint64(serialized[:outpointsLengthSize], uint64(len(outpoints)+1))
+	_, err := deserializeOutpoints(serialized)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected error to be EOF, instead got: %v", err)
+	}
+}
+
+func Test_serializeSpendingTransaction(t *testing.T) {
+	spendingTransaction := &SpendingTransaction{
+		TransactionID:      externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		AcceptingBlockHash: externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+	}
+	result, err := deserializeSpendingTransaction(serializeSpendingTransaction(spendingTransaction))
+	if err != nil {
+		t.Fatalf("Failed deserializing spending transaction: %v", err)
+	}
+	if !result.TransactionID.Equal(spendingTransaction.TransactionID) ||
+		!result.AcceptingBlockHash.Equal(spendingTransaction.AcceptingBlockHash) {
+		t.Fatalf("Expected \n %+v \n==\n %+v\n", spendingTransaction, result)
+	}
+}
@@ -0,0 +1,146 @@
+package stxoindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+var spendingTransactionsBucket = database.MakeBucket([]byte("stxo-index-spending-transactions"))
+var acceptedSpentOutpointsBucket = database.MakeBucket([]byte("stxo-index-accepted-spent-outpoints"))
+var chainBlockTrackerBucket = database.MakeBucket([]byte("stxo-index-chain-block-tracker"))
+var virtualSelectedParentKey = database.MakeBucket([]byte("")).Key([]byte("stxo-index-virtual-selected-parent"))
+
+type stxoIndexStore struct {
+	database          database.Database
+	chainBlockTracker *indexretention.ChainBlockTracker
+}
+
+func newSTXOIndexStore(database database.Database) *stxoIndexStore {
+	return &stxoIndexStore{
+		database:          database,
+		chainBlockTracker: indexretention.NewChainBlockTracker(chainBlockTrackerBucket),
+	}
+}
+
+func (sis *stxoIndexStore) spendingTransactionKey(outpoint *externalapi.DomainOutpoint) *database.Key {
+	return spendingTransactionsBucket.Key(serializeOutpoint(outpoint))
+}
+
+func (sis *stxoIndexStore) acceptedSpentOutpointsKey(acceptingBlockHash *externalapi.DomainHash) *database.Key {
+	return acceptedSpentOutpointsBucket.Key(acceptingBlockHash.ByteSlice())
+}
+
+// addAcceptedSpends records that the given outpoints were spent by the given
+// transactions, and that the given chain block accepted these spends
+func (sis *stxoIndexStore) addAcceptedSpends(dataAccessor database.DataAccessor, acceptingBlockHash *externalapi.DomainHash,
+	acceptingBlockBlueScore uint64, outpoints []*externalapi.DomainOutpoint,
+	spendingTransactionIDs []*externalapi.DomainTransactionID) error {
+
+	size := uint64(0)
+	for i, outpoint := range outpoints {
+		spendingTransaction := &SpendingTransaction{
+			TransactionID:      spendingTransactionIDs[i],
+			AcceptingBlockHash: acceptingBlockHash,
+		}
+		key := sis.spendingTransactionKey(outpoint)
+		serializedSpendingTransaction := serializeSpendingTransaction(spendingTransaction)
+		err := dataAccessor.Put(key, serializedSpendingTransaction)
+		if err != nil {
+			return err
+		}
+		size += indexretention.RecordSize(key, serializedSpendingTransaction)
+	}
+
+	key := sis.acceptedSpentOutpointsKey(acceptingBlockHash)
+	serializedOutpoints := serializeOutpoints(outpoints)
+	err := dataAccessor.Put(key, serializedOutpoints)
+	if err != nil {
+		return err
+	}
+	size += indexretention.RecordSize(key, serializedOutpoints)
+
+	return sis.chainBlockTracker.Track(dataAccessor, acceptingBlockHash, acceptingBlockBlueScore, size)
+}
+
+// removeAcceptedSpends removes the spends accepted by the given chain block,
+// after it was removed from the virtual selected parent chain or pruned
+func (sis *stxoIndexStore) removeAcceptedSpends(dataAccessor database.DataAccessor,
+	acceptingBlockHash *externalapi.DomainHash, acceptingBlockBlueScore uint64) error {
+
+	err := sis.chainBlockTracker.Untrack(dataAccessor, acceptingBlockHash, acceptingBlockBlueScore)
+	if err != nil {
+		return err
+	}
+
+	key := sis.acceptedSpentOutpointsKey(acceptingBlockHash)
+	serializedOutpoints, err := dataAccessor.Get(key)
+	if err != nil {
+		// The block was accepted before the index was started
+		if database.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	outpoints, err := deserializeOutpoints(serializedOutpoints)
+	if err != nil {
+		return err
+	}
+
+	for _, outpoint := range outpoints {
+		err := dataAccessor.Delete(sis.spendingTransactionKey(outpoint))
+		if err != nil {
+			return err
+		}
+	}
+
+	return dataAccessor.Delete(key)
+}
+
+func (sis *stxoIndexStore) getSpendingTransaction(outpoint *externalapi.DomainOutpoint) (*SpendingTransaction, bool, error) {
+	serializedSpendingTransaction, err := sis.database.Get(sis.spendingTransactionKey(outpoint))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	spendingTransaction, err := deserializeSpendingTransaction(serializedSpendingTransaction)
+	if err != nil {
+		return nil, false, err
+	}
+	return spendingTransaction, true, nil
+}
+
+func (sis *stxoIndexStore) deleteAll() error {
+	for _, bucket := range []*database.Bucket{spendingTransactionsBucket, acceptedSpentOutpointsBucket, chainBlockTrackerBucket} {
+		err := sis.deleteBucket(bucket)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sis *stxoIndexStore) deleteBucket(bucket *database.Bucket) error {
+	cursor, err := sis.database.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+
+		err = sis.database.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
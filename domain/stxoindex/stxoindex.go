@@ -0,0 +1,140 @@
+package stxoindex
+
+import (
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/domain/indexsync"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// STXOIndex maintains an index between spent transaction outpoints
+// and the transactions that spent them
+type STXOIndex struct {
+	store  *stxoIndexStore
+	syncer *indexsync.Syncer
+}
+
+// New creates a new STXO index.
+//
+// Blocks may be added to the consensus while this is called, since the index
+// catches up with the chain changes it misses meanwhile on the next Update.
+func New(domain domain.Domain, database database.Database) (*STXOIndex, error) {
+	stxoIndex := &STXOIndex{
+		store: newSTXOIndexStore(database),
+	}
+	stxoIndex.syncer = indexsync.New("STXO index", domain, database, virtualSelectedParentKey, &indexsync.Callbacks{
+		Reset:            stxoIndex.reset,
+		RemoveChainBlock: stxoIndex.removeChainBlock,
+		AddChainBlock:    stxoIndex.addChainBlock,
+	})
+
+	err := stxoIndex.syncer.CatchUp()
+	if err != nil {
+		return nil, err
+	}
+	return stxoIndex, nil
+}
+
+// Reset deletes the whole STXO index and resyncs it from the pruning point.
+// Spends that were accepted before the pruning point are not indexed.
+func (si *STXOIndex) Reset() error {
+	return si.syncer.Reset()
+}
+
+// Update updates the STXO index with the given DAG selected parent chain changes
+func (si *STXOIndex) Update(chainChanges *externalapi.SelectedChainPath) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "STXOIndex.Update")
+	defer onEnd()
+
+	return si.syncer.Update(chainChanges)
+}
+
+func (si *STXOIndex) reset(_ *externalapi.DomainHash) error {
+	return si.store.deleteAll()
+}
+
+func (si *STXOIndex) removeChainBlock(dbTransaction database.Transaction,
+	blockHash *externalapi.DomainHash, blueScore uint64) error {
+
+	log.Tracef("Removing the spends accepted by chain block %s from the STXO index", blockHash)
+	return si.store.removeAcceptedSpends(dbTransaction, blockHash, blueScore)
+}
+
+func (si *STXOIndex) addChainBlock(dbTransaction database.Transaction, blockHash *externalapi.DomainHash,
+	header externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error {
+
+	outpoints, spendingTransactionIDs := acceptedSpends(acceptanceData)
+	log.Tracef("Adding %d spends accepted by chain block %s to the STXO index", len(outpoints), blockHash)
+	return si.store.addAcceptedSpends(dbTransaction, blockHash, header.BlueScore(), outpoints, spendingTransactionIDs)
+}
+
+// acceptedSpends returns the outpoints spent by the transactions
+// accepted in the given acceptance data, along with the IDs of
+// the transactions that spent them
+func acceptedSpends(acceptanceData externalapi.AcceptanceData) (
+	outpoints []*externalapi.DomainOutpoint, spendingTransactionIDs []*externalapi.DomainTransactionID) {
+
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted {
+				continue
+			}
+			transaction := transactionAcceptanceData.Transaction
+			transactionID := consensushashing.TransactionID(transaction)
+			for _, input := range transaction.Inputs {
+				outpoints = append(outpoints, &input.PreviousOutpoint)
+				spendingTransactionIDs = append(spendingTransactionIDs, transactionID)
+			}
+		}
+	}
+	return outpoints, spendingTransactionIDs
+}
+
+// SpendingTransaction returns the transaction that spent the given outpoint,
+// and the chain block that accepted it. found is false if the outpoint is
+// unspent, unknown, or was spent before the index had started.
+func (si *STXOIndex) SpendingTransaction(outpoint *externalapi.DomainOutpoint) (
+	spendingTransaction *SpendingTransaction, found bool, err error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "STXOIndex.SpendingTransaction")
+	defer onEnd()
+
+	si.syncer.Lock()
+	defer si.syncer.Unlock()
+
+	return si.store.getSpendingTransaction(outpoint)
+}
+
+// ChainBlockTracker returns the tracker of the chain blocks the STXO index holds spends of
+func (si *STXOIndex) ChainBlockTracker() *indexretention.ChainBlockTracker {
+	return si.store.chainBlockTracker
+}
+
+// VirtualSelectedParentBlueScore returns the blue score of the
+// virtual selected parent the STXO index is synced with
+func (si *STXOIndex) VirtualSelectedParentBlueScore() (uint64, error) {
+	return si.syncer.VirtualSelectedParentBlueScore()
+}
+
+// PruneChainBlocks removes the spends accepted by the given chain blocks from the STXO index
+func (si *STXOIndex) PruneChainBlocks(chainBlocks []*indexretention.TrackedChainBlock) error {
+	si.syncer.Lock()
+	defer si.syncer.Unlock()
+
+	dbTransaction, err := si.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, chainBlock := range chainBlocks {
+		err := si.store.removeAcceptedSpends(dbTransaction, chainBlock.BlockHash, chainBlock.BlueScore)
+		if err != nil {
+			return err
+		}
+	}
+	return dbTransaction.Commit()
+}
@@ -0,0 +1,22 @@
+package txindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// TransactionBlocks are the blocks that include a transaction, along with
+// the chain block that accepted it
+type TransactionBlocks struct {
+	IncludingBlockHashes []*externalapi.DomainHash
+
+	// AcceptingBlockHash is nil if none of the including blocks
+	// had the transaction accepted
+	AcceptingBlockHash *externalapi.DomainHash
+}
+
+// mergedTransaction is a transaction that was included in blocks
+// merged by a single chain block
+type mergedTransaction struct {
+	includingBlockHashes []*externalapi.DomainHash
+	isAccepted           bool
+}
@@ -0,0 +1,96 @@
+package txindex
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+const hashesLengthSize = 8
+const isAcceptedSize = 1
+
+func serializeHashes(hashes [][]byte) []byte {
+	serializedHashes := make([]byte, hashesLengthSize+externalapi.DomainHashSize*len(hashes))
+	binary.LittleEndian.PutUint64(serializedHashes[:hashesLengthSize], uint64(len(hashes)))
+	for i, hash := range hashes {
+		start := hashesLengthSize + externalapi.DomainHashSize*i
+		copy(serializedHashes[start:start+externalapi.DomainHashSize], hash)
+	}
+	return serializedHashes
+}
+
+func deserializeHashes(serializedHashes []byte) ([][]byte, error) {
+	if len(serializedHashes) < hashesLengthSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing hashes")
+	}
+	length := binary.LittleEndian.Uint64(serializedHashes[:hashesLengthSize])
+	hashes := make([][]byte, length)
+	for i := uint64(0); i < length; i++ {
+		start := hashesLengthSize + externalapi.DomainHashSize*i
+		end := start + externalapi.DomainHashSize
+
+		if end > uint64(len(serializedHashes)) {
+			return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing hashes")
+		}
+		hashes[i] = serializedHashes[start:end]
+	}
+	return hashes, nil
+}
+
+func serializeTransactionIDs(transactionIDs []*externalapi.DomainTransactionID) []byte {
+	hashes := make([][]byte, len(transactionIDs))
+	for i, transactionID := range transactionIDs {
+		hashes[i] = transactionID.ByteSlice()
+	}
+	return serializeHashes(hashes)
+}
+
+func deserializeTransactionIDs(serializedTransactionIDs []byte) ([]*externalapi.DomainTransactionID, error) {
+	hashes, err := deserializeHashes(serializedTransactionIDs)
+	if err != nil {
+		return nil, err
+	}
+	transactionIDs := make([]*externalapi.DomainTransactionID, len(hashes))
+	for i, hash := range hashes {
+		transactionIDs[i], err = externalapi.NewDomainTransactionIDFromByteSlice(hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return transactionIDs, nil
+}
+
+func serializeMergedTransaction(transaction *mergedTransaction) []byte {
+	hashes := make([][]byte, len(transaction.includingBlockHashes))
+	for i, blockHash := range transaction.includingBlockHashes {
+		hashes[i] = blockHash.ByteSlice()
+	}
+	serializedTransaction := make([]byte, isAcceptedSize, isAcceptedSize+hashesLengthSize+externalapi.DomainHashSize*len(hashes))
+	if transaction.isAccepted {
+		serializedTransaction[0] = 1
+	}
+	return append(serializedTransaction, serializeHashes(hashes)...)
+}
+
+func deserializeMergedTransaction(serializedTransaction []byte) (*mergedTransaction, error) {
+	if len(serializedTransaction) < isAcceptedSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing a merged transaction")
+	}
+	hashes, err := deserializeHashes(serializedTransaction[isAcceptedSize:])
+	if err != nil {
+		return nil, err
+	}
+	includingBlockHashes := make([]*externalapi.DomainHash, len(hashes))
+	for i, hash := range hashes {
+		includingBlockHashes[i], err = externalapi.NewDomainHashFromByteSlice(hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &mergedTransaction{
+		includingBlockHashes: includingBlockHashes,
+		isAccepted:           serializedTransaction[0] == 1,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package txindex
+
+import (
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+func Test_serializeTransactionIDs(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+
+	for length := 0; length < 32; length++ {
+		transactionIDs := make([]*externalapi.DomainTransactionID, length)
+		for i := range transactionIDs {
+			var transactionIDBytes [externalapi.DomainHashSize]byte
+			r.Read(transactionIDBytes[:])
+			transactionIDs[i] = externalapi.NewDomainTransactionIDFromByteArray(&transactionIDBytes)
+		}
+		result, err := deserializeTransactionIDs(serializeTransactionIDs(transactionIDs))
+		if err != nil {
+			t.Fatalf("Failed deserializing transaction IDs: %v", err)
+		}
+		if len(result) != len(transactionIDs) {
+			t.Fatalf("Expected %d transaction IDs, got %d", len(transactionIDs), len(result))
+		}
+		for i := range transactionIDs {
+			if !transactionIDs[i].Equal(result[i]) {
+				t.Fatalf("Expected \n %s \n==\n %s\n", transactionIDs[i], result[i])
+			}
+		}
+	}
+}
+
+func Test_deserializeTransactionIDsFailure(t *testing.T) {
+	transactionIDs := []*externalapi.DomainTransactionID{
+		externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+	}
+	serialized := serializeTransactionIDs(transactionIDs)
+	binary.LittleEndian.PutUint64(serialized[:hashesLengthSize], uint64(len(transactionIDs)+1))
+	_, err := deserializeTransactionIDs(serialized)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected error to be EOF, instead got: %v", err)
+	}
+}
+
+func Test_serializeMergedTransaction(t *testing.T) {
+	for _, isAccepted := range []bool{false, true} {
+		transaction := &mergedTransaction{
+			includingBlockHashes: []*externalapi.DomainHash{
+				externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+				externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+			},
+			isAccepted: isAccepted,
+		}
+		result, err := deserializeMergedTransaction(serializeMergedTransaction(transaction))
+		if err != nil {
+			t.Fatalf("Failed deserializing merged transaction: %v", err)
+		}
+		if result.isAccepted != transaction.isAccepted ||
+			!externalapi.HashesEqual(result.includingBlockHashes, transaction.includingBlockHashes) {
+			t.Fatalf("Expected \n %+v \n==\n %+v\n", transaction, result)
+		}
+	}
+}
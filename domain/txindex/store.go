@@ -0,0 +1,175 @@
+package txindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+var mergedTransactionsBucket = database.MakeBucket([]byte("tx-index-merged-transactions"))
+var mergedTransactionIDsBucket = database.MakeBucket([]byte("tx-index-merged-transaction-ids"))
+var chainBlockTrackerBucket = database.MakeBucket([]byte("tx-index-chain-block-tracker"))
+var virtualSelectedParentKey = database.MakeBucket([]byte("")).Key([]byte("tx-index-virtual-selected-parent"))
+
+type txIndexStore struct {
+	database          database.Database
+	chainBlockTracker *indexretention.ChainBlockTracker
+}
+
+func newTXIndexStore(database database.Database) *txIndexStore {
+	return &txIndexStore{
+		database:          database,
+		chainBlockTracker: indexretention.NewChainBlockTracker(chainBlockTrackerBucket),
+	}
+}
+
+func (tis *txIndexStore) mergedTransactionsBucket(transactionID *externalapi.DomainTransactionID) *database.Bucket {
+	return mergedTransactionsBucket.Bucket(transactionID.ByteSlice())
+}
+
+func (tis *txIndexStore) mergedTransactionKey(transactionID *externalapi.DomainTransactionID,
+	mergingBlockHash *externalapi.DomainHash) *database.Key {
+
+	return tis.mergedTransactionsBucket(transactionID).Key(mergingBlockHash.ByteSlice())
+}
+
+func (tis *txIndexStore) mergedTransactionIDsKey(mergingBlockHash *externalapi.DomainHash) *database.Key {
+	return mergedTransactionIDsBucket.Key(mergingBlockHash.ByteSlice())
+}
+
+// addMergedTransactions records the given transactions, which were included in
+// the merge set of the given chain block
+func (tis *txIndexStore) addMergedTransactions(dataAccessor database.DataAccessor, mergingBlockHash *externalapi.DomainHash,
+	mergingBlockBlueScore uint64, transactionIDs []*externalapi.DomainTransactionID,
+	transactions []*mergedTransaction) error {
+
+	size := uint64(0)
+	for i, transactionID := range transactionIDs {
+		key := tis.mergedTransactionKey(transactionID, mergingBlockHash)
+		serializedTransaction := serializeMergedTransaction(transactions[i])
+		err := dataAccessor.Put(key, serializedTransaction)
+		if err != nil {
+			return err
+		}
+		size += indexretention.RecordSize(key, serializedTransaction)
+	}
+
+	key := tis.mergedTransactionIDsKey(mergingBlockHash)
+	serializedTransactionIDs := serializeTransactionIDs(transactionIDs)
+	err := dataAccessor.Put(key, serializedTransactionIDs)
+	if err != nil {
+		return err
+	}
+	size += indexretention.RecordSize(key, serializedTransactionIDs)
+
+	return tis.chainBlockTracker.Track(dataAccessor, mergingBlockHash, mergingBlockBlueScore, size)
+}
+
+// removeMergedTransactions removes the transactions merged by the given chain
+// block, after it was removed from the virtual selected parent chain or pruned
+func (tis *txIndexStore) removeMergedTransactions(dataAccessor database.DataAccessor,
+	mergingBlockHash *externalapi.DomainHash, mergingBlockBlueScore uint64) error {
+
+	err := tis.chainBlockTracker.Untrack(dataAccessor, mergingBlockHash, mergingBlockBlueScore)
+	if err != nil {
+		return err
+	}
+
+	key := tis.mergedTransactionIDsKey(mergingBlockHash)
+	serializedTransactionIDs, err := dataAccessor.Get(key)
+	if err != nil {
+		// The block was merged before the index was started
+		if database.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	transactionIDs, err := deserializeTransactionIDs(serializedTransactionIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, transactionID := range transactionIDs {
+		err := dataAccessor.Delete(tis.mergedTransactionKey(transactionID, mergingBlockHash))
+		if err != nil {
+			return err
+		}
+	}
+
+	return dataAccessor.Delete(key)
+}
+
+// getTransactionBlocks collects the blocks that include the given transaction
+// from all the chain blocks that merged them
+func (tis *txIndexStore) getTransactionBlocks(transactionID *externalapi.DomainTransactionID) (
+	*TransactionBlocks, bool, error) {
+
+	cursor, err := tis.database.Cursor(tis.mergedTransactionsBucket(transactionID))
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close()
+
+	transactionBlocks := &TransactionBlocks{}
+	found := false
+	for cursor.Next() {
+		found = true
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, false, err
+		}
+		serializedTransaction, err := cursor.Value()
+		if err != nil {
+			return nil, false, err
+		}
+		transaction, err := deserializeMergedTransaction(serializedTransaction)
+		if err != nil {
+			return nil, false, err
+		}
+
+		transactionBlocks.IncludingBlockHashes = append(transactionBlocks.IncludingBlockHashes,
+			transaction.includingBlockHashes...)
+		if transaction.isAccepted {
+			transactionBlocks.AcceptingBlockHash, err = externalapi.NewDomainHashFromByteSlice(key.Suffix())
+			if err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	if !found {
+		return nil, false, nil
+	}
+	return transactionBlocks, true, nil
+}
+
+func (tis *txIndexStore) deleteAll() error {
+	for _, bucket := range []*database.Bucket{mergedTransactionsBucket, mergedTransactionIDsBucket, chainBlockTrackerBucket} {
+		err := tis.deleteBucket(bucket)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tis *txIndexStore) deleteBucket(bucket *database.Bucket) error {
+	cursor, err := tis.database.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+
+		err = tis.database.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package txindex
+
+import (
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/indexretention"
+	"github.com/kaspanet/kaspad/domain/indexsync"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// TXIndex maintains an index between transaction IDs and the
+// blocks that include them
+type TXIndex struct {
+	store  *txIndexStore
+	syncer *indexsync.Syncer
+}
+
+// New creates a new TX index.
+//
+// Blocks may be added to the consensus while this is called, since the index
+// catches up with the chain changes it misses meanwhile on the next Update.
+func New(domain domain.Domain, database database.Database) (*TXIndex, error) {
+	txIndex := &TXIndex{
+		store: newTXIndexStore(database),
+	}
+	txIndex.syncer = indexsync.New("TX index", domain, database, virtualSelectedParentKey, &indexsync.Callbacks{
+		Reset:            txIndex.reset,
+		RemoveChainBlock: txIndex.removeChainBlock,
+		AddChainBlock:    txIndex.addChainBlock,
+	})
+
+	err := txIndex.syncer.CatchUp()
+	if err != nil {
+		return nil, err
+	}
+	return txIndex, nil
+}
+
+// Reset deletes the whole TX index and resyncs it from the pruning point.
+// Transactions that were merged before the pruning point are not indexed.
+func (ti *TXIndex) Reset() error {
+	return ti.syncer.Reset()
+}
+
+// Update updates the TX index with the given DAG selected parent chain changes
+func (ti *TXIndex) Update(chainChanges *externalapi.SelectedChainPath) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "TXIndex.Update")
+	defer onEnd()
+
+	return ti.syncer.Update(chainChanges)
+}
+
+func (ti *TXIndex) reset(_ *externalapi.DomainHash) error {
+	return ti.store.deleteAll()
+}
+
+func (ti *TXIndex) removeChainBlock(dbTransaction database.Transaction,
+	blockHash *externalapi.DomainHash, blueScore uint64) error {
+
+	log.Tracef("Removing the transactions merged by chain block %s from the TX index", blockHash)
+	return ti.store.removeMergedTransactions(dbTransaction, blockHash, blueScore)
+}
+
+func (ti *TXIndex) addChainBlock(dbTransaction database.Transaction, blockHash *externalapi.DomainHash,
+	header externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error {
+
+	transactionIDs, transactions := mergedTransactions(acceptanceData)
+	log.Tracef("Adding %d transactions merged by chain block %s to the TX index", len(transactionIDs), blockHash)
+	return ti.store.addMergedTransactions(dbTransaction, blockHash, header.BlueScore(), transactionIDs, transactions)
+}
+
+// mergedTransactions returns the IDs of the transactions included in the merge
+// set of a chain block with the given acceptance data, along with the blocks
+// that include each of them and whether the chain block accepted it
+func mergedTransactions(acceptanceData externalapi.AcceptanceData) (
+	transactionIDs []*externalapi.DomainTransactionID, transactions []*mergedTransaction) {
+
+	transactionsByID := make(map[externalapi.DomainTransactionID]*mergedTransaction)
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			transactionID := consensushashing.TransactionID(transactionAcceptanceData.Transaction)
+			transaction, ok := transactionsByID[*transactionID]
+			if !ok {
+				transaction = &mergedTransaction{}
+				transactionsByID[*transactionID] = transaction
+				transactionIDs = append(transactionIDs, transactionID)
+				transactions = append(transactions, transaction)
+			}
+			transaction.includingBlockHashes = append(transaction.includingBlockHashes, blockAcceptanceData.BlockHash)
+			if transactionAcceptanceData.IsAccepted {
+				transaction.isAccepted = true
+			}
+		}
+	}
+	return transactionIDs, transactions
+}
+
+// TransactionBlocks returns the blocks that include the given transaction,
+// and the chain block that accepted it. found is false if the transaction is
+// unknown, or was merged before the index had started.
+func (ti *TXIndex) TransactionBlocks(transactionID *externalapi.DomainTransactionID) (
+	transactionBlocks *TransactionBlocks, found bool, err error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "TXIndex.TransactionBlocks")
+	defer onEnd()
+
+	ti.syncer.Lock()
+	defer ti.syncer.Unlock()
+
+	return ti.store.getTransactionBlocks(transactionID)
+}
+
+// ChainBlockTracker returns the tracker of the chain blocks the TX index holds the merged transactions of
+func (ti *TXIndex) ChainBlockTracker() *indexretention.ChainBlockTracker {
+	return ti.store.chainBlockTracker
+}
+
+// VirtualSelectedParentBlueScore returns the blue score of the
+// virtual selected parent the TX index is synced with
+func (ti *TXIndex) VirtualSelectedParentBlueScore() (uint64, error) {
+	return ti.syncer.VirtualSelectedParentBlueScore()
+}
+
+// PruneChainBlocks removes the transactions merged by the given chain blocks from the TX index
+func (ti *TXIndex) PruneChainBlocks(chainBlocks []*indexretention.TrackedChainBlock) error {
+	ti.syncer.Lock()
+	defer ti.syncer.Unlock()
+
+	dbTransaction, err := ti.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, chainBlock := range chainBlocks {
+		err := ti.store.removeMergedTransactions(dbTransaction, chainBlock.BlockHash, chainBlock.BlueScore)
+		if err != nil {
+			return err
+		}
+	}
+	return dbTransaction.Commit()
+}
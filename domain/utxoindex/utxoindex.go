@@ -36,6 +36,13 @@ func New(domain domain.Domain, database database.Database) (*UTXOIndex, error) {
 		return nil, err
 	}
 
+	if !isSynced && hasCirculatingSupplyKey {
+		isSynced, err = utxoIndex.catchUp()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if !isSynced || !hasCirculatingSupplyKey {
 
 		err := utxoIndex.Reset()
@@ -47,6 +54,45 @@ func New(domain domain.Domain, database database.Database) (*UTXOIndex, error) {
 	return utxoIndex, nil
 }
 
+// catchUp applies the virtual changes the UTXO index had missed, e.g. because
+// the node shut down before the index was updated with them, from the virtual
+// change journal of consensus. It returns false if the index is too far behind
+// for the journal to bring it in sync, in which case it has to be reset.
+func (ui *UTXOIndex) catchUp() (bool, error) {
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+
+	utxoIndexVirtualParents, err := ui.store.getVirtualParents()
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// The virtual UTXO set is determined by the virtual parents, so replaying
+	// the changes after any journal entry with the index's virtual parents
+	// is just as good
+	entries, found, err := ui.domain.Consensus().GetVirtualChangeJournalEntriesAfter(utxoIndexVirtualParents)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		log.Infof("The UTXO index is too far behind the virtual change journal to catch up")
+		return false, nil
+	}
+
+	log.Infof("Catching up the UTXO index with %d virtual changes", len(entries))
+	for _, entry := range entries {
+		_, err := ui.update(entry.VirtualUTXODiff, entry.VirtualParents)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return ui.isSynced()
+}
+
 // Reset deletes the whole UTXO index and resyncs it from consensus.
 func (ui *UTXOIndex) Reset() error {
 	ui.mutex.Lock()
@@ -116,18 +162,24 @@ func (ui *UTXOIndex) Update(virtualChangeSet *externalapi.VirtualChangeSet) (*UT
 	ui.mutex.Lock()
 	defer ui.mutex.Unlock()
 
-	log.Tracef("Updating UTXO index with VirtualUTXODiff: %+v", virtualChangeSet.VirtualUTXODiff)
-	err := ui.removeUTXOs(virtualChangeSet.VirtualUTXODiff.ToRemove())
+	return ui.update(virtualChangeSet.VirtualUTXODiff, virtualChangeSet.VirtualParents)
+}
+
+func (ui *UTXOIndex) update(virtualUTXODiff externalapi.UTXODiff,
+	virtualParents []*externalapi.DomainHash) (*UTXOChanges, error) {
+
+	log.Tracef("Updating UTXO index with VirtualUTXODiff: %+v", virtualUTXODiff)
+	err := ui.removeUTXOs(virtualUTXODiff.ToRemove())
 	if err != nil {
 		return nil, err
 	}
 
-	err = ui.addUTXOs(virtualChangeSet.VirtualUTXODiff.ToAdd())
+	err = ui.addUTXOs(virtualUTXODiff.ToAdd())
 	if err != nil {
 		return nil, err
 	}
 
-	ui.store.updateVirtualParents(virtualChangeSet.VirtualParents)
+	ui.store.updateVirtualParents(virtualParents)
 
 	added, removed, _ := ui.store.stagedData()
 	utxoIndexChanges := &UTXOChanges{
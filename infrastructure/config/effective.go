@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// OptionSource denotes where the value of a configuration option was taken from
+type OptionSource string
+
+const (
+	// OptionSourceDefault denotes an option that was not set explicitly
+	OptionSourceDefault OptionSource = "default"
+
+	// OptionSourceConfigFile denotes an option that was set in the config file
+	OptionSourceConfigFile OptionSource = "configfile"
+
+	// OptionSourceCommandLine denotes an option that was set on the command line
+	OptionSourceCommandLine OptionSource = "commandline"
+)
+
+// redactedValue replaces the value of secret options
+const redactedValue = "<redacted>"
+
+// EffectiveOption is the resolved value of a single configuration option, along
+// with the source it was taken from
+type EffectiveOption struct {
+	Name   string
+	Value  string
+	Source OptionSource
+}
+
+// setOptionNames returns the long names of all the options that were set
+// explicitly through the given parser
+func setOptionNames(parser *flags.Parser) map[string]struct{} {
+	names := make(map[string]struct{})
+	var visit func(group *flags.Group)
+	visit = func(group *flags.Group) {
+		for _, option := range group.Options() {
+			if option.IsSet() && !option.IsSetDefault() {
+				names[option.LongName] = struct{}{}
+			}
+		}
+		for _, subGroup := range group.Groups() {
+			visit(subGroup)
+		}
+	}
+	visit(parser.Group)
+	return names
+}
+
+// resolveOptionSources records where each of the explicitly set options came
+// from. Options set both in the config file and on the command line are
+// attributed to the command line, since it takes precedence.
+func (cfg *Config) resolveOptionSources(configFileOptions, commandLineOptions map[string]struct{}) {
+	cfg.optionSources = make(map[string]OptionSource, len(configFileOptions)+len(commandLineOptions))
+	for name := range configFileOptions {
+		cfg.optionSources[name] = OptionSourceConfigFile
+	}
+	for name := range commandLineOptions {
+		cfg.optionSources[name] = OptionSourceCommandLine
+	}
+}
+
+// EffectiveOptions returns the resolved value of every configuration option,
+// sorted by name. Secret options, such as passwords, are redacted.
+//
+// Note that the values are the ones kaspad actually runs with, after defaults
+// were applied and paths were expanded, so they may differ from the raw
+// values given in the config file or on the command line.
+func (cfg *Config) EffectiveOptions() []*EffectiveOption {
+	return cfg.effectiveOptions(true)
+}
+
+func (cfg *Config) effectiveOptions(redactSecrets bool) []*EffectiveOption {
+	var options []*EffectiveOption
+	var visit func(value reflect.Value)
+	visit = func(value reflect.Value) {
+		valueType := value.Type()
+		for i := 0; i < valueType.NumField(); i++ {
+			field := valueType.Field(i)
+			fieldValue := value.Field(i)
+			if fieldValue.Kind() == reflect.Ptr && fieldValue.Elem().Kind() == reflect.Struct &&
+				field.Tag.Get("long") == "" {
+				if !fieldValue.IsNil() {
+					visit(fieldValue.Elem())
+				}
+				continue
+			}
+			if field.Anonymous && fieldValue.Kind() == reflect.Struct {
+				visit(fieldValue)
+				continue
+			}
+
+			name := field.Tag.Get("long")
+			if name == "" {
+				continue
+			}
+			source, ok := cfg.optionSources[name]
+			if !ok {
+				source = OptionSourceDefault
+			}
+			option := &EffectiveOption{
+				Name:   name,
+				Value:  formatOptionValue(fieldValue),
+				Source: source,
+			}
+			if redactSecrets && field.Tag.Get("default-mask") == "-" && option.Value != "" {
+				option.Value = redactedValue
+			}
+			options = append(options, option)
+		}
+	}
+	visit(reflect.ValueOf(cfg.Flags).Elem())
+
+	sort.Slice(options, func(i, j int) bool { return options[i].Name < options[j].Name })
+	return options
+}
+
+func formatOptionValue(value reflect.Value) string {
+	if value.Kind() == reflect.Slice {
+		elements := make([]string, value.Len())
+		for i := range elements {
+			elements[i] = fmt.Sprint(value.Index(i).Interface())
+		}
+		return strings.Join(elements, ",")
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// RelayNonStdSource returns where the policy for relaying non-standard
+// transactions was taken from. OptionSourceDefault denotes the default of the
+// active network.
+func (cfg *Config) RelayNonStdSource() OptionSource {
+	for _, name := range []string{"rejectnonstd", "relaynonstd"} {
+		if source, ok := cfg.optionSources[name]; ok {
+			return source
+		}
+	}
+	return OptionSourceDefault
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jessevdk/go-flags"
+)
+
+func TestEffectiveOptions(t *testing.T) {
+	cfgFlags := defaultFlags()
+	parser := newConfigParser(cfgFlags, flags.Default)
+	err := flags.NewIniParser(parser).Parse(strings.NewReader("outpeers=3\nmaxinpeers=5\n"))
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	configFileOptions := setOptionNames(parser)
+
+	commandLineParser := newConfigParser(cfgFlags, flags.Default)
+	_, err = commandLineParser.ParseArgs([]string{"--maxinpeers=7", "--proxypass=hunter2"})
+	if err != nil {
+		t.Fatalf("ParseArgs: %s", err)
+	}
+	commandLineOptions := setOptionNames(commandLineParser)
+
+	cfg := &Config{Flags: cfgFlags}
+	cfg.resolveOptionSources(configFileOptions, commandLineOptions)
+
+	expectedOptions := map[string]EffectiveOption{
+		"outpeers":   {Value: "3", Source: OptionSourceConfigFile},
+		"maxinpeers": {Value: "7", Source: OptionSourceCommandLine},
+		"proxypass":  {Value: redactedValue, Source: OptionSourceCommandLine},
+		"proxyuser":  {Value: "", Source: OptionSourceDefault},
+		"testnet":    {Value: "false", Source: OptionSourceDefault},
+	}
+	options := cfg.EffectiveOptions()
+	for _, option := range options {
+		expected, ok := expectedOptions[option.Name]
+		if !ok {
+			continue
+		}
+		if option.Value != expected.Value || option.Source != expected.Source {
+			t.Errorf("%s: expected value %q from %s, but got %q from %s",
+				option.Name, expected.Value, expected.Source, option.Value, option.Source)
+		}
+		delete(expectedOptions, option.Name)
+	}
+	for name := range expectedOptions {
+		t.Errorf("option %s is missing", name)
+	}
+	for i := 1; i < len(options); i++ {
+		if options[i-1].Name >= options[i].Name {
+			t.Fatalf("options are not sorted by name: %s before %s", options[i-1].Name, options[i].Name)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+)
+
+// InitCommand is the name of the sub-command that generates a config file
+const InitCommand = "init"
+
+var initNetworks = []string{"mainnet", "testnet", "simnet", "devnet"}
+
+type initFlags struct {
+	ConfigFile     string `short:"C" long:"configfile" description:"Path of the config file to generate"`
+	Network        string `long:"network" description:"The network to connect to {mainnet, testnet, simnet, devnet}"`
+	AppDir         string `short:"b" long:"appdir" description:"Directory to store data"`
+	UTXOIndex      bool   `long:"utxoindex" description:"Enable the UTXO index"`
+	IsArchivalNode bool   `long:"archival" description:"Run as an archival node"`
+	RPCListen      string `long:"rpclisten" description:"Interface/port to listen for RPC connections"`
+	NonInteractive bool   `short:"y" long:"noninteractive" description:"Don't prompt, generate the config file from the given flags"`
+	Force          bool   `long:"force" description:"Overwrite the config file if it already exists"`
+}
+
+var initConfigTemplate = template.Must(template.New("kaspad.conf").Parse(`; This file was generated by 'kaspad init' on {{.Date}}.
+; See sample-kaspad.conf in the kaspad repository for all the available options.
+[Application Options]
+
+; The network to connect to. Mainnet is used if none is selected.
+{{if eq .Network "mainnet"}}; testnet=1{{else}}{{.Network}}=1{{end}}
+
+; The directory to store data such as the block DAG and peer addresses. The
+; block DAG takes several GB, so this location must have a lot of free space.
+{{if .AppDir}}appdir={{.AppDir}}{{else}}; appdir={{.DefaultAppDir}}{{end}}
+
+; Maintain an index of the UTXO set by address. This is required by wallets
+; and other services that query balances and UTXOs over RPC.
+{{if .UTXOIndex}}{{else}}; {{end}}utxoindex=1
+
+; Keep all block data instead of deleting it when the pruning point moves.
+; Warning: this requires a lot of disk space, which keeps growing over time.
+{{if .IsArchivalNode}}{{else}}; {{end}}archival=1
+
+; The interface/port to listen for RPC connections on. By default kaspad listens
+; on all interfaces. Use 127.0.0.1 to only allow connections from this machine.
+; Note that the RPC server does not authenticate its clients unless rpccookie is
+; set.
+{{if .RPCListen}}rpclisten={{.RPCListen}}{{else}}; rpclisten=127.0.0.1:{{.RPCPort}}{{end}}
+`))
+
+// RunInitCommand generates a commented config file, prompting for the main
+// options on the given input unless --noninteractive is given. args should not
+// include the name of the sub-command itself.
+func RunInitCommand(args []string, input io.Reader, output io.Writer) error {
+	cfgFlags := &initFlags{ConfigFile: defaultConfigFile, Network: initNetworks[0]}
+	parser := flags.NewParser(cfgFlags, flags.HelpFlag)
+	parser.Usage = InitCommand + " [OPTIONS]"
+	_, err := parser.ParseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if !cfgFlags.NonInteractive {
+		err = promptInitFlags(cfgFlags, bufio.NewReader(input), output)
+		if err != nil {
+			return err
+		}
+	}
+
+	networkFlags := &NetworkFlags{}
+	switch cfgFlags.Network {
+	case "mainnet":
+	case "testnet":
+		networkFlags.Testnet = true
+	case "simnet":
+		networkFlags.Simnet = true
+	case "devnet":
+		networkFlags.Devnet = true
+	default:
+		return errors.Errorf("unknown network %q. Choose one of: %s",
+			cfgFlags.Network, strings.Join(initNetworks, ", "))
+	}
+	err = networkFlags.ResolveNetwork(parser)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(cfgFlags.ConfigFile); err == nil && !cfgFlags.Force {
+		return errors.Errorf("%s already exists. Use --force to overwrite it", cfgFlags.ConfigFile)
+	}
+
+	var content strings.Builder
+	err = initConfigTemplate.Execute(&content, map[string]interface{}{
+		"Date":           time.Now().Format("2006-01-02"),
+		"Network":        cfgFlags.Network,
+		"AppDir":         cfgFlags.AppDir,
+		"DefaultAppDir":  defaultDataDir,
+		"UTXOIndex":      cfgFlags.UTXOIndex,
+		"IsArchivalNode": cfgFlags.IsArchivalNode,
+		"RPCListen":      cfgFlags.RPCListen,
+		"RPCPort":        networkFlags.NetParams().RPCPort,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Make sure kaspad is going to accept the generated file
+	err = flags.NewIniParser(newConfigParser(defaultFlags(), flags.None)).Parse(strings.NewReader(content.String()))
+	if err != nil {
+		return errors.Wrapf(err, "the generated config file is invalid")
+	}
+
+	err = os.MkdirAll(filepath.Dir(cfgFlags.ConfigFile), 0700)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(cfgFlags.ConfigFile, []byte(content.String()), 0600)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "Wrote %s\n", cfgFlags.ConfigFile)
+	return nil
+}
+
+func promptInitFlags(cfgFlags *initFlags, reader *bufio.Reader, output io.Writer) error {
+	var err error
+	cfgFlags.Network, err = promptString(reader, output,
+		fmt.Sprintf("Network (%s)", strings.Join(initNetworks, ", ")), cfgFlags.Network)
+	if err != nil {
+		return err
+	}
+	cfgFlags.AppDir, err = promptString(reader, output,
+		"Data directory (leave empty for the default)", cfgFlags.AppDir)
+	if err != nil {
+		return err
+	}
+	cfgFlags.UTXOIndex, err = promptBool(reader, output,
+		"Enable the UTXO index? It is required by wallets connecting to this node", cfgFlags.UTXOIndex)
+	if err != nil {
+		return err
+	}
+	cfgFlags.IsArchivalNode, err = promptBool(reader, output,
+		"Run as an archival node? This requires a lot of disk space", cfgFlags.IsArchivalNode)
+	if err != nil {
+		return err
+	}
+	cfgFlags.RPCListen, err = promptString(reader, output,
+		"RPC listen address (leave empty to listen on all interfaces)", cfgFlags.RPCListen)
+	return err
+}
+
+func promptString(reader *bufio.Reader, output io.Writer, prompt string, defaultValue string) (string, error) {
+	fmt.Fprintf(output, "%s [%s]: ", prompt, defaultValue)
+	line, err := reader.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", errors.Wrapf(err, "failed reading the answer to %q", prompt)
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return defaultValue, nil
+	}
+	return line, nil
+}
+
+func promptBool(reader *bufio.Reader, output io.Writer, prompt string, defaultValue bool) (bool, error) {
+	defaultAnswer := "y/N"
+	if defaultValue {
+		defaultAnswer = "Y/n"
+	}
+	for {
+		answer, err := promptString(reader, output, prompt, defaultAnswer)
+		if err != nil {
+			return false, err
+		}
+		if answer == defaultAnswer {
+			return defaultValue, nil
+		}
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(output, "Please answer y or n")
+	}
+}
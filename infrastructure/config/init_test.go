@@ -0,0 +1,56 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jessevdk/go-flags"
+)
+
+func TestRunInitCommand(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "kaspad-init")
+	if err != nil {
+		t.Fatalf("Failed creating a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	configFile := filepath.Join(tmpDir, "kaspad.conf")
+
+	// Network, data directory, UTXO index, archival, RPC listen address
+	answers := "testnet\n/tmp/kaspad-data\ny\n\n127.0.0.1:16210\n"
+	err = RunInitCommand([]string{"--configfile", configFile}, strings.NewReader(answers), ioutil.Discard)
+	if err != nil {
+		t.Fatalf("RunInitCommand: %s", err)
+	}
+
+	cfgFlags := defaultFlags()
+	err = flags.NewIniParser(newConfigParser(cfgFlags, flags.None)).ParseFile(configFile)
+	if err != nil {
+		t.Fatalf("ParseFile: %s", err)
+	}
+	if !cfgFlags.Testnet || cfgFlags.AppDir != "/tmp/kaspad-data" || !cfgFlags.UTXOIndex ||
+		cfgFlags.IsArchivalNode || len(cfgFlags.RPCListeners) != 1 || cfgFlags.RPCListeners[0] != "127.0.0.1:16210" {
+
+		t.Fatalf("The generated config file does not match the answers: %+v", cfgFlags)
+	}
+
+	err = RunInitCommand([]string{"--configfile", configFile, "--noninteractive"}, nil, ioutil.Discard)
+	if err == nil {
+		t.Fatalf("RunInitCommand unexpectedly overwrote an existing config file")
+	}
+	err = RunInitCommand([]string{"--configfile", configFile, "--noninteractive", "--force", "--network", "simnet"},
+		nil, ioutil.Discard)
+	if err != nil {
+		t.Fatalf("RunInitCommand: %s", err)
+	}
+	cfgFlags = defaultFlags()
+	err = flags.NewIniParser(newConfigParser(cfgFlags, flags.None)).ParseFile(configFile)
+	if err != nil {
+		t.Fatalf("ParseFile: %s", err)
+	}
+	if !cfgFlags.Simnet || cfgFlags.Testnet || cfgFlags.UTXOIndex || len(cfgFlags.RPCListeners) != 0 {
+		t.Fatalf("The regenerated config file does not match the flags: %+v", cfgFlags)
+	}
+}
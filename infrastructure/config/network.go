@@ -44,6 +44,8 @@ type overrideDAGParamsConfig struct {
 	TimestampDeviationTolerance             *int               `json:"timestampDeviationTolerance"`
 	DifficultyAdjustmentWindowSize          *int               `json:"difficultyAdjustmentWindowSize"`
 	RelayNonStdTxs                          *bool              `json:"relayNonStdTxs"`
+	RequireMinimalPushes                    *bool              `json:"requireMinimalPushes"`
+	RequireCanonicalSignatures              *bool              `json:"requireCanonicalSignatures"`
 	AcceptUnroutable                        *bool              `json:"acceptUnroutable"`
 	EnableNonNativeSubnetworks              *bool              `json:"enableNonNativeSubnetworks"`
 	DisableDifficultyAdjustment             *bool              `json:"disableDifficultyAdjustment"`
@@ -76,7 +78,7 @@ func (networkFlags *NetworkFlags) ResolveNetwork(parser *flags.Parser) error {
 	if numNets > 1 {
 		message := "Multiple networks parameters (testnet, simnet, devnet, etc.) cannot be used" +
 			"together. Please choose only one network"
-		err := errors.Errorf(message)
+		err := errors.New(message)
 		fmt.Fprintln(os.Stderr, err)
 		parser.WriteHelp(os.Stderr)
 		return err
@@ -201,6 +203,14 @@ func (networkFlags *NetworkFlags) overrideDAGParams() error {
 		networkFlags.ActiveNetParams.RelayNonStdTxs = *config.RelayNonStdTxs
 	}
 
+	if config.RequireMinimalPushes != nil {
+		networkFlags.ActiveNetParams.RequireMinimalPushes = *config.RequireMinimalPushes
+	}
+
+	if config.RequireCanonicalSignatures != nil {
+		networkFlags.ActiveNetParams.RequireCanonicalSignatures = *config.RequireCanonicalSignatures
+	}
+
 	if config.AcceptUnroutable != nil {
 		networkFlags.ActiveNetParams.AcceptUnroutable = *config.AcceptUnroutable
 	}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestLoadNetworkConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	appDir := filepath.Join(tmpDir, "appdir")
+	configFile := filepath.Join(tmpDir, "testnet.conf")
+	err := os.WriteFile(configFile, []byte("testnet=1\nappdir="+appDir+"\nrpclisten=127.0.0.1:16310\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+
+	cfg, err := LoadNetworkConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadNetworkConfig: %+v", err)
+	}
+	if cfg.NetParams().Name != dagconfig.TestnetParams.Name {
+		t.Fatalf("Expected network %s, but got %s", dagconfig.TestnetParams.Name, cfg.NetParams().Name)
+	}
+	// The app directory is namespaced per network, like the one of the main network
+	expectedAppDir := filepath.Join(appDir, dagconfig.TestnetParams.Name)
+	if cfg.AppDir != expectedAppDir {
+		t.Fatalf("Expected app directory %s, but got %s", expectedAppDir, cfg.AppDir)
+	}
+	if len(cfg.RPCListeners) != 1 || cfg.RPCListeners[0] != "127.0.0.1:16310" {
+		t.Fatalf("Unexpected RPC listeners %v", cfg.RPCListeners)
+	}
+	if cfg.optionSources["rpclisten"] != OptionSourceConfigFile {
+		t.Fatalf("Expected rpclisten to be taken from the config file")
+	}
+
+	// Additional networks can't add networks of their own
+	err = os.WriteFile(configFile, []byte("testnet=1\nnetworkconfigfile=other.conf\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	_, err = LoadNetworkConfig(configFile)
+	if err == nil {
+		t.Fatalf("Expected a nested networkconfigfile to be rejected")
+	}
+
+	// Invalid options are rejected just like in the configuration of the main network
+	err = os.WriteFile(configFile, []byte("testnet=1\ndbtype=other\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	_, err = LoadNetworkConfig(configFile)
+	if err == nil {
+		t.Fatalf("Expected an invalid dbtype to be rejected")
+	}
+}
+
+func TestRelayNonStdPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "network.conf")
+
+	tests := []struct {
+		config              string
+		expectedRelayNonStd bool
+		expectedSource      OptionSource
+		expectsError        bool
+	}{
+		{config: "testnet=1", expectedRelayNonStd: false, expectedSource: OptionSourceDefault},
+		{config: "testnet=1\nrelaynonstd=1", expectedRelayNonStd: true, expectedSource: OptionSourceConfigFile},
+		{config: "simnet=1", expectedRelayNonStd: true, expectedSource: OptionSourceDefault},
+		{config: "devnet=1", expectedRelayNonStd: true, expectedSource: OptionSourceDefault},
+		{config: "devnet=1\nrejectnonstd=1", expectedRelayNonStd: false, expectedSource: OptionSourceConfigFile},
+		{config: "relaynonstd=1", expectsError: true},
+		{config: "simnet=1\nrelaynonstd=1\nrejectnonstd=1", expectsError: true},
+	}
+	for _, test := range tests {
+		err := os.WriteFile(configFile, []byte(test.config+"\nappdir="+tmpDir+"\n"), 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+		cfg, err := LoadNetworkConfig(configFile)
+		if test.expectsError {
+			if err == nil {
+				t.Errorf("%q: expected an error", test.config)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: LoadNetworkConfig: %+v", test.config, err)
+		}
+		if cfg.RelayNonStd != test.expectedRelayNonStd || cfg.RelayNonStdSource() != test.expectedSource {
+			t.Errorf("%q: expected relaynonstd %t from %s, but got %t from %s", test.config,
+				test.expectedRelayNonStd, test.expectedSource, cfg.RelayNonStd, cfg.RelayNonStdSource())
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+)
+
+// reloadableOptions are the options whose changes are applied to a running
+// node when its configuration is reloaded. Changes to any other option take
+// effect only after a restart.
+var reloadableOptions = map[string]struct{}{
+	"loglevel":      {},
+	"outpeers":      {},
+	"maxinpeers":    {},
+	"enablebanning": {},
+	"banduration":   {},
+	"banthreshold":  {},
+	"minrelaytxfee": {},
+	"dustrelayfee":  {},
+}
+
+// loggingOptions are ignored in the configuration of an additional network,
+// since logging is shared with the main network
+var loggingOptions = map[string]struct{}{
+	"loglevel":   {},
+	"logdir":     {},
+	"nologfiles": {},
+}
+
+// Reload loads the configuration again from the sources it was loaded from,
+// that is the config file and the command line of the process, or the network
+// config file of an additional network. Logging isn't initialized again, so
+// a new log level is only applied once the returned configuration is.
+func (cfg *Config) Reload() (*Config, error) {
+	if cfg.reload == nil {
+		return nil, errors.New("the configuration wasn't loaded from a config file, so it can't be reloaded")
+	}
+	return cfg.reload()
+}
+
+// ChangedOptions returns the names of the options whose values in newCfg
+// differ from their values in cfg, sorted by name. The changed options are
+// split into the ones that can be applied to a running node and the ones that
+// require a restart.
+func (cfg *Config) ChangedOptions(newCfg *Config) (reloadable []string, requireRestart []string) {
+	newValues := make(map[string]string)
+	for _, option := range newCfg.effectiveOptions(false) {
+		newValues[option.Name] = option.Value
+	}
+	for _, option := range cfg.effectiveOptions(false) {
+		if option.Value == newValues[option.Name] {
+			continue
+		}
+		if _, ok := loggingOptions[option.Name]; ok && cfg.isNetworkConfig {
+			continue
+		}
+		if _, ok := reloadableOptions[option.Name]; ok {
+			reloadable = append(reloadable, option.Name)
+			continue
+		}
+		requireRestart = append(requireRestart, option.Name)
+	}
+	return reloadable, requireRestart
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReload(t *testing.T) {
+	_, err := DefaultConfig().Reload()
+	if err == nil {
+		t.Fatalf("Expected reloading a configuration that wasn't loaded from a file to fail")
+	}
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "simnet.conf")
+	writeConfigFile := func(content string) {
+		err := os.WriteFile(configFile, []byte("simnet=1\nappdir="+tmpDir+"\n"+content), 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+	}
+
+	writeConfigFile("outpeers=3\nloglevel=info\nrpclisten=127.0.0.1:16510\nproxy=127.0.0.1:9050\nproxypass=hunter1\n")
+	cfg, err := LoadNetworkConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadNetworkConfig: %+v", err)
+	}
+
+	writeConfigFile("outpeers=5\nloglevel=debug\nrpclisten=127.0.0.1:16610\nproxy=127.0.0.1:9050\nproxypass=hunter2\n" +
+		"minrelaytxfee=0.0001\n")
+	newCfg, err := cfg.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %+v", err)
+	}
+	if newCfg.TargetOutboundPeers != 5 {
+		t.Fatalf("Expected the reloaded configuration to have 5 outbound peers, but got %d",
+			newCfg.TargetOutboundPeers)
+	}
+
+	// The log level of an additional network is ignored, and secret options are
+	// compared although they're redacted in the effective options
+	reloadable, requireRestart := cfg.ChangedOptions(newCfg)
+	expectedReloadable := []string{"minrelaytxfee", "outpeers"}
+	if !reflect.DeepEqual(reloadable, expectedReloadable) {
+		t.Fatalf("Expected reloadable changes %v, but got %v", expectedReloadable, reloadable)
+	}
+	expectedRequireRestart := []string{"proxypass", "rpclisten"}
+	if !reflect.DeepEqual(requireRestart, expectedRequireRestart) {
+		t.Fatalf("Expected changes that require a restart %v, but got %v", expectedRequireRestart, requireRestart)
+	}
+
+	reloadable, requireRestart = newCfg.ChangedOptions(newCfg)
+	if len(reloadable) != 0 || len(requireRestart) != 0 {
+		t.Fatalf("Expected no changes, but got %v and %v", reloadable, requireRestart)
+	}
+
+	// A configuration that fails validation fails the reload
+	writeConfigFile("dbtype=other\n")
+	_, err = cfg.Reload()
+	if err == nil {
+		t.Fatalf("Expected reloading an invalid configuration to fail")
+	}
+}
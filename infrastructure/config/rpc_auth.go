@@ -0,0 +1,153 @@
+package config
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RPCPermission is the level of access of an authenticated RPC client
+type RPCPermission int
+
+const (
+	// RPCPermissionAdmin allows calling all the RPC commands
+	RPCPermissionAdmin RPCPermission = iota
+
+	// RPCPermissionReadOnly allows calling only the RPC commands that read
+	// the state of the node, and not the ones that control it or submit
+	// blocks and transactions to it
+	RPCPermissionReadOnly
+)
+
+var rpcPermissionNames = map[string]RPCPermission{
+	"admin":    RPCPermissionAdmin,
+	"readonly": RPCPermissionReadOnly,
+}
+
+// RPCCredential is a credential that RPC clients may authenticate with: either
+// a bearer token, or a user and password
+type RPCCredential struct {
+	User       string
+	Password   string
+	Token      string
+	Permission RPCPermission
+}
+
+// ID returns an identifier of the credential that can be shown and kept
+// without revealing the credential: the user of a user and password, and a
+// hash of a bearer token
+func (credential *RPCCredential) ID() string {
+	if credential.Token != "" {
+		tokenHash := sha256.Sum256([]byte(credential.Token))
+		return "token:" + hex.EncodeToString(tokenHash[:8])
+	}
+	return "user:" + credential.User
+}
+
+// matches returns whether the given value of the Authorization header
+// presents this credential. Bearer tokens may be presented without the
+// "Bearer " prefix as well.
+func (credential *RPCCredential) matches(authorization string) bool {
+	if credential.Token != "" {
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		return subtle.ConstantTimeCompare([]byte(token), []byte(credential.Token)) == 1
+	}
+
+	const basicPrefix = "Basic "
+	if !strings.HasPrefix(authorization, basicPrefix) {
+		return false
+	}
+	userAndPassword, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(authorization, basicPrefix))
+	if err != nil {
+		return false
+	}
+	expected := credential.User + ":" + credential.Password
+	return subtle.ConstantTimeCompare(userAndPassword, []byte(expected)) == 1
+}
+
+// AuthenticateRPC returns the credential presented by any of the given values
+// of the Authorization header of an RPC client, and false if none of them
+// presents any of the given credentials
+func AuthenticateRPC(credentials []*RPCCredential, authorizations []string) (*RPCCredential, bool) {
+	for _, authorization := range authorizations {
+		for _, credential := range credentials {
+			if credential.matches(authorization) {
+				return credential, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// parseRPCCredential parses an --rpcauth value of the form
+// user=<user>,password=<password>[,permission=<admin|readonly>] or
+// token=<token>[,permission=<admin|readonly>]
+func parseRPCCredential(spec string) (*RPCCredential, error) {
+	credential := &RPCCredential{Permission: RPCPermissionAdmin}
+	for _, field := range strings.Split(spec, ",") {
+		keyAndValue := strings.SplitN(field, "=", 2)
+		if len(keyAndValue) != 2 || keyAndValue[1] == "" {
+			return nil, errors.Errorf("invalid field %q: expected <key>=<value>", field)
+		}
+		key, value := strings.TrimSpace(keyAndValue[0]), strings.TrimSpace(keyAndValue[1])
+		switch key {
+		case "user":
+			if strings.Contains(value, ":") {
+				return nil, errors.Errorf("invalid user %q: users may not contain ':'", value)
+			}
+			credential.User = value
+		case "password":
+			credential.Password = value
+		case "token":
+			credential.Token = value
+		case "permission":
+			permission, ok := rpcPermissionNames[value]
+			if !ok {
+				return nil, errors.Errorf("unknown permission %q: expected admin or readonly", value)
+			}
+			credential.Permission = permission
+		default:
+			return nil, errors.Errorf("unknown field %q", key)
+		}
+	}
+
+	hasUser := credential.User != "" || credential.Password != ""
+	if hasUser && credential.Token != "" {
+		return nil, errors.New("a credential is either a user and password or a token, not both")
+	}
+	if credential.Token == "" && (credential.User == "" || credential.Password == "") {
+		return nil, errors.New("missing a user and password, or a token")
+	}
+	return credential, nil
+}
+
+// parseRPCCredentials parses all the --rpcauth values, and makes sure that no
+// user or token is given more than once
+func (cfg *Config) parseRPCCredentials() error {
+	cfg.RPCCredentials = make([]*RPCCredential, 0, len(cfg.RPCAuth))
+	users := make(map[string]struct{}, len(cfg.RPCAuth))
+	tokens := make(map[string]struct{}, len(cfg.RPCAuth))
+	for _, spec := range cfg.RPCAuth {
+		credential, err := parseRPCCredential(spec)
+		if err != nil {
+			return errors.Wrapf(err, "invalid --rpcauth")
+		}
+		if credential.Token != "" {
+			if _, ok := tokens[credential.Token]; ok {
+				return errors.New("invalid --rpcauth: a token is given more than once")
+			}
+			tokens[credential.Token] = struct{}{}
+		} else {
+			if _, ok := users[credential.User]; ok {
+				return errors.Errorf("invalid --rpcauth: user %s is given more than once", credential.User)
+			}
+			users[credential.User] = struct{}{}
+		}
+		cfg.RPCCredentials = append(cfg.RPCCredentials, credential)
+	}
+	return nil
+}
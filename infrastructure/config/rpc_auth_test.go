@@ -0,0 +1,100 @@
+package config
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestParseRPCCredential(t *testing.T) {
+	credential, err := parseRPCCredential("user=alice,password=secret")
+	if err != nil {
+		t.Fatalf("parseRPCCredential: %s", err)
+	}
+	if credential.User != "alice" || credential.Password != "secret" || credential.Permission != RPCPermissionAdmin {
+		t.Errorf("unexpected credential %+v", credential)
+	}
+
+	credential, err = parseRPCCredential("token=abcd,permission=readonly")
+	if err != nil {
+		t.Fatalf("parseRPCCredential: %s", err)
+	}
+	if credential.Token != "abcd" || credential.Permission != RPCPermissionReadOnly {
+		t.Errorf("unexpected credential %+v", credential)
+	}
+
+	invalidSpecs := []string{
+		"user=alice",
+		"password=secret",
+		"user=al:ice,password=secret",
+		"user=alice,password=secret,token=abcd",
+		"token=abcd,permission=root",
+		"token=",
+		"token=abcd,unknown=1",
+	}
+	for _, spec := range invalidSpecs {
+		_, err := parseRPCCredential(spec)
+		if err == nil {
+			t.Errorf("expected %q to be invalid", spec)
+		}
+	}
+}
+
+func TestAuthenticateRPC(t *testing.T) {
+	admin := &RPCCredential{User: "alice", Password: "secret", Permission: RPCPermissionAdmin}
+	readOnly := &RPCCredential{Token: "abcd", Permission: RPCPermissionReadOnly}
+	credentials := []*RPCCredential{admin, readOnly}
+
+	basic := func(userAndPassword string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(userAndPassword))
+	}
+	tests := []struct {
+		authorizations []string
+		expected       *RPCCredential
+	}{
+		{authorizations: []string{basic("alice:secret")}, expected: admin},
+		{authorizations: []string{"Bearer abcd"}, expected: readOnly},
+		{authorizations: []string{"abcd"}, expected: readOnly},
+		{authorizations: []string{"Bearer wrong", basic("alice:secret")}, expected: admin},
+		{authorizations: []string{basic("alice:wrong")}, expected: nil},
+		{authorizations: []string{basic("alice")}, expected: nil},
+		{authorizations: []string{"Basic !!!"}, expected: nil},
+		{authorizations: nil, expected: nil},
+	}
+	for _, test := range tests {
+		credential, ok := AuthenticateRPC(credentials, test.authorizations)
+		if ok != (test.expected != nil) || credential != test.expected {
+			t.Errorf("authorizations %v: expected %+v, got %+v", test.authorizations, test.expected, credential)
+		}
+	}
+}
+
+func TestRPCCredentialID(t *testing.T) {
+	user := &RPCCredential{User: "alice", Password: "secret"}
+	if user.ID() != "user:alice" {
+		t.Errorf("unexpected ID %q of a user credential", user.ID())
+	}
+	token := &RPCCredential{Token: "abcd"}
+	otherToken := &RPCCredential{Token: "abce"}
+	if strings.Contains(token.ID(), "abcd") {
+		t.Errorf("ID %q reveals the token", token.ID())
+	}
+	if token.ID() == otherToken.ID() {
+		t.Errorf("different tokens have the same ID %q", token.ID())
+	}
+}
+
+func TestParseRPCCredentialsConflicts(t *testing.T) {
+	cfg := &Config{Flags: defaultFlags()}
+	cfg.RPCAuth = []string{"user=alice,password=a", "user=alice,password=b"}
+	err := cfg.parseRPCCredentials()
+	if err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Errorf("expected a user conflict, got: %v", err)
+	}
+
+	cfg.RPCAuth = []string{"token=abcd", "token=abcd,permission=readonly"}
+	err = cfg.parseRPCCredentials()
+	if err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Errorf("expected a token conflict, got: %v", err)
+	}
+}
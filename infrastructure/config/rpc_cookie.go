@@ -0,0 +1,35 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// RPCCookieFilename is the name of the RPC auth cookie file in the network
+// directory of the appdir, unless --rpccookiefile says otherwise
+const RPCCookieFilename = ".cookie"
+
+// DefaultRPCCookieFile returns the path of the RPC auth cookie file of a node
+// that runs the network with the given name in the default appdir
+func DefaultRPCCookieFile(networkName string) string {
+	return filepath.Join(DefaultAppDir, networkName, RPCCookieFilename)
+}
+
+// parseRPCCookie validates --rpccookie and resolves --rpccookiefile
+func (cfg *Config) parseRPCCookie() error {
+	if cfg.RPCCookieFile != "" && !cfg.RPCCookie {
+		return errors.New("--rpccookiefile requires --rpccookie")
+	}
+	if !cfg.RPCCookie {
+		return nil
+	}
+	if cfg.DisableRPC {
+		return errors.New("--rpccookie and --norpc are mutually exclusive")
+	}
+	if cfg.RPCCookieFile == "" {
+		cfg.RPCCookieFile = filepath.Join(cfg.AppDir, RPCCookieFilename)
+	}
+	cfg.RPCCookieFile = cleanAndExpandPath(cfg.RPCCookieFile)
+	return nil
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/util/network"
+	"github.com/pkg/errors"
+)
+
+// RPCEndpoint is a logical RPC endpoint, served on its own listeners. Each
+// endpoint restricts its clients independently of the others, so that a
+// single node may serve, for example, both a public read-only API and a
+// private admin API.
+type RPCEndpoint struct {
+	Name       string
+	Listeners  []string
+	AuthToken  string
+	MaxClients int
+
+	// RateLimit is the maximum amount of requests per second a single client
+	// may make. Zero means unlimited.
+	RateLimit float64
+
+	// AllowedMethods are the request commands clients of this endpoint may
+	// call. An empty set allows all of them.
+	AllowedMethods map[appmessage.MessageCommand]struct{}
+}
+
+// IsMethodAllowed returns whether the given request command may be called
+// through this endpoint. Negotiating the RPC API version is always allowed,
+// since it only affects the connection it's made on.
+func (endpoint *RPCEndpoint) IsMethodAllowed(requestCommand appmessage.MessageCommand) bool {
+	if len(endpoint.AllowedMethods) == 0 || requestCommand == appmessage.CmdNegotiateAPIVersionRequestMessage {
+		return true
+	}
+	_, ok := endpoint.AllowedMethods[requestCommand]
+	return ok
+}
+
+// parseRPCEndpoint parses an --rpcendpoint value of the form
+// name=<name>,listen=<address>[,listen=<address>...][,authtoken=<token>]
+// [,ratelimit=<requests per second>][,maxclients=<count>][,method=<method>...]
+func parseRPCEndpoint(spec string, defaultPort string, defaultMaxClients int) (*RPCEndpoint, error) {
+	endpoint := &RPCEndpoint{MaxClients: defaultMaxClients}
+	for _, field := range strings.Split(spec, ",") {
+		keyAndValue := strings.SplitN(field, "=", 2)
+		if len(keyAndValue) != 2 || keyAndValue[1] == "" {
+			return nil, errors.Errorf("invalid field %q: expected <key>=<value>", field)
+		}
+		key, value := strings.TrimSpace(keyAndValue[0]), strings.TrimSpace(keyAndValue[1])
+		switch key {
+		case "name":
+			endpoint.Name = value
+		case "listen":
+			endpoint.Listeners = append(endpoint.Listeners, value)
+		case "authtoken":
+			endpoint.AuthToken = value
+		case "ratelimit":
+			rateLimit, err := strconv.ParseFloat(value, 64)
+			if err != nil || rateLimit <= 0 {
+				return nil, errors.Errorf("invalid ratelimit %q: expected a positive number of requests per second", value)
+			}
+			endpoint.RateLimit = rateLimit
+		case "maxclients":
+			maxClients, err := strconv.Atoi(value)
+			if err != nil || maxClients <= 0 {
+				return nil, errors.Errorf("invalid maxclients %q: expected a positive number", value)
+			}
+			endpoint.MaxClients = maxClients
+		case "method":
+			requestCommand, ok := rpcRequestCommandByName(value)
+			if !ok {
+				return nil, errors.Errorf("unknown RPC method %q", value)
+			}
+			if endpoint.AllowedMethods == nil {
+				endpoint.AllowedMethods = make(map[appmessage.MessageCommand]struct{})
+			}
+			endpoint.AllowedMethods[requestCommand] = struct{}{}
+		default:
+			return nil, errors.Errorf("unknown field %q", key)
+		}
+	}
+
+	if endpoint.Name == "" {
+		return nil, errors.New("missing name")
+	}
+	if len(endpoint.Listeners) == 0 {
+		return nil, errors.Errorf("endpoint %s has no listen addresses", endpoint.Name)
+	}
+	var err error
+	endpoint.Listeners, err = network.NormalizeAddresses(endpoint.Listeners, defaultPort)
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// rpcRequestCommandByName returns the request command of the RPC method with
+// the given name. The name is matched case-insensitively, with or without the
+// "Request" suffix, so that both "getInfo" and "GetInfoRequest" are accepted.
+func rpcRequestCommandByName(name string) (appmessage.MessageCommand, bool) {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, "request") {
+		name += "request"
+	}
+	for command, commandName := range appmessage.RPCMessageCommandToString {
+		if strings.ToLower(commandName) == name {
+			return command, true
+		}
+	}
+	return 0, false
+}
+
+// parseRPCEndpoints parses all the --rpcendpoint values, and makes sure that
+// every endpoint has a unique name and that no address is listened on twice
+func (cfg *Config) parseRPCEndpoints() error {
+	listenerOwners := make(map[string]string, len(cfg.RPCListeners))
+	for _, listener := range cfg.RPCListeners {
+		listenerOwners[listener] = "--rpclisten"
+	}
+
+	cfg.RPCEndpoints = make([]*RPCEndpoint, 0, len(cfg.RPCEndpointSpecs))
+	names := make(map[string]struct{}, len(cfg.RPCEndpointSpecs))
+	for _, spec := range cfg.RPCEndpointSpecs {
+		endpoint, err := parseRPCEndpoint(spec, cfg.NetParams().RPCPort, cfg.RPCMaxClients)
+		if err != nil {
+			return errors.Wrapf(err, "invalid --rpcendpoint")
+		}
+		if _, ok := names[endpoint.Name]; ok {
+			return errors.Errorf("more than one RPC endpoint is named %s", endpoint.Name)
+		}
+		names[endpoint.Name] = struct{}{}
+
+		for _, listener := range endpoint.Listeners {
+			if owner, ok := listenerOwners[listener]; ok {
+				return errors.Errorf("RPC endpoint %s listens on %s, which is already used by %s",
+					endpoint.Name, listener, owner)
+			}
+			listenerOwners[listener] = "RPC endpoint " + endpoint.Name
+		}
+		cfg.RPCEndpoints = append(cfg.RPCEndpoints, endpoint)
+	}
+	return nil
+}
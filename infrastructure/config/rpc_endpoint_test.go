@@ -0,0 +1,79 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestParseRPCEndpoint(t *testing.T) {
+	endpoint, err := parseRPCEndpoint("name=public,listen=0.0.0.0,listen=127.0.0.1:17110,ratelimit=2.5,"+
+		"method=getInfo,method=GetBlockRequest", "16110", 128)
+	if err != nil {
+		t.Fatalf("parseRPCEndpoint: %s", err)
+	}
+	if endpoint.Name != "public" {
+		t.Errorf("unexpected name %s", endpoint.Name)
+	}
+	if strings.Join(endpoint.Listeners, ",") != "0.0.0.0:16110,127.0.0.1:17110" {
+		t.Errorf("unexpected listeners %v", endpoint.Listeners)
+	}
+	if endpoint.RateLimit != 2.5 || endpoint.MaxClients != 128 || endpoint.AuthToken != "" {
+		t.Errorf("unexpected restrictions %+v", endpoint)
+	}
+	if !endpoint.IsMethodAllowed(appmessage.CmdGetInfoRequestMessage) ||
+		!endpoint.IsMethodAllowed(appmessage.CmdGetBlockRequestMessage) {
+		t.Errorf("listed methods are not allowed")
+	}
+	if endpoint.IsMethodAllowed(appmessage.CmdShutDownRequestMessage) {
+		t.Errorf("unlisted method is allowed")
+	}
+
+	endpoint, err = parseRPCEndpoint("name=admin,listen=127.0.0.1,authtoken=secret,maxclients=4", "16110", 128)
+	if err != nil {
+		t.Fatalf("parseRPCEndpoint: %s", err)
+	}
+	if endpoint.AuthToken != "secret" || endpoint.MaxClients != 4 {
+		t.Errorf("unexpected restrictions %+v", endpoint)
+	}
+	if !endpoint.IsMethodAllowed(appmessage.CmdShutDownRequestMessage) {
+		t.Errorf("an endpoint without methods doesn't allow all methods")
+	}
+
+	invalidSpecs := []string{
+		"listen=127.0.0.1",
+		"name=noListeners",
+		"name=x,listen=127.0.0.1,method=noSuchMethod",
+		"name=x,listen=127.0.0.1,ratelimit=0",
+		"name=x,listen=127.0.0.1,maxclients=-1",
+		"name=x,listen=127.0.0.1,unknown=1",
+		"name=x,listen=127.0.0.1,authtoken=",
+	}
+	for _, spec := range invalidSpecs {
+		_, err := parseRPCEndpoint(spec, "16110", 128)
+		if err == nil {
+			t.Errorf("expected %q to be invalid", spec)
+		}
+	}
+}
+
+func TestParseRPCEndpointsConflicts(t *testing.T) {
+	cfgFlags := defaultFlags()
+	cfgFlags.ActiveNetParams = &dagconfig.MainnetParams
+	cfgFlags.RPCListeners = []string{"127.0.0.1:16110"}
+	cfgFlags.RPCEndpointSpecs = []string{"name=admin,listen=127.0.0.1"}
+	cfg := &Config{Flags: cfgFlags}
+	err := cfg.parseRPCEndpoints()
+	if err == nil || !strings.Contains(err.Error(), "already used by --rpclisten") {
+		t.Errorf("expected a listener conflict, got: %v", err)
+	}
+
+	cfgFlags.RPCListeners = nil
+	cfgFlags.RPCEndpointSpecs = []string{"name=a,listen=127.0.0.1:1", "name=a,listen=127.0.0.1:2"}
+	err = cfg.parseRPCEndpoints()
+	if err == nil || !strings.Contains(err.Error(), "more than one") {
+		t.Errorf("expected a name conflict, got: %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// parseRPCUnixSocket validates --rpcunixsocket and parses --rpcunixsocketmode
+func (cfg *Config) parseRPCUnixSocket() error {
+	if cfg.RPCUnixSocket == "" {
+		return nil
+	}
+	if cfg.DisableRPC {
+		return errors.New("--rpcunixsocket and --norpc are mutually exclusive")
+	}
+	cfg.RPCUnixSocket = cleanAndExpandPath(cfg.RPCUnixSocket)
+
+	mode, err := strconv.ParseUint(cfg.RPCUnixSocketMode, 8, 32)
+	if err != nil || os.FileMode(mode)&^os.ModePerm != 0 {
+		return errors.Errorf("invalid --rpcunixsocketmode %q: expected octal file permissions such as 0660",
+			cfg.RPCUnixSocketMode)
+	}
+	cfg.RPCUnixSocketFileMode = os.FileMode(mode)
+	return nil
+}
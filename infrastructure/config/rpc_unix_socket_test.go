@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseRPCUnixSocket(t *testing.T) {
+	tests := []struct {
+		mode         string
+		disableRPC   bool
+		expectedMode os.FileMode
+		expectsError bool
+	}{
+		{mode: defaultRPCUnixSocketMode, expectedMode: 0600},
+		{mode: "660", expectedMode: 0660},
+		{mode: "0777", expectedMode: 0777},
+		{mode: "0644", disableRPC: true, expectsError: true},
+		{mode: "rw-------", expectsError: true},
+		{mode: "0999", expectsError: true},
+		{mode: "17777", expectsError: true},
+	}
+	for _, test := range tests {
+		cfg := &Config{Flags: &Flags{
+			RPCUnixSocket:     "/tmp/kaspad.sock",
+			RPCUnixSocketMode: test.mode,
+			DisableRPC:        test.disableRPC,
+		}}
+		err := cfg.parseRPCUnixSocket()
+		if test.expectsError {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.mode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: parseRPCUnixSocket: %s", test.mode, err)
+			continue
+		}
+		if cfg.RPCUnixSocketFileMode != test.expectedMode {
+			t.Errorf("%s: expected mode %s, but got %s", test.mode, test.expectedMode, cfg.RPCUnixSocketFileMode)
+		}
+	}
+}
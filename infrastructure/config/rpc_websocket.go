@@ -0,0 +1,21 @@
+package config
+
+import (
+	"github.com/kaspanet/kaspad/util/network"
+	"github.com/pkg/errors"
+)
+
+// parseRPCWebSocketListeners validates --rpcwslisten and adds the default
+// WebSocket RPC port to the listeners that don't specify one
+func (cfg *Config) parseRPCWebSocketListeners() error {
+	if len(cfg.RPCWebSocketListeners) == 0 {
+		return nil
+	}
+	if cfg.DisableRPC {
+		return errors.New("--rpcwslisten and --norpc are mutually exclusive")
+	}
+	var err error
+	cfg.RPCWebSocketListeners, err = network.NormalizeAddresses(cfg.RPCWebSocketListeners,
+		cfg.NetParams().RPCWebSocketPort)
+	return err
+}
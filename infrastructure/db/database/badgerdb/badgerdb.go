@@ -0,0 +1,111 @@
+package badgerdb
+
+import (
+	"runtime"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+const mib = 1024 * 1024
+
+// BadgerDB defines a thin wrapper around badger.
+type BadgerDB struct {
+	db *badger.DB
+}
+
+// NewBadgerDB opens a badger instance defined by the given path.
+func NewBadgerDB(path string, cacheSizeMiB int) (*BadgerDB, error) {
+	// Open badger. If it doesn't exist, create it.
+	options := Options(path)
+	options = options.WithBlockCacheSize(int64(cacheSizeMiB) * mib)
+	db, err := badger.Open(options)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &BadgerDB{
+		db: db,
+	}, nil
+}
+
+// Compact compacts the badger instance, and then garbage collects its
+// value log.
+func (db *BadgerDB) Compact() error {
+	err := db.db.Flatten(runtime.NumCPU())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for {
+		err := db.db.RunValueLogGC(valueLogGCDiscardRatio)
+		if errors.Is(err, badger.ErrNoRewrite) {
+			return nil
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+}
+
+// Close closes the badger instance.
+func (db *BadgerDB) Close() error {
+	err := db.db.Close()
+	return errors.WithStack(err)
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *BadgerDB) Put(key *database.Key, value []byte) error {
+	err := db.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key.Bytes(), value)
+	})
+	return errors.WithStack(err)
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *BadgerDB) Get(key *database.Key) ([]byte, error) {
+	var data []byte
+	err := db.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key.Bytes())
+		if err != nil {
+			return err
+		}
+		data, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return nil, errors.Wrapf(database.ErrNotFound,
+				"key %s not found", key)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return data, nil
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *BadgerDB) Has(key *database.Key) (bool, error) {
+	err := db.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(key.Bytes())
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *BadgerDB) Delete(key *database.Key) error {
+	err := db.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key.Bytes())
+	})
+	return errors.WithStack(err)
+}
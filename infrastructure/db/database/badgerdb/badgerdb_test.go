@@ -0,0 +1,110 @@
+package badgerdb
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+func TestBadgerDBReopen(t *testing.T) {
+	path := t.TempDir()
+	db, err := NewBadgerDB(path, 8)
+	if err != nil {
+		t.Fatalf("NewBadgerDB unexpectedly failed: %s", err)
+	}
+
+	// Put a few keys in a transaction, reusing the same buffers for all of
+	// them, as callers may do
+	bucket := database.MakeBucket([]byte("bucket"))
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin unexpectedly failed: %s", err)
+	}
+	keyBuffer := make([]byte, 4)
+	valueBuffer := make([]byte, 6)
+	for i := 0; i < 10; i++ {
+		copy(keyBuffer, fmt.Sprintf("key%d", i))
+		copy(valueBuffer, fmt.Sprintf("value%d", i))
+		err = tx.Put(bucket.Key(keyBuffer), valueBuffer)
+		if err != nil {
+			t.Fatalf("Put unexpectedly failed: %s", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf("Commit unexpectedly failed: %s", err)
+	}
+	err = db.Compact()
+	if err != nil {
+		t.Fatalf("Compact unexpectedly failed: %s", err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close unexpectedly failed: %s", err)
+	}
+
+	// Everything that was committed is there once the database is reopened
+	db, err = NewBadgerDB(path, 8)
+	if err != nil {
+		t.Fatalf("NewBadgerDB unexpectedly failed: %s", err)
+	}
+	defer db.Close()
+	for i := 0; i < 10; i++ {
+		value, err := db.Get(bucket.Key([]byte(fmt.Sprintf("key%d", i))))
+		if err != nil {
+			t.Fatalf("Get unexpectedly failed: %s", err)
+		}
+		expectedValue := []byte(fmt.Sprintf("value%d", i))
+		if !reflect.DeepEqual(value, expectedValue) {
+			t.Fatalf("Expected %s, but got %s", expectedValue, value)
+		}
+	}
+}
+
+func TestBadgerDBTransactionTooBig(t *testing.T) {
+	// A small memtable makes for a small transaction size limit
+	badgerDB, err := badger.Open(Options(t.TempDir()).WithMemTableSize(mib).WithValueThreshold(1024))
+	if err != nil {
+		t.Fatalf("badger.Open unexpectedly failed: %s", err)
+	}
+	db := &BadgerDB{db: badgerDB}
+	defer db.Close()
+
+	bucket := database.MakeBucket([]byte("bucket"))
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin unexpectedly failed: %s", err)
+	}
+	defer tx.RollbackUnlessClosed()
+	value := make([]byte, 512)
+	for i := 0; ; i++ {
+		err = tx.Put(bucket.Key([]byte(fmt.Sprintf("key%d", i))), value)
+		if err != nil {
+			break
+		}
+		if i == mib {
+			t.Fatalf("Expected the transaction to get too big")
+		}
+	}
+	if !errors.Is(err, ErrTransactionTooBig) {
+		t.Fatalf("Expected Put to fail with ErrTransactionTooBig, but got: %s", err)
+	}
+
+	// Committing the changes that were made before the transaction got too
+	// big would commit only a part of it
+	err = tx.Commit()
+	if !errors.Is(err, ErrTransactionTooBig) {
+		t.Fatalf("Expected Commit to fail with ErrTransactionTooBig, but got: %s", err)
+	}
+	has, err := db.Has(bucket.Key([]byte("key0")))
+	if err != nil {
+		t.Fatalf("Has unexpectedly failed: %s", err)
+	}
+	if has {
+		t.Fatalf("Expected the database to be left unchanged")
+	}
+}
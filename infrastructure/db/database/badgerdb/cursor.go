@@ -0,0 +1,136 @@
+package badgerdb
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// BadgerDBCursor is a thin wrapper around native badger iterators.
+type BadgerDBCursor struct {
+	txn      *badger.Txn
+	iterator *badger.Iterator
+	bucket   *database.Bucket
+
+	// A badger iterator is positioned at its first item once it's created,
+	// while a cursor is positioned before it until Next or First are called
+	isStarted bool
+	isClosed  bool
+}
+
+// Cursor begins a new cursor over the given prefix.
+func (db *BadgerDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	txn := db.db.NewTransaction(false)
+	iteratorOptions := badger.DefaultIteratorOptions
+	iteratorOptions.Prefix = bucket.Path()
+	iteratorOptions.PrefetchValues = false
+
+	return &BadgerDBCursor{
+		txn:       txn,
+		iterator:  txn.NewIterator(iteratorOptions),
+		bucket:    bucket,
+		isStarted: false,
+		isClosed:  false,
+	}, nil
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted. Panics if the cursor is closed.
+func (c *BadgerDBCursor) Next() bool {
+	if c.isClosed {
+		panic("cannot call next on a closed cursor")
+	}
+	if !c.isStarted {
+		return c.First()
+	}
+	if !c.iterator.Valid() {
+		return false
+	}
+	c.iterator.Next()
+	return c.iterator.Valid()
+}
+
+// First moves the iterator to the first key/value pair. It returns false if
+// such a pair does not exist. Panics if the cursor is closed.
+func (c *BadgerDBCursor) First() bool {
+	if c.isClosed {
+		panic("cannot call first on a closed cursor")
+	}
+	c.isStarted = true
+	c.iterator.Rewind()
+	return c.iterator.Valid()
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key. It returns ErrNotFound if such pair does not
+// exist.
+func (c *BadgerDBCursor) Seek(key *database.Key) error {
+	if c.isClosed {
+		return errors.New("cannot seek a closed cursor")
+	}
+
+	c.isStarted = true
+	c.iterator.Seek(key.Bytes())
+	if !c.iterator.Valid() {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+
+	// Use the key of the iterator because c.Key removes the prefix from the key
+	currentKey := c.iterator.Item().Key()
+	if !bytes.Equal(currentKey, key.Bytes()) {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+
+	return nil
+}
+
+// Key returns the key of the current key/value pair, or ErrNotFound if done.
+// Note that the key is trimmed to not include the prefix the cursor was opened
+// with. The caller should not modify the contents of the returned slice, and
+// its contents may change on the next call to Next.
+func (c *BadgerDBCursor) Key() (*database.Key, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the key of a closed cursor")
+	}
+	if !c.isStarted || !c.iterator.Valid() {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"key of an exhausted cursor")
+	}
+	fullKeyPath := c.iterator.Item().KeyCopy(nil)
+	suffix := bytes.TrimPrefix(fullKeyPath, c.bucket.Path())
+	return c.bucket.Key(suffix), nil
+}
+
+// Value returns the value of the current key/value pair, or ErrNotFound if done.
+// The caller should not modify the contents of the returned slice, and its
+// contents may change on the next call to Next.
+func (c *BadgerDBCursor) Value() ([]byte, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the value of a closed cursor")
+	}
+	if !c.isStarted || !c.iterator.Valid() {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"value of an exhausted cursor")
+	}
+	value, err := c.iterator.Item().ValueCopy(nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return value, nil
+}
+
+// Close releases associated resources.
+func (c *BadgerDBCursor) Close() error {
+	if c.isClosed {
+		return errors.New("cannot close an already closed cursor")
+	}
+	c.isClosed = true
+	c.iterator.Close()
+	c.txn.Discard()
+	c.iterator = nil
+	c.txn = nil
+	c.bucket = nil
+	return nil
+}
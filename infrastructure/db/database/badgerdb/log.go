@@ -0,0 +1,28 @@
+package badgerdb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("KSDB")
+
+// badgerLogger forwards the logs of badger to the KSDB subsystem. Badger
+// logs every compaction and flush at its info level, so those are logged
+// one level lower here.
+type badgerLogger struct{}
+
+func (badgerLogger) Errorf(format string, params ...interface{}) {
+	log.Errorf(format, params...)
+}
+
+func (badgerLogger) Warningf(format string, params ...interface{}) {
+	log.Warnf(format, params...)
+}
+
+func (badgerLogger) Infof(format string, params ...interface{}) {
+	log.Debugf(format, params...)
+}
+
+func (badgerLogger) Debugf(format string, params ...interface{}) {
+	log.Tracef(format, params...)
+}
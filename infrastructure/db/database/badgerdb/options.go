@@ -0,0 +1,28 @@
+package badgerdb
+
+import (
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/badger/v3/options"
+)
+
+// valueLogGCDiscardRatio is the ratio of stale data a value log file must
+// have for Compact to rewrite it
+const valueLogGCDiscardRatio = 0.5
+
+// Options is a function that returns the badger options for opening a
+// database at the given path.
+//
+// A database transaction is committed as a single badger transaction, which
+// may be at most 15% of a memtable, so the memtables are large enough for the
+// largest transactions kaspad commits, such as the ones of pruning. A
+// transaction beyond that limit fails with ErrTransactionTooBig, since
+// splitting it would leave the consensus data inconsistent if kaspad stopped
+// in between its parts.
+func Options(path string) badger.Options {
+	return badger.DefaultOptions(path).
+		WithLogger(badgerLogger{}).
+		WithCompression(options.None).
+		WithSyncWrites(false).
+		WithDetectConflicts(false).
+		WithMemTableSize(256 * mib)
+}
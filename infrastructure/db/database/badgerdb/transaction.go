@@ -0,0 +1,149 @@
+package badgerdb
+
+import (
+	"github.com/dgraph-io/badger/v3"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// BadgerDBTransaction is a thin wrapper around native badger
+// transactions. It supports both get and put.
+//
+// Note that reads are done from the Database directly, so if another transaction changed the data,
+// you will read the new data, and not the one from the time the transaction was opened. This is the
+// same as it is with LevelDBTransaction.
+//
+// Note: As it's currently implemented, if one puts data into the transaction
+// then it will not be available to get within the same transaction.
+//
+// A transaction is committed as a single badger transaction, so that it's
+// atomic, which limits its size (see Options). A transaction that exceeds the
+// limit can't be committed at all, rather than being committed in parts.
+type BadgerDBTransaction struct {
+	db       *BadgerDB
+	txn      *badger.Txn
+	isClosed bool
+	isTooBig bool
+}
+
+// ErrTransactionTooBig indicates that a transaction exceeds the size of a
+// badger transaction, and so can't be committed atomically
+var ErrTransactionTooBig = errors.New("the database transaction is too big for a badger database, which " +
+	"can't commit it atomically. The database was left unchanged. Consider running kaspad with " +
+	"--dbtype=leveldb instead")
+
+// Begin begins a new transaction.
+func (db *BadgerDB) Begin() (database.Transaction, error) {
+	transaction := &BadgerDBTransaction{
+		db:       db,
+		txn:      db.db.NewTransaction(true),
+		isClosed: false,
+	}
+	return transaction, nil
+}
+
+// Commit commits whatever changes were made to the database
+// within this transaction.
+func (tx *BadgerDBTransaction) Commit() error {
+	if tx.isClosed {
+		return errors.New("cannot commit a closed transaction")
+	}
+	if tx.isTooBig {
+		// Committing would write only the changes that were made before the
+		// transaction got too big
+		return errors.WithStack(ErrTransactionTooBig)
+	}
+
+	tx.isClosed = true
+	return tx.wrapError(tx.txn.Commit())
+}
+
+// Rollback rolls back whatever changes were made to the
+// database within this transaction.
+func (tx *BadgerDBTransaction) Rollback() error {
+	if tx.isClosed {
+		return errors.New("cannot rollback a closed transaction")
+	}
+
+	tx.isClosed = true
+	tx.txn.Discard()
+	return nil
+}
+
+// RollbackUnlessClosed rolls back changes that were made to
+// the database within the transaction, unless the transaction
+// had already been closed using either Rollback or Commit.
+func (tx *BadgerDBTransaction) RollbackUnlessClosed() error {
+	if tx.isClosed {
+		return nil
+	}
+	return tx.Rollback()
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (tx *BadgerDBTransaction) Put(key *database.Key, value []byte) error {
+	if tx.isClosed {
+		return errors.New("cannot put into a closed transaction")
+	}
+
+	// Badger keeps the given slices until the transaction is committed, while
+	// the callers may reuse them right away
+	err := tx.txn.Set(copyBytes(key.Bytes()), copyBytes(value))
+	return tx.wrapError(err)
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (tx *BadgerDBTransaction) Get(key *database.Key) ([]byte, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot get from a closed transaction")
+	}
+	return tx.db.Get(key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (tx *BadgerDBTransaction) Has(key *database.Key) (bool, error) {
+	if tx.isClosed {
+		return false, errors.New("cannot has from a closed transaction")
+	}
+	return tx.db.Has(key)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (tx *BadgerDBTransaction) Delete(key *database.Key) error {
+	if tx.isClosed {
+		return errors.New("cannot delete from a closed transaction")
+	}
+
+	err := tx.txn.Delete(copyBytes(key.Bytes()))
+	return tx.wrapError(err)
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (tx *BadgerDBTransaction) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot open a cursor from a closed transaction")
+	}
+
+	return tx.db.Cursor(bucket)
+}
+
+// wrapError replaces badger.ErrTxnTooBig with ErrTransactionTooBig, which
+// explains what it means for kaspad, and marks the transaction as too big
+// to be committed
+func (tx *BadgerDBTransaction) wrapError(err error) error {
+	if errors.Is(err, badger.ErrTxnTooBig) {
+		tx.isTooBig = true
+		return errors.Wrapf(ErrTransactionTooBig, "%s", err)
+	}
+	return errors.WithStack(err)
+}
+
+func copyBytes(bytes []byte) []byte {
+	copied := make([]byte, len(bytes))
+	copy(copied, bytes)
+	return copied
+}
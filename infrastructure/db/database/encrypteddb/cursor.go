@@ -0,0 +1,82 @@
+package encrypteddb
+
+import (
+	"bytes"
+	"crypto/cipher"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+type encryptedCursor struct {
+	cursor database.Cursor
+	aead   cipher.AEAD
+}
+
+func newCursor(dataAccessor database.DataAccessor, aead cipher.AEAD, bucket *database.Bucket) (database.Cursor, error) {
+	cursor, err := dataAccessor.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedCursor{cursor: cursor, aead: aead}, nil
+}
+
+// skipParameters moves the cursor past the encryption parameters, which are
+// not part of the encrypted data
+func (c *encryptedCursor) skipParameters(hasPair bool) bool {
+	for hasPair && c.isAtParameters() {
+		hasPair = c.cursor.Next()
+	}
+	return hasPair
+}
+
+func (c *encryptedCursor) isAtParameters() bool {
+	key, err := c.cursor.Key()
+	return err == nil && bytes.Equal(key.Bytes(), parametersKey.Bytes())
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted. Panics if the cursor is closed.
+func (c *encryptedCursor) Next() bool {
+	return c.skipParameters(c.cursor.Next())
+}
+
+// First moves the iterator to the first key/value pair. It returns false if
+// such a pair does not exist. Panics if the cursor is closed.
+func (c *encryptedCursor) First() bool {
+	return c.skipParameters(c.cursor.First())
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key. It returns ErrNotFound if such pair does not
+// exist.
+func (c *encryptedCursor) Seek(key *database.Key) error {
+	if bytes.Equal(key.Bytes(), parametersKey.Bytes()) {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+	return c.cursor.Seek(key)
+}
+
+// Key returns the key of the current key/value pair, or ErrNotFound if done.
+func (c *encryptedCursor) Key() (*database.Key, error) {
+	return c.cursor.Key()
+}
+
+// Value returns the decrypted value of the current key/value pair, or
+// ErrNotFound if done.
+func (c *encryptedCursor) Value() ([]byte, error) {
+	key, err := c.cursor.Key()
+	if err != nil {
+		return nil, err
+	}
+	sealedValue, err := c.cursor.Value()
+	if err != nil {
+		return nil, err
+	}
+	return open(c.aead, key, sealedValue)
+}
+
+// Close releases associated resources.
+func (c *encryptedCursor) Close() error {
+	return c.cursor.Close()
+}
@@ -0,0 +1,214 @@
+package encrypteddb
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// parametersKey is where the salt of the encryption key, and a value that
+// verifies the passphrase, are kept. It's stored unencrypted, since it's
+// needed in order to derive the key.
+var parametersKey = database.MakeBucket([]byte("")).Key([]byte("database-encryption-parameters"))
+
+// verificationValue is encrypted into the parameters, so that a wrong
+// passphrase is detected when the database is opened rather than on the
+// first read
+var verificationValue = []byte("kaspad-database-encryption")
+
+const (
+	saltSize      = 16
+	argon2Time    = 1
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	keySize       = chacha20poly1305.KeySize
+)
+
+// ErrWrongPassphrase denotes that the database was encrypted with a
+// different passphrase than the one it's being opened with.
+var ErrWrongPassphrase = errors.New("wrong database encryption passphrase")
+
+// EncryptedDB is a database.Database that encrypts all the values written
+// to the database it wraps with XChaCha20-Poly1305. Keys are stored as is,
+// since their order and prefixes are what buckets and cursors rely on.
+// Each value is bound to its key, so values can't be swapped between keys
+// without being detected.
+type EncryptedDB struct {
+	db   database.Database
+	aead cipher.AEAD
+}
+
+// New wraps the given database with encryption, using a key that's derived
+// from the given passphrase. A new database is set up for encryption with the
+// passphrase, while an existing one must have been encrypted with the same one.
+// Encrypting an existing database that isn't encrypted is not supported.
+func New(db database.Database, passphrase []byte) (*EncryptedDB, error) {
+	parameters, err := db.Get(parametersKey)
+	if database.IsNotFoundError(err) {
+		return setUp(db, passphrase)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parameters) < saltSize {
+		return nil, errors.Errorf("malformed database encryption parameters")
+	}
+	salt, sealedVerificationValue := parameters[:saltSize], parameters[saltSize:]
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	decryptedVerificationValue, err := open(aead, parametersKey, sealedVerificationValue)
+	if err != nil || !bytes.Equal(decryptedVerificationValue, verificationValue) {
+		return nil, errors.WithStack(ErrWrongPassphrase)
+	}
+	return &EncryptedDB{db: db, aead: aead}, nil
+}
+
+func setUp(db database.Database, passphrase []byte) (*EncryptedDB, error) {
+	isEmpty, err := isEmpty(db)
+	if err != nil {
+		return nil, err
+	}
+	if !isEmpty {
+		return nil, errors.New("the database already exists and isn't encrypted. " +
+			"Encrypting an existing database is not supported, so it has to be recreated " +
+			"with --reset-db")
+	}
+
+	salt := make([]byte, saltSize)
+	_, err = rand.Read(salt)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	sealedVerificationValue, err := seal(aead, parametersKey, verificationValue)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Put(parametersKey, append(salt, sealedVerificationValue...))
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedDB{db: db, aead: aead}, nil
+}
+
+// IsEncrypted returns whether the given database was set up for encryption
+// by New
+func IsEncrypted(db database.Database) (bool, error) {
+	return db.Has(parametersKey)
+}
+
+func isEmpty(db database.Database) (bool, error) {
+	cursor, err := db.Cursor(database.MakeBucket(nil))
+	if err != nil {
+		return false, err
+	}
+	defer cursor.Close()
+
+	return !cursor.First(), nil
+}
+
+func newAEAD(passphrase, salt []byte) (cipher.AEAD, error) {
+	key := argon2.IDKey(passphrase, salt, argon2Time, argon2Memory, argon2Threads, keySize)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return aead, nil
+}
+
+// seal encrypts the given value with a random nonce, which is prepended to it
+func seal(aead cipher.AEAD, key *database.Key, value []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return aead.Seal(nonce, nonce, value, key.Bytes()), nil
+}
+
+func open(aead cipher.AEAD, key *database.Key, sealedValue []byte) ([]byte, error) {
+	if len(sealedValue) < aead.NonceSize() {
+		return nil, errors.Errorf("the encrypted value of key %s is too short", key)
+	}
+	nonce, ciphertext := sealedValue[:aead.NonceSize()], sealedValue[aead.NonceSize():]
+	value, err := aead.Open(nil, nonce, ciphertext, key.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed decrypting the value of key %s", key)
+	}
+	return value, nil
+}
+
+// Begin begins a new database transaction.
+func (db *EncryptedDB) Begin() (database.Transaction, error) {
+	transaction, err := db.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedTransaction{transaction: transaction, aead: db.aead}, nil
+}
+
+// Compact compacts the database instance.
+func (db *EncryptedDB) Compact() error {
+	return db.db.Compact()
+}
+
+// Close closes the database.
+func (db *EncryptedDB) Close() error {
+	return db.db.Close()
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *EncryptedDB) Put(key *database.Key, value []byte) error {
+	return put(db.db, db.aead, key, value)
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *EncryptedDB) Get(key *database.Key) ([]byte, error) {
+	return get(db.db, db.aead, key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *EncryptedDB) Has(key *database.Key) (bool, error) {
+	return db.db.Has(key)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *EncryptedDB) Delete(key *database.Key) error {
+	return db.db.Delete(key)
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (db *EncryptedDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	return newCursor(db.db, db.aead, bucket)
+}
+
+func put(dataAccessor database.DataAccessor, aead cipher.AEAD, key *database.Key, value []byte) error {
+	sealedValue, err := seal(aead, key, value)
+	if err != nil {
+		return err
+	}
+	return dataAccessor.Put(key, sealedValue)
+}
+
+func get(dataAccessor database.DataAccessor, aead cipher.AEAD, key *database.Key) ([]byte, error) {
+	sealedValue, err := dataAccessor.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, key, sealedValue)
+}
@@ -0,0 +1,100 @@
+package encrypteddb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+func TestEncryptedDB(t *testing.T) {
+	underlyingDB, err := ldb.NewInMemoryLevelDB(8)
+	if err != nil {
+		t.Fatalf("NewInMemoryLevelDB: %+v", err)
+	}
+	defer underlyingDB.Close()
+
+	passphrase := []byte("passphrase")
+	db, err := New(underlyingDB, passphrase)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	isEncrypted, err := IsEncrypted(underlyingDB)
+	if err != nil {
+		t.Fatalf("IsEncrypted: %+v", err)
+	}
+	if !isEncrypted {
+		t.Fatalf("Expected the database to be encrypted")
+	}
+
+	key1 := database.MakeBucket([]byte("bucket")).Key([]byte("key1"))
+	key2 := database.MakeBucket([]byte("bucket")).Key([]byte("key2"))
+	value := []byte("some plaintext value")
+	err = db.Put(key1, value)
+	if err != nil {
+		t.Fatalf("Put: %+v", err)
+	}
+
+	// The value is not kept in plaintext
+	storedValue, err := underlyingDB.Get(key1)
+	if err != nil {
+		t.Fatalf("Get: %+v", err)
+	}
+	if bytes.Contains(storedValue, value) {
+		t.Fatalf("Expected the stored value to be encrypted")
+	}
+
+	// A value that's moved to another key is detected
+	err = underlyingDB.Put(key2, storedValue)
+	if err != nil {
+		t.Fatalf("Put: %+v", err)
+	}
+	_, err = db.Get(key2)
+	if err == nil {
+		t.Fatalf("Expected getting a value that was moved between keys to fail")
+	}
+
+	// Reopening with the same passphrase decrypts the existing values
+	reopenedDB, err := New(underlyingDB, passphrase)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	returnedValue, err := reopenedDB.Get(key1)
+	if err != nil {
+		t.Fatalf("Get: %+v", err)
+	}
+	if !bytes.Equal(returnedValue, value) {
+		t.Fatalf("Expected value %s, but got %s", value, returnedValue)
+	}
+
+	_, err = New(underlyingDB, []byte("wrong passphrase"))
+	if !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("Expected ErrWrongPassphrase, but got %+v", err)
+	}
+}
+
+func TestEncryptedDBExistingUnencryptedDatabase(t *testing.T) {
+	underlyingDB, err := ldb.NewInMemoryLevelDB(8)
+	if err != nil {
+		t.Fatalf("NewInMemoryLevelDB: %+v", err)
+	}
+	defer underlyingDB.Close()
+
+	err = underlyingDB.Put(database.MakeBucket(nil).Key([]byte("key")), []byte("value"))
+	if err != nil {
+		t.Fatalf("Put: %+v", err)
+	}
+	_, err = New(underlyingDB, []byte("passphrase"))
+	if err == nil {
+		t.Fatalf("Expected encrypting an existing database to fail")
+	}
+	isEncrypted, err := IsEncrypted(underlyingDB)
+	if err != nil {
+		t.Fatalf("IsEncrypted: %+v", err)
+	}
+	if isEncrypted {
+		t.Fatalf("Expected the database not to be encrypted")
+	}
+}
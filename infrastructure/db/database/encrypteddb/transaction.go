@@ -0,0 +1,60 @@
+package encrypteddb
+
+import (
+	"crypto/cipher"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+type encryptedTransaction struct {
+	transaction database.Transaction
+	aead        cipher.AEAD
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (tx *encryptedTransaction) Put(key *database.Key, value []byte) error {
+	return put(tx.transaction, tx.aead, key, value)
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (tx *encryptedTransaction) Get(key *database.Key) ([]byte, error) {
+	return get(tx.transaction, tx.aead, key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (tx *encryptedTransaction) Has(key *database.Key) (bool, error) {
+	return tx.transaction.Has(key)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (tx *encryptedTransaction) Delete(key *database.Key) error {
+	return tx.transaction.Delete(key)
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (tx *encryptedTransaction) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	return newCursor(tx.transaction, tx.aead, bucket)
+}
+
+// Rollback rolls back whatever changes were made to the
+// database within this transaction.
+func (tx *encryptedTransaction) Rollback() error {
+	return tx.transaction.Rollback()
+}
+
+// Commit commits whatever changes were made to the database
+// within this transaction.
+func (tx *encryptedTransaction) Commit() error {
+	return tx.transaction.Commit()
+}
+
+// RollbackUnlessClosed rolls back changes that were made to
+// the database within the transaction, unless the transaction
+// had already been closed using either Rollback or Commit.
+func (tx *encryptedTransaction) RollbackUnlessClosed() error {
+	return tx.transaction.RollbackUnlessClosed()
+}
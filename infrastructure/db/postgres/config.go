@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultApplicationName = "kaspad"
+	defaultConnectTimeout  = 10 * time.Second
+)
+
+// identifierRegexp matches the identifiers that may be used in SQL as is
+var identifierRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// ValidateIdentifier returns an error unless the given name of a schema, table
+// or column consists of up to 63 lowercase letters, digits and underscores,
+// and doesn't begin with a digit. Such names may be used in SQL without
+// escaping.
+func ValidateIdentifier(name string) error {
+	if !identifierRegexp.MatchString(name) {
+		return errors.Errorf("invalid identifier %q: it must consist of up to 63 lowercase letters, "+
+			"digits and underscores, and may not begin with a digit", name)
+	}
+	return nil
+}
+
+// ValidateConnectionURL returns an error if the given connection URL can't be
+// connected with
+func ValidateConnectionURL(connectionURL string) error {
+	_, err := parseConnectionURL(connectionURL)
+	return err
+}
+
+// parseConnectionURL parses a connection URL of the form
+// postgres://[user[:password]@][host][:port][/database][?option=value&...].
+// The options are those of libpq, as supported by pgx, such as sslmode,
+// sslrootcert, connect_timeout and application_name.
+func parseConnectionURL(connectionURL string) (*pgx.ConnConfig, error) {
+	if !strings.HasPrefix(connectionURL, "postgres://") && !strings.HasPrefix(connectionURL, "postgresql://") {
+		return nil, errors.New("invalid PostgreSQL connection URL: expected postgres://")
+	}
+	config, err := pgx.ParseConfig(connectionURL)
+	if err != nil {
+		// The error of pgx quotes the connection URL, which may contain the
+		// password, so only its cause is kept
+		if cause := errors.Unwrap(err); cause != nil {
+			return nil, errors.Wrapf(cause, "invalid PostgreSQL connection URL")
+		}
+		return nil, errors.New("invalid PostgreSQL connection URL")
+	}
+	if _, ok := config.RuntimeParams["application_name"]; !ok {
+		config.RuntimeParams["application_name"] = defaultApplicationName
+	}
+	if config.ConnectTimeout == 0 {
+		config.ConnectTimeout = defaultConnectTimeout
+	}
+	return config, nil
+}
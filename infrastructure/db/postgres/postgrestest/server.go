@@ -0,0 +1,35 @@
+// Package postgrestest provides the PostgreSQL server that tests run against.
+//
+// There's no server to run in-process, so the tests that need one run only
+// when the KASPAD_TEST_POSTGRES_URL environment variable holds the connection
+// URL of a database they may create schemas in, and are skipped otherwise.
+package postgrestest
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// URLEnvironmentVariable is the environment variable that holds the
+// connection URL of the database to test against
+const URLEnvironmentVariable = "KASPAD_TEST_POSTGRES_URL"
+
+// ConnectionURL returns the connection URL of the database to test against,
+// or skips the test if there's none
+func ConnectionURL(t testing.TB) string {
+	connectionURL := os.Getenv(URLEnvironmentVariable)
+	if connectionURL == "" {
+		t.Skipf("%s isn't set", URLEnvironmentVariable)
+	}
+	return connectionURL
+}
+
+var schemaCount uint64
+
+// SchemaName returns the name of a schema that no earlier test run used
+func SchemaName() string {
+	return fmt.Sprintf("kaspadtest_%d_%d", time.Now().UnixNano(), atomic.AddUint64(&schemaCount, 1))
+}
@@ -18,6 +18,10 @@ import (
 const (
 	maxAddresses                   = 4096
 	connectionFailedCountForRemove = 4
+
+	// defaultBanDuration is how long addresses are banned for when
+	// the Config doesn't specify a ban duration
+	defaultBanDuration = 24 * time.Hour
 )
 
 // addressRandomizer is the interface for the randomizer needed for the AddressManager.
@@ -34,6 +38,13 @@ type addressKey struct {
 type address struct {
 	netAddress            *appmessage.NetAddress
 	connectionFailedCount uint64
+
+	// source is where the address was first learned from
+	source string
+
+	// banReason is why the address was banned. It's set only for
+	// banned addresses
+	banReason string
 }
 
 type ipv6 [net.IPv6len]byte
@@ -83,17 +94,20 @@ func New(cfg *Config, database database.Database) (*AddressManager, error) {
 	}, nil
 }
 
-func (am *AddressManager) addAddressNoLock(netAddress *appmessage.NetAddress) error {
+func (am *AddressManager) addAddressNoLock(netAddress *appmessage.NetAddress, source string) (isNew bool, err error) {
 	if !IsRoutable(netAddress, am.cfg.AcceptUnroutable) {
-		return nil
+		return false, nil
 	}
 
 	key := netAddressKey(netAddress)
+	if _, ok := am.store.getNotBanned(key); ok {
+		return false, nil
+	}
 	// We mark `connectionFailedCount` as 0 only after first success
-	address := &address{netAddress: netAddress, connectionFailedCount: 1}
-	err := am.store.add(key, address)
+	address := &address{netAddress: netAddress, connectionFailedCount: 1, source: source}
+	err = am.store.add(key, address)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if am.store.notBannedCount() > maxAddresses {
@@ -110,10 +124,10 @@ func (am *AddressManager) addAddressNoLock(netAddress *appmessage.NetAddress) er
 
 		err := am.removeAddressNoLock(toRemove.netAddress)
 		if err != nil {
-			return err
+			return false, err
 		}
 	}
-	return nil
+	return true, nil
 }
 
 func (am *AddressManager) removeAddressNoLock(address *appmessage.NetAddress) error {
@@ -126,21 +140,34 @@ func (am *AddressManager) AddAddress(address *appmessage.NetAddress) error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
-	return am.addAddressNoLock(address)
+	_, err := am.addAddressNoLock(address, AddressSourceUnknown)
+	return err
 }
 
 // AddAddresses adds addresses to the address manager
 func (am *AddressManager) AddAddresses(addresses ...*appmessage.NetAddress) error {
+	_, err := am.AddAddressesFromSource(AddressSourceUnknown, addresses...)
+	return err
+}
+
+// AddAddressesFromSource adds addresses to the address manager, recording
+// where they were learned from, and returns how many of them weren't known
+// before. The source of an address that is already known isn't changed.
+func (am *AddressManager) AddAddressesFromSource(source string, addresses ...*appmessage.NetAddress) (int, error) {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
+	newAddressCount := 0
 	for _, address := range addresses {
-		err := am.addAddressNoLock(address)
+		isNew, err := am.addAddressNoLock(address, source)
 		if err != nil {
-			return err
+			return newAddressCount, err
+		}
+		if isNew {
+			newAddressCount++
 		}
 	}
-	return nil
+	return newAddressCount, nil
 }
 
 // RemoveAddress removes addresses from the address manager
@@ -211,20 +238,77 @@ func (am *AddressManager) notBannedAddressesWithException(exceptions []*appmessa
 	return am.store.getAllNotBannedNetAddressesWithout(exceptions)
 }
 
-// RandomAddresses returns count addresses at random that aren't banned and aren't in exceptions
+// RandomAddresses returns count addresses at random that aren't banned and aren't in exceptions.
+// Tor addresses are returned only if they're reachable.
 func (am *AddressManager) RandomAddresses(count int, exceptions []*appmessage.NetAddress) []*appmessage.NetAddress {
 	validAddresses := am.notBannedAddressesWithException(exceptions)
+	if !am.cfg.IsTorReachable {
+		reachableAddresses := make([]*address, 0, len(validAddresses))
+		for _, address := range validAddresses {
+			if !IsOnionCatTor(address.netAddress) {
+				reachableAddresses = append(reachableAddresses, address)
+			}
+		}
+		validAddresses = reachableAddresses
+	}
 	return am.random.RandomAddresses(validAddresses, count)
 }
 
+// AddExternalAddressVote records the external address of this node as
+// reported by the given outbound peer. Once enough peers report the same
+// external IP, it's advertised to peers.
+func (am *AddressManager) AddExternalAddressVote(peerAddress *appmessage.NetAddress,
+	reportedAddress *appmessage.NetAddress) {
+
+	am.localAddresses.addExternalAddressVote(peerAddress, reportedAddress)
+}
+
 // BestLocalAddress returns the most appropriate local address to use
 // for the given remote address.
 func (am *AddressManager) BestLocalAddress(remoteAddress *appmessage.NetAddress) *appmessage.NetAddress {
 	return am.localAddresses.bestLocalAddress(remoteAddress)
 }
 
-// Ban marks the given address as banned
-func (am *AddressManager) Ban(addressToBan *appmessage.NetAddress) error {
+// BannedAddressEntry describes a banned address
+type BannedAddressEntry struct {
+	Address     *appmessage.NetAddress
+	BanTime     mstime.Time
+	BannedUntil mstime.Time
+	Reason      string
+}
+
+// BannedAddressEntries returns all the addresses whose ban didn't expire
+// yet, along with when and why they were banned
+func (am *AddressManager) BannedAddressEntries() ([]*BannedAddressEntry, error) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	bannedAddresses := am.store.getAllBanned()
+	entries := make([]*BannedAddressEntry, 0, len(bannedAddresses))
+	for _, bannedAddress := range bannedAddresses {
+		key := netAddressKey(bannedAddress.netAddress)
+		err := am.unbanIfOldEnough(key)
+		if err != nil {
+			return nil, err
+		}
+		if !am.store.isBanned(key) {
+			continue
+		}
+		banTime := bannedAddress.netAddress.Timestamp
+		entries = append(entries, &BannedAddressEntry{
+			Address:     bannedAddress.netAddress,
+			BanTime:     banTime,
+			BannedUntil: banTime.Add(am.banDuration()),
+			Reason:      bannedAddress.banReason,
+		})
+	}
+	return entries, nil
+}
+
+// Ban marks the given address as banned for the configured ban duration,
+// recording the given reason. The ban starts at the timestamp of the
+// address.
+func (am *AddressManager) Ban(addressToBan *appmessage.NetAddress, reason string) error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
@@ -243,7 +327,7 @@ func (am *AddressManager) Ban(addressToBan *appmessage.NetAddress) error {
 		}
 	}
 
-	address := &address{netAddress: addressToBan}
+	address := &address{netAddress: addressToBan, banReason: reason}
 	return am.store.addBanned(keyToBan, address)
 }
 
@@ -282,14 +366,22 @@ func (am *AddressManager) IsBanned(address *appmessage.NetAddress) (bool, error)
 	return true, nil
 }
 
+// SetBanDuration changes how long addresses are banned for. It applies to the
+// current bans as well, since their expiry is derived from when they started.
+func (am *AddressManager) SetBanDuration(banDuration time.Duration) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	am.cfg.BanDuration = banDuration
+}
+
 func (am *AddressManager) unbanIfOldEnough(key addressKey) error {
 	address, ok := am.store.getBanned(key)
 	if !ok {
 		return nil
 	}
 
-	const maxBanTime = 24 * time.Hour
-	if mstime.Since(address.netAddress.Timestamp) > maxBanTime {
+	if mstime.Since(address.netAddress.Timestamp) > am.banDuration() {
 		err := am.store.removeBanned(key)
 		if err != nil {
 			return err
@@ -297,3 +389,10 @@ func (am *AddressManager) unbanIfOldEnough(key addressKey) error {
 	}
 	return nil
 }
+
+func (am *AddressManager) banDuration() time.Duration {
+	if am.cfg.BanDuration == 0 {
+		return defaultBanDuration
+	}
+	return am.cfg.BanDuration
+}
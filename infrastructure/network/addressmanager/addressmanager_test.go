@@ -8,6 +8,7 @@ import (
 	"net"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/infrastructure/config"
@@ -178,7 +179,7 @@ func TestAddressManager(t *testing.T) {
 
 	// Ban a different address
 	addressToBan := testAddress3
-	err = addressManager.Ban(addressToBan)
+	err = addressManager.Ban(addressToBan, "test")
 	if err != nil {
 		t.Fatalf("Ban() failed: %s", err)
 	}
@@ -258,7 +259,7 @@ func TestRestoreAddressManager(t *testing.T) {
 
 	// Ban one of the addresses
 	addressToBan := testAddress1
-	err = addressManager.Ban(addressToBan)
+	err = addressManager.Ban(addressToBan, "test")
 	if err != nil {
 		t.Fatalf("Ban() failed: %s", err)
 	}
@@ -303,6 +304,62 @@ func TestRestoreAddressManager(t *testing.T) {
 	if !reflect.DeepEqual(addressToBan, bannedAddresses[0]) {
 		t.Fatalf("Banned address %s not returned from BannedAddresses()", addressToBan.IP)
 	}
+
+	// Make sure that the ban reason was restored as well
+	entries, err := addressManager.BannedAddressEntries()
+	if err != nil {
+		t.Fatalf("BannedAddressEntries() failed: %s", err)
+	}
+	if len(entries) != 1 || entries[0].Reason != "test" {
+		t.Fatalf("Unexpected banned address entries: %+v", entries)
+	}
+}
+
+func TestBanExpiry(t *testing.T) {
+	cfg := NewConfig(config.DefaultConfig())
+	cfg.BanDuration = time.Hour
+
+	database, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer database.Close()
+
+	addressManager, err := New(cfg, database)
+	if err != nil {
+		t.Fatalf("Error creating address manager: %s", err)
+	}
+
+	now := mstime.Now()
+	recentlyBanned := &appmessage.NetAddress{IP: net.ParseIP("1.2.3.4"), Timestamp: now.Add(-time.Minute)}
+	banExpired := &appmessage.NetAddress{IP: net.ParseIP("5.6.7.8"), Timestamp: now.Add(-2 * time.Hour)}
+	for _, address := range []*appmessage.NetAddress{recentlyBanned, banExpired} {
+		err = addressManager.Ban(address, "invalid block")
+		if err != nil {
+			t.Fatalf("Ban() failed: %s", err)
+		}
+	}
+
+	entries, err := addressManager.BannedAddressEntries()
+	if err != nil {
+		t.Fatalf("BannedAddressEntries() failed: %s", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Unexpected amount of banned address entries. Want: %d, got: %d", 1, len(entries))
+	}
+	entry := entries[0]
+	if !entry.Address.IP.Equal(recentlyBanned.IP) || entry.Reason != "invalid block" {
+		t.Fatalf("Unexpected banned address entry: %+v", entry)
+	}
+	if entry.BanTime != recentlyBanned.Timestamp || entry.BannedUntil != recentlyBanned.Timestamp.Add(time.Hour) {
+		t.Fatalf("Unexpected ban period. Want: %s to %s, got: %s to %s", recentlyBanned.Timestamp,
+			recentlyBanned.Timestamp.Add(time.Hour), entry.BanTime, entry.BannedUntil)
+	}
+
+	// The address whose ban expired was unbanned
+	if len(addressManager.BannedAddresses()) != 1 {
+		t.Fatalf("Expected the address whose ban expired to be unbanned")
+	}
 }
 
 func TestOverfillAddressManager(t *testing.T) {
@@ -372,3 +429,109 @@ func TestOverfillAddressManager(t *testing.T) {
 		}
 	}
 }
+
+func TestAddExternalAddressVote(t *testing.T) {
+	amgr, teardown := newAddressManagerForTest(t, "TestAddExternalAddressVote")
+	defer teardown()
+
+	remoteAddress := appmessage.NewNetAddressIPPort(net.ParseIP("204.124.8.1"), 16111)
+	if best := amgr.BestLocalAddress(remoteAddress); !best.IP.Equal(net.IPv4zero) {
+		t.Fatalf("Expected no local address to be advertised, but got %s", best.IP)
+	}
+
+	vote := func(voterIP string, reportedIP string) {
+		amgr.AddExternalAddressVote(appmessage.NewNetAddressIPPort(net.ParseIP(voterIP), 16111),
+			appmessage.NewNetAddressIPPort(net.ParseIP(reportedIP), 54321))
+	}
+
+	// Unroutable reports are ignored, and a peer votes only once
+	vote("1.1.1.1", "10.0.0.1")
+	vote("1.1.1.2", "10.0.0.1")
+	vote("1.1.1.3", "10.0.0.1")
+	vote("1.1.1.1", "173.194.115.66")
+	vote("1.1.1.1", "173.194.115.66")
+	vote("1.1.1.1", "173.194.115.66")
+	if best := amgr.BestLocalAddress(remoteAddress); !best.IP.Equal(net.IPv4zero) {
+		t.Fatalf("Expected no local address to be advertised before enough peers agree, but got %s", best.IP)
+	}
+
+	vote("1.1.1.2", "173.194.115.66")
+	vote("1.1.1.3", "173.194.115.66")
+	best := amgr.BestLocalAddress(remoteAddress)
+	if !best.IP.Equal(net.ParseIP("173.194.115.66")) || best.Port != 16111 {
+		t.Fatalf("Expected the external address reported by the peers to be advertised on the default port, "+
+			"but got %s", best.TCPAddress())
+	}
+
+	// Once the majority of the peers reports a different address, it replaces the previous one
+	vote("1.1.1.1", "173.194.115.67")
+	vote("1.1.1.2", "173.194.115.67")
+	vote("1.1.1.4", "173.194.115.67")
+	if best := amgr.BestLocalAddress(remoteAddress); !best.IP.Equal(net.ParseIP("173.194.115.67")) {
+		t.Fatalf("Expected the new external address to be advertised, but got %s", best.IP)
+	}
+	if len(amgr.localAddresses.localAddresses) != 1 {
+		t.Fatalf("Expected the previous external address not to be advertised anymore")
+	}
+}
+
+func TestExternalIPs(t *testing.T) {
+	cfg := NewConfig(config.DefaultConfig())
+	cfg.ExternalIPs = []string{
+		"173.194.115.66:1234,services=SFNodeNetwork",
+		"[2001:470::1]:5678",
+	}
+	localAddresses, err := newLocalAddressManager(cfg)
+	if err != nil {
+		t.Fatalf("newLocalAddressManager: %s", err)
+	}
+
+	best := localAddresses.bestLocalAddress(appmessage.NewNetAddressIPPort(net.ParseIP("2602:100:abcd::102"), 16111))
+	if !best.IP.Equal(net.ParseIP("2001:470::1")) || best.Port != 5678 || best.Services != 0 {
+		t.Fatalf("Expected the IPv6 external address to be advertised to an IPv6 peer, but got %s with "+
+			"services %s", best.TCPAddress(), best.Services)
+	}
+
+	best = localAddresses.bestLocalAddress(appmessage.NewNetAddressIPPort(net.ParseIP("204.124.8.1"), 16111))
+	if !best.IP.Equal(net.ParseIP("173.194.115.66")) || best.Port != 1234 || best.Services != appmessage.SFNodeNetwork {
+		t.Fatalf("Expected the IPv4 external address to be advertised to an IPv4 peer, but got %s with "+
+			"services %s", best.TCPAddress(), best.Services)
+	}
+
+	host, port, services, err := ParseExternalIP("173.194.115.67,services=SFNodeNetwork|SFNodeBloom", "16111")
+	if err != nil {
+		t.Fatalf("ParseExternalIP: %s", err)
+	}
+	if host != "173.194.115.67" || port != 16111 || services != appmessage.SFNodeNetwork|appmessage.SFNodeBloom {
+		t.Fatalf("Unexpected parsed external address %s:%d with services %s", host, port, services)
+	}
+
+	// An onion external address is advertised to peers connected through tor
+	cfg.ExternalIPs = []string{"173.194.115.66", "abcdefghijklmnop.onion"}
+	localAddresses, err = newLocalAddressManager(cfg)
+	if err != nil {
+		t.Fatalf("newLocalAddressManager: %s", err)
+	}
+	onionIP, err := OnionCatIP("abcdefghijklmnop.onion")
+	if err != nil {
+		t.Fatalf("OnionCatIP: %s", err)
+	}
+	best = localAddresses.bestLocalAddress(appmessage.NewNetAddressIPPort(net.ParseIP("fd87:d87e:eb43:25::1"), 16111))
+	if !best.IP.Equal(onionIP) {
+		t.Fatalf("Expected the onion external address to be advertised to a tor peer, but got %s", best.TCPAddress())
+	}
+	best = localAddresses.bestLocalAddress(appmessage.NewNetAddressIPPort(net.ParseIP("204.124.8.1"), 16111))
+	if !best.IP.Equal(net.ParseIP("173.194.115.66")) {
+		t.Fatalf("Expected the IPv4 external address to be advertised to an IPv4 peer, but got %s", best.TCPAddress())
+	}
+
+	for _, invalidExternalIP := range []string{"1.2.3.4:abc", "1.2.3.4,services=SFNodeUnknown",
+		"1.2.3.4,port=1", "abcdefghijklmnopqrstuvwxyz234567abcdefghijklmnopqrstuvwx.onion", "abcdefghijklmno1.onion"} {
+
+		cfg.ExternalIPs = []string{invalidExternalIP}
+		_, err := newLocalAddressManager(cfg)
+		if err == nil {
+			t.Fatalf("Expected externalip %s to be rejected", invalidExternalIP)
+		}
+	}
+}
@@ -2,26 +2,37 @@ package addressmanager
 
 import (
 	"net"
+	"time"
 
 	"github.com/kaspanet/kaspad/infrastructure/config"
 )
 
 // Config is a descriptor which specifies the AddressManager instance configuration.
 type Config struct {
-	AcceptUnroutable bool
-	DefaultPort      string
-	ExternalIPs      []string
-	Listeners        []string
-	Lookup           func(string) ([]net.IP, error)
+	AcceptUnroutable           bool
+	DefaultPort                string
+	ExternalIPs                []string
+	Listeners                  []string
+	DisableListen              bool
+	DisableExternalIPDiscovery bool
+	ExternalIPProbe            string
+	IsTorReachable             bool
+	BanDuration                time.Duration
+	Lookup                     func(string) ([]net.IP, error)
 }
 
 // NewConfig returns a new address manager Config.
 func NewConfig(cfg *config.Config) *Config {
 	return &Config{
-		AcceptUnroutable: cfg.NetParams().AcceptUnroutable,
-		DefaultPort:      cfg.NetParams().DefaultPort,
-		ExternalIPs:      cfg.ExternalIPs,
-		Listeners:        cfg.Listeners,
-		Lookup:           cfg.Lookup,
+		AcceptUnroutable:           cfg.NetParams().AcceptUnroutable,
+		DefaultPort:                cfg.NetParams().DefaultPort,
+		ExternalIPs:                cfg.ExternalIPs,
+		Listeners:                  cfg.Listeners,
+		DisableListen:              cfg.DisableListen,
+		DisableExternalIPDiscovery: cfg.DisableExternalIPDiscovery,
+		ExternalIPProbe:            cfg.ExternalIPProbe,
+		IsTorReachable:             cfg.OnionDial != nil,
+		BanDuration:                cfg.BanDuration,
+		Lookup:                     cfg.Lookup,
 	}
 }
@@ -0,0 +1,167 @@
+package addressmanager
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+const (
+	// minExternalAddressVotes is the number of outbound peers that have to
+	// report the same external IP before it's advertised
+	minExternalAddressVotes = 3
+
+	// maxExternalAddressVoters is the maximum number of peers whose reports of
+	// the external IP are kept. Once it's reached, the report of an arbitrary
+	// peer is dropped for every new one, so that a change of the external IP
+	// is eventually discovered.
+	maxExternalAddressVoters = 100
+
+	// externalAddressProbeTimeout is how long probing the external IP via
+	// --externalipprobe may take
+	externalAddressProbeTimeout = 10 * time.Second
+
+	// maxExternalAddressProbeResponseSize is the maximum size of the response
+	// of the service given by --externalipprobe
+	maxExternalAddressProbeResponseSize = 64
+)
+
+// ipKey identifies an IP regardless of whether it's represented as an IPv4 or
+// as an IPv6 address
+type ipKey ipv6
+
+func netAddressIPKey(netAddress *appmessage.NetAddress) ipKey {
+	var key ipKey
+	copy(key[:], netAddress.IP.To16())
+	return key
+}
+
+// isExternalAddressDiscoveryEnabled returns whether this node should discover
+// its external address. It shouldn't if it doesn't listen, or if its external
+// addresses were given explicitly with --externalip.
+func (lam *localAddressManager) isExternalAddressDiscoveryEnabled() bool {
+	return !lam.cfg.DisableExternalIPDiscovery && !lam.cfg.DisableListen && len(lam.cfg.ExternalIPs) == 0
+}
+
+// externalAddressPort returns the port to advertise along with a discovered
+// external IP, which is the port of the first listener
+func (lam *localAddressManager) externalAddressPort() (uint16, error) {
+	port := lam.cfg.DefaultPort
+	if len(lam.cfg.Listeners) > 0 {
+		_, listenerPort, err := net.SplitHostPort(lam.cfg.Listeners[0])
+		if err != nil {
+			return 0, err
+		}
+		port = listenerPort
+	}
+	parsedPort, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return 0, errors.Wrapf(err, "can not parse port %s", port)
+	}
+	return uint16(parsedPort), nil
+}
+
+// addExternalAddressVote records the external IP of this node as reported by
+// the given outbound peer. Once enough peers report the same IP, it's
+// advertised in place of the IP this node advertised by a previous vote.
+func (lam *localAddressManager) addExternalAddressVote(voter *appmessage.NetAddress,
+	reportedAddress *appmessage.NetAddress) {
+
+	if !lam.isExternalAddressDiscoveryEnabled() || !IsRoutable(reportedAddress, lam.cfg.AcceptUnroutable) {
+		return
+	}
+
+	lam.mutex.Lock()
+	defer lam.mutex.Unlock()
+
+	voterKey := netAddressIPKey(voter)
+	if _, ok := lam.externalAddressVotes[voterKey]; !ok && len(lam.externalAddressVotes) >= maxExternalAddressVoters {
+		for key := range lam.externalAddressVotes {
+			delete(lam.externalAddressVotes, key)
+			break
+		}
+	}
+	lam.externalAddressVotes[voterKey] = netAddressIPKey(reportedAddress)
+
+	votes := make(map[ipKey]int)
+	var winner ipKey
+	winnerVotes := 0
+	for _, votedIP := range lam.externalAddressVotes {
+		votes[votedIP]++
+		if votes[votedIP] > winnerVotes {
+			winner = votedIP
+			winnerVotes = votes[votedIP]
+		}
+	}
+	if winnerVotes < minExternalAddressVotes {
+		return
+	}
+	// The address that's already advertised is kept on a tie
+	if lam.votedExternalAddress != nil && votes[netAddressIPKey(lam.votedExternalAddress)] >= winnerVotes {
+		return
+	}
+
+	port, err := lam.externalAddressPort()
+	if err != nil {
+		log.Warnf("Not advertising the discovered external address: %s", err)
+		return
+	}
+	externalAddress := appmessage.NewNetAddressIPPort(net.IP(winner[:]), port)
+	if lam.votedExternalAddress != nil {
+		delete(lam.localAddresses, netAddressKey(lam.votedExternalAddress))
+	}
+	lam.votedExternalAddress = externalAddress
+	log.Infof("Discovered the external address %s, which was reported by %d peers",
+		externalAddress.TCPAddress(), winnerVotes)
+	lam.addLocalNetAddressNoLock(externalAddress, PeerVotePrio)
+}
+
+// probeExternalAddress asks the service given by --externalipprobe for the
+// external IP of this node, and advertises it
+func (lam *localAddressManager) probeExternalAddress() {
+	ip, err := lam.fetchExternalIP()
+	if err != nil {
+		log.Warnf("Couldn't probe the external address via %s: %s", lam.cfg.ExternalIPProbe, err)
+		return
+	}
+	port, err := lam.externalAddressPort()
+	if err != nil {
+		log.Warnf("Not advertising the probed external address: %s", err)
+		return
+	}
+	externalAddress := appmessage.NewNetAddressIPPort(ip, port)
+	err = lam.addLocalNetAddress(externalAddress, HTTPPrio)
+	if err != nil {
+		log.Warnf("Not advertising the probed external address: %s", err)
+		return
+	}
+	log.Infof("Probed the external address %s via %s", externalAddress.TCPAddress(), lam.cfg.ExternalIPProbe)
+}
+
+func (lam *localAddressManager) fetchExternalIP() (net.IP, error) {
+	client := http.Client{Timeout: externalAddressProbeTimeout}
+	response, err := client.Get(lam.cfg.ExternalIPProbe)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s", response.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxExternalAddressProbeResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, errors.Errorf("the response %q is not an IP address", body)
+	}
+	return ip, nil
+}
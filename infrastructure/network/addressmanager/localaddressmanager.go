@@ -28,6 +28,9 @@ const (
 	// HTTPPrio signifies the address was obtained from an external HTTP service.
 	HTTPPrio
 
+	// PeerVotePrio signifies the address was reported by enough outbound peers.
+	PeerVotePrio
+
 	// ManualPrio signifies the address was provided by --externalip.
 	ManualPrio
 )
@@ -42,13 +45,22 @@ type localAddressManager struct {
 	lookupFunc     func(string) ([]net.IP, error)
 	cfg            *Config
 	mutex          sync.Mutex
+
+	// externalAddressVotes maps the IPs of outbound peers to the external
+	// IP of this node they reported
+	externalAddressVotes map[ipKey]ipKey
+
+	// votedExternalAddress is nil if the peers didn't agree on an external
+	// address yet
+	votedExternalAddress *appmessage.NetAddress
 }
 
 func newLocalAddressManager(cfg *Config) (*localAddressManager, error) {
 	localAddressManager := localAddressManager{
-		localAddresses: map[addressKey]*localAddress{},
-		cfg:            cfg,
-		lookupFunc:     cfg.Lookup,
+		localAddresses:       map[addressKey]*localAddress{},
+		cfg:                  cfg,
+		lookupFunc:           cfg.Lookup,
+		externalAddressVotes: map[ipKey]ipKey{},
 	}
 
 	err := localAddressManager.initListeners()
@@ -56,6 +68,10 @@ func newLocalAddressManager(cfg *Config) (*localAddressManager, error) {
 		return nil, err
 	}
 
+	if cfg.ExternalIPProbe != "" && localAddressManager.isExternalAddressDiscoveryEnabled() {
+		spawn("localAddressManager.probeExternalAddress", localAddressManager.probeExternalAddress)
+	}
+
 	return &localAddressManager, nil
 }
 
@@ -69,6 +85,13 @@ func (lam *localAddressManager) addLocalNetAddress(netAddress *appmessage.NetAdd
 	lam.mutex.Lock()
 	defer lam.mutex.Unlock()
 
+	lam.addLocalNetAddressNoLock(netAddress, priority)
+	return nil
+}
+
+// addLocalNetAddressNoLock is the lock-free version of addLocalNetAddress. It
+// expects netAddress to be routable.
+func (lam *localAddressManager) addLocalNetAddressNoLock(netAddress *appmessage.NetAddress, priority AddressPriority) {
 	addressKey := netAddressKey(netAddress)
 	address, ok := lam.localAddresses[addressKey]
 	if !ok || address.score < priority {
@@ -81,7 +104,6 @@ func (lam *localAddressManager) addLocalNetAddress(netAddress *appmessage.NetAdd
 			}
 		}
 	}
-	return nil
 }
 
 // bestLocalAddress returns the most appropriate local address to use
@@ -167,33 +189,17 @@ func (lam *localAddressManager) addLocalAddress(addr string) error {
 // addresses to the address manager
 func (lam *localAddressManager) initListeners() error {
 	if len(lam.cfg.ExternalIPs) != 0 {
-		defaultPort, err := strconv.ParseUint(lam.cfg.DefaultPort, 10, 16)
-		if err != nil {
-			log.Errorf("Can not parse default port %s for active DAG: %s",
-				lam.cfg.DefaultPort, err)
-			return err
-		}
-
-		for _, sip := range lam.cfg.ExternalIPs {
-			eport := uint16(defaultPort)
-			host, portstr, err := net.SplitHostPort(sip)
+		for _, externalIP := range lam.cfg.ExternalIPs {
+			host, port, services, err := ParseExternalIP(externalIP, lam.cfg.DefaultPort)
 			if err != nil {
-				// no port, use default.
-				host = sip
-			} else {
-				port, err := strconv.ParseUint(portstr, 10, 16)
-				if err != nil {
-					log.Warnf("Can not parse port from %s for "+
-						"externalip: %s", sip, err)
-					continue
-				}
-				eport = uint16(port)
+				return err
 			}
-			na, err := lam.hostToNetAddress(host, eport)
+			na, err := lam.hostToNetAddress(host, port)
 			if err != nil {
-				log.Warnf("Not adding %s as externalip: %s", sip, err)
+				log.Warnf("Not adding %s as externalip: %s", externalIP, err)
 				continue
 			}
+			na.Services = services
 
 			err = lam.addLocalNetAddress(na, ManualPrio)
 			if err != nil {
@@ -229,9 +235,58 @@ func (lam *localAddressManager) initListeners() error {
 	return nil
 }
 
+// ParseExternalIP parses an --externalip entry, which has the form
+// host[:port][,services=<flags>]. The port defaults to defaultPort, and the
+// service flags, given in the form accepted by appmessage.ParseServiceFlags,
+// default to none, meaning that they're unknown.
+func ParseExternalIP(externalIP string, defaultPort string) (host string, port uint16, services appmessage.ServiceFlag, err error) {
+	address := externalIP
+	if separatorIndex := strings.Index(externalIP, ","); separatorIndex >= 0 {
+		address = externalIP[:separatorIndex]
+		option := externalIP[separatorIndex+1:]
+		const servicesPrefix = "services="
+		if !strings.HasPrefix(option, servicesPrefix) {
+			return "", 0, 0, errors.Errorf("invalid externalip %s: unknown option %s", externalIP, option)
+		}
+		services, err = appmessage.ParseServiceFlags(strings.TrimPrefix(option, servicesPrefix))
+		if err != nil {
+			return "", 0, 0, errors.Wrapf(err, "invalid externalip %s", externalIP)
+		}
+	}
+
+	portString := defaultPort
+	host, splitPort, err := net.SplitHostPort(address)
+	if err != nil {
+		// No port, so the default one is used
+		host = address
+	} else {
+		portString = splitPort
+	}
+	if IsOnionHost(host) {
+		_, err := OnionCatIP(host)
+		if err != nil {
+			return "", 0, 0, errors.Wrapf(err, "invalid externalip %s", externalIP)
+		}
+	}
+	parsedPort, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil {
+		return "", 0, 0, errors.Wrapf(err, "invalid externalip %s: can not parse port %s", externalIP, portString)
+	}
+	return host, uint16(parsedPort), services, nil
+}
+
 // hostToNetAddress returns a netaddress given a host address. If
-// the host is not an IP address it will be resolved.
+// the host is a .onion address it's mapped to its Tor address, and if
+// it's not an IP address otherwise it will be resolved.
 func (lam *localAddressManager) hostToNetAddress(host string, port uint16) (*appmessage.NetAddress, error) {
+	if IsOnionHost(host) {
+		ip, err := OnionCatIP(host)
+		if err != nil {
+			return nil, err
+		}
+		return appmessage.NewNetAddressIPPort(ip, port), nil
+	}
+
 	ip := net.ParseIP(host)
 	if ip == nil {
 		ips, err := lam.lookupFunc(host)
@@ -319,13 +374,25 @@ func reachabilityFrom(localAddress, remoteAddress *appmessage.NetAddress, accept
 		return IsValid(na) && !(IsRFC1918(na) || IsRFC2544(na) ||
 			IsRFC3927(na) || IsRFC4862(na) || IsRFC3849(na) ||
 			IsRFC4843(na) || IsRFC5737(na) || IsRFC6598(na) ||
-			IsLocal(na) || (IsRFC4193(na)))
+			IsLocal(na) || (IsRFC4193(na) && !IsOnionCatTor(na)))
 	}
 
 	if !IsRoutable(remoteAddress) {
 		return Unreachable
 	}
 
+	if IsOnionCatTor(remoteAddress) {
+		if IsOnionCatTor(localAddress) {
+			return Private
+		}
+
+		if IsRoutable(localAddress) && IsIPv4(localAddress) {
+			return Ipv4
+		}
+
+		return Default
+	}
+
 	if IsRFC4380(remoteAddress) {
 		if !IsRoutable(localAddress) {
 			return Default
@@ -356,7 +423,8 @@ func reachabilityFrom(localAddress, remoteAddress *appmessage.NetAddress, accept
 		tunnelled = true
 	}
 
-	if !IsRoutable(localAddress) {
+	// Tor addresses are advertised to clearnet peers only as a last resort
+	if !IsRoutable(localAddress) || IsOnionCatTor(localAddress) {
 		return Default
 	}
 
@@ -6,6 +6,8 @@ package addressmanager
 
 import (
 	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
 )
 
 var log = logger.RegisterSubSystem("ADXR")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -65,6 +65,8 @@ func TestIPTypes(t *testing.T) {
 			false, false, false, false, false, false, false, true, true, false),
 		newIPTest("fd00:dead::1", false, false, false, false, false, true,
 			false, false, false, false, false, false, false, false, true, false),
+		newIPTest("fd87:d87e:eb43:25::1", false, false, false, false, false, true,
+			false, false, false, false, false, false, false, false, true, true),
 		newIPTest("2001::1", false, false, false, false, false, false,
 			true, false, false, false, false, false, false, false, true, true),
 		newIPTest("2001:10:abcd::1:1", false, false, false, false, false, false,
@@ -183,9 +185,9 @@ func TestGroupKey(t *testing.T) {
 		{name: "ipv6 rfc6145 translated ipv4", ip: "::ffff:0:0c01:0203", expected: "12.1.0.0"},
 
 		// Tor.
-		{name: "ipv6 tor onioncat", ip: "fd87:d87e:eb43:1234::5678", expected: "unroutable"},
-		{name: "ipv6 tor onioncat 2", ip: "fd87:d87e:eb43:1245::6789", expected: "unroutable"},
-		{name: "ipv6 tor onioncat 3", ip: "fd87:d87e:eb43:1345::6789", expected: "unroutable"},
+		{name: "ipv6 tor onioncat", ip: "fd87:d87e:eb43:1234::5678", expected: "tor:2"},
+		{name: "ipv6 tor onioncat 2", ip: "fd87:d87e:eb43:1245::6789", expected: "tor:2"},
+		{name: "ipv6 tor onioncat 3", ip: "fd87:d87e:eb43:1345::6789", expected: "tor:3"},
 
 		// IPv6 normal.
 		{name: "ipv6 normal", ip: "2602:100::1", expected: "2602:100::"},
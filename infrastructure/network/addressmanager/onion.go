@@ -0,0 +1,51 @@
+package addressmanager
+
+import (
+	"encoding/base32"
+	"net"
+	"strings"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+const onionSuffix = ".onion"
+
+// onionKeyHashLength is the length of the base32 encoded key hash of the
+// .onion addresses that can be mapped into onionCatNet. Longer (version 3)
+// onion addresses don't fit in an IPv6 address.
+const onionKeyHashLength = 16
+
+// IsOnionHost returns whether the given host is a .onion address
+func IsOnionHost(host string) bool {
+	return strings.HasSuffix(strings.ToLower(host), onionSuffix)
+}
+
+// OnionCatIP returns the IP in onionCatNet that the given .onion host is
+// mapped to, so that it can be stored and exchanged with peers like any other
+// address
+func OnionCatIP(host string) (net.IP, error) {
+	if !IsOnionHost(host) {
+		return nil, errors.Errorf("%s is not an onion address", host)
+	}
+	keyHash := strings.ToUpper(host[:len(host)-len(onionSuffix)])
+	if len(keyHash) != onionKeyHashLength {
+		return nil, errors.Errorf("onion address %s can't be mapped to an IP: only onion addresses "+
+			"of %d characters, excluding the %s suffix, are supported", host, onionKeyHashLength, onionSuffix)
+	}
+	data, err := base32.StdEncoding.DecodeString(keyHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid onion address %s", host)
+	}
+
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, onionCatNet.IP)
+	copy(ip[len(ip)-len(data):], data)
+	return ip, nil
+}
+
+// OnionHost returns the .onion host that the given Tor address stands for
+func OnionHost(na *appmessage.NetAddress) string {
+	keyHash := base32.StdEncoding.EncodeToString(na.IP[6:])
+	return strings.ToLower(keyHash) + onionSuffix
+}
@@ -0,0 +1,62 @@
+package addressmanager
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func TestOnionCatIP(t *testing.T) {
+	const host = "abcdefghijklmnop.onion"
+	ip, err := OnionCatIP("ABCDEFGHIJKLMNOP.onion")
+	if err != nil {
+		t.Fatalf("OnionCatIP: %s", err)
+	}
+	if !ip.Equal(net.ParseIP("fd87:d87e:eb43:44:3214:c742:54b6:35cf")) {
+		t.Fatalf("Unexpected IP %s", ip)
+	}
+	netAddress := appmessage.NewNetAddressIPPort(ip, 16111)
+	if !IsOnionCatTor(netAddress) || !IsRoutable(netAddress, false) {
+		t.Fatalf("Expected %s to be a routable tor address", ip)
+	}
+	if onionHost := OnionHost(netAddress); onionHost != host {
+		t.Fatalf("Unexpected onion host. Want: %s, got: %s", host, onionHost)
+	}
+
+	for _, invalidHost := range []string{"1.2.3.4", "abcdefghijklmno.onion", "abcdefghijklmno1.onion",
+		"abcdefghijklmnopqrstuvwxyz234567abcdefghijklmnopqrstuvwx.onion"} {
+
+		_, err := OnionCatIP(invalidHost)
+		if err == nil {
+			t.Fatalf("Expected %s not to be mapped to an IP", invalidHost)
+		}
+	}
+}
+
+func TestRandomAddressesSkipsUnreachableTorAddresses(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestRandomAddressesSkipsUnreachableTorAddresses")
+	defer teardown()
+
+	ip, err := OnionCatIP("abcdefghijklmnop.onion")
+	if err != nil {
+		t.Fatalf("OnionCatIP: %s", err)
+	}
+	torAddress := appmessage.NewNetAddressIPPort(ip, 16111)
+	ipv4Address := appmessage.NewNetAddressIPPort(net.ParseIP("173.194.115.66"), 16111)
+	err = addressManager.AddAddresses(torAddress, ipv4Address)
+	if err != nil {
+		t.Fatalf("AddAddresses: %s", err)
+	}
+
+	addresses := addressManager.RandomAddresses(2, nil)
+	if len(addresses) != 1 || !addresses[0].IP.Equal(ipv4Address.IP) {
+		t.Fatalf("Expected only the IPv4 address while tor is unreachable, but got %v", addresses)
+	}
+
+	addressManager.cfg.IsTorReachable = true
+	addresses = addressManager.RandomAddresses(2, nil)
+	if len(addresses) != 2 {
+		t.Fatalf("Expected both addresses while tor is reachable, but got %v", addresses)
+	}
+}
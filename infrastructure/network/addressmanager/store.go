@@ -81,7 +81,7 @@ func (as *addressStore) restoreBannedAddresses() error {
 		if err != nil {
 			return err
 		}
-		netAddress := as.deserializeAddress(serializedNetAddress)
+		netAddress := as.deserializeBannedAddress(serializedNetAddress)
 		as.bannedAddresses[ipv6] = netAddress
 	}
 	return nil
@@ -169,7 +169,7 @@ func (as *addressStore) addBanned(key addressKey, address *address) error {
 	as.bannedAddresses[key.address] = address
 
 	databaseKey := as.bannedDatabaseKey(key)
-	serializedAddress := as.serializeAddress(address)
+	serializedAddress := as.serializeBannedAddress(address)
 	return as.database.Put(databaseKey, serializedAddress)
 }
 
@@ -180,6 +180,14 @@ func (as *addressStore) removeBanned(key addressKey) error {
 	return as.database.Delete(databaseKey)
 }
 
+func (as *addressStore) getAllBanned() []*address {
+	bannedAddresses := make([]*address, 0, len(as.bannedAddresses))
+	for _, bannedAddress := range as.bannedAddresses {
+		bannedAddresses = append(bannedAddresses, bannedAddress)
+	}
+	return bannedAddresses
+}
+
 func (as *addressStore) getAllBannedNetAddresses() []*appmessage.NetAddress {
 	bannedAddresses := make([]*appmessage.NetAddress, 0, len(as.bannedAddresses))
 	for _, bannedAddress := range as.bannedAddresses {
@@ -239,19 +247,48 @@ func (as *addressStore) deserializeAddressKey(serializedKey []byte) addressKey {
 	}
 }
 
+// serializedAddressSourceOffset is where the source of an address starts in
+// its serialized form. Addresses that were stored before sources were recorded
+// end there. Banned addresses store their ban reason there instead.
+const serializedAddressSourceOffset = 16 + 2 + 8 + 8 // ipv6 + port + timestamp + connectionFailedCount
+
 func (as *addressStore) serializeAddress(address *address) []byte {
-	serializedSize := 16 + 2 + 8 + 8 // ipv6 + port + timestamp + connectionFailedCount
+	return as.serializeAddressWithSuffix(address, address.source)
+}
+
+func (as *addressStore) deserializeAddress(serializedAddress []byte) *address {
+	address := as.deserializeAddressWithoutSuffix(serializedAddress)
+	address.source = AddressSourceUnknown
+	if len(serializedAddress) > serializedAddressSourceOffset {
+		address.source = string(serializedAddress[serializedAddressSourceOffset:])
+	}
+	return address
+}
+
+func (as *addressStore) serializeBannedAddress(address *address) []byte {
+	return as.serializeAddressWithSuffix(address, address.banReason)
+}
+
+func (as *addressStore) deserializeBannedAddress(serializedAddress []byte) *address {
+	address := as.deserializeAddressWithoutSuffix(serializedAddress)
+	address.banReason = string(serializedAddress[serializedAddressSourceOffset:])
+	return address
+}
+
+func (as *addressStore) serializeAddressWithSuffix(address *address, suffix string) []byte {
+	serializedSize := serializedAddressSourceOffset + len(suffix)
 	serializedNetAddress := make([]byte, serializedSize)
 
 	copy(serializedNetAddress[:], address.netAddress.IP.To16()[:])
 	binary.LittleEndian.PutUint16(serializedNetAddress[16:], address.netAddress.Port)
 	binary.LittleEndian.PutUint64(serializedNetAddress[18:], uint64(address.netAddress.Timestamp.UnixMilliseconds()))
 	binary.LittleEndian.PutUint64(serializedNetAddress[26:], uint64(address.connectionFailedCount))
+	copy(serializedNetAddress[serializedAddressSourceOffset:], suffix)
 
 	return serializedNetAddress
 }
 
-func (as *addressStore) deserializeAddress(serializedAddress []byte) *address {
+func (as *addressStore) deserializeAddressWithoutSuffix(serializedAddress []byte) *address {
 	ip := make(net.IP, 16)
 	copy(ip[:], serializedAddress[:])
 
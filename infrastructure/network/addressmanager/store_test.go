@@ -36,6 +36,7 @@ func TestAddressSerialization(t *testing.T) {
 			Timestamp: mstime.Now(),
 		},
 		connectionFailedCount: 98465,
+		source:                AddressSourceDNSSeed,
 	}
 
 	serializedTestAddress := addressStore.serializeAddress(testAddress)
@@ -44,4 +45,11 @@ func TestAddressSerialization(t *testing.T) {
 		t.Fatalf("testAddress and deserializedTestAddress are not equal\n"+
 			"testAddress:%+v\ndeserializedTestAddress:%+v", testAddress, deserializedTestAddress)
 	}
+
+	// Addresses that were stored before sources were recorded have an unknown source
+	deserializedTestAddress = addressStore.deserializeAddress(serializedTestAddress[:serializedAddressSourceOffset])
+	if deserializedTestAddress.source != AddressSourceUnknown {
+		t.Fatalf("expected the source of an address stored without one to be %s, but got %s",
+			AddressSourceUnknown, deserializedTestAddress.source)
+	}
 }
@@ -0,0 +1,121 @@
+package addressmanager
+
+import (
+	"sort"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// The sources addresses are learned from, other than peers
+const (
+	// AddressSourceUnknown is the source of the addresses that were stored
+	// before sources were recorded, or that were added without one
+	AddressSourceUnknown  = "unknown"
+	AddressSourceDNSSeed  = "dns seed"
+	AddressSourceGRPCSeed = "grpc seed"
+
+	// AddressSourceSeedCache is the source of the addresses that were added
+	// from the results of an earlier seeding, because seeding failed
+	AddressSourceSeedCache = "seed cache"
+)
+
+// PeerAddressSource returns the source of the addresses learned from the peer
+// with the given address, including the peer's own address
+func PeerAddressSource(peerAddress string) string {
+	return "peer " + peerAddress
+}
+
+// AddressInfo describes a single address of the address manager
+type AddressInfo struct {
+	NetAddress *appmessage.NetAddress
+
+	// ConnectionFailedCount is the amount of connection attempts to the address
+	// that failed since the last successful one. Addresses that were never
+	// connected to start at 1.
+	ConnectionFailedCount uint64
+	Source                string
+	Group                 string
+}
+
+// SourceAddressCount is the amount of addresses learned from a single source
+type SourceAddressCount struct {
+	Source       string
+	AddressCount int
+}
+
+// TableInfo describes the contents of the address manager, to help debug why
+// a node struggles to find outbound peers
+type TableInfo struct {
+	AddressCount       int
+	BannedAddressCount int
+
+	// AddressCountsByConnectionFailures holds at index i the amount of
+	// addresses with a ConnectionFailedCount of i. It is 0 for the addresses
+	// that were connected to most recently, and an address is removed
+	// once it reaches connectionFailedCountForRemove.
+	AddressCountsByConnectionFailures []int
+
+	// AddressGroupCount is the amount of distinct network groups the
+	// addresses are in, as returned by GroupKey
+	AddressGroupCount int
+
+	// SourceAddressCounts are ordered from the source most addresses were
+	// learned from
+	SourceAddressCounts []*SourceAddressCount
+
+	// SampleAddresses are chosen at random the same way outbound peers are
+	SampleAddresses []*AddressInfo
+}
+
+// TableInfo returns a description of the contents of the address manager,
+// with up to sampleSize sample addresses
+func (am *AddressManager) TableInfo(sampleSize int) *TableInfo {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	addresses := am.store.getAllNotBanned()
+	info := &TableInfo{
+		AddressCount:                      len(addresses),
+		BannedAddressCount:                len(am.store.bannedAddresses),
+		AddressCountsByConnectionFailures: make([]int, connectionFailedCountForRemove),
+	}
+
+	groups := make(map[string]struct{})
+	sourceAddressCounts := make(map[string]int)
+	addressesByKey := make(map[addressKey]*address, len(addresses))
+	for _, address := range addresses {
+		if address.connectionFailedCount < uint64(len(info.AddressCountsByConnectionFailures)) {
+			info.AddressCountsByConnectionFailures[address.connectionFailedCount]++
+		}
+		groups[am.GroupKey(address.netAddress)] = struct{}{}
+		sourceAddressCounts[address.source]++
+		addressesByKey[netAddressKey(address.netAddress)] = address
+	}
+	info.AddressGroupCount = len(groups)
+
+	info.SourceAddressCounts = make([]*SourceAddressCount, 0, len(sourceAddressCounts))
+	for source, addressCount := range sourceAddressCounts {
+		info.SourceAddressCounts = append(info.SourceAddressCounts,
+			&SourceAddressCount{Source: source, AddressCount: addressCount})
+	}
+	sort.Slice(info.SourceAddressCounts, func(i, j int) bool {
+		if info.SourceAddressCounts[i].AddressCount != info.SourceAddressCounts[j].AddressCount {
+			return info.SourceAddressCounts[i].AddressCount > info.SourceAddressCounts[j].AddressCount
+		}
+		return info.SourceAddressCounts[i].Source < info.SourceAddressCounts[j].Source
+	})
+
+	sampleNetAddresses := am.random.RandomAddresses(addresses, sampleSize)
+	info.SampleAddresses = make([]*AddressInfo, len(sampleNetAddresses))
+	for i, netAddress := range sampleNetAddresses {
+		address := addressesByKey[netAddressKey(netAddress)]
+		info.SampleAddresses[i] = &AddressInfo{
+			NetAddress:            address.netAddress,
+			ConnectionFailedCount: address.connectionFailedCount,
+			Source:                address.source,
+			Group:                 am.GroupKey(address.netAddress),
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,71 @@
+package addressmanager
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+func TestTableInfo(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestTableInfo")
+	defer teardown()
+
+	seededAddress1 := &appmessage.NetAddress{IP: net.ParseIP("1.2.3.4"), Timestamp: mstime.Now()}
+	seededAddress2 := &appmessage.NetAddress{IP: net.ParseIP("1.2.5.6"), Timestamp: mstime.Now()}
+	peerAddress := &appmessage.NetAddress{IP: net.ParseIP("9.0.1.2"), Timestamp: mstime.Now()}
+	bannedAddress := &appmessage.NetAddress{IP: net.ParseIP("5.6.8.8"), Timestamp: mstime.Now()}
+
+	_, err := addressManager.AddAddressesFromSource(AddressSourceDNSSeed, seededAddress1, seededAddress2)
+	if err != nil {
+		t.Fatalf("AddAddressesFromSource() failed: %s", err)
+	}
+	peerSource := PeerAddressSource("7.7.7.7:16111")
+	_, err = addressManager.AddAddressesFromSource(peerSource, peerAddress, bannedAddress)
+	if err != nil {
+		t.Fatalf("AddAddressesFromSource() failed: %s", err)
+	}
+	err = addressManager.MarkConnectionSuccess(seededAddress1)
+	if err != nil {
+		t.Fatalf("MarkConnectionSuccess() failed: %s", err)
+	}
+	err = addressManager.Ban(bannedAddress, "test")
+	if err != nil {
+		t.Fatalf("Ban() failed: %s", err)
+	}
+
+	info := addressManager.TableInfo(10)
+	if info.AddressCount != 3 || info.BannedAddressCount != 1 {
+		t.Fatalf("Unexpected address counts. Want: 3 addresses and 1 banned, got: %d and %d",
+			info.AddressCount, info.BannedAddressCount)
+	}
+	if len(info.AddressCountsByConnectionFailures) != connectionFailedCountForRemove ||
+		info.AddressCountsByConnectionFailures[0] != 1 || info.AddressCountsByConnectionFailures[1] != 2 {
+		t.Fatalf("Unexpected address counts by connection failures: %v", info.AddressCountsByConnectionFailures)
+	}
+	if info.AddressGroupCount != 2 {
+		t.Fatalf("Unexpected address group count. Want: 2, got: %d", info.AddressGroupCount)
+	}
+	if len(info.SourceAddressCounts) != 2 ||
+		*info.SourceAddressCounts[0] != (SourceAddressCount{Source: AddressSourceDNSSeed, AddressCount: 2}) ||
+		*info.SourceAddressCounts[1] != (SourceAddressCount{Source: peerSource, AddressCount: 1}) {
+		t.Fatalf("Unexpected source address counts: %+v, %+v", info.SourceAddressCounts[0], info.SourceAddressCounts[1])
+	}
+	if len(info.SampleAddresses) != 3 {
+		t.Fatalf("Unexpected amount of sample addresses. Want: 3, got: %d", len(info.SampleAddresses))
+	}
+	for _, sampleAddress := range info.SampleAddresses {
+		if sampleAddress.NetAddress.IP.Equal(peerAddress.IP) && sampleAddress.Source != peerSource {
+			t.Fatalf("Unexpected source of %s: %s", peerAddress.IP, sampleAddress.Source)
+		}
+		if sampleAddress.NetAddress.IP.Equal(seededAddress1.IP) && sampleAddress.ConnectionFailedCount != 0 {
+			t.Fatalf("Unexpected connection failed count of %s: %d", seededAddress1.IP, sampleAddress.ConnectionFailedCount)
+		}
+	}
+
+	info = addressManager.TableInfo(1)
+	if len(info.SampleAddresses) != 1 {
+		t.Fatalf("Unexpected amount of sample addresses. Want: 1, got: %d", len(info.SampleAddresses))
+	}
+}
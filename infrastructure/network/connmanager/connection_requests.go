@@ -96,6 +96,8 @@ func (c *ConnectionManager) AddConnectionRequest(address string, isPermanent boo
 }
 
 func (c *ConnectionManager) addConnectionRequest(address string, isPermanent bool) {
+	address = normalizeOnionAddress(address)
+
 	c.connectionRequestsLock.Lock()
 	defer c.connectionRequestsLock.Unlock()
 	if _, ok := c.activeRequested[address]; ok {
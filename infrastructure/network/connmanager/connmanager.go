@@ -2,6 +2,7 @@ package connmanager
 
 import (
 	"net"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -35,15 +36,31 @@ type ConnectionManager struct {
 	activeRequested  map[string]*connectionRequest
 	pendingRequested map[string]*connectionRequest
 	activeOutgoing   map[string]struct{}
-	targetOutgoing   int
 	activeIncoming   map[string]struct{}
-	maxIncoming      int
+
+	targetOutgoing int
+	maxIncoming    int
+	peerLimitsLock sync.Mutex
 
 	stop                   uint32
 	connectionRequestsLock sync.RWMutex
 
 	resetLoopChan chan struct{}
 	loopTicker    *time.Ticker
+	loopInterval  time.Duration
+
+	// seedCache is nil if DNS seeding is disabled
+	seedCache                *dnsseed.SeedCache
+	seeded                   uint32
+	seedCacheFallbackStarted uint32
+
+	// lastBlockTime is the time, in Unix nanoseconds, the last new block arrived
+	lastBlockTime          int64
+	partitionTimeout       time.Duration
+	degradedSince          time.Time
+	networkHealthLock      sync.Mutex
+	isDegraded             bool
+	lastDegradedReseedTime time.Time
 }
 
 // New instantiates a new instance of a ConnectionManager
@@ -58,6 +75,9 @@ func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *
 		activeIncoming:   map[string]struct{}{},
 		resetLoopChan:    make(chan struct{}),
 		loopTicker:       time.NewTicker(connectionsLoopInterval),
+		loopInterval:     connectionsLoopInterval,
+		lastBlockTime:    time.Now().UnixNano(),
+		partitionTimeout: time.Duration(cfg.PartitionBlockIntervals) * cfg.NetParams().TargetTimePerBlock,
 	}
 
 	connectPeers := cfg.AddPeers
@@ -65,10 +85,17 @@ func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *
 		connectPeers = cfg.ConnectPeers
 	}
 
+	netAdapter.SetP2PDialer(c.dial, c.dialTimeout())
+
 	c.maxIncoming = cfg.MaxInboundPeers
 	c.targetOutgoing = cfg.TargetOutboundPeers
 
+	if !cfg.DisableDNSSeed {
+		c.seedCache = dnsseed.NewSeedCache(filepath.Join(cfg.AppDir, seedCacheFilename))
+	}
+
 	for _, connectPeer := range connectPeers {
+		connectPeer = normalizeOnionAddress(connectPeer)
 		c.pendingRequested[connectPeer] = &connectionRequest{
 			address:     connectPeer,
 			isPermanent: true,
@@ -96,6 +123,26 @@ func (c *ConnectionManager) Stop() {
 	c.run()
 }
 
+// SetPeerLimits changes the target number of outgoing connections and the
+// maximum number of incoming connections. The connections are brought within
+// the new limits on the next iteration of the connection loop, which is forced
+// if the loop is waiting for one.
+func (c *ConnectionManager) SetPeerLimits(targetOutgoing int, maxIncoming int) {
+	c.peerLimitsLock.Lock()
+	c.targetOutgoing = targetOutgoing
+	c.maxIncoming = maxIncoming
+	c.peerLimitsLock.Unlock()
+
+	c.runIfWaiting()
+}
+
+func (c *ConnectionManager) peerLimits() (targetOutgoing int, maxIncoming int) {
+	c.peerLimitsLock.Lock()
+	defer c.peerLimitsLock.Unlock()
+
+	return c.targetOutgoing, c.maxIncoming
+}
+
 func (c *ConnectionManager) run() {
 	c.resetLoopChan <- struct{}{}
 }
@@ -124,6 +171,8 @@ func (c *ConnectionManager) connectionsLoop() {
 
 		c.checkIncomingConnections(connSet)
 
+		c.checkNetworkHealth()
+
 		c.waitTillNextIteration()
 	}
 }
@@ -137,16 +186,16 @@ func (c *ConnectionManager) ConnectionCount() int {
 var ErrCannotBanPermanent = errors.New("ErrCannotBanPermanent")
 
 // Ban marks the given netConnection as banned
-func (c *ConnectionManager) Ban(netConnection *netadapter.NetConnection) error {
+func (c *ConnectionManager) Ban(netConnection *netadapter.NetConnection, reason string) error {
 	if c.isPermanent(netConnection.Address()) {
 		return errors.Wrapf(ErrCannotBanPermanent, "Cannot ban %s because it's a permanent connection", netConnection.Address())
 	}
 
-	return c.addressManager.Ban(netConnection.NetAddress())
+	return c.addressManager.Ban(netConnection.NetAddress(), reason)
 }
 
 // BanByIP bans the given IP and disconnects from all the connection with that IP.
-func (c *ConnectionManager) BanByIP(ip net.IP) error {
+func (c *ConnectionManager) BanByIP(ip net.IP, reason string) error {
 	ipHasPermanentConnection, err := c.ipHasPermanentConnection(ip)
 	if err != nil {
 		return err
@@ -163,7 +212,7 @@ func (c *ConnectionManager) BanByIP(ip net.IP) error {
 		}
 	}
 
-	return c.addressManager.Ban(appmessage.NewNetAddressIPPort(ip, 0))
+	return c.addressManager.Ban(appmessage.NewNetAddressIPPort(ip, 0), reason)
 }
 
 // IsBanned returns whether the given netConnection is banned
@@ -178,8 +227,9 @@ func (c *ConnectionManager) IsBanned(netConnection *netadapter.NetConnection) (b
 func (c *ConnectionManager) waitTillNextIteration() {
 	select {
 	case <-c.resetLoopChan:
-		c.loopTicker.Reset(connectionsLoopInterval)
+		c.loopTicker.Reset(c.loopInterval)
 	case <-c.loopTicker.C:
+		c.loopTicker.Reset(c.loopInterval)
 	}
 }
 
@@ -252,21 +302,3 @@ func (c *ConnectionManager) extractAddressIPs(address string) ([]net.IP, error)
 
 	return []net.IP{ip}, nil
 }
-
-func (c *ConnectionManager) seedFromDNS() {
-	cfg := c.cfg
-	if len(c.activeOutgoing) == 0 && !cfg.DisableDNSSeed {
-		dnsseed.SeedFromDNS(cfg.NetParams(), cfg.DNSSeed, false, nil,
-			cfg.Lookup, func(addresses []*appmessage.NetAddress) {
-				// Kaspad uses a lookup of the dns seeder here. Since seeder returns
-				// IPs of nodes and not its own IP, we can not know real IP of
-				// source. So we'll take first returned address as source.
-				_ = c.addressManager.AddAddresses(addresses...)
-			})
-
-		dnsseed.SeedFromGRPC(cfg.NetParams(), cfg.GRPCSeed, false, nil,
-			func(addresses []*appmessage.NetAddress) {
-				_ = c.addressManager.AddAddresses(addresses...)
-			})
-	}
-}
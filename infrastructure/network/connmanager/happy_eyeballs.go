@@ -0,0 +1,159 @@
+package connmanager
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
+	"github.com/pkg/errors"
+)
+
+// connectionAttemptDelay is how long a connection attempt is given before the
+// attempt to the next address of the same peer starts, as recommended by
+// RFC 8305
+const connectionAttemptDelay = 250 * time.Millisecond
+
+// dial opens the network connection of an outbound connection to the given
+// address. If the host of the address resolves to both IPv4 and IPv6
+// addresses, the connection attempts to them are raced, so that a broken IPv6
+// (or IPv4) connectivity doesn't fail the connection or delay it until the
+// dial times out. Tor addresses and .onion hosts are dialed through the onion
+// proxy.
+func (c *ConnectionManager) dial(ctx context.Context, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if onionHost, ok := onionHostOf(ip); ok {
+			return c.dialOnion(ctx, onionHost, port)
+		}
+		return c.dialTCP(ctx, address)
+	}
+	if addressmanager.IsOnionHost(host) {
+		return c.dialOnion(ctx, host, port)
+	}
+
+	ips, err := c.cfg.Lookup(host)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, errors.Errorf("no addresses found for %s", host)
+	}
+
+	sortedIPs := sortIPsForHappyEyeballs(ips)
+	addresses := make([]string, len(sortedIPs))
+	for i, ip := range sortedIPs {
+		addresses[i] = net.JoinHostPort(ip.String(), port)
+	}
+
+	return raceDials(ctx, addresses, connectionAttemptDelay, c.dialTCP)
+}
+
+// sortIPsForHappyEyeballs orders the given IPs so that IPv6 and IPv4 addresses
+// alternate, starting with IPv6, as described in RFC 8305 section 4. The
+// relative order of the addresses of each family is kept.
+func sortIPsForHappyEyeballs(ips []net.IP) []net.IP {
+	var ipv6s, ipv4s []net.IP
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			ipv4s = append(ipv4s, ip)
+		} else {
+			ipv6s = append(ipv6s, ip)
+		}
+	}
+
+	sortedIPs := make([]net.IP, 0, len(ips))
+	for i := 0; i < len(ipv6s) || i < len(ipv4s); i++ {
+		if i < len(ipv6s) {
+			sortedIPs = append(sortedIPs, ipv6s[i])
+		}
+		if i < len(ipv4s) {
+			sortedIPs = append(sortedIPs, ipv4s[i])
+		}
+	}
+	return sortedIPs
+}
+
+type dialResult struct {
+	address    string
+	connection net.Conn
+	err        error
+}
+
+// raceDials dials the given addresses in order, starting the next attempt once
+// an attempt fails or once attemptDelay passes, whichever comes first.
+// It returns the first connection that succeeds and closes the rest. If all
+// the attempts fail, the error of the last attempt is returned.
+func raceDials(ctx context.Context, addresses []string, attemptDelay time.Duration,
+	dial func(ctx context.Context, address string) (net.Conn, error)) (net.Conn, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The channel is buffered so that attempts that end after
+	// a winner was found don't block
+	results := make(chan *dialResult, len(addresses))
+	startAttempt := func(address string) {
+		spawn("raceDials-dial", func() {
+			connection, err := dial(ctx, address)
+			results <- &dialResult{address: address, connection: connection, err: err}
+		})
+	}
+
+	startAttempt(addresses[0])
+	nextAddressIndex := 1
+	pendingAttempts := 1
+	var lastErr error
+	for {
+		if pendingAttempts == 0 {
+			return nil, lastErr
+		}
+
+		var attemptDelayTimer <-chan time.Time
+		if nextAddressIndex < len(addresses) {
+			attemptDelayTimer = time.After(attemptDelay)
+		}
+
+		select {
+		case result := <-results:
+			pendingAttempts--
+			if result.err != nil {
+				log.Debugf("Couldn't dial %s: %s", result.address, result.err)
+				lastErr = result.err
+				if nextAddressIndex < len(addresses) {
+					startAttempt(addresses[nextAddressIndex])
+					nextAddressIndex++
+					pendingAttempts++
+				}
+				continue
+			}
+			if len(addresses) > 1 {
+				log.Debugf("Dialed %s first out of %d addresses", result.address, len(addresses))
+			}
+			cancel()
+			spawn("raceDials-closeLosers", func() {
+				closeLateConnections(results, pendingAttempts)
+			})
+			return result.connection, nil
+		case <-attemptDelayTimer:
+			startAttempt(addresses[nextAddressIndex])
+			nextAddressIndex++
+			pendingAttempts++
+		}
+	}
+}
+
+// closeLateConnections closes the connections of attempts that succeeded
+// after another attempt already won the race
+func closeLateConnections(results <-chan *dialResult, pendingAttempts int) {
+	for i := 0; i < pendingAttempts; i++ {
+		result := <-results
+		if result.err == nil {
+			result.connection.Close()
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package connmanager
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestSortIPsForHappyEyeballs(t *testing.T) {
+	ips := []net.IP{
+		net.ParseIP("1.1.1.1"),
+		net.ParseIP("2.2.2.2"),
+		net.ParseIP("3.3.3.3"),
+		net.ParseIP("::1"),
+		net.ParseIP("::2"),
+	}
+	expected := []net.IP{
+		net.ParseIP("::1"),
+		net.ParseIP("1.1.1.1"),
+		net.ParseIP("::2"),
+		net.ParseIP("2.2.2.2"),
+		net.ParseIP("3.3.3.3"),
+	}
+
+	sortedIPs := sortIPsForHappyEyeballs(ips)
+	if !reflect.DeepEqual(sortedIPs, expected) {
+		t.Fatalf("sortIPsForHappyEyeballs: expected %s but got %s", expected, sortedIPs)
+	}
+}
+
+type fakeConnection struct {
+	net.Conn
+	address string
+	closed  chan struct{}
+}
+
+func (c *fakeConnection) Close() error {
+	close(c.closed)
+	return nil
+}
+
+func TestRaceDials(t *testing.T) {
+	const attemptDelay = 10 * time.Millisecond
+
+	// hanging addresses never connect until the dial is canceled, failing addresses
+	// fail right away and the rest connect after the given delay.
+	type addressBehaviour struct {
+		hangs        bool
+		fails        bool
+		connectDelay time.Duration
+	}
+
+	tests := []struct {
+		name            string
+		addresses       map[string]addressBehaviour
+		order           []string
+		expectedWinner  string
+		expectedFailure bool
+	}{
+		{
+			name: "first address hangs",
+			addresses: map[string]addressBehaviour{
+				"[::1]:16111":   {hangs: true},
+				"1.1.1.1:16111": {},
+			},
+			order:          []string{"[::1]:16111", "1.1.1.1:16111"},
+			expectedWinner: "1.1.1.1:16111",
+		},
+		{
+			name: "first address fails",
+			addresses: map[string]addressBehaviour{
+				"[::1]:16111":   {fails: true},
+				"1.1.1.1:16111": {},
+			},
+			order:          []string{"[::1]:16111", "1.1.1.1:16111"},
+			expectedWinner: "1.1.1.1:16111",
+		},
+		{
+			name: "first address is slower than the delay but still wins",
+			addresses: map[string]addressBehaviour{
+				"[::1]:16111":   {connectDelay: 2 * attemptDelay},
+				"1.1.1.1:16111": {connectDelay: 10 * attemptDelay},
+			},
+			order:          []string{"[::1]:16111", "1.1.1.1:16111"},
+			expectedWinner: "[::1]:16111",
+		},
+		{
+			name: "all addresses fail",
+			addresses: map[string]addressBehaviour{
+				"[::1]:16111":   {fails: true},
+				"1.1.1.1:16111": {fails: true},
+			},
+			order:           []string{"[::1]:16111", "1.1.1.1:16111"},
+			expectedFailure: true,
+		},
+	}
+
+	for _, test := range tests {
+		lateConnections := make(chan *fakeConnection, len(test.order))
+		dial := func(ctx context.Context, address string) (net.Conn, error) {
+			behaviour := test.addresses[address]
+			if behaviour.fails {
+				return nil, errors.Errorf("failed connecting to %s", address)
+			}
+			if behaviour.hangs {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			time.Sleep(behaviour.connectDelay)
+			connection := &fakeConnection{address: address, closed: make(chan struct{})}
+			lateConnections <- connection
+			return connection, nil
+		}
+
+		connection, err := raceDials(context.Background(), test.order, attemptDelay, dial)
+		if test.expectedFailure {
+			if err == nil {
+				t.Fatalf("%s: expected an error but got none", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: raceDials: %s", test.name, err)
+		}
+		winner := connection.(*fakeConnection)
+		if winner.address != test.expectedWinner {
+			t.Fatalf("%s: expected %s to win but %s won", test.name, test.expectedWinner, winner.address)
+		}
+
+		// Every connection but the winner must eventually be closed
+		for _, behaviour := range test.addresses {
+			if behaviour.fails || behaviour.hangs {
+				continue
+			}
+			lateConnection := <-lateConnections
+			if lateConnection == winner {
+				continue
+			}
+			select {
+			case <-lateConnection.closed:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: the connection to %s wasn't closed", test.name, lateConnection.address)
+			}
+		}
+	}
+}
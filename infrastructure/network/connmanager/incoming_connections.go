@@ -3,13 +3,14 @@ package connmanager
 // checkIncomingConnections makes sure there's no more than maxIncoming incoming connections
 // if there are - it randomly disconnects enough to go below that number
 func (c *ConnectionManager) checkIncomingConnections(incomingConnectionSet connectionSet) {
-	if len(incomingConnectionSet) <= c.maxIncoming {
+	_, maxIncoming := c.peerLimits()
+	if len(incomingConnectionSet) <= maxIncoming {
 		return
 	}
 
-	numConnectionsOverMax := len(incomingConnectionSet) - c.maxIncoming
+	numConnectionsOverMax := len(incomingConnectionSet) - maxIncoming
 	log.Debugf("Got %d incoming connections while only %d are allowed. Disconnecting "+
-		"%d", len(incomingConnectionSet), c.maxIncoming, numConnectionsOverMax)
+		"%d", len(incomingConnectionSet), maxIncoming, numConnectionsOverMax)
 
 	// randomly disconnect nodes until the number of incoming connections is smaller than maxIncoming
 	for _, connection := range incomingConnectionSet {
@@ -0,0 +1,118 @@
+package connmanager
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// degradedConnectionsLoopInterval replaces connectionsLoopInterval while
+	// the connection of the node to the network is degraded, so that it
+	// reconnects more aggressively
+	degradedConnectionsLoopInterval = 5 * time.Second
+
+	// degradedReseedInterval is how often the node reseeds while the
+	// connection of the node to the network is degraded
+	degradedReseedInterval = time.Minute
+)
+
+// NetworkHealth describes whether the connection of the node to the network
+// is degraded, either because it has too few peers or because no new blocks
+// arrived for a while, which may indicate that the node is partitioned from
+// the rest of the network
+type NetworkHealth struct {
+	IsDegraded bool
+
+	// Reasons describes why the connection is degraded. It's empty if it isn't.
+	Reasons []string
+
+	// DegradedSince is the zero time if the connection isn't degraded
+	DegradedSince time.Time
+
+	PeerCount int
+	MinPeers  int
+
+	// LastBlockTime is when the last new block arrived, or when the node
+	// started if none arrived since
+	LastBlockTime time.Time
+}
+
+// MarkBlockReceived records that a new block arrived from the network
+func (c *ConnectionManager) MarkBlockReceived() {
+	atomic.StoreInt64(&c.lastBlockTime, time.Now().UnixNano())
+}
+
+// NetworkHealth evaluates whether the connection of the node to the network
+// is degraded
+func (c *ConnectionManager) NetworkHealth() *NetworkHealth {
+	now := time.Now()
+	health := &NetworkHealth{
+		PeerCount:     c.ConnectionCount(),
+		MinPeers:      c.cfg.MinPeers,
+		LastBlockTime: time.Unix(0, atomic.LoadInt64(&c.lastBlockTime)),
+	}
+	if health.PeerCount < health.MinPeers {
+		health.Reasons = append(health.Reasons,
+			fmt.Sprintf("connected to %d peers, fewer than the minimum of %d", health.PeerCount, health.MinPeers))
+	}
+	if c.partitionTimeout > 0 && now.Sub(health.LastBlockTime) > c.partitionTimeout {
+		health.Reasons = append(health.Reasons,
+			fmt.Sprintf("no new blocks arrived since %s", health.LastBlockTime.Format(time.RFC3339)))
+	}
+	health.IsDegraded = len(health.Reasons) > 0
+
+	c.networkHealthLock.Lock()
+	defer c.networkHealthLock.Unlock()
+
+	if !health.IsDegraded {
+		c.degradedSince = time.Time{}
+	} else if c.degradedSince.IsZero() {
+		c.degradedSince = now
+	}
+	health.DegradedSince = c.degradedSince
+	return health
+}
+
+// checkNetworkHealth reconnects and reseeds aggressively while the connection
+// of the node to the network is degraded
+func (c *ConnectionManager) checkNetworkHealth() {
+	health := c.NetworkHealth()
+	if health.IsDegraded != c.isDegraded {
+		c.isDegraded = health.IsDegraded
+		if health.IsDegraded {
+			log.Warnf("The connection to the network is degraded: %s. Reconnecting and reseeding "+
+				"more aggressively", strings.Join(health.Reasons, "; "))
+		} else {
+			log.Infof("The connection to the network has recovered")
+		}
+	}
+	if !health.IsDegraded {
+		c.loopInterval = connectionsLoopInterval
+		return
+	}
+	c.loopInterval = degradedConnectionsLoopInterval
+
+	c.retryPendingRequestedConnectionsNow()
+
+	if !c.cfg.DisableDNSSeed && time.Since(c.lastDegradedReseedTime) > degradedReseedInterval {
+		c.lastDegradedReseedTime = time.Now()
+		log.Infof("Reseeding since the connection to the network is degraded")
+		c.seed()
+	}
+}
+
+// retryPendingRequestedConnectionsNow resets the backoff of the pending
+// connection requests, so that they're retried in the next iteration
+func (c *ConnectionManager) retryPendingRequestedConnectionsNow() {
+	c.connectionRequestsLock.Lock()
+	defer c.connectionRequestsLock.Unlock()
+
+	now := time.Now()
+	for _, connReq := range c.pendingRequested {
+		if connReq.nextAttempt.After(now) {
+			connReq.nextAttempt = now
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package connmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+)
+
+func TestNetworkHealth(t *testing.T) {
+	cfg := config.DefaultConfig()
+	netAdapter, err := netadapter.NewNetAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewNetAdapter: %+v", err)
+	}
+	cfg.MinPeers = 0
+	c := &ConnectionManager{
+		cfg:              cfg,
+		netAdapter:       netAdapter,
+		lastBlockTime:    time.Now().UnixNano(),
+		partitionTimeout: time.Minute,
+	}
+
+	health := c.NetworkHealth()
+	if health.IsDegraded || len(health.Reasons) != 0 || !health.DegradedSince.IsZero() {
+		t.Fatalf("Expected the connection not to be degraded, but got %+v", health)
+	}
+
+	// Too few peers
+	cfg.MinPeers = 1
+	health = c.NetworkHealth()
+	if !health.IsDegraded || len(health.Reasons) != 1 || health.DegradedSince.IsZero() {
+		t.Fatalf("Expected the connection to be degraded for having too few peers, but got %+v", health)
+	}
+	degradedSince := health.DegradedSince
+
+	// No new blocks for longer than the partition timeout
+	c.lastBlockTime = time.Now().Add(-2 * c.partitionTimeout).UnixNano()
+	health = c.NetworkHealth()
+	if !health.IsDegraded || len(health.Reasons) != 2 || !health.DegradedSince.Equal(degradedSince) {
+		t.Fatalf("Expected the connection to remain degraded for both reasons since %s, but got %+v",
+			degradedSince, health)
+	}
+
+	cfg.MinPeers = 0
+	c.MarkBlockReceived()
+	health = c.NetworkHealth()
+	if health.IsDegraded || !health.DegradedSince.IsZero() {
+		t.Fatalf("Expected the connection to recover, but got %+v", health)
+	}
+
+	// A zero partition timeout disables the check
+	c.partitionTimeout = 0
+	c.lastBlockTime = 0
+	if health = c.NetworkHealth(); health.IsDegraded {
+		t.Fatalf("Expected a zero partition timeout to disable the check, but got %+v", health)
+	}
+}
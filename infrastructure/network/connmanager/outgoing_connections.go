@@ -3,12 +3,21 @@ package connmanager
 import "github.com/kaspanet/kaspad/app/appmessage"
 
 // checkOutgoingConnections goes over all activeOutgoing and makes sure they are still active.
-// Then it opens connections so that we have targetOutgoing active connections
+// Then it opens connections so that we have targetOutgoing active connections, or
+// closes the connections over targetOutgoing if it was lowered
 func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
+	targetOutgoing, _ := c.peerLimits()
+
 	for address := range c.activeOutgoing {
 		connection, ok := connSet.get(address)
 		if ok { // connection is still connected
 			connSet.remove(connection)
+			if len(c.activeOutgoing) > targetOutgoing {
+				log.Debugf("Disconnecting %s since the target of outgoing connections was lowered to %d",
+					connection, targetOutgoing)
+				connection.Disconnect()
+				delete(c.activeOutgoing, address)
+			}
 			continue
 		}
 
@@ -23,21 +32,21 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 	}
 
 	liveConnections := len(c.activeOutgoing)
-	if c.targetOutgoing == liveConnections {
+	if liveConnections >= targetOutgoing {
 		return
 	}
 
 	log.Debugf("Have got %d outgoing connections out of target %d, adding %d more",
-		liveConnections, c.targetOutgoing, c.targetOutgoing-liveConnections)
+		liveConnections, targetOutgoing, targetOutgoing-liveConnections)
 
-	connectionsNeededCount := c.targetOutgoing - len(c.activeOutgoing)
+	connectionsNeededCount := targetOutgoing - len(c.activeOutgoing)
 	netAddresses := c.addressManager.RandomAddresses(connectionsNeededCount, connectedAddresses)
 
 	for _, netAddress := range netAddresses {
 		addressString := netAddress.TCPAddress().String()
 
 		log.Debugf("Connecting to %s because we have %d outgoing connections and the target is "+
-			"%d", addressString, len(c.activeOutgoing), c.targetOutgoing)
+			"%d", addressString, len(c.activeOutgoing), targetOutgoing)
 
 		err := c.initiateConnection(addressString)
 		if err != nil {
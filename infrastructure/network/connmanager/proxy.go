@@ -0,0 +1,135 @@
+package connmanager
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
+	"github.com/pkg/errors"
+)
+
+const (
+	// directDialTimeout is how long an outbound connection is given to be
+	// established when dialing directly
+	directDialTimeout = 1 * time.Second
+
+	// proxiedDialTimeout is how long an outbound connection is given to be
+	// established when dialing through a SOCKS5 proxy, which for tor
+	// includes building a circuit
+	proxiedDialTimeout = 15 * time.Second
+)
+
+// proxyDialFunc is the dial function of a SOCKS5 proxy
+type proxyDialFunc func(network string, address string, timeout time.Duration) (net.Conn, error)
+
+// proxiedConnection is a connection through a SOCKS5 proxy. It reports the
+// address it was dialed to as its remote address, rather than the address of
+// the proxy, since connections are identified by their remote TCP address.
+type proxiedConnection struct {
+	net.Conn
+	remoteAddress *net.TCPAddr
+}
+
+// RemoteAddr returns the address the connection was dialed to
+func (c *proxiedConnection) RemoteAddr() net.Addr {
+	return c.remoteAddress
+}
+
+func (c *ConnectionManager) dialTimeout() time.Duration {
+	if c.cfg.Proxy != "" || c.cfg.OnionProxy != "" {
+		return proxiedDialTimeout
+	}
+	return directDialTimeout
+}
+
+// dialTCP dials the given IP address, through the proxy if one is configured
+func (c *ConnectionManager) dialTCP(ctx context.Context, address string) (net.Conn, error) {
+	if c.cfg.Proxy == "" {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+
+	remoteAddress, err := net.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return proxiedDial(ctx, c.cfg.Dial, address, remoteAddress)
+}
+
+// dialOnion dials the given .onion host through the onion proxy
+func (c *ConnectionManager) dialOnion(ctx context.Context, host string, port string) (net.Conn, error) {
+	if c.cfg.OnionDial == nil {
+		return nil, errors.Errorf("can't connect to %s: tor hidden services are reachable only "+
+			"with --onion or --proxy, and without --noonion", host)
+	}
+
+	ip, err := addressmanager.OnionCatIP(host)
+	if err != nil {
+		return nil, err
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid port %s", port)
+	}
+	remoteAddress := &net.TCPAddr{IP: ip, Port: portNumber}
+	return proxiedDial(ctx, c.cfg.OnionDial, net.JoinHostPort(host, port), remoteAddress)
+}
+
+// proxiedDial dials the given address with the given proxy dial function,
+// which doesn't support contexts, and gives up once ctx is done
+func proxiedDial(ctx context.Context, dial proxyDialFunc, address string, remoteAddress *net.TCPAddr) (net.Conn, error) {
+	timeout := proxiedDialTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	// The channel is buffered so that a dial that ends after ctx
+	// is done doesn't block
+	results := make(chan *dialResult, 1)
+	spawn("proxiedDial", func() {
+		connection, err := dial("tcp", address, timeout)
+		results <- &dialResult{address: address, connection: connection, err: err}
+	})
+
+	select {
+	case result := <-results:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return &proxiedConnection{Conn: result.connection, remoteAddress: remoteAddress}, nil
+	case <-ctx.Done():
+		spawn("proxiedDial-closeLate", func() {
+			closeLateConnections(results, 1)
+		})
+		return nil, ctx.Err()
+	}
+}
+
+// normalizeOnionAddress returns the address of the Tor address that the
+// given .onion address is mapped to, so that the connection to it, whose
+// remote address is the Tor address, is matched with the request. Any other
+// address is returned as is.
+func normalizeOnionAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || !addressmanager.IsOnionHost(host) {
+		return address
+	}
+	ip, err := addressmanager.OnionCatIP(host)
+	if err != nil {
+		return address
+	}
+	return net.JoinHostPort(ip.String(), port)
+}
+
+// onionHostOf returns the .onion host the given IP stands for, if it's a Tor
+// address
+func onionHostOf(ip net.IP) (host string, ok bool) {
+	netAddress := appmessage.NewNetAddressIPPort(ip, 0)
+	if !addressmanager.IsOnionCatTor(netAddress) {
+		return "", false
+	}
+	return addressmanager.OnionHost(netAddress), true
+}
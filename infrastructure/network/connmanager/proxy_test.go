@@ -0,0 +1,43 @@
+package connmanager
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+func TestDialOnion(t *testing.T) {
+	const onionAddress = "abcdefghijklmnop.onion:16111"
+	const torAddress = "[fd87:d87e:eb43:44:3214:c742:54b6:35cf]:16111"
+	if normalizedAddress := normalizeOnionAddress(onionAddress); normalizedAddress != torAddress {
+		t.Fatalf("Unexpected normalized address. Want: %s, got: %s", torAddress, normalizedAddress)
+	}
+
+	cfg := config.DefaultConfig()
+	connectionManager := &ConnectionManager{cfg: cfg}
+	_, err := connectionManager.dial(context.Background(), torAddress)
+	if err == nil || !strings.Contains(err.Error(), "tor hidden services are reachable only") {
+		t.Fatalf("Expected dialing a tor address without an onion proxy to fail, but got %v", err)
+	}
+
+	var dialedAddress string
+	cfg.OnionProxy = "127.0.0.1:9050"
+	cfg.OnionDial = func(network string, address string, timeout time.Duration) (net.Conn, error) {
+		dialedAddress = address
+		return &fakeConnection{address: address, closed: make(chan struct{})}, nil
+	}
+	connection, err := connectionManager.dial(context.Background(), torAddress)
+	if err != nil {
+		t.Fatalf("dial: %s", err)
+	}
+	if dialedAddress != onionAddress {
+		t.Fatalf("Expected the onion proxy to dial %s, but it dialed %s", onionAddress, dialedAddress)
+	}
+	if connection.RemoteAddr().String() != torAddress {
+		t.Fatalf("Unexpected remote address. Want: %s, got: %s", torAddress, connection.RemoteAddr())
+	}
+}
@@ -0,0 +1,157 @@
+package connmanager
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/dnsseed"
+	"github.com/pkg/errors"
+)
+
+const (
+	seedCacheFilename = "seedcache.json"
+
+	// seedCacheFallbackDelay is how long seeding may take before the node
+	// falls back to the addresses of an earlier seeding
+	seedCacheFallbackDelay = 10 * time.Second
+
+	// seedRetryInterval is how often seeding is retried in the background
+	// while the node relies on the addresses of an earlier seeding
+	seedRetryInterval = 5 * time.Minute
+
+	// refreshSeedsTimeout is how long RefreshSeeds waits for the seeders
+	refreshSeedsTimeout = 30 * time.Second
+)
+
+func (c *ConnectionManager) seedFromDNS() {
+	cfg := c.cfg
+	if len(c.activeOutgoing) == 0 && !cfg.DisableDNSSeed {
+		c.seed()
+
+		if atomic.LoadUint32(&c.seeded) == 0 && atomic.CompareAndSwapUint32(&c.seedCacheFallbackStarted, 0, 1) {
+			spawn("ConnectionManager.fallBackToSeedCache", c.fallBackToSeedCache)
+		}
+	}
+}
+
+func (c *ConnectionManager) seed() {
+	c.seedWithNewAddressCount(nil)
+}
+
+// seedWithNewAddressCount seeds from the DNS and gRPC seeders in the
+// background. newAddressCount, unless it's nil, is increased by the number of
+// addresses each seeder provides that weren't known before. The returned wait
+// groups are done once all the seeders responded.
+func (c *ConnectionManager) seedWithNewAddressCount(newAddressCount *uint32) (dnsWaitGroup, grpcWaitGroup *sync.WaitGroup) {
+	cfg := c.cfg
+	addSeededAddresses := func(source string, addresses []*appmessage.NetAddress) {
+		count, err := c.addressManager.AddAddressesFromSource(source, addresses...)
+		if err != nil {
+			log.Warnf("Couldn't add the seeded addresses: %s", err)
+		}
+		if newAddressCount != nil {
+			atomic.AddUint32(newAddressCount, uint32(count))
+		}
+		c.onSeeded(addresses)
+	}
+
+	dnsWaitGroup = dnsseed.SeedFromDNS(cfg.NetParams(), cfg.DNSSeed, false, nil,
+		cfg.Lookup, func(addresses []*appmessage.NetAddress) {
+			// Kaspad uses a lookup of the dns seeder here. Since seeder returns
+			// IPs of nodes and not its own IP, we can not know real IP of
+			// source. So we'll take first returned address as source.
+			addSeededAddresses(addressmanager.AddressSourceDNSSeed, addresses)
+		})
+
+	// The gRPC seeders are dialed directly, so they're skipped when
+	// connecting through a proxy
+	if cfg.Proxy != "" {
+		return dnsWaitGroup, &sync.WaitGroup{}
+	}
+	grpcWaitGroup = dnsseed.SeedFromGRPC(cfg.NetParams(), cfg.GRPCSeed, false, nil,
+		func(addresses []*appmessage.NetAddress) {
+			addSeededAddresses(addressmanager.AddressSourceGRPCSeed, addresses)
+		})
+	return dnsWaitGroup, grpcWaitGroup
+}
+
+// RefreshSeeds seeds from the DNS and gRPC seeders on demand, regardless of
+// whether the node has outgoing connections, and returns the number of
+// addresses they provided that weren't known before. It waits for the seeders
+// for at most refreshSeedsTimeout, and returns whether they all responded in
+// time. Addresses provided afterwards are still added.
+func (c *ConnectionManager) RefreshSeeds() (newAddressCount uint32, isComplete bool, err error) {
+	if c.cfg.DisableDNSSeed {
+		return 0, false, errors.New("seeding is disabled by --nodnsseed")
+	}
+
+	log.Infof("Refreshing the addresses from the seeders on demand")
+	var count uint32
+	dnsWaitGroup, grpcWaitGroup := c.seedWithNewAddressCount(&count)
+	done := make(chan struct{})
+	spawn("ConnectionManager.RefreshSeeds-wait", func() {
+		dnsWaitGroup.Wait()
+		grpcWaitGroup.Wait()
+		close(done)
+	})
+
+	select {
+	case <-done:
+		isComplete = true
+	case <-time.After(refreshSeedsTimeout):
+		log.Infof("Not all the seeders responded within %s", refreshSeedsTimeout)
+	}
+	newAddressCount = atomic.LoadUint32(&count)
+	log.Infof("Refreshing from the seeders added %d new addresses", newAddressCount)
+	c.runIfWaiting()
+	return newAddressCount, isComplete, nil
+}
+
+func (c *ConnectionManager) onSeeded(addresses []*appmessage.NetAddress) {
+	atomic.StoreUint32(&c.seeded, 1)
+
+	err := c.seedCache.Add(addresses)
+	if err != nil {
+		log.Warnf("Couldn't cache the seeded addresses: %s", err)
+	}
+}
+
+// fallBackToSeedCache adds the addresses of earlier seedings to the address
+// manager if seeding doesn't succeed in time, and keeps retrying seeding in the
+// background until it does. It does so at most once per run of the node.
+func (c *ConnectionManager) fallBackToSeedCache() {
+	time.Sleep(seedCacheFallbackDelay)
+	if atomic.LoadUint32(&c.seeded) != 0 || atomic.LoadUint32(&c.stop) != 0 {
+		return
+	}
+
+	addresses, err := c.seedCache.Addresses()
+	if err != nil {
+		log.Warnf("Couldn't read the seed cache: %s", err)
+	}
+	if len(addresses) > 0 {
+		log.Infof("Seeding hasn't succeeded yet - connecting to the %d addresses of earlier seedings", len(addresses))
+		_, _ = c.addressManager.AddAddressesFromSource(addressmanager.AddressSourceSeedCache, addresses...)
+		c.runIfWaiting()
+	}
+
+	for atomic.LoadUint32(&c.seeded) == 0 && atomic.LoadUint32(&c.stop) == 0 {
+		time.Sleep(seedRetryInterval)
+		if atomic.LoadUint32(&c.seeded) == 0 && atomic.LoadUint32(&c.stop) == 0 {
+			log.Infof("Retrying seeding")
+			c.seed()
+		}
+	}
+}
+
+// runIfWaiting forces the next iteration of the connection loop if it's
+// waiting for one. Unlike run, it may be called after the loop stopped.
+func (c *ConnectionManager) runIfWaiting() {
+	select {
+	case c.resetLoopChan <- struct{}{}:
+	default:
+	}
+}
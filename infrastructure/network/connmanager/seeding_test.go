@@ -0,0 +1,66 @@
+package connmanager
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+)
+
+func TestRefreshSeeds(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.AppDir = t.TempDir()
+	cfg.DNSSeed = "seed.test"
+	seededIPs := []net.IP{net.ParseIP("173.194.115.66"), net.ParseIP("173.194.115.67")}
+	cfg.Lookup = func(string) ([]net.IP, error) {
+		return seededIPs, nil
+	}
+
+	database, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %+v", err)
+	}
+	defer database.Close()
+	addressManager, err := addressmanager.New(addressmanager.NewConfig(cfg), database)
+	if err != nil {
+		t.Fatalf("addressmanager.New: %+v", err)
+	}
+	netAdapter, err := netadapter.NewNetAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewNetAdapter: %+v", err)
+	}
+	c, err := New(cfg, netAdapter, addressManager)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+
+	newAddressCount, isComplete, err := c.RefreshSeeds()
+	if err != nil {
+		t.Fatalf("RefreshSeeds: %+v", err)
+	}
+	if newAddressCount != 2 || !isComplete {
+		t.Fatalf("Expected both seeded addresses to be new, but got %d new addresses (complete: %t)",
+			newAddressCount, isComplete)
+	}
+	if len(addressManager.Addresses()) != 2 {
+		t.Fatalf("Expected the seeded addresses to be added to the address manager")
+	}
+
+	seededIPs = append(seededIPs, net.ParseIP("173.194.115.68"))
+	newAddressCount, _, err = c.RefreshSeeds()
+	if err != nil {
+		t.Fatalf("RefreshSeeds: %+v", err)
+	}
+	if newAddressCount != 1 {
+		t.Fatalf("Expected only the address that wasn't seeded before to be new, but got %d", newAddressCount)
+	}
+
+	cfg.DisableDNSSeed = true
+	_, _, err = c.RefreshSeeds()
+	if err == nil {
+		t.Fatalf("Expected RefreshSeeds to fail while seeding is disabled")
+	}
+}
@@ -0,0 +1,138 @@
+package dnsseed
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+// maxSeedCacheAddressCount is the maximum amount of addresses a SeedCache
+// keeps. Once it's reached, the addresses that were seeded longest ago are
+// dropped first.
+const maxSeedCacheAddressCount = 1000
+
+// SeedCache keeps the addresses returned by the last successful seedings in a
+// file, so that a node may find peers even if the seeders are unreachable
+// when it starts
+type SeedCache struct {
+	path  string
+	mutex sync.Mutex
+}
+
+type seedCacheEntry struct {
+	Address string `json:"address"`
+
+	// SeededAt is the time the address was last returned by a seeder, in
+	// milliseconds since the epoch
+	SeededAt int64 `json:"seededAt"`
+}
+
+// NewSeedCache returns a SeedCache that keeps its addresses in the file at
+// the given path
+func NewSeedCache(path string) *SeedCache {
+	return &SeedCache{path: path}
+}
+
+// Addresses returns the cached addresses, each with the time it was last
+// seeded as its timestamp. It returns no addresses if nothing was cached yet.
+func (sc *SeedCache) Addresses() ([]*appmessage.NetAddress, error) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	entries, err := sc.read()
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]*appmessage.NetAddress, 0, len(entries))
+	for _, entry := range entries {
+		host, portString, err := net.SplitHostPort(entry.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "malformed address %s in the seed cache %s", entry.Address, sc.path)
+		}
+		ip := net.ParseIP(host)
+		port, err := strconv.ParseUint(portString, 10, 16)
+		if ip == nil || err != nil {
+			return nil, errors.Errorf("malformed address %s in the seed cache %s", entry.Address, sc.path)
+		}
+		addresses = append(addresses,
+			appmessage.NewNetAddressTimestamp(mstime.UnixMilliseconds(entry.SeededAt), ip, uint16(port)))
+	}
+	return addresses, nil
+}
+
+// Add adds the given seeded addresses to the cache, or marks them as seeded
+// now if they are already cached
+func (sc *SeedCache) Add(addresses []*appmessage.NetAddress) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	entries, err := sc.read()
+	if err != nil {
+		return err
+	}
+	seededAt := make(map[string]int64, len(entries)+len(addresses))
+	for _, entry := range entries {
+		seededAt[entry.Address] = entry.SeededAt
+	}
+	now := mstime.Now().UnixMilliseconds()
+	for _, address := range addresses {
+		seededAt[address.TCPAddress().String()] = now
+	}
+
+	entries = make([]*seedCacheEntry, 0, len(seededAt))
+	for address, addressSeededAt := range seededAt {
+		entries = append(entries, &seedCacheEntry{Address: address, SeededAt: addressSeededAt})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].SeededAt != entries[j].SeededAt {
+			return entries[i].SeededAt > entries[j].SeededAt
+		}
+		return entries[i].Address < entries[j].Address
+	})
+	if len(entries) > maxSeedCacheAddressCount {
+		entries = entries[:maxSeedCacheAddressCount]
+	}
+	return sc.write(entries)
+}
+
+func (sc *SeedCache) read() ([]*seedCacheEntry, error) {
+	content, err := os.ReadFile(sc.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "couldn't read the seed cache %s", sc.path)
+	}
+	var entries []*seedCacheEntry
+	err = json.Unmarshal(content, &entries)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't parse the seed cache %s", sc.path)
+	}
+	return entries, nil
+}
+
+// write replaces the cache file through a temporary file, so that a crash
+// never leaves it half written
+func (sc *SeedCache) write(entries []*seedCacheEntry) error {
+	content, err := json.Marshal(entries)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	temporaryPath := sc.path + ".tmp"
+	err = os.WriteFile(temporaryPath, content, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't write the seed cache %s", temporaryPath)
+	}
+	err = os.Rename(temporaryPath, sc.path)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't replace the seed cache %s", sc.path)
+	}
+	return nil
+}
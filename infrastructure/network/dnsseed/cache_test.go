@@ -0,0 +1,94 @@
+package dnsseed
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func TestSeedCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seedcache.json")
+	cache := NewSeedCache(path)
+
+	addresses, err := cache.Addresses()
+	if err != nil {
+		t.Fatalf("Addresses: %+v", err)
+	}
+	if len(addresses) != 0 {
+		t.Fatalf("expected an empty cache, but got %d addresses", len(addresses))
+	}
+
+	err = cache.Add([]*appmessage.NetAddress{
+		appmessage.NewNetAddressIPPort(net.ParseIP("1.2.3.4"), 16111),
+		appmessage.NewNetAddressIPPort(net.ParseIP("2001:470::1"), 16111),
+	})
+	if err != nil {
+		t.Fatalf("Add: %+v", err)
+	}
+	err = cache.Add([]*appmessage.NetAddress{
+		appmessage.NewNetAddressIPPort(net.ParseIP("1.2.3.4"), 16111),
+		appmessage.NewNetAddressIPPort(net.ParseIP("5.6.7.8"), 16112),
+	})
+	if err != nil {
+		t.Fatalf("Add: %+v", err)
+	}
+
+	// A new cache on the same file finds the addresses of both seedings
+	addresses, err = NewSeedCache(path).Addresses()
+	if err != nil {
+		t.Fatalf("Addresses: %+v", err)
+	}
+	expectedAddresses := map[string]struct{}{"1.2.3.4:16111": {}, "[2001:470::1]:16111": {}, "5.6.7.8:16112": {}}
+	if len(addresses) != len(expectedAddresses) {
+		t.Fatalf("expected %d addresses, but got %d", len(expectedAddresses), len(addresses))
+	}
+	for _, address := range addresses {
+		if _, ok := expectedAddresses[address.TCPAddress().String()]; !ok {
+			t.Fatalf("unexpected address %s", address.TCPAddress())
+		}
+		if address.Timestamp.UnixMilliseconds() == 0 {
+			t.Fatalf("expected address %s to have the time it was seeded", address.TCPAddress())
+		}
+	}
+
+	err = os.WriteFile(path, []byte("not json"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+	_, err = cache.Addresses()
+	if err == nil {
+		t.Fatalf("expected a corrupted cache to fail to load")
+	}
+}
+
+func TestSeedCacheDropsOldestAddresses(t *testing.T) {
+	cache := NewSeedCache(filepath.Join(t.TempDir(), "seedcache.json"))
+
+	addresses := make([]*appmessage.NetAddress, maxSeedCacheAddressCount)
+	for i := range addresses {
+		addresses[i] = appmessage.NewNetAddressIPPort(net.IPv4(10, 0, byte(i/256), byte(i%256)), 16111)
+	}
+	err := cache.Add(addresses)
+	if err != nil {
+		t.Fatalf("Add: %+v", err)
+	}
+	newAddress := appmessage.NewNetAddressIPPort(net.ParseIP("1.2.3.4"), 16111)
+	err = cache.Add([]*appmessage.NetAddress{newAddress})
+	if err != nil {
+		t.Fatalf("Add: %+v", err)
+	}
+
+	cachedAddresses, err := cache.Addresses()
+	if err != nil {
+		t.Fatalf("Addresses: %+v", err)
+	}
+	if len(cachedAddresses) != maxSeedCacheAddressCount {
+		t.Fatalf("expected %d addresses, but got %d", maxSeedCacheAddressCount, len(cachedAddresses))
+	}
+	if !cachedAddresses[0].IP.Equal(newAddress.IP) {
+		t.Fatalf("expected the newest address to be kept first, but got %s", cachedAddresses[0].IP)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"net"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
@@ -41,8 +42,12 @@ type OnSeed func(addrs []*appmessage.NetAddress)
 type LookupFunc func(string) ([]net.IP, error)
 
 // SeedFromDNS uses DNS seeding to populate the address manager with peers.
+// The seeds are queried in the background, and the returned wait group is done
+// once all of them responded.
 func SeedFromDNS(dagParams *dagconfig.Params, customSeed string, includeAllSubnetworks bool,
-	subnetworkID *externalapi.DomainSubnetworkID, lookupFn LookupFunc, seedFn OnSeed) {
+	subnetworkID *externalapi.DomainSubnetworkID, lookupFn LookupFunc, seedFn OnSeed) *sync.WaitGroup {
+
+	waitGroup := &sync.WaitGroup{}
 
 	var dnsSeeds []string
 	if customSeed != "" {
@@ -62,7 +67,9 @@ func SeedFromDNS(dagParams *dagconfig.Params, customSeed string, includeAllSubne
 			}
 		}
 
+		waitGroup.Add(1)
 		spawn("SeedFromDNS", func() {
+			defer waitGroup.Done()
 			randSource := rand.New(rand.NewSource(time.Now().UnixNano()))
 
 			seedPeers, err := lookupFn(host)
@@ -92,11 +99,16 @@ func SeedFromDNS(dagParams *dagconfig.Params, customSeed string, includeAllSubne
 			seedFn(addresses)
 		})
 	}
+	return waitGroup
 }
 
-// SeedFromGRPC send gRPC request to get list of peers for a given host
+// SeedFromGRPC send gRPC request to get list of peers for a given host. The
+// requests are sent in the background, and the returned wait group is done
+// once all of them were responded.
 func SeedFromGRPC(dagParams *dagconfig.Params, customSeed string, includeAllSubnetworks bool,
-	subnetworkID *externalapi.DomainSubnetworkID, seedFn OnSeed) {
+	subnetworkID *externalapi.DomainSubnetworkID, seedFn OnSeed) *sync.WaitGroup {
+
+	waitGroup := &sync.WaitGroup{}
 
 	var grpcSeeds []string
 	if customSeed != "" {
@@ -106,7 +118,10 @@ func SeedFromGRPC(dagParams *dagconfig.Params, customSeed string, includeAllSubn
 	}
 
 	for _, host := range grpcSeeds {
+		host := host
+		waitGroup.Add(1)
 		spawn("SeedFromGRPC", func() {
+			defer waitGroup.Done()
 			randSource := rand.New(rand.NewSource(time.Now().UnixNano()))
 
 			conn, err := grpc.Dial(host, grpc.WithInsecure())
@@ -157,6 +172,7 @@ func SeedFromGRPC(dagParams *dagconfig.Params, customSeed string, includeAllSubn
 			seedFn(addresses)
 		})
 	}
+	return waitGroup
 }
 
 func fromProtobufAddresses(proto []*pb2.NetAddress) []net.IP {
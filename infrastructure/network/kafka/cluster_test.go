@@ -0,0 +1,148 @@
+package kafka_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"github.com/twmb/franz-go/pkg/kversion"
+)
+
+// brokerEnvironmentVariable is the environment variable that may hold the
+// address of a real broker to test against
+const brokerEnvironmentVariable = "KASPAD_TEST_KAFKA_BROKER"
+
+// readTimeout is the time readMessages waits for the expected messages
+const readTimeout = 30 * time.Second
+
+// producedMessage is a message that was produced to the test cluster
+type producedMessage struct {
+	Topic     string
+	Partition int32
+	Key       []byte
+	Value     []byte
+}
+
+// testCluster is the Kafka cluster that tests produce to. By default it's an
+// in-process github.com/twmb/franz-go/pkg/kfake cluster that speaks the wire
+// protocol of Kafka 4.0. Setting the KASPAD_TEST_KAFKA_BROKER environment
+// variable to the host:port address of a broker runs the tests against that
+// broker instead.
+type testCluster struct {
+	fakeCluster   *kfake.Cluster
+	brokerAddress string
+	prefix        string
+
+	shouldFailProduceRequests atomic.Bool
+}
+
+var testClusterCount uint64
+
+// newTestCluster returns a cluster whose topics are created with the given
+// amount of partitions once they're first produced to. A real broker
+// creates topics according to its own settings.
+func newTestCluster(t testing.TB, partitionCount int) *testCluster {
+	// Every cluster gets its own topics, so that tests against a real
+	// broker don't read the messages of earlier runs
+	topicPrefix := fmt.Sprintf("kaspadtest%d-%d", time.Now().UnixNano(), atomic.AddUint64(&testClusterCount, 1))
+
+	if address := os.Getenv(brokerEnvironmentVariable); address != "" {
+		return &testCluster{brokerAddress: address, prefix: topicPrefix}
+	}
+
+	fakeCluster, err := kfake.NewCluster(
+		kfake.NumBrokers(1),
+		kfake.DefaultNumPartitions(partitionCount),
+		kfake.AllowAutoTopicCreation(),
+		kfake.MaxVersions(kversion.V4_0_0()),
+	)
+	if err != nil {
+		t.Fatalf("Error starting a fake Kafka cluster: %s", err)
+	}
+	cluster := &testCluster{
+		fakeCluster:   fakeCluster,
+		brokerAddress: fakeCluster.ListenAddrs()[0],
+		prefix:        topicPrefix,
+	}
+	fakeCluster.ControlKey(kmsg.Produce.Int16(), func(request kmsg.Request) (kmsg.Response, error, bool) {
+		fakeCluster.KeepControl()
+		if !cluster.shouldFailProduceRequests.Load() {
+			return nil, nil, false
+		}
+		return nil, errors.New("failing produce requests"), true
+	})
+	return cluster
+}
+
+// address returns the host:port address of a broker of the cluster
+func (c *testCluster) address() string {
+	return c.brokerAddress
+}
+
+// topicPrefix returns a prefix for topic names that no other cluster
+// returned
+func (c *testCluster) topicPrefix() string {
+	return c.prefix
+}
+
+// canFailProduceRequests returns whether failProduceRequests is supported,
+// which it isn't against a real broker
+func (c *testCluster) canFailProduceRequests() bool {
+	return c.fakeCluster != nil
+}
+
+// setFailProduceRequests makes the cluster close the connections that produce
+// requests are sent on, until it's called again with false
+func (c *testCluster) setFailProduceRequests(fail bool) {
+	c.shouldFailProduceRequests.Store(fail)
+}
+
+// close stops the cluster
+func (c *testCluster) close() {
+	if c.fakeCluster != nil {
+		c.fakeCluster.Close()
+	}
+}
+
+// readMessages reads the messages of the given topics from their beginning
+// until at least count messages were read, and returns them. Messages that
+// went to the same partition are returned in the order they were produced.
+func (c *testCluster) readMessages(t testing.TB, count int, topics ...string) []*producedMessage {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(c.brokerAddress),
+		kgo.ConsumeTopics(topics...),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+		kgo.FetchMaxWait(100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Error creating a Kafka consumer: %s", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), readTimeout)
+	defer cancel()
+
+	var messages []*producedMessage
+	for len(messages) < count {
+		fetches := client.PollFetches(ctx)
+		if ctx.Err() != nil {
+			t.Fatalf("Timed out reading messages from Kafka: read %d out of %d", len(messages), count)
+		}
+		for _, record := range fetches.Records() {
+			messages = append(messages, &producedMessage{
+				Topic:     record.Topic,
+				Partition: record.Partition,
+				Key:       record.Key,
+				Value:     record.Value,
+			})
+		}
+	}
+	return messages
+}
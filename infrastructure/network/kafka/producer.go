@@ -0,0 +1,139 @@
+// Package kafka produces messages to Kafka with github.com/segmentio/kafka-go.
+// It supports what kaspad needs to publish events: producing batches of keyed
+// messages that all the in-sync replicas acknowledge, to partitions picked
+// the way the Java client picks them.
+package kafka
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Message is a message to produce to a topic. Messages with the same key go
+// to the same partition of their topic.
+type Message struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// Producer produces messages to a Kafka cluster
+type Producer struct {
+	writer    *kafkago.Writer
+	transport *kafkago.Transport
+}
+
+// maxMessagesPerBatch is the maximum amount of messages produced to a
+// partition in a single request
+const maxMessagesPerBatch = 10_000
+
+// NewProducer returns a producer that finds the cluster through the brokers
+// at the given host:port addresses. It connects to the brokers only once
+// it's first used.
+func NewProducer(bootstrapAddresses []string, clientID string, timeout time.Duration) (*Producer, error) {
+	if len(bootstrapAddresses) == 0 {
+		return nil, errors.New("no Kafka brokers were given")
+	}
+	for _, address := range bootstrapAddresses {
+		err := ValidateBrokerAddress(address)
+		if err != nil {
+			return nil, err
+		}
+	}
+	transport := &kafkago.Transport{
+		ClientID:    clientID,
+		DialTimeout: timeout,
+	}
+	writer := &kafkago.Writer{
+		Addr:      kafkago.TCP(bootstrapAddresses...),
+		Transport: transport,
+		// The default partitioner of the Java client, so that consumers may
+		// rely on messages with the same key going to the same partition no
+		// matter which client produced them
+		Balancer:     &kafkago.Murmur2Balancer{},
+		RequiredAcks: kafkago.RequireAll,
+		// Failed messages are produced again by the caller, which knows
+		// whether to keep them
+		MaxAttempts: 1,
+		// Produce is always given all of the messages at once, so there's no
+		// point in waiting for more of them to fill a batch
+		BatchSize:    maxMessagesPerBatch,
+		BatchTimeout: time.Millisecond,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+		// The brokers may create the topics if they don't exist yet,
+		// depending on their auto.create.topics.enable setting
+		AllowAutoTopicCreation: true,
+	}
+	return &Producer{writer: writer, transport: transport}, nil
+}
+
+// ValidateBrokerAddress returns an error if the given address isn't of the
+// form host:port
+func ValidateBrokerAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrapf(err, "invalid Kafka broker address %s", address)
+	}
+	if host == "" {
+		return errors.Errorf("invalid Kafka broker address %s: missing host", address)
+	}
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || portNumber == 0 {
+		return errors.Errorf("invalid Kafka broker address %s: invalid port %s", address, port)
+	}
+	return nil
+}
+
+// maxTopicNameLength is the length of the longest topic name Kafka accepts
+const maxTopicNameLength = 249
+
+// ValidateTopicName returns an error if Kafka doesn't accept the given topic
+// name
+func ValidateTopicName(topic string) error {
+	if topic == "" || topic == "." || topic == ".." {
+		return errors.Errorf("invalid Kafka topic name %q", topic)
+	}
+	if len(topic) > maxTopicNameLength {
+		return errors.Errorf("Kafka topic name %s is longer than %d characters", topic, maxTopicNameLength)
+	}
+	for _, character := range topic {
+		isValid := (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z') ||
+			(character >= '0' && character <= '9') || character == '.' || character == '_' || character == '-'
+		if !isValid {
+			return errors.Errorf("invalid Kafka topic name %s: topic names may only contain "+
+				"ASCII letters, digits, '.', '_' and '-'", topic)
+		}
+	}
+	return nil
+}
+
+// Produce produces the given messages, and returns once all the in-sync
+// replicas of their partitions have them. The messages that go to the same
+// partition are appended to it in order.
+//
+// If Produce fails, some of the messages may have been produced anyway, so
+// producing all of them again delivers every message at least once.
+func (p *Producer) Produce(messages []*Message) error {
+	kafkaMessages := make([]kafkago.Message, len(messages))
+	for i, message := range messages {
+		kafkaMessages[i] = kafkago.Message{Topic: message.Topic, Key: message.Key, Value: message.Value}
+	}
+	err := p.writer.WriteMessages(context.Background(), kafkaMessages...)
+	if err != nil {
+		return errors.Wrapf(err, "error producing %d messages to Kafka", len(messages))
+	}
+	return nil
+}
+
+// Close closes the connections to the brokers
+func (p *Producer) Close() {
+	// Writer.Close never fails
+	_ = p.writer.Close()
+	p.transport.CloseIdleConnections()
+}
@@ -0,0 +1,195 @@
+package kafka_test
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/kafka"
+)
+
+func TestProducer(t *testing.T) {
+	const partitionCount = 4
+	cluster := newTestCluster(t, partitionCount)
+	defer cluster.close()
+
+	producer, err := kafka.NewProducer([]string{cluster.address()}, "kaspad-test", 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewProducer: %s", err)
+	}
+	defer producer.Close()
+
+	blocksTopic := cluster.topicPrefix() + ".blocks"
+	mempoolTopic := cluster.topicPrefix() + ".mempool"
+	var messages []*kafka.Message
+	for i := 0; i < 20; i++ {
+		topic := blocksTopic
+		if i%2 == 1 {
+			topic = mempoolTopic
+		}
+		messages = append(messages, &kafka.Message{
+			Topic: topic,
+			Key:   []byte(fmt.Sprintf("key%d", i%5)),
+			Value: []byte(fmt.Sprintf("value%d", i)),
+		})
+	}
+	err = producer.Produce(messages)
+	if err != nil {
+		t.Fatalf("Produce: %s", err)
+	}
+
+	producedMessages := cluster.readMessages(t, len(messages), blocksTopic, mempoolTopic)
+	if len(producedMessages) != len(messages) {
+		t.Fatalf("Expected %d messages, but the cluster has %d", len(messages), len(producedMessages))
+	}
+	partitionsByKey := make(map[string]int32)
+	lastValueIndexes := make(map[string]int)
+	for _, producedMessage := range producedMessages {
+		topicAndKey := producedMessage.Topic + "/" + string(producedMessage.Key)
+		partition, ok := partitionsByKey[topicAndKey]
+		if ok && partition != producedMessage.Partition {
+			t.Fatalf("Messages with key %s went to different partitions", topicAndKey)
+		}
+		partitionsByKey[topicAndKey] = producedMessage.Partition
+
+		var valueIndex int
+		_, err := fmt.Sscanf(string(producedMessage.Value), "value%d", &valueIndex)
+		if err != nil {
+			t.Fatalf("Unexpected value %s", producedMessage.Value)
+		}
+		if lastValueIndex, ok := lastValueIndexes[topicAndKey]; ok && lastValueIndex > valueIndex {
+			t.Fatalf("Messages with key %s were produced out of order", topicAndKey)
+		}
+		lastValueIndexes[topicAndKey] = valueIndex
+	}
+
+	if !cluster.canFailProduceRequests() {
+		return
+	}
+
+	// A failed produce request fails Produce, and the same messages may be
+	// produced again once the cluster recovers
+	cluster.setFailProduceRequests(true)
+	err = producer.Produce(messages[:1])
+	if err == nil {
+		t.Fatalf("Expected Produce to fail")
+	}
+	cluster.setFailProduceRequests(false)
+	err = producer.Produce(messages[:1])
+	if err != nil {
+		t.Fatalf("Produce: %s", err)
+	}
+	producedMessages = cluster.readMessages(t, len(messages)+1, blocksTopic, mempoolTopic)
+	if len(producedMessages) != len(messages)+1 {
+		t.Fatalf("The message wasn't produced after the cluster recovered")
+	}
+}
+
+// The partitions that keys go to must match the default partitioner of the
+// Java client, which hashes keys with murmur2
+func TestProducerPartitionsLikeTheJavaClient(t *testing.T) {
+	const partitionCount = 8
+	cluster := newTestCluster(t, partitionCount)
+	defer cluster.close()
+	if !cluster.canFailProduceRequests() {
+		t.Skip("The partition count of the topics of a real broker is unknown")
+	}
+
+	producer, err := kafka.NewProducer([]string{cluster.address()}, "kaspad-test", 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewProducer: %s", err)
+	}
+	defer producer.Close()
+
+	// The partitions that follow from the murmur2 test vectors of the Java
+	// client
+	expectedPartitions := map[string]int32{
+		"21":                         4,
+		"foobar":                     6,
+		"a-little-bit-long-string":   0,
+		"a-little-bit-longer-string": 3,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": 5,
+		"abc": 3,
+	}
+	topic := cluster.topicPrefix() + ".blocks"
+	var messages []*kafka.Message
+	for key := range expectedPartitions {
+		messages = append(messages, &kafka.Message{Topic: topic, Key: []byte(key), Value: []byte("value")})
+	}
+	err = producer.Produce(messages)
+	if err != nil {
+		t.Fatalf("Produce: %s", err)
+	}
+	for _, message := range cluster.readMessages(t, len(messages), topic) {
+		expectedPartition := expectedPartitions[string(message.Key)]
+		if message.Partition != expectedPartition {
+			t.Errorf("Key %s: expected partition %d, but got %d", message.Key, expectedPartition, message.Partition)
+		}
+	}
+}
+
+func TestProducerUnreachableBroker(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %s", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	producer, err := kafka.NewProducer([]string{address}, "kaspad-test", time.Second)
+	if err != nil {
+		t.Fatalf("NewProducer: %s", err)
+	}
+	defer producer.Close()
+	err = producer.Produce([]*kafka.Message{{Topic: "blocks", Key: []byte("key"), Value: []byte("value")}})
+	if err == nil {
+		t.Fatalf("Expected Produce to fail")
+	}
+}
+
+func TestValidateBrokerAddress(t *testing.T) {
+	tests := []struct {
+		address       string
+		expectedValid bool
+	}{
+		{address: "localhost:9092", expectedValid: true},
+		{address: "10.0.0.1:9092", expectedValid: true},
+		{address: "[::1]:9092", expectedValid: true},
+		{address: "localhost", expectedValid: false},
+		{address: ":9092", expectedValid: false},
+		{address: "localhost:0", expectedValid: false},
+		{address: "localhost:kafka", expectedValid: false},
+		{address: "localhost:70000", expectedValid: false},
+	}
+	for _, test := range tests {
+		err := kafka.ValidateBrokerAddress(test.address)
+		if (err == nil) != test.expectedValid {
+			t.Errorf("ValidateBrokerAddress(%s): expected valid: %t, but got error: %v",
+				test.address, test.expectedValid, err)
+		}
+	}
+}
+
+func TestValidateTopicName(t *testing.T) {
+	tests := []struct {
+		topic         string
+		expectedValid bool
+	}{
+		{topic: "kaspad.blocks", expectedValid: true},
+		{topic: "kaspa-mainnet_events.chain", expectedValid: true},
+		{topic: "", expectedValid: false},
+		{topic: "..", expectedValid: false},
+		{topic: "kaspad blocks", expectedValid: false},
+		{topic: "kaspad/blocks", expectedValid: false},
+		{topic: strings.Repeat("a", 250), expectedValid: false},
+	}
+	for _, test := range tests {
+		err := kafka.ValidateTopicName(test.topic)
+		if (err == nil) != test.expectedValid {
+			t.Errorf("ValidateTopicName(%q): expected valid: %t, but got error: %v",
+				test.topic, test.expectedValid, err)
+		}
+	}
+}
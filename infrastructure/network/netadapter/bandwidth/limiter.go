@@ -0,0 +1,87 @@
+package bandwidth
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket that limits the rate at which bytes pass through it.
+// The bucket fills at the limit rate, up to a second's worth of bytes.
+//
+// Taking more bytes than the bucket holds puts it in debt, and the taker waits
+// until the debt is paid off. This lets arbitrarily large messages through
+// while keeping the average rate within the limit.
+//
+// A nil Limiter doesn't limit anything.
+type Limiter struct {
+	bytesPerSecond uint64
+	tokens         float64
+	lastRefill     time.Time
+	lock           sync.Mutex
+}
+
+// NewLimiter returns a Limiter that limits the rate to the given bytes per second.
+// It returns nil, which doesn't limit anything, if bytesPerSecond is 0.
+func NewLimiter(bytesPerSecond uint64) *Limiter {
+	if bytesPerSecond == 0 {
+		return nil
+	}
+	return &Limiter{
+		bytesPerSecond: bytesPerSecond,
+		tokens:         float64(bytesPerSecond),
+		lastRefill:     time.Now(),
+	}
+}
+
+// BytesPerSecond returns the rate limit of the Limiter. It's 0 for a nil Limiter.
+func (l *Limiter) BytesPerSecond() uint64 {
+	if l == nil {
+		return 0
+	}
+	return l.bytesPerSecond
+}
+
+// Wait takes the given amount of bytes out of the bucket, and blocks until the
+// bucket is no longer in debt. It returns false if stopChan was closed first.
+func (l *Limiter) Wait(bytes int, stopChan <-chan struct{}) bool {
+	delay := l.reserve(bytes, time.Now())
+	if delay == 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stopChan:
+		return false
+	}
+}
+
+// reserve takes the given amount of bytes out of the bucket, and returns how
+// long it'd take the bucket to pay its debt, if it's in debt
+func (l *Limiter) reserve(bytes int, now time.Time) time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	bucketSize := float64(l.bytesPerSecond)
+	elapsed := now.Sub(l.lastRefill)
+	if elapsed > 0 {
+		l.tokens += elapsed.Seconds() * bucketSize
+		if l.tokens > bucketSize {
+			l.tokens = bucketSize
+		}
+		l.lastRefill = now
+	}
+
+	l.tokens -= float64(bytes)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / bucketSize * float64(time.Second))
+}
@@ -0,0 +1,48 @@
+package bandwidth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterReserve(t *testing.T) {
+	limiter := NewLimiter(1000)
+	now := limiter.lastRefill
+
+	// The bucket starts full
+	if delay := limiter.reserve(1000, now); delay != 0 {
+		t.Fatalf("Expected a full bucket to let 1000 bytes through, but got a delay of %s", delay)
+	}
+
+	// An empty bucket puts the taker in debt for as long as it takes to refill
+	if delay := limiter.reserve(500, now); delay != 500*time.Millisecond {
+		t.Fatalf("Unexpected delay. Want: %s, got: %s", 500*time.Millisecond, delay)
+	}
+
+	// Once the debt is paid off, the bucket refills at the limit rate
+	now = now.Add(time.Second)
+	if delay := limiter.reserve(500, now); delay != 0 {
+		t.Fatalf("Expected the refilled bucket to let 500 bytes through, but got a delay of %s", delay)
+	}
+
+	// The bucket doesn't fill beyond a second's worth of bytes, and larger
+	// messages get through after paying off their debt
+	now = now.Add(time.Hour)
+	if delay := limiter.reserve(3000, now); delay != 2*time.Second {
+		t.Fatalf("Unexpected delay. Want: %s, got: %s", 2*time.Second, delay)
+	}
+
+	var unlimited *Limiter
+	if NewLimiter(0) != nil || unlimited.reserve(1_000_000, now) != 0 || unlimited.BytesPerSecond() != 0 {
+		t.Fatalf("Expected a limit of 0 to be unlimited")
+	}
+}
+
+func TestLimiterWaitStops(t *testing.T) {
+	limiter := NewLimiter(1)
+	stopChan := make(chan struct{})
+	close(stopChan)
+	if limiter.Wait(1000, stopChan) {
+		t.Fatalf("Expected Wait to return false once stopChan is closed")
+	}
+}
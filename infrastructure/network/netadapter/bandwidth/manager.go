@@ -0,0 +1,93 @@
+package bandwidth
+
+import (
+	"sync/atomic"
+)
+
+// Manager holds the global upload and download limits of the P2P connections,
+// and counts the bytes that pass through all of them
+type Manager struct {
+	uploadLimiter      *Limiter
+	downloadLimiter    *Limiter
+	peerUploadLimit    uint64
+	peerDownloadLimit  uint64
+	totalBytesSent     uint64
+	totalBytesReceived uint64
+}
+
+// NewManager returns a new Manager with the given limits, in bytes per second.
+// A limit of 0 means unlimited.
+func NewManager(uploadLimit, downloadLimit, peerUploadLimit, peerDownloadLimit uint64) *Manager {
+	return &Manager{
+		uploadLimiter:     NewLimiter(uploadLimit),
+		downloadLimiter:   NewLimiter(downloadLimit),
+		peerUploadLimit:   peerUploadLimit,
+		peerDownloadLimit: peerDownloadLimit,
+	}
+}
+
+// NewConnectionThrottle returns a ConnectionThrottle for a new connection,
+// which is limited by both the per-peer limits and the global ones
+func (m *Manager) NewConnectionThrottle() *ConnectionThrottle {
+	return &ConnectionThrottle{
+		manager:         m,
+		uploadLimiter:   NewLimiter(m.peerUploadLimit),
+		downloadLimiter: NewLimiter(m.peerDownloadLimit),
+	}
+}
+
+// TotalBytesSent returns the amount of bytes sent through all the connections
+func (m *Manager) TotalBytesSent() uint64 {
+	return atomic.LoadUint64(&m.totalBytesSent)
+}
+
+// TotalBytesReceived returns the amount of bytes received through all the connections
+func (m *Manager) TotalBytesReceived() uint64 {
+	return atomic.LoadUint64(&m.totalBytesReceived)
+}
+
+// UploadLimit returns the global upload limit, in bytes per second
+func (m *Manager) UploadLimit() uint64 {
+	return m.uploadLimiter.BytesPerSecond()
+}
+
+// DownloadLimit returns the global download limit, in bytes per second
+func (m *Manager) DownloadLimit() uint64 {
+	return m.downloadLimiter.BytesPerSecond()
+}
+
+// PeerUploadLimit returns the upload limit of every connection, in bytes per second
+func (m *Manager) PeerUploadLimit() uint64 {
+	return m.peerUploadLimit
+}
+
+// PeerDownloadLimit returns the download limit of every connection, in bytes per second
+func (m *Manager) PeerDownloadLimit() uint64 {
+	return m.peerDownloadLimit
+}
+
+// ConnectionThrottle throttles the traffic of a single connection
+type ConnectionThrottle struct {
+	manager         *Manager
+	uploadLimiter   *Limiter
+	downloadLimiter *Limiter
+}
+
+// ThrottleUpload accounts for the given amount of bytes about to be sent, and
+// blocks until sending them is within both the connection's and the global
+// upload limits. It returns false if stopChan was closed first.
+func (t *ConnectionThrottle) ThrottleUpload(bytes int, stopChan <-chan struct{}) bool {
+	atomic.AddUint64(&t.manager.totalBytesSent, uint64(bytes))
+	return t.uploadLimiter.Wait(bytes, stopChan) && t.manager.uploadLimiter.Wait(bytes, stopChan)
+}
+
+// ThrottleDownload accounts for the given amount of received bytes, and
+// blocks until receiving them is within both the connection's and the global
+// download limits. It returns false if stopChan was closed first.
+//
+// Since the connection isn't read while it's blocked, the sending peer is
+// eventually slowed down by the flow control of the underlying transport.
+func (t *ConnectionThrottle) ThrottleDownload(bytes int, stopChan <-chan struct{}) bool {
+	atomic.AddUint64(&t.manager.totalBytesReceived, uint64(bytes))
+	return t.downloadLimiter.Wait(bytes, stopChan) && t.manager.downloadLimiter.Wait(bytes, stopChan)
+}
@@ -1,15 +1,20 @@
 package netadapter
 
 import (
+	"crypto/tls"
+	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/bandwidth"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/id"
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/websocketserver"
 	"github.com/pkg/errors"
 )
 
@@ -27,9 +32,10 @@ type NetAdapter struct {
 	id                   *id.ID
 	p2pServer            server.P2PServer
 	p2pRouterInitializer RouterInitializer
-	rpcServer            server.Server
+	rpcServers           []server.RPCServer
 	rpcRouterInitializer RouterInitializer
 	stop                 uint32
+	bandwidthManager     *bandwidth.Manager
 
 	p2pConnections     map[*NetConnection]struct{}
 	p2pConnectionsLock sync.RWMutex
@@ -42,29 +48,103 @@ func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
 	if err != nil {
 		return nil, err
 	}
-	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners)
+	err = grpcserver.SetCompression(cfg.CompressionThreshold, cfg.CompressionLevel)
 	if err != nil {
 		return nil, err
 	}
-	rpcServer, err := grpcserver.NewRPCServer(cfg.RPCListeners, cfg.RPCMaxClients)
+
+	const bytesPerKiB = 1024
+	bandwidthManager := bandwidth.NewManager(cfg.MaxUploadTarget*bytesPerKiB, cfg.LimitDownload*bytesPerKiB,
+		cfg.MaxPeerUploadTarget*bytesPerKiB, cfg.LimitPeerDownload*bytesPerKiB)
+	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners, bandwidthManager)
 	if err != nil {
 		return nil, err
 	}
 	adapter := NetAdapter{
-		cfg:       cfg,
-		id:        netAdapterID,
-		p2pServer: p2pServer,
-		rpcServer: rpcServer,
+		cfg:              cfg,
+		id:               netAdapterID,
+		p2pServer:        p2pServer,
+		bandwidthManager: bandwidthManager,
 
 		p2pConnections: make(map[*NetConnection]struct{}),
 	}
-
 	adapter.p2pServer.SetOnConnectedHandler(adapter.onP2PConnectedHandler)
-	adapter.rpcServer.SetOnConnectedHandler(adapter.onRPCConnectedHandler)
+
+	var unixSocket *grpcserver.UnixSocket
+	if cfg.RPCUnixSocket != "" {
+		unixSocket = &grpcserver.UnixSocket{Path: cfg.RPCUnixSocket, Mode: cfg.RPCUnixSocketFileMode}
+	}
+	rpcCredentials := cfg.RPCCredentials
+	if cfg.RPCCookie {
+		rpcAuthToken, err := grpcserver.WriteRPCCookie(cfg.RPCCookieFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error writing the RPC cookie file %s", cfg.RPCCookieFile)
+		}
+		log.Infof("RPC clients authenticate with the cookie in %s", cfg.RPCCookieFile)
+		rpcCredentials = append([]*config.RPCCredential{{Token: rpcAuthToken, Permission: config.RPCPermissionAdmin}},
+			rpcCredentials...)
+	}
+	var rpcTLSConfig *tls.Config
+	if cfg.RPCTLS {
+		rpcTLSConfig, err = grpcserver.LoadRPCTLSConfig(cfg.RPCCert, cfg.RPCKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rpcAuthenticator := newRPCAuthenticator(rpcCredentials)
+	rpcServer, err := grpcserver.NewRPCServer("RPC", cfg.RPCListeners, unixSocket, cfg.RPCMaxClients,
+		rpcAuthenticator, rpcTLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	rpcServer.SetOnConnectedHandler(adapter.newOnRPCConnectedHandler(nil))
+	adapter.rpcServers = append(adapter.rpcServers, rpcServer)
+
+	if len(cfg.RPCWebSocketListeners) > 0 {
+		webSocketRPCServer, err := websocketserver.NewWebSocketRPCServer("WebSocket RPC",
+			cfg.RPCWebSocketListeners, cfg.RPCMaxWebsockets, rpcAuthenticator, rpcTLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		webSocketRPCServer.SetOnConnectedHandler(adapter.newOnRPCConnectedHandler(nil))
+		adapter.rpcServers = append(adapter.rpcServers, webSocketRPCServer)
+	}
+
+	if !cfg.DisableRPC {
+		for _, endpoint := range cfg.RPCEndpoints {
+			var endpointCredentials []*config.RPCCredential
+			if endpoint.AuthToken != "" {
+				endpointCredentials = []*config.RPCCredential{{Token: endpoint.AuthToken, Permission: config.RPCPermissionAdmin}}
+			}
+			endpointServer, err := grpcserver.NewRPCServer(fmt.Sprintf("RPC[%s]", endpoint.Name),
+				endpoint.Listeners, nil, endpoint.MaxClients, newRPCAuthenticator(endpointCredentials), rpcTLSConfig)
+			if err != nil {
+				return nil, err
+			}
+			endpointServer.SetOnConnectedHandler(adapter.newOnRPCConnectedHandler(endpoint))
+			adapter.rpcServers = append(adapter.rpcServers, endpointServer)
+		}
+	}
 
 	return &adapter, nil
 }
 
+// newRPCAuthenticator returns an authenticator that accepts RPC clients that
+// present any of the given credentials, or nil if there are none, in which
+// case no authentication is required
+func newRPCAuthenticator(credentials []*config.RPCCredential) server.RPCAuthenticator {
+	if len(credentials) == 0 {
+		return nil
+	}
+	return func(authorizations []string) (isAuthorized bool, isReadOnly bool, credentialID string) {
+		credential, ok := config.AuthenticateRPC(credentials, authorizations)
+		if !ok {
+			return false, false, ""
+		}
+		return true, credential.Permission == config.RPCPermissionReadOnly, credential.ID()
+	}
+}
+
 // Start begins the operation of the NetAdapter
 func (na *NetAdapter) Start() error {
 	if na.p2pRouterInitializer == nil {
@@ -78,9 +158,11 @@ func (na *NetAdapter) Start() error {
 	if err != nil {
 		return err
 	}
-	err = na.rpcServer.Start()
-	if err != nil {
-		return err
+	for _, rpcServer := range na.rpcServers {
+		err = rpcServer.Start()
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -95,7 +177,20 @@ func (na *NetAdapter) Stop() error {
 	if err != nil {
 		return err
 	}
-	return na.rpcServer.Stop()
+	for _, rpcServer := range na.rpcServers {
+		err = rpcServer.Stop()
+		if err != nil {
+			return err
+		}
+	}
+	if na.cfg.RPCCookie {
+		// The cookie is valid only while the node runs
+		err = grpcserver.RemoveRPCCookie(na.cfg.RPCCookieFile)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // P2PConnect tells the NetAdapter's underlying p2p server to initiate a connection
@@ -128,7 +223,7 @@ func (na *NetAdapter) P2PConnectionCount() int {
 }
 
 func (na *NetAdapter) onP2PConnectedHandler(connection server.Connection) error {
-	netConnection := newNetConnection(connection, na.p2pRouterInitializer, "on P2P connected")
+	netConnection := newNetConnection(connection, nil, na.p2pRouterInitializer, "on P2P connected")
 
 	na.p2pConnectionsLock.Lock()
 	defer na.p2pConnectionsLock.Unlock()
@@ -147,12 +242,17 @@ func (na *NetAdapter) onP2PConnectedHandler(connection server.Connection) error
 	return nil
 }
 
-func (na *NetAdapter) onRPCConnectedHandler(connection server.Connection) error {
-	netConnection := newNetConnection(connection, na.rpcRouterInitializer, "on RPC connected")
-	netConnection.setOnDisconnectedHandler(func() {})
-	netConnection.start()
+// newOnRPCConnectedHandler returns the connected handler of the RPC server
+// serving the given endpoint. The endpoint is nil for the default RPC server,
+// which serves --rpclisten.
+func (na *NetAdapter) newOnRPCConnectedHandler(rpcEndpoint *config.RPCEndpoint) server.OnConnectedHandler {
+	return func(connection server.Connection) error {
+		netConnection := newNetConnection(connection, rpcEndpoint, na.rpcRouterInitializer, "on RPC connected")
+		netConnection.setOnDisconnectedHandler(func() {})
+		netConnection.start()
 
-	return nil
+		return nil
+	}
 }
 
 // SetP2PRouterInitializer sets the p2pRouterInitializer function
@@ -161,12 +261,42 @@ func (na *NetAdapter) SetP2PRouterInitializer(routerInitializer RouterInitialize
 	na.p2pRouterInitializer = routerInitializer
 }
 
+// SetP2PDialer sets the function that opens the network
+// connections of outbound p2p connections, and how long
+// it's waited for
+func (na *NetAdapter) SetP2PDialer(dialer server.Dialer, dialTimeout time.Duration) {
+	na.p2pServer.SetDialer(dialer, dialTimeout)
+}
+
 // SetRPCRouterInitializer sets the rpcRouterInitializer function
 // for the net adapter
 func (na *NetAdapter) SetRPCRouterInitializer(routerInitializer RouterInitializer) {
 	na.rpcRouterInitializer = routerInitializer
 }
 
+// SetRPCIsSynced tells all the RPC servers whether the node is synced,
+// which they report through the gRPC health service
+func (na *NetAdapter) SetRPCIsSynced(isSynced bool) {
+	for _, rpcServer := range na.rpcServers {
+		rpcServer.SetIsSynced(isSynced)
+	}
+}
+
+// SetRPCIsNetworkDegraded tells all the RPC servers whether the connection of
+// the node to the network is degraded, which they report through the gRPC
+// health service
+func (na *NetAdapter) SetRPCIsNetworkDegraded(isNetworkDegraded bool) {
+	for _, rpcServer := range na.rpcServers {
+		rpcServer.SetIsNetworkDegraded(isNetworkDegraded)
+	}
+}
+
+// BandwidthManager returns the manager that throttles the p2p connections
+// and counts their traffic
+func (na *NetAdapter) BandwidthManager() *bandwidth.Manager {
+	return na.bandwidthManager
+}
+
 // ID returns this netAdapter's ID in the network
 func (na *NetAdapter) ID() *id.ID {
 	return na.id
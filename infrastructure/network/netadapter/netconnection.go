@@ -3,6 +3,7 @@ package netadapter
 import (
 	"fmt"
 	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/config"
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
 	"sync/atomic"
@@ -18,14 +19,18 @@ type NetConnection struct {
 	router                *routerpkg.Router
 	onDisconnectedHandler server.OnDisconnectedHandler
 	isRouterClosed        uint32
+	rpcEndpoint           *config.RPCEndpoint
 }
 
-func newNetConnection(connection server.Connection, routerInitializer RouterInitializer, name string) *NetConnection {
+func newNetConnection(connection server.Connection, rpcEndpoint *config.RPCEndpoint,
+	routerInitializer RouterInitializer, name string) *NetConnection {
+
 	router := routerpkg.NewRouter(name)
 
 	netConnection := &NetConnection{
-		connection: connection,
-		router:     router,
+		connection:  connection,
+		router:      router,
+		rpcEndpoint: rpcEndpoint,
 	}
 
 	netConnection.connection.SetOnDisconnectedHandler(func() {
@@ -67,7 +72,46 @@ func (c *NetConnection) SetID(peerID *id.ID) {
 
 // Address returns the address associated with this connection
 func (c *NetConnection) Address() string {
-	return c.connection.Address().String()
+	return c.connection.String()
+}
+
+// RPCEndpoint returns the RPC endpoint this connection was made through. It
+// returns nil for P2P connections and for connections to the default RPC
+// server, which are unrestricted.
+func (c *NetConnection) RPCEndpoint() *config.RPCEndpoint {
+	return c.rpcEndpoint
+}
+
+// IsReadOnly returns whether this connection is of an RPC client that may only
+// call read-only commands
+func (c *NetConnection) IsReadOnly() bool {
+	return c.connection.IsReadOnly()
+}
+
+// CredentialID returns the ID of the credential the RPC client of this
+// connection authenticated with, or an empty string if it didn't have to
+func (c *NetConnection) CredentialID() string {
+	return c.connection.CredentialID()
+}
+
+// ReceivedMessageCount returns the amount of messages received through this connection
+func (c *NetConnection) ReceivedMessageCount() uint64 {
+	return c.connection.ReceivedMessageCount()
+}
+
+// SentMessageCount returns the amount of messages sent through this connection
+func (c *NetConnection) SentMessageCount() uint64 {
+	return c.connection.SentMessageCount()
+}
+
+// BytesReceived returns the amount of bytes received through this connection
+func (c *NetConnection) BytesReceived() uint64 {
+	return c.connection.BytesReceived()
+}
+
+// BytesSent returns the amount of bytes sent through this connection
+func (c *NetConnection) BytesSent() uint64 {
+	return c.connection.BytesSent()
 }
 
 // IsOutbound returns whether the connection is outbound
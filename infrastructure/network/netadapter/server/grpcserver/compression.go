@@ -0,0 +1,129 @@
+package grpcserver
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/encoding"
+
+	// The gzip compressor of gRPC is imported so that its registration is
+	// overridden by the one below, rather than the other way around
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// GZIPCompressorName is the gRPC encoding name of gzip compression
+const GZIPCompressorName = "gzip"
+
+// DeflateCompressorName is the gRPC encoding name of deflate compression
+const DeflateCompressorName = "deflate"
+
+// Compression levels, as accepted by SetCompression
+const (
+	MinCompressionLevel     = flate.BestSpeed
+	MaxCompressionLevel     = flate.BestCompression
+	DefaultCompressionLevel = 6
+)
+
+// DefaultCompressionThreshold is the default size, in bytes, of the smallest
+// message that's compressed
+const DefaultCompressionThreshold = 1024
+
+var (
+	compressionThreshold int64 = DefaultCompressionThreshold
+	compressionLevel     int64 = DefaultCompressionLevel
+)
+
+func init() {
+	encoding.RegisterCompressor(&compressor{
+		name: GZIPCompressorName,
+		newWriter: func(w io.Writer, level int) (io.WriteCloser, error) {
+			return gzip.NewWriterLevel(w, level)
+		},
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		},
+	})
+	encoding.RegisterCompressor(&compressor{
+		name: DeflateCompressorName,
+		newWriter: func(w io.Writer, level int) (io.WriteCloser, error) {
+			return flate.NewWriter(w, level)
+		},
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return flate.NewReader(r), nil
+		},
+	})
+}
+
+// SetCompression sets how the messages of gRPC streams that negotiated gzip or
+// deflate compression are compressed. Messages smaller than threshold bytes are
+// sent as is within the compressed format, since compressing them costs more
+// than it saves, and larger ones are compressed at the given level.
+//
+// gRPC compressors are registered per process, so this applies to all the
+// servers and clients of the process.
+func SetCompression(threshold int, level int) error {
+	if threshold < 0 {
+		return errors.Errorf("compression threshold %d is negative", threshold)
+	}
+	if level < MinCompressionLevel || level > MaxCompressionLevel {
+		return errors.Errorf("compression level %d is not between %d and %d",
+			level, MinCompressionLevel, MaxCompressionLevel)
+	}
+	atomic.StoreInt64(&compressionThreshold, int64(threshold))
+	atomic.StoreInt64(&compressionLevel, int64(level))
+	return nil
+}
+
+// compressor is a gRPC encoding.Compressor that compresses only messages that
+// are at least compressionThreshold bytes long. A gRPC compressor is handed
+// every message separately, and the writer it returns is closed once the whole
+// message is written to it.
+type compressor struct {
+	name      string
+	newWriter func(w io.Writer, level int) (io.WriteCloser, error)
+	newReader func(r io.Reader) (io.Reader, error)
+}
+
+func (c *compressor) Name() string {
+	return c.name
+}
+
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &thresholdWriter{compressor: c, destination: w}, nil
+}
+
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	return c.newReader(r)
+}
+
+// thresholdWriter buffers a message until it's closed, when its size is known
+// and it's compressed into destination accordingly
+type thresholdWriter struct {
+	compressor  *compressor
+	destination io.Writer
+	buffer      bytes.Buffer
+}
+
+func (w *thresholdWriter) Write(p []byte) (int, error) {
+	return w.buffer.Write(p)
+}
+
+func (w *thresholdWriter) Close() error {
+	level := int(atomic.LoadInt64(&compressionLevel))
+	if int64(w.buffer.Len()) < atomic.LoadInt64(&compressionThreshold) {
+		level = flate.NoCompression
+	}
+	writer, err := w.compressor.newWriter(w.destination, level)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(w.buffer.Bytes())
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
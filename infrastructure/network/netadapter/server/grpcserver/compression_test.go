@@ -0,0 +1,79 @@
+package grpcserver
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"google.golang.org/grpc/encoding"
+)
+
+func TestCompressor(t *testing.T) {
+	const threshold = 100
+	err := SetCompression(threshold, MaxCompressionLevel)
+	if err != nil {
+		t.Fatalf("SetCompression: %s", err)
+	}
+	defer func() {
+		err := SetCompression(DefaultCompressionThreshold, DefaultCompressionLevel)
+		if err != nil {
+			t.Fatalf("SetCompression: %s", err)
+		}
+	}()
+
+	compress := func(compressor encoding.Compressor, message []byte) []byte {
+		var compressed bytes.Buffer
+		writer, err := compressor.Compress(&compressed)
+		if err != nil {
+			t.Fatalf("Compress: %s", err)
+		}
+		_, err = writer.Write(message)
+		if err != nil {
+			t.Fatalf("Write: %s", err)
+		}
+		err = writer.Close()
+		if err != nil {
+			t.Fatalf("Close: %s", err)
+		}
+
+		compressedBytes := compressed.Bytes()
+		reader, err := compressor.Decompress(bytes.NewReader(compressedBytes))
+		if err != nil {
+			t.Fatalf("Decompress: %s", err)
+		}
+		decompressed, err := ioutil.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("ReadAll: %s", err)
+		}
+		if !bytes.Equal(decompressed, message) {
+			t.Fatalf("The decompressed message doesn't match the original one")
+		}
+		return compressedBytes
+	}
+
+	for _, name := range []string{GZIPCompressorName, DeflateCompressorName} {
+		compressor := encoding.GetCompressor(name)
+		if compressor == nil {
+			t.Fatalf("The %s compressor is not registered", name)
+		}
+
+		small := bytes.Repeat([]byte{'a'}, threshold-1)
+		if compressed := compress(compressor, small); len(compressed) <= len(small) {
+			t.Errorf("%s: expected a message below the threshold to be stored as is, "+
+				"but it got compressed to %d bytes", name, len(compressed))
+		}
+
+		large := bytes.Repeat([]byte{'a'}, threshold)
+		if compressed := compress(compressor, large); len(compressed) >= len(large) {
+			t.Errorf("%s: expected a message at the threshold to be compressed, "+
+				"but it got %d bytes long", name, len(compressed))
+		}
+	}
+
+	if SetCompression(-1, DefaultCompressionLevel) == nil {
+		t.Errorf("Expected a negative threshold to be rejected")
+	}
+	if SetCompression(threshold, MaxCompressionLevel+1) == nil {
+		t.Errorf("Expected an out of range level to be rejected")
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
 )
@@ -67,10 +69,17 @@ func (c *gRPCConnection) sendLoop() error {
 			return err
 		}
 
+		messageSize := proto.Size(messageProto)
+		if c.bandwidthThrottle != nil && !c.bandwidthThrottle.ThrottleUpload(messageSize, c.stopChan) {
+			return nil
+		}
+
 		err = c.send(messageProto)
 		if err != nil {
 			return err
 		}
+		atomic.AddUint64(&c.sentMessageCount, 1)
+		atomic.AddUint64(&c.bytesSent, uint64(messageSize))
 	}
 	return nil
 }
@@ -85,6 +94,13 @@ func (c *gRPCConnection) receiveLoop() error {
 			}
 			return err
 		}
+
+		messageSize := proto.Size(protoMessage)
+		atomic.AddUint64(&c.bytesReceived, uint64(messageSize))
+		if c.bandwidthThrottle != nil && !c.bandwidthThrottle.ThrottleDownload(messageSize, c.stopChan) {
+			return nil
+		}
+
 		message, err := protoMessage.ToAppMessage()
 		if err != nil {
 			if c.onInvalidMessageHandler != nil {
@@ -94,6 +110,7 @@ func (c *gRPCConnection) receiveLoop() error {
 		}
 
 		messageNumber++
+		atomic.StoreUint64(&c.receivedMessageCount, messageNumber)
 		message.SetMessageNumber(messageNumber)
 		message.SetReceivedAt(time.Now())
 
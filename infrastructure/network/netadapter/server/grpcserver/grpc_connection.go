@@ -5,6 +5,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/bandwidth"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/pkg/errors"
@@ -17,7 +18,7 @@ import (
 
 type gRPCConnection struct {
 	server                   *gRPCServer
-	address                  *net.TCPAddr
+	address                  net.Addr
 	stream                   grpcStream
 	router                   *router.Router
 	lowLevelClientConnection *grpc.ClientConn
@@ -34,6 +35,17 @@ type gRPCConnection struct {
 	onInvalidMessageHandler server.OnInvalidMessageHandler
 
 	isConnected uint32
+
+	receivedMessageCount uint64
+	sentMessageCount     uint64
+	bytesReceived        uint64
+	bytesSent            uint64
+
+	// bandwidthThrottle is nil for connections that aren't throttled
+	bandwidthThrottle *bandwidth.ConnectionThrottle
+
+	isReadOnly   bool
+	credentialID string
 }
 
 type grpcStream interface {
@@ -41,7 +53,7 @@ type grpcStream interface {
 	Recv() (*protowire.KaspadMessage, error)
 }
 
-func newConnection(server *gRPCServer, address *net.TCPAddr, stream grpcStream,
+func newConnection(server *gRPCServer, address net.Addr, stream grpcStream,
 	lowLevelClientConnection *grpc.ClientConn) *gRPCConnection {
 	connection := &gRPCConnection{
 		server:                   server,
@@ -51,6 +63,9 @@ func newConnection(server *gRPCServer, address *net.TCPAddr, stream grpcStream,
 		isConnected:              1,
 		lowLevelClientConnection: lowLevelClientConnection,
 	}
+	if server.bandwidthManager != nil {
+		connection.bandwidthThrottle = server.bandwidthManager.NewConnectionThrottle()
+	}
 
 	return connection
 }
@@ -81,7 +96,7 @@ func (c *gRPCConnection) Start(router *router.Router) {
 }
 
 func (c *gRPCConnection) String() string {
-	return c.Address().String()
+	return c.address.String()
 }
 
 func (c *gRPCConnection) IsConnected() bool {
@@ -123,8 +138,53 @@ func (c *gRPCConnection) Disconnect() {
 	}
 }
 
+// ReceivedMessageCount returns the amount of messages received through the connection
+//
+// This is part of the Connection interface
+func (c *gRPCConnection) ReceivedMessageCount() uint64 {
+	return atomic.LoadUint64(&c.receivedMessageCount)
+}
+
+// SentMessageCount returns the amount of messages sent through the connection
+//
+// This is part of the Connection interface
+func (c *gRPCConnection) SentMessageCount() uint64 {
+	return atomic.LoadUint64(&c.sentMessageCount)
+}
+
+// BytesReceived returns the amount of bytes received through the connection
+//
+// This is part of the Connection interface
+func (c *gRPCConnection) BytesReceived() uint64 {
+	return atomic.LoadUint64(&c.bytesReceived)
+}
+
+// BytesSent returns the amount of bytes sent through the connection
+//
+// This is part of the Connection interface
+func (c *gRPCConnection) BytesSent() uint64 {
+	return atomic.LoadUint64(&c.bytesSent)
+}
+
+// IsReadOnly returns whether the connection is of an RPC client that may only
+// call read-only commands
+//
+// This is part of the Connection interface
+func (c *gRPCConnection) IsReadOnly() bool {
+	return c.isReadOnly
+}
+
+// CredentialID returns the ID of the credential the RPC client of the
+// connection authenticated with, or an empty string if it didn't have to
+//
+// This is part of the Connection interface
+func (c *gRPCConnection) CredentialID() string {
+	return c.credentialID
+}
+
 func (c *gRPCConnection) Address() *net.TCPAddr {
-	return c.address
+	tcpAddress, _ := c.address.(*net.TCPAddr)
+	return tcpAddress
 }
 
 func (c *gRPCConnection) receive() (*protowire.KaspadMessage, error) {
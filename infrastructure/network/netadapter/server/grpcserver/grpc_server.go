@@ -2,7 +2,9 @@ package grpcserver
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/bandwidth"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/util/panics"
 	"github.com/pkg/errors"
@@ -16,19 +18,28 @@ import (
 type gRPCServer struct {
 	onConnectedHandler server.OnConnectedHandler
 	listeningAddresses []string
+	unixSocket         *UnixSocket
+	tlsConfig          *tls.Config
 	server             *grpc.Server
 	name               string
 
 	maxInboundConnections      int
 	inboundConnectionCount     int
 	inboundConnectionCountLock *sync.Mutex
+
+	// bandwidthManager throttles the connections of the server. It's
+	// nil for RPC servers, whose connections aren't throttled.
+	bandwidthManager *bandwidth.Manager
 }
 
 // newGRPCServer creates a gRPC server
-func newGRPCServer(listeningAddresses []string, maxMessageSize int, maxInboundConnections int, name string) *gRPCServer {
+func newGRPCServer(listeningAddresses []string, maxMessageSize int, maxInboundConnections int, name string,
+	serverOptions ...grpc.ServerOption) *gRPCServer {
+
 	log.Debugf("Created new %s GRPC server with maxMessageSize %d and maxInboundConnections %d", name, maxMessageSize, maxInboundConnections)
+	serverOptions = append(serverOptions, grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize))
 	return &gRPCServer{
-		server:                     grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)),
+		server:                     grpc.NewServer(serverOptions...),
 		listeningAddresses:         listeningAddresses,
 		name:                       name,
 		maxInboundConnections:      maxInboundConnections,
@@ -48,6 +59,12 @@ func (s *gRPCServer) Start() error {
 			return err
 		}
 	}
+	if s.unixSocket != nil {
+		err := s.listenOnUnixSocket(s.unixSocket)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -57,7 +74,15 @@ func (s *gRPCServer) listenOn(listenAddr string) error {
 	if err != nil {
 		return errors.Wrapf(err, "%s error listening on %s", s.name, listenAddr)
 	}
+	if s.tlsConfig != nil {
+		listener = tls.NewListener(listener, s.tlsConfig)
+	}
 
+	s.serve(listener, listenAddr)
+	return nil
+}
+
+func (s *gRPCServer) serve(listener net.Listener, listenAddr string) {
 	spawn(fmt.Sprintf("%s.gRPCServer.listenOn-Serve", s.name), func() {
 		err := s.server.Serve(listener)
 		if err != nil {
@@ -66,7 +91,6 @@ func (s *gRPCServer) listenOn(listenAddr string) error {
 	})
 
 	log.Infof("%s Server listening on %s", s.name, listener.Addr())
-	return nil
 }
 
 func (s *gRPCServer) Stop() error {
@@ -93,7 +117,9 @@ func (s *gRPCServer) SetOnConnectedHandler(onConnectedHandler server.OnConnected
 	s.onConnectedHandler = onConnectedHandler
 }
 
-func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStream) error {
+func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStream,
+	isReadOnly bool, credentialID string) error {
+
 	connectionCount, err := s.incrementInboundConnectionCountAndLimitIfRequired()
 	if err != nil {
 		return err
@@ -104,12 +130,15 @@ func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStr
 	if !ok {
 		return errors.Errorf("Error getting stream peer info from context")
 	}
-	tcpAddress, ok := peerInfo.Addr.(*net.TCPAddr)
-	if !ok {
-		return errors.Errorf("non-tcp connections are not supported")
+	switch peerInfo.Addr.(type) {
+	case *net.TCPAddr, *net.UnixAddr:
+	default:
+		return errors.Errorf("%s connections are not supported", peerInfo.Addr.Network())
 	}
 
-	connection := newConnection(s, tcpAddress, stream, nil)
+	connection := newConnection(s, peerInfo.Addr, stream, nil)
+	connection.isReadOnly = isReadOnly
+	connection.credentialID = credentialID
 
 	err = s.onConnectedHandler(connection)
 	if err != nil {
@@ -2,6 +2,7 @@ package grpcserver
 
 import (
 	"context"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/bandwidth"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/kaspanet/kaspad/util/panics"
@@ -16,20 +17,28 @@ import (
 type p2pServer struct {
 	protowire.UnimplementedP2PServer
 	gRPCServer
+	dialer      server.Dialer
+	dialTimeout time.Duration
 }
 
 const p2pMaxMessageSize = 1024 * 1024 * 1024 // 1GB
 
+// defaultDialTimeout is how long Connect waits for the connection
+// to be established, unless a dialer with its own timeout is set
+const defaultDialTimeout = 1 * time.Second
+
 // p2pMaxInboundConnections is the max amount of inbound connections for the P2P server.
 // Note that inbound connections are not limited by the gRPC server. (A value of 0 means
 // unlimited inbound connections.) The P2P limiting logic is more applicative, and as such
 // is handled in the ConnectionManager instead.
 const p2pMaxInboundConnections = 0
 
-// NewP2PServer creates a new P2PServer
-func NewP2PServer(listeningAddresses []string) (server.P2PServer, error) {
+// NewP2PServer creates a new P2PServer, whose connections are throttled
+// by the given bandwidth manager
+func NewP2PServer(listeningAddresses []string, bandwidthManager *bandwidth.Manager) (server.P2PServer, error) {
 	gRPCServer := newGRPCServer(listeningAddresses, p2pMaxMessageSize, p2pMaxInboundConnections, "P2P")
-	p2pServer := &p2pServer{gRPCServer: *gRPCServer}
+	gRPCServer.bandwidthManager = bandwidthManager
+	p2pServer := &p2pServer{gRPCServer: *gRPCServer, dialTimeout: defaultDialTimeout}
 	protowire.RegisterP2PServer(gRPCServer.server, p2pServer)
 	return p2pServer, nil
 }
@@ -37,7 +46,7 @@ func NewP2PServer(listeningAddresses []string) (server.P2PServer, error) {
 func (p *p2pServer) MessageStream(stream protowire.P2P_MessageStreamServer) error {
 	defer panics.HandlePanic(log, "p2pServer.MessageStream", nil)
 
-	return p.handleInboundConnection(stream.Context(), stream)
+	return p.handleInboundConnection(stream.Context(), stream, false, "")
 }
 
 // Connect connects to the given address
@@ -45,11 +54,14 @@ func (p *p2pServer) MessageStream(stream protowire.P2P_MessageStreamServer) erro
 func (p *p2pServer) Connect(address string) (server.Connection, error) {
 	log.Debugf("%s Dialing to %s", p.name, address)
 
-	const dialTimeout = 1 * time.Second
-	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), p.dialTimeout)
 	defer cancel()
 
-	gRPCClientConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	dialOptions := []grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock()}
+	if p.dialer != nil {
+		dialOptions = append(dialOptions, grpc.WithContextDialer(p.dialer))
+	}
+	gRPCClientConnection, err := grpc.DialContext(ctx, address, dialOptions...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "%s error connecting to %s", p.name, address)
 	}
@@ -81,3 +93,10 @@ func (p *p2pServer) Connect(address string) (server.Connection, error) {
 
 	return connection, nil
 }
+
+// SetDialer sets the dialer used by Connect, and how long Connect waits for it
+// This is part of the P2PServer interface
+func (p *p2pServer) SetDialer(dialer server.Dialer, dialTimeout time.Duration) {
+	p.dialer = dialer
+	p.dialTimeout = dialTimeout
+}
@@ -107,6 +107,7 @@ func (x *NetAddress) toAppMessage() (*appmessage.NetAddress, error) {
 		Timestamp: mstime.UnixMilliseconds(x.Timestamp),
 		IP:        x.Ip,
 		Port:      uint16(x.Port),
+		Services:  appmessage.ServiceFlag(x.Services),
 	}, nil
 }
 
@@ -115,6 +116,7 @@ func appMessageNetAddressToProto(address *appmessage.NetAddress) *NetAddress {
 		Timestamp: address.Timestamp.UnixMilliseconds(),
 		Ip:        address.IP,
 		Port:      uint32(address.Port),
+		Services:  uint64(address.Services),
 	}
 }
 
@@ -0,0 +1,72 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_CompactBlock) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_CompactBlock is nil")
+	}
+	return x.CompactBlock.toAppMessage()
+}
+
+func (x *CompactBlockMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "CompactBlockMessage is nil")
+	}
+	header, err := x.Header.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	prefilledTransactions := make([]*appmessage.PrefilledTransaction, len(x.PrefilledTransactions))
+	for i, prefilledTransaction := range x.PrefilledTransactions {
+		prefilledTransactions[i], err = prefilledTransaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return appmessage.NewMsgCompactBlock(header, x.ShortIds, prefilledTransactions), nil
+}
+
+func (x *PrefilledTransaction) toAppMessage() (*appmessage.PrefilledTransaction, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "PrefilledTransaction is nil")
+	}
+	msgTx, err := x.Transaction.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.PrefilledTransaction{
+		Index: x.Index,
+		Tx:    msgTx.(*appmessage.MsgTx),
+	}, nil
+}
+
+func (x *KaspadMessage_CompactBlock) fromAppMessage(msgCompactBlock *appmessage.MsgCompactBlock) error {
+	header := new(BlockHeader)
+	err := header.fromAppMessage(&msgCompactBlock.Header)
+	if err != nil {
+		return err
+	}
+
+	prefilledTransactions := make([]*PrefilledTransaction, len(msgCompactBlock.PrefilledTransactions))
+	for i, prefilledTransaction := range msgCompactBlock.PrefilledTransactions {
+		protoTx := new(TransactionMessage)
+		protoTx.fromAppMessage(prefilledTransaction.Tx)
+		prefilledTransactions[i] = &PrefilledTransaction{
+			Index:       prefilledTransaction.Index,
+			Transaction: protoTx,
+		}
+	}
+
+	x.CompactBlock = &CompactBlockMessage{
+		Header:                header,
+		ShortIds:              msgCompactBlock.ShortIDs,
+		PrefilledTransactions: prefilledTransactions,
+	}
+	return nil
+}
@@ -24,6 +24,12 @@ func (x *VersionMessage) toAppMessage() (appmessage.Message, error) {
 		return nil, err
 	}
 
+	receiverAddress, err := x.ReceiverAddress.toAppMessage()
+	// ReceiverAddress is optional, and isn't sent by older nodes
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
 	subnetworkID, err := x.SubnetworkId.toDomain()
 	//  Full kaspa nodes set SubnetworkId==nil
 	if err != nil && !errors.Is(err, errorNil) {
@@ -54,6 +60,7 @@ func (x *VersionMessage) toAppMessage() (appmessage.Message, error) {
 		UserAgent:       x.UserAgent,
 		DisableRelayTx:  x.DisableRelayTx,
 		SubnetworkID:    subnetworkID,
+		ReceiverAddress: receiverAddress,
 	}, nil
 }
 
@@ -74,6 +81,11 @@ func (x *KaspadMessage_Version) fromAppMessage(msgVersion *appmessage.MsgVersion
 		address = appMessageNetAddressToProto(msgVersion.Address)
 	}
 
+	var receiverAddress *NetAddress
+	if msgVersion.ReceiverAddress != nil {
+		receiverAddress = appMessageNetAddressToProto(msgVersion.ReceiverAddress)
+	}
+
 	x.Version = &VersionMessage{
 		ProtocolVersion: msgVersion.ProtocolVersion,
 		Network:         msgVersion.Network,
@@ -84,6 +96,7 @@ func (x *KaspadMessage_Version) fromAppMessage(msgVersion *appmessage.MsgVersion
 		UserAgent:       msgVersion.UserAgent,
 		DisableRelayTx:  msgVersion.DisableRelayTx,
 		SubnetworkId:    domainSubnetworkIDToProto(msgVersion.SubnetworkID),
+		ReceiverAddress: receiverAddress,
 	}
 	return nil
 }
@@ -50,7 +50,7 @@ func (x *BanResponseMessage) toAppMessage() (appmessage.Message, error) {
 func (x *KaspadMessage_BanResponse) fromAppMessage(message *appmessage.BanResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.BanResponse = &BanResponseMessage{
 		Error: err,
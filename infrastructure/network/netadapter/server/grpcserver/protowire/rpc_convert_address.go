@@ -0,0 +1,102 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ConvertAddressRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ConvertAddressRequest is nil")
+	}
+	return x.ConvertAddressRequest.toAppMessage()
+}
+
+func (x *ConvertAddressRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ConvertAddressRequestMessage is nil")
+	}
+	scriptPublicKey, err := optionalScriptPublicKeyToAppMessage(x.ScriptPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.ConvertAddressRequestMessage{
+		Address:         x.Address,
+		ScriptPublicKey: scriptPublicKey,
+	}, nil
+}
+
+func (x *KaspadMessage_ConvertAddressRequest) fromAppMessage(message *appmessage.ConvertAddressRequestMessage) error {
+	x.ConvertAddressRequest = &ConvertAddressRequestMessage{
+		Address:         message.Address,
+		ScriptPublicKey: optionalScriptPublicKeyFromAppMessage(message.ScriptPublicKey),
+	}
+	return nil
+}
+
+func (x *KaspadMessage_ConvertAddressResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ConvertAddressResponse is nil")
+	}
+	return x.ConvertAddressResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_ConvertAddressResponse) fromAppMessage(message *appmessage.ConvertAddressResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.ConvertAddressResponse = &ConvertAddressResponseMessage{
+		Address:         message.Address,
+		ScriptPublicKey: optionalScriptPublicKeyFromAppMessage(message.ScriptPublicKey),
+		AddressType:     message.AddressType,
+		Error:           err,
+	}
+	return nil
+}
+
+func (x *ConvertAddressResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ConvertAddressResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && (x.Address != "" || x.ScriptPublicKey != nil) {
+		return nil, errors.New("ConvertAddressResponseMessage contains both an error and a response")
+	}
+
+	scriptPublicKey, err := optionalScriptPublicKeyToAppMessage(x.ScriptPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.ConvertAddressResponseMessage{
+		Address:         x.Address,
+		ScriptPublicKey: scriptPublicKey,
+		AddressType:     x.AddressType,
+		Error:           rpcErr,
+	}, nil
+}
+
+// optionalScriptPublicKeyToAppMessage converts a scriptPublicKey that may be
+// unset, in which case it returns nil
+func optionalScriptPublicKeyToAppMessage(scriptPublicKey *RpcScriptPublicKey) (*appmessage.RPCScriptPublicKey, error) {
+	if scriptPublicKey == nil {
+		return nil, nil
+	}
+	return scriptPublicKey.toAppMessage()
+}
+
+// optionalScriptPublicKeyFromAppMessage converts a scriptPublicKey that may be
+// nil, in which case it returns nil
+func optionalScriptPublicKeyFromAppMessage(scriptPublicKey *appmessage.RPCScriptPublicKey) *RpcScriptPublicKey {
+	if scriptPublicKey == nil {
+		return nil
+	}
+	protoScriptPublicKey := &RpcScriptPublicKey{}
+	protoScriptPublicKey.fromAppMessage(scriptPublicKey)
+	return protoScriptPublicKey
+}
@@ -0,0 +1,35 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_DagSnapshotNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DagSnapshotNotification is nil")
+	}
+	return x.DagSnapshotNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_DagSnapshotNotification) fromAppMessage(message *appmessage.DAGSnapshotNotificationMessage) error {
+	x.DagSnapshotNotification = &DagSnapshotNotificationMessage{
+		TipHashes:                      message.TipHashes,
+		VirtualSelectedParentHash:      message.VirtualSelectedParentHash,
+		VirtualSelectedParentBlueScore: message.VirtualSelectedParentBlueScore,
+		VirtualDaaScore:                message.VirtualDAAScore,
+	}
+	return nil
+}
+
+func (x *DagSnapshotNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DagSnapshotNotificationMessage is nil")
+	}
+	return &appmessage.DAGSnapshotNotificationMessage{
+		TipHashes:                      x.TipHashes,
+		VirtualSelectedParentHash:      x.VirtualSelectedParentHash,
+		VirtualSelectedParentBlueScore: x.VirtualSelectedParentBlueScore,
+		VirtualDAAScore:                x.VirtualDaaScore,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_DeriveAddressFromPublicKeyRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DeriveAddressFromPublicKeyRequest is nil")
+	}
+	return x.DeriveAddressFromPublicKeyRequest.toAppMessage()
+}
+
+func (x *DeriveAddressFromPublicKeyRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DeriveAddressFromPublicKeyRequestMessage is nil")
+	}
+	return &appmessage.DeriveAddressFromPublicKeyRequestMessage{
+		PublicKey: x.PublicKey,
+	}, nil
+}
+
+func (x *KaspadMessage_DeriveAddressFromPublicKeyRequest) fromAppMessage(message *appmessage.DeriveAddressFromPublicKeyRequestMessage) error {
+	x.DeriveAddressFromPublicKeyRequest = &DeriveAddressFromPublicKeyRequestMessage{
+		PublicKey: message.PublicKey,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_DeriveAddressFromPublicKeyResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DeriveAddressFromPublicKeyResponse is nil")
+	}
+	return x.DeriveAddressFromPublicKeyResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_DeriveAddressFromPublicKeyResponse) fromAppMessage(message *appmessage.DeriveAddressFromPublicKeyResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.DeriveAddressFromPublicKeyResponse = &DeriveAddressFromPublicKeyResponseMessage{
+		Address:     message.Address,
+		AddressType: message.AddressType,
+		Error:       err,
+	}
+	return nil
+}
+
+func (x *DeriveAddressFromPublicKeyResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DeriveAddressFromPublicKeyResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && x.Address != "" {
+		return nil, errors.New("DeriveAddressFromPublicKeyResponseMessage contains both an error and a response")
+	}
+
+	return &appmessage.DeriveAddressFromPublicKeyResponseMessage{
+		Address:     x.Address,
+		AddressType: x.AddressType,
+		Error:       rpcErr,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_DisconnectRPCSessionRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DisconnectRPCSessionRequest is nil")
+	}
+	return x.DisconnectRPCSessionRequest.toAppMessage()
+}
+
+func (x *DisconnectRPCSessionRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DisconnectRPCSessionRequestMessage is nil")
+	}
+	return &appmessage.DisconnectRPCSessionRequestMessage{
+		SessionID: x.SessionId,
+	}, nil
+}
+
+func (x *KaspadMessage_DisconnectRPCSessionRequest) fromAppMessage(message *appmessage.DisconnectRPCSessionRequestMessage) error {
+	x.DisconnectRPCSessionRequest = &DisconnectRPCSessionRequestMessage{
+		SessionId: message.SessionID,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_DisconnectRPCSessionResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DisconnectRPCSessionResponse is nil")
+	}
+	return x.DisconnectRPCSessionResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_DisconnectRPCSessionResponse) fromAppMessage(message *appmessage.DisconnectRPCSessionResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.DisconnectRPCSessionResponse = &DisconnectRPCSessionResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *DisconnectRPCSessionResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DisconnectRPCSessionResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.DisconnectRPCSessionResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
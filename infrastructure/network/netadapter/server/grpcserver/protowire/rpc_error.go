@@ -9,5 +9,9 @@ func (x *RPCError) toAppMessage() (*appmessage.RPCError, error) {
 	if x == nil {
 		return nil, errors.Wrapf(errorNil, "RPCError is nil")
 	}
-	return &appmessage.RPCError{Message: x.Message}, nil
+	return &appmessage.RPCError{Code: appmessage.RPCErrorCode(x.Code), Message: x.Message}, nil
+}
+
+func newRPCError(rpcError *appmessage.RPCError) *RPCError {
+	return &RPCError{Message: rpcError.Message, Code: RPCError_Code(rpcError.Code)}
 }
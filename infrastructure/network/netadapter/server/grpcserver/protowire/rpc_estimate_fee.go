@@ -0,0 +1,68 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_EstimateFeeRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_EstimateFeeRequest is nil")
+	}
+	return x.EstimateFeeRequest.toAppMessage()
+}
+
+func (x *EstimateFeeRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "EstimateFeeRequestMessage is nil")
+	}
+	return &appmessage.EstimateFeeRequestMessage{
+		TargetDAAScore: x.TargetDAAScore,
+	}, nil
+}
+
+func (x *KaspadMessage_EstimateFeeRequest) fromAppMessage(message *appmessage.EstimateFeeRequestMessage) error {
+	x.EstimateFeeRequest = &EstimateFeeRequestMessage{
+		TargetDAAScore: message.TargetDAAScore,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_EstimateFeeResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_EstimateFeeResponse is nil")
+	}
+	return x.EstimateFeeResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_EstimateFeeResponse) fromAppMessage(message *appmessage.EstimateFeeResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.EstimateFeeResponse = &EstimateFeeResponseMessage{
+		FeeRate:            message.FeeRate,
+		ConfirmationWindow: message.ConfirmationWindow,
+		IsFallback:         message.IsFallback,
+		Error:              err,
+	}
+	return nil
+}
+
+func (x *EstimateFeeResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "EstimateFeeResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.EstimateFeeResponseMessage{
+		FeeRate:            x.FeeRate,
+		ConfirmationWindow: x.ConfirmationWindow,
+		IsFallback:         x.IsFallback,
+		Error:              rpcErr,
+	}, nil
+}
@@ -40,7 +40,7 @@ func (x *KaspadMessage_EstimateNetworkHashesPerSecondResponse) toAppMessage() (a
 func (x *KaspadMessage_EstimateNetworkHashesPerSecondResponse) fromAppMessage(message *appmessage.EstimateNetworkHashesPerSecondResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.EstimateNetworkHashesPerSecondResponse = &EstimateNetworkHashesPerSecondResponseMessage{
 		NetworkHashesPerSecond: message.NetworkHashesPerSecond,
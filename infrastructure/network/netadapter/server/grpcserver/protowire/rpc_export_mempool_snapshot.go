@@ -0,0 +1,70 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ExportMempoolSnapshotRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ExportMempoolSnapshotRequest is nil")
+	}
+	return x.ExportMempoolSnapshotRequest.toAppMessage()
+}
+
+func (x *ExportMempoolSnapshotRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ExportMempoolSnapshotRequestMessage is nil")
+	}
+	return &appmessage.ExportMempoolSnapshotRequestMessage{
+		Path:              x.Path,
+		IncludeOrphanPool: x.IncludeOrphanPool,
+	}, nil
+}
+
+func (x *KaspadMessage_ExportMempoolSnapshotRequest) fromAppMessage(message *appmessage.ExportMempoolSnapshotRequestMessage) error {
+	x.ExportMempoolSnapshotRequest = &ExportMempoolSnapshotRequestMessage{
+		Path:              message.Path,
+		IncludeOrphanPool: message.IncludeOrphanPool,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_ExportMempoolSnapshotResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ExportMempoolSnapshotResponse is nil")
+	}
+	return x.ExportMempoolSnapshotResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_ExportMempoolSnapshotResponse) fromAppMessage(message *appmessage.ExportMempoolSnapshotResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.ExportMempoolSnapshotResponse = &ExportMempoolSnapshotResponseMessage{
+		TransactionCount: message.TransactionCount,
+		OrphanCount:      message.OrphanCount,
+		Timestamp:        message.Timestamp,
+		Error:            err,
+	}
+	return nil
+}
+
+func (x *ExportMempoolSnapshotResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ExportMempoolSnapshotResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.ExportMempoolSnapshotResponseMessage{
+		TransactionCount: x.TransactionCount,
+		OrphanCount:      x.OrphanCount,
+		Timestamp:        x.Timestamp,
+		Error:            rpcErr,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ExportUTXOSnapshotRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ExportUTXOSnapshotRequest is nil")
+	}
+	return x.ExportUTXOSnapshotRequest.toAppMessage()
+}
+
+func (x *ExportUTXOSnapshotRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ExportUTXOSnapshotRequestMessage is nil")
+	}
+	return &appmessage.ExportUTXOSnapshotRequestMessage{
+		Path: x.Path,
+	}, nil
+}
+
+func (x *KaspadMessage_ExportUTXOSnapshotRequest) fromAppMessage(message *appmessage.ExportUTXOSnapshotRequestMessage) error {
+	x.ExportUTXOSnapshotRequest = &ExportUTXOSnapshotRequestMessage{
+		Path: message.Path,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_ExportUTXOSnapshotResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ExportUTXOSnapshotResponse is nil")
+	}
+	return x.ExportUTXOSnapshotResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_ExportUTXOSnapshotResponse) fromAppMessage(message *appmessage.ExportUTXOSnapshotResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.ExportUTXOSnapshotResponse = &ExportUTXOSnapshotResponseMessage{
+		PruningPointHash: message.PruningPointHash,
+		BlueScore:        message.BlueScore,
+		UtxoCommitment:   message.UTXOCommitment,
+		UtxoCount:        message.UTXOCount,
+		Error:            err,
+	}
+	return nil
+}
+
+func (x *ExportUTXOSnapshotResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ExportUTXOSnapshotResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.ExportUTXOSnapshotResponseMessage{
+		PruningPointHash: x.PruningPointHash,
+		BlueScore:        x.BlueScore,
+		UTXOCommitment:   x.UtxoCommitment,
+		UTXOCount:        x.UtxoCount,
+		Error:            rpcErr,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetAddressManagerInfoRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetAddressManagerInfoRequest is nil")
+	}
+	return x.GetAddressManagerInfoRequest.toAppMessage()
+}
+
+func (x *GetAddressManagerInfoRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetAddressManagerInfoRequestMessage is nil")
+	}
+	return &appmessage.GetAddressManagerInfoRequestMessage{
+		SampleSize: x.SampleSize,
+	}, nil
+}
+
+func (x *KaspadMessage_GetAddressManagerInfoRequest) fromAppMessage(message *appmessage.GetAddressManagerInfoRequestMessage) error {
+	x.GetAddressManagerInfoRequest = &GetAddressManagerInfoRequestMessage{
+		SampleSize: message.SampleSize,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetAddressManagerInfoResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetAddressManagerInfoResponse is nil")
+	}
+	return x.GetAddressManagerInfoResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetAddressManagerInfoResponse) fromAppMessage(message *appmessage.GetAddressManagerInfoResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	sourceAddressCounts := make([]*AddressManagerSourceCount, len(message.SourceAddressCounts))
+	for i, sourceAddressCount := range message.SourceAddressCounts {
+		sourceAddressCounts[i] = &AddressManagerSourceCount{
+			Source:       sourceAddressCount.Source,
+			AddressCount: sourceAddressCount.AddressCount,
+		}
+	}
+	sampleAddresses := make([]*AddressManagerEntry, len(message.SampleAddresses))
+	for i, sampleAddress := range message.SampleAddresses {
+		sampleAddresses[i] = &AddressManagerEntry{
+			Address:               sampleAddress.Address,
+			ConnectionFailedCount: sampleAddress.ConnectionFailedCount,
+			Source:                sampleAddress.Source,
+			Group:                 sampleAddress.Group,
+			Timestamp:             sampleAddress.Timestamp,
+		}
+	}
+	x.GetAddressManagerInfoResponse = &GetAddressManagerInfoResponseMessage{
+		AddressCount:                      message.AddressCount,
+		BannedAddressCount:                message.BannedAddressCount,
+		AddressCountsByConnectionFailures: message.AddressCountsByConnectionFailures,
+		AddressGroupCount:                 message.AddressGroupCount,
+		SourceAddressCounts:               sourceAddressCounts,
+		SampleAddresses:                   sampleAddresses,
+		Error:                             err,
+	}
+	return nil
+}
+
+func (x *GetAddressManagerInfoResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetAddressManagerInfoResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	sourceAddressCounts := make([]*appmessage.AddressManagerSourceCount, len(x.SourceAddressCounts))
+	for i, sourceAddressCount := range x.SourceAddressCounts {
+		if sourceAddressCount == nil {
+			return nil, errors.Wrapf(errorNil, "AddressManagerSourceCount is nil")
+		}
+		sourceAddressCounts[i] = &appmessage.AddressManagerSourceCount{
+			Source:       sourceAddressCount.Source,
+			AddressCount: sourceAddressCount.AddressCount,
+		}
+	}
+	sampleAddresses := make([]*appmessage.AddressManagerEntry, len(x.SampleAddresses))
+	for i, sampleAddress := range x.SampleAddresses {
+		if sampleAddress == nil {
+			return nil, errors.Wrapf(errorNil, "AddressManagerEntry is nil")
+		}
+		sampleAddresses[i] = &appmessage.AddressManagerEntry{
+			Address:               sampleAddress.Address,
+			ConnectionFailedCount: sampleAddress.ConnectionFailedCount,
+			Source:                sampleAddress.Source,
+			Group:                 sampleAddress.Group,
+			Timestamp:             sampleAddress.Timestamp,
+		}
+	}
+	return &appmessage.GetAddressManagerInfoResponseMessage{
+		AddressCount:                      x.AddressCount,
+		BannedAddressCount:                x.BannedAddressCount,
+		AddressCountsByConnectionFailures: x.AddressCountsByConnectionFailures,
+		AddressGroupCount:                 x.AddressGroupCount,
+		SourceAddressCounts:               sourceAddressCounts,
+		SampleAddresses:                   sampleAddresses,
+		Error:                             rpcErr,
+	}, nil
+}
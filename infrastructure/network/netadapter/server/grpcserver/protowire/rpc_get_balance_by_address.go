@@ -38,7 +38,7 @@ func (x *KaspadMessage_GetBalanceByAddressResponse) toAppMessage() (appmessage.M
 func (x *KaspadMessage_GetBalanceByAddressResponse) fromAppMessage(message *appmessage.GetBalanceByAddressResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.GetBalanceByAddressResponse = &GetBalanceByAddressResponseMessage{
 		Balance: message.Balance,
@@ -58,7 +58,7 @@ func (x *GetBalanceByAddressResponseMessage) toAppMessage() (appmessage.Message,
 		return nil, err
 	}
 
-	if rpcErr != nil && x.Balance != 1 {
+	if rpcErr != nil && x.Balance != 0 {
 		return nil, errors.New("GetBalanceByAddressResponse contains both an error and a response")
 	}
 
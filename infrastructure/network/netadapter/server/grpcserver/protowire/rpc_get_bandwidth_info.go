@@ -0,0 +1,81 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetBandwidthInfoRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetBandwidthInfoRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetBandwidthInfoRequest) fromAppMessage(_ *appmessage.GetBandwidthInfoRequestMessage) error {
+	x.GetBandwidthInfoRequest = &GetBandwidthInfoRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetBandwidthInfoResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetBandwidthInfoResponse is nil")
+	}
+	return x.GetBandwidthInfoResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetBandwidthInfoResponse) fromAppMessage(message *appmessage.GetBandwidthInfoResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	peers := make([]*PeerBandwidthInfo, len(message.Peers))
+	for i, peer := range message.Peers {
+		peers[i] = &PeerBandwidthInfo{
+			Id:            peer.ID,
+			Address:       peer.Address,
+			BytesSent:     peer.BytesSent,
+			BytesReceived: peer.BytesReceived,
+		}
+	}
+	x.GetBandwidthInfoResponse = &GetBandwidthInfoResponseMessage{
+		TotalBytesSent:     message.TotalBytesSent,
+		TotalBytesReceived: message.TotalBytesReceived,
+		UploadLimit:        message.UploadLimit,
+		DownloadLimit:      message.DownloadLimit,
+		PeerUploadLimit:    message.PeerUploadLimit,
+		PeerDownloadLimit:  message.PeerDownloadLimit,
+		Peers:              peers,
+		Error:              err,
+	}
+	return nil
+}
+
+func (x *GetBandwidthInfoResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetBandwidthInfoResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	peers := make([]*appmessage.PeerBandwidthInfo, len(x.Peers))
+	for i, peer := range x.Peers {
+		peers[i] = &appmessage.PeerBandwidthInfo{
+			ID:            peer.Id,
+			Address:       peer.Address,
+			BytesSent:     peer.BytesSent,
+			BytesReceived: peer.BytesReceived,
+		}
+	}
+
+	return &appmessage.GetBandwidthInfoResponseMessage{
+		TotalBytesSent:     x.TotalBytesSent,
+		TotalBytesReceived: x.TotalBytesReceived,
+		UploadLimit:        x.UploadLimit,
+		DownloadLimit:      x.DownloadLimit,
+		PeerUploadLimit:    x.PeerUploadLimit,
+		PeerDownloadLimit:  x.PeerDownloadLimit,
+		Peers:              peers,
+		Error:              rpcErr,
+	}, nil
+}
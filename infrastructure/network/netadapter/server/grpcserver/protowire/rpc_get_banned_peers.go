@@ -0,0 +1,76 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetBannedPeersRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetBannedPeersRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetBannedPeersRequest) fromAppMessage(_ *appmessage.GetBannedPeersRequestMessage) error {
+	x.GetBannedPeersRequest = &GetBannedPeersRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetBannedPeersResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetBannedPeersResponse is nil")
+	}
+	return x.GetBannedPeersResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetBannedPeersResponse) fromAppMessage(message *appmessage.GetBannedPeersResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetBannedPeersResponse = &GetBannedPeersResponseMessage{
+		BannedPeers: bannedPeersFromAppMessage(message.BannedPeers),
+		Error:       err,
+	}
+	return nil
+}
+
+func (x *GetBannedPeersResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetBannedPeersResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetBannedPeersResponseMessage{
+		BannedPeers: bannedPeersToAppMessage(x.BannedPeers),
+		Error:       rpcErr,
+	}, nil
+}
+
+func bannedPeersToAppMessage(bannedPeers []*BannedPeer) []*appmessage.BannedPeer {
+	appBannedPeers := make([]*appmessage.BannedPeer, len(bannedPeers))
+	for i, bannedPeer := range bannedPeers {
+		appBannedPeers[i] = &appmessage.BannedPeer{
+			IP:          bannedPeer.Ip,
+			BanTime:     bannedPeer.BanTime,
+			BannedUntil: bannedPeer.BannedUntil,
+			Reason:      bannedPeer.Reason,
+		}
+	}
+	return appBannedPeers
+}
+
+func bannedPeersFromAppMessage(bannedPeers []*appmessage.BannedPeer) []*BannedPeer {
+	protoBannedPeers := make([]*BannedPeer, len(bannedPeers))
+	for i, bannedPeer := range bannedPeers {
+		protoBannedPeers[i] = &BannedPeer{
+			Ip:          bannedPeer.IP,
+			BanTime:     bannedPeer.BanTime,
+			BannedUntil: bannedPeer.BannedUntil,
+			Reason:      bannedPeer.Reason,
+		}
+	}
+	return protoBannedPeers
+}
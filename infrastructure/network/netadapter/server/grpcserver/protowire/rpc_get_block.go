@@ -19,6 +19,7 @@ func (x *GetBlockRequestMessage) toAppMessage() (appmessage.Message, error) {
 	return &appmessage.GetBlockRequestMessage{
 		Hash:                x.Hash,
 		IncludeTransactions: x.IncludeTransactions,
+		Verbosity:           appmessage.RPCVerbosity(x.Verbosity),
 	}, nil
 }
 
@@ -26,6 +27,7 @@ func (x *KaspadMessage_GetBlockRequest) fromAppMessage(message *appmessage.GetBl
 	x.GetBlockRequest = &GetBlockRequestMessage{
 		Hash:                message.Hash,
 		IncludeTransactions: message.IncludeTransactions,
+		Verbosity:           RpcVerbosity(message.Verbosity),
 	}
 	return nil
 }
@@ -66,7 +68,7 @@ func (x *GetBlockResponseMessage) toAppMessage() (appmessage.Message, error) {
 func (x *KaspadMessage_GetBlockResponse) fromAppMessage(message *appmessage.GetBlockResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	var block *RpcBlock
 	if message.Block != nil {
@@ -27,7 +27,7 @@ func (x *KaspadMessage_GetBlockCountResponse) toAppMessage() (appmessage.Message
 func (x *KaspadMessage_GetBlockCountResponse) fromAppMessage(message *appmessage.GetBlockCountResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.GetBlockCountResponse = &GetBlockCountResponseMessage{
 		BlockCount:  message.BlockCount,
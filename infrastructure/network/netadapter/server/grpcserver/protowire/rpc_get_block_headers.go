@@ -0,0 +1,67 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetBlockHeadersRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetBlockHeadersRequest is nil")
+	}
+	return x.GetBlockHeadersRequest.toAppMessage()
+}
+
+func (x *GetBlockHeadersRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetBlockHeadersRequestMessage is nil")
+	}
+	return &appmessage.GetBlockHeadersRequestMessage{
+		LowHash: x.LowHash,
+		Count:   x.Count,
+	}, nil
+}
+
+func (x *KaspadMessage_GetBlockHeadersRequest) fromAppMessage(message *appmessage.GetBlockHeadersRequestMessage) error {
+	x.GetBlockHeadersRequest = &GetBlockHeadersRequestMessage{
+		LowHash: message.LowHash,
+		Count:   message.Count,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetBlockHeadersResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetBlockHeadersResponse is nil")
+	}
+	return x.GetBlockHeadersResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetBlockHeadersResponse) fromAppMessage(message *appmessage.GetBlockHeadersResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetBlockHeadersResponse = &GetBlockHeadersResponseMessage{
+		Headers:  message.Headers,
+		HighHash: message.HighHash,
+		Error:    err,
+	}
+	return nil
+}
+
+func (x *GetBlockHeadersResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetBlockHeadersResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.GetBlockHeadersResponseMessage{
+		Headers:  x.Headers,
+		HighHash: x.HighHash,
+		Error:    rpcErr,
+	}, nil
+}
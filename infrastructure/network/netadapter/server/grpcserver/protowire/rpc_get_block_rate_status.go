@@ -0,0 +1,77 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetBlockRateStatusRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetBlockRateStatusRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetBlockRateStatusRequest) fromAppMessage(_ *appmessage.GetBlockRateStatusRequestMessage) error {
+	x.GetBlockRateStatusRequest = &GetBlockRateStatusRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetBlockRateStatusResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetBlockRateStatusResponse is nil")
+	}
+	return x.GetBlockRateStatusResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetBlockRateStatusResponse) fromAppMessage(message *appmessage.GetBlockRateStatusResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	alerts := make([]*BlockRateAlert, len(message.Alerts))
+	for i, alert := range message.Alerts {
+		alerts[i] = &BlockRateAlert{
+			Kind:    alert.Kind,
+			Message: alert.Message,
+			Since:   alert.Since,
+		}
+	}
+	x.GetBlockRateStatusResponse = &GetBlockRateStatusResponseMessage{
+		TargetBlockRate:   message.TargetBlockRate,
+		ObservedBlockRate: message.ObservedBlockRate,
+		IsWindowFull:      message.IsWindowFull,
+		DifficultyChange:  message.DifficultyChange,
+		TipTimestampDelay: message.TipTimestampDelay,
+		Alerts:            alerts,
+		Error:             err,
+	}
+	return nil
+}
+
+func (x *GetBlockRateStatusResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetBlockRateStatusResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	alerts := make([]*appmessage.BlockRateAlert, len(x.Alerts))
+	for i, alert := range x.Alerts {
+		alerts[i] = &appmessage.BlockRateAlert{
+			Kind:    alert.Kind,
+			Message: alert.Message,
+			Since:   alert.Since,
+		}
+	}
+
+	return &appmessage.GetBlockRateStatusResponseMessage{
+		TargetBlockRate:   x.TargetBlockRate,
+		ObservedBlockRate: x.ObservedBlockRate,
+		IsWindowFull:      x.IsWindowFull,
+		DifficultyChange:  x.DifficultyChange,
+		TipTimestampDelay: x.TipTimestampDelay,
+		Alerts:            alerts,
+		Error:             rpcErr,
+	}, nil
+}
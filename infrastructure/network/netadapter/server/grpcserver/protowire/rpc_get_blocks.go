@@ -17,6 +17,8 @@ func (x *KaspadMessage_GetBlocksRequest) fromAppMessage(message *appmessage.GetB
 		LowHash:             message.LowHash,
 		IncludeBlocks:       message.IncludeBlocks,
 		IncludeTransactions: message.IncludeTransactions,
+		Verbosity:           RpcVerbosity(message.Verbosity),
+		Limit:               message.Limit,
 	}
 	return nil
 }
@@ -29,6 +31,8 @@ func (x *GetBlocksRequestMessage) toAppMessage() (appmessage.Message, error) {
 		LowHash:             x.LowHash,
 		IncludeBlocks:       x.IncludeBlocks,
 		IncludeTransactions: x.IncludeTransactions,
+		Verbosity:           appmessage.RPCVerbosity(x.Verbosity),
+		Limit:               x.Limit,
 	}, nil
 }
 
@@ -42,10 +46,12 @@ func (x *KaspadMessage_GetBlocksResponse) toAppMessage() (appmessage.Message, er
 func (x *KaspadMessage_GetBlocksResponse) fromAppMessage(message *appmessage.GetBlocksResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.GetBlocksResponse = &GetBlocksResponseMessage{
-		Error: err,
+		NextLowHash: message.NextLowHash,
+		HasMore:     message.HasMore,
+		Error:       err,
 	}
 	x.GetBlocksResponse.BlockHashes = message.BlockHashes
 	x.GetBlocksResponse.Blocks = make([]*RpcBlock, len(message.Blocks))
@@ -84,6 +90,8 @@ func (x *GetBlocksResponseMessage) toAppMessage() (appmessage.Message, error) {
 	return &appmessage.GetBlocksResponseMessage{
 		BlockHashes: x.BlockHashes,
 		Blocks:      blocks,
+		NextLowHash: x.NextLowHash,
+		HasMore:     x.HasMore,
 		Error:       rpcErr,
 	}, nil
 }
@@ -0,0 +1,113 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetChainChangedEventsFromBlockRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetChainChangedEventsFromBlockRequest is nil")
+	}
+	return x.GetChainChangedEventsFromBlockRequest.toAppMessage()
+}
+
+func (x *GetChainChangedEventsFromBlockRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetChainChangedEventsFromBlockRequestMessage is nil")
+	}
+	return &appmessage.GetChainChangedEventsFromBlockRequestMessage{
+		StartHash: x.StartHash,
+		Limit:     x.Limit,
+	}, nil
+}
+
+func (x *KaspadMessage_GetChainChangedEventsFromBlockRequest) fromAppMessage(message *appmessage.GetChainChangedEventsFromBlockRequestMessage) error {
+	x.GetChainChangedEventsFromBlockRequest = &GetChainChangedEventsFromBlockRequestMessage{
+		StartHash: message.StartHash,
+		Limit:     message.Limit,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetChainChangedEventsFromBlockResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetChainChangedEventsFromBlockResponse is nil")
+	}
+	return x.GetChainChangedEventsFromBlockResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetChainChangedEventsFromBlockResponse) fromAppMessage(message *appmessage.GetChainChangedEventsFromBlockResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetChainChangedEventsFromBlockResponse = &GetChainChangedEventsFromBlockResponseMessage{
+		RemovedChainBlockHashes: message.RemovedChainBlockHashes,
+		AddedChainBlockHashes:   message.AddedChainBlockHashes,
+		AcceptedTransactions:    acceptedTransactionsFromAppMessage(message.AcceptedTransactions),
+		NextStartHash:           message.NextStartHash,
+		HasMore:                 message.HasMore,
+		Error:                   err,
+	}
+	return nil
+}
+
+func (x *GetChainChangedEventsFromBlockResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetChainChangedEventsFromBlockResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	acceptedTransactions, err := acceptedTransactionsToAppMessage(x.AcceptedTransactions)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.GetChainChangedEventsFromBlockResponseMessage{
+		RemovedChainBlockHashes: x.RemovedChainBlockHashes,
+		AddedChainBlockHashes:   x.AddedChainBlockHashes,
+		AcceptedTransactions:    acceptedTransactions,
+		NextStartHash:           x.NextStartHash,
+		HasMore:                 x.HasMore,
+		Error:                   rpcErr,
+	}, nil
+}
+
+func acceptedTransactionsToAppMessage(acceptedTransactions []*AcceptedTransactions) ([]*appmessage.AcceptedTransactions, error) {
+	appAcceptedTransactions := make([]*appmessage.AcceptedTransactions, len(acceptedTransactions))
+	for i, blockAcceptedTransactions := range acceptedTransactions {
+		transactions := make([]*appmessage.RPCTransaction, len(blockAcceptedTransactions.AcceptedTransactions))
+		for j, transaction := range blockAcceptedTransactions.AcceptedTransactions {
+			appTransaction, err := transaction.toAppMessage()
+			if err != nil {
+				return nil, err
+			}
+			transactions[j] = appTransaction
+		}
+		appAcceptedTransactions[i] = &appmessage.AcceptedTransactions{
+			AcceptingBlockHash:   blockAcceptedTransactions.AcceptingBlockHash,
+			AcceptedTransactions: transactions,
+		}
+	}
+	return appAcceptedTransactions, nil
+}
+
+func acceptedTransactionsFromAppMessage(acceptedTransactions []*appmessage.AcceptedTransactions) []*AcceptedTransactions {
+	protoAcceptedTransactions := make([]*AcceptedTransactions, len(acceptedTransactions))
+	for i, blockAcceptedTransactions := range acceptedTransactions {
+		transactions := make([]*RpcTransaction, len(blockAcceptedTransactions.AcceptedTransactions))
+		for j, transaction := range blockAcceptedTransactions.AcceptedTransactions {
+			transactions[j] = &RpcTransaction{}
+			transactions[j].fromAppMessage(transaction)
+		}
+		protoAcceptedTransactions[i] = &AcceptedTransactions{
+			AcceptingBlockHash:   blockAcceptedTransactions.AcceptingBlockHash,
+			AcceptedTransactions: transactions,
+		}
+	}
+	return protoAcceptedTransactions
+}
@@ -0,0 +1,62 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetChainWorkStatusRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetChainWorkStatusRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetChainWorkStatusRequest) fromAppMessage(_ *appmessage.GetChainWorkStatusRequestMessage) error {
+	x.GetChainWorkStatusRequest = &GetChainWorkStatusRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetChainWorkStatusResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetChainWorkStatusResponse is nil")
+	}
+	return x.GetChainWorkStatusResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetChainWorkStatusResponse) fromAppMessage(message *appmessage.GetChainWorkStatusResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetChainWorkStatusResponse = &GetChainWorkStatusResponseMessage{
+		VirtualSelectedParentHash:     message.VirtualSelectedParentHash,
+		VirtualSelectedParentBlueWork: message.VirtualSelectedParentBlueWork,
+		HeaviestClaimedBlockHash:      message.HeaviestClaimedBlockHash,
+		HeaviestClaimedBlueWork:       message.HeaviestClaimedBlueWork,
+		HeaviestClaimedByPeer:         message.HeaviestClaimedByPeer,
+		LagInBlocks:                   message.LagInBlocks,
+		IsLagging:                     message.IsLagging,
+		Error:                         err,
+	}
+	return nil
+}
+
+func (x *GetChainWorkStatusResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetChainWorkStatusResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetChainWorkStatusResponseMessage{
+		VirtualSelectedParentHash:     x.VirtualSelectedParentHash,
+		VirtualSelectedParentBlueWork: x.VirtualSelectedParentBlueWork,
+		HeaviestClaimedBlockHash:      x.HeaviestClaimedBlockHash,
+		HeaviestClaimedBlueWork:       x.HeaviestClaimedBlueWork,
+		HeaviestClaimedByPeer:         x.HeaviestClaimedByPeer,
+		LagInBlocks:                   x.LagInBlocks,
+		IsLagging:                     x.IsLagging,
+		Error:                         rpcErr,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetCoinAgeAnalyticsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetCoinAgeAnalyticsRequest is nil")
+	}
+	return x.GetCoinAgeAnalyticsRequest.toAppMessage()
+}
+
+func (x *GetCoinAgeAnalyticsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetCoinAgeAnalyticsRequestMessage is nil")
+	}
+	return &appmessage.GetCoinAgeAnalyticsRequestMessage{
+		Window: x.Window,
+	}, nil
+}
+
+func (x *KaspadMessage_GetCoinAgeAnalyticsRequest) fromAppMessage(message *appmessage.GetCoinAgeAnalyticsRequestMessage) error {
+	x.GetCoinAgeAnalyticsRequest = &GetCoinAgeAnalyticsRequestMessage{
+		Window: message.Window,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetCoinAgeAnalyticsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetCoinAgeAnalyticsResponse is nil")
+	}
+	return x.GetCoinAgeAnalyticsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetCoinAgeAnalyticsResponse) fromAppMessage(message *appmessage.GetCoinAgeAnalyticsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetCoinAgeAnalyticsResponse = &GetCoinAgeAnalyticsResponseMessage{
+		DaaScore:           message.DAAScore,
+		DaaScoreWindowSize: message.DAAScoreWindowSize,
+		ChainBlocks:        chainBlockCoinDaysDestroyedFromAppMessage(message.ChainBlocks),
+		UtxoAgeWindows:     utxoAgeWindowsFromAppMessage(message.UTXOAgeWindows),
+		Error:              err,
+	}
+	return nil
+}
+
+func (x *GetCoinAgeAnalyticsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetCoinAgeAnalyticsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetCoinAgeAnalyticsResponseMessage{
+		DAAScore:           x.DaaScore,
+		DAAScoreWindowSize: x.DaaScoreWindowSize,
+		ChainBlocks:        chainBlockCoinDaysDestroyedToAppMessage(x.ChainBlocks),
+		UTXOAgeWindows:     utxoAgeWindowsToAppMessage(x.UtxoAgeWindows),
+		Error:              rpcErr,
+	}, nil
+}
+
+func chainBlockCoinDaysDestroyedToAppMessage(chainBlocks []*ChainBlockCoinDaysDestroyed) []*appmessage.ChainBlockCoinDaysDestroyed {
+	appChainBlocks := make([]*appmessage.ChainBlockCoinDaysDestroyed, len(chainBlocks))
+	for i, chainBlock := range chainBlocks {
+		appChainBlocks[i] = &appmessage.ChainBlockCoinDaysDestroyed{
+			BlockHash:         chainBlock.BlockHash,
+			DAAScore:          chainBlock.DaaScore,
+			SpentAmount:       chainBlock.SpentAmount,
+			CoinDaysDestroyed: chainBlock.CoinDaysDestroyed,
+		}
+	}
+	return appChainBlocks
+}
+
+func chainBlockCoinDaysDestroyedFromAppMessage(chainBlocks []*appmessage.ChainBlockCoinDaysDestroyed) []*ChainBlockCoinDaysDestroyed {
+	protoChainBlocks := make([]*ChainBlockCoinDaysDestroyed, len(chainBlocks))
+	for i, chainBlock := range chainBlocks {
+		protoChainBlocks[i] = &ChainBlockCoinDaysDestroyed{
+			BlockHash:         chainBlock.BlockHash,
+			DaaScore:          chainBlock.DAAScore,
+			SpentAmount:       chainBlock.SpentAmount,
+			CoinDaysDestroyed: chainBlock.CoinDaysDestroyed,
+		}
+	}
+	return protoChainBlocks
+}
+
+func utxoAgeWindowsToAppMessage(ageWindows []*UtxoAgeWindow) []*appmessage.UTXOAgeWindow {
+	appAgeWindows := make([]*appmessage.UTXOAgeWindow, len(ageWindows))
+	for i, ageWindow := range ageWindows {
+		appAgeWindows[i] = &appmessage.UTXOAgeWindow{
+			StartDAAScore: ageWindow.StartDaaScore,
+			UTXOCount:     ageWindow.UtxoCount,
+			TotalAmount:   ageWindow.TotalAmount,
+		}
+	}
+	return appAgeWindows
+}
+
+func utxoAgeWindowsFromAppMessage(ageWindows []*appmessage.UTXOAgeWindow) []*UtxoAgeWindow {
+	protoAgeWindows := make([]*UtxoAgeWindow, len(ageWindows))
+	for i, ageWindow := range ageWindows {
+		protoAgeWindows[i] = &UtxoAgeWindow{
+			StartDaaScore: ageWindow.StartDAAScore,
+			UtxoCount:     ageWindow.UTXOCount,
+			TotalAmount:   ageWindow.TotalAmount,
+		}
+	}
+	return protoAgeWindows
+}
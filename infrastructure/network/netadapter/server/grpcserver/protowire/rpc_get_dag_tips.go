@@ -0,0 +1,80 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetDAGTipsRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetDAGTipsRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetDAGTipsRequest) fromAppMessage(_ *appmessage.GetDAGTipsRequestMessage) error {
+	x.GetDAGTipsRequest = &GetDAGTipsRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetDAGTipsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetDAGTipsResponse is nil")
+	}
+	return x.GetDAGTipsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetDAGTipsResponse) fromAppMessage(message *appmessage.GetDAGTipsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetDAGTipsResponse = &GetDAGTipsResponseMessage{
+		Tips:  dagTipsFromAppMessage(message.Tips),
+		Error: err,
+	}
+	return nil
+}
+
+func (x *GetDAGTipsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetDAGTipsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetDAGTipsResponseMessage{
+		Tips:  dagTipsToAppMessage(x.Tips),
+		Error: rpcErr,
+	}, nil
+}
+
+func dagTipsToAppMessage(tips []*DAGTip) []*appmessage.DAGTip {
+	appTips := make([]*appmessage.DAGTip, len(tips))
+	for i, tip := range tips {
+		appTips[i] = &appmessage.DAGTip{
+			Hash:                    tip.Hash,
+			BlueScore:               tip.BlueScore,
+			IsVirtualParent:         tip.IsVirtualParent,
+			IsVirtualSelectedParent: tip.IsVirtualSelectedParent,
+			IsReceiveTimeKnown:      tip.IsReceiveTimeKnown,
+			TimeSinceReceived:       tip.TimeSinceReceived,
+		}
+	}
+	return appTips
+}
+
+func dagTipsFromAppMessage(tips []*appmessage.DAGTip) []*DAGTip {
+	protoTips := make([]*DAGTip, len(tips))
+	for i, tip := range tips {
+		protoTips[i] = &DAGTip{
+			Hash:                    tip.Hash,
+			BlueScore:               tip.BlueScore,
+			IsVirtualParent:         tip.IsVirtualParent,
+			IsVirtualSelectedParent: tip.IsVirtualSelectedParent,
+			IsReceiveTimeKnown:      tip.IsReceiveTimeKnown,
+			TimeSinceReceived:       tip.TimeSinceReceived,
+		}
+	}
+	return protoTips
+}
@@ -0,0 +1,70 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetEffectiveConfigRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetEffectiveConfigRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetEffectiveConfigRequest) fromAppMessage(_ *appmessage.GetEffectiveConfigRequestMessage) error {
+	x.GetEffectiveConfigRequest = &GetEffectiveConfigRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetEffectiveConfigResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetEffectiveConfigResponse is nil")
+	}
+	return x.GetEffectiveConfigResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetEffectiveConfigResponse) fromAppMessage(message *appmessage.GetEffectiveConfigResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	options := make([]*EffectiveConfigOption, len(message.Options))
+	for i, option := range message.Options {
+		options[i] = &EffectiveConfigOption{
+			Name:   option.Name,
+			Value:  option.Value,
+			Source: option.Source,
+		}
+	}
+	x.GetEffectiveConfigResponse = &GetEffectiveConfigResponseMessage{
+		Options: options,
+		Error:   err,
+	}
+	return nil
+}
+
+func (x *GetEffectiveConfigResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetEffectiveConfigResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Options) != 0 {
+		return nil, errors.New("GetEffectiveConfigResponseMessage contains both an error and a response")
+	}
+
+	options := make([]*appmessage.EffectiveConfigOption, len(x.Options))
+	for i, option := range x.Options {
+		options[i] = &appmessage.EffectiveConfigOption{
+			Name:   option.Name,
+			Value:  option.Value,
+			Source: option.Source,
+		}
+	}
+	return &appmessage.GetEffectiveConfigResponseMessage{
+		Options: options,
+		Error:   rpcErr,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetFeeHistoryRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetFeeHistoryRequest is nil")
+	}
+	return x.GetFeeHistoryRequest.toAppMessage()
+}
+
+func (x *GetFeeHistoryRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetFeeHistoryRequestMessage is nil")
+	}
+	return &appmessage.GetFeeHistoryRequestMessage{
+		Window: x.Window,
+	}, nil
+}
+
+func (x *KaspadMessage_GetFeeHistoryRequest) fromAppMessage(message *appmessage.GetFeeHistoryRequestMessage) error {
+	x.GetFeeHistoryRequest = &GetFeeHistoryRequestMessage{
+		Window: message.Window,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetFeeHistoryResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetFeeHistoryResponse is nil")
+	}
+	return x.GetFeeHistoryResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetFeeHistoryResponse) fromAppMessage(message *appmessage.GetFeeHistoryResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetFeeHistoryResponse = &GetFeeHistoryResponseMessage{
+		Percentiles: message.Percentiles,
+		ChainBlocks: chainBlockFeeRatesFromAppMessage(message.ChainBlocks),
+		Error:       err,
+	}
+	return nil
+}
+
+func (x *GetFeeHistoryResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetFeeHistoryResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetFeeHistoryResponseMessage{
+		Percentiles: x.Percentiles,
+		ChainBlocks: chainBlockFeeRatesToAppMessage(x.ChainBlocks),
+		Error:       rpcErr,
+	}, nil
+}
+
+func chainBlockFeeRatesToAppMessage(chainBlocks []*ChainBlockFeeRates) []*appmessage.ChainBlockFeeRates {
+	appChainBlocks := make([]*appmessage.ChainBlockFeeRates, len(chainBlocks))
+	for i, chainBlock := range chainBlocks {
+		appChainBlocks[i] = &appmessage.ChainBlockFeeRates{
+			BlockHash:          chainBlock.BlockHash,
+			BlueScore:          chainBlock.BlueScore,
+			DAAScore:           chainBlock.DaaScore,
+			Timestamp:          chainBlock.Timestamp,
+			TransactionCount:   chainBlock.TransactionCount,
+			FeeRatePercentiles: chainBlock.FeeRatePercentiles,
+		}
+	}
+	return appChainBlocks
+}
+
+func chainBlockFeeRatesFromAppMessage(chainBlocks []*appmessage.ChainBlockFeeRates) []*ChainBlockFeeRates {
+	protoChainBlocks := make([]*ChainBlockFeeRates, len(chainBlocks))
+	for i, chainBlock := range chainBlocks {
+		protoChainBlocks[i] = &ChainBlockFeeRates{
+			BlockHash:          chainBlock.BlockHash,
+			BlueScore:          chainBlock.BlueScore,
+			DaaScore:           chainBlock.DAAScore,
+			Timestamp:          chainBlock.Timestamp,
+			TransactionCount:   chainBlock.TransactionCount,
+			FeeRatePercentiles: chainBlock.FeeRatePercentiles,
+		}
+	}
+	return protoChainBlocks
+}
@@ -42,7 +42,7 @@ func (x *KaspadMessage_GetHeadersResponse) toAppMessage() (appmessage.Message, e
 func (x *KaspadMessage_GetHeadersResponse) fromAppMessage(message *appmessage.GetHeadersResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.GetHeadersResponse = &GetHeadersResponseMessage{
 		Headers: message.Headers,
@@ -0,0 +1,64 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetHeadersSelectedTipRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetHeadersSelectedTipRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetHeadersSelectedTipRequest) fromAppMessage(_ *appmessage.GetHeadersSelectedTipRequestMessage) error {
+	x.GetHeadersSelectedTipRequest = &GetHeadersSelectedTipRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetHeadersSelectedTipResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetHeadersSelectedTipResponse is nil")
+	}
+	return x.GetHeadersSelectedTipResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetHeadersSelectedTipResponse) fromAppMessage(message *appmessage.GetHeadersSelectedTipResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetHeadersSelectedTipResponse = &GetHeadersSelectedTipResponseMessage{
+		Hash:        message.Hash,
+		BlueScore:   message.BlueScore,
+		DaaScore:    message.DAAScore,
+		BlueWork:    message.BlueWork,
+		Bits:        message.Bits,
+		Difficulty:  message.Difficulty,
+		Timestamp:   message.Timestamp,
+		HeaderCount: message.HeaderCount,
+		Error:       err,
+	}
+	return nil
+}
+
+func (x *GetHeadersSelectedTipResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetHeadersSelectedTipResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetHeadersSelectedTipResponseMessage{
+		Hash:        x.Hash,
+		BlueScore:   x.BlueScore,
+		DAAScore:    x.DaaScore,
+		BlueWork:    x.BlueWork,
+		Bits:        x.Bits,
+		Difficulty:  x.Difficulty,
+		Timestamp:   x.Timestamp,
+		HeaderCount: x.HeaderCount,
+		Error:       rpcErr,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetImmatureCoinbaseOutputsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetImmatureCoinbaseOutputsRequest is nil")
+	}
+	return x.GetImmatureCoinbaseOutputsRequest.toAppMessage()
+}
+
+func (x *GetImmatureCoinbaseOutputsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetImmatureCoinbaseOutputsRequestMessage is nil")
+	}
+	return &appmessage.GetImmatureCoinbaseOutputsRequestMessage{
+		Address: x.Address,
+	}, nil
+}
+
+func (x *KaspadMessage_GetImmatureCoinbaseOutputsRequest) fromAppMessage(message *appmessage.GetImmatureCoinbaseOutputsRequestMessage) error {
+	x.GetImmatureCoinbaseOutputsRequest = &GetImmatureCoinbaseOutputsRequestMessage{
+		Address: message.Address,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetImmatureCoinbaseOutputsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetImmatureCoinbaseOutputsResponse is nil")
+	}
+	return x.GetImmatureCoinbaseOutputsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetImmatureCoinbaseOutputsResponse) fromAppMessage(message *appmessage.GetImmatureCoinbaseOutputsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetImmatureCoinbaseOutputsResponse = &GetImmatureCoinbaseOutputsResponseMessage{
+		VirtualDaaScore:     message.VirtualDAAScore,
+		CoinbaseMaturity:    message.CoinbaseMaturity,
+		TotalImmatureAmount: message.TotalImmatureAmount,
+		Outputs:             immatureCoinbaseOutputsFromAppMessage(message.Outputs),
+		Error:               err,
+	}
+	return nil
+}
+
+func (x *GetImmatureCoinbaseOutputsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetImmatureCoinbaseOutputsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	outputs, err := immatureCoinbaseOutputsToAppMessage(x.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.GetImmatureCoinbaseOutputsResponseMessage{
+		VirtualDAAScore:     x.VirtualDaaScore,
+		CoinbaseMaturity:    x.CoinbaseMaturity,
+		TotalImmatureAmount: x.TotalImmatureAmount,
+		Outputs:             outputs,
+		Error:               rpcErr,
+	}, nil
+}
+
+func immatureCoinbaseOutputsToAppMessage(outputs []*ImmatureCoinbaseOutput) ([]*appmessage.ImmatureCoinbaseOutput, error) {
+	appOutputs := make([]*appmessage.ImmatureCoinbaseOutput, len(outputs))
+	for i, output := range outputs {
+		outpoint, err := output.Outpoint.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		appOutputs[i] = &appmessage.ImmatureCoinbaseOutput{
+			Outpoint:            outpoint,
+			Amount:              output.Amount,
+			BlockHash:           output.BlockHash,
+			DAAScore:            output.DaaScore,
+			SpendableAtDAAScore: output.SpendableAtDaaScore,
+		}
+	}
+	return appOutputs, nil
+}
+
+func immatureCoinbaseOutputsFromAppMessage(outputs []*appmessage.ImmatureCoinbaseOutput) []*ImmatureCoinbaseOutput {
+	protoOutputs := make([]*ImmatureCoinbaseOutput, len(outputs))
+	for i, output := range outputs {
+		outpoint := &RpcOutpoint{}
+		outpoint.fromAppMessage(output.Outpoint)
+		protoOutputs[i] = &ImmatureCoinbaseOutput{
+			Outpoint:            outpoint,
+			Amount:              output.Amount,
+			BlockHash:           output.BlockHash,
+			DaaScore:            output.DAAScore,
+			SpendableAtDaaScore: output.SpendableAtDAAScore,
+		}
+	}
+	return protoOutputs
+}
@@ -0,0 +1,84 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetIndexRetentionStatusRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetIndexRetentionStatusRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetIndexRetentionStatusRequest) fromAppMessage(_ *appmessage.GetIndexRetentionStatusRequestMessage) error {
+	x.GetIndexRetentionStatusRequest = &GetIndexRetentionStatusRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetIndexRetentionStatusResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetIndexRetentionStatusResponse is nil")
+	}
+	return x.GetIndexRetentionStatusResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetIndexRetentionStatusResponse) fromAppMessage(message *appmessage.GetIndexRetentionStatusResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetIndexRetentionStatusResponse = &GetIndexRetentionStatusResponseMessage{
+		Indexes: indexRetentionStatusesFromAppMessage(message.Indexes),
+		Error:   err,
+	}
+	return nil
+}
+
+func (x *GetIndexRetentionStatusResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetIndexRetentionStatusResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetIndexRetentionStatusResponseMessage{
+		Indexes: indexRetentionStatusesToAppMessage(x.Indexes),
+		Error:   rpcErr,
+	}, nil
+}
+
+func indexRetentionStatusesToAppMessage(statuses []*IndexRetentionStatus) []*appmessage.IndexRetentionStatus {
+	appStatuses := make([]*appmessage.IndexRetentionStatus, len(statuses))
+	for i, status := range statuses {
+		appStatuses[i] = &appmessage.IndexRetentionStatus{
+			IndexName:             status.IndexName,
+			MaxAgeInSeconds:       status.MaxAge,
+			MaxSize:               status.MaxSize,
+			Size:                  status.Size,
+			ChainBlockCount:       status.ChainBlockCount,
+			PrunedChainBlockCount: status.PrunedChainBlockCount,
+			ReclaimedBytes:        status.ReclaimedBytes,
+			LastPruneTimestamp:    status.LastPruneTimestamp,
+		}
+	}
+	return appStatuses
+}
+
+func indexRetentionStatusesFromAppMessage(statuses []*appmessage.IndexRetentionStatus) []*IndexRetentionStatus {
+	protoStatuses := make([]*IndexRetentionStatus, len(statuses))
+	for i, status := range statuses {
+		protoStatuses[i] = &IndexRetentionStatus{
+			IndexName:             status.IndexName,
+			MaxAge:                status.MaxAgeInSeconds,
+			MaxSize:               status.MaxSize,
+			Size:                  status.Size,
+			ChainBlockCount:       status.ChainBlockCount,
+			PrunedChainBlockCount: status.PrunedChainBlockCount,
+			ReclaimedBytes:        status.ReclaimedBytes,
+			LastPruneTimestamp:    status.LastPruneTimestamp,
+		}
+	}
+	return protoStatuses
+}
@@ -0,0 +1,66 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetIndexStatusRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetIndexStatusRequest is nil")
+	}
+	return x.GetIndexStatusRequest.toAppMessage()
+}
+
+func (x *GetIndexStatusRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetIndexStatusRequestMessage is nil")
+	}
+	return &appmessage.GetIndexStatusRequestMessage{
+		IndexName: x.IndexName,
+	}, nil
+}
+
+func (x *KaspadMessage_GetIndexStatusRequest) fromAppMessage(message *appmessage.GetIndexStatusRequestMessage) error {
+	x.GetIndexStatusRequest = &GetIndexStatusRequestMessage{
+		IndexName: message.IndexName,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetIndexStatusResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetIndexStatusResponse is nil")
+	}
+	return x.GetIndexStatusResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetIndexStatusResponse) fromAppMessage(message *appmessage.GetIndexStatusResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetIndexStatusResponse = &GetIndexStatusResponseMessage{
+		Status:     message.Status,
+		BuildError: message.BuildError,
+		Error:      err,
+	}
+	return nil
+}
+
+func (x *GetIndexStatusResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetIndexStatusResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetIndexStatusResponseMessage{
+		Status:     x.Status,
+		BuildError: x.BuildError,
+		Error:      rpcErr,
+	}, nil
+}
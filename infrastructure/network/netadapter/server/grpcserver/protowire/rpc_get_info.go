@@ -24,7 +24,7 @@ func (x *KaspadMessage_GetInfoResponse) toAppMessage() (appmessage.Message, erro
 func (x *KaspadMessage_GetInfoResponse) fromAppMessage(message *appmessage.GetInfoResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.GetInfoResponse = &GetInfoResponseMessage{
 		P2PId:         message.P2PID,
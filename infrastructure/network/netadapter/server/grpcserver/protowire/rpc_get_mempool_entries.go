@@ -16,6 +16,7 @@ func (x *KaspadMessage_GetMempoolEntriesRequest) fromAppMessage(message *appmess
 	x.GetMempoolEntriesRequest = &GetMempoolEntriesRequestMessage{
 		IncludeOrphanPool:     message.IncludeOrphanPool,
 		FilterTransactionPool: message.FilterTransactionPool,
+		Verbose:               message.Verbose,
 	}
 	return nil
 }
@@ -27,6 +28,7 @@ func (x *GetMempoolEntriesRequestMessage) toAppMessage() (appmessage.Message, er
 	return &appmessage.GetMempoolEntriesRequestMessage{
 		IncludeOrphanPool:     x.IncludeOrphanPool,
 		FilterTransactionPool: x.FilterTransactionPool,
+		Verbose:               x.Verbose,
 	}, nil
 }
 
@@ -40,7 +42,7 @@ func (x *KaspadMessage_GetMempoolEntriesResponse) toAppMessage() (appmessage.Mes
 func (x *KaspadMessage_GetMempoolEntriesResponse) fromAppMessage(message *appmessage.GetMempoolEntriesResponseMessage) error {
 	var rpcErr *RPCError
 	if message.Error != nil {
-		rpcErr = &RPCError{Message: message.Error.Message}
+		rpcErr = newRPCError(message.Error)
 	}
 	entries := make([]*MempoolEntry, len(message.Entries))
 	for i, entry := range message.Entries {
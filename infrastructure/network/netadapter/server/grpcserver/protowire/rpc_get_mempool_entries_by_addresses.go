@@ -42,7 +42,7 @@ func (x *KaspadMessage_GetMempoolEntriesByAddressesResponse) toAppMessage() (app
 func (x *KaspadMessage_GetMempoolEntriesByAddressesResponse) fromAppMessage(message *appmessage.GetMempoolEntriesByAddressesResponseMessage) error {
 	var rpcErr *RPCError
 	if message.Error != nil {
-		rpcErr = &RPCError{Message: message.Error.Message}
+		rpcErr = newRPCError(message.Error)
 	}
 	entries := make([]*MempoolEntryByAddress, len(message.Entries))
 	for i, entry := range message.Entries {
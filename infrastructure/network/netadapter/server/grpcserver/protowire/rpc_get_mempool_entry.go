@@ -17,6 +17,7 @@ func (x *KaspadMessage_GetMempoolEntryRequest) fromAppMessage(message *appmessag
 		TxId:                  message.TxID,
 		IncludeOrphanPool:     message.IncludeOrphanPool,
 		FilterTransactionPool: message.FilterTransactionPool,
+		Verbose:               message.Verbose,
 	}
 	return nil
 }
@@ -29,6 +30,7 @@ func (x *GetMempoolEntryRequestMessage) toAppMessage() (appmessage.Message, erro
 		TxID:                  x.TxId,
 		IncludeOrphanPool:     x.IncludeOrphanPool,
 		FilterTransactionPool: x.FilterTransactionPool,
+		Verbose:               x.Verbose,
 	}, nil
 }
 
@@ -42,7 +44,7 @@ func (x *KaspadMessage_GetMempoolEntryResponse) toAppMessage() (appmessage.Messa
 func (x *KaspadMessage_GetMempoolEntryResponse) fromAppMessage(message *appmessage.GetMempoolEntryResponseMessage) error {
 	var rpcErr *RPCError
 	if message.Error != nil {
-		rpcErr = &RPCError{Message: message.Error.Message}
+		rpcErr = newRPCError(message.Error)
 	}
 	var entry *MempoolEntry
 	if message.Entry != nil {
@@ -92,10 +94,16 @@ func (x *MempoolEntry) toAppMessage() (*appmessage.MempoolEntry, error) {
 	if err != nil {
 		return nil, err
 	}
+	verboseData, err := x.VerboseData.toAppMessage()
+	// VerboseData is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
 	return &appmessage.MempoolEntry{
 		Fee:         x.Fee,
 		Transaction: transaction,
 		IsOrphan:    x.IsOrphan,
+		VerboseData: verboseData,
 	}, nil
 }
 
@@ -105,10 +113,39 @@ func (x *MempoolEntry) fromAppMessage(message *appmessage.MempoolEntry) error {
 		transaction = new(RpcTransaction)
 		transaction.fromAppMessage(message.Transaction)
 	}
+	var verboseData *RpcMempoolEntryVerboseData
+	if message.VerboseData != nil {
+		verboseData = new(RpcMempoolEntryVerboseData)
+		verboseData.fromAppMessage(message.VerboseData)
+	}
 	*x = MempoolEntry{
 		Fee:         message.Fee,
 		Transaction: transaction,
 		IsOrphan:    message.IsOrphan,
+		VerboseData: verboseData,
 	}
 	return nil
 }
+
+func (x *RpcMempoolEntryVerboseData) toAppMessage() (*appmessage.MempoolEntryVerboseData, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RpcMempoolEntryVerboseData is nil")
+	}
+	return &appmessage.MempoolEntryVerboseData{
+		Mass:                  x.Mass,
+		ArrivalTime:           x.ArrivalTime,
+		ArrivalDAAScore:       x.ArrivalDaaScore,
+		DependsTransactionIDs: x.DependsTransactionIds,
+		SpentByTransactionIDs: x.SpentByTransactionIds,
+	}, nil
+}
+
+func (x *RpcMempoolEntryVerboseData) fromAppMessage(message *appmessage.MempoolEntryVerboseData) {
+	*x = RpcMempoolEntryVerboseData{
+		Mass:                  message.Mass,
+		ArrivalTime:           message.ArrivalTime,
+		ArrivalDaaScore:       message.ArrivalDAAScore,
+		DependsTransactionIds: message.DependsTransactionIDs,
+		SpentByTransactionIds: message.SpentByTransactionIDs,
+	}
+}
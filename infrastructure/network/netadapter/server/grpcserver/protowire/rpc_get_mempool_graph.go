@@ -0,0 +1,133 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetMempoolGraphRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetMempoolGraphRequest is nil")
+	}
+	return x.GetMempoolGraphRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_GetMempoolGraphRequest) fromAppMessage(message *appmessage.GetMempoolGraphRequestMessage) error {
+	x.GetMempoolGraphRequest = &GetMempoolGraphRequestMessage{
+		TransactionIds:    message.TransactionIDs,
+		IncludeOrphanPool: message.IncludeOrphanPool,
+		IncludeAncestry:   message.IncludeAncestry,
+	}
+	return nil
+}
+
+func (x *GetMempoolGraphRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetMempoolGraphRequestMessage is nil")
+	}
+	return &appmessage.GetMempoolGraphRequestMessage{
+		TransactionIDs:    x.TransactionIds,
+		IncludeOrphanPool: x.IncludeOrphanPool,
+		IncludeAncestry:   x.IncludeAncestry,
+	}, nil
+}
+
+func (x *KaspadMessage_GetMempoolGraphResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetMempoolGraphResponse is nil")
+	}
+	return x.GetMempoolGraphResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetMempoolGraphResponse) fromAppMessage(message *appmessage.GetMempoolGraphResponseMessage) error {
+	var rpcErr *RPCError
+	if message.Error != nil {
+		rpcErr = newRPCError(message.Error)
+	}
+	entries := make([]*RpcMempoolGraphEntry, len(message.Entries))
+	for i, entry := range message.Entries {
+		entries[i] = new(RpcMempoolGraphEntry)
+		entries[i].fromAppMessage(entry)
+	}
+	x.GetMempoolGraphResponse = &GetMempoolGraphResponseMessage{
+		Entries: entries,
+		Error:   rpcErr,
+	}
+	return nil
+}
+
+func (x *GetMempoolGraphResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetMempoolGraphResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Entries) != 0 {
+		return nil, errors.New("GetMempoolGraphResponseMessage contains both an error and a response")
+	}
+	entries := make([]*appmessage.MempoolGraphEntry, len(x.Entries))
+	for i, entry := range x.Entries {
+		entries[i], err = entry.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.GetMempoolGraphResponseMessage{
+		Entries: entries,
+		Error:   rpcErr,
+	}, nil
+}
+
+func (x *RpcMempoolGraphEntry) toAppMessage() (*appmessage.MempoolGraphEntry, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RpcMempoolGraphEntry is nil")
+	}
+	conflicts := make([]*appmessage.MempoolConflict, len(x.Conflicts))
+	for i, conflict := range x.Conflicts {
+		if conflict == nil {
+			return nil, errors.Wrapf(errorNil, "RpcMempoolConflict is nil")
+		}
+		outpoint, err := conflict.Outpoint.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		conflicts[i] = &appmessage.MempoolConflict{
+			Outpoint:                  outpoint,
+			ConflictingTransactionIDs: conflict.ConflictingTransactionIds,
+		}
+	}
+	return &appmessage.MempoolGraphEntry{
+		TransactionID: x.TransactionId,
+		IsOrphan:      x.IsOrphan,
+		ParentIDs:     x.ParentIds,
+		ChildIDs:      x.ChildIds,
+		AncestorIDs:   x.AncestorIds,
+		DescendantIDs: x.DescendantIds,
+		Conflicts:     conflicts,
+	}, nil
+}
+
+func (x *RpcMempoolGraphEntry) fromAppMessage(message *appmessage.MempoolGraphEntry) {
+	conflicts := make([]*RpcMempoolConflict, len(message.Conflicts))
+	for i, conflict := range message.Conflicts {
+		outpoint := new(RpcOutpoint)
+		outpoint.fromAppMessage(conflict.Outpoint)
+		conflicts[i] = &RpcMempoolConflict{
+			Outpoint:                  outpoint,
+			ConflictingTransactionIds: conflict.ConflictingTransactionIDs,
+		}
+	}
+	*x = RpcMempoolGraphEntry{
+		TransactionId: message.TransactionID,
+		IsOrphan:      message.IsOrphan,
+		ParentIds:     message.ParentIDs,
+		ChildIds:      message.ChildIDs,
+		AncestorIds:   message.AncestorIDs,
+		DescendantIds: message.DescendantIDs,
+		Conflicts:     conflicts,
+	}
+}
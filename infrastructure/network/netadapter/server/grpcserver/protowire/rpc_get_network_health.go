@@ -0,0 +1,64 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetNetworkHealthRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetNetworkHealthRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetNetworkHealthRequest) fromAppMessage(_ *appmessage.GetNetworkHealthRequestMessage) error {
+	x.GetNetworkHealthRequest = &GetNetworkHealthRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetNetworkHealthResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetNetworkHealthResponse is nil")
+	}
+	return x.GetNetworkHealthResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetNetworkHealthResponse) fromAppMessage(message *appmessage.GetNetworkHealthResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetNetworkHealthResponse = &GetNetworkHealthResponseMessage{
+		IsDegraded:             message.IsDegraded,
+		Reasons:                message.Reasons,
+		DegradedSinceTimestamp: message.DegradedSinceTimestamp,
+		PeerCount:              message.PeerCount,
+		MinPeers:               message.MinPeers,
+		LastBlockTimestamp:     message.LastBlockTimestamp,
+		Error:                  err,
+	}
+	return nil
+}
+
+func (x *GetNetworkHealthResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetNetworkHealthResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && x.LastBlockTimestamp != 0 {
+		return nil, errors.New("GetNetworkHealthResponseMessage contains both an error and a response")
+	}
+
+	return &appmessage.GetNetworkHealthResponseMessage{
+		IsDegraded:             x.IsDegraded,
+		Reasons:                x.Reasons,
+		DegradedSinceTimestamp: x.DegradedSinceTimestamp,
+		PeerCount:              x.PeerCount,
+		MinPeers:               x.MinPeers,
+		LastBlockTimestamp:     x.LastBlockTimestamp,
+		Error:                  rpcErr,
+	}, nil
+}
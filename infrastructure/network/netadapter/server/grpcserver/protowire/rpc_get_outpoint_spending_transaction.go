@@ -0,0 +1,74 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetOutpointSpendingTransactionRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetOutpointSpendingTransactionRequest is nil")
+	}
+	return x.GetOutpointSpendingTransactionRequest.toAppMessage()
+}
+
+func (x *GetOutpointSpendingTransactionRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetOutpointSpendingTransactionRequestMessage is nil")
+	}
+	outpoint, err := x.Outpoint.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.GetOutpointSpendingTransactionRequestMessage{
+		Outpoint: outpoint,
+	}, nil
+}
+
+func (x *KaspadMessage_GetOutpointSpendingTransactionRequest) fromAppMessage(message *appmessage.GetOutpointSpendingTransactionRequestMessage) error {
+	outpoint := &RpcOutpoint{}
+	outpoint.fromAppMessage(message.Outpoint)
+	x.GetOutpointSpendingTransactionRequest = &GetOutpointSpendingTransactionRequestMessage{
+		Outpoint: outpoint,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetOutpointSpendingTransactionResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetOutpointSpendingTransactionResponse is nil")
+	}
+	return x.GetOutpointSpendingTransactionResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetOutpointSpendingTransactionResponse) fromAppMessage(message *appmessage.GetOutpointSpendingTransactionResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetOutpointSpendingTransactionResponse = &GetOutpointSpendingTransactionResponseMessage{
+		IsSpent:               message.IsSpent,
+		SpendingTransactionId: message.SpendingTransactionID,
+		AcceptingBlockHash:    message.AcceptingBlockHash,
+		Error:                 err,
+	}
+	return nil
+}
+
+func (x *GetOutpointSpendingTransactionResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetOutpointSpendingTransactionResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetOutpointSpendingTransactionResponseMessage{
+		IsSpent:               x.IsSpent,
+		SpendingTransactionID: x.SpendingTransactionId,
+		AcceptingBlockHash:    x.AcceptingBlockHash,
+		Error:                 rpcErr,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetPayoutStatsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetPayoutStatsRequest is nil")
+	}
+	return x.GetPayoutStatsRequest.toAppMessage()
+}
+
+func (x *GetPayoutStatsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetPayoutStatsRequestMessage is nil")
+	}
+	return &appmessage.GetPayoutStatsRequestMessage{
+		RecentBlockCount: x.RecentBlockCount,
+	}, nil
+}
+
+func (x *KaspadMessage_GetPayoutStatsRequest) fromAppMessage(message *appmessage.GetPayoutStatsRequestMessage) error {
+	x.GetPayoutStatsRequest = &GetPayoutStatsRequestMessage{
+		RecentBlockCount: message.RecentBlockCount,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetPayoutStatsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetPayoutStatsResponse is nil")
+	}
+	return x.GetPayoutStatsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetPayoutStatsResponse) fromAppMessage(message *appmessage.GetPayoutStatsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	recentBlocks := make([]*PayoutBlock, len(message.RecentBlocks))
+	for i, block := range message.RecentBlocks {
+		recentBlocks[i] = &PayoutBlock{
+			BlockHash:        block.BlockHash,
+			Status:           block.Status,
+			DaaScore:         block.DAAScore,
+			MergingBlockHash: block.MergingBlockHash,
+			Reward:           block.Reward,
+			IsRewardMature:   block.IsRewardMature,
+		}
+	}
+	x.GetPayoutStatsResponse = &GetPayoutStatsResponseMessage{
+		Address:           message.Address,
+		BlueBlockCount:    message.BlueBlockCount,
+		RedBlockCount:     message.RedBlockCount,
+		PendingBlockCount: message.PendingBlockCount,
+		OrphanBlockCount:  message.OrphanBlockCount,
+		TotalReward:       message.TotalReward,
+		MatureReward:      message.MatureReward,
+		RecentBlocks:      recentBlocks,
+		Error:             err,
+	}
+	return nil
+}
+
+func (x *GetPayoutStatsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetPayoutStatsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	recentBlocks := make([]*appmessage.PayoutBlock, len(x.RecentBlocks))
+	for i, block := range x.RecentBlocks {
+		recentBlocks[i] = &appmessage.PayoutBlock{
+			BlockHash:        block.BlockHash,
+			Status:           block.Status,
+			DAAScore:         block.DaaScore,
+			MergingBlockHash: block.MergingBlockHash,
+			Reward:           block.Reward,
+			IsRewardMature:   block.IsRewardMature,
+		}
+	}
+
+	return &appmessage.GetPayoutStatsResponseMessage{
+		Address:           x.Address,
+		BlueBlockCount:    x.BlueBlockCount,
+		RedBlockCount:     x.RedBlockCount,
+		PendingBlockCount: x.PendingBlockCount,
+		OrphanBlockCount:  x.OrphanBlockCount,
+		TotalReward:       x.TotalReward,
+		MatureReward:      x.MatureReward,
+		RecentBlocks:      recentBlocks,
+		Error:             rpcErr,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetPeerFlowStatisticsRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetPeerFlowStatisticsRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetPeerFlowStatisticsRequest) fromAppMessage(_ *appmessage.GetPeerFlowStatisticsRequestMessage) error {
+	x.GetPeerFlowStatisticsRequest = &GetPeerFlowStatisticsRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetPeerFlowStatisticsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetPeerFlowStatisticsResponse is nil")
+	}
+	return x.GetPeerFlowStatisticsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetPeerFlowStatisticsResponse) fromAppMessage(message *appmessage.GetPeerFlowStatisticsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	peers := make([]*PeerFlowStatistics, len(message.Peers))
+	for i, peer := range message.Peers {
+		flows := make([]*FlowStatistics, len(peer.Flows))
+		for j, flow := range peer.Flows {
+			flows[j] = &FlowStatistics{
+				Flow:            flow.Flow,
+				Count:           flow.Count,
+				AverageDuration: flow.AverageDuration,
+				MaxDuration:     flow.MaxDuration,
+			}
+		}
+		anomalies := make([]*PeerFlowAnomaly, len(peer.Anomalies))
+		for j, anomaly := range peer.Anomalies {
+			anomalies[j] = &PeerFlowAnomaly{
+				Flow:   anomaly.Flow,
+				Reason: anomaly.Reason,
+			}
+		}
+		peers[i] = &PeerFlowStatistics{
+			Id:                    peer.ID,
+			Address:               peer.Address,
+			Flows:                 flows,
+			RepeatedBlockRequests: peer.RepeatedBlockRequests,
+			Anomalies:             anomalies,
+		}
+	}
+	x.GetPeerFlowStatisticsResponse = &GetPeerFlowStatisticsResponseMessage{
+		Peers: peers,
+		Error: err,
+	}
+	return nil
+}
+
+func (x *GetPeerFlowStatisticsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetPeerFlowStatisticsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	if rpcErr != nil && len(x.Peers) != 0 {
+		return nil, errors.New("GetPeerFlowStatisticsResponseMessage contains both an error and a response")
+	}
+
+	peers := make([]*appmessage.PeerFlowStatistics, len(x.Peers))
+	for i, peer := range x.Peers {
+		appPeer, err := peer.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		peers[i] = appPeer
+	}
+	return &appmessage.GetPeerFlowStatisticsResponseMessage{
+		Peers: peers,
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *PeerFlowStatistics) toAppMessage() (*appmessage.PeerFlowStatistics, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "PeerFlowStatistics is nil")
+	}
+	flows := make([]*appmessage.FlowStatistics, len(x.Flows))
+	for i, flow := range x.Flows {
+		if flow == nil {
+			return nil, errors.Wrapf(errorNil, "FlowStatistics is nil")
+		}
+		flows[i] = &appmessage.FlowStatistics{
+			Flow:            flow.Flow,
+			Count:           flow.Count,
+			AverageDuration: flow.AverageDuration,
+			MaxDuration:     flow.MaxDuration,
+		}
+	}
+	anomalies := make([]*appmessage.PeerFlowAnomaly, len(x.Anomalies))
+	for i, anomaly := range x.Anomalies {
+		if anomaly == nil {
+			return nil, errors.Wrapf(errorNil, "PeerFlowAnomaly is nil")
+		}
+		anomalies[i] = &appmessage.PeerFlowAnomaly{
+			Flow:   anomaly.Flow,
+			Reason: anomaly.Reason,
+		}
+	}
+	return &appmessage.PeerFlowStatistics{
+		ID:                    x.Id,
+		Address:               x.Address,
+		Flows:                 flows,
+		RepeatedBlockRequests: x.RepeatedBlockRequests,
+		Anomalies:             anomalies,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetRelayPolicyRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetRelayPolicyRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetRelayPolicyRequest) fromAppMessage(_ *appmessage.GetRelayPolicyRequestMessage) error {
+	x.GetRelayPolicyRequest = &GetRelayPolicyRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetRelayPolicyResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetRelayPolicyResponse is nil")
+	}
+	return x.GetRelayPolicyResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetRelayPolicyResponse) fromAppMessage(message *appmessage.GetRelayPolicyResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetRelayPolicyResponse = &GetRelayPolicyResponseMessage{
+		Network:                             message.Network,
+		RelayNonStandardTransactions:        message.RelayNonStandardTransactions,
+		RelayNonStandardTransactionsSource:  message.RelayNonStandardTransactionsSource,
+		MinimumRelayTransactionFee:          message.MinimumRelayTransactionFee,
+		MaximumOrphanTransactionCount:       message.MaximumOrphanTransactionCount,
+		DustRelayTransactionFee:             message.DustRelayTransactionFee,
+		PayToPubKeyDustThreshold:            message.PayToPubKeyDustThreshold,
+		MaximumStandardTransactionMass:      message.MaximumStandardTransactionMass,
+		EffectiveMinimumRelayTransactionFee: message.EffectiveMinimumRelayTransactionFee,
+		Error:                               err,
+	}
+	return nil
+}
+
+func (x *GetRelayPolicyResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetRelayPolicyResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && x.Network != "" {
+		return nil, errors.New("GetRelayPolicyResponseMessage contains both an error and a response")
+	}
+
+	return &appmessage.GetRelayPolicyResponseMessage{
+		Network:                             x.Network,
+		RelayNonStandardTransactions:        x.RelayNonStandardTransactions,
+		RelayNonStandardTransactionsSource:  x.RelayNonStandardTransactionsSource,
+		MinimumRelayTransactionFee:          x.MinimumRelayTransactionFee,
+		MaximumOrphanTransactionCount:       x.MaximumOrphanTransactionCount,
+		DustRelayTransactionFee:             x.DustRelayTransactionFee,
+		PayToPubKeyDustThreshold:            x.PayToPubKeyDustThreshold,
+		MaximumStandardTransactionMass:      x.MaximumStandardTransactionMass,
+		EffectiveMinimumRelayTransactionFee: x.EffectiveMinimumRelayTransactionFee,
+		Error:                               rpcErr,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetReorgedTransactionsStatsRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetReorgedTransactionsStatsRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetReorgedTransactionsStatsRequest) fromAppMessage(_ *appmessage.GetReorgedTransactionsStatsRequestMessage) error {
+	x.GetReorgedTransactionsStatsRequest = &GetReorgedTransactionsStatsRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetReorgedTransactionsStatsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetReorgedTransactionsStatsResponse is nil")
+	}
+	return x.GetReorgedTransactionsStatsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetReorgedTransactionsStatsResponse) fromAppMessage(message *appmessage.GetReorgedTransactionsStatsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetReorgedTransactionsStatsResponse = &GetReorgedTransactionsStatsResponseMessage{
+		ReorgCount:                  message.ReorgCount,
+		ReorgedTransactionCount:     message.ReorgedTransactionCount,
+		ReinsertedTransactionCount:  message.ReinsertedTransactionCount,
+		DiscardedTransactionCount:   message.DiscardedTransactionCount,
+		RebroadcastTransactionCount: message.RebroadcastTransactionCount,
+		Error:                       err,
+	}
+	return nil
+}
+
+func (x *GetReorgedTransactionsStatsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetReorgedTransactionsStatsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetReorgedTransactionsStatsResponseMessage{
+		ReorgCount:                  x.ReorgCount,
+		ReorgedTransactionCount:     x.ReorgedTransactionCount,
+		ReinsertedTransactionCount:  x.ReinsertedTransactionCount,
+		DiscardedTransactionCount:   x.DiscardedTransactionCount,
+		RebroadcastTransactionCount: x.RebroadcastTransactionCount,
+		Error:                       rpcErr,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetRPCSessionsRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetRPCSessionsRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetRPCSessionsRequest) fromAppMessage(_ *appmessage.GetRPCSessionsRequestMessage) error {
+	x.GetRPCSessionsRequest = &GetRPCSessionsRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetRPCSessionsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetRPCSessionsResponse is nil")
+	}
+	return x.GetRPCSessionsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetRPCSessionsResponse) fromAppMessage(message *appmessage.GetRPCSessionsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetRPCSessionsResponse = &GetRPCSessionsResponseMessage{
+		Sessions: rpcSessionsFromAppMessage(message.Sessions),
+		Error:    err,
+	}
+	return nil
+}
+
+func (x *GetRPCSessionsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetRPCSessionsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetRPCSessionsResponseMessage{
+		Sessions: rpcSessionsToAppMessage(x.Sessions),
+		Error:    rpcErr,
+	}, nil
+}
+
+func rpcSessionsToAppMessage(sessions []*RPCSession) []*appmessage.RPCSession {
+	appSessions := make([]*appmessage.RPCSession, len(sessions))
+	for i, session := range sessions {
+		appSessions[i] = &appmessage.RPCSession{
+			ID:                       session.Id,
+			Address:                  session.Address,
+			EndpointName:             session.EndpointName,
+			IsAuthenticated:          session.IsAuthenticated,
+			ConnectedAt:              session.ConnectedAt,
+			APIVersion:               session.ApiVersion,
+			Subscriptions:            session.Subscriptions,
+			UTXOsChangedAddressCount: session.UtxosChangedAddressCount,
+			ReceivedMessageCount:     session.ReceivedMessageCount,
+			SentMessageCount:         session.SentMessageCount,
+			IsCurrentSession:         session.IsCurrentSession,
+		}
+	}
+	return appSessions
+}
+
+func rpcSessionsFromAppMessage(sessions []*appmessage.RPCSession) []*RPCSession {
+	protoSessions := make([]*RPCSession, len(sessions))
+	for i, session := range sessions {
+		protoSessions[i] = &RPCSession{
+			Id:                       session.ID,
+			Address:                  session.Address,
+			EndpointName:             session.EndpointName,
+			IsAuthenticated:          session.IsAuthenticated,
+			ConnectedAt:              session.ConnectedAt,
+			ApiVersion:               session.APIVersion,
+			Subscriptions:            session.Subscriptions,
+			UtxosChangedAddressCount: session.UTXOsChangedAddressCount,
+			ReceivedMessageCount:     session.ReceivedMessageCount,
+			SentMessageCount:         session.SentMessageCount,
+			IsCurrentSession:         session.IsCurrentSession,
+		}
+	}
+	return protoSessions
+}
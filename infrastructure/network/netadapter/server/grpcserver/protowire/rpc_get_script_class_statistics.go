@@ -0,0 +1,106 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetScriptClassStatisticsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetScriptClassStatisticsRequest is nil")
+	}
+	return x.GetScriptClassStatisticsRequest.toAppMessage()
+}
+
+func (x *GetScriptClassStatisticsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetScriptClassStatisticsRequestMessage is nil")
+	}
+	return &appmessage.GetScriptClassStatisticsRequestMessage{
+		StartBlueScore: x.StartBlueScore,
+		EndBlueScore:   x.EndBlueScore,
+	}, nil
+}
+
+func (x *KaspadMessage_GetScriptClassStatisticsRequest) fromAppMessage(message *appmessage.GetScriptClassStatisticsRequestMessage) error {
+	x.GetScriptClassStatisticsRequest = &GetScriptClassStatisticsRequestMessage{
+		StartBlueScore: message.StartBlueScore,
+		EndBlueScore:   message.EndBlueScore,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetScriptClassStatisticsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetScriptClassStatisticsResponse is nil")
+	}
+	return x.GetScriptClassStatisticsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetScriptClassStatisticsResponse) fromAppMessage(message *appmessage.GetScriptClassStatisticsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetScriptClassStatisticsResponse = &GetScriptClassStatisticsResponseMessage{
+		WindowSize: message.WindowSize,
+		Windows:    scriptClassStatisticsWindowsFromAppMessage(message.Windows),
+		Error:      err,
+	}
+	return nil
+}
+
+func (x *GetScriptClassStatisticsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetScriptClassStatisticsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetScriptClassStatisticsResponseMessage{
+		WindowSize: x.WindowSize,
+		Windows:    scriptClassStatisticsWindowsToAppMessage(x.Windows),
+		Error:      rpcErr,
+	}, nil
+}
+
+func scriptClassStatisticsWindowsToAppMessage(windows []*ScriptClassStatisticsWindow) []*appmessage.ScriptClassStatisticsWindow {
+	appWindows := make([]*appmessage.ScriptClassStatisticsWindow, len(windows))
+	for i, window := range windows {
+		scriptClasses := make([]*appmessage.ScriptClassStatistics, len(window.ScriptClasses))
+		for j, scriptClass := range window.ScriptClasses {
+			scriptClasses[j] = &appmessage.ScriptClassStatistics{
+				ScriptClass: scriptClass.ScriptClass,
+				OutputCount: scriptClass.OutputCount,
+				TotalAmount: scriptClass.TotalAmount,
+			}
+		}
+		appWindows[i] = &appmessage.ScriptClassStatisticsWindow{
+			StartBlueScore: window.StartBlueScore,
+			ScriptClasses:  scriptClasses,
+		}
+	}
+	return appWindows
+}
+
+func scriptClassStatisticsWindowsFromAppMessage(windows []*appmessage.ScriptClassStatisticsWindow) []*ScriptClassStatisticsWindow {
+	protoWindows := make([]*ScriptClassStatisticsWindow, len(windows))
+	for i, window := range windows {
+		scriptClasses := make([]*ScriptClassStatistics, len(window.ScriptClasses))
+		for j, scriptClass := range window.ScriptClasses {
+			scriptClasses[j] = &ScriptClassStatistics{
+				ScriptClass: scriptClass.ScriptClass,
+				OutputCount: scriptClass.OutputCount,
+				TotalAmount: scriptClass.TotalAmount,
+			}
+		}
+		protoWindows[i] = &ScriptClassStatisticsWindow{
+			StartBlueScore: window.StartBlueScore,
+			ScriptClasses:  scriptClasses,
+		}
+	}
+	return protoWindows
+}
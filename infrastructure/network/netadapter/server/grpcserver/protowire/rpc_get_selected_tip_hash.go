@@ -23,7 +23,7 @@ func (x *KaspadMessage_GetSelectedTipHashResponse) toAppMessage() (appmessage.Me
 func (x *KaspadMessage_GetSelectedTipHashResponse) fromAppMessage(message *appmessage.GetSelectedTipHashResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.GetSelectedTipHashResponse = &GetSelectedTipHashResponseMessage{
 		SelectedTipHash: message.SelectedTipHash,
@@ -38,7 +38,7 @@ func (x *KaspadMessage_GetSubnetworkResponse) toAppMessage() (appmessage.Message
 func (x *KaspadMessage_GetSubnetworkResponse) fromAppMessage(message *appmessage.GetSubnetworkResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.GetSubnetworkResponse = &GetSubnetworkResponseMessage{
 		GasLimit: message.GasLimit,
@@ -0,0 +1,100 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetTransactionPropagationReportRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetTransactionPropagationReportRequest is nil")
+	}
+	return x.GetTransactionPropagationReportRequest.toAppMessage()
+}
+
+func (x *GetTransactionPropagationReportRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionPropagationReportRequestMessage is nil")
+	}
+	return &appmessage.GetTransactionPropagationReportRequestMessage{
+		TransactionID: x.TransactionId,
+	}, nil
+}
+
+func (x *KaspadMessage_GetTransactionPropagationReportRequest) fromAppMessage(message *appmessage.GetTransactionPropagationReportRequestMessage) error {
+	x.GetTransactionPropagationReportRequest = &GetTransactionPropagationReportRequestMessage{
+		TransactionId: message.TransactionID,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetTransactionPropagationReportResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetTransactionPropagationReportResponse is nil")
+	}
+	return x.GetTransactionPropagationReportResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetTransactionPropagationReportResponse) fromAppMessage(message *appmessage.GetTransactionPropagationReportResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.GetTransactionPropagationReportResponse = &GetTransactionPropagationReportResponseMessage{
+		TransactionId:      message.TransactionID,
+		SubmittedAt:        message.SubmittedAt,
+		AnnouncedPeerCount: message.AnnouncedPeerCount,
+		RequestedPeerCount: message.RequestedPeerCount,
+		Announcements:      transactionPropagationEventsFromAppMessage(message.Announcements),
+		Requests:           transactionPropagationEventsFromAppMessage(message.Requests),
+		Error:              err,
+	}
+	return nil
+}
+
+func (x *GetTransactionPropagationReportResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionPropagationReportResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && x.TransactionId != "" {
+		return nil, errors.New("GetTransactionPropagationReportResponseMessage contains both an error and a response")
+	}
+
+	return &appmessage.GetTransactionPropagationReportResponseMessage{
+		TransactionID:      x.TransactionId,
+		SubmittedAt:        x.SubmittedAt,
+		AnnouncedPeerCount: x.AnnouncedPeerCount,
+		RequestedPeerCount: x.RequestedPeerCount,
+		Announcements:      transactionPropagationEventsToAppMessage(x.Announcements),
+		Requests:           transactionPropagationEventsToAppMessage(x.Requests),
+		Error:              rpcErr,
+	}, nil
+}
+
+func transactionPropagationEventsToAppMessage(events []*TransactionPropagationEvent) []*appmessage.TransactionPropagationEvent {
+	appEvents := make([]*appmessage.TransactionPropagationEvent, len(events))
+	for i, event := range events {
+		appEvents[i] = &appmessage.TransactionPropagationEvent{
+			PeerAddress: event.PeerAddress,
+			Timestamp:   event.Timestamp,
+		}
+	}
+	return appEvents
+}
+
+func transactionPropagationEventsFromAppMessage(events []*appmessage.TransactionPropagationEvent) []*TransactionPropagationEvent {
+	protoEvents := make([]*TransactionPropagationEvent, len(events))
+	for i, event := range events {
+		protoEvents[i] = &TransactionPropagationEvent{
+			PeerAddress: event.PeerAddress,
+			Timestamp:   event.Timestamp,
+		}
+	}
+	return protoEvents
+}
@@ -0,0 +1,97 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetTransactionsByAddressRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetTransactionsByAddressRequest is nil")
+	}
+	return x.GetTransactionsByAddressRequest.toAppMessage()
+}
+
+func (x *GetTransactionsByAddressRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionsByAddressRequestMessage is nil")
+	}
+	return &appmessage.GetTransactionsByAddressRequestMessage{
+		Address: x.Address,
+		Offset:  x.Offset,
+		Limit:   x.Limit,
+	}, nil
+}
+
+func (x *KaspadMessage_GetTransactionsByAddressRequest) fromAppMessage(message *appmessage.GetTransactionsByAddressRequestMessage) error {
+	x.GetTransactionsByAddressRequest = &GetTransactionsByAddressRequestMessage{
+		Address: message.Address,
+		Offset:  message.Offset,
+		Limit:   message.Limit,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetTransactionsByAddressResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetTransactionsByAddressResponse is nil")
+	}
+	return x.GetTransactionsByAddressResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetTransactionsByAddressResponse) fromAppMessage(message *appmessage.GetTransactionsByAddressResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	transactions := make([]*AddressTransaction, len(message.Transactions))
+	for i, transaction := range message.Transactions {
+		transactions[i] = &AddressTransaction{
+			TransactionId:           transaction.TransactionID,
+			AcceptingBlockHash:      transaction.AcceptingBlockHash,
+			AcceptingBlockBlueScore: transaction.AcceptingBlockBlueScore,
+			Received:                transaction.Received,
+			Sent:                    transaction.Sent,
+		}
+	}
+	x.GetTransactionsByAddressResponse = &GetTransactionsByAddressResponseMessage{
+		Transactions: transactions,
+		HasMore:      message.HasMore,
+		Error:        err,
+	}
+	return nil
+}
+
+func (x *GetTransactionsByAddressResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionsByAddressResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Transactions) != 0 {
+		return nil, errors.New("GetTransactionsByAddressResponseMessage contains both an error and a response")
+	}
+
+	transactions := make([]*appmessage.AddressTransaction, len(x.Transactions))
+	for i, transaction := range x.Transactions {
+		if transaction == nil {
+			return nil, errors.Wrapf(errorNil, "AddressTransaction is nil")
+		}
+		transactions[i] = &appmessage.AddressTransaction{
+			TransactionID:           transaction.TransactionId,
+			AcceptingBlockHash:      transaction.AcceptingBlockHash,
+			AcceptingBlockBlueScore: transaction.AcceptingBlockBlueScore,
+			Received:                transaction.Received,
+			Sent:                    transaction.Sent,
+		}
+	}
+	return &appmessage.GetTransactionsByAddressResponseMessage{
+		Transactions: transactions,
+		HasMore:      x.HasMore,
+		Error:        rpcErr,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetUTXORequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetUTXORequest is nil")
+	}
+	return x.GetUTXORequest.toAppMessage()
+}
+
+func (x *GetUTXORequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetUTXORequestMessage is nil")
+	}
+	outpoint, err := x.Outpoint.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.GetUTXORequestMessage{
+		Outpoint:       outpoint,
+		IncludeMempool: x.IncludeMempool,
+	}, nil
+}
+
+func (x *KaspadMessage_GetUTXORequest) fromAppMessage(message *appmessage.GetUTXORequestMessage) error {
+	outpoint := &RpcOutpoint{}
+	outpoint.fromAppMessage(message.Outpoint)
+	x.GetUTXORequest = &GetUTXORequestMessage{
+		Outpoint:       outpoint,
+		IncludeMempool: message.IncludeMempool,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_GetUTXOResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetUTXOResponse is nil")
+	}
+	return x.GetUTXOResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetUTXOResponse) fromAppMessage(message *appmessage.GetUTXOResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	var utxoEntry *RpcUtxoEntry
+	if message.UTXOEntry != nil {
+		utxoEntry = &RpcUtxoEntry{}
+		utxoEntry.fromAppMessage(message.UTXOEntry)
+	}
+	x.GetUTXOResponse = &GetUTXOResponseMessage{
+		IsUnspent:                    message.IsUnspent,
+		UtxoEntry:                    utxoEntry,
+		IsInMempool:                  message.IsInMempool,
+		SpendingMempoolTransactionId: message.SpendingMempoolTransactionID,
+		AcceptingBlockHash:           message.AcceptingBlockHash,
+		Error:                        err,
+	}
+	return nil
+}
+
+func (x *GetUTXOResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetUTXOResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	utxoEntry, err := x.UtxoEntry.toAppMessage()
+	// UtxoEntry is set only if the outpoint is unspent
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetUTXOResponseMessage{
+		IsUnspent:                    x.IsUnspent,
+		UTXOEntry:                    utxoEntry,
+		IsInMempool:                  x.IsInMempool,
+		SpendingMempoolTransactionID: x.SpendingMempoolTransactionId,
+		AcceptingBlockHash:           x.AcceptingBlockHash,
+		Error:                        rpcErr,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NegotiateAPIVersionRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NegotiateAPIVersionRequest is nil")
+	}
+	return x.NegotiateAPIVersionRequest.toAppMessage()
+}
+
+func (x *NegotiateAPIVersionRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NegotiateAPIVersionRequestMessage is nil")
+	}
+	return &appmessage.NegotiateAPIVersionRequestMessage{
+		Version: x.Version,
+	}, nil
+}
+
+func (x *KaspadMessage_NegotiateAPIVersionRequest) fromAppMessage(message *appmessage.NegotiateAPIVersionRequestMessage) error {
+	x.NegotiateAPIVersionRequest = &NegotiateAPIVersionRequestMessage{
+		Version: message.Version,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_NegotiateAPIVersionResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NegotiateAPIVersionResponse is nil")
+	}
+	return x.NegotiateAPIVersionResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NegotiateAPIVersionResponse) fromAppMessage(message *appmessage.NegotiateAPIVersionResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.NegotiateAPIVersionResponse = &NegotiateAPIVersionResponseMessage{
+		Version:    message.Version,
+		MinVersion: message.MinVersion,
+		MaxVersion: message.MaxVersion,
+		Error:      err,
+	}
+	return nil
+}
+
+func (x *NegotiateAPIVersionResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NegotiateAPIVersionResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.NegotiateAPIVersionResponseMessage{
+		Version:    x.Version,
+		MinVersion: x.MinVersion,
+		MaxVersion: x.MaxVersion,
+		Error:      rpcErr,
+	}, nil
+}
@@ -6,11 +6,20 @@ import (
 )
 
 func (x *KaspadMessage_NotifyBlockAddedRequest) toAppMessage() (appmessage.Message, error) {
-	return &appmessage.NotifyBlockAddedRequestMessage{}, nil
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyBlockAddedRequest is nil")
+	}
+	return &appmessage.NotifyBlockAddedRequestMessage{
+		IncludeSnapshot:                x.NotifyBlockAddedRequest.GetIncludeSnapshot(),
+		CoalesceIntervalInMilliseconds: x.NotifyBlockAddedRequest.GetCoalesceInterval(),
+	}, nil
 }
 
-func (x *KaspadMessage_NotifyBlockAddedRequest) fromAppMessage(_ *appmessage.NotifyBlockAddedRequestMessage) error {
-	x.NotifyBlockAddedRequest = &NotifyBlockAddedRequestMessage{}
+func (x *KaspadMessage_NotifyBlockAddedRequest) fromAppMessage(message *appmessage.NotifyBlockAddedRequestMessage) error {
+	x.NotifyBlockAddedRequest = &NotifyBlockAddedRequestMessage{
+		IncludeSnapshot:  message.IncludeSnapshot,
+		CoalesceInterval: message.CoalesceIntervalInMilliseconds,
+	}
 	return nil
 }
 
@@ -24,7 +33,7 @@ func (x *KaspadMessage_NotifyBlockAddedResponse) toAppMessage() (appmessage.Mess
 func (x *KaspadMessage_NotifyBlockAddedResponse) fromAppMessage(message *appmessage.NotifyBlockAddedResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.NotifyBlockAddedResponse = &NotifyBlockAddedResponseMessage{
 		Error: err,
@@ -77,3 +86,42 @@ func (x *BlockAddedNotificationMessage) toAppMessage() (appmessage.Message, erro
 		Block: block,
 	}, nil
 }
+
+func (x *KaspadMessage_BlockAddedBatchNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_BlockAddedBatchNotification is nil")
+	}
+	return x.BlockAddedBatchNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_BlockAddedBatchNotification) fromAppMessage(message *appmessage.BlockAddedBatchNotificationMessage) error {
+	blocks := make([]*RpcBlock, len(message.Blocks))
+	for i, appBlock := range message.Blocks {
+		blocks[i] = &RpcBlock{}
+		err := blocks[i].fromAppMessage(appBlock)
+		if err != nil {
+			return err
+		}
+	}
+	x.BlockAddedBatchNotification = &BlockAddedBatchNotificationMessage{
+		Blocks: blocks,
+	}
+	return nil
+}
+
+func (x *BlockAddedBatchNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "BlockAddedBatchNotificationMessage is nil")
+	}
+	blocks := make([]*appmessage.RPCBlock, len(x.Blocks))
+	for i, block := range x.Blocks {
+		appBlock, err := block.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = appBlock
+	}
+	return &appmessage.BlockAddedBatchNotificationMessage{
+		Blocks: blocks,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyBlueScoreReachedRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyBlueScoreReachedRequest is nil")
+	}
+	return x.NotifyBlueScoreReachedRequest.toAppMessage()
+}
+
+func (x *NotifyBlueScoreReachedRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyBlueScoreReachedRequestMessage is nil")
+	}
+	return &appmessage.NotifyBlueScoreReachedRequestMessage{
+		ID:            x.Id,
+		BlueScore:     x.BlueScore,
+		BlockHash:     x.BlockHash,
+		Confirmations: x.Confirmations,
+	}, nil
+}
+
+func (x *KaspadMessage_NotifyBlueScoreReachedRequest) fromAppMessage(message *appmessage.NotifyBlueScoreReachedRequestMessage) error {
+	x.NotifyBlueScoreReachedRequest = &NotifyBlueScoreReachedRequestMessage{
+		Id:            message.ID,
+		BlueScore:     message.BlueScore,
+		BlockHash:     message.BlockHash,
+		Confirmations: message.Confirmations,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_NotifyBlueScoreReachedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyBlueScoreReachedResponse is nil")
+	}
+	return x.NotifyBlueScoreReachedResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyBlueScoreReachedResponse) fromAppMessage(message *appmessage.NotifyBlueScoreReachedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.NotifyBlueScoreReachedResponse = &NotifyBlueScoreReachedResponseMessage{
+		TargetBlueScore: message.TargetBlueScore,
+		Error:           err,
+	}
+	return nil
+}
+
+func (x *NotifyBlueScoreReachedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyBlueScoreReachedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.NotifyBlueScoreReachedResponseMessage{
+		TargetBlueScore: x.TargetBlueScore,
+		Error:           rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_BlueScoreReachedNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_BlueScoreReachedNotification is nil")
+	}
+	return x.BlueScoreReachedNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_BlueScoreReachedNotification) fromAppMessage(message *appmessage.BlueScoreReachedNotificationMessage) error {
+	x.BlueScoreReachedNotification = &BlueScoreReachedNotificationMessage{
+		Id:                             message.ID,
+		BlockHash:                      message.BlockHash,
+		Confirmations:                  message.Confirmations,
+		TargetBlueScore:                message.TargetBlueScore,
+		VirtualSelectedParentBlueScore: message.VirtualSelectedParentBlueScore,
+	}
+	return nil
+}
+
+func (x *BlueScoreReachedNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "BlueScoreReachedNotificationMessage is nil")
+	}
+	return &appmessage.BlueScoreReachedNotificationMessage{
+		ID:                             x.Id,
+		BlockHash:                      x.BlockHash,
+		Confirmations:                  x.Confirmations,
+		TargetBlueScore:                x.TargetBlueScore,
+		VirtualSelectedParentBlueScore: x.VirtualSelectedParentBlueScore,
+	}, nil
+}
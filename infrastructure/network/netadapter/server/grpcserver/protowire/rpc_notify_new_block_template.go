@@ -6,11 +6,20 @@ import (
 )
 
 func (x *KaspadMessage_NotifyNewBlockTemplateRequest) toAppMessage() (appmessage.Message, error) {
-	return &appmessage.NotifyNewBlockTemplateRequestMessage{}, nil
+	if x == nil || x.NotifyNewBlockTemplateRequest == nil {
+		return &appmessage.NotifyNewBlockTemplateRequestMessage{}, nil
+	}
+	return &appmessage.NotifyNewBlockTemplateRequestMessage{
+		PayAddress: x.NotifyNewBlockTemplateRequest.PayAddress,
+		ExtraData:  x.NotifyNewBlockTemplateRequest.ExtraData,
+	}, nil
 }
 
-func (x *KaspadMessage_NotifyNewBlockTemplateRequest) fromAppMessage(_ *appmessage.NotifyNewBlockTemplateRequestMessage) error {
-	x.NotifyNewBlockTemplateRequest = &NotifyNewBlockTemplateRequestMessage{}
+func (x *KaspadMessage_NotifyNewBlockTemplateRequest) fromAppMessage(message *appmessage.NotifyNewBlockTemplateRequestMessage) error {
+	x.NotifyNewBlockTemplateRequest = &NotifyNewBlockTemplateRequestMessage{
+		PayAddress: message.PayAddress,
+		ExtraData:  message.ExtraData,
+	}
 	return nil
 }
 
@@ -24,7 +33,7 @@ func (x *KaspadMessage_NotifyNewBlockTemplateResponse) toAppMessage() (appmessag
 func (x *KaspadMessage_NotifyNewBlockTemplateResponse) fromAppMessage(message *appmessage.NotifyNewBlockTemplateResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.NotifyNewBlockTemplateResponse = &NotifyNewBlockTemplateResponseMessage{
 		Error: err,
@@ -54,7 +63,18 @@ func (x *KaspadMessage_NewBlockTemplateNotification) toAppMessage() (appmessage.
 }
 
 func (x *KaspadMessage_NewBlockTemplateNotification) fromAppMessage(message *appmessage.NewBlockTemplateNotificationMessage) error {
-	x.NewBlockTemplateNotification = &NewBlockTemplateNotificationMessage{}
+	var block *RpcBlock
+	if message.Block != nil {
+		block = &RpcBlock{}
+		err := block.fromAppMessage(message.Block)
+		if err != nil {
+			return err
+		}
+	}
+	x.NewBlockTemplateNotification = &NewBlockTemplateNotificationMessage{
+		Block:    block,
+		IsSynced: message.IsSynced,
+	}
 	return nil
 }
 
@@ -62,5 +82,16 @@ func (x *NewBlockTemplateNotificationMessage) toAppMessage() (appmessage.Message
 	if x == nil {
 		return nil, errors.Wrapf(errorNil, "NewBlockTemplateNotificationMessage is nil")
 	}
-	return &appmessage.NewBlockTemplateNotificationMessage{}, nil
+	var block *appmessage.RPCBlock
+	if x.Block != nil {
+		var err error
+		block, err = x.Block.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.NewBlockTemplateNotificationMessage{
+		Block:    block,
+		IsSynced: x.IsSynced,
+	}, nil
 }
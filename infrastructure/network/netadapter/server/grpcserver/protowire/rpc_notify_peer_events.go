@@ -0,0 +1,87 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyPeerEventsRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.NotifyPeerEventsRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_NotifyPeerEventsRequest) fromAppMessage(_ *appmessage.NotifyPeerEventsRequestMessage) error {
+	x.NotifyPeerEventsRequest = &NotifyPeerEventsRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_NotifyPeerEventsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyPeerEventsResponse is nil")
+	}
+	return x.NotifyPeerEventsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyPeerEventsResponse) fromAppMessage(message *appmessage.NotifyPeerEventsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.NotifyPeerEventsResponse = &NotifyPeerEventsResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyPeerEventsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyPeerEventsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.NotifyPeerEventsResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_PeerEventNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_PeerEventNotification is nil")
+	}
+	return x.PeerEventNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_PeerEventNotification) fromAppMessage(message *appmessage.PeerEventNotificationMessage) error {
+	x.PeerEventNotification = &PeerEventNotificationMessage{
+		Event:                          message.Event,
+		Address:                        message.Address,
+		IsOutbound:                     message.IsOutbound,
+		Id:                             message.ID,
+		UserAgent:                      message.UserAgent,
+		ProtocolVersion:                message.ProtocolVersion,
+		Reason:                         message.Reason,
+		ConnectionDurationMilliseconds: message.ConnectionDurationMilliseconds,
+		Timestamp:                      message.Timestamp,
+	}
+	return nil
+}
+
+func (x *PeerEventNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "PeerEventNotificationMessage is nil")
+	}
+	return &appmessage.PeerEventNotificationMessage{
+		Event:                          x.Event,
+		Address:                        x.Address,
+		IsOutbound:                     x.IsOutbound,
+		ID:                             x.Id,
+		UserAgent:                      x.UserAgent,
+		ProtocolVersion:                x.ProtocolVersion,
+		Reason:                         x.Reason,
+		ConnectionDurationMilliseconds: x.ConnectionDurationMilliseconds,
+		Timestamp:                      x.Timestamp,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyTransactionConfirmedRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.NotifyTransactionConfirmedRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_NotifyTransactionConfirmedRequest) fromAppMessage(_ *appmessage.NotifyTransactionConfirmedRequestMessage) error {
+	x.NotifyTransactionConfirmedRequest = &NotifyTransactionConfirmedRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_NotifyTransactionConfirmedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyTransactionConfirmedResponse is nil")
+	}
+	return x.NotifyTransactionConfirmedResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyTransactionConfirmedResponse) fromAppMessage(message *appmessage.NotifyTransactionConfirmedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.NotifyTransactionConfirmedResponse = &NotifyTransactionConfirmedResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyTransactionConfirmedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyTransactionConfirmedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyTransactionConfirmedResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_TransactionConfirmedNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_TransactionConfirmedNotification is nil")
+	}
+	return x.TransactionConfirmedNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_TransactionConfirmedNotification) fromAppMessage(message *appmessage.TransactionConfirmedNotificationMessage) error {
+	x.TransactionConfirmedNotification = &TransactionConfirmedNotificationMessage{
+		TransactionId: message.TransactionID,
+		BlockHash:     message.BlockHash,
+	}
+	return nil
+}
+
+func (x *TransactionConfirmedNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "TransactionConfirmedNotificationMessage is nil")
+	}
+	return &appmessage.TransactionConfirmedNotificationMessage{
+		TransactionID: x.TransactionId,
+		BlockHash:     x.BlockHash,
+	}, nil
+}
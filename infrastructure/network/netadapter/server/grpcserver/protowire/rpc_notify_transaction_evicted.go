@@ -0,0 +1,72 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyTransactionEvictedRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.NotifyTransactionEvictedRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_NotifyTransactionEvictedRequest) fromAppMessage(_ *appmessage.NotifyTransactionEvictedRequestMessage) error {
+	x.NotifyTransactionEvictedRequest = &NotifyTransactionEvictedRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_NotifyTransactionEvictedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyTransactionEvictedResponse is nil")
+	}
+	return x.NotifyTransactionEvictedResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyTransactionEvictedResponse) fromAppMessage(message *appmessage.NotifyTransactionEvictedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.NotifyTransactionEvictedResponse = &NotifyTransactionEvictedResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyTransactionEvictedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyTransactionEvictedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyTransactionEvictedResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_TransactionEvictedNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_TransactionEvictedNotification is nil")
+	}
+	return x.TransactionEvictedNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_TransactionEvictedNotification) fromAppMessage(message *appmessage.TransactionEvictedNotificationMessage) error {
+	x.TransactionEvictedNotification = &TransactionEvictedNotificationMessage{
+		TransactionId: message.TransactionID,
+		Reason:        message.Reason,
+	}
+	return nil
+}
+
+func (x *TransactionEvictedNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "TransactionEvictedNotificationMessage is nil")
+	}
+	return &appmessage.TransactionEvictedNotificationMessage{
+		TransactionID: x.TransactionId,
+		Reason:        x.Reason,
+	}, nil
+}
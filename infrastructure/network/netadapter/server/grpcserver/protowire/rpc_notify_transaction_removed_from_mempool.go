@@ -0,0 +1,72 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyTransactionRemovedFromMempoolRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.NotifyTransactionRemovedFromMempoolRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_NotifyTransactionRemovedFromMempoolRequest) fromAppMessage(_ *appmessage.NotifyTransactionRemovedFromMempoolRequestMessage) error {
+	x.NotifyTransactionRemovedFromMempoolRequest = &NotifyTransactionRemovedFromMempoolRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_NotifyTransactionRemovedFromMempoolResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyTransactionRemovedFromMempoolResponse is nil")
+	}
+	return x.NotifyTransactionRemovedFromMempoolResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyTransactionRemovedFromMempoolResponse) fromAppMessage(message *appmessage.NotifyTransactionRemovedFromMempoolResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.NotifyTransactionRemovedFromMempoolResponse = &NotifyTransactionRemovedFromMempoolResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyTransactionRemovedFromMempoolResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyTransactionRemovedFromMempoolResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyTransactionRemovedFromMempoolResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_TransactionRemovedFromMempoolNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_TransactionRemovedFromMempoolNotification is nil")
+	}
+	return x.TransactionRemovedFromMempoolNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_TransactionRemovedFromMempoolNotification) fromAppMessage(message *appmessage.TransactionRemovedFromMempoolNotificationMessage) error {
+	x.TransactionRemovedFromMempoolNotification = &TransactionRemovedFromMempoolNotificationMessage{
+		TransactionId: message.TransactionID,
+		Reason:        message.Reason,
+	}
+	return nil
+}
+
+func (x *TransactionRemovedFromMempoolNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "TransactionRemovedFromMempoolNotificationMessage is nil")
+	}
+	return &appmessage.TransactionRemovedFromMempoolNotificationMessage{
+		TransactionID: x.TransactionId,
+		Reason:        x.Reason,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyTransactionReplacedRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.NotifyTransactionReplacedRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_NotifyTransactionReplacedRequest) fromAppMessage(_ *appmessage.NotifyTransactionReplacedRequestMessage) error {
+	x.NotifyTransactionReplacedRequest = &NotifyTransactionReplacedRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_NotifyTransactionReplacedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyTransactionReplacedResponse is nil")
+	}
+	return x.NotifyTransactionReplacedResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyTransactionReplacedResponse) fromAppMessage(message *appmessage.NotifyTransactionReplacedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.NotifyTransactionReplacedResponse = &NotifyTransactionReplacedResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyTransactionReplacedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyTransactionReplacedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyTransactionReplacedResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_TransactionReplacedNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_TransactionReplacedNotification is nil")
+	}
+	return x.TransactionReplacedNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_TransactionReplacedNotification) fromAppMessage(message *appmessage.TransactionReplacedNotificationMessage) error {
+	x.TransactionReplacedNotification = &TransactionReplacedNotificationMessage{
+		ReplacedTransactionId:    message.ReplacedTransactionID,
+		ReplacementTransactionId: message.ReplacementTransactionID,
+	}
+	return nil
+}
+
+func (x *TransactionReplacedNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "TransactionReplacedNotificationMessage is nil")
+	}
+	return &appmessage.TransactionReplacedNotificationMessage{
+		ReplacedTransactionID:    x.ReplacedTransactionId,
+		ReplacementTransactionID: x.ReplacementTransactionId,
+	}, nil
+}
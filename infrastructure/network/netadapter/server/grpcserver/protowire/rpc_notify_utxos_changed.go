@@ -38,7 +38,7 @@ func (x *KaspadMessage_NotifyUtxosChangedResponse) toAppMessage() (appmessage.Me
 func (x *KaspadMessage_NotifyUtxosChangedResponse) fromAppMessage(message *appmessage.NotifyUTXOsChangedResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.NotifyUtxosChangedResponse = &NotifyUtxosChangedResponseMessage{
 		Error: err,
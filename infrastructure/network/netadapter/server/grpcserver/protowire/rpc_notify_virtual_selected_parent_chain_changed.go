@@ -10,13 +10,17 @@ func (x *KaspadMessage_NotifyVirtualSelectedParentChainChangedRequest) toAppMess
 		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyVirtualSelectedParentChainChangedRequest is nil")
 	}
 	return &appmessage.NotifyVirtualSelectedParentChainChangedRequestMessage{
-		IncludeAcceptedTransactionIDs: x.NotifyVirtualSelectedParentChainChangedRequest.IncludeAcceptedTransactionIds,
+		IncludeAcceptedTransactionIDs:  x.NotifyVirtualSelectedParentChainChangedRequest.IncludeAcceptedTransactionIds,
+		IncludeSnapshot:                x.NotifyVirtualSelectedParentChainChangedRequest.IncludeSnapshot,
+		CoalesceIntervalInMilliseconds: x.NotifyVirtualSelectedParentChainChangedRequest.CoalesceInterval,
 	}, nil
 }
 
 func (x *KaspadMessage_NotifyVirtualSelectedParentChainChangedRequest) fromAppMessage(appmessage *appmessage.NotifyVirtualSelectedParentChainChangedRequestMessage) error {
 	x.NotifyVirtualSelectedParentChainChangedRequest = &NotifyVirtualSelectedParentChainChangedRequestMessage{
 		IncludeAcceptedTransactionIds: appmessage.IncludeAcceptedTransactionIDs,
+		IncludeSnapshot:               appmessage.IncludeSnapshot,
+		CoalesceInterval:              appmessage.CoalesceIntervalInMilliseconds,
 	}
 	return nil
 }
@@ -31,7 +35,7 @@ func (x *KaspadMessage_NotifyVirtualSelectedParentChainChangedResponse) toAppMes
 func (x *KaspadMessage_NotifyVirtualSelectedParentChainChangedResponse) fromAppMessage(message *appmessage.NotifyVirtualSelectedParentChainChangedResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.NotifyVirtualSelectedParentChainChangedResponse = &NotifyVirtualSelectedParentChainChangedResponseMessage{
 		Error: err,
@@ -0,0 +1,52 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_RefreshSeedsRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.RefreshSeedsRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_RefreshSeedsRequest) fromAppMessage(_ *appmessage.RefreshSeedsRequestMessage) error {
+	x.RefreshSeedsRequest = &RefreshSeedsRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_RefreshSeedsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_RefreshSeedsResponse is nil")
+	}
+	return x.RefreshSeedsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_RefreshSeedsResponse) fromAppMessage(message *appmessage.RefreshSeedsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.RefreshSeedsResponse = &RefreshSeedsResponseMessage{
+		NewAddressCount: message.NewAddressCount,
+		IsComplete:      message.IsComplete,
+		Error:           err,
+	}
+	return nil
+}
+
+func (x *RefreshSeedsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RefreshSeedsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.RefreshSeedsResponseMessage{
+		NewAddressCount: x.NewAddressCount,
+		IsComplete:      x.IsComplete,
+		Error:           rpcErr,
+	}, nil
+}
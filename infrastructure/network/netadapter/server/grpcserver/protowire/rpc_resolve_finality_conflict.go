@@ -38,7 +38,7 @@ func (x *KaspadMessage_ResolveFinalityConflictResponse) toAppMessage() (appmessa
 func (x *KaspadMessage_ResolveFinalityConflictResponse) fromAppMessage(message *appmessage.ResolveFinalityConflictResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.ResolveFinalityConflictResponse = &ResolveFinalityConflictResponseMessage{
 		Error: err,
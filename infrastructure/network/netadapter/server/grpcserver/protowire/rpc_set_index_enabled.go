@@ -0,0 +1,66 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_SetIndexEnabledRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SetIndexEnabledRequest is nil")
+	}
+	return x.SetIndexEnabledRequest.toAppMessage()
+}
+
+func (x *SetIndexEnabledRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SetIndexEnabledRequestMessage is nil")
+	}
+	return &appmessage.SetIndexEnabledRequestMessage{
+		IndexName: x.IndexName,
+		Enabled:   x.Enabled,
+	}, nil
+}
+
+func (x *KaspadMessage_SetIndexEnabledRequest) fromAppMessage(message *appmessage.SetIndexEnabledRequestMessage) error {
+	x.SetIndexEnabledRequest = &SetIndexEnabledRequestMessage{
+		IndexName: message.IndexName,
+		Enabled:   message.Enabled,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_SetIndexEnabledResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SetIndexEnabledResponse is nil")
+	}
+	return x.SetIndexEnabledResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_SetIndexEnabledResponse) fromAppMessage(message *appmessage.SetIndexEnabledResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.SetIndexEnabledResponse = &SetIndexEnabledResponseMessage{
+		Status: message.Status,
+		Error:  err,
+	}
+	return nil
+}
+
+func (x *SetIndexEnabledResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SetIndexEnabledResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.SetIndexEnabledResponseMessage{
+		Status: x.Status,
+		Error:  rpcErr,
+	}, nil
+}
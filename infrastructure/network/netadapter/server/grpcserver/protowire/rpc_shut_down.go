@@ -24,7 +24,7 @@ func (x *KaspadMessage_ShutDownResponse) toAppMessage() (appmessage.Message, err
 func (x *KaspadMessage_ShutDownResponse) fromAppMessage(message *appmessage.ShutDownResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.ShutDownResponse = &ShutDownResponseMessage{
 		Error: err,
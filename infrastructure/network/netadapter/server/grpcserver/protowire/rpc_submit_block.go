@@ -16,6 +16,7 @@ func (x *KaspadMessage_SubmitBlockRequest) toAppMessage() (appmessage.Message, e
 func (x *KaspadMessage_SubmitBlockRequest) fromAppMessage(message *appmessage.SubmitBlockRequestMessage) error {
 	x.SubmitBlockRequest = &SubmitBlockRequestMessage{Block: &RpcBlock{}}
 	x.SubmitBlockRequest.AllowNonDAABlocks = message.AllowNonDAABlocks
+	x.SubmitBlockRequest.IdempotencyKey = message.IdempotencyKey
 	return x.SubmitBlockRequest.Block.fromAppMessage(message.Block)
 }
 
@@ -30,6 +31,7 @@ func (x *SubmitBlockRequestMessage) toAppMessage() (appmessage.Message, error) {
 	return &appmessage.SubmitBlockRequestMessage{
 		Block:             blockAppMessage,
 		AllowNonDAABlocks: x.GetAllowNonDAABlocks(),
+		IdempotencyKey:    x.IdempotencyKey,
 	}, nil
 }
 
@@ -43,7 +45,7 @@ func (x *KaspadMessage_SubmitBlockResponse) toAppMessage() (appmessage.Message,
 func (x *KaspadMessage_SubmitBlockResponse) fromAppMessage(message *appmessage.SubmitBlockResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.SubmitBlockResponse = &SubmitBlockResponseMessage{
 		RejectReason: SubmitBlockResponseMessage_RejectReason(message.RejectReason),
@@ -71,9 +73,15 @@ func (x *RpcBlock) toAppMessage() (*appmessage.RPCBlock, error) {
 	if x == nil {
 		return nil, errors.Wrapf(errorNil, "RpcBlock is nil")
 	}
-	header, err := x.Header.toAppMessage()
-	if err != nil {
-		return nil, err
+	// The header is omitted from blocks that are returned with
+	// VERBOSITY_HASHES_ONLY
+	var header *appmessage.RPCBlockHeader
+	if x.Header != nil {
+		var err error
+		header, err = x.Header.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
 	}
 	transactions := make([]*appmessage.RPCTransaction, len(x.Transactions))
 	for i, transaction := range x.Transactions {
@@ -99,8 +107,11 @@ func (x *RpcBlock) toAppMessage() (*appmessage.RPCBlock, error) {
 }
 
 func (x *RpcBlock) fromAppMessage(message *appmessage.RPCBlock) error {
-	header := &RpcBlockHeader{}
-	header.fromAppMessage(message.Header)
+	var header *RpcBlockHeader
+	if message.Header != nil {
+		header = &RpcBlockHeader{}
+		header.fromAppMessage(message.Header)
+	}
 	transactions := make([]*RpcTransaction, len(message.Transactions))
 	for i, transaction := range message.Transactions {
 		rpcTransaction := &RpcTransaction{}
@@ -203,6 +214,9 @@ func (x *RpcBlockVerboseData) toAppMessage() (*appmessage.RPCBlockVerboseData, e
 		MergeSetBluesHashes: x.MergeSetBluesHashes,
 		MergeSetRedsHashes:  x.MergeSetRedsHashes,
 		IsChainBlock:        x.IsChainBlock,
+		TotalMass:           x.TotalMass,
+		TotalFees:           x.TotalFees,
+		AreFeesKnown:        x.AreFeesKnown,
 	}, nil
 }
 
@@ -218,5 +232,8 @@ func (x *RpcBlockVerboseData) fromAppMessage(message *appmessage.RPCBlockVerbose
 		MergeSetBluesHashes: message.MergeSetBluesHashes,
 		MergeSetRedsHashes:  message.MergeSetRedsHashes,
 		IsChainBlock:        message.IsChainBlock,
+		TotalMass:           message.TotalMass,
+		TotalFees:           message.TotalFees,
+		AreFeesKnown:        message.AreFeesKnown,
 	}
 }
@@ -16,8 +16,9 @@ func (x *KaspadMessage_SubmitTransactionRequest) toAppMessage() (appmessage.Mess
 
 func (x *KaspadMessage_SubmitTransactionRequest) fromAppMessage(message *appmessage.SubmitTransactionRequestMessage) error {
 	x.SubmitTransactionRequest = &SubmitTransactionRequestMessage{
-		Transaction: &RpcTransaction{},
-		AllowOrphan: message.AllowOrphan,
+		Transaction:    &RpcTransaction{},
+		AllowOrphan:    message.AllowOrphan,
+		IdempotencyKey: message.IdempotencyKey,
 	}
 	x.SubmitTransactionRequest.Transaction.fromAppMessage(message.Transaction)
 	return nil
@@ -32,8 +33,9 @@ func (x *SubmitTransactionRequestMessage) toAppMessage() (appmessage.Message, er
 		return nil, err
 	}
 	return &appmessage.SubmitTransactionRequestMessage{
-		Transaction: rpcTransaction,
-		AllowOrphan: x.AllowOrphan,
+		Transaction:    rpcTransaction,
+		AllowOrphan:    x.AllowOrphan,
+		IdempotencyKey: x.IdempotencyKey,
 	}, nil
 }
 
@@ -47,7 +49,7 @@ func (x *KaspadMessage_SubmitTransactionResponse) toAppMessage() (appmessage.Mes
 func (x *KaspadMessage_SubmitTransactionResponse) fromAppMessage(message *appmessage.SubmitTransactionResponseMessage) error {
 	var err *RPCError
 	if message.Error != nil {
-		err = &RPCError{Message: message.Error.Message}
+		err = newRPCError(message.Error)
 	}
 	x.SubmitTransactionResponse = &SubmitTransactionResponseMessage{
 		TransactionId: message.TransactionID,
@@ -154,7 +156,7 @@ func (x *RpcTransactionInput) toAppMessage() (*appmessage.RPCTransactionInput, e
 		return nil, err
 	}
 	var verboseData *appmessage.RPCTransactionInputVerboseData
-	for x.VerboseData != nil {
+	if x.VerboseData != nil {
 		appMessageVerboseData, err := x.VerboseData.toAppMessage()
 		if err != nil {
 			return nil, err
@@ -175,7 +177,7 @@ func (x *RpcTransactionInput) fromAppMessage(message *appmessage.RPCTransactionI
 	previousOutpoint.fromAppMessage(message.PreviousOutpoint)
 	var verboseData *RpcTransactionInputVerboseData
 	if message.VerboseData != nil {
-		verboseData := &RpcTransactionInputVerboseData{}
+		verboseData = &RpcTransactionInputVerboseData{}
 		verboseData.fromAppData(message.VerboseData)
 	}
 	*x = RpcTransactionInput{
@@ -299,6 +301,8 @@ func (x *RpcTransactionVerboseData) toAppMessage() (*appmessage.RPCTransactionVe
 		Mass:          x.Mass,
 		BlockHash:     x.BlockHash,
 		BlockTime:     x.BlockTime,
+		Fee:           x.Fee,
+		IsFeeKnown:    x.IsFeeKnown,
 	}, nil
 }
 
@@ -309,6 +313,8 @@ func (x *RpcTransactionVerboseData) fromAppMessage(message *appmessage.RPCTransa
 		Mass:          message.Mass,
 		BlockHash:     message.BlockHash,
 		BlockTime:     message.BlockTime,
+		Fee:           message.Fee,
+		IsFeeKnown:    message.IsFeeKnown,
 	}
 }
 
@@ -316,11 +322,15 @@ func (x *RpcTransactionInputVerboseData) toAppMessage() (*appmessage.RPCTransact
 	if x == nil {
 		return nil, errors.Wrapf(errorNil, "RpcTransactionInputVerboseData is nil")
 	}
-	return &appmessage.RPCTransactionInputVerboseData{}, nil
+	return &appmessage.RPCTransactionInputVerboseData{
+		SignatureScriptAsm: x.SignatureScriptAsm,
+	}, nil
 }
 
 func (x *RpcTransactionInputVerboseData) fromAppData(message *appmessage.RPCTransactionInputVerboseData) {
-	*x = RpcTransactionInputVerboseData{}
+	*x = RpcTransactionInputVerboseData{
+		SignatureScriptAsm: message.SignatureScriptAsm,
+	}
 }
 
 func (x *RpcTransactionOutputVerboseData) toAppMessage() (*appmessage.RPCTransactionOutputVerboseData, error) {
@@ -330,6 +340,7 @@ func (x *RpcTransactionOutputVerboseData) toAppMessage() (*appmessage.RPCTransac
 	return &appmessage.RPCTransactionOutputVerboseData{
 		ScriptPublicKeyType:    x.ScriptPublicKeyType,
 		ScriptPublicKeyAddress: x.ScriptPublicKeyAddress,
+		ScriptPublicKeyAsm:     x.ScriptPublicKeyAsm,
 	}, nil
 }
 
@@ -337,5 +348,6 @@ func (x *RpcTransactionOutputVerboseData) fromAppMessage(message *appmessage.RPC
 	*x = RpcTransactionOutputVerboseData{
 		ScriptPublicKeyType:    message.ScriptPublicKeyType,
 		ScriptPublicKeyAddress: message.ScriptPublicKeyAddress,
+		ScriptPublicKeyAsm:     message.ScriptPublicKeyAsm,
 	}
 }
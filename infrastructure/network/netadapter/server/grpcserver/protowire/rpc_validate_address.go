@@ -0,0 +1,74 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ValidateAddressRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ValidateAddressRequest is nil")
+	}
+	return x.ValidateAddressRequest.toAppMessage()
+}
+
+func (x *ValidateAddressRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ValidateAddressRequestMessage is nil")
+	}
+	return &appmessage.ValidateAddressRequestMessage{
+		Address: x.Address,
+	}, nil
+}
+
+func (x *KaspadMessage_ValidateAddressRequest) fromAppMessage(message *appmessage.ValidateAddressRequestMessage) error {
+	x.ValidateAddressRequest = &ValidateAddressRequestMessage{
+		Address: message.Address,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_ValidateAddressResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ValidateAddressResponse is nil")
+	}
+	return x.ValidateAddressResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_ValidateAddressResponse) fromAppMessage(message *appmessage.ValidateAddressResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = newRPCError(message.Error)
+	}
+	x.ValidateAddressResponse = &ValidateAddressResponseMessage{
+		IsValid:       message.IsValid,
+		InvalidReason: message.InvalidReason,
+		Prefix:        message.Prefix,
+		AddressType:   message.AddressType,
+		Error:         err,
+	}
+	return nil
+}
+
+func (x *ValidateAddressResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ValidateAddressResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && (x.IsValid || x.InvalidReason != "") {
+		return nil, errors.New("ValidateAddressResponseMessage contains both an error and a response")
+	}
+
+	return &appmessage.ValidateAddressResponseMessage{
+		IsValid:       x.IsValid,
+		InvalidReason: x.InvalidReason,
+		Prefix:        x.Prefix,
+		AddressType:   x.AddressType,
+		Error:         rpcErr,
+	}, nil
+}
@@ -22,6 +22,9 @@ func (x *KaspadMessage) ToAppMessage() (appmessage.Message, error) {
 	if err != nil {
 		return nil, err
 	}
+	for _, warning := range x.Warnings {
+		appMessage.AddWarning(warning)
+	}
 	return appMessage, nil
 }
 
@@ -32,7 +35,8 @@ func FromAppMessage(message appmessage.Message) (*KaspadMessage, error) {
 		return nil, err
 	}
 	return &KaspadMessage{
-		Payload: payload,
+		Payload:  payload,
+		Warnings: message.Warnings(),
 	}, nil
 }
 
@@ -359,6 +363,34 @@ func toP2PPayload(message appmessage.Message) (isKaspadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.MsgRequestCompactBlock:
+		payload := new(KaspadMessage_RequestCompactBlock)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgCompactBlock:
+		payload := new(KaspadMessage_CompactBlock)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgRequestBlockTransactions:
+		payload := new(KaspadMessage_RequestBlockTransactions)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgBlockTransactions:
+		payload := new(KaspadMessage_BlockTransactions)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	default:
 		return nil, nil
 	}
@@ -870,6 +902,13 @@ func toRPCPayload(message appmessage.Message) (isKaspadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.StopNotifyingPruningPointUTXOSetOverrideResponseMessage:
+		payload := new(KaspadMessage_StopNotifyingPruningPointUTXOSetOverrideResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	case *appmessage.EstimateNetworkHashesPerSecondRequestMessage:
 		payload := new(KaspadMessage_EstimateNetworkHashesPerSecondRequest)
 		err := payload.fromAppMessage(message)
@@ -968,6 +1007,692 @@ func toRPCPayload(message appmessage.Message) (isKaspadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.GetChainWorkStatusRequestMessage:
+		payload := new(KaspadMessage_GetChainWorkStatusRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetChainWorkStatusResponseMessage:
+		payload := new(KaspadMessage_GetChainWorkStatusResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetEffectiveConfigRequestMessage:
+		payload := new(KaspadMessage_GetEffectiveConfigRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetEffectiveConfigResponseMessage:
+		payload := new(KaspadMessage_GetEffectiveConfigResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionPropagationReportRequestMessage:
+		payload := new(KaspadMessage_GetTransactionPropagationReportRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionPropagationReportResponseMessage:
+		payload := new(KaspadMessage_GetTransactionPropagationReportResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetReorgedTransactionsStatsRequestMessage:
+		payload := new(KaspadMessage_GetReorgedTransactionsStatsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetReorgedTransactionsStatsResponseMessage:
+		payload := new(KaspadMessage_GetReorgedTransactionsStatsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyTransactionEvictedRequestMessage:
+		payload := new(KaspadMessage_NotifyTransactionEvictedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyTransactionEvictedResponseMessage:
+		payload := new(KaspadMessage_NotifyTransactionEvictedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.TransactionEvictedNotificationMessage:
+		payload := new(KaspadMessage_TransactionEvictedNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetImmatureCoinbaseOutputsRequestMessage:
+		payload := new(KaspadMessage_GetImmatureCoinbaseOutputsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetImmatureCoinbaseOutputsResponseMessage:
+		payload := new(KaspadMessage_GetImmatureCoinbaseOutputsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyBlueScoreReachedRequestMessage:
+		payload := new(KaspadMessage_NotifyBlueScoreReachedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyBlueScoreReachedResponseMessage:
+		payload := new(KaspadMessage_NotifyBlueScoreReachedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.BlueScoreReachedNotificationMessage:
+		payload := new(KaspadMessage_BlueScoreReachedNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetChainChangedEventsFromBlockRequestMessage:
+		payload := new(KaspadMessage_GetChainChangedEventsFromBlockRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetChainChangedEventsFromBlockResponseMessage:
+		payload := new(KaspadMessage_GetChainChangedEventsFromBlockResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetOutpointSpendingTransactionRequestMessage:
+		payload := new(KaspadMessage_GetOutpointSpendingTransactionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetOutpointSpendingTransactionResponseMessage:
+		payload := new(KaspadMessage_GetOutpointSpendingTransactionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetScriptClassStatisticsRequestMessage:
+		payload := new(KaspadMessage_GetScriptClassStatisticsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetScriptClassStatisticsResponseMessage:
+		payload := new(KaspadMessage_GetScriptClassStatisticsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetFeeHistoryRequestMessage:
+		payload := new(KaspadMessage_GetFeeHistoryRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetFeeHistoryResponseMessage:
+		payload := new(KaspadMessage_GetFeeHistoryResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetCoinAgeAnalyticsRequestMessage:
+		payload := new(KaspadMessage_GetCoinAgeAnalyticsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetCoinAgeAnalyticsResponseMessage:
+		payload := new(KaspadMessage_GetCoinAgeAnalyticsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetIndexRetentionStatusRequestMessage:
+		payload := new(KaspadMessage_GetIndexRetentionStatusRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetIndexRetentionStatusResponseMessage:
+		payload := new(KaspadMessage_GetIndexRetentionStatusResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NegotiateAPIVersionRequestMessage:
+		payload := new(KaspadMessage_NegotiateAPIVersionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NegotiateAPIVersionResponseMessage:
+		payload := new(KaspadMessage_NegotiateAPIVersionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetRPCSessionsRequestMessage:
+		payload := new(KaspadMessage_GetRPCSessionsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetRPCSessionsResponseMessage:
+		payload := new(KaspadMessage_GetRPCSessionsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DisconnectRPCSessionRequestMessage:
+		payload := new(KaspadMessage_DisconnectRPCSessionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DisconnectRPCSessionResponseMessage:
+		payload := new(KaspadMessage_DisconnectRPCSessionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.BlockAddedBatchNotificationMessage:
+		payload := new(KaspadMessage_BlockAddedBatchNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DAGSnapshotNotificationMessage:
+		payload := new(KaspadMessage_DagSnapshotNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetHeadersSelectedTipRequestMessage:
+		payload := new(KaspadMessage_GetHeadersSelectedTipRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetHeadersSelectedTipResponseMessage:
+		payload := new(KaspadMessage_GetHeadersSelectedTipResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SetIndexEnabledRequestMessage:
+		payload := new(KaspadMessage_SetIndexEnabledRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SetIndexEnabledResponseMessage:
+		payload := new(KaspadMessage_SetIndexEnabledResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetIndexStatusRequestMessage:
+		payload := new(KaspadMessage_GetIndexStatusRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetIndexStatusResponseMessage:
+		payload := new(KaspadMessage_GetIndexStatusResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyPeerEventsRequestMessage:
+		payload := new(KaspadMessage_NotifyPeerEventsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyPeerEventsResponseMessage:
+		payload := new(KaspadMessage_NotifyPeerEventsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.PeerEventNotificationMessage:
+		payload := new(KaspadMessage_PeerEventNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetAddressManagerInfoRequestMessage:
+		payload := new(KaspadMessage_GetAddressManagerInfoRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetAddressManagerInfoResponseMessage:
+		payload := new(KaspadMessage_GetAddressManagerInfoResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetPeerFlowStatisticsRequestMessage:
+		payload := new(KaspadMessage_GetPeerFlowStatisticsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetPeerFlowStatisticsResponseMessage:
+		payload := new(KaspadMessage_GetPeerFlowStatisticsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionRequestMessage:
+		payload := new(KaspadMessage_GetTransactionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionResponseMessage:
+		payload := new(KaspadMessage_GetTransactionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionsByAddressRequestMessage:
+		payload := new(KaspadMessage_GetTransactionsByAddressRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionsByAddressResponseMessage:
+		payload := new(KaspadMessage_GetTransactionsByAddressResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetRelayPolicyRequestMessage:
+		payload := new(KaspadMessage_GetRelayPolicyRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetRelayPolicyResponseMessage:
+		payload := new(KaspadMessage_GetRelayPolicyResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetNetworkHealthRequestMessage:
+		payload := new(KaspadMessage_GetNetworkHealthRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetNetworkHealthResponseMessage:
+		payload := new(KaspadMessage_GetNetworkHealthResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.EstimateFeeRequestMessage:
+		payload := new(KaspadMessage_EstimateFeeRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.EstimateFeeResponseMessage:
+		payload := new(KaspadMessage_EstimateFeeResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.RefreshSeedsRequestMessage:
+		payload := new(KaspadMessage_RefreshSeedsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.RefreshSeedsResponseMessage:
+		payload := new(KaspadMessage_RefreshSeedsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyTransactionRemovedFromMempoolRequestMessage:
+		payload := new(KaspadMessage_NotifyTransactionRemovedFromMempoolRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyTransactionRemovedFromMempoolResponseMessage:
+		payload := new(KaspadMessage_NotifyTransactionRemovedFromMempoolResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.TransactionRemovedFromMempoolNotificationMessage:
+		payload := new(KaspadMessage_TransactionRemovedFromMempoolNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyTransactionConfirmedRequestMessage:
+		payload := new(KaspadMessage_NotifyTransactionConfirmedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyTransactionConfirmedResponseMessage:
+		payload := new(KaspadMessage_NotifyTransactionConfirmedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.TransactionConfirmedNotificationMessage:
+		payload := new(KaspadMessage_TransactionConfirmedNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetUTXORequestMessage:
+		payload := new(KaspadMessage_GetUTXORequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetUTXOResponseMessage:
+		payload := new(KaspadMessage_GetUTXOResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBlockHeadersRequestMessage:
+		payload := new(KaspadMessage_GetBlockHeadersRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBlockHeadersResponseMessage:
+		payload := new(KaspadMessage_GetBlockHeadersResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetDAGTipsRequestMessage:
+		payload := new(KaspadMessage_GetDAGTipsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetDAGTipsResponseMessage:
+		payload := new(KaspadMessage_GetDAGTipsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBannedPeersRequestMessage:
+		payload := new(KaspadMessage_GetBannedPeersRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBannedPeersResponseMessage:
+		payload := new(KaspadMessage_GetBannedPeersResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBandwidthInfoRequestMessage:
+		payload := new(KaspadMessage_GetBandwidthInfoRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBandwidthInfoResponseMessage:
+		payload := new(KaspadMessage_GetBandwidthInfoResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetPayoutStatsRequestMessage:
+		payload := new(KaspadMessage_GetPayoutStatsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetPayoutStatsResponseMessage:
+		payload := new(KaspadMessage_GetPayoutStatsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBlockRateStatusRequestMessage:
+		payload := new(KaspadMessage_GetBlockRateStatusRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBlockRateStatusResponseMessage:
+		payload := new(KaspadMessage_GetBlockRateStatusResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ExportUTXOSnapshotRequestMessage:
+		payload := new(KaspadMessage_ExportUTXOSnapshotRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ExportUTXOSnapshotResponseMessage:
+		payload := new(KaspadMessage_ExportUTXOSnapshotResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ReloadConfigRequestMessage:
+		payload := new(KaspadMessage_ReloadConfigRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ReloadConfigResponseMessage:
+		payload := new(KaspadMessage_ReloadConfigResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetMempoolGraphRequestMessage:
+		payload := new(KaspadMessage_GetMempoolGraphRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetMempoolGraphResponseMessage:
+		payload := new(KaspadMessage_GetMempoolGraphResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ExportMempoolSnapshotRequestMessage:
+		payload := new(KaspadMessage_ExportMempoolSnapshotRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ExportMempoolSnapshotResponseMessage:
+		payload := new(KaspadMessage_ExportMempoolSnapshotResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DeriveAddressFromPublicKeyRequestMessage:
+		payload := new(KaspadMessage_DeriveAddressFromPublicKeyRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DeriveAddressFromPublicKeyResponseMessage:
+		payload := new(KaspadMessage_DeriveAddressFromPublicKeyResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ValidateAddressRequestMessage:
+		payload := new(KaspadMessage_ValidateAddressRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ValidateAddressResponseMessage:
+		payload := new(KaspadMessage_ValidateAddressResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ConvertAddressRequestMessage:
+		payload := new(KaspadMessage_ConvertAddressRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ConvertAddressResponseMessage:
+		payload := new(KaspadMessage_ConvertAddressResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyTransactionReplacedRequestMessage:
+		payload := new(KaspadMessage_NotifyTransactionReplacedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyTransactionReplacedResponseMessage:
+		payload := new(KaspadMessage_NotifyTransactionReplacedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.TransactionReplacedNotificationMessage:
+		payload := new(KaspadMessage_TransactionReplacedNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	default:
 		return nil, nil
 	}
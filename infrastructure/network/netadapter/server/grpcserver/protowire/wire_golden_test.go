@@ -0,0 +1,234 @@
+package protowire
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// updateGoldenVectors regenerates the golden vectors file. It should only be
+// used when a wire encoding change is intentional, e.g. when a new message is
+// added, and the resulting diff should be reviewed as part of that change.
+var updateGoldenVectors = flag.Bool("update-golden", false, "regenerate testdata/golden_vectors.json")
+
+var goldenVectorsPath = filepath.Join("testdata", "golden_vectors.json")
+
+// TestWireGoldenVectors fills every KaspadMessage payload with canonical
+// sample data, converts it to its appmessage representation and back, makes
+// sure no data was lost on the way, and compares the resulting encoding to the golden vectors generated by previous
+// releases. It also makes sure that the golden vectors themselves still decode
+// into the same appmessage.
+func TestWireGoldenVectors(t *testing.T) {
+	goldenVectors := make(map[string]string)
+	if !*updateGoldenVectors {
+		goldenVectorsJSON, err := os.ReadFile(goldenVectorsPath)
+		if err != nil {
+			t.Fatalf("ReadFile: %s", err)
+		}
+		err = json.Unmarshal(goldenVectorsJSON, &goldenVectors)
+		if err != nil {
+			t.Fatalf("Unmarshal: %s", err)
+		}
+	}
+
+	payloadFields := (&KaspadMessage{}).ProtoReflect().Descriptor().Oneofs().ByName("payload").Fields()
+	payloadNames := make(map[string]struct{}, payloadFields.Len())
+	for i := 0; i < payloadFields.Len(); i++ {
+		payloadField := payloadFields.Get(i)
+		payloadName := string(payloadField.Name())
+		payloadNames[payloadName] = struct{}{}
+
+		sample := &KaspadMessage{}
+		fillSampleMessage(sample.ProtoReflect().Mutable(payloadField).Message(), 0)
+		sampleAppMessage, err := sample.ToAppMessage()
+		if err != nil {
+			t.Errorf("%s: ToAppMessage: %s", payloadName, err)
+			continue
+		}
+		encoded, err := encodeAppMessage(sampleAppMessage)
+		if err != nil {
+			t.Errorf("%s: %s", payloadName, err)
+			continue
+		}
+		reencoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(sample)
+		if err != nil {
+			t.Fatalf("Marshal: %s", err)
+		}
+		if !bytes.Equal(encoded, reencoded) {
+			t.Errorf("%s: converting to appmessage and back is lossy", payloadName)
+			continue
+		}
+
+		if *updateGoldenVectors {
+			goldenVectors[payloadName] = hex.EncodeToString(encoded)
+			continue
+		}
+
+		goldenVector, ok := goldenVectors[payloadName]
+		if !ok {
+			t.Errorf("%s: missing golden vector. If this is a new message, run this test with -update-golden", payloadName)
+			continue
+		}
+		if hex.EncodeToString(encoded) != goldenVector {
+			t.Errorf("%s: the encoding differs from the golden vector. This breaks wire compatibility with "+
+				"previous releases.\nexpected: %s\ngot:      %x", payloadName, goldenVector, encoded)
+			continue
+		}
+
+		goldenAppMessage, err := decodeAppMessage(goldenVector)
+		if err != nil {
+			t.Errorf("%s: failed decoding golden vector: %s", payloadName, err)
+			continue
+		}
+		if !reflect.DeepEqual(goldenAppMessage, sampleAppMessage) {
+			t.Errorf("%s: the golden vector decodes into %+v, but expected %+v",
+				payloadName, goldenAppMessage, sampleAppMessage)
+		}
+	}
+
+	if *updateGoldenVectors {
+		goldenVectorsJSON, err := json.MarshalIndent(goldenVectors, "", "  ")
+		if err != nil {
+			t.Fatalf("MarshalIndent: %s", err)
+		}
+		err = os.WriteFile(goldenVectorsPath, append(goldenVectorsJSON, '\n'), 0644)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+		return
+	}
+
+	// Messages may never be removed from the wire protocol without it being
+	// a deliberate compatibility break
+	var removedPayloadNames []string
+	for payloadName := range goldenVectors {
+		if _, ok := payloadNames[payloadName]; !ok {
+			removedPayloadNames = append(removedPayloadNames, payloadName)
+		}
+	}
+	sort.Strings(removedPayloadNames)
+	for _, payloadName := range removedPayloadNames {
+		t.Errorf("%s: has a golden vector but no longer exists", payloadName)
+	}
+}
+
+func encodeAppMessage(message appmessage.Message) ([]byte, error) {
+	kaspadMessage, err := FromAppMessage(message)
+	if err != nil {
+		return nil, fmt.Errorf("FromAppMessage: %s", err)
+	}
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(kaspadMessage)
+	if err != nil {
+		return nil, fmt.Errorf("Marshal: %s", err)
+	}
+	return encoded, nil
+}
+
+func decodeAppMessage(encodedHex string) (appmessage.Message, error) {
+	encoded, err := hex.DecodeString(encodedHex)
+	if err != nil {
+		return nil, err
+	}
+	kaspadMessage := &KaspadMessage{}
+	err = proto.Unmarshal(encoded, kaspadMessage)
+	if err != nil {
+		return nil, err
+	}
+	return kaspadMessage.ToAppMessage()
+}
+
+const sampleMaxDepth = 10
+
+// fillSampleMessage deterministically fills every field of the given message
+// with sample data. The data is derived from the field numbers only, so it
+// stays stable as long as the schema of the message does.
+func fillSampleMessage(message protoreflect.Message, depth int) {
+	fields := message.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		// A populated error makes RPC responses drop all their other fields
+		if field.Kind() == protoreflect.MessageKind && field.Message().Name() == "RPCError" {
+			continue
+		}
+		if field.Kind() == protoreflect.MessageKind && depth >= sampleMaxDepth {
+			continue
+		}
+
+		if field.IsList() {
+			list := message.Mutable(field).List()
+			for j := 0; j < 2; j++ {
+				if field.Kind() == protoreflect.MessageKind {
+					element := list.NewElement()
+					fillSampleMessage(element.Message(), depth+1)
+					list.Append(element)
+				} else {
+					list.Append(sampleScalar(message.Descriptor(), field, j))
+				}
+			}
+			continue
+		}
+
+		if field.Kind() == protoreflect.MessageKind {
+			fillSampleMessage(message.Mutable(field).Message(), depth+1)
+			continue
+		}
+		message.Set(field, sampleScalar(message.Descriptor(), field, 0))
+	}
+}
+
+func sampleScalar(parent protoreflect.MessageDescriptor, field protoreflect.FieldDescriptor,
+	index int) protoreflect.Value {
+
+	number := uint64(field.Number()) + uint64(index)
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		return protoreflect.ValueOfEnum(values.Get(values.Len() - 1).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(number))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(number))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(number))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(number)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(number) + 0.5)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(float64(number) + 0.5)
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(fmt.Sprintf("%s-%d", field.Name(), number))
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(sampleBytes(parent, number))
+	default:
+		panic(fmt.Sprintf("unsupported field kind %s", field.Kind()))
+	}
+}
+
+func sampleBytes(parent protoreflect.MessageDescriptor, number uint64) []byte {
+	length := 32
+	switch parent.Name() {
+	case "SubnetworkId":
+		length = 20
+	case "NetAddress", "VersionMessage":
+		length = 16
+	}
+	sample := make([]byte, length)
+	for i := range sample {
+		sample[i] = byte(number + uint64(i))
+	}
+	return sample
+}
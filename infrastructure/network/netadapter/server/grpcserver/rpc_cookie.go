@@ -0,0 +1,67 @@
+package grpcserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// rpcCookieSize is the number of random bytes in an RPC auth cookie
+const rpcCookieSize = 32
+
+// WriteRPCCookie generates a new RPC auth cookie and writes it to the file in
+// the given path, which only the current user may read. Any cookie that was
+// previously written to it is replaced.
+func WriteRPCCookie(path string) (string, error) {
+	cookieBytes := make([]byte, rpcCookieSize)
+	_, err := rand.Read(cookieBytes)
+	if err != nil {
+		return "", err
+	}
+	cookie := hex.EncodeToString(cookieBytes)
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return "", err
+	}
+	// The cookie is written to a temporary file that's renamed over the
+	// cookie file, so that clients never read a partially written cookie
+	temporaryPath := path + ".tmp"
+	err = os.WriteFile(temporaryPath, []byte(cookie), 0600)
+	if err != nil {
+		return "", err
+	}
+	err = os.Rename(temporaryPath, path)
+	if err != nil {
+		os.Remove(temporaryPath)
+		return "", err
+	}
+	return cookie, nil
+}
+
+// ReadRPCCookie reads the RPC auth cookie from the file in the given path
+func ReadRPCCookie(path string) (string, error) {
+	cookieBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	cookie := strings.TrimSpace(string(cookieBytes))
+	if cookie == "" {
+		return "", errors.Errorf("the RPC cookie file %s is empty", path)
+	}
+	return cookie, nil
+}
+
+// RemoveRPCCookie removes the RPC auth cookie file in the given path, if it
+// exists
+func RemoveRPCCookie(path string) error {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package grpcserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// selfSignedCertificateValidity is how long a generated RPC certificate is
+// valid for
+const selfSignedCertificateValidity = 10 * 365 * 24 * time.Hour
+
+// LoadRPCTLSConfig returns the TLS config that the RPC servers serve with,
+// using the certificate and key in the given files. If neither file exists, a
+// self-signed certificate and its key are generated and written to them
+// first, so that clients may trust the certificate file.
+func LoadRPCTLSConfig(certFile, keyFile string) (*tls.Config, error) {
+	if !fileExists(certFile) && !fileExists(keyFile) {
+		err := writeSelfSignedCertificate(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error generating the RPC certificate %s", certFile)
+		}
+		log.Infof("Generated a self-signed RPC certificate in %s", certFile)
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading the RPC certificate %s and key %s", certFile, keyFile)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		// gRPC clients negotiate HTTP/2 over ALPN
+		NextProtos: []string{"h2"},
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// writeSelfSignedCertificate generates a self-signed certificate that's valid
+// for localhost and for the host's names and addresses, and writes it and its
+// key to the given files
+func writeSelfSignedCertificate(certFile, keyFile string) error {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{"kaspad autogenerated cert"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedCertificateValidity),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "localhost" {
+		template.Subject.CommonName = hostname
+		template.DNSNames = append(template.DNSNames, hostname)
+	}
+	interfaceAddresses, err := net.InterfaceAddrs()
+	if err == nil {
+		for _, interfaceAddress := range interfaceAddresses {
+			ipNet, ok := interfaceAddress.(*net.IPNet)
+			if ok && !ipNet.IP.IsLoopback() {
+				template.IPAddresses = append(template.IPAddresses, ipNet.IP)
+			}
+		}
+	}
+
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return err
+	}
+	privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(certFile), 0700)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(keyFile), 0700)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateBytes}), 0644)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyBytes}), 0600)
+	if err != nil {
+		os.Remove(certFile)
+		return err
+	}
+	return nil
+}
@@ -1,29 +1,173 @@
 package grpcserver
 
 import (
+	"context"
+	"crypto/tls"
+	"strings"
+
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/kaspanet/kaspad/util/panics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 type rpcServer struct {
 	protowire.UnimplementedRPCServer
 	gRPCServer
+
+	authenticate server.RPCAuthenticator
+	healthServer *health.Server
 }
 
 // RPCMaxMessageSize is the max message size for the RPC server to send and receive
 const RPCMaxMessageSize = 1024 * 1024 * 1024 // 1 GB
 
-// NewRPCServer creates a new RPCServer
-func NewRPCServer(listeningAddresses []string, rpcMaxInboundConnections int) (server.Server, error) {
-	gRPCServer := newGRPCServer(listeningAddresses, RPCMaxMessageSize, rpcMaxInboundConnections, "RPC")
-	rpcServer := &rpcServer{gRPCServer: *gRPCServer}
+// AuthorizationMetadataKey is the gRPC metadata key by which RPC clients
+// present their credentials, in the form of an HTTP Authorization header:
+// "Bearer <token>" or "Basic <base64 of user:password>"
+const AuthorizationMetadataKey = "authorization"
+
+// AuthorizedMetadataKey is the gRPC header metadata key by which the RPC server
+// tells clients that presented credentials that they were accepted
+const AuthorizedMetadataKey = "kaspad-authorized"
+
+// RPCServiceName is the name of the RPC service in the gRPC health service.
+// It's reported as serving only while the node is synced, while the server
+// as a whole, named "", is serving as long as it's running.
+var RPCServiceName = protowire.RPC_ServiceDesc.ServiceName
+
+// NetworkServiceName is the name of the service in the gRPC health service
+// that's reported as serving only while the connection of the node to the
+// network isn't degraded
+const NetworkServiceName = "kaspad.Network"
+
+// NewRPCServer creates a new RPCServer. It listens on unixSocket as well,
+// unless it's nil. If authenticate is not nil, clients must be authenticated
+// by it in order to connect. If tlsConfig is not nil, the listening addresses,
+// but not the Unix socket, are served over TLS. The gRPC reflection and health
+// services are served as well, and require the same authentication.
+func NewRPCServer(name string, listeningAddresses []string, unixSocket *UnixSocket, rpcMaxInboundConnections int,
+	authenticate server.RPCAuthenticator, tlsConfig *tls.Config) (server.RPCServer, error) {
+
+	var serverOptions []grpc.ServerOption
+	if authenticate != nil {
+		serverOptions = append(serverOptions,
+			grpc.UnaryInterceptor(unaryAuthenticationInterceptor(name, authenticate)),
+			grpc.StreamInterceptor(streamAuthenticationInterceptor(name, authenticate)))
+	}
+	gRPCServer := newGRPCServer(listeningAddresses, RPCMaxMessageSize, rpcMaxInboundConnections, name, serverOptions...)
+	gRPCServer.unixSocket = unixSocket
+	gRPCServer.tlsConfig = tlsConfig
+	rpcServer := &rpcServer{gRPCServer: *gRPCServer, authenticate: authenticate, healthServer: health.NewServer()}
 	protowire.RegisterRPCServer(gRPCServer.server, rpcServer)
+
+	rpcServer.healthServer.SetServingStatus(RPCServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	rpcServer.healthServer.SetServingStatus(NetworkServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(gRPCServer.server, rpcServer.healthServer)
+	reflection.Register(gRPCServer.server)
 	return rpcServer, nil
 }
 
+// SetIsSynced sets whether the node is synced
+func (r *rpcServer) SetIsSynced(isSynced bool) {
+	servingStatus := healthpb.HealthCheckResponse_NOT_SERVING
+	if isSynced {
+		servingStatus = healthpb.HealthCheckResponse_SERVING
+	}
+	r.healthServer.SetServingStatus(RPCServiceName, servingStatus)
+}
+
+// SetIsNetworkDegraded sets whether the connection of the node to the network is degraded
+func (r *rpcServer) SetIsNetworkDegraded(isNetworkDegraded bool) {
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+	if isNetworkDegraded {
+		servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	r.healthServer.SetServingStatus(NetworkServiceName, servingStatus)
+}
+
+// Stop reports all the services as not serving, so that health watchers are
+// notified, and stops the server
+func (r *rpcServer) Stop() error {
+	r.healthServer.Shutdown()
+	return r.gRPCServer.Stop()
+}
+
 func (r *rpcServer) MessageStream(stream protowire.RPC_MessageStreamServer) error {
 	defer panics.HandlePanic(log, "rpcServer.MessageStream", nil)
 
-	return r.handleInboundConnection(stream.Context(), stream)
+	isAuthorized, isReadOnly, credentialID := authenticateContext(stream.Context(), r.authenticate)
+	if !isAuthorized {
+		log.Warnf("%s rejected a connection with missing or invalid credentials", r.name)
+		return status.Error(codes.Unauthenticated, "missing or invalid credentials")
+	}
+	// Sending the headers right away lets clients that present credentials
+	// know that they were accepted before they make any request
+	err := stream.SendHeader(metadata.Pairs(AuthorizedMetadataKey, "true"))
+	if err != nil {
+		return err
+	}
+
+	return r.handleInboundConnection(stream.Context(), stream, isReadOnly, credentialID)
+}
+
+// authenticateContext authenticates the client of the call with the given
+// context by the credentials in its metadata
+func authenticateContext(ctx context.Context, authenticate server.RPCAuthenticator) (
+	isAuthorized bool, isReadOnly bool, credentialID string) {
+
+	if authenticate == nil {
+		return true, false, ""
+	}
+	callMetadata, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false, false, ""
+	}
+	return authenticate(callMetadata.Get(AuthorizationMetadataKey))
+}
+
+// isRPCServiceMethod returns whether the given full gRPC method name belongs
+// to the RPC service, which authenticates its clients by itself
+func isRPCServiceMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+RPCServiceName+"/")
+}
+
+// unaryAuthenticationInterceptor rejects calls of clients that weren't
+// authenticated by authenticate to the services other than the RPC service
+func unaryAuthenticationInterceptor(name string, authenticate server.RPCAuthenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		if !isRPCServiceMethod(info.FullMethod) {
+			isAuthorized, _, _ := authenticateContext(ctx, authenticate)
+			if !isAuthorized {
+				log.Warnf("%s rejected a call to %s with missing or invalid credentials", name, info.FullMethod)
+				return nil, status.Error(codes.Unauthenticated, "missing or invalid credentials")
+			}
+		}
+		return handler(ctx, request)
+	}
+}
+
+// streamAuthenticationInterceptor rejects streams of clients that weren't
+// authenticated by authenticate to the services other than the RPC service
+func streamAuthenticationInterceptor(name string, authenticate server.RPCAuthenticator) grpc.StreamServerInterceptor {
+	return func(service interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+
+		if !isRPCServiceMethod(info.FullMethod) {
+			isAuthorized, _, _ := authenticateContext(stream.Context(), authenticate)
+			if !isAuthorized {
+				log.Warnf("%s rejected a call to %s with missing or invalid credentials", name, info.FullMethod)
+				return status.Error(codes.Unauthenticated, "missing or invalid credentials")
+			}
+		}
+		return handler(service, stream)
+	}
 }
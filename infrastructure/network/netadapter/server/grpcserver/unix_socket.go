@@ -0,0 +1,86 @@
+package grpcserver
+
+import (
+	"net"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// UnixSocket is a Unix domain socket for a server to listen on
+type UnixSocket struct {
+	Path string
+
+	// Mode is the file mode of the socket. Only users that may write to
+	// the socket may connect to it.
+	Mode os.FileMode
+}
+
+func (s *gRPCServer) listenOnUnixSocket(unixSocket *UnixSocket) error {
+	err := removeStaleUnixSocket(unixSocket.Path)
+	if err != nil {
+		return errors.Wrapf(err, "%s error listening on %s", s.name, unixSocket.Path)
+	}
+	// The socket is created with the permissions the umask allows, which
+	// usually don't let other users connect to it before it's chmod-ed
+	listener, err := net.Listen("unix", unixSocket.Path)
+	if err != nil {
+		return errors.Wrapf(err, "%s error listening on %s", s.name, unixSocket.Path)
+	}
+	err = os.Chmod(unixSocket.Path, unixSocket.Mode)
+	if err != nil {
+		listener.Close()
+		return errors.Wrapf(err, "%s error setting the permissions of %s", s.name, unixSocket.Path)
+	}
+
+	s.serve(&unixSocketListener{
+		Listener: listener,
+		address:  &net.UnixAddr{Name: unixSocket.Path, Net: "unix"},
+	}, unixSocket.Path)
+	return nil
+}
+
+// removeStaleUnixSocket removes the socket left behind by a node that didn't
+// shut down cleanly. A socket that is still being served is not removed.
+func removeStaleUnixSocket(path string) error {
+	fileInfo, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fileInfo.Mode()&os.ModeSocket == 0 {
+		return errors.Errorf("%s already exists and is not a socket", path)
+	}
+	connection, err := net.Dial("unix", path)
+	if err == nil {
+		connection.Close()
+		return errors.Errorf("%s is already being served", path)
+	}
+	return os.Remove(path)
+}
+
+// unixSocketListener reports the path of the socket as the remote address of
+// the connections it accepts, since the actual remote addresses are unnamed
+type unixSocketListener struct {
+	net.Listener
+	address *net.UnixAddr
+}
+
+func (l *unixSocketListener) Accept() (net.Conn, error) {
+	connection, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &unixSocketConnection{Conn: connection, remoteAddress: l.address}, nil
+}
+
+type unixSocketConnection struct {
+	net.Conn
+	remoteAddress net.Addr
+}
+
+func (c *unixSocketConnection) RemoteAddr() net.Addr {
+	return c.remoteAddress
+}
@@ -1,8 +1,10 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 )
@@ -20,6 +22,16 @@ type OnDisconnectedHandler func()
 // was received from a connection.
 type OnInvalidMessageHandler func(err error)
 
+// RPCAuthenticator authenticates an RPC client by the values of the
+// Authorization header it presented. It returns whether the client is
+// authorized to connect, whether it may only call read-only commands, and the
+// ID of the credential it presented.
+type RPCAuthenticator func(authorizations []string) (isAuthorized bool, isReadOnly bool, credentialID string)
+
+// Dialer is a function that opens the underlying network
+// connection of an outbound Connection to the given address.
+type Dialer func(ctx context.Context, address string) (net.Conn, error)
+
 // Server represents a server.
 type Server interface {
 	Start() error
@@ -27,10 +39,28 @@ type Server interface {
 	SetOnConnectedHandler(onConnectedHandler OnConnectedHandler)
 }
 
+// RPCServer represents an RPC server.
+type RPCServer interface {
+	Server
+
+	// SetIsSynced sets whether the node is synced. The server reports
+	// its RPC service as healthy only while the node is synced.
+	SetIsSynced(isSynced bool)
+
+	// SetIsNetworkDegraded sets whether the connection of the node to the
+	// network is degraded. The server reports its network service as healthy
+	// only while it isn't.
+	SetIsNetworkDegraded(isNetworkDegraded bool)
+}
+
 // P2PServer represents a p2p server.
 type P2PServer interface {
 	Server
 	Connect(address string) (Connection, error)
+
+	// SetDialer sets the dialer used by Connect, and how long Connect
+	// waits for it. If it's not set, Connect dials the address as given.
+	SetDialer(dialer Dialer, dialTimeout time.Duration)
 }
 
 // Connection represents a server connection.
@@ -42,5 +72,20 @@ type Connection interface {
 	IsOutbound() bool
 	SetOnDisconnectedHandler(onDisconnectedHandler OnDisconnectedHandler)
 	SetOnInvalidMessageHandler(onInvalidMessageHandler OnInvalidMessageHandler)
+	ReceivedMessageCount() uint64
+	SentMessageCount() uint64
+	BytesReceived() uint64
+	BytesSent() uint64
+
+	// IsReadOnly returns whether the connection is of an RPC client that
+	// may only call read-only commands
+	IsReadOnly() bool
+
+	// CredentialID returns the ID of the credential the RPC client of the
+	// connection authenticated with, or an empty string if it didn't have to
+	CredentialID() string
+
+	// Address returns the TCP address of the connection, or nil if it was
+	// made through a Unix domain socket
 	Address() *net.TCPAddr
 }
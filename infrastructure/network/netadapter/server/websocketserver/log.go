@@ -0,0 +1,9 @@
+package websocketserver
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("TXMP")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,262 @@
+package websocketserver
+
+import (
+	"io"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type webSocketConnection struct {
+	address *net.TCPAddr
+	ws      *websocket.Conn
+	router  *router.Router
+
+	stopChan                chan struct{}
+	onDisconnectedHandler   server.OnDisconnectedHandler
+	onInvalidMessageHandler server.OnInvalidMessageHandler
+
+	isConnected uint32
+
+	receivedMessageCount uint64
+	sentMessageCount     uint64
+	bytesReceived        uint64
+	bytesSent            uint64
+
+	isReadOnly   bool
+	credentialID string
+}
+
+func newConnection(address *net.TCPAddr, ws *websocket.Conn, isReadOnly bool, credentialID string) *webSocketConnection {
+	return &webSocketConnection{
+		address:      address,
+		ws:           ws,
+		stopChan:     make(chan struct{}),
+		isConnected:  1,
+		isReadOnly:   isReadOnly,
+		credentialID: credentialID,
+	}
+}
+
+func (c *webSocketConnection) Start(router *router.Router) {
+	if c.onDisconnectedHandler == nil {
+		panic(errors.New("onDisconnectedHandler is nil"))
+	}
+
+	c.router = router
+
+	spawn("webSocketConnection.Start-connectionLoops", func() {
+		err := c.connectionLoops()
+		if err != nil {
+			log.Debugf("Disconnected from %s: %s", c, err)
+		}
+	})
+}
+
+func (c *webSocketConnection) String() string {
+	return c.address.String()
+}
+
+func (c *webSocketConnection) IsConnected() bool {
+	return atomic.LoadUint32(&c.isConnected) != 0
+}
+
+func (c *webSocketConnection) SetOnDisconnectedHandler(onDisconnectedHandler server.OnDisconnectedHandler) {
+	c.onDisconnectedHandler = onDisconnectedHandler
+}
+
+func (c *webSocketConnection) SetOnInvalidMessageHandler(onInvalidMessageHandler server.OnInvalidMessageHandler) {
+	c.onInvalidMessageHandler = onInvalidMessageHandler
+}
+
+// IsOutbound returns false, since WebSocket connections are only accepted
+func (c *webSocketConnection) IsOutbound() bool {
+	return false
+}
+
+// Disconnect disconnects the connection
+// Calling this function a second time doesn't do anything
+//
+// This is part of the Connection interface
+func (c *webSocketConnection) Disconnect() {
+	if !atomic.CompareAndSwapUint32(&c.isConnected, 1, 0) {
+		return
+	}
+
+	close(c.stopChan)
+	// Closing the WebSocket interrupts the receive loop
+	_ = c.ws.Close()
+
+	log.Debugf("Disconnecting from %s", c)
+	if c.onDisconnectedHandler != nil {
+		c.onDisconnectedHandler()
+	}
+}
+
+// ReceivedMessageCount returns the amount of messages received through the connection
+//
+// This is part of the Connection interface
+func (c *webSocketConnection) ReceivedMessageCount() uint64 {
+	return atomic.LoadUint64(&c.receivedMessageCount)
+}
+
+// SentMessageCount returns the amount of messages sent through the connection
+//
+// This is part of the Connection interface
+func (c *webSocketConnection) SentMessageCount() uint64 {
+	return atomic.LoadUint64(&c.sentMessageCount)
+}
+
+// BytesReceived returns the amount of bytes received through the connection
+//
+// This is part of the Connection interface
+func (c *webSocketConnection) BytesReceived() uint64 {
+	return atomic.LoadUint64(&c.bytesReceived)
+}
+
+// BytesSent returns the amount of bytes sent through the connection
+//
+// This is part of the Connection interface
+func (c *webSocketConnection) BytesSent() uint64 {
+	return atomic.LoadUint64(&c.bytesSent)
+}
+
+// IsReadOnly returns whether the connection is of an RPC client that may only
+// call read-only commands
+//
+// This is part of the Connection interface
+func (c *webSocketConnection) IsReadOnly() bool {
+	return c.isReadOnly
+}
+
+// CredentialID returns the ID of the credential the RPC client of the
+// connection authenticated with, or an empty string if it didn't have to
+//
+// This is part of the Connection interface
+func (c *webSocketConnection) CredentialID() string {
+	return c.credentialID
+}
+
+func (c *webSocketConnection) Address() *net.TCPAddr {
+	return c.address
+}
+
+func (c *webSocketConnection) connectionLoops() error {
+	errChan := make(chan error, 1) // buffered channel because one of the loops might try write after disconnect
+
+	spawn("webSocketConnection.receiveLoop", func() { errChan <- c.receiveLoop() })
+	spawn("webSocketConnection.sendLoop", func() { errChan <- c.sendLoop() })
+
+	err := <-errChan
+
+	c.Disconnect()
+
+	return err
+}
+
+func (c *webSocketConnection) sendLoop() error {
+	outgoingRoute := c.router.OutgoingRoute()
+	for c.IsConnected() {
+		message, err := outgoingRoute.Dequeue()
+		if err != nil {
+			if errors.Is(err, router.ErrRouteClosed) {
+				return nil
+			}
+			return err
+		}
+
+		log.Debugf("outgoing '%s' message to %s", message.Command(), c)
+		log.Tracef("outgoing '%s' message to %s: %s", message.Command(), c, logger.NewLogClosure(func() string {
+			return spew.Sdump(message)
+		}))
+
+		messageProto, err := protowire.FromAppMessage(message)
+		if err != nil {
+			return err
+		}
+		messageJSON, err := protojson.Marshal(messageProto)
+		if err != nil {
+			return err
+		}
+
+		err = websocket.Message.Send(c.ws, string(messageJSON))
+		if err != nil {
+			return err
+		}
+		atomic.AddUint64(&c.sentMessageCount, 1)
+		atomic.AddUint64(&c.bytesSent, uint64(len(messageJSON)))
+	}
+	return nil
+}
+
+func (c *webSocketConnection) receiveLoop() error {
+	messageNumber := uint64(0)
+	for c.IsConnected() {
+		var messageJSON []byte
+		err := websocket.Message.Receive(c.ws, &messageJSON)
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return err
+		}
+		atomic.AddUint64(&c.bytesReceived, uint64(len(messageJSON)))
+
+		protoMessage := &protowire.KaspadMessage{}
+		err = protojson.Unmarshal(messageJSON, protoMessage)
+		if err != nil {
+			err = errors.Wrapf(err, "malformed JSON message")
+			if c.onInvalidMessageHandler != nil {
+				c.onInvalidMessageHandler(err)
+			}
+			return err
+		}
+		message, err := protoMessage.ToAppMessage()
+		if err != nil {
+			if c.onInvalidMessageHandler != nil {
+				c.onInvalidMessageHandler(err)
+			}
+			return err
+		}
+
+		messageNumber++
+		atomic.StoreUint64(&c.receivedMessageCount, messageNumber)
+		message.SetMessageNumber(messageNumber)
+		message.SetReceivedAt(time.Now())
+
+		log.Debugf("incoming '%s' message from %s (message number %d)", message.Command(), c,
+			message.MessageNumber())
+
+		log.Tracef("incoming '%s' message from %s  (message number %d): %s", message.Command(),
+			c, message.MessageNumber(), logger.NewLogClosure(func() string {
+				return spew.Sdump(message)
+			}))
+
+		err = c.router.EnqueueIncomingMessage(message)
+		if err != nil {
+			if errors.Is(err, router.ErrRouteClosed) {
+				return nil
+			}
+
+			// ErrRouteCapacityReached isn't an invalid message error, so
+			// we return it in order to log it later on.
+			if errors.Is(err, router.ErrRouteCapacityReached) {
+				return err
+			}
+			if c.onInvalidMessageHandler != nil {
+				c.onInvalidMessageHandler(err)
+			}
+			return err
+		}
+	}
+	return nil
+}
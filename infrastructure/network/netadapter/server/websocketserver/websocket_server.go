@@ -0,0 +1,201 @@
+package websocketserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/kaspanet/kaspad/util/panics"
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+)
+
+// AuthTokenQueryParameter is the URL query parameter by which WebSocket RPC
+// clients may present their auth token. Browsers can't set the Authorization
+// header of WebSocket requests, so it's accepted as an alternative to it.
+const AuthTokenQueryParameter = "token"
+
+type webSocketServer struct {
+	onConnectedHandler server.OnConnectedHandler
+	listeningAddresses []string
+	authenticate       server.RPCAuthenticator
+	tlsConfig          *tls.Config
+	httpServer         *http.Server
+	name               string
+
+	maxInboundConnections int
+	connections           map[*webSocketConnection]struct{}
+	connectionsLock       sync.Mutex
+}
+
+// NewWebSocketRPCServer creates a new RPC server that serves RPC over
+// WebSocket. Every text message is a single protowire.KaspadMessage, encoded
+// as JSON. If authenticate is not nil, clients must be authenticated by it in
+// order to connect. If tlsConfig is not nil, the server is served over TLS.
+func NewWebSocketRPCServer(name string, listeningAddresses []string, maxInboundConnections int,
+	authenticate server.RPCAuthenticator, tlsConfig *tls.Config) (server.RPCServer, error) {
+
+	s := &webSocketServer{
+		listeningAddresses:    listeningAddresses,
+		authenticate:          authenticate,
+		tlsConfig:             tlsConfig,
+		name:                  name,
+		maxInboundConnections: maxInboundConnections,
+		connections:           make(map[*webSocketConnection]struct{}),
+	}
+	s.httpServer = &http.Server{
+		Handler: websocket.Server{
+			// Any origin is accepted, since serving browser-based
+			// clients of other origins is what this server is for.
+			// Access is restricted by the auth token instead.
+			Handshake: s.handshake,
+			Handler:   s.handleInboundConnection,
+		},
+	}
+	return s, nil
+}
+
+func (s *webSocketServer) Start() error {
+	if s.onConnectedHandler == nil {
+		return errors.New("onConnectedHandler is nil")
+	}
+
+	for _, listenAddress := range s.listeningAddresses {
+		listener, err := net.Listen("tcp", listenAddress)
+		if err != nil {
+			return errors.Wrapf(err, "%s error listening on %s", s.name, listenAddress)
+		}
+		if s.tlsConfig != nil {
+			listener = tls.NewListener(listener, s.tlsConfig)
+		}
+		s.serve(listener, listenAddress)
+	}
+	return nil
+}
+
+func (s *webSocketServer) serve(listener net.Listener, listenAddress string) {
+	spawn(fmt.Sprintf("%s.webSocketServer.listenOn-Serve", s.name), func() {
+		err := s.httpServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panics.Exit(log, fmt.Sprintf("error serving %s on %s: %+v", s.name, listenAddress, err))
+		}
+	})
+
+	log.Infof("%s Server listening on %s", s.name, listener.Addr())
+}
+
+// Stop stops listening and disconnects all the connected clients, since
+// WebSocket connections outlive the HTTP server that accepted them
+func (s *webSocketServer) Stop() error {
+	err := s.httpServer.Close()
+	if err != nil {
+		return err
+	}
+
+	s.connectionsLock.Lock()
+	connections := make([]*webSocketConnection, 0, len(s.connections))
+	for connection := range s.connections {
+		connections = append(connections, connection)
+	}
+	s.connectionsLock.Unlock()
+
+	for _, connection := range connections {
+		connection.Disconnect()
+	}
+	return nil
+}
+
+// SetOnConnectedHandler sets the peer connected handler
+// function for the server
+func (s *webSocketServer) SetOnConnectedHandler(onConnectedHandler server.OnConnectedHandler) {
+	s.onConnectedHandler = onConnectedHandler
+}
+
+// SetIsSynced does nothing, since the health of the node is reported only
+// by the gRPC health service
+func (s *webSocketServer) SetIsSynced(bool) {}
+
+// SetIsNetworkDegraded does nothing, since the health of the node is
+// reported only by the gRPC health service
+func (s *webSocketServer) SetIsNetworkDegraded(bool) {}
+
+func (s *webSocketServer) handshake(_ *websocket.Config, request *http.Request) error {
+	isAuthorized, _, _ := s.authenticateRequest(request)
+	if !isAuthorized {
+		log.Warnf("%s rejected a connection from %s with missing or invalid credentials",
+			s.name, request.RemoteAddr)
+		return errors.New("missing or invalid credentials")
+	}
+	return nil
+}
+
+// authenticateRequest authenticates the client that made the given request by
+// its Authorization headers and its auth token query parameters
+func (s *webSocketServer) authenticateRequest(request *http.Request) (
+	isAuthorized bool, isReadOnly bool, credentialID string) {
+
+	if s.authenticate == nil {
+		return true, false, ""
+	}
+	authorizations := request.Header.Values("Authorization")
+	for _, token := range request.URL.Query()[AuthTokenQueryParameter] {
+		authorizations = append(authorizations, "Bearer "+token)
+	}
+	return s.authenticate(authorizations)
+}
+
+func (s *webSocketServer) handleInboundConnection(ws *websocket.Conn) {
+	defer panics.HandlePanic(log, "webSocketServer.handleInboundConnection", nil)
+
+	address, err := net.ResolveTCPAddr("tcp", ws.Request().RemoteAddr)
+	if err != nil {
+		log.Warnf("%s rejected a connection from %s: %s", s.name, ws.Request().RemoteAddr, err)
+		return
+	}
+	ws.PayloadType = websocket.TextFrame
+	ws.MaxPayloadBytes = grpcserver.RPCMaxMessageSize
+	// The handshake already made sure that the client is authorized
+	_, isReadOnly, credentialID := s.authenticateRequest(ws.Request())
+	connection := newConnection(address, ws, isReadOnly, credentialID)
+
+	connectionCount, err := s.addConnection(connection)
+	if err != nil {
+		log.Warnf("%s rejected a connection from %s: %s", s.name, address, err)
+		return
+	}
+	defer s.removeConnection(connection)
+
+	err = s.onConnectedHandler(connection)
+	if err != nil {
+		log.Warnf("%s rejected a connection from %s: %s", s.name, address, err)
+		return
+	}
+
+	log.Infof("%s Incoming connection from %s #%d", s.name, address, connectionCount)
+
+	// The WebSocket is closed once this handler returns
+	<-connection.stopChan
+}
+
+func (s *webSocketServer) addConnection(connection *webSocketConnection) (int, error) {
+	s.connectionsLock.Lock()
+	defer s.connectionsLock.Unlock()
+
+	if s.maxInboundConnections > 0 && len(s.connections) == s.maxInboundConnections {
+		return len(s.connections), errors.Errorf("limit of %d %s inbound connections has been exceeded",
+			s.maxInboundConnections, s.name)
+	}
+	s.connections[connection] = struct{}{}
+	return len(s.connections), nil
+}
+
+func (s *webSocketServer) removeConnection(connection *webSocketConnection) {
+	s.connectionsLock.Lock()
+	defer s.connectionsLock.Unlock()
+
+	delete(s.connections, connection)
+}
@@ -2,13 +2,17 @@ package grpcclient
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/base64"
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
 	"io"
 	"time"
 )
@@ -27,23 +31,88 @@ type GRPCClient struct {
 	onDisconnectedHandler OnDisconnectedHandler
 }
 
+// ConnectOptions are the options of connecting to an RPC server
+type ConnectOptions struct {
+	// Authorization is presented to the RPC server as the value of the
+	// Authorization header, unless it is empty. See AuthTokenAuthorization
+	// and BasicAuthorization.
+	Authorization string
+
+	// TLSConfig is used to connect to the RPC server over TLS, unless it is
+	// nil
+	TLSConfig *tls.Config
+}
+
+// AuthTokenAuthorization returns the Authorization header value that presents
+// the given auth token
+func AuthTokenAuthorization(authToken string) string {
+	return "Bearer " + authToken
+}
+
+// BasicAuthorization returns the Authorization header value that presents the
+// given user and password
+func BasicAuthorization(user, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+}
+
 // Connect connects to the RPC server with the given address
 func Connect(address string) (*GRPCClient, error) {
+	return ConnectWithOptions(address, &ConnectOptions{})
+}
+
+// ConnectWithAuthToken connects to the RPC server with the given address,
+// presenting the given auth token if it is not empty
+func ConnectWithAuthToken(address string, authToken string) (*GRPCClient, error) {
+	options := &ConnectOptions{}
+	if authToken != "" {
+		options.Authorization = AuthTokenAuthorization(authToken)
+	}
+	return ConnectWithOptions(address, options)
+}
+
+// ConnectWithOptions connects to the RPC server with the given address and
+// options
+func ConnectWithOptions(address string, options *ConnectOptions) (*GRPCClient, error) {
 	const dialTimeout = 5 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
-	gRPCConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	transportOption := grpc.WithInsecure()
+	if options.TLSConfig != nil {
+		transportOption = grpc.WithTransportCredentials(credentials.NewTLS(options.TLSConfig))
+	}
+	gRPCConnection, err := grpc.DialContext(ctx, address, transportOption, grpc.WithBlock())
 	if err != nil {
 		return nil, errors.Wrapf(err, "error connecting to %s", address)
 	}
 
+	streamContext := context.Background()
+	if options.Authorization != "" {
+		streamContext = metadata.AppendToOutgoingContext(streamContext,
+			grpcserver.AuthorizationMetadataKey, options.Authorization)
+	}
 	grpcClient := protowire.NewRPCClient(gRPCConnection)
-	stream, err := grpcClient.MessageStream(context.Background(), grpc.UseCompressor(gzip.Name),
+	stream, err := grpcClient.MessageStream(streamContext, grpc.UseCompressor(gzip.Name),
 		grpc.MaxCallRecvMsgSize(grpcserver.RPCMaxMessageSize), grpc.MaxCallSendMsgSize(grpcserver.RPCMaxMessageSize))
 	if err != nil {
 		return nil, errors.Wrapf(err, "error getting client stream for %s", address)
 	}
+	if options.Authorization != "" {
+		// Wait for the server to accept the credentials, rather than failing
+		// only once the first request times out
+		header, err := stream.Header()
+		if err == nil && len(header.Get(grpcserver.AuthorizedMetadataKey)) == 0 {
+			// The server rejected the stream without sending any headers, in
+			// which case the status error is returned from Recv
+			_, err = stream.Recv()
+			if err == nil {
+				err = errors.New("the server did not accept the credentials")
+			}
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "error authenticating to %s", address)
+		}
+	}
 	return &GRPCClient{stream: stream, connection: gRPCConnection}, nil
 }
 
@@ -91,6 +160,9 @@ func (c *GRPCClient) AttachRouter(router *router.Router) {
 				c.handleError(err)
 				return
 			}
+			for _, warning := range message.Warnings() {
+				log.Warnf("%s: %s", message.Command(), warning)
+			}
 			err = router.EnqueueIncomingMessage(message)
 			if err != nil {
 				c.handleError(err)
@@ -100,6 +172,13 @@ func (c *GRPCClient) AttachRouter(router *router.Router) {
 	})
 }
 
+// SendAppMessage sends the given message to the server, without waiting for
+// any response. It's meant for clients that only ever send messages, and
+// mustn't be used along with a router.
+func (c *GRPCClient) SendAppMessage(message appmessage.Message) error {
+	return c.send(message)
+}
+
 func (c *GRPCClient) send(requestAppMessage appmessage.Message) error {
 	request, err := protowire.FromAppMessage(requestAppMessage)
 	if err != nil {
@@ -0,0 +1,22 @@
+package rpcclient
+
+import (
+	"time"
+)
+
+// NotificationOptions are the options of a subscription to blockAdded or
+// virtualSelectedParentChainChanged notifications
+type NotificationOptions struct {
+	// IncludeSnapshot asks the node for a snapshot of the DAG, which is
+	// taken as the subscription starts
+	IncludeSnapshot bool
+
+	// CoalesceInterval, if not zero, asks the node to coalesce the
+	// notifications of the events that happen within it. The handler is
+	// still called once per block or chain change.
+	CoalesceInterval time.Duration
+}
+
+func (options *NotificationOptions) coalesceIntervalInMilliseconds() uint32 {
+	return uint32(options.CoalesceInterval.Milliseconds())
+}
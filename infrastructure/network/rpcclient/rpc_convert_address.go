@@ -0,0 +1,23 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// ConvertAddress sends an RPC request respective to the function's name and returns the RPC server's response.
+// Exactly one of address and scriptPublicKey must be set.
+func (c *RPCClient) ConvertAddress(address string, scriptPublicKey *appmessage.RPCScriptPublicKey) (
+	*appmessage.ConvertAddressResponseMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewConvertAddressRequestMessage(address, scriptPublicKey))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdConvertAddressResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	convertAddressResponse := response.(*appmessage.ConvertAddressResponseMessage)
+	if convertAddressResponse.Error != nil {
+		return nil, c.convertRPCError(convertAddressResponse.Error)
+	}
+	return convertAddressResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// DeriveAddressFromPublicKey sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) DeriveAddressFromPublicKey(publicKey string) (*appmessage.DeriveAddressFromPublicKeyResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewDeriveAddressFromPublicKeyRequestMessage(publicKey))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdDeriveAddressFromPublicKeyResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	deriveAddressFromPublicKeyResponse := response.(*appmessage.DeriveAddressFromPublicKeyResponseMessage)
+	if deriveAddressFromPublicKeyResponse.Error != nil {
+		return nil, c.convertRPCError(deriveAddressFromPublicKeyResponse.Error)
+	}
+	return deriveAddressFromPublicKeyResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// DisconnectRPCSession sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) DisconnectRPCSession(sessionID uint64) (*appmessage.DisconnectRPCSessionResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewDisconnectRPCSessionRequestMessage(sessionID))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdDisconnectRPCSessionResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	disconnectRPCSessionResponse := response.(*appmessage.DisconnectRPCSessionResponseMessage)
+	if disconnectRPCSessionResponse.Error != nil {
+		return nil, c.convertRPCError(disconnectRPCSessionResponse.Error)
+	}
+	return disconnectRPCSessionResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// EstimateFee sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) EstimateFee(targetDAAScore uint64) (*appmessage.EstimateFeeResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewEstimateFeeRequestMessage(targetDAAScore))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdEstimateFeeResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	estimateFeeResponse := response.(*appmessage.EstimateFeeResponseMessage)
+	if estimateFeeResponse.Error != nil {
+		return nil, c.convertRPCError(estimateFeeResponse.Error)
+	}
+	return estimateFeeResponse, nil
+}
@@ -0,0 +1,22 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// ExportMempoolSnapshot sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) ExportMempoolSnapshot(path string, includeOrphanPool bool) (
+	*appmessage.ExportMempoolSnapshotResponseMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewExportMempoolSnapshotRequestMessage(path, includeOrphanPool))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdExportMempoolSnapshotResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	exportMempoolSnapshotResponse := response.(*appmessage.ExportMempoolSnapshotResponseMessage)
+	if exportMempoolSnapshotResponse.Error != nil {
+		return nil, c.convertRPCError(exportMempoolSnapshotResponse.Error)
+	}
+	return exportMempoolSnapshotResponse, nil
+}
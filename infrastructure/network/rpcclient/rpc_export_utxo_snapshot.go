@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// ExportUTXOSnapshot sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) ExportUTXOSnapshot(path string) (*appmessage.ExportUTXOSnapshotResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewExportUTXOSnapshotRequestMessage(path))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdExportUTXOSnapshotResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	exportUTXOSnapshotResponse := response.(*appmessage.ExportUTXOSnapshotResponseMessage)
+	if exportUTXOSnapshotResponse.Error != nil {
+		return nil, c.convertRPCError(exportUTXOSnapshotResponse.Error)
+	}
+	return exportUTXOSnapshotResponse, nil
+}
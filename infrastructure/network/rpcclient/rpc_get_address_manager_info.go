@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetAddressManagerInfo sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetAddressManagerInfo(sampleSize uint32) (*appmessage.GetAddressManagerInfoResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetAddressManagerInfoRequestMessage(sampleSize))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetAddressManagerInfoResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getAddressManagerInfoResponse := response.(*appmessage.GetAddressManagerInfoResponseMessage)
+	if getAddressManagerInfoResponse.Error != nil {
+		return nil, c.convertRPCError(getAddressManagerInfoResponse.Error)
+	}
+	return getAddressManagerInfoResponse, nil
+}
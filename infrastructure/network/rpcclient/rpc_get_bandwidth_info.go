@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetBandwidthInfo sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetBandwidthInfo() (*appmessage.GetBandwidthInfoResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetBandwidthInfoRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetBandwidthInfoResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getBandwidthInfoResponse := response.(*appmessage.GetBandwidthInfoResponseMessage)
+	if getBandwidthInfoResponse.Error != nil {
+		return nil, c.convertRPCError(getBandwidthInfoResponse.Error)
+	}
+	return getBandwidthInfoResponse, nil
+}
@@ -6,8 +6,19 @@ import "github.com/kaspanet/kaspad/app/appmessage"
 func (c *RPCClient) GetBlock(hash string, includeTransactions bool) (
 	*appmessage.GetBlockResponseMessage, error) {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(
-		appmessage.NewGetBlockRequestMessage(hash, includeTransactions))
+	return c.getBlock(appmessage.NewGetBlockRequestMessage(hash, includeTransactions))
+}
+
+// GetBlockWithVerbosity sends a GetBlock RPC request with the given verbosity
+// and returns the RPC server's response
+func (c *RPCClient) GetBlockWithVerbosity(hash string, verbosity appmessage.RPCVerbosity) (
+	*appmessage.GetBlockResponseMessage, error) {
+
+	return c.getBlock(&appmessage.GetBlockRequestMessage{Hash: hash, Verbosity: verbosity})
+}
+
+func (c *RPCClient) getBlock(request *appmessage.GetBlockRequestMessage) (*appmessage.GetBlockResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(request)
 	if err != nil {
 		return nil, err
 	}
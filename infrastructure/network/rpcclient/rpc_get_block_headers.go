@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetBlockHeaders sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetBlockHeaders(lowHash string, count uint64) (*appmessage.GetBlockHeadersResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetBlockHeadersRequestMessage(lowHash, count))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetBlockHeadersResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getBlockHeadersResponse := response.(*appmessage.GetBlockHeadersResponseMessage)
+	if getBlockHeadersResponse.Error != nil {
+		return nil, c.convertRPCError(getBlockHeadersResponse.Error)
+	}
+	return getBlockHeadersResponse, nil
+}
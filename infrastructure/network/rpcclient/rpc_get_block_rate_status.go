@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetBlockRateStatus sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetBlockRateStatus() (*appmessage.GetBlockRateStatusResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetBlockRateStatusRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetBlockRateStatusResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getBlockRateStatusResponse := response.(*appmessage.GetBlockRateStatusResponseMessage)
+	if getBlockRateStatusResponse.Error != nil {
+		return nil, c.convertRPCError(getBlockRateStatusResponse.Error)
+	}
+	return getBlockRateStatusResponse, nil
+}
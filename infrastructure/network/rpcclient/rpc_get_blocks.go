@@ -6,8 +6,28 @@ import "github.com/kaspanet/kaspad/app/appmessage"
 func (c *RPCClient) GetBlocks(lowHash string, includeBlocks bool,
 	includeTransactions bool) (*appmessage.GetBlocksResponseMessage, error) {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(
-		appmessage.NewGetBlocksRequestMessage(lowHash, includeBlocks, includeTransactions))
+	return c.getBlocks(appmessage.NewGetBlocksRequestMessage(lowHash, includeBlocks, includeTransactions))
+}
+
+// GetBlocksWithVerbosity sends a GetBlocks RPC request with the given verbosity
+// and returns the RPC server's response
+func (c *RPCClient) GetBlocksWithVerbosity(lowHash string, verbosity appmessage.RPCVerbosity) (
+	*appmessage.GetBlocksResponseMessage, error) {
+
+	return c.getBlocks(&appmessage.GetBlocksRequestMessage{LowHash: lowHash, Verbosity: verbosity})
+}
+
+// GetBlocksPage sends a GetBlocks RPC request for at most limit blocks, with
+// the given verbosity, and returns the RPC server's response. The following
+// page is requested from the returned NextLowHash, while HasMore is set.
+func (c *RPCClient) GetBlocksPage(lowHash string, verbosity appmessage.RPCVerbosity, limit uint32) (
+	*appmessage.GetBlocksResponseMessage, error) {
+
+	return c.getBlocks(&appmessage.GetBlocksRequestMessage{LowHash: lowHash, Verbosity: verbosity, Limit: limit})
+}
+
+func (c *RPCClient) getBlocks(request *appmessage.GetBlocksRequestMessage) (*appmessage.GetBlocksResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(request)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetChainChangedEventsFromBlock sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetChainChangedEventsFromBlock(startHash string, limit uint32) (*appmessage.GetChainChangedEventsFromBlockResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetChainChangedEventsFromBlockRequestMessage(startHash, limit))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetChainChangedEventsFromBlockResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getChainChangedEventsFromBlockResponse := response.(*appmessage.GetChainChangedEventsFromBlockResponseMessage)
+	if getChainChangedEventsFromBlockResponse.Error != nil {
+		return nil, c.convertRPCError(getChainChangedEventsFromBlockResponse.Error)
+	}
+	return getChainChangedEventsFromBlockResponse, nil
+}
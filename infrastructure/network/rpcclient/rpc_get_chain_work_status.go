@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetChainWorkStatus sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetChainWorkStatus() (*appmessage.GetChainWorkStatusResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetChainWorkStatusRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetChainWorkStatusResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getChainWorkStatusResponse := response.(*appmessage.GetChainWorkStatusResponseMessage)
+	if getChainWorkStatusResponse.Error != nil {
+		return nil, c.convertRPCError(getChainWorkStatusResponse.Error)
+	}
+	return getChainWorkStatusResponse, nil
+}
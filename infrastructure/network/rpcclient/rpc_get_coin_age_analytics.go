@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetCoinAgeAnalytics sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetCoinAgeAnalytics(window uint32) (*appmessage.GetCoinAgeAnalyticsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetCoinAgeAnalyticsRequestMessage(window))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetCoinAgeAnalyticsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getCoinAgeAnalyticsResponse := response.(*appmessage.GetCoinAgeAnalyticsResponseMessage)
+	if getCoinAgeAnalyticsResponse.Error != nil {
+		return nil, c.convertRPCError(getCoinAgeAnalyticsResponse.Error)
+	}
+	return getCoinAgeAnalyticsResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetDAGTips sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetDAGTips() (*appmessage.GetDAGTipsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetDAGTipsRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetDAGTipsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getDAGTipsResponse := response.(*appmessage.GetDAGTipsResponseMessage)
+	if getDAGTipsResponse.Error != nil {
+		return nil, c.convertRPCError(getDAGTipsResponse.Error)
+	}
+	return getDAGTipsResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetEffectiveConfig sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetEffectiveConfig() (*appmessage.GetEffectiveConfigResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetEffectiveConfigRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetEffectiveConfigResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getEffectiveConfigResponse := response.(*appmessage.GetEffectiveConfigResponseMessage)
+	if getEffectiveConfigResponse.Error != nil {
+		return nil, c.convertRPCError(getEffectiveConfigResponse.Error)
+	}
+	return getEffectiveConfigResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetFeeHistory sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetFeeHistory(window uint32) (*appmessage.GetFeeHistoryResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetFeeHistoryRequestMessage(window))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetFeeHistoryResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getFeeHistoryResponse := response.(*appmessage.GetFeeHistoryResponseMessage)
+	if getFeeHistoryResponse.Error != nil {
+		return nil, c.convertRPCError(getFeeHistoryResponse.Error)
+	}
+	return getFeeHistoryResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetHeadersSelectedTip sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetHeadersSelectedTip() (*appmessage.GetHeadersSelectedTipResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetHeadersSelectedTipRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetHeadersSelectedTipResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getHeadersSelectedTipResponse := response.(*appmessage.GetHeadersSelectedTipResponseMessage)
+	if getHeadersSelectedTipResponse.Error != nil {
+		return nil, c.convertRPCError(getHeadersSelectedTipResponse.Error)
+	}
+	return getHeadersSelectedTipResponse, nil
+}
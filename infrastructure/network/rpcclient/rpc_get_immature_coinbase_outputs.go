@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetImmatureCoinbaseOutputs sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetImmatureCoinbaseOutputs(address string) (*appmessage.GetImmatureCoinbaseOutputsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetImmatureCoinbaseOutputsRequestMessage(address))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetImmatureCoinbaseOutputsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getImmatureCoinbaseOutputsResponse := response.(*appmessage.GetImmatureCoinbaseOutputsResponseMessage)
+	if getImmatureCoinbaseOutputsResponse.Error != nil {
+		return nil, c.convertRPCError(getImmatureCoinbaseOutputsResponse.Error)
+	}
+	return getImmatureCoinbaseOutputsResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetIndexRetentionStatus sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetIndexRetentionStatus() (*appmessage.GetIndexRetentionStatusResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetIndexRetentionStatusRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetIndexRetentionStatusResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getIndexRetentionStatusResponse := response.(*appmessage.GetIndexRetentionStatusResponseMessage)
+	if getIndexRetentionStatusResponse.Error != nil {
+		return nil, c.convertRPCError(getIndexRetentionStatusResponse.Error)
+	}
+	return getIndexRetentionStatusResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetIndexStatus sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetIndexStatus(indexName string) (*appmessage.GetIndexStatusResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetIndexStatusRequestMessage(indexName))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetIndexStatusResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getIndexStatusResponse := response.(*appmessage.GetIndexStatusResponseMessage)
+	if getIndexStatusResponse.Error != nil {
+		return nil, c.convertRPCError(getIndexStatusResponse.Error)
+	}
+	return getIndexStatusResponse, nil
+}
@@ -3,8 +3,10 @@ package rpcclient
 import "github.com/kaspanet/kaspad/app/appmessage"
 
 // GetMempoolEntries sends an RPC request respective to the function's name and returns the RPC server's response
-func (c *RPCClient) GetMempoolEntries(includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntriesResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetMempoolEntriesRequestMessage(includeOrphanPool, filterTransactionPool))
+func (c *RPCClient) GetMempoolEntries(includeOrphanPool bool, filterTransactionPool bool,
+	verbose bool) (*appmessage.GetMempoolEntriesResponseMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetMempoolEntriesRequestMessage(includeOrphanPool, filterTransactionPool, verbose))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,23 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetMempoolGraph sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetMempoolGraph(transactionIDs []string, includeOrphanPool bool,
+	includeAncestry bool) (*appmessage.GetMempoolGraphResponseMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(
+		appmessage.NewGetMempoolGraphRequestMessage(transactionIDs, includeOrphanPool, includeAncestry))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetMempoolGraphResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getMempoolGraphResponse := response.(*appmessage.GetMempoolGraphResponseMessage)
+	if getMempoolGraphResponse.Error != nil {
+		return nil, c.convertRPCError(getMempoolGraphResponse.Error)
+	}
+	return getMempoolGraphResponse, nil
+}
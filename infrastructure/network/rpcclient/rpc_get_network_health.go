@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetNetworkHealth sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetNetworkHealth() (*appmessage.GetNetworkHealthResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetNetworkHealthRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetNetworkHealthResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getNetworkHealthResponse := response.(*appmessage.GetNetworkHealthResponseMessage)
+	if getNetworkHealthResponse.Error != nil {
+		return nil, c.convertRPCError(getNetworkHealthResponse.Error)
+	}
+	return getNetworkHealthResponse, nil
+}
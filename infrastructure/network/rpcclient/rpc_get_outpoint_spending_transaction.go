@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetOutpointSpendingTransaction sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetOutpointSpendingTransaction(outpoint *appmessage.RPCOutpoint) (*appmessage.GetOutpointSpendingTransactionResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetOutpointSpendingTransactionRequestMessage(outpoint))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetOutpointSpendingTransactionResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getOutpointSpendingTransactionResponse := response.(*appmessage.GetOutpointSpendingTransactionResponseMessage)
+	if getOutpointSpendingTransactionResponse.Error != nil {
+		return nil, c.convertRPCError(getOutpointSpendingTransactionResponse.Error)
+	}
+	return getOutpointSpendingTransactionResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetPayoutStats sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetPayoutStats(recentBlockCount uint32) (*appmessage.GetPayoutStatsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetPayoutStatsRequestMessage(recentBlockCount))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetPayoutStatsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getPayoutStatsResponse := response.(*appmessage.GetPayoutStatsResponseMessage)
+	if getPayoutStatsResponse.Error != nil {
+		return nil, c.convertRPCError(getPayoutStatsResponse.Error)
+	}
+	return getPayoutStatsResponse, nil
+}
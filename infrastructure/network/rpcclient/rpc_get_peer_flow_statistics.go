@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetPeerFlowStatistics sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetPeerFlowStatistics() (*appmessage.GetPeerFlowStatisticsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetPeerFlowStatisticsRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetPeerFlowStatisticsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getPeerFlowStatisticsResponse := response.(*appmessage.GetPeerFlowStatisticsResponseMessage)
+	if getPeerFlowStatisticsResponse.Error != nil {
+		return nil, c.convertRPCError(getPeerFlowStatisticsResponse.Error)
+	}
+	return getPeerFlowStatisticsResponse, nil
+}
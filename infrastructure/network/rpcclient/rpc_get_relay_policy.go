@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetRelayPolicy sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetRelayPolicy() (*appmessage.GetRelayPolicyResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetRelayPolicyRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetRelayPolicyResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getRelayPolicyResponse := response.(*appmessage.GetRelayPolicyResponseMessage)
+	if getRelayPolicyResponse.Error != nil {
+		return nil, c.convertRPCError(getRelayPolicyResponse.Error)
+	}
+	return getRelayPolicyResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetReorgedTransactionsStats sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetReorgedTransactionsStats() (*appmessage.GetReorgedTransactionsStatsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetReorgedTransactionsStatsRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetReorgedTransactionsStatsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getReorgedTransactionsStatsResponse := response.(*appmessage.GetReorgedTransactionsStatsResponseMessage)
+	if getReorgedTransactionsStatsResponse.Error != nil {
+		return nil, c.convertRPCError(getReorgedTransactionsStatsResponse.Error)
+	}
+	return getReorgedTransactionsStatsResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetRPCSessions sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetRPCSessions() (*appmessage.GetRPCSessionsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetRPCSessionsRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetRPCSessionsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getRPCSessionsResponse := response.(*appmessage.GetRPCSessionsResponseMessage)
+	if getRPCSessionsResponse.Error != nil {
+		return nil, c.convertRPCError(getRPCSessionsResponse.Error)
+	}
+	return getRPCSessionsResponse, nil
+}
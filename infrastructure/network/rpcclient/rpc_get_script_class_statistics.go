@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetScriptClassStatistics sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetScriptClassStatistics(startBlueScore uint64, endBlueScore uint64) (*appmessage.GetScriptClassStatisticsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetScriptClassStatisticsRequestMessage(startBlueScore, endBlueScore))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetScriptClassStatisticsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getScriptClassStatisticsResponse := response.(*appmessage.GetScriptClassStatisticsResponseMessage)
+	if getScriptClassStatisticsResponse.Error != nil {
+		return nil, c.convertRPCError(getScriptClassStatisticsResponse.Error)
+	}
+	return getScriptClassStatisticsResponse, nil
+}
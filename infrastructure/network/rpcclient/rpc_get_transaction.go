@@ -0,0 +1,34 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetTransaction sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetTransaction(transactionID string) (*appmessage.GetTransactionResponseMessage, error) {
+	return c.getTransaction(appmessage.NewGetTransactionRequestMessage(transactionID))
+}
+
+// GetTransactionWithVerbosity sends a GetTransaction RPC request with the given
+// verbosity and returns the RPC server's response
+func (c *RPCClient) GetTransactionWithVerbosity(transactionID string, verbosity appmessage.RPCVerbosity) (
+	*appmessage.GetTransactionResponseMessage, error) {
+
+	return c.getTransaction(&appmessage.GetTransactionRequestMessage{TransactionID: transactionID, Verbosity: verbosity})
+}
+
+func (c *RPCClient) getTransaction(request *appmessage.GetTransactionRequestMessage) (
+	*appmessage.GetTransactionResponseMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(request)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetTransactionResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getTransactionResponse := response.(*appmessage.GetTransactionResponseMessage)
+	if getTransactionResponse.Error != nil {
+		return nil, c.convertRPCError(getTransactionResponse.Error)
+	}
+	return getTransactionResponse, nil
+}
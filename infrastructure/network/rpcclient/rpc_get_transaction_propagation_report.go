@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetTransactionPropagationReport sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetTransactionPropagationReport(transactionID string) (*appmessage.GetTransactionPropagationReportResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetTransactionPropagationReportRequestMessage(transactionID))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetTransactionPropagationReportResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getTransactionPropagationReportResponse := response.(*appmessage.GetTransactionPropagationReportResponseMessage)
+	if getTransactionPropagationReportResponse.Error != nil {
+		return nil, c.convertRPCError(getTransactionPropagationReportResponse.Error)
+	}
+	return getTransactionPropagationReportResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetUTXO sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetUTXO(outpoint *appmessage.RPCOutpoint, includeMempool bool) (*appmessage.GetUTXOResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetUTXORequestMessage(outpoint, includeMempool))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetUTXOResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getUTXOResponse := response.(*appmessage.GetUTXOResponseMessage)
+	if getUTXOResponse.Error != nil {
+		return nil, c.convertRPCError(getUTXOResponse.Error)
+	}
+	return getUTXOResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// NegotiateAPIVersion sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) NegotiateAPIVersion(version uint32) (*appmessage.NegotiateAPIVersionResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNegotiateAPIVersionRequestMessage(version))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdNegotiateAPIVersionResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	negotiateAPIVersionResponse := response.(*appmessage.NegotiateAPIVersionResponseMessage)
+	if negotiateAPIVersionResponse.Error != nil {
+		return nil, c.convertRPCError(negotiateAPIVersionResponse.Error)
+	}
+	return negotiateAPIVersionResponse, nil
+}
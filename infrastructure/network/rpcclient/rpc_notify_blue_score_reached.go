@@ -0,0 +1,22 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// NotifyBlueScoreReached sends an RPC request respective to the function's name and returns the RPC server's response
+// The notification is delivered to the handler given to RegisterForBlueScoreReachedNotifications, which
+// must be called beforehand
+func (c *RPCClient) NotifyBlueScoreReached(id string, blueScore uint64, blockHash string, confirmations uint64) (*appmessage.NotifyBlueScoreReachedResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyBlueScoreReachedRequestMessage(id, blueScore, blockHash, confirmations))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdNotifyBlueScoreReachedResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	notifyBlueScoreReachedResponse := response.(*appmessage.NotifyBlueScoreReachedResponseMessage)
+	if notifyBlueScoreReachedResponse.Error != nil {
+		return nil, c.convertRPCError(notifyBlueScoreReachedResponse.Error)
+	}
+	return notifyBlueScoreReachedResponse, nil
+}
@@ -9,20 +9,49 @@ import (
 // RegisterForBlockAddedNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
 // Additionally, it starts listening for the appropriate notification using the given handler function
 func (c *RPCClient) RegisterForBlockAddedNotifications(onBlockAdded func(notification *appmessage.BlockAddedNotificationMessage)) error {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyBlockAddedRequestMessage())
+	_, err := c.RegisterForBlockAddedNotificationsWithOptions(&NotificationOptions{}, onBlockAdded)
+	return err
+}
+
+// RegisterForBlockAddedNotificationsWithOptions is like RegisterForBlockAddedNotifications, but with the given options.
+// It returns the snapshot of the DAG if options.IncludeSnapshot is set, or nil otherwise.
+func (c *RPCClient) RegisterForBlockAddedNotificationsWithOptions(options *NotificationOptions,
+	onBlockAdded func(notification *appmessage.BlockAddedNotificationMessage)) (
+	*appmessage.DAGSnapshotNotificationMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyBlockAddedRequestMessage(
+		options.IncludeSnapshot, options.coalesceIntervalInMilliseconds()))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	response, err := c.route(appmessage.CmdNotifyBlockAddedResponseMessage).DequeueWithTimeout(c.timeout)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	notifyBlockAddedResponse := response.(*appmessage.NotifyBlockAddedResponseMessage)
 	if notifyBlockAddedResponse.Error != nil {
-		return c.convertRPCError(notifyBlockAddedResponse.Error)
+		return nil, c.convertRPCError(notifyBlockAddedResponse.Error)
+	}
+	snapshot, err := c.dequeueDAGSnapshot(options)
+	if err != nil {
+		return nil, err
 	}
 	spawn("RegisterForBlockAddedNotifications", func() {
 		for {
+			if options.CoalesceInterval > 0 {
+				notification, err := c.route(appmessage.CmdBlockAddedBatchNotificationMessage).Dequeue()
+				if err != nil {
+					if errors.Is(err, routerpkg.ErrRouteClosed) {
+						break
+					}
+					panic(err)
+				}
+				blockAddedBatchNotification := notification.(*appmessage.BlockAddedBatchNotificationMessage)
+				for _, block := range blockAddedBatchNotification.Blocks {
+					onBlockAdded(appmessage.NewBlockAddedNotificationMessage(block))
+				}
+				continue
+			}
 			notification, err := c.route(appmessage.CmdBlockAddedNotificationMessage).Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
@@ -34,5 +63,19 @@ func (c *RPCClient) RegisterForBlockAddedNotifications(onBlockAdded func(notific
 			onBlockAdded(blockAddedNotification)
 		}
 	})
-	return nil
+	return snapshot, nil
+}
+
+// dequeueDAGSnapshot returns the snapshot the node sent along with a
+// subscription with the given options, if they include a snapshot. The node
+// sends it before it responds to the subscription request.
+func (c *RPCClient) dequeueDAGSnapshot(options *NotificationOptions) (*appmessage.DAGSnapshotNotificationMessage, error) {
+	if !options.IncludeSnapshot {
+		return nil, nil
+	}
+	notification, err := c.route(appmessage.CmdDAGSnapshotNotificationMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	return notification.(*appmessage.DAGSnapshotNotificationMessage), nil
 }
@@ -0,0 +1,26 @@
+package rpcclient
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// RegisterForBlueScoreReachedNotifications starts listening for BlueScoreReached notifications using
+// the given handler function. Unlike other notifications, they're not sent until scheduled one by one
+// with NotifyBlueScoreReached, so no request is sent to the RPC server.
+func (c *RPCClient) RegisterForBlueScoreReachedNotifications(onBlueScoreReached func(notification *appmessage.BlueScoreReachedNotificationMessage)) {
+	spawn("RegisterForBlueScoreReachedNotifications", func() {
+		for {
+			notification, err := c.route(appmessage.CmdBlueScoreReachedNotificationMessage).Dequeue()
+			if err != nil {
+				if errors.Is(err, routerpkg.ErrRouteClosed) {
+					break
+				}
+				panic(err)
+			}
+			blueScoreReachedNotification := notification.(*appmessage.BlueScoreReachedNotificationMessage)
+			onBlueScoreReached(blueScoreReachedNotification)
+		}
+	})
+}
@@ -11,18 +11,35 @@ import (
 func (c *RPCClient) RegisterForVirtualSelectedParentChainChangedNotifications(includeAcceptedTransactionIDs bool,
 	onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(
-		appmessage.NewNotifyVirtualSelectedParentChainChangedRequestMessage(includeAcceptedTransactionIDs))
+	_, err := c.RegisterForVirtualSelectedParentChainChangedNotificationsWithOptions(includeAcceptedTransactionIDs,
+		&NotificationOptions{}, onChainChanged)
+	return err
+}
+
+// RegisterForVirtualSelectedParentChainChangedNotificationsWithOptions is like
+// RegisterForVirtualSelectedParentChainChangedNotifications, but with the given options.
+// It returns the snapshot of the DAG if options.IncludeSnapshot is set, or nil otherwise.
+func (c *RPCClient) RegisterForVirtualSelectedParentChainChangedNotificationsWithOptions(includeAcceptedTransactionIDs bool,
+	options *NotificationOptions,
+	onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) (
+	*appmessage.DAGSnapshotNotificationMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyVirtualSelectedParentChainChangedRequestMessage(
+		includeAcceptedTransactionIDs, options.IncludeSnapshot, options.coalesceIntervalInMilliseconds()))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	response, err := c.route(appmessage.CmdNotifyVirtualSelectedParentChainChangedResponseMessage).DequeueWithTimeout(c.timeout)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	notifyChainChangedResponse := response.(*appmessage.NotifyVirtualSelectedParentChainChangedResponseMessage)
 	if notifyChainChangedResponse.Error != nil {
-		return c.convertRPCError(notifyChainChangedResponse.Error)
+		return nil, c.convertRPCError(notifyChainChangedResponse.Error)
+	}
+	snapshot, err := c.dequeueDAGSnapshot(options)
+	if err != nil {
+		return nil, err
 	}
 	spawn("RegisterForVirtualSelectedParentChainChangedNotifications", func() {
 		for {
@@ -37,5 +54,5 @@ func (c *RPCClient) RegisterForVirtualSelectedParentChainChangedNotifications(in
 			onChainChanged(ChainChangedNotification)
 		}
 	})
-	return nil
+	return snapshot, nil
 }
@@ -9,7 +9,23 @@ import (
 // RegisterForNewBlockTemplateNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
 // Additionally, it starts listening for the appropriate notification using the given handler function
 func (c *RPCClient) RegisterForNewBlockTemplateNotifications(onNewBlockTemplate func(notification *appmessage.NewBlockTemplateNotificationMessage)) error {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyNewBlockTemplateRequestMessage())
+	return c.registerForNewBlockTemplateNotifications(appmessage.NewNotifyNewBlockTemplateRequestMessage(), onNewBlockTemplate)
+}
+
+// RegisterForNewBlockTemplateNotificationsWithTemplates is the same as
+// RegisterForNewBlockTemplateNotifications, except that every notification
+// carries the new template, whose coinbase pays to payAddress with extraData
+func (c *RPCClient) RegisterForNewBlockTemplateNotificationsWithTemplates(payAddress string, extraData string,
+	onNewBlockTemplate func(notification *appmessage.NewBlockTemplateNotificationMessage)) error {
+
+	return c.registerForNewBlockTemplateNotifications(
+		appmessage.NewNotifyNewBlockTemplateWithTemplatesRequestMessage(payAddress, extraData), onNewBlockTemplate)
+}
+
+func (c *RPCClient) registerForNewBlockTemplateNotifications(request *appmessage.NotifyNewBlockTemplateRequestMessage,
+	onNewBlockTemplate func(notification *appmessage.NewBlockTemplateNotificationMessage)) error {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(request)
 	if err != nil {
 		return err
 	}
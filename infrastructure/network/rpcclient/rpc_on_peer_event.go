@@ -0,0 +1,38 @@
+package rpcclient
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// RegisterForPeerEventNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function
+func (c *RPCClient) RegisterForPeerEventNotifications(onPeerEvent func(notification *appmessage.PeerEventNotificationMessage)) error {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyPeerEventsRequestMessage())
+	if err != nil {
+		return err
+	}
+	response, err := c.route(appmessage.CmdNotifyPeerEventsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return err
+	}
+	notifyPeerEventsResponse := response.(*appmessage.NotifyPeerEventsResponseMessage)
+	if notifyPeerEventsResponse.Error != nil {
+		return c.convertRPCError(notifyPeerEventsResponse.Error)
+	}
+	spawn("RegisterForPeerEventNotifications", func() {
+		for {
+			notification, err := c.route(appmessage.CmdPeerEventNotificationMessage).Dequeue()
+			if err != nil {
+				if errors.Is(err, routerpkg.ErrRouteClosed) {
+					break
+				}
+				panic(err)
+			}
+			peerEventNotification := notification.(*appmessage.PeerEventNotificationMessage)
+			onPeerEvent(peerEventNotification)
+		}
+	})
+	return nil
+}
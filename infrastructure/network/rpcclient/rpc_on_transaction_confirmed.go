@@ -0,0 +1,38 @@
+package rpcclient
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// RegisterForTransactionConfirmedNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function
+func (c *RPCClient) RegisterForTransactionConfirmedNotifications(onTransactionConfirmed func(notification *appmessage.TransactionConfirmedNotificationMessage)) error {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyTransactionConfirmedRequestMessage())
+	if err != nil {
+		return err
+	}
+	response, err := c.route(appmessage.CmdNotifyTransactionConfirmedResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return err
+	}
+	notifyTransactionConfirmedResponse := response.(*appmessage.NotifyTransactionConfirmedResponseMessage)
+	if notifyTransactionConfirmedResponse.Error != nil {
+		return c.convertRPCError(notifyTransactionConfirmedResponse.Error)
+	}
+	spawn("RegisterForTransactionConfirmedNotifications", func() {
+		for {
+			notification, err := c.route(appmessage.CmdTransactionConfirmedNotificationMessage).Dequeue()
+			if err != nil {
+				if errors.Is(err, routerpkg.ErrRouteClosed) {
+					break
+				}
+				panic(err)
+			}
+			transactionEvictedNotification := notification.(*appmessage.TransactionConfirmedNotificationMessage)
+			onTransactionConfirmed(transactionEvictedNotification)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,38 @@
+package rpcclient
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// RegisterForTransactionEvictedNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function
+func (c *RPCClient) RegisterForTransactionEvictedNotifications(onTransactionEvicted func(notification *appmessage.TransactionEvictedNotificationMessage)) error {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyTransactionEvictedRequestMessage())
+	if err != nil {
+		return err
+	}
+	response, err := c.route(appmessage.CmdNotifyTransactionEvictedResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return err
+	}
+	notifyTransactionEvictedResponse := response.(*appmessage.NotifyTransactionEvictedResponseMessage)
+	if notifyTransactionEvictedResponse.Error != nil {
+		return c.convertRPCError(notifyTransactionEvictedResponse.Error)
+	}
+	spawn("RegisterForTransactionEvictedNotifications", func() {
+		for {
+			notification, err := c.route(appmessage.CmdTransactionEvictedNotificationMessage).Dequeue()
+			if err != nil {
+				if errors.Is(err, routerpkg.ErrRouteClosed) {
+					break
+				}
+				panic(err)
+			}
+			transactionEvictedNotification := notification.(*appmessage.TransactionEvictedNotificationMessage)
+			onTransactionEvicted(transactionEvictedNotification)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,38 @@
+package rpcclient
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// RegisterForTransactionRemovedFromMempoolNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function
+func (c *RPCClient) RegisterForTransactionRemovedFromMempoolNotifications(onTransactionRemovedFromMempool func(notification *appmessage.TransactionRemovedFromMempoolNotificationMessage)) error {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyTransactionRemovedFromMempoolRequestMessage())
+	if err != nil {
+		return err
+	}
+	response, err := c.route(appmessage.CmdNotifyTransactionRemovedFromMempoolResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return err
+	}
+	notifyTransactionRemovedFromMempoolResponse := response.(*appmessage.NotifyTransactionRemovedFromMempoolResponseMessage)
+	if notifyTransactionRemovedFromMempoolResponse.Error != nil {
+		return c.convertRPCError(notifyTransactionRemovedFromMempoolResponse.Error)
+	}
+	spawn("RegisterForTransactionRemovedFromMempoolNotifications", func() {
+		for {
+			notification, err := c.route(appmessage.CmdTransactionRemovedFromMempoolNotificationMessage).Dequeue()
+			if err != nil {
+				if errors.Is(err, routerpkg.ErrRouteClosed) {
+					break
+				}
+				panic(err)
+			}
+			transactionEvictedNotification := notification.(*appmessage.TransactionRemovedFromMempoolNotificationMessage)
+			onTransactionRemovedFromMempool(transactionEvictedNotification)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,38 @@
+package rpcclient
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// RegisterForTransactionReplacedNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function
+func (c *RPCClient) RegisterForTransactionReplacedNotifications(onTransactionReplaced func(notification *appmessage.TransactionReplacedNotificationMessage)) error {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyTransactionReplacedRequestMessage())
+	if err != nil {
+		return err
+	}
+	response, err := c.route(appmessage.CmdNotifyTransactionReplacedResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return err
+	}
+	notifyTransactionReplacedResponse := response.(*appmessage.NotifyTransactionReplacedResponseMessage)
+	if notifyTransactionReplacedResponse.Error != nil {
+		return c.convertRPCError(notifyTransactionReplacedResponse.Error)
+	}
+	spawn("RegisterForTransactionReplacedNotifications", func() {
+		for {
+			notification, err := c.route(appmessage.CmdTransactionReplacedNotificationMessage).Dequeue()
+			if err != nil {
+				if errors.Is(err, routerpkg.ErrRouteClosed) {
+					break
+				}
+				panic(err)
+			}
+			transactionReplacedNotification := notification.(*appmessage.TransactionReplacedNotificationMessage)
+			onTransactionReplaced(transactionReplacedNotification)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// RefreshSeeds sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) RefreshSeeds() (*appmessage.RefreshSeedsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewRefreshSeedsRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdRefreshSeedsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	refreshSeedsResponse := response.(*appmessage.RefreshSeedsResponseMessage)
+	if refreshSeedsResponse.Error != nil {
+		return nil, c.convertRPCError(refreshSeedsResponse.Error)
+	}
+	return refreshSeedsResponse, nil
+}
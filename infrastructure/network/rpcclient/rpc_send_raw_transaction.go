@@ -6,7 +6,18 @@ import (
 
 // SubmitTransaction sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) SubmitTransaction(transaction *appmessage.RPCTransaction, allowOrphan bool) (*appmessage.SubmitTransactionResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewSubmitTransactionRequestMessage(transaction, allowOrphan))
+	return c.SubmitTransactionWithIdempotencyKey(transaction, allowOrphan, "")
+}
+
+// SubmitTransactionWithIdempotencyKey operates the same as SubmitTransaction, except that if
+// idempotencyKey is not empty, retrying the call with the same key returns the response to
+// the original call instead of submitting the transaction again
+func (c *RPCClient) SubmitTransactionWithIdempotencyKey(transaction *appmessage.RPCTransaction, allowOrphan bool,
+	idempotencyKey string) (*appmessage.SubmitTransactionResponseMessage, error) {
+
+	request := appmessage.NewSubmitTransactionRequestMessage(transaction, allowOrphan)
+	request.IdempotencyKey = idempotencyKey
+	err := c.rpcRouter.outgoingRoute().Enqueue(request)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// SetIndexEnabled sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) SetIndexEnabled(indexName string, enabled bool) (*appmessage.SetIndexEnabledResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewSetIndexEnabledRequestMessage(indexName, enabled))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdSetIndexEnabledResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	setIndexEnabledResponse := response.(*appmessage.SetIndexEnabledResponseMessage)
+	if setIndexEnabledResponse.Error != nil {
+		return nil, c.convertRPCError(setIndexEnabledResponse.Error)
+	}
+	return setIndexEnabledResponse, nil
+}
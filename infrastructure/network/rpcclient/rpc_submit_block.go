@@ -5,9 +5,12 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 )
 
-func (c *RPCClient) submitBlock(block *externalapi.DomainBlock, allowNonDAABlocks bool) (appmessage.RejectReason, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(
-		appmessage.NewSubmitBlockRequestMessage(appmessage.DomainBlockToRPCBlock(block), allowNonDAABlocks))
+func (c *RPCClient) submitBlock(block *externalapi.DomainBlock, allowNonDAABlocks bool,
+	idempotencyKey string) (appmessage.RejectReason, error) {
+
+	request := appmessage.NewSubmitBlockRequestMessage(appmessage.DomainBlockToRPCBlock(block), allowNonDAABlocks)
+	request.IdempotencyKey = idempotencyKey
+	err := c.rpcRouter.outgoingRoute().Enqueue(request)
 	if err != nil {
 		return appmessage.RejectReasonNone, err
 	}
@@ -24,10 +27,19 @@ func (c *RPCClient) submitBlock(block *externalapi.DomainBlock, allowNonDAABlock
 
 // SubmitBlock sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) SubmitBlock(block *externalapi.DomainBlock) (appmessage.RejectReason, error) {
-	return c.submitBlock(block, false)
+	return c.submitBlock(block, false, "")
+}
+
+// SubmitBlockWithIdempotencyKey operates the same as SubmitBlock, except that if idempotencyKey
+// is not empty, retrying the call with the same key returns the response to the original call
+// instead of submitting the block again
+func (c *RPCClient) SubmitBlockWithIdempotencyKey(block *externalapi.DomainBlock,
+	idempotencyKey string) (appmessage.RejectReason, error) {
+
+	return c.submitBlock(block, false, idempotencyKey)
 }
 
 // SubmitBlockAlsoIfNonDAA operates the same as SubmitBlock with the exception that `allowNonDAABlocks` is set to true
 func (c *RPCClient) SubmitBlockAlsoIfNonDAA(block *externalapi.DomainBlock) (appmessage.RejectReason, error) {
-	return c.submitBlock(block, true)
+	return c.submitBlock(block, true, "")
 }
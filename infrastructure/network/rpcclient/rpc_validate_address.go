@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// ValidateAddress sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) ValidateAddress(address string) (*appmessage.ValidateAddressResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewValidateAddressRequestMessage(address))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdValidateAddressResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	validateAddressResponse := response.(*appmessage.ValidateAddressResponseMessage)
+	if validateAddressResponse.Error != nil {
+		return nil, c.convertRPCError(validateAddressResponse.Error)
+	}
+	return validateAddressResponse, nil
+}
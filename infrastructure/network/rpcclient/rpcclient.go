@@ -1,6 +1,7 @@
 package rpcclient
 
 import (
+	"fmt"
 	"sync/atomic"
 	"time"
 
@@ -20,6 +21,7 @@ type RPCClient struct {
 	*grpcclient.GRPCClient
 
 	rpcAddress           string
+	connectOptions       *grpcclient.ConnectOptions
 	rpcRouter            *rpcRouter
 	isConnected          uint32
 	isClosed             uint32
@@ -31,9 +33,28 @@ type RPCClient struct {
 
 // NewRPCClient сreates a new RPC client with a default call timeout value
 func NewRPCClient(rpcAddress string) (*RPCClient, error) {
+	return NewRPCClientWithAuthToken(rpcAddress, "")
+}
+
+// NewRPCClientWithAuthToken creates a new RPC client with a default call timeout
+// value, which presents the given auth token to the RPC server. This is required
+// to connect to RPC endpoints that have an auth token.
+func NewRPCClientWithAuthToken(rpcAddress string, authToken string) (*RPCClient, error) {
+	connectOptions := &grpcclient.ConnectOptions{}
+	if authToken != "" {
+		connectOptions.Authorization = grpcclient.AuthTokenAuthorization(authToken)
+	}
+	return NewRPCClientWithOptions(rpcAddress, connectOptions)
+}
+
+// NewRPCClientWithOptions creates a new RPC client with a default call timeout
+// value, which connects to the RPC server with the given options, such as the
+// credentials to present to it and whether to connect over TLS
+func NewRPCClientWithOptions(rpcAddress string, connectOptions *grpcclient.ConnectOptions) (*RPCClient, error) {
 	rpcClient := &RPCClient{
-		rpcAddress: rpcAddress,
-		timeout:    defaultTimeout,
+		rpcAddress:     rpcAddress,
+		connectOptions: connectOptions,
+		timeout:        defaultTimeout,
 	}
 	err := rpcClient.connect()
 	if err != nil {
@@ -44,7 +65,7 @@ func NewRPCClient(rpcAddress string) (*RPCClient, error) {
 }
 
 func (c *RPCClient) connect() error {
-	rpcClient, err := grpcclient.Connect(c.rpcAddress)
+	rpcClient, err := grpcclient.ConnectWithOptions(c.rpcAddress, c.connectOptions)
 	if err != nil {
 		return errors.Wrapf(err, "error connecting to address %s", c.rpcAddress)
 	}
@@ -75,6 +96,15 @@ func (c *RPCClient) connect() error {
 		log.Warnf("version mismatch, client: %s, server: %s - expected responses and requests may deviate", localVersion, remoteVersion)
 	}
 
+	negotiateAPIVersionResponse, err := c.NegotiateAPIVersion(appmessage.MaxRPCAPIVersion)
+	if err != nil {
+		return errors.Wrapf(err, "error negotiating the RPC API version")
+	}
+	if negotiateAPIVersionResponse.Version != appmessage.MaxRPCAPIVersion {
+		log.Warnf("RPC API version mismatch, client: %d, server: %d - expected responses and requests may deviate",
+			appmessage.MaxRPCAPIVersion, negotiateAPIVersionResponse.Version)
+	}
+
 	return nil
 }
 
@@ -177,8 +207,25 @@ func (c *RPCClient) route(command appmessage.MessageCommand) *routerpkg.Route {
 // ErrRPC is an error in the RPC protocol
 var ErrRPC = errors.New("rpc error")
 
+// RPCError is returned when the node responds to a request with an error.
+// Callers can branch on its code using errors.As, and errors.Is(err, ErrRPC)
+// holds for it.
+type RPCError struct {
+	Code    appmessage.RPCErrorCode
+	Message string
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Message, ErrRPC)
+}
+
+// Is returns whether the target is ErrRPC
+func (e *RPCError) Is(target error) bool {
+	return target == ErrRPC
+}
+
 func (c *RPCClient) convertRPCError(rpcError *appmessage.RPCError) error {
-	return errors.Wrap(ErrRPC, rpcError.Message)
+	return errors.WithStack(&RPCError{Code: rpcError.Code, Message: rpcError.Message})
 }
 
 // SetLogger uses a specified Logger to output package logging info
@@ -4,7 +4,14 @@
 
 package limits
 
+import "math"
+
 // SetLimits is a no-op on Plan 9 due to the lack of process accounting.
 func SetLimits(*DesiredLimits) error {
 	return nil
 }
+
+// FileLimit always returns math.MaxUint64 on Plan 9 due to the lack of process accounting.
+func FileLimit() (uint64, error) {
+	return math.MaxUint64, nil
+}
@@ -47,3 +47,14 @@ func SetLimits(desiredLimits *DesiredLimits) error {
 
 	return nil
 }
+
+// FileLimit returns the current soft limit on the amount of open file
+// descriptors
+func FileLimit() (uint64, error) {
+	var rLimit syscall.Rlimit
+	err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rLimit)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(rLimit.Cur), nil
+}
@@ -4,7 +4,14 @@
 
 package limits
 
+import "math"
+
 // SetLimits is a no-op on Windows since it's not required there.
 func SetLimits(*DesiredLimits) error {
 	return nil
 }
+
+// FileLimit always returns math.MaxUint64 on Windows since it's not enforced there.
+func FileLimit() (uint64, error) {
+	return math.MaxUint64, nil
+}
@@ -17,6 +17,11 @@ var ShutdownRequestChannel = make(chan struct{})
 // shutdown. This may be modified during init depending on the platform.
 var interruptSignals = []os.Signal{os.Interrupt}
 
+// reloadSignals defines the signals to catch in order to reload the
+// configuration. There are none by default, and they may be added during init
+// depending on the platform.
+var reloadSignals []os.Signal
+
 // InterruptListener listens for OS Signals such as SIGINT (Ctrl+C) and shutdown
 // requests from shutdownRequestChannel. It returns a channel that is closed
 // when either signal is received.
@@ -57,6 +62,32 @@ func InterruptListener() chan struct{} {
 	return c
 }
 
+// ReloadListener listens for OS signals that ask to reload the configuration,
+// such as SIGHUP. It returns a channel that receives a value once one is
+// received. Signals that arrive before the previous one was consumed are
+// coalesced into it. The channel never receives on platforms that have no
+// such signal.
+func ReloadListener() <-chan struct{} {
+	c := make(chan struct{}, 1)
+	if len(reloadSignals) == 0 {
+		return c
+	}
+
+	reloadChannel := make(chan os.Signal, 1)
+	signal.Notify(reloadChannel, reloadSignals...)
+	go func() {
+		for sig := range reloadChannel {
+			kasdLog.Infof("Received signal (%s). Reloading the configuration...", sig)
+			select {
+			case c <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return c
+}
+
 // InterruptRequested returns true when the channel returned by
 // InterruptListener was closed. This simplifies early shutdown slightly since
 // the caller can just use an if statement instead of a select.
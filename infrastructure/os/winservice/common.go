@@ -9,9 +9,9 @@ type ServiceDescription struct {
 	Description string
 }
 
-// MainFunc specifies the signature of an application's main function to be able to run as a windows service
+// MainFunc specifies the signature of an application's main function to be able to run as a service
 type MainFunc func(startedChan chan<- struct{}) error
 
-// WinServiceMain is only invoked on Windows. It detects when kaspad is running
-// as a service and reacts accordingly.
+// WinServiceMain is only invoked on Windows and macOS. It detects when kaspad is
+// running as a service and reacts accordingly, and performs the service commands.
 var WinServiceMain = func(MainFunc, *ServiceDescription, *config.Config) (bool, error) { return false, nil }
@@ -0,0 +1,260 @@
+package winservice
+
+import (
+	"encoding/xml"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+// launchdExitTimeoutSeconds is how long launchd waits for kaspad to shut down
+// before killing it. It must exceed kaspad's own graceful shutdown timeout, so
+// that the database is never left in the middle of being closed.
+const launchdExitTimeoutSeconds = 150
+
+var launchdPlistTemplate = template.Must(template.New("plist").Funcs(template.FuncMap{"xml": xmlEscape}).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{xml .Label}}</string>
+	<key>ProgramArguments</key>
+	<array>
+{{- range .ProgramArguments}}
+		<string>{{xml .}}</string>
+{{- end}}
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<dict>
+		<key>SuccessfulExit</key>
+		<false/>
+	</dict>
+	<key>ProcessType</key>
+	<string>Background</string>
+	<key>ExitTimeOut</key>
+	<integer>{{.ExitTimeOut}}</integer>
+	<key>StandardOutPath</key>
+	<string>/dev/null</string>
+	<key>StandardErrorPath</key>
+	<string>{{xml .StandardErrorPath}}</string>
+</dict>
+</plist>
+`))
+
+func xmlEscape(text string) (string, error) {
+	var escaped strings.Builder
+	err := xml.EscapeText(&escaped, []byte(text))
+	return escaped.String(), err
+}
+
+// launchdService manages kaspad as a launchd user agent. Unlike a Windows
+// service, a launchd job is an ordinary process that launchd starts in the
+// foreground and stops with SIGTERM, which kaspad already handles, so only
+// the service commands require special treatment.
+type launchdService struct {
+	description *ServiceDescription
+	cfg         *config.Config
+}
+
+func (s *launchdService) label() string {
+	return "org.kaspanet." + s.description.Name
+}
+
+func (s *launchdService) plistPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, "Library", "LaunchAgents", s.label()+".plist"), nil
+}
+
+// performServiceCommand attempts to run one of the supported service commands
+// provided on the command line via the service command flag. An appropriate
+// error is returned if an invalid command is specified.
+func (s *launchdService) performServiceCommand() error {
+	plistPath, err := s.plistPath()
+	if err != nil {
+		return err
+	}
+
+	command := s.cfg.ServiceOptions.ServiceCommand
+	switch command {
+	case "install":
+		return s.installService(plistPath)
+
+	case "remove":
+		// Unloading fails if the job is not loaded, which is fine
+		_ = launchctl("unload", plistPath)
+		err := os.Remove(plistPath)
+		if os.IsNotExist(err) {
+			return errors.Errorf("service %s is not installed", s.label())
+		}
+		return err
+
+	case "start":
+		return launchctl("load", "-w", plistPath)
+
+	case "stop":
+		// Unloading rather than stopping the job makes sure launchd doesn't
+		// restart it
+		return launchctl("unload", plistPath)
+
+	default:
+		return errors.Errorf("invalid service command [%s]", command)
+	}
+}
+
+// installService writes a launchd property list that runs kaspad with the
+// same command line it was invoked with, minus the service command itself and
+// with relative paths made absolute.
+func (s *launchdService) installService(plistPath string) error {
+	if _, err := os.Stat(plistPath); err == nil {
+		return errors.Errorf("service %s already exists at %s", s.label(), plistPath)
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	args, err := withAbsolutePaths(withoutServiceCommand(os.Args[1:]))
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(plistPath), 0755)
+	if err != nil {
+		return err
+	}
+	plistFile, err := os.OpenFile(plistPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	defer plistFile.Close()
+
+	return launchdPlistTemplate.Execute(plistFile, map[string]interface{}{
+		"Label":             s.label(),
+		"ProgramArguments":  append([]string{exePath}, args...),
+		"ExitTimeOut":       launchdExitTimeoutSeconds,
+		"StandardErrorPath": filepath.Join(s.cfg.LogDir, "kaspad_stderr.log"),
+	})
+}
+
+// launchdServiceCommands are the values of the service command flag
+var launchdServiceCommands = map[string]bool{"install": true, "remove": true, "start": true, "stop": true}
+
+// pathFlags are the flags, by their long and short names, whose values are
+// paths. launchd runs kaspad from the root directory, so relative paths given
+// to them when installing the service must be made absolute.
+var pathFlags = map[string]bool{
+	"-C": true, "--configfile": true,
+	"-b": true, "--appdir": true,
+	"--datadir":             true,
+	"--logdir":              true,
+	"--rpccert":             true,
+	"--rpckey":              true,
+	"--rpcunixsocket":       true,
+	"--rpccookiefile":       true,
+	"--startupstatussocket": true,
+	"--dbencryptionkeyfile": true,
+	"--networkconfigfile":   true,
+}
+
+// splitFlag splits the given command line argument into its flag and the
+// value attached to it, either by "=" or, for short flags, directly
+func splitFlag(arg string) (flag string, value string, hasValue bool) {
+	if strings.HasPrefix(arg, "--") {
+		return strings.Cut(arg, "=")
+	}
+	if !strings.HasPrefix(arg, "-") || len(arg) <= 2 {
+		return arg, "", false
+	}
+	return arg[:2], strings.TrimPrefix(arg[2:], "="), true
+}
+
+// withoutServiceCommand removes the service command flag from the given
+// command line arguments
+func withoutServiceCommand(args []string) []string {
+	filtered := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "-s" || arg == "--service" {
+			i++
+			continue
+		}
+		if strings.HasPrefix(arg, "-s=") || strings.HasPrefix(arg, "--service=") ||
+			(strings.HasPrefix(arg, "-s") && launchdServiceCommands[arg[2:]]) {
+			continue
+		}
+		filtered = append(filtered, arg)
+	}
+	return filtered
+}
+
+// withAbsolutePaths returns the given command line arguments with the
+// relative values of the path flags made absolute
+func withAbsolutePaths(args []string) ([]string, error) {
+	absoluteArgs := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		flag, value, hasValue := splitFlag(args[i])
+		if !pathFlags[flag] {
+			absoluteArgs = append(absoluteArgs, args[i])
+			continue
+		}
+		if !hasValue {
+			absoluteArgs = append(absoluteArgs, args[i])
+			if i+1 == len(args) {
+				break
+			}
+			i++
+			value = args[i]
+		}
+		// Paths starting with ~ or an environment variable are expanded by
+		// kaspad itself, so they're left as they are
+		if value != "" && !filepath.IsAbs(value) && !strings.HasPrefix(value, "~") &&
+			!strings.HasPrefix(value, "$") {
+
+			absoluteValue, err := filepath.Abs(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to resolve the path %s given to %s", value, flag)
+			}
+			value = absoluteValue
+		}
+		if hasValue {
+			absoluteArgs = append(absoluteArgs, flag+"="+value)
+		} else {
+			absoluteArgs = append(absoluteArgs, value)
+		}
+	}
+	return absoluteArgs, nil
+}
+
+func launchctl(args ...string) error {
+	output, err := exec.Command("launchctl", args...).CombinedOutput()
+	if err != nil {
+		return errors.Errorf("launchctl %s failed: %s: %s", strings.Join(args, " "), err,
+			strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// launchdServiceMain performs the service command, if one was given. Otherwise
+// kaspad runs normally, whether it was launched by launchd or interactively.
+func launchdServiceMain(_ MainFunc, description *ServiceDescription, cfg *config.Config) (bool, error) {
+	if cfg.ServiceOptions.ServiceCommand == "" {
+		return false, nil
+	}
+	service := &launchdService{description: description, cfg: cfg}
+	return true, service.performServiceCommand()
+}
+
+// Set macOS specific functions to real functions.
+func init() {
+	WinServiceMain = launchdServiceMain
+}
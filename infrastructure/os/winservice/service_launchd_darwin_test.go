@@ -0,0 +1,58 @@
+package winservice
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWithoutServiceCommand(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{args: []string{"-s", "install", "--utxoindex"}, expected: []string{"--utxoindex"}},
+		{args: []string{"--utxoindex", "--service", "install"}, expected: []string{"--utxoindex"}},
+		{args: []string{"-s=install", "--service=install", "-sinstall"}, expected: []string{}},
+		// Arguments that only start like the service flag are kept
+		{args: []string{"-s", "install", "--sigcachemaxsize=1000", "--appdir", "-storage"},
+			expected: []string{"--sigcachemaxsize=1000", "--appdir", "-storage"}},
+		{args: []string{"--servicefoo", "-sfoo"}, expected: []string{"--servicefoo", "-sfoo"}},
+	}
+	for _, test := range tests {
+		result := withoutServiceCommand(test.args)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("withoutServiceCommand(%q): expected %q, got %q", test.args, test.expected, result)
+		}
+	}
+}
+
+func TestWithAbsolutePaths(t *testing.T) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %s", err)
+	}
+	absolute := func(path string) string {
+		return filepath.Join(workingDir, path)
+	}
+
+	args := []string{
+		"--appdir", "data", "--logdir=logs", "-C", "kaspad.conf", "-bdata", "-b=data",
+		"--rpccert", "/etc/kaspad/rpc.cert", "--rpckey=~/rpc.key", "--rpccookiefile", "$HOME/.cookie",
+		"--utxoindex", "--rpclisten", "localhost", "--networkconfigfile", "testnet.conf",
+	}
+	expected := []string{
+		"--appdir", absolute("data"), "--logdir=" + absolute("logs"), "-C", absolute("kaspad.conf"),
+		"-b=" + absolute("data"), "-b=" + absolute("data"),
+		"--rpccert", "/etc/kaspad/rpc.cert", "--rpckey=~/rpc.key", "--rpccookiefile", "$HOME/.cookie",
+		"--utxoindex", "--rpclisten", "localhost", "--networkconfigfile", absolute("testnet.conf"),
+	}
+	result, err := withAbsolutePaths(args)
+	if err != nil {
+		t.Fatalf("withAbsolutePaths: %s", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("withAbsolutePaths: expected %q, got %q", expected, result)
+	}
+}
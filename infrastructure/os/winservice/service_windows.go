@@ -40,7 +40,7 @@ func (s *Service) Start() error {
 	s.eventLog = elog
 	defer s.eventLog.Close()
 
-	err = svc.Run(s.description.Name, &Service{})
+	err = svc.Run(s.description.Name, s)
 	if err != nil {
 		s.eventLog.Error(1, fmt.Sprintf("Service start failed: %s", err))
 		return err
@@ -83,6 +83,7 @@ loop:
 				// Service stop is pending. Don't accept any
 				// more commands while pending.
 				changes <- svc.Status{State: svc.StopPending}
+				s.eventLog.Info(1, fmt.Sprintf("%s is shutting down", s.description.DisplayName))
 
 				// Signal the main function to exit.
 				signal.ShutdownRequestChannel <- struct{}{}
@@ -97,7 +98,8 @@ loop:
 
 		case err := <-doneChan:
 			if err != nil {
-				s.eventLog.Error(1, err.Error())
+				s.eventLog.Error(1, fmt.Sprintf("%s stopped with an error: %s. See %s for details",
+					s.description.DisplayName, err, s.cfg.LogDir))
 			}
 			break loop
 		}
@@ -116,4 +118,5 @@ func (s *Service) logServiceStart() {
 	message += fmt.Sprintf("Configuration file: %s\n", s.cfg.ConfigFile)
 	message += fmt.Sprintf("Application directory: %s\n", s.cfg.AppDir)
 	message += fmt.Sprintf("Logs directory: %s\n", s.cfg.LogDir)
+	s.eventLog.Info(1, message)
 }
@@ -0,0 +1,98 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestAddrIndex(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		addrIndex:               true,
+	})
+	defer teardown()
+
+	// Mine a mature coinbase and have the mining address pay it to itself
+	mineNextBlock(t, kaspad)
+	fundingBlock := mineNextBlock(t, kaspad)
+	for i := uint64(0); i < kaspad.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, kaspad)
+	}
+	fundingCoinbase := fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	msgTx := generateTx(t, fundingCoinbase, kaspad, kaspad)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	submitTransactionResponse, err := kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+	transactionID := submitTransactionResponse.TransactionID
+
+	// The transaction is accepted by the selected child of the block that includes it
+	mineNextBlock(t, kaspad)
+	acceptingBlock := mineNextBlock(t, kaspad)
+	acceptingBlockHash := consensushashing.BlockHash(acceptingBlock).String()
+
+	start := time.Now()
+	var addressTransactions []*appmessage.AddressTransaction
+	for {
+		response, err := kaspad.rpcClient.GetTransactionsByAddress(miningAddress1, 0, 0)
+		if err != nil {
+			t.Fatalf("Error getting the transactions by address: %+v", err)
+		}
+		if response.HasMore {
+			t.Fatalf("Expected all the transactions of the address to fit in a single page")
+		}
+		addressTransactions = response.Transactions
+		if len(addressTransactions) > 0 && addressTransactions[len(addressTransactions)-1].TransactionID == transactionID {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the transaction to be indexed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	addressTransaction := addressTransactions[len(addressTransactions)-1]
+	if addressTransaction.AcceptingBlockHash != acceptingBlockHash {
+		t.Fatalf("Unexpected accepting block. Want: %s, got: %s", acceptingBlockHash, addressTransaction.AcceptingBlockHash)
+	}
+	fundingAmount := fundingCoinbase.Outputs[0].Value
+	if addressTransaction.Sent != fundingAmount || addressTransaction.Received != fundingAmount-1000 {
+		t.Fatalf("Unexpected amounts. Want: sent %d and received %d, got: sent %d and received %d",
+			fundingAmount, fundingAmount-1000, addressTransaction.Sent, addressTransaction.Received)
+	}
+	for i := 1; i < len(addressTransactions); i++ {
+		if addressTransactions[i].AcceptingBlockBlueScore < addressTransactions[i-1].AcceptingBlockBlueScore {
+			t.Fatalf("Expected the transactions to be ordered by the blue score of their accepting blocks")
+		}
+	}
+
+	// Paging through the transactions should return the same transactions
+	for offset := 0; offset < len(addressTransactions); offset++ {
+		response, err := kaspad.rpcClient.GetTransactionsByAddress(miningAddress1, uint64(offset), 1)
+		if err != nil {
+			t.Fatalf("Error getting the transactions by address: %+v", err)
+		}
+		if len(response.Transactions) != 1 ||
+			response.Transactions[0].TransactionID != addressTransactions[offset].TransactionID {
+			t.Fatalf("Unexpected transactions at offset %d. Want: %s, got: %+v",
+				offset, addressTransactions[offset].TransactionID, response.Transactions)
+		}
+		expectedHasMore := offset < len(addressTransactions)-1
+		if response.HasMore != expectedHasMore {
+			t.Fatalf("Unexpected hasMore at offset %d. Want: %t, got: %t", offset, expectedHasMore, response.HasMore)
+		}
+	}
+
+	_, err = kaspad.rpcClient.GetTransactionsByAddress("invalid", 0, 0)
+	if err == nil {
+		t.Fatalf("Expected an invalid address to be rejected")
+	}
+}
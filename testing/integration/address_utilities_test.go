@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func TestAddressUtilities(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	privateKeyBytes, err := hex.DecodeString(miningAddress1PrivateKey)
+	if err != nil {
+		t.Fatalf("DecodeString: %+v", err)
+	}
+	keyPair, err := secp256k1.DeserializeSchnorrPrivateKeyFromSlice(privateKeyBytes)
+	if err != nil {
+		t.Fatalf("DeserializeSchnorrPrivateKeyFromSlice: %+v", err)
+	}
+	publicKey, err := keyPair.SchnorrPublicKey()
+	if err != nil {
+		t.Fatalf("SchnorrPublicKey: %+v", err)
+	}
+	serializedPublicKey, err := publicKey.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize: %+v", err)
+	}
+
+	deriveResponse, err := kaspad.rpcClient.DeriveAddressFromPublicKey(hex.EncodeToString(serializedPublicKey[:]))
+	if err != nil {
+		t.Fatalf("DeriveAddressFromPublicKey: %+v", err)
+	}
+	if deriveResponse.Address != miningAddress1 || deriveResponse.AddressType != "pubkey" {
+		t.Fatalf("Expected the pubkey address %s, but got the %s address %s",
+			miningAddress1, deriveResponse.AddressType, deriveResponse.Address)
+	}
+	_, err = kaspad.rpcClient.DeriveAddressFromPublicKey(hex.EncodeToString(serializedPublicKey[1:]))
+	if err == nil || !strings.Contains(err.Error(), "bytes long") {
+		t.Fatalf("Expected deriving an address from a short public key to fail, but got %v", err)
+	}
+
+	validateResponse, err := kaspad.rpcClient.ValidateAddress(miningAddress1)
+	if err != nil {
+		t.Fatalf("ValidateAddress: %+v", err)
+	}
+	if !validateResponse.IsValid || validateResponse.Prefix != "kaspasim" {
+		t.Fatalf("Expected %s to be a valid kaspasim address, but got %+v", miningAddress1, validateResponse)
+	}
+
+	// An address of another network is well formed, but isn't valid on this one
+	mainnetAddress := "kaspa:qqkqkzjvr7zwxxmjxjkmxxdwju9kjs6e9u82uh59z07vgaks6gg62v8707g73"
+	validateResponse, err = kaspad.rpcClient.ValidateAddress(mainnetAddress)
+	if err != nil {
+		t.Fatalf("ValidateAddress: %+v", err)
+	}
+	if validateResponse.IsValid || validateResponse.Prefix != "kaspa" || validateResponse.InvalidReason == "" {
+		t.Fatalf("Expected %s to be an invalid kaspa address, but got %+v", mainnetAddress, validateResponse)
+	}
+
+	validateResponse, err = kaspad.rpcClient.ValidateAddress(miningAddress1[:len(miningAddress1)-1])
+	if err != nil {
+		t.Fatalf("ValidateAddress: %+v", err)
+	}
+	if validateResponse.IsValid || validateResponse.Prefix != "" {
+		t.Fatalf("Expected a truncated address to be malformed, but got %+v", validateResponse)
+	}
+
+	toScriptPublicKeyResponse, err := kaspad.rpcClient.ConvertAddress(miningAddress1, nil)
+	if err != nil {
+		t.Fatalf("ConvertAddress: %+v", err)
+	}
+	expectedScript := "20" + hex.EncodeToString(serializedPublicKey[:]) + "ac"
+	if toScriptPublicKeyResponse.ScriptPublicKey.Script != expectedScript {
+		t.Fatalf("Expected scriptPublicKey %s, but got %s", expectedScript, toScriptPublicKeyResponse.ScriptPublicKey.Script)
+	}
+
+	toAddressResponse, err := kaspad.rpcClient.ConvertAddress("", toScriptPublicKeyResponse.ScriptPublicKey)
+	if err != nil {
+		t.Fatalf("ConvertAddress: %+v", err)
+	}
+	if toAddressResponse.Address != miningAddress1 {
+		t.Fatalf("Expected address %s, but got %s", miningAddress1, toAddressResponse.Address)
+	}
+
+	_, err = kaspad.rpcClient.ConvertAddress("", &appmessage.RPCScriptPublicKey{Script: "51"})
+	if err == nil || !strings.Contains(err.Error(), "standard form") {
+		t.Fatalf("Expected converting a non-standard scriptPublicKey to fail, but got %v", err)
+	}
+	_, err = kaspad.rpcClient.ConvertAddress(miningAddress1, toScriptPublicKeyResponse.ScriptPublicKey)
+	if err == nil || !strings.Contains(err.Error(), "Exactly one") {
+		t.Fatalf("Expected converting both an address and a scriptPublicKey to fail, but got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package integration
+
+import (
+	"testing"
+)
+
+func TestGetBandwidthInfo(t *testing.T) {
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+		},
+	})
+	defer teardown()
+	kaspad1, kaspad2 := harnesses[0], harnesses[1]
+
+	connect(t, kaspad1, kaspad2)
+	mineNextBlock(t, kaspad1)
+
+	response, err := kaspad1.rpcClient.GetBandwidthInfo()
+	if err != nil {
+		t.Fatalf("Error getting the bandwidth info: %+v", err)
+	}
+	if response.UploadLimit != 0 || response.DownloadLimit != 0 ||
+		response.PeerUploadLimit != 0 || response.PeerDownloadLimit != 0 {
+		t.Fatalf("Expected the bandwidth to be unlimited by default, but got %+v", response)
+	}
+	if len(response.Peers) != 1 || response.Peers[0].ID != kaspad2.app.P2PNodeID().String() {
+		t.Fatalf("Expected the bandwidth info of kaspad2 only, but got %+v", response.Peers)
+	}
+	peer := response.Peers[0]
+	if peer.BytesSent == 0 || peer.BytesReceived == 0 {
+		t.Fatalf("Expected traffic to have been exchanged with kaspad2, but got %+v", peer)
+	}
+	if response.TotalBytesSent < peer.BytesSent || response.TotalBytesReceived < peer.BytesReceived {
+		t.Fatalf("Expected the totals (%d sent, %d received) to include the traffic of kaspad2 "+
+			"(%d sent, %d received)", response.TotalBytesSent, response.TotalBytesReceived,
+			peer.BytesSent, peer.BytesReceived)
+	}
+}
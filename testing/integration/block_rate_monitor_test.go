@@ -0,0 +1,46 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBlockRateMonitor(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		blockRateMonitor:        true,
+	})
+	defer teardown()
+
+	status, err := kaspad.rpcClient.GetBlockRateStatus()
+	if err != nil {
+		t.Fatalf("GetBlockRateStatus: %s", err)
+	}
+	expectedTargetBlockRate := float64(time.Second) / float64(kaspad.config.ActiveNetParams.TargetTimePerBlock)
+	if status.TargetBlockRate != expectedTargetBlockRate {
+		t.Fatalf("Expected a target block rate of %f, but got %f", expectedTargetBlockRate, status.TargetBlockRate)
+	}
+	// A whole window takes minutes to measure
+	if status.IsWindowFull || status.ObservedBlockRate != 0 || len(status.Alerts) != 0 {
+		t.Fatalf("Unexpected status before a whole window was measured: %+v", status)
+	}
+}
+
+func TestBlockRateMonitorDisabled(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	_, err := kaspad.rpcClient.GetBlockRateStatus()
+	if err == nil || !strings.Contains(err.Error(), "--blockratemonitor") {
+		t.Fatalf("Expected GetBlockRateStatus to be unavailable, but got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestNewBlockTemplateNotificationsWithTemplates(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	// The coinbase of the first block pays nothing, so the second one is spent
+	mineNextBlock(t, kaspad)
+	fundingBlock := mineNextBlock(t, kaspad)
+	for i := uint64(0); i < kaspad.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, kaspad)
+	}
+
+	err := kaspad.rpcClient.RegisterForNewBlockTemplateNotificationsWithTemplates("invalid", "",
+		func(_ *appmessage.NewBlockTemplateNotificationMessage) {})
+	if err == nil {
+		t.Fatalf("Expected registering with an invalid pay address to fail")
+	}
+
+	templates := make(chan *appmessage.NewBlockTemplateNotificationMessage, 100)
+	err = kaspad.rpcClient.RegisterForNewBlockTemplateNotificationsWithTemplates(kaspad.miningAddress, "test",
+		func(notification *appmessage.NewBlockTemplateNotificationMessage) {
+			templates <- notification
+		})
+	if err != nil {
+		t.Fatalf("RegisterForNewBlockTemplateNotificationsWithTemplates: %s", err)
+	}
+	waitForTemplate := func(isExpected func(template *appmessage.RPCBlock) bool) {
+		timeout := time.After(defaultTimeout)
+		for {
+			select {
+			case notification := <-templates:
+				if notification.Block == nil {
+					t.Fatalf("Got a notification without a template")
+				}
+				if isExpected(notification.Block) {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("Timed out waiting for the expected template")
+			}
+		}
+	}
+
+	// A new block changes the parents of the template
+	blockHash := consensushashing.BlockHash(mineNextBlock(t, kaspad)).String()
+	waitForTemplate(func(template *appmessage.RPCBlock) bool {
+		parents := template.Header.Parents[0].ParentHashes
+		return len(parents) == 1 && parents[0] == blockHash && len(template.Transactions) == 1
+	})
+
+	// A new mempool transaction changes the transactions of the template
+	fundingCoinbase := fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	msgTx := generateTx(t, fundingCoinbase, kaspad, kaspad)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	_, err = kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+	waitForTemplate(func(template *appmessage.RPCBlock) bool {
+		return len(template.Transactions) == 2
+	})
+
+	// Templates that are the same work aren't sent again
+	select {
+	case notification := <-templates:
+		t.Fatalf("Got an unexpected template with %d transactions", len(notification.Block.Transactions))
+	case <-time.After(3 * time.Second):
+	}
+}
@@ -0,0 +1,79 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestBlockVerboseDataFees(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	mineNextBlock(t, kaspad)
+	fundingBlock := mineNextBlock(t, kaspad)
+	for i := uint64(0); i < kaspad.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, kaspad)
+	}
+	fundingCoinbase := fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	msgTx := generateTx(t, fundingCoinbase, kaspad, kaspad)
+	const expectedFee = 1000
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	submitTransactionResponse, err := kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+
+	includingBlock := mineNextBlock(t, kaspad)
+	if len(includingBlock.Transactions) != 2 {
+		t.Fatalf("Expected the block to include the transaction, but it has %d transactions",
+			len(includingBlock.Transactions))
+	}
+	includingBlockHash := consensushashing.BlockHash(includingBlock).String()
+
+	// The fees are unknown until the block is merged
+	response, err := kaspad.rpcClient.GetBlock(includingBlockHash, true)
+	if err != nil {
+		t.Fatalf("Error getting block: %+v", err)
+	}
+	verboseData := response.Block.VerboseData
+	if verboseData.AreFeesKnown || verboseData.TotalFees != 0 {
+		t.Fatalf("Expected the fees of an unmerged block to be unknown, but got %+v", verboseData)
+	}
+	totalMass := uint64(0)
+	for _, transaction := range response.Block.Transactions {
+		if transaction.VerboseData.IsFeeKnown {
+			t.Fatalf("Expected the fee of transaction %s to be unknown", transaction.VerboseData.TransactionID)
+		}
+		totalMass += transaction.VerboseData.Mass
+	}
+	if totalMass == 0 || verboseData.TotalMass != totalMass {
+		t.Fatalf("Unexpected total mass. Want: %d, got: %d", totalMass, verboseData.TotalMass)
+	}
+
+	mineNextBlock(t, kaspad)
+
+	response, err = kaspad.rpcClient.GetBlock(includingBlockHash, true)
+	if err != nil {
+		t.Fatalf("Error getting block: %+v", err)
+	}
+	verboseData = response.Block.VerboseData
+	if !verboseData.AreFeesKnown || verboseData.TotalFees != expectedFee {
+		t.Fatalf("Expected the block to pay %d in fees, but got %+v", expectedFee, verboseData)
+	}
+	transactionVerboseData := response.Block.Transactions[1].VerboseData
+	if transactionVerboseData.TransactionID != submitTransactionResponse.TransactionID {
+		t.Fatalf("Unexpected transaction. Want: %s, got: %s",
+			submitTransactionResponse.TransactionID, transactionVerboseData.TransactionID)
+	}
+	if !transactionVerboseData.IsFeeKnown || transactionVerboseData.Fee != expectedFee {
+		t.Fatalf("Expected the transaction to pay a fee of %d, but got %+v", expectedFee, transactionVerboseData)
+	}
+}
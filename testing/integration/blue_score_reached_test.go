@@ -0,0 +1,98 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestBlueScoreReached(t *testing.T) {
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	onBlueScoreReachedChan := make(chan *appmessage.BlueScoreReachedNotificationMessage, 10)
+	kaspad.rpcClient.RegisterForBlueScoreReachedNotifications(
+		func(notification *appmessage.BlueScoreReachedNotificationMessage) {
+			onBlueScoreReachedChan <- notification
+		})
+
+	block := mineNextBlock(t, kaspad)
+	blockHash := consensushashing.BlockHash(block).String()
+
+	// An already reached target is notified right away
+	response, err := kaspad.rpcClient.NotifyBlueScoreReached("reached", 1, "", 0)
+	if err != nil {
+		t.Fatalf("Error scheduling a BlueScoreReached notification: %+v", err)
+	}
+	if response.TargetBlueScore != 1 {
+		t.Fatalf("Unexpected target blue score. Want: %d, got: %d", 1, response.TargetBlueScore)
+	}
+	notification := waitForBlueScoreReached(t, onBlueScoreReachedChan)
+	if notification.ID != "reached" || notification.VirtualSelectedParentBlueScore != 1 {
+		t.Fatalf("Unexpected notification: %+v", notification)
+	}
+
+	const confirmations = 3
+	response, err = kaspad.rpcClient.NotifyBlueScoreReached("confirmations", 0, blockHash, confirmations)
+	if err != nil {
+		t.Fatalf("Error scheduling a BlueScoreReached notification: %+v", err)
+	}
+	if response.TargetBlueScore != 1+confirmations {
+		t.Fatalf("Unexpected target blue score. Want: %d, got: %d", 1+confirmations, response.TargetBlueScore)
+	}
+	_, err = kaspad.rpcClient.NotifyBlueScoreReached("blueScore", 2, "", 0)
+	if err != nil {
+		t.Fatalf("Error scheduling a BlueScoreReached notification: %+v", err)
+	}
+
+	// Every mined block increases the blue score by exactly one, so each
+	// notification must be sent right when its target is reached
+	for i := 0; i < 5; i++ {
+		mineNextBlock(t, kaspad)
+	}
+	for _, expected := range []struct {
+		id              string
+		targetBlueScore uint64
+	}{
+		{id: "blueScore", targetBlueScore: 2},
+		{id: "confirmations", targetBlueScore: 1 + confirmations},
+	} {
+		notification := waitForBlueScoreReached(t, onBlueScoreReachedChan)
+		if notification.ID != expected.id || notification.TargetBlueScore != expected.targetBlueScore ||
+			notification.VirtualSelectedParentBlueScore != expected.targetBlueScore {
+			t.Fatalf("Unexpected notification: %+v", notification)
+		}
+	}
+
+	// The notifications are one-shot
+	select {
+	case notification := <-onBlueScoreReachedChan:
+		t.Fatalf("Unexpected notification: %+v", notification)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, err = kaspad.rpcClient.NotifyBlueScoreReached("invalid", 0, "", confirmations)
+	if err == nil {
+		t.Fatalf("Expected an error when scheduling confirmations without a block hash")
+	}
+}
+
+func waitForBlueScoreReached(t *testing.T,
+	onBlueScoreReachedChan chan *appmessage.BlueScoreReachedNotificationMessage) *appmessage.BlueScoreReachedNotificationMessage {
+
+	select {
+	case notification := <-onBlueScoreReachedChan:
+		return notification
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timed out waiting for a BlueScoreReached notification")
+	}
+	return nil
+}
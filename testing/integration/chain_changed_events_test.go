@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestChainChangedEventsFromBlock(t *testing.T) {
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	const blockAmountToMine = 5
+	var minedBlockHashes []string
+	for i := 0; i < blockAmountToMine; i++ {
+		block := mineNextBlock(t, kaspad)
+		minedBlockHashes = append(minedBlockHashes, consensushashing.BlockHash(block).String())
+	}
+
+	// Replay the whole chain from the pruning point, which is genesis here,
+	// two chain blocks at a time
+	dagInfo, err := kaspad.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error getting block DAG info: %+v", err)
+	}
+	startHash := dagInfo.PruningPointHash
+	var addedChainBlockHashes []string
+	for {
+		response, err := kaspad.rpcClient.GetChainChangedEventsFromBlock(startHash, 2)
+		if err != nil {
+			t.Fatalf("Error getting chain changed events: %+v", err)
+		}
+		if len(response.RemovedChainBlockHashes) != 0 {
+			t.Fatalf("Unexpected removed chain blocks: %s", response.RemovedChainBlockHashes)
+		}
+		if len(response.AddedChainBlockHashes) > 2 {
+			t.Fatalf("Got %d added chain blocks, more than the limit", len(response.AddedChainBlockHashes))
+		}
+		if len(response.AcceptedTransactions) != len(response.AddedChainBlockHashes) {
+			t.Fatalf("Got accepted transactions for %d chain blocks, but %d chain blocks were added",
+				len(response.AcceptedTransactions), len(response.AddedChainBlockHashes))
+		}
+		for i, acceptedTransactions := range response.AcceptedTransactions {
+			if acceptedTransactions.AcceptingBlockHash != response.AddedChainBlockHashes[i] {
+				t.Fatalf("Unexpected accepting block hash. Want: %s, got: %s",
+					response.AddedChainBlockHashes[i], acceptedTransactions.AcceptingBlockHash)
+			}
+			// Every chain block accepts at least the coinbase of its selected parent
+			if len(acceptedTransactions.AcceptedTransactions) == 0 {
+				t.Fatalf("Chain block %s accepted no transactions", acceptedTransactions.AcceptingBlockHash)
+			}
+		}
+		addedChainBlockHashes = append(addedChainBlockHashes, response.AddedChainBlockHashes...)
+		startHash = response.NextStartHash
+		if !response.HasMore {
+			break
+		}
+	}
+
+	if len(addedChainBlockHashes) != len(minedBlockHashes) {
+		t.Fatalf("Unexpected amount of added chain blocks. Want: %d, got: %d",
+			len(minedBlockHashes), len(addedChainBlockHashes))
+	}
+	for i, minedBlockHash := range minedBlockHashes {
+		if addedChainBlockHashes[i] != minedBlockHash {
+			t.Fatalf("Unexpected added chain block at index %d. Want: %s, got: %s",
+				i, minedBlockHash, addedChainBlockHashes[i])
+		}
+	}
+}
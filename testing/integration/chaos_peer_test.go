@@ -0,0 +1,226 @@
+package integration
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/id"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// rawFrame is a message that is sent over the P2P stream exactly as is,
+// without being encoded
+type rawFrame []byte
+
+// rawCodec is a gRPC codec that sends rawFrames byte for byte, and encodes
+// and decodes everything else as protobuf. It's named "proto" so that the
+// node decodes the frames with its regular codec.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	if frame, ok := v.(rawFrame); ok {
+		return frame, nil
+	}
+	return proto.Marshal(v.(proto.Message))
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	return proto.Unmarshal(data, v.(proto.Message))
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// chaosPeer is a P2P peer that lets tests send the node under test arbitrary
+// frames: malformed, out of order, duplicated or oversized messages
+type chaosPeer struct {
+	t                   *testing.T
+	network             string
+	gRPCConnection      *grpc.ClientConn
+	stream              protowire.P2P_MessageStreamClient
+	incomingMessageChan chan *protowire.KaspadMessage
+	disconnectedChan    chan struct{}
+}
+
+func connectChaosPeer(t *testing.T, harness *appHarness) *chaosPeer {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	gRPCConnection, err := grpc.DialContext(ctx, harness.p2pAddress, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Error connecting to %s: %+v", harness.p2pAddress, err)
+	}
+	stream, err := protowire.NewP2PClient(gRPCConnection).MessageStream(context.Background(),
+		grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		t.Fatalf("Error opening a message stream to %s: %+v", harness.p2pAddress, err)
+	}
+
+	peer := &chaosPeer{
+		t:                   t,
+		network:             harness.config.ActiveNetParams.Name,
+		gRPCConnection:      gRPCConnection,
+		stream:              stream,
+		incomingMessageChan: make(chan *protowire.KaspadMessage, 100),
+		disconnectedChan:    make(chan struct{}),
+	}
+	go peer.receiveLoop()
+	return peer
+}
+
+func (cp *chaosPeer) receiveLoop() {
+	defer close(cp.disconnectedChan)
+	for {
+		message := &protowire.KaspadMessage{}
+		err := cp.stream.RecvMsg(message)
+		if err != nil {
+			return
+		}
+		select {
+		case cp.incomingMessageChan <- message:
+		default:
+			// The tests only wait for a handful of messages, so
+			// the rest are dropped once the buffer fills up
+		}
+	}
+}
+
+func (cp *chaosPeer) close() {
+	err := cp.gRPCConnection.Close()
+	if err != nil {
+		cp.t.Fatalf("Error closing the chaos peer connection: %+v", err)
+	}
+}
+
+// sendRaw sends the given bytes as a single frame
+func (cp *chaosPeer) sendRaw(frame []byte) {
+	err := cp.stream.SendMsg(rawFrame(frame))
+	if err != nil {
+		cp.t.Fatalf("Error sending a frame: %+v", err)
+	}
+}
+
+// sendProto sends the given message, which doesn't have to pass the
+// validations done when converting an appmessage to its protowire form
+func (cp *chaosPeer) sendProto(message *protowire.KaspadMessage) {
+	frame, err := proto.Marshal(message)
+	if err != nil {
+		cp.t.Fatalf("Error encoding a %T message: %+v", message.Payload, err)
+	}
+	cp.sendRaw(frame)
+}
+
+func (cp *chaosPeer) sendMessage(message appmessage.Message) {
+	cp.sendProto(cp.toProto(message))
+}
+
+func (cp *chaosPeer) toProto(message appmessage.Message) *protowire.KaspadMessage {
+	protoMessage, err := protowire.FromAppMessage(message)
+	if err != nil {
+		cp.t.Fatalf("Error converting a %s message: %+v", message.Command(), err)
+	}
+	return protoMessage
+}
+
+// waitForMessage waits for a message with the given command, skipping all others
+func (cp *chaosPeer) waitForMessage(command appmessage.MessageCommand) appmessage.Message {
+	timeout := time.After(defaultTimeout)
+	for {
+		select {
+		case protoMessage := <-cp.incomingMessageChan:
+			message, err := protoMessage.ToAppMessage()
+			if err != nil {
+				cp.t.Fatalf("Error converting a %T message: %+v", protoMessage.Payload, err)
+			}
+			if message.Command() == command {
+				return message
+			}
+		case <-cp.disconnectedChan:
+			cp.t.Fatalf("Disconnected while waiting for a %s message", command)
+		case <-timeout:
+			cp.t.Fatalf("Timed out waiting for a %s message", command)
+		}
+	}
+}
+
+// waitForDisconnect waits for the node to close the connection,
+// and returns the commands of the messages received meanwhile
+func (cp *chaosPeer) waitForDisconnect() []appmessage.MessageCommand {
+	var commands []appmessage.MessageCommand
+	timeout := time.After(defaultTimeout)
+	for {
+		select {
+		case protoMessage := <-cp.incomingMessageChan:
+			message, err := protoMessage.ToAppMessage()
+			if err != nil {
+				cp.t.Fatalf("Error converting a %T message: %+v", protoMessage.Payload, err)
+			}
+			commands = append(commands, message.Command())
+		case <-cp.disconnectedChan:
+			return commands
+		case <-timeout:
+			cp.t.Fatalf("Timed out waiting for the node to disconnect")
+		}
+	}
+}
+
+func (cp *chaosPeer) newVersionMessage() *appmessage.MsgVersion {
+	peerID, err := id.GenerateID()
+	if err != nil {
+		cp.t.Fatalf("Error generating a peer ID: %+v", err)
+	}
+	version := appmessage.NewMsgVersion(nil, peerID, cp.network, nil, 5)
+	version.UserAgent = "/chaos-peer/"
+	return version
+}
+
+// handshake completes the handshake with the node, and waits for its flows to
+// start, after which the node doesn't expect any more handshake messages
+func (cp *chaosPeer) handshake() {
+	cp.waitForMessage(appmessage.CmdVersion)
+	cp.sendMessage(cp.newVersionMessage())
+	cp.waitForMessage(appmessage.CmdVerAck)
+	cp.sendMessage(appmessage.NewMsgVerAck())
+	cp.sendMessage(appmessage.NewMsgReady())
+	cp.waitForMessage(appmessage.CmdReady)
+
+	cp.waitForMessage(appmessage.CmdRequestAddresses)
+	cp.sendMessage(appmessage.NewMsgAddresses(nil))
+}
+
+// chaosPeerIP is the IP the chaos peers connect to the node from
+const chaosPeerIP = "127.0.0.1"
+
+func isChaosPeerBanned(t *testing.T, harness *appHarness) bool {
+	response, err := harness.rpcClient.GetPeerAddresses()
+	if err != nil {
+		t.Fatalf("Error getting the peer addresses: %+v", err)
+	}
+	for _, bannedAddress := range response.BannedAddresses {
+		host, _, err := net.SplitHostPort(bannedAddress.Addr)
+		if err != nil {
+			t.Fatalf("Error parsing banned address %s: %+v", bannedAddress.Addr, err)
+		}
+		if host == chaosPeerIP {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForChaosPeerBan waits for the node to ban the IP of the chaos peers.
+// The node may disconnect a peer before it's done banning it.
+func waitForChaosPeerBan(t *testing.T, harness *appHarness) {
+	start := time.Now()
+	for !isChaosPeerBanned(t, harness) {
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the chaos peer to be banned")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
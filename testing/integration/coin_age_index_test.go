@@ -0,0 +1,106 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestCoinAgeIndex(t *testing.T) {
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		coinAgeIndex:            true,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	// Mine a mature coinbase and spend it
+	var minedBlocks []*externalapi.DomainBlock
+	minedBlocks = append(minedBlocks, mineNextBlock(t, kaspad))
+	fundingBlock := mineNextBlock(t, kaspad)
+	minedBlocks = append(minedBlocks, fundingBlock)
+	for i := uint64(0); i < kaspad.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		minedBlocks = append(minedBlocks, mineNextBlock(t, kaspad))
+	}
+	fundingCoinbase := fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	msgTx := generateTx(t, fundingCoinbase, kaspad, kaspad)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	_, err := kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+
+	// The transaction is accepted by the selected child of the block that includes it
+	minedBlocks = append(minedBlocks, mineNextBlock(t, kaspad))
+	acceptingBlock := mineNextBlock(t, kaspad)
+	minedBlocks = append(minedBlocks, acceptingBlock)
+	acceptingBlockHash := consensushashing.BlockHash(acceptingBlock).String()
+
+	var response *appmessage.GetCoinAgeAnalyticsResponseMessage
+	start := time.Now()
+	for {
+		response, err = kaspad.rpcClient.GetCoinAgeAnalytics(0)
+		if err != nil {
+			t.Fatalf("Error getting coin age analytics: %+v", err)
+		}
+		if len(response.ChainBlocks) > 0 &&
+			response.ChainBlocks[len(response.ChainBlocks)-1].BlockHash == acceptingBlockHash {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the accepting block to be indexed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if len(response.ChainBlocks) != len(minedBlocks) {
+		t.Fatalf("Unexpected amount of chain blocks. Want: %d, got: %d", len(minedBlocks), len(response.ChainBlocks))
+	}
+	for _, chainBlock := range response.ChainBlocks[:len(response.ChainBlocks)-1] {
+		if chainBlock.SpentAmount != 0 || chainBlock.CoinDaysDestroyed != 0 {
+			t.Fatalf("Chain block %s unexpectedly destroyed %f coin-days", chainBlock.BlockHash, chainBlock.CoinDaysDestroyed)
+		}
+	}
+	acceptingChainBlock := response.ChainBlocks[len(response.ChainBlocks)-1]
+	if acceptingChainBlock.SpentAmount != fundingCoinbase.Outputs[0].Value {
+		t.Fatalf("Unexpected spent amount. Want: %d, got: %d",
+			fundingCoinbase.Outputs[0].Value, acceptingChainBlock.SpentAmount)
+	}
+	if acceptingChainBlock.CoinDaysDestroyed <= 0 {
+		t.Fatalf("Unexpected coin-days destroyed %f", acceptingChainBlock.CoinDaysDestroyed)
+	}
+
+	// The coinbase transaction of the virtual selected parent is accepted by the
+	// virtual rather than by a chain block, so its outputs aren't unspent yet. The
+	// spending transaction replaced the spent output with a single output, minus its fee.
+	expectedUTXOCount := uint64(0)
+	expectedTotalAmount := uint64(0)
+	for _, block := range minedBlocks[:len(minedBlocks)-1] {
+		for _, output := range block.Transactions[transactionhelper.CoinbaseTransactionIndex].Outputs {
+			expectedUTXOCount++
+			expectedTotalAmount += output.Value
+		}
+	}
+	expectedTotalAmount -= fundingCoinbase.Outputs[0].Value - msgTx.TxOut[0].Value
+
+	utxoCount := uint64(0)
+	totalAmount := uint64(0)
+	for _, ageWindow := range response.UTXOAgeWindows {
+		if ageWindow.StartDAAScore > response.DAAScore {
+			t.Fatalf("Age window %d starts after the DAA score %d", ageWindow.StartDAAScore, response.DAAScore)
+		}
+		utxoCount += ageWindow.UTXOCount
+		totalAmount += ageWindow.TotalAmount
+	}
+	if utxoCount != expectedUTXOCount || totalAmount != expectedTotalAmount {
+		t.Fatalf("Unexpected UTXO age distribution. Want %d UTXOs worth %d, got %d UTXOs worth %d",
+			expectedUTXOCount, expectedTotalAmount, utxoCount, totalAmount)
+	}
+}
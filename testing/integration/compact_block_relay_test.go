@@ -0,0 +1,86 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+// TestCompactBlockRelayFromMempool makes sure that a node rebuilds a relayed
+// compact block out of the transactions it already has in its mempool
+func TestCompactBlockRelayFromMempool(t *testing.T) {
+	payer, payee, _, teardown := standardSetup(t)
+	defer teardown()
+
+	connect(t, payer, payee)
+
+	payeeBlockAddedChan := make(chan *appmessage.RPCBlockHeader)
+	setOnBlockAddedHandler(t, payee, func(notification *appmessage.BlockAddedNotificationMessage) {
+		payeeBlockAddedChan <- notification.Block.Header
+	})
+	// skip the first block because it's paying to genesis script
+	mineNextBlock(t, payer)
+	waitForPayeeToReceiveBlock(t, payeeBlockAddedChan)
+	// use the second block to get money to pay with
+	secondBlock := mineNextBlock(t, payer)
+	waitForPayeeToReceiveBlock(t, payeeBlockAddedChan)
+
+	// Mine BlockCoinbaseMaturity more blocks for our money to mature
+	for i := uint64(0); i < payer.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, payer)
+		waitForPayeeToReceiveBlock(t, payeeBlockAddedChan)
+	}
+
+	// Sleep for `TransactionIDPropagationInterval` to make sure that our transaction will
+	// be propagated
+	time.Sleep(flowcontext.TransactionIDPropagationInterval)
+
+	msgTx := generateTx(t, secondBlock.Transactions[transactionhelper.CoinbaseTransactionIndex], payer, payee)
+	domainTransaction := appmessage.MsgTxToDomainTransaction(msgTx)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(domainTransaction)
+	response, err := payer.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+	txID := response.TransactionID
+
+	waitForMempoolEntry := func(shouldExist bool) {
+		deadline := time.Now().Add(defaultTimeout)
+		for {
+			_, err := payee.rpcClient.GetMempoolEntry(txID, false, false, false)
+			if err != nil && !strings.Contains(err.Error(), "not found") {
+				t.Fatalf("Error getting mempool entry: %+v", err)
+			}
+			if (err == nil) == shouldExist {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Timeout waiting for transaction %s to be in the mempool of the payee: %t",
+					txID, shouldExist)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitForMempoolEntry(true)
+
+	// The payee already has the only non-coinbase transaction of the block in
+	// its mempool, so it rebuilds the block instead of downloading it
+	block := mineNextBlock(t, payer)
+	if len(block.Transactions) != 2 {
+		t.Fatalf("Expected the block to contain the coinbase and the submitted transaction, "+
+			"but it contains %d transactions", len(block.Transactions))
+	}
+	waitForPayeeToReceiveBlock(t, payeeBlockAddedChan)
+
+	blockHash := consensushashing.BlockHash(block).String()
+	_, err = payee.rpcClient.GetBlock(blockHash, true)
+	if err != nil {
+		t.Fatalf("Error getting the relayed block from the payee: %+v", err)
+	}
+	waitForMempoolEntry(false)
+}
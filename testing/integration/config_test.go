@@ -2,6 +2,7 @@ package integration
 
 import (
 	"io/ioutil"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -39,8 +40,53 @@ func setConfig(t *testing.T, harness *appHarness, protocolVersion uint32) {
 	harness.config.AppDir = randomDirectory(t)
 	harness.config.Listeners = []string{harness.p2pAddress}
 	harness.config.RPCListeners = []string{harness.rpcAddress}
+	harness.config.RPCEndpoints = harness.rpcEndpoints
+	harness.config.RPCUnixSocket = harness.rpcUnixSocket
+	harness.config.RPCUnixSocketFileMode = 0600
+	if harness.rpcWebSocketAddress != "" {
+		harness.config.RPCWebSocketListeners = []string{harness.rpcWebSocketAddress}
+	}
+	harness.config.RPCCookie = harness.rpcCookie
+	harness.config.RPCCredentials = harness.rpcCredentials
+	harness.config.RPCTLS = harness.rpcTLS
+	harness.config.RPCCert = filepath.Join(harness.config.AppDir, "rpc.cert")
+	harness.config.RPCKey = filepath.Join(harness.config.AppDir, "rpc.key")
+	if harness.rpcCookie {
+		harness.config.RPCCookieFile = filepath.Join(harness.config.AppDir, config.RPCCookieFilename)
+	}
 	harness.config.UTXOIndex = harness.utxoIndex
+	harness.config.STXOIndex = harness.stxoIndex
+	harness.config.ScriptClassIndex = harness.scriptClassIndex
+	harness.config.FeeHistoryIndex = harness.feeHistoryIndex
+	harness.config.CoinAgeIndex = harness.coinAgeIndex
+	harness.config.TXIndex = harness.txIndex
+	harness.config.AddrIndex = harness.addrIndex
+	harness.config.STXOIndexMaxSize = harness.stxoIndexMaxSize
+	harness.config.EnableBanning = harness.enableBanning
+	harness.config.HeadersOnly = harness.headersOnly
+	harness.config.SQLMirror = harness.sqlMirror
+	if harness.sqlMirrorSchema != "" {
+		harness.config.SQLMirrorSchema = harness.sqlMirrorSchema
+	}
+	harness.config.KafkaBrokers = harness.kafkaBrokers
+	if harness.kafkaTopicPrefix != "" {
+		harness.config.KafkaTopicPrefix = harness.kafkaTopicPrefix
+	}
+	harness.config.TemplatePush = harness.templatePush
+	harness.config.TemplatePushPayAddress = harness.miningAddress
+	harness.config.StratumListen = harness.stratumListen
+	harness.config.StratumPayAddress = harness.miningAddress
+	if harness.stratumShareDifficulty != 0 {
+		harness.config.StratumShareDifficulty = harness.stratumShareDifficulty
+	}
+	if harness.payoutWatch {
+		harness.config.PayoutWatchAddress = harness.miningAddress
+	}
+	harness.config.BlockRateMonitor = harness.blockRateMonitor
 	harness.config.AllowSubmitBlockWhenNotSynced = true
+	if harness.dbType != "" {
+		harness.config.DbType = harness.dbType
+	}
 	if protocolVersion != 0 {
 		harness.config.ProtocolVersion = protocolVersion
 	}
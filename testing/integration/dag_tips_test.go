@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+)
+
+func TestGetDAGTips(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	mineNextBlock(t, kaspad)
+
+	// Two blocks mined from the same template are siblings, and are both tips
+	blockTemplate, err := kaspad.rpcClient.GetBlockTemplate(kaspad.miningAddress, "integration")
+	if err != nil {
+		t.Fatalf("Error getting block template: %+v", err)
+	}
+	rd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	siblingHashes := make(map[string]struct{})
+	for len(siblingHashes) < 2 {
+		block, err := appmessage.RPCBlockToDomainBlock(blockTemplate.Block)
+		if err != nil {
+			t.Fatalf("Error converting block: %s", err)
+		}
+		mining.SolveBlock(block, rd)
+		blockHash := consensushashing.BlockHash(block).String()
+		if _, ok := siblingHashes[blockHash]; ok {
+			continue
+		}
+		_, err = kaspad.rpcClient.SubmitBlockAlsoIfNonDAA(block)
+		if err != nil {
+			t.Fatalf("Error submitting block: %s", err)
+		}
+		siblingHashes[blockHash] = struct{}{}
+	}
+
+	var tips []*appmessage.DAGTip
+	start := time.Now()
+	for {
+		response, err := kaspad.rpcClient.GetDAGTips()
+		if err != nil {
+			t.Fatalf("Error getting the DAG tips: %+v", err)
+		}
+		tips = response.Tips
+		if len(tips) == 2 && tips[0].IsReceiveTimeKnown && tips[1].IsReceiveTimeKnown {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the receive times of both tips. Got: %+v", tips)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	virtualSelectedParentCount := 0
+	for _, tip := range tips {
+		if _, ok := siblingHashes[tip.Hash]; !ok {
+			t.Fatalf("Unexpected tip %s", tip.Hash)
+		}
+		if !tip.IsVirtualParent || tip.BlueScore != 2 || tip.TimeSinceReceived < 0 {
+			t.Fatalf("Unexpected tip %+v", tip)
+		}
+		if tip.IsVirtualSelectedParent {
+			virtualSelectedParentCount++
+		}
+	}
+	if virtualSelectedParentCount != 1 {
+		t.Fatalf("Expected exactly one of the tips to be the virtual selected parent, but got %d",
+			virtualSelectedParentCount)
+	}
+
+	// Once a block is mined on top of both, it's the only tip
+	block := mineNextBlock(t, kaspad)
+	response, err := kaspad.rpcClient.GetDAGTips()
+	if err != nil {
+		t.Fatalf("Error getting the DAG tips: %+v", err)
+	}
+	if len(response.Tips) != 1 || response.Tips[0].Hash != consensushashing.BlockHash(block).String() {
+		t.Fatalf("Expected the mined block to be the only tip, but got %+v", response.Tips)
+	}
+}
@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestFeeHistoryIndex(t *testing.T) {
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		feeHistoryIndex:         true,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	// Mine a mature coinbase and spend it
+	mineNextBlock(t, kaspad)
+	fundingBlock := mineNextBlock(t, kaspad)
+	for i := uint64(0); i < kaspad.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, kaspad)
+	}
+	fundingCoinbase := fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	msgTx := generateTx(t, fundingCoinbase, kaspad, kaspad)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	_, err := kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+
+	// The transaction is accepted by the selected child of the block that includes it
+	mineNextBlock(t, kaspad)
+	acceptingBlock := mineNextBlock(t, kaspad)
+	acceptingBlockHash := consensushashing.BlockHash(acceptingBlock).String()
+
+	const window = 3
+	var response *appmessage.GetFeeHistoryResponseMessage
+	start := time.Now()
+	for {
+		response, err = kaspad.rpcClient.GetFeeHistory(window)
+		if err != nil {
+			t.Fatalf("Error getting the fee history: %+v", err)
+		}
+		if len(response.ChainBlocks) > 0 &&
+			response.ChainBlocks[len(response.ChainBlocks)-1].BlockHash == acceptingBlockHash {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the accepting block to be indexed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if len(response.ChainBlocks) != window {
+		t.Fatalf("Unexpected amount of chain blocks. Want: %d, got: %d", window, len(response.ChainBlocks))
+	}
+	for i := 1; i < len(response.ChainBlocks); i++ {
+		if response.ChainBlocks[i].BlueScore <= response.ChainBlocks[i-1].BlueScore {
+			t.Fatalf("The chain blocks are not ordered by blue score: %d after %d",
+				response.ChainBlocks[i].BlueScore, response.ChainBlocks[i-1].BlueScore)
+		}
+	}
+	for _, chainBlock := range response.ChainBlocks[:len(response.ChainBlocks)-1] {
+		if chainBlock.TransactionCount != 0 {
+			t.Fatalf("Chain block %s unexpectedly accepted %d transactions",
+				chainBlock.BlockHash, chainBlock.TransactionCount)
+		}
+	}
+
+	acceptingChainBlock := response.ChainBlocks[len(response.ChainBlocks)-1]
+	if acceptingChainBlock.TransactionCount != 1 {
+		t.Fatalf("Unexpected transaction count. Want: 1, got: %d", acceptingChainBlock.TransactionCount)
+	}
+	if len(acceptingChainBlock.FeeRatePercentiles) != len(response.Percentiles) {
+		t.Fatalf("Got %d fee rates for %d percentiles",
+			len(acceptingChainBlock.FeeRatePercentiles), len(response.Percentiles))
+	}
+	// All the percentiles of a single transaction are its own fee rate
+	feeRate := acceptingChainBlock.FeeRatePercentiles[0]
+	if feeRate <= 0 {
+		t.Fatalf("Unexpected fee rate %f", feeRate)
+	}
+	for _, percentileFeeRate := range acceptingChainBlock.FeeRatePercentiles {
+		if percentileFeeRate != feeRate {
+			t.Fatalf("Unexpected fee rate percentiles %v", acceptingChainBlock.FeeRatePercentiles)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestGetBlockHeaders(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	const blockCount = 5
+	expectedHashes := make([]string, blockCount)
+	for i := 0; i < blockCount; i++ {
+		block := mineNextBlock(t, kaspad)
+		expectedHashes[i] = consensushashing.BlockHash(block).String()
+	}
+
+	response, err := kaspad.rpcClient.GetBlockHeaders("", 0)
+	if err != nil {
+		t.Fatalf("Error getting block headers: %+v", err)
+	}
+	if len(response.Headers) != blockCount {
+		t.Fatalf("Unexpected amount of headers. Want: %d, got: %d", blockCount, len(response.Headers))
+	}
+	for i, serializedHeader := range response.Headers {
+		headerBytes, err := hex.DecodeString(serializedHeader)
+		if err != nil {
+			t.Fatalf("Error decoding header %d: %s", i, err)
+		}
+		header, err := blockheader.DeserializeHeader(bytes.NewReader(headerBytes))
+		if err != nil {
+			t.Fatalf("Error deserializing header %d: %s", i, err)
+		}
+		headerHash := consensushashing.HeaderHash(header).String()
+		if headerHash != expectedHashes[i] {
+			t.Fatalf("Unexpected header %d. Want: %s, got: %s", i, expectedHashes[i], headerHash)
+		}
+	}
+	if response.HighHash != expectedHashes[blockCount-1] {
+		t.Fatalf("Unexpected high hash. Want: %s, got: %s", expectedHashes[blockCount-1], response.HighHash)
+	}
+
+	// The next batch starts right above lowHash
+	response, err = kaspad.rpcClient.GetBlockHeaders(expectedHashes[2], 0)
+	if err != nil {
+		t.Fatalf("Error getting block headers: %+v", err)
+	}
+	if len(response.Headers) != 2 || response.HighHash != expectedHashes[blockCount-1] {
+		t.Fatalf("Expected the 2 headers above %s, but got %d headers up to %s",
+			expectedHashes[2], len(response.Headers), response.HighHash)
+	}
+
+	response, err = kaspad.rpcClient.GetBlockHeaders(response.HighHash, 0)
+	if err != nil {
+		t.Fatalf("Error getting block headers: %+v", err)
+	}
+	if len(response.Headers) != 0 || response.HighHash != "" {
+		t.Fatalf("Expected no headers above the headers selected tip, but got %d", len(response.Headers))
+	}
+
+	_, err = kaspad.rpcClient.GetBlockHeaders("invalid", 0)
+	if err == nil {
+		t.Fatalf("Expected an error for an invalid lowHash")
+	}
+}
@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestGetBlocksPagination(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	const blockCount = 10
+	minedHashes := make(map[string]struct{}, blockCount)
+	for i := 0; i < blockCount; i++ {
+		block := mineNextBlock(t, kaspad)
+		minedHashes[consensushashing.BlockHash(block).String()] = struct{}{}
+	}
+
+	const limit = 3
+	returnedHashes := make(map[string]struct{})
+	lowHash := ""
+	for pageCount := 0; ; pageCount++ {
+		if pageCount > blockCount {
+			t.Fatalf("The pages did not advance")
+		}
+		response, err := kaspad.rpcClient.GetBlocksPage(lowHash, appmessage.RPCVerbosityHashesOnly, limit)
+		if err != nil {
+			t.Fatalf("GetBlocksPage: %+v", err)
+		}
+		if len(response.BlockHashes) > limit || len(response.Blocks) != 0 {
+			t.Fatalf("Unexpected page of %d hashes and %d blocks", len(response.BlockHashes), len(response.Blocks))
+		}
+		for _, blockHash := range response.BlockHashes {
+			returnedHashes[blockHash] = struct{}{}
+		}
+		lowHash = response.NextLowHash
+		if !response.HasMore {
+			break
+		}
+	}
+	for blockHash := range minedHashes {
+		if _, ok := returnedHashes[blockHash]; !ok {
+			t.Fatalf("Mined block %s was not returned in any page", blockHash)
+		}
+	}
+
+	// Polling from the last nextLowHash returns only the blocks that were
+	// added since
+	block := mineNextBlock(t, kaspad)
+	response, err := kaspad.rpcClient.GetBlocksPage(lowHash, appmessage.RPCVerbosityHeadersOnly, limit)
+	if err != nil {
+		t.Fatalf("GetBlocksPage: %+v", err)
+	}
+	blockHash := consensushashing.BlockHash(block).String()
+	if len(response.BlockHashes) != 2 || response.BlockHashes[0] != lowHash || response.BlockHashes[1] != blockHash ||
+		response.HasMore || response.NextLowHash != blockHash {
+		t.Fatalf("Unexpected response when polling for new blocks: %+v", response)
+	}
+	if len(response.Blocks) != 2 || response.Blocks[1].Header == nil {
+		t.Fatalf("Expected the headers of the blocks, but got %+v", response.Blocks)
+	}
+
+	_, err = kaspad.rpcClient.GetBlocksPage(lowHash, appmessage.RPCVerbosityHashesOnly, 1)
+	if err == nil {
+		t.Fatalf("Expected a limit of 1 to be rejected")
+	}
+}
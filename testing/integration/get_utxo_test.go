@@ -0,0 +1,123 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestGetUTXO(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		txIndex:                 true,
+	})
+	defer teardown()
+
+	mineNextBlock(t, kaspad)
+	fundingBlock := mineNextBlock(t, kaspad)
+	fundingBlockChild := mineNextBlock(t, kaspad)
+	for i := uint64(1); i < kaspad.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, kaspad)
+	}
+	fundingCoinbase := fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	fundingOutpoint := &appmessage.RPCOutpoint{
+		TransactionID: consensushashing.TransactionID(fundingCoinbase).String(),
+		Index:         0,
+	}
+
+	// The coinbase is accepted by the selected child of the block that includes it
+	fundingBlockChildHash := consensushashing.BlockHash(fundingBlockChild).String()
+	start := time.Now()
+	for {
+		response, err := kaspad.rpcClient.GetUTXO(fundingOutpoint, false)
+		if err != nil {
+			t.Fatalf("Error getting the UTXO: %+v", err)
+		}
+		if !response.IsUnspent || response.IsInMempool {
+			t.Fatalf("Expected the coinbase output to be unspent in the UTXO set, but got %+v", response)
+		}
+		if response.UTXOEntry.Amount != fundingCoinbase.Outputs[0].Value || !response.UTXOEntry.IsCoinbase {
+			t.Fatalf("Unexpected UTXO entry %+v", response.UTXOEntry)
+		}
+		if response.AcceptingBlockHash == fundingBlockChildHash {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the accepting block of the coinbase. Want: %s, got: %s",
+				fundingBlockChildHash, response.AcceptingBlockHash)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	msgTx := generateTx(t, fundingCoinbase, kaspad, kaspad)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	submitTransactionResponse, err := kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+	transactionOutpoint := &appmessage.RPCOutpoint{
+		TransactionID: submitTransactionResponse.TransactionID,
+		Index:         0,
+	}
+
+	response, err := kaspad.rpcClient.GetUTXO(fundingOutpoint, true)
+	if err != nil {
+		t.Fatalf("Error getting the UTXO: %+v", err)
+	}
+	if response.IsUnspent || response.SpendingMempoolTransactionID != submitTransactionResponse.TransactionID {
+		t.Fatalf("Expected the coinbase output to be spent by transaction %s in the mempool, but got %+v",
+			submitTransactionResponse.TransactionID, response)
+	}
+	response, err = kaspad.rpcClient.GetUTXO(fundingOutpoint, false)
+	if err != nil {
+		t.Fatalf("Error getting the UTXO: %+v", err)
+	}
+	if !response.IsUnspent {
+		t.Fatalf("Expected the coinbase output to be unspent when the mempool is excluded")
+	}
+
+	response, err = kaspad.rpcClient.GetUTXO(transactionOutpoint, true)
+	if err != nil {
+		t.Fatalf("Error getting the UTXO: %+v", err)
+	}
+	if !response.IsUnspent || !response.IsInMempool || response.UTXOEntry.Amount != msgTx.TxOut[0].Value {
+		t.Fatalf("Expected the transaction output to be unspent in the mempool, but got %+v", response)
+	}
+	response, err = kaspad.rpcClient.GetUTXO(transactionOutpoint, false)
+	if err != nil {
+		t.Fatalf("Error getting the UTXO: %+v", err)
+	}
+	if response.IsUnspent {
+		t.Fatalf("Expected the transaction output not to be found when the mempool is excluded")
+	}
+
+	// Once the transaction is accepted, its output is in the UTXO set
+	mineNextBlock(t, kaspad)
+	mineNextBlock(t, kaspad)
+
+	response, err = kaspad.rpcClient.GetUTXO(transactionOutpoint, false)
+	if err != nil {
+		t.Fatalf("Error getting the UTXO: %+v", err)
+	}
+	if !response.IsUnspent || response.IsInMempool {
+		t.Fatalf("Expected the transaction output to be unspent in the UTXO set, but got %+v", response)
+	}
+	response, err = kaspad.rpcClient.GetUTXO(fundingOutpoint, true)
+	if err != nil {
+		t.Fatalf("Error getting the UTXO: %+v", err)
+	}
+	if response.IsUnspent || response.SpendingMempoolTransactionID != "" {
+		t.Fatalf("Expected the coinbase output to be spent, but got %+v", response)
+	}
+
+	_, err = kaspad.rpcClient.GetUTXO(&appmessage.RPCOutpoint{TransactionID: "invalid"}, true)
+	if err == nil {
+		t.Fatalf("Expected an error for an invalid transaction ID")
+	}
+}
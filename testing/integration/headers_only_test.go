@@ -0,0 +1,86 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+func TestHeadersOnly(t *testing.T) {
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+			headersOnly:             true,
+		},
+	})
+	defer teardown()
+	fullNode, headersOnlyNode := harnesses[0], harnesses[1]
+
+	// The headers mined before connecting are synced via IBD
+	for i := 0; i < 3; i++ {
+		mineNextBlock(t, fullNode)
+	}
+	connect(t, fullNode, headersOnlyNode)
+	waitForHeadersSelectedTip(t, headersOnlyNode, fullNode)
+
+	// The headers mined afterwards are synced via relay
+	mineNextBlock(t, fullNode)
+	lastBlock := mineNextBlock(t, fullNode)
+	headersSelectedTip := waitForHeadersSelectedTip(t, headersOnlyNode, fullNode)
+	if headersSelectedTip.Difficulty <= 0 || headersSelectedTip.DAAScore != lastBlock.Header.DAAScore() {
+		t.Fatalf("Unexpected headers selected tip: %+v", headersSelectedTip)
+	}
+
+	blockCount, err := headersOnlyNode.rpcClient.GetBlockCount()
+	if err != nil {
+		t.Fatalf("Error getting the block count: %+v", err)
+	}
+	// Both counts include the genesis
+	if blockCount.HeaderCount != 6 || blockCount.BlockCount != 1 {
+		t.Fatalf("Expected 6 headers and only the genesis block, but got %d headers and %d blocks",
+			blockCount.HeaderCount, blockCount.BlockCount)
+	}
+
+	_, err = headersOnlyNode.rpcClient.GetBlockDAGInfo()
+	rpcError := &rpcclient.RPCError{}
+	if !errors.As(err, &rpcError) || rpcError.Code != appmessage.RPCErrorCodeMethodUnavailable {
+		t.Fatalf("Expected a %s error, but got %+v", appmessage.RPCErrorCodeMethodUnavailable, err)
+	}
+}
+
+// waitForHeadersSelectedTip waits until the headers selected tip of the given
+// node equals the one of the expected node, and returns it
+func waitForHeadersSelectedTip(t *testing.T, node, expectedNode *appHarness) *appmessage.GetHeadersSelectedTipResponseMessage {
+	expected, err := expectedNode.rpcClient.GetHeadersSelectedTip()
+	if err != nil {
+		t.Fatalf("Error getting the headers selected tip: %+v", err)
+	}
+
+	start := time.Now()
+	for {
+		headersSelectedTip, err := node.rpcClient.GetHeadersSelectedTip()
+		if err != nil {
+			t.Fatalf("Error getting the headers selected tip: %+v", err)
+		}
+		if headersSelectedTip.Hash == expected.Hash {
+			return headersSelectedTip
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the headers selected tip %s, while it's still %s",
+				expected.Hash, headersSelectedTip.Hash)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
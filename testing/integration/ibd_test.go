@@ -68,6 +68,75 @@ func TestIBD(t *testing.T) {
 	}
 }
 
+// TestIBDFromSeveralPeers checks that IBD succeeds when the bodies of
+// the blocks may be downloaded from several peers in parallel
+func TestIBDFromSeveralPeers(t *testing.T) {
+	const numBlocks = 300
+
+	syncer, helper, syncee, teardown := standardSetup(t)
+	defer teardown()
+
+	for i := 0; i < numBlocks; i++ {
+		mineNextBlock(t, syncer)
+	}
+
+	// ibd connects the given harness to the given peers and waits
+	// until it receives all the blocks
+	ibd := func(harness *appHarness, peers ...*appHarness) {
+		blockAddedWG := sync.WaitGroup{}
+		blockAddedWG.Add(numBlocks)
+		receivedBlocks := 0
+		isDone := false
+		setOnBlockAddedHandler(t, harness, func(_ *appmessage.BlockAddedNotificationMessage) {
+			if isDone {
+				return
+			}
+			receivedBlocks++
+			isDone = receivedBlocks == numBlocks
+			blockAddedWG.Done()
+		})
+
+		// The connections are requested at once, so that the IBD peer isn't
+		// done with the headers before the other peers are connected
+		for _, peer := range peers[1:] {
+			err := harness.rpcClient.AddPeer(peer.p2pAddress, false)
+			if err != nil {
+				t.Fatalf("Error connecting the nodes")
+			}
+		}
+		connect(t, peers[0], harness)
+
+		select {
+		case <-time.After(defaultTimeout):
+			t.Fatalf("Timeout waiting for IBD to finish. Received %d blocks out of %d", receivedBlocks, numBlocks)
+		case <-ReceiveFromChanWhenDone(func() { blockAddedWG.Wait() }):
+		}
+	}
+
+	ibd(helper, syncer)
+	// The syncee may download the block bodies from both the syncer and the helper
+	ibd(syncee, syncer, helper)
+
+	// Wait for the syncee to exit IBD
+	time.Sleep(time.Second)
+	// This should trigger resolving the syncee virtual
+	mineNextBlock(t, syncer)
+	time.Sleep(time.Second)
+
+	syncerTip, err := syncer.rpcClient.GetSelectedTipHash()
+	if err != nil {
+		t.Fatalf("Error getting tip for syncer")
+	}
+	synceeTip, err := syncee.rpcClient.GetSelectedTipHash()
+	if err != nil {
+		t.Fatalf("Error getting tip for syncee")
+	}
+	if syncerTip.SelectedTipHash != synceeTip.SelectedTipHash {
+		t.Errorf("Tips of syncer: '%s' and syncee '%s' are not equal",
+			syncerTip.SelectedTipHash, synceeTip.SelectedTipHash)
+	}
+}
+
 // TestIBDWithPruning checks the IBD from a node with
 // already pruned blocks.
 func TestIBDWithPruning(t *testing.T) {
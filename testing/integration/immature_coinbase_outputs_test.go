@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"testing"
+)
+
+func TestImmatureCoinbaseOutputs(t *testing.T) {
+	// Setup a single kaspad instance without a UTXO index, since the
+	// immature coinbase outputs are read from the DAG itself
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	coinbaseMaturity := kaspad.config.ActiveNetParams.BlockCoinbaseMaturity
+	for i := uint64(0); i < 2*coinbaseMaturity; i++ {
+		mineNextBlock(t, kaspad)
+	}
+
+	response, err := kaspad.rpcClient.GetImmatureCoinbaseOutputs(miningAddress1)
+	if err != nil {
+		t.Fatalf("Error getting immature coinbase outputs: %+v", err)
+	}
+	if response.CoinbaseMaturity != coinbaseMaturity {
+		t.Fatalf("Unexpected coinbase maturity. Want: %d, got: %d", coinbaseMaturity, response.CoinbaseMaturity)
+	}
+	// Every chain block's coinbase pays the miner of its selected parent,
+	// and the coinbase of the virtual selected parent is accepted by the
+	// virtual, so exactly coinbaseMaturity outputs are still immature
+	if uint64(len(response.Outputs)) != coinbaseMaturity {
+		t.Fatalf("Unexpected amount of immature coinbase outputs. Want: %d, got: %d",
+			coinbaseMaturity, len(response.Outputs))
+	}
+	totalImmatureAmount := uint64(0)
+	for _, output := range response.Outputs {
+		if output.SpendableAtDAAScore != output.DAAScore+coinbaseMaturity {
+			t.Fatalf("Unexpected spendable DAA score %d for output accepted at DAA score %d",
+				output.SpendableAtDAAScore, output.DAAScore)
+		}
+		if output.SpendableAtDAAScore <= response.VirtualDAAScore {
+			t.Fatalf("Output %s:%d is reported as immature but is spendable at DAA score %d "+
+				"while the virtual DAA score is %d", output.Outpoint.TransactionID, output.Outpoint.Index,
+				output.SpendableAtDAAScore, response.VirtualDAAScore)
+		}
+		totalImmatureAmount += output.Amount
+	}
+	if totalImmatureAmount != response.TotalImmatureAmount {
+		t.Fatalf("Unexpected total immature amount. Want: %d, got: %d",
+			totalImmatureAmount, response.TotalImmatureAmount)
+	}
+
+	// Mining a single block makes the oldest immature output mature
+	oldestOutput := response.Outputs[len(response.Outputs)-1]
+	mineNextBlock(t, kaspad)
+	response, err = kaspad.rpcClient.GetImmatureCoinbaseOutputs(miningAddress1)
+	if err != nil {
+		t.Fatalf("Error getting immature coinbase outputs: %+v", err)
+	}
+	for _, output := range response.Outputs {
+		if *output.Outpoint == *oldestOutput.Outpoint {
+			t.Fatalf("Output %s:%d is still reported as immature at virtual DAA score %d",
+				output.Outpoint.TransactionID, output.Outpoint.Index, response.VirtualDAAScore)
+		}
+	}
+}
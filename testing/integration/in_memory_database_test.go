@@ -0,0 +1,40 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+func TestInMemoryDatabase(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		dbType:                  config.DbTypeMemory,
+	})
+	defer teardown()
+
+	const blockCount = 5
+	for i := 0; i < blockCount; i++ {
+		mineNextBlock(t, kaspad)
+	}
+
+	blockDAGInfo, err := kaspad.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error getting the block DAG info: %+v", err)
+	}
+	// The block count includes the genesis
+	if blockDAGInfo.BlockCount != blockCount+1 {
+		t.Fatalf("Expected %d blocks, but got %d", blockCount+1, blockDAGInfo.BlockCount)
+	}
+
+	// Nothing is written to the database directory
+	_, err = os.Stat(filepath.Join(kaspad.config.AppDir, "db"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("Expected the database directory not to exist, but got %v", err)
+	}
+}
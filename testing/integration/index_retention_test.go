@@ -0,0 +1,53 @@
+package integration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIndexRetentionStatus(t *testing.T) {
+	const stxoIndexMaxSize = 1_000_000
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		stxoIndex:               true,
+		feeHistoryIndex:         true,
+		stxoIndexMaxSize:        stxoIndexMaxSize,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	for i := 0; i < 5; i++ {
+		mineNextBlock(t, kaspad)
+	}
+
+	// The indexes are pruned as soon as the node starts, so wait for the first
+	// pruning to be reported. Only the STXO index has a retention limit.
+	start := time.Now()
+	for {
+		response, err := kaspad.rpcClient.GetIndexRetentionStatus()
+		if err != nil {
+			t.Fatalf("Error getting the index retention status: %+v", err)
+		}
+		if len(response.Indexes) != 1 {
+			t.Fatalf("Expected the retention status of a single index, but got %d", len(response.Indexes))
+		}
+		status := response.Indexes[0]
+		if status.IndexName != "STXO" || status.MaxSize != stxoIndexMaxSize || status.MaxAgeInSeconds != 0 {
+			t.Fatalf("Unexpected index retention status: %+v", status)
+		}
+		if status.LastPruneTimestamp != 0 {
+			// Nothing is reclaimed while the index is far below its maximum size
+			if status.PrunedChainBlockCount != 0 || status.ReclaimedBytes != 0 {
+				t.Fatalf("Unexpected index retention status: %+v", status)
+			}
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the STXO index to be pruned")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,103 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kversion"
+)
+
+// kafkaBrokerEnvironmentVariable is the environment variable that may hold
+// the address of a real broker to test against
+const kafkaBrokerEnvironmentVariable = "KASPAD_TEST_KAFKA_BROKER"
+
+// kafkaReadTimeout is the time readMessages waits for the expected messages
+const kafkaReadTimeout = 30 * time.Second
+
+// kafkaMessage is a message that was produced to a kafkaTestCluster
+type kafkaMessage struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// kafkaTestCluster is the Kafka cluster that kaspad publishes events to in
+// the tests. By default it's an in-process github.com/twmb/franz-go/pkg/kfake
+// cluster that speaks the wire protocol of Kafka 4.0. Setting the
+// KASPAD_TEST_KAFKA_BROKER environment variable to the host:port address of a
+// broker runs the tests against that broker instead.
+type kafkaTestCluster struct {
+	fakeCluster   *kfake.Cluster
+	brokerAddress string
+	topicPrefix   string
+}
+
+// newKafkaTestCluster returns a cluster whose topics are created with the
+// given amount of partitions once they're first produced to. A real broker
+// creates topics according to its own settings.
+func newKafkaTestCluster(t *testing.T, partitionCount int) *kafkaTestCluster {
+	// Every cluster gets its own topics, so that tests against a real
+	// broker don't read the messages of earlier runs
+	topicPrefix := fmt.Sprintf("kaspadtest%d", time.Now().UnixNano())
+
+	if brokerAddress := os.Getenv(kafkaBrokerEnvironmentVariable); brokerAddress != "" {
+		return &kafkaTestCluster{brokerAddress: brokerAddress, topicPrefix: topicPrefix}
+	}
+
+	fakeCluster, err := kfake.NewCluster(
+		kfake.NumBrokers(1),
+		kfake.DefaultNumPartitions(partitionCount),
+		kfake.AllowAutoTopicCreation(),
+		kfake.MaxVersions(kversion.V4_0_0()),
+	)
+	if err != nil {
+		t.Fatalf("Error starting a fake Kafka cluster: %s", err)
+	}
+	return &kafkaTestCluster{
+		fakeCluster:   fakeCluster,
+		brokerAddress: fakeCluster.ListenAddrs()[0],
+		topicPrefix:   topicPrefix,
+	}
+}
+
+func (c *kafkaTestCluster) close() {
+	if c.fakeCluster != nil {
+		c.fakeCluster.Close()
+	}
+}
+
+// readMessages reads the messages of the given topics from their beginning
+// until at least count messages were read, and returns them. Messages that
+// went to the same partition are returned in the order they were produced.
+func (c *kafkaTestCluster) readMessages(t *testing.T, count int, topics ...string) []*kafkaMessage {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(c.brokerAddress),
+		kgo.ConsumeTopics(topics...),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+		kgo.FetchMaxWait(100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Error creating a Kafka consumer: %s", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), kafkaReadTimeout)
+	defer cancel()
+
+	var messages []*kafkaMessage
+	for len(messages) < count {
+		fetches := client.PollFetches(ctx)
+		if ctx.Err() != nil {
+			t.Fatalf("Timed out reading messages from Kafka: read %d out of %d", len(messages), count)
+		}
+		for _, record := range fetches.Records() {
+			messages = append(messages, &kafkaMessage{Topic: record.Topic, Key: record.Key, Value: record.Value})
+		}
+	}
+	return messages
+}
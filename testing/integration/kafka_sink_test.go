@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/kafkasink"
+)
+
+func TestKafkaSink(t *testing.T) {
+	cluster := newKafkaTestCluster(t, 4)
+	defer cluster.close()
+	topicPrefix := cluster.topicPrefix
+
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		kafkaBrokers:            []string{cluster.brokerAddress},
+		kafkaTopicPrefix:        topicPrefix,
+	})
+	defer teardown()
+
+	var minedBlockHashes []string
+	mineBlock := func() {
+		block := mineNextBlock(t, kaspad)
+		minedBlockHashes = append(minedBlockHashes, consensushashing.BlockHash(block).String())
+	}
+
+	// The coinbase of the first block pays nothing, so the second one is spent
+	mineBlock()
+	fundingBlock := mineNextBlock(t, kaspad)
+	minedBlockHashes = append(minedBlockHashes, consensushashing.BlockHash(fundingBlock).String())
+	for i := uint64(0); i < kaspad.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineBlock()
+	}
+	fundingCoinbase := fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	msgTx := generateTx(t, fundingCoinbase, kaspad, kaspad)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	submitTransactionResponse, err := kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+	transactionID := submitTransactionResponse.TransactionID
+	mineBlock()
+	lastMinedBlockHash := minedBlockHashes[len(minedBlockHashes)-1]
+
+	type event struct {
+		Type      string `json:"type"`
+		Network   string `json:"network"`
+		BlockHash string `json:"blockHash"`
+	}
+
+	// Every mined block is added to the DAG and extends the selected parent
+	// chain, and the transaction is added to the mempool and confirmed
+	blocksTopic := topicPrefix + kafkasink.BlocksTopicSuffix
+	chainTopic := topicPrefix + kafkasink.ChainTopicSuffix
+	mempoolTopic := topicPrefix + kafkasink.MempoolTopicSuffix
+	messages := cluster.readMessages(t, len(minedBlockHashes), blocksTopic)
+	messages = append(messages, cluster.readMessages(t, len(minedBlockHashes), chainTopic)...)
+	messages = append(messages, cluster.readMessages(t, 2, mempoolTopic)...)
+
+	eventsByTopicAndKey := make(map[string][]*event)
+	for _, message := range messages {
+		var decodedEvent event
+		err := json.Unmarshal(message.Value, &decodedEvent)
+		if err != nil {
+			t.Fatalf("Error decoding event %s: %s", message.Value, err)
+		}
+		if decodedEvent.Network != kaspad.config.ActiveNetParams.Name {
+			t.Fatalf("Unexpected network in event %s", message.Value)
+		}
+		topicAndKey := message.Topic + "/" + string(message.Key)
+		eventsByTopicAndKey[topicAndKey] = append(eventsByTopicAndKey[topicAndKey], &decodedEvent)
+	}
+	if len(eventsByTopicAndKey[chainTopic+"/"+lastMinedBlockHash]) == 0 ||
+		len(eventsByTopicAndKey[mempoolTopic+"/"+transactionID]) != 2 {
+
+		t.Fatalf("Missing the events of block %s and transaction %s", lastMinedBlockHash, transactionID)
+	}
+
+	for _, blockHash := range minedBlockHashes {
+		blockEvents := eventsByTopicAndKey[blocksTopic+"/"+blockHash]
+		if len(blockEvents) != 1 || blockEvents[0].Type != "blockAdded" || blockEvents[0].BlockHash != blockHash {
+			t.Fatalf("Expected a single blockAdded event for block %s", blockHash)
+		}
+	}
+	chainEvents := eventsByTopicAndKey[chainTopic+"/"+lastMinedBlockHash]
+	if chainEvents[0].Type != "virtualSelectedParentChainChanged" {
+		t.Fatalf("Unexpected chain event type %s", chainEvents[0].Type)
+	}
+
+	// The mempool events of a transaction are published in order
+	mempoolEvents := eventsByTopicAndKey[mempoolTopic+"/"+transactionID]
+	if mempoolEvents[0].Type != "transactionAddedToMempool" || mempoolEvents[1].Type != "transactionConfirmed" ||
+		mempoolEvents[1].BlockHash != lastMinedBlockHash {
+
+		t.Fatalf("Unexpected mempool events for transaction %s: %s, %s",
+			transactionID, mempoolEvents[0].Type, mempoolEvents[1].Type)
+	}
+}
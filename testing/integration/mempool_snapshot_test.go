@@ -0,0 +1,48 @@
+package integration
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/mempoolsnapshot"
+)
+
+func TestExportMempoolSnapshot(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	_, err := kaspad.rpcClient.ExportMempoolSnapshot("mempool.json", true)
+	if err == nil || !strings.Contains(err.Error(), "must be absolute") {
+		t.Fatalf("Expected exporting to a relative path to fail, but got %v", err)
+	}
+
+	mineNextBlock(t, kaspad)
+	path := filepath.Join(t.TempDir(), "mempool.json")
+	response, err := kaspad.rpcClient.ExportMempoolSnapshot(path, true)
+	if err != nil {
+		t.Fatalf("ExportMempoolSnapshot: %+v", err)
+	}
+	if response.TransactionCount != 0 || response.OrphanCount != 0 {
+		t.Fatalf("Expected an empty snapshot, but got %d transactions", response.TransactionCount)
+	}
+
+	snapshot, err := mempoolsnapshot.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %+v", err)
+	}
+	if snapshot.Timestamp != response.Timestamp || snapshot.VirtualDAAScore == 0 {
+		t.Fatalf("Unexpected snapshot header %+v", snapshot)
+	}
+
+	// An existing file is never overwritten
+	_, err = kaspad.rpcClient.ExportMempoolSnapshot(path, true)
+	if err == nil || !strings.Contains(err.Error(), "file exists") {
+		t.Fatalf("Expected exporting to an existing file to fail, but got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestEnableIndexAtRuntime(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	block := mineNextBlock(t, kaspad)
+	mineNextBlock(t, kaspad)
+	coinbaseOutpoint := &appmessage.RPCOutpoint{
+		TransactionID: consensushashing.TransactionID(block.Transactions[transactionhelper.CoinbaseTransactionIndex]).String(),
+		Index:         0,
+	}
+
+	_, err := kaspad.rpcClient.GetOutpointSpendingTransaction(coinbaseOutpoint)
+	if err == nil {
+		t.Fatalf("Expected GetOutpointSpendingTransaction to fail while the STXO index is disabled")
+	}
+
+	_, err = kaspad.rpcClient.GetIndexStatus("nosuchindex")
+	if err == nil {
+		t.Fatalf("Expected GetIndexStatus to fail for an unknown index")
+	}
+
+	setIndexEnabledResponse, err := kaspad.rpcClient.SetIndexEnabled("stxoindex", true)
+	if err != nil {
+		t.Fatalf("Error enabling the STXO index: %+v", err)
+	}
+	if setIndexEnabledResponse.Status != rpccontext.IndexStatusBuilding &&
+		setIndexEnabledResponse.Status != rpccontext.IndexStatusReady {
+		t.Fatalf("Unexpected status of an index that was just enabled: %s", setIndexEnabledResponse.Status)
+	}
+
+	start := time.Now()
+	for {
+		getIndexStatusResponse, err := kaspad.rpcClient.GetIndexStatus("stxoindex")
+		if err != nil {
+			t.Fatalf("Error getting the index status: %+v", err)
+		}
+		if getIndexStatusResponse.Status == rpccontext.IndexStatusReady {
+			break
+		}
+		if getIndexStatusResponse.Status != rpccontext.IndexStatusBuilding {
+			t.Fatalf("Unexpected status of the STXO index: %s %s",
+				getIndexStatusResponse.Status, getIndexStatusResponse.BuildError)
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the STXO index to be built")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	response, err := kaspad.rpcClient.GetOutpointSpendingTransaction(coinbaseOutpoint)
+	if err != nil {
+		t.Fatalf("Error getting the spending transaction: %+v", err)
+	}
+	if response.IsSpent {
+		t.Fatalf("Expected the coinbase output to be unspent")
+	}
+
+	_, err = kaspad.rpcClient.SetIndexEnabled("stxoindex", false)
+	if err != nil {
+		t.Fatalf("Error disabling the STXO index: %+v", err)
+	}
+	_, err = kaspad.rpcClient.GetOutpointSpendingTransaction(coinbaseOutpoint)
+	if err == nil {
+		t.Fatalf("Expected GetOutpointSpendingTransaction to fail once the STXO index is disabled")
+	}
+}
@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestPayoutWatcher(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		payoutWatch:             true,
+	})
+	defer teardown()
+
+	// Every block is merged blue by the next one, except for the last one,
+	// which is still pending. The rewards of the earliest blocks mature.
+	blockCount := kaspad.config.ActiveNetParams.BlockCoinbaseMaturity + 5
+	var blocks []string
+	for i := uint64(0); i < blockCount; i++ {
+		blocks = append(blocks, consensushashing.BlockHash(mineNextBlock(t, kaspad)).String())
+	}
+	lastBlock := mineNextBlock(t, kaspad)
+	blocks = append(blocks, consensushashing.BlockHash(lastBlock).String())
+
+	// The virtual selected parent chain changes reach the watcher asynchronously
+	var stats *appmessage.GetPayoutStatsResponseMessage
+	timeout := time.After(defaultTimeout)
+	for {
+		var err error
+		stats, err = kaspad.rpcClient.GetPayoutStats(2)
+		if err != nil {
+			t.Fatalf("GetPayoutStats: %s", err)
+		}
+		if stats.BlueBlockCount == blockCount {
+			break
+		}
+		select {
+		case <-timeout:
+			t.Fatalf("Timed out waiting for %d blue blocks, got %d", blockCount, stats.BlueBlockCount)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if stats.Address != kaspad.miningAddress {
+		t.Fatalf("Expected the stats of %s, but got the ones of %s", kaspad.miningAddress, stats.Address)
+	}
+	if stats.RedBlockCount != 0 || stats.PendingBlockCount != 1 || stats.OrphanBlockCount != 0 {
+		t.Fatalf("Unexpected block counts: %d red, %d pending, %d orphans",
+			stats.RedBlockCount, stats.PendingBlockCount, stats.OrphanBlockCount)
+	}
+	if stats.MatureReward == 0 || stats.MatureReward >= stats.TotalReward {
+		t.Fatalf("Expected part of the total reward of %d to be mature, but %d is", stats.TotalReward, stats.MatureReward)
+	}
+
+	if len(stats.RecentBlocks) != 2 {
+		t.Fatalf("Expected 2 recent blocks, but got %d", len(stats.RecentBlocks))
+	}
+	pendingBlock, blueBlock := stats.RecentBlocks[0], stats.RecentBlocks[1]
+	if pendingBlock.BlockHash != blocks[len(blocks)-1] || pendingBlock.Status != "pending" {
+		t.Fatalf("Expected block %s to be pending, but got block %s with status %s",
+			blocks[len(blocks)-1], pendingBlock.BlockHash, pendingBlock.Status)
+	}
+	if blueBlock.BlockHash != blocks[len(blocks)-2] || blueBlock.Status != "blue" ||
+		blueBlock.MergingBlockHash != blocks[len(blocks)-1] {
+		t.Fatalf("Expected block %s to be merged blue by %s, but got block %s with status %s, merged by %s",
+			blocks[len(blocks)-2], blocks[len(blocks)-1], blueBlock.BlockHash, blueBlock.Status, blueBlock.MergingBlockHash)
+	}
+	expectedReward := lastBlock.Transactions[transactionhelper.CoinbaseTransactionIndex].Outputs[0].Value
+	if blueBlock.Reward != expectedReward || blueBlock.IsRewardMature {
+		t.Fatalf("Expected an immature reward of %d, but got %d, mature: %t",
+			expectedReward, blueBlock.Reward, blueBlock.IsRewardMature)
+	}
+}
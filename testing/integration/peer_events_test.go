@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func TestPeerEventNotifications(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		enableBanning:           true,
+	})
+	defer teardown()
+
+	peerEvents := make(chan *appmessage.PeerEventNotificationMessage, 10)
+	err := kaspad.rpcClient.RegisterForPeerEventNotifications(func(notification *appmessage.PeerEventNotificationMessage) {
+		peerEvents <- notification
+	})
+	if err != nil {
+		t.Fatalf("Error registering for peer event notifications: %+v", err)
+	}
+
+	waitForPeerEvent := func(expectedEvent string) *appmessage.PeerEventNotificationMessage {
+		select {
+		case notification := <-peerEvents:
+			if notification.Event != expectedEvent {
+				t.Fatalf("Expected a %s peer event, but got %+v", expectedEvent, notification)
+			}
+			if notification.IsOutbound {
+				t.Fatalf("Expected the peer to be inbound, but got %+v", notification)
+			}
+			return notification
+		case <-time.After(defaultTimeout):
+			t.Fatalf("Timed out waiting for a %s peer event", expectedEvent)
+			return nil
+		}
+	}
+
+	// A peer that completes the handshake and then disconnects
+	peer := connectChaosPeer(t, kaspad)
+	peer.handshake()
+	connected := waitForPeerEvent(appmessage.PeerEventConnected)
+	handshakeCompleted := waitForPeerEvent(appmessage.PeerEventHandshakeCompleted)
+	if handshakeCompleted.Address != connected.Address || handshakeCompleted.ID == "" ||
+		handshakeCompleted.UserAgent != "/chaos-peer/" || handshakeCompleted.ProtocolVersion == 0 {
+		t.Fatalf("Unexpected handshake completed peer event: %+v", handshakeCompleted)
+	}
+	peer.close()
+	disconnected := waitForPeerEvent(appmessage.PeerEventDisconnected)
+	if disconnected.Address != connected.Address || disconnected.ID != handshakeCompleted.ID ||
+		disconnected.Reason == "" {
+		t.Fatalf("Unexpected disconnected peer event: %+v", disconnected)
+	}
+
+	// A peer that misbehaves before the handshake, and gets banned
+	peer = connectChaosPeer(t, kaspad)
+	defer peer.close()
+	peer.sendMessage(appmessage.NewMsgPing(1))
+	connected = waitForPeerEvent(appmessage.PeerEventConnected)
+	banned := waitForPeerEvent(appmessage.PeerEventBanned)
+	if banned.Address != connected.Address || banned.Reason == "" {
+		t.Fatalf("Unexpected banned peer event: %+v", banned)
+	}
+	disconnected = waitForPeerEvent(appmessage.PeerEventDisconnected)
+	if disconnected.Reason != banned.Reason || disconnected.ID != "" {
+		t.Fatalf("Unexpected disconnected peer event: %+v", disconnected)
+	}
+}
@@ -0,0 +1,185 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestProtocolConformance has a chaos peer send the node malformed, out of
+// order, duplicated and oversized messages, and checks that the node
+// disconnects from it, and bans it whenever the misbehavior is attributable
+// to the peer. Any panic in the node brings down the whole test binary.
+func TestProtocolConformance(t *testing.T) {
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		enableBanning:           true,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	tests := []struct {
+		name      string
+		shouldBan bool
+		misbehave func(peer *chaosPeer)
+	}{
+		{
+			// The frame can't be decoded at all, so it's dropped by
+			// the transport layer before reaching the protocol
+			name:      "undecodable frame",
+			shouldBan: false,
+			misbehave: func(peer *chaosPeer) {
+				peer.sendRaw([]byte{0xff, 0xff, 0xff, 0xff})
+			},
+		},
+		{
+			name:      "truncated version",
+			shouldBan: false,
+			misbehave: func(peer *chaosPeer) {
+				frame := encodeForTest(t, peer.toProto(peer.newVersionMessage()))
+				peer.sendRaw(frame[:len(frame)/2])
+			},
+		},
+		{
+			name:      "message before version",
+			shouldBan: true,
+			misbehave: func(peer *chaosPeer) {
+				peer.sendMessage(appmessage.NewMsgPing(1))
+			},
+		},
+		{
+			name:      "wrong network",
+			shouldBan: true,
+			misbehave: func(peer *chaosPeer) {
+				version := peer.newVersionMessage()
+				version.Network = "kaspa-chaos"
+				peer.sendMessage(version)
+			},
+		},
+		{
+			name:      "oversized user agent",
+			shouldBan: true,
+			misbehave: func(peer *chaosPeer) {
+				version := peer.toProto(peer.newVersionMessage())
+				version.GetVersion().UserAgent = strings.Repeat("a", appmessage.MaxUserAgentLen+1)
+				peer.sendProto(version)
+			},
+		},
+		{
+			name:      "duplicated version after the handshake",
+			shouldBan: true,
+			misbehave: func(peer *chaosPeer) {
+				peer.handshake()
+				peer.sendMessage(peer.newVersionMessage())
+			},
+		},
+		{
+			name:      "hash of the wrong length",
+			shouldBan: true,
+			misbehave: func(peer *chaosPeer) {
+				peer.handshake()
+				inv := peer.toProto(appmessage.NewMsgInvBlock(&externalapi.DomainHash{}))
+				inv.GetInvRelayBlock().Hash.Bytes = make([]byte, externalapi.DomainHashSize-1)
+				peer.sendProto(inv)
+			},
+		},
+		{
+			name:      "oversized relay block request",
+			shouldBan: true,
+			misbehave: func(peer *chaosPeer) {
+				peer.handshake()
+				request := peer.toProto(appmessage.NewMsgRequestRelayBlocks([]*externalapi.DomainHash{{}}))
+				hashes := make([]*protowire.Hash, appmessage.MaxRequestRelayBlocksHashes+1)
+				for i := range hashes {
+					hashes[i] = request.GetRequestRelayBlocks().Hashes[0]
+				}
+				request.GetRequestRelayBlocks().Hashes = hashes
+				peer.sendProto(request)
+			},
+		},
+		{
+			name:      "oversized addresses",
+			shouldBan: true,
+			misbehave: func(peer *chaosPeer) {
+				peer.waitForMessage(appmessage.CmdVersion)
+				peer.sendMessage(peer.newVersionMessage())
+				peer.waitForMessage(appmessage.CmdVerAck)
+				peer.sendMessage(appmessage.NewMsgVerAck())
+				peer.sendMessage(appmessage.NewMsgReady())
+				peer.waitForMessage(appmessage.CmdRequestAddresses)
+
+				addresses := peer.toProto(appmessage.NewMsgAddresses([]*appmessage.NetAddress{
+					appmessage.NewNetAddressIPPort([]byte{10, 0, 0, 1}, 16111),
+				}))
+				addressList := make([]*protowire.NetAddress, appmessage.MaxAddressesPerMsg+1)
+				for i := range addressList {
+					addressList[i] = addresses.GetAddresses().AddressList[0]
+				}
+				addresses.GetAddresses().AddressList = addressList
+				peer.sendProto(addresses)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		peer := connectChaosPeer(t, kaspad)
+		test.misbehave(peer)
+		peer.waitForDisconnect()
+		peer.close()
+
+		if !test.shouldBan {
+			if isChaosPeerBanned(t, kaspad) {
+				t.Fatalf("%s: the chaos peer was banned", test.name)
+			}
+			continue
+		}
+
+		waitForChaosPeerBan(t, kaspad)
+		bannedPeer := connectChaosPeer(t, kaspad)
+		commands := bannedPeer.waitForDisconnect()
+		bannedPeer.close()
+		if len(commands) != 0 {
+			t.Fatalf("%s: the node sent a banned peer %v before disconnecting", test.name, commands)
+		}
+		bannedPeersResponse, err := kaspad.rpcClient.GetBannedPeers()
+		if err != nil {
+			t.Fatalf("%s: error getting the banned peers: %+v", test.name, err)
+		}
+		if len(bannedPeersResponse.BannedPeers) != 1 {
+			t.Fatalf("%s: expected only the chaos peer to be banned, but got %+v", test.name, bannedPeersResponse.BannedPeers)
+		}
+		bannedPeerEntry := bannedPeersResponse.BannedPeers[0]
+		if bannedPeerEntry.IP != chaosPeerIP || bannedPeerEntry.Reason == "" ||
+			bannedPeerEntry.BannedUntil <= bannedPeerEntry.BanTime {
+			t.Fatalf("%s: unexpected banned peer %+v", test.name, bannedPeerEntry)
+		}
+		_, err = kaspad.rpcClient.Unban(chaosPeerIP)
+		if err != nil {
+			t.Fatalf("%s: error unbanning the chaos peer: %+v", test.name, err)
+		}
+	}
+
+	// The node stays responsive, and still accepts well behaving peers
+	_, err := kaspad.rpcClient.GetInfo()
+	if err != nil {
+		t.Fatalf("Error getting info: %+v", err)
+	}
+	peer := connectChaosPeer(t, kaspad)
+	defer peer.close()
+	peer.handshake()
+}
+
+func encodeForTest(t *testing.T, message *protowire.KaspadMessage) []byte {
+	frame, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("Error encoding a %T message: %+v", message.Payload, err)
+	}
+	return frame
+}
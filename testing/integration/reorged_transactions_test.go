@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestReorgedTransactions(t *testing.T) {
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+		},
+	})
+	defer teardown()
+	payer, rival := harnesses[0], harnesses[1]
+
+	// Mine a mature coinbase and spend it on the payer's chain, making
+	// sure that a chain block accepts the transaction
+	mineNextBlock(t, payer)
+	fundingBlock := mineNextBlock(t, payer)
+	for i := uint64(0); i < payer.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, payer)
+	}
+	msgTx := generateTx(t, fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex], payer, rival)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	_, err := payer.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+	payerBlockCount := payer.config.ActiveNetParams.BlockCoinbaseMaturity + 4
+	mineNextBlock(t, payer)
+	mineNextBlock(t, payer)
+
+	// Mine a heavier chain that doesn't share any block with the payer's
+	// besides genesis, and let the payer reorg to it
+	for i := uint64(0); i < payerBlockCount+10; i++ {
+		mineNextBlock(t, rival)
+	}
+	connect(t, payer, rival)
+
+	start := time.Now()
+	for {
+		response, err := payer.rpcClient.GetReorgedTransactionsStats()
+		if err != nil {
+			t.Fatalf("Error getting reorged transactions stats: %+v", err)
+		}
+		// The reorged transactions are counted before they're re-inserted or discarded
+		if response.ReorgCount > 0 && response.ReorgedTransactionCount > 0 &&
+			response.ReinsertedTransactionCount+response.DiscardedTransactionCount == response.ReorgedTransactionCount {
+
+			// The funding coinbase is not in the rival chain, so the
+			// transaction can't be re-inserted
+			if response.ReorgedTransactionCount != 1 || response.DiscardedTransactionCount != 1 ||
+				response.ReinsertedTransactionCount != 0 || response.RebroadcastTransactionCount != 0 {
+				t.Fatalf("Unexpected reorged transactions stats: %+v", response)
+			}
+			return
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the payer to reorg. Last stats: %+v", response)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,50 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func TestRPCAPIVersion(t *testing.T) {
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		utxoIndex:               true,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	// The RPC client negotiates the newest version when it connects, so a failed
+	// GetBalanceByAddress request is answered with a GetBalanceByAddress response
+	// rather than timing out
+	_, err := kaspad.rpcClient.GetBalanceByAddress("kaspasim:invalid")
+	if err == nil || !strings.Contains(err.Error(), "decode address") {
+		t.Fatalf("Expected an address decoding error, but got: %v", err)
+	}
+
+	// GetBalanceByAddress is deprecated, so its responses carry a warning
+	response, err := kaspad.rpcClient.GetBalanceByAddress(miningAddress1)
+	if err != nil {
+		t.Fatalf("Error getting the balance: %+v", err)
+	}
+	warnings := response.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], appmessage.CmdGetBalancesByAddressesRequestMessage.String()) {
+		t.Fatalf("Expected a single deprecation warning naming the replacement method, but got %v", warnings)
+	}
+
+	negotiateAPIVersionResponse, err := kaspad.rpcClient.NegotiateAPIVersion(appmessage.MaxRPCAPIVersion + 1)
+	if err != nil {
+		t.Fatalf("Error negotiating the RPC API version: %+v", err)
+	}
+	if negotiateAPIVersionResponse.Version != appmessage.MaxRPCAPIVersion {
+		t.Fatalf("Expected version %d, but got %d", appmessage.MaxRPCAPIVersion, negotiateAPIVersionResponse.Version)
+	}
+	_, err = kaspad.rpcClient.NegotiateAPIVersion(appmessage.MinRPCAPIVersion - 1)
+	if err == nil {
+		t.Fatalf("Expected negotiating an unsupported version to fail")
+	}
+}
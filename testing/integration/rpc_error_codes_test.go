@@ -0,0 +1,75 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+func TestRPCErrorCodes(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	block := mineNextBlock(t, kaspad)
+
+	tests := []struct {
+		name         string
+		call         func() error
+		expectedCode appmessage.RPCErrorCode
+	}{
+		{
+			name: "malformed block hash",
+			call: func() error {
+				_, err := kaspad.rpcClient.GetBlock("invalid", false)
+				return err
+			},
+			expectedCode: appmessage.RPCErrorCodeInvalidParams,
+		},
+		{
+			name: "unknown block",
+			call: func() error {
+				_, err := kaspad.rpcClient.GetBlock(strings.Repeat("ab", 32), false)
+				return err
+			},
+			expectedCode: appmessage.RPCErrorCodeNotFound,
+		},
+		{
+			name: "disabled index",
+			call: func() error {
+				_, err := kaspad.rpcClient.GetUTXOsByAddresses([]string{kaspad.miningAddress})
+				return err
+			},
+			expectedCode: appmessage.RPCErrorCodeMethodUnavailable,
+		},
+		{
+			name: "duplicate block",
+			call: func() error {
+				_, err := kaspad.rpcClient.SubmitBlock(block)
+				return err
+			},
+			expectedCode: appmessage.RPCErrorCodeBlockDuplicate,
+		},
+	}
+	for _, test := range tests {
+		err := test.call()
+		if !errors.Is(err, rpcclient.ErrRPC) {
+			t.Fatalf("%s: expected an RPC error, but got %+v", test.name, err)
+		}
+		rpcError := &rpcclient.RPCError{}
+		if !errors.As(err, &rpcError) {
+			t.Fatalf("%s: the error %+v is not an rpcclient.RPCError", test.name, err)
+		}
+		if rpcError.Code != test.expectedCode {
+			t.Fatalf("%s: expected error code %s, but got %s (%s)",
+				test.name, test.expectedCode, rpcError.Code, rpcError.Message)
+		}
+	}
+}
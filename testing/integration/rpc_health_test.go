@@ -0,0 +1,203 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient/grpcclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+)
+
+func TestRPCHealthAndReflection(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	gRPCConnection, err := grpc.DialContext(ctx, kaspad.rpcAddress, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Error connecting to %s: %+v", kaspad.rpcAddress, err)
+	}
+	defer gRPCConnection.Close()
+
+	// The RPC service is only reported as serving once the node is synced,
+	// which it becomes after it mines a block on top of the old genesis
+	healthClient := healthpb.NewHealthClient(gRPCConnection)
+	checkHealth := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		response, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Error checking the health of %q: %+v", service, err)
+		}
+		return response.Status
+	}
+	if status := checkHealth(""); status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Expected the server to be serving, but got %s", status)
+	}
+	if status := checkHealth(grpcserver.RPCServiceName); status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("Expected %s to be not serving before the node is synced, but got %s",
+			grpcserver.RPCServiceName, status)
+	}
+	mineNextBlock(t, kaspad)
+	start := time.Now()
+	for checkHealth(grpcserver.RPCServiceName) != healthpb.HealthCheckResponse_SERVING {
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for %s to be serving", grpcserver.RPCServiceName)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	reflectionStream, err := reflectionpb.NewServerReflectionClient(gRPCConnection).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("Error opening a reflection stream: %+v", err)
+	}
+	err = reflectionStream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		t.Fatalf("Error requesting the service list: %+v", err)
+	}
+	response, err := reflectionStream.Recv()
+	if err != nil {
+		t.Fatalf("Error receiving the service list: %+v", err)
+	}
+	services := make(map[string]bool)
+	for _, service := range response.GetListServicesResponse().GetService() {
+		services[service.Name] = true
+	}
+	for _, expectedService := range []string{grpcserver.RPCServiceName, "grpc.health.v1.Health"} {
+		if !services[expectedService] {
+			t.Fatalf("Expected reflection to list %s, but got %v", expectedService, services)
+		}
+	}
+
+	// The descriptors of the RPC messages are needed in order to call it
+	err = reflectionStream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: "protowire.SubmitBlockRequestMessage",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error requesting a file descriptor: %+v", err)
+	}
+	response, err = reflectionStream.Recv()
+	if err != nil {
+		t.Fatalf("Error receiving a file descriptor: %+v", err)
+	}
+	if len(response.GetFileDescriptorResponse().GetFileDescriptorProto()) == 0 {
+		t.Fatalf("Expected a file descriptor, but got %+v", response)
+	}
+}
+
+func TestNetworkHealth(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	// The node has no peers, fewer than the default minimum
+	response, err := kaspad.rpcClient.GetNetworkHealth()
+	if err != nil {
+		t.Fatalf("Error getting the network health: %+v", err)
+	}
+	if !response.IsDegraded || response.PeerCount != 0 || response.MinPeers != uint32(kaspad.config.MinPeers) ||
+		len(response.Reasons) == 0 || response.DegradedSinceTimestamp == 0 {
+		t.Fatalf("Expected the connection to the network to be degraded, but got %+v", response)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	gRPCConnection, err := grpc.DialContext(ctx, kaspad.rpcAddress, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Error connecting to %s: %+v", kaspad.rpcAddress, err)
+	}
+	defer gRPCConnection.Close()
+	healthResponse, err := healthpb.NewHealthClient(gRPCConnection).Check(ctx,
+		&healthpb.HealthCheckRequest{Service: grpcserver.NetworkServiceName})
+	if err != nil {
+		t.Fatalf("Error checking the health of %s: %+v", grpcserver.NetworkServiceName, err)
+	}
+	if healthResponse.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("Expected %s to be not serving, but got %s", grpcserver.NetworkServiceName, healthResponse.Status)
+	}
+}
+
+func TestRPCHealthAndReflectionAuth(t *testing.T) {
+	readOnly := &config.RPCCredential{Token: "monitor-token", Permission: config.RPCPermissionReadOnly}
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcCredentials:          []*config.RPCCredential{readOnly},
+	})
+	defer teardown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	gRPCConnection, err := grpc.DialContext(ctx, kaspad.rpcAddress, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Error connecting to %s: %+v", kaspad.rpcAddress, err)
+	}
+	defer gRPCConnection.Close()
+
+	checkHealthAndReflection := func(callContext context.Context) error {
+		_, err := healthpb.NewHealthClient(gRPCConnection).Check(callContext, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		reflectionStream, err := reflectionpb.NewServerReflectionClient(gRPCConnection).ServerReflectionInfo(callContext)
+		if err != nil {
+			return err
+		}
+		err = reflectionStream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = reflectionStream.Recv()
+		return err
+	}
+
+	_, err = healthpb.NewHealthClient(gRPCConnection).Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected an unauthenticated health check to be rejected, got: %v", err)
+	}
+	reflectionStream, err := reflectionpb.NewServerReflectionClient(gRPCConnection).ServerReflectionInfo(ctx)
+	if err == nil {
+		_, err = reflectionStream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected an unauthenticated reflection stream to be rejected, got: %v", err)
+	}
+
+	wrongTokenContext := metadata.AppendToOutgoingContext(ctx, grpcserver.AuthorizationMetadataKey,
+		grpcclient.AuthTokenAuthorization("wrong-token"))
+	err = checkHealthAndReflection(wrongTokenContext)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected health and reflection with a wrong token to be rejected, got: %v", err)
+	}
+
+	authorizedContext := metadata.AppendToOutgoingContext(ctx, grpcserver.AuthorizationMetadataKey,
+		grpcclient.AuthTokenAuthorization(readOnly.Token))
+	err = checkHealthAndReflection(authorizedContext)
+	if err != nil {
+		t.Fatalf("Health and reflection failed with a valid token: %+v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package integration
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+func TestNotificationSnapshotAndCoalescing(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	mineNextBlock(t, kaspad)
+
+	var lock sync.Mutex
+	var addedChainBlockHashes []string
+	chainChangedNotificationCount := 0
+	snapshot, err := kaspad.rpcClient.RegisterForVirtualSelectedParentChainChangedNotificationsWithOptions(false,
+		&rpcclient.NotificationOptions{IncludeSnapshot: true, CoalesceInterval: time.Second},
+		func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage) {
+			lock.Lock()
+			defer lock.Unlock()
+			addedChainBlockHashes = append(addedChainBlockHashes, notification.AddedChainBlockHashes...)
+			chainChangedNotificationCount++
+		})
+	if err != nil {
+		t.Fatalf("Error registering for chain changed notifications: %+v", err)
+	}
+
+	selectedTipHashResponse, err := kaspad.rpcClient.GetSelectedTipHash()
+	if err != nil {
+		t.Fatalf("Error getting the selected tip hash: %+v", err)
+	}
+	blockDAGInfo, err := kaspad.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error getting the block DAG info: %+v", err)
+	}
+	if snapshot == nil || snapshot.VirtualSelectedParentHash != selectedTipHashResponse.SelectedTipHash ||
+		len(snapshot.TipHashes) != len(blockDAGInfo.TipHashes) || snapshot.VirtualDAAScore != blockDAGInfo.VirtualDAAScore {
+
+		t.Fatalf("Unexpected snapshot %+v", snapshot)
+	}
+
+	const blockCount = 3
+	minedBlockHashes := make(map[string]struct{}, blockCount)
+	for i := 0; i < blockCount; i++ {
+		minedBlock := mineNextBlock(t, kaspad)
+		minedBlockHashes[consensushashing.BlockHash(minedBlock).String()] = struct{}{}
+	}
+
+	start := time.Now()
+	for {
+		lock.Lock()
+		foundCount := 0
+		for _, addedChainBlockHash := range addedChainBlockHashes {
+			if _, ok := minedBlockHashes[addedChainBlockHash]; ok {
+				foundCount++
+			}
+		}
+		notificationCount := chainChangedNotificationCount
+		lock.Unlock()
+		if foundCount == blockCount {
+			if notificationCount > blockCount {
+				t.Fatalf("Expected at most %d chain changed notifications, but got %d", blockCount, notificationCount)
+			}
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the mined blocks to be added to the chain")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Block added notifications are still delivered one block at a time
+	otherClient, err := newTestRPCClient(kaspad.rpcAddress)
+	if err != nil {
+		t.Fatalf("Error connecting another client: %+v", err)
+	}
+	defer otherClient.Close()
+	blockAddedChan := make(chan *appmessage.BlockAddedNotificationMessage, blockCount)
+	snapshot, err = otherClient.RegisterForBlockAddedNotificationsWithOptions(
+		&rpcclient.NotificationOptions{IncludeSnapshot: true, CoalesceInterval: 100 * time.Millisecond},
+		func(notification *appmessage.BlockAddedNotificationMessage) {
+			blockAddedChan <- notification
+		})
+	if err != nil {
+		t.Fatalf("Error registering for block added notifications: %+v", err)
+	}
+	if snapshot == nil || len(snapshot.TipHashes) == 0 {
+		t.Fatalf("Unexpected snapshot %+v", snapshot)
+	}
+	for i := 0; i < blockCount; i++ {
+		mineNextBlock(t, kaspad)
+	}
+	for i := 0; i < blockCount; i++ {
+		select {
+		case <-blockAddedChan:
+		case <-time.After(defaultTimeout):
+			t.Fatalf("Timed out waiting for block added notification %d", i)
+		}
+	}
+
+	_, err = otherClient.RegisterForBlockAddedNotificationsWithOptions(
+		&rpcclient.NotificationOptions{CoalesceInterval: time.Minute},
+		func(_ *appmessage.BlockAddedNotificationMessage) {})
+	rpcError := &rpcclient.RPCError{}
+	if !errors.As(err, &rpcError) || rpcError.Code != appmessage.RPCErrorCodeInvalidParams {
+		t.Fatalf("Expected a %s error, but got %+v", appmessage.RPCErrorCodeInvalidParams, err)
+	}
+}
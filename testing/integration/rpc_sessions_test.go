@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+func TestRPCSessions(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	otherClient, err := newTestRPCClient(kaspad.rpcAddress)
+	if err != nil {
+		t.Fatalf("Error connecting another client: %+v", err)
+	}
+	defer otherClient.Close()
+	err = otherClient.RegisterForBlockAddedNotifications(func(_ *appmessage.BlockAddedNotificationMessage) {})
+	if err != nil {
+		t.Fatalf("Error registering for block added notifications: %+v", err)
+	}
+
+	currentSession, otherSession := getCurrentAndOtherRPCSession(t, kaspad.rpcClient)
+	if otherSession == nil {
+		t.Fatalf("Expected the session of the other client to be listed")
+	}
+	if len(currentSession.Subscriptions) != 0 {
+		t.Fatalf("Expected the current session to have no subscriptions, but got %v", currentSession.Subscriptions)
+	}
+	if len(otherSession.Subscriptions) != 1 || otherSession.Subscriptions[0] != "blockAdded" {
+		t.Fatalf("Expected the other session to be subscribed to blockAdded, but got %v", otherSession.Subscriptions)
+	}
+	// The other client sent its notification registration and got a response
+	if otherSession.ReceivedMessageCount < 1 || otherSession.SentMessageCount < 1 {
+		t.Fatalf("Unexpected message counts: %+v", otherSession)
+	}
+	if otherSession.EndpointName != "" || otherSession.IsAuthenticated ||
+		otherSession.APIVersion != appmessage.MaxRPCAPIVersion || otherSession.ConnectedAt == 0 {
+
+		t.Fatalf("Unexpected session: %+v", otherSession)
+	}
+
+	_, err = kaspad.rpcClient.DisconnectRPCSession(otherSession.ID)
+	if err != nil {
+		t.Fatalf("Error disconnecting the other session: %+v", err)
+	}
+
+	// The other client reconnects on its own, and gets a new session
+	start := time.Now()
+	for {
+		_, reconnectedSession := getCurrentAndOtherRPCSession(t, kaspad.rpcClient)
+		if reconnectedSession != nil && reconnectedSession.ID != otherSession.ID {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the other client to reconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_, err = kaspad.rpcClient.DisconnectRPCSession(otherSession.ID)
+	if err == nil {
+		t.Fatalf("Expected disconnecting a closed session to fail")
+	}
+	rpcError := &rpcclient.RPCError{}
+	if !errors.As(err, &rpcError) || rpcError.Code != appmessage.RPCErrorCodeNotFound {
+		t.Fatalf("Expected a %s error, but got %+v", appmessage.RPCErrorCodeNotFound, err)
+	}
+}
+
+// getCurrentAndOtherRPCSession returns the session of the given client, and
+// the session of the other client, if it's connected
+func getCurrentAndOtherRPCSession(t *testing.T, client *testRPCClient) (current, other *appmessage.RPCSession) {
+	response, err := client.GetRPCSessions()
+	if err != nil {
+		t.Fatalf("Error getting the RPC sessions: %+v", err)
+	}
+	for _, session := range response.Sessions {
+		if session.IsCurrentSession {
+			current = session
+		} else {
+			other = session
+		}
+	}
+	if current == nil || len(response.Sessions) > 2 {
+		t.Fatalf("Unexpected RPC sessions: %+v", response.Sessions)
+	}
+	return current, other
+}
@@ -1,12 +1,21 @@
 package integration
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient/grpcclient"
 )
 
 const rpcTimeout = 10 * time.Second
@@ -16,7 +25,19 @@ type testRPCClient struct {
 }
 
 func newTestRPCClient(rpcAddress string) (*testRPCClient, error) {
-	rpcClient, err := rpcclient.NewRPCClient(rpcAddress)
+	return newTestRPCClientWithAuthToken(rpcAddress, "")
+}
+
+func newTestRPCClientWithAuthToken(rpcAddress string, authToken string) (*testRPCClient, error) {
+	connectOptions := &grpcclient.ConnectOptions{}
+	if authToken != "" {
+		connectOptions.Authorization = grpcclient.AuthTokenAuthorization(authToken)
+	}
+	return newTestRPCClientWithOptions(rpcAddress, connectOptions)
+}
+
+func newTestRPCClientWithOptions(rpcAddress string, connectOptions *grpcclient.ConnectOptions) (*testRPCClient, error) {
+	rpcClient, err := rpcclient.NewRPCClientWithOptions(rpcAddress, connectOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -113,3 +134,193 @@ func TestRPCMaxInboundConnections(t *testing.T) {
 	case <-time.After(time.Second * 15):
 	}
 }
+
+func TestRPCEndpoints(t *testing.T) {
+	const authToken = "admin-token"
+	publicEndpoint := &config.RPCEndpoint{
+		Name:           "public",
+		Listeners:      []string{rpcAddress2},
+		MaxClients:     config.DefaultMaxRPCClients,
+		AllowedMethods: map[appmessage.MessageCommand]struct{}{appmessage.CmdGetInfoRequestMessage: {}},
+	}
+	adminEndpoint := &config.RPCEndpoint{
+		Name:       "admin",
+		Listeners:  []string{rpcAddress3},
+		MaxClients: config.DefaultMaxRPCClients,
+		AuthToken:  authToken,
+	}
+	_, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcEndpoints:            []*config.RPCEndpoint{publicEndpoint, adminEndpoint},
+	})
+	defer teardown()
+
+	publicClient, err := rpcclient.NewRPCClient(rpcAddress2)
+	if err != nil {
+		t.Fatalf("Failed to connect to the public endpoint: %s", err)
+	}
+	defer publicClient.Close()
+	_, err = publicClient.GetBlockCount()
+	if err == nil || !strings.Contains(err.Error(), "not allowed on RPC endpoint public") {
+		t.Fatalf("Expected GetBlockCount to be rejected by the public endpoint, got: %v", err)
+	}
+
+	_, err = rpcclient.NewRPCClientWithAuthToken(rpcAddress3, "wrong-token")
+	if err == nil {
+		t.Fatalf("Connecting to the admin endpoint with a wrong auth token unexpectedly succeeded")
+	}
+	adminClient, err := rpcclient.NewRPCClientWithAuthToken(rpcAddress3, authToken)
+	if err != nil {
+		t.Fatalf("Failed to connect to the admin endpoint: %s", err)
+	}
+	defer adminClient.Close()
+	_, err = adminClient.GetBlockCount()
+	if err != nil {
+		t.Fatalf("GetBlockCount failed on the admin endpoint: %s", err)
+	}
+}
+
+func TestRPCCookie(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcCookie:               true,
+	})
+	defer teardown()
+
+	// The harness RPC client presents the cookie
+	_, err := harness.rpcClient.GetBlockCount()
+	if err != nil {
+		t.Fatalf("GetBlockCount failed with the RPC cookie: %s", err)
+	}
+
+	err = connectAndClose(rpcAddress1)
+	if err == nil {
+		t.Fatalf("Connecting without the RPC cookie unexpectedly succeeded")
+	}
+
+	// A new cookie is generated on every run
+	previousCookie, err := grpcserver.ReadRPCCookie(harness.config.RPCCookieFile)
+	if err != nil {
+		t.Fatalf("ReadRPCCookie: %s", err)
+	}
+	harness.rpcClient.Close()
+	harness.app.Stop()
+	if _, err := os.Stat(harness.config.RPCCookieFile); !os.IsNotExist(err) {
+		t.Fatalf("Expected the RPC cookie file to be removed once the node stopped, but got: %v", err)
+	}
+	setApp(t, harness)
+	harness.app.Start()
+	setRPCClient(t, harness)
+	cookie, err := grpcserver.ReadRPCCookie(harness.config.RPCCookieFile)
+	if err != nil {
+		t.Fatalf("ReadRPCCookie: %s", err)
+	}
+	if cookie == previousCookie {
+		t.Fatalf("Expected a new RPC cookie once the node restarted")
+	}
+}
+
+// rpcCredentialAuthorization returns the Authorization header value that
+// presents the given credential
+func rpcCredentialAuthorization(credential *config.RPCCredential) string {
+	if credential.Token != "" {
+		return grpcclient.AuthTokenAuthorization(credential.Token)
+	}
+	return grpcclient.BasicAuthorization(credential.User, credential.Password)
+}
+
+// rpcTLSClientConfig returns a TLS config that trusts the certificate that the
+// node of the given harness serves RPC with
+func rpcTLSClientConfig(t *testing.T, harness *appHarness) *tls.Config {
+	certificate, err := os.ReadFile(harness.config.RPCCert)
+	if err != nil {
+		t.Fatalf("Error reading the RPC certificate: %+v", err)
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(certificate) {
+		t.Fatalf("No certificate was found in %s", harness.config.RPCCert)
+	}
+	return &tls.Config{RootCAs: rootCAs}
+}
+
+func TestRPCAuth(t *testing.T) {
+	admin := &config.RPCCredential{User: "alice", Password: "secret", Permission: config.RPCPermissionAdmin}
+	readOnly := &config.RPCCredential{Token: "explorer-token", Permission: config.RPCPermissionReadOnly}
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcCredentials:          []*config.RPCCredential{admin, readOnly},
+		rpcTLS:                  true,
+	})
+	defer teardown()
+
+	// The harness RPC client authenticates as the admin, over TLS with the
+	// generated self-signed certificate
+	_, err := harness.rpcClient.GetBlockCount()
+	if err != nil {
+		t.Fatalf("GetBlockCount failed for the admin: %s", err)
+	}
+
+	wrongPassword := &config.RPCCredential{User: admin.User, Password: "wrong"}
+	_, err = rpcclient.NewRPCClientWithOptions(rpcAddress1, &grpcclient.ConnectOptions{
+		Authorization: rpcCredentialAuthorization(wrongPassword),
+		TLSConfig:     rpcTLSClientConfig(t, harness),
+	})
+	if err == nil {
+		t.Fatalf("Connecting with a wrong password unexpectedly succeeded")
+	}
+
+	readOnlyClient, err := newTestRPCClientWithOptions(rpcAddress1, &grpcclient.ConnectOptions{
+		Authorization: rpcCredentialAuthorization(readOnly),
+		TLSConfig:     rpcTLSClientConfig(t, harness),
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect with the read-only token: %s", err)
+	}
+	defer readOnlyClient.Close()
+	_, err = readOnlyClient.GetBlockCount()
+	if err != nil {
+		t.Fatalf("GetBlockCount failed for the read-only client: %s", err)
+	}
+	_, err = readOnlyClient.Ban("127.0.0.2")
+	if err == nil || !strings.Contains(err.Error(), "requires the admin permission") {
+		t.Fatalf("Expected Ban to be rejected for the read-only client, got: %v", err)
+	}
+	_, err = harness.rpcClient.Ban("127.0.0.2")
+	if err != nil {
+		t.Fatalf("Ban failed for the admin: %s", err)
+	}
+
+	// Exporting a snapshot writes a file wherever the client asks, so a
+	// read-only client may not do it
+	utxoSnapshotPath := filepath.Join(randomDirectory(t), "utxos.snapshot")
+	_, err = readOnlyClient.ExportUTXOSnapshot(utxoSnapshotPath)
+	if err == nil || !strings.Contains(err.Error(), "requires the admin permission") {
+		t.Fatalf("Expected ExportUTXOSnapshot to be rejected for the read-only client, got: %v", err)
+	}
+	if _, err := os.Stat(utxoSnapshotPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected no UTXO snapshot to be written for the read-only client, got: %v", err)
+	}
+
+	mempoolSnapshotPath := filepath.Join(randomDirectory(t), "mempool.json")
+	_, err = readOnlyClient.ExportMempoolSnapshot(mempoolSnapshotPath, true)
+	if err == nil || !strings.Contains(err.Error(), "requires the admin permission") {
+		t.Fatalf("Expected ExportMempoolSnapshot to be rejected for the read-only client, got: %v", err)
+	}
+	if _, err := os.Stat(mempoolSnapshotPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected no mempool snapshot to be written for the read-only client, got: %v", err)
+	}
+
+	_, err = readOnlyClient.ReloadConfig()
+	if err == nil || !strings.Contains(err.Error(), "requires the admin permission") {
+		t.Fatalf("Expected ReloadConfig to be rejected for the read-only client, got: %v", err)
+	}
+}
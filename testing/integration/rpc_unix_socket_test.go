@@ -0,0 +1,45 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+)
+
+func TestRPCUnixSocket(t *testing.T) {
+	unixSocketPath := filepath.Join(t.TempDir(), "kaspad.sock")
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcUnixSocket:           unixSocketPath,
+	})
+
+	fileInfo, err := os.Lstat(unixSocketPath)
+	if err != nil {
+		t.Fatalf("Error getting the socket file info: %+v", err)
+	}
+	if fileInfo.Mode()&os.ModeSocket == 0 || fileInfo.Mode().Perm() != kaspad.config.RPCUnixSocketFileMode {
+		t.Fatalf("Expected a socket with permissions %s, but got %s", kaspad.config.RPCUnixSocketFileMode, fileInfo.Mode())
+	}
+
+	unixSocketClient, err := rpcclient.NewRPCClient("unix:" + unixSocketPath)
+	if err != nil {
+		t.Fatalf("Error connecting through the Unix domain socket: %+v", err)
+	}
+	_, err = unixSocketClient.GetInfo()
+	if err != nil {
+		t.Fatalf("Error getting info through the Unix domain socket: %+v", err)
+	}
+	unixSocketClient.Close()
+
+	// The socket is removed once the node shuts down
+	teardown()
+	_, err = os.Lstat(unixSocketPath)
+	if !os.IsNotExist(err) {
+		t.Fatalf("Expected the socket to be removed, but got %+v", err)
+	}
+}
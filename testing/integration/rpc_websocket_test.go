@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
+	"golang.org/x/net/websocket"
+)
+
+const rpcWebSocketAddress1 = "127.0.0.1:12445"
+
+func TestRPCWebSocket(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcWebSocketAddress:     rpcWebSocketAddress1,
+		rpcCookie:               true,
+	})
+	defer teardown()
+
+	const origin = "http://localhost/"
+	url := "ws://" + rpcWebSocketAddress1 + "/"
+	_, err := websocket.Dial(url, "", origin)
+	if err == nil {
+		t.Fatalf("Connecting without the RPC cookie unexpectedly succeeded")
+	}
+
+	cookie, err := grpcserver.ReadRPCCookie(harness.config.RPCCookieFile)
+	if err != nil {
+		t.Fatalf("ReadRPCCookie: %s", err)
+	}
+	ws, err := websocket.Dial(url+"?token="+cookie, "", origin)
+	if err != nil {
+		t.Fatalf("Error connecting with the RPC cookie: %s", err)
+	}
+	defer ws.Close()
+
+	type message map[string]json.RawMessage
+	send := func(request string) {
+		err := websocket.Message.Send(ws, request)
+		if err != nil {
+			t.Fatalf("Error sending %s: %s", request, err)
+		}
+	}
+	receive := func(expectedPayload string) json.RawMessage {
+		err := ws.SetReadDeadline(time.Now().Add(defaultTimeout))
+		if err != nil {
+			t.Fatalf("SetReadDeadline: %s", err)
+		}
+		var received message
+		err = websocket.JSON.Receive(ws, &received)
+		if err != nil {
+			t.Fatalf("Error receiving %s: %s", expectedPayload, err)
+		}
+		payload, ok := received[expectedPayload]
+		if !ok {
+			t.Fatalf("Expected %s, but got %v", expectedPayload, received)
+		}
+		return payload
+	}
+
+	send(`{"getInfoRequest":{}}`)
+	var getInfoResponse struct {
+		ServerVersion string
+		Error         interface{}
+	}
+	err = json.Unmarshal(receive("getInfoResponse"), &getInfoResponse)
+	if err != nil {
+		t.Fatalf("Error decoding the GetInfo response: %s", err)
+	}
+	if getInfoResponse.Error != nil || getInfoResponse.ServerVersion == "" {
+		t.Fatalf("Unexpected GetInfo response %+v", getInfoResponse)
+	}
+
+	send(`{"notifyBlockAddedRequest":{}}`)
+	receive("notifyBlockAddedResponse")
+	mineNextBlock(t, harness)
+	receive("blockAddedNotification")
+}
@@ -0,0 +1,448 @@
+package integration
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+)
+
+// scenario is a reproducible simnet scenario: a set of nodes, and the steps
+// that connect them, mine on them, partition and heal the network between
+// them, and check their DAGs. All the random choices of a scenario are made
+// with its seed, so that a failing scenario can be replayed exactly.
+//
+// Scenarios are built with the step methods, and executed with run:
+//
+//	newScenario("partition", 3, 1).
+//		connect(0, 1).connect(1, 2).
+//		mine(0, 5).
+//		partition(0).
+//		mine(0, 3).mine(2, 4).
+//		heal().
+//		mine(0, 1).mine(2, 1).
+//		assertDAGsEqual().
+//		run(t)
+type scenario struct {
+	name      string
+	nodeCount int
+	seed      int64
+	steps     []*scenarioStep
+}
+
+type scenarioStep struct {
+	description string
+	execute     func(run *scenarioRun)
+}
+
+// maxScenarioNodeCount is the amount of P2P and RPC addresses reserved for integration tests
+const maxScenarioNodeCount = 5
+
+func newScenario(name string, nodeCount int, seed int64) *scenario {
+	return &scenario{
+		name:      name,
+		nodeCount: nodeCount,
+		seed:      seed,
+	}
+}
+
+func (s *scenario) addStep(description string, execute func(run *scenarioRun)) *scenario {
+	s.steps = append(s.steps, &scenarioStep{description: description, execute: execute})
+	return s
+}
+
+// connect has node `from` connect to node `to`
+func (s *scenario) connect(from int, to int) *scenario {
+	return s.addStep(fmt.Sprintf("connect node %d to node %d", from, to), func(run *scenarioRun) {
+		run.connect(from, to)
+	})
+}
+
+// mine mines blockCount blocks on the given node, waiting for every
+// block to reach all the nodes the miner can currently reach
+func (s *scenario) mine(node int, blockCount int) *scenario {
+	return s.addStep(fmt.Sprintf("mine %d blocks on node %d", blockCount, node), func(run *scenarioRun) {
+		for i := 0; i < blockCount; i++ {
+			run.mineBlock(node)
+		}
+	})
+}
+
+// mineOnRandomNodes mines blockCount blocks, each on a node chosen with the scenario seed
+func (s *scenario) mineOnRandomNodes(blockCount int) *scenario {
+	return s.addStep(fmt.Sprintf("mine %d blocks on random nodes", blockCount), func(run *scenarioRun) {
+		for i := 0; i < blockCount; i++ {
+			run.mineBlock(run.random.Intn(len(run.nodes)))
+		}
+	})
+}
+
+// partition cuts all the connections between the given nodes and the rest of the nodes
+func (s *scenario) partition(nodes ...int) *scenario {
+	return s.addStep(fmt.Sprintf("partition nodes %v from the rest", nodes), func(run *scenarioRun) {
+		run.partition(nodes)
+	})
+}
+
+// heal restores all the connections that were cut. Note that nodes don't
+// sync on connection, but only once a block they don't have is relayed to them,
+// so a scenario should mine on both sides of a partition after healing it.
+func (s *scenario) heal() *scenario {
+	return s.addStep("heal the network", func(run *scenarioRun) {
+		run.heal()
+	})
+}
+
+// assertDAGsEqual waits for all the nodes to have the same DAG, and fails if they don't
+func (s *scenario) assertDAGsEqual() *scenario {
+	return s.addStep("assert that all DAGs are equal", func(run *scenarioRun) {
+		run.assertDAGsEqual()
+	})
+}
+
+// run sets up the nodes of the scenario, executes its steps in order, and tears the nodes down
+func (s *scenario) run(t *testing.T) {
+	if s.nodeCount > maxScenarioNodeCount {
+		t.Fatalf("Scenario %s has %d nodes, but at most %d are supported", s.name, s.nodeCount, maxScenarioNodeCount)
+	}
+
+	p2pAddresses := []string{p2pAddress1, p2pAddress2, p2pAddress3, p2pAddress4, p2pAddress5}
+	rpcAddresses := []string{rpcAddress1, rpcAddress2, rpcAddress3, rpcAddress4, rpcAddress5}
+	miningAddresses := []string{miningAddress1, miningAddress2, miningAddress3}
+	miningAddressPrivateKeys := []string{miningAddress1PrivateKey, miningAddress2PrivateKey, miningAddress3PrivateKey}
+	harnessesParams := make([]*harnessParams, s.nodeCount)
+	for i := range harnessesParams {
+		harnessesParams[i] = &harnessParams{
+			p2pAddress:              p2pAddresses[i],
+			rpcAddress:              rpcAddresses[i],
+			miningAddress:           miningAddresses[i%len(miningAddresses)],
+			miningAddressPrivateKey: miningAddressPrivateKeys[i%len(miningAddressPrivateKeys)],
+		}
+	}
+	nodes, teardown := setupHarnesses(t, harnessesParams)
+	defer teardown()
+
+	run := &scenarioRun{
+		t:        t,
+		scenario: s,
+		random:   rand.New(rand.NewSource(s.seed)),
+		nodes:    nodes,
+	}
+	defer run.closeLinks()
+
+	for i, step := range s.steps {
+		t.Logf("Scenario %s (seed %d), step %d: %s", s.name, s.seed, i, step.description)
+		run.stepIndex = i
+		step.execute(run)
+	}
+}
+
+// scenarioRun is the state of a single execution of a scenario
+type scenarioRun struct {
+	t         *testing.T
+	scenario  *scenario
+	random    *rand.Rand
+	nodes     []*appHarness
+	links     []*scenarioLink
+	stepIndex int
+}
+
+func (run *scenarioRun) fatalf(format string, args ...interface{}) {
+	step := run.scenario.steps[run.stepIndex]
+	run.t.Fatalf("Scenario %s (seed %d), step %d (%s): %s", run.scenario.name, run.scenario.seed,
+		run.stepIndex, step.description, fmt.Sprintf(format, args...))
+}
+
+func (run *scenarioRun) closeLinks() {
+	for _, link := range run.links {
+		link.close()
+	}
+}
+
+func (run *scenarioRun) connect(from int, to int) {
+	link, err := newScenarioLink(from, to, run.nodes[to].p2pAddress)
+	if err != nil {
+		run.fatalf("Error creating the link: %+v", err)
+	}
+	run.links = append(run.links, link)
+	run.establish(link)
+}
+
+// establish has the nodes of the given link connect through it, retrying
+// since a node may still consider a connection that was just cut as active
+func (run *scenarioRun) establish(link *scenarioLink) {
+	from, to := run.nodes[link.from], run.nodes[link.to]
+	start := time.Now()
+	for !isConnected(run.t, from, to) {
+		if time.Since(start) > defaultTimeout {
+			run.fatalf("Timed out waiting for node %d to connect to node %d", link.from, link.to)
+		}
+		err := from.rpcClient.AddPeer(link.address(), false)
+		if err != nil {
+			run.fatalf("Error connecting node %d to node %d: %+v", link.from, link.to, err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func (run *scenarioRun) mineBlock(node int) {
+	harness := run.nodes[node]
+	blockTemplate, err := harness.rpcClient.GetBlockTemplate(harness.miningAddress, "scenario")
+	if err != nil {
+		run.fatalf("Error getting a block template from node %d: %+v", node, err)
+	}
+	block, err := appmessage.RPCBlockToDomainBlock(blockTemplate.Block)
+	if err != nil {
+		run.fatalf("Error converting the block template of node %d: %+v", node, err)
+	}
+	mining.SolveBlock(block, run.random)
+	_, err = harness.rpcClient.SubmitBlockAlsoIfNonDAA(block)
+	if err != nil {
+		run.fatalf("Error submitting a block to node %d: %+v", node, err)
+	}
+
+	blockHash := consensushashing.BlockHash(block).String()
+	for _, reachableNode := range run.reachableNodes(node) {
+		run.waitFor(fmt.Sprintf("block %s to reach node %d", blockHash, reachableNode), func() bool {
+			_, err := run.nodes[reachableNode].rpcClient.GetBlock(blockHash, false)
+			return err == nil
+		})
+	}
+}
+
+// reachableNodes returns the nodes that are connected to the given node
+// through links that aren't cut, directly or through other nodes
+func (run *scenarioRun) reachableNodes(node int) []int {
+	isReached := map[int]bool{node: true}
+	queue := []int{node}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, link := range run.links {
+			if link.isCut() {
+				continue
+			}
+			for _, neighbor := range link.neighborsOf(current) {
+				if !isReached[neighbor] {
+					isReached[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	}
+
+	reachableNodes := make([]int, 0, len(isReached)-1)
+	for reachableNode := range isReached {
+		if reachableNode != node {
+			reachableNodes = append(reachableNodes, reachableNode)
+		}
+	}
+	sort.Ints(reachableNodes)
+	return reachableNodes
+}
+
+func (run *scenarioRun) partition(nodes []int) {
+	isInPartition := make(map[int]bool, len(nodes))
+	for _, node := range nodes {
+		isInPartition[node] = true
+	}
+	for _, link := range run.links {
+		if isInPartition[link.from] == isInPartition[link.to] || link.isCut() {
+			continue
+		}
+		link.cut()
+		from, to := run.nodes[link.from], run.nodes[link.to]
+		run.waitFor(fmt.Sprintf("node %d to disconnect from node %d", link.from, link.to), func() bool {
+			return !isPeer(run.t, from, to) && !isPeer(run.t, to, from)
+		})
+	}
+}
+
+func (run *scenarioRun) heal() {
+	for _, link := range run.links {
+		if !link.isCut() {
+			continue
+		}
+		link.restore()
+		run.establish(link)
+	}
+}
+
+func (run *scenarioRun) assertDAGsEqual() {
+	var dagInfos []*appmessage.GetBlockDAGInfoResponseMessage
+	run.waitFor("all the nodes to have the same tips", func() bool {
+		dagInfos = make([]*appmessage.GetBlockDAGInfoResponseMessage, len(run.nodes))
+		for i, node := range run.nodes {
+			dagInfo, err := node.rpcClient.GetBlockDAGInfo()
+			if err != nil {
+				run.fatalf("Error getting the DAG info of node %d: %+v", i, err)
+			}
+			sort.Strings(dagInfo.TipHashes)
+			sort.Strings(dagInfo.VirtualParentHashes)
+			dagInfos[i] = dagInfo
+		}
+		for _, dagInfo := range dagInfos[1:] {
+			if !reflect.DeepEqual(dagInfo.TipHashes, dagInfos[0].TipHashes) {
+				return false
+			}
+		}
+		return true
+	})
+
+	for i, dagInfo := range dagInfos[1:] {
+		if dagInfo.BlockCount != dagInfos[0].BlockCount || dagInfo.HeaderCount != dagInfos[0].HeaderCount {
+			run.fatalf("Node %d has %d blocks and %d headers, but node 0 has %d blocks and %d headers",
+				i+1, dagInfo.BlockCount, dagInfo.HeaderCount, dagInfos[0].BlockCount, dagInfos[0].HeaderCount)
+		}
+		if !reflect.DeepEqual(dagInfo.VirtualParentHashes, dagInfos[0].VirtualParentHashes) {
+			run.fatalf("Node %d has the virtual parents %s, but node 0 has %s",
+				i+1, dagInfo.VirtualParentHashes, dagInfos[0].VirtualParentHashes)
+		}
+		if dagInfo.PruningPointHash != dagInfos[0].PruningPointHash {
+			run.fatalf("Node %d has the pruning point %s, but node 0 has %s",
+				i+1, dagInfo.PruningPointHash, dagInfos[0].PruningPointHash)
+		}
+	}
+}
+
+func (run *scenarioRun) waitFor(description string, condition func() bool) {
+	start := time.Now()
+	for !condition() {
+		if time.Since(start) > defaultTimeout {
+			run.fatalf("Timed out waiting for %s", description)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// isPeer returns whether node has a connection to peer
+func isPeer(t *testing.T, node *appHarness, peer *appHarness) bool {
+	connectedPeerInfo, err := node.rpcClient.GetConnectedPeerInfo()
+	if err != nil {
+		t.Fatalf("Error getting connected peer info: %+v", err)
+	}
+	peerID := peer.app.P2PNodeID().String()
+	for _, connectedPeer := range connectedPeerInfo.Infos {
+		if connectedPeer.ID == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+// scenarioLink is a TCP proxy that node `from` connects to node `to` through,
+// so that the connection between them can be cut without the nodes' cooperation
+type scenarioLink struct {
+	from, to int
+	target   string
+	listener net.Listener
+
+	lock        sync.Mutex
+	cutFlag     bool
+	connections []net.Conn
+}
+
+func newScenarioLink(from int, to int, target string) (*scenarioLink, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	link := &scenarioLink{
+		from:     from,
+		to:       to,
+		target:   target,
+		listener: listener,
+	}
+	go link.acceptLoop()
+	return link, nil
+}
+
+func (link *scenarioLink) address() string {
+	return link.listener.Addr().String()
+}
+
+func (link *scenarioLink) neighborsOf(node int) []int {
+	switch node {
+	case link.from:
+		return []int{link.to}
+	case link.to:
+		return []int{link.from}
+	default:
+		return nil
+	}
+}
+
+func (link *scenarioLink) acceptLoop() {
+	for {
+		connection, err := link.listener.Accept()
+		if err != nil {
+			// The listener was closed
+			return
+		}
+		link.forward(connection)
+	}
+}
+
+func (link *scenarioLink) forward(connection net.Conn) {
+	link.lock.Lock()
+	defer link.lock.Unlock()
+
+	if link.cutFlag {
+		connection.Close()
+		return
+	}
+	targetConnection, err := net.Dial("tcp", link.target)
+	if err != nil {
+		connection.Close()
+		return
+	}
+	link.connections = append(link.connections, connection, targetConnection)
+	go pipe(targetConnection, connection)
+	go pipe(connection, targetConnection)
+}
+
+func pipe(destination net.Conn, source net.Conn) {
+	_, _ = io.Copy(destination, source)
+	destination.Close()
+	source.Close()
+}
+
+func (link *scenarioLink) isCut() bool {
+	link.lock.Lock()
+	defer link.lock.Unlock()
+
+	return link.cutFlag
+}
+
+// cut closes all the connections through the link, and refuses new ones until it's restored
+func (link *scenarioLink) cut() {
+	link.lock.Lock()
+	defer link.lock.Unlock()
+
+	link.cutFlag = true
+	for _, connection := range link.connections {
+		connection.Close()
+	}
+	link.connections = nil
+}
+
+func (link *scenarioLink) restore() {
+	link.lock.Lock()
+	defer link.lock.Unlock()
+
+	link.cutFlag = false
+}
+
+func (link *scenarioLink) close() {
+	link.listener.Close()
+	link.cut()
+}
@@ -0,0 +1,41 @@
+package integration
+
+import "testing"
+
+func TestScenarioPartitionAndHeal(t *testing.T) {
+	newScenario("partition and heal", 3, 1).
+		connect(0, 1).
+		connect(1, 2).
+		mine(0, 3).
+		assertDAGsEqual().
+		partition(2).
+		mine(0, 4).
+		mine(2, 3).
+		heal().
+		mine(2, 1).
+		mine(0, 1).
+		assertDAGsEqual().
+		run(t)
+}
+
+func TestScenarioRandomMiningAcrossPartitions(t *testing.T) {
+	newScenario("random mining across partitions", 4, 2).
+		connect(0, 1).
+		connect(1, 2).
+		connect(2, 3).
+		connect(3, 0).
+		mineOnRandomNodes(5).
+		partition(0, 1).
+		mineOnRandomNodes(8).
+		heal().
+		mine(0, 1).
+		mine(2, 1).
+		assertDAGsEqual().
+		partition(1, 3).
+		mineOnRandomNodes(8).
+		heal().
+		mine(1, 1).
+		mine(2, 1).
+		assertDAGsEqual().
+		run(t)
+}
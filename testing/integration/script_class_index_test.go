@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+)
+
+func TestScriptClassIndex(t *testing.T) {
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		scriptClassIndex:        true,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	const blockAmountToMine = 5
+	var minedBlocks []*externalapi.DomainBlock
+	for i := 0; i < blockAmountToMine; i++ {
+		minedBlocks = append(minedBlocks, mineNextBlock(t, kaspad))
+	}
+
+	// The coinbase transaction of the virtual selected parent is accepted by
+	// the virtual rather than by a chain block, so it isn't counted yet
+	expectedOutputCounts := make(map[string]uint64)
+	expectedTotalAmounts := make(map[string]uint64)
+	for _, block := range minedBlocks[:len(minedBlocks)-1] {
+		coinbase := block.Transactions[transactionhelper.CoinbaseTransactionIndex]
+		for _, output := range coinbase.Outputs {
+			scriptClass := txscript.GetScriptClass(output.ScriptPublicKey.Script).String()
+			expectedOutputCounts[scriptClass]++
+			expectedTotalAmounts[scriptClass] += output.Value
+		}
+	}
+	if len(expectedOutputCounts) == 0 {
+		t.Fatalf("The mined coinbase transactions have no outputs")
+	}
+
+	start := time.Now()
+	for {
+		response, err := kaspad.rpcClient.GetScriptClassStatistics(0, 0)
+		if err != nil {
+			t.Fatalf("Error getting script class statistics: %+v", err)
+		}
+		if len(response.Windows) > 1 {
+			t.Fatalf("Expected at most a single window, got %d", len(response.Windows))
+		}
+		if len(response.Windows) == 1 && response.Windows[0].StartBlueScore != 0 {
+			t.Fatalf("Unexpected window start blue score. Want: 0, got: %d",
+				response.Windows[0].StartBlueScore)
+		}
+
+		matches := len(response.Windows) == 1
+		if matches {
+			for _, scriptClass := range response.Windows[0].ScriptClasses {
+				if scriptClass.OutputCount != expectedOutputCounts[scriptClass.ScriptClass] ||
+					scriptClass.TotalAmount != expectedTotalAmounts[scriptClass.ScriptClass] {
+					matches = false
+				}
+			}
+		}
+		if matches {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the script class statistics. Want output counts %v, got: %+v",
+				expectedOutputCounts, response.Windows)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_, err := kaspad.rpcClient.GetScriptClassStatistics(2, 1)
+	if err == nil {
+		t.Fatalf("Expected an error when startBlueScore is greater than endBlueScore")
+	}
+}
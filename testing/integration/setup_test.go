@@ -6,12 +6,15 @@ import (
 
 	"github.com/kaspanet/kaspad/domain/dagconfig"
 
+	"github.com/kaspanet/kaspad/infrastructure/db/database/badgerdb"
 	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
 
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
 
 	"github.com/kaspanet/kaspad/app"
 	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient/grpcclient"
 )
 
 type appHarness struct {
@@ -24,7 +27,32 @@ type appHarness struct {
 	config                  *config.Config
 	database                database.Database
 	utxoIndex               bool
+	stxoIndex               bool
+	scriptClassIndex        bool
+	feeHistoryIndex         bool
+	coinAgeIndex            bool
+	txIndex                 bool
+	addrIndex               bool
+	stxoIndexMaxSize        uint64
+	enableBanning           bool
 	overrideDAGParams       *dagconfig.Params
+	rpcEndpoints            []*config.RPCEndpoint
+	rpcUnixSocket           string
+	rpcWebSocketAddress     string
+	rpcCookie               bool
+	rpcCredentials          []*config.RPCCredential
+	rpcTLS                  bool
+	dbType                  string
+	headersOnly             bool
+	sqlMirror               string
+	sqlMirrorSchema         string
+	kafkaBrokers            []string
+	kafkaTopicPrefix        string
+	templatePush            string
+	stratumListen           string
+	stratumShareDifficulty  float64
+	payoutWatch             bool
+	blockRateMonitor        bool
 }
 
 type harnessParams struct {
@@ -33,8 +61,33 @@ type harnessParams struct {
 	miningAddress           string
 	miningAddressPrivateKey string
 	utxoIndex               bool
+	stxoIndex               bool
+	scriptClassIndex        bool
+	feeHistoryIndex         bool
+	coinAgeIndex            bool
+	txIndex                 bool
+	addrIndex               bool
+	stxoIndexMaxSize        uint64
+	enableBanning           bool
 	overrideDAGParams       *dagconfig.Params
 	protocolVersion         uint32
+	rpcEndpoints            []*config.RPCEndpoint
+	rpcUnixSocket           string
+	rpcWebSocketAddress     string
+	rpcCookie               bool
+	rpcCredentials          []*config.RPCCredential
+	rpcTLS                  bool
+	dbType                  string
+	headersOnly             bool
+	sqlMirror               string
+	sqlMirrorSchema         string
+	kafkaBrokers            []string
+	kafkaTopicPrefix        string
+	templatePush            string
+	stratumListen           string
+	stratumShareDifficulty  float64
+	payoutWatch             bool
+	blockRateMonitor        bool
 }
 
 // setupHarness creates a single appHarness with given parameters
@@ -45,7 +98,32 @@ func setupHarness(t *testing.T, params *harnessParams) (harness *appHarness, tea
 		miningAddress:           params.miningAddress,
 		miningAddressPrivateKey: params.miningAddressPrivateKey,
 		utxoIndex:               params.utxoIndex,
+		stxoIndex:               params.stxoIndex,
+		scriptClassIndex:        params.scriptClassIndex,
+		feeHistoryIndex:         params.feeHistoryIndex,
+		coinAgeIndex:            params.coinAgeIndex,
+		txIndex:                 params.txIndex,
+		addrIndex:               params.addrIndex,
+		stxoIndexMaxSize:        params.stxoIndexMaxSize,
+		enableBanning:           params.enableBanning,
 		overrideDAGParams:       params.overrideDAGParams,
+		rpcEndpoints:            params.rpcEndpoints,
+		rpcUnixSocket:           params.rpcUnixSocket,
+		rpcWebSocketAddress:     params.rpcWebSocketAddress,
+		rpcCookie:               params.rpcCookie,
+		rpcCredentials:          params.rpcCredentials,
+		rpcTLS:                  params.rpcTLS,
+		dbType:                  params.dbType,
+		headersOnly:             params.headersOnly,
+		sqlMirror:               params.sqlMirror,
+		sqlMirrorSchema:         params.sqlMirrorSchema,
+		kafkaBrokers:            params.kafkaBrokers,
+		kafkaTopicPrefix:        params.kafkaTopicPrefix,
+		templatePush:            params.templatePush,
+		stratumListen:           params.stratumListen,
+		stratumShareDifficulty:  params.stratumShareDifficulty,
+		payoutWatch:             params.payoutWatch,
+		blockRateMonitor:        params.blockRateMonitor,
 	}
 
 	setConfig(t, harness, params.protocolVersion)
@@ -101,8 +179,22 @@ func standardSetup(t *testing.T) (appHarness1, appHarness2, appHarness3 *appHarn
 }
 
 func setRPCClient(t *testing.T, harness *appHarness) {
+	connectOptions := &grpcclient.ConnectOptions{}
+	if harness.rpcCookie {
+		authToken, err := grpcserver.ReadRPCCookie(harness.config.RPCCookieFile)
+		if err != nil {
+			t.Fatalf("Error reading the RPC cookie: %+v", err)
+		}
+		connectOptions.Authorization = grpcclient.AuthTokenAuthorization(authToken)
+	} else if len(harness.rpcCredentials) > 0 {
+		// The harness RPC client authenticates with the first credential
+		connectOptions.Authorization = rpcCredentialAuthorization(harness.rpcCredentials[0])
+	}
+	if harness.rpcTLS {
+		connectOptions.TLSConfig = rpcTLSClientConfig(t, harness)
+	}
 	var err error
-	harness.rpcClient, err = newTestRPCClient(harness.rpcAddress)
+	harness.rpcClient, err = newTestRPCClientWithOptions(harness.rpcAddress, connectOptions)
 	if err != nil {
 		t.Fatalf("Error getting RPC client %+v", err)
 	}
@@ -135,6 +227,12 @@ func setDatabaseContext(t *testing.T, harness *appHarness) {
 }
 
 func openDB(cfg *config.Config) (database.Database, error) {
-	dbPath := filepath.Join(cfg.AppDir, "db")
-	return ldb.NewLevelDB(dbPath, 8)
+	switch cfg.DbType {
+	case config.DbTypeMemory:
+		return ldb.NewInMemoryLevelDB(8)
+	case config.DbTypeBadger:
+		return badgerdb.NewBadgerDB(filepath.Join(cfg.AppDir, "badgerdb"), 8)
+	default:
+		return ldb.NewLevelDB(filepath.Join(cfg.AppDir, "db"), 8)
+	}
 }
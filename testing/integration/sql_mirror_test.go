@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/db/postgres"
+	"github.com/kaspanet/kaspad/infrastructure/db/postgres/postgrestest"
+)
+
+func TestSQLMirror(t *testing.T) {
+	connectionURL := postgrestest.ConnectionURL(t)
+	schema := postgrestest.SchemaName()
+
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		sqlMirror:               connectionURL,
+		sqlMirrorSchema:         schema,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	conn, err := postgres.Connect(connectionURL)
+	if err != nil {
+		t.Fatalf("Error connecting to PostgreSQL: %s", err)
+	}
+	defer conn.Close()
+	defer func() {
+		err := conn.ExecScript("DROP SCHEMA IF EXISTS " + schema + " CASCADE")
+		if err != nil {
+			t.Errorf("Error dropping the schema: %s", err)
+		}
+	}()
+
+	var minedBlockHashes []string
+	for i := 0; i < 3; i++ {
+		block := mineNextBlock(t, kaspad)
+		minedBlockHashes = append(minedBlockHashes, consensushashing.BlockHash(block).String())
+	}
+
+	// Wait for the mirror to advance its position to the last mined block
+	lastMinedBlockHash := minedBlockHashes[len(minedBlockHashes)-1]
+	start := time.Now()
+	for {
+		var network, chainBlockHash string
+		err := conn.QueryRow("SELECT network, chain_block_hash FROM "+schema+".sync_state").
+			Scan(&network, &chainBlockHash)
+		if err == nil && network != kaspad.config.ActiveNetParams.Name {
+			t.Fatalf("The schema mirrors %s rather than %s", network, kaspad.config.ActiveNetParams.Name)
+		}
+		if err == nil && chainBlockHash == lastMinedBlockHash {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the mirror to reach block %s: %v", lastMinedBlockHash, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for _, blockHash := range minedBlockHashes {
+		var isChainBlock bool
+		err := conn.QueryRow("SELECT is_chain_block FROM "+schema+".blocks WHERE hash = $1", blockHash).
+			Scan(&isChainBlock)
+		if err != nil {
+			t.Fatalf("Block %s was not mirrored: %s", blockHash, err)
+		}
+		if !isChainBlock {
+			t.Fatalf("Block %s was not marked as a chain block", blockHash)
+		}
+	}
+
+	// Every mined block is merged by the next one, so all the coinbase
+	// transactions but the last one are accepted
+	for i, blockHash := range minedBlockHashes[:len(minedBlockHashes)-1] {
+		var acceptingBlockHash string
+		err := conn.QueryRow("SELECT accepting_block_hash FROM "+schema+".accepted_transactions "+
+			"WHERE block_hash = $1", blockHash).Scan(&acceptingBlockHash)
+		if err != nil {
+			t.Fatalf("The coinbase transaction of block %s was not marked as accepted: %s", blockHash, err)
+		}
+		if acceptingBlockHash != minedBlockHashes[i+1] {
+			t.Fatalf("Expected the coinbase transaction of block %s to be accepted by %s, but it's accepted by %s",
+				blockHash, minedBlockHashes[i+1], acceptingBlockHash)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package integration
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+const stratumAddress1 = "127.0.0.1:12545"
+
+// stratumMiner is a minimal stratum client
+type stratumMiner struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+	nextID int
+
+	// jobID is the ID of the last job the miner got
+	jobID string
+}
+
+type stratumMessage struct {
+	ID     *int              `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	Result json.RawMessage   `json:"result"`
+	Error  []interface{}     `json:"error"`
+}
+
+// call calls the given method and returns its response, handling the
+// notifications that come before it
+func (m *stratumMiner) call(method string, params ...interface{}) *stratumMessage {
+	m.nextID++
+	id := m.nextID
+	request, err := json.Marshal(map[string]interface{}{"id": id, "method": method, "params": params})
+	if err != nil {
+		m.t.Fatalf("Error encoding the request: %s", err)
+	}
+	_, err = m.conn.Write(append(request, '\n'))
+	if err != nil {
+		m.t.Fatalf("Error sending the request: %s", err)
+	}
+	for {
+		message := m.readMessage()
+		if message.Method == "" && message.ID != nil && *message.ID == id {
+			return message
+		}
+	}
+}
+
+// waitForJob reads messages until a job arrives
+func (m *stratumMiner) waitForJob() {
+	for {
+		message := m.readMessage()
+		if message.Method == "mining.notify" {
+			return
+		}
+	}
+}
+
+func (m *stratumMiner) readMessage() *stratumMessage {
+	err := m.conn.SetReadDeadline(time.Now().Add(defaultTimeout))
+	if err != nil {
+		m.t.Fatalf("Error setting the read deadline: %s", err)
+	}
+	line, err := m.reader.ReadBytes('\n')
+	if err != nil {
+		m.t.Fatalf("Error reading a stratum message: %s", err)
+	}
+	message := &stratumMessage{}
+	err = json.Unmarshal(line, message)
+	if err != nil {
+		m.t.Fatalf("Error decoding the stratum message %s: %s", line, err)
+	}
+	if message.Method == "mining.notify" {
+		err := json.Unmarshal(message.Params[0], &m.jobID)
+		if err != nil {
+			m.t.Fatalf("Error decoding the job ID: %s", err)
+		}
+	}
+	return message
+}
+
+func TestStratum(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		stratumListen:           stratumAddress1,
+		// Low enough that every nonce is a share
+		stratumShareDifficulty: 1e-12,
+	})
+	defer teardown()
+
+	conn, err := net.Dial("tcp", stratumAddress1)
+	if err != nil {
+		t.Fatalf("Error connecting to the stratum server: %s", err)
+	}
+	defer conn.Close()
+	miner := &stratumMiner{t: t, conn: conn, reader: bufio.NewReader(conn)}
+
+	response := miner.call("mining.subscribe", "integration", "EthereumStratum/1.0.0")
+	if response.Error != nil {
+		t.Fatalf("mining.subscribe failed: %v", response.Error)
+	}
+	response = miner.call("mining.authorize", "worker1", "x")
+	if string(response.Result) != "true" {
+		t.Fatalf("mining.authorize failed: %v", response.Error)
+	}
+	miner.waitForJob()
+
+	// Submitting shares of the job eventually finds a block, since about
+	// every other nonce is a block on simnet
+	blockCountBefore, err := kaspad.rpcClient.GetBlockCount()
+	if err != nil {
+		t.Fatalf("GetBlockCount: %s", err)
+	}
+	jobID := miner.jobID
+	isBlockFound := false
+	for nonce := 0; nonce < 20 && !isBlockFound; nonce++ {
+		response = miner.call("mining.submit", "worker1", jobID, fmt.Sprintf("%016x", nonce))
+		if string(response.Result) != "true" {
+			t.Fatalf("Share %d was rejected: %v", nonce, response.Error)
+		}
+		blockCount, err := kaspad.rpcClient.GetBlockCount()
+		if err != nil {
+			t.Fatalf("GetBlockCount: %s", err)
+		}
+		isBlockFound = blockCount.BlockCount > blockCountBefore.BlockCount
+	}
+	if !isBlockFound {
+		t.Fatalf("No block was found")
+	}
+
+	// The found block changes the template, so a new job follows
+	for miner.jobID == jobID {
+		miner.waitForJob()
+	}
+
+	response = miner.call("mining.submit", "worker1", jobID, fmt.Sprintf("%016x", 0))
+	if len(response.Error) == 0 || response.Error[0] != float64(22) {
+		t.Fatalf("Expected a duplicate share error, but got %v", response.Error)
+	}
+	response = miner.call("mining.submit", "worker1", "1000", fmt.Sprintf("%016x", 0))
+	if len(response.Error) == 0 || response.Error[0] != float64(21) {
+		t.Fatalf("Expected a job not found error, but got %v", response.Error)
+	}
+	response = miner.call("mining.submit", "worker2", miner.jobID, fmt.Sprintf("%016x", 0))
+	if len(response.Error) == 0 || response.Error[0] != float64(24) {
+		t.Fatalf("Expected an unauthorized error, but got %v", response.Error)
+	}
+}
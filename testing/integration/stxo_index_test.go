@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestSTXOIndex(t *testing.T) {
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+			stxoIndex:               true,
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+		},
+	})
+	defer teardown()
+	kaspad, rival := harnesses[0], harnesses[1]
+
+	// Mine a mature coinbase and spend it
+	kaspadBlockCount := kaspad.config.ActiveNetParams.BlockCoinbaseMaturity + 4
+	mineNextBlock(t, kaspad)
+	fundingBlock := mineNextBlock(t, kaspad)
+	for i := uint64(0); i < kaspad.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, kaspad)
+	}
+	fundingCoinbase := fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	msgTx := generateTx(t, fundingCoinbase, kaspad, kaspad)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	submitTransactionResponse, err := kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+
+	// The transaction is accepted by the selected child of the block that includes it
+	mineNextBlock(t, kaspad)
+	acceptingBlock := mineNextBlock(t, kaspad)
+	acceptingBlockHash := consensushashing.BlockHash(acceptingBlock).String()
+
+	spentOutpoint := &appmessage.RPCOutpoint{
+		TransactionID: consensushashing.TransactionID(fundingCoinbase).String(),
+		Index:         0,
+	}
+	start := time.Now()
+	for {
+		response, err := kaspad.rpcClient.GetOutpointSpendingTransaction(spentOutpoint)
+		if err != nil {
+			t.Fatalf("Error getting the spending transaction: %+v", err)
+		}
+		if response.IsSpent {
+			if response.SpendingTransactionID != submitTransactionResponse.TransactionID {
+				t.Fatalf("Unexpected spending transaction. Want: %s, got: %s",
+					submitTransactionResponse.TransactionID, response.SpendingTransactionID)
+			}
+			if response.AcceptingBlockHash != acceptingBlockHash {
+				t.Fatalf("Unexpected accepting block. Want: %s, got: %s",
+					acceptingBlockHash, response.AcceptingBlockHash)
+			}
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the outpoint to be indexed as spent")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	unspentOutpoint := &appmessage.RPCOutpoint{
+		TransactionID: submitTransactionResponse.TransactionID,
+		Index:         0,
+	}
+	response, err := kaspad.rpcClient.GetOutpointSpendingTransaction(unspentOutpoint)
+	if err != nil {
+		t.Fatalf("Error getting the spending transaction: %+v", err)
+	}
+	if response.IsSpent {
+		t.Fatalf("Unspent outpoint %s:%d is reported as spent by %s",
+			unspentOutpoint.TransactionID, unspentOutpoint.Index, response.SpendingTransactionID)
+	}
+
+	// Reorg to a heavier chain that doesn't include the funding
+	// block, which must undo the indexed spend
+	for i := uint64(0); i < kaspadBlockCount+10; i++ {
+		mineNextBlock(t, rival)
+	}
+	connect(t, kaspad, rival)
+
+	start = time.Now()
+	for {
+		response, err := kaspad.rpcClient.GetOutpointSpendingTransaction(spentOutpoint)
+		if err != nil {
+			t.Fatalf("Error getting the spending transaction: %+v", err)
+		}
+		if !response.IsSpent {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the reorged spend to be removed from the index")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"google.golang.org/grpc"
+)
+
+// fakeWorkDistributor is an RPC server that records the block templates that
+// are pushed to it
+type fakeWorkDistributor struct {
+	protowire.UnimplementedRPCServer
+
+	lock      sync.Mutex
+	templates []*appmessage.GetBlockTemplateResponseMessage
+}
+
+func (d *fakeWorkDistributor) MessageStream(stream protowire.RPC_MessageStreamServer) error {
+	for {
+		message, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		appMessage, err := message.ToAppMessage()
+		if err != nil {
+			return err
+		}
+		template, ok := appMessage.(*appmessage.GetBlockTemplateResponseMessage)
+		if !ok {
+			continue
+		}
+		d.lock.Lock()
+		d.templates = append(d.templates, template)
+		d.lock.Unlock()
+	}
+}
+
+func (d *fakeWorkDistributor) lastTemplate() *appmessage.GetBlockTemplateResponseMessage {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if len(d.templates) == 0 {
+		return nil
+	}
+	return d.templates[len(d.templates)-1]
+}
+
+func TestTemplatePush(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error listening: %s", err)
+	}
+	distributor := &fakeWorkDistributor{}
+	server := grpc.NewServer()
+	protowire.RegisterRPCServer(server, distributor)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		templatePush:            listener.Addr().String(),
+	})
+	defer teardown()
+
+	// Every new block is followed by a template on top of it, without any
+	// request of the distributor
+	for i := 0; i < 3; i++ {
+		block := mineNextBlock(t, kaspad)
+		blockHash := consensushashing.BlockHash(block).String()
+
+		start := time.Now()
+		for {
+			template := distributor.lastTemplate()
+			if template != nil && len(template.Block.Header.Parents) > 0 &&
+				len(template.Block.Header.Parents[0].ParentHashes) == 1 &&
+				template.Block.Header.Parents[0].ParentHashes[0] == blockHash {
+
+				break
+			}
+			if time.Since(start) > defaultTimeout {
+				t.Fatalf("Timed out waiting for a template on top of block %s", blockHash)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
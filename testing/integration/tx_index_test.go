@@ -0,0 +1,117 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestTXIndex(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		txIndex:                 true,
+	})
+	defer teardown()
+
+	// Mine a mature coinbase and spend it
+	mineNextBlock(t, kaspad)
+	fundingBlock := mineNextBlock(t, kaspad)
+	for i := uint64(0); i < kaspad.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, kaspad)
+	}
+	fundingCoinbase := fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	msgTx := generateTx(t, fundingCoinbase, kaspad, kaspad)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	submitTransactionResponse, err := kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %+v", err)
+	}
+	transactionID := submitTransactionResponse.TransactionID
+
+	response, err := kaspad.rpcClient.GetTransaction(transactionID)
+	if err != nil {
+		t.Fatalf("Error getting the transaction: %+v", err)
+	}
+	if !response.IsInMempool || len(response.IncludingBlockHashes) != 0 {
+		t.Fatalf("Expected the transaction to be only in the mempool, but got %+v", response)
+	}
+
+	response, err = kaspad.rpcClient.GetTransactionWithVerbosity(transactionID, appmessage.RPCVerbosityHashesOnly)
+	if err != nil {
+		t.Fatalf("Error getting the transaction: %+v", err)
+	}
+	if response.Transaction.VerboseData.TransactionID != transactionID || len(response.Transaction.Inputs) != 0 {
+		t.Fatalf("Expected only the hashes of the transaction, but got %+v", response.Transaction)
+	}
+
+	response, err = kaspad.rpcClient.GetTransactionWithVerbosity(transactionID, appmessage.RPCVerbosityFull)
+	if err != nil {
+		t.Fatalf("Error getting the transaction: %+v", err)
+	}
+	if response.Transaction.Inputs[0].VerboseData.SignatureScriptAsm == "" {
+		t.Fatalf("Expected the signature script to be disassembled, but got %+v", response.Transaction.Inputs[0])
+	}
+
+	// The transaction is accepted by the selected child of the block that includes it
+	includingBlock := mineNextBlock(t, kaspad)
+	acceptingBlock := mineNextBlock(t, kaspad)
+	includingBlockHash := consensushashing.BlockHash(includingBlock).String()
+	acceptingBlockHash := consensushashing.BlockHash(acceptingBlock).String()
+
+	start := time.Now()
+	for {
+		response, err := kaspad.rpcClient.GetTransaction(transactionID)
+		if err != nil {
+			t.Fatalf("Error getting the transaction: %+v", err)
+		}
+		if response.AcceptingBlockHash != "" {
+			if response.AcceptingBlockHash != acceptingBlockHash {
+				t.Fatalf("Unexpected accepting block. Want: %s, got: %s",
+					acceptingBlockHash, response.AcceptingBlockHash)
+			}
+			if len(response.IncludingBlockHashes) != 1 || response.IncludingBlockHashes[0] != includingBlockHash {
+				t.Fatalf("Unexpected including blocks. Want: [%s], got: %s",
+					includingBlockHash, response.IncludingBlockHashes)
+			}
+			if response.IsInMempool {
+				t.Fatalf("An accepted transaction is reported to be in the mempool")
+			}
+			if response.Transaction.VerboseData.TransactionID != transactionID {
+				t.Fatalf("Unexpected transaction. Want: %s, got: %s",
+					transactionID, response.Transaction.VerboseData.TransactionID)
+			}
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the transaction to be indexed as accepted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mineNextBlock(t, kaspad)
+	start = time.Now()
+	for {
+		response, err := kaspad.rpcClient.GetTransaction(transactionID)
+		if err != nil {
+			t.Fatalf("Error getting the transaction: %+v", err)
+		}
+		if response.Confirmations == 1 {
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the transaction to have 1 confirmation. Got %d", response.Confirmations)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_, err = kaspad.rpcClient.GetTransaction(consensushashing.BlockHash(acceptingBlock).String())
+	if err == nil {
+		t.Fatalf("Expected an unknown transaction not to be found")
+	}
+}
@@ -53,12 +53,22 @@ func TestTxRelay(t *testing.T) {
 	msgTx := generateTx(t, secondBlock.Transactions[transactionhelper.CoinbaseTransactionIndex], payer, payee)
 	domainTransaction := appmessage.MsgTxToDomainTransaction(msgTx)
 	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(domainTransaction)
-	response, err := payer.rpcClient.SubmitTransaction(rpcTransaction, false)
+	response, err := payer.rpcClient.SubmitTransactionWithIdempotencyKey(rpcTransaction, false, "TestTxRelay")
 	if err != nil {
 		t.Fatalf("Error submitting transaction: %+v", err)
 	}
 	txID := response.TransactionID
 
+	// Resubmitting with the same idempotency key must return the original
+	// response rather than an already-in-mempool rejection
+	repeatedResponse, err := payer.rpcClient.SubmitTransactionWithIdempotencyKey(rpcTransaction, false, "TestTxRelay")
+	if err != nil {
+		t.Fatalf("Error resubmitting transaction: %+v", err)
+	}
+	if repeatedResponse.TransactionID != txID {
+		t.Fatalf("Unexpected transaction ID on resubmission. Want: %s, got: %s", txID, repeatedResponse.TransactionID)
+	}
+
 	txAddedToMempoolChan := make(chan struct{})
 
 	mempoolAddressQuery := []string{payee.miningAddress, payer.miningAddress}
@@ -69,7 +79,7 @@ func TestTxRelay(t *testing.T) {
 
 		for range ticker.C {
 
-			getMempoolEntryResponse, err := payee.rpcClient.GetMempoolEntry(txID, true, false)
+			getMempoolEntryResponse, err := payee.rpcClient.GetMempoolEntry(txID, true, false, true)
 			if err != nil {
 				if strings.Contains(err.Error(), "not found") {
 					continue
@@ -81,6 +91,17 @@ func TestTxRelay(t *testing.T) {
 			if mempoolEntry.IsOrphan {
 				t.Fatalf("transaction %s is an orphan, although it shouldn't be", mempoolEntry.Transaction.VerboseData.TransactionID)
 			}
+			if mempoolEntry.Fee != 1000 {
+				t.Fatalf("Unexpected fee: expected 1000 but got %d", mempoolEntry.Fee)
+			}
+			if mempoolEntry.VerboseData == nil || mempoolEntry.VerboseData.Mass == 0 ||
+				mempoolEntry.VerboseData.ArrivalTime == 0 {
+				t.Fatalf("Unexpected verbose data of mempool entry: %+v", mempoolEntry.VerboseData)
+			}
+			if len(mempoolEntry.VerboseData.DependsTransactionIDs) != 0 ||
+				len(mempoolEntry.VerboseData.SpentByTransactionIDs) != 0 {
+				t.Fatalf("Transaction %s unexpectedly depends on or is spent by other pending transactions", txID)
+			}
 
 			getMempoolEntriesByAddressesResponse, err := payee.rpcClient.GetMempoolEntriesByAddresses(mempoolAddressQuery, true, false)
 			if err != nil {
@@ -125,6 +146,58 @@ func TestTxRelay(t *testing.T) {
 	case <-time.After(defaultTimeout):
 		t.Fatalf("Timeout waiting for transaction to be accepted into mempool")
 	}
+
+	// The payer's only peer is the mediator, which must have both been
+	// announced the transaction and requested it for it to reach the payee
+	report, err := payer.rpcClient.GetTransactionPropagationReport(txID)
+	if err != nil {
+		t.Fatalf("Error getting transaction propagation report: %+v", err)
+	}
+	if report.AnnouncedPeerCount != 1 || report.RequestedPeerCount != 1 {
+		t.Fatalf("Unexpected propagation report. Want the transaction announced to and requested by 1 peer, "+
+			"got %d and %d", report.AnnouncedPeerCount, report.RequestedPeerCount)
+	}
+	for _, event := range append(report.Announcements, report.Requests...) {
+		if event.Timestamp < report.SubmittedAt {
+			t.Fatalf("Propagation event of %s at %d precedes the submission at %d",
+				event.PeerAddress, event.Timestamp, report.SubmittedAt)
+		}
+	}
+
+	// Mining the transaction confirms it on the payer, without removing it
+	// from the mempool for any other reason
+	transactionConfirmedChan := make(chan *appmessage.TransactionConfirmedNotificationMessage, 1)
+	err = payer.rpcClient.RegisterForTransactionConfirmedNotifications(
+		func(notification *appmessage.TransactionConfirmedNotificationMessage) {
+			transactionConfirmedChan <- notification
+		})
+	if err != nil {
+		t.Fatalf("Error registering for transaction confirmed notifications: %+v", err)
+	}
+	transactionRemovedChan := make(chan *appmessage.TransactionRemovedFromMempoolNotificationMessage, 1)
+	err = payer.rpcClient.RegisterForTransactionRemovedFromMempoolNotifications(
+		func(notification *appmessage.TransactionRemovedFromMempoolNotificationMessage) {
+			transactionRemovedChan <- notification
+		})
+	if err != nil {
+		t.Fatalf("Error registering for transaction removed from mempool notifications: %+v", err)
+	}
+	block := mineNextBlock(t, payer)
+	select {
+	case notification := <-transactionConfirmedChan:
+		blockHash := consensushashing.BlockHash(block).String()
+		if notification.TransactionID != txID || notification.BlockHash != blockHash {
+			t.Fatalf("Unexpected transaction confirmed notification. Want %s in block %s, got %s in block %s",
+				txID, blockHash, notification.TransactionID, notification.BlockHash)
+		}
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timeout waiting for the transaction confirmed notification")
+	}
+	select {
+	case notification := <-transactionRemovedChan:
+		t.Fatalf("Unexpected removal of %s from the mempool: %s", notification.TransactionID, notification.Reason)
+	default:
+	}
 }
 
 func waitForPayeeToReceiveBlock(t *testing.T, payeeBlockAddedChan chan *appmessage.RPCBlockHeader) {
@@ -175,3 +248,42 @@ func generateTx(t *testing.T, firstBlockCoinbase *externalapi.DomainTransaction,
 
 	return msgTx
 }
+
+func TestSubmitTransactionRetriesTransientRejection(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	// Skip the first block because it's paying to genesis script
+	mineNextBlock(t, harness)
+	coinbaseBlock := mineNextBlock(t, harness)
+	mineNextBlock(t, harness)
+
+	msgTx := generateTx(t, coinbaseBlock.Transactions[transactionhelper.CoinbaseTransactionIndex], harness, harness)
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(appmessage.MsgTxToDomainTransaction(msgTx))
+	const idempotencyKey = "TestSubmitTransactionRetriesTransientRejection"
+
+	// The coinbase output isn't mature yet, so the transaction is rejected,
+	// but the rejection must not be kept for the idempotency key
+	_, err := harness.rpcClient.SubmitTransactionWithIdempotencyKey(rpcTransaction, false, idempotencyKey)
+	if err == nil {
+		t.Fatalf("Expected the transaction to be rejected while its input is immature")
+	}
+
+	for i := uint64(0); i < harness.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	response, err := harness.rpcClient.SubmitTransactionWithIdempotencyKey(rpcTransaction, false, idempotencyKey)
+	if err != nil {
+		t.Fatalf("Error resubmitting the transaction once its input matured: %+v", err)
+	}
+	expectedTransactionID := consensushashing.TransactionID(appmessage.MsgTxToDomainTransaction(msgTx)).String()
+	if response.TransactionID != expectedTransactionID {
+		t.Fatalf("Unexpected transaction ID. Want: %s, got: %s", expectedTransactionID, response.TransactionID)
+	}
+}
@@ -0,0 +1,36 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportUTXOSnapshot(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	_, err := kaspad.rpcClient.ExportUTXOSnapshot("snapshot")
+	if err == nil || !strings.Contains(err.Error(), "must be absolute") {
+		t.Fatalf("Expected exporting to a relative path to fail, but got %v", err)
+	}
+
+	// The pruning point of a new node is the genesis, which has no snapshot,
+	// and the file of a failed export is removed
+	mineNextBlock(t, kaspad)
+	path := filepath.Join(t.TempDir(), "snapshot")
+	_, err = kaspad.rpcClient.ExportUTXOSnapshot(path)
+	if err == nil || !strings.Contains(err.Error(), "genesis") {
+		t.Fatalf("Expected exporting at the genesis to fail, but got %v", err)
+	}
+	_, err = os.Stat(path)
+	if !os.IsNotExist(err) {
+		t.Fatalf("Expected the snapshot file of a failed export to be removed, but got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestBlockVerbosity(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	// The coinbase of the second block has an output that pays the miner of the first
+	mineNextBlock(t, kaspad)
+	block := mineNextBlock(t, kaspad)
+	if len(block.Transactions[0].Outputs) == 0 {
+		t.Fatalf("Expected the coinbase transaction to have outputs")
+	}
+	blockHash := consensushashing.BlockHash(block).String()
+	coinbaseID := consensushashing.TransactionID(block.Transactions[0]).String()
+
+	response, err := kaspad.rpcClient.GetBlockWithVerbosity(blockHash, appmessage.RPCVerbosityHashesOnly)
+	if err != nil {
+		t.Fatalf("Error getting the block: %+v", err)
+	}
+	hashesOnlyBlock := response.Block
+	if hashesOnlyBlock.Header != nil || len(hashesOnlyBlock.Transactions) != 0 {
+		t.Fatalf("Expected only hashes, but got the header or transactions: %+v", hashesOnlyBlock)
+	}
+	if hashesOnlyBlock.VerboseData.Hash != blockHash || len(hashesOnlyBlock.VerboseData.TransactionIDs) != 1 ||
+		hashesOnlyBlock.VerboseData.TransactionIDs[0] != coinbaseID {
+		t.Fatalf("Unexpected verbose data: %+v", hashesOnlyBlock.VerboseData)
+	}
+
+	response, err = kaspad.rpcClient.GetBlockWithVerbosity(blockHash, appmessage.RPCVerbosityHeadersOnly)
+	if err != nil {
+		t.Fatalf("Error getting the block: %+v", err)
+	}
+	headersOnlyBlock := response.Block
+	if headersOnlyBlock.Header == nil || len(headersOnlyBlock.Transactions) != 0 {
+		t.Fatalf("Expected only the header, but got %+v", headersOnlyBlock)
+	}
+	if headersOnlyBlock.VerboseData.Hash != blockHash || headersOnlyBlock.VerboseData.BlueScore != 2 {
+		t.Fatalf("Unexpected verbose data: %+v", headersOnlyBlock.VerboseData)
+	}
+
+	response, err = kaspad.rpcClient.GetBlockWithVerbosity(blockHash, appmessage.RPCVerbosityFull)
+	if err != nil {
+		t.Fatalf("Error getting the block: %+v", err)
+	}
+	fullBlock := response.Block
+	if fullBlock.Header == nil || len(fullBlock.Transactions) != 1 {
+		t.Fatalf("Expected the full block, but got %+v", fullBlock)
+	}
+	for _, output := range fullBlock.Transactions[0].Outputs {
+		if output.VerboseData.ScriptPublicKeyAsm == "" {
+			t.Fatalf("Expected the script public keys to be disassembled, but got %+v", output.VerboseData)
+		}
+	}
+
+	_, err = kaspad.rpcClient.GetBlockWithVerbosity(blockHash, appmessage.RPCVerbosityFull+1)
+	if err == nil {
+		t.Fatalf("Expected an unknown verbosity to fail")
+	}
+
+	blocksResponse, err := kaspad.rpcClient.GetBlocksWithVerbosity("", appmessage.RPCVerbosityHashesOnly)
+	if err != nil {
+		t.Fatalf("Error getting blocks: %+v", err)
+	}
+	if len(blocksResponse.BlockHashes) != 3 || len(blocksResponse.Blocks) != 0 {
+		t.Fatalf("Expected the hashes of the genesis and the mined blocks only, but got %d hashes and %d blocks",
+			len(blocksResponse.BlockHashes), len(blocksResponse.Blocks))
+	}
+
+	blocksResponse, err = kaspad.rpcClient.GetBlocksWithVerbosity("", appmessage.RPCVerbosityFull)
+	if err != nil {
+		t.Fatalf("Error getting blocks: %+v", err)
+	}
+	if len(blocksResponse.Blocks) != 3 || len(blocksResponse.Blocks[2].Transactions) != 1 ||
+		blocksResponse.Blocks[2].Transactions[0].Outputs[0].VerboseData.ScriptPublicKeyAsm == "" {
+		t.Fatalf("Expected full blocks with disassembled scripts, but got %+v", blocksResponse.Blocks)
+	}
+}
@@ -37,7 +37,7 @@ func (c *Calculator) CalculateTransactionMass(transaction *externalapi.DomainTra
 	}
 
 	// calculate mass for size
-	size := transactionEstimatedSerializedSize(transaction)
+	size := TransactionEstimatedSerializedSize(transaction)
 	massForSize := size * c.massPerTxByte
 
 	// calculate mass for scriptPubKey
@@ -59,11 +59,11 @@ func (c *Calculator) CalculateTransactionMass(transaction *externalapi.DomainTra
 	return massForSize + massForScriptPubKey + massForSigOps
 }
 
-// transactionEstimatedSerializedSize is the estimated size of a transaction in some
+// TransactionEstimatedSerializedSize is the estimated size of a transaction in some
 // serialization. This has to be deterministic, but not necessarily accurate, since
 // it's only used as the size component in the transaction and block mass limit
-// calculation.
-func transactionEstimatedSerializedSize(tx *externalapi.DomainTransaction) uint64 {
+// calculation, and to bound the size of the mempool.
+func TransactionEstimatedSerializedSize(tx *externalapi.DomainTransaction) uint64 {
 	if transactionhelper.IsCoinBase(tx) {
 		return 0
 	}
@@ -108,7 +108,7 @@ func outpointEstimatedSerializedSize() uint64 {
 	return size
 }
 
-// TransactionOutputEstimatedSerializedSize is the same as transactionEstimatedSerializedSize but for outputs only
+// TransactionOutputEstimatedSerializedSize is the same as TransactionEstimatedSerializedSize but for outputs only
 func TransactionOutputEstimatedSerializedSize(output *externalapi.DomainTransactionOutput) uint64 {
 	size := uint64(0)
 	size += 8 // value (uint64)